@@ -612,6 +612,9 @@ type TopicInfo struct {
 	DatasetCount  int32                  `protobuf:"varint,5,opt,name=dataset_count,json=datasetCount,proto3" json:"dataset_count,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Acl           []*TopicACLEntry       `protobuf:"bytes,8,rep,name=acl,proto3" json:"acl,omitempty"` // replicated with the topic
+	Owners        []string               `protobuf:"bytes,9,rep,name=owners,proto3" json:"owners,omitempty"`
+	DataSchema    *DataSchema            `protobuf:"bytes,10,opt,name=data_schema,json=dataSchema,proto3" json:"data_schema,omitempty"` // replicated with the topic
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -695,6 +698,218 @@ func (x *TopicInfo) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *TopicInfo) GetAcl() []*TopicACLEntry {
+	if x != nil {
+		return x.Acl
+	}
+	return nil
+}
+
+func (x *TopicInfo) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *TopicInfo) GetDataSchema() *DataSchema {
+	if x != nil {
+		return x.DataSchema
+	}
+	return nil
+}
+
+// DataSchema is a JSON Schema that dataset content must conform to
+type DataSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JsonSchema    string                 `protobuf:"bytes,1,opt,name=json_schema,json=jsonSchema,proto3" json:"json_schema,omitempty"` // JSON Schema document (draft 2020-12)
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`                               // "enforce" rejects writes, "warn" only audits them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DataSchema) Reset() {
+	*x = DataSchema{}
+	mi := &file_bib_v1_common_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSchema) ProtoMessage() {}
+
+func (x *DataSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_common_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSchema.ProtoReflect.Descriptor instead.
+func (*DataSchema) Descriptor() ([]byte, []int) {
+	return file_bib_v1_common_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DataSchema) GetJsonSchema() string {
+	if x != nil {
+		return x.JsonSchema
+	}
+	return ""
+}
+
+func (x *DataSchema) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// SchemaViolation is a single place where content does not match a DataSchema
+type SchemaViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Record        int32                  `protobuf:"varint,1,opt,name=record,proto3" json:"record,omitempty"` // index of the JSON value in the content (JSON Lines)
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`      // JSON Pointer into the record, "/" for the root
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaViolation) Reset() {
+	*x = SchemaViolation{}
+	mi := &file_bib_v1_common_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaViolation) ProtoMessage() {}
+
+func (x *SchemaViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_common_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaViolation.ProtoReflect.Descriptor instead.
+func (*SchemaViolation) Descriptor() ([]byte, []int) {
+	return file_bib_v1_common_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SchemaViolation) GetRecord() int32 {
+	if x != nil {
+		return x.Record
+	}
+	return 0
+}
+
+func (x *SchemaViolation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SchemaViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// TopicACLEntry grants a permission on a topic to a user or role
+type TopicACLEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PrincipalType string                 `protobuf:"bytes,1,opt,name=principal_type,json=principalType,proto3" json:"principal_type,omitempty"` // "user", "role"
+	Principal     string                 `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`                              // user ID or role name
+	Permission    string                 `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"`                            // "read", "write", "admin"
+	GrantedBy     string                 `protobuf:"bytes,4,opt,name=granted_by,json=grantedBy,proto3" json:"granted_by,omitempty"`
+	GrantedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=granted_at,json=grantedAt,proto3" json:"granted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicACLEntry) Reset() {
+	*x = TopicACLEntry{}
+	mi := &file_bib_v1_common_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicACLEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicACLEntry) ProtoMessage() {}
+
+func (x *TopicACLEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_common_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicACLEntry.ProtoReflect.Descriptor instead.
+func (*TopicACLEntry) Descriptor() ([]byte, []int) {
+	return file_bib_v1_common_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopicACLEntry) GetPrincipalType() string {
+	if x != nil {
+		return x.PrincipalType
+	}
+	return ""
+}
+
+func (x *TopicACLEntry) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *TopicACLEntry) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+func (x *TopicACLEntry) GetGrantedBy() string {
+	if x != nil {
+		return x.GrantedBy
+	}
+	return ""
+}
+
+func (x *TopicACLEntry) GetGrantedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GrantedAt
+	}
+	return nil
+}
+
 // DatasetInfo contains dataset metadata
 type DatasetInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -714,7 +929,7 @@ type DatasetInfo struct {
 
 func (x *DatasetInfo) Reset() {
 	*x = DatasetInfo{}
-	mi := &file_bib_v1_common_proto_msgTypes[8]
+	mi := &file_bib_v1_common_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -726,7 +941,7 @@ func (x *DatasetInfo) String() string {
 func (*DatasetInfo) ProtoMessage() {}
 
 func (x *DatasetInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_common_proto_msgTypes[8]
+	mi := &file_bib_v1_common_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -739,7 +954,7 @@ func (x *DatasetInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatasetInfo.ProtoReflect.Descriptor instead.
 func (*DatasetInfo) Descriptor() ([]byte, []int) {
-	return file_bib_v1_common_proto_rawDescGZIP(), []int{8}
+	return file_bib_v1_common_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DatasetInfo) GetId() string {
@@ -829,7 +1044,7 @@ type CatalogEntry struct {
 
 func (x *CatalogEntry) Reset() {
 	*x = CatalogEntry{}
-	mi := &file_bib_v1_common_proto_msgTypes[9]
+	mi := &file_bib_v1_common_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -841,7 +1056,7 @@ func (x *CatalogEntry) String() string {
 func (*CatalogEntry) ProtoMessage() {}
 
 func (x *CatalogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_common_proto_msgTypes[9]
+	mi := &file_bib_v1_common_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -854,7 +1069,7 @@ func (x *CatalogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CatalogEntry.ProtoReflect.Descriptor instead.
 func (*CatalogEntry) Descriptor() ([]byte, []int) {
-	return file_bib_v1_common_proto_rawDescGZIP(), []int{9}
+	return file_bib_v1_common_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CatalogEntry) GetTopicId() string {
@@ -926,7 +1141,7 @@ type Catalog struct {
 
 func (x *Catalog) Reset() {
 	*x = Catalog{}
-	mi := &file_bib_v1_common_proto_msgTypes[10]
+	mi := &file_bib_v1_common_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -938,7 +1153,7 @@ func (x *Catalog) String() string {
 func (*Catalog) ProtoMessage() {}
 
 func (x *Catalog) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_common_proto_msgTypes[10]
+	mi := &file_bib_v1_common_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -951,7 +1166,7 @@ func (x *Catalog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Catalog.ProtoReflect.Descriptor instead.
 func (*Catalog) Descriptor() ([]byte, []int) {
-	return file_bib_v1_common_proto_rawDescGZIP(), []int{10}
+	return file_bib_v1_common_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Catalog) GetPeerId() string {
@@ -994,7 +1209,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_bib_v1_common_proto_msgTypes[11]
+	mi := &file_bib_v1_common_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1006,7 +1221,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_common_proto_msgTypes[11]
+	mi := &file_bib_v1_common_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1019,7 +1234,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_bib_v1_common_proto_rawDescGZIP(), []int{11}
+	return file_bib_v1_common_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Error) GetCode() int32 {
@@ -1090,7 +1305,7 @@ const file_bib_v1_common_proto_rawDesc = "" +
 	"\taddresses\x18\x02 \x03(\tR\taddresses\x12\x1b\n" +
 	"\tnode_mode\x18\x03 \x01(\tR\bnodeMode\x12\x18\n" +
 	"\aversion\x18\x04 \x01(\tR\aversion\x127\n" +
-	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\x84\x02\n" +
+	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\xfa\x02\n" +
 	"\tTopicInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1100,7 +1315,31 @@ const file_bib_v1_common_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa6\x03\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
+	"\x03acl\x18\b \x03(\v2\x15.bib.v1.TopicACLEntryR\x03acl\x12\x16\n" +
+	"\x06owners\x18\t \x03(\tR\x06owners\x123\n" +
+	"\vdata_schema\x18\n" +
+	" \x01(\v2\x12.bib.v1.DataSchemaR\n" +
+	"dataSchema\"A\n" +
+	"\n" +
+	"DataSchema\x12\x1f\n" +
+	"\vjson_schema\x18\x01 \x01(\tR\n" +
+	"jsonSchema\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\"W\n" +
+	"\x0fSchemaViolation\x12\x16\n" +
+	"\x06record\x18\x01 \x01(\x05R\x06record\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xce\x01\n" +
+	"\rTopicACLEntry\x12%\n" +
+	"\x0eprincipal_type\x18\x01 \x01(\tR\rprincipalType\x12\x1c\n" +
+	"\tprincipal\x18\x02 \x01(\tR\tprincipal\x12\x1e\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\tR\n" +
+	"permission\x12\x1d\n" +
+	"\n" +
+	"granted_by\x18\x04 \x01(\tR\tgrantedBy\x129\n" +
+	"\n" +
+	"granted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tgrantedAt\"\xa6\x03\n" +
 	"\vDatasetInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\btopic_id\x18\x02 \x01(\tR\atopicId\x12\x12\n" +
@@ -1177,7 +1416,7 @@ func file_bib_v1_common_proto_rawDescGZIP() []byte {
 }
 
 var file_bib_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_bib_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_bib_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_bib_v1_common_proto_goTypes = []any{
 	(FilterOperator)(0),           // 0: bib.v1.FilterOperator
 	(*PageRequest)(nil),           // 1: bib.v1.PageRequest
@@ -1188,35 +1427,41 @@ var file_bib_v1_common_proto_goTypes = []any{
 	(*OperationMetadata)(nil),     // 6: bib.v1.OperationMetadata
 	(*PeerInfo)(nil),              // 7: bib.v1.PeerInfo
 	(*TopicInfo)(nil),             // 8: bib.v1.TopicInfo
-	(*DatasetInfo)(nil),           // 9: bib.v1.DatasetInfo
-	(*CatalogEntry)(nil),          // 10: bib.v1.CatalogEntry
-	(*Catalog)(nil),               // 11: bib.v1.Catalog
-	(*Error)(nil),                 // 12: bib.v1.Error
-	nil,                           // 13: bib.v1.OperationMetadata.ContextEntry
-	nil,                           // 14: bib.v1.DatasetInfo.MetadataEntry
-	nil,                           // 15: bib.v1.Error.DetailsEntry
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*DataSchema)(nil),            // 9: bib.v1.DataSchema
+	(*SchemaViolation)(nil),       // 10: bib.v1.SchemaViolation
+	(*TopicACLEntry)(nil),         // 11: bib.v1.TopicACLEntry
+	(*DatasetInfo)(nil),           // 12: bib.v1.DatasetInfo
+	(*CatalogEntry)(nil),          // 13: bib.v1.CatalogEntry
+	(*Catalog)(nil),               // 14: bib.v1.Catalog
+	(*Error)(nil),                 // 15: bib.v1.Error
+	nil,                           // 16: bib.v1.OperationMetadata.ContextEntry
+	nil,                           // 17: bib.v1.DatasetInfo.MetadataEntry
+	nil,                           // 18: bib.v1.Error.DetailsEntry
+	(*timestamppb.Timestamp)(nil), // 19: google.protobuf.Timestamp
 }
 var file_bib_v1_common_proto_depIdxs = []int32{
 	0,  // 0: bib.v1.Filter.operator:type_name -> bib.v1.FilterOperator
 	4,  // 1: bib.v1.FilterGroup.filters:type_name -> bib.v1.Filter
-	16, // 2: bib.v1.OperationMetadata.timestamp:type_name -> google.protobuf.Timestamp
-	13, // 3: bib.v1.OperationMetadata.context:type_name -> bib.v1.OperationMetadata.ContextEntry
-	16, // 4: bib.v1.PeerInfo.last_seen:type_name -> google.protobuf.Timestamp
-	16, // 5: bib.v1.TopicInfo.created_at:type_name -> google.protobuf.Timestamp
-	16, // 6: bib.v1.TopicInfo.updated_at:type_name -> google.protobuf.Timestamp
-	16, // 7: bib.v1.DatasetInfo.created_at:type_name -> google.protobuf.Timestamp
-	16, // 8: bib.v1.DatasetInfo.updated_at:type_name -> google.protobuf.Timestamp
-	14, // 9: bib.v1.DatasetInfo.metadata:type_name -> bib.v1.DatasetInfo.MetadataEntry
-	16, // 10: bib.v1.CatalogEntry.updated_at:type_name -> google.protobuf.Timestamp
-	10, // 11: bib.v1.Catalog.entries:type_name -> bib.v1.CatalogEntry
-	16, // 12: bib.v1.Catalog.last_updated:type_name -> google.protobuf.Timestamp
-	15, // 13: bib.v1.Error.details:type_name -> bib.v1.Error.DetailsEntry
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	19, // 2: bib.v1.OperationMetadata.timestamp:type_name -> google.protobuf.Timestamp
+	16, // 3: bib.v1.OperationMetadata.context:type_name -> bib.v1.OperationMetadata.ContextEntry
+	19, // 4: bib.v1.PeerInfo.last_seen:type_name -> google.protobuf.Timestamp
+	19, // 5: bib.v1.TopicInfo.created_at:type_name -> google.protobuf.Timestamp
+	19, // 6: bib.v1.TopicInfo.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 7: bib.v1.TopicInfo.acl:type_name -> bib.v1.TopicACLEntry
+	9,  // 8: bib.v1.TopicInfo.data_schema:type_name -> bib.v1.DataSchema
+	19, // 9: bib.v1.TopicACLEntry.granted_at:type_name -> google.protobuf.Timestamp
+	19, // 10: bib.v1.DatasetInfo.created_at:type_name -> google.protobuf.Timestamp
+	19, // 11: bib.v1.DatasetInfo.updated_at:type_name -> google.protobuf.Timestamp
+	17, // 12: bib.v1.DatasetInfo.metadata:type_name -> bib.v1.DatasetInfo.MetadataEntry
+	19, // 13: bib.v1.CatalogEntry.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 14: bib.v1.Catalog.entries:type_name -> bib.v1.CatalogEntry
+	19, // 15: bib.v1.Catalog.last_updated:type_name -> google.protobuf.Timestamp
+	18, // 16: bib.v1.Error.details:type_name -> bib.v1.Error.DetailsEntry
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_common_proto_init() }
@@ -1230,7 +1475,7 @@ func file_bib_v1_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_common_proto_rawDesc), len(file_bib_v1_common_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   15,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
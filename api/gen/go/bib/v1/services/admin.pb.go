@@ -25,6 +25,121 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SelfTestStatus is the outcome of a self-test step.
+type SelfTestStatus int32
+
+const (
+	SelfTestStatus_SELF_TEST_STATUS_UNSPECIFIED SelfTestStatus = 0
+	SelfTestStatus_SELF_TEST_STATUS_PASSED      SelfTestStatus = 1
+	SelfTestStatus_SELF_TEST_STATUS_FAILED      SelfTestStatus = 2
+	SelfTestStatus_SELF_TEST_STATUS_SKIPPED     SelfTestStatus = 3
+)
+
+// Enum value maps for SelfTestStatus.
+var (
+	SelfTestStatus_name = map[int32]string{
+		0: "SELF_TEST_STATUS_UNSPECIFIED",
+		1: "SELF_TEST_STATUS_PASSED",
+		2: "SELF_TEST_STATUS_FAILED",
+		3: "SELF_TEST_STATUS_SKIPPED",
+	}
+	SelfTestStatus_value = map[string]int32{
+		"SELF_TEST_STATUS_UNSPECIFIED": 0,
+		"SELF_TEST_STATUS_PASSED":      1,
+		"SELF_TEST_STATUS_FAILED":      2,
+		"SELF_TEST_STATUS_SKIPPED":     3,
+	}
+)
+
+func (x SelfTestStatus) Enum() *SelfTestStatus {
+	p := new(SelfTestStatus)
+	*p = x
+	return p
+}
+
+func (x SelfTestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SelfTestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bib_v1_services_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (SelfTestStatus) Type() protoreflect.EnumType {
+	return &file_bib_v1_services_admin_proto_enumTypes[0]
+}
+
+func (x SelfTestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SelfTestStatus.Descriptor instead.
+func (SelfTestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// ProfileType is the kind of pprof profile to capture.
+type ProfileType int32
+
+const (
+	ProfileType_PROFILE_TYPE_UNSPECIFIED ProfileType = 0
+	ProfileType_PROFILE_TYPE_CPU         ProfileType = 1
+	ProfileType_PROFILE_TYPE_HEAP        ProfileType = 2
+	ProfileType_PROFILE_TYPE_ALLOCS      ProfileType = 3
+	ProfileType_PROFILE_TYPE_GOROUTINE   ProfileType = 4
+	ProfileType_PROFILE_TYPE_BLOCK       ProfileType = 5
+	ProfileType_PROFILE_TYPE_MUTEX       ProfileType = 6
+)
+
+// Enum value maps for ProfileType.
+var (
+	ProfileType_name = map[int32]string{
+		0: "PROFILE_TYPE_UNSPECIFIED",
+		1: "PROFILE_TYPE_CPU",
+		2: "PROFILE_TYPE_HEAP",
+		3: "PROFILE_TYPE_ALLOCS",
+		4: "PROFILE_TYPE_GOROUTINE",
+		5: "PROFILE_TYPE_BLOCK",
+		6: "PROFILE_TYPE_MUTEX",
+	}
+	ProfileType_value = map[string]int32{
+		"PROFILE_TYPE_UNSPECIFIED": 0,
+		"PROFILE_TYPE_CPU":         1,
+		"PROFILE_TYPE_HEAP":        2,
+		"PROFILE_TYPE_ALLOCS":      3,
+		"PROFILE_TYPE_GOROUTINE":   4,
+		"PROFILE_TYPE_BLOCK":       5,
+		"PROFILE_TYPE_MUTEX":       6,
+	}
+)
+
+func (x ProfileType) Enum() *ProfileType {
+	p := new(ProfileType)
+	*p = x
+	return p
+}
+
+func (x ProfileType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProfileType) Descriptor() protoreflect.EnumDescriptor {
+	return file_bib_v1_services_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (ProfileType) Type() protoreflect.EnumType {
+	return &file_bib_v1_services_admin_proto_enumTypes[1]
+}
+
+func (x ProfileType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProfileType.Descriptor instead.
+func (ProfileType) EnumDescriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{1}
+}
+
 // GetConfigRequest requests configuration.
 type GetConfigRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -550,7 +665,10 @@ type StreamLogsRequest struct {
 	// Include historical logs.
 	IncludeHistory bool `protobuf:"varint,4,opt,name=include_history,json=includeHistory,proto3" json:"include_history,omitempty"`
 	// Number of historical entries.
-	HistoryCount  int32 `protobuf:"varint,5,opt,name=history_count,json=historyCount,proto3" json:"history_count,omitempty"`
+	HistoryCount int32 `protobuf:"varint,5,opt,name=history_count,json=historyCount,proto3" json:"history_count,omitempty"`
+	// Resume after the entry carrying this token (from LogEntry.resume_token).
+	// Takes precedence over include_history.
+	ResumeToken   string `protobuf:"bytes,6,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -620,6 +738,13 @@ func (x *StreamLogsRequest) GetHistoryCount() int32 {
 	return 0
 }
 
+func (x *StreamLogsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // LogEntry represents a log entry.
 type LogEntry struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -636,7 +761,9 @@ type LogEntry struct {
 	// Request ID (for tracing).
 	RequestId string `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	// Error stack trace (if error).
-	StackTrace    string `protobuf:"bytes,7,opt,name=stack_trace,json=stackTrace,proto3" json:"stack_trace,omitempty"`
+	StackTrace string `protobuf:"bytes,7,opt,name=stack_trace,json=stackTrace,proto3" json:"stack_trace,omitempty"`
+	// Opaque stream position; pass back as resume_token to continue after this entry.
+	ResumeToken   string `protobuf:"bytes,8,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -720,6 +847,13 @@ func (x *LogEntry) GetStackTrace() string {
 	return ""
 }
 
+func (x *LogEntry) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // GetAuditLogsRequest requests audit logs.
 type GetAuditLogsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -873,6 +1007,182 @@ func (x *GetAuditLogsResponse) GetPageInfo() *v1.PageInfo {
 	return nil
 }
 
+// QueryAuditRequest searches the audit trail. All filters are optional and
+// combined with AND.
+type QueryAuditRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filter by actor (user or node).
+	Actor string `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Filter by action, e.g. "INSERT", "DELETE".
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// Filter by outcome: "success" or "failure".
+	Outcome string `protobuf:"bytes,3,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	// Filter by resource type (the affected table).
+	ResourceType string `protobuf:"bytes,4,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	// Filter by resource ID.
+	ResourceId string `protobuf:"bytes,5,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// Start time (inclusive).
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End time (inclusive).
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// CEL expression over `entry`, in the language of the audit alert rules,
+	// e.g. `entry.break_glass && entry.action == "DELETE"`.
+	Expression string `protobuf:"bytes,8,opt,name=expression,proto3" json:"expression,omitempty"`
+	// Pagination. page.limit defaults to 50 (max 1000); page.cursor continues
+	// from the next_cursor of a previous response.
+	Page          *v1.PageRequest `protobuf:"bytes,9,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditRequest) Reset() {
+	*x = QueryAuditRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditRequest) ProtoMessage() {}
+
+func (x *QueryAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QueryAuditRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *QueryAuditRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *QueryAuditRequest) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *QueryAuditRequest) GetPage() *v1.PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+// QueryAuditResponse contains a page of matching audit entries, newest first.
+type QueryAuditResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*AuditLogEntry       `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// total_count is only set when the query needs no outcome, resource ID or
+	// expression filtering, which happens after the entries are read.
+	PageInfo      *v1.PageInfo `protobuf:"bytes,2,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditResponse) Reset() {
+	*x = QueryAuditResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditResponse) ProtoMessage() {}
+
+func (x *QueryAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuditResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QueryAuditResponse) GetEntries() []*AuditLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *QueryAuditResponse) GetPageInfo() *v1.PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
 // AuditLogEntry represents an audit log entry.
 type AuditLogEntry struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -910,7 +1220,7 @@ type AuditLogEntry struct {
 
 func (x *AuditLogEntry) Reset() {
 	*x = AuditLogEntry{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[12]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -922,7 +1232,7 @@ func (x *AuditLogEntry) String() string {
 func (*AuditLogEntry) ProtoMessage() {}
 
 func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[12]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -935,7 +1245,7 @@ func (x *AuditLogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuditLogEntry.ProtoReflect.Descriptor instead.
 func (*AuditLogEntry) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{12}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AuditLogEntry) GetId() string {
@@ -1036,36 +1346,28 @@ func (x *AuditLogEntry) GetNodeId() string {
 	return ""
 }
 
-// TriggerBackupRequest triggers a backup.
-type TriggerBackupRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Backup name (auto-generated if empty).
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Include blob storage.
-	IncludeBlobs bool `protobuf:"varint,2,opt,name=include_blobs,json=includeBlobs,proto3" json:"include_blobs,omitempty"`
-	// Compress backup.
-	Compress bool `protobuf:"varint,3,opt,name=compress,proto3" json:"compress,omitempty"`
-	// Encrypt backup.
-	Encrypt       bool `protobuf:"varint,4,opt,name=encrypt,proto3" json:"encrypt,omitempty"`
+// ListRateLimitBlocksRequest lists the active blocks.
+type ListRateLimitBlocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TriggerBackupRequest) Reset() {
-	*x = TriggerBackupRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[13]
+func (x *ListRateLimitBlocksRequest) Reset() {
+	*x = ListRateLimitBlocksRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TriggerBackupRequest) String() string {
+func (x *ListRateLimitBlocksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TriggerBackupRequest) ProtoMessage() {}
+func (*ListRateLimitBlocksRequest) ProtoMessage() {}
 
-func (x *TriggerBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[13]
+func (x *ListRateLimitBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1076,62 +1378,94 @@ func (x *TriggerBackupRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TriggerBackupRequest.ProtoReflect.Descriptor instead.
-func (*TriggerBackupRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListRateLimitBlocksRequest.ProtoReflect.Descriptor instead.
+func (*ListRateLimitBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *TriggerBackupRequest) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// ListRateLimitBlocksResponse contains the active blocks, soonest to expire
+// first.
+type ListRateLimitBlocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*RateLimitBlock      `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TriggerBackupRequest) GetIncludeBlobs() bool {
-	if x != nil {
-		return x.IncludeBlobs
-	}
-	return false
+func (x *ListRateLimitBlocksResponse) Reset() {
+	*x = ListRateLimitBlocksResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *TriggerBackupRequest) GetCompress() bool {
+func (x *ListRateLimitBlocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRateLimitBlocksResponse) ProtoMessage() {}
+
+func (x *ListRateLimitBlocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[16]
 	if x != nil {
-		return x.Compress
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *TriggerBackupRequest) GetEncrypt() bool {
+// Deprecated: Use ListRateLimitBlocksResponse.ProtoReflect.Descriptor instead.
+func (*ListRateLimitBlocksResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListRateLimitBlocksResponse) GetBlocks() []*RateLimitBlock {
 	if x != nil {
-		return x.Encrypt
+		return x.Blocks
 	}
-	return false
+	return nil
 }
 
-// TriggerBackupResponse contains backup result.
-type TriggerBackupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Backup        *BackupInfo            `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+// RateLimitBlock is a user or role whose requests are rejected until the
+// block expires.
+type RateLimitBlock struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key identifying the block: "actor:<user id>" or "role:<role>".
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Kind of the blocked subject: "actor" or "role".
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// The blocked user ID or role.
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Audit alert rule that triggered the block (empty if set manually).
+	Rule string `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Description of the alert.
+	Reason string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	// When the block started.
+	BlockedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=blocked_at,json=blockedAt,proto3" json:"blocked_at,omitempty"`
+	// When the block expires.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TriggerBackupResponse) Reset() {
-	*x = TriggerBackupResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[14]
+func (x *RateLimitBlock) Reset() {
+	*x = RateLimitBlock{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TriggerBackupResponse) String() string {
+func (x *RateLimitBlock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TriggerBackupResponse) ProtoMessage() {}
+func (*RateLimitBlock) ProtoMessage() {}
 
-func (x *TriggerBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[14]
+func (x *RateLimitBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,9 +1476,260 @@ func (x *TriggerBackupResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TriggerBackupResponse.ProtoReflect.Descriptor instead.
+// Deprecated: Use RateLimitBlock.ProtoReflect.Descriptor instead.
+func (*RateLimitBlock) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RateLimitBlock) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RateLimitBlock) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RateLimitBlock) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RateLimitBlock) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RateLimitBlock) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RateLimitBlock) GetBlockedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockedAt
+	}
+	return nil
+}
+
+func (x *RateLimitBlock) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// UnblockRateLimitRequest lifts a block.
+type UnblockRateLimitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Key of the block, as listed by ListRateLimitBlocks.
+	Key           string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockRateLimitRequest) Reset() {
+	*x = UnblockRateLimitRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockRateLimitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockRateLimitRequest) ProtoMessage() {}
+
+func (x *UnblockRateLimitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockRateLimitRequest.ProtoReflect.Descriptor instead.
+func (*UnblockRateLimitRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnblockRateLimitRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// UnblockRateLimitResponse confirms the block was lifted.
+type UnblockRateLimitResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *RateLimitBlock        `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockRateLimitResponse) Reset() {
+	*x = UnblockRateLimitResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockRateLimitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockRateLimitResponse) ProtoMessage() {}
+
+func (x *UnblockRateLimitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockRateLimitResponse.ProtoReflect.Descriptor instead.
+func (*UnblockRateLimitResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UnblockRateLimitResponse) GetBlock() *RateLimitBlock {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+// TriggerBackupRequest triggers a backup.
+type TriggerBackupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Backup name (auto-generated if empty).
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Include blob storage.
+	IncludeBlobs bool `protobuf:"varint,2,opt,name=include_blobs,json=includeBlobs,proto3" json:"include_blobs,omitempty"`
+	// Compress backup.
+	Compress bool `protobuf:"varint,3,opt,name=compress,proto3" json:"compress,omitempty"`
+	// Encrypt backup.
+	Encrypt       bool `protobuf:"varint,4,opt,name=encrypt,proto3" json:"encrypt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerBackupRequest) Reset() {
+	*x = TriggerBackupRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerBackupRequest) ProtoMessage() {}
+
+func (x *TriggerBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerBackupRequest.ProtoReflect.Descriptor instead.
+func (*TriggerBackupRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TriggerBackupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TriggerBackupRequest) GetIncludeBlobs() bool {
+	if x != nil {
+		return x.IncludeBlobs
+	}
+	return false
+}
+
+func (x *TriggerBackupRequest) GetCompress() bool {
+	if x != nil {
+		return x.Compress
+	}
+	return false
+}
+
+func (x *TriggerBackupRequest) GetEncrypt() bool {
+	if x != nil {
+		return x.Encrypt
+	}
+	return false
+}
+
+// TriggerBackupResponse contains backup result.
+type TriggerBackupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Backup        *BackupInfo            `protobuf:"bytes,1,opt,name=backup,proto3" json:"backup,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerBackupResponse) Reset() {
+	*x = TriggerBackupResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerBackupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerBackupResponse) ProtoMessage() {}
+
+func (x *TriggerBackupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerBackupResponse.ProtoReflect.Descriptor instead.
 func (*TriggerBackupResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{14}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *TriggerBackupResponse) GetBackup() *BackupInfo {
@@ -1187,7 +1772,7 @@ type BackupInfo struct {
 
 func (x *BackupInfo) Reset() {
 	*x = BackupInfo{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[15]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1199,7 +1784,7 @@ func (x *BackupInfo) String() string {
 func (*BackupInfo) ProtoMessage() {}
 
 func (x *BackupInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[15]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1212,7 +1797,7 @@ func (x *BackupInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BackupInfo.ProtoReflect.Descriptor instead.
 func (*BackupInfo) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{15}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *BackupInfo) GetId() string {
@@ -1309,7 +1894,7 @@ type ListBackupsRequest struct {
 
 func (x *ListBackupsRequest) Reset() {
 	*x = ListBackupsRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[16]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1321,7 +1906,7 @@ func (x *ListBackupsRequest) String() string {
 func (*ListBackupsRequest) ProtoMessage() {}
 
 func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[16]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1334,7 +1919,7 @@ func (x *ListBackupsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackupsRequest.ProtoReflect.Descriptor instead.
 func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{16}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListBackupsRequest) GetPage() *v1.PageRequest {
@@ -1355,7 +1940,7 @@ type ListBackupsResponse struct {
 
 func (x *ListBackupsResponse) Reset() {
 	*x = ListBackupsResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[17]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1367,7 +1952,7 @@ func (x *ListBackupsResponse) String() string {
 func (*ListBackupsResponse) ProtoMessage() {}
 
 func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[17]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,7 +1965,7 @@ func (x *ListBackupsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListBackupsResponse.ProtoReflect.Descriptor instead.
 func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{17}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListBackupsResponse) GetBackups() []*BackupInfo {
@@ -1412,7 +1997,7 @@ type RestoreBackupRequest struct {
 
 func (x *RestoreBackupRequest) Reset() {
 	*x = RestoreBackupRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[18]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1424,7 +2009,7 @@ func (x *RestoreBackupRequest) String() string {
 func (*RestoreBackupRequest) ProtoMessage() {}
 
 func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[18]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1437,7 +2022,7 @@ func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestoreBackupRequest.ProtoReflect.Descriptor instead.
 func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{18}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *RestoreBackupRequest) GetBackupId() string {
@@ -1473,7 +2058,7 @@ type RestoreBackupResponse struct {
 
 func (x *RestoreBackupResponse) Reset() {
 	*x = RestoreBackupResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[19]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1485,7 +2070,7 @@ func (x *RestoreBackupResponse) String() string {
 func (*RestoreBackupResponse) ProtoMessage() {}
 
 func (x *RestoreBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[19]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1498,7 +2083,7 @@ func (x *RestoreBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RestoreBackupResponse.ProtoReflect.Descriptor instead.
 func (*RestoreBackupResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{19}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *RestoreBackupResponse) GetSuccess() bool {
@@ -1524,15 +2109,19 @@ func (x *RestoreBackupResponse) GetRestartRequired() bool {
 
 // DeleteBackupRequest deletes a backup.
 type DeleteBackupRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	BackupId      string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	BackupId string                 `protobuf:"bytes,1,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	// Report what would be deleted without deleting it.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Token from a dry run of the same deletion; required to delete.
+	ConfirmationToken string `protobuf:"bytes,3,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *DeleteBackupRequest) Reset() {
 	*x = DeleteBackupRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[20]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1544,7 +2133,7 @@ func (x *DeleteBackupRequest) String() string {
 func (*DeleteBackupRequest) ProtoMessage() {}
 
 func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[20]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1557,7 +2146,7 @@ func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{20}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *DeleteBackupRequest) GetBackupId() string {
@@ -1567,17 +2156,33 @@ func (x *DeleteBackupRequest) GetBackupId() string {
 	return ""
 }
 
-// DeleteBackupResponse confirms deletion.
+func (x *DeleteBackupRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DeleteBackupRequest) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+// DeleteBackupResponse confirms deletion.
 type DeleteBackupResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// What would be deleted (set for dry runs only).
+	DryRun        *DryRunReport `protobuf:"bytes,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteBackupResponse) Reset() {
 	*x = DeleteBackupResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[21]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1589,7 +2194,7 @@ func (x *DeleteBackupResponse) String() string {
 func (*DeleteBackupResponse) ProtoMessage() {}
 
 func (x *DeleteBackupResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[21]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1602,7 +2207,7 @@ func (x *DeleteBackupResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBackupResponse.ProtoReflect.Descriptor instead.
 func (*DeleteBackupResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{21}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *DeleteBackupResponse) GetSuccess() bool {
@@ -1612,6 +2217,169 @@ func (x *DeleteBackupResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *DeleteBackupResponse) GetDryRun() *DryRunReport {
+	if x != nil {
+		return x.DryRun
+	}
+	return nil
+}
+
+// DryRunReport describes what a destructive operation would affect. It is
+// computed by the daemon from the same state the operation would act on.
+type DryRunReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Resources that would be removed or changed.
+	Resources []*AffectedResource `protobuf:"bytes,1,rep,name=resources,proto3" json:"resources,omitempty"`
+	// Total bytes that would be freed.
+	TotalBytes int64 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	// One-line summary, e.g. "would delete 2 snapshots (14 MB)".
+	Summary string `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Token to pass to the same operation to execute it, for irreversible
+	// operations. It is single-use, bound to the operation, its target and
+	// the caller, and expires at confirmation_expires_at.
+	ConfirmationToken     string                 `protobuf:"bytes,4,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	ConfirmationExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=confirmation_expires_at,json=confirmationExpiresAt,proto3" json:"confirmation_expires_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DryRunReport) Reset() {
+	*x = DryRunReport{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DryRunReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunReport) ProtoMessage() {}
+
+func (x *DryRunReport) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunReport.ProtoReflect.Descriptor instead.
+func (*DryRunReport) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DryRunReport) GetResources() []*AffectedResource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *DryRunReport) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *DryRunReport) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *DryRunReport) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+func (x *DryRunReport) GetConfirmationExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConfirmationExpiresAt
+	}
+	return nil
+}
+
+// AffectedResource is a resource a destructive operation would affect.
+type AffectedResource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Kind: "backup", "snapshot", "cluster_member".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Size on disk (0 if not applicable).
+	SizeBytes int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Human-readable detail, e.g. a member's address and role.
+	Detail        string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AffectedResource) Reset() {
+	*x = AffectedResource{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AffectedResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AffectedResource) ProtoMessage() {}
+
+func (x *AffectedResource) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AffectedResource.ProtoReflect.Descriptor instead.
+func (*AffectedResource) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AffectedResource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AffectedResource) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AffectedResource) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *AffectedResource) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
 // GetClusterStatusRequest requests cluster status.
 type GetClusterStatusRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1623,7 +2391,7 @@ type GetClusterStatusRequest struct {
 
 func (x *GetClusterStatusRequest) Reset() {
 	*x = GetClusterStatusRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[22]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1635,7 +2403,7 @@ func (x *GetClusterStatusRequest) String() string {
 func (*GetClusterStatusRequest) ProtoMessage() {}
 
 func (x *GetClusterStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[22]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1648,7 +2416,7 @@ func (x *GetClusterStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetClusterStatusRequest.ProtoReflect.Descriptor instead.
 func (*GetClusterStatusRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{22}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetClusterStatusRequest) GetIncludeMembers() bool {
@@ -1685,7 +2453,7 @@ type GetClusterStatusResponse struct {
 
 func (x *GetClusterStatusResponse) Reset() {
 	*x = GetClusterStatusResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[23]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1697,7 +2465,7 @@ func (x *GetClusterStatusResponse) String() string {
 func (*GetClusterStatusResponse) ProtoMessage() {}
 
 func (x *GetClusterStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[23]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1710,7 +2478,7 @@ func (x *GetClusterStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetClusterStatusResponse.ProtoReflect.Descriptor instead.
 func (*GetClusterStatusResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{23}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetClusterStatusResponse) GetEnabled() bool {
@@ -1797,7 +2565,7 @@ type ClusterMember struct {
 
 func (x *ClusterMember) Reset() {
 	*x = ClusterMember{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[24]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1809,7 +2577,7 @@ func (x *ClusterMember) String() string {
 func (*ClusterMember) ProtoMessage() {}
 
 func (x *ClusterMember) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[24]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1822,7 +2590,7 @@ func (x *ClusterMember) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClusterMember.ProtoReflect.Descriptor instead.
 func (*ClusterMember) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{24}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ClusterMember) GetId() string {
@@ -1886,7 +2654,7 @@ type SnapshotInfo struct {
 
 func (x *SnapshotInfo) Reset() {
 	*x = SnapshotInfo{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[25]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1898,7 +2666,7 @@ func (x *SnapshotInfo) String() string {
 func (*SnapshotInfo) ProtoMessage() {}
 
 func (x *SnapshotInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[25]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1911,7 +2679,7 @@ func (x *SnapshotInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SnapshotInfo.ProtoReflect.Descriptor instead.
 func (*SnapshotInfo) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{25}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *SnapshotInfo) GetId() string {
@@ -1951,14 +2719,16 @@ func (x *SnapshotInfo) GetSize() int64 {
 
 // TriggerSnapshotRequest triggers a snapshot.
 type TriggerSnapshotRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Report the snapshots retention would prune without taking one.
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TriggerSnapshotRequest) Reset() {
 	*x = TriggerSnapshotRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[26]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1970,7 +2740,7 @@ func (x *TriggerSnapshotRequest) String() string {
 func (*TriggerSnapshotRequest) ProtoMessage() {}
 
 func (x *TriggerSnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[26]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1983,20 +2753,31 @@ func (x *TriggerSnapshotRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerSnapshotRequest.ProtoReflect.Descriptor instead.
 func (*TriggerSnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{26}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TriggerSnapshotRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
 }
 
 // TriggerSnapshotResponse contains snapshot result.
 type TriggerSnapshotResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Snapshot      *SnapshotInfo          `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot *SnapshotInfo          `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// Snapshots removed to stay within snapshot.retain_count.
+	Pruned []*SnapshotInfo `protobuf:"bytes,2,rep,name=pruned,proto3" json:"pruned,omitempty"`
+	// What would be pruned (set for dry runs only).
+	DryRun        *DryRunReport `protobuf:"bytes,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TriggerSnapshotResponse) Reset() {
 	*x = TriggerSnapshotResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[27]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2008,7 +2789,7 @@ func (x *TriggerSnapshotResponse) String() string {
 func (*TriggerSnapshotResponse) ProtoMessage() {}
 
 func (x *TriggerSnapshotResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[27]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2021,7 +2802,7 @@ func (x *TriggerSnapshotResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerSnapshotResponse.ProtoReflect.Descriptor instead.
 func (*TriggerSnapshotResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{27}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *TriggerSnapshotResponse) GetSnapshot() *SnapshotInfo {
@@ -2031,6 +2812,20 @@ func (x *TriggerSnapshotResponse) GetSnapshot() *SnapshotInfo {
 	return nil
 }
 
+func (x *TriggerSnapshotResponse) GetPruned() []*SnapshotInfo {
+	if x != nil {
+		return x.Pruned
+	}
+	return nil
+}
+
+func (x *TriggerSnapshotResponse) GetDryRun() *DryRunReport {
+	if x != nil {
+		return x.DryRun
+	}
+	return nil
+}
+
 // TransferLeadershipRequest transfers leadership.
 type TransferLeadershipRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2042,7 +2837,7 @@ type TransferLeadershipRequest struct {
 
 func (x *TransferLeadershipRequest) Reset() {
 	*x = TransferLeadershipRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[28]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2054,7 +2849,7 @@ func (x *TransferLeadershipRequest) String() string {
 func (*TransferLeadershipRequest) ProtoMessage() {}
 
 func (x *TransferLeadershipRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[28]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2067,7 +2862,7 @@ func (x *TransferLeadershipRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferLeadershipRequest.ProtoReflect.Descriptor instead.
 func (*TransferLeadershipRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{28}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *TransferLeadershipRequest) GetTargetId() string {
@@ -2088,7 +2883,7 @@ type TransferLeadershipResponse struct {
 
 func (x *TransferLeadershipResponse) Reset() {
 	*x = TransferLeadershipResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[29]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2100,7 +2895,7 @@ func (x *TransferLeadershipResponse) String() string {
 func (*TransferLeadershipResponse) ProtoMessage() {}
 
 func (x *TransferLeadershipResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[29]
+	mi := &file_bib_v1_services_admin_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2113,7 +2908,7 @@ func (x *TransferLeadershipResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TransferLeadershipResponse.ProtoReflect.Descriptor instead.
 func (*TransferLeadershipResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{29}
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *TransferLeadershipResponse) GetSuccess() bool {
@@ -2130,34 +2925,33 @@ func (x *TransferLeadershipResponse) GetNewLeaderId() string {
 	return ""
 }
 
-// ShutdownRequest requests shutdown.
-type ShutdownRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Graceful shutdown timeout.
-	Timeout *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	// Force shutdown.
-	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
-	// Reason for shutdown.
-	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// RemoveClusterMemberRequest removes a cluster member.
+type RemoveClusterMemberRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NodeId string                 `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Validate and report what would be removed without removing it.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Token from a dry run of the same removal; required to remove.
+	ConfirmationToken string `protobuf:"bytes,3,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *ShutdownRequest) Reset() {
-	*x = ShutdownRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[30]
+func (x *RemoveClusterMemberRequest) Reset() {
+	*x = RemoveClusterMemberRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShutdownRequest) String() string {
+func (x *RemoveClusterMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShutdownRequest) ProtoMessage() {}
+func (*RemoveClusterMemberRequest) ProtoMessage() {}
 
-func (x *ShutdownRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[30]
+func (x *RemoveClusterMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2168,56 +2962,60 @@ func (x *ShutdownRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShutdownRequest.ProtoReflect.Descriptor instead.
-func (*ShutdownRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use RemoveClusterMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveClusterMemberRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ShutdownRequest) GetTimeout() *durationpb.Duration {
+func (x *RemoveClusterMemberRequest) GetNodeId() string {
 	if x != nil {
-		return x.Timeout
+		return x.NodeId
 	}
-	return nil
+	return ""
 }
 
-func (x *ShutdownRequest) GetForce() bool {
+func (x *RemoveClusterMemberRequest) GetDryRun() bool {
 	if x != nil {
-		return x.Force
+		return x.DryRun
 	}
 	return false
 }
 
-func (x *ShutdownRequest) GetReason() string {
+func (x *RemoveClusterMemberRequest) GetConfirmationToken() string {
 	if x != nil {
-		return x.Reason
+		return x.ConfirmationToken
 	}
 	return ""
 }
 
-// ShutdownResponse confirms shutdown initiated.
-type ShutdownResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+// RemoveClusterMemberResponse confirms removal.
+type RemoveClusterMemberResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The removed member.
+	Member *ClusterMember `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	// Voters left after the removal.
+	RemainingVoters int32 `protobuf:"varint,2,opt,name=remaining_voters,json=remainingVoters,proto3" json:"remaining_voters,omitempty"`
+	// What would be removed (set for dry runs only).
+	DryRun        *DryRunReport `protobuf:"bytes,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShutdownResponse) Reset() {
-	*x = ShutdownResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[31]
+func (x *RemoveClusterMemberResponse) Reset() {
+	*x = RemoveClusterMemberResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShutdownResponse) String() string {
+func (x *RemoveClusterMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShutdownResponse) ProtoMessage() {}
+func (*RemoveClusterMemberResponse) ProtoMessage() {}
 
-func (x *ShutdownResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[31]
+func (x *RemoveClusterMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2228,47 +3026,1293 @@ func (x *ShutdownResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShutdownResponse.ProtoReflect.Descriptor instead.
-func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use RemoveClusterMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveClusterMemberResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ShutdownResponse) GetAccepted() bool {
+func (x *RemoveClusterMemberResponse) GetMember() *ClusterMember {
 	if x != nil {
-		return x.Accepted
+		return x.Member
 	}
-	return false
+	return nil
 }
 
-func (x *ShutdownResponse) GetMessage() string {
+func (x *RemoveClusterMemberResponse) GetRemainingVoters() int32 {
 	if x != nil {
-		return x.Message
+		return x.RemainingVoters
 	}
-	return ""
+	return 0
 }
 
-// GetSystemInfoRequest requests system info.
-type GetSystemInfoRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *RemoveClusterMemberResponse) GetDryRun() *DryRunReport {
+	if x != nil {
+		return x.DryRun
+	}
+	return nil
+}
+
+// ClusterLock is a lease on a cluster-wide lock.
+type ClusterLock struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Who holds the lock, e.g. a node ID.
+	Holder string `protobuf:"bytes,2,opt,name=holder,proto3" json:"holder,omitempty"`
+	// Token identifying the lease; needed to renew or release it.
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	AcquiredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=acquired_at,json=acquiredAt,proto3" json:"acquired_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemInfoRequest) Reset() {
-	*x = GetSystemInfoRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[32]
+func (x *ClusterLock) Reset() {
+	*x = ClusterLock{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemInfoRequest) String() string {
+func (x *ClusterLock) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemInfoRequest) ProtoMessage() {}
+func (*ClusterLock) ProtoMessage() {}
 
-func (x *GetSystemInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[32]
+func (x *ClusterLock) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterLock.ProtoReflect.Descriptor instead.
+func (*ClusterLock) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ClusterLock) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ClusterLock) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+func (x *ClusterLock) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ClusterLock) GetAcquiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcquiredAt
+	}
+	return nil
+}
+
+func (x *ClusterLock) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// AcquireLockRequest acquires a lock.
+type AcquireLockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Lease duration (default 30s).
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Holder recorded on the lock (default: the calling user).
+	Holder        string `protobuf:"bytes,3,opt,name=holder,proto3" json:"holder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireLockRequest) Reset() {
+	*x = AcquireLockRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireLockRequest) ProtoMessage() {}
+
+func (x *AcquireLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireLockRequest.ProtoReflect.Descriptor instead.
+func (*AcquireLockRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AcquireLockRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AcquireLockRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *AcquireLockRequest) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+// AcquireLockResponse returns the lease.
+type AcquireLockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lock          *ClusterLock           `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireLockResponse) Reset() {
+	*x = AcquireLockResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireLockResponse) ProtoMessage() {}
+
+func (x *AcquireLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireLockResponse.ProtoReflect.Descriptor instead.
+func (*AcquireLockResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AcquireLockResponse) GetLock() *ClusterLock {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+// RenewLockRequest extends a lease.
+type RenewLockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Token string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// New lease duration from now (default 30s).
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewLockRequest) Reset() {
+	*x = RenewLockRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewLockRequest) ProtoMessage() {}
+
+func (x *RenewLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewLockRequest.ProtoReflect.Descriptor instead.
+func (*RenewLockRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RenewLockRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RenewLockRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RenewLockRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+// RenewLockResponse returns the renewed lease.
+type RenewLockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lock          *ClusterLock           `protobuf:"bytes,1,opt,name=lock,proto3" json:"lock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewLockResponse) Reset() {
+	*x = RenewLockResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewLockResponse) ProtoMessage() {}
+
+func (x *RenewLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewLockResponse.ProtoReflect.Descriptor instead.
+func (*RenewLockResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RenewLockResponse) GetLock() *ClusterLock {
+	if x != nil {
+		return x.Lock
+	}
+	return nil
+}
+
+// ReleaseLockRequest releases a lease.
+type ReleaseLockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseLockRequest) Reset() {
+	*x = ReleaseLockRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseLockRequest) ProtoMessage() {}
+
+func (x *ReleaseLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseLockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseLockRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReleaseLockRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReleaseLockRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// ReleaseLockResponse confirms the release.
+type ReleaseLockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseLockResponse) Reset() {
+	*x = ReleaseLockResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseLockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseLockResponse) ProtoMessage() {}
+
+func (x *ReleaseLockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseLockResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseLockResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{47}
+}
+
+// ListLocksRequest lists the held locks.
+type ListLocksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocksRequest) Reset() {
+	*x = ListLocksRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocksRequest) ProtoMessage() {}
+
+func (x *ListLocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocksRequest.ProtoReflect.Descriptor instead.
+func (*ListLocksRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{48}
+}
+
+// ListLocksResponse contains the held locks.
+type ListLocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locks         []*ClusterLock         `protobuf:"bytes,1,rep,name=locks,proto3" json:"locks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLocksResponse) Reset() {
+	*x = ListLocksResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLocksResponse) ProtoMessage() {}
+
+func (x *ListLocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLocksResponse.ProtoReflect.Descriptor instead.
+func (*ListLocksResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListLocksResponse) GetLocks() []*ClusterLock {
+	if x != nil {
+		return x.Locks
+	}
+	return nil
+}
+
+// ShutdownRequest requests shutdown.
+type ShutdownRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Graceful shutdown timeout.
+	Timeout *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Force shutdown.
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// Reason for shutdown.
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShutdownRequest) Reset() {
+	*x = ShutdownRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShutdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownRequest) ProtoMessage() {}
+
+func (x *ShutdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownRequest.ProtoReflect.Descriptor instead.
+func (*ShutdownRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ShutdownRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ShutdownRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ShutdownRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ShutdownResponse confirms shutdown initiated.
+type ShutdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShutdownResponse) Reset() {
+	*x = ShutdownResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShutdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownResponse) ProtoMessage() {}
+
+func (x *ShutdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownResponse.ProtoReflect.Descriptor instead.
+func (*ShutdownResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ShutdownResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *ShutdownResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetSystemInfoRequest requests system info.
+type GetSystemInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemInfoRequest) Reset() {
+	*x = GetSystemInfoRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemInfoRequest) ProtoMessage() {}
+
+func (x *GetSystemInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemInfoRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{52}
+}
+
+// GetSystemInfoResponse contains system info.
+type GetSystemInfoResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// OS info.
+	Os     string `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Arch   string `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
+	NumCpu int32  `protobuf:"varint,3,opt,name=num_cpu,json=numCpu,proto3" json:"num_cpu,omitempty"`
+	// Memory (bytes).
+	TotalMemory int64 `protobuf:"varint,4,opt,name=total_memory,json=totalMemory,proto3" json:"total_memory,omitempty"`
+	UsedMemory  int64 `protobuf:"varint,5,opt,name=used_memory,json=usedMemory,proto3" json:"used_memory,omitempty"`
+	HeapAlloc   int64 `protobuf:"varint,6,opt,name=heap_alloc,json=heapAlloc,proto3" json:"heap_alloc,omitempty"`
+	// Go runtime.
+	GoVersion    string `protobuf:"bytes,7,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	NumGoroutine int32  `protobuf:"varint,8,opt,name=num_goroutine,json=numGoroutine,proto3" json:"num_goroutine,omitempty"`
+	// Process.
+	Pid       int32                  `protobuf:"varint,9,opt,name=pid,proto3" json:"pid,omitempty"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Uptime    *durationpb.Duration   `protobuf:"bytes,11,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	// Disk usage.
+	DiskTotal int64 `protobuf:"varint,12,opt,name=disk_total,json=diskTotal,proto3" json:"disk_total,omitempty"`
+	DiskUsed  int64 `protobuf:"varint,13,opt,name=disk_used,json=diskUsed,proto3" json:"disk_used,omitempty"`
+	DiskFree  int64 `protobuf:"varint,14,opt,name=disk_free,json=diskFree,proto3" json:"disk_free,omitempty"`
+	// Background maintenance.
+	Maintenance   *MaintenanceState `protobuf:"bytes,15,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSystemInfoResponse) Reset() {
+	*x = GetSystemInfoResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSystemInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSystemInfoResponse) ProtoMessage() {}
+
+func (x *GetSystemInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSystemInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetSystemInfoResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetSystemInfoResponse) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetNumCpu() int32 {
+	if x != nil {
+		return x.NumCpu
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetTotalMemory() int64 {
+	if x != nil {
+		return x.TotalMemory
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetUsedMemory() int64 {
+	if x != nil {
+		return x.UsedMemory
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetHeapAlloc() int64 {
+	if x != nil {
+		return x.HeapAlloc
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetSystemInfoResponse) GetNumGoroutine() int32 {
+	if x != nil {
+		return x.NumGoroutine
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetSystemInfoResponse) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *GetSystemInfoResponse) GetDiskTotal() int64 {
+	if x != nil {
+		return x.DiskTotal
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetDiskUsed() int64 {
+	if x != nil {
+		return x.DiskUsed
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetDiskFree() int64 {
+	if x != nil {
+		return x.DiskFree
+	}
+	return 0
+}
+
+func (x *GetSystemInfoResponse) GetMaintenance() *MaintenanceState {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
+// RunMaintenanceRequest runs maintenance.
+type RunMaintenanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tasks to run.
+	Tasks []string `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// Force run even if recently run.
+	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceRequest) Reset() {
+	*x = RunMaintenanceRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceRequest) ProtoMessage() {}
+
+func (x *RunMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RunMaintenanceRequest) GetTasks() []string {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *RunMaintenanceRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// RunMaintenanceResponse contains maintenance result.
+type RunMaintenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*MaintenanceResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunMaintenanceResponse) Reset() {
+	*x = RunMaintenanceResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunMaintenanceResponse) ProtoMessage() {}
+
+func (x *RunMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RunMaintenanceResponse) GetResults() []*MaintenanceResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// MaintenanceResult is a maintenance task result.
+type MaintenanceResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          string                 `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceResult) Reset() {
+	*x = MaintenanceResult{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceResult) ProtoMessage() {}
+
+func (x *MaintenanceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceResult.ProtoReflect.Descriptor instead.
+func (*MaintenanceResult) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *MaintenanceResult) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *MaintenanceResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *MaintenanceResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MaintenanceResult) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// MaintenanceState reports whether background maintenance is paused.
+type MaintenanceState struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Paused bool                   `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Why, by whom and when maintenance was paused.
+	Reason   string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	PausedBy string                 `protobuf:"bytes,3,opt,name=paused_by,json=pausedBy,proto3" json:"paused_by,omitempty"`
+	PausedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=paused_at,json=pausedAt,proto3" json:"paused_at,omitempty"`
+	// Workers observing the pause: "gc", "vacuum", "rotation", "sync".
+	Workers []string `protobuf:"bytes,5,rep,name=workers,proto3" json:"workers,omitempty"`
+	// Workers currently held by the pause.
+	Waiting       []string `protobuf:"bytes,6,rep,name=waiting,proto3" json:"waiting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceState) Reset() {
+	*x = MaintenanceState{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceState) ProtoMessage() {}
+
+func (x *MaintenanceState) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceState.ProtoReflect.Descriptor instead.
+func (*MaintenanceState) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *MaintenanceState) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *MaintenanceState) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MaintenanceState) GetPausedBy() string {
+	if x != nil {
+		return x.PausedBy
+	}
+	return ""
+}
+
+func (x *MaintenanceState) GetPausedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PausedAt
+	}
+	return nil
+}
+
+func (x *MaintenanceState) GetWorkers() []string {
+	if x != nil {
+		return x.Workers
+	}
+	return nil
+}
+
+func (x *MaintenanceState) GetWaiting() []string {
+	if x != nil {
+		return x.Waiting
+	}
+	return nil
+}
+
+// PauseMaintenanceRequest pauses background maintenance.
+type PauseMaintenanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reason for pausing, recorded in the audit log.
+	Reason        string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseMaintenanceRequest) Reset() {
+	*x = PauseMaintenanceRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseMaintenanceRequest) ProtoMessage() {}
+
+func (x *PauseMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*PauseMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *PauseMaintenanceRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// PauseMaintenanceResponse contains the maintenance state.
+type PauseMaintenanceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State *MaintenanceState      `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// False if maintenance was already paused.
+	Changed       bool `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseMaintenanceResponse) Reset() {
+	*x = PauseMaintenanceResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseMaintenanceResponse) ProtoMessage() {}
+
+func (x *PauseMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*PauseMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *PauseMaintenanceResponse) GetState() *MaintenanceState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *PauseMaintenanceResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+// ResumeMaintenanceRequest resumes background maintenance.
+type ResumeMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeMaintenanceRequest) Reset() {
+	*x = ResumeMaintenanceRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeMaintenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeMaintenanceRequest) ProtoMessage() {}
+
+func (x *ResumeMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*ResumeMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{60}
+}
+
+// ResumeMaintenanceResponse contains the maintenance state.
+type ResumeMaintenanceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State *MaintenanceState      `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// False if maintenance was not paused.
+	Changed       bool `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeMaintenanceResponse) Reset() {
+	*x = ResumeMaintenanceResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeMaintenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeMaintenanceResponse) ProtoMessage() {}
+
+func (x *ResumeMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*ResumeMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ResumeMaintenanceResponse) GetState() *MaintenanceState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ResumeMaintenanceResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+// UpgradeRequest requests preparation for an upgrade.
+type UpgradeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version to upgrade to.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Skip the pre-upgrade backup.
+	SkipBackup bool `protobuf:"varint,2,opt,name=skip_backup,json=skipBackup,proto3" json:"skip_backup,omitempty"`
+	// Validate only; take no backup.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Allow upgrading to an older version.
+	AllowDowngrade bool `protobuf:"varint,4,opt,name=allow_downgrade,json=allowDowngrade,proto3" json:"allow_downgrade,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpgradeRequest) Reset() {
+	*x = UpgradeRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeRequest) ProtoMessage() {}
+
+func (x *UpgradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2279,52 +4323,70 @@ func (x *GetSystemInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemInfoRequest.ProtoReflect.Descriptor instead.
-func (*GetSystemInfoRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use UpgradeRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{62}
 }
 
-// GetSystemInfoResponse contains system info.
-type GetSystemInfoResponse struct {
+func (x *UpgradeRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpgradeRequest) GetSkipBackup() bool {
+	if x != nil {
+		return x.SkipBackup
+	}
+	return false
+}
+
+func (x *UpgradeRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *UpgradeRequest) GetAllowDowngrade() bool {
+	if x != nil {
+		return x.AllowDowngrade
+	}
+	return false
+}
+
+// UpgradeResponse reports the daemon's readiness for the upgrade.
+type UpgradeResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// OS info.
-	Os     string `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
-	Arch   string `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
-	NumCpu int32  `protobuf:"varint,3,opt,name=num_cpu,json=numCpu,proto3" json:"num_cpu,omitempty"`
-	// Memory (bytes).
-	TotalMemory int64 `protobuf:"varint,4,opt,name=total_memory,json=totalMemory,proto3" json:"total_memory,omitempty"`
-	UsedMemory  int64 `protobuf:"varint,5,opt,name=used_memory,json=usedMemory,proto3" json:"used_memory,omitempty"`
-	HeapAlloc   int64 `protobuf:"varint,6,opt,name=heap_alloc,json=heapAlloc,proto3" json:"heap_alloc,omitempty"`
-	// Go runtime.
-	GoVersion    string `protobuf:"bytes,7,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
-	NumGoroutine int32  `protobuf:"varint,8,opt,name=num_goroutine,json=numGoroutine,proto3" json:"num_goroutine,omitempty"`
-	// Process.
-	Pid       int32                  `protobuf:"varint,9,opt,name=pid,proto3" json:"pid,omitempty"`
-	StartedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	Uptime    *durationpb.Duration   `protobuf:"bytes,11,opt,name=uptime,proto3" json:"uptime,omitempty"`
-	// Disk usage.
-	DiskTotal     int64 `protobuf:"varint,12,opt,name=disk_total,json=diskTotal,proto3" json:"disk_total,omitempty"`
-	DiskUsed      int64 `protobuf:"varint,13,opt,name=disk_used,json=diskUsed,proto3" json:"disk_used,omitempty"`
-	DiskFree      int64 `protobuf:"varint,14,opt,name=disk_free,json=diskFree,proto3" json:"disk_free,omitempty"`
+	// Whether the upgrade may proceed.
+	Accepted bool `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Version the daemon is running.
+	CurrentVersion string `protobuf:"bytes,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
+	// Version requested.
+	TargetVersion string `protobuf:"bytes,3,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	// Backup taken before the upgrade (empty if none).
+	BackupId      string `protobuf:"bytes,4,opt,name=backup_id,json=backupId,proto3" json:"backup_id,omitempty"`
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemInfoResponse) Reset() {
-	*x = GetSystemInfoResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[33]
+func (x *UpgradeResponse) Reset() {
+	*x = UpgradeResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemInfoResponse) String() string {
+func (x *UpgradeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemInfoResponse) ProtoMessage() {}
+func (*UpgradeResponse) ProtoMessage() {}
 
-func (x *GetSystemInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[33]
+func (x *UpgradeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2335,135 +4397,197 @@ func (x *GetSystemInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemInfoResponse.ProtoReflect.Descriptor instead.
-func (*GetSystemInfoResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use UpgradeResponse.ProtoReflect.Descriptor instead.
+func (*UpgradeResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *GetSystemInfoResponse) GetOs() string {
+func (x *UpgradeResponse) GetAccepted() bool {
 	if x != nil {
-		return x.Os
+		return x.Accepted
 	}
-	return ""
+	return false
 }
 
-func (x *GetSystemInfoResponse) GetArch() string {
+func (x *UpgradeResponse) GetCurrentVersion() string {
 	if x != nil {
-		return x.Arch
+		return x.CurrentVersion
 	}
 	return ""
 }
 
-func (x *GetSystemInfoResponse) GetNumCpu() int32 {
+func (x *UpgradeResponse) GetTargetVersion() string {
 	if x != nil {
-		return x.NumCpu
+		return x.TargetVersion
 	}
-	return 0
+	return ""
 }
 
-func (x *GetSystemInfoResponse) GetTotalMemory() int64 {
+func (x *UpgradeResponse) GetBackupId() string {
 	if x != nil {
-		return x.TotalMemory
+		return x.BackupId
 	}
-	return 0
+	return ""
 }
 
-func (x *GetSystemInfoResponse) GetUsedMemory() int64 {
+func (x *UpgradeResponse) GetMessage() string {
 	if x != nil {
-		return x.UsedMemory
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *GetSystemInfoResponse) GetHeapAlloc() int64 {
+// SelfTestRequest requests an end-to-end self test.
+type SelfTestRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peer to ping (default: first connected peer).
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// Timeout for each step (default: 10s).
+	Timeout       *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelfTestRequest) Reset() {
+	*x = SelfTestRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestRequest) ProtoMessage() {}
+
+func (x *SelfTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[64]
 	if x != nil {
-		return x.HeapAlloc
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *GetSystemInfoResponse) GetGoVersion() string {
+// Deprecated: Use SelfTestRequest.ProtoReflect.Descriptor instead.
+func (*SelfTestRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *SelfTestRequest) GetPeerId() string {
 	if x != nil {
-		return x.GoVersion
+		return x.PeerId
 	}
 	return ""
 }
 
-func (x *GetSystemInfoResponse) GetNumGoroutine() int32 {
+func (x *SelfTestRequest) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.NumGoroutine
+		return x.Timeout
 	}
-	return 0
+	return nil
 }
 
-func (x *GetSystemInfoResponse) GetPid() int32 {
-	if x != nil {
-		return x.Pid
-	}
-	return 0
+// SelfTestStep is the result of a single self-test step.
+type SelfTestStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status        SelfTestStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=bib.v1.services.SelfTestStatus" json:"status,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemInfoResponse) GetStartedAt() *timestamppb.Timestamp {
+func (x *SelfTestStep) Reset() {
+	*x = SelfTestStep{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelfTestStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelfTestStep) ProtoMessage() {}
+
+func (x *SelfTestStep) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[65]
 	if x != nil {
-		return x.StartedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetSystemInfoResponse) GetUptime() *durationpb.Duration {
+// Deprecated: Use SelfTestStep.ProtoReflect.Descriptor instead.
+func (*SelfTestStep) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SelfTestStep) GetName() string {
 	if x != nil {
-		return x.Uptime
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *GetSystemInfoResponse) GetDiskTotal() int64 {
+func (x *SelfTestStep) GetStatus() SelfTestStatus {
 	if x != nil {
-		return x.DiskTotal
+		return x.Status
 	}
-	return 0
+	return SelfTestStatus_SELF_TEST_STATUS_UNSPECIFIED
 }
 
-func (x *GetSystemInfoResponse) GetDiskUsed() int64 {
+func (x *SelfTestStep) GetDuration() *durationpb.Duration {
 	if x != nil {
-		return x.DiskUsed
+		return x.Duration
 	}
-	return 0
+	return nil
 }
 
-func (x *GetSystemInfoResponse) GetDiskFree() int64 {
+func (x *SelfTestStep) GetMessage() string {
 	if x != nil {
-		return x.DiskFree
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-// RunMaintenanceRequest runs maintenance.
-type RunMaintenanceRequest struct {
+// SelfTestResponse contains the self-test results.
+type SelfTestResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Tasks to run.
-	Tasks []string `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	// Force run even if recently run.
-	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// True if no step failed.
+	Passed        bool                 `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	Steps         []*SelfTestStep      `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	Duration      *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	NodeId        string               `protobuf:"bytes,4,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunMaintenanceRequest) Reset() {
-	*x = RunMaintenanceRequest{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[34]
+func (x *SelfTestResponse) Reset() {
+	*x = SelfTestResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunMaintenanceRequest) String() string {
+func (x *SelfTestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunMaintenanceRequest) ProtoMessage() {}
+func (*SelfTestResponse) ProtoMessage() {}
 
-func (x *RunMaintenanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[34]
+func (x *SelfTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2474,48 +4598,65 @@ func (x *RunMaintenanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunMaintenanceRequest.ProtoReflect.Descriptor instead.
-func (*RunMaintenanceRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use SelfTestResponse.ProtoReflect.Descriptor instead.
+func (*SelfTestResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *RunMaintenanceRequest) GetTasks() []string {
+func (x *SelfTestResponse) GetPassed() bool {
 	if x != nil {
-		return x.Tasks
+		return x.Passed
+	}
+	return false
+}
+
+func (x *SelfTestResponse) GetSteps() []*SelfTestStep {
+	if x != nil {
+		return x.Steps
 	}
 	return nil
 }
 
-func (x *RunMaintenanceRequest) GetForce() bool {
+func (x *SelfTestResponse) GetDuration() *durationpb.Duration {
 	if x != nil {
-		return x.Force
+		return x.Duration
 	}
-	return false
+	return nil
 }
 
-// RunMaintenanceResponse contains maintenance result.
-type RunMaintenanceResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*MaintenanceResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+func (x *SelfTestResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+// CaptureProfileRequest requests a pprof profile.
+type CaptureProfileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  ProfileType            `protobuf:"varint,1,opt,name=type,proto3,enum=bib.v1.services.ProfileType" json:"type,omitempty"`
+	// How long to sample CPU, block and mutex profiles (default: 30s).
+	// Ignored for snapshots of the heap, allocations and goroutines.
+	Duration      *durationpb.Duration `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunMaintenanceResponse) Reset() {
-	*x = RunMaintenanceResponse{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[35]
+func (x *CaptureProfileRequest) Reset() {
+	*x = CaptureProfileRequest{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunMaintenanceResponse) String() string {
+func (x *CaptureProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunMaintenanceResponse) ProtoMessage() {}
+func (*CaptureProfileRequest) ProtoMessage() {}
 
-func (x *RunMaintenanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[35]
+func (x *CaptureProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2526,44 +4667,54 @@ func (x *RunMaintenanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunMaintenanceResponse.ProtoReflect.Descriptor instead.
-func (*RunMaintenanceResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use CaptureProfileRequest.ProtoReflect.Descriptor instead.
+func (*CaptureProfileRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *RunMaintenanceResponse) GetResults() []*MaintenanceResult {
+func (x *CaptureProfileRequest) GetType() ProfileType {
 	if x != nil {
-		return x.Results
+		return x.Type
+	}
+	return ProfileType_PROFILE_TYPE_UNSPECIFIED
+}
+
+func (x *CaptureProfileRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
 	}
 	return nil
 }
 
-// MaintenanceResult is a maintenance task result.
-type MaintenanceResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          string                 `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Duration      *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+// CaptureProfileResponse contains the captured profile.
+type CaptureProfileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  ProfileType            `protobuf:"varint,1,opt,name=type,proto3,enum=bib.v1.services.ProfileType" json:"type,omitempty"`
+	// Profile in the gzipped protobuf format read by 'go tool pprof'.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// How long the profile was sampled (zero for snapshots).
+	Duration      *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	CapturedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	NodeId        string                 `protobuf:"bytes,5,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MaintenanceResult) Reset() {
-	*x = MaintenanceResult{}
-	mi := &file_bib_v1_services_admin_proto_msgTypes[36]
+func (x *CaptureProfileResponse) Reset() {
+	*x = CaptureProfileResponse{}
+	mi := &file_bib_v1_services_admin_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MaintenanceResult) String() string {
+func (x *CaptureProfileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MaintenanceResult) ProtoMessage() {}
+func (*CaptureProfileResponse) ProtoMessage() {}
 
-func (x *MaintenanceResult) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_admin_proto_msgTypes[36]
+func (x *CaptureProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_admin_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2574,39 +4725,46 @@ func (x *MaintenanceResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MaintenanceResult.ProtoReflect.Descriptor instead.
-func (*MaintenanceResult) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use CaptureProfileResponse.ProtoReflect.Descriptor instead.
+func (*CaptureProfileResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_admin_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *MaintenanceResult) GetTask() string {
+func (x *CaptureProfileResponse) GetType() ProfileType {
 	if x != nil {
-		return x.Task
+		return x.Type
 	}
-	return ""
+	return ProfileType_PROFILE_TYPE_UNSPECIFIED
 }
 
-func (x *MaintenanceResult) GetSuccess() bool {
+func (x *CaptureProfileResponse) GetData() []byte {
 	if x != nil {
-		return x.Success
+		return x.Data
 	}
-	return false
+	return nil
 }
 
-func (x *MaintenanceResult) GetMessage() string {
+func (x *CaptureProfileResponse) GetDuration() *durationpb.Duration {
 	if x != nil {
-		return x.Message
+		return x.Duration
 	}
-	return ""
+	return nil
 }
 
-func (x *MaintenanceResult) GetDuration() *durationpb.Duration {
+func (x *CaptureProfileResponse) GetCapturedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Duration
+		return x.CapturedAt
 	}
 	return nil
 }
 
+func (x *CaptureProfileResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
 var File_bib_v1_services_admin_proto protoreflect.FileDescriptor
 
 const file_bib_v1_services_admin_proto_rawDesc = "" +
@@ -2648,13 +4806,14 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaf\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd2\x01\n" +
 	"\x11StreamLogsRequest\x12\x14\n" +
 	"\x05level\x18\x01 \x01(\tR\x05level\x12\x1c\n" +
 	"\tcomponent\x18\x02 \x01(\tR\tcomponent\x12\x18\n" +
 	"\apattern\x18\x03 \x01(\tR\apattern\x12'\n" +
 	"\x0finclude_history\x18\x04 \x01(\bR\x0eincludeHistory\x12#\n" +
-	"\rhistory_count\x18\x05 \x01(\x05R\fhistoryCount\"\xcc\x02\n" +
+	"\rhistory_count\x18\x05 \x01(\x05R\fhistoryCount\x12!\n" +
+	"\fresume_token\x18\x06 \x01(\tR\vresumeToken\"\xef\x02\n" +
 	"\bLogEntry\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
 	"\x05level\x18\x02 \x01(\tR\x05level\x12\x1c\n" +
@@ -2664,7 +4823,8 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\n" +
 	"request_id\x18\x06 \x01(\tR\trequestId\x12\x1f\n" +
 	"\vstack_trace\x18\a \x01(\tR\n" +
-	"stackTrace\x1a9\n" +
+	"stackTrace\x12!\n" +
+	"\fresume_token\x18\b \x01(\tR\vresumeToken\x1a9\n" +
 	"\vFieldsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa7\x02\n" +
@@ -2680,6 +4840,23 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\x04page\x18\a \x01(\v2\x13.bib.v1.PageRequestR\x04page\"\x7f\n" +
 	"\x14GetAuditLogsResponse\x128\n" +
 	"\aentries\x18\x01 \x03(\v2\x1e.bib.v1.services.AuditLogEntryR\aentries\x12-\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\xdc\x02\n" +
+	"\x11QueryAuditRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x18\n" +
+	"\aoutcome\x18\x03 \x01(\tR\aoutcome\x12#\n" +
+	"\rresource_type\x18\x04 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x05 \x01(\tR\n" +
+	"resourceId\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1e\n" +
+	"\n" +
+	"expression\x18\b \x01(\tR\n" +
+	"expression\x12'\n" +
+	"\x04page\x18\t \x01(\v2\x13.bib.v1.PageRequestR\x04page\"}\n" +
+	"\x12QueryAuditResponse\x128\n" +
+	"\aentries\x18\x01 \x03(\v2\x1e.bib.v1.services.AuditLogEntryR\aentries\x12-\n" +
 	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\x98\x04\n" +
 	"\rAuditLogEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x128\n" +
@@ -2701,7 +4878,24 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\anode_id\x18\x0e \x01(\tR\x06nodeId\x1a:\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1c\n" +
+	"\x1aListRateLimitBlocksRequest\"V\n" +
+	"\x1bListRateLimitBlocksResponse\x127\n" +
+	"\x06blocks\x18\x01 \x03(\v2\x1f.bib.v1.services.RateLimitBlockR\x06blocks\"\xf2\x01\n" +
+	"\x0eRateLimitBlock\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x12\n" +
+	"\x04rule\x18\x04 \x01(\tR\x04rule\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"blocked_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tblockedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"+\n" +
+	"\x17UnblockRateLimitRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"Q\n" +
+	"\x18UnblockRateLimitResponse\x125\n" +
+	"\x05block\x18\x01 \x01(\v2\x1f.bib.v1.services.RateLimitBlockR\x05block\"\x85\x01\n" +
 	"\x14TriggerBackupRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
 	"\rinclude_blobs\x18\x02 \x01(\bR\fincludeBlobs\x12\x1a\n" +
@@ -2741,11 +4935,27 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\x15RestoreBackupResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
-	"\x10restart_required\x18\x03 \x01(\bR\x0frestartRequired\"2\n" +
+	"\x10restart_required\x18\x03 \x01(\bR\x0frestartRequired\"z\n" +
 	"\x13DeleteBackupRequest\x12\x1b\n" +
-	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\"0\n" +
+	"\tbackup_id\x18\x01 \x01(\tR\bbackupId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12-\n" +
+	"\x12confirmation_token\x18\x03 \x01(\tR\x11confirmationToken\"h\n" +
 	"\x14DeleteBackupResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"B\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x126\n" +
+	"\adry_run\x18\x02 \x01(\v2\x1d.bib.v1.services.DryRunReportR\x06dryRun\"\x8d\x02\n" +
+	"\fDryRunReport\x12?\n" +
+	"\tresources\x18\x01 \x03(\v2!.bib.v1.services.AffectedResourceR\tresources\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x03R\n" +
+	"totalBytes\x12\x18\n" +
+	"\asummary\x18\x03 \x01(\tR\asummary\x12-\n" +
+	"\x12confirmation_token\x18\x04 \x01(\tR\x11confirmationToken\x12R\n" +
+	"\x17confirmation_expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x15confirmationExpiresAt\"m\n" +
+	"\x10AffectedResource\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"B\n" +
 	"\x17GetClusterStatusRequest\x12'\n" +
 	"\x0finclude_members\x18\x01 \x01(\bR\x0eincludeMembers\"\xd5\x02\n" +
 	"\x18GetClusterStatusResponse\x12\x18\n" +
@@ -2771,15 +4981,53 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\x05index\x18\x03 \x01(\x04R\x05index\x129\n" +
 	"\n" +
 	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
-	"\x04size\x18\x05 \x01(\x03R\x04size\"\x18\n" +
-	"\x16TriggerSnapshotRequest\"T\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\"1\n" +
+	"\x16TriggerSnapshotRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"\xc3\x01\n" +
 	"\x17TriggerSnapshotResponse\x129\n" +
-	"\bsnapshot\x18\x01 \x01(\v2\x1d.bib.v1.services.SnapshotInfoR\bsnapshot\"8\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x1d.bib.v1.services.SnapshotInfoR\bsnapshot\x125\n" +
+	"\x06pruned\x18\x02 \x03(\v2\x1d.bib.v1.services.SnapshotInfoR\x06pruned\x126\n" +
+	"\adry_run\x18\x03 \x01(\v2\x1d.bib.v1.services.DryRunReportR\x06dryRun\"8\n" +
 	"\x19TransferLeadershipRequest\x12\x1b\n" +
 	"\ttarget_id\x18\x01 \x01(\tR\btargetId\"Z\n" +
 	"\x1aTransferLeadershipResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\"\n" +
-	"\rnew_leader_id\x18\x02 \x01(\tR\vnewLeaderId\"t\n" +
+	"\rnew_leader_id\x18\x02 \x01(\tR\vnewLeaderId\"}\n" +
+	"\x1aRemoveClusterMemberRequest\x12\x17\n" +
+	"\anode_id\x18\x01 \x01(\tR\x06nodeId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12-\n" +
+	"\x12confirmation_token\x18\x03 \x01(\tR\x11confirmationToken\"\xb8\x01\n" +
+	"\x1bRemoveClusterMemberResponse\x126\n" +
+	"\x06member\x18\x01 \x01(\v2\x1e.bib.v1.services.ClusterMemberR\x06member\x12)\n" +
+	"\x10remaining_voters\x18\x02 \x01(\x05R\x0fremainingVoters\x126\n" +
+	"\adry_run\x18\x03 \x01(\v2\x1d.bib.v1.services.DryRunReportR\x06dryRun\"\xc7\x01\n" +
+	"\vClusterLock\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06holder\x18\x02 \x01(\tR\x06holder\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12;\n" +
+	"\vacquired_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acquiredAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"m\n" +
+	"\x12AcquireLockRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12\x16\n" +
+	"\x06holder\x18\x03 \x01(\tR\x06holder\"G\n" +
+	"\x13AcquireLockResponse\x120\n" +
+	"\x04lock\x18\x01 \x01(\v2\x1c.bib.v1.services.ClusterLockR\x04lock\"i\n" +
+	"\x10RenewLockRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"E\n" +
+	"\x11RenewLockResponse\x120\n" +
+	"\x04lock\x18\x01 \x01(\v2\x1c.bib.v1.services.ClusterLockR\x04lock\">\n" +
+	"\x12ReleaseLockRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x15\n" +
+	"\x13ReleaseLockResponse\"\x12\n" +
+	"\x10ListLocksRequest\"G\n" +
+	"\x11ListLocksResponse\x122\n" +
+	"\x05locks\x18\x01 \x03(\v2\x1c.bib.v1.services.ClusterLockR\x05locks\"t\n" +
 	"\x0fShutdownRequest\x123\n" +
 	"\atimeout\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12\x14\n" +
 	"\x05force\x18\x02 \x01(\bR\x05force\x12\x16\n" +
@@ -2787,7 +5035,7 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\x10ShutdownResponse\x12\x1a\n" +
 	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\"\x16\n" +
-	"\x14GetSystemInfoRequest\"\xd4\x03\n" +
+	"\x14GetSystemInfoRequest\"\x99\x04\n" +
 	"\x15GetSystemInfoResponse\x12\x0e\n" +
 	"\x02os\x18\x01 \x01(\tR\x02os\x12\x12\n" +
 	"\x04arch\x18\x02 \x01(\tR\x04arch\x12\x17\n" +
@@ -2808,7 +5056,8 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\n" +
 	"disk_total\x18\f \x01(\x03R\tdiskTotal\x12\x1b\n" +
 	"\tdisk_used\x18\r \x01(\x03R\bdiskUsed\x12\x1b\n" +
-	"\tdisk_free\x18\x0e \x01(\x03R\bdiskFree\"C\n" +
+	"\tdisk_free\x18\x0e \x01(\x03R\bdiskFree\x12C\n" +
+	"\vmaintenance\x18\x0f \x01(\v2!.bib.v1.services.MaintenanceStateR\vmaintenance\"C\n" +
 	"\x15RunMaintenanceRequest\x12\x14\n" +
 	"\x05tasks\x18\x01 \x03(\tR\x05tasks\x12\x14\n" +
 	"\x05force\x18\x02 \x01(\bR\x05force\"V\n" +
@@ -2818,7 +5067,71 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"\x04task\x18\x01 \x01(\tR\x04task\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x125\n" +
-	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration2\x8b\v\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\"\xcc\x01\n" +
+	"\x10MaintenanceState\x12\x16\n" +
+	"\x06paused\x18\x01 \x01(\bR\x06paused\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tpaused_by\x18\x03 \x01(\tR\bpausedBy\x127\n" +
+	"\tpaused_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bpausedAt\x12\x18\n" +
+	"\aworkers\x18\x05 \x03(\tR\aworkers\x12\x18\n" +
+	"\awaiting\x18\x06 \x03(\tR\awaiting\"1\n" +
+	"\x17PauseMaintenanceRequest\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"m\n" +
+	"\x18PauseMaintenanceResponse\x127\n" +
+	"\x05state\x18\x01 \x01(\v2!.bib.v1.services.MaintenanceStateR\x05state\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\"\x1a\n" +
+	"\x18ResumeMaintenanceRequest\"n\n" +
+	"\x19ResumeMaintenanceResponse\x127\n" +
+	"\x05state\x18\x01 \x01(\v2!.bib.v1.services.MaintenanceStateR\x05state\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\"\x8d\x01\n" +
+	"\x0eUpgradeRequest\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1f\n" +
+	"\vskip_backup\x18\x02 \x01(\bR\n" +
+	"skipBackup\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12'\n" +
+	"\x0fallow_downgrade\x18\x04 \x01(\bR\x0eallowDowngrade\"\xb4\x01\n" +
+	"\x0fUpgradeResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12'\n" +
+	"\x0fcurrent_version\x18\x02 \x01(\tR\x0ecurrentVersion\x12%\n" +
+	"\x0etarget_version\x18\x03 \x01(\tR\rtargetVersion\x12\x1b\n" +
+	"\tbackup_id\x18\x04 \x01(\tR\bbackupId\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"_\n" +
+	"\x0fSelfTestRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xac\x01\n" +
+	"\fSelfTestStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1f.bib.v1.services.SelfTestStatusR\x06status\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"\xaf\x01\n" +
+	"\x10SelfTestResponse\x12\x16\n" +
+	"\x06passed\x18\x01 \x01(\bR\x06passed\x123\n" +
+	"\x05steps\x18\x02 \x03(\v2\x1d.bib.v1.services.SelfTestStepR\x05steps\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x17\n" +
+	"\anode_id\x18\x04 \x01(\tR\x06nodeId\"\x80\x01\n" +
+	"\x15CaptureProfileRequest\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1c.bib.v1.services.ProfileTypeR\x04type\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\"\xeb\x01\n" +
+	"\x16CaptureProfileResponse\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1c.bib.v1.services.ProfileTypeR\x04type\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12;\n" +
+	"\vcaptured_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"capturedAt\x12\x17\n" +
+	"\anode_id\x18\x05 \x01(\tR\x06nodeId*\x8a\x01\n" +
+	"\x0eSelfTestStatus\x12 \n" +
+	"\x1cSELF_TEST_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17SELF_TEST_STATUS_PASSED\x10\x01\x12\x1b\n" +
+	"\x17SELF_TEST_STATUS_FAILED\x10\x02\x12\x1c\n" +
+	"\x18SELF_TEST_STATUS_SKIPPED\x10\x03*\xbd\x01\n" +
+	"\vProfileType\x12\x1c\n" +
+	"\x18PROFILE_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10PROFILE_TYPE_CPU\x10\x01\x12\x15\n" +
+	"\x11PROFILE_TYPE_HEAP\x10\x02\x12\x17\n" +
+	"\x13PROFILE_TYPE_ALLOCS\x10\x03\x12\x1a\n" +
+	"\x16PROFILE_TYPE_GOROUTINE\x10\x04\x12\x16\n" +
+	"\x12PROFILE_TYPE_BLOCK\x10\x05\x12\x16\n" +
+	"\x12PROFILE_TYPE_MUTEX\x10\x062\xbd\x15\n" +
 	"\fAdminService\x12R\n" +
 	"\tGetConfig\x12!.bib.v1.services.GetConfigRequest\x1a\".bib.v1.services.GetConfigResponse\x12[\n" +
 	"\fUpdateConfig\x12$.bib.v1.services.UpdateConfigRequest\x1a%.bib.v1.services.UpdateConfigResponse\x12U\n" +
@@ -2826,17 +5139,32 @@ const file_bib_v1_services_admin_proto_rawDesc = "" +
 	"GetMetrics\x12\".bib.v1.services.GetMetricsRequest\x1a#.bib.v1.services.GetMetricsResponse\x12M\n" +
 	"\n" +
 	"StreamLogs\x12\".bib.v1.services.StreamLogsRequest\x1a\x19.bib.v1.services.LogEntry0\x01\x12[\n" +
-	"\fGetAuditLogs\x12$.bib.v1.services.GetAuditLogsRequest\x1a%.bib.v1.services.GetAuditLogsResponse\x12^\n" +
+	"\fGetAuditLogs\x12$.bib.v1.services.GetAuditLogsRequest\x1a%.bib.v1.services.GetAuditLogsResponse\x12Y\n" +
+	"\x0fStreamAuditLogs\x12$.bib.v1.services.GetAuditLogsRequest\x1a\x1e.bib.v1.services.AuditLogEntry0\x01\x12U\n" +
+	"\n" +
+	"QueryAudit\x12\".bib.v1.services.QueryAuditRequest\x1a#.bib.v1.services.QueryAuditResponse\x12p\n" +
+	"\x13ListRateLimitBlocks\x12+.bib.v1.services.ListRateLimitBlocksRequest\x1a,.bib.v1.services.ListRateLimitBlocksResponse\x12g\n" +
+	"\x10UnblockRateLimit\x12(.bib.v1.services.UnblockRateLimitRequest\x1a).bib.v1.services.UnblockRateLimitResponse\x12^\n" +
 	"\rTriggerBackup\x12%.bib.v1.services.TriggerBackupRequest\x1a&.bib.v1.services.TriggerBackupResponse\x12X\n" +
 	"\vListBackups\x12#.bib.v1.services.ListBackupsRequest\x1a$.bib.v1.services.ListBackupsResponse\x12^\n" +
 	"\rRestoreBackup\x12%.bib.v1.services.RestoreBackupRequest\x1a&.bib.v1.services.RestoreBackupResponse\x12[\n" +
 	"\fDeleteBackup\x12$.bib.v1.services.DeleteBackupRequest\x1a%.bib.v1.services.DeleteBackupResponse\x12g\n" +
 	"\x10GetClusterStatus\x12(.bib.v1.services.GetClusterStatusRequest\x1a).bib.v1.services.GetClusterStatusResponse\x12d\n" +
 	"\x0fTriggerSnapshot\x12'.bib.v1.services.TriggerSnapshotRequest\x1a(.bib.v1.services.TriggerSnapshotResponse\x12m\n" +
-	"\x12TransferLeadership\x12*.bib.v1.services.TransferLeadershipRequest\x1a+.bib.v1.services.TransferLeadershipResponse\x12O\n" +
+	"\x12TransferLeadership\x12*.bib.v1.services.TransferLeadershipRequest\x1a+.bib.v1.services.TransferLeadershipResponse\x12p\n" +
+	"\x13RemoveClusterMember\x12+.bib.v1.services.RemoveClusterMemberRequest\x1a,.bib.v1.services.RemoveClusterMemberResponse\x12X\n" +
+	"\vAcquireLock\x12#.bib.v1.services.AcquireLockRequest\x1a$.bib.v1.services.AcquireLockResponse\x12R\n" +
+	"\tRenewLock\x12!.bib.v1.services.RenewLockRequest\x1a\".bib.v1.services.RenewLockResponse\x12X\n" +
+	"\vReleaseLock\x12#.bib.v1.services.ReleaseLockRequest\x1a$.bib.v1.services.ReleaseLockResponse\x12R\n" +
+	"\tListLocks\x12!.bib.v1.services.ListLocksRequest\x1a\".bib.v1.services.ListLocksResponse\x12O\n" +
 	"\bShutdown\x12 .bib.v1.services.ShutdownRequest\x1a!.bib.v1.services.ShutdownResponse\x12^\n" +
 	"\rGetSystemInfo\x12%.bib.v1.services.GetSystemInfoRequest\x1a&.bib.v1.services.GetSystemInfoResponse\x12a\n" +
-	"\x0eRunMaintenance\x12&.bib.v1.services.RunMaintenanceRequest\x1a'.bib.v1.services.RunMaintenanceResponseB\x9f\x01\n" +
+	"\x0eRunMaintenance\x12&.bib.v1.services.RunMaintenanceRequest\x1a'.bib.v1.services.RunMaintenanceResponse\x12g\n" +
+	"\x10PauseMaintenance\x12(.bib.v1.services.PauseMaintenanceRequest\x1a).bib.v1.services.PauseMaintenanceResponse\x12j\n" +
+	"\x11ResumeMaintenance\x12).bib.v1.services.ResumeMaintenanceRequest\x1a*.bib.v1.services.ResumeMaintenanceResponse\x12L\n" +
+	"\aUpgrade\x12\x1f.bib.v1.services.UpgradeRequest\x1a .bib.v1.services.UpgradeResponse\x12O\n" +
+	"\bSelfTest\x12 .bib.v1.services.SelfTestRequest\x1a!.bib.v1.services.SelfTestResponse\x12a\n" +
+	"\x0eCaptureProfile\x12&.bib.v1.services.CaptureProfileRequest\x1a'.bib.v1.services.CaptureProfileResponseB\x9f\x01\n" +
 	"\x13com.bib.v1.servicesB\n" +
 	"AdminProtoP\x01Z\x1ebib/api/gen/go/bib/v1/services\xa2\x02\x03BVS\xaa\x02\x0fBib.V1.Services\xca\x02\x0fBib\\V1\\Services\xe2\x02\x1bBib\\V1\\Services\\GPBMetadata\xea\x02\x11Bib::V1::Servicesb\x06proto3"
 
@@ -2852,122 +5180,222 @@ func file_bib_v1_services_admin_proto_rawDescGZIP() []byte {
 	return file_bib_v1_services_admin_proto_rawDescData
 }
 
-var file_bib_v1_services_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_bib_v1_services_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_bib_v1_services_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 72)
 var file_bib_v1_services_admin_proto_goTypes = []any{
-	(*GetConfigRequest)(nil),           // 0: bib.v1.services.GetConfigRequest
-	(*GetConfigResponse)(nil),          // 1: bib.v1.services.GetConfigResponse
-	(*UpdateConfigRequest)(nil),        // 2: bib.v1.services.UpdateConfigRequest
-	(*UpdateConfigResponse)(nil),       // 3: bib.v1.services.UpdateConfigResponse
-	(*GetMetricsRequest)(nil),          // 4: bib.v1.services.GetMetricsRequest
-	(*GetMetricsResponse)(nil),         // 5: bib.v1.services.GetMetricsResponse
-	(*Metric)(nil),                     // 6: bib.v1.services.Metric
-	(*MetricValue)(nil),                // 7: bib.v1.services.MetricValue
-	(*StreamLogsRequest)(nil),          // 8: bib.v1.services.StreamLogsRequest
-	(*LogEntry)(nil),                   // 9: bib.v1.services.LogEntry
-	(*GetAuditLogsRequest)(nil),        // 10: bib.v1.services.GetAuditLogsRequest
-	(*GetAuditLogsResponse)(nil),       // 11: bib.v1.services.GetAuditLogsResponse
-	(*AuditLogEntry)(nil),              // 12: bib.v1.services.AuditLogEntry
-	(*TriggerBackupRequest)(nil),       // 13: bib.v1.services.TriggerBackupRequest
-	(*TriggerBackupResponse)(nil),      // 14: bib.v1.services.TriggerBackupResponse
-	(*BackupInfo)(nil),                 // 15: bib.v1.services.BackupInfo
-	(*ListBackupsRequest)(nil),         // 16: bib.v1.services.ListBackupsRequest
-	(*ListBackupsResponse)(nil),        // 17: bib.v1.services.ListBackupsResponse
-	(*RestoreBackupRequest)(nil),       // 18: bib.v1.services.RestoreBackupRequest
-	(*RestoreBackupResponse)(nil),      // 19: bib.v1.services.RestoreBackupResponse
-	(*DeleteBackupRequest)(nil),        // 20: bib.v1.services.DeleteBackupRequest
-	(*DeleteBackupResponse)(nil),       // 21: bib.v1.services.DeleteBackupResponse
-	(*GetClusterStatusRequest)(nil),    // 22: bib.v1.services.GetClusterStatusRequest
-	(*GetClusterStatusResponse)(nil),   // 23: bib.v1.services.GetClusterStatusResponse
-	(*ClusterMember)(nil),              // 24: bib.v1.services.ClusterMember
-	(*SnapshotInfo)(nil),               // 25: bib.v1.services.SnapshotInfo
-	(*TriggerSnapshotRequest)(nil),     // 26: bib.v1.services.TriggerSnapshotRequest
-	(*TriggerSnapshotResponse)(nil),    // 27: bib.v1.services.TriggerSnapshotResponse
-	(*TransferLeadershipRequest)(nil),  // 28: bib.v1.services.TransferLeadershipRequest
-	(*TransferLeadershipResponse)(nil), // 29: bib.v1.services.TransferLeadershipResponse
-	(*ShutdownRequest)(nil),            // 30: bib.v1.services.ShutdownRequest
-	(*ShutdownResponse)(nil),           // 31: bib.v1.services.ShutdownResponse
-	(*GetSystemInfoRequest)(nil),       // 32: bib.v1.services.GetSystemInfoRequest
-	(*GetSystemInfoResponse)(nil),      // 33: bib.v1.services.GetSystemInfoResponse
-	(*RunMaintenanceRequest)(nil),      // 34: bib.v1.services.RunMaintenanceRequest
-	(*RunMaintenanceResponse)(nil),     // 35: bib.v1.services.RunMaintenanceResponse
-	(*MaintenanceResult)(nil),          // 36: bib.v1.services.MaintenanceResult
-	nil,                                // 37: bib.v1.services.MetricValue.LabelsEntry
-	nil,                                // 38: bib.v1.services.LogEntry.FieldsEntry
-	nil,                                // 39: bib.v1.services.AuditLogEntry.DetailsEntry
-	(*structpb.Struct)(nil),            // 40: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil),      // 41: google.protobuf.Timestamp
-	(*v1.PageRequest)(nil),             // 42: bib.v1.PageRequest
-	(*v1.PageInfo)(nil),                // 43: bib.v1.PageInfo
-	(*durationpb.Duration)(nil),        // 44: google.protobuf.Duration
+	(SelfTestStatus)(0),                 // 0: bib.v1.services.SelfTestStatus
+	(ProfileType)(0),                    // 1: bib.v1.services.ProfileType
+	(*GetConfigRequest)(nil),            // 2: bib.v1.services.GetConfigRequest
+	(*GetConfigResponse)(nil),           // 3: bib.v1.services.GetConfigResponse
+	(*UpdateConfigRequest)(nil),         // 4: bib.v1.services.UpdateConfigRequest
+	(*UpdateConfigResponse)(nil),        // 5: bib.v1.services.UpdateConfigResponse
+	(*GetMetricsRequest)(nil),           // 6: bib.v1.services.GetMetricsRequest
+	(*GetMetricsResponse)(nil),          // 7: bib.v1.services.GetMetricsResponse
+	(*Metric)(nil),                      // 8: bib.v1.services.Metric
+	(*MetricValue)(nil),                 // 9: bib.v1.services.MetricValue
+	(*StreamLogsRequest)(nil),           // 10: bib.v1.services.StreamLogsRequest
+	(*LogEntry)(nil),                    // 11: bib.v1.services.LogEntry
+	(*GetAuditLogsRequest)(nil),         // 12: bib.v1.services.GetAuditLogsRequest
+	(*GetAuditLogsResponse)(nil),        // 13: bib.v1.services.GetAuditLogsResponse
+	(*QueryAuditRequest)(nil),           // 14: bib.v1.services.QueryAuditRequest
+	(*QueryAuditResponse)(nil),          // 15: bib.v1.services.QueryAuditResponse
+	(*AuditLogEntry)(nil),               // 16: bib.v1.services.AuditLogEntry
+	(*ListRateLimitBlocksRequest)(nil),  // 17: bib.v1.services.ListRateLimitBlocksRequest
+	(*ListRateLimitBlocksResponse)(nil), // 18: bib.v1.services.ListRateLimitBlocksResponse
+	(*RateLimitBlock)(nil),              // 19: bib.v1.services.RateLimitBlock
+	(*UnblockRateLimitRequest)(nil),     // 20: bib.v1.services.UnblockRateLimitRequest
+	(*UnblockRateLimitResponse)(nil),    // 21: bib.v1.services.UnblockRateLimitResponse
+	(*TriggerBackupRequest)(nil),        // 22: bib.v1.services.TriggerBackupRequest
+	(*TriggerBackupResponse)(nil),       // 23: bib.v1.services.TriggerBackupResponse
+	(*BackupInfo)(nil),                  // 24: bib.v1.services.BackupInfo
+	(*ListBackupsRequest)(nil),          // 25: bib.v1.services.ListBackupsRequest
+	(*ListBackupsResponse)(nil),         // 26: bib.v1.services.ListBackupsResponse
+	(*RestoreBackupRequest)(nil),        // 27: bib.v1.services.RestoreBackupRequest
+	(*RestoreBackupResponse)(nil),       // 28: bib.v1.services.RestoreBackupResponse
+	(*DeleteBackupRequest)(nil),         // 29: bib.v1.services.DeleteBackupRequest
+	(*DeleteBackupResponse)(nil),        // 30: bib.v1.services.DeleteBackupResponse
+	(*DryRunReport)(nil),                // 31: bib.v1.services.DryRunReport
+	(*AffectedResource)(nil),            // 32: bib.v1.services.AffectedResource
+	(*GetClusterStatusRequest)(nil),     // 33: bib.v1.services.GetClusterStatusRequest
+	(*GetClusterStatusResponse)(nil),    // 34: bib.v1.services.GetClusterStatusResponse
+	(*ClusterMember)(nil),               // 35: bib.v1.services.ClusterMember
+	(*SnapshotInfo)(nil),                // 36: bib.v1.services.SnapshotInfo
+	(*TriggerSnapshotRequest)(nil),      // 37: bib.v1.services.TriggerSnapshotRequest
+	(*TriggerSnapshotResponse)(nil),     // 38: bib.v1.services.TriggerSnapshotResponse
+	(*TransferLeadershipRequest)(nil),   // 39: bib.v1.services.TransferLeadershipRequest
+	(*TransferLeadershipResponse)(nil),  // 40: bib.v1.services.TransferLeadershipResponse
+	(*RemoveClusterMemberRequest)(nil),  // 41: bib.v1.services.RemoveClusterMemberRequest
+	(*RemoveClusterMemberResponse)(nil), // 42: bib.v1.services.RemoveClusterMemberResponse
+	(*ClusterLock)(nil),                 // 43: bib.v1.services.ClusterLock
+	(*AcquireLockRequest)(nil),          // 44: bib.v1.services.AcquireLockRequest
+	(*AcquireLockResponse)(nil),         // 45: bib.v1.services.AcquireLockResponse
+	(*RenewLockRequest)(nil),            // 46: bib.v1.services.RenewLockRequest
+	(*RenewLockResponse)(nil),           // 47: bib.v1.services.RenewLockResponse
+	(*ReleaseLockRequest)(nil),          // 48: bib.v1.services.ReleaseLockRequest
+	(*ReleaseLockResponse)(nil),         // 49: bib.v1.services.ReleaseLockResponse
+	(*ListLocksRequest)(nil),            // 50: bib.v1.services.ListLocksRequest
+	(*ListLocksResponse)(nil),           // 51: bib.v1.services.ListLocksResponse
+	(*ShutdownRequest)(nil),             // 52: bib.v1.services.ShutdownRequest
+	(*ShutdownResponse)(nil),            // 53: bib.v1.services.ShutdownResponse
+	(*GetSystemInfoRequest)(nil),        // 54: bib.v1.services.GetSystemInfoRequest
+	(*GetSystemInfoResponse)(nil),       // 55: bib.v1.services.GetSystemInfoResponse
+	(*RunMaintenanceRequest)(nil),       // 56: bib.v1.services.RunMaintenanceRequest
+	(*RunMaintenanceResponse)(nil),      // 57: bib.v1.services.RunMaintenanceResponse
+	(*MaintenanceResult)(nil),           // 58: bib.v1.services.MaintenanceResult
+	(*MaintenanceState)(nil),            // 59: bib.v1.services.MaintenanceState
+	(*PauseMaintenanceRequest)(nil),     // 60: bib.v1.services.PauseMaintenanceRequest
+	(*PauseMaintenanceResponse)(nil),    // 61: bib.v1.services.PauseMaintenanceResponse
+	(*ResumeMaintenanceRequest)(nil),    // 62: bib.v1.services.ResumeMaintenanceRequest
+	(*ResumeMaintenanceResponse)(nil),   // 63: bib.v1.services.ResumeMaintenanceResponse
+	(*UpgradeRequest)(nil),              // 64: bib.v1.services.UpgradeRequest
+	(*UpgradeResponse)(nil),             // 65: bib.v1.services.UpgradeResponse
+	(*SelfTestRequest)(nil),             // 66: bib.v1.services.SelfTestRequest
+	(*SelfTestStep)(nil),                // 67: bib.v1.services.SelfTestStep
+	(*SelfTestResponse)(nil),            // 68: bib.v1.services.SelfTestResponse
+	(*CaptureProfileRequest)(nil),       // 69: bib.v1.services.CaptureProfileRequest
+	(*CaptureProfileResponse)(nil),      // 70: bib.v1.services.CaptureProfileResponse
+	nil,                                 // 71: bib.v1.services.MetricValue.LabelsEntry
+	nil,                                 // 72: bib.v1.services.LogEntry.FieldsEntry
+	nil,                                 // 73: bib.v1.services.AuditLogEntry.DetailsEntry
+	(*structpb.Struct)(nil),             // 74: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),       // 75: google.protobuf.Timestamp
+	(*v1.PageRequest)(nil),              // 76: bib.v1.PageRequest
+	(*v1.PageInfo)(nil),                 // 77: bib.v1.PageInfo
+	(*durationpb.Duration)(nil),         // 78: google.protobuf.Duration
 }
 var file_bib_v1_services_admin_proto_depIdxs = []int32{
-	40, // 0: bib.v1.services.GetConfigResponse.config:type_name -> google.protobuf.Struct
-	41, // 1: bib.v1.services.GetConfigResponse.last_modified:type_name -> google.protobuf.Timestamp
-	40, // 2: bib.v1.services.GetConfigResponse.effective_config:type_name -> google.protobuf.Struct
-	40, // 3: bib.v1.services.UpdateConfigRequest.updates:type_name -> google.protobuf.Struct
-	6,  // 4: bib.v1.services.GetMetricsResponse.structured_metrics:type_name -> bib.v1.services.Metric
-	7,  // 5: bib.v1.services.Metric.values:type_name -> bib.v1.services.MetricValue
-	37, // 6: bib.v1.services.MetricValue.labels:type_name -> bib.v1.services.MetricValue.LabelsEntry
-	41, // 7: bib.v1.services.MetricValue.timestamp:type_name -> google.protobuf.Timestamp
-	41, // 8: bib.v1.services.LogEntry.timestamp:type_name -> google.protobuf.Timestamp
-	38, // 9: bib.v1.services.LogEntry.fields:type_name -> bib.v1.services.LogEntry.FieldsEntry
-	41, // 10: bib.v1.services.GetAuditLogsRequest.start_time:type_name -> google.protobuf.Timestamp
-	41, // 11: bib.v1.services.GetAuditLogsRequest.end_time:type_name -> google.protobuf.Timestamp
-	42, // 12: bib.v1.services.GetAuditLogsRequest.page:type_name -> bib.v1.PageRequest
-	12, // 13: bib.v1.services.GetAuditLogsResponse.entries:type_name -> bib.v1.services.AuditLogEntry
-	43, // 14: bib.v1.services.GetAuditLogsResponse.page_info:type_name -> bib.v1.PageInfo
-	41, // 15: bib.v1.services.AuditLogEntry.timestamp:type_name -> google.protobuf.Timestamp
-	39, // 16: bib.v1.services.AuditLogEntry.details:type_name -> bib.v1.services.AuditLogEntry.DetailsEntry
-	15, // 17: bib.v1.services.TriggerBackupResponse.backup:type_name -> bib.v1.services.BackupInfo
-	41, // 18: bib.v1.services.BackupInfo.created_at:type_name -> google.protobuf.Timestamp
-	42, // 19: bib.v1.services.ListBackupsRequest.page:type_name -> bib.v1.PageRequest
-	15, // 20: bib.v1.services.ListBackupsResponse.backups:type_name -> bib.v1.services.BackupInfo
-	43, // 21: bib.v1.services.ListBackupsResponse.page_info:type_name -> bib.v1.PageInfo
-	24, // 22: bib.v1.services.GetClusterStatusResponse.members:type_name -> bib.v1.services.ClusterMember
-	25, // 23: bib.v1.services.GetClusterStatusResponse.last_snapshot:type_name -> bib.v1.services.SnapshotInfo
-	41, // 24: bib.v1.services.ClusterMember.last_contact:type_name -> google.protobuf.Timestamp
-	41, // 25: bib.v1.services.SnapshotInfo.created_at:type_name -> google.protobuf.Timestamp
-	25, // 26: bib.v1.services.TriggerSnapshotResponse.snapshot:type_name -> bib.v1.services.SnapshotInfo
-	44, // 27: bib.v1.services.ShutdownRequest.timeout:type_name -> google.protobuf.Duration
-	41, // 28: bib.v1.services.GetSystemInfoResponse.started_at:type_name -> google.protobuf.Timestamp
-	44, // 29: bib.v1.services.GetSystemInfoResponse.uptime:type_name -> google.protobuf.Duration
-	36, // 30: bib.v1.services.RunMaintenanceResponse.results:type_name -> bib.v1.services.MaintenanceResult
-	44, // 31: bib.v1.services.MaintenanceResult.duration:type_name -> google.protobuf.Duration
-	0,  // 32: bib.v1.services.AdminService.GetConfig:input_type -> bib.v1.services.GetConfigRequest
-	2,  // 33: bib.v1.services.AdminService.UpdateConfig:input_type -> bib.v1.services.UpdateConfigRequest
-	4,  // 34: bib.v1.services.AdminService.GetMetrics:input_type -> bib.v1.services.GetMetricsRequest
-	8,  // 35: bib.v1.services.AdminService.StreamLogs:input_type -> bib.v1.services.StreamLogsRequest
-	10, // 36: bib.v1.services.AdminService.GetAuditLogs:input_type -> bib.v1.services.GetAuditLogsRequest
-	13, // 37: bib.v1.services.AdminService.TriggerBackup:input_type -> bib.v1.services.TriggerBackupRequest
-	16, // 38: bib.v1.services.AdminService.ListBackups:input_type -> bib.v1.services.ListBackupsRequest
-	18, // 39: bib.v1.services.AdminService.RestoreBackup:input_type -> bib.v1.services.RestoreBackupRequest
-	20, // 40: bib.v1.services.AdminService.DeleteBackup:input_type -> bib.v1.services.DeleteBackupRequest
-	22, // 41: bib.v1.services.AdminService.GetClusterStatus:input_type -> bib.v1.services.GetClusterStatusRequest
-	26, // 42: bib.v1.services.AdminService.TriggerSnapshot:input_type -> bib.v1.services.TriggerSnapshotRequest
-	28, // 43: bib.v1.services.AdminService.TransferLeadership:input_type -> bib.v1.services.TransferLeadershipRequest
-	30, // 44: bib.v1.services.AdminService.Shutdown:input_type -> bib.v1.services.ShutdownRequest
-	32, // 45: bib.v1.services.AdminService.GetSystemInfo:input_type -> bib.v1.services.GetSystemInfoRequest
-	34, // 46: bib.v1.services.AdminService.RunMaintenance:input_type -> bib.v1.services.RunMaintenanceRequest
-	1,  // 47: bib.v1.services.AdminService.GetConfig:output_type -> bib.v1.services.GetConfigResponse
-	3,  // 48: bib.v1.services.AdminService.UpdateConfig:output_type -> bib.v1.services.UpdateConfigResponse
-	5,  // 49: bib.v1.services.AdminService.GetMetrics:output_type -> bib.v1.services.GetMetricsResponse
-	9,  // 50: bib.v1.services.AdminService.StreamLogs:output_type -> bib.v1.services.LogEntry
-	11, // 51: bib.v1.services.AdminService.GetAuditLogs:output_type -> bib.v1.services.GetAuditLogsResponse
-	14, // 52: bib.v1.services.AdminService.TriggerBackup:output_type -> bib.v1.services.TriggerBackupResponse
-	17, // 53: bib.v1.services.AdminService.ListBackups:output_type -> bib.v1.services.ListBackupsResponse
-	19, // 54: bib.v1.services.AdminService.RestoreBackup:output_type -> bib.v1.services.RestoreBackupResponse
-	21, // 55: bib.v1.services.AdminService.DeleteBackup:output_type -> bib.v1.services.DeleteBackupResponse
-	23, // 56: bib.v1.services.AdminService.GetClusterStatus:output_type -> bib.v1.services.GetClusterStatusResponse
-	27, // 57: bib.v1.services.AdminService.TriggerSnapshot:output_type -> bib.v1.services.TriggerSnapshotResponse
-	29, // 58: bib.v1.services.AdminService.TransferLeadership:output_type -> bib.v1.services.TransferLeadershipResponse
-	31, // 59: bib.v1.services.AdminService.Shutdown:output_type -> bib.v1.services.ShutdownResponse
-	33, // 60: bib.v1.services.AdminService.GetSystemInfo:output_type -> bib.v1.services.GetSystemInfoResponse
-	35, // 61: bib.v1.services.AdminService.RunMaintenance:output_type -> bib.v1.services.RunMaintenanceResponse
-	47, // [47:62] is the sub-list for method output_type
-	32, // [32:47] is the sub-list for method input_type
-	32, // [32:32] is the sub-list for extension type_name
-	32, // [32:32] is the sub-list for extension extendee
-	0,  // [0:32] is the sub-list for field type_name
+	74, // 0: bib.v1.services.GetConfigResponse.config:type_name -> google.protobuf.Struct
+	75, // 1: bib.v1.services.GetConfigResponse.last_modified:type_name -> google.protobuf.Timestamp
+	74, // 2: bib.v1.services.GetConfigResponse.effective_config:type_name -> google.protobuf.Struct
+	74, // 3: bib.v1.services.UpdateConfigRequest.updates:type_name -> google.protobuf.Struct
+	8,  // 4: bib.v1.services.GetMetricsResponse.structured_metrics:type_name -> bib.v1.services.Metric
+	9,  // 5: bib.v1.services.Metric.values:type_name -> bib.v1.services.MetricValue
+	71, // 6: bib.v1.services.MetricValue.labels:type_name -> bib.v1.services.MetricValue.LabelsEntry
+	75, // 7: bib.v1.services.MetricValue.timestamp:type_name -> google.protobuf.Timestamp
+	75, // 8: bib.v1.services.LogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	72, // 9: bib.v1.services.LogEntry.fields:type_name -> bib.v1.services.LogEntry.FieldsEntry
+	75, // 10: bib.v1.services.GetAuditLogsRequest.start_time:type_name -> google.protobuf.Timestamp
+	75, // 11: bib.v1.services.GetAuditLogsRequest.end_time:type_name -> google.protobuf.Timestamp
+	76, // 12: bib.v1.services.GetAuditLogsRequest.page:type_name -> bib.v1.PageRequest
+	16, // 13: bib.v1.services.GetAuditLogsResponse.entries:type_name -> bib.v1.services.AuditLogEntry
+	77, // 14: bib.v1.services.GetAuditLogsResponse.page_info:type_name -> bib.v1.PageInfo
+	75, // 15: bib.v1.services.QueryAuditRequest.start_time:type_name -> google.protobuf.Timestamp
+	75, // 16: bib.v1.services.QueryAuditRequest.end_time:type_name -> google.protobuf.Timestamp
+	76, // 17: bib.v1.services.QueryAuditRequest.page:type_name -> bib.v1.PageRequest
+	16, // 18: bib.v1.services.QueryAuditResponse.entries:type_name -> bib.v1.services.AuditLogEntry
+	77, // 19: bib.v1.services.QueryAuditResponse.page_info:type_name -> bib.v1.PageInfo
+	75, // 20: bib.v1.services.AuditLogEntry.timestamp:type_name -> google.protobuf.Timestamp
+	73, // 21: bib.v1.services.AuditLogEntry.details:type_name -> bib.v1.services.AuditLogEntry.DetailsEntry
+	19, // 22: bib.v1.services.ListRateLimitBlocksResponse.blocks:type_name -> bib.v1.services.RateLimitBlock
+	75, // 23: bib.v1.services.RateLimitBlock.blocked_at:type_name -> google.protobuf.Timestamp
+	75, // 24: bib.v1.services.RateLimitBlock.expires_at:type_name -> google.protobuf.Timestamp
+	19, // 25: bib.v1.services.UnblockRateLimitResponse.block:type_name -> bib.v1.services.RateLimitBlock
+	24, // 26: bib.v1.services.TriggerBackupResponse.backup:type_name -> bib.v1.services.BackupInfo
+	75, // 27: bib.v1.services.BackupInfo.created_at:type_name -> google.protobuf.Timestamp
+	76, // 28: bib.v1.services.ListBackupsRequest.page:type_name -> bib.v1.PageRequest
+	24, // 29: bib.v1.services.ListBackupsResponse.backups:type_name -> bib.v1.services.BackupInfo
+	77, // 30: bib.v1.services.ListBackupsResponse.page_info:type_name -> bib.v1.PageInfo
+	31, // 31: bib.v1.services.DeleteBackupResponse.dry_run:type_name -> bib.v1.services.DryRunReport
+	32, // 32: bib.v1.services.DryRunReport.resources:type_name -> bib.v1.services.AffectedResource
+	75, // 33: bib.v1.services.DryRunReport.confirmation_expires_at:type_name -> google.protobuf.Timestamp
+	35, // 34: bib.v1.services.GetClusterStatusResponse.members:type_name -> bib.v1.services.ClusterMember
+	36, // 35: bib.v1.services.GetClusterStatusResponse.last_snapshot:type_name -> bib.v1.services.SnapshotInfo
+	75, // 36: bib.v1.services.ClusterMember.last_contact:type_name -> google.protobuf.Timestamp
+	75, // 37: bib.v1.services.SnapshotInfo.created_at:type_name -> google.protobuf.Timestamp
+	36, // 38: bib.v1.services.TriggerSnapshotResponse.snapshot:type_name -> bib.v1.services.SnapshotInfo
+	36, // 39: bib.v1.services.TriggerSnapshotResponse.pruned:type_name -> bib.v1.services.SnapshotInfo
+	31, // 40: bib.v1.services.TriggerSnapshotResponse.dry_run:type_name -> bib.v1.services.DryRunReport
+	35, // 41: bib.v1.services.RemoveClusterMemberResponse.member:type_name -> bib.v1.services.ClusterMember
+	31, // 42: bib.v1.services.RemoveClusterMemberResponse.dry_run:type_name -> bib.v1.services.DryRunReport
+	75, // 43: bib.v1.services.ClusterLock.acquired_at:type_name -> google.protobuf.Timestamp
+	75, // 44: bib.v1.services.ClusterLock.expires_at:type_name -> google.protobuf.Timestamp
+	78, // 45: bib.v1.services.AcquireLockRequest.ttl:type_name -> google.protobuf.Duration
+	43, // 46: bib.v1.services.AcquireLockResponse.lock:type_name -> bib.v1.services.ClusterLock
+	78, // 47: bib.v1.services.RenewLockRequest.ttl:type_name -> google.protobuf.Duration
+	43, // 48: bib.v1.services.RenewLockResponse.lock:type_name -> bib.v1.services.ClusterLock
+	43, // 49: bib.v1.services.ListLocksResponse.locks:type_name -> bib.v1.services.ClusterLock
+	78, // 50: bib.v1.services.ShutdownRequest.timeout:type_name -> google.protobuf.Duration
+	75, // 51: bib.v1.services.GetSystemInfoResponse.started_at:type_name -> google.protobuf.Timestamp
+	78, // 52: bib.v1.services.GetSystemInfoResponse.uptime:type_name -> google.protobuf.Duration
+	59, // 53: bib.v1.services.GetSystemInfoResponse.maintenance:type_name -> bib.v1.services.MaintenanceState
+	58, // 54: bib.v1.services.RunMaintenanceResponse.results:type_name -> bib.v1.services.MaintenanceResult
+	78, // 55: bib.v1.services.MaintenanceResult.duration:type_name -> google.protobuf.Duration
+	75, // 56: bib.v1.services.MaintenanceState.paused_at:type_name -> google.protobuf.Timestamp
+	59, // 57: bib.v1.services.PauseMaintenanceResponse.state:type_name -> bib.v1.services.MaintenanceState
+	59, // 58: bib.v1.services.ResumeMaintenanceResponse.state:type_name -> bib.v1.services.MaintenanceState
+	78, // 59: bib.v1.services.SelfTestRequest.timeout:type_name -> google.protobuf.Duration
+	0,  // 60: bib.v1.services.SelfTestStep.status:type_name -> bib.v1.services.SelfTestStatus
+	78, // 61: bib.v1.services.SelfTestStep.duration:type_name -> google.protobuf.Duration
+	67, // 62: bib.v1.services.SelfTestResponse.steps:type_name -> bib.v1.services.SelfTestStep
+	78, // 63: bib.v1.services.SelfTestResponse.duration:type_name -> google.protobuf.Duration
+	1,  // 64: bib.v1.services.CaptureProfileRequest.type:type_name -> bib.v1.services.ProfileType
+	78, // 65: bib.v1.services.CaptureProfileRequest.duration:type_name -> google.protobuf.Duration
+	1,  // 66: bib.v1.services.CaptureProfileResponse.type:type_name -> bib.v1.services.ProfileType
+	78, // 67: bib.v1.services.CaptureProfileResponse.duration:type_name -> google.protobuf.Duration
+	75, // 68: bib.v1.services.CaptureProfileResponse.captured_at:type_name -> google.protobuf.Timestamp
+	2,  // 69: bib.v1.services.AdminService.GetConfig:input_type -> bib.v1.services.GetConfigRequest
+	4,  // 70: bib.v1.services.AdminService.UpdateConfig:input_type -> bib.v1.services.UpdateConfigRequest
+	6,  // 71: bib.v1.services.AdminService.GetMetrics:input_type -> bib.v1.services.GetMetricsRequest
+	10, // 72: bib.v1.services.AdminService.StreamLogs:input_type -> bib.v1.services.StreamLogsRequest
+	12, // 73: bib.v1.services.AdminService.GetAuditLogs:input_type -> bib.v1.services.GetAuditLogsRequest
+	12, // 74: bib.v1.services.AdminService.StreamAuditLogs:input_type -> bib.v1.services.GetAuditLogsRequest
+	14, // 75: bib.v1.services.AdminService.QueryAudit:input_type -> bib.v1.services.QueryAuditRequest
+	17, // 76: bib.v1.services.AdminService.ListRateLimitBlocks:input_type -> bib.v1.services.ListRateLimitBlocksRequest
+	20, // 77: bib.v1.services.AdminService.UnblockRateLimit:input_type -> bib.v1.services.UnblockRateLimitRequest
+	22, // 78: bib.v1.services.AdminService.TriggerBackup:input_type -> bib.v1.services.TriggerBackupRequest
+	25, // 79: bib.v1.services.AdminService.ListBackups:input_type -> bib.v1.services.ListBackupsRequest
+	27, // 80: bib.v1.services.AdminService.RestoreBackup:input_type -> bib.v1.services.RestoreBackupRequest
+	29, // 81: bib.v1.services.AdminService.DeleteBackup:input_type -> bib.v1.services.DeleteBackupRequest
+	33, // 82: bib.v1.services.AdminService.GetClusterStatus:input_type -> bib.v1.services.GetClusterStatusRequest
+	37, // 83: bib.v1.services.AdminService.TriggerSnapshot:input_type -> bib.v1.services.TriggerSnapshotRequest
+	39, // 84: bib.v1.services.AdminService.TransferLeadership:input_type -> bib.v1.services.TransferLeadershipRequest
+	41, // 85: bib.v1.services.AdminService.RemoveClusterMember:input_type -> bib.v1.services.RemoveClusterMemberRequest
+	44, // 86: bib.v1.services.AdminService.AcquireLock:input_type -> bib.v1.services.AcquireLockRequest
+	46, // 87: bib.v1.services.AdminService.RenewLock:input_type -> bib.v1.services.RenewLockRequest
+	48, // 88: bib.v1.services.AdminService.ReleaseLock:input_type -> bib.v1.services.ReleaseLockRequest
+	50, // 89: bib.v1.services.AdminService.ListLocks:input_type -> bib.v1.services.ListLocksRequest
+	52, // 90: bib.v1.services.AdminService.Shutdown:input_type -> bib.v1.services.ShutdownRequest
+	54, // 91: bib.v1.services.AdminService.GetSystemInfo:input_type -> bib.v1.services.GetSystemInfoRequest
+	56, // 92: bib.v1.services.AdminService.RunMaintenance:input_type -> bib.v1.services.RunMaintenanceRequest
+	60, // 93: bib.v1.services.AdminService.PauseMaintenance:input_type -> bib.v1.services.PauseMaintenanceRequest
+	62, // 94: bib.v1.services.AdminService.ResumeMaintenance:input_type -> bib.v1.services.ResumeMaintenanceRequest
+	64, // 95: bib.v1.services.AdminService.Upgrade:input_type -> bib.v1.services.UpgradeRequest
+	66, // 96: bib.v1.services.AdminService.SelfTest:input_type -> bib.v1.services.SelfTestRequest
+	69, // 97: bib.v1.services.AdminService.CaptureProfile:input_type -> bib.v1.services.CaptureProfileRequest
+	3,  // 98: bib.v1.services.AdminService.GetConfig:output_type -> bib.v1.services.GetConfigResponse
+	5,  // 99: bib.v1.services.AdminService.UpdateConfig:output_type -> bib.v1.services.UpdateConfigResponse
+	7,  // 100: bib.v1.services.AdminService.GetMetrics:output_type -> bib.v1.services.GetMetricsResponse
+	11, // 101: bib.v1.services.AdminService.StreamLogs:output_type -> bib.v1.services.LogEntry
+	13, // 102: bib.v1.services.AdminService.GetAuditLogs:output_type -> bib.v1.services.GetAuditLogsResponse
+	16, // 103: bib.v1.services.AdminService.StreamAuditLogs:output_type -> bib.v1.services.AuditLogEntry
+	15, // 104: bib.v1.services.AdminService.QueryAudit:output_type -> bib.v1.services.QueryAuditResponse
+	18, // 105: bib.v1.services.AdminService.ListRateLimitBlocks:output_type -> bib.v1.services.ListRateLimitBlocksResponse
+	21, // 106: bib.v1.services.AdminService.UnblockRateLimit:output_type -> bib.v1.services.UnblockRateLimitResponse
+	23, // 107: bib.v1.services.AdminService.TriggerBackup:output_type -> bib.v1.services.TriggerBackupResponse
+	26, // 108: bib.v1.services.AdminService.ListBackups:output_type -> bib.v1.services.ListBackupsResponse
+	28, // 109: bib.v1.services.AdminService.RestoreBackup:output_type -> bib.v1.services.RestoreBackupResponse
+	30, // 110: bib.v1.services.AdminService.DeleteBackup:output_type -> bib.v1.services.DeleteBackupResponse
+	34, // 111: bib.v1.services.AdminService.GetClusterStatus:output_type -> bib.v1.services.GetClusterStatusResponse
+	38, // 112: bib.v1.services.AdminService.TriggerSnapshot:output_type -> bib.v1.services.TriggerSnapshotResponse
+	40, // 113: bib.v1.services.AdminService.TransferLeadership:output_type -> bib.v1.services.TransferLeadershipResponse
+	42, // 114: bib.v1.services.AdminService.RemoveClusterMember:output_type -> bib.v1.services.RemoveClusterMemberResponse
+	45, // 115: bib.v1.services.AdminService.AcquireLock:output_type -> bib.v1.services.AcquireLockResponse
+	47, // 116: bib.v1.services.AdminService.RenewLock:output_type -> bib.v1.services.RenewLockResponse
+	49, // 117: bib.v1.services.AdminService.ReleaseLock:output_type -> bib.v1.services.ReleaseLockResponse
+	51, // 118: bib.v1.services.AdminService.ListLocks:output_type -> bib.v1.services.ListLocksResponse
+	53, // 119: bib.v1.services.AdminService.Shutdown:output_type -> bib.v1.services.ShutdownResponse
+	55, // 120: bib.v1.services.AdminService.GetSystemInfo:output_type -> bib.v1.services.GetSystemInfoResponse
+	57, // 121: bib.v1.services.AdminService.RunMaintenance:output_type -> bib.v1.services.RunMaintenanceResponse
+	61, // 122: bib.v1.services.AdminService.PauseMaintenance:output_type -> bib.v1.services.PauseMaintenanceResponse
+	63, // 123: bib.v1.services.AdminService.ResumeMaintenance:output_type -> bib.v1.services.ResumeMaintenanceResponse
+	65, // 124: bib.v1.services.AdminService.Upgrade:output_type -> bib.v1.services.UpgradeResponse
+	68, // 125: bib.v1.services.AdminService.SelfTest:output_type -> bib.v1.services.SelfTestResponse
+	70, // 126: bib.v1.services.AdminService.CaptureProfile:output_type -> bib.v1.services.CaptureProfileResponse
+	98, // [98:127] is the sub-list for method output_type
+	69, // [69:98] is the sub-list for method input_type
+	69, // [69:69] is the sub-list for extension type_name
+	69, // [69:69] is the sub-list for extension extendee
+	0,  // [0:69] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_admin_proto_init() }
@@ -2980,13 +5408,14 @@ func file_bib_v1_services_admin_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_admin_proto_rawDesc), len(file_bib_v1_services_admin_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   40,
+			NumEnums:      2,
+			NumMessages:   72,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_bib_v1_services_admin_proto_goTypes,
 		DependencyIndexes: file_bib_v1_services_admin_proto_depIdxs,
+		EnumInfos:         file_bib_v1_services_admin_proto_enumTypes,
 		MessageInfos:      file_bib_v1_services_admin_proto_msgTypes,
 	}.Build()
 	File_bib_v1_services_admin_proto = out.File
@@ -19,21 +19,35 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AdminService_GetConfig_FullMethodName          = "/bib.v1.services.AdminService/GetConfig"
-	AdminService_UpdateConfig_FullMethodName       = "/bib.v1.services.AdminService/UpdateConfig"
-	AdminService_GetMetrics_FullMethodName         = "/bib.v1.services.AdminService/GetMetrics"
-	AdminService_StreamLogs_FullMethodName         = "/bib.v1.services.AdminService/StreamLogs"
-	AdminService_GetAuditLogs_FullMethodName       = "/bib.v1.services.AdminService/GetAuditLogs"
-	AdminService_TriggerBackup_FullMethodName      = "/bib.v1.services.AdminService/TriggerBackup"
-	AdminService_ListBackups_FullMethodName        = "/bib.v1.services.AdminService/ListBackups"
-	AdminService_RestoreBackup_FullMethodName      = "/bib.v1.services.AdminService/RestoreBackup"
-	AdminService_DeleteBackup_FullMethodName       = "/bib.v1.services.AdminService/DeleteBackup"
-	AdminService_GetClusterStatus_FullMethodName   = "/bib.v1.services.AdminService/GetClusterStatus"
-	AdminService_TriggerSnapshot_FullMethodName    = "/bib.v1.services.AdminService/TriggerSnapshot"
-	AdminService_TransferLeadership_FullMethodName = "/bib.v1.services.AdminService/TransferLeadership"
-	AdminService_Shutdown_FullMethodName           = "/bib.v1.services.AdminService/Shutdown"
-	AdminService_GetSystemInfo_FullMethodName      = "/bib.v1.services.AdminService/GetSystemInfo"
-	AdminService_RunMaintenance_FullMethodName     = "/bib.v1.services.AdminService/RunMaintenance"
+	AdminService_GetConfig_FullMethodName           = "/bib.v1.services.AdminService/GetConfig"
+	AdminService_UpdateConfig_FullMethodName        = "/bib.v1.services.AdminService/UpdateConfig"
+	AdminService_GetMetrics_FullMethodName          = "/bib.v1.services.AdminService/GetMetrics"
+	AdminService_StreamLogs_FullMethodName          = "/bib.v1.services.AdminService/StreamLogs"
+	AdminService_GetAuditLogs_FullMethodName        = "/bib.v1.services.AdminService/GetAuditLogs"
+	AdminService_StreamAuditLogs_FullMethodName     = "/bib.v1.services.AdminService/StreamAuditLogs"
+	AdminService_QueryAudit_FullMethodName          = "/bib.v1.services.AdminService/QueryAudit"
+	AdminService_ListRateLimitBlocks_FullMethodName = "/bib.v1.services.AdminService/ListRateLimitBlocks"
+	AdminService_UnblockRateLimit_FullMethodName    = "/bib.v1.services.AdminService/UnblockRateLimit"
+	AdminService_TriggerBackup_FullMethodName       = "/bib.v1.services.AdminService/TriggerBackup"
+	AdminService_ListBackups_FullMethodName         = "/bib.v1.services.AdminService/ListBackups"
+	AdminService_RestoreBackup_FullMethodName       = "/bib.v1.services.AdminService/RestoreBackup"
+	AdminService_DeleteBackup_FullMethodName        = "/bib.v1.services.AdminService/DeleteBackup"
+	AdminService_GetClusterStatus_FullMethodName    = "/bib.v1.services.AdminService/GetClusterStatus"
+	AdminService_TriggerSnapshot_FullMethodName     = "/bib.v1.services.AdminService/TriggerSnapshot"
+	AdminService_TransferLeadership_FullMethodName  = "/bib.v1.services.AdminService/TransferLeadership"
+	AdminService_RemoveClusterMember_FullMethodName = "/bib.v1.services.AdminService/RemoveClusterMember"
+	AdminService_AcquireLock_FullMethodName         = "/bib.v1.services.AdminService/AcquireLock"
+	AdminService_RenewLock_FullMethodName           = "/bib.v1.services.AdminService/RenewLock"
+	AdminService_ReleaseLock_FullMethodName         = "/bib.v1.services.AdminService/ReleaseLock"
+	AdminService_ListLocks_FullMethodName           = "/bib.v1.services.AdminService/ListLocks"
+	AdminService_Shutdown_FullMethodName            = "/bib.v1.services.AdminService/Shutdown"
+	AdminService_GetSystemInfo_FullMethodName       = "/bib.v1.services.AdminService/GetSystemInfo"
+	AdminService_RunMaintenance_FullMethodName      = "/bib.v1.services.AdminService/RunMaintenance"
+	AdminService_PauseMaintenance_FullMethodName    = "/bib.v1.services.AdminService/PauseMaintenance"
+	AdminService_ResumeMaintenance_FullMethodName   = "/bib.v1.services.AdminService/ResumeMaintenance"
+	AdminService_Upgrade_FullMethodName             = "/bib.v1.services.AdminService/Upgrade"
+	AdminService_SelfTest_FullMethodName            = "/bib.v1.services.AdminService/SelfTest"
+	AdminService_CaptureProfile_FullMethodName      = "/bib.v1.services.AdminService/CaptureProfile"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -53,13 +67,25 @@ type AdminServiceClient interface {
 	StreamLogs(ctx context.Context, in *StreamLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogEntry], error)
 	// GetAuditLogs queries the audit trail.
 	GetAuditLogs(ctx context.Context, in *GetAuditLogsRequest, opts ...grpc.CallOption) (*GetAuditLogsResponse, error)
+	// StreamAuditLogs streams audit log entries one by one, newest first.
+	// page.limit caps the number of entries sent (0 = all).
+	StreamAuditLogs(ctx context.Context, in *GetAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditLogEntry], error)
+	// QueryAudit searches the audit trail with filters, an optional CEL
+	// expression and cursor pagination. Sensitive fields are redacted.
+	QueryAudit(ctx context.Context, in *QueryAuditRequest, opts ...grpc.CallOption) (*QueryAuditResponse, error)
+	// ListRateLimitBlocks lists the users and roles blocked by audit alerts
+	// that trigger rate limiting.
+	ListRateLimitBlocks(ctx context.Context, in *ListRateLimitBlocksRequest, opts ...grpc.CallOption) (*ListRateLimitBlocksResponse, error)
+	// UnblockRateLimit lifts a block before it expires.
+	UnblockRateLimit(ctx context.Context, in *UnblockRateLimitRequest, opts ...grpc.CallOption) (*UnblockRateLimitResponse, error)
 	// TriggerBackup initiates a database backup.
 	TriggerBackup(ctx context.Context, in *TriggerBackupRequest, opts ...grpc.CallOption) (*TriggerBackupResponse, error)
 	// ListBackups lists available backups.
 	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
 	// RestoreBackup restores from a backup.
 	RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
-	// DeleteBackup deletes a backup.
+	// DeleteBackup deletes a backup. Requires the confirmation token of a
+	// dry run.
 	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error)
 	// GetClusterStatus returns cluster/raft status.
 	GetClusterStatus(ctx context.Context, in *GetClusterStatusRequest, opts ...grpc.CallOption) (*GetClusterStatusResponse, error)
@@ -67,12 +93,38 @@ type AdminServiceClient interface {
 	TriggerSnapshot(ctx context.Context, in *TriggerSnapshotRequest, opts ...grpc.CallOption) (*TriggerSnapshotResponse, error)
 	// TransferLeadership transfers Raft leadership.
 	TransferLeadership(ctx context.Context, in *TransferLeadershipRequest, opts ...grpc.CallOption) (*TransferLeadershipResponse, error)
+	// RemoveClusterMember removes a member from the cluster. Only the leader
+	// removes members, and never below the minimum number of voters.
+	// Requires the confirmation token of a dry run.
+	RemoveClusterMember(ctx context.Context, in *RemoveClusterMemberRequest, opts ...grpc.CallOption) (*RemoveClusterMemberResponse, error)
+	// AcquireLock acquires a lease on a cluster-wide lock. Only the leader
+	// grants locks. The lease expires after its TTL unless renewed.
+	AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockResponse, error)
+	// RenewLock extends a lease.
+	RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockResponse, error)
+	// ReleaseLock releases a lease.
+	ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*ReleaseLockResponse, error)
+	// ListLocks lists the cluster-wide locks currently held.
+	ListLocks(ctx context.Context, in *ListLocksRequest, opts ...grpc.CallOption) (*ListLocksResponse, error)
 	// Shutdown gracefully shuts down the daemon.
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
 	// GetSystemInfo returns system information.
 	GetSystemInfo(ctx context.Context, in *GetSystemInfoRequest, opts ...grpc.CallOption) (*GetSystemInfoResponse, error)
 	// RunMaintenance runs maintenance tasks.
 	RunMaintenance(ctx context.Context, in *RunMaintenanceRequest, opts ...grpc.CallOption) (*RunMaintenanceResponse, error)
+	// PauseMaintenance pauses the background maintenance workers (blob GC,
+	// vacuum, credential rotation and sync) until ResumeMaintenance.
+	PauseMaintenance(ctx context.Context, in *PauseMaintenanceRequest, opts ...grpc.CallOption) (*PauseMaintenanceResponse, error)
+	// ResumeMaintenance resumes the paused maintenance workers.
+	ResumeMaintenance(ctx context.Context, in *ResumeMaintenanceRequest, opts ...grpc.CallOption) (*ResumeMaintenanceResponse, error)
+	// Upgrade prepares the daemon for an upgrade to a new version.
+	// The image swap itself is driven by the client's deploy target.
+	Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error)
+	// SelfTest actively exercises the storage and P2P data paths.
+	SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestResponse, error)
+	// CaptureProfile captures a pprof profile of the daemon. Disabled unless
+	// profiling is enabled in the daemon's configuration.
+	CaptureProfile(ctx context.Context, in *CaptureProfileRequest, opts ...grpc.CallOption) (*CaptureProfileResponse, error)
 }
 
 type adminServiceClient struct {
@@ -142,6 +194,55 @@ func (c *adminServiceClient) GetAuditLogs(ctx context.Context, in *GetAuditLogsR
 	return out, nil
 }
 
+func (c *adminServiceClient) StreamAuditLogs(ctx context.Context, in *GetAuditLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditLogEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[1], AdminService_StreamAuditLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetAuditLogsRequest, AuditLogEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AdminService_StreamAuditLogsClient = grpc.ServerStreamingClient[AuditLogEntry]
+
+func (c *adminServiceClient) QueryAudit(ctx context.Context, in *QueryAuditRequest, opts ...grpc.CallOption) (*QueryAuditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditResponse)
+	err := c.cc.Invoke(ctx, AdminService_QueryAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListRateLimitBlocks(ctx context.Context, in *ListRateLimitBlocksRequest, opts ...grpc.CallOption) (*ListRateLimitBlocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRateLimitBlocksResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListRateLimitBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnblockRateLimit(ctx context.Context, in *UnblockRateLimitRequest, opts ...grpc.CallOption) (*UnblockRateLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockRateLimitResponse)
+	err := c.cc.Invoke(ctx, AdminService_UnblockRateLimit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) TriggerBackup(ctx context.Context, in *TriggerBackupRequest, opts ...grpc.CallOption) (*TriggerBackupResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(TriggerBackupResponse)
@@ -212,6 +313,56 @@ func (c *adminServiceClient) TransferLeadership(ctx context.Context, in *Transfe
 	return out, nil
 }
 
+func (c *adminServiceClient) RemoveClusterMember(ctx context.Context, in *RemoveClusterMemberRequest, opts ...grpc.CallOption) (*RemoveClusterMemberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveClusterMemberResponse)
+	err := c.cc.Invoke(ctx, AdminService_RemoveClusterMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcquireLockResponse)
+	err := c.cc.Invoke(ctx, AdminService_AcquireLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*RenewLockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenewLockResponse)
+	err := c.cc.Invoke(ctx, AdminService_RenewLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*ReleaseLockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseLockResponse)
+	err := c.cc.Invoke(ctx, AdminService_ReleaseLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListLocks(ctx context.Context, in *ListLocksRequest, opts ...grpc.CallOption) (*ListLocksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLocksResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListLocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ShutdownResponse)
@@ -242,6 +393,56 @@ func (c *adminServiceClient) RunMaintenance(ctx context.Context, in *RunMaintena
 	return out, nil
 }
 
+func (c *adminServiceClient) PauseMaintenance(ctx context.Context, in *PauseMaintenanceRequest, opts ...grpc.CallOption) (*PauseMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AdminService_PauseMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ResumeMaintenance(ctx context.Context, in *ResumeMaintenanceRequest, opts ...grpc.CallOption) (*ResumeMaintenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeMaintenanceResponse)
+	err := c.cc.Invoke(ctx, AdminService_ResumeMaintenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Upgrade(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpgradeResponse)
+	err := c.cc.Invoke(ctx, AdminService_Upgrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SelfTest(ctx context.Context, in *SelfTestRequest, opts ...grpc.CallOption) (*SelfTestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SelfTestResponse)
+	err := c.cc.Invoke(ctx, AdminService_SelfTest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CaptureProfile(ctx context.Context, in *CaptureProfileRequest, opts ...grpc.CallOption) (*CaptureProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureProfileResponse)
+	err := c.cc.Invoke(ctx, AdminService_CaptureProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations should embed UnimplementedAdminServiceServer
 // for forward compatibility.
@@ -259,13 +460,25 @@ type AdminServiceServer interface {
 	StreamLogs(*StreamLogsRequest, grpc.ServerStreamingServer[LogEntry]) error
 	// GetAuditLogs queries the audit trail.
 	GetAuditLogs(context.Context, *GetAuditLogsRequest) (*GetAuditLogsResponse, error)
+	// StreamAuditLogs streams audit log entries one by one, newest first.
+	// page.limit caps the number of entries sent (0 = all).
+	StreamAuditLogs(*GetAuditLogsRequest, grpc.ServerStreamingServer[AuditLogEntry]) error
+	// QueryAudit searches the audit trail with filters, an optional CEL
+	// expression and cursor pagination. Sensitive fields are redacted.
+	QueryAudit(context.Context, *QueryAuditRequest) (*QueryAuditResponse, error)
+	// ListRateLimitBlocks lists the users and roles blocked by audit alerts
+	// that trigger rate limiting.
+	ListRateLimitBlocks(context.Context, *ListRateLimitBlocksRequest) (*ListRateLimitBlocksResponse, error)
+	// UnblockRateLimit lifts a block before it expires.
+	UnblockRateLimit(context.Context, *UnblockRateLimitRequest) (*UnblockRateLimitResponse, error)
 	// TriggerBackup initiates a database backup.
 	TriggerBackup(context.Context, *TriggerBackupRequest) (*TriggerBackupResponse, error)
 	// ListBackups lists available backups.
 	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
 	// RestoreBackup restores from a backup.
 	RestoreBackup(context.Context, *RestoreBackupRequest) (*RestoreBackupResponse, error)
-	// DeleteBackup deletes a backup.
+	// DeleteBackup deletes a backup. Requires the confirmation token of a
+	// dry run.
 	DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error)
 	// GetClusterStatus returns cluster/raft status.
 	GetClusterStatus(context.Context, *GetClusterStatusRequest) (*GetClusterStatusResponse, error)
@@ -273,12 +486,38 @@ type AdminServiceServer interface {
 	TriggerSnapshot(context.Context, *TriggerSnapshotRequest) (*TriggerSnapshotResponse, error)
 	// TransferLeadership transfers Raft leadership.
 	TransferLeadership(context.Context, *TransferLeadershipRequest) (*TransferLeadershipResponse, error)
+	// RemoveClusterMember removes a member from the cluster. Only the leader
+	// removes members, and never below the minimum number of voters.
+	// Requires the confirmation token of a dry run.
+	RemoveClusterMember(context.Context, *RemoveClusterMemberRequest) (*RemoveClusterMemberResponse, error)
+	// AcquireLock acquires a lease on a cluster-wide lock. Only the leader
+	// grants locks. The lease expires after its TTL unless renewed.
+	AcquireLock(context.Context, *AcquireLockRequest) (*AcquireLockResponse, error)
+	// RenewLock extends a lease.
+	RenewLock(context.Context, *RenewLockRequest) (*RenewLockResponse, error)
+	// ReleaseLock releases a lease.
+	ReleaseLock(context.Context, *ReleaseLockRequest) (*ReleaseLockResponse, error)
+	// ListLocks lists the cluster-wide locks currently held.
+	ListLocks(context.Context, *ListLocksRequest) (*ListLocksResponse, error)
 	// Shutdown gracefully shuts down the daemon.
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	// GetSystemInfo returns system information.
 	GetSystemInfo(context.Context, *GetSystemInfoRequest) (*GetSystemInfoResponse, error)
 	// RunMaintenance runs maintenance tasks.
 	RunMaintenance(context.Context, *RunMaintenanceRequest) (*RunMaintenanceResponse, error)
+	// PauseMaintenance pauses the background maintenance workers (blob GC,
+	// vacuum, credential rotation and sync) until ResumeMaintenance.
+	PauseMaintenance(context.Context, *PauseMaintenanceRequest) (*PauseMaintenanceResponse, error)
+	// ResumeMaintenance resumes the paused maintenance workers.
+	ResumeMaintenance(context.Context, *ResumeMaintenanceRequest) (*ResumeMaintenanceResponse, error)
+	// Upgrade prepares the daemon for an upgrade to a new version.
+	// The image swap itself is driven by the client's deploy target.
+	Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error)
+	// SelfTest actively exercises the storage and P2P data paths.
+	SelfTest(context.Context, *SelfTestRequest) (*SelfTestResponse, error)
+	// CaptureProfile captures a pprof profile of the daemon. Disabled unless
+	// profiling is enabled in the daemon's configuration.
+	CaptureProfile(context.Context, *CaptureProfileRequest) (*CaptureProfileResponse, error)
 }
 
 // UnimplementedAdminServiceServer should be embedded to have
@@ -303,6 +542,18 @@ func (UnimplementedAdminServiceServer) StreamLogs(*StreamLogsRequest, grpc.Serve
 func (UnimplementedAdminServiceServer) GetAuditLogs(context.Context, *GetAuditLogsRequest) (*GetAuditLogsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAuditLogs not implemented")
 }
+func (UnimplementedAdminServiceServer) StreamAuditLogs(*GetAuditLogsRequest, grpc.ServerStreamingServer[AuditLogEntry]) error {
+	return status.Error(codes.Unimplemented, "method StreamAuditLogs not implemented")
+}
+func (UnimplementedAdminServiceServer) QueryAudit(context.Context, *QueryAuditRequest) (*QueryAuditResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method QueryAudit not implemented")
+}
+func (UnimplementedAdminServiceServer) ListRateLimitBlocks(context.Context, *ListRateLimitBlocksRequest) (*ListRateLimitBlocksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRateLimitBlocks not implemented")
+}
+func (UnimplementedAdminServiceServer) UnblockRateLimit(context.Context, *UnblockRateLimitRequest) (*UnblockRateLimitResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnblockRateLimit not implemented")
+}
 func (UnimplementedAdminServiceServer) TriggerBackup(context.Context, *TriggerBackupRequest) (*TriggerBackupResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TriggerBackup not implemented")
 }
@@ -324,6 +575,21 @@ func (UnimplementedAdminServiceServer) TriggerSnapshot(context.Context, *Trigger
 func (UnimplementedAdminServiceServer) TransferLeadership(context.Context, *TransferLeadershipRequest) (*TransferLeadershipResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TransferLeadership not implemented")
 }
+func (UnimplementedAdminServiceServer) RemoveClusterMember(context.Context, *RemoveClusterMemberRequest) (*RemoveClusterMemberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveClusterMember not implemented")
+}
+func (UnimplementedAdminServiceServer) AcquireLock(context.Context, *AcquireLockRequest) (*AcquireLockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcquireLock not implemented")
+}
+func (UnimplementedAdminServiceServer) RenewLock(context.Context, *RenewLockRequest) (*RenewLockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenewLock not implemented")
+}
+func (UnimplementedAdminServiceServer) ReleaseLock(context.Context, *ReleaseLockRequest) (*ReleaseLockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseLock not implemented")
+}
+func (UnimplementedAdminServiceServer) ListLocks(context.Context, *ListLocksRequest) (*ListLocksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLocks not implemented")
+}
 func (UnimplementedAdminServiceServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Shutdown not implemented")
 }
@@ -333,6 +599,21 @@ func (UnimplementedAdminServiceServer) GetSystemInfo(context.Context, *GetSystem
 func (UnimplementedAdminServiceServer) RunMaintenance(context.Context, *RunMaintenanceRequest) (*RunMaintenanceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RunMaintenance not implemented")
 }
+func (UnimplementedAdminServiceServer) PauseMaintenance(context.Context, *PauseMaintenanceRequest) (*PauseMaintenanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PauseMaintenance not implemented")
+}
+func (UnimplementedAdminServiceServer) ResumeMaintenance(context.Context, *ResumeMaintenanceRequest) (*ResumeMaintenanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResumeMaintenance not implemented")
+}
+func (UnimplementedAdminServiceServer) Upgrade(context.Context, *UpgradeRequest) (*UpgradeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Upgrade not implemented")
+}
+func (UnimplementedAdminServiceServer) SelfTest(context.Context, *SelfTestRequest) (*SelfTestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SelfTest not implemented")
+}
+func (UnimplementedAdminServiceServer) CaptureProfile(context.Context, *CaptureProfileRequest) (*CaptureProfileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CaptureProfile not implemented")
+}
 func (UnimplementedAdminServiceServer) testEmbeddedByValue() {}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -436,6 +717,71 @@ func _AdminService_GetAuditLogs_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_StreamAuditLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAuditLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).StreamAuditLogs(m, &grpc.GenericServerStream[GetAuditLogsRequest, AuditLogEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AdminService_StreamAuditLogsServer = grpc.ServerStreamingServer[AuditLogEntry]
+
+func _AdminService_QueryAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).QueryAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_QueryAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).QueryAudit(ctx, req.(*QueryAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListRateLimitBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRateLimitBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListRateLimitBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListRateLimitBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListRateLimitBlocks(ctx, req.(*ListRateLimitBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnblockRateLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockRateLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnblockRateLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UnblockRateLimit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnblockRateLimit(ctx, req.(*UnblockRateLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_TriggerBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TriggerBackupRequest)
 	if err := dec(in); err != nil {
@@ -562,6 +908,96 @@ func _AdminService_TransferLeadership_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_RemoveClusterMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveClusterMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RemoveClusterMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RemoveClusterMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RemoveClusterMember(ctx, req.(*RemoveClusterMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AcquireLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AcquireLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AcquireLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AcquireLock(ctx, req.(*AcquireLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_RenewLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).RenewLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_RenewLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).RenewLock(ctx, req.(*RenewLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ReleaseLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ReleaseLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ReleaseLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ReleaseLock(ctx, req.(*ReleaseLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListLocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListLocks(ctx, req.(*ListLocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShutdownRequest)
 	if err := dec(in); err != nil {
@@ -616,6 +1052,96 @@ func _AdminService_RunMaintenance_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_PauseMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).PauseMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_PauseMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).PauseMaintenance(ctx, req.(*PauseMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ResumeMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ResumeMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ResumeMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ResumeMaintenance(ctx, req.(*ResumeMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Upgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Upgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_Upgrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Upgrade(ctx, req.(*UpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SelfTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SelfTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SelfTest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SelfTest(ctx, req.(*SelfTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CaptureProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CaptureProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CaptureProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CaptureProfile(ctx, req.(*CaptureProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -639,6 +1165,18 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAuditLogs",
 			Handler:    _AdminService_GetAuditLogs_Handler,
 		},
+		{
+			MethodName: "QueryAudit",
+			Handler:    _AdminService_QueryAudit_Handler,
+		},
+		{
+			MethodName: "ListRateLimitBlocks",
+			Handler:    _AdminService_ListRateLimitBlocks_Handler,
+		},
+		{
+			MethodName: "UnblockRateLimit",
+			Handler:    _AdminService_UnblockRateLimit_Handler,
+		},
 		{
 			MethodName: "TriggerBackup",
 			Handler:    _AdminService_TriggerBackup_Handler,
@@ -667,6 +1205,26 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TransferLeadership",
 			Handler:    _AdminService_TransferLeadership_Handler,
 		},
+		{
+			MethodName: "RemoveClusterMember",
+			Handler:    _AdminService_RemoveClusterMember_Handler,
+		},
+		{
+			MethodName: "AcquireLock",
+			Handler:    _AdminService_AcquireLock_Handler,
+		},
+		{
+			MethodName: "RenewLock",
+			Handler:    _AdminService_RenewLock_Handler,
+		},
+		{
+			MethodName: "ReleaseLock",
+			Handler:    _AdminService_ReleaseLock_Handler,
+		},
+		{
+			MethodName: "ListLocks",
+			Handler:    _AdminService_ListLocks_Handler,
+		},
 		{
 			MethodName: "Shutdown",
 			Handler:    _AdminService_Shutdown_Handler,
@@ -679,6 +1237,26 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RunMaintenance",
 			Handler:    _AdminService_RunMaintenance_Handler,
 		},
+		{
+			MethodName: "PauseMaintenance",
+			Handler:    _AdminService_PauseMaintenance_Handler,
+		},
+		{
+			MethodName: "ResumeMaintenance",
+			Handler:    _AdminService_ResumeMaintenance_Handler,
+		},
+		{
+			MethodName: "Upgrade",
+			Handler:    _AdminService_Upgrade_Handler,
+		},
+		{
+			MethodName: "SelfTest",
+			Handler:    _AdminService_SelfTest_Handler,
+		},
+		{
+			MethodName: "CaptureProfile",
+			Handler:    _AdminService_CaptureProfile_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -686,6 +1264,11 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _AdminService_StreamLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamAuditLogs",
+			Handler:       _AdminService_StreamAuditLogs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "bib/v1/services/admin.proto",
 }
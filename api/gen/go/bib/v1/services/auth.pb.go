@@ -22,6 +22,70 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DeviceAuthStatus is the state of a device authorization.
+type DeviceAuthStatus int32
+
+const (
+	DeviceAuthStatus_DEVICE_AUTH_STATUS_UNSPECIFIED DeviceAuthStatus = 0
+	// Waiting for the user to approve.
+	DeviceAuthStatus_DEVICE_AUTH_STATUS_PENDING DeviceAuthStatus = 1
+	// Polled too fast; wait interval_seconds before polling again.
+	DeviceAuthStatus_DEVICE_AUTH_STATUS_SLOW_DOWN DeviceAuthStatus = 2
+	// Approved; the response carries the session.
+	DeviceAuthStatus_DEVICE_AUTH_STATUS_APPROVED DeviceAuthStatus = 3
+	// Denied by the user.
+	DeviceAuthStatus_DEVICE_AUTH_STATUS_DENIED DeviceAuthStatus = 4
+	// Expired, or the session was already collected.
+	DeviceAuthStatus_DEVICE_AUTH_STATUS_EXPIRED DeviceAuthStatus = 5
+)
+
+// Enum value maps for DeviceAuthStatus.
+var (
+	DeviceAuthStatus_name = map[int32]string{
+		0: "DEVICE_AUTH_STATUS_UNSPECIFIED",
+		1: "DEVICE_AUTH_STATUS_PENDING",
+		2: "DEVICE_AUTH_STATUS_SLOW_DOWN",
+		3: "DEVICE_AUTH_STATUS_APPROVED",
+		4: "DEVICE_AUTH_STATUS_DENIED",
+		5: "DEVICE_AUTH_STATUS_EXPIRED",
+	}
+	DeviceAuthStatus_value = map[string]int32{
+		"DEVICE_AUTH_STATUS_UNSPECIFIED": 0,
+		"DEVICE_AUTH_STATUS_PENDING":     1,
+		"DEVICE_AUTH_STATUS_SLOW_DOWN":   2,
+		"DEVICE_AUTH_STATUS_APPROVED":    3,
+		"DEVICE_AUTH_STATUS_DENIED":      4,
+		"DEVICE_AUTH_STATUS_EXPIRED":     5,
+	}
+)
+
+func (x DeviceAuthStatus) Enum() *DeviceAuthStatus {
+	p := new(DeviceAuthStatus)
+	*p = x
+	return p
+}
+
+func (x DeviceAuthStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceAuthStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_bib_v1_services_auth_proto_enumTypes[0].Descriptor()
+}
+
+func (DeviceAuthStatus) Type() protoreflect.EnumType {
+	return &file_bib_v1_services_auth_proto_enumTypes[0]
+}
+
+func (x DeviceAuthStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeviceAuthStatus.Descriptor instead.
+func (DeviceAuthStatus) EnumDescriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{0}
+}
+
 // ChallengeRequest requests an authentication challenge.
 type ChallengeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1292,6 +1356,540 @@ func (x *RevokeAllSessionsResponse) GetRevokedCount() int32 {
 	return 0
 }
 
+// WhoAmIRequest asks for the caller's identity.
+type WhoAmIRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhoAmIRequest) Reset() {
+	*x = WhoAmIRequest{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIRequest) ProtoMessage() {}
+
+func (x *WhoAmIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhoAmIRequest.ProtoReflect.Descriptor instead.
+func (*WhoAmIRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{21}
+}
+
+// WhoAmIResponse describes the caller as the node sees them.
+type WhoAmIResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The authenticated user.
+	User *UserInfo `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// Fingerprint of the key the session was authenticated with. Differs from
+	// the user's primary key fingerprint when signed in with a device key.
+	PublicKeyFingerprint string `protobuf:"bytes,2,opt,name=public_key_fingerprint,json=publicKeyFingerprint,proto3" json:"public_key_fingerprint,omitempty"`
+	// Effective roles, the user's role and the roles it implies
+	// (e.g. "admin", "user", "readonly").
+	Roles []string `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	// RPC methods the caller may invoke, as full method names
+	// (e.g. "/bib.v1.services.TopicService/CreateTopic").
+	Permissions []string `protobuf:"bytes,4,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// The current session.
+	Session *SessionInfo `protobuf:"bytes,5,opt,name=session,proto3" json:"session,omitempty"`
+	// When the session expires without further activity.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Node that answered the request.
+	NodeId string `protobuf:"bytes,7,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// Mode of the answering node.
+	NodeMode string `protobuf:"bytes,8,opt,name=node_mode,json=nodeMode,proto3" json:"node_mode,omitempty"`
+	// Version of the answering node.
+	Version       string `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WhoAmIResponse) Reset() {
+	*x = WhoAmIResponse{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WhoAmIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhoAmIResponse) ProtoMessage() {}
+
+func (x *WhoAmIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhoAmIResponse.ProtoReflect.Descriptor instead.
+func (*WhoAmIResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *WhoAmIResponse) GetUser() *UserInfo {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *WhoAmIResponse) GetPublicKeyFingerprint() string {
+	if x != nil {
+		return x.PublicKeyFingerprint
+	}
+	return ""
+}
+
+func (x *WhoAmIResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *WhoAmIResponse) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *WhoAmIResponse) GetSession() *SessionInfo {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *WhoAmIResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *WhoAmIResponse) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *WhoAmIResponse) GetNodeMode() string {
+	if x != nil {
+		return x.NodeMode
+	}
+	return ""
+}
+
+func (x *WhoAmIResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// StartDeviceAuthRequest starts a device authorization.
+type StartDeviceAuthRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Client information, shown to the approving user.
+	ClientInfo    *ClientInfo `protobuf:"bytes,1,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDeviceAuthRequest) Reset() {
+	*x = StartDeviceAuthRequest{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDeviceAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDeviceAuthRequest) ProtoMessage() {}
+
+func (x *StartDeviceAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDeviceAuthRequest.ProtoReflect.Descriptor instead.
+func (*StartDeviceAuthRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StartDeviceAuthRequest) GetClientInfo() *ClientInfo {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return nil
+}
+
+// StartDeviceAuthResponse contains the codes for a device authorization.
+type StartDeviceAuthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secret code the device polls with. Never shown to the user.
+	DeviceCode string `protobuf:"bytes,1,opt,name=device_code,json=deviceCode,proto3" json:"device_code,omitempty"`
+	// Short code the user approves, e.g. "BCDF-GHJK".
+	UserCode string `protobuf:"bytes,2,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	// Where the user approves the code. Empty if the node has none, in which
+	// case the user approves with "bib login approve" on a signed-in device.
+	VerificationUri string `protobuf:"bytes,3,opt,name=verification_uri,json=verificationUri,proto3" json:"verification_uri,omitempty"`
+	// When the codes expire.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Minimum seconds between polls.
+	IntervalSeconds int32 `protobuf:"varint,5,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StartDeviceAuthResponse) Reset() {
+	*x = StartDeviceAuthResponse{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDeviceAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDeviceAuthResponse) ProtoMessage() {}
+
+func (x *StartDeviceAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDeviceAuthResponse.ProtoReflect.Descriptor instead.
+func (*StartDeviceAuthResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StartDeviceAuthResponse) GetDeviceCode() string {
+	if x != nil {
+		return x.DeviceCode
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthResponse) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthResponse) GetVerificationUri() string {
+	if x != nil {
+		return x.VerificationUri
+	}
+	return ""
+}
+
+func (x *StartDeviceAuthResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StartDeviceAuthResponse) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+// PollDeviceAuthRequest polls a device authorization.
+type PollDeviceAuthRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Device code from StartDeviceAuth.
+	DeviceCode    string `protobuf:"bytes,1,opt,name=device_code,json=deviceCode,proto3" json:"device_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollDeviceAuthRequest) Reset() {
+	*x = PollDeviceAuthRequest{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollDeviceAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollDeviceAuthRequest) ProtoMessage() {}
+
+func (x *PollDeviceAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollDeviceAuthRequest.ProtoReflect.Descriptor instead.
+func (*PollDeviceAuthRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PollDeviceAuthRequest) GetDeviceCode() string {
+	if x != nil {
+		return x.DeviceCode
+	}
+	return ""
+}
+
+// PollDeviceAuthResponse contains the state of a device authorization.
+type PollDeviceAuthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Authorization state.
+	Status DeviceAuthStatus `protobuf:"varint,1,opt,name=status,proto3,enum=bib.v1.services.DeviceAuthStatus" json:"status,omitempty"`
+	// Minimum seconds before the next poll.
+	IntervalSeconds int32 `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	// Session token, when approved.
+	SessionToken string `protobuf:"bytes,3,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+	// When the session expires, when approved.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// The signed-in user, when approved.
+	User *UserInfo `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	// Session details, when approved.
+	Session       *SessionInfo `protobuf:"bytes,6,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollDeviceAuthResponse) Reset() {
+	*x = PollDeviceAuthResponse{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollDeviceAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollDeviceAuthResponse) ProtoMessage() {}
+
+func (x *PollDeviceAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollDeviceAuthResponse.ProtoReflect.Descriptor instead.
+func (*PollDeviceAuthResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PollDeviceAuthResponse) GetStatus() DeviceAuthStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeviceAuthStatus_DEVICE_AUTH_STATUS_UNSPECIFIED
+}
+
+func (x *PollDeviceAuthResponse) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *PollDeviceAuthResponse) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
+func (x *PollDeviceAuthResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PollDeviceAuthResponse) GetUser() *UserInfo {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *PollDeviceAuthResponse) GetSession() *SessionInfo {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// ApproveDeviceAuthRequest approves or denies a device authorization.
+type ApproveDeviceAuthRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User code shown on the device. Case and the separator are ignored.
+	UserCode string `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	// Deny the authorization instead of approving it.
+	Deny          bool `protobuf:"varint,2,opt,name=deny,proto3" json:"deny,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceAuthRequest) Reset() {
+	*x = ApproveDeviceAuthRequest{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceAuthRequest) ProtoMessage() {}
+
+func (x *ApproveDeviceAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceAuthRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceAuthRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ApproveDeviceAuthRequest) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+func (x *ApproveDeviceAuthRequest) GetDeny() bool {
+	if x != nil {
+		return x.Deny
+	}
+	return false
+}
+
+// ApproveDeviceAuthResponse contains the approval result.
+type ApproveDeviceAuthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the device was approved (false if denied).
+	Approved bool `protobuf:"varint,1,opt,name=approved,proto3" json:"approved,omitempty"`
+	// The device that requested authorization.
+	ClientInfo    *ClientInfo `protobuf:"bytes,2,opt,name=client_info,json=clientInfo,proto3" json:"client_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceAuthResponse) Reset() {
+	*x = ApproveDeviceAuthResponse{}
+	mi := &file_bib_v1_services_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceAuthResponse) ProtoMessage() {}
+
+func (x *ApproveDeviceAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceAuthResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceAuthResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ApproveDeviceAuthResponse) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ApproveDeviceAuthResponse) GetClientInfo() *ClientInfo {
+	if x != nil {
+		return x.ClientInfo
+	}
+	return nil
+}
+
 // UserInfo contains basic user information returned by auth operations.
 // For full user management, see UserService.
 type UserInfo struct {
@@ -1316,7 +1914,7 @@ type UserInfo struct {
 
 func (x *UserInfo) Reset() {
 	*x = UserInfo{}
-	mi := &file_bib_v1_services_auth_proto_msgTypes[21]
+	mi := &file_bib_v1_services_auth_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1328,7 +1926,7 @@ func (x *UserInfo) String() string {
 func (*UserInfo) ProtoMessage() {}
 
 func (x *UserInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_auth_proto_msgTypes[21]
+	mi := &file_bib_v1_services_auth_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1341,7 +1939,7 @@ func (x *UserInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserInfo.ProtoReflect.Descriptor instead.
 func (*UserInfo) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{21}
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *UserInfo) GetId() string {
@@ -1420,7 +2018,7 @@ type SessionInfo struct {
 
 func (x *SessionInfo) Reset() {
 	*x = SessionInfo{}
-	mi := &file_bib_v1_services_auth_proto_msgTypes[22]
+	mi := &file_bib_v1_services_auth_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1432,7 +2030,7 @@ func (x *SessionInfo) String() string {
 func (*SessionInfo) ProtoMessage() {}
 
 func (x *SessionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_auth_proto_msgTypes[22]
+	mi := &file_bib_v1_services_auth_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1445,7 +2043,7 @@ func (x *SessionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
 func (*SessionInfo) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{22}
+	return file_bib_v1_services_auth_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SessionInfo) GetId() string {
@@ -1607,7 +2205,48 @@ const file_bib_v1_services_auth_proto_rawDesc = "" +
 	"\x18RevokeAllSessionsRequest\x12'\n" +
 	"\x0finclude_current\x18\x01 \x01(\bR\x0eincludeCurrent\"@\n" +
 	"\x19RevokeAllSessionsResponse\x12#\n" +
-	"\rrevoked_count\x18\x01 \x01(\x05R\frevokedCount\"\xbe\x01\n" +
+	"\rrevoked_count\x18\x01 \x01(\x05R\frevokedCount\"\x0f\n" +
+	"\rWhoAmIRequest\"\xf0\x02\n" +
+	"\x0eWhoAmIResponse\x12-\n" +
+	"\x04user\x18\x01 \x01(\v2\x19.bib.v1.services.UserInfoR\x04user\x124\n" +
+	"\x16public_key_fingerprint\x18\x02 \x01(\tR\x14publicKeyFingerprint\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12 \n" +
+	"\vpermissions\x18\x04 \x03(\tR\vpermissions\x126\n" +
+	"\asession\x18\x05 \x01(\v2\x1c.bib.v1.services.SessionInfoR\asession\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x17\n" +
+	"\anode_id\x18\a \x01(\tR\x06nodeId\x12\x1b\n" +
+	"\tnode_mode\x18\b \x01(\tR\bnodeMode\x12\x18\n" +
+	"\aversion\x18\t \x01(\tR\aversion\"V\n" +
+	"\x16StartDeviceAuthRequest\x12<\n" +
+	"\vclient_info\x18\x01 \x01(\v2\x1b.bib.v1.services.ClientInfoR\n" +
+	"clientInfo\"\xe8\x01\n" +
+	"\x17StartDeviceAuthResponse\x12\x1f\n" +
+	"\vdevice_code\x18\x01 \x01(\tR\n" +
+	"deviceCode\x12\x1b\n" +
+	"\tuser_code\x18\x02 \x01(\tR\buserCode\x12)\n" +
+	"\x10verification_uri\x18\x03 \x01(\tR\x0fverificationUri\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12)\n" +
+	"\x10interval_seconds\x18\x05 \x01(\x05R\x0fintervalSeconds\"8\n" +
+	"\x15PollDeviceAuthRequest\x12\x1f\n" +
+	"\vdevice_code\x18\x01 \x01(\tR\n" +
+	"deviceCode\"\xc5\x02\n" +
+	"\x16PollDeviceAuthResponse\x129\n" +
+	"\x06status\x18\x01 \x01(\x0e2!.bib.v1.services.DeviceAuthStatusR\x06status\x12)\n" +
+	"\x10interval_seconds\x18\x02 \x01(\x05R\x0fintervalSeconds\x12#\n" +
+	"\rsession_token\x18\x03 \x01(\tR\fsessionToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12-\n" +
+	"\x04user\x18\x05 \x01(\v2\x19.bib.v1.services.UserInfoR\x04user\x126\n" +
+	"\asession\x18\x06 \x01(\v2\x1c.bib.v1.services.SessionInfoR\asession\"K\n" +
+	"\x18ApproveDeviceAuthRequest\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\x12\x12\n" +
+	"\x04deny\x18\x02 \x01(\bR\x04deny\"u\n" +
+	"\x19ApproveDeviceAuthResponse\x12\x1a\n" +
+	"\bapproved\x18\x01 \x01(\bR\bapproved\x12<\n" +
+	"\vclient_info\x18\x02 \x01(\v2\x1b.bib.v1.services.ClientInfoR\n" +
+	"clientInfo\"\xbe\x01\n" +
 	"\bUserInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -1628,7 +2267,15 @@ const file_bib_v1_services_auth_proto_rawDesc = "" +
 	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12D\n" +
 	"\x10last_activity_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\x12\x1d\n" +
 	"\n" +
-	"is_current\x18\t \x01(\bR\tisCurrent2\xd3\a\n" +
+	"is_current\x18\t \x01(\bR\tisCurrent*\xd8\x01\n" +
+	"\x10DeviceAuthStatus\x12\"\n" +
+	"\x1eDEVICE_AUTH_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aDEVICE_AUTH_STATUS_PENDING\x10\x01\x12 \n" +
+	"\x1cDEVICE_AUTH_STATUS_SLOW_DOWN\x10\x02\x12\x1f\n" +
+	"\x1bDEVICE_AUTH_STATUS_APPROVED\x10\x03\x12\x1d\n" +
+	"\x19DEVICE_AUTH_STATUS_DENIED\x10\x04\x12\x1e\n" +
+	"\x1aDEVICE_AUTH_STATUS_EXPIRED\x10\x052\xd3\n" +
+	"\n" +
 	"\vAuthService\x12R\n" +
 	"\tChallenge\x12!.bib.v1.services.ChallengeRequest\x1a\".bib.v1.services.ChallengeResponse\x12d\n" +
 	"\x0fVerifyChallenge\x12'.bib.v1.services.VerifyChallengeRequest\x1a(.bib.v1.services.VerifyChallengeResponse\x12I\n" +
@@ -1639,7 +2286,11 @@ const file_bib_v1_services_auth_proto_rawDesc = "" +
 	"\x10GetPublicKeyInfo\x12(.bib.v1.services.GetPublicKeyInfoRequest\x1a).bib.v1.services.GetPublicKeyInfoResponse\x12a\n" +
 	"\x0eListMySessions\x12&.bib.v1.services.ListMySessionsRequest\x1a'.bib.v1.services.ListMySessionsResponse\x12^\n" +
 	"\rRevokeSession\x12%.bib.v1.services.RevokeSessionRequest\x1a&.bib.v1.services.RevokeSessionResponse\x12j\n" +
-	"\x11RevokeAllSessions\x12).bib.v1.services.RevokeAllSessionsRequest\x1a*.bib.v1.services.RevokeAllSessionsResponseB\x9e\x01\n" +
+	"\x11RevokeAllSessions\x12).bib.v1.services.RevokeAllSessionsRequest\x1a*.bib.v1.services.RevokeAllSessionsResponse\x12I\n" +
+	"\x06WhoAmI\x12\x1e.bib.v1.services.WhoAmIRequest\x1a\x1f.bib.v1.services.WhoAmIResponse\x12d\n" +
+	"\x0fStartDeviceAuth\x12'.bib.v1.services.StartDeviceAuthRequest\x1a(.bib.v1.services.StartDeviceAuthResponse\x12a\n" +
+	"\x0ePollDeviceAuth\x12&.bib.v1.services.PollDeviceAuthRequest\x1a'.bib.v1.services.PollDeviceAuthResponse\x12j\n" +
+	"\x11ApproveDeviceAuth\x12).bib.v1.services.ApproveDeviceAuthRequest\x1a*.bib.v1.services.ApproveDeviceAuthResponseB\x9e\x01\n" +
 	"\x13com.bib.v1.servicesB\tAuthProtoP\x01Z\x1ebib/api/gen/go/bib/v1/services\xa2\x02\x03BVS\xaa\x02\x0fBib.V1.Services\xca\x02\x0fBib\\V1\\Services\xe2\x02\x1bBib\\V1\\Services\\GPBMetadata\xea\x02\x11Bib::V1::Servicesb\x06proto3"
 
 var (
@@ -1654,74 +2305,102 @@ func file_bib_v1_services_auth_proto_rawDescGZIP() []byte {
 	return file_bib_v1_services_auth_proto_rawDescData
 }
 
-var file_bib_v1_services_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_bib_v1_services_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_bib_v1_services_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_bib_v1_services_auth_proto_goTypes = []any{
-	(*ChallengeRequest)(nil),          // 0: bib.v1.services.ChallengeRequest
-	(*ChallengeResponse)(nil),         // 1: bib.v1.services.ChallengeResponse
-	(*VerifyChallengeRequest)(nil),    // 2: bib.v1.services.VerifyChallengeRequest
-	(*ClientInfo)(nil),                // 3: bib.v1.services.ClientInfo
-	(*VerifyChallengeResponse)(nil),   // 4: bib.v1.services.VerifyChallengeResponse
-	(*LogoutRequest)(nil),             // 5: bib.v1.services.LogoutRequest
-	(*LogoutResponse)(nil),            // 6: bib.v1.services.LogoutResponse
-	(*RefreshSessionRequest)(nil),     // 7: bib.v1.services.RefreshSessionRequest
-	(*RefreshSessionResponse)(nil),    // 8: bib.v1.services.RefreshSessionResponse
-	(*ValidateSessionRequest)(nil),    // 9: bib.v1.services.ValidateSessionRequest
-	(*ValidateSessionResponse)(nil),   // 10: bib.v1.services.ValidateSessionResponse
-	(*GetAuthConfigRequest)(nil),      // 11: bib.v1.services.GetAuthConfigRequest
-	(*GetAuthConfigResponse)(nil),     // 12: bib.v1.services.GetAuthConfigResponse
-	(*GetPublicKeyInfoRequest)(nil),   // 13: bib.v1.services.GetPublicKeyInfoRequest
-	(*GetPublicKeyInfoResponse)(nil),  // 14: bib.v1.services.GetPublicKeyInfoResponse
-	(*ListMySessionsRequest)(nil),     // 15: bib.v1.services.ListMySessionsRequest
-	(*ListMySessionsResponse)(nil),    // 16: bib.v1.services.ListMySessionsResponse
-	(*RevokeSessionRequest)(nil),      // 17: bib.v1.services.RevokeSessionRequest
-	(*RevokeSessionResponse)(nil),     // 18: bib.v1.services.RevokeSessionResponse
-	(*RevokeAllSessionsRequest)(nil),  // 19: bib.v1.services.RevokeAllSessionsRequest
-	(*RevokeAllSessionsResponse)(nil), // 20: bib.v1.services.RevokeAllSessionsResponse
-	(*UserInfo)(nil),                  // 21: bib.v1.services.UserInfo
-	(*SessionInfo)(nil),               // 22: bib.v1.services.SessionInfo
-	nil,                               // 23: bib.v1.services.ClientInfo.MetadataEntry
-	(*timestamppb.Timestamp)(nil),     // 24: google.protobuf.Timestamp
+	(DeviceAuthStatus)(0),             // 0: bib.v1.services.DeviceAuthStatus
+	(*ChallengeRequest)(nil),          // 1: bib.v1.services.ChallengeRequest
+	(*ChallengeResponse)(nil),         // 2: bib.v1.services.ChallengeResponse
+	(*VerifyChallengeRequest)(nil),    // 3: bib.v1.services.VerifyChallengeRequest
+	(*ClientInfo)(nil),                // 4: bib.v1.services.ClientInfo
+	(*VerifyChallengeResponse)(nil),   // 5: bib.v1.services.VerifyChallengeResponse
+	(*LogoutRequest)(nil),             // 6: bib.v1.services.LogoutRequest
+	(*LogoutResponse)(nil),            // 7: bib.v1.services.LogoutResponse
+	(*RefreshSessionRequest)(nil),     // 8: bib.v1.services.RefreshSessionRequest
+	(*RefreshSessionResponse)(nil),    // 9: bib.v1.services.RefreshSessionResponse
+	(*ValidateSessionRequest)(nil),    // 10: bib.v1.services.ValidateSessionRequest
+	(*ValidateSessionResponse)(nil),   // 11: bib.v1.services.ValidateSessionResponse
+	(*GetAuthConfigRequest)(nil),      // 12: bib.v1.services.GetAuthConfigRequest
+	(*GetAuthConfigResponse)(nil),     // 13: bib.v1.services.GetAuthConfigResponse
+	(*GetPublicKeyInfoRequest)(nil),   // 14: bib.v1.services.GetPublicKeyInfoRequest
+	(*GetPublicKeyInfoResponse)(nil),  // 15: bib.v1.services.GetPublicKeyInfoResponse
+	(*ListMySessionsRequest)(nil),     // 16: bib.v1.services.ListMySessionsRequest
+	(*ListMySessionsResponse)(nil),    // 17: bib.v1.services.ListMySessionsResponse
+	(*RevokeSessionRequest)(nil),      // 18: bib.v1.services.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),     // 19: bib.v1.services.RevokeSessionResponse
+	(*RevokeAllSessionsRequest)(nil),  // 20: bib.v1.services.RevokeAllSessionsRequest
+	(*RevokeAllSessionsResponse)(nil), // 21: bib.v1.services.RevokeAllSessionsResponse
+	(*WhoAmIRequest)(nil),             // 22: bib.v1.services.WhoAmIRequest
+	(*WhoAmIResponse)(nil),            // 23: bib.v1.services.WhoAmIResponse
+	(*StartDeviceAuthRequest)(nil),    // 24: bib.v1.services.StartDeviceAuthRequest
+	(*StartDeviceAuthResponse)(nil),   // 25: bib.v1.services.StartDeviceAuthResponse
+	(*PollDeviceAuthRequest)(nil),     // 26: bib.v1.services.PollDeviceAuthRequest
+	(*PollDeviceAuthResponse)(nil),    // 27: bib.v1.services.PollDeviceAuthResponse
+	(*ApproveDeviceAuthRequest)(nil),  // 28: bib.v1.services.ApproveDeviceAuthRequest
+	(*ApproveDeviceAuthResponse)(nil), // 29: bib.v1.services.ApproveDeviceAuthResponse
+	(*UserInfo)(nil),                  // 30: bib.v1.services.UserInfo
+	(*SessionInfo)(nil),               // 31: bib.v1.services.SessionInfo
+	nil,                               // 32: bib.v1.services.ClientInfo.MetadataEntry
+	(*timestamppb.Timestamp)(nil),     // 33: google.protobuf.Timestamp
 }
 var file_bib_v1_services_auth_proto_depIdxs = []int32{
-	24, // 0: bib.v1.services.ChallengeResponse.expires_at:type_name -> google.protobuf.Timestamp
-	3,  // 1: bib.v1.services.VerifyChallengeRequest.client_info:type_name -> bib.v1.services.ClientInfo
-	23, // 2: bib.v1.services.ClientInfo.metadata:type_name -> bib.v1.services.ClientInfo.MetadataEntry
-	24, // 3: bib.v1.services.VerifyChallengeResponse.expires_at:type_name -> google.protobuf.Timestamp
-	21, // 4: bib.v1.services.VerifyChallengeResponse.user:type_name -> bib.v1.services.UserInfo
-	22, // 5: bib.v1.services.VerifyChallengeResponse.session:type_name -> bib.v1.services.SessionInfo
-	24, // 6: bib.v1.services.RefreshSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
-	21, // 7: bib.v1.services.ValidateSessionResponse.user:type_name -> bib.v1.services.UserInfo
-	22, // 8: bib.v1.services.ValidateSessionResponse.session:type_name -> bib.v1.services.SessionInfo
-	24, // 9: bib.v1.services.ValidateSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
-	22, // 10: bib.v1.services.ListMySessionsResponse.sessions:type_name -> bib.v1.services.SessionInfo
-	24, // 11: bib.v1.services.SessionInfo.started_at:type_name -> google.protobuf.Timestamp
-	24, // 12: bib.v1.services.SessionInfo.expires_at:type_name -> google.protobuf.Timestamp
-	24, // 13: bib.v1.services.SessionInfo.last_activity_at:type_name -> google.protobuf.Timestamp
-	0,  // 14: bib.v1.services.AuthService.Challenge:input_type -> bib.v1.services.ChallengeRequest
-	2,  // 15: bib.v1.services.AuthService.VerifyChallenge:input_type -> bib.v1.services.VerifyChallengeRequest
-	5,  // 16: bib.v1.services.AuthService.Logout:input_type -> bib.v1.services.LogoutRequest
-	7,  // 17: bib.v1.services.AuthService.RefreshSession:input_type -> bib.v1.services.RefreshSessionRequest
-	9,  // 18: bib.v1.services.AuthService.ValidateSession:input_type -> bib.v1.services.ValidateSessionRequest
-	11, // 19: bib.v1.services.AuthService.GetAuthConfig:input_type -> bib.v1.services.GetAuthConfigRequest
-	13, // 20: bib.v1.services.AuthService.GetPublicKeyInfo:input_type -> bib.v1.services.GetPublicKeyInfoRequest
-	15, // 21: bib.v1.services.AuthService.ListMySessions:input_type -> bib.v1.services.ListMySessionsRequest
-	17, // 22: bib.v1.services.AuthService.RevokeSession:input_type -> bib.v1.services.RevokeSessionRequest
-	19, // 23: bib.v1.services.AuthService.RevokeAllSessions:input_type -> bib.v1.services.RevokeAllSessionsRequest
-	1,  // 24: bib.v1.services.AuthService.Challenge:output_type -> bib.v1.services.ChallengeResponse
-	4,  // 25: bib.v1.services.AuthService.VerifyChallenge:output_type -> bib.v1.services.VerifyChallengeResponse
-	6,  // 26: bib.v1.services.AuthService.Logout:output_type -> bib.v1.services.LogoutResponse
-	8,  // 27: bib.v1.services.AuthService.RefreshSession:output_type -> bib.v1.services.RefreshSessionResponse
-	10, // 28: bib.v1.services.AuthService.ValidateSession:output_type -> bib.v1.services.ValidateSessionResponse
-	12, // 29: bib.v1.services.AuthService.GetAuthConfig:output_type -> bib.v1.services.GetAuthConfigResponse
-	14, // 30: bib.v1.services.AuthService.GetPublicKeyInfo:output_type -> bib.v1.services.GetPublicKeyInfoResponse
-	16, // 31: bib.v1.services.AuthService.ListMySessions:output_type -> bib.v1.services.ListMySessionsResponse
-	18, // 32: bib.v1.services.AuthService.RevokeSession:output_type -> bib.v1.services.RevokeSessionResponse
-	20, // 33: bib.v1.services.AuthService.RevokeAllSessions:output_type -> bib.v1.services.RevokeAllSessionsResponse
-	24, // [24:34] is the sub-list for method output_type
-	14, // [14:24] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	33, // 0: bib.v1.services.ChallengeResponse.expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 1: bib.v1.services.VerifyChallengeRequest.client_info:type_name -> bib.v1.services.ClientInfo
+	32, // 2: bib.v1.services.ClientInfo.metadata:type_name -> bib.v1.services.ClientInfo.MetadataEntry
+	33, // 3: bib.v1.services.VerifyChallengeResponse.expires_at:type_name -> google.protobuf.Timestamp
+	30, // 4: bib.v1.services.VerifyChallengeResponse.user:type_name -> bib.v1.services.UserInfo
+	31, // 5: bib.v1.services.VerifyChallengeResponse.session:type_name -> bib.v1.services.SessionInfo
+	33, // 6: bib.v1.services.RefreshSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
+	30, // 7: bib.v1.services.ValidateSessionResponse.user:type_name -> bib.v1.services.UserInfo
+	31, // 8: bib.v1.services.ValidateSessionResponse.session:type_name -> bib.v1.services.SessionInfo
+	33, // 9: bib.v1.services.ValidateSessionResponse.expires_at:type_name -> google.protobuf.Timestamp
+	31, // 10: bib.v1.services.ListMySessionsResponse.sessions:type_name -> bib.v1.services.SessionInfo
+	30, // 11: bib.v1.services.WhoAmIResponse.user:type_name -> bib.v1.services.UserInfo
+	31, // 12: bib.v1.services.WhoAmIResponse.session:type_name -> bib.v1.services.SessionInfo
+	33, // 13: bib.v1.services.WhoAmIResponse.expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 14: bib.v1.services.StartDeviceAuthRequest.client_info:type_name -> bib.v1.services.ClientInfo
+	33, // 15: bib.v1.services.StartDeviceAuthResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 16: bib.v1.services.PollDeviceAuthResponse.status:type_name -> bib.v1.services.DeviceAuthStatus
+	33, // 17: bib.v1.services.PollDeviceAuthResponse.expires_at:type_name -> google.protobuf.Timestamp
+	30, // 18: bib.v1.services.PollDeviceAuthResponse.user:type_name -> bib.v1.services.UserInfo
+	31, // 19: bib.v1.services.PollDeviceAuthResponse.session:type_name -> bib.v1.services.SessionInfo
+	4,  // 20: bib.v1.services.ApproveDeviceAuthResponse.client_info:type_name -> bib.v1.services.ClientInfo
+	33, // 21: bib.v1.services.SessionInfo.started_at:type_name -> google.protobuf.Timestamp
+	33, // 22: bib.v1.services.SessionInfo.expires_at:type_name -> google.protobuf.Timestamp
+	33, // 23: bib.v1.services.SessionInfo.last_activity_at:type_name -> google.protobuf.Timestamp
+	1,  // 24: bib.v1.services.AuthService.Challenge:input_type -> bib.v1.services.ChallengeRequest
+	3,  // 25: bib.v1.services.AuthService.VerifyChallenge:input_type -> bib.v1.services.VerifyChallengeRequest
+	6,  // 26: bib.v1.services.AuthService.Logout:input_type -> bib.v1.services.LogoutRequest
+	8,  // 27: bib.v1.services.AuthService.RefreshSession:input_type -> bib.v1.services.RefreshSessionRequest
+	10, // 28: bib.v1.services.AuthService.ValidateSession:input_type -> bib.v1.services.ValidateSessionRequest
+	12, // 29: bib.v1.services.AuthService.GetAuthConfig:input_type -> bib.v1.services.GetAuthConfigRequest
+	14, // 30: bib.v1.services.AuthService.GetPublicKeyInfo:input_type -> bib.v1.services.GetPublicKeyInfoRequest
+	16, // 31: bib.v1.services.AuthService.ListMySessions:input_type -> bib.v1.services.ListMySessionsRequest
+	18, // 32: bib.v1.services.AuthService.RevokeSession:input_type -> bib.v1.services.RevokeSessionRequest
+	20, // 33: bib.v1.services.AuthService.RevokeAllSessions:input_type -> bib.v1.services.RevokeAllSessionsRequest
+	22, // 34: bib.v1.services.AuthService.WhoAmI:input_type -> bib.v1.services.WhoAmIRequest
+	24, // 35: bib.v1.services.AuthService.StartDeviceAuth:input_type -> bib.v1.services.StartDeviceAuthRequest
+	26, // 36: bib.v1.services.AuthService.PollDeviceAuth:input_type -> bib.v1.services.PollDeviceAuthRequest
+	28, // 37: bib.v1.services.AuthService.ApproveDeviceAuth:input_type -> bib.v1.services.ApproveDeviceAuthRequest
+	2,  // 38: bib.v1.services.AuthService.Challenge:output_type -> bib.v1.services.ChallengeResponse
+	5,  // 39: bib.v1.services.AuthService.VerifyChallenge:output_type -> bib.v1.services.VerifyChallengeResponse
+	7,  // 40: bib.v1.services.AuthService.Logout:output_type -> bib.v1.services.LogoutResponse
+	9,  // 41: bib.v1.services.AuthService.RefreshSession:output_type -> bib.v1.services.RefreshSessionResponse
+	11, // 42: bib.v1.services.AuthService.ValidateSession:output_type -> bib.v1.services.ValidateSessionResponse
+	13, // 43: bib.v1.services.AuthService.GetAuthConfig:output_type -> bib.v1.services.GetAuthConfigResponse
+	15, // 44: bib.v1.services.AuthService.GetPublicKeyInfo:output_type -> bib.v1.services.GetPublicKeyInfoResponse
+	17, // 45: bib.v1.services.AuthService.ListMySessions:output_type -> bib.v1.services.ListMySessionsResponse
+	19, // 46: bib.v1.services.AuthService.RevokeSession:output_type -> bib.v1.services.RevokeSessionResponse
+	21, // 47: bib.v1.services.AuthService.RevokeAllSessions:output_type -> bib.v1.services.RevokeAllSessionsResponse
+	23, // 48: bib.v1.services.AuthService.WhoAmI:output_type -> bib.v1.services.WhoAmIResponse
+	25, // 49: bib.v1.services.AuthService.StartDeviceAuth:output_type -> bib.v1.services.StartDeviceAuthResponse
+	27, // 50: bib.v1.services.AuthService.PollDeviceAuth:output_type -> bib.v1.services.PollDeviceAuthResponse
+	29, // 51: bib.v1.services.AuthService.ApproveDeviceAuth:output_type -> bib.v1.services.ApproveDeviceAuthResponse
+	38, // [38:52] is the sub-list for method output_type
+	24, // [24:38] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_auth_proto_init() }
@@ -1734,13 +2413,14 @@ func file_bib_v1_services_auth_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_auth_proto_rawDesc), len(file_bib_v1_services_auth_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   24,
+			NumEnums:      1,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_bib_v1_services_auth_proto_goTypes,
 		DependencyIndexes: file_bib_v1_services_auth_proto_depIdxs,
+		EnumInfos:         file_bib_v1_services_auth_proto_enumTypes,
 		MessageInfos:      file_bib_v1_services_auth_proto_msgTypes,
 	}.Build()
 	File_bib_v1_services_auth_proto = out.File
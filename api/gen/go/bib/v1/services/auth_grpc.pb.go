@@ -29,6 +29,10 @@ const (
 	AuthService_ListMySessions_FullMethodName    = "/bib.v1.services.AuthService/ListMySessions"
 	AuthService_RevokeSession_FullMethodName     = "/bib.v1.services.AuthService/RevokeSession"
 	AuthService_RevokeAllSessions_FullMethodName = "/bib.v1.services.AuthService/RevokeAllSessions"
+	AuthService_WhoAmI_FullMethodName            = "/bib.v1.services.AuthService/WhoAmI"
+	AuthService_StartDeviceAuth_FullMethodName   = "/bib.v1.services.AuthService/StartDeviceAuth"
+	AuthService_PollDeviceAuth_FullMethodName    = "/bib.v1.services.AuthService/PollDeviceAuth"
+	AuthService_ApproveDeviceAuth_FullMethodName = "/bib.v1.services.AuthService/ApproveDeviceAuth"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -62,6 +66,18 @@ type AuthServiceClient interface {
 	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
 	// RevokeAllSessions revokes all sessions except the current one.
 	RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*RevokeAllSessionsResponse, error)
+	// WhoAmI returns the authenticated principal of the current session, with
+	// its effective roles and permissions on the answering node.
+	WhoAmI(ctx context.Context, in *WhoAmIRequest, opts ...grpc.CallOption) (*WhoAmIResponse, error)
+	// StartDeviceAuth starts a device authorization for a device without an
+	// identity key. The device shows the user code and polls PollDeviceAuth.
+	StartDeviceAuth(ctx context.Context, in *StartDeviceAuthRequest, opts ...grpc.CallOption) (*StartDeviceAuthResponse, error)
+	// PollDeviceAuth reports whether a device authorization was approved and,
+	// once it is, returns the device's session token.
+	PollDeviceAuth(ctx context.Context, in *PollDeviceAuthRequest, opts ...grpc.CallOption) (*PollDeviceAuthResponse, error)
+	// ApproveDeviceAuth approves or denies a device authorization by user code,
+	// signing the device in as the caller.
+	ApproveDeviceAuth(ctx context.Context, in *ApproveDeviceAuthRequest, opts ...grpc.CallOption) (*ApproveDeviceAuthResponse, error)
 }
 
 type authServiceClient struct {
@@ -172,6 +188,46 @@ func (c *authServiceClient) RevokeAllSessions(ctx context.Context, in *RevokeAll
 	return out, nil
 }
 
+func (c *authServiceClient) WhoAmI(ctx context.Context, in *WhoAmIRequest, opts ...grpc.CallOption) (*WhoAmIResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WhoAmIResponse)
+	err := c.cc.Invoke(ctx, AuthService_WhoAmI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) StartDeviceAuth(ctx context.Context, in *StartDeviceAuthRequest, opts ...grpc.CallOption) (*StartDeviceAuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartDeviceAuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_StartDeviceAuth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) PollDeviceAuth(ctx context.Context, in *PollDeviceAuthRequest, opts ...grpc.CallOption) (*PollDeviceAuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PollDeviceAuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_PollDeviceAuth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ApproveDeviceAuth(ctx context.Context, in *ApproveDeviceAuthRequest, opts ...grpc.CallOption) (*ApproveDeviceAuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeviceAuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_ApproveDeviceAuth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations should embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -203,6 +259,18 @@ type AuthServiceServer interface {
 	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
 	// RevokeAllSessions revokes all sessions except the current one.
 	RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error)
+	// WhoAmI returns the authenticated principal of the current session, with
+	// its effective roles and permissions on the answering node.
+	WhoAmI(context.Context, *WhoAmIRequest) (*WhoAmIResponse, error)
+	// StartDeviceAuth starts a device authorization for a device without an
+	// identity key. The device shows the user code and polls PollDeviceAuth.
+	StartDeviceAuth(context.Context, *StartDeviceAuthRequest) (*StartDeviceAuthResponse, error)
+	// PollDeviceAuth reports whether a device authorization was approved and,
+	// once it is, returns the device's session token.
+	PollDeviceAuth(context.Context, *PollDeviceAuthRequest) (*PollDeviceAuthResponse, error)
+	// ApproveDeviceAuth approves or denies a device authorization by user code,
+	// signing the device in as the caller.
+	ApproveDeviceAuth(context.Context, *ApproveDeviceAuthRequest) (*ApproveDeviceAuthResponse, error)
 }
 
 // UnimplementedAuthServiceServer should be embedded to have
@@ -242,6 +310,18 @@ func (UnimplementedAuthServiceServer) RevokeSession(context.Context, *RevokeSess
 func (UnimplementedAuthServiceServer) RevokeAllSessions(context.Context, *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method RevokeAllSessions not implemented")
 }
+func (UnimplementedAuthServiceServer) WhoAmI(context.Context, *WhoAmIRequest) (*WhoAmIResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WhoAmI not implemented")
+}
+func (UnimplementedAuthServiceServer) StartDeviceAuth(context.Context, *StartDeviceAuthRequest) (*StartDeviceAuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartDeviceAuth not implemented")
+}
+func (UnimplementedAuthServiceServer) PollDeviceAuth(context.Context, *PollDeviceAuthRequest) (*PollDeviceAuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PollDeviceAuth not implemented")
+}
+func (UnimplementedAuthServiceServer) ApproveDeviceAuth(context.Context, *ApproveDeviceAuthRequest) (*ApproveDeviceAuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApproveDeviceAuth not implemented")
+}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue() {}
 
 // UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -442,6 +522,78 @@ func _AuthService_RevokeAllSessions_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_WhoAmI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhoAmIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).WhoAmI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_WhoAmI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).WhoAmI(ctx, req.(*WhoAmIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_StartDeviceAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartDeviceAuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).StartDeviceAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_StartDeviceAuth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).StartDeviceAuth(ctx, req.(*StartDeviceAuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_PollDeviceAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PollDeviceAuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).PollDeviceAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_PollDeviceAuth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).PollDeviceAuth(ctx, req.(*PollDeviceAuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ApproveDeviceAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceAuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ApproveDeviceAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ApproveDeviceAuth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ApproveDeviceAuth(ctx, req.(*ApproveDeviceAuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -489,6 +641,22 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeAllSessions",
 			Handler:    _AuthService_RevokeAllSessions_Handler,
 		},
+		{
+			MethodName: "WhoAmI",
+			Handler:    _AuthService_WhoAmI_Handler,
+		},
+		{
+			MethodName: "StartDeviceAuth",
+			Handler:    _AuthService_StartDeviceAuth_Handler,
+		},
+		{
+			MethodName: "PollDeviceAuth",
+			Handler:    _AuthService_PollDeviceAuth_Handler,
+		},
+		{
+			MethodName: "ApproveDeviceAuth",
+			Handler:    _AuthService_ApproveDeviceAuth_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bib/v1/services/auth.proto",
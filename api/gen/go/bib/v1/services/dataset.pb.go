@@ -61,7 +61,13 @@ type Dataset struct {
 	// Schema validation status.
 	SchemaStatus string `protobuf:"bytes,17,opt,name=schema_status,json=schemaStatus,proto3" json:"schema_status,omitempty"`
 	// Source information (where this data came from).
-	Source        *DataSource `protobuf:"bytes,18,opt,name=source,proto3" json:"source,omitempty"`
+	Source *DataSource `protobuf:"bytes,18,opt,name=source,proto3" json:"source,omitempty"`
+	// JSON Schema declared by the dataset itself. When unset, the topic's
+	// data schema applies.
+	DataSchema *v1.DataSchema `protobuf:"bytes,19,opt,name=data_schema,json=dataSchema,proto3" json:"data_schema,omitempty"`
+	// Revision of the dataset record, incremented by every change. Pass it
+	// as expected_revision to reject changes made since it was read.
+	Revision      int64 `protobuf:"varint,20,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -222,6 +228,20 @@ func (x *Dataset) GetSource() *DataSource {
 	return nil
 }
 
+func (x *Dataset) GetDataSchema() *v1.DataSchema {
+	if x != nil {
+		return x.DataSchema
+	}
+	return nil
+}
+
+func (x *Dataset) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 // DataSource describes where the dataset originated.
 type DataSource struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -325,6 +345,11 @@ type DatasetVersion struct {
 	Message string `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
 	// Parent version (for branching).
 	ParentVersion int32 `protobuf:"varint,9,opt,name=parent_version,json=parentVersion,proto3" json:"parent_version,omitempty"`
+	// Unique version identifier.
+	Id string `protobuf:"bytes,10,opt,name=id,proto3" json:"id,omitempty"`
+	// Version whose content this version restored, if it was created by
+	// RevertToVersion.
+	RevertedFrom  int32 `protobuf:"varint,11,opt,name=reverted_from,json=revertedFrom,proto3" json:"reverted_from,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -422,6 +447,20 @@ func (x *DatasetVersion) GetParentVersion() int32 {
 	return 0
 }
 
+func (x *DatasetVersion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DatasetVersion) GetRevertedFrom() int32 {
+	if x != nil {
+		return x.RevertedFrom
+	}
+	return 0
+}
+
 // CreateDatasetRequest creates a new dataset (metadata only).
 // Use UploadDataset to upload content.
 type CreateDatasetRequest struct {
@@ -437,7 +476,9 @@ type CreateDatasetRequest struct {
 	// Tags.
 	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
 	// Metadata.
-	Metadata      map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional JSON Schema that content must conform to.
+	DataSchema    *v1.DataSchema `protobuf:"bytes,7,opt,name=data_schema,json=dataSchema,proto3" json:"data_schema,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -514,6 +555,13 @@ func (x *CreateDatasetRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *CreateDatasetRequest) GetDataSchema() *v1.DataSchema {
+	if x != nil {
+		return x.DataSchema
+	}
+	return nil
+}
+
 // CreateDatasetResponse contains the created dataset.
 type CreateDatasetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -648,8 +696,11 @@ type GetDatasetResponse struct {
 	AvailableLocally bool `protobuf:"varint,2,opt,name=available_locally,json=availableLocally,proto3" json:"available_locally,omitempty"`
 	// Nodes that have this dataset.
 	AvailableOnNodes []string `protobuf:"bytes,3,rep,name=available_on_nodes,json=availableOnNodes,proto3" json:"available_on_nodes,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// The requested version; the dataset's size, hash and chunk fields
+	// describe it. Unset if the dataset has no versions.
+	Version       *DatasetVersion `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetDatasetResponse) Reset() {
@@ -703,6 +754,13 @@ func (x *GetDatasetResponse) GetAvailableOnNodes() []string {
 	return nil
 }
 
+func (x *GetDatasetResponse) GetVersion() *DatasetVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
 // ListDatasetsRequest lists datasets.
 type ListDatasetsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -882,8 +940,14 @@ type UpdateDatasetRequest struct {
 	// Replace metadata.
 	Metadata       map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	UpdateMetadata bool              `protobuf:"varint,8,opt,name=update_metadata,json=updateMetadata,proto3" json:"update_metadata,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Replace the data schema; an unset data_schema removes it.
+	DataSchema       *v1.DataSchema `protobuf:"bytes,9,opt,name=data_schema,json=dataSchema,proto3" json:"data_schema,omitempty"`
+	UpdateDataSchema bool           `protobuf:"varint,10,opt,name=update_data_schema,json=updateDataSchema,proto3" json:"update_data_schema,omitempty"`
+	// Revision the dataset must still have; 0 skips the check. Fails with
+	// FailedPrecondition (subreason REVISION_CONFLICT) otherwise.
+	ExpectedRevision int64 `protobuf:"varint,11,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UpdateDatasetRequest) Reset() {
@@ -972,6 +1036,27 @@ func (x *UpdateDatasetRequest) GetUpdateMetadata() bool {
 	return false
 }
 
+func (x *UpdateDatasetRequest) GetDataSchema() *v1.DataSchema {
+	if x != nil {
+		return x.DataSchema
+	}
+	return nil
+}
+
+func (x *UpdateDatasetRequest) GetUpdateDataSchema() bool {
+	if x != nil {
+		return x.UpdateDataSchema
+	}
+	return false
+}
+
+func (x *UpdateDatasetRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
 // UpdateDatasetResponse contains the updated dataset.
 type UpdateDatasetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1025,9 +1110,11 @@ type DeleteDatasetRequest struct {
 	// Delete all versions.
 	DeleteAllVersions bool `protobuf:"varint,2,opt,name=delete_all_versions,json=deleteAllVersions,proto3" json:"delete_all_versions,omitempty"`
 	// Force delete even if referenced.
-	Force         bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Force bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	// Revision the dataset must still have; 0 skips the check.
+	ExpectedRevision int64 `protobuf:"varint,4,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *DeleteDatasetRequest) Reset() {
@@ -1081,6 +1168,13 @@ func (x *DeleteDatasetRequest) GetForce() bool {
 	return false
 }
 
+func (x *DeleteDatasetRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
 // DeleteDatasetResponse confirms deletion.
 type DeleteDatasetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1141,6 +1235,7 @@ type UploadDatasetRequest struct {
 	//
 	//	*UploadDatasetRequest_Metadata
 	//	*UploadDatasetRequest_Chunk
+	//	*UploadDatasetRequest_IndexedChunk
 	Data          isUploadDatasetRequest_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -1201,6 +1296,15 @@ func (x *UploadDatasetRequest) GetChunk() []byte {
 	return nil
 }
 
+func (x *UploadDatasetRequest) GetIndexedChunk() *UploadChunk {
+	if x != nil {
+		if x, ok := x.Data.(*UploadDatasetRequest_IndexedChunk); ok {
+			return x.IndexedChunk
+		}
+	}
+	return nil
+}
+
 type isUploadDatasetRequest_Data interface {
 	isUploadDatasetRequest_Data()
 }
@@ -1215,10 +1319,72 @@ type UploadDatasetRequest_Chunk struct {
 	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
 }
 
+type UploadDatasetRequest_IndexedChunk struct {
+	// Indexed chunk data, used when metadata lists chunk_hashes.
+	IndexedChunk *UploadChunk `protobuf:"bytes,3,opt,name=indexed_chunk,json=indexedChunk,proto3,oneof"`
+}
+
 func (*UploadDatasetRequest_Metadata) isUploadDatasetRequest_Data() {}
 
 func (*UploadDatasetRequest_Chunk) isUploadDatasetRequest_Data() {}
 
+func (*UploadDatasetRequest_IndexedChunk) isUploadDatasetRequest_Data() {}
+
+// UploadChunk is one chunk of a manifest upload.
+type UploadChunk struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index into UploadMetadata.chunk_hashes.
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Chunk content; its SHA-256 must match chunk_hashes[index].
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunk) Reset() {
+	*x = UploadChunk{}
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunk) ProtoMessage() {}
+
+func (x *UploadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunk.ProtoReflect.Descriptor instead.
+func (*UploadChunk) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UploadChunk) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *UploadChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 // UploadMetadata contains upload metadata.
 type UploadMetadata struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1243,14 +1409,19 @@ type UploadMetadata struct {
 	// Tags.
 	Tags []string `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
 	// Metadata.
-	Metadata      map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Size of every chunk but the last (required with chunk_hashes).
+	ChunkSize int64 `protobuf:"varint,12,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// SHA-256 of each chunk, in order. When set, the upload only needs to
+	// carry the chunks the node is missing, as indexed_chunk messages.
+	ChunkHashes   []string `protobuf:"bytes,13,rep,name=chunk_hashes,json=chunkHashes,proto3" json:"chunk_hashes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UploadMetadata) Reset() {
 	*x = UploadMetadata{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[14]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1262,7 +1433,7 @@ func (x *UploadMetadata) String() string {
 func (*UploadMetadata) ProtoMessage() {}
 
 func (x *UploadMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[14]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1275,7 +1446,7 @@ func (x *UploadMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadMetadata.ProtoReflect.Descriptor instead.
 func (*UploadMetadata) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{14}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UploadMetadata) GetDatasetId() string {
@@ -1355,6 +1526,20 @@ func (x *UploadMetadata) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *UploadMetadata) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *UploadMetadata) GetChunkHashes() []string {
+	if x != nil {
+		return x.ChunkHashes
+	}
+	return nil
+}
+
 // UploadDatasetResponse contains upload result.
 type UploadDatasetResponse struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
@@ -1364,14 +1549,21 @@ type UploadDatasetResponse struct {
 	// Chunks created.
 	ChunksCreated int32 `protobuf:"varint,3,opt,name=chunks_created,json=chunksCreated,proto3" json:"chunks_created,omitempty"`
 	// New version number.
-	Version       int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Version int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Chunks that were already stored and not re-sent.
+	ChunksDeduplicated int32 `protobuf:"varint,5,opt,name=chunks_deduplicated,json=chunksDeduplicated,proto3" json:"chunks_deduplicated,omitempty"`
+	// True if the content matched the latest version and no version was created.
+	Unchanged bool `protobuf:"varint,6,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	// Places where the content does not match the data schema. Only set in
+	// "warn" mode; in "enforce" mode the upload is rejected instead.
+	SchemaViolations []*v1.SchemaViolation `protobuf:"bytes,7,rep,name=schema_violations,json=schemaViolations,proto3" json:"schema_violations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UploadDatasetResponse) Reset() {
 	*x = UploadDatasetResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[15]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1383,7 +1575,7 @@ func (x *UploadDatasetResponse) String() string {
 func (*UploadDatasetResponse) ProtoMessage() {}
 
 func (x *UploadDatasetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[15]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1396,7 +1588,7 @@ func (x *UploadDatasetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UploadDatasetResponse.ProtoReflect.Descriptor instead.
 func (*UploadDatasetResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{15}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UploadDatasetResponse) GetDataset() *Dataset {
@@ -1427,6 +1619,117 @@ func (x *UploadDatasetResponse) GetVersion() int32 {
 	return 0
 }
 
+func (x *UploadDatasetResponse) GetChunksDeduplicated() int32 {
+	if x != nil {
+		return x.ChunksDeduplicated
+	}
+	return 0
+}
+
+func (x *UploadDatasetResponse) GetUnchanged() bool {
+	if x != nil {
+		return x.Unchanged
+	}
+	return false
+}
+
+func (x *UploadDatasetResponse) GetSchemaViolations() []*v1.SchemaViolation {
+	if x != nil {
+		return x.SchemaViolations
+	}
+	return nil
+}
+
+// FindMissingChunksRequest lists chunk hashes to check.
+type FindMissingChunksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hashes        []string               `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMissingChunksRequest) Reset() {
+	*x = FindMissingChunksRequest{}
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMissingChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMissingChunksRequest) ProtoMessage() {}
+
+func (x *FindMissingChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMissingChunksRequest.ProtoReflect.Descriptor instead.
+func (*FindMissingChunksRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FindMissingChunksRequest) GetHashes() []string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// FindMissingChunksResponse lists the hashes the node does not have.
+type FindMissingChunksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Missing       []string               `protobuf:"bytes,1,rep,name=missing,proto3" json:"missing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMissingChunksResponse) Reset() {
+	*x = FindMissingChunksResponse{}
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMissingChunksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMissingChunksResponse) ProtoMessage() {}
+
+func (x *FindMissingChunksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMissingChunksResponse.ProtoReflect.Descriptor instead.
+func (*FindMissingChunksResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FindMissingChunksResponse) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
 // DownloadDatasetRequest requests dataset download.
 type DownloadDatasetRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1446,7 +1749,7 @@ type DownloadDatasetRequest struct {
 
 func (x *DownloadDatasetRequest) Reset() {
 	*x = DownloadDatasetRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[16]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1458,7 +1761,7 @@ func (x *DownloadDatasetRequest) String() string {
 func (*DownloadDatasetRequest) ProtoMessage() {}
 
 func (x *DownloadDatasetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[16]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,7 +1774,7 @@ func (x *DownloadDatasetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadDatasetRequest.ProtoReflect.Descriptor instead.
 func (*DownloadDatasetRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{16}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DownloadDatasetRequest) GetId() string {
@@ -1523,7 +1826,7 @@ type DownloadDatasetResponse struct {
 
 func (x *DownloadDatasetResponse) Reset() {
 	*x = DownloadDatasetResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[17]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1535,7 +1838,7 @@ func (x *DownloadDatasetResponse) String() string {
 func (*DownloadDatasetResponse) ProtoMessage() {}
 
 func (x *DownloadDatasetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[17]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1548,7 +1851,7 @@ func (x *DownloadDatasetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadDatasetResponse.ProtoReflect.Descriptor instead.
 func (*DownloadDatasetResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{17}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DownloadDatasetResponse) GetData() isDownloadDatasetResponse_Data {
@@ -1596,17 +1899,23 @@ func (*DownloadDatasetResponse_Chunk) isDownloadDatasetResponse_Data() {}
 
 // DownloadMetadata contains download metadata.
 type DownloadMetadata struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Dataset       *Dataset               `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
-	TotalChunks   int32                  `protobuf:"varint,2,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
-	TotalSize     int64                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Dataset     *Dataset               `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	TotalChunks int32                  `protobuf:"varint,2,opt,name=total_chunks,json=totalChunks,proto3" json:"total_chunks,omitempty"`
+	TotalSize   int64                  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// SHA-256 of the full content, for verifying the reassembled export.
+	ContentHash string `protobuf:"bytes,4,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"`
+	// Size of every chunk but the last; chunk i starts at i * chunk_size.
+	ChunkSize int64 `protobuf:"varint,5,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// Version being downloaded, so a resumed export can detect a new version.
+	VersionId     string `protobuf:"bytes,6,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DownloadMetadata) Reset() {
 	*x = DownloadMetadata{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[18]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1618,7 +1927,7 @@ func (x *DownloadMetadata) String() string {
 func (*DownloadMetadata) ProtoMessage() {}
 
 func (x *DownloadMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[18]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1631,7 +1940,7 @@ func (x *DownloadMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DownloadMetadata.ProtoReflect.Descriptor instead.
 func (*DownloadMetadata) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{18}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DownloadMetadata) GetDataset() *Dataset {
@@ -1655,6 +1964,27 @@ func (x *DownloadMetadata) GetTotalSize() int64 {
 	return 0
 }
 
+func (x *DownloadMetadata) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
+func (x *DownloadMetadata) GetChunkSize() int64 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *DownloadMetadata) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
 // ChunkData contains chunk content.
 type ChunkData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1668,7 +1998,7 @@ type ChunkData struct {
 
 func (x *ChunkData) Reset() {
 	*x = ChunkData{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[19]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1680,7 +2010,7 @@ func (x *ChunkData) String() string {
 func (*ChunkData) ProtoMessage() {}
 
 func (x *ChunkData) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[19]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1693,7 +2023,7 @@ func (x *ChunkData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChunkData.ProtoReflect.Descriptor instead.
 func (*ChunkData) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{19}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ChunkData) GetIndex() int32 {
@@ -1735,7 +2065,7 @@ type GetDatasetVersionsRequest struct {
 
 func (x *GetDatasetVersionsRequest) Reset() {
 	*x = GetDatasetVersionsRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[20]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1747,7 +2077,7 @@ func (x *GetDatasetVersionsRequest) String() string {
 func (*GetDatasetVersionsRequest) ProtoMessage() {}
 
 func (x *GetDatasetVersionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[20]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1760,7 +2090,7 @@ func (x *GetDatasetVersionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDatasetVersionsRequest.ProtoReflect.Descriptor instead.
 func (*GetDatasetVersionsRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{20}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetDatasetVersionsRequest) GetDatasetId() string {
@@ -1788,7 +2118,7 @@ type GetDatasetVersionsResponse struct {
 
 func (x *GetDatasetVersionsResponse) Reset() {
 	*x = GetDatasetVersionsResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[21]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1800,7 +2130,7 @@ func (x *GetDatasetVersionsResponse) String() string {
 func (*GetDatasetVersionsResponse) ProtoMessage() {}
 
 func (x *GetDatasetVersionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[21]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1813,7 +2143,7 @@ func (x *GetDatasetVersionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDatasetVersionsResponse.ProtoReflect.Descriptor instead.
 func (*GetDatasetVersionsResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{21}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetDatasetVersionsResponse) GetVersions() []*DatasetVersion {
@@ -1841,7 +2171,7 @@ type GetVersionRequest struct {
 
 func (x *GetVersionRequest) Reset() {
 	*x = GetVersionRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[22]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1853,7 +2183,7 @@ func (x *GetVersionRequest) String() string {
 func (*GetVersionRequest) ProtoMessage() {}
 
 func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[22]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1866,7 +2196,7 @@ func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
 func (*GetVersionRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{22}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetVersionRequest) GetDatasetId() string {
@@ -1893,7 +2223,7 @@ type GetVersionResponse struct {
 
 func (x *GetVersionResponse) Reset() {
 	*x = GetVersionResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[23]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1905,7 +2235,7 @@ func (x *GetVersionResponse) String() string {
 func (*GetVersionResponse) ProtoMessage() {}
 
 func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[23]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1918,7 +2248,7 @@ func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVersionResponse.ProtoReflect.Descriptor instead.
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{23}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetVersionResponse) GetVersion() *DatasetVersion {
@@ -1928,6 +2258,131 @@ func (x *GetVersionResponse) GetVersion() *DatasetVersion {
 	return nil
 }
 
+// RevertToVersionRequest restores an earlier version.
+type RevertToVersionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DatasetId string                 `protobuf:"bytes,1,opt,name=dataset_id,json=datasetId,proto3" json:"dataset_id,omitempty"`
+	// Version to restore.
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Change description (default: "Revert to version N").
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Revision the dataset must still have; 0 skips the check.
+	ExpectedRevision int64 `protobuf:"varint,4,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RevertToVersionRequest) Reset() {
+	*x = RevertToVersionRequest{}
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertToVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertToVersionRequest) ProtoMessage() {}
+
+func (x *RevertToVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertToVersionRequest.ProtoReflect.Descriptor instead.
+func (*RevertToVersionRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RevertToVersionRequest) GetDatasetId() string {
+	if x != nil {
+		return x.DatasetId
+	}
+	return ""
+}
+
+func (x *RevertToVersionRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RevertToVersionRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RevertToVersionRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
+// RevertToVersionResponse contains the new version.
+type RevertToVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dataset       *Dataset               `protobuf:"bytes,1,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	Version       *DatasetVersion        `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertToVersionResponse) Reset() {
+	*x = RevertToVersionResponse{}
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertToVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertToVersionResponse) ProtoMessage() {}
+
+func (x *RevertToVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertToVersionResponse.ProtoReflect.Descriptor instead.
+func (*RevertToVersionResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RevertToVersionResponse) GetDataset() *Dataset {
+	if x != nil {
+		return x.Dataset
+	}
+	return nil
+}
+
+func (x *RevertToVersionResponse) GetVersion() *DatasetVersion {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
 // GetChunkRequest retrieves a chunk.
 type GetChunkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1940,7 +2395,7 @@ type GetChunkRequest struct {
 
 func (x *GetChunkRequest) Reset() {
 	*x = GetChunkRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[24]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1952,7 +2407,7 @@ func (x *GetChunkRequest) String() string {
 func (*GetChunkRequest) ProtoMessage() {}
 
 func (x *GetChunkRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[24]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1965,7 +2420,7 @@ func (x *GetChunkRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChunkRequest.ProtoReflect.Descriptor instead.
 func (*GetChunkRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{24}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetChunkRequest) GetDatasetId() string {
@@ -1999,7 +2454,7 @@ type GetChunkResponse struct {
 
 func (x *GetChunkResponse) Reset() {
 	*x = GetChunkResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[25]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2011,7 +2466,7 @@ func (x *GetChunkResponse) String() string {
 func (*GetChunkResponse) ProtoMessage() {}
 
 func (x *GetChunkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[25]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2024,7 +2479,7 @@ func (x *GetChunkResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetChunkResponse.ProtoReflect.Descriptor instead.
 func (*GetChunkResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{25}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *GetChunkResponse) GetChunk() *ChunkData {
@@ -2046,7 +2501,7 @@ type VerifyDatasetRequest struct {
 
 func (x *VerifyDatasetRequest) Reset() {
 	*x = VerifyDatasetRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[26]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2058,7 +2513,7 @@ func (x *VerifyDatasetRequest) String() string {
 func (*VerifyDatasetRequest) ProtoMessage() {}
 
 func (x *VerifyDatasetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[26]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2071,7 +2526,7 @@ func (x *VerifyDatasetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyDatasetRequest.ProtoReflect.Descriptor instead.
 func (*VerifyDatasetRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{26}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *VerifyDatasetRequest) GetDatasetId() string {
@@ -2109,7 +2564,7 @@ type VerifyDatasetResponse struct {
 
 func (x *VerifyDatasetResponse) Reset() {
 	*x = VerifyDatasetResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[27]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2121,7 +2576,7 @@ func (x *VerifyDatasetResponse) String() string {
 func (*VerifyDatasetResponse) ProtoMessage() {}
 
 func (x *VerifyDatasetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[27]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2134,7 +2589,7 @@ func (x *VerifyDatasetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyDatasetResponse.ProtoReflect.Descriptor instead.
 func (*VerifyDatasetResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{27}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *VerifyDatasetResponse) GetValid() bool {
@@ -2185,7 +2640,7 @@ type SearchDatasetsRequest struct {
 
 func (x *SearchDatasetsRequest) Reset() {
 	*x = SearchDatasetsRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[28]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2197,7 +2652,7 @@ func (x *SearchDatasetsRequest) String() string {
 func (*SearchDatasetsRequest) ProtoMessage() {}
 
 func (x *SearchDatasetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[28]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2210,7 +2665,7 @@ func (x *SearchDatasetsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchDatasetsRequest.ProtoReflect.Descriptor instead.
 func (*SearchDatasetsRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{28}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *SearchDatasetsRequest) GetQuery() string {
@@ -2252,7 +2707,7 @@ type SearchDatasetsResponse struct {
 
 func (x *SearchDatasetsResponse) Reset() {
 	*x = SearchDatasetsResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[29]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2264,7 +2719,7 @@ func (x *SearchDatasetsResponse) String() string {
 func (*SearchDatasetsResponse) ProtoMessage() {}
 
 func (x *SearchDatasetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[29]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2277,7 +2732,7 @@ func (x *SearchDatasetsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchDatasetsResponse.ProtoReflect.Descriptor instead.
 func (*SearchDatasetsResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{29}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *SearchDatasetsResponse) GetDatasets() []*Dataset {
@@ -2304,7 +2759,7 @@ type GetDatasetStatsRequest struct {
 
 func (x *GetDatasetStatsRequest) Reset() {
 	*x = GetDatasetStatsRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[30]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2316,7 +2771,7 @@ func (x *GetDatasetStatsRequest) String() string {
 func (*GetDatasetStatsRequest) ProtoMessage() {}
 
 func (x *GetDatasetStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[30]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2329,7 +2784,7 @@ func (x *GetDatasetStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDatasetStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetDatasetStatsRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{30}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetDatasetStatsRequest) GetDatasetId() string {
@@ -2354,7 +2809,7 @@ type GetDatasetStatsResponse struct {
 
 func (x *GetDatasetStatsResponse) Reset() {
 	*x = GetDatasetStatsResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[31]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2366,7 +2821,7 @@ func (x *GetDatasetStatsResponse) String() string {
 func (*GetDatasetStatsResponse) ProtoMessage() {}
 
 func (x *GetDatasetStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[31]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2379,7 +2834,7 @@ func (x *GetDatasetStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDatasetStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetDatasetStatsResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{31}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetDatasetStatsResponse) GetDatasetId() string {
@@ -2436,7 +2891,7 @@ type CopyDatasetRequest struct {
 
 func (x *CopyDatasetRequest) Reset() {
 	*x = CopyDatasetRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[32]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2448,7 +2903,7 @@ func (x *CopyDatasetRequest) String() string {
 func (*CopyDatasetRequest) ProtoMessage() {}
 
 func (x *CopyDatasetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[32]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2461,7 +2916,7 @@ func (x *CopyDatasetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CopyDatasetRequest.ProtoReflect.Descriptor instead.
 func (*CopyDatasetRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{32}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *CopyDatasetRequest) GetSourceDatasetId() string {
@@ -2495,7 +2950,7 @@ type CopyDatasetResponse struct {
 
 func (x *CopyDatasetResponse) Reset() {
 	*x = CopyDatasetResponse{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[33]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2507,7 +2962,7 @@ func (x *CopyDatasetResponse) String() string {
 func (*CopyDatasetResponse) ProtoMessage() {}
 
 func (x *CopyDatasetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[33]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2520,7 +2975,7 @@ func (x *CopyDatasetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CopyDatasetResponse.ProtoReflect.Descriptor instead.
 func (*CopyDatasetResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{33}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *CopyDatasetResponse) GetDataset() *Dataset {
@@ -2541,7 +2996,7 @@ type StreamDatasetEventsRequest struct {
 
 func (x *StreamDatasetEventsRequest) Reset() {
 	*x = StreamDatasetEventsRequest{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[34]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2553,7 +3008,7 @@ func (x *StreamDatasetEventsRequest) String() string {
 func (*StreamDatasetEventsRequest) ProtoMessage() {}
 
 func (x *StreamDatasetEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[34]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2566,7 +3021,7 @@ func (x *StreamDatasetEventsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamDatasetEventsRequest.ProtoReflect.Descriptor instead.
 func (*StreamDatasetEventsRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{34}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *StreamDatasetEventsRequest) GetDatasetIds() []string {
@@ -2596,7 +3051,7 @@ type DatasetEvent struct {
 
 func (x *DatasetEvent) Reset() {
 	*x = DatasetEvent{}
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[35]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2608,7 +3063,7 @@ func (x *DatasetEvent) String() string {
 func (*DatasetEvent) ProtoMessage() {}
 
 func (x *DatasetEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_dataset_proto_msgTypes[35]
+	mi := &file_bib_v1_services_dataset_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2621,7 +3076,7 @@ func (x *DatasetEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DatasetEvent.ProtoReflect.Descriptor instead.
 func (*DatasetEvent) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{35}
+	return file_bib_v1_services_dataset_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *DatasetEvent) GetEventType() string {
@@ -2656,7 +3111,7 @@ var File_bib_v1_services_dataset_proto protoreflect.FileDescriptor
 
 const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\n" +
-	"\x1dbib/v1/services/dataset.proto\x12\x0fbib.v1.services\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13bib/v1/common.proto\"\xa7\x05\n" +
+	"\x1dbib/v1/services/dataset.proto\x12\x0fbib.v1.services\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13bib/v1/common.proto\"\xf8\x05\n" +
 	"\aDataset\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\btopic_id\x18\x02 \x01(\tR\atopicId\x12\x12\n" +
@@ -2680,7 +3135,10 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\x04tags\x18\x0f \x03(\tR\x04tags\x12B\n" +
 	"\bmetadata\x18\x10 \x03(\v2&.bib.v1.services.Dataset.MetadataEntryR\bmetadata\x12#\n" +
 	"\rschema_status\x18\x11 \x01(\tR\fschemaStatus\x123\n" +
-	"\x06source\x18\x12 \x01(\v2\x1b.bib.v1.services.DataSourceR\x06source\x1a;\n" +
+	"\x06source\x18\x12 \x01(\v2\x1b.bib.v1.services.DataSourceR\x06source\x123\n" +
+	"\vdata_schema\x18\x13 \x01(\v2\x12.bib.v1.DataSchemaR\n" +
+	"dataSchema\x12\x1a\n" +
+	"\brevision\x18\x14 \x01(\x03R\brevision\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8d\x01\n" +
@@ -2690,7 +3148,7 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\x03url\x18\x02 \x01(\tR\x03url\x12*\n" +
 	"\x11parent_dataset_id\x18\x03 \x01(\tR\x0fparentDatasetId\x12\x15\n" +
 	"\x06job_id\x18\x04 \x01(\tR\x05jobId\x12\x16\n" +
-	"\x06format\x18\x05 \x01(\tR\x06format\"\xad\x02\n" +
+	"\x06format\x18\x05 \x01(\tR\x06format\"\xe2\x02\n" +
 	"\x0eDatasetVersion\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x1d\n" +
 	"\n" +
@@ -2704,14 +3162,19 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\n" +
 	"created_by\x18\a \x01(\tR\tcreatedBy\x12\x18\n" +
 	"\amessage\x18\b \x01(\tR\amessage\x12%\n" +
-	"\x0eparent_version\x18\t \x01(\x05R\rparentVersion\"\xac\x02\n" +
+	"\x0eparent_version\x18\t \x01(\x05R\rparentVersion\x12\x0e\n" +
+	"\x02id\x18\n" +
+	" \x01(\tR\x02id\x12#\n" +
+	"\rreverted_from\x18\v \x01(\x05R\frevertedFrom\"\xe1\x02\n" +
 	"\x14CreateDatasetRequest\x12\x19\n" +
 	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
 	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
 	"\x04tags\x18\x05 \x03(\tR\x04tags\x12O\n" +
-	"\bmetadata\x18\x06 \x03(\v23.bib.v1.services.CreateDatasetRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x06 \x03(\v23.bib.v1.services.CreateDatasetRequest.MetadataEntryR\bmetadata\x123\n" +
+	"\vdata_schema\x18\a \x01(\v2\x12.bib.v1.DataSchemaR\n" +
+	"dataSchema\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"K\n" +
@@ -2722,11 +3185,12 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\btopic_id\x18\x02 \x01(\tR\atopicId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x04 \x01(\x05R\aversion\x12%\n" +
-	"\x0einclude_source\x18\x05 \x01(\bR\rincludeSource\"\xa3\x01\n" +
+	"\x0einclude_source\x18\x05 \x01(\bR\rincludeSource\"\xde\x01\n" +
 	"\x12GetDatasetResponse\x122\n" +
 	"\adataset\x18\x01 \x01(\v2\x18.bib.v1.services.DatasetR\adataset\x12+\n" +
 	"\x11available_locally\x18\x02 \x01(\bR\x10availableLocally\x12,\n" +
-	"\x12available_on_nodes\x18\x03 \x03(\tR\x10availableOnNodes\"\x89\x02\n" +
+	"\x12available_on_nodes\x18\x03 \x03(\tR\x10availableOnNodes\x129\n" +
+	"\aversion\x18\x04 \x01(\v2\x1f.bib.v1.services.DatasetVersionR\aversion\"\x89\x02\n" +
 	"\x13ListDatasetsRequest\x12\x19\n" +
 	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x19\n" +
@@ -2739,7 +3203,7 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\x04sort\x18\b \x01(\v2\x11.bib.v1.SortOrderR\x04sort\"{\n" +
 	"\x14ListDatasetsResponse\x124\n" +
 	"\bdatasets\x18\x01 \x03(\v2\x18.bib.v1.services.DatasetR\bdatasets\x12-\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\xa4\x03\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\xb4\x04\n" +
 	"\x14UpdateDatasetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
@@ -2749,7 +3213,12 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\vupdate_tags\x18\x06 \x01(\bR\n" +
 	"updateTags\x12O\n" +
 	"\bmetadata\x18\a \x03(\v23.bib.v1.services.UpdateDatasetRequest.MetadataEntryR\bmetadata\x12'\n" +
-	"\x0fupdate_metadata\x18\b \x01(\bR\x0eupdateMetadata\x1a;\n" +
+	"\x0fupdate_metadata\x18\b \x01(\bR\x0eupdateMetadata\x123\n" +
+	"\vdata_schema\x18\t \x01(\v2\x12.bib.v1.DataSchemaR\n" +
+	"dataSchema\x12,\n" +
+	"\x12update_data_schema\x18\n" +
+	" \x01(\bR\x10updateDataSchema\x12+\n" +
+	"\x11expected_revision\x18\v \x01(\x03R\x10expectedRevision\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
@@ -2757,19 +3226,24 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\f_descriptionB\x0f\n" +
 	"\r_content_type\"K\n" +
 	"\x15UpdateDatasetResponse\x122\n" +
-	"\adataset\x18\x01 \x01(\v2\x18.bib.v1.services.DatasetR\adataset\"l\n" +
+	"\adataset\x18\x01 \x01(\v2\x18.bib.v1.services.DatasetR\adataset\"\x99\x01\n" +
 	"\x14DeleteDatasetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
 	"\x13delete_all_versions\x18\x02 \x01(\bR\x11deleteAllVersions\x12\x14\n" +
-	"\x05force\x18\x03 \x01(\bR\x05force\"R\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x12+\n" +
+	"\x11expected_revision\x18\x04 \x01(\x03R\x10expectedRevision\"R\n" +
 	"\x15DeleteDatasetResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
 	"\vbytes_freed\x18\x02 \x01(\x03R\n" +
-	"bytesFreed\"u\n" +
+	"bytesFreed\"\xba\x01\n" +
 	"\x14UploadDatasetRequest\x12=\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1f.bib.v1.services.UploadMetadataH\x00R\bmetadata\x12\x16\n" +
-	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
-	"\x04data\"\xd3\x03\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunk\x12C\n" +
+	"\rindexed_chunk\x18\x03 \x01(\v2\x1c.bib.v1.services.UploadChunkH\x00R\findexedChunkB\x06\n" +
+	"\x04data\"7\n" +
+	"\vUploadChunk\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\x95\x04\n" +
 	"\x0eUploadMetadata\x12\x1d\n" +
 	"\n" +
 	"dataset_id\x18\x01 \x01(\tR\tdatasetId\x12\x19\n" +
@@ -2784,15 +3258,25 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\vdescription\x18\t \x01(\tR\vdescription\x12\x12\n" +
 	"\x04tags\x18\n" +
 	" \x03(\tR\x04tags\x12I\n" +
-	"\bmetadata\x18\v \x03(\v2-.bib.v1.services.UploadMetadata.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\v \x03(\v2-.bib.v1.services.UploadMetadata.MetadataEntryR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\f \x01(\x03R\tchunkSize\x12!\n" +
+	"\fchunk_hashes\x18\r \x03(\tR\vchunkHashes\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x02\n" +
 	"\x15UploadDatasetResponse\x122\n" +
 	"\adataset\x18\x01 \x01(\v2\x18.bib.v1.services.DatasetR\adataset\x12%\n" +
 	"\x0ebytes_uploaded\x18\x02 \x01(\x03R\rbytesUploaded\x12%\n" +
 	"\x0echunks_created\x18\x03 \x01(\x05R\rchunksCreated\x12\x18\n" +
-	"\aversion\x18\x04 \x01(\x05R\aversion\"\xa7\x01\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12/\n" +
+	"\x13chunks_deduplicated\x18\x05 \x01(\x05R\x12chunksDeduplicated\x12\x1c\n" +
+	"\tunchanged\x18\x06 \x01(\bR\tunchanged\x12D\n" +
+	"\x11schema_violations\x18\a \x03(\v2\x17.bib.v1.SchemaViolationR\x10schemaViolations\"2\n" +
+	"\x18FindMissingChunksRequest\x12\x16\n" +
+	"\x06hashes\x18\x01 \x03(\tR\x06hashes\"5\n" +
+	"\x19FindMissingChunksResponse\x12\x18\n" +
+	"\amissing\x18\x01 \x03(\tR\amissing\"\xa7\x01\n" +
 	"\x16DownloadDatasetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x1f\n" +
@@ -2803,12 +3287,17 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\x17DownloadDatasetResponse\x12?\n" +
 	"\bmetadata\x18\x01 \x01(\v2!.bib.v1.services.DownloadMetadataH\x00R\bmetadata\x122\n" +
 	"\x05chunk\x18\x02 \x01(\v2\x1a.bib.v1.services.ChunkDataH\x00R\x05chunkB\x06\n" +
-	"\x04data\"\x88\x01\n" +
+	"\x04data\"\xe9\x01\n" +
 	"\x10DownloadMetadata\x122\n" +
 	"\adataset\x18\x01 \x01(\v2\x18.bib.v1.services.DatasetR\adataset\x12!\n" +
 	"\ftotal_chunks\x18\x02 \x01(\x05R\vtotalChunks\x12\x1d\n" +
 	"\n" +
-	"total_size\x18\x03 \x01(\x03R\ttotalSize\"]\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\x12!\n" +
+	"\fcontent_hash\x18\x04 \x01(\tR\vcontentHash\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x05 \x01(\x03R\tchunkSize\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x06 \x01(\tR\tversionId\"]\n" +
 	"\tChunkData\x12\x14\n" +
 	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
 	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
@@ -2826,7 +3315,16 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"dataset_id\x18\x01 \x01(\tR\tdatasetId\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\x05R\aversion\"O\n" +
 	"\x12GetVersionResponse\x129\n" +
-	"\aversion\x18\x01 \x01(\v2\x1f.bib.v1.services.DatasetVersionR\aversion\"k\n" +
+	"\aversion\x18\x01 \x01(\v2\x1f.bib.v1.services.DatasetVersionR\aversion\"\x98\x01\n" +
+	"\x16RevertToVersionRequest\x12\x1d\n" +
+	"\n" +
+	"dataset_id\x18\x01 \x01(\tR\tdatasetId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12+\n" +
+	"\x11expected_revision\x18\x04 \x01(\x03R\x10expectedRevision\"\x88\x01\n" +
+	"\x17RevertToVersionResponse\x122\n" +
+	"\adataset\x18\x01 \x01(\v2\x18.bib.v1.services.DatasetR\adataset\x129\n" +
+	"\aversion\x18\x02 \x01(\v2\x1f.bib.v1.services.DatasetVersionR\aversion\"k\n" +
 	"\x0fGetChunkRequest\x12\x1d\n" +
 	"\n" +
 	"dataset_id\x18\x01 \x01(\tR\tdatasetId\x12\x18\n" +
@@ -2880,7 +3378,7 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"event_type\x18\x01 \x01(\tR\teventType\x122\n" +
 	"\adataset\x18\x02 \x01(\v2\x18.bib.v1.services.DatasetR\adataset\x128\n" +
 	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12$\n" +
-	"\x0esource_node_id\x18\x04 \x01(\tR\fsourceNodeId2\xad\v\n" +
+	"\x0esource_node_id\x18\x04 \x01(\tR\fsourceNodeId2\xd3\r\n" +
 	"\x0eDatasetService\x12^\n" +
 	"\rCreateDataset\x12%.bib.v1.services.CreateDatasetRequest\x1a&.bib.v1.services.CreateDatasetResponse\x12U\n" +
 	"\n" +
@@ -2888,17 +3386,20 @@ const file_bib_v1_services_dataset_proto_rawDesc = "" +
 	"\fListDatasets\x12$.bib.v1.services.ListDatasetsRequest\x1a%.bib.v1.services.ListDatasetsResponse\x12^\n" +
 	"\rUpdateDataset\x12%.bib.v1.services.UpdateDatasetRequest\x1a&.bib.v1.services.UpdateDatasetResponse\x12^\n" +
 	"\rDeleteDataset\x12%.bib.v1.services.DeleteDatasetRequest\x1a&.bib.v1.services.DeleteDatasetResponse\x12`\n" +
-	"\rUploadDataset\x12%.bib.v1.services.UploadDatasetRequest\x1a&.bib.v1.services.UploadDatasetResponse(\x01\x12f\n" +
+	"\rUploadDataset\x12%.bib.v1.services.UploadDatasetRequest\x1a&.bib.v1.services.UploadDatasetResponse(\x01\x12j\n" +
+	"\x11FindMissingChunks\x12).bib.v1.services.FindMissingChunksRequest\x1a*.bib.v1.services.FindMissingChunksResponse\x12f\n" +
 	"\x0fDownloadDataset\x12'.bib.v1.services.DownloadDatasetRequest\x1a(.bib.v1.services.DownloadDatasetResponse0\x01\x12m\n" +
 	"\x12GetDatasetVersions\x12*.bib.v1.services.GetDatasetVersionsRequest\x1a+.bib.v1.services.GetDatasetVersionsResponse\x12U\n" +
 	"\n" +
-	"GetVersion\x12\".bib.v1.services.GetVersionRequest\x1a#.bib.v1.services.GetVersionResponse\x12O\n" +
+	"GetVersion\x12\".bib.v1.services.GetVersionRequest\x1a#.bib.v1.services.GetVersionResponse\x12d\n" +
+	"\x0fRevertToVersion\x12'.bib.v1.services.RevertToVersionRequest\x1a(.bib.v1.services.RevertToVersionResponse\x12O\n" +
 	"\bGetChunk\x12 .bib.v1.services.GetChunkRequest\x1a!.bib.v1.services.GetChunkResponse\x12^\n" +
 	"\rVerifyDataset\x12%.bib.v1.services.VerifyDatasetRequest\x1a&.bib.v1.services.VerifyDatasetResponse\x12a\n" +
 	"\x0eSearchDatasets\x12&.bib.v1.services.SearchDatasetsRequest\x1a'.bib.v1.services.SearchDatasetsResponse\x12d\n" +
 	"\x0fGetDatasetStats\x12'.bib.v1.services.GetDatasetStatsRequest\x1a(.bib.v1.services.GetDatasetStatsResponse\x12X\n" +
 	"\vCopyDataset\x12#.bib.v1.services.CopyDatasetRequest\x1a$.bib.v1.services.CopyDatasetResponse\x12c\n" +
-	"\x13StreamDatasetEvents\x12+.bib.v1.services.StreamDatasetEventsRequest\x1a\x1d.bib.v1.services.DatasetEvent0\x01B\xa1\x01\n" +
+	"\x13StreamDatasetEvents\x12+.bib.v1.services.StreamDatasetEventsRequest\x1a\x1d.bib.v1.services.DatasetEvent0\x01\x12R\n" +
+	"\x0eStreamDatasets\x12$.bib.v1.services.ListDatasetsRequest\x1a\x18.bib.v1.services.Dataset0\x01B\xa1\x01\n" +
 	"\x13com.bib.v1.servicesB\fDatasetProtoP\x01Z\x1ebib/api/gen/go/bib/v1/services\xa2\x02\x03BVS\xaa\x02\x0fBib.V1.Services\xca\x02\x0fBib\\V1\\Services\xe2\x02\x1bBib\\V1\\Services\\GPBMetadata\xea\x02\x11Bib::V1::Servicesb\x06proto3"
 
 var (
@@ -2913,7 +3414,7 @@ func file_bib_v1_services_dataset_proto_rawDescGZIP() []byte {
 	return file_bib_v1_services_dataset_proto_rawDescData
 }
 
-var file_bib_v1_services_dataset_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
+var file_bib_v1_services_dataset_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_bib_v1_services_dataset_proto_goTypes = []any{
 	(*Dataset)(nil),                    // 0: bib.v1.services.Dataset
 	(*DataSource)(nil),                 // 1: bib.v1.services.DataSource
@@ -2929,105 +3430,126 @@ var file_bib_v1_services_dataset_proto_goTypes = []any{
 	(*DeleteDatasetRequest)(nil),       // 11: bib.v1.services.DeleteDatasetRequest
 	(*DeleteDatasetResponse)(nil),      // 12: bib.v1.services.DeleteDatasetResponse
 	(*UploadDatasetRequest)(nil),       // 13: bib.v1.services.UploadDatasetRequest
-	(*UploadMetadata)(nil),             // 14: bib.v1.services.UploadMetadata
-	(*UploadDatasetResponse)(nil),      // 15: bib.v1.services.UploadDatasetResponse
-	(*DownloadDatasetRequest)(nil),     // 16: bib.v1.services.DownloadDatasetRequest
-	(*DownloadDatasetResponse)(nil),    // 17: bib.v1.services.DownloadDatasetResponse
-	(*DownloadMetadata)(nil),           // 18: bib.v1.services.DownloadMetadata
-	(*ChunkData)(nil),                  // 19: bib.v1.services.ChunkData
-	(*GetDatasetVersionsRequest)(nil),  // 20: bib.v1.services.GetDatasetVersionsRequest
-	(*GetDatasetVersionsResponse)(nil), // 21: bib.v1.services.GetDatasetVersionsResponse
-	(*GetVersionRequest)(nil),          // 22: bib.v1.services.GetVersionRequest
-	(*GetVersionResponse)(nil),         // 23: bib.v1.services.GetVersionResponse
-	(*GetChunkRequest)(nil),            // 24: bib.v1.services.GetChunkRequest
-	(*GetChunkResponse)(nil),           // 25: bib.v1.services.GetChunkResponse
-	(*VerifyDatasetRequest)(nil),       // 26: bib.v1.services.VerifyDatasetRequest
-	(*VerifyDatasetResponse)(nil),      // 27: bib.v1.services.VerifyDatasetResponse
-	(*SearchDatasetsRequest)(nil),      // 28: bib.v1.services.SearchDatasetsRequest
-	(*SearchDatasetsResponse)(nil),     // 29: bib.v1.services.SearchDatasetsResponse
-	(*GetDatasetStatsRequest)(nil),     // 30: bib.v1.services.GetDatasetStatsRequest
-	(*GetDatasetStatsResponse)(nil),    // 31: bib.v1.services.GetDatasetStatsResponse
-	(*CopyDatasetRequest)(nil),         // 32: bib.v1.services.CopyDatasetRequest
-	(*CopyDatasetResponse)(nil),        // 33: bib.v1.services.CopyDatasetResponse
-	(*StreamDatasetEventsRequest)(nil), // 34: bib.v1.services.StreamDatasetEventsRequest
-	(*DatasetEvent)(nil),               // 35: bib.v1.services.DatasetEvent
-	nil,                                // 36: bib.v1.services.Dataset.MetadataEntry
-	nil,                                // 37: bib.v1.services.CreateDatasetRequest.MetadataEntry
-	nil,                                // 38: bib.v1.services.UpdateDatasetRequest.MetadataEntry
-	nil,                                // 39: bib.v1.services.UploadMetadata.MetadataEntry
-	(*timestamppb.Timestamp)(nil),      // 40: google.protobuf.Timestamp
-	(*v1.PageRequest)(nil),             // 41: bib.v1.PageRequest
-	(*v1.SortOrder)(nil),               // 42: bib.v1.SortOrder
-	(*v1.PageInfo)(nil),                // 43: bib.v1.PageInfo
+	(*UploadChunk)(nil),                // 14: bib.v1.services.UploadChunk
+	(*UploadMetadata)(nil),             // 15: bib.v1.services.UploadMetadata
+	(*UploadDatasetResponse)(nil),      // 16: bib.v1.services.UploadDatasetResponse
+	(*FindMissingChunksRequest)(nil),   // 17: bib.v1.services.FindMissingChunksRequest
+	(*FindMissingChunksResponse)(nil),  // 18: bib.v1.services.FindMissingChunksResponse
+	(*DownloadDatasetRequest)(nil),     // 19: bib.v1.services.DownloadDatasetRequest
+	(*DownloadDatasetResponse)(nil),    // 20: bib.v1.services.DownloadDatasetResponse
+	(*DownloadMetadata)(nil),           // 21: bib.v1.services.DownloadMetadata
+	(*ChunkData)(nil),                  // 22: bib.v1.services.ChunkData
+	(*GetDatasetVersionsRequest)(nil),  // 23: bib.v1.services.GetDatasetVersionsRequest
+	(*GetDatasetVersionsResponse)(nil), // 24: bib.v1.services.GetDatasetVersionsResponse
+	(*GetVersionRequest)(nil),          // 25: bib.v1.services.GetVersionRequest
+	(*GetVersionResponse)(nil),         // 26: bib.v1.services.GetVersionResponse
+	(*RevertToVersionRequest)(nil),     // 27: bib.v1.services.RevertToVersionRequest
+	(*RevertToVersionResponse)(nil),    // 28: bib.v1.services.RevertToVersionResponse
+	(*GetChunkRequest)(nil),            // 29: bib.v1.services.GetChunkRequest
+	(*GetChunkResponse)(nil),           // 30: bib.v1.services.GetChunkResponse
+	(*VerifyDatasetRequest)(nil),       // 31: bib.v1.services.VerifyDatasetRequest
+	(*VerifyDatasetResponse)(nil),      // 32: bib.v1.services.VerifyDatasetResponse
+	(*SearchDatasetsRequest)(nil),      // 33: bib.v1.services.SearchDatasetsRequest
+	(*SearchDatasetsResponse)(nil),     // 34: bib.v1.services.SearchDatasetsResponse
+	(*GetDatasetStatsRequest)(nil),     // 35: bib.v1.services.GetDatasetStatsRequest
+	(*GetDatasetStatsResponse)(nil),    // 36: bib.v1.services.GetDatasetStatsResponse
+	(*CopyDatasetRequest)(nil),         // 37: bib.v1.services.CopyDatasetRequest
+	(*CopyDatasetResponse)(nil),        // 38: bib.v1.services.CopyDatasetResponse
+	(*StreamDatasetEventsRequest)(nil), // 39: bib.v1.services.StreamDatasetEventsRequest
+	(*DatasetEvent)(nil),               // 40: bib.v1.services.DatasetEvent
+	nil,                                // 41: bib.v1.services.Dataset.MetadataEntry
+	nil,                                // 42: bib.v1.services.CreateDatasetRequest.MetadataEntry
+	nil,                                // 43: bib.v1.services.UpdateDatasetRequest.MetadataEntry
+	nil,                                // 44: bib.v1.services.UploadMetadata.MetadataEntry
+	(*timestamppb.Timestamp)(nil),      // 45: google.protobuf.Timestamp
+	(*v1.DataSchema)(nil),              // 46: bib.v1.DataSchema
+	(*v1.PageRequest)(nil),             // 47: bib.v1.PageRequest
+	(*v1.SortOrder)(nil),               // 48: bib.v1.SortOrder
+	(*v1.PageInfo)(nil),                // 49: bib.v1.PageInfo
+	(*v1.SchemaViolation)(nil),         // 50: bib.v1.SchemaViolation
 }
 var file_bib_v1_services_dataset_proto_depIdxs = []int32{
-	40, // 0: bib.v1.services.Dataset.created_at:type_name -> google.protobuf.Timestamp
-	40, // 1: bib.v1.services.Dataset.updated_at:type_name -> google.protobuf.Timestamp
-	36, // 2: bib.v1.services.Dataset.metadata:type_name -> bib.v1.services.Dataset.MetadataEntry
+	45, // 0: bib.v1.services.Dataset.created_at:type_name -> google.protobuf.Timestamp
+	45, // 1: bib.v1.services.Dataset.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 2: bib.v1.services.Dataset.metadata:type_name -> bib.v1.services.Dataset.MetadataEntry
 	1,  // 3: bib.v1.services.Dataset.source:type_name -> bib.v1.services.DataSource
-	40, // 4: bib.v1.services.DatasetVersion.created_at:type_name -> google.protobuf.Timestamp
-	37, // 5: bib.v1.services.CreateDatasetRequest.metadata:type_name -> bib.v1.services.CreateDatasetRequest.MetadataEntry
-	0,  // 6: bib.v1.services.CreateDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
-	0,  // 7: bib.v1.services.GetDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
-	41, // 8: bib.v1.services.ListDatasetsRequest.page:type_name -> bib.v1.PageRequest
-	42, // 9: bib.v1.services.ListDatasetsRequest.sort:type_name -> bib.v1.SortOrder
-	0,  // 10: bib.v1.services.ListDatasetsResponse.datasets:type_name -> bib.v1.services.Dataset
-	43, // 11: bib.v1.services.ListDatasetsResponse.page_info:type_name -> bib.v1.PageInfo
-	38, // 12: bib.v1.services.UpdateDatasetRequest.metadata:type_name -> bib.v1.services.UpdateDatasetRequest.MetadataEntry
-	0,  // 13: bib.v1.services.UpdateDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
-	14, // 14: bib.v1.services.UploadDatasetRequest.metadata:type_name -> bib.v1.services.UploadMetadata
-	39, // 15: bib.v1.services.UploadMetadata.metadata:type_name -> bib.v1.services.UploadMetadata.MetadataEntry
-	0,  // 16: bib.v1.services.UploadDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
-	18, // 17: bib.v1.services.DownloadDatasetResponse.metadata:type_name -> bib.v1.services.DownloadMetadata
-	19, // 18: bib.v1.services.DownloadDatasetResponse.chunk:type_name -> bib.v1.services.ChunkData
-	0,  // 19: bib.v1.services.DownloadMetadata.dataset:type_name -> bib.v1.services.Dataset
-	41, // 20: bib.v1.services.GetDatasetVersionsRequest.page:type_name -> bib.v1.PageRequest
-	2,  // 21: bib.v1.services.GetDatasetVersionsResponse.versions:type_name -> bib.v1.services.DatasetVersion
-	43, // 22: bib.v1.services.GetDatasetVersionsResponse.page_info:type_name -> bib.v1.PageInfo
-	2,  // 23: bib.v1.services.GetVersionResponse.version:type_name -> bib.v1.services.DatasetVersion
-	19, // 24: bib.v1.services.GetChunkResponse.chunk:type_name -> bib.v1.services.ChunkData
-	41, // 25: bib.v1.services.SearchDatasetsRequest.page:type_name -> bib.v1.PageRequest
-	0,  // 26: bib.v1.services.SearchDatasetsResponse.datasets:type_name -> bib.v1.services.Dataset
-	43, // 27: bib.v1.services.SearchDatasetsResponse.page_info:type_name -> bib.v1.PageInfo
-	40, // 28: bib.v1.services.GetDatasetStatsResponse.last_accessed:type_name -> google.protobuf.Timestamp
-	0,  // 29: bib.v1.services.CopyDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
-	0,  // 30: bib.v1.services.DatasetEvent.dataset:type_name -> bib.v1.services.Dataset
-	40, // 31: bib.v1.services.DatasetEvent.timestamp:type_name -> google.protobuf.Timestamp
-	3,  // 32: bib.v1.services.DatasetService.CreateDataset:input_type -> bib.v1.services.CreateDatasetRequest
-	5,  // 33: bib.v1.services.DatasetService.GetDataset:input_type -> bib.v1.services.GetDatasetRequest
-	7,  // 34: bib.v1.services.DatasetService.ListDatasets:input_type -> bib.v1.services.ListDatasetsRequest
-	9,  // 35: bib.v1.services.DatasetService.UpdateDataset:input_type -> bib.v1.services.UpdateDatasetRequest
-	11, // 36: bib.v1.services.DatasetService.DeleteDataset:input_type -> bib.v1.services.DeleteDatasetRequest
-	13, // 37: bib.v1.services.DatasetService.UploadDataset:input_type -> bib.v1.services.UploadDatasetRequest
-	16, // 38: bib.v1.services.DatasetService.DownloadDataset:input_type -> bib.v1.services.DownloadDatasetRequest
-	20, // 39: bib.v1.services.DatasetService.GetDatasetVersions:input_type -> bib.v1.services.GetDatasetVersionsRequest
-	22, // 40: bib.v1.services.DatasetService.GetVersion:input_type -> bib.v1.services.GetVersionRequest
-	24, // 41: bib.v1.services.DatasetService.GetChunk:input_type -> bib.v1.services.GetChunkRequest
-	26, // 42: bib.v1.services.DatasetService.VerifyDataset:input_type -> bib.v1.services.VerifyDatasetRequest
-	28, // 43: bib.v1.services.DatasetService.SearchDatasets:input_type -> bib.v1.services.SearchDatasetsRequest
-	30, // 44: bib.v1.services.DatasetService.GetDatasetStats:input_type -> bib.v1.services.GetDatasetStatsRequest
-	32, // 45: bib.v1.services.DatasetService.CopyDataset:input_type -> bib.v1.services.CopyDatasetRequest
-	34, // 46: bib.v1.services.DatasetService.StreamDatasetEvents:input_type -> bib.v1.services.StreamDatasetEventsRequest
-	4,  // 47: bib.v1.services.DatasetService.CreateDataset:output_type -> bib.v1.services.CreateDatasetResponse
-	6,  // 48: bib.v1.services.DatasetService.GetDataset:output_type -> bib.v1.services.GetDatasetResponse
-	8,  // 49: bib.v1.services.DatasetService.ListDatasets:output_type -> bib.v1.services.ListDatasetsResponse
-	10, // 50: bib.v1.services.DatasetService.UpdateDataset:output_type -> bib.v1.services.UpdateDatasetResponse
-	12, // 51: bib.v1.services.DatasetService.DeleteDataset:output_type -> bib.v1.services.DeleteDatasetResponse
-	15, // 52: bib.v1.services.DatasetService.UploadDataset:output_type -> bib.v1.services.UploadDatasetResponse
-	17, // 53: bib.v1.services.DatasetService.DownloadDataset:output_type -> bib.v1.services.DownloadDatasetResponse
-	21, // 54: bib.v1.services.DatasetService.GetDatasetVersions:output_type -> bib.v1.services.GetDatasetVersionsResponse
-	23, // 55: bib.v1.services.DatasetService.GetVersion:output_type -> bib.v1.services.GetVersionResponse
-	25, // 56: bib.v1.services.DatasetService.GetChunk:output_type -> bib.v1.services.GetChunkResponse
-	27, // 57: bib.v1.services.DatasetService.VerifyDataset:output_type -> bib.v1.services.VerifyDatasetResponse
-	29, // 58: bib.v1.services.DatasetService.SearchDatasets:output_type -> bib.v1.services.SearchDatasetsResponse
-	31, // 59: bib.v1.services.DatasetService.GetDatasetStats:output_type -> bib.v1.services.GetDatasetStatsResponse
-	33, // 60: bib.v1.services.DatasetService.CopyDataset:output_type -> bib.v1.services.CopyDatasetResponse
-	35, // 61: bib.v1.services.DatasetService.StreamDatasetEvents:output_type -> bib.v1.services.DatasetEvent
-	47, // [47:62] is the sub-list for method output_type
-	32, // [32:47] is the sub-list for method input_type
-	32, // [32:32] is the sub-list for extension type_name
-	32, // [32:32] is the sub-list for extension extendee
-	0,  // [0:32] is the sub-list for field type_name
+	46, // 4: bib.v1.services.Dataset.data_schema:type_name -> bib.v1.DataSchema
+	45, // 5: bib.v1.services.DatasetVersion.created_at:type_name -> google.protobuf.Timestamp
+	42, // 6: bib.v1.services.CreateDatasetRequest.metadata:type_name -> bib.v1.services.CreateDatasetRequest.MetadataEntry
+	46, // 7: bib.v1.services.CreateDatasetRequest.data_schema:type_name -> bib.v1.DataSchema
+	0,  // 8: bib.v1.services.CreateDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
+	0,  // 9: bib.v1.services.GetDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
+	2,  // 10: bib.v1.services.GetDatasetResponse.version:type_name -> bib.v1.services.DatasetVersion
+	47, // 11: bib.v1.services.ListDatasetsRequest.page:type_name -> bib.v1.PageRequest
+	48, // 12: bib.v1.services.ListDatasetsRequest.sort:type_name -> bib.v1.SortOrder
+	0,  // 13: bib.v1.services.ListDatasetsResponse.datasets:type_name -> bib.v1.services.Dataset
+	49, // 14: bib.v1.services.ListDatasetsResponse.page_info:type_name -> bib.v1.PageInfo
+	43, // 15: bib.v1.services.UpdateDatasetRequest.metadata:type_name -> bib.v1.services.UpdateDatasetRequest.MetadataEntry
+	46, // 16: bib.v1.services.UpdateDatasetRequest.data_schema:type_name -> bib.v1.DataSchema
+	0,  // 17: bib.v1.services.UpdateDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
+	15, // 18: bib.v1.services.UploadDatasetRequest.metadata:type_name -> bib.v1.services.UploadMetadata
+	14, // 19: bib.v1.services.UploadDatasetRequest.indexed_chunk:type_name -> bib.v1.services.UploadChunk
+	44, // 20: bib.v1.services.UploadMetadata.metadata:type_name -> bib.v1.services.UploadMetadata.MetadataEntry
+	0,  // 21: bib.v1.services.UploadDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
+	50, // 22: bib.v1.services.UploadDatasetResponse.schema_violations:type_name -> bib.v1.SchemaViolation
+	21, // 23: bib.v1.services.DownloadDatasetResponse.metadata:type_name -> bib.v1.services.DownloadMetadata
+	22, // 24: bib.v1.services.DownloadDatasetResponse.chunk:type_name -> bib.v1.services.ChunkData
+	0,  // 25: bib.v1.services.DownloadMetadata.dataset:type_name -> bib.v1.services.Dataset
+	47, // 26: bib.v1.services.GetDatasetVersionsRequest.page:type_name -> bib.v1.PageRequest
+	2,  // 27: bib.v1.services.GetDatasetVersionsResponse.versions:type_name -> bib.v1.services.DatasetVersion
+	49, // 28: bib.v1.services.GetDatasetVersionsResponse.page_info:type_name -> bib.v1.PageInfo
+	2,  // 29: bib.v1.services.GetVersionResponse.version:type_name -> bib.v1.services.DatasetVersion
+	0,  // 30: bib.v1.services.RevertToVersionResponse.dataset:type_name -> bib.v1.services.Dataset
+	2,  // 31: bib.v1.services.RevertToVersionResponse.version:type_name -> bib.v1.services.DatasetVersion
+	22, // 32: bib.v1.services.GetChunkResponse.chunk:type_name -> bib.v1.services.ChunkData
+	47, // 33: bib.v1.services.SearchDatasetsRequest.page:type_name -> bib.v1.PageRequest
+	0,  // 34: bib.v1.services.SearchDatasetsResponse.datasets:type_name -> bib.v1.services.Dataset
+	49, // 35: bib.v1.services.SearchDatasetsResponse.page_info:type_name -> bib.v1.PageInfo
+	45, // 36: bib.v1.services.GetDatasetStatsResponse.last_accessed:type_name -> google.protobuf.Timestamp
+	0,  // 37: bib.v1.services.CopyDatasetResponse.dataset:type_name -> bib.v1.services.Dataset
+	0,  // 38: bib.v1.services.DatasetEvent.dataset:type_name -> bib.v1.services.Dataset
+	45, // 39: bib.v1.services.DatasetEvent.timestamp:type_name -> google.protobuf.Timestamp
+	3,  // 40: bib.v1.services.DatasetService.CreateDataset:input_type -> bib.v1.services.CreateDatasetRequest
+	5,  // 41: bib.v1.services.DatasetService.GetDataset:input_type -> bib.v1.services.GetDatasetRequest
+	7,  // 42: bib.v1.services.DatasetService.ListDatasets:input_type -> bib.v1.services.ListDatasetsRequest
+	9,  // 43: bib.v1.services.DatasetService.UpdateDataset:input_type -> bib.v1.services.UpdateDatasetRequest
+	11, // 44: bib.v1.services.DatasetService.DeleteDataset:input_type -> bib.v1.services.DeleteDatasetRequest
+	13, // 45: bib.v1.services.DatasetService.UploadDataset:input_type -> bib.v1.services.UploadDatasetRequest
+	17, // 46: bib.v1.services.DatasetService.FindMissingChunks:input_type -> bib.v1.services.FindMissingChunksRequest
+	19, // 47: bib.v1.services.DatasetService.DownloadDataset:input_type -> bib.v1.services.DownloadDatasetRequest
+	23, // 48: bib.v1.services.DatasetService.GetDatasetVersions:input_type -> bib.v1.services.GetDatasetVersionsRequest
+	25, // 49: bib.v1.services.DatasetService.GetVersion:input_type -> bib.v1.services.GetVersionRequest
+	27, // 50: bib.v1.services.DatasetService.RevertToVersion:input_type -> bib.v1.services.RevertToVersionRequest
+	29, // 51: bib.v1.services.DatasetService.GetChunk:input_type -> bib.v1.services.GetChunkRequest
+	31, // 52: bib.v1.services.DatasetService.VerifyDataset:input_type -> bib.v1.services.VerifyDatasetRequest
+	33, // 53: bib.v1.services.DatasetService.SearchDatasets:input_type -> bib.v1.services.SearchDatasetsRequest
+	35, // 54: bib.v1.services.DatasetService.GetDatasetStats:input_type -> bib.v1.services.GetDatasetStatsRequest
+	37, // 55: bib.v1.services.DatasetService.CopyDataset:input_type -> bib.v1.services.CopyDatasetRequest
+	39, // 56: bib.v1.services.DatasetService.StreamDatasetEvents:input_type -> bib.v1.services.StreamDatasetEventsRequest
+	7,  // 57: bib.v1.services.DatasetService.StreamDatasets:input_type -> bib.v1.services.ListDatasetsRequest
+	4,  // 58: bib.v1.services.DatasetService.CreateDataset:output_type -> bib.v1.services.CreateDatasetResponse
+	6,  // 59: bib.v1.services.DatasetService.GetDataset:output_type -> bib.v1.services.GetDatasetResponse
+	8,  // 60: bib.v1.services.DatasetService.ListDatasets:output_type -> bib.v1.services.ListDatasetsResponse
+	10, // 61: bib.v1.services.DatasetService.UpdateDataset:output_type -> bib.v1.services.UpdateDatasetResponse
+	12, // 62: bib.v1.services.DatasetService.DeleteDataset:output_type -> bib.v1.services.DeleteDatasetResponse
+	16, // 63: bib.v1.services.DatasetService.UploadDataset:output_type -> bib.v1.services.UploadDatasetResponse
+	18, // 64: bib.v1.services.DatasetService.FindMissingChunks:output_type -> bib.v1.services.FindMissingChunksResponse
+	20, // 65: bib.v1.services.DatasetService.DownloadDataset:output_type -> bib.v1.services.DownloadDatasetResponse
+	24, // 66: bib.v1.services.DatasetService.GetDatasetVersions:output_type -> bib.v1.services.GetDatasetVersionsResponse
+	26, // 67: bib.v1.services.DatasetService.GetVersion:output_type -> bib.v1.services.GetVersionResponse
+	28, // 68: bib.v1.services.DatasetService.RevertToVersion:output_type -> bib.v1.services.RevertToVersionResponse
+	30, // 69: bib.v1.services.DatasetService.GetChunk:output_type -> bib.v1.services.GetChunkResponse
+	32, // 70: bib.v1.services.DatasetService.VerifyDataset:output_type -> bib.v1.services.VerifyDatasetResponse
+	34, // 71: bib.v1.services.DatasetService.SearchDatasets:output_type -> bib.v1.services.SearchDatasetsResponse
+	36, // 72: bib.v1.services.DatasetService.GetDatasetStats:output_type -> bib.v1.services.GetDatasetStatsResponse
+	38, // 73: bib.v1.services.DatasetService.CopyDataset:output_type -> bib.v1.services.CopyDatasetResponse
+	40, // 74: bib.v1.services.DatasetService.StreamDatasetEvents:output_type -> bib.v1.services.DatasetEvent
+	0,  // 75: bib.v1.services.DatasetService.StreamDatasets:output_type -> bib.v1.services.Dataset
+	58, // [58:76] is the sub-list for method output_type
+	40, // [40:58] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_dataset_proto_init() }
@@ -3039,8 +3561,9 @@ func file_bib_v1_services_dataset_proto_init() {
 	file_bib_v1_services_dataset_proto_msgTypes[13].OneofWrappers = []any{
 		(*UploadDatasetRequest_Metadata)(nil),
 		(*UploadDatasetRequest_Chunk)(nil),
+		(*UploadDatasetRequest_IndexedChunk)(nil),
 	}
-	file_bib_v1_services_dataset_proto_msgTypes[17].OneofWrappers = []any{
+	file_bib_v1_services_dataset_proto_msgTypes[20].OneofWrappers = []any{
 		(*DownloadDatasetResponse_Metadata)(nil),
 		(*DownloadDatasetResponse_Chunk)(nil),
 	}
@@ -3050,7 +3573,7 @@ func file_bib_v1_services_dataset_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_dataset_proto_rawDesc), len(file_bib_v1_services_dataset_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   40,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
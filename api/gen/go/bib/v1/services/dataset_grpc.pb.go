@@ -25,15 +25,18 @@ const (
 	DatasetService_UpdateDataset_FullMethodName       = "/bib.v1.services.DatasetService/UpdateDataset"
 	DatasetService_DeleteDataset_FullMethodName       = "/bib.v1.services.DatasetService/DeleteDataset"
 	DatasetService_UploadDataset_FullMethodName       = "/bib.v1.services.DatasetService/UploadDataset"
+	DatasetService_FindMissingChunks_FullMethodName   = "/bib.v1.services.DatasetService/FindMissingChunks"
 	DatasetService_DownloadDataset_FullMethodName     = "/bib.v1.services.DatasetService/DownloadDataset"
 	DatasetService_GetDatasetVersions_FullMethodName  = "/bib.v1.services.DatasetService/GetDatasetVersions"
 	DatasetService_GetVersion_FullMethodName          = "/bib.v1.services.DatasetService/GetVersion"
+	DatasetService_RevertToVersion_FullMethodName     = "/bib.v1.services.DatasetService/RevertToVersion"
 	DatasetService_GetChunk_FullMethodName            = "/bib.v1.services.DatasetService/GetChunk"
 	DatasetService_VerifyDataset_FullMethodName       = "/bib.v1.services.DatasetService/VerifyDataset"
 	DatasetService_SearchDatasets_FullMethodName      = "/bib.v1.services.DatasetService/SearchDatasets"
 	DatasetService_GetDatasetStats_FullMethodName     = "/bib.v1.services.DatasetService/GetDatasetStats"
 	DatasetService_CopyDataset_FullMethodName         = "/bib.v1.services.DatasetService/CopyDataset"
 	DatasetService_StreamDatasetEvents_FullMethodName = "/bib.v1.services.DatasetService/StreamDatasetEvents"
+	DatasetService_StreamDatasets_FullMethodName      = "/bib.v1.services.DatasetService/StreamDatasets"
 )
 
 // DatasetServiceClient is the client API for DatasetService service.
@@ -54,12 +57,18 @@ type DatasetServiceClient interface {
 	DeleteDataset(ctx context.Context, in *DeleteDatasetRequest, opts ...grpc.CallOption) (*DeleteDatasetResponse, error)
 	// UploadDataset uploads dataset content (streaming).
 	UploadDataset(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadDatasetRequest, UploadDatasetResponse], error)
+	// FindMissingChunks reports which chunk hashes are not yet stored,
+	// so uploads can skip content the node already has.
+	FindMissingChunks(ctx context.Context, in *FindMissingChunksRequest, opts ...grpc.CallOption) (*FindMissingChunksResponse, error)
 	// DownloadDataset downloads dataset content (streaming).
 	DownloadDataset(ctx context.Context, in *DownloadDatasetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadDatasetResponse], error)
 	// GetDatasetVersions lists versions of a dataset.
 	GetDatasetVersions(ctx context.Context, in *GetDatasetVersionsRequest, opts ...grpc.CallOption) (*GetDatasetVersionsResponse, error)
 	// GetVersion retrieves a specific version.
 	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionResponse, error)
+	// RevertToVersion creates a new version with the content of an earlier
+	// one. History is kept: the reverted versions remain available.
+	RevertToVersion(ctx context.Context, in *RevertToVersionRequest, opts ...grpc.CallOption) (*RevertToVersionResponse, error)
 	// GetChunk retrieves a specific chunk.
 	GetChunk(ctx context.Context, in *GetChunkRequest, opts ...grpc.CallOption) (*GetChunkResponse, error)
 	// VerifyDataset verifies dataset integrity.
@@ -72,6 +81,10 @@ type DatasetServiceClient interface {
 	CopyDataset(ctx context.Context, in *CopyDatasetRequest, opts ...grpc.CallOption) (*CopyDatasetResponse, error)
 	// StreamDatasetEvents streams dataset events.
 	StreamDatasetEvents(ctx context.Context, in *StreamDatasetEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DatasetEvent], error)
+	// StreamDatasets streams the datasets matching a ListDatasets request one
+	// by one, for listings too large to return at once. page.limit caps the
+	// number of datasets sent (0 = all).
+	StreamDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Dataset], error)
 }
 
 type datasetServiceClient struct {
@@ -145,6 +158,16 @@ func (c *datasetServiceClient) UploadDataset(ctx context.Context, opts ...grpc.C
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DatasetService_UploadDatasetClient = grpc.ClientStreamingClient[UploadDatasetRequest, UploadDatasetResponse]
 
+func (c *datasetServiceClient) FindMissingChunks(ctx context.Context, in *FindMissingChunksRequest, opts ...grpc.CallOption) (*FindMissingChunksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindMissingChunksResponse)
+	err := c.cc.Invoke(ctx, DatasetService_FindMissingChunks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *datasetServiceClient) DownloadDataset(ctx context.Context, in *DownloadDatasetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadDatasetResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &DatasetService_ServiceDesc.Streams[1], DatasetService_DownloadDataset_FullMethodName, cOpts...)
@@ -184,6 +207,16 @@ func (c *datasetServiceClient) GetVersion(ctx context.Context, in *GetVersionReq
 	return out, nil
 }
 
+func (c *datasetServiceClient) RevertToVersion(ctx context.Context, in *RevertToVersionRequest, opts ...grpc.CallOption) (*RevertToVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevertToVersionResponse)
+	err := c.cc.Invoke(ctx, DatasetService_RevertToVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *datasetServiceClient) GetChunk(ctx context.Context, in *GetChunkRequest, opts ...grpc.CallOption) (*GetChunkResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetChunkResponse)
@@ -253,6 +286,25 @@ func (c *datasetServiceClient) StreamDatasetEvents(ctx context.Context, in *Stre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DatasetService_StreamDatasetEventsClient = grpc.ServerStreamingClient[DatasetEvent]
 
+func (c *datasetServiceClient) StreamDatasets(ctx context.Context, in *ListDatasetsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Dataset], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DatasetService_ServiceDesc.Streams[3], DatasetService_StreamDatasets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDatasetsRequest, Dataset]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DatasetService_StreamDatasetsClient = grpc.ServerStreamingClient[Dataset]
+
 // DatasetServiceServer is the server API for DatasetService service.
 // All implementations should embed UnimplementedDatasetServiceServer
 // for forward compatibility.
@@ -271,12 +323,18 @@ type DatasetServiceServer interface {
 	DeleteDataset(context.Context, *DeleteDatasetRequest) (*DeleteDatasetResponse, error)
 	// UploadDataset uploads dataset content (streaming).
 	UploadDataset(grpc.ClientStreamingServer[UploadDatasetRequest, UploadDatasetResponse]) error
+	// FindMissingChunks reports which chunk hashes are not yet stored,
+	// so uploads can skip content the node already has.
+	FindMissingChunks(context.Context, *FindMissingChunksRequest) (*FindMissingChunksResponse, error)
 	// DownloadDataset downloads dataset content (streaming).
 	DownloadDataset(*DownloadDatasetRequest, grpc.ServerStreamingServer[DownloadDatasetResponse]) error
 	// GetDatasetVersions lists versions of a dataset.
 	GetDatasetVersions(context.Context, *GetDatasetVersionsRequest) (*GetDatasetVersionsResponse, error)
 	// GetVersion retrieves a specific version.
 	GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error)
+	// RevertToVersion creates a new version with the content of an earlier
+	// one. History is kept: the reverted versions remain available.
+	RevertToVersion(context.Context, *RevertToVersionRequest) (*RevertToVersionResponse, error)
 	// GetChunk retrieves a specific chunk.
 	GetChunk(context.Context, *GetChunkRequest) (*GetChunkResponse, error)
 	// VerifyDataset verifies dataset integrity.
@@ -289,6 +347,10 @@ type DatasetServiceServer interface {
 	CopyDataset(context.Context, *CopyDatasetRequest) (*CopyDatasetResponse, error)
 	// StreamDatasetEvents streams dataset events.
 	StreamDatasetEvents(*StreamDatasetEventsRequest, grpc.ServerStreamingServer[DatasetEvent]) error
+	// StreamDatasets streams the datasets matching a ListDatasets request one
+	// by one, for listings too large to return at once. page.limit caps the
+	// number of datasets sent (0 = all).
+	StreamDatasets(*ListDatasetsRequest, grpc.ServerStreamingServer[Dataset]) error
 }
 
 // UnimplementedDatasetServiceServer should be embedded to have
@@ -316,6 +378,9 @@ func (UnimplementedDatasetServiceServer) DeleteDataset(context.Context, *DeleteD
 func (UnimplementedDatasetServiceServer) UploadDataset(grpc.ClientStreamingServer[UploadDatasetRequest, UploadDatasetResponse]) error {
 	return status.Error(codes.Unimplemented, "method UploadDataset not implemented")
 }
+func (UnimplementedDatasetServiceServer) FindMissingChunks(context.Context, *FindMissingChunksRequest) (*FindMissingChunksResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FindMissingChunks not implemented")
+}
 func (UnimplementedDatasetServiceServer) DownloadDataset(*DownloadDatasetRequest, grpc.ServerStreamingServer[DownloadDatasetResponse]) error {
 	return status.Error(codes.Unimplemented, "method DownloadDataset not implemented")
 }
@@ -325,6 +390,9 @@ func (UnimplementedDatasetServiceServer) GetDatasetVersions(context.Context, *Ge
 func (UnimplementedDatasetServiceServer) GetVersion(context.Context, *GetVersionRequest) (*GetVersionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetVersion not implemented")
 }
+func (UnimplementedDatasetServiceServer) RevertToVersion(context.Context, *RevertToVersionRequest) (*RevertToVersionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevertToVersion not implemented")
+}
 func (UnimplementedDatasetServiceServer) GetChunk(context.Context, *GetChunkRequest) (*GetChunkResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetChunk not implemented")
 }
@@ -343,6 +411,9 @@ func (UnimplementedDatasetServiceServer) CopyDataset(context.Context, *CopyDatas
 func (UnimplementedDatasetServiceServer) StreamDatasetEvents(*StreamDatasetEventsRequest, grpc.ServerStreamingServer[DatasetEvent]) error {
 	return status.Error(codes.Unimplemented, "method StreamDatasetEvents not implemented")
 }
+func (UnimplementedDatasetServiceServer) StreamDatasets(*ListDatasetsRequest, grpc.ServerStreamingServer[Dataset]) error {
+	return status.Error(codes.Unimplemented, "method StreamDatasets not implemented")
+}
 func (UnimplementedDatasetServiceServer) testEmbeddedByValue() {}
 
 // UnsafeDatasetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -460,6 +531,24 @@ func _DatasetService_UploadDataset_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DatasetService_UploadDatasetServer = grpc.ClientStreamingServer[UploadDatasetRequest, UploadDatasetResponse]
 
+func _DatasetService_FindMissingChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMissingChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatasetServiceServer).FindMissingChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatasetService_FindMissingChunks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatasetServiceServer).FindMissingChunks(ctx, req.(*FindMissingChunksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DatasetService_DownloadDataset_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(DownloadDatasetRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -507,6 +596,24 @@ func _DatasetService_GetVersion_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DatasetService_RevertToVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertToVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatasetServiceServer).RevertToVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DatasetService_RevertToVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatasetServiceServer).RevertToVersion(ctx, req.(*RevertToVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DatasetService_GetChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetChunkRequest)
 	if err := dec(in); err != nil {
@@ -608,6 +715,17 @@ func _DatasetService_StreamDatasetEvents_Handler(srv interface{}, stream grpc.Se
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DatasetService_StreamDatasetEventsServer = grpc.ServerStreamingServer[DatasetEvent]
 
+func _DatasetService_StreamDatasets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDatasetsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DatasetServiceServer).StreamDatasets(m, &grpc.GenericServerStream[ListDatasetsRequest, Dataset]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DatasetService_StreamDatasetsServer = grpc.ServerStreamingServer[Dataset]
+
 // DatasetService_ServiceDesc is the grpc.ServiceDesc for DatasetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -635,6 +753,10 @@ var DatasetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDataset",
 			Handler:    _DatasetService_DeleteDataset_Handler,
 		},
+		{
+			MethodName: "FindMissingChunks",
+			Handler:    _DatasetService_FindMissingChunks_Handler,
+		},
 		{
 			MethodName: "GetDatasetVersions",
 			Handler:    _DatasetService_GetDatasetVersions_Handler,
@@ -643,6 +765,10 @@ var DatasetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVersion",
 			Handler:    _DatasetService_GetVersion_Handler,
 		},
+		{
+			MethodName: "RevertToVersion",
+			Handler:    _DatasetService_RevertToVersion_Handler,
+		},
 		{
 			MethodName: "GetChunk",
 			Handler:    _DatasetService_GetChunk_Handler,
@@ -680,6 +806,11 @@ var DatasetService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _DatasetService_StreamDatasetEvents_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamDatasets",
+			Handler:       _DatasetService_StreamDatasets_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "bib/v1/services/dataset.proto",
 }
@@ -693,9 +693,16 @@ type ClusterInfo struct {
 	// Number of healthy members.
 	HealthyMembers int32 `protobuf:"varint,6,opt,name=healthy_members,json=healthyMembers,proto3" json:"healthy_members,omitempty"`
 	// Cluster state: "healthy", "degraded", "no_quorum".
-	State         string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	State string `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`
+	// Largest clock skew to a cluster member heard from recently, positive
+	// if the member's clock is ahead. Unset if none was measured.
+	MaxClockSkew *durationpb.Duration `protobuf:"bytes,8,opt,name=max_clock_skew,json=maxClockSkew,proto3" json:"max_clock_skew,omitempty"`
+	// Member with the largest clock skew.
+	MaxClockSkewNode string `protobuf:"bytes,9,opt,name=max_clock_skew_node,json=maxClockSkewNode,proto3" json:"max_clock_skew_node,omitempty"`
+	// Whether the largest clock skew exceeds cluster.max_clock_skew.
+	ClockSkewExceeded bool `protobuf:"varint,10,opt,name=clock_skew_exceeded,json=clockSkewExceeded,proto3" json:"clock_skew_exceeded,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ClusterInfo) Reset() {
@@ -777,6 +784,27 @@ func (x *ClusterInfo) GetState() string {
 	return ""
 }
 
+func (x *ClusterInfo) GetMaxClockSkew() *durationpb.Duration {
+	if x != nil {
+		return x.MaxClockSkew
+	}
+	return nil
+}
+
+func (x *ClusterInfo) GetMaxClockSkewNode() string {
+	if x != nil {
+		return x.MaxClockSkewNode
+	}
+	return ""
+}
+
+func (x *ClusterInfo) GetClockSkewExceeded() bool {
+	if x != nil {
+		return x.ClockSkewExceeded
+	}
+	return false
+}
+
 // PingRequest is an empty ping request.
 type PingRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -887,6 +915,205 @@ func (x *PingResponse) GetNodeId() string {
 	return ""
 }
 
+// GetDaemonVersionRequest requests the daemon version.
+type GetDaemonVersionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Client software version (informational).
+	ClientVersion string `protobuf:"bytes,1,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	// API version the client was built against (major.minor).
+	ClientApiVersion string `protobuf:"bytes,2,opt,name=client_api_version,json=clientApiVersion,proto3" json:"client_api_version,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetDaemonVersionRequest) Reset() {
+	*x = GetDaemonVersionRequest{}
+	mi := &file_bib_v1_services_health_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDaemonVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDaemonVersionRequest) ProtoMessage() {}
+
+func (x *GetDaemonVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_health_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDaemonVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetDaemonVersionRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_health_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDaemonVersionRequest) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionRequest) GetClientApiVersion() string {
+	if x != nil {
+		return x.ClientApiVersion
+	}
+	return ""
+}
+
+// GetDaemonVersionResponse contains the daemon version and API compatibility.
+type GetDaemonVersionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Software version.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Git commit hash.
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// Build timestamp.
+	BuildTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	// API version served by the daemon (major.minor).
+	ApiVersion string `protobuf:"bytes,4,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// Oldest client API version the daemon serves.
+	MinApiVersion string `protobuf:"bytes,5,opt,name=min_api_version,json=minApiVersion,proto3" json:"min_api_version,omitempty"`
+	// Optional features supported by the daemon (e.g. "admin.upgrade", "cluster").
+	Features []string `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	// Go version the daemon was built with.
+	GoVersion string `protobuf:"bytes,7,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	// Operating system and architecture.
+	Os   string `protobuf:"bytes,8,opt,name=os,proto3" json:"os,omitempty"`
+	Arch string `protobuf:"bytes,9,opt,name=arch,proto3" json:"arch,omitempty"`
+	// Whether this is a development build.
+	DevMode bool `protobuf:"varint,10,opt,name=dev_mode,json=devMode,proto3" json:"dev_mode,omitempty"`
+	// Whether client_api_version is supported (true if it was not sent).
+	ClientCompatible bool `protobuf:"varint,11,opt,name=client_compatible,json=clientCompatible,proto3" json:"client_compatible,omitempty"`
+	// Why the client is incompatible or may miss features.
+	CompatibilityMessage string `protobuf:"bytes,12,opt,name=compatibility_message,json=compatibilityMessage,proto3" json:"compatibility_message,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetDaemonVersionResponse) Reset() {
+	*x = GetDaemonVersionResponse{}
+	mi := &file_bib_v1_services_health_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDaemonVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDaemonVersionResponse) ProtoMessage() {}
+
+func (x *GetDaemonVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_health_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDaemonVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetDaemonVersionResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_health_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetDaemonVersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionResponse) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionResponse) GetBuildTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildTime
+	}
+	return nil
+}
+
+func (x *GetDaemonVersionResponse) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionResponse) GetMinApiVersion() string {
+	if x != nil {
+		return x.MinApiVersion
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *GetDaemonVersionResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionResponse) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionResponse) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *GetDaemonVersionResponse) GetDevMode() bool {
+	if x != nil {
+		return x.DevMode
+	}
+	return false
+}
+
+func (x *GetDaemonVersionResponse) GetClientCompatible() bool {
+	if x != nil {
+		return x.ClientCompatible
+	}
+	return false
+}
+
+func (x *GetDaemonVersionResponse) GetCompatibilityMessage() string {
+	if x != nil {
+		return x.CompatibilityMessage
+	}
+	return ""
+}
+
 var File_bib_v1_services_health_proto protoreflect.FileDescriptor
 
 const file_bib_v1_services_health_proto_rawDesc = "" +
@@ -954,7 +1181,7 @@ const file_bib_v1_services_health_proto_rawDesc = "" +
 	"\n" +
 	"bytes_used\x18\x05 \x01(\x03R\tbytesUsed\x12'\n" +
 	"\x0fbytes_available\x18\x06 \x01(\x03R\x0ebytesAvailable\x12)\n" +
-	"\x10is_authoritative\x18\a \x01(\bR\x0fisAuthoritative\"\xce\x01\n" +
+	"\x10is_authoritative\x18\a \x01(\bR\x0fisAuthoritative\"\xee\x02\n" +
 	"\vClusterInfo\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x12\n" +
 	"\x04role\x18\x02 \x01(\tR\x04role\x12\x1b\n" +
@@ -962,23 +1189,49 @@ const file_bib_v1_services_health_proto_rawDesc = "" +
 	"\x04term\x18\x04 \x01(\x04R\x04term\x12!\n" +
 	"\fmember_count\x18\x05 \x01(\x05R\vmemberCount\x12'\n" +
 	"\x0fhealthy_members\x18\x06 \x01(\x05R\x0ehealthyMembers\x12\x14\n" +
-	"\x05state\x18\a \x01(\tR\x05state\"'\n" +
+	"\x05state\x18\a \x01(\tR\x05state\x12?\n" +
+	"\x0emax_clock_skew\x18\b \x01(\v2\x19.google.protobuf.DurationR\fmaxClockSkew\x12-\n" +
+	"\x13max_clock_skew_node\x18\t \x01(\tR\x10maxClockSkewNode\x12.\n" +
+	"\x13clock_skew_exceeded\x18\n" +
+	" \x01(\bR\x11clockSkewExceeded\"'\n" +
 	"\vPingRequest\x12\x18\n" +
 	"\apayload\x18\x01 \x01(\fR\apayload\"{\n" +
 	"\fPingResponse\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x18\n" +
 	"\apayload\x18\x02 \x01(\fR\apayload\x12\x17\n" +
-	"\anode_id\x18\x03 \x01(\tR\x06nodeId*\x87\x01\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\"n\n" +
+	"\x17GetDaemonVersionRequest\x12%\n" +
+	"\x0eclient_version\x18\x01 \x01(\tR\rclientVersion\x12,\n" +
+	"\x12client_api_version\x18\x02 \x01(\tR\x10clientApiVersion\"\xac\x03\n" +
+	"\x18GetDaemonVersionResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x129\n" +
+	"\n" +
+	"build_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tbuildTime\x12\x1f\n" +
+	"\vapi_version\x18\x04 \x01(\tR\n" +
+	"apiVersion\x12&\n" +
+	"\x0fmin_api_version\x18\x05 \x01(\tR\rminApiVersion\x12\x1a\n" +
+	"\bfeatures\x18\x06 \x03(\tR\bfeatures\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\a \x01(\tR\tgoVersion\x12\x0e\n" +
+	"\x02os\x18\b \x01(\tR\x02os\x12\x12\n" +
+	"\x04arch\x18\t \x01(\tR\x04arch\x12\x19\n" +
+	"\bdev_mode\x18\n" +
+	" \x01(\bR\adevMode\x12+\n" +
+	"\x11client_compatible\x18\v \x01(\bR\x10clientCompatible\x123\n" +
+	"\x15compatibility_message\x18\f \x01(\tR\x14compatibilityMessage*\x87\x01\n" +
 	"\rServingStatus\x12\x1e\n" +
 	"\x1aSERVING_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16SERVING_STATUS_SERVING\x10\x01\x12\x1e\n" +
 	"\x1aSERVING_STATUS_NOT_SERVING\x10\x02\x12\x1a\n" +
-	"\x16SERVING_STATUS_UNKNOWN\x10\x032\xd8\x02\n" +
+	"\x16SERVING_STATUS_UNKNOWN\x10\x032\xbb\x03\n" +
 	"\rHealthService\x12R\n" +
 	"\x05Check\x12#.bib.v1.services.HealthCheckRequest\x1a$.bib.v1.services.HealthCheckResponse\x12T\n" +
 	"\x05Watch\x12#.bib.v1.services.HealthCheckRequest\x1a$.bib.v1.services.HealthCheckResponse0\x01\x12X\n" +
 	"\vGetNodeInfo\x12#.bib.v1.services.GetNodeInfoRequest\x1a$.bib.v1.services.GetNodeInfoResponse\x12C\n" +
-	"\x04Ping\x12\x1c.bib.v1.services.PingRequest\x1a\x1d.bib.v1.services.PingResponseB\xa0\x01\n" +
+	"\x04Ping\x12\x1c.bib.v1.services.PingRequest\x1a\x1d.bib.v1.services.PingResponse\x12a\n" +
+	"\n" +
+	"GetVersion\x12(.bib.v1.services.GetDaemonVersionRequest\x1a).bib.v1.services.GetDaemonVersionResponseB\xa0\x01\n" +
 	"\x13com.bib.v1.servicesB\vHealthProtoP\x01Z\x1ebib/api/gen/go/bib/v1/services\xa2\x02\x03BVS\xaa\x02\x0fBib.V1.Services\xca\x02\x0fBib\\V1\\Services\xe2\x02\x1bBib\\V1\\Services\\GPBMetadata\xea\x02\x11Bib::V1::Servicesb\x06proto3"
 
 var (
@@ -994,53 +1247,59 @@ func file_bib_v1_services_health_proto_rawDescGZIP() []byte {
 }
 
 var file_bib_v1_services_health_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_bib_v1_services_health_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_bib_v1_services_health_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_bib_v1_services_health_proto_goTypes = []any{
-	(ServingStatus)(0),            // 0: bib.v1.services.ServingStatus
-	(*HealthCheckRequest)(nil),    // 1: bib.v1.services.HealthCheckRequest
-	(*HealthCheckResponse)(nil),   // 2: bib.v1.services.HealthCheckResponse
-	(*ComponentHealth)(nil),       // 3: bib.v1.services.ComponentHealth
-	(*GetNodeInfoRequest)(nil),    // 4: bib.v1.services.GetNodeInfoRequest
-	(*GetNodeInfoResponse)(nil),   // 5: bib.v1.services.GetNodeInfoResponse
-	(*NetworkInfo)(nil),           // 6: bib.v1.services.NetworkInfo
-	(*StorageInfo)(nil),           // 7: bib.v1.services.StorageInfo
-	(*ClusterInfo)(nil),           // 8: bib.v1.services.ClusterInfo
-	(*PingRequest)(nil),           // 9: bib.v1.services.PingRequest
-	(*PingResponse)(nil),          // 10: bib.v1.services.PingResponse
-	nil,                           // 11: bib.v1.services.HealthCheckResponse.ComponentsEntry
-	nil,                           // 12: bib.v1.services.GetNodeInfoResponse.ComponentsEntry
-	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 14: google.protobuf.Duration
+	(ServingStatus)(0),               // 0: bib.v1.services.ServingStatus
+	(*HealthCheckRequest)(nil),       // 1: bib.v1.services.HealthCheckRequest
+	(*HealthCheckResponse)(nil),      // 2: bib.v1.services.HealthCheckResponse
+	(*ComponentHealth)(nil),          // 3: bib.v1.services.ComponentHealth
+	(*GetNodeInfoRequest)(nil),       // 4: bib.v1.services.GetNodeInfoRequest
+	(*GetNodeInfoResponse)(nil),      // 5: bib.v1.services.GetNodeInfoResponse
+	(*NetworkInfo)(nil),              // 6: bib.v1.services.NetworkInfo
+	(*StorageInfo)(nil),              // 7: bib.v1.services.StorageInfo
+	(*ClusterInfo)(nil),              // 8: bib.v1.services.ClusterInfo
+	(*PingRequest)(nil),              // 9: bib.v1.services.PingRequest
+	(*PingResponse)(nil),             // 10: bib.v1.services.PingResponse
+	(*GetDaemonVersionRequest)(nil),  // 11: bib.v1.services.GetDaemonVersionRequest
+	(*GetDaemonVersionResponse)(nil), // 12: bib.v1.services.GetDaemonVersionResponse
+	nil,                              // 13: bib.v1.services.HealthCheckResponse.ComponentsEntry
+	nil,                              // 14: bib.v1.services.GetNodeInfoResponse.ComponentsEntry
+	(*timestamppb.Timestamp)(nil),    // 15: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 16: google.protobuf.Duration
 }
 var file_bib_v1_services_health_proto_depIdxs = []int32{
 	0,  // 0: bib.v1.services.HealthCheckResponse.status:type_name -> bib.v1.services.ServingStatus
-	11, // 1: bib.v1.services.HealthCheckResponse.components:type_name -> bib.v1.services.HealthCheckResponse.ComponentsEntry
-	13, // 2: bib.v1.services.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
+	13, // 1: bib.v1.services.HealthCheckResponse.components:type_name -> bib.v1.services.HealthCheckResponse.ComponentsEntry
+	15, // 2: bib.v1.services.HealthCheckResponse.timestamp:type_name -> google.protobuf.Timestamp
 	0,  // 3: bib.v1.services.ComponentHealth.status:type_name -> bib.v1.services.ServingStatus
-	13, // 4: bib.v1.services.ComponentHealth.last_check:type_name -> google.protobuf.Timestamp
-	13, // 5: bib.v1.services.GetNodeInfoResponse.build_time:type_name -> google.protobuf.Timestamp
-	13, // 6: bib.v1.services.GetNodeInfoResponse.started_at:type_name -> google.protobuf.Timestamp
-	14, // 7: bib.v1.services.GetNodeInfoResponse.uptime:type_name -> google.protobuf.Duration
+	15, // 4: bib.v1.services.ComponentHealth.last_check:type_name -> google.protobuf.Timestamp
+	15, // 5: bib.v1.services.GetNodeInfoResponse.build_time:type_name -> google.protobuf.Timestamp
+	15, // 6: bib.v1.services.GetNodeInfoResponse.started_at:type_name -> google.protobuf.Timestamp
+	16, // 7: bib.v1.services.GetNodeInfoResponse.uptime:type_name -> google.protobuf.Duration
 	6,  // 8: bib.v1.services.GetNodeInfoResponse.network:type_name -> bib.v1.services.NetworkInfo
 	7,  // 9: bib.v1.services.GetNodeInfoResponse.storage:type_name -> bib.v1.services.StorageInfo
-	12, // 10: bib.v1.services.GetNodeInfoResponse.components:type_name -> bib.v1.services.GetNodeInfoResponse.ComponentsEntry
+	14, // 10: bib.v1.services.GetNodeInfoResponse.components:type_name -> bib.v1.services.GetNodeInfoResponse.ComponentsEntry
 	8,  // 11: bib.v1.services.GetNodeInfoResponse.cluster:type_name -> bib.v1.services.ClusterInfo
-	13, // 12: bib.v1.services.PingResponse.timestamp:type_name -> google.protobuf.Timestamp
-	3,  // 13: bib.v1.services.HealthCheckResponse.ComponentsEntry.value:type_name -> bib.v1.services.ComponentHealth
-	3,  // 14: bib.v1.services.GetNodeInfoResponse.ComponentsEntry.value:type_name -> bib.v1.services.ComponentHealth
-	1,  // 15: bib.v1.services.HealthService.Check:input_type -> bib.v1.services.HealthCheckRequest
-	1,  // 16: bib.v1.services.HealthService.Watch:input_type -> bib.v1.services.HealthCheckRequest
-	4,  // 17: bib.v1.services.HealthService.GetNodeInfo:input_type -> bib.v1.services.GetNodeInfoRequest
-	9,  // 18: bib.v1.services.HealthService.Ping:input_type -> bib.v1.services.PingRequest
-	2,  // 19: bib.v1.services.HealthService.Check:output_type -> bib.v1.services.HealthCheckResponse
-	2,  // 20: bib.v1.services.HealthService.Watch:output_type -> bib.v1.services.HealthCheckResponse
-	5,  // 21: bib.v1.services.HealthService.GetNodeInfo:output_type -> bib.v1.services.GetNodeInfoResponse
-	10, // 22: bib.v1.services.HealthService.Ping:output_type -> bib.v1.services.PingResponse
-	19, // [19:23] is the sub-list for method output_type
-	15, // [15:19] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	16, // 12: bib.v1.services.ClusterInfo.max_clock_skew:type_name -> google.protobuf.Duration
+	15, // 13: bib.v1.services.PingResponse.timestamp:type_name -> google.protobuf.Timestamp
+	15, // 14: bib.v1.services.GetDaemonVersionResponse.build_time:type_name -> google.protobuf.Timestamp
+	3,  // 15: bib.v1.services.HealthCheckResponse.ComponentsEntry.value:type_name -> bib.v1.services.ComponentHealth
+	3,  // 16: bib.v1.services.GetNodeInfoResponse.ComponentsEntry.value:type_name -> bib.v1.services.ComponentHealth
+	1,  // 17: bib.v1.services.HealthService.Check:input_type -> bib.v1.services.HealthCheckRequest
+	1,  // 18: bib.v1.services.HealthService.Watch:input_type -> bib.v1.services.HealthCheckRequest
+	4,  // 19: bib.v1.services.HealthService.GetNodeInfo:input_type -> bib.v1.services.GetNodeInfoRequest
+	9,  // 20: bib.v1.services.HealthService.Ping:input_type -> bib.v1.services.PingRequest
+	11, // 21: bib.v1.services.HealthService.GetVersion:input_type -> bib.v1.services.GetDaemonVersionRequest
+	2,  // 22: bib.v1.services.HealthService.Check:output_type -> bib.v1.services.HealthCheckResponse
+	2,  // 23: bib.v1.services.HealthService.Watch:output_type -> bib.v1.services.HealthCheckResponse
+	5,  // 24: bib.v1.services.HealthService.GetNodeInfo:output_type -> bib.v1.services.GetNodeInfoResponse
+	10, // 25: bib.v1.services.HealthService.Ping:output_type -> bib.v1.services.PingResponse
+	12, // 26: bib.v1.services.HealthService.GetVersion:output_type -> bib.v1.services.GetDaemonVersionResponse
+	22, // [22:27] is the sub-list for method output_type
+	17, // [17:22] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_health_proto_init() }
@@ -1054,7 +1313,7 @@ func file_bib_v1_services_health_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_health_proto_rawDesc), len(file_bib_v1_services_health_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   12,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
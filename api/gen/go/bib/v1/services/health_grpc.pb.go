@@ -23,6 +23,7 @@ const (
 	HealthService_Watch_FullMethodName       = "/bib.v1.services.HealthService/Watch"
 	HealthService_GetNodeInfo_FullMethodName = "/bib.v1.services.HealthService/GetNodeInfo"
 	HealthService_Ping_FullMethodName        = "/bib.v1.services.HealthService/Ping"
+	HealthService_GetVersion_FullMethodName  = "/bib.v1.services.HealthService/GetVersion"
 )
 
 // HealthServiceClient is the client API for HealthService service.
@@ -40,6 +41,9 @@ type HealthServiceClient interface {
 	GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoResponse, error)
 	// Ping is a simple connectivity check.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// GetVersion returns the daemon version, API version and supported features.
+	// Clients call it after connecting to detect version skew.
+	GetVersion(ctx context.Context, in *GetDaemonVersionRequest, opts ...grpc.CallOption) (*GetDaemonVersionResponse, error)
 }
 
 type healthServiceClient struct {
@@ -99,6 +103,16 @@ func (c *healthServiceClient) Ping(ctx context.Context, in *PingRequest, opts ..
 	return out, nil
 }
 
+func (c *healthServiceClient) GetVersion(ctx context.Context, in *GetDaemonVersionRequest, opts ...grpc.CallOption) (*GetDaemonVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDaemonVersionResponse)
+	err := c.cc.Invoke(ctx, HealthService_GetVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HealthServiceServer is the server API for HealthService service.
 // All implementations should embed UnimplementedHealthServiceServer
 // for forward compatibility.
@@ -114,6 +128,9 @@ type HealthServiceServer interface {
 	GetNodeInfo(context.Context, *GetNodeInfoRequest) (*GetNodeInfoResponse, error)
 	// Ping is a simple connectivity check.
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// GetVersion returns the daemon version, API version and supported features.
+	// Clients call it after connecting to detect version skew.
+	GetVersion(context.Context, *GetDaemonVersionRequest) (*GetDaemonVersionResponse, error)
 }
 
 // UnimplementedHealthServiceServer should be embedded to have
@@ -135,6 +152,9 @@ func (UnimplementedHealthServiceServer) GetNodeInfo(context.Context, *GetNodeInf
 func (UnimplementedHealthServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedHealthServiceServer) GetVersion(context.Context, *GetDaemonVersionRequest) (*GetDaemonVersionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVersion not implemented")
+}
 func (UnimplementedHealthServiceServer) testEmbeddedByValue() {}
 
 // UnsafeHealthServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -220,6 +240,24 @@ func _HealthService_Ping_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HealthService_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDaemonVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HealthServiceServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HealthService_GetVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HealthServiceServer).GetVersion(ctx, req.(*GetDaemonVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HealthService_ServiceDesc is the grpc.ServiceDesc for HealthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +277,10 @@ var HealthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _HealthService_Ping_Handler,
 		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _HealthService_GetVersion_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
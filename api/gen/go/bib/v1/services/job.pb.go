@@ -1429,10 +1429,13 @@ func (x *ResumeJobResponse) GetJob() *Job {
 
 // StreamJobLogsRequest requests log streaming.
 type StreamJobLogsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Follow        bool                   `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`
-	TailLines     int32                  `protobuf:"varint,3,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Follow    bool                   `protobuf:"varint,2,opt,name=follow,proto3" json:"follow,omitempty"`
+	TailLines int32                  `protobuf:"varint,3,opt,name=tail_lines,json=tailLines,proto3" json:"tail_lines,omitempty"`
+	// Resume after the entry carrying this token (from JobLogEntry.resume_token).
+	// Takes precedence over tail_lines.
+	ResumeToken   string `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1488,13 +1491,22 @@ func (x *StreamJobLogsRequest) GetTailLines() int32 {
 	return 0
 }
 
+func (x *StreamJobLogsRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // JobLogEntry is a log entry.
 type JobLogEntry struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Stream        string                 `protobuf:"bytes,4,opt,name=stream,proto3" json:"stream,omitempty"` // "stdout", "stderr"
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Level     string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Message   string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Stream    string                 `protobuf:"bytes,4,opt,name=stream,proto3" json:"stream,omitempty"` // "stdout", "stderr"
+	// Opaque stream position; pass back as resume_token to continue after this entry.
+	ResumeToken   string `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1557,10 +1569,19 @@ func (x *JobLogEntry) GetStream() string {
 	return ""
 }
 
+func (x *JobLogEntry) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // StreamJobStatusRequest requests status streaming.
 type StreamJobStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Resume after the update carrying this token (from JobStatusUpdate.resume_token).
+	ResumeToken   string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1602,12 +1623,21 @@ func (x *StreamJobStatusRequest) GetId() string {
 	return ""
 }
 
+func (x *StreamJobStatusRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // JobStatusUpdate is a status update.
 type JobStatusUpdate struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Job           *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Event         string                 `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Job       *Job                   `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Event     string                 `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	// Opaque stream position; pass back as resume_token to continue after this update.
+	ResumeToken   string `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1663,6 +1693,13 @@ func (x *JobStatusUpdate) GetEvent() string {
 	return ""
 }
 
+func (x *JobStatusUpdate) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // GetJobResultRequest gets job result.
 type GetJobResultRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2166,23 +2203,27 @@ const file_bib_v1_services_job_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"U\n" +
 	"\x11ResumeJobResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12&\n" +
-	"\x03job\x18\x02 \x01(\v2\x14.bib.v1.services.JobR\x03job\"]\n" +
+	"\x03job\x18\x02 \x01(\v2\x14.bib.v1.services.JobR\x03job\"\x80\x01\n" +
 	"\x14StreamJobLogsRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06follow\x18\x02 \x01(\bR\x06follow\x12\x1d\n" +
 	"\n" +
-	"tail_lines\x18\x03 \x01(\x05R\ttailLines\"\x8f\x01\n" +
+	"tail_lines\x18\x03 \x01(\x05R\ttailLines\x12!\n" +
+	"\fresume_token\x18\x04 \x01(\tR\vresumeToken\"\xb2\x01\n" +
 	"\vJobLogEntry\x128\n" +
 	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
 	"\x05level\x18\x02 \x01(\tR\x05level\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
-	"\x06stream\x18\x04 \x01(\tR\x06stream\"(\n" +
+	"\x06stream\x18\x04 \x01(\tR\x06stream\x12!\n" +
+	"\fresume_token\x18\x05 \x01(\tR\vresumeToken\"K\n" +
 	"\x16StreamJobStatusRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x89\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\"\xac\x01\n" +
 	"\x0fJobStatusUpdate\x12&\n" +
 	"\x03job\x18\x01 \x01(\v2\x14.bib.v1.services.JobR\x03job\x128\n" +
 	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
-	"\x05event\x18\x03 \x01(\tR\x05event\"%\n" +
+	"\x05event\x18\x03 \x01(\tR\x05event\x12!\n" +
+	"\fresume_token\x18\x04 \x01(\tR\vresumeToken\"%\n" +
 	"\x13GetJobResultRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\xe5\x01\n" +
 	"\x14GetJobResultResponse\x12&\n" +
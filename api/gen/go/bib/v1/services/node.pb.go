@@ -57,7 +57,10 @@ type NodeInfo struct {
 	// Peer reputation score (0-100).
 	Reputation int32 `protobuf:"varint,15,opt,name=reputation,proto3" json:"reputation,omitempty"`
 	// Additional metadata.
-	Metadata      map[string]string `protobuf:"bytes,16,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata map[string]string `protobuf:"bytes,16,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Node labels (region, role, capacity class), as set by the node's
+	// operator and exchanged with connected peers.
+	Labels        map[string]string `protobuf:"bytes,17,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -204,6 +207,13 @@ func (x *NodeInfo) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *NodeInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 // GetNodeRequest requests a node by ID.
 type GetNodeRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -307,7 +317,9 @@ type ListNodesRequest struct {
 	// Pagination.
 	Page *v1.PageRequest `protobuf:"bytes,4,opt,name=page,proto3" json:"page,omitempty"`
 	// Sorting.
-	Sort          *v1.SortOrder `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	Sort *v1.SortOrder `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Only nodes having all of these labels.
+	LabelSelector map[string]string `protobuf:"bytes,6,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -377,6 +389,13 @@ func (x *ListNodesRequest) GetSort() *v1.SortOrder {
 	return nil
 }
 
+func (x *ListNodesRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
 // ListNodesResponse contains the node list.
 type ListNodesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1920,11 +1939,737 @@ func (x *ListBannedPeersResponse) GetPageInfo() *v1.PageInfo {
 	return nil
 }
 
+// NodeSubscription is a topic pattern a selective node replicates.
+type NodeSubscription struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Topic pattern, e.g. "weather/*".
+	TopicPattern string `protobuf:"bytes,1,opt,name=topic_pattern,json=topicPattern,proto3" json:"topic_pattern,omitempty"`
+	// When subscribed.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// When the matching topics were last synced (unset if never).
+	LastSync      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_sync,json=lastSync,proto3" json:"last_sync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeSubscription) Reset() {
+	*x = NodeSubscription{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeSubscription) ProtoMessage() {}
+
+func (x *NodeSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeSubscription.ProtoReflect.Descriptor instead.
+func (*NodeSubscription) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *NodeSubscription) GetTopicPattern() string {
+	if x != nil {
+		return x.TopicPattern
+	}
+	return ""
+}
+
+func (x *NodeSubscription) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *NodeSubscription) GetLastSync() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSync
+	}
+	return nil
+}
+
+// AddSubscriptionRequest subscribes to a topic pattern.
+type AddSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pattern       string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSubscriptionRequest) Reset() {
+	*x = AddSubscriptionRequest{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSubscriptionRequest) ProtoMessage() {}
+
+func (x *AddSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*AddSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AddSubscriptionRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// AddSubscriptionResponse returns the subscription.
+type AddSubscriptionResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Subscription *NodeSubscription      `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	// False if the node was already subscribed to the pattern.
+	Added         bool `protobuf:"varint,2,opt,name=added,proto3" json:"added,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSubscriptionResponse) Reset() {
+	*x = AddSubscriptionResponse{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSubscriptionResponse) ProtoMessage() {}
+
+func (x *AddSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*AddSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AddSubscriptionResponse) GetSubscription() *NodeSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *AddSubscriptionResponse) GetAdded() bool {
+	if x != nil {
+		return x.Added
+	}
+	return false
+}
+
+// RemoveSubscriptionRequest unsubscribes from a topic pattern.
+type RemoveSubscriptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pattern       string                 `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSubscriptionRequest) Reset() {
+	*x = RemoveSubscriptionRequest{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSubscriptionRequest) ProtoMessage() {}
+
+func (x *RemoveSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RemoveSubscriptionRequest) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+// RemoveSubscriptionResponse confirms the removal.
+type RemoveSubscriptionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False if the node was not subscribed to the pattern.
+	Removed       bool `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveSubscriptionResponse) Reset() {
+	*x = RemoveSubscriptionResponse{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveSubscriptionResponse) ProtoMessage() {}
+
+func (x *RemoveSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*RemoveSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemoveSubscriptionResponse) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+// ListNodeSubscriptionsRequest lists the subscriptions.
+type ListNodeSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNodeSubscriptionsRequest) Reset() {
+	*x = ListNodeSubscriptionsRequest{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNodeSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodeSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListNodeSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodeSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListNodeSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{34}
+}
+
+// ListNodeSubscriptionsResponse contains the subscriptions.
+type ListNodeSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*NodeSubscription    `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNodeSubscriptionsResponse) Reset() {
+	*x = ListNodeSubscriptionsResponse{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNodeSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodeSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListNodeSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodeSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListNodeSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListNodeSubscriptionsResponse) GetSubscriptions() []*NodeSubscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+// SetModeRequest switches the P2P mode.
+type SetModeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Mode: "proxy", "selective" or "full". Full requires the PostgreSQL
+	// backend.
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Remove the replicated catalogs and sync checkpoints when leaving full
+	// mode. Otherwise they are kept for a later switch back.
+	PurgeReplica  bool `protobuf:"varint,2,opt,name=purge_replica,json=purgeReplica,proto3" json:"purge_replica,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetModeRequest) Reset() {
+	*x = SetModeRequest{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetModeRequest) ProtoMessage() {}
+
+func (x *SetModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetModeRequest.ProtoReflect.Descriptor instead.
+func (*SetModeRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetModeRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *SetModeRequest) GetPurgeReplica() bool {
+	if x != nil {
+		return x.PurgeReplica
+	}
+	return false
+}
+
+// SetModeResponse reports the switch.
+type SetModeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Mode before the switch.
+	PreviousMode string `protobuf:"bytes,1,opt,name=previous_mode,json=previousMode,proto3" json:"previous_mode,omitempty"`
+	// Mode now.
+	Mode string `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	// False if the node already was in the mode.
+	Changed bool `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	// Whether the mode was written to the config file. If false, the node
+	// returns to the configured mode on restart.
+	Persisted     bool `protobuf:"varint,4,opt,name=persisted,proto3" json:"persisted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetModeResponse) Reset() {
+	*x = SetModeResponse{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetModeResponse) ProtoMessage() {}
+
+func (x *SetModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetModeResponse.ProtoReflect.Descriptor instead.
+func (*SetModeResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetModeResponse) GetPreviousMode() string {
+	if x != nil {
+		return x.PreviousMode
+	}
+	return ""
+}
+
+func (x *SetModeResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *SetModeResponse) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+func (x *SetModeResponse) GetPersisted() bool {
+	if x != nil {
+		return x.Persisted
+	}
+	return false
+}
+
+// SendMessageRequest sends a message to a peer.
+type SendMessageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peer ID of the recipient.
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// Application-defined message kind, passed to the recipient as is.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Message payload. Limited by p2p.messaging.max_payload_size.
+	Payload       []byte `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SendMessageRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// SendMessageResponse reports the delivery.
+type SendMessageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the message, shared by all delivery attempts.
+	MessageId string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Whether the recipient accepted the message.
+	Accepted bool `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Why the recipient rejected the message, if it did.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Number of delivery attempts made.
+	Attempts      int32 `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SendMessageResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SendMessageResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *SendMessageResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SendMessageResponse) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+// SetLabelsRequest changes this node's labels. Keys are 1-63 lowercase
+// letters, digits, '-' and '_'; values are at most 63 letters, digits, '.',
+// '-' and '_'.
+type SetLabelsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Labels to add or overwrite.
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Keys of labels to remove.
+	Remove []string `protobuf:"bytes,2,rep,name=remove,proto3" json:"remove,omitempty"`
+	// Replace all labels with labels instead of merging.
+	Replace       bool `protobuf:"varint,3,opt,name=replace,proto3" json:"replace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLabelsRequest) Reset() {
+	*x = SetLabelsRequest{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLabelsRequest) ProtoMessage() {}
+
+func (x *SetLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLabelsRequest.ProtoReflect.Descriptor instead.
+func (*SetLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetLabelsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SetLabelsRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+func (x *SetLabelsRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+// SetLabelsResponse reports the node's labels after the change.
+type SetLabelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The node's labels now.
+	Labels map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Whether the labels were written to the config file. If false, the node
+	// returns to the configured labels on restart.
+	Persisted     bool `protobuf:"varint,2,opt,name=persisted,proto3" json:"persisted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLabelsResponse) Reset() {
+	*x = SetLabelsResponse{}
+	mi := &file_bib_v1_services_node_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLabelsResponse) ProtoMessage() {}
+
+func (x *SetLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_node_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLabelsResponse.ProtoReflect.Descriptor instead.
+func (*SetLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_node_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetLabelsResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SetLabelsResponse) GetPersisted() bool {
+	if x != nil {
+		return x.Persisted
+	}
+	return false
+}
+
 var File_bib_v1_services_node_proto protoreflect.FileDescriptor
 
 const file_bib_v1_services_node_proto_rawDesc = "" +
 	"\n" +
-	"\x1abib/v1/services/node.proto\x12\x0fbib.v1.services\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13bib/v1/common.proto\"\x98\x05\n" +
+	"\x1abib/v1/services/node.proto\x12\x0fbib.v1.services\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13bib/v1/common.proto\"\x92\x06\n" +
 	"\bNodeInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x18\n" +
@@ -1945,20 +2690,28 @@ const file_bib_v1_services_node_proto_rawDesc = "" +
 	"\n" +
 	"reputation\x18\x0f \x01(\x05R\n" +
 	"reputation\x12C\n" +
-	"\bmetadata\x18\x10 \x03(\v2'.bib.v1.services.NodeInfo.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x10 \x03(\v2'.bib.v1.services.NodeInfo.MetadataEntryR\bmetadata\x12=\n" +
+	"\x06labels\x18\x11 \x03(\v2%.bib.v1.services.NodeInfo.LabelsEntryR\x06labels\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\")\n" +
 	"\x0eGetNodeRequest\x12\x17\n" +
 	"\anode_id\x18\x01 \x01(\tR\x06nodeId\"@\n" +
 	"\x0fGetNodeResponse\x12-\n" +
-	"\x04node\x18\x01 \x01(\v2\x19.bib.v1.services.NodeInfoR\x04node\"\xcc\x01\n" +
+	"\x04node\x18\x01 \x01(\v2\x19.bib.v1.services.NodeInfoR\x04node\"\xeb\x02\n" +
 	"\x10ListNodesRequest\x12\x12\n" +
 	"\x04mode\x18\x01 \x01(\tR\x04mode\x12%\n" +
 	"\x0econnected_only\x18\x02 \x01(\bR\rconnectedOnly\x12-\n" +
 	"\x12authoritative_only\x18\x03 \x01(\bR\x11authoritativeOnly\x12'\n" +
 	"\x04page\x18\x04 \x01(\v2\x13.bib.v1.PageRequestR\x04page\x12%\n" +
-	"\x04sort\x18\x05 \x01(\v2\x11.bib.v1.SortOrderR\x04sort\"s\n" +
+	"\x04sort\x18\x05 \x01(\v2\x11.bib.v1.SortOrderR\x04sort\x12[\n" +
+	"\x0elabel_selector\x18\x06 \x03(\v24.bib.v1.services.ListNodesRequest.LabelSelectorEntryR\rlabelSelector\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"s\n" +
 	"\x11ListNodesResponse\x12/\n" +
 	"\x05nodes\x18\x01 \x03(\v2\x19.bib.v1.services.NodeInfoR\x05nodes\x12-\n" +
 	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"9\n" +
@@ -2071,7 +2824,55 @@ const file_bib_v1_services_node_proto_rawDesc = "" +
 	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"{\n" +
 	"\x17ListBannedPeersResponse\x121\n" +
 	"\x05peers\x18\x01 \x03(\v2\x1b.bib.v1.services.BannedPeerR\x05peers\x12-\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo2\xd9\b\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\xab\x01\n" +
+	"\x10NodeSubscription\x12#\n" +
+	"\rtopic_pattern\x18\x01 \x01(\tR\ftopicPattern\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tlast_sync\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\blastSync\"2\n" +
+	"\x16AddSubscriptionRequest\x12\x18\n" +
+	"\apattern\x18\x01 \x01(\tR\apattern\"v\n" +
+	"\x17AddSubscriptionResponse\x12E\n" +
+	"\fsubscription\x18\x01 \x01(\v2!.bib.v1.services.NodeSubscriptionR\fsubscription\x12\x14\n" +
+	"\x05added\x18\x02 \x01(\bR\x05added\"5\n" +
+	"\x19RemoveSubscriptionRequest\x12\x18\n" +
+	"\apattern\x18\x01 \x01(\tR\apattern\"6\n" +
+	"\x1aRemoveSubscriptionResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\bR\aremoved\"\x1e\n" +
+	"\x1cListNodeSubscriptionsRequest\"h\n" +
+	"\x1dListNodeSubscriptionsResponse\x12G\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2!.bib.v1.services.NodeSubscriptionR\rsubscriptions\"I\n" +
+	"\x0eSetModeRequest\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12#\n" +
+	"\rpurge_replica\x18\x02 \x01(\bR\fpurgeReplica\"\x82\x01\n" +
+	"\x0fSetModeResponse\x12#\n" +
+	"\rprevious_mode\x18\x01 \x01(\tR\fpreviousMode\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x18\n" +
+	"\achanged\x18\x03 \x01(\bR\achanged\x12\x1c\n" +
+	"\tpersisted\x18\x04 \x01(\bR\tpersisted\"[\n" +
+	"\x12SendMessageRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\"\x84\x01\n" +
+	"\x13SendMessageResponse\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\"\xc6\x01\n" +
+	"\x10SetLabelsRequest\x12E\n" +
+	"\x06labels\x18\x01 \x03(\v2-.bib.v1.services.SetLabelsRequest.LabelsEntryR\x06labels\x12\x16\n" +
+	"\x06remove\x18\x02 \x03(\tR\x06remove\x12\x18\n" +
+	"\areplace\x18\x03 \x01(\bR\areplace\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb4\x01\n" +
+	"\x11SetLabelsResponse\x12F\n" +
+	"\x06labels\x18\x01 \x03(\v2..bib.v1.services.SetLabelsResponse.LabelsEntryR\x06labels\x12\x1c\n" +
+	"\tpersisted\x18\x02 \x01(\bR\tpersisted\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\x9e\r\n" +
 	"\vNodeService\x12L\n" +
 	"\aGetNode\x12\x1f.bib.v1.services.GetNodeRequest\x1a .bib.v1.services.GetNodeResponse\x12R\n" +
 	"\tListNodes\x12!.bib.v1.services.ListNodesRequest\x1a\".bib.v1.services.ListNodesResponse\x12X\n" +
@@ -2084,7 +2885,13 @@ const file_bib_v1_services_node_proto_rawDesc = "" +
 	"\x12ListConnectedPeers\x12*.bib.v1.services.ListConnectedPeersRequest\x1a+.bib.v1.services.ListConnectedPeersResponse\x12L\n" +
 	"\aBanPeer\x12\x1f.bib.v1.services.BanPeerRequest\x1a .bib.v1.services.BanPeerResponse\x12R\n" +
 	"\tUnbanPeer\x12!.bib.v1.services.UnbanPeerRequest\x1a\".bib.v1.services.UnbanPeerResponse\x12d\n" +
-	"\x0fListBannedPeers\x12'.bib.v1.services.ListBannedPeersRequest\x1a(.bib.v1.services.ListBannedPeersResponseB\x9e\x01\n" +
+	"\x0fListBannedPeers\x12'.bib.v1.services.ListBannedPeersRequest\x1a(.bib.v1.services.ListBannedPeersResponse\x12d\n" +
+	"\x0fAddSubscription\x12'.bib.v1.services.AddSubscriptionRequest\x1a(.bib.v1.services.AddSubscriptionResponse\x12m\n" +
+	"\x12RemoveSubscription\x12*.bib.v1.services.RemoveSubscriptionRequest\x1a+.bib.v1.services.RemoveSubscriptionResponse\x12r\n" +
+	"\x11ListSubscriptions\x12-.bib.v1.services.ListNodeSubscriptionsRequest\x1a..bib.v1.services.ListNodeSubscriptionsResponse\x12L\n" +
+	"\aSetMode\x12\x1f.bib.v1.services.SetModeRequest\x1a .bib.v1.services.SetModeResponse\x12X\n" +
+	"\vSendMessage\x12#.bib.v1.services.SendMessageRequest\x1a$.bib.v1.services.SendMessageResponse\x12R\n" +
+	"\tSetLabels\x12!.bib.v1.services.SetLabelsRequest\x1a\".bib.v1.services.SetLabelsResponseB\x9e\x01\n" +
 	"\x13com.bib.v1.servicesB\tNodeProtoP\x01Z\x1ebib/api/gen/go/bib/v1/services\xa2\x02\x03BVS\xaa\x02\x0fBib.V1.Services\xca\x02\x0fBib\\V1\\Services\xe2\x02\x1bBib\\V1\\Services\\GPBMetadata\xea\x02\x11Bib::V1::Servicesb\x06proto3"
 
 var (
@@ -2099,102 +2906,139 @@ func file_bib_v1_services_node_proto_rawDescGZIP() []byte {
 	return file_bib_v1_services_node_proto_rawDescData
 }
 
-var file_bib_v1_services_node_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
+var file_bib_v1_services_node_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_bib_v1_services_node_proto_goTypes = []any{
-	(*NodeInfo)(nil),                   // 0: bib.v1.services.NodeInfo
-	(*GetNodeRequest)(nil),             // 1: bib.v1.services.GetNodeRequest
-	(*GetNodeResponse)(nil),            // 2: bib.v1.services.GetNodeResponse
-	(*ListNodesRequest)(nil),           // 3: bib.v1.services.ListNodesRequest
-	(*ListNodesResponse)(nil),          // 4: bib.v1.services.ListNodesResponse
-	(*GetSelfNodeRequest)(nil),         // 5: bib.v1.services.GetSelfNodeRequest
-	(*GetSelfNodeResponse)(nil),        // 6: bib.v1.services.GetSelfNodeResponse
-	(*ConnectPeerRequest)(nil),         // 7: bib.v1.services.ConnectPeerRequest
-	(*ConnectPeerResponse)(nil),        // 8: bib.v1.services.ConnectPeerResponse
-	(*DisconnectPeerRequest)(nil),      // 9: bib.v1.services.DisconnectPeerRequest
-	(*DisconnectPeerResponse)(nil),     // 10: bib.v1.services.DisconnectPeerResponse
-	(*GetPeerInfoRequest)(nil),         // 11: bib.v1.services.GetPeerInfoRequest
-	(*GetPeerInfoResponse)(nil),        // 12: bib.v1.services.GetPeerInfoResponse
-	(*ConnectionInfo)(nil),             // 13: bib.v1.services.ConnectionInfo
-	(*ListConnectedPeersRequest)(nil),  // 14: bib.v1.services.ListConnectedPeersRequest
-	(*ListConnectedPeersResponse)(nil), // 15: bib.v1.services.ListConnectedPeersResponse
-	(*GetNetworkStatsRequest)(nil),     // 16: bib.v1.services.GetNetworkStatsRequest
-	(*GetNetworkStatsResponse)(nil),    // 17: bib.v1.services.GetNetworkStatsResponse
-	(*ProtocolStats)(nil),              // 18: bib.v1.services.ProtocolStats
-	(*BandwidthStats)(nil),             // 19: bib.v1.services.BandwidthStats
-	(*StreamNodeEventsRequest)(nil),    // 20: bib.v1.services.StreamNodeEventsRequest
-	(*NodeEvent)(nil),                  // 21: bib.v1.services.NodeEvent
-	(*BanPeerRequest)(nil),             // 22: bib.v1.services.BanPeerRequest
-	(*BanPeerResponse)(nil),            // 23: bib.v1.services.BanPeerResponse
-	(*UnbanPeerRequest)(nil),           // 24: bib.v1.services.UnbanPeerRequest
-	(*UnbanPeerResponse)(nil),          // 25: bib.v1.services.UnbanPeerResponse
-	(*ListBannedPeersRequest)(nil),     // 26: bib.v1.services.ListBannedPeersRequest
-	(*BannedPeer)(nil),                 // 27: bib.v1.services.BannedPeer
-	(*ListBannedPeersResponse)(nil),    // 28: bib.v1.services.ListBannedPeersResponse
-	nil,                                // 29: bib.v1.services.NodeInfo.MetadataEntry
-	nil,                                // 30: bib.v1.services.GetNetworkStatsResponse.ProtocolStatsEntry
-	nil,                                // 31: bib.v1.services.NodeEvent.DetailsEntry
-	(*timestamppb.Timestamp)(nil),      // 32: google.protobuf.Timestamp
-	(*v1.PageRequest)(nil),             // 33: bib.v1.PageRequest
-	(*v1.SortOrder)(nil),               // 34: bib.v1.SortOrder
-	(*v1.PageInfo)(nil),                // 35: bib.v1.PageInfo
+	(*NodeInfo)(nil),                      // 0: bib.v1.services.NodeInfo
+	(*GetNodeRequest)(nil),                // 1: bib.v1.services.GetNodeRequest
+	(*GetNodeResponse)(nil),               // 2: bib.v1.services.GetNodeResponse
+	(*ListNodesRequest)(nil),              // 3: bib.v1.services.ListNodesRequest
+	(*ListNodesResponse)(nil),             // 4: bib.v1.services.ListNodesResponse
+	(*GetSelfNodeRequest)(nil),            // 5: bib.v1.services.GetSelfNodeRequest
+	(*GetSelfNodeResponse)(nil),           // 6: bib.v1.services.GetSelfNodeResponse
+	(*ConnectPeerRequest)(nil),            // 7: bib.v1.services.ConnectPeerRequest
+	(*ConnectPeerResponse)(nil),           // 8: bib.v1.services.ConnectPeerResponse
+	(*DisconnectPeerRequest)(nil),         // 9: bib.v1.services.DisconnectPeerRequest
+	(*DisconnectPeerResponse)(nil),        // 10: bib.v1.services.DisconnectPeerResponse
+	(*GetPeerInfoRequest)(nil),            // 11: bib.v1.services.GetPeerInfoRequest
+	(*GetPeerInfoResponse)(nil),           // 12: bib.v1.services.GetPeerInfoResponse
+	(*ConnectionInfo)(nil),                // 13: bib.v1.services.ConnectionInfo
+	(*ListConnectedPeersRequest)(nil),     // 14: bib.v1.services.ListConnectedPeersRequest
+	(*ListConnectedPeersResponse)(nil),    // 15: bib.v1.services.ListConnectedPeersResponse
+	(*GetNetworkStatsRequest)(nil),        // 16: bib.v1.services.GetNetworkStatsRequest
+	(*GetNetworkStatsResponse)(nil),       // 17: bib.v1.services.GetNetworkStatsResponse
+	(*ProtocolStats)(nil),                 // 18: bib.v1.services.ProtocolStats
+	(*BandwidthStats)(nil),                // 19: bib.v1.services.BandwidthStats
+	(*StreamNodeEventsRequest)(nil),       // 20: bib.v1.services.StreamNodeEventsRequest
+	(*NodeEvent)(nil),                     // 21: bib.v1.services.NodeEvent
+	(*BanPeerRequest)(nil),                // 22: bib.v1.services.BanPeerRequest
+	(*BanPeerResponse)(nil),               // 23: bib.v1.services.BanPeerResponse
+	(*UnbanPeerRequest)(nil),              // 24: bib.v1.services.UnbanPeerRequest
+	(*UnbanPeerResponse)(nil),             // 25: bib.v1.services.UnbanPeerResponse
+	(*ListBannedPeersRequest)(nil),        // 26: bib.v1.services.ListBannedPeersRequest
+	(*BannedPeer)(nil),                    // 27: bib.v1.services.BannedPeer
+	(*ListBannedPeersResponse)(nil),       // 28: bib.v1.services.ListBannedPeersResponse
+	(*NodeSubscription)(nil),              // 29: bib.v1.services.NodeSubscription
+	(*AddSubscriptionRequest)(nil),        // 30: bib.v1.services.AddSubscriptionRequest
+	(*AddSubscriptionResponse)(nil),       // 31: bib.v1.services.AddSubscriptionResponse
+	(*RemoveSubscriptionRequest)(nil),     // 32: bib.v1.services.RemoveSubscriptionRequest
+	(*RemoveSubscriptionResponse)(nil),    // 33: bib.v1.services.RemoveSubscriptionResponse
+	(*ListNodeSubscriptionsRequest)(nil),  // 34: bib.v1.services.ListNodeSubscriptionsRequest
+	(*ListNodeSubscriptionsResponse)(nil), // 35: bib.v1.services.ListNodeSubscriptionsResponse
+	(*SetModeRequest)(nil),                // 36: bib.v1.services.SetModeRequest
+	(*SetModeResponse)(nil),               // 37: bib.v1.services.SetModeResponse
+	(*SendMessageRequest)(nil),            // 38: bib.v1.services.SendMessageRequest
+	(*SendMessageResponse)(nil),           // 39: bib.v1.services.SendMessageResponse
+	(*SetLabelsRequest)(nil),              // 40: bib.v1.services.SetLabelsRequest
+	(*SetLabelsResponse)(nil),             // 41: bib.v1.services.SetLabelsResponse
+	nil,                                   // 42: bib.v1.services.NodeInfo.MetadataEntry
+	nil,                                   // 43: bib.v1.services.NodeInfo.LabelsEntry
+	nil,                                   // 44: bib.v1.services.ListNodesRequest.LabelSelectorEntry
+	nil,                                   // 45: bib.v1.services.GetNetworkStatsResponse.ProtocolStatsEntry
+	nil,                                   // 46: bib.v1.services.NodeEvent.DetailsEntry
+	nil,                                   // 47: bib.v1.services.SetLabelsRequest.LabelsEntry
+	nil,                                   // 48: bib.v1.services.SetLabelsResponse.LabelsEntry
+	(*timestamppb.Timestamp)(nil),         // 49: google.protobuf.Timestamp
+	(*v1.PageRequest)(nil),                // 50: bib.v1.PageRequest
+	(*v1.SortOrder)(nil),                  // 51: bib.v1.SortOrder
+	(*v1.PageInfo)(nil),                   // 52: bib.v1.PageInfo
 }
 var file_bib_v1_services_node_proto_depIdxs = []int32{
-	32, // 0: bib.v1.services.NodeInfo.discovered_at:type_name -> google.protobuf.Timestamp
-	32, // 1: bib.v1.services.NodeInfo.last_seen:type_name -> google.protobuf.Timestamp
-	29, // 2: bib.v1.services.NodeInfo.metadata:type_name -> bib.v1.services.NodeInfo.MetadataEntry
-	0,  // 3: bib.v1.services.GetNodeResponse.node:type_name -> bib.v1.services.NodeInfo
-	33, // 4: bib.v1.services.ListNodesRequest.page:type_name -> bib.v1.PageRequest
-	34, // 5: bib.v1.services.ListNodesRequest.sort:type_name -> bib.v1.SortOrder
-	0,  // 6: bib.v1.services.ListNodesResponse.nodes:type_name -> bib.v1.services.NodeInfo
-	35, // 7: bib.v1.services.ListNodesResponse.page_info:type_name -> bib.v1.PageInfo
-	0,  // 8: bib.v1.services.GetSelfNodeResponse.node:type_name -> bib.v1.services.NodeInfo
-	0,  // 9: bib.v1.services.ConnectPeerResponse.node:type_name -> bib.v1.services.NodeInfo
-	0,  // 10: bib.v1.services.GetPeerInfoResponse.node:type_name -> bib.v1.services.NodeInfo
-	13, // 11: bib.v1.services.GetPeerInfoResponse.connection:type_name -> bib.v1.services.ConnectionInfo
-	32, // 12: bib.v1.services.ConnectionInfo.connected_at:type_name -> google.protobuf.Timestamp
-	33, // 13: bib.v1.services.ListConnectedPeersRequest.page:type_name -> bib.v1.PageRequest
-	0,  // 14: bib.v1.services.ListConnectedPeersResponse.peers:type_name -> bib.v1.services.NodeInfo
-	35, // 15: bib.v1.services.ListConnectedPeersResponse.page_info:type_name -> bib.v1.PageInfo
-	30, // 16: bib.v1.services.GetNetworkStatsResponse.protocol_stats:type_name -> bib.v1.services.GetNetworkStatsResponse.ProtocolStatsEntry
-	19, // 17: bib.v1.services.GetNetworkStatsResponse.bandwidth:type_name -> bib.v1.services.BandwidthStats
-	0,  // 18: bib.v1.services.NodeEvent.node:type_name -> bib.v1.services.NodeInfo
-	32, // 19: bib.v1.services.NodeEvent.timestamp:type_name -> google.protobuf.Timestamp
-	31, // 20: bib.v1.services.NodeEvent.details:type_name -> bib.v1.services.NodeEvent.DetailsEntry
-	33, // 21: bib.v1.services.ListBannedPeersRequest.page:type_name -> bib.v1.PageRequest
-	32, // 22: bib.v1.services.BannedPeer.banned_at:type_name -> google.protobuf.Timestamp
-	32, // 23: bib.v1.services.BannedPeer.expires_at:type_name -> google.protobuf.Timestamp
-	27, // 24: bib.v1.services.ListBannedPeersResponse.peers:type_name -> bib.v1.services.BannedPeer
-	35, // 25: bib.v1.services.ListBannedPeersResponse.page_info:type_name -> bib.v1.PageInfo
-	18, // 26: bib.v1.services.GetNetworkStatsResponse.ProtocolStatsEntry.value:type_name -> bib.v1.services.ProtocolStats
-	1,  // 27: bib.v1.services.NodeService.GetNode:input_type -> bib.v1.services.GetNodeRequest
-	3,  // 28: bib.v1.services.NodeService.ListNodes:input_type -> bib.v1.services.ListNodesRequest
-	5,  // 29: bib.v1.services.NodeService.GetSelfNode:input_type -> bib.v1.services.GetSelfNodeRequest
-	7,  // 30: bib.v1.services.NodeService.ConnectPeer:input_type -> bib.v1.services.ConnectPeerRequest
-	9,  // 31: bib.v1.services.NodeService.DisconnectPeer:input_type -> bib.v1.services.DisconnectPeerRequest
-	16, // 32: bib.v1.services.NodeService.GetNetworkStats:input_type -> bib.v1.services.GetNetworkStatsRequest
-	20, // 33: bib.v1.services.NodeService.StreamNodeEvents:input_type -> bib.v1.services.StreamNodeEventsRequest
-	11, // 34: bib.v1.services.NodeService.GetPeerInfo:input_type -> bib.v1.services.GetPeerInfoRequest
-	14, // 35: bib.v1.services.NodeService.ListConnectedPeers:input_type -> bib.v1.services.ListConnectedPeersRequest
-	22, // 36: bib.v1.services.NodeService.BanPeer:input_type -> bib.v1.services.BanPeerRequest
-	24, // 37: bib.v1.services.NodeService.UnbanPeer:input_type -> bib.v1.services.UnbanPeerRequest
-	26, // 38: bib.v1.services.NodeService.ListBannedPeers:input_type -> bib.v1.services.ListBannedPeersRequest
-	2,  // 39: bib.v1.services.NodeService.GetNode:output_type -> bib.v1.services.GetNodeResponse
-	4,  // 40: bib.v1.services.NodeService.ListNodes:output_type -> bib.v1.services.ListNodesResponse
-	6,  // 41: bib.v1.services.NodeService.GetSelfNode:output_type -> bib.v1.services.GetSelfNodeResponse
-	8,  // 42: bib.v1.services.NodeService.ConnectPeer:output_type -> bib.v1.services.ConnectPeerResponse
-	10, // 43: bib.v1.services.NodeService.DisconnectPeer:output_type -> bib.v1.services.DisconnectPeerResponse
-	17, // 44: bib.v1.services.NodeService.GetNetworkStats:output_type -> bib.v1.services.GetNetworkStatsResponse
-	21, // 45: bib.v1.services.NodeService.StreamNodeEvents:output_type -> bib.v1.services.NodeEvent
-	12, // 46: bib.v1.services.NodeService.GetPeerInfo:output_type -> bib.v1.services.GetPeerInfoResponse
-	15, // 47: bib.v1.services.NodeService.ListConnectedPeers:output_type -> bib.v1.services.ListConnectedPeersResponse
-	23, // 48: bib.v1.services.NodeService.BanPeer:output_type -> bib.v1.services.BanPeerResponse
-	25, // 49: bib.v1.services.NodeService.UnbanPeer:output_type -> bib.v1.services.UnbanPeerResponse
-	28, // 50: bib.v1.services.NodeService.ListBannedPeers:output_type -> bib.v1.services.ListBannedPeersResponse
-	39, // [39:51] is the sub-list for method output_type
-	27, // [27:39] is the sub-list for method input_type
-	27, // [27:27] is the sub-list for extension type_name
-	27, // [27:27] is the sub-list for extension extendee
-	0,  // [0:27] is the sub-list for field type_name
+	49, // 0: bib.v1.services.NodeInfo.discovered_at:type_name -> google.protobuf.Timestamp
+	49, // 1: bib.v1.services.NodeInfo.last_seen:type_name -> google.protobuf.Timestamp
+	42, // 2: bib.v1.services.NodeInfo.metadata:type_name -> bib.v1.services.NodeInfo.MetadataEntry
+	43, // 3: bib.v1.services.NodeInfo.labels:type_name -> bib.v1.services.NodeInfo.LabelsEntry
+	0,  // 4: bib.v1.services.GetNodeResponse.node:type_name -> bib.v1.services.NodeInfo
+	50, // 5: bib.v1.services.ListNodesRequest.page:type_name -> bib.v1.PageRequest
+	51, // 6: bib.v1.services.ListNodesRequest.sort:type_name -> bib.v1.SortOrder
+	44, // 7: bib.v1.services.ListNodesRequest.label_selector:type_name -> bib.v1.services.ListNodesRequest.LabelSelectorEntry
+	0,  // 8: bib.v1.services.ListNodesResponse.nodes:type_name -> bib.v1.services.NodeInfo
+	52, // 9: bib.v1.services.ListNodesResponse.page_info:type_name -> bib.v1.PageInfo
+	0,  // 10: bib.v1.services.GetSelfNodeResponse.node:type_name -> bib.v1.services.NodeInfo
+	0,  // 11: bib.v1.services.ConnectPeerResponse.node:type_name -> bib.v1.services.NodeInfo
+	0,  // 12: bib.v1.services.GetPeerInfoResponse.node:type_name -> bib.v1.services.NodeInfo
+	13, // 13: bib.v1.services.GetPeerInfoResponse.connection:type_name -> bib.v1.services.ConnectionInfo
+	49, // 14: bib.v1.services.ConnectionInfo.connected_at:type_name -> google.protobuf.Timestamp
+	50, // 15: bib.v1.services.ListConnectedPeersRequest.page:type_name -> bib.v1.PageRequest
+	0,  // 16: bib.v1.services.ListConnectedPeersResponse.peers:type_name -> bib.v1.services.NodeInfo
+	52, // 17: bib.v1.services.ListConnectedPeersResponse.page_info:type_name -> bib.v1.PageInfo
+	45, // 18: bib.v1.services.GetNetworkStatsResponse.protocol_stats:type_name -> bib.v1.services.GetNetworkStatsResponse.ProtocolStatsEntry
+	19, // 19: bib.v1.services.GetNetworkStatsResponse.bandwidth:type_name -> bib.v1.services.BandwidthStats
+	0,  // 20: bib.v1.services.NodeEvent.node:type_name -> bib.v1.services.NodeInfo
+	49, // 21: bib.v1.services.NodeEvent.timestamp:type_name -> google.protobuf.Timestamp
+	46, // 22: bib.v1.services.NodeEvent.details:type_name -> bib.v1.services.NodeEvent.DetailsEntry
+	50, // 23: bib.v1.services.ListBannedPeersRequest.page:type_name -> bib.v1.PageRequest
+	49, // 24: bib.v1.services.BannedPeer.banned_at:type_name -> google.protobuf.Timestamp
+	49, // 25: bib.v1.services.BannedPeer.expires_at:type_name -> google.protobuf.Timestamp
+	27, // 26: bib.v1.services.ListBannedPeersResponse.peers:type_name -> bib.v1.services.BannedPeer
+	52, // 27: bib.v1.services.ListBannedPeersResponse.page_info:type_name -> bib.v1.PageInfo
+	49, // 28: bib.v1.services.NodeSubscription.created_at:type_name -> google.protobuf.Timestamp
+	49, // 29: bib.v1.services.NodeSubscription.last_sync:type_name -> google.protobuf.Timestamp
+	29, // 30: bib.v1.services.AddSubscriptionResponse.subscription:type_name -> bib.v1.services.NodeSubscription
+	29, // 31: bib.v1.services.ListNodeSubscriptionsResponse.subscriptions:type_name -> bib.v1.services.NodeSubscription
+	47, // 32: bib.v1.services.SetLabelsRequest.labels:type_name -> bib.v1.services.SetLabelsRequest.LabelsEntry
+	48, // 33: bib.v1.services.SetLabelsResponse.labels:type_name -> bib.v1.services.SetLabelsResponse.LabelsEntry
+	18, // 34: bib.v1.services.GetNetworkStatsResponse.ProtocolStatsEntry.value:type_name -> bib.v1.services.ProtocolStats
+	1,  // 35: bib.v1.services.NodeService.GetNode:input_type -> bib.v1.services.GetNodeRequest
+	3,  // 36: bib.v1.services.NodeService.ListNodes:input_type -> bib.v1.services.ListNodesRequest
+	5,  // 37: bib.v1.services.NodeService.GetSelfNode:input_type -> bib.v1.services.GetSelfNodeRequest
+	7,  // 38: bib.v1.services.NodeService.ConnectPeer:input_type -> bib.v1.services.ConnectPeerRequest
+	9,  // 39: bib.v1.services.NodeService.DisconnectPeer:input_type -> bib.v1.services.DisconnectPeerRequest
+	16, // 40: bib.v1.services.NodeService.GetNetworkStats:input_type -> bib.v1.services.GetNetworkStatsRequest
+	20, // 41: bib.v1.services.NodeService.StreamNodeEvents:input_type -> bib.v1.services.StreamNodeEventsRequest
+	11, // 42: bib.v1.services.NodeService.GetPeerInfo:input_type -> bib.v1.services.GetPeerInfoRequest
+	14, // 43: bib.v1.services.NodeService.ListConnectedPeers:input_type -> bib.v1.services.ListConnectedPeersRequest
+	22, // 44: bib.v1.services.NodeService.BanPeer:input_type -> bib.v1.services.BanPeerRequest
+	24, // 45: bib.v1.services.NodeService.UnbanPeer:input_type -> bib.v1.services.UnbanPeerRequest
+	26, // 46: bib.v1.services.NodeService.ListBannedPeers:input_type -> bib.v1.services.ListBannedPeersRequest
+	30, // 47: bib.v1.services.NodeService.AddSubscription:input_type -> bib.v1.services.AddSubscriptionRequest
+	32, // 48: bib.v1.services.NodeService.RemoveSubscription:input_type -> bib.v1.services.RemoveSubscriptionRequest
+	34, // 49: bib.v1.services.NodeService.ListSubscriptions:input_type -> bib.v1.services.ListNodeSubscriptionsRequest
+	36, // 50: bib.v1.services.NodeService.SetMode:input_type -> bib.v1.services.SetModeRequest
+	38, // 51: bib.v1.services.NodeService.SendMessage:input_type -> bib.v1.services.SendMessageRequest
+	40, // 52: bib.v1.services.NodeService.SetLabels:input_type -> bib.v1.services.SetLabelsRequest
+	2,  // 53: bib.v1.services.NodeService.GetNode:output_type -> bib.v1.services.GetNodeResponse
+	4,  // 54: bib.v1.services.NodeService.ListNodes:output_type -> bib.v1.services.ListNodesResponse
+	6,  // 55: bib.v1.services.NodeService.GetSelfNode:output_type -> bib.v1.services.GetSelfNodeResponse
+	8,  // 56: bib.v1.services.NodeService.ConnectPeer:output_type -> bib.v1.services.ConnectPeerResponse
+	10, // 57: bib.v1.services.NodeService.DisconnectPeer:output_type -> bib.v1.services.DisconnectPeerResponse
+	17, // 58: bib.v1.services.NodeService.GetNetworkStats:output_type -> bib.v1.services.GetNetworkStatsResponse
+	21, // 59: bib.v1.services.NodeService.StreamNodeEvents:output_type -> bib.v1.services.NodeEvent
+	12, // 60: bib.v1.services.NodeService.GetPeerInfo:output_type -> bib.v1.services.GetPeerInfoResponse
+	15, // 61: bib.v1.services.NodeService.ListConnectedPeers:output_type -> bib.v1.services.ListConnectedPeersResponse
+	23, // 62: bib.v1.services.NodeService.BanPeer:output_type -> bib.v1.services.BanPeerResponse
+	25, // 63: bib.v1.services.NodeService.UnbanPeer:output_type -> bib.v1.services.UnbanPeerResponse
+	28, // 64: bib.v1.services.NodeService.ListBannedPeers:output_type -> bib.v1.services.ListBannedPeersResponse
+	31, // 65: bib.v1.services.NodeService.AddSubscription:output_type -> bib.v1.services.AddSubscriptionResponse
+	33, // 66: bib.v1.services.NodeService.RemoveSubscription:output_type -> bib.v1.services.RemoveSubscriptionResponse
+	35, // 67: bib.v1.services.NodeService.ListSubscriptions:output_type -> bib.v1.services.ListNodeSubscriptionsResponse
+	37, // 68: bib.v1.services.NodeService.SetMode:output_type -> bib.v1.services.SetModeResponse
+	39, // 69: bib.v1.services.NodeService.SendMessage:output_type -> bib.v1.services.SendMessageResponse
+	41, // 70: bib.v1.services.NodeService.SetLabels:output_type -> bib.v1.services.SetLabelsResponse
+	53, // [53:71] is the sub-list for method output_type
+	35, // [35:53] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_node_proto_init() }
@@ -2208,7 +3052,7 @@ func file_bib_v1_services_node_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_node_proto_rawDesc), len(file_bib_v1_services_node_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   32,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,6 +31,12 @@ const (
 	NodeService_BanPeer_FullMethodName            = "/bib.v1.services.NodeService/BanPeer"
 	NodeService_UnbanPeer_FullMethodName          = "/bib.v1.services.NodeService/UnbanPeer"
 	NodeService_ListBannedPeers_FullMethodName    = "/bib.v1.services.NodeService/ListBannedPeers"
+	NodeService_AddSubscription_FullMethodName    = "/bib.v1.services.NodeService/AddSubscription"
+	NodeService_RemoveSubscription_FullMethodName = "/bib.v1.services.NodeService/RemoveSubscription"
+	NodeService_ListSubscriptions_FullMethodName  = "/bib.v1.services.NodeService/ListSubscriptions"
+	NodeService_SetMode_FullMethodName            = "/bib.v1.services.NodeService/SetMode"
+	NodeService_SendMessage_FullMethodName        = "/bib.v1.services.NodeService/SendMessage"
+	NodeService_SetLabels_FullMethodName          = "/bib.v1.services.NodeService/SetLabels"
 )
 
 // NodeServiceClient is the client API for NodeService service.
@@ -63,6 +69,22 @@ type NodeServiceClient interface {
 	UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*UnbanPeerResponse, error)
 	// ListBannedPeers lists banned peers.
 	ListBannedPeers(ctx context.Context, in *ListBannedPeersRequest, opts ...grpc.CallOption) (*ListBannedPeersResponse, error)
+	// AddSubscription subscribes a selective node to a topic pattern and
+	// syncs the matching topics right away.
+	AddSubscription(ctx context.Context, in *AddSubscriptionRequest, opts ...grpc.CallOption) (*AddSubscriptionResponse, error)
+	// RemoveSubscription unsubscribes a selective node from a topic pattern.
+	RemoveSubscription(ctx context.Context, in *RemoveSubscriptionRequest, opts ...grpc.CallOption) (*RemoveSubscriptionResponse, error)
+	// ListSubscriptions lists the topic patterns a selective node replicates.
+	ListSubscriptions(ctx context.Context, in *ListNodeSubscriptionsRequest, opts ...grpc.CallOption) (*ListNodeSubscriptionsResponse, error)
+	// SetMode switches the node's P2P mode at runtime and persists it to the
+	// daemon's config file.
+	SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*SetModeResponse, error)
+	// SendMessage delivers a message directly to a connected peer and waits
+	// for the peer's acknowledgment.
+	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
+	// SetLabels changes this node's labels, pushes them to the connected
+	// peers and persists them to the daemon's config file.
+	SetLabels(ctx context.Context, in *SetLabelsRequest, opts ...grpc.CallOption) (*SetLabelsResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -202,6 +224,66 @@ func (c *nodeServiceClient) ListBannedPeers(ctx context.Context, in *ListBannedP
 	return out, nil
 }
 
+func (c *nodeServiceClient) AddSubscription(ctx context.Context, in *AddSubscriptionRequest, opts ...grpc.CallOption) (*AddSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddSubscriptionResponse)
+	err := c.cc.Invoke(ctx, NodeService_AddSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) RemoveSubscription(ctx context.Context, in *RemoveSubscriptionRequest, opts ...grpc.CallOption) (*RemoveSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveSubscriptionResponse)
+	err := c.cc.Invoke(ctx, NodeService_RemoveSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) ListSubscriptions(ctx context.Context, in *ListNodeSubscriptionsRequest, opts ...grpc.CallOption) (*ListNodeSubscriptionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNodeSubscriptionsResponse)
+	err := c.cc.Invoke(ctx, NodeService_ListSubscriptions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) SetMode(ctx context.Context, in *SetModeRequest, opts ...grpc.CallOption) (*SetModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetModeResponse)
+	err := c.cc.Invoke(ctx, NodeService_SetMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendMessageResponse)
+	err := c.cc.Invoke(ctx, NodeService_SendMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) SetLabels(ctx context.Context, in *SetLabelsRequest, opts ...grpc.CallOption) (*SetLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLabelsResponse)
+	err := c.cc.Invoke(ctx, NodeService_SetLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServiceServer is the server API for NodeService service.
 // All implementations should embed UnimplementedNodeServiceServer
 // for forward compatibility.
@@ -232,6 +314,22 @@ type NodeServiceServer interface {
 	UnbanPeer(context.Context, *UnbanPeerRequest) (*UnbanPeerResponse, error)
 	// ListBannedPeers lists banned peers.
 	ListBannedPeers(context.Context, *ListBannedPeersRequest) (*ListBannedPeersResponse, error)
+	// AddSubscription subscribes a selective node to a topic pattern and
+	// syncs the matching topics right away.
+	AddSubscription(context.Context, *AddSubscriptionRequest) (*AddSubscriptionResponse, error)
+	// RemoveSubscription unsubscribes a selective node from a topic pattern.
+	RemoveSubscription(context.Context, *RemoveSubscriptionRequest) (*RemoveSubscriptionResponse, error)
+	// ListSubscriptions lists the topic patterns a selective node replicates.
+	ListSubscriptions(context.Context, *ListNodeSubscriptionsRequest) (*ListNodeSubscriptionsResponse, error)
+	// SetMode switches the node's P2P mode at runtime and persists it to the
+	// daemon's config file.
+	SetMode(context.Context, *SetModeRequest) (*SetModeResponse, error)
+	// SendMessage delivers a message directly to a connected peer and waits
+	// for the peer's acknowledgment.
+	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
+	// SetLabels changes this node's labels, pushes them to the connected
+	// peers and persists them to the daemon's config file.
+	SetLabels(context.Context, *SetLabelsRequest) (*SetLabelsResponse, error)
 }
 
 // UnimplementedNodeServiceServer should be embedded to have
@@ -277,6 +375,24 @@ func (UnimplementedNodeServiceServer) UnbanPeer(context.Context, *UnbanPeerReque
 func (UnimplementedNodeServiceServer) ListBannedPeers(context.Context, *ListBannedPeersRequest) (*ListBannedPeersResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListBannedPeers not implemented")
 }
+func (UnimplementedNodeServiceServer) AddSubscription(context.Context, *AddSubscriptionRequest) (*AddSubscriptionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddSubscription not implemented")
+}
+func (UnimplementedNodeServiceServer) RemoveSubscription(context.Context, *RemoveSubscriptionRequest) (*RemoveSubscriptionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveSubscription not implemented")
+}
+func (UnimplementedNodeServiceServer) ListSubscriptions(context.Context, *ListNodeSubscriptionsRequest) (*ListNodeSubscriptionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSubscriptions not implemented")
+}
+func (UnimplementedNodeServiceServer) SetMode(context.Context, *SetModeRequest) (*SetModeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMode not implemented")
+}
+func (UnimplementedNodeServiceServer) SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedNodeServiceServer) SetLabels(context.Context, *SetLabelsRequest) (*SetLabelsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetLabels not implemented")
+}
 func (UnimplementedNodeServiceServer) testEmbeddedByValue() {}
 
 // UnsafeNodeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -506,6 +622,114 @@ func _NodeService_ListBannedPeers_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_AddSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).AddSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_AddSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).AddSubscription(ctx, req.(*AddSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_RemoveSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).RemoveSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_RemoveSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).RemoveSubscription(ctx, req.(*RemoveSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_ListSubscriptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeSubscriptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ListSubscriptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_ListSubscriptions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ListSubscriptions(ctx, req.(*ListNodeSubscriptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_SetMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).SetMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_SetMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).SetMode(ctx, req.(*SetModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_SendMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_SetLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).SetLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NodeService_SetLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).SetLabels(ctx, req.(*SetLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NodeService_ServiceDesc is the grpc.ServiceDesc for NodeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -557,6 +781,30 @@ var NodeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListBannedPeers",
 			Handler:    _NodeService_ListBannedPeers_Handler,
 		},
+		{
+			MethodName: "AddSubscription",
+			Handler:    _NodeService_AddSubscription_Handler,
+		},
+		{
+			MethodName: "RemoveSubscription",
+			Handler:    _NodeService_RemoveSubscription_Handler,
+		},
+		{
+			MethodName: "ListSubscriptions",
+			Handler:    _NodeService_ListSubscriptions_Handler,
+		},
+		{
+			MethodName: "SetMode",
+			Handler:    _NodeService_SetMode_Handler,
+		},
+		{
+			MethodName: "SendMessage",
+			Handler:    _NodeService_SendMessage_Handler,
+		},
+		{
+			MethodName: "SetLabels",
+			Handler:    _NodeService_SetLabels_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
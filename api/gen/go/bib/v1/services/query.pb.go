@@ -1416,12 +1416,14 @@ func (x *QueryHistoryEntry) GetError() string {
 	return ""
 }
 
-// SaveQueryRequest saves a query.
+// SaveQueryRequest saves a query. Saving under a name the caller already
+// uses replaces that query.
 type SaveQueryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Query name.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Expression.
+	// Expression. Placeholders of the form ${name} are filled in with
+	// parameters when the query is run.
 	Expression string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
 	// Description.
 	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
@@ -1576,7 +1578,9 @@ type SavedQuery struct {
 	// Updated at.
 	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	// Use count.
-	UseCount      int64 `protobuf:"varint,11,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	UseCount int64 `protobuf:"varint,11,opt,name=use_count,json=useCount,proto3" json:"use_count,omitempty"`
+	// Parameters referenced by the expression's placeholders.
+	Parameters    []*ParameterInfo `protobuf:"bytes,12,rep,name=parameters,proto3" json:"parameters,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1688,6 +1692,159 @@ func (x *SavedQuery) GetUseCount() int64 {
 	return 0
 }
 
+func (x *SavedQuery) GetParameters() []*ParameterInfo {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// RunSavedQueryRequest runs a saved query.
+type RunSavedQueryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Query name. The caller's own query takes precedence over public ones.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Query ID (alternative to name).
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// Parameter values, overriding the query's defaults.
+	Parameters map[string]*structpb.Value `protobuf:"bytes,3,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Pagination.
+	Page *v1.PageRequest `protobuf:"bytes,4,opt,name=page,proto3" json:"page,omitempty"`
+	// Maximum execution time.
+	Timeout       *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSavedQueryRequest) Reset() {
+	*x = RunSavedQueryRequest{}
+	mi := &file_bib_v1_services_query_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSavedQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSavedQueryRequest) ProtoMessage() {}
+
+func (x *RunSavedQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_query_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSavedQueryRequest.ProtoReflect.Descriptor instead.
+func (*RunSavedQueryRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RunSavedQueryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunSavedQueryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RunSavedQueryRequest) GetParameters() map[string]*structpb.Value {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *RunSavedQueryRequest) GetPage() *v1.PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *RunSavedQueryRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// RunSavedQueryResponse contains the results of a saved query.
+type RunSavedQueryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The query that was run.
+	Query *SavedQuery `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// The expression after filling in parameters.
+	Expression string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	// Query results.
+	Result        *ExecuteQueryResponse `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunSavedQueryResponse) Reset() {
+	*x = RunSavedQueryResponse{}
+	mi := &file_bib_v1_services_query_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunSavedQueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSavedQueryResponse) ProtoMessage() {}
+
+func (x *RunSavedQueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_query_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunSavedQueryResponse.ProtoReflect.Descriptor instead.
+func (*RunSavedQueryResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RunSavedQueryResponse) GetQuery() *SavedQuery {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *RunSavedQueryResponse) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *RunSavedQueryResponse) GetResult() *ExecuteQueryResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
 // ListSavedQueriesRequest lists saved queries.
 type ListSavedQueriesRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1704,7 +1861,7 @@ type ListSavedQueriesRequest struct {
 
 func (x *ListSavedQueriesRequest) Reset() {
 	*x = ListSavedQueriesRequest{}
-	mi := &file_bib_v1_services_query_proto_msgTypes[21]
+	mi := &file_bib_v1_services_query_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1716,7 +1873,7 @@ func (x *ListSavedQueriesRequest) String() string {
 func (*ListSavedQueriesRequest) ProtoMessage() {}
 
 func (x *ListSavedQueriesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_query_proto_msgTypes[21]
+	mi := &file_bib_v1_services_query_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1729,7 +1886,7 @@ func (x *ListSavedQueriesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSavedQueriesRequest.ProtoReflect.Descriptor instead.
 func (*ListSavedQueriesRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{21}
+	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ListSavedQueriesRequest) GetIncludePublic() bool {
@@ -1771,7 +1928,7 @@ type ListSavedQueriesResponse struct {
 
 func (x *ListSavedQueriesResponse) Reset() {
 	*x = ListSavedQueriesResponse{}
-	mi := &file_bib_v1_services_query_proto_msgTypes[22]
+	mi := &file_bib_v1_services_query_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1783,7 +1940,7 @@ func (x *ListSavedQueriesResponse) String() string {
 func (*ListSavedQueriesResponse) ProtoMessage() {}
 
 func (x *ListSavedQueriesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_query_proto_msgTypes[22]
+	mi := &file_bib_v1_services_query_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1796,7 +1953,7 @@ func (x *ListSavedQueriesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSavedQueriesResponse.ProtoReflect.Descriptor instead.
 func (*ListSavedQueriesResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{22}
+	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ListSavedQueriesResponse) GetQueries() []*SavedQuery {
@@ -1823,7 +1980,7 @@ type DeleteSavedQueryRequest struct {
 
 func (x *DeleteSavedQueryRequest) Reset() {
 	*x = DeleteSavedQueryRequest{}
-	mi := &file_bib_v1_services_query_proto_msgTypes[23]
+	mi := &file_bib_v1_services_query_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1835,7 +1992,7 @@ func (x *DeleteSavedQueryRequest) String() string {
 func (*DeleteSavedQueryRequest) ProtoMessage() {}
 
 func (x *DeleteSavedQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_query_proto_msgTypes[23]
+	mi := &file_bib_v1_services_query_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1848,7 +2005,7 @@ func (x *DeleteSavedQueryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSavedQueryRequest.ProtoReflect.Descriptor instead.
 func (*DeleteSavedQueryRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{23}
+	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *DeleteSavedQueryRequest) GetId() string {
@@ -1868,7 +2025,7 @@ type DeleteSavedQueryResponse struct {
 
 func (x *DeleteSavedQueryResponse) Reset() {
 	*x = DeleteSavedQueryResponse{}
-	mi := &file_bib_v1_services_query_proto_msgTypes[24]
+	mi := &file_bib_v1_services_query_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1880,7 +2037,7 @@ func (x *DeleteSavedQueryResponse) String() string {
 func (*DeleteSavedQueryResponse) ProtoMessage() {}
 
 func (x *DeleteSavedQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_query_proto_msgTypes[24]
+	mi := &file_bib_v1_services_query_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1893,7 +2050,7 @@ func (x *DeleteSavedQueryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSavedQueryResponse.ProtoReflect.Descriptor instead.
 func (*DeleteSavedQueryResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{24}
+	return file_bib_v1_services_query_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DeleteSavedQueryResponse) GetSuccess() bool {
@@ -2059,7 +2216,7 @@ const file_bib_v1_services_query_proto_rawDesc = "" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
 	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01\"F\n" +
 	"\x11SaveQueryResponse\x121\n" +
-	"\x05query\x18\x01 \x01(\v2\x1b.bib.v1.services.SavedQueryR\x05query\"\x92\x04\n" +
+	"\x05query\x18\x01 \x01(\v2\x1b.bib.v1.services.SavedQueryR\x05query\"\xd2\x04\n" +
 	"\n" +
 	"SavedQuery\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
@@ -2077,10 +2234,30 @@ const file_bib_v1_services_query_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
-	"\tuse_count\x18\v \x01(\x03R\buseCount\x1a\\\n" +
+	"\tuse_count\x18\v \x01(\x03R\buseCount\x12>\n" +
+	"\n" +
+	"parameters\x18\f \x03(\v2\x1e.bib.v1.services.ParameterInfoR\n" +
+	"parameters\x1a\\\n" +
 	"\x16DefaultParametersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
-	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01\"\x95\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01\"\xc6\x02\n" +
+	"\x14RunSavedQueryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12U\n" +
+	"\n" +
+	"parameters\x18\x03 \x03(\v25.bib.v1.services.RunSavedQueryRequest.ParametersEntryR\n" +
+	"parameters\x12'\n" +
+	"\x04page\x18\x04 \x01(\v2\x13.bib.v1.PageRequestR\x04page\x123\n" +
+	"\atimeout\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1aU\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01\"\xa9\x01\n" +
+	"\x15RunSavedQueryResponse\x121\n" +
+	"\x05query\x18\x01 \x01(\v2\x1b.bib.v1.services.SavedQueryR\x05query\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x02 \x01(\tR\n" +
+	"expression\x12=\n" +
+	"\x06result\x18\x03 \x01(\v2%.bib.v1.services.ExecuteQueryResponseR\x06result\"\x95\x01\n" +
 	"\x17ListSavedQueriesRequest\x12%\n" +
 	"\x0einclude_public\x18\x01 \x01(\bR\rincludePublic\x12\x12\n" +
 	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x16\n" +
@@ -2092,7 +2269,7 @@ const file_bib_v1_services_query_proto_rawDesc = "" +
 	"\x17DeleteSavedQueryRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
 	"\x18DeleteSavedQueryResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xe6\x06\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xc6\a\n" +
 	"\fQueryService\x12V\n" +
 	"\aExecute\x12$.bib.v1.services.ExecuteQueryRequest\x1a%.bib.v1.services.ExecuteQueryResponse\x12U\n" +
 	"\rExecuteStream\x12$.bib.v1.services.ExecuteQueryRequest\x1a\x1c.bib.v1.services.QueryResult0\x01\x12^\n" +
@@ -2100,7 +2277,8 @@ const file_bib_v1_services_query_proto_rawDesc = "" +
 	"\fExplainQuery\x12$.bib.v1.services.ExplainQueryRequest\x1a%.bib.v1.services.ExplainQueryResponse\x12^\n" +
 	"\rListFunctions\x12%.bib.v1.services.ListFunctionsRequest\x1a&.bib.v1.services.ListFunctionsResponse\x12d\n" +
 	"\x0fGetQueryHistory\x12'.bib.v1.services.GetQueryHistoryRequest\x1a(.bib.v1.services.GetQueryHistoryResponse\x12R\n" +
-	"\tSaveQuery\x12!.bib.v1.services.SaveQueryRequest\x1a\".bib.v1.services.SaveQueryResponse\x12g\n" +
+	"\tSaveQuery\x12!.bib.v1.services.SaveQueryRequest\x1a\".bib.v1.services.SaveQueryResponse\x12^\n" +
+	"\rRunSavedQuery\x12%.bib.v1.services.RunSavedQueryRequest\x1a&.bib.v1.services.RunSavedQueryResponse\x12g\n" +
 	"\x10ListSavedQueries\x12(.bib.v1.services.ListSavedQueriesRequest\x1a).bib.v1.services.ListSavedQueriesResponse\x12g\n" +
 	"\x10DeleteSavedQuery\x12(.bib.v1.services.DeleteSavedQueryRequest\x1a).bib.v1.services.DeleteSavedQueryResponseB\x9f\x01\n" +
 	"\x13com.bib.v1.servicesB\n" +
@@ -2118,7 +2296,7 @@ func file_bib_v1_services_query_proto_rawDescGZIP() []byte {
 	return file_bib_v1_services_query_proto_rawDescData
 }
 
-var file_bib_v1_services_query_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_bib_v1_services_query_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_bib_v1_services_query_proto_goTypes = []any{
 	(*ExecuteQueryRequest)(nil),      // 0: bib.v1.services.ExecuteQueryRequest
 	(*ExecuteQueryResponse)(nil),     // 1: bib.v1.services.ExecuteQueryResponse
@@ -2141,84 +2319,96 @@ var file_bib_v1_services_query_proto_goTypes = []any{
 	(*SaveQueryRequest)(nil),         // 18: bib.v1.services.SaveQueryRequest
 	(*SaveQueryResponse)(nil),        // 19: bib.v1.services.SaveQueryResponse
 	(*SavedQuery)(nil),               // 20: bib.v1.services.SavedQuery
-	(*ListSavedQueriesRequest)(nil),  // 21: bib.v1.services.ListSavedQueriesRequest
-	(*ListSavedQueriesResponse)(nil), // 22: bib.v1.services.ListSavedQueriesResponse
-	(*DeleteSavedQueryRequest)(nil),  // 23: bib.v1.services.DeleteSavedQueryRequest
-	(*DeleteSavedQueryResponse)(nil), // 24: bib.v1.services.DeleteSavedQueryResponse
-	nil,                              // 25: bib.v1.services.ExecuteQueryRequest.ParametersEntry
-	nil,                              // 26: bib.v1.services.ValidateQueryRequest.ParameterTypesEntry
-	nil,                              // 27: bib.v1.services.ExplainQueryRequest.ParametersEntry
-	nil,                              // 28: bib.v1.services.QueryPlan.PropertiesEntry
-	nil,                              // 29: bib.v1.services.SaveQueryRequest.DefaultParametersEntry
-	nil,                              // 30: bib.v1.services.SavedQuery.DefaultParametersEntry
-	(*v1.PageRequest)(nil),           // 31: bib.v1.PageRequest
-	(*durationpb.Duration)(nil),      // 32: google.protobuf.Duration
-	(*v1.PageInfo)(nil),              // 33: bib.v1.PageInfo
-	(*structpb.Struct)(nil),          // 34: google.protobuf.Struct
-	(*structpb.Value)(nil),           // 35: google.protobuf.Value
-	(*timestamppb.Timestamp)(nil),    // 36: google.protobuf.Timestamp
+	(*RunSavedQueryRequest)(nil),     // 21: bib.v1.services.RunSavedQueryRequest
+	(*RunSavedQueryResponse)(nil),    // 22: bib.v1.services.RunSavedQueryResponse
+	(*ListSavedQueriesRequest)(nil),  // 23: bib.v1.services.ListSavedQueriesRequest
+	(*ListSavedQueriesResponse)(nil), // 24: bib.v1.services.ListSavedQueriesResponse
+	(*DeleteSavedQueryRequest)(nil),  // 25: bib.v1.services.DeleteSavedQueryRequest
+	(*DeleteSavedQueryResponse)(nil), // 26: bib.v1.services.DeleteSavedQueryResponse
+	nil,                              // 27: bib.v1.services.ExecuteQueryRequest.ParametersEntry
+	nil,                              // 28: bib.v1.services.ValidateQueryRequest.ParameterTypesEntry
+	nil,                              // 29: bib.v1.services.ExplainQueryRequest.ParametersEntry
+	nil,                              // 30: bib.v1.services.QueryPlan.PropertiesEntry
+	nil,                              // 31: bib.v1.services.SaveQueryRequest.DefaultParametersEntry
+	nil,                              // 32: bib.v1.services.SavedQuery.DefaultParametersEntry
+	nil,                              // 33: bib.v1.services.RunSavedQueryRequest.ParametersEntry
+	(*v1.PageRequest)(nil),           // 34: bib.v1.PageRequest
+	(*durationpb.Duration)(nil),      // 35: google.protobuf.Duration
+	(*v1.PageInfo)(nil),              // 36: bib.v1.PageInfo
+	(*structpb.Struct)(nil),          // 37: google.protobuf.Struct
+	(*structpb.Value)(nil),           // 38: google.protobuf.Value
+	(*timestamppb.Timestamp)(nil),    // 39: google.protobuf.Timestamp
 }
 var file_bib_v1_services_query_proto_depIdxs = []int32{
-	25, // 0: bib.v1.services.ExecuteQueryRequest.parameters:type_name -> bib.v1.services.ExecuteQueryRequest.ParametersEntry
-	31, // 1: bib.v1.services.ExecuteQueryRequest.page:type_name -> bib.v1.PageRequest
-	32, // 2: bib.v1.services.ExecuteQueryRequest.timeout:type_name -> google.protobuf.Duration
+	27, // 0: bib.v1.services.ExecuteQueryRequest.parameters:type_name -> bib.v1.services.ExecuteQueryRequest.ParametersEntry
+	34, // 1: bib.v1.services.ExecuteQueryRequest.page:type_name -> bib.v1.PageRequest
+	35, // 2: bib.v1.services.ExecuteQueryRequest.timeout:type_name -> google.protobuf.Duration
 	2,  // 3: bib.v1.services.ExecuteQueryResponse.results:type_name -> bib.v1.services.QueryResult
-	33, // 4: bib.v1.services.ExecuteQueryResponse.page_info:type_name -> bib.v1.PageInfo
+	36, // 4: bib.v1.services.ExecuteQueryResponse.page_info:type_name -> bib.v1.PageInfo
 	3,  // 5: bib.v1.services.ExecuteQueryResponse.stats:type_name -> bib.v1.services.QueryStats
-	34, // 6: bib.v1.services.QueryResult.data:type_name -> google.protobuf.Struct
-	32, // 7: bib.v1.services.QueryStats.execution_time:type_name -> google.protobuf.Duration
-	26, // 8: bib.v1.services.ValidateQueryRequest.parameter_types:type_name -> bib.v1.services.ValidateQueryRequest.ParameterTypesEntry
+	37, // 6: bib.v1.services.QueryResult.data:type_name -> google.protobuf.Struct
+	35, // 7: bib.v1.services.QueryStats.execution_time:type_name -> google.protobuf.Duration
+	28, // 8: bib.v1.services.ValidateQueryRequest.parameter_types:type_name -> bib.v1.services.ValidateQueryRequest.ParameterTypesEntry
 	6,  // 9: bib.v1.services.ValidateQueryResponse.errors:type_name -> bib.v1.services.QueryError
 	7,  // 10: bib.v1.services.ValidateQueryResponse.required_parameters:type_name -> bib.v1.services.ParameterInfo
-	35, // 11: bib.v1.services.ParameterInfo.default_value:type_name -> google.protobuf.Value
-	27, // 12: bib.v1.services.ExplainQueryRequest.parameters:type_name -> bib.v1.services.ExplainQueryRequest.ParametersEntry
+	38, // 11: bib.v1.services.ParameterInfo.default_value:type_name -> google.protobuf.Value
+	29, // 12: bib.v1.services.ExplainQueryRequest.parameters:type_name -> bib.v1.services.ExplainQueryRequest.ParametersEntry
 	10, // 13: bib.v1.services.ExplainQueryResponse.plan:type_name -> bib.v1.services.QueryPlan
 	11, // 14: bib.v1.services.ExplainQueryResponse.estimated_cost:type_name -> bib.v1.services.QueryCost
 	10, // 15: bib.v1.services.QueryPlan.children:type_name -> bib.v1.services.QueryPlan
-	28, // 16: bib.v1.services.QueryPlan.properties:type_name -> bib.v1.services.QueryPlan.PropertiesEntry
-	32, // 17: bib.v1.services.QueryCost.estimated_duration:type_name -> google.protobuf.Duration
+	30, // 16: bib.v1.services.QueryPlan.properties:type_name -> bib.v1.services.QueryPlan.PropertiesEntry
+	35, // 17: bib.v1.services.QueryCost.estimated_duration:type_name -> google.protobuf.Duration
 	14, // 18: bib.v1.services.ListFunctionsResponse.functions:type_name -> bib.v1.services.FunctionInfo
 	7,  // 19: bib.v1.services.FunctionInfo.parameters:type_name -> bib.v1.services.ParameterInfo
-	31, // 20: bib.v1.services.GetQueryHistoryRequest.page:type_name -> bib.v1.PageRequest
+	34, // 20: bib.v1.services.GetQueryHistoryRequest.page:type_name -> bib.v1.PageRequest
 	17, // 21: bib.v1.services.GetQueryHistoryResponse.entries:type_name -> bib.v1.services.QueryHistoryEntry
-	33, // 22: bib.v1.services.GetQueryHistoryResponse.page_info:type_name -> bib.v1.PageInfo
-	36, // 23: bib.v1.services.QueryHistoryEntry.executed_at:type_name -> google.protobuf.Timestamp
-	32, // 24: bib.v1.services.QueryHistoryEntry.duration:type_name -> google.protobuf.Duration
-	29, // 25: bib.v1.services.SaveQueryRequest.default_parameters:type_name -> bib.v1.services.SaveQueryRequest.DefaultParametersEntry
+	36, // 22: bib.v1.services.GetQueryHistoryResponse.page_info:type_name -> bib.v1.PageInfo
+	39, // 23: bib.v1.services.QueryHistoryEntry.executed_at:type_name -> google.protobuf.Timestamp
+	35, // 24: bib.v1.services.QueryHistoryEntry.duration:type_name -> google.protobuf.Duration
+	31, // 25: bib.v1.services.SaveQueryRequest.default_parameters:type_name -> bib.v1.services.SaveQueryRequest.DefaultParametersEntry
 	20, // 26: bib.v1.services.SaveQueryResponse.query:type_name -> bib.v1.services.SavedQuery
-	30, // 27: bib.v1.services.SavedQuery.default_parameters:type_name -> bib.v1.services.SavedQuery.DefaultParametersEntry
-	36, // 28: bib.v1.services.SavedQuery.created_at:type_name -> google.protobuf.Timestamp
-	36, // 29: bib.v1.services.SavedQuery.updated_at:type_name -> google.protobuf.Timestamp
-	31, // 30: bib.v1.services.ListSavedQueriesRequest.page:type_name -> bib.v1.PageRequest
-	20, // 31: bib.v1.services.ListSavedQueriesResponse.queries:type_name -> bib.v1.services.SavedQuery
-	33, // 32: bib.v1.services.ListSavedQueriesResponse.page_info:type_name -> bib.v1.PageInfo
-	35, // 33: bib.v1.services.ExecuteQueryRequest.ParametersEntry.value:type_name -> google.protobuf.Value
-	35, // 34: bib.v1.services.ExplainQueryRequest.ParametersEntry.value:type_name -> google.protobuf.Value
-	35, // 35: bib.v1.services.SaveQueryRequest.DefaultParametersEntry.value:type_name -> google.protobuf.Value
-	35, // 36: bib.v1.services.SavedQuery.DefaultParametersEntry.value:type_name -> google.protobuf.Value
-	0,  // 37: bib.v1.services.QueryService.Execute:input_type -> bib.v1.services.ExecuteQueryRequest
-	0,  // 38: bib.v1.services.QueryService.ExecuteStream:input_type -> bib.v1.services.ExecuteQueryRequest
-	4,  // 39: bib.v1.services.QueryService.ValidateQuery:input_type -> bib.v1.services.ValidateQueryRequest
-	8,  // 40: bib.v1.services.QueryService.ExplainQuery:input_type -> bib.v1.services.ExplainQueryRequest
-	12, // 41: bib.v1.services.QueryService.ListFunctions:input_type -> bib.v1.services.ListFunctionsRequest
-	15, // 42: bib.v1.services.QueryService.GetQueryHistory:input_type -> bib.v1.services.GetQueryHistoryRequest
-	18, // 43: bib.v1.services.QueryService.SaveQuery:input_type -> bib.v1.services.SaveQueryRequest
-	21, // 44: bib.v1.services.QueryService.ListSavedQueries:input_type -> bib.v1.services.ListSavedQueriesRequest
-	23, // 45: bib.v1.services.QueryService.DeleteSavedQuery:input_type -> bib.v1.services.DeleteSavedQueryRequest
-	1,  // 46: bib.v1.services.QueryService.Execute:output_type -> bib.v1.services.ExecuteQueryResponse
-	2,  // 47: bib.v1.services.QueryService.ExecuteStream:output_type -> bib.v1.services.QueryResult
-	5,  // 48: bib.v1.services.QueryService.ValidateQuery:output_type -> bib.v1.services.ValidateQueryResponse
-	9,  // 49: bib.v1.services.QueryService.ExplainQuery:output_type -> bib.v1.services.ExplainQueryResponse
-	13, // 50: bib.v1.services.QueryService.ListFunctions:output_type -> bib.v1.services.ListFunctionsResponse
-	16, // 51: bib.v1.services.QueryService.GetQueryHistory:output_type -> bib.v1.services.GetQueryHistoryResponse
-	19, // 52: bib.v1.services.QueryService.SaveQuery:output_type -> bib.v1.services.SaveQueryResponse
-	22, // 53: bib.v1.services.QueryService.ListSavedQueries:output_type -> bib.v1.services.ListSavedQueriesResponse
-	24, // 54: bib.v1.services.QueryService.DeleteSavedQuery:output_type -> bib.v1.services.DeleteSavedQueryResponse
-	46, // [46:55] is the sub-list for method output_type
-	37, // [37:46] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	32, // 27: bib.v1.services.SavedQuery.default_parameters:type_name -> bib.v1.services.SavedQuery.DefaultParametersEntry
+	39, // 28: bib.v1.services.SavedQuery.created_at:type_name -> google.protobuf.Timestamp
+	39, // 29: bib.v1.services.SavedQuery.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 30: bib.v1.services.SavedQuery.parameters:type_name -> bib.v1.services.ParameterInfo
+	33, // 31: bib.v1.services.RunSavedQueryRequest.parameters:type_name -> bib.v1.services.RunSavedQueryRequest.ParametersEntry
+	34, // 32: bib.v1.services.RunSavedQueryRequest.page:type_name -> bib.v1.PageRequest
+	35, // 33: bib.v1.services.RunSavedQueryRequest.timeout:type_name -> google.protobuf.Duration
+	20, // 34: bib.v1.services.RunSavedQueryResponse.query:type_name -> bib.v1.services.SavedQuery
+	1,  // 35: bib.v1.services.RunSavedQueryResponse.result:type_name -> bib.v1.services.ExecuteQueryResponse
+	34, // 36: bib.v1.services.ListSavedQueriesRequest.page:type_name -> bib.v1.PageRequest
+	20, // 37: bib.v1.services.ListSavedQueriesResponse.queries:type_name -> bib.v1.services.SavedQuery
+	36, // 38: bib.v1.services.ListSavedQueriesResponse.page_info:type_name -> bib.v1.PageInfo
+	38, // 39: bib.v1.services.ExecuteQueryRequest.ParametersEntry.value:type_name -> google.protobuf.Value
+	38, // 40: bib.v1.services.ExplainQueryRequest.ParametersEntry.value:type_name -> google.protobuf.Value
+	38, // 41: bib.v1.services.SaveQueryRequest.DefaultParametersEntry.value:type_name -> google.protobuf.Value
+	38, // 42: bib.v1.services.SavedQuery.DefaultParametersEntry.value:type_name -> google.protobuf.Value
+	38, // 43: bib.v1.services.RunSavedQueryRequest.ParametersEntry.value:type_name -> google.protobuf.Value
+	0,  // 44: bib.v1.services.QueryService.Execute:input_type -> bib.v1.services.ExecuteQueryRequest
+	0,  // 45: bib.v1.services.QueryService.ExecuteStream:input_type -> bib.v1.services.ExecuteQueryRequest
+	4,  // 46: bib.v1.services.QueryService.ValidateQuery:input_type -> bib.v1.services.ValidateQueryRequest
+	8,  // 47: bib.v1.services.QueryService.ExplainQuery:input_type -> bib.v1.services.ExplainQueryRequest
+	12, // 48: bib.v1.services.QueryService.ListFunctions:input_type -> bib.v1.services.ListFunctionsRequest
+	15, // 49: bib.v1.services.QueryService.GetQueryHistory:input_type -> bib.v1.services.GetQueryHistoryRequest
+	18, // 50: bib.v1.services.QueryService.SaveQuery:input_type -> bib.v1.services.SaveQueryRequest
+	21, // 51: bib.v1.services.QueryService.RunSavedQuery:input_type -> bib.v1.services.RunSavedQueryRequest
+	23, // 52: bib.v1.services.QueryService.ListSavedQueries:input_type -> bib.v1.services.ListSavedQueriesRequest
+	25, // 53: bib.v1.services.QueryService.DeleteSavedQuery:input_type -> bib.v1.services.DeleteSavedQueryRequest
+	1,  // 54: bib.v1.services.QueryService.Execute:output_type -> bib.v1.services.ExecuteQueryResponse
+	2,  // 55: bib.v1.services.QueryService.ExecuteStream:output_type -> bib.v1.services.QueryResult
+	5,  // 56: bib.v1.services.QueryService.ValidateQuery:output_type -> bib.v1.services.ValidateQueryResponse
+	9,  // 57: bib.v1.services.QueryService.ExplainQuery:output_type -> bib.v1.services.ExplainQueryResponse
+	13, // 58: bib.v1.services.QueryService.ListFunctions:output_type -> bib.v1.services.ListFunctionsResponse
+	16, // 59: bib.v1.services.QueryService.GetQueryHistory:output_type -> bib.v1.services.GetQueryHistoryResponse
+	19, // 60: bib.v1.services.QueryService.SaveQuery:output_type -> bib.v1.services.SaveQueryResponse
+	22, // 61: bib.v1.services.QueryService.RunSavedQuery:output_type -> bib.v1.services.RunSavedQueryResponse
+	24, // 62: bib.v1.services.QueryService.ListSavedQueries:output_type -> bib.v1.services.ListSavedQueriesResponse
+	26, // 63: bib.v1.services.QueryService.DeleteSavedQuery:output_type -> bib.v1.services.DeleteSavedQueryResponse
+	54, // [54:64] is the sub-list for method output_type
+	44, // [44:54] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_query_proto_init() }
@@ -2232,7 +2422,7 @@ func file_bib_v1_services_query_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_query_proto_rawDesc), len(file_bib_v1_services_query_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,7 @@ const (
 	QueryService_ListFunctions_FullMethodName    = "/bib.v1.services.QueryService/ListFunctions"
 	QueryService_GetQueryHistory_FullMethodName  = "/bib.v1.services.QueryService/GetQueryHistory"
 	QueryService_SaveQuery_FullMethodName        = "/bib.v1.services.QueryService/SaveQuery"
+	QueryService_RunSavedQuery_FullMethodName    = "/bib.v1.services.QueryService/RunSavedQuery"
 	QueryService_ListSavedQueries_FullMethodName = "/bib.v1.services.QueryService/ListSavedQueries"
 	QueryService_DeleteSavedQuery_FullMethodName = "/bib.v1.services.QueryService/DeleteSavedQuery"
 )
@@ -51,6 +52,8 @@ type QueryServiceClient interface {
 	GetQueryHistory(ctx context.Context, in *GetQueryHistoryRequest, opts ...grpc.CallOption) (*GetQueryHistoryResponse, error)
 	// SaveQuery saves a named query.
 	SaveQuery(ctx context.Context, in *SaveQueryRequest, opts ...grpc.CallOption) (*SaveQueryResponse, error)
+	// RunSavedQuery runs a saved query, filling in its parameters.
+	RunSavedQuery(ctx context.Context, in *RunSavedQueryRequest, opts ...grpc.CallOption) (*RunSavedQueryResponse, error)
 	// ListSavedQueries lists saved queries.
 	ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error)
 	// DeleteSavedQuery deletes a saved query.
@@ -144,6 +147,16 @@ func (c *queryServiceClient) SaveQuery(ctx context.Context, in *SaveQueryRequest
 	return out, nil
 }
 
+func (c *queryServiceClient) RunSavedQuery(ctx context.Context, in *RunSavedQueryRequest, opts ...grpc.CallOption) (*RunSavedQueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunSavedQueryResponse)
+	err := c.cc.Invoke(ctx, QueryService_RunSavedQuery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *queryServiceClient) ListSavedQueries(ctx context.Context, in *ListSavedQueriesRequest, opts ...grpc.CallOption) (*ListSavedQueriesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListSavedQueriesResponse)
@@ -185,6 +198,8 @@ type QueryServiceServer interface {
 	GetQueryHistory(context.Context, *GetQueryHistoryRequest) (*GetQueryHistoryResponse, error)
 	// SaveQuery saves a named query.
 	SaveQuery(context.Context, *SaveQueryRequest) (*SaveQueryResponse, error)
+	// RunSavedQuery runs a saved query, filling in its parameters.
+	RunSavedQuery(context.Context, *RunSavedQueryRequest) (*RunSavedQueryResponse, error)
 	// ListSavedQueries lists saved queries.
 	ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error)
 	// DeleteSavedQuery deletes a saved query.
@@ -219,6 +234,9 @@ func (UnimplementedQueryServiceServer) GetQueryHistory(context.Context, *GetQuer
 func (UnimplementedQueryServiceServer) SaveQuery(context.Context, *SaveQueryRequest) (*SaveQueryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SaveQuery not implemented")
 }
+func (UnimplementedQueryServiceServer) RunSavedQuery(context.Context, *RunSavedQueryRequest) (*RunSavedQueryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RunSavedQuery not implemented")
+}
 func (UnimplementedQueryServiceServer) ListSavedQueries(context.Context, *ListSavedQueriesRequest) (*ListSavedQueriesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListSavedQueries not implemented")
 }
@@ -364,6 +382,24 @@ func _QueryService_SaveQuery_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QueryService_RunSavedQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunSavedQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServiceServer).RunSavedQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QueryService_RunSavedQuery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServiceServer).RunSavedQuery(ctx, req.(*RunSavedQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QueryService_ListSavedQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSavedQueriesRequest)
 	if err := dec(in); err != nil {
@@ -431,6 +467,10 @@ var QueryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SaveQuery",
 			Handler:    _QueryService_SaveQuery_Handler,
 		},
+		{
+			MethodName: "RunSavedQuery",
+			Handler:    _QueryService_RunSavedQuery_Handler,
+		},
 		{
 			MethodName: "ListSavedQueries",
 			Handler:    _QueryService_ListSavedQueries_Handler,
@@ -53,7 +53,15 @@ type Topic struct {
 	// Tags for categorization.
 	Tags []string `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
 	// Additional metadata.
-	Metadata      map[string]string `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata map[string]string `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Whether access is limited by the topic's ACL.
+	Restricted bool `protobuf:"varint,15,opt,name=restricted,proto3" json:"restricted,omitempty"`
+	// JSON Schema that content of datasets in this topic must conform to,
+	// unless a dataset declares its own.
+	DataSchema *v1.DataSchema `protobuf:"bytes,16,opt,name=data_schema,json=dataSchema,proto3" json:"data_schema,omitempty"`
+	// Revision of the topic, incremented by every change. Pass it as
+	// expected_revision to reject changes made since it was read.
+	Revision      int64 `protobuf:"varint,17,opt,name=revision,proto3" json:"revision,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,6 +194,27 @@ func (x *Topic) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *Topic) GetRestricted() bool {
+	if x != nil {
+		return x.Restricted
+	}
+	return false
+}
+
+func (x *Topic) GetDataSchema() *v1.DataSchema {
+	if x != nil {
+		return x.DataSchema
+	}
+	return nil
+}
+
+func (x *Topic) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 // Subscription represents a topic subscription.
 type Subscription struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -318,7 +347,9 @@ type CreateTopicRequest struct {
 	// Tags.
 	Tags []string `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
 	// Additional metadata.
-	Metadata      map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional JSON Schema that dataset content must conform to.
+	DataSchema    *v1.DataSchema `protobuf:"bytes,7,opt,name=data_schema,json=dataSchema,proto3" json:"data_schema,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -395,6 +426,13 @@ func (x *CreateTopicRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *CreateTopicRequest) GetDataSchema() *v1.DataSchema {
+	if x != nil {
+		return x.DataSchema
+	}
+	return nil
+}
+
 // CreateTopicResponse contains the created topic.
 type CreateTopicResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -511,9 +549,11 @@ type GetTopicResponse struct {
 	// Whether we're subscribed to this topic.
 	Subscribed bool `protobuf:"varint,2,opt,name=subscribed,proto3" json:"subscribed,omitempty"`
 	// Subscription info (if subscribed).
-	Subscription  *Subscription `protobuf:"bytes,3,opt,name=subscription,proto3" json:"subscription,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Subscription *Subscription `protobuf:"bytes,3,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	// Caller's membership state: "none", "invited", "pending" or "member".
+	MembershipState string `protobuf:"bytes,4,opt,name=membership_state,json=membershipState,proto3" json:"membership_state,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetTopicResponse) Reset() {
@@ -567,6 +607,13 @@ func (x *GetTopicResponse) GetSubscription() *Subscription {
 	return nil
 }
 
+func (x *GetTopicResponse) GetMembershipState() string {
+	if x != nil {
+		return x.MembershipState
+	}
+	return ""
+}
+
 // ListTopicsRequest lists topics.
 type ListTopicsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -741,8 +788,15 @@ type UpdateTopicRequest struct {
 	Metadata map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Whether to update metadata.
 	UpdateMetadata bool `protobuf:"varint,9,opt,name=update_metadata,json=updateMetadata,proto3" json:"update_metadata,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Replace the data schema; an unset data_schema removes it.
+	DataSchema *v1.DataSchema `protobuf:"bytes,10,opt,name=data_schema,json=dataSchema,proto3" json:"data_schema,omitempty"`
+	// Whether to update the data schema.
+	UpdateDataSchema bool `protobuf:"varint,11,opt,name=update_data_schema,json=updateDataSchema,proto3" json:"update_data_schema,omitempty"`
+	// Revision the topic must still have; 0 skips the check. Fails with
+	// FailedPrecondition (subreason REVISION_CONFLICT) otherwise.
+	ExpectedRevision int64 `protobuf:"varint,12,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UpdateTopicRequest) Reset() {
@@ -838,6 +892,27 @@ func (x *UpdateTopicRequest) GetUpdateMetadata() bool {
 	return false
 }
 
+func (x *UpdateTopicRequest) GetDataSchema() *v1.DataSchema {
+	if x != nil {
+		return x.DataSchema
+	}
+	return nil
+}
+
+func (x *UpdateTopicRequest) GetUpdateDataSchema() bool {
+	if x != nil {
+		return x.UpdateDataSchema
+	}
+	return false
+}
+
+func (x *UpdateTopicRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
 // UpdateTopicResponse contains the updated topic.
 type UpdateTopicResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -889,9 +964,11 @@ type DeleteTopicRequest struct {
 	// Topic ID.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	// Force delete even if topic has datasets.
-	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// Revision the topic must still have; 0 skips the check.
+	ExpectedRevision int64 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *DeleteTopicRequest) Reset() {
@@ -938,6 +1015,13 @@ func (x *DeleteTopicRequest) GetForce() bool {
 	return false
 }
 
+func (x *DeleteTopicRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
 // DeleteTopicResponse confirms deletion.
 type DeleteTopicResponse struct {
 	state   protoimpl.MessageState `protogen:"open.v1"`
@@ -1424,8 +1508,10 @@ type StreamTopicUpdatesRequest struct {
 	TopicIds []string `protobuf:"bytes,1,rep,name=topic_ids,json=topicIds,proto3" json:"topic_ids,omitempty"`
 	// Include dataset events.
 	IncludeDatasets bool `protobuf:"varint,2,opt,name=include_datasets,json=includeDatasets,proto3" json:"include_datasets,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Resume after the update carrying this token (from TopicUpdate.resume_token).
+	ResumeToken   string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StreamTopicUpdatesRequest) Reset() {
@@ -1472,6 +1558,13 @@ func (x *StreamTopicUpdatesRequest) GetIncludeDatasets() bool {
 	return false
 }
 
+func (x *StreamTopicUpdatesRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // TopicUpdate represents a topic update event.
 type TopicUpdate struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1484,7 +1577,9 @@ type TopicUpdate struct {
 	// Event timestamp.
 	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	// Source node.
-	SourceNodeId  string `protobuf:"bytes,5,opt,name=source_node_id,json=sourceNodeId,proto3" json:"source_node_id,omitempty"`
+	SourceNodeId string `protobuf:"bytes,5,opt,name=source_node_id,json=sourceNodeId,proto3" json:"source_node_id,omitempty"`
+	// Opaque stream position; pass back as resume_token to continue after this update.
+	ResumeToken   string `protobuf:"bytes,6,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1554,6 +1649,13 @@ func (x *TopicUpdate) GetSourceNodeId() string {
 	return ""
 }
 
+func (x *TopicUpdate) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
 // GetTopicStatsRequest requests topic statistics.
 type GetTopicStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1825,168 +1927,1306 @@ func (x *SearchTopicsResponse) GetPageInfo() *v1.PageInfo {
 	return nil
 }
 
-var File_bib_v1_services_topic_proto protoreflect.FileDescriptor
+// SetTopicACLRequest changes the grants on a topic. Revocations are applied
+// before grants; a grant replaces any earlier grant to the same principal.
+type SetTopicACLRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TopicId string                 `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	// Grants to add or replace.
+	Grant []*v1.TopicACLEntry `protobuf:"bytes,2,rep,name=grant,proto3" json:"grant,omitempty"`
+	// Principals whose grants are removed (only principal_type and principal
+	// are used).
+	Revoke []*v1.TopicACLEntry `protobuf:"bytes,3,rep,name=revoke,proto3" json:"revoke,omitempty"`
+	// Revision the topic must still have; 0 skips the check.
+	ExpectedRevision int64 `protobuf:"varint,4,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
 
-const file_bib_v1_services_topic_proto_rawDesc = "" +
-	"\n" +
-	"\x1bbib/v1/services/topic.proto\x12\x0fbib.v1.services\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13bib/v1/common.proto\"\xc0\x04\n" +
-	"\x05Topic\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06schema\x18\x04 \x01(\tR\x06schema\x12#\n" +
-	"\rdataset_count\x18\x05 \x01(\x03R\fdatasetCount\x12\x1d\n" +
-	"\n" +
-	"total_size\x18\x06 \x01(\x03R\ttotalSize\x12\x19\n" +
-	"\bowner_id\x18\a \x01(\tR\aownerId\x12\x1b\n" +
-	"\tis_public\x18\b \x01(\bR\bisPublic\x12\x16\n" +
-	"\x06status\x18\t \x01(\tR\x06status\x129\n" +
-	"\n" +
-	"created_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12<\n" +
-	"\flast_sync_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"lastSyncAt\x12\x12\n" +
-	"\x04tags\x18\r \x03(\tR\x04tags\x12@\n" +
-	"\bmetadata\x18\x0e \x03(\v2$.bib.v1.services.Topic.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf4\x02\n" +
-	"\fSubscription\x12\x19\n" +
-	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x1d\n" +
-	"\n" +
-	"topic_name\x18\x02 \x01(\tR\ttopicName\x12?\n" +
-	"\rsubscribed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fsubscribedAt\x12\x1f\n" +
-	"\vsync_status\x18\x04 \x01(\tR\n" +
-	"syncStatus\x12<\n" +
-	"\flast_sync_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"lastSyncAt\x12'\n" +
-	"\x0fsynced_datasets\x18\x06 \x01(\x03R\x0esyncedDatasets\x12%\n" +
-	"\x0etotal_datasets\x18\a \x01(\x03R\rtotalDatasets\x12\x1d\n" +
-	"\n" +
-	"sync_error\x18\b \x01(\tR\tsyncError\x12\x1b\n" +
-	"\tauto_sync\x18\t \x01(\bR\bautoSync\"\x9f\x02\n" +
-	"\x12CreateTopicRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06schema\x18\x03 \x01(\tR\x06schema\x12\x1b\n" +
-	"\tis_public\x18\x04 \x01(\bR\bisPublic\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\x12M\n" +
-	"\bmetadata\x18\x06 \x03(\v21.bib.v1.services.CreateTopicRequest.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"C\n" +
-	"\x13CreateTopicResponse\x12,\n" +
-	"\x05topic\x18\x01 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\"Z\n" +
-	"\x0fGetTopicRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
-	"\rinclude_stats\x18\x03 \x01(\bR\fincludeStats\"\xa3\x01\n" +
-	"\x10GetTopicResponse\x12,\n" +
-	"\x05topic\x18\x01 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\x12\x1e\n" +
-	"\n" +
-	"subscribed\x18\x02 \x01(\bR\n" +
-	"subscribed\x12A\n" +
-	"\fsubscription\x18\x03 \x01(\v2\x1d.bib.v1.services.SubscriptionR\fsubscription\"\xf4\x01\n" +
-	"\x11ListTopicsRequest\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
-	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x1f\n" +
-	"\vpublic_only\x18\x03 \x01(\bR\n" +
-	"publicOnly\x12'\n" +
-	"\x0fsubscribed_only\x18\x04 \x01(\bR\x0esubscribedOnly\x12\x12\n" +
-	"\x04tags\x18\x05 \x03(\tR\x04tags\x12'\n" +
-	"\x04page\x18\x06 \x01(\v2\x13.bib.v1.PageRequestR\x04page\x12%\n" +
-	"\x04sort\x18\a \x01(\v2\x11.bib.v1.SortOrderR\x04sort\"s\n" +
-	"\x12ListTopicsResponse\x12.\n" +
-	"\x06topics\x18\x01 \x03(\v2\x16.bib.v1.services.TopicR\x06topics\x12-\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\xbf\x03\n" +
-	"\x12UpdateTopicRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1b\n" +
-	"\x06schema\x18\x04 \x01(\tH\x02R\x06schema\x88\x01\x01\x12 \n" +
-	"\tis_public\x18\x05 \x01(\bH\x03R\bisPublic\x88\x01\x01\x12\x12\n" +
-	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1f\n" +
-	"\vupdate_tags\x18\a \x01(\bR\n" +
-	"updateTags\x12M\n" +
-	"\bmetadata\x18\b \x03(\v21.bib.v1.services.UpdateTopicRequest.MetadataEntryR\bmetadata\x12'\n" +
-	"\x0fupdate_metadata\x18\t \x01(\bR\x0eupdateMetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
-	"\x05_nameB\x0e\n" +
-	"\f_descriptionB\t\n" +
-	"\a_schemaB\f\n" +
-	"\n" +
-	"_is_public\"C\n" +
-	"\x13UpdateTopicResponse\x12,\n" +
-	"\x05topic\x18\x01 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\":\n" +
-	"\x12DeleteTopicRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05force\x18\x02 \x01(\bR\x05force\"\\\n" +
-	"\x13DeleteTopicResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12+\n" +
-	"\x11datasets_affected\x18\x02 \x01(\x03R\x10datasetsAffected\"\x84\x01\n" +
-	"\x10SubscribeRequest\x12\x19\n" +
-	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x1d\n" +
-	"\n" +
-	"topic_name\x18\x02 \x01(\tR\ttopicName\x12\x1b\n" +
-	"\tauto_sync\x18\x03 \x01(\bR\bautoSync\x12\x19\n" +
-	"\bsync_now\x18\x04 \x01(\bR\asyncNow\"V\n" +
-	"\x11SubscribeResponse\x12A\n" +
-	"\fsubscription\x18\x01 \x01(\v2\x1d.bib.v1.services.SubscriptionR\fsubscription\"[\n" +
-	"\x12UnsubscribeRequest\x12\x19\n" +
-	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12*\n" +
-	"\x11delete_local_data\x18\x02 \x01(\bR\x0fdeleteLocalData\"P\n" +
-	"\x13UnsubscribeResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
-	"\vbytes_freed\x18\x02 \x01(\x03R\n" +
-	"bytesFreed\"d\n" +
-	"\x18ListSubscriptionsRequest\x12\x1f\n" +
-	"\vsync_status\x18\x01 \x01(\tR\n" +
-	"syncStatus\x12'\n" +
-	"\x04page\x18\x02 \x01(\v2\x13.bib.v1.PageRequestR\x04page\"\x8f\x01\n" +
-	"\x19ListSubscriptionsResponse\x12C\n" +
-	"\rsubscriptions\x18\x01 \x03(\v2\x1d.bib.v1.services.SubscriptionR\rsubscriptions\x12-\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"3\n" +
-	"\x16GetSubscriptionRequest\x12\x19\n" +
-	"\btopic_id\x18\x01 \x01(\tR\atopicId\"\\\n" +
-	"\x17GetSubscriptionResponse\x12A\n" +
-	"\fsubscription\x18\x01 \x01(\v2\x1d.bib.v1.services.SubscriptionR\fsubscription\"c\n" +
-	"\x19StreamTopicUpdatesRequest\x12\x1b\n" +
-	"\ttopic_ids\x18\x01 \x03(\tR\btopicIds\x12)\n" +
-	"\x10include_datasets\x18\x02 \x01(\bR\x0fincludeDatasets\"\xe9\x01\n" +
-	"\vTopicUpdate\x12\x1d\n" +
-	"\n" +
-	"event_type\x18\x01 \x01(\tR\teventType\x12,\n" +
-	"\x05topic\x18\x02 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\x12-\n" +
-	"\adataset\x18\x03 \x01(\v2\x13.bib.v1.DatasetInfoR\adataset\x128\n" +
-	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12$\n" +
-	"\x0esource_node_id\x18\x05 \x01(\tR\fsourceNodeId\"1\n" +
-	"\x14GetTopicStatsRequest\x12\x19\n" +
-	"\btopic_id\x18\x01 \x01(\tR\atopicId\"\xb0\x03\n" +
-	"\x15GetTopicStatsResponse\x12\x19\n" +
-	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12#\n" +
-	"\rdataset_count\x18\x02 \x01(\x03R\fdatasetCount\x12\x1d\n" +
-	"\n" +
-	"total_size\x18\x03 \x01(\x03R\ttotalSize\x12)\n" +
-	"\x10subscriber_count\x18\x04 \x01(\x03R\x0fsubscriberCount\x12#\n" +
-	"\rreplica_count\x18\x05 \x01(\x03R\freplicaCount\x12d\n" +
-	"\x10datasets_by_type\x18\x06 \x03(\v2:.bib.v1.services.GetTopicStatsResponse.DatasetsByTypeEntryR\x0edatasetsByType\x12?\n" +
-	"\rlast_activity\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x1aA\n" +
-	"\x13DatasetsByTypeEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x89\x01\n" +
-	"\x13SearchTopicsRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1f\n" +
-	"\vpublic_only\x18\x02 \x01(\bR\n" +
-	"publicOnly\x12\x12\n" +
-	"\x04tags\x18\x03 \x03(\tR\x04tags\x12'\n" +
-	"\x04page\x18\x04 \x01(\v2\x13.bib.v1.PageRequestR\x04page\"u\n" +
-	"\x14SearchTopicsResponse\x12.\n" +
-	"\x06topics\x18\x01 \x03(\v2\x16.bib.v1.services.TopicR\x06topics\x12-\n" +
-	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo2\xe3\b\n" +
+func (x *SetTopicACLRequest) Reset() {
+	*x = SetTopicACLRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTopicACLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTopicACLRequest) ProtoMessage() {}
+
+func (x *SetTopicACLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTopicACLRequest.ProtoReflect.Descriptor instead.
+func (*SetTopicACLRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetTopicACLRequest) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+func (x *SetTopicACLRequest) GetGrant() []*v1.TopicACLEntry {
+	if x != nil {
+		return x.Grant
+	}
+	return nil
+}
+
+func (x *SetTopicACLRequest) GetRevoke() []*v1.TopicACLEntry {
+	if x != nil {
+		return x.Revoke
+	}
+	return nil
+}
+
+func (x *SetTopicACLRequest) GetExpectedRevision() int64 {
+	if x != nil {
+		return x.ExpectedRevision
+	}
+	return 0
+}
+
+// SetTopicACLResponse contains the resulting ACL.
+type SetTopicACLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*v1.TopicACLEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTopicACLResponse) Reset() {
+	*x = SetTopicACLResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTopicACLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTopicACLResponse) ProtoMessage() {}
+
+func (x *SetTopicACLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTopicACLResponse.ProtoReflect.Descriptor instead.
+func (*SetTopicACLResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetTopicACLResponse) GetEntries() []*v1.TopicACLEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// GetTopicACLRequest requests the ACL of a topic.
+type GetTopicACLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TopicId       string                 `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopicACLRequest) Reset() {
+	*x = GetTopicACLRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopicACLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopicACLRequest) ProtoMessage() {}
+
+func (x *GetTopicACLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopicACLRequest.ProtoReflect.Descriptor instead.
+func (*GetTopicACLRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetTopicACLRequest) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+// GetTopicACLResponse contains the ACL of a topic. An empty ACL leaves the
+// topic open to all users.
+type GetTopicACLResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*v1.TopicACLEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Topic owners, who always have admin.
+	Owners        []string `protobuf:"bytes,2,rep,name=owners,proto3" json:"owners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopicACLResponse) Reset() {
+	*x = GetTopicACLResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopicACLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopicACLResponse) ProtoMessage() {}
+
+func (x *GetTopicACLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopicACLResponse.ProtoReflect.Descriptor instead.
+func (*GetTopicACLResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetTopicACLResponse) GetEntries() []*v1.TopicACLEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetTopicACLResponse) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+// TopicInvitation is an invitation to join a topic, or a request to join
+// one.
+type TopicInvitation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TopicId   string                 `protobuf:"bytes,2,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	TopicName string                 `protobuf:"bytes,3,opt,name=topic_name,json=topicName,proto3" json:"topic_name,omitempty"`
+	// Kind: "invite" (an owner invited user_id or email) or "request"
+	// (user_id asked to join).
+	Kind string `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Invited or requesting user.
+	UserId string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Invited email address, for users without an account yet.
+	Email string `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	// User who sent the invitation; the requester for requests.
+	InviterId string `protobuf:"bytes,7,opt,name=inviter_id,json=inviterId,proto3" json:"inviter_id,omitempty"`
+	// Membership role: "owner", "editor" or "viewer".
+	Role    string `protobuf:"bytes,8,opt,name=role,proto3" json:"role,omitempty"`
+	Message string `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	// Status: "pending", "accepted", "declined", "expired" or "cancelled".
+	Status      string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RespondedAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=responded_at,json=respondedAt,proto3" json:"responded_at,omitempty"`
+	// User who accepted, declined, approved, denied or cancelled.
+	RespondedBy   string `protobuf:"bytes,14,opt,name=responded_by,json=respondedBy,proto3" json:"responded_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicInvitation) Reset() {
+	*x = TopicInvitation{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicInvitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicInvitation) ProtoMessage() {}
+
+func (x *TopicInvitation) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicInvitation.ProtoReflect.Descriptor instead.
+func (*TopicInvitation) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TopicInvitation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetTopicName() string {
+	if x != nil {
+		return x.TopicName
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetInviterId() string {
+	if x != nil {
+		return x.InviterId
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TopicInvitation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TopicInvitation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *TopicInvitation) GetRespondedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RespondedAt
+	}
+	return nil
+}
+
+func (x *TopicInvitation) GetRespondedBy() string {
+	if x != nil {
+		return x.RespondedBy
+	}
+	return ""
+}
+
+// InviteToTopicRequest invites a user by ID or email address.
+type InviteToTopicRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TopicId string                 `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	// User to invite.
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Or email address to invite (if user_id is empty).
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// Role granted on acceptance (default: "viewer").
+	Role string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	// Optional message to the invitee.
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToTopicRequest) Reset() {
+	*x = InviteToTopicRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToTopicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToTopicRequest) ProtoMessage() {}
+
+func (x *InviteToTopicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToTopicRequest.ProtoReflect.Descriptor instead.
+func (*InviteToTopicRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *InviteToTopicRequest) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+func (x *InviteToTopicRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *InviteToTopicRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InviteToTopicRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *InviteToTopicRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// InviteToTopicResponse contains the invitation.
+type InviteToTopicResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Invitation *TopicInvitation       `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	// Token the invitee accepts with; also delivered in the invitation email.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToTopicResponse) Reset() {
+	*x = InviteToTopicResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToTopicResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToTopicResponse) ProtoMessage() {}
+
+func (x *InviteToTopicResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToTopicResponse.ProtoReflect.Descriptor instead.
+func (*InviteToTopicResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *InviteToTopicResponse) GetInvitation() *TopicInvitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+func (x *InviteToTopicResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// RequestTopicAccessRequest asks to join a topic.
+type RequestTopicAccessRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TopicId string                 `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	// Requested role: "editor" or "viewer" (default).
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// Optional message to the owners.
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestTopicAccessRequest) Reset() {
+	*x = RequestTopicAccessRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTopicAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTopicAccessRequest) ProtoMessage() {}
+
+func (x *RequestTopicAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTopicAccessRequest.ProtoReflect.Descriptor instead.
+func (*RequestTopicAccessRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RequestTopicAccessRequest) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+func (x *RequestTopicAccessRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RequestTopicAccessRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RequestTopicAccessResponse contains the pending request.
+type RequestTopicAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *TopicInvitation       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestTopicAccessResponse) Reset() {
+	*x = RequestTopicAccessResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTopicAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTopicAccessResponse) ProtoMessage() {}
+
+func (x *RequestTopicAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTopicAccessResponse.ProtoReflect.Descriptor instead.
+func (*RequestTopicAccessResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RequestTopicAccessResponse) GetRequest() *TopicInvitation {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// RespondToTopicInvitationRequest accepts or declines an invitation,
+// identified by ID or by token.
+type RespondToTopicInvitationRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId string                 `protobuf:"bytes,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	// Or the invitation token (if invitation_id is empty).
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Accept        bool   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondToTopicInvitationRequest) Reset() {
+	*x = RespondToTopicInvitationRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToTopicInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToTopicInvitationRequest) ProtoMessage() {}
+
+func (x *RespondToTopicInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToTopicInvitationRequest.ProtoReflect.Descriptor instead.
+func (*RespondToTopicInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RespondToTopicInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+func (x *RespondToTopicInvitationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RespondToTopicInvitationRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+// RespondToTopicInvitationResponse contains the answered invitation.
+type RespondToTopicInvitationResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Invitation *TopicInvitation       `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	// The new subscription, when accepted.
+	Subscription  *Subscription `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondToTopicInvitationResponse) Reset() {
+	*x = RespondToTopicInvitationResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToTopicInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToTopicInvitationResponse) ProtoMessage() {}
+
+func (x *RespondToTopicInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToTopicInvitationResponse.ProtoReflect.Descriptor instead.
+func (*RespondToTopicInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RespondToTopicInvitationResponse) GetInvitation() *TopicInvitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+func (x *RespondToTopicInvitationResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+// ReviewTopicAccessRequestRequest approves or denies an access request.
+type ReviewTopicAccessRequestRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Approve   bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	// Role to grant instead of the requested one (optional).
+	Role          string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTopicAccessRequestRequest) Reset() {
+	*x = ReviewTopicAccessRequestRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTopicAccessRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTopicAccessRequestRequest) ProtoMessage() {}
+
+func (x *ReviewTopicAccessRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTopicAccessRequestRequest.ProtoReflect.Descriptor instead.
+func (*ReviewTopicAccessRequestRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReviewTopicAccessRequestRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ReviewTopicAccessRequestRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *ReviewTopicAccessRequestRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// ReviewTopicAccessRequestResponse contains the reviewed request.
+type ReviewTopicAccessRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       *TopicInvitation       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTopicAccessRequestResponse) Reset() {
+	*x = ReviewTopicAccessRequestResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTopicAccessRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTopicAccessRequestResponse) ProtoMessage() {}
+
+func (x *ReviewTopicAccessRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTopicAccessRequestResponse.ProtoReflect.Descriptor instead.
+func (*ReviewTopicAccessRequestResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReviewTopicAccessRequestResponse) GetRequest() *TopicInvitation {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+// CancelTopicInvitationRequest cancels a pending invitation or request.
+type CancelTopicInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId  string                 `protobuf:"bytes,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTopicInvitationRequest) Reset() {
+	*x = CancelTopicInvitationRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTopicInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTopicInvitationRequest) ProtoMessage() {}
+
+func (x *CancelTopicInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTopicInvitationRequest.ProtoReflect.Descriptor instead.
+func (*CancelTopicInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *CancelTopicInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+// CancelTopicInvitationResponse contains the cancelled invitation.
+type CancelTopicInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitation    *TopicInvitation       `protobuf:"bytes,1,opt,name=invitation,proto3" json:"invitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTopicInvitationResponse) Reset() {
+	*x = CancelTopicInvitationResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTopicInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTopicInvitationResponse) ProtoMessage() {}
+
+func (x *CancelTopicInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTopicInvitationResponse.ProtoReflect.Descriptor instead.
+func (*CancelTopicInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CancelTopicInvitationResponse) GetInvitation() *TopicInvitation {
+	if x != nil {
+		return x.Invitation
+	}
+	return nil
+}
+
+// ListTopicInvitationsRequest lists invitations and access requests.
+type ListTopicInvitationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Topic to list (owners). Empty lists the caller's own invitations and
+	// requests.
+	TopicId string `protobuf:"bytes,1,opt,name=topic_id,json=topicId,proto3" json:"topic_id,omitempty"`
+	// Filter by kind: "invite" or "request".
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Filter by status (default: all).
+	Status        string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicInvitationsRequest) Reset() {
+	*x = ListTopicInvitationsRequest{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicInvitationsRequest) ProtoMessage() {}
+
+func (x *ListTopicInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListTopicInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListTopicInvitationsRequest) GetTopicId() string {
+	if x != nil {
+		return x.TopicId
+	}
+	return ""
+}
+
+func (x *ListTopicInvitationsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListTopicInvitationsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ListTopicInvitationsResponse contains the invitations.
+type ListTopicInvitationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitations   []*TopicInvitation     `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopicInvitationsResponse) Reset() {
+	*x = ListTopicInvitationsResponse{}
+	mi := &file_bib_v1_services_topic_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopicInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopicInvitationsResponse) ProtoMessage() {}
+
+func (x *ListTopicInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_topic_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopicInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopicInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_topic_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListTopicInvitationsResponse) GetInvitations() []*TopicInvitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+var File_bib_v1_services_topic_proto protoreflect.FileDescriptor
+
+const file_bib_v1_services_topic_proto_rawDesc = "" +
+	"\n" +
+	"\x1bbib/v1/services/topic.proto\x12\x0fbib.v1.services\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13bib/v1/common.proto\"\xb1\x05\n" +
+	"\x05Topic\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06schema\x18\x04 \x01(\tR\x06schema\x12#\n" +
+	"\rdataset_count\x18\x05 \x01(\x03R\fdatasetCount\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x06 \x01(\x03R\ttotalSize\x12\x19\n" +
+	"\bowner_id\x18\a \x01(\tR\aownerId\x12\x1b\n" +
+	"\tis_public\x18\b \x01(\bR\bisPublic\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12<\n" +
+	"\flast_sync_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSyncAt\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12@\n" +
+	"\bmetadata\x18\x0e \x03(\v2$.bib.v1.services.Topic.MetadataEntryR\bmetadata\x12\x1e\n" +
+	"\n" +
+	"restricted\x18\x0f \x01(\bR\n" +
+	"restricted\x123\n" +
+	"\vdata_schema\x18\x10 \x01(\v2\x12.bib.v1.DataSchemaR\n" +
+	"dataSchema\x12\x1a\n" +
+	"\brevision\x18\x11 \x01(\x03R\brevision\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf4\x02\n" +
+	"\fSubscription\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\x02 \x01(\tR\ttopicName\x12?\n" +
+	"\rsubscribed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fsubscribedAt\x12\x1f\n" +
+	"\vsync_status\x18\x04 \x01(\tR\n" +
+	"syncStatus\x12<\n" +
+	"\flast_sync_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSyncAt\x12'\n" +
+	"\x0fsynced_datasets\x18\x06 \x01(\x03R\x0esyncedDatasets\x12%\n" +
+	"\x0etotal_datasets\x18\a \x01(\x03R\rtotalDatasets\x12\x1d\n" +
+	"\n" +
+	"sync_error\x18\b \x01(\tR\tsyncError\x12\x1b\n" +
+	"\tauto_sync\x18\t \x01(\bR\bautoSync\"\xd4\x02\n" +
+	"\x12CreateTopicRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06schema\x18\x03 \x01(\tR\x06schema\x12\x1b\n" +
+	"\tis_public\x18\x04 \x01(\bR\bisPublic\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12M\n" +
+	"\bmetadata\x18\x06 \x03(\v21.bib.v1.services.CreateTopicRequest.MetadataEntryR\bmetadata\x123\n" +
+	"\vdata_schema\x18\a \x01(\v2\x12.bib.v1.DataSchemaR\n" +
+	"dataSchema\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"C\n" +
+	"\x13CreateTopicResponse\x12,\n" +
+	"\x05topic\x18\x01 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\"Z\n" +
+	"\x0fGetTopicRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rinclude_stats\x18\x03 \x01(\bR\fincludeStats\"\xce\x01\n" +
+	"\x10GetTopicResponse\x12,\n" +
+	"\x05topic\x18\x01 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\x12\x1e\n" +
+	"\n" +
+	"subscribed\x18\x02 \x01(\bR\n" +
+	"subscribed\x12A\n" +
+	"\fsubscription\x18\x03 \x01(\v2\x1d.bib.v1.services.SubscriptionR\fsubscription\x12)\n" +
+	"\x10membership_state\x18\x04 \x01(\tR\x0fmembershipState\"\xf4\x01\n" +
+	"\x11ListTopicsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12\x1f\n" +
+	"\vpublic_only\x18\x03 \x01(\bR\n" +
+	"publicOnly\x12'\n" +
+	"\x0fsubscribed_only\x18\x04 \x01(\bR\x0esubscribedOnly\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12'\n" +
+	"\x04page\x18\x06 \x01(\v2\x13.bib.v1.PageRequestR\x04page\x12%\n" +
+	"\x04sort\x18\a \x01(\v2\x11.bib.v1.SortOrderR\x04sort\"s\n" +
+	"\x12ListTopicsResponse\x12.\n" +
+	"\x06topics\x18\x01 \x03(\v2\x16.bib.v1.services.TopicR\x06topics\x12-\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\xcf\x04\n" +
+	"\x12UpdateTopicRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1b\n" +
+	"\x06schema\x18\x04 \x01(\tH\x02R\x06schema\x88\x01\x01\x12 \n" +
+	"\tis_public\x18\x05 \x01(\bH\x03R\bisPublic\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1f\n" +
+	"\vupdate_tags\x18\a \x01(\bR\n" +
+	"updateTags\x12M\n" +
+	"\bmetadata\x18\b \x03(\v21.bib.v1.services.UpdateTopicRequest.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0fupdate_metadata\x18\t \x01(\bR\x0eupdateMetadata\x123\n" +
+	"\vdata_schema\x18\n" +
+	" \x01(\v2\x12.bib.v1.DataSchemaR\n" +
+	"dataSchema\x12,\n" +
+	"\x12update_data_schema\x18\v \x01(\bR\x10updateDataSchema\x12+\n" +
+	"\x11expected_revision\x18\f \x01(\x03R\x10expectedRevision\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\t\n" +
+	"\a_schemaB\f\n" +
+	"\n" +
+	"_is_public\"C\n" +
+	"\x13UpdateTopicResponse\x12,\n" +
+	"\x05topic\x18\x01 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\"g\n" +
+	"\x12DeleteTopicRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12+\n" +
+	"\x11expected_revision\x18\x03 \x01(\x03R\x10expectedRevision\"\\\n" +
+	"\x13DeleteTopicResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12+\n" +
+	"\x11datasets_affected\x18\x02 \x01(\x03R\x10datasetsAffected\"\x84\x01\n" +
+	"\x10SubscribeRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\x02 \x01(\tR\ttopicName\x12\x1b\n" +
+	"\tauto_sync\x18\x03 \x01(\bR\bautoSync\x12\x19\n" +
+	"\bsync_now\x18\x04 \x01(\bR\asyncNow\"V\n" +
+	"\x11SubscribeResponse\x12A\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1d.bib.v1.services.SubscriptionR\fsubscription\"[\n" +
+	"\x12UnsubscribeRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12*\n" +
+	"\x11delete_local_data\x18\x02 \x01(\bR\x0fdeleteLocalData\"P\n" +
+	"\x13UnsubscribeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1f\n" +
+	"\vbytes_freed\x18\x02 \x01(\x03R\n" +
+	"bytesFreed\"d\n" +
+	"\x18ListSubscriptionsRequest\x12\x1f\n" +
+	"\vsync_status\x18\x01 \x01(\tR\n" +
+	"syncStatus\x12'\n" +
+	"\x04page\x18\x02 \x01(\v2\x13.bib.v1.PageRequestR\x04page\"\x8f\x01\n" +
+	"\x19ListSubscriptionsResponse\x12C\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\x1d.bib.v1.services.SubscriptionR\rsubscriptions\x12-\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"3\n" +
+	"\x16GetSubscriptionRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\"\\\n" +
+	"\x17GetSubscriptionResponse\x12A\n" +
+	"\fsubscription\x18\x01 \x01(\v2\x1d.bib.v1.services.SubscriptionR\fsubscription\"\x86\x01\n" +
+	"\x19StreamTopicUpdatesRequest\x12\x1b\n" +
+	"\ttopic_ids\x18\x01 \x03(\tR\btopicIds\x12)\n" +
+	"\x10include_datasets\x18\x02 \x01(\bR\x0fincludeDatasets\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\"\x8c\x02\n" +
+	"\vTopicUpdate\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12,\n" +
+	"\x05topic\x18\x02 \x01(\v2\x16.bib.v1.services.TopicR\x05topic\x12-\n" +
+	"\adataset\x18\x03 \x01(\v2\x13.bib.v1.DatasetInfoR\adataset\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12$\n" +
+	"\x0esource_node_id\x18\x05 \x01(\tR\fsourceNodeId\x12!\n" +
+	"\fresume_token\x18\x06 \x01(\tR\vresumeToken\"1\n" +
+	"\x14GetTopicStatsRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\"\xb0\x03\n" +
+	"\x15GetTopicStatsResponse\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12#\n" +
+	"\rdataset_count\x18\x02 \x01(\x03R\fdatasetCount\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x03R\ttotalSize\x12)\n" +
+	"\x10subscriber_count\x18\x04 \x01(\x03R\x0fsubscriberCount\x12#\n" +
+	"\rreplica_count\x18\x05 \x01(\x03R\freplicaCount\x12d\n" +
+	"\x10datasets_by_type\x18\x06 \x03(\v2:.bib.v1.services.GetTopicStatsResponse.DatasetsByTypeEntryR\x0edatasetsByType\x12?\n" +
+	"\rlast_activity\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x1aA\n" +
+	"\x13DatasetsByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x89\x01\n" +
+	"\x13SearchTopicsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1f\n" +
+	"\vpublic_only\x18\x02 \x01(\bR\n" +
+	"publicOnly\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12'\n" +
+	"\x04page\x18\x04 \x01(\v2\x13.bib.v1.PageRequestR\x04page\"u\n" +
+	"\x14SearchTopicsResponse\x12.\n" +
+	"\x06topics\x18\x01 \x03(\v2\x16.bib.v1.services.TopicR\x06topics\x12-\n" +
+	"\tpage_info\x18\x02 \x01(\v2\x10.bib.v1.PageInfoR\bpageInfo\"\xb8\x01\n" +
+	"\x12SetTopicACLRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12+\n" +
+	"\x05grant\x18\x02 \x03(\v2\x15.bib.v1.TopicACLEntryR\x05grant\x12-\n" +
+	"\x06revoke\x18\x03 \x03(\v2\x15.bib.v1.TopicACLEntryR\x06revoke\x12+\n" +
+	"\x11expected_revision\x18\x04 \x01(\x03R\x10expectedRevision\"F\n" +
+	"\x13SetTopicACLResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.bib.v1.TopicACLEntryR\aentries\"/\n" +
+	"\x12GetTopicACLRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\"^\n" +
+	"\x13GetTopicACLResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.bib.v1.TopicACLEntryR\aentries\x12\x16\n" +
+	"\x06owners\x18\x02 \x03(\tR\x06owners\"\xdb\x03\n" +
+	"\x0fTopicInvitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\btopic_id\x18\x02 \x01(\tR\atopicId\x12\x1d\n" +
+	"\n" +
+	"topic_name\x18\x03 \x01(\tR\ttopicName\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x06 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"inviter_id\x18\a \x01(\tR\tinviterId\x12\x12\n" +
+	"\x04role\x18\b \x01(\tR\x04role\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12=\n" +
+	"\fresponded_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vrespondedAt\x12!\n" +
+	"\fresponded_by\x18\x0e \x01(\tR\vrespondedBy\"\x8e\x01\n" +
+	"\x14InviteToTopicRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\"o\n" +
+	"\x15InviteToTopicResponse\x12@\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2 .bib.v1.services.TopicInvitationR\n" +
+	"invitation\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"d\n" +
+	"\x19RequestTopicAccessRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"X\n" +
+	"\x1aRequestTopicAccessResponse\x12:\n" +
+	"\arequest\x18\x01 \x01(\v2 .bib.v1.services.TopicInvitationR\arequest\"t\n" +
+	"\x1fRespondToTopicInvitationRequest\x12#\n" +
+	"\rinvitation_id\x18\x01 \x01(\tR\finvitationId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\"\xa7\x01\n" +
+	" RespondToTopicInvitationResponse\x12@\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2 .bib.v1.services.TopicInvitationR\n" +
+	"invitation\x12A\n" +
+	"\fsubscription\x18\x02 \x01(\v2\x1d.bib.v1.services.SubscriptionR\fsubscription\"n\n" +
+	"\x1fReviewTopicAccessRequestRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"^\n" +
+	" ReviewTopicAccessRequestResponse\x12:\n" +
+	"\arequest\x18\x01 \x01(\v2 .bib.v1.services.TopicInvitationR\arequest\"C\n" +
+	"\x1cCancelTopicInvitationRequest\x12#\n" +
+	"\rinvitation_id\x18\x01 \x01(\tR\finvitationId\"a\n" +
+	"\x1dCancelTopicInvitationResponse\x12@\n" +
+	"\n" +
+	"invitation\x18\x01 \x01(\v2 .bib.v1.services.TopicInvitationR\n" +
+	"invitation\"d\n" +
+	"\x1bListTopicInvitationsRequest\x12\x19\n" +
+	"\btopic_id\x18\x01 \x01(\tR\atopicId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"b\n" +
+	"\x1cListTopicInvitationsResponse\x12B\n" +
+	"\vinvitations\x18\x01 \x03(\v2 .bib.v1.services.TopicInvitationR\vinvitations2\xd5\x0f\n" +
 	"\fTopicService\x12X\n" +
 	"\vCreateTopic\x12#.bib.v1.services.CreateTopicRequest\x1a$.bib.v1.services.CreateTopicResponse\x12O\n" +
 	"\bGetTopic\x12 .bib.v1.services.GetTopicRequest\x1a!.bib.v1.services.GetTopicResponse\x12U\n" +
@@ -2000,7 +3240,15 @@ const file_bib_v1_services_topic_proto_rawDesc = "" +
 	"\x0fGetSubscription\x12'.bib.v1.services.GetSubscriptionRequest\x1a(.bib.v1.services.GetSubscriptionResponse\x12`\n" +
 	"\x12StreamTopicUpdates\x12*.bib.v1.services.StreamTopicUpdatesRequest\x1a\x1c.bib.v1.services.TopicUpdate0\x01\x12^\n" +
 	"\rGetTopicStats\x12%.bib.v1.services.GetTopicStatsRequest\x1a&.bib.v1.services.GetTopicStatsResponse\x12[\n" +
-	"\fSearchTopics\x12$.bib.v1.services.SearchTopicsRequest\x1a%.bib.v1.services.SearchTopicsResponseB\x9f\x01\n" +
+	"\fSearchTopics\x12$.bib.v1.services.SearchTopicsRequest\x1a%.bib.v1.services.SearchTopicsResponse\x12X\n" +
+	"\vSetTopicACL\x12#.bib.v1.services.SetTopicACLRequest\x1a$.bib.v1.services.SetTopicACLResponse\x12X\n" +
+	"\vGetTopicACL\x12#.bib.v1.services.GetTopicACLRequest\x1a$.bib.v1.services.GetTopicACLResponse\x12^\n" +
+	"\rInviteToTopic\x12%.bib.v1.services.InviteToTopicRequest\x1a&.bib.v1.services.InviteToTopicResponse\x12m\n" +
+	"\x12RequestTopicAccess\x12*.bib.v1.services.RequestTopicAccessRequest\x1a+.bib.v1.services.RequestTopicAccessResponse\x12\x7f\n" +
+	"\x18RespondToTopicInvitation\x120.bib.v1.services.RespondToTopicInvitationRequest\x1a1.bib.v1.services.RespondToTopicInvitationResponse\x12\x7f\n" +
+	"\x18ReviewTopicAccessRequest\x120.bib.v1.services.ReviewTopicAccessRequestRequest\x1a1.bib.v1.services.ReviewTopicAccessRequestResponse\x12v\n" +
+	"\x15CancelTopicInvitation\x12-.bib.v1.services.CancelTopicInvitationRequest\x1a..bib.v1.services.CancelTopicInvitationResponse\x12s\n" +
+	"\x14ListTopicInvitations\x12,.bib.v1.services.ListTopicInvitationsRequest\x1a-.bib.v1.services.ListTopicInvitationsResponseB\x9f\x01\n" +
 	"\x13com.bib.v1.servicesB\n" +
 	"TopicProtoP\x01Z\x1ebib/api/gen/go/bib/v1/services\xa2\x02\x03BVS\xaa\x02\x0fBib.V1.Services\xca\x02\x0fBib\\V1\\Services\xe2\x02\x1bBib\\V1\\Services\\GPBMetadata\xea\x02\x11Bib::V1::Servicesb\x06proto3"
 
@@ -2016,103 +3264,155 @@ func file_bib_v1_services_topic_proto_rawDescGZIP() []byte {
 	return file_bib_v1_services_topic_proto_rawDescData
 }
 
-var file_bib_v1_services_topic_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_bib_v1_services_topic_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_bib_v1_services_topic_proto_goTypes = []any{
-	(*Topic)(nil),                     // 0: bib.v1.services.Topic
-	(*Subscription)(nil),              // 1: bib.v1.services.Subscription
-	(*CreateTopicRequest)(nil),        // 2: bib.v1.services.CreateTopicRequest
-	(*CreateTopicResponse)(nil),       // 3: bib.v1.services.CreateTopicResponse
-	(*GetTopicRequest)(nil),           // 4: bib.v1.services.GetTopicRequest
-	(*GetTopicResponse)(nil),          // 5: bib.v1.services.GetTopicResponse
-	(*ListTopicsRequest)(nil),         // 6: bib.v1.services.ListTopicsRequest
-	(*ListTopicsResponse)(nil),        // 7: bib.v1.services.ListTopicsResponse
-	(*UpdateTopicRequest)(nil),        // 8: bib.v1.services.UpdateTopicRequest
-	(*UpdateTopicResponse)(nil),       // 9: bib.v1.services.UpdateTopicResponse
-	(*DeleteTopicRequest)(nil),        // 10: bib.v1.services.DeleteTopicRequest
-	(*DeleteTopicResponse)(nil),       // 11: bib.v1.services.DeleteTopicResponse
-	(*SubscribeRequest)(nil),          // 12: bib.v1.services.SubscribeRequest
-	(*SubscribeResponse)(nil),         // 13: bib.v1.services.SubscribeResponse
-	(*UnsubscribeRequest)(nil),        // 14: bib.v1.services.UnsubscribeRequest
-	(*UnsubscribeResponse)(nil),       // 15: bib.v1.services.UnsubscribeResponse
-	(*ListSubscriptionsRequest)(nil),  // 16: bib.v1.services.ListSubscriptionsRequest
-	(*ListSubscriptionsResponse)(nil), // 17: bib.v1.services.ListSubscriptionsResponse
-	(*GetSubscriptionRequest)(nil),    // 18: bib.v1.services.GetSubscriptionRequest
-	(*GetSubscriptionResponse)(nil),   // 19: bib.v1.services.GetSubscriptionResponse
-	(*StreamTopicUpdatesRequest)(nil), // 20: bib.v1.services.StreamTopicUpdatesRequest
-	(*TopicUpdate)(nil),               // 21: bib.v1.services.TopicUpdate
-	(*GetTopicStatsRequest)(nil),      // 22: bib.v1.services.GetTopicStatsRequest
-	(*GetTopicStatsResponse)(nil),     // 23: bib.v1.services.GetTopicStatsResponse
-	(*SearchTopicsRequest)(nil),       // 24: bib.v1.services.SearchTopicsRequest
-	(*SearchTopicsResponse)(nil),      // 25: bib.v1.services.SearchTopicsResponse
-	nil,                               // 26: bib.v1.services.Topic.MetadataEntry
-	nil,                               // 27: bib.v1.services.CreateTopicRequest.MetadataEntry
-	nil,                               // 28: bib.v1.services.UpdateTopicRequest.MetadataEntry
-	nil,                               // 29: bib.v1.services.GetTopicStatsResponse.DatasetsByTypeEntry
-	(*timestamppb.Timestamp)(nil),     // 30: google.protobuf.Timestamp
-	(*v1.PageRequest)(nil),            // 31: bib.v1.PageRequest
-	(*v1.SortOrder)(nil),              // 32: bib.v1.SortOrder
-	(*v1.PageInfo)(nil),               // 33: bib.v1.PageInfo
-	(*v1.DatasetInfo)(nil),            // 34: bib.v1.DatasetInfo
+	(*Topic)(nil),                            // 0: bib.v1.services.Topic
+	(*Subscription)(nil),                     // 1: bib.v1.services.Subscription
+	(*CreateTopicRequest)(nil),               // 2: bib.v1.services.CreateTopicRequest
+	(*CreateTopicResponse)(nil),              // 3: bib.v1.services.CreateTopicResponse
+	(*GetTopicRequest)(nil),                  // 4: bib.v1.services.GetTopicRequest
+	(*GetTopicResponse)(nil),                 // 5: bib.v1.services.GetTopicResponse
+	(*ListTopicsRequest)(nil),                // 6: bib.v1.services.ListTopicsRequest
+	(*ListTopicsResponse)(nil),               // 7: bib.v1.services.ListTopicsResponse
+	(*UpdateTopicRequest)(nil),               // 8: bib.v1.services.UpdateTopicRequest
+	(*UpdateTopicResponse)(nil),              // 9: bib.v1.services.UpdateTopicResponse
+	(*DeleteTopicRequest)(nil),               // 10: bib.v1.services.DeleteTopicRequest
+	(*DeleteTopicResponse)(nil),              // 11: bib.v1.services.DeleteTopicResponse
+	(*SubscribeRequest)(nil),                 // 12: bib.v1.services.SubscribeRequest
+	(*SubscribeResponse)(nil),                // 13: bib.v1.services.SubscribeResponse
+	(*UnsubscribeRequest)(nil),               // 14: bib.v1.services.UnsubscribeRequest
+	(*UnsubscribeResponse)(nil),              // 15: bib.v1.services.UnsubscribeResponse
+	(*ListSubscriptionsRequest)(nil),         // 16: bib.v1.services.ListSubscriptionsRequest
+	(*ListSubscriptionsResponse)(nil),        // 17: bib.v1.services.ListSubscriptionsResponse
+	(*GetSubscriptionRequest)(nil),           // 18: bib.v1.services.GetSubscriptionRequest
+	(*GetSubscriptionResponse)(nil),          // 19: bib.v1.services.GetSubscriptionResponse
+	(*StreamTopicUpdatesRequest)(nil),        // 20: bib.v1.services.StreamTopicUpdatesRequest
+	(*TopicUpdate)(nil),                      // 21: bib.v1.services.TopicUpdate
+	(*GetTopicStatsRequest)(nil),             // 22: bib.v1.services.GetTopicStatsRequest
+	(*GetTopicStatsResponse)(nil),            // 23: bib.v1.services.GetTopicStatsResponse
+	(*SearchTopicsRequest)(nil),              // 24: bib.v1.services.SearchTopicsRequest
+	(*SearchTopicsResponse)(nil),             // 25: bib.v1.services.SearchTopicsResponse
+	(*SetTopicACLRequest)(nil),               // 26: bib.v1.services.SetTopicACLRequest
+	(*SetTopicACLResponse)(nil),              // 27: bib.v1.services.SetTopicACLResponse
+	(*GetTopicACLRequest)(nil),               // 28: bib.v1.services.GetTopicACLRequest
+	(*GetTopicACLResponse)(nil),              // 29: bib.v1.services.GetTopicACLResponse
+	(*TopicInvitation)(nil),                  // 30: bib.v1.services.TopicInvitation
+	(*InviteToTopicRequest)(nil),             // 31: bib.v1.services.InviteToTopicRequest
+	(*InviteToTopicResponse)(nil),            // 32: bib.v1.services.InviteToTopicResponse
+	(*RequestTopicAccessRequest)(nil),        // 33: bib.v1.services.RequestTopicAccessRequest
+	(*RequestTopicAccessResponse)(nil),       // 34: bib.v1.services.RequestTopicAccessResponse
+	(*RespondToTopicInvitationRequest)(nil),  // 35: bib.v1.services.RespondToTopicInvitationRequest
+	(*RespondToTopicInvitationResponse)(nil), // 36: bib.v1.services.RespondToTopicInvitationResponse
+	(*ReviewTopicAccessRequestRequest)(nil),  // 37: bib.v1.services.ReviewTopicAccessRequestRequest
+	(*ReviewTopicAccessRequestResponse)(nil), // 38: bib.v1.services.ReviewTopicAccessRequestResponse
+	(*CancelTopicInvitationRequest)(nil),     // 39: bib.v1.services.CancelTopicInvitationRequest
+	(*CancelTopicInvitationResponse)(nil),    // 40: bib.v1.services.CancelTopicInvitationResponse
+	(*ListTopicInvitationsRequest)(nil),      // 41: bib.v1.services.ListTopicInvitationsRequest
+	(*ListTopicInvitationsResponse)(nil),     // 42: bib.v1.services.ListTopicInvitationsResponse
+	nil,                                      // 43: bib.v1.services.Topic.MetadataEntry
+	nil,                                      // 44: bib.v1.services.CreateTopicRequest.MetadataEntry
+	nil,                                      // 45: bib.v1.services.UpdateTopicRequest.MetadataEntry
+	nil,                                      // 46: bib.v1.services.GetTopicStatsResponse.DatasetsByTypeEntry
+	(*timestamppb.Timestamp)(nil),            // 47: google.protobuf.Timestamp
+	(*v1.DataSchema)(nil),                    // 48: bib.v1.DataSchema
+	(*v1.PageRequest)(nil),                   // 49: bib.v1.PageRequest
+	(*v1.SortOrder)(nil),                     // 50: bib.v1.SortOrder
+	(*v1.PageInfo)(nil),                      // 51: bib.v1.PageInfo
+	(*v1.DatasetInfo)(nil),                   // 52: bib.v1.DatasetInfo
+	(*v1.TopicACLEntry)(nil),                 // 53: bib.v1.TopicACLEntry
 }
 var file_bib_v1_services_topic_proto_depIdxs = []int32{
-	30, // 0: bib.v1.services.Topic.created_at:type_name -> google.protobuf.Timestamp
-	30, // 1: bib.v1.services.Topic.updated_at:type_name -> google.protobuf.Timestamp
-	30, // 2: bib.v1.services.Topic.last_sync_at:type_name -> google.protobuf.Timestamp
-	26, // 3: bib.v1.services.Topic.metadata:type_name -> bib.v1.services.Topic.MetadataEntry
-	30, // 4: bib.v1.services.Subscription.subscribed_at:type_name -> google.protobuf.Timestamp
-	30, // 5: bib.v1.services.Subscription.last_sync_at:type_name -> google.protobuf.Timestamp
-	27, // 6: bib.v1.services.CreateTopicRequest.metadata:type_name -> bib.v1.services.CreateTopicRequest.MetadataEntry
-	0,  // 7: bib.v1.services.CreateTopicResponse.topic:type_name -> bib.v1.services.Topic
-	0,  // 8: bib.v1.services.GetTopicResponse.topic:type_name -> bib.v1.services.Topic
-	1,  // 9: bib.v1.services.GetTopicResponse.subscription:type_name -> bib.v1.services.Subscription
-	31, // 10: bib.v1.services.ListTopicsRequest.page:type_name -> bib.v1.PageRequest
-	32, // 11: bib.v1.services.ListTopicsRequest.sort:type_name -> bib.v1.SortOrder
-	0,  // 12: bib.v1.services.ListTopicsResponse.topics:type_name -> bib.v1.services.Topic
-	33, // 13: bib.v1.services.ListTopicsResponse.page_info:type_name -> bib.v1.PageInfo
-	28, // 14: bib.v1.services.UpdateTopicRequest.metadata:type_name -> bib.v1.services.UpdateTopicRequest.MetadataEntry
-	0,  // 15: bib.v1.services.UpdateTopicResponse.topic:type_name -> bib.v1.services.Topic
-	1,  // 16: bib.v1.services.SubscribeResponse.subscription:type_name -> bib.v1.services.Subscription
-	31, // 17: bib.v1.services.ListSubscriptionsRequest.page:type_name -> bib.v1.PageRequest
-	1,  // 18: bib.v1.services.ListSubscriptionsResponse.subscriptions:type_name -> bib.v1.services.Subscription
-	33, // 19: bib.v1.services.ListSubscriptionsResponse.page_info:type_name -> bib.v1.PageInfo
-	1,  // 20: bib.v1.services.GetSubscriptionResponse.subscription:type_name -> bib.v1.services.Subscription
-	0,  // 21: bib.v1.services.TopicUpdate.topic:type_name -> bib.v1.services.Topic
-	34, // 22: bib.v1.services.TopicUpdate.dataset:type_name -> bib.v1.DatasetInfo
-	30, // 23: bib.v1.services.TopicUpdate.timestamp:type_name -> google.protobuf.Timestamp
-	29, // 24: bib.v1.services.GetTopicStatsResponse.datasets_by_type:type_name -> bib.v1.services.GetTopicStatsResponse.DatasetsByTypeEntry
-	30, // 25: bib.v1.services.GetTopicStatsResponse.last_activity:type_name -> google.protobuf.Timestamp
-	31, // 26: bib.v1.services.SearchTopicsRequest.page:type_name -> bib.v1.PageRequest
-	0,  // 27: bib.v1.services.SearchTopicsResponse.topics:type_name -> bib.v1.services.Topic
-	33, // 28: bib.v1.services.SearchTopicsResponse.page_info:type_name -> bib.v1.PageInfo
-	2,  // 29: bib.v1.services.TopicService.CreateTopic:input_type -> bib.v1.services.CreateTopicRequest
-	4,  // 30: bib.v1.services.TopicService.GetTopic:input_type -> bib.v1.services.GetTopicRequest
-	6,  // 31: bib.v1.services.TopicService.ListTopics:input_type -> bib.v1.services.ListTopicsRequest
-	8,  // 32: bib.v1.services.TopicService.UpdateTopic:input_type -> bib.v1.services.UpdateTopicRequest
-	10, // 33: bib.v1.services.TopicService.DeleteTopic:input_type -> bib.v1.services.DeleteTopicRequest
-	12, // 34: bib.v1.services.TopicService.Subscribe:input_type -> bib.v1.services.SubscribeRequest
-	14, // 35: bib.v1.services.TopicService.Unsubscribe:input_type -> bib.v1.services.UnsubscribeRequest
-	16, // 36: bib.v1.services.TopicService.ListSubscriptions:input_type -> bib.v1.services.ListSubscriptionsRequest
-	18, // 37: bib.v1.services.TopicService.GetSubscription:input_type -> bib.v1.services.GetSubscriptionRequest
-	20, // 38: bib.v1.services.TopicService.StreamTopicUpdates:input_type -> bib.v1.services.StreamTopicUpdatesRequest
-	22, // 39: bib.v1.services.TopicService.GetTopicStats:input_type -> bib.v1.services.GetTopicStatsRequest
-	24, // 40: bib.v1.services.TopicService.SearchTopics:input_type -> bib.v1.services.SearchTopicsRequest
-	3,  // 41: bib.v1.services.TopicService.CreateTopic:output_type -> bib.v1.services.CreateTopicResponse
-	5,  // 42: bib.v1.services.TopicService.GetTopic:output_type -> bib.v1.services.GetTopicResponse
-	7,  // 43: bib.v1.services.TopicService.ListTopics:output_type -> bib.v1.services.ListTopicsResponse
-	9,  // 44: bib.v1.services.TopicService.UpdateTopic:output_type -> bib.v1.services.UpdateTopicResponse
-	11, // 45: bib.v1.services.TopicService.DeleteTopic:output_type -> bib.v1.services.DeleteTopicResponse
-	13, // 46: bib.v1.services.TopicService.Subscribe:output_type -> bib.v1.services.SubscribeResponse
-	15, // 47: bib.v1.services.TopicService.Unsubscribe:output_type -> bib.v1.services.UnsubscribeResponse
-	17, // 48: bib.v1.services.TopicService.ListSubscriptions:output_type -> bib.v1.services.ListSubscriptionsResponse
-	19, // 49: bib.v1.services.TopicService.GetSubscription:output_type -> bib.v1.services.GetSubscriptionResponse
-	21, // 50: bib.v1.services.TopicService.StreamTopicUpdates:output_type -> bib.v1.services.TopicUpdate
-	23, // 51: bib.v1.services.TopicService.GetTopicStats:output_type -> bib.v1.services.GetTopicStatsResponse
-	25, // 52: bib.v1.services.TopicService.SearchTopics:output_type -> bib.v1.services.SearchTopicsResponse
-	41, // [41:53] is the sub-list for method output_type
-	29, // [29:41] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	47, // 0: bib.v1.services.Topic.created_at:type_name -> google.protobuf.Timestamp
+	47, // 1: bib.v1.services.Topic.updated_at:type_name -> google.protobuf.Timestamp
+	47, // 2: bib.v1.services.Topic.last_sync_at:type_name -> google.protobuf.Timestamp
+	43, // 3: bib.v1.services.Topic.metadata:type_name -> bib.v1.services.Topic.MetadataEntry
+	48, // 4: bib.v1.services.Topic.data_schema:type_name -> bib.v1.DataSchema
+	47, // 5: bib.v1.services.Subscription.subscribed_at:type_name -> google.protobuf.Timestamp
+	47, // 6: bib.v1.services.Subscription.last_sync_at:type_name -> google.protobuf.Timestamp
+	44, // 7: bib.v1.services.CreateTopicRequest.metadata:type_name -> bib.v1.services.CreateTopicRequest.MetadataEntry
+	48, // 8: bib.v1.services.CreateTopicRequest.data_schema:type_name -> bib.v1.DataSchema
+	0,  // 9: bib.v1.services.CreateTopicResponse.topic:type_name -> bib.v1.services.Topic
+	0,  // 10: bib.v1.services.GetTopicResponse.topic:type_name -> bib.v1.services.Topic
+	1,  // 11: bib.v1.services.GetTopicResponse.subscription:type_name -> bib.v1.services.Subscription
+	49, // 12: bib.v1.services.ListTopicsRequest.page:type_name -> bib.v1.PageRequest
+	50, // 13: bib.v1.services.ListTopicsRequest.sort:type_name -> bib.v1.SortOrder
+	0,  // 14: bib.v1.services.ListTopicsResponse.topics:type_name -> bib.v1.services.Topic
+	51, // 15: bib.v1.services.ListTopicsResponse.page_info:type_name -> bib.v1.PageInfo
+	45, // 16: bib.v1.services.UpdateTopicRequest.metadata:type_name -> bib.v1.services.UpdateTopicRequest.MetadataEntry
+	48, // 17: bib.v1.services.UpdateTopicRequest.data_schema:type_name -> bib.v1.DataSchema
+	0,  // 18: bib.v1.services.UpdateTopicResponse.topic:type_name -> bib.v1.services.Topic
+	1,  // 19: bib.v1.services.SubscribeResponse.subscription:type_name -> bib.v1.services.Subscription
+	49, // 20: bib.v1.services.ListSubscriptionsRequest.page:type_name -> bib.v1.PageRequest
+	1,  // 21: bib.v1.services.ListSubscriptionsResponse.subscriptions:type_name -> bib.v1.services.Subscription
+	51, // 22: bib.v1.services.ListSubscriptionsResponse.page_info:type_name -> bib.v1.PageInfo
+	1,  // 23: bib.v1.services.GetSubscriptionResponse.subscription:type_name -> bib.v1.services.Subscription
+	0,  // 24: bib.v1.services.TopicUpdate.topic:type_name -> bib.v1.services.Topic
+	52, // 25: bib.v1.services.TopicUpdate.dataset:type_name -> bib.v1.DatasetInfo
+	47, // 26: bib.v1.services.TopicUpdate.timestamp:type_name -> google.protobuf.Timestamp
+	46, // 27: bib.v1.services.GetTopicStatsResponse.datasets_by_type:type_name -> bib.v1.services.GetTopicStatsResponse.DatasetsByTypeEntry
+	47, // 28: bib.v1.services.GetTopicStatsResponse.last_activity:type_name -> google.protobuf.Timestamp
+	49, // 29: bib.v1.services.SearchTopicsRequest.page:type_name -> bib.v1.PageRequest
+	0,  // 30: bib.v1.services.SearchTopicsResponse.topics:type_name -> bib.v1.services.Topic
+	51, // 31: bib.v1.services.SearchTopicsResponse.page_info:type_name -> bib.v1.PageInfo
+	53, // 32: bib.v1.services.SetTopicACLRequest.grant:type_name -> bib.v1.TopicACLEntry
+	53, // 33: bib.v1.services.SetTopicACLRequest.revoke:type_name -> bib.v1.TopicACLEntry
+	53, // 34: bib.v1.services.SetTopicACLResponse.entries:type_name -> bib.v1.TopicACLEntry
+	53, // 35: bib.v1.services.GetTopicACLResponse.entries:type_name -> bib.v1.TopicACLEntry
+	47, // 36: bib.v1.services.TopicInvitation.created_at:type_name -> google.protobuf.Timestamp
+	47, // 37: bib.v1.services.TopicInvitation.expires_at:type_name -> google.protobuf.Timestamp
+	47, // 38: bib.v1.services.TopicInvitation.responded_at:type_name -> google.protobuf.Timestamp
+	30, // 39: bib.v1.services.InviteToTopicResponse.invitation:type_name -> bib.v1.services.TopicInvitation
+	30, // 40: bib.v1.services.RequestTopicAccessResponse.request:type_name -> bib.v1.services.TopicInvitation
+	30, // 41: bib.v1.services.RespondToTopicInvitationResponse.invitation:type_name -> bib.v1.services.TopicInvitation
+	1,  // 42: bib.v1.services.RespondToTopicInvitationResponse.subscription:type_name -> bib.v1.services.Subscription
+	30, // 43: bib.v1.services.ReviewTopicAccessRequestResponse.request:type_name -> bib.v1.services.TopicInvitation
+	30, // 44: bib.v1.services.CancelTopicInvitationResponse.invitation:type_name -> bib.v1.services.TopicInvitation
+	30, // 45: bib.v1.services.ListTopicInvitationsResponse.invitations:type_name -> bib.v1.services.TopicInvitation
+	2,  // 46: bib.v1.services.TopicService.CreateTopic:input_type -> bib.v1.services.CreateTopicRequest
+	4,  // 47: bib.v1.services.TopicService.GetTopic:input_type -> bib.v1.services.GetTopicRequest
+	6,  // 48: bib.v1.services.TopicService.ListTopics:input_type -> bib.v1.services.ListTopicsRequest
+	8,  // 49: bib.v1.services.TopicService.UpdateTopic:input_type -> bib.v1.services.UpdateTopicRequest
+	10, // 50: bib.v1.services.TopicService.DeleteTopic:input_type -> bib.v1.services.DeleteTopicRequest
+	12, // 51: bib.v1.services.TopicService.Subscribe:input_type -> bib.v1.services.SubscribeRequest
+	14, // 52: bib.v1.services.TopicService.Unsubscribe:input_type -> bib.v1.services.UnsubscribeRequest
+	16, // 53: bib.v1.services.TopicService.ListSubscriptions:input_type -> bib.v1.services.ListSubscriptionsRequest
+	18, // 54: bib.v1.services.TopicService.GetSubscription:input_type -> bib.v1.services.GetSubscriptionRequest
+	20, // 55: bib.v1.services.TopicService.StreamTopicUpdates:input_type -> bib.v1.services.StreamTopicUpdatesRequest
+	22, // 56: bib.v1.services.TopicService.GetTopicStats:input_type -> bib.v1.services.GetTopicStatsRequest
+	24, // 57: bib.v1.services.TopicService.SearchTopics:input_type -> bib.v1.services.SearchTopicsRequest
+	26, // 58: bib.v1.services.TopicService.SetTopicACL:input_type -> bib.v1.services.SetTopicACLRequest
+	28, // 59: bib.v1.services.TopicService.GetTopicACL:input_type -> bib.v1.services.GetTopicACLRequest
+	31, // 60: bib.v1.services.TopicService.InviteToTopic:input_type -> bib.v1.services.InviteToTopicRequest
+	33, // 61: bib.v1.services.TopicService.RequestTopicAccess:input_type -> bib.v1.services.RequestTopicAccessRequest
+	35, // 62: bib.v1.services.TopicService.RespondToTopicInvitation:input_type -> bib.v1.services.RespondToTopicInvitationRequest
+	37, // 63: bib.v1.services.TopicService.ReviewTopicAccessRequest:input_type -> bib.v1.services.ReviewTopicAccessRequestRequest
+	39, // 64: bib.v1.services.TopicService.CancelTopicInvitation:input_type -> bib.v1.services.CancelTopicInvitationRequest
+	41, // 65: bib.v1.services.TopicService.ListTopicInvitations:input_type -> bib.v1.services.ListTopicInvitationsRequest
+	3,  // 66: bib.v1.services.TopicService.CreateTopic:output_type -> bib.v1.services.CreateTopicResponse
+	5,  // 67: bib.v1.services.TopicService.GetTopic:output_type -> bib.v1.services.GetTopicResponse
+	7,  // 68: bib.v1.services.TopicService.ListTopics:output_type -> bib.v1.services.ListTopicsResponse
+	9,  // 69: bib.v1.services.TopicService.UpdateTopic:output_type -> bib.v1.services.UpdateTopicResponse
+	11, // 70: bib.v1.services.TopicService.DeleteTopic:output_type -> bib.v1.services.DeleteTopicResponse
+	13, // 71: bib.v1.services.TopicService.Subscribe:output_type -> bib.v1.services.SubscribeResponse
+	15, // 72: bib.v1.services.TopicService.Unsubscribe:output_type -> bib.v1.services.UnsubscribeResponse
+	17, // 73: bib.v1.services.TopicService.ListSubscriptions:output_type -> bib.v1.services.ListSubscriptionsResponse
+	19, // 74: bib.v1.services.TopicService.GetSubscription:output_type -> bib.v1.services.GetSubscriptionResponse
+	21, // 75: bib.v1.services.TopicService.StreamTopicUpdates:output_type -> bib.v1.services.TopicUpdate
+	23, // 76: bib.v1.services.TopicService.GetTopicStats:output_type -> bib.v1.services.GetTopicStatsResponse
+	25, // 77: bib.v1.services.TopicService.SearchTopics:output_type -> bib.v1.services.SearchTopicsResponse
+	27, // 78: bib.v1.services.TopicService.SetTopicACL:output_type -> bib.v1.services.SetTopicACLResponse
+	29, // 79: bib.v1.services.TopicService.GetTopicACL:output_type -> bib.v1.services.GetTopicACLResponse
+	32, // 80: bib.v1.services.TopicService.InviteToTopic:output_type -> bib.v1.services.InviteToTopicResponse
+	34, // 81: bib.v1.services.TopicService.RequestTopicAccess:output_type -> bib.v1.services.RequestTopicAccessResponse
+	36, // 82: bib.v1.services.TopicService.RespondToTopicInvitation:output_type -> bib.v1.services.RespondToTopicInvitationResponse
+	38, // 83: bib.v1.services.TopicService.ReviewTopicAccessRequest:output_type -> bib.v1.services.ReviewTopicAccessRequestResponse
+	40, // 84: bib.v1.services.TopicService.CancelTopicInvitation:output_type -> bib.v1.services.CancelTopicInvitationResponse
+	42, // 85: bib.v1.services.TopicService.ListTopicInvitations:output_type -> bib.v1.services.ListTopicInvitationsResponse
+	66, // [66:86] is the sub-list for method output_type
+	46, // [46:66] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_topic_proto_init() }
@@ -2127,7 +3427,7 @@ func file_bib_v1_services_topic_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_topic_proto_rawDesc), len(file_bib_v1_services_topic_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   30,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
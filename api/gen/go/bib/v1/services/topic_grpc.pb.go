@@ -19,18 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TopicService_CreateTopic_FullMethodName        = "/bib.v1.services.TopicService/CreateTopic"
-	TopicService_GetTopic_FullMethodName           = "/bib.v1.services.TopicService/GetTopic"
-	TopicService_ListTopics_FullMethodName         = "/bib.v1.services.TopicService/ListTopics"
-	TopicService_UpdateTopic_FullMethodName        = "/bib.v1.services.TopicService/UpdateTopic"
-	TopicService_DeleteTopic_FullMethodName        = "/bib.v1.services.TopicService/DeleteTopic"
-	TopicService_Subscribe_FullMethodName          = "/bib.v1.services.TopicService/Subscribe"
-	TopicService_Unsubscribe_FullMethodName        = "/bib.v1.services.TopicService/Unsubscribe"
-	TopicService_ListSubscriptions_FullMethodName  = "/bib.v1.services.TopicService/ListSubscriptions"
-	TopicService_GetSubscription_FullMethodName    = "/bib.v1.services.TopicService/GetSubscription"
-	TopicService_StreamTopicUpdates_FullMethodName = "/bib.v1.services.TopicService/StreamTopicUpdates"
-	TopicService_GetTopicStats_FullMethodName      = "/bib.v1.services.TopicService/GetTopicStats"
-	TopicService_SearchTopics_FullMethodName       = "/bib.v1.services.TopicService/SearchTopics"
+	TopicService_CreateTopic_FullMethodName              = "/bib.v1.services.TopicService/CreateTopic"
+	TopicService_GetTopic_FullMethodName                 = "/bib.v1.services.TopicService/GetTopic"
+	TopicService_ListTopics_FullMethodName               = "/bib.v1.services.TopicService/ListTopics"
+	TopicService_UpdateTopic_FullMethodName              = "/bib.v1.services.TopicService/UpdateTopic"
+	TopicService_DeleteTopic_FullMethodName              = "/bib.v1.services.TopicService/DeleteTopic"
+	TopicService_Subscribe_FullMethodName                = "/bib.v1.services.TopicService/Subscribe"
+	TopicService_Unsubscribe_FullMethodName              = "/bib.v1.services.TopicService/Unsubscribe"
+	TopicService_ListSubscriptions_FullMethodName        = "/bib.v1.services.TopicService/ListSubscriptions"
+	TopicService_GetSubscription_FullMethodName          = "/bib.v1.services.TopicService/GetSubscription"
+	TopicService_StreamTopicUpdates_FullMethodName       = "/bib.v1.services.TopicService/StreamTopicUpdates"
+	TopicService_GetTopicStats_FullMethodName            = "/bib.v1.services.TopicService/GetTopicStats"
+	TopicService_SearchTopics_FullMethodName             = "/bib.v1.services.TopicService/SearchTopics"
+	TopicService_SetTopicACL_FullMethodName              = "/bib.v1.services.TopicService/SetTopicACL"
+	TopicService_GetTopicACL_FullMethodName              = "/bib.v1.services.TopicService/GetTopicACL"
+	TopicService_InviteToTopic_FullMethodName            = "/bib.v1.services.TopicService/InviteToTopic"
+	TopicService_RequestTopicAccess_FullMethodName       = "/bib.v1.services.TopicService/RequestTopicAccess"
+	TopicService_RespondToTopicInvitation_FullMethodName = "/bib.v1.services.TopicService/RespondToTopicInvitation"
+	TopicService_ReviewTopicAccessRequest_FullMethodName = "/bib.v1.services.TopicService/ReviewTopicAccessRequest"
+	TopicService_CancelTopicInvitation_FullMethodName    = "/bib.v1.services.TopicService/CancelTopicInvitation"
+	TopicService_ListTopicInvitations_FullMethodName     = "/bib.v1.services.TopicService/ListTopicInvitations"
 )
 
 // TopicServiceClient is the client API for TopicService service.
@@ -63,6 +71,23 @@ type TopicServiceClient interface {
 	GetTopicStats(ctx context.Context, in *GetTopicStatsRequest, opts ...grpc.CallOption) (*GetTopicStatsResponse, error)
 	// SearchTopics searches topics by text query.
 	SearchTopics(ctx context.Context, in *SearchTopicsRequest, opts ...grpc.CallOption) (*SearchTopicsResponse, error)
+	// SetTopicACL grants or revokes access to a topic (owners and topic admins).
+	SetTopicACL(ctx context.Context, in *SetTopicACLRequest, opts ...grpc.CallOption) (*SetTopicACLResponse, error)
+	// GetTopicACL returns the access control list of a topic.
+	GetTopicACL(ctx context.Context, in *GetTopicACLRequest, opts ...grpc.CallOption) (*GetTopicACLResponse, error)
+	// InviteToTopic invites a user to join a topic (owners).
+	InviteToTopic(ctx context.Context, in *InviteToTopicRequest, opts ...grpc.CallOption) (*InviteToTopicResponse, error)
+	// RequestTopicAccess asks the owners of a topic to let the caller join.
+	RequestTopicAccess(ctx context.Context, in *RequestTopicAccessRequest, opts ...grpc.CallOption) (*RequestTopicAccessResponse, error)
+	// RespondToTopicInvitation accepts or declines an invitation (invitee).
+	RespondToTopicInvitation(ctx context.Context, in *RespondToTopicInvitationRequest, opts ...grpc.CallOption) (*RespondToTopicInvitationResponse, error)
+	// ReviewTopicAccessRequest approves or denies an access request (owners).
+	ReviewTopicAccessRequest(ctx context.Context, in *ReviewTopicAccessRequestRequest, opts ...grpc.CallOption) (*ReviewTopicAccessRequestResponse, error)
+	// CancelTopicInvitation withdraws a pending invitation or access request.
+	CancelTopicInvitation(ctx context.Context, in *CancelTopicInvitationRequest, opts ...grpc.CallOption) (*CancelTopicInvitationResponse, error)
+	// ListTopicInvitations lists the invitations and access requests of a
+	// topic, or those of the caller.
+	ListTopicInvitations(ctx context.Context, in *ListTopicInvitationsRequest, opts ...grpc.CallOption) (*ListTopicInvitationsResponse, error)
 }
 
 type topicServiceClient struct {
@@ -202,6 +227,86 @@ func (c *topicServiceClient) SearchTopics(ctx context.Context, in *SearchTopicsR
 	return out, nil
 }
 
+func (c *topicServiceClient) SetTopicACL(ctx context.Context, in *SetTopicACLRequest, opts ...grpc.CallOption) (*SetTopicACLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTopicACLResponse)
+	err := c.cc.Invoke(ctx, TopicService_SetTopicACL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) GetTopicACL(ctx context.Context, in *GetTopicACLRequest, opts ...grpc.CallOption) (*GetTopicACLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTopicACLResponse)
+	err := c.cc.Invoke(ctx, TopicService_GetTopicACL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) InviteToTopic(ctx context.Context, in *InviteToTopicRequest, opts ...grpc.CallOption) (*InviteToTopicResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteToTopicResponse)
+	err := c.cc.Invoke(ctx, TopicService_InviteToTopic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) RequestTopicAccess(ctx context.Context, in *RequestTopicAccessRequest, opts ...grpc.CallOption) (*RequestTopicAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestTopicAccessResponse)
+	err := c.cc.Invoke(ctx, TopicService_RequestTopicAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) RespondToTopicInvitation(ctx context.Context, in *RespondToTopicInvitationRequest, opts ...grpc.CallOption) (*RespondToTopicInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespondToTopicInvitationResponse)
+	err := c.cc.Invoke(ctx, TopicService_RespondToTopicInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) ReviewTopicAccessRequest(ctx context.Context, in *ReviewTopicAccessRequestRequest, opts ...grpc.CallOption) (*ReviewTopicAccessRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewTopicAccessRequestResponse)
+	err := c.cc.Invoke(ctx, TopicService_ReviewTopicAccessRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) CancelTopicInvitation(ctx context.Context, in *CancelTopicInvitationRequest, opts ...grpc.CallOption) (*CancelTopicInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTopicInvitationResponse)
+	err := c.cc.Invoke(ctx, TopicService_CancelTopicInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *topicServiceClient) ListTopicInvitations(ctx context.Context, in *ListTopicInvitationsRequest, opts ...grpc.CallOption) (*ListTopicInvitationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopicInvitationsResponse)
+	err := c.cc.Invoke(ctx, TopicService_ListTopicInvitations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TopicServiceServer is the server API for TopicService service.
 // All implementations should embed UnimplementedTopicServiceServer
 // for forward compatibility.
@@ -232,6 +337,23 @@ type TopicServiceServer interface {
 	GetTopicStats(context.Context, *GetTopicStatsRequest) (*GetTopicStatsResponse, error)
 	// SearchTopics searches topics by text query.
 	SearchTopics(context.Context, *SearchTopicsRequest) (*SearchTopicsResponse, error)
+	// SetTopicACL grants or revokes access to a topic (owners and topic admins).
+	SetTopicACL(context.Context, *SetTopicACLRequest) (*SetTopicACLResponse, error)
+	// GetTopicACL returns the access control list of a topic.
+	GetTopicACL(context.Context, *GetTopicACLRequest) (*GetTopicACLResponse, error)
+	// InviteToTopic invites a user to join a topic (owners).
+	InviteToTopic(context.Context, *InviteToTopicRequest) (*InviteToTopicResponse, error)
+	// RequestTopicAccess asks the owners of a topic to let the caller join.
+	RequestTopicAccess(context.Context, *RequestTopicAccessRequest) (*RequestTopicAccessResponse, error)
+	// RespondToTopicInvitation accepts or declines an invitation (invitee).
+	RespondToTopicInvitation(context.Context, *RespondToTopicInvitationRequest) (*RespondToTopicInvitationResponse, error)
+	// ReviewTopicAccessRequest approves or denies an access request (owners).
+	ReviewTopicAccessRequest(context.Context, *ReviewTopicAccessRequestRequest) (*ReviewTopicAccessRequestResponse, error)
+	// CancelTopicInvitation withdraws a pending invitation or access request.
+	CancelTopicInvitation(context.Context, *CancelTopicInvitationRequest) (*CancelTopicInvitationResponse, error)
+	// ListTopicInvitations lists the invitations and access requests of a
+	// topic, or those of the caller.
+	ListTopicInvitations(context.Context, *ListTopicInvitationsRequest) (*ListTopicInvitationsResponse, error)
 }
 
 // UnimplementedTopicServiceServer should be embedded to have
@@ -277,6 +399,30 @@ func (UnimplementedTopicServiceServer) GetTopicStats(context.Context, *GetTopicS
 func (UnimplementedTopicServiceServer) SearchTopics(context.Context, *SearchTopicsRequest) (*SearchTopicsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SearchTopics not implemented")
 }
+func (UnimplementedTopicServiceServer) SetTopicACL(context.Context, *SetTopicACLRequest) (*SetTopicACLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTopicACL not implemented")
+}
+func (UnimplementedTopicServiceServer) GetTopicACL(context.Context, *GetTopicACLRequest) (*GetTopicACLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopicACL not implemented")
+}
+func (UnimplementedTopicServiceServer) InviteToTopic(context.Context, *InviteToTopicRequest) (*InviteToTopicResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InviteToTopic not implemented")
+}
+func (UnimplementedTopicServiceServer) RequestTopicAccess(context.Context, *RequestTopicAccessRequest) (*RequestTopicAccessResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestTopicAccess not implemented")
+}
+func (UnimplementedTopicServiceServer) RespondToTopicInvitation(context.Context, *RespondToTopicInvitationRequest) (*RespondToTopicInvitationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RespondToTopicInvitation not implemented")
+}
+func (UnimplementedTopicServiceServer) ReviewTopicAccessRequest(context.Context, *ReviewTopicAccessRequestRequest) (*ReviewTopicAccessRequestResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReviewTopicAccessRequest not implemented")
+}
+func (UnimplementedTopicServiceServer) CancelTopicInvitation(context.Context, *CancelTopicInvitationRequest) (*CancelTopicInvitationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelTopicInvitation not implemented")
+}
+func (UnimplementedTopicServiceServer) ListTopicInvitations(context.Context, *ListTopicInvitationsRequest) (*ListTopicInvitationsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTopicInvitations not implemented")
+}
 func (UnimplementedTopicServiceServer) testEmbeddedByValue() {}
 
 // UnsafeTopicServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -506,6 +652,150 @@ func _TopicService_SearchTopics_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TopicService_SetTopicACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTopicACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).SetTopicACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_SetTopicACL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).SetTopicACL(ctx, req.(*SetTopicACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_GetTopicACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopicACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).GetTopicACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_GetTopicACL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).GetTopicACL(ctx, req.(*GetTopicACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_InviteToTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteToTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).InviteToTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_InviteToTopic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).InviteToTopic(ctx, req.(*InviteToTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_RequestTopicAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestTopicAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).RequestTopicAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_RequestTopicAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).RequestTopicAccess(ctx, req.(*RequestTopicAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_RespondToTopicInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToTopicInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).RespondToTopicInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_RespondToTopicInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).RespondToTopicInvitation(ctx, req.(*RespondToTopicInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_ReviewTopicAccessRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewTopicAccessRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).ReviewTopicAccessRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_ReviewTopicAccessRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).ReviewTopicAccessRequest(ctx, req.(*ReviewTopicAccessRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_CancelTopicInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTopicInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).CancelTopicInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_CancelTopicInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).CancelTopicInvitation(ctx, req.(*CancelTopicInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TopicService_ListTopicInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopicInvitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TopicServiceServer).ListTopicInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TopicService_ListTopicInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TopicServiceServer).ListTopicInvitations(ctx, req.(*ListTopicInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TopicService_ServiceDesc is the grpc.ServiceDesc for TopicService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -557,6 +847,38 @@ var TopicService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchTopics",
 			Handler:    _TopicService_SearchTopics_Handler,
 		},
+		{
+			MethodName: "SetTopicACL",
+			Handler:    _TopicService_SetTopicACL_Handler,
+		},
+		{
+			MethodName: "GetTopicACL",
+			Handler:    _TopicService_GetTopicACL_Handler,
+		},
+		{
+			MethodName: "InviteToTopic",
+			Handler:    _TopicService_InviteToTopic_Handler,
+		},
+		{
+			MethodName: "RequestTopicAccess",
+			Handler:    _TopicService_RequestTopicAccess_Handler,
+		},
+		{
+			MethodName: "RespondToTopicInvitation",
+			Handler:    _TopicService_RespondToTopicInvitation_Handler,
+		},
+		{
+			MethodName: "ReviewTopicAccessRequest",
+			Handler:    _TopicService_ReviewTopicAccessRequest_Handler,
+		},
+		{
+			MethodName: "CancelTopicInvitation",
+			Handler:    _TopicService_CancelTopicInvitation_Handler,
+		},
+		{
+			MethodName: "ListTopicInvitations",
+			Handler:    _TopicService_ListTopicInvitations_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
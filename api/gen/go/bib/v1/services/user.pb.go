@@ -484,6 +484,100 @@ func (x *Session) GetMetadata() map[string]string {
 	return nil
 }
 
+// UserPublicKey is an identity key registered to a user. Every key can
+// authenticate as the user; additional keys are labeled by device so they can
+// be revoked individually.
+type UserPublicKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SHA256 fingerprint of the public key (hex-encoded).
+	Fingerprint string `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	// Public key bytes (Ed25519 or RSA).
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Key type: "ed25519" or "rsa".
+	KeyType string `protobuf:"bytes,3,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	// Device label, e.g. "laptop" (empty for the primary key).
+	Label string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	// Whether this is the key the user ID was derived from. The primary key
+	// cannot be removed.
+	Primary bool `protobuf:"varint,5,opt,name=primary,proto3" json:"primary,omitempty"`
+	// When the key was registered.
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPublicKey) Reset() {
+	*x = UserPublicKey{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPublicKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPublicKey) ProtoMessage() {}
+
+func (x *UserPublicKey) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPublicKey.ProtoReflect.Descriptor instead.
+func (*UserPublicKey) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserPublicKey) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *UserPublicKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *UserPublicKey) GetKeyType() string {
+	if x != nil {
+		return x.KeyType
+	}
+	return ""
+}
+
+func (x *UserPublicKey) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *UserPublicKey) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+func (x *UserPublicKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
 // UserPreferences contains user preference settings.
 type UserPreferences struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -509,7 +603,7 @@ type UserPreferences struct {
 
 func (x *UserPreferences) Reset() {
 	*x = UserPreferences{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[2]
+	mi := &file_bib_v1_services_user_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -521,7 +615,7 @@ func (x *UserPreferences) String() string {
 func (*UserPreferences) ProtoMessage() {}
 
 func (x *UserPreferences) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[2]
+	mi := &file_bib_v1_services_user_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -534,7 +628,7 @@ func (x *UserPreferences) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserPreferences.ProtoReflect.Descriptor instead.
 func (*UserPreferences) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{2}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UserPreferences) GetUserId() string {
@@ -603,7 +697,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[3]
+	mi := &file_bib_v1_services_user_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -615,7 +709,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[3]
+	mi := &file_bib_v1_services_user_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +722,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{3}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetUserRequest) GetUserId() string {
@@ -648,7 +742,7 @@ type GetUserResponse struct {
 
 func (x *GetUserResponse) Reset() {
 	*x = GetUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[4]
+	mi := &file_bib_v1_services_user_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -660,7 +754,7 @@ func (x *GetUserResponse) String() string {
 func (*GetUserResponse) ProtoMessage() {}
 
 func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[4]
+	mi := &file_bib_v1_services_user_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,7 +767,7 @@ func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
 func (*GetUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{4}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetUserResponse) GetUser() *User {
@@ -693,7 +787,7 @@ type GetUserByPublicKeyRequest struct {
 
 func (x *GetUserByPublicKeyRequest) Reset() {
 	*x = GetUserByPublicKeyRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[5]
+	mi := &file_bib_v1_services_user_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -705,7 +799,7 @@ func (x *GetUserByPublicKeyRequest) String() string {
 func (*GetUserByPublicKeyRequest) ProtoMessage() {}
 
 func (x *GetUserByPublicKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[5]
+	mi := &file_bib_v1_services_user_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -718,7 +812,7 @@ func (x *GetUserByPublicKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserByPublicKeyRequest.ProtoReflect.Descriptor instead.
 func (*GetUserByPublicKeyRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{5}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetUserByPublicKeyRequest) GetPublicKey() []byte {
@@ -738,7 +832,7 @@ type GetUserByPublicKeyResponse struct {
 
 func (x *GetUserByPublicKeyResponse) Reset() {
 	*x = GetUserByPublicKeyResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[6]
+	mi := &file_bib_v1_services_user_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -750,7 +844,7 @@ func (x *GetUserByPublicKeyResponse) String() string {
 func (*GetUserByPublicKeyResponse) ProtoMessage() {}
 
 func (x *GetUserByPublicKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[6]
+	mi := &file_bib_v1_services_user_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,7 +857,7 @@ func (x *GetUserByPublicKeyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserByPublicKeyResponse.ProtoReflect.Descriptor instead.
 func (*GetUserByPublicKeyResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{6}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetUserByPublicKeyResponse) GetUser() *User {
@@ -790,7 +884,7 @@ type ListUsersRequest struct {
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[7]
+	mi := &file_bib_v1_services_user_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -802,7 +896,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[7]
+	mi := &file_bib_v1_services_user_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -815,7 +909,7 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{7}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListUsersRequest) GetStatus() UserStatus {
@@ -857,7 +951,7 @@ type ListUsersResponse struct {
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[8]
+	mi := &file_bib_v1_services_user_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -869,7 +963,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[8]
+	mi := &file_bib_v1_services_user_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -882,7 +976,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{8}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListUsersResponse) GetUsers() []*User {
@@ -916,7 +1010,7 @@ type SearchUsersRequest struct {
 
 func (x *SearchUsersRequest) Reset() {
 	*x = SearchUsersRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[9]
+	mi := &file_bib_v1_services_user_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -928,7 +1022,7 @@ func (x *SearchUsersRequest) String() string {
 func (*SearchUsersRequest) ProtoMessage() {}
 
 func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[9]
+	mi := &file_bib_v1_services_user_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,7 +1035,7 @@ func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
 func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{9}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SearchUsersRequest) GetQuery() string {
@@ -983,7 +1077,7 @@ type SearchUsersResponse struct {
 
 func (x *SearchUsersResponse) Reset() {
 	*x = SearchUsersResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[10]
+	mi := &file_bib_v1_services_user_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -995,7 +1089,7 @@ func (x *SearchUsersResponse) String() string {
 func (*SearchUsersResponse) ProtoMessage() {}
 
 func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[10]
+	mi := &file_bib_v1_services_user_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1008,7 +1102,7 @@ func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
 func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{10}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SearchUsersResponse) GetUsers() []*User {
@@ -1050,7 +1144,7 @@ type CreateUserRequest struct {
 
 func (x *CreateUserRequest) Reset() {
 	*x = CreateUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[11]
+	mi := &file_bib_v1_services_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1062,7 +1156,7 @@ func (x *CreateUserRequest) String() string {
 func (*CreateUserRequest) ProtoMessage() {}
 
 func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[11]
+	mi := &file_bib_v1_services_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1075,7 +1169,7 @@ func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
 func (*CreateUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{11}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CreateUserRequest) GetPublicKey() []byte {
@@ -1144,7 +1238,7 @@ type CreateUserResponse struct {
 
 func (x *CreateUserResponse) Reset() {
 	*x = CreateUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[12]
+	mi := &file_bib_v1_services_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1156,7 +1250,7 @@ func (x *CreateUserResponse) String() string {
 func (*CreateUserResponse) ProtoMessage() {}
 
 func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[12]
+	mi := &file_bib_v1_services_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1169,7 +1263,7 @@ func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
 func (*CreateUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{12}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CreateUserResponse) GetUser() *User {
@@ -1200,7 +1294,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[13]
+	mi := &file_bib_v1_services_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1212,7 +1306,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[13]
+	mi := &file_bib_v1_services_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1225,7 +1319,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{13}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdateUserRequest) GetUserId() string {
@@ -1280,7 +1374,7 @@ type UpdateUserResponse struct {
 
 func (x *UpdateUserResponse) Reset() {
 	*x = UpdateUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[14]
+	mi := &file_bib_v1_services_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1292,7 +1386,7 @@ func (x *UpdateUserResponse) String() string {
 func (*UpdateUserResponse) ProtoMessage() {}
 
 func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[14]
+	mi := &file_bib_v1_services_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1305,7 +1399,7 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{14}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UpdateUserResponse) GetUser() *User {
@@ -1326,7 +1420,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[15]
+	mi := &file_bib_v1_services_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1338,7 +1432,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[15]
+	mi := &file_bib_v1_services_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1351,7 +1445,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{15}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeleteUserRequest) GetUserId() string {
@@ -1371,7 +1465,7 @@ type DeleteUserResponse struct {
 
 func (x *DeleteUserResponse) Reset() {
 	*x = DeleteUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[16]
+	mi := &file_bib_v1_services_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1383,7 +1477,7 @@ func (x *DeleteUserResponse) String() string {
 func (*DeleteUserResponse) ProtoMessage() {}
 
 func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[16]
+	mi := &file_bib_v1_services_user_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1396,7 +1490,7 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{16}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DeleteUserResponse) GetSuccess() bool {
@@ -1417,7 +1511,7 @@ type SuspendUserRequest struct {
 
 func (x *SuspendUserRequest) Reset() {
 	*x = SuspendUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[17]
+	mi := &file_bib_v1_services_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1429,7 +1523,7 @@ func (x *SuspendUserRequest) String() string {
 func (*SuspendUserRequest) ProtoMessage() {}
 
 func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[17]
+	mi := &file_bib_v1_services_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1442,7 +1536,7 @@ func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SuspendUserRequest.ProtoReflect.Descriptor instead.
 func (*SuspendUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{17}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SuspendUserRequest) GetUserId() string {
@@ -1469,7 +1563,7 @@ type SuspendUserResponse struct {
 
 func (x *SuspendUserResponse) Reset() {
 	*x = SuspendUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[18]
+	mi := &file_bib_v1_services_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1481,7 +1575,7 @@ func (x *SuspendUserResponse) String() string {
 func (*SuspendUserResponse) ProtoMessage() {}
 
 func (x *SuspendUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[18]
+	mi := &file_bib_v1_services_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1494,7 +1588,7 @@ func (x *SuspendUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SuspendUserResponse.ProtoReflect.Descriptor instead.
 func (*SuspendUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{18}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SuspendUserResponse) GetUser() *User {
@@ -1514,7 +1608,7 @@ type ActivateUserRequest struct {
 
 func (x *ActivateUserRequest) Reset() {
 	*x = ActivateUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[19]
+	mi := &file_bib_v1_services_user_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1526,7 +1620,7 @@ func (x *ActivateUserRequest) String() string {
 func (*ActivateUserRequest) ProtoMessage() {}
 
 func (x *ActivateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[19]
+	mi := &file_bib_v1_services_user_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1539,7 +1633,7 @@ func (x *ActivateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActivateUserRequest.ProtoReflect.Descriptor instead.
 func (*ActivateUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{19}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ActivateUserRequest) GetUserId() string {
@@ -1559,7 +1653,7 @@ type ActivateUserResponse struct {
 
 func (x *ActivateUserResponse) Reset() {
 	*x = ActivateUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[20]
+	mi := &file_bib_v1_services_user_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1571,7 +1665,7 @@ func (x *ActivateUserResponse) String() string {
 func (*ActivateUserResponse) ProtoMessage() {}
 
 func (x *ActivateUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[20]
+	mi := &file_bib_v1_services_user_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1584,7 +1678,7 @@ func (x *ActivateUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActivateUserResponse.ProtoReflect.Descriptor instead.
 func (*ActivateUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{20}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ActivateUserResponse) GetUser() *User {
@@ -1605,7 +1699,7 @@ type SetUserRoleRequest struct {
 
 func (x *SetUserRoleRequest) Reset() {
 	*x = SetUserRoleRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[21]
+	mi := &file_bib_v1_services_user_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1617,7 +1711,7 @@ func (x *SetUserRoleRequest) String() string {
 func (*SetUserRoleRequest) ProtoMessage() {}
 
 func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[21]
+	mi := &file_bib_v1_services_user_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1630,7 +1724,7 @@ func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
 func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{21}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *SetUserRoleRequest) GetUserId() string {
@@ -1657,7 +1751,7 @@ type SetUserRoleResponse struct {
 
 func (x *SetUserRoleResponse) Reset() {
 	*x = SetUserRoleResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[22]
+	mi := &file_bib_v1_services_user_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1669,7 +1763,7 @@ func (x *SetUserRoleResponse) String() string {
 func (*SetUserRoleResponse) ProtoMessage() {}
 
 func (x *SetUserRoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[22]
+	mi := &file_bib_v1_services_user_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1682,7 +1776,7 @@ func (x *SetUserRoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetUserRoleResponse.ProtoReflect.Descriptor instead.
 func (*SetUserRoleResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{22}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *SetUserRoleResponse) GetUser() *User {
@@ -1701,7 +1795,7 @@ type GetCurrentUserRequest struct {
 
 func (x *GetCurrentUserRequest) Reset() {
 	*x = GetCurrentUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[23]
+	mi := &file_bib_v1_services_user_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1713,7 +1807,7 @@ func (x *GetCurrentUserRequest) String() string {
 func (*GetCurrentUserRequest) ProtoMessage() {}
 
 func (x *GetCurrentUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[23]
+	mi := &file_bib_v1_services_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1726,7 +1820,7 @@ func (x *GetCurrentUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCurrentUserRequest.ProtoReflect.Descriptor instead.
 func (*GetCurrentUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{23}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{24}
 }
 
 // GetCurrentUserResponse contains the current user.
@@ -1739,7 +1833,7 @@ type GetCurrentUserResponse struct {
 
 func (x *GetCurrentUserResponse) Reset() {
 	*x = GetCurrentUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[24]
+	mi := &file_bib_v1_services_user_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1751,7 +1845,7 @@ func (x *GetCurrentUserResponse) String() string {
 func (*GetCurrentUserResponse) ProtoMessage() {}
 
 func (x *GetCurrentUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[24]
+	mi := &file_bib_v1_services_user_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1764,7 +1858,7 @@ func (x *GetCurrentUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCurrentUserResponse.ProtoReflect.Descriptor instead.
 func (*GetCurrentUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{24}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetCurrentUserResponse) GetUser() *User {
@@ -1789,7 +1883,7 @@ type UpdateCurrentUserRequest struct {
 
 func (x *UpdateCurrentUserRequest) Reset() {
 	*x = UpdateCurrentUserRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[25]
+	mi := &file_bib_v1_services_user_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1801,7 +1895,7 @@ func (x *UpdateCurrentUserRequest) String() string {
 func (*UpdateCurrentUserRequest) ProtoMessage() {}
 
 func (x *UpdateCurrentUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[25]
+	mi := &file_bib_v1_services_user_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1814,7 +1908,7 @@ func (x *UpdateCurrentUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCurrentUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateCurrentUserRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{25}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *UpdateCurrentUserRequest) GetName() string {
@@ -1848,7 +1942,7 @@ type UpdateCurrentUserResponse struct {
 
 func (x *UpdateCurrentUserResponse) Reset() {
 	*x = UpdateCurrentUserResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[26]
+	mi := &file_bib_v1_services_user_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1860,7 +1954,7 @@ func (x *UpdateCurrentUserResponse) String() string {
 func (*UpdateCurrentUserResponse) ProtoMessage() {}
 
 func (x *UpdateCurrentUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[26]
+	mi := &file_bib_v1_services_user_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1873,7 +1967,7 @@ func (x *UpdateCurrentUserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateCurrentUserResponse.ProtoReflect.Descriptor instead.
 func (*UpdateCurrentUserResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{26}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *UpdateCurrentUserResponse) GetUser() *User {
@@ -1894,7 +1988,7 @@ type GetUserPreferencesRequest struct {
 
 func (x *GetUserPreferencesRequest) Reset() {
 	*x = GetUserPreferencesRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[27]
+	mi := &file_bib_v1_services_user_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1906,7 +2000,7 @@ func (x *GetUserPreferencesRequest) String() string {
 func (*GetUserPreferencesRequest) ProtoMessage() {}
 
 func (x *GetUserPreferencesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[27]
+	mi := &file_bib_v1_services_user_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1919,7 +2013,7 @@ func (x *GetUserPreferencesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPreferencesRequest.ProtoReflect.Descriptor instead.
 func (*GetUserPreferencesRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{27}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetUserPreferencesRequest) GetUserId() string {
@@ -1939,7 +2033,7 @@ type GetUserPreferencesResponse struct {
 
 func (x *GetUserPreferencesResponse) Reset() {
 	*x = GetUserPreferencesResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[28]
+	mi := &file_bib_v1_services_user_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1951,7 +2045,7 @@ func (x *GetUserPreferencesResponse) String() string {
 func (*GetUserPreferencesResponse) ProtoMessage() {}
 
 func (x *GetUserPreferencesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[28]
+	mi := &file_bib_v1_services_user_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1964,7 +2058,7 @@ func (x *GetUserPreferencesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserPreferencesResponse.ProtoReflect.Descriptor instead.
 func (*GetUserPreferencesResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{28}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetUserPreferencesResponse) GetPreferences() *UserPreferences {
@@ -1987,7 +2081,7 @@ type UpdateUserPreferencesRequest struct {
 
 func (x *UpdateUserPreferencesRequest) Reset() {
 	*x = UpdateUserPreferencesRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[29]
+	mi := &file_bib_v1_services_user_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1999,7 +2093,7 @@ func (x *UpdateUserPreferencesRequest) String() string {
 func (*UpdateUserPreferencesRequest) ProtoMessage() {}
 
 func (x *UpdateUserPreferencesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[29]
+	mi := &file_bib_v1_services_user_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2012,7 +2106,7 @@ func (x *UpdateUserPreferencesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserPreferencesRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserPreferencesRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{29}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *UpdateUserPreferencesRequest) GetUserId() string {
@@ -2039,7 +2133,7 @@ type UpdateUserPreferencesResponse struct {
 
 func (x *UpdateUserPreferencesResponse) Reset() {
 	*x = UpdateUserPreferencesResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[30]
+	mi := &file_bib_v1_services_user_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2051,7 +2145,7 @@ func (x *UpdateUserPreferencesResponse) String() string {
 func (*UpdateUserPreferencesResponse) ProtoMessage() {}
 
 func (x *UpdateUserPreferencesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[30]
+	mi := &file_bib_v1_services_user_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2064,7 +2158,7 @@ func (x *UpdateUserPreferencesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserPreferencesResponse.ProtoReflect.Descriptor instead.
 func (*UpdateUserPreferencesResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{30}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *UpdateUserPreferencesResponse) GetPreferences() *UserPreferences {
@@ -2074,34 +2168,280 @@ func (x *UpdateUserPreferencesResponse) GetPreferences() *UserPreferences {
 	return nil
 }
 
-// ListUserSessionsRequest lists sessions for a user.
-type ListUserSessionsRequest struct {
+// CLIPreferences are bib CLI settings synced across a user's machines.
+type CLIPreferences struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// User ID (empty = current user).
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	// Include expired sessions.
-	IncludeExpired bool `protobuf:"varint,2,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
-	// Pagination.
-	Page          *v1.PageRequest `protobuf:"bytes,3,opt,name=page,proto3" json:"page,omitempty"`
+	// Output format: "table", "json", "yaml", "text" (empty = not set).
+	OutputFormat string `protobuf:"bytes,1,opt,name=output_format,json=outputFormat,proto3" json:"output_format,omitempty"`
+	// Colored output (unset = not set).
+	Color *bool `protobuf:"varint,2,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	// UI locale (empty = not set).
+	Locale string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	// When the preferences were last stored.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUserSessionsRequest) Reset() {
-	*x = ListUserSessionsRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[31]
+func (x *CLIPreferences) Reset() {
+	*x = CLIPreferences{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUserSessionsRequest) String() string {
+func (x *CLIPreferences) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUserSessionsRequest) ProtoMessage() {}
+func (*CLIPreferences) ProtoMessage() {}
 
-func (x *ListUserSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[31]
+func (x *CLIPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CLIPreferences.ProtoReflect.Descriptor instead.
+func (*CLIPreferences) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CLIPreferences) GetOutputFormat() string {
+	if x != nil {
+		return x.OutputFormat
+	}
+	return ""
+}
+
+func (x *CLIPreferences) GetColor() bool {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return false
+}
+
+func (x *CLIPreferences) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *CLIPreferences) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// GetPreferencesRequest gets the current user's CLI preferences.
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{33}
+}
+
+// GetPreferencesResponse contains the CLI preferences.
+type GetPreferencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *CLIPreferences        `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesResponse) Reset() {
+	*x = GetPreferencesResponse{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesResponse) ProtoMessage() {}
+
+func (x *GetPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*GetPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetPreferencesResponse) GetPreferences() *CLIPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// SetPreferencesRequest stores CLI preferences. Fields that are not set are
+// left unchanged.
+type SetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *CLIPreferences        `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPreferencesRequest) Reset() {
+	*x = SetPreferencesRequest{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPreferencesRequest) ProtoMessage() {}
+
+func (x *SetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*SetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetPreferencesRequest) GetPreferences() *CLIPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// SetPreferencesResponse contains the stored CLI preferences.
+type SetPreferencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *CLIPreferences        `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPreferencesResponse) Reset() {
+	*x = SetPreferencesResponse{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPreferencesResponse) ProtoMessage() {}
+
+func (x *SetPreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPreferencesResponse.ProtoReflect.Descriptor instead.
+func (*SetPreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetPreferencesResponse) GetPreferences() *CLIPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// ListUserSessionsRequest lists sessions for a user.
+type ListUserSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (empty = current user).
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Include expired sessions.
+	IncludeExpired bool `protobuf:"varint,2,opt,name=include_expired,json=includeExpired,proto3" json:"include_expired,omitempty"`
+	// Pagination.
+	Page          *v1.PageRequest `protobuf:"bytes,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserSessionsRequest) Reset() {
+	*x = ListUserSessionsRequest{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserSessionsRequest) ProtoMessage() {}
+
+func (x *ListUserSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2114,7 +2454,7 @@ func (x *ListUserSessionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUserSessionsRequest.ProtoReflect.Descriptor instead.
 func (*ListUserSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{31}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ListUserSessionsRequest) GetUserId() string {
@@ -2149,7 +2489,7 @@ type ListUserSessionsResponse struct {
 
 func (x *ListUserSessionsResponse) Reset() {
 	*x = ListUserSessionsResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[32]
+	mi := &file_bib_v1_services_user_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2161,7 +2501,7 @@ func (x *ListUserSessionsResponse) String() string {
 func (*ListUserSessionsResponse) ProtoMessage() {}
 
 func (x *ListUserSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[32]
+	mi := &file_bib_v1_services_user_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2174,7 +2514,7 @@ func (x *ListUserSessionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUserSessionsResponse.ProtoReflect.Descriptor instead.
 func (*ListUserSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{32}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ListUserSessionsResponse) GetSessions() []*Session {
@@ -2201,7 +2541,7 @@ type EndUserSessionRequest struct {
 
 func (x *EndUserSessionRequest) Reset() {
 	*x = EndUserSessionRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[33]
+	mi := &file_bib_v1_services_user_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2213,7 +2553,7 @@ func (x *EndUserSessionRequest) String() string {
 func (*EndUserSessionRequest) ProtoMessage() {}
 
 func (x *EndUserSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[33]
+	mi := &file_bib_v1_services_user_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2226,7 +2566,7 @@ func (x *EndUserSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EndUserSessionRequest.ProtoReflect.Descriptor instead.
 func (*EndUserSessionRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{33}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *EndUserSessionRequest) GetSessionId() string {
@@ -2246,7 +2586,7 @@ type EndUserSessionResponse struct {
 
 func (x *EndUserSessionResponse) Reset() {
 	*x = EndUserSessionResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[34]
+	mi := &file_bib_v1_services_user_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2258,7 +2598,7 @@ func (x *EndUserSessionResponse) String() string {
 func (*EndUserSessionResponse) ProtoMessage() {}
 
 func (x *EndUserSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[34]
+	mi := &file_bib_v1_services_user_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2271,7 +2611,7 @@ func (x *EndUserSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EndUserSessionResponse.ProtoReflect.Descriptor instead.
 func (*EndUserSessionResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{34}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *EndUserSessionResponse) GetSuccess() bool {
@@ -2291,7 +2631,7 @@ type EndAllUserSessionsRequest struct {
 
 func (x *EndAllUserSessionsRequest) Reset() {
 	*x = EndAllUserSessionsRequest{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[35]
+	mi := &file_bib_v1_services_user_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2303,7 +2643,7 @@ func (x *EndAllUserSessionsRequest) String() string {
 func (*EndAllUserSessionsRequest) ProtoMessage() {}
 
 func (x *EndAllUserSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[35]
+	mi := &file_bib_v1_services_user_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2316,7 +2656,7 @@ func (x *EndAllUserSessionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EndAllUserSessionsRequest.ProtoReflect.Descriptor instead.
 func (*EndAllUserSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{35}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *EndAllUserSessionsRequest) GetUserId() string {
@@ -2336,7 +2676,7 @@ type EndAllUserSessionsResponse struct {
 
 func (x *EndAllUserSessionsResponse) Reset() {
 	*x = EndAllUserSessionsResponse{}
-	mi := &file_bib_v1_services_user_proto_msgTypes[36]
+	mi := &file_bib_v1_services_user_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2348,7 +2688,7 @@ func (x *EndAllUserSessionsResponse) String() string {
 func (*EndAllUserSessionsResponse) ProtoMessage() {}
 
 func (x *EndAllUserSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bib_v1_services_user_proto_msgTypes[36]
+	mi := &file_bib_v1_services_user_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2361,7 +2701,7 @@ func (x *EndAllUserSessionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EndAllUserSessionsResponse.ProtoReflect.Descriptor instead.
 func (*EndAllUserSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{36}
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *EndAllUserSessionsResponse) GetEndedCount() int32 {
@@ -2371,6 +2711,324 @@ func (x *EndAllUserSessionsResponse) GetEndedCount() int32 {
 	return 0
 }
 
+// AddPublicKeyRequest registers an additional identity key.
+type AddPublicKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (empty = current user).
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Public key bytes (Ed25519 or RSA).
+	PublicKey []byte `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Key type: "ed25519" or "rsa".
+	KeyType string `protobuf:"bytes,3,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	// Device label, unique per user.
+	Label         string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPublicKeyRequest) Reset() {
+	*x = AddPublicKeyRequest{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPublicKeyRequest) ProtoMessage() {}
+
+func (x *AddPublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*AddPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AddPublicKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddPublicKeyRequest) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *AddPublicKeyRequest) GetKeyType() string {
+	if x != nil {
+		return x.KeyType
+	}
+	return ""
+}
+
+func (x *AddPublicKeyRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// AddPublicKeyResponse contains the registered key.
+type AddPublicKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *UserPublicKey         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddPublicKeyResponse) Reset() {
+	*x = AddPublicKeyResponse{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddPublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPublicKeyResponse) ProtoMessage() {}
+
+func (x *AddPublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*AddPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AddPublicKeyResponse) GetKey() *UserPublicKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+// ListPublicKeysRequest lists a user's identity keys.
+type ListPublicKeysRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (empty = current user).
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicKeysRequest) Reset() {
+	*x = ListPublicKeysRequest{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicKeysRequest) ProtoMessage() {}
+
+func (x *ListPublicKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListPublicKeysRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListPublicKeysRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// ListPublicKeysResponse contains the keys, primary key first.
+type ListPublicKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*UserPublicKey       `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPublicKeysResponse) Reset() {
+	*x = ListPublicKeysResponse{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPublicKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPublicKeysResponse) ProtoMessage() {}
+
+func (x *ListPublicKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPublicKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListPublicKeysResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListPublicKeysResponse) GetKeys() []*UserPublicKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// RemovePublicKeyRequest revokes an identity key.
+type RemovePublicKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// User ID (empty = current user).
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Fingerprint of the key to remove.
+	Fingerprint   string `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePublicKeyRequest) Reset() {
+	*x = RemovePublicKeyRequest{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePublicKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePublicKeyRequest) ProtoMessage() {}
+
+func (x *RemovePublicKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePublicKeyRequest.ProtoReflect.Descriptor instead.
+func (*RemovePublicKeyRequest) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RemovePublicKeyRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemovePublicKeyRequest) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+// RemovePublicKeyResponse confirms the key was removed.
+type RemovePublicKeyResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Number of sessions authenticated with the key that were ended.
+	SessionsEnded int32 `protobuf:"varint,2,opt,name=sessions_ended,json=sessionsEnded,proto3" json:"sessions_ended,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePublicKeyResponse) Reset() {
+	*x = RemovePublicKeyResponse{}
+	mi := &file_bib_v1_services_user_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePublicKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePublicKeyResponse) ProtoMessage() {}
+
+func (x *RemovePublicKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bib_v1_services_user_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePublicKeyResponse.ProtoReflect.Descriptor instead.
+func (*RemovePublicKeyResponse) Descriptor() ([]byte, []int) {
+	return file_bib_v1_services_user_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RemovePublicKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemovePublicKeyResponse) GetSessionsEnded() int32 {
+	if x != nil {
+		return x.SessionsEnded
+	}
+	return 0
+}
+
 var File_bib_v1_services_user_proto protoreflect.FileDescriptor
 
 const file_bib_v1_services_user_proto_rawDesc = "" +
@@ -2415,7 +3073,16 @@ const file_bib_v1_services_user_proto_rawDesc = "" +
 	"\bmetadata\x18\f \x03(\v2&.bib.v1.services.Session.MetadataEntryR\bmetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfc\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd6\x01\n" +
+	"\rUserPublicKey\x12 \n" +
+	"\vfingerprint\x18\x01 \x01(\tR\vfingerprint\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x19\n" +
+	"\bkey_type\x18\x03 \x01(\tR\akeyType\x12\x14\n" +
+	"\x05label\x18\x04 \x01(\tR\x05label\x12\x18\n" +
+	"\aprimary\x18\x05 \x01(\bR\aprimary\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xfc\x02\n" +
 	"\x0fUserPreferences\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05theme\x18\x02 \x01(\tR\x05theme\x12\x16\n" +
@@ -2522,7 +3189,21 @@ const file_bib_v1_services_user_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12B\n" +
 	"\vpreferences\x18\x02 \x01(\v2 .bib.v1.services.UserPreferencesR\vpreferences\"c\n" +
 	"\x1dUpdateUserPreferencesResponse\x12B\n" +
-	"\vpreferences\x18\x01 \x01(\v2 .bib.v1.services.UserPreferencesR\vpreferences\"\x84\x01\n" +
+	"\vpreferences\x18\x01 \x01(\v2 .bib.v1.services.UserPreferencesR\vpreferences\"\xad\x01\n" +
+	"\x0eCLIPreferences\x12#\n" +
+	"\routput_format\x18\x01 \x01(\tR\foutputFormat\x12\x19\n" +
+	"\x05color\x18\x02 \x01(\bH\x00R\x05color\x88\x01\x01\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\b\n" +
+	"\x06_color\"\x17\n" +
+	"\x15GetPreferencesRequest\"[\n" +
+	"\x16GetPreferencesResponse\x12A\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1f.bib.v1.services.CLIPreferencesR\vpreferences\"Z\n" +
+	"\x15SetPreferencesRequest\x12A\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1f.bib.v1.services.CLIPreferencesR\vpreferences\"[\n" +
+	"\x16SetPreferencesResponse\x12A\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1f.bib.v1.services.CLIPreferencesR\vpreferences\"\x84\x01\n" +
 	"\x17ListUserSessionsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
 	"\x0finclude_expired\x18\x02 \x01(\bR\x0eincludeExpired\x12'\n" +
@@ -2539,7 +3220,25 @@ const file_bib_v1_services_user_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"=\n" +
 	"\x1aEndAllUserSessionsResponse\x12\x1f\n" +
 	"\vended_count\x18\x01 \x01(\x05R\n" +
-	"endedCount*\x8e\x01\n" +
+	"endedCount\"~\n" +
+	"\x13AddPublicKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x19\n" +
+	"\bkey_type\x18\x03 \x01(\tR\akeyType\x12\x14\n" +
+	"\x05label\x18\x04 \x01(\tR\x05label\"H\n" +
+	"\x14AddPublicKeyResponse\x120\n" +
+	"\x03key\x18\x01 \x01(\v2\x1e.bib.v1.services.UserPublicKeyR\x03key\"0\n" +
+	"\x15ListPublicKeysRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"L\n" +
+	"\x16ListPublicKeysResponse\x122\n" +
+	"\x04keys\x18\x01 \x03(\v2\x1e.bib.v1.services.UserPublicKeyR\x04keys\"S\n" +
+	"\x16RemovePublicKeyRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12 \n" +
+	"\vfingerprint\x18\x02 \x01(\tR\vfingerprint\"Z\n" +
+	"\x17RemovePublicKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12%\n" +
+	"\x0esessions_ended\x18\x02 \x01(\x05R\rsessionsEnded*\x8e\x01\n" +
 	"\n" +
 	"UserStatus\x12\x1b\n" +
 	"\x17USER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
@@ -2556,7 +3255,7 @@ const file_bib_v1_services_user_proto_rawDesc = "" +
 	"\x18SESSION_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10SESSION_TYPE_SSH\x10\x01\x12\x15\n" +
 	"\x11SESSION_TYPE_GRPC\x10\x02\x12\x14\n" +
-	"\x10SESSION_TYPE_API\x10\x032\xff\f\n" +
+	"\x10SESSION_TYPE_API\x10\x032\xeb\x10\n" +
 	"\vUserService\x12L\n" +
 	"\aGetUser\x12\x1f.bib.v1.services.GetUserRequest\x1a .bib.v1.services.GetUserResponse\x12m\n" +
 	"\x12GetUserByPublicKey\x12*.bib.v1.services.GetUserByPublicKeyRequest\x1a+.bib.v1.services.GetUserByPublicKeyResponse\x12R\n" +
@@ -2574,10 +3273,15 @@ const file_bib_v1_services_user_proto_rawDesc = "" +
 	"\x0eGetCurrentUser\x12&.bib.v1.services.GetCurrentUserRequest\x1a'.bib.v1.services.GetCurrentUserResponse\x12j\n" +
 	"\x11UpdateCurrentUser\x12).bib.v1.services.UpdateCurrentUserRequest\x1a*.bib.v1.services.UpdateCurrentUserResponse\x12m\n" +
 	"\x12GetUserPreferences\x12*.bib.v1.services.GetUserPreferencesRequest\x1a+.bib.v1.services.GetUserPreferencesResponse\x12v\n" +
-	"\x15UpdateUserPreferences\x12-.bib.v1.services.UpdateUserPreferencesRequest\x1a..bib.v1.services.UpdateUserPreferencesResponse\x12g\n" +
+	"\x15UpdateUserPreferences\x12-.bib.v1.services.UpdateUserPreferencesRequest\x1a..bib.v1.services.UpdateUserPreferencesResponse\x12a\n" +
+	"\x0eGetPreferences\x12&.bib.v1.services.GetPreferencesRequest\x1a'.bib.v1.services.GetPreferencesResponse\x12a\n" +
+	"\x0eSetPreferences\x12&.bib.v1.services.SetPreferencesRequest\x1a'.bib.v1.services.SetPreferencesResponse\x12g\n" +
 	"\x10ListUserSessions\x12(.bib.v1.services.ListUserSessionsRequest\x1a).bib.v1.services.ListUserSessionsResponse\x12a\n" +
 	"\x0eEndUserSession\x12&.bib.v1.services.EndUserSessionRequest\x1a'.bib.v1.services.EndUserSessionResponse\x12m\n" +
-	"\x12EndAllUserSessions\x12*.bib.v1.services.EndAllUserSessionsRequest\x1a+.bib.v1.services.EndAllUserSessionsResponseB\x9e\x01\n" +
+	"\x12EndAllUserSessions\x12*.bib.v1.services.EndAllUserSessionsRequest\x1a+.bib.v1.services.EndAllUserSessionsResponse\x12[\n" +
+	"\fAddPublicKey\x12$.bib.v1.services.AddPublicKeyRequest\x1a%.bib.v1.services.AddPublicKeyResponse\x12a\n" +
+	"\x0eListPublicKeys\x12&.bib.v1.services.ListPublicKeysRequest\x1a'.bib.v1.services.ListPublicKeysResponse\x12d\n" +
+	"\x0fRemovePublicKey\x12'.bib.v1.services.RemovePublicKeyRequest\x1a(.bib.v1.services.RemovePublicKeyResponseB\x9e\x01\n" +
 	"\x13com.bib.v1.servicesB\tUserProtoP\x01Z\x1ebib/api/gen/go/bib/v1/services\xa2\x02\x03BVS\xaa\x02\x0fBib.V1.Services\xca\x02\x0fBib\\V1\\Services\xe2\x02\x1bBib\\V1\\Services\\GPBMetadata\xea\x02\x11Bib::V1::Servicesb\x06proto3"
 
 var (
@@ -2593,142 +3297,171 @@ func file_bib_v1_services_user_proto_rawDescGZIP() []byte {
 }
 
 var file_bib_v1_services_user_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_bib_v1_services_user_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_bib_v1_services_user_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_bib_v1_services_user_proto_goTypes = []any{
 	(UserStatus)(0),                       // 0: bib.v1.services.UserStatus
 	(UserRole)(0),                         // 1: bib.v1.services.UserRole
 	(SessionType)(0),                      // 2: bib.v1.services.SessionType
 	(*User)(nil),                          // 3: bib.v1.services.User
 	(*Session)(nil),                       // 4: bib.v1.services.Session
-	(*UserPreferences)(nil),               // 5: bib.v1.services.UserPreferences
-	(*GetUserRequest)(nil),                // 6: bib.v1.services.GetUserRequest
-	(*GetUserResponse)(nil),               // 7: bib.v1.services.GetUserResponse
-	(*GetUserByPublicKeyRequest)(nil),     // 8: bib.v1.services.GetUserByPublicKeyRequest
-	(*GetUserByPublicKeyResponse)(nil),    // 9: bib.v1.services.GetUserByPublicKeyResponse
-	(*ListUsersRequest)(nil),              // 10: bib.v1.services.ListUsersRequest
-	(*ListUsersResponse)(nil),             // 11: bib.v1.services.ListUsersResponse
-	(*SearchUsersRequest)(nil),            // 12: bib.v1.services.SearchUsersRequest
-	(*SearchUsersResponse)(nil),           // 13: bib.v1.services.SearchUsersResponse
-	(*CreateUserRequest)(nil),             // 14: bib.v1.services.CreateUserRequest
-	(*CreateUserResponse)(nil),            // 15: bib.v1.services.CreateUserResponse
-	(*UpdateUserRequest)(nil),             // 16: bib.v1.services.UpdateUserRequest
-	(*UpdateUserResponse)(nil),            // 17: bib.v1.services.UpdateUserResponse
-	(*DeleteUserRequest)(nil),             // 18: bib.v1.services.DeleteUserRequest
-	(*DeleteUserResponse)(nil),            // 19: bib.v1.services.DeleteUserResponse
-	(*SuspendUserRequest)(nil),            // 20: bib.v1.services.SuspendUserRequest
-	(*SuspendUserResponse)(nil),           // 21: bib.v1.services.SuspendUserResponse
-	(*ActivateUserRequest)(nil),           // 22: bib.v1.services.ActivateUserRequest
-	(*ActivateUserResponse)(nil),          // 23: bib.v1.services.ActivateUserResponse
-	(*SetUserRoleRequest)(nil),            // 24: bib.v1.services.SetUserRoleRequest
-	(*SetUserRoleResponse)(nil),           // 25: bib.v1.services.SetUserRoleResponse
-	(*GetCurrentUserRequest)(nil),         // 26: bib.v1.services.GetCurrentUserRequest
-	(*GetCurrentUserResponse)(nil),        // 27: bib.v1.services.GetCurrentUserResponse
-	(*UpdateCurrentUserRequest)(nil),      // 28: bib.v1.services.UpdateCurrentUserRequest
-	(*UpdateCurrentUserResponse)(nil),     // 29: bib.v1.services.UpdateCurrentUserResponse
-	(*GetUserPreferencesRequest)(nil),     // 30: bib.v1.services.GetUserPreferencesRequest
-	(*GetUserPreferencesResponse)(nil),    // 31: bib.v1.services.GetUserPreferencesResponse
-	(*UpdateUserPreferencesRequest)(nil),  // 32: bib.v1.services.UpdateUserPreferencesRequest
-	(*UpdateUserPreferencesResponse)(nil), // 33: bib.v1.services.UpdateUserPreferencesResponse
-	(*ListUserSessionsRequest)(nil),       // 34: bib.v1.services.ListUserSessionsRequest
-	(*ListUserSessionsResponse)(nil),      // 35: bib.v1.services.ListUserSessionsResponse
-	(*EndUserSessionRequest)(nil),         // 36: bib.v1.services.EndUserSessionRequest
-	(*EndUserSessionResponse)(nil),        // 37: bib.v1.services.EndUserSessionResponse
-	(*EndAllUserSessionsRequest)(nil),     // 38: bib.v1.services.EndAllUserSessionsRequest
-	(*EndAllUserSessionsResponse)(nil),    // 39: bib.v1.services.EndAllUserSessionsResponse
-	nil,                                   // 40: bib.v1.services.User.MetadataEntry
-	nil,                                   // 41: bib.v1.services.Session.MetadataEntry
-	nil,                                   // 42: bib.v1.services.UserPreferences.CustomEntry
-	nil,                                   // 43: bib.v1.services.CreateUserRequest.MetadataEntry
-	nil,                                   // 44: bib.v1.services.UpdateUserRequest.MetadataEntry
-	(*timestamppb.Timestamp)(nil),         // 45: google.protobuf.Timestamp
-	(*v1.PageRequest)(nil),                // 46: bib.v1.PageRequest
-	(*v1.SortOrder)(nil),                  // 47: bib.v1.SortOrder
-	(*v1.PageInfo)(nil),                   // 48: bib.v1.PageInfo
+	(*UserPublicKey)(nil),                 // 5: bib.v1.services.UserPublicKey
+	(*UserPreferences)(nil),               // 6: bib.v1.services.UserPreferences
+	(*GetUserRequest)(nil),                // 7: bib.v1.services.GetUserRequest
+	(*GetUserResponse)(nil),               // 8: bib.v1.services.GetUserResponse
+	(*GetUserByPublicKeyRequest)(nil),     // 9: bib.v1.services.GetUserByPublicKeyRequest
+	(*GetUserByPublicKeyResponse)(nil),    // 10: bib.v1.services.GetUserByPublicKeyResponse
+	(*ListUsersRequest)(nil),              // 11: bib.v1.services.ListUsersRequest
+	(*ListUsersResponse)(nil),             // 12: bib.v1.services.ListUsersResponse
+	(*SearchUsersRequest)(nil),            // 13: bib.v1.services.SearchUsersRequest
+	(*SearchUsersResponse)(nil),           // 14: bib.v1.services.SearchUsersResponse
+	(*CreateUserRequest)(nil),             // 15: bib.v1.services.CreateUserRequest
+	(*CreateUserResponse)(nil),            // 16: bib.v1.services.CreateUserResponse
+	(*UpdateUserRequest)(nil),             // 17: bib.v1.services.UpdateUserRequest
+	(*UpdateUserResponse)(nil),            // 18: bib.v1.services.UpdateUserResponse
+	(*DeleteUserRequest)(nil),             // 19: bib.v1.services.DeleteUserRequest
+	(*DeleteUserResponse)(nil),            // 20: bib.v1.services.DeleteUserResponse
+	(*SuspendUserRequest)(nil),            // 21: bib.v1.services.SuspendUserRequest
+	(*SuspendUserResponse)(nil),           // 22: bib.v1.services.SuspendUserResponse
+	(*ActivateUserRequest)(nil),           // 23: bib.v1.services.ActivateUserRequest
+	(*ActivateUserResponse)(nil),          // 24: bib.v1.services.ActivateUserResponse
+	(*SetUserRoleRequest)(nil),            // 25: bib.v1.services.SetUserRoleRequest
+	(*SetUserRoleResponse)(nil),           // 26: bib.v1.services.SetUserRoleResponse
+	(*GetCurrentUserRequest)(nil),         // 27: bib.v1.services.GetCurrentUserRequest
+	(*GetCurrentUserResponse)(nil),        // 28: bib.v1.services.GetCurrentUserResponse
+	(*UpdateCurrentUserRequest)(nil),      // 29: bib.v1.services.UpdateCurrentUserRequest
+	(*UpdateCurrentUserResponse)(nil),     // 30: bib.v1.services.UpdateCurrentUserResponse
+	(*GetUserPreferencesRequest)(nil),     // 31: bib.v1.services.GetUserPreferencesRequest
+	(*GetUserPreferencesResponse)(nil),    // 32: bib.v1.services.GetUserPreferencesResponse
+	(*UpdateUserPreferencesRequest)(nil),  // 33: bib.v1.services.UpdateUserPreferencesRequest
+	(*UpdateUserPreferencesResponse)(nil), // 34: bib.v1.services.UpdateUserPreferencesResponse
+	(*CLIPreferences)(nil),                // 35: bib.v1.services.CLIPreferences
+	(*GetPreferencesRequest)(nil),         // 36: bib.v1.services.GetPreferencesRequest
+	(*GetPreferencesResponse)(nil),        // 37: bib.v1.services.GetPreferencesResponse
+	(*SetPreferencesRequest)(nil),         // 38: bib.v1.services.SetPreferencesRequest
+	(*SetPreferencesResponse)(nil),        // 39: bib.v1.services.SetPreferencesResponse
+	(*ListUserSessionsRequest)(nil),       // 40: bib.v1.services.ListUserSessionsRequest
+	(*ListUserSessionsResponse)(nil),      // 41: bib.v1.services.ListUserSessionsResponse
+	(*EndUserSessionRequest)(nil),         // 42: bib.v1.services.EndUserSessionRequest
+	(*EndUserSessionResponse)(nil),        // 43: bib.v1.services.EndUserSessionResponse
+	(*EndAllUserSessionsRequest)(nil),     // 44: bib.v1.services.EndAllUserSessionsRequest
+	(*EndAllUserSessionsResponse)(nil),    // 45: bib.v1.services.EndAllUserSessionsResponse
+	(*AddPublicKeyRequest)(nil),           // 46: bib.v1.services.AddPublicKeyRequest
+	(*AddPublicKeyResponse)(nil),          // 47: bib.v1.services.AddPublicKeyResponse
+	(*ListPublicKeysRequest)(nil),         // 48: bib.v1.services.ListPublicKeysRequest
+	(*ListPublicKeysResponse)(nil),        // 49: bib.v1.services.ListPublicKeysResponse
+	(*RemovePublicKeyRequest)(nil),        // 50: bib.v1.services.RemovePublicKeyRequest
+	(*RemovePublicKeyResponse)(nil),       // 51: bib.v1.services.RemovePublicKeyResponse
+	nil,                                   // 52: bib.v1.services.User.MetadataEntry
+	nil,                                   // 53: bib.v1.services.Session.MetadataEntry
+	nil,                                   // 54: bib.v1.services.UserPreferences.CustomEntry
+	nil,                                   // 55: bib.v1.services.CreateUserRequest.MetadataEntry
+	nil,                                   // 56: bib.v1.services.UpdateUserRequest.MetadataEntry
+	(*timestamppb.Timestamp)(nil),         // 57: google.protobuf.Timestamp
+	(*v1.PageRequest)(nil),                // 58: bib.v1.PageRequest
+	(*v1.SortOrder)(nil),                  // 59: bib.v1.SortOrder
+	(*v1.PageInfo)(nil),                   // 60: bib.v1.PageInfo
 }
 var file_bib_v1_services_user_proto_depIdxs = []int32{
 	0,  // 0: bib.v1.services.User.status:type_name -> bib.v1.services.UserStatus
 	1,  // 1: bib.v1.services.User.role:type_name -> bib.v1.services.UserRole
-	45, // 2: bib.v1.services.User.created_at:type_name -> google.protobuf.Timestamp
-	45, // 3: bib.v1.services.User.updated_at:type_name -> google.protobuf.Timestamp
-	45, // 4: bib.v1.services.User.last_login_at:type_name -> google.protobuf.Timestamp
-	40, // 5: bib.v1.services.User.metadata:type_name -> bib.v1.services.User.MetadataEntry
+	57, // 2: bib.v1.services.User.created_at:type_name -> google.protobuf.Timestamp
+	57, // 3: bib.v1.services.User.updated_at:type_name -> google.protobuf.Timestamp
+	57, // 4: bib.v1.services.User.last_login_at:type_name -> google.protobuf.Timestamp
+	52, // 5: bib.v1.services.User.metadata:type_name -> bib.v1.services.User.MetadataEntry
 	2,  // 6: bib.v1.services.Session.type:type_name -> bib.v1.services.SessionType
-	45, // 7: bib.v1.services.Session.started_at:type_name -> google.protobuf.Timestamp
-	45, // 8: bib.v1.services.Session.ended_at:type_name -> google.protobuf.Timestamp
-	45, // 9: bib.v1.services.Session.expires_at:type_name -> google.protobuf.Timestamp
-	45, // 10: bib.v1.services.Session.last_activity_at:type_name -> google.protobuf.Timestamp
-	41, // 11: bib.v1.services.Session.metadata:type_name -> bib.v1.services.Session.MetadataEntry
-	42, // 12: bib.v1.services.UserPreferences.custom:type_name -> bib.v1.services.UserPreferences.CustomEntry
-	3,  // 13: bib.v1.services.GetUserResponse.user:type_name -> bib.v1.services.User
-	3,  // 14: bib.v1.services.GetUserByPublicKeyResponse.user:type_name -> bib.v1.services.User
-	0,  // 15: bib.v1.services.ListUsersRequest.status:type_name -> bib.v1.services.UserStatus
-	1,  // 16: bib.v1.services.ListUsersRequest.role:type_name -> bib.v1.services.UserRole
-	46, // 17: bib.v1.services.ListUsersRequest.page:type_name -> bib.v1.PageRequest
-	47, // 18: bib.v1.services.ListUsersRequest.sort:type_name -> bib.v1.SortOrder
-	3,  // 19: bib.v1.services.ListUsersResponse.users:type_name -> bib.v1.services.User
-	48, // 20: bib.v1.services.ListUsersResponse.page_info:type_name -> bib.v1.PageInfo
-	0,  // 21: bib.v1.services.SearchUsersRequest.status:type_name -> bib.v1.services.UserStatus
-	1,  // 22: bib.v1.services.SearchUsersRequest.role:type_name -> bib.v1.services.UserRole
-	46, // 23: bib.v1.services.SearchUsersRequest.page:type_name -> bib.v1.PageRequest
-	3,  // 24: bib.v1.services.SearchUsersResponse.users:type_name -> bib.v1.services.User
-	48, // 25: bib.v1.services.SearchUsersResponse.page_info:type_name -> bib.v1.PageInfo
-	1,  // 26: bib.v1.services.CreateUserRequest.role:type_name -> bib.v1.services.UserRole
-	0,  // 27: bib.v1.services.CreateUserRequest.status:type_name -> bib.v1.services.UserStatus
-	43, // 28: bib.v1.services.CreateUserRequest.metadata:type_name -> bib.v1.services.CreateUserRequest.MetadataEntry
-	3,  // 29: bib.v1.services.CreateUserResponse.user:type_name -> bib.v1.services.User
-	44, // 30: bib.v1.services.UpdateUserRequest.metadata:type_name -> bib.v1.services.UpdateUserRequest.MetadataEntry
-	3,  // 31: bib.v1.services.UpdateUserResponse.user:type_name -> bib.v1.services.User
-	3,  // 32: bib.v1.services.SuspendUserResponse.user:type_name -> bib.v1.services.User
-	3,  // 33: bib.v1.services.ActivateUserResponse.user:type_name -> bib.v1.services.User
-	1,  // 34: bib.v1.services.SetUserRoleRequest.role:type_name -> bib.v1.services.UserRole
-	3,  // 35: bib.v1.services.SetUserRoleResponse.user:type_name -> bib.v1.services.User
-	3,  // 36: bib.v1.services.GetCurrentUserResponse.user:type_name -> bib.v1.services.User
-	3,  // 37: bib.v1.services.UpdateCurrentUserResponse.user:type_name -> bib.v1.services.User
-	5,  // 38: bib.v1.services.GetUserPreferencesResponse.preferences:type_name -> bib.v1.services.UserPreferences
-	5,  // 39: bib.v1.services.UpdateUserPreferencesRequest.preferences:type_name -> bib.v1.services.UserPreferences
-	5,  // 40: bib.v1.services.UpdateUserPreferencesResponse.preferences:type_name -> bib.v1.services.UserPreferences
-	46, // 41: bib.v1.services.ListUserSessionsRequest.page:type_name -> bib.v1.PageRequest
-	4,  // 42: bib.v1.services.ListUserSessionsResponse.sessions:type_name -> bib.v1.services.Session
-	48, // 43: bib.v1.services.ListUserSessionsResponse.page_info:type_name -> bib.v1.PageInfo
-	6,  // 44: bib.v1.services.UserService.GetUser:input_type -> bib.v1.services.GetUserRequest
-	8,  // 45: bib.v1.services.UserService.GetUserByPublicKey:input_type -> bib.v1.services.GetUserByPublicKeyRequest
-	10, // 46: bib.v1.services.UserService.ListUsers:input_type -> bib.v1.services.ListUsersRequest
-	12, // 47: bib.v1.services.UserService.SearchUsers:input_type -> bib.v1.services.SearchUsersRequest
-	14, // 48: bib.v1.services.UserService.CreateUser:input_type -> bib.v1.services.CreateUserRequest
-	16, // 49: bib.v1.services.UserService.UpdateUser:input_type -> bib.v1.services.UpdateUserRequest
-	18, // 50: bib.v1.services.UserService.DeleteUser:input_type -> bib.v1.services.DeleteUserRequest
-	20, // 51: bib.v1.services.UserService.SuspendUser:input_type -> bib.v1.services.SuspendUserRequest
-	22, // 52: bib.v1.services.UserService.ActivateUser:input_type -> bib.v1.services.ActivateUserRequest
-	24, // 53: bib.v1.services.UserService.SetUserRole:input_type -> bib.v1.services.SetUserRoleRequest
-	26, // 54: bib.v1.services.UserService.GetCurrentUser:input_type -> bib.v1.services.GetCurrentUserRequest
-	28, // 55: bib.v1.services.UserService.UpdateCurrentUser:input_type -> bib.v1.services.UpdateCurrentUserRequest
-	30, // 56: bib.v1.services.UserService.GetUserPreferences:input_type -> bib.v1.services.GetUserPreferencesRequest
-	32, // 57: bib.v1.services.UserService.UpdateUserPreferences:input_type -> bib.v1.services.UpdateUserPreferencesRequest
-	34, // 58: bib.v1.services.UserService.ListUserSessions:input_type -> bib.v1.services.ListUserSessionsRequest
-	36, // 59: bib.v1.services.UserService.EndUserSession:input_type -> bib.v1.services.EndUserSessionRequest
-	38, // 60: bib.v1.services.UserService.EndAllUserSessions:input_type -> bib.v1.services.EndAllUserSessionsRequest
-	7,  // 61: bib.v1.services.UserService.GetUser:output_type -> bib.v1.services.GetUserResponse
-	9,  // 62: bib.v1.services.UserService.GetUserByPublicKey:output_type -> bib.v1.services.GetUserByPublicKeyResponse
-	11, // 63: bib.v1.services.UserService.ListUsers:output_type -> bib.v1.services.ListUsersResponse
-	13, // 64: bib.v1.services.UserService.SearchUsers:output_type -> bib.v1.services.SearchUsersResponse
-	15, // 65: bib.v1.services.UserService.CreateUser:output_type -> bib.v1.services.CreateUserResponse
-	17, // 66: bib.v1.services.UserService.UpdateUser:output_type -> bib.v1.services.UpdateUserResponse
-	19, // 67: bib.v1.services.UserService.DeleteUser:output_type -> bib.v1.services.DeleteUserResponse
-	21, // 68: bib.v1.services.UserService.SuspendUser:output_type -> bib.v1.services.SuspendUserResponse
-	23, // 69: bib.v1.services.UserService.ActivateUser:output_type -> bib.v1.services.ActivateUserResponse
-	25, // 70: bib.v1.services.UserService.SetUserRole:output_type -> bib.v1.services.SetUserRoleResponse
-	27, // 71: bib.v1.services.UserService.GetCurrentUser:output_type -> bib.v1.services.GetCurrentUserResponse
-	29, // 72: bib.v1.services.UserService.UpdateCurrentUser:output_type -> bib.v1.services.UpdateCurrentUserResponse
-	31, // 73: bib.v1.services.UserService.GetUserPreferences:output_type -> bib.v1.services.GetUserPreferencesResponse
-	33, // 74: bib.v1.services.UserService.UpdateUserPreferences:output_type -> bib.v1.services.UpdateUserPreferencesResponse
-	35, // 75: bib.v1.services.UserService.ListUserSessions:output_type -> bib.v1.services.ListUserSessionsResponse
-	37, // 76: bib.v1.services.UserService.EndUserSession:output_type -> bib.v1.services.EndUserSessionResponse
-	39, // 77: bib.v1.services.UserService.EndAllUserSessions:output_type -> bib.v1.services.EndAllUserSessionsResponse
-	61, // [61:78] is the sub-list for method output_type
-	44, // [44:61] is the sub-list for method input_type
-	44, // [44:44] is the sub-list for extension type_name
-	44, // [44:44] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	57, // 7: bib.v1.services.Session.started_at:type_name -> google.protobuf.Timestamp
+	57, // 8: bib.v1.services.Session.ended_at:type_name -> google.protobuf.Timestamp
+	57, // 9: bib.v1.services.Session.expires_at:type_name -> google.protobuf.Timestamp
+	57, // 10: bib.v1.services.Session.last_activity_at:type_name -> google.protobuf.Timestamp
+	53, // 11: bib.v1.services.Session.metadata:type_name -> bib.v1.services.Session.MetadataEntry
+	57, // 12: bib.v1.services.UserPublicKey.created_at:type_name -> google.protobuf.Timestamp
+	54, // 13: bib.v1.services.UserPreferences.custom:type_name -> bib.v1.services.UserPreferences.CustomEntry
+	3,  // 14: bib.v1.services.GetUserResponse.user:type_name -> bib.v1.services.User
+	3,  // 15: bib.v1.services.GetUserByPublicKeyResponse.user:type_name -> bib.v1.services.User
+	0,  // 16: bib.v1.services.ListUsersRequest.status:type_name -> bib.v1.services.UserStatus
+	1,  // 17: bib.v1.services.ListUsersRequest.role:type_name -> bib.v1.services.UserRole
+	58, // 18: bib.v1.services.ListUsersRequest.page:type_name -> bib.v1.PageRequest
+	59, // 19: bib.v1.services.ListUsersRequest.sort:type_name -> bib.v1.SortOrder
+	3,  // 20: bib.v1.services.ListUsersResponse.users:type_name -> bib.v1.services.User
+	60, // 21: bib.v1.services.ListUsersResponse.page_info:type_name -> bib.v1.PageInfo
+	0,  // 22: bib.v1.services.SearchUsersRequest.status:type_name -> bib.v1.services.UserStatus
+	1,  // 23: bib.v1.services.SearchUsersRequest.role:type_name -> bib.v1.services.UserRole
+	58, // 24: bib.v1.services.SearchUsersRequest.page:type_name -> bib.v1.PageRequest
+	3,  // 25: bib.v1.services.SearchUsersResponse.users:type_name -> bib.v1.services.User
+	60, // 26: bib.v1.services.SearchUsersResponse.page_info:type_name -> bib.v1.PageInfo
+	1,  // 27: bib.v1.services.CreateUserRequest.role:type_name -> bib.v1.services.UserRole
+	0,  // 28: bib.v1.services.CreateUserRequest.status:type_name -> bib.v1.services.UserStatus
+	55, // 29: bib.v1.services.CreateUserRequest.metadata:type_name -> bib.v1.services.CreateUserRequest.MetadataEntry
+	3,  // 30: bib.v1.services.CreateUserResponse.user:type_name -> bib.v1.services.User
+	56, // 31: bib.v1.services.UpdateUserRequest.metadata:type_name -> bib.v1.services.UpdateUserRequest.MetadataEntry
+	3,  // 32: bib.v1.services.UpdateUserResponse.user:type_name -> bib.v1.services.User
+	3,  // 33: bib.v1.services.SuspendUserResponse.user:type_name -> bib.v1.services.User
+	3,  // 34: bib.v1.services.ActivateUserResponse.user:type_name -> bib.v1.services.User
+	1,  // 35: bib.v1.services.SetUserRoleRequest.role:type_name -> bib.v1.services.UserRole
+	3,  // 36: bib.v1.services.SetUserRoleResponse.user:type_name -> bib.v1.services.User
+	3,  // 37: bib.v1.services.GetCurrentUserResponse.user:type_name -> bib.v1.services.User
+	3,  // 38: bib.v1.services.UpdateCurrentUserResponse.user:type_name -> bib.v1.services.User
+	6,  // 39: bib.v1.services.GetUserPreferencesResponse.preferences:type_name -> bib.v1.services.UserPreferences
+	6,  // 40: bib.v1.services.UpdateUserPreferencesRequest.preferences:type_name -> bib.v1.services.UserPreferences
+	6,  // 41: bib.v1.services.UpdateUserPreferencesResponse.preferences:type_name -> bib.v1.services.UserPreferences
+	57, // 42: bib.v1.services.CLIPreferences.updated_at:type_name -> google.protobuf.Timestamp
+	35, // 43: bib.v1.services.GetPreferencesResponse.preferences:type_name -> bib.v1.services.CLIPreferences
+	35, // 44: bib.v1.services.SetPreferencesRequest.preferences:type_name -> bib.v1.services.CLIPreferences
+	35, // 45: bib.v1.services.SetPreferencesResponse.preferences:type_name -> bib.v1.services.CLIPreferences
+	58, // 46: bib.v1.services.ListUserSessionsRequest.page:type_name -> bib.v1.PageRequest
+	4,  // 47: bib.v1.services.ListUserSessionsResponse.sessions:type_name -> bib.v1.services.Session
+	60, // 48: bib.v1.services.ListUserSessionsResponse.page_info:type_name -> bib.v1.PageInfo
+	5,  // 49: bib.v1.services.AddPublicKeyResponse.key:type_name -> bib.v1.services.UserPublicKey
+	5,  // 50: bib.v1.services.ListPublicKeysResponse.keys:type_name -> bib.v1.services.UserPublicKey
+	7,  // 51: bib.v1.services.UserService.GetUser:input_type -> bib.v1.services.GetUserRequest
+	9,  // 52: bib.v1.services.UserService.GetUserByPublicKey:input_type -> bib.v1.services.GetUserByPublicKeyRequest
+	11, // 53: bib.v1.services.UserService.ListUsers:input_type -> bib.v1.services.ListUsersRequest
+	13, // 54: bib.v1.services.UserService.SearchUsers:input_type -> bib.v1.services.SearchUsersRequest
+	15, // 55: bib.v1.services.UserService.CreateUser:input_type -> bib.v1.services.CreateUserRequest
+	17, // 56: bib.v1.services.UserService.UpdateUser:input_type -> bib.v1.services.UpdateUserRequest
+	19, // 57: bib.v1.services.UserService.DeleteUser:input_type -> bib.v1.services.DeleteUserRequest
+	21, // 58: bib.v1.services.UserService.SuspendUser:input_type -> bib.v1.services.SuspendUserRequest
+	23, // 59: bib.v1.services.UserService.ActivateUser:input_type -> bib.v1.services.ActivateUserRequest
+	25, // 60: bib.v1.services.UserService.SetUserRole:input_type -> bib.v1.services.SetUserRoleRequest
+	27, // 61: bib.v1.services.UserService.GetCurrentUser:input_type -> bib.v1.services.GetCurrentUserRequest
+	29, // 62: bib.v1.services.UserService.UpdateCurrentUser:input_type -> bib.v1.services.UpdateCurrentUserRequest
+	31, // 63: bib.v1.services.UserService.GetUserPreferences:input_type -> bib.v1.services.GetUserPreferencesRequest
+	33, // 64: bib.v1.services.UserService.UpdateUserPreferences:input_type -> bib.v1.services.UpdateUserPreferencesRequest
+	36, // 65: bib.v1.services.UserService.GetPreferences:input_type -> bib.v1.services.GetPreferencesRequest
+	38, // 66: bib.v1.services.UserService.SetPreferences:input_type -> bib.v1.services.SetPreferencesRequest
+	40, // 67: bib.v1.services.UserService.ListUserSessions:input_type -> bib.v1.services.ListUserSessionsRequest
+	42, // 68: bib.v1.services.UserService.EndUserSession:input_type -> bib.v1.services.EndUserSessionRequest
+	44, // 69: bib.v1.services.UserService.EndAllUserSessions:input_type -> bib.v1.services.EndAllUserSessionsRequest
+	46, // 70: bib.v1.services.UserService.AddPublicKey:input_type -> bib.v1.services.AddPublicKeyRequest
+	48, // 71: bib.v1.services.UserService.ListPublicKeys:input_type -> bib.v1.services.ListPublicKeysRequest
+	50, // 72: bib.v1.services.UserService.RemovePublicKey:input_type -> bib.v1.services.RemovePublicKeyRequest
+	8,  // 73: bib.v1.services.UserService.GetUser:output_type -> bib.v1.services.GetUserResponse
+	10, // 74: bib.v1.services.UserService.GetUserByPublicKey:output_type -> bib.v1.services.GetUserByPublicKeyResponse
+	12, // 75: bib.v1.services.UserService.ListUsers:output_type -> bib.v1.services.ListUsersResponse
+	14, // 76: bib.v1.services.UserService.SearchUsers:output_type -> bib.v1.services.SearchUsersResponse
+	16, // 77: bib.v1.services.UserService.CreateUser:output_type -> bib.v1.services.CreateUserResponse
+	18, // 78: bib.v1.services.UserService.UpdateUser:output_type -> bib.v1.services.UpdateUserResponse
+	20, // 79: bib.v1.services.UserService.DeleteUser:output_type -> bib.v1.services.DeleteUserResponse
+	22, // 80: bib.v1.services.UserService.SuspendUser:output_type -> bib.v1.services.SuspendUserResponse
+	24, // 81: bib.v1.services.UserService.ActivateUser:output_type -> bib.v1.services.ActivateUserResponse
+	26, // 82: bib.v1.services.UserService.SetUserRole:output_type -> bib.v1.services.SetUserRoleResponse
+	28, // 83: bib.v1.services.UserService.GetCurrentUser:output_type -> bib.v1.services.GetCurrentUserResponse
+	30, // 84: bib.v1.services.UserService.UpdateCurrentUser:output_type -> bib.v1.services.UpdateCurrentUserResponse
+	32, // 85: bib.v1.services.UserService.GetUserPreferences:output_type -> bib.v1.services.GetUserPreferencesResponse
+	34, // 86: bib.v1.services.UserService.UpdateUserPreferences:output_type -> bib.v1.services.UpdateUserPreferencesResponse
+	37, // 87: bib.v1.services.UserService.GetPreferences:output_type -> bib.v1.services.GetPreferencesResponse
+	39, // 88: bib.v1.services.UserService.SetPreferences:output_type -> bib.v1.services.SetPreferencesResponse
+	41, // 89: bib.v1.services.UserService.ListUserSessions:output_type -> bib.v1.services.ListUserSessionsResponse
+	43, // 90: bib.v1.services.UserService.EndUserSession:output_type -> bib.v1.services.EndUserSessionResponse
+	45, // 91: bib.v1.services.UserService.EndAllUserSessions:output_type -> bib.v1.services.EndAllUserSessionsResponse
+	47, // 92: bib.v1.services.UserService.AddPublicKey:output_type -> bib.v1.services.AddPublicKeyResponse
+	49, // 93: bib.v1.services.UserService.ListPublicKeys:output_type -> bib.v1.services.ListPublicKeysResponse
+	51, // 94: bib.v1.services.UserService.RemovePublicKey:output_type -> bib.v1.services.RemovePublicKeyResponse
+	73, // [73:95] is the sub-list for method output_type
+	51, // [51:73] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_bib_v1_services_user_proto_init() }
@@ -2736,15 +3469,16 @@ func file_bib_v1_services_user_proto_init() {
 	if File_bib_v1_services_user_proto != nil {
 		return
 	}
-	file_bib_v1_services_user_proto_msgTypes[13].OneofWrappers = []any{}
-	file_bib_v1_services_user_proto_msgTypes[25].OneofWrappers = []any{}
+	file_bib_v1_services_user_proto_msgTypes[14].OneofWrappers = []any{}
+	file_bib_v1_services_user_proto_msgTypes[26].OneofWrappers = []any{}
+	file_bib_v1_services_user_proto_msgTypes[32].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bib_v1_services_user_proto_rawDesc), len(file_bib_v1_services_user_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   42,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
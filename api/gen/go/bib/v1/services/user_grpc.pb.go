@@ -33,9 +33,14 @@ const (
 	UserService_UpdateCurrentUser_FullMethodName     = "/bib.v1.services.UserService/UpdateCurrentUser"
 	UserService_GetUserPreferences_FullMethodName    = "/bib.v1.services.UserService/GetUserPreferences"
 	UserService_UpdateUserPreferences_FullMethodName = "/bib.v1.services.UserService/UpdateUserPreferences"
+	UserService_GetPreferences_FullMethodName        = "/bib.v1.services.UserService/GetPreferences"
+	UserService_SetPreferences_FullMethodName        = "/bib.v1.services.UserService/SetPreferences"
 	UserService_ListUserSessions_FullMethodName      = "/bib.v1.services.UserService/ListUserSessions"
 	UserService_EndUserSession_FullMethodName        = "/bib.v1.services.UserService/EndUserSession"
 	UserService_EndAllUserSessions_FullMethodName    = "/bib.v1.services.UserService/EndAllUserSessions"
+	UserService_AddPublicKey_FullMethodName          = "/bib.v1.services.UserService/AddPublicKey"
+	UserService_ListPublicKeys_FullMethodName        = "/bib.v1.services.UserService/ListPublicKeys"
+	UserService_RemovePublicKey_FullMethodName       = "/bib.v1.services.UserService/RemovePublicKey"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -73,12 +78,25 @@ type UserServiceClient interface {
 	GetUserPreferences(ctx context.Context, in *GetUserPreferencesRequest, opts ...grpc.CallOption) (*GetUserPreferencesResponse, error)
 	// UpdateUserPreferences updates user preferences.
 	UpdateUserPreferences(ctx context.Context, in *UpdateUserPreferencesRequest, opts ...grpc.CallOption) (*UpdateUserPreferencesResponse, error)
+	// GetPreferences retrieves the current user's CLI preferences.
+	GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*GetPreferencesResponse, error)
+	// SetPreferences stores the current user's CLI preferences.
+	SetPreferences(ctx context.Context, in *SetPreferencesRequest, opts ...grpc.CallOption) (*SetPreferencesResponse, error)
 	// ListUserSessions lists sessions for a user (admin only, or self).
 	ListUserSessions(ctx context.Context, in *ListUserSessionsRequest, opts ...grpc.CallOption) (*ListUserSessionsResponse, error)
 	// EndUserSession ends a specific session (admin only, or self).
 	EndUserSession(ctx context.Context, in *EndUserSessionRequest, opts ...grpc.CallOption) (*EndUserSessionResponse, error)
 	// EndAllUserSessions ends all sessions for a user (admin only).
 	EndAllUserSessions(ctx context.Context, in *EndAllUserSessionsRequest, opts ...grpc.CallOption) (*EndAllUserSessionsResponse, error)
+	// AddPublicKey registers an additional identity key for a user, e.g. for
+	// another device (admin only, or self).
+	AddPublicKey(ctx context.Context, in *AddPublicKeyRequest, opts ...grpc.CallOption) (*AddPublicKeyResponse, error)
+	// ListPublicKeys lists a user's identity keys, including the primary key
+	// (admin only, or self).
+	ListPublicKeys(ctx context.Context, in *ListPublicKeysRequest, opts ...grpc.CallOption) (*ListPublicKeysResponse, error)
+	// RemovePublicKey revokes an additional identity key and ends the sessions
+	// authenticated with it (admin only, or self).
+	RemovePublicKey(ctx context.Context, in *RemovePublicKeyRequest, opts ...grpc.CallOption) (*RemovePublicKeyResponse, error)
 }
 
 type userServiceClient struct {
@@ -229,6 +247,26 @@ func (c *userServiceClient) UpdateUserPreferences(ctx context.Context, in *Updat
 	return out, nil
 }
 
+func (c *userServiceClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*GetPreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPreferencesResponse)
+	err := c.cc.Invoke(ctx, UserService_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SetPreferences(ctx context.Context, in *SetPreferencesRequest, opts ...grpc.CallOption) (*SetPreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetPreferencesResponse)
+	err := c.cc.Invoke(ctx, UserService_SetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ListUserSessions(ctx context.Context, in *ListUserSessionsRequest, opts ...grpc.CallOption) (*ListUserSessionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUserSessionsResponse)
@@ -259,6 +297,36 @@ func (c *userServiceClient) EndAllUserSessions(ctx context.Context, in *EndAllUs
 	return out, nil
 }
 
+func (c *userServiceClient) AddPublicKey(ctx context.Context, in *AddPublicKeyRequest, opts ...grpc.CallOption) (*AddPublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddPublicKeyResponse)
+	err := c.cc.Invoke(ctx, UserService_AddPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListPublicKeys(ctx context.Context, in *ListPublicKeysRequest, opts ...grpc.CallOption) (*ListPublicKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPublicKeysResponse)
+	err := c.cc.Invoke(ctx, UserService_ListPublicKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemovePublicKey(ctx context.Context, in *RemovePublicKeyRequest, opts ...grpc.CallOption) (*RemovePublicKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePublicKeyResponse)
+	err := c.cc.Invoke(ctx, UserService_RemovePublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations should embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -294,12 +362,25 @@ type UserServiceServer interface {
 	GetUserPreferences(context.Context, *GetUserPreferencesRequest) (*GetUserPreferencesResponse, error)
 	// UpdateUserPreferences updates user preferences.
 	UpdateUserPreferences(context.Context, *UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error)
+	// GetPreferences retrieves the current user's CLI preferences.
+	GetPreferences(context.Context, *GetPreferencesRequest) (*GetPreferencesResponse, error)
+	// SetPreferences stores the current user's CLI preferences.
+	SetPreferences(context.Context, *SetPreferencesRequest) (*SetPreferencesResponse, error)
 	// ListUserSessions lists sessions for a user (admin only, or self).
 	ListUserSessions(context.Context, *ListUserSessionsRequest) (*ListUserSessionsResponse, error)
 	// EndUserSession ends a specific session (admin only, or self).
 	EndUserSession(context.Context, *EndUserSessionRequest) (*EndUserSessionResponse, error)
 	// EndAllUserSessions ends all sessions for a user (admin only).
 	EndAllUserSessions(context.Context, *EndAllUserSessionsRequest) (*EndAllUserSessionsResponse, error)
+	// AddPublicKey registers an additional identity key for a user, e.g. for
+	// another device (admin only, or self).
+	AddPublicKey(context.Context, *AddPublicKeyRequest) (*AddPublicKeyResponse, error)
+	// ListPublicKeys lists a user's identity keys, including the primary key
+	// (admin only, or self).
+	ListPublicKeys(context.Context, *ListPublicKeysRequest) (*ListPublicKeysResponse, error)
+	// RemovePublicKey revokes an additional identity key and ends the sessions
+	// authenticated with it (admin only, or self).
+	RemovePublicKey(context.Context, *RemovePublicKeyRequest) (*RemovePublicKeyResponse, error)
 }
 
 // UnimplementedUserServiceServer should be embedded to have
@@ -351,6 +432,12 @@ func (UnimplementedUserServiceServer) GetUserPreferences(context.Context, *GetUs
 func (UnimplementedUserServiceServer) UpdateUserPreferences(context.Context, *UpdateUserPreferencesRequest) (*UpdateUserPreferencesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateUserPreferences not implemented")
 }
+func (UnimplementedUserServiceServer) GetPreferences(context.Context, *GetPreferencesRequest) (*GetPreferencesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedUserServiceServer) SetPreferences(context.Context, *SetPreferencesRequest) (*SetPreferencesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPreferences not implemented")
+}
 func (UnimplementedUserServiceServer) ListUserSessions(context.Context, *ListUserSessionsRequest) (*ListUserSessionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListUserSessions not implemented")
 }
@@ -360,6 +447,15 @@ func (UnimplementedUserServiceServer) EndUserSession(context.Context, *EndUserSe
 func (UnimplementedUserServiceServer) EndAllUserSessions(context.Context, *EndAllUserSessionsRequest) (*EndAllUserSessionsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method EndAllUserSessions not implemented")
 }
+func (UnimplementedUserServiceServer) AddPublicKey(context.Context, *AddPublicKeyRequest) (*AddPublicKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddPublicKey not implemented")
+}
+func (UnimplementedUserServiceServer) ListPublicKeys(context.Context, *ListPublicKeysRequest) (*ListPublicKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPublicKeys not implemented")
+}
+func (UnimplementedUserServiceServer) RemovePublicKey(context.Context, *RemovePublicKeyRequest) (*RemovePublicKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemovePublicKey not implemented")
+}
 func (UnimplementedUserServiceServer) testEmbeddedByValue() {}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -632,6 +728,42 @@ func _UserService_UpdateUserPreferences_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetPreferences(ctx, req.(*GetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SetPreferences(ctx, req.(*SetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ListUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUserSessionsRequest)
 	if err := dec(in); err != nil {
@@ -686,6 +818,60 @@ func _UserService_EndAllUserSessions_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_AddPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AddPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AddPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AddPublicKey(ctx, req.(*AddPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListPublicKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPublicKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListPublicKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListPublicKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListPublicKeys(ctx, req.(*ListPublicKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemovePublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemovePublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemovePublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemovePublicKey(ctx, req.(*RemovePublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -749,6 +935,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUserPreferences",
 			Handler:    _UserService_UpdateUserPreferences_Handler,
 		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _UserService_GetPreferences_Handler,
+		},
+		{
+			MethodName: "SetPreferences",
+			Handler:    _UserService_SetPreferences_Handler,
+		},
 		{
 			MethodName: "ListUserSessions",
 			Handler:    _UserService_ListUserSessions_Handler,
@@ -761,6 +955,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EndAllUserSessions",
 			Handler:    _UserService_EndAllUserSessions_Handler,
 		},
+		{
+			MethodName: "AddPublicKey",
+			Handler:    _UserService_AddPublicKey_Handler,
+		},
+		{
+			MethodName: "ListPublicKeys",
+			Handler:    _UserService_ListPublicKeys_Handler,
+		},
+		{
+			MethodName: "RemovePublicKey",
+			Handler:    _UserService_RemovePublicKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bib/v1/services/user.proto",
@@ -1,9 +1,14 @@
 package admin
 
 import (
+	"context"
+
 	"bib/cmd/bib/cmd/admin/backup"
 	"bib/cmd/bib/cmd/admin/blob"
 	"bib/cmd/bib/cmd/admin/breakglass"
+	"bib/cmd/bib/cmd/admin/keys"
+	"bib/cmd/bib/cmd/admin/storage"
+	client "bib/internal/grpc/client"
 
 	"github.com/spf13/cobra"
 )
@@ -16,17 +21,30 @@ var Cmd = &cobra.Command{
 	Annotations: map[string]string{"i18n": "true"},
 }
 
-// NewCommand returns the admin command with all subcommands registered
-func NewCommand() *cobra.Command {
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// NewCommand returns the admin command with all subcommands registered.
+// getClient is called lazily by subcommands that need the daemon.
+func NewCommand(getClient ClientFunc) *cobra.Command {
 	// Add subcommand groups from subpackages
-	Cmd.AddCommand(backup.NewCommand())
+	Cmd.AddCommand(backup.NewCommand(backup.ClientFunc(getClient)))
 	Cmd.AddCommand(backup.NewRestoreCommand())
 	Cmd.AddCommand(blob.NewCommand())
 	Cmd.AddCommand(breakglass.NewCommand())
+	Cmd.AddCommand(keys.NewCommand())
+	Cmd.AddCommand(storage.NewCommand())
 
 	// Add standalone commands
+	Cmd.AddCommand(newAuditCommand(getClient))
+	Cmd.AddCommand(newMaintenanceCommand(getClient))
+	Cmd.AddCommand(newMetricsCommand())
+	Cmd.AddCommand(newProfileCommand(getClient))
+	Cmd.AddCommand(newRateLimitCommand(getClient))
 	Cmd.AddCommand(cleanupCmd)
 	Cmd.AddCommand(resetCmd)
+	Cmd.AddCommand(newSelfTestCommand(getClient))
+	Cmd.AddCommand(newUpgradeCommand(getClient))
 
 	return Cmd
 }
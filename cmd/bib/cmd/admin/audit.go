@@ -0,0 +1,157 @@
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// auditItem is an audit log entry as written by bib admin audit
+type auditItem struct {
+	ID           string            `json:"id" yaml:"id"`
+	Timestamp    time.Time         `json:"timestamp" yaml:"timestamp"`
+	UserID       string            `json:"user_id" yaml:"user_id"`
+	Action       string            `json:"action" yaml:"action"`
+	ResourceType string            `json:"resource_type,omitempty" yaml:"resource_type,omitempty"`
+	ResourceID   string            `json:"resource_id,omitempty" yaml:"resource_id,omitempty"`
+	Result       string            `json:"result" yaml:"result"`
+	Error        string            `json:"error,omitempty" yaml:"error,omitempty"`
+	ClientIP     string            `json:"client_ip,omitempty" yaml:"client_ip,omitempty"`
+	RequestID    string            `json:"request_id,omitempty" yaml:"request_id,omitempty"`
+	NodeID       string            `json:"node_id,omitempty" yaml:"node_id,omitempty"`
+	Details      map[string]string `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+func toAuditItem(e *services.AuditLogEntry) auditItem {
+	return auditItem{
+		ID:           e.GetId(),
+		Timestamp:    e.GetTimestamp().AsTime(),
+		UserID:       e.GetUserId(),
+		Action:       e.GetAction(),
+		ResourceType: e.GetResourceType(),
+		ResourceID:   e.GetResourceId(),
+		Result:       e.GetResult(),
+		Error:        e.GetError(),
+		ClientIP:     e.GetClientIp(),
+		RequestID:    e.GetRequestId(),
+		NodeID:       e.GetNodeId(),
+		Details:      e.GetDetails(),
+	}
+}
+
+func newAuditCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		userID       string
+		action       string
+		resourceType string
+		resourceID   string
+		since        time.Duration
+		until        string
+		limit        int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the daemon's audit log",
+		Long: `Show the audit log of the connected bibd, newest entries first.
+
+Entries are streamed from the daemon. With --output jsonl each entry is
+printed as one line of JSON as soon as it arrives, so even a long log can
+be piped into jq or a log shipper without waiting for the whole listing.
+The other formats print once the listing is complete.
+
+If the stream fails part way, the entries received so far are still
+printed and the command exits with an error.
+
+Requires the admin role.`,
+		Example: `  # Show the last 100 entries
+  bib admin audit --limit 100
+
+  # Stream the last day of dataset deletions as JSON lines
+  bib admin audit --since 24h --action DELETE --resource-type datasets -o jsonl`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			req := &services.GetAuditLogsRequest{
+				UserId:       userID,
+				Action:       action,
+				ResourceType: resourceType,
+				ResourceId:   resourceID,
+				Page:         &bibv1.PageRequest{Limit: limit},
+			}
+			var err error
+			req.StartTime, req.EndTime, err = parseTimeFlags(since, until)
+			if err != nil {
+				return err
+			}
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			stream, err := adminClient.StreamAuditLogs(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			return output.Copy(newAuditStream(cmd), stream, toAuditItem)
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "Only show entries by this user")
+	cmd.Flags().StringVar(&action, "action", "", "Only show this action")
+	cmd.Flags().StringVar(&resourceType, "resource-type", "", "Only show entries for this resource type")
+	cmd.Flags().StringVar(&resourceID, "resource-id", "", "Only show entries for this resource")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show entries newer than this (e.g. 1h, 24h)")
+	cmd.Flags().StringVar(&until, "until", "", "Only show entries before this time (RFC 3339)")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Maximum number of entries (0 = all)")
+
+	cmd.AddCommand(newAuditQueryCommand(getClient))
+	cmd.AddCommand(newAuditVerifyCommand())
+
+	return cmd
+}
+
+// newAuditStream creates the output stream for audit entries.
+func newAuditStream(cmd *cobra.Command) *output.Stream[auditItem] {
+	w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+	return output.NewStream(w,
+		[]string{"ID", "TIME", "USER", "ACTION", "RESOURCE", "RESULT"},
+		func(e auditItem) []string {
+			resource := e.ResourceType
+			if e.ResourceID != "" {
+				resource += "/" + e.ResourceID
+			}
+			return []string{
+				e.ID, e.Timestamp.Local().Format(time.DateTime), e.UserID,
+				e.Action, resource, e.Result,
+			}
+		})
+}
+
+// parseTimeFlags converts --since and --until to request timestamps.
+func parseTimeFlags(since time.Duration, until string) (start, end *timestamppb.Timestamp, err error) {
+	if since > 0 {
+		start = timestamppb.New(time.Now().Add(-since))
+	}
+	if until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --until %q: use RFC 3339, e.g. 2024-01-02T15:04:05Z", until)
+		}
+		end = timestamppb.New(t)
+	}
+	return start, end, nil
+}
@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+
+	"github.com/spf13/cobra"
+)
+
+func newAuditQueryCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		actor        string
+		action       string
+		outcome      string
+		resourceType string
+		resourceID   string
+		since        time.Duration
+		until        string
+		where        string
+		limit        int32
+		cursor       string
+		all          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Search the audit log",
+		Long: `Search the audit log of the connected bibd, newest entries first.
+
+Filters are combined with AND. For anything the flags cannot express, --where
+takes a CEL expression over 'entry', the same language as the CEL rules of
+audit alerts. Fields: actor, action, table_name, role_used, node_id, job_id,
+operation_id, source_component, rows_affected, duration_ms, suspicious,
+break_glass and metadata (a map, e.g. entry.metadata.resource_id).
+
+Results come in pages of --limit entries. When more entries match, the
+cursor of the next page is printed on stderr; pass it to --cursor to
+continue, or use --all to fetch every page.
+
+Sensitive fields such as passwords and tokens are redacted by the daemon.
+Requires the admin role.`,
+		Example: `  # Failed operations of a user in the last hour
+  bib admin audit query --actor alice --outcome failure --since 1h
+
+  # Break-glass deletions, as JSON
+  bib admin audit query --where 'entry.break_glass && entry.action == "DELETE"' -o json
+
+  # Everything that touched a dataset
+  bib admin audit query --resource-type datasets --resource-id 3f2a9c1e --all`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			req := &services.QueryAuditRequest{
+				Actor:        actor,
+				Action:       action,
+				Outcome:      outcome,
+				ResourceType: resourceType,
+				ResourceId:   resourceID,
+				Expression:   where,
+				Page:         &bibv1.PageRequest{Limit: limit, Cursor: cursor},
+			}
+			var err error
+			req.StartTime, req.EndTime, err = parseTimeFlags(since, until)
+			if err != nil {
+				return err
+			}
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			s := newAuditStream(cmd)
+			for {
+				resp, err := adminClient.QueryAudit(ctx, req)
+				if err != nil {
+					// Show what was found before the failure
+					_ = s.Close()
+					return err
+				}
+				for _, e := range resp.GetEntries() {
+					if err := s.Write(toAuditItem(e)); err != nil {
+						return err
+					}
+				}
+
+				next := resp.GetPageInfo().GetNextCursor()
+				if next == "" {
+					break
+				}
+				if !all {
+					fmt.Fprintf(cmd.ErrOrStderr(), "More entries match; continue with --cursor %s\n", next)
+					break
+				}
+				req.Page.Cursor = next
+			}
+			return s.Close()
+		},
+	}
+
+	cmd.Flags().StringVar(&actor, "actor", "", "Only show entries by this user or node")
+	cmd.Flags().StringVar(&action, "action", "", "Only show this action")
+	cmd.Flags().StringVar(&outcome, "outcome", "", "Only show entries with this outcome (success, failure)")
+	cmd.Flags().StringVar(&resourceType, "resource-type", "", "Only show entries for this resource type")
+	cmd.Flags().StringVar(&resourceID, "resource-id", "", "Only show entries for this resource")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show entries newer than this (e.g. 1h, 24h)")
+	cmd.Flags().StringVar(&until, "until", "", "Only show entries before this time (RFC 3339)")
+	cmd.Flags().StringVar(&where, "where", "", "CEL expression entries must match")
+	cmd.Flags().Int32Var(&limit, "limit", 50, "Entries per page (max 1000)")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Continue from the cursor of a previous query")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch every page")
+
+	return cmd
+}
@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+
+	"github.com/spf13/cobra"
+)
+
+func newAuditVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [path]",
+		Short: "Verify the hash chain of the bibd audit log file",
+		Long: `Verify the hash chain of the audit log file bibd writes (log.audit_path).
+
+Every entry records the hash of the entry before it and its own hash over
+the line as written. The command reads the rotated files, gzip-compressed
+or not, oldest first, then the current file, and reports the first line
+that was altered, removed or reordered.
+
+The files are read directly, so run it on the bibd host. Without a path,
+log.audit_path of the bibd configuration is verified. The daemon is not
+contacted.`,
+		Example: `  # Verify the audit log of the local bibd
+  bib admin audit verify
+
+  # Verify a copied audit log
+  bib admin audit verify /mnt/evidence/audit.log`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			} else {
+				cfg, err := config.LoadBibd("")
+				if err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+				if cfg.Log.AuditPath == "" {
+					return fmt.Errorf("log.audit_path is not set in the bibd configuration; pass the audit log path")
+				}
+				path = cfg.Log.AuditPath
+			}
+
+			n, err := logger.VerifyAuditLog(path)
+			var broken *logger.AuditVerifyError
+			if errors.As(err, &broken) {
+				return fmt.Errorf("audit log hash chain broken after %d valid entries: %w", n, err)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read the audit log: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Audit log hash chain intact (%d entries)\n", n)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,9 +1,16 @@
 package backup
 
 import (
+	"context"
+
+	client "bib/internal/grpc/client"
+
 	"github.com/spf13/cobra"
 )
 
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
 // Cmd represents the backup command group
 var Cmd = &cobra.Command{
 	Use:   "backup",
@@ -40,11 +47,12 @@ point-in-time recovery (if WAL archiving is enabled).`,
 	RunE: runRestore,
 }
 
-// NewCommand returns the backup command with all subcommands registered
-func NewCommand() *cobra.Command {
+// NewCommand returns the backup command with all subcommands registered.
+// getClient is called lazily by subcommands that go through the daemon.
+func NewCommand(getClient ClientFunc) *cobra.Command {
 	Cmd.AddCommand(createCmd)
 	Cmd.AddCommand(listCmd)
-	Cmd.AddCommand(deleteCmd)
+	Cmd.AddCommand(newDeleteCommand(getClient))
 
 	return Cmd
 }
@@ -59,9 +67,6 @@ func init() {
 	createCmd.Flags().StringVar(&backupNotes, "notes", "", "Notes about this backup")
 	createCmd.Flags().BoolVar(&backupVerify, "verify", true, "Verify backup integrity after creation")
 
-	// Backup delete flags
-	deleteCmd.Flags().BoolVar(&backupForce, "force", false, "Force deletion without confirmation")
-
 	// Restore flags
 	RestoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Force restore even if data exists")
 	RestoreCmd.Flags().BoolVar(&restoreVerify, "verify", true, "Verify backup integrity before restore")
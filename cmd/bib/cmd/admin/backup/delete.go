@@ -2,64 +2,110 @@ package backup
 
 import (
 	"fmt"
+	"io"
+	"time"
 
-	"bib/internal/config"
-	"bib/internal/storage"
-	"bib/internal/storage/backup"
+	services "bib/api/gen/go/bib/v1/services"
 
 	"github.com/spf13/cobra"
 )
 
-var backupForce bool
+func newDeleteCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		force, dryRun bool
+		token         string
+	)
 
-// deleteCmd deletes a backup
-var deleteCmd = &cobra.Command{
-	Use:   "delete <backup-id>",
-	Short: "Delete a backup",
-	Long: `Delete a specific backup by ID.
+	cmd := &cobra.Command{
+		Use:   "delete <backup-id>",
+		Short: "Delete a backup",
+		Long: `Delete a specific backup by ID through the connected daemon.
 
-This permanently removes the backup file and its metadata.`,
-	Example: `  # Delete a specific backup
-  bib admin backup delete 1234567890`,
-	Args: cobra.ExactArgs(1),
-	RunE: runBackupDelete,
-}
+This permanently removes the backup file and its metadata, so the daemon
+only deletes a backup with the confirmation token of a dry run of the same
+deletion. The command previews the deletion, asks for confirmation and
+passes the token on. Use --dry-run to only preview it and print the token,
+and --confirm to delete with a token printed earlier, e.g. from a script.
+Tokens are single-use and expire after 5 minutes.`,
+		Example: `  # Delete a specific backup
+  bib admin backup delete 1234567890
 
-func runBackupDelete(cmd *cobra.Command, args []string) error {
-	backupID := args[0]
+  # Show what would be deleted
+  bib admin backup delete 1234567890 --dry-run
 
-	// Load configuration
-	cfg, err := config.LoadBibd("")
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
+  # Delete with the token of an earlier dry run
+  bib admin backup delete 1234567890 --confirm 9f86d081884c7d65...`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			backupID := args[0]
+			out := cmd.OutOrStdout()
 
-	// Initialize backup manager
-	backupCfg := backup.DefaultBackupConfig()
-	backupCfg.LocalPath = cfg.Server.DataDir + "/backups"
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
 
-	mgr, err := backup.NewManager(backupCfg, storage.BackendType(cfg.Database.Backend), cfg.Server.DataDir, cfg.Cluster.NodeID)
-	if err != nil {
-		return fmt.Errorf("failed to create backup manager: %w", err)
-	}
+			if token == "" {
+				preview, err := adminClient.DeleteBackup(ctx, &services.DeleteBackupRequest{
+					BackupId: backupID,
+					DryRun:   true,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to preview deletion: %w", err)
+				}
+				report := preview.GetDryRun()
+				writeDryRun(out, report)
+				if dryRun {
+					fmt.Fprintln(out, "No changes were made.")
+					if report.GetConfirmationToken() != "" {
+						fmt.Fprintf(out, "Confirmation token: %s (valid until %s)\n", report.GetConfirmationToken(),
+							report.GetConfirmationExpiresAt().AsTime().Local().Format(time.DateTime))
+					}
+					return nil
+				}
+
+				if !force {
+					fmt.Fprintf(out, "Are you sure you want to delete backup %s? (y/N): ", backupID)
+					var response string
+					_, _ = fmt.Fscanln(cmd.InOrStdin(), &response)
+					if response != "y" && response != "Y" {
+						fmt.Fprintln(out, "Cancelled")
+						return nil
+					}
+				}
+				token = report.GetConfirmationToken()
+			}
 
-	// Confirm deletion unless --force is used
-	if !backupForce {
-		fmt.Printf("Are you sure you want to delete backup %s? (y/N): ", backupID)
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" {
-			fmt.Println("Cancelled")
+			if _, err := adminClient.DeleteBackup(ctx, &services.DeleteBackupRequest{
+				BackupId:          backupID,
+				ConfirmationToken: token,
+			}); err != nil {
+				return fmt.Errorf("failed to delete backup: %w", err)
+			}
+
+			fmt.Fprintf(out, "✓ Backup %s deleted successfully\n", backupID)
 			return nil
-		}
+		},
 	}
 
-	// Delete backup
-	if err := mgr.Delete(cmd.Context(), backupID); err != nil {
-		return fmt.Errorf("failed to delete backup: %w", err)
-	}
+	cmd.Flags().BoolVar(&force, "force", false, "Delete without asking; the deletion is still previewed")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the backup that would be deleted and print a confirmation token, without deleting it")
+	cmd.Flags().StringVar(&token, "confirm", "", "Delete with the confirmation token of an earlier dry run")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "confirm")
 
-	fmt.Printf("✓ Backup %s deleted successfully\n", backupID)
+	return cmd
+}
 
-	return nil
+// writeDryRun writes what a deletion would remove.
+func writeDryRun(out io.Writer, report *services.DryRunReport) {
+	fmt.Fprintf(out, "Dry run: %s\n", report.GetSummary())
+	for _, res := range report.GetResources() {
+		fmt.Fprintf(out, "  %s %s: %s, %.1f MB\n", res.GetKind(), res.GetId(), res.GetDetail(),
+			float64(res.GetSizeBytes())/(1024*1024))
+	}
 }
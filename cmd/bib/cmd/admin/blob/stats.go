@@ -71,11 +71,12 @@ func runBlobStats(ctx context.Context) error {
   "total_blobs": %d,
   "total_size": %d,
   "total_size_compressed": %d,
+  "compression_ratio": %.2f,
   "oldest_blob": "%s",
   "newest_blob": "%s",
   "backend": "%s"
 }
-`, stats.TotalBlobs, stats.TotalSize, stats.TotalSizeCompressed,
+`, stats.TotalBlobs, stats.TotalSize, stats.TotalSizeCompressed, stats.CompressionRatio(),
 			stats.OldestBlob.Format(time.RFC3339),
 			stats.NewestBlob.Format(time.RFC3339),
 			stats.Backend)
@@ -85,8 +86,7 @@ func runBlobStats(ctx context.Context) error {
 		fmt.Fprintln(w, "METRIC\tVALUE")
 		fmt.Fprintf(w, "Total Blobs\t%d\n", stats.TotalBlobs)
 		fmt.Fprintf(w, "Total Size\t%s\n", formatBytes(stats.TotalSize))
-		if stats.TotalSizeCompressed > 0 {
-			ratio := float64(stats.TotalSize) / float64(stats.TotalSizeCompressed)
+		if ratio := stats.CompressionRatio(); ratio > 0 {
 			fmt.Fprintf(w, "Compressed Size\t%s (%.2fx)\n", formatBytes(stats.TotalSizeCompressed), ratio)
 		}
 		if !stats.OldestBlob.IsZero() {
@@ -10,65 +10,133 @@ import (
 	"strings"
 
 	"bib/internal/config"
+	"bib/internal/deploy"
+	"bib/internal/deploy/docker"
+	"bib/internal/deploy/kubernetes"
+	"bib/internal/deploy/podman"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	cleanupAll       bool
-	cleanupPostgres  bool
-	cleanupBackups   bool
-	cleanupLogs      bool
-	cleanupCache     bool
-	cleanupForce     bool
-	cleanupContainer string
+	cleanupAll        bool
+	cleanupContainers bool
+	cleanupVolumes    bool
+	cleanupNetworks   bool
+	cleanupCerts      bool
+	cleanupData       bool
+	cleanupPostgres   bool
+	cleanupBackups    bool
+	cleanupLogs       bool
+	cleanupCache      bool
+	cleanupForce      bool
+	cleanupDryRun     bool
+	cleanupContainer  string
+	cleanupTarget     string
+	cleanupDir        string
+	cleanupNamespace  string
 )
 
 // cleanupCmd represents the cleanup command
 var cleanupCmd = &cobra.Command{
 	Use:   "cleanup",
 	Short: "Clean up bibd resources",
-	Long: `Clean up resources created by bibd, including:
-
-- Managed PostgreSQL containers
-- Backup files
-- Log files
-- Cache data
-- Configuration files (with --all)
-
-This command is useful for:
-- Completely removing bibd from a system
-- Cleaning up after failed installations
-- Freeing disk space`,
-	Example: `  # Clean up everything (interactive confirmation)
-  bib cleanup --all
-
-  # Clean up only PostgreSQL containers
-  bib cleanup --postgres
-
-  # Clean up backups and logs
-  bib cleanup --backups --logs
-
-  # Force cleanup without confirmation
-  bib cleanup --all --force`,
+	Long: `Tear down the resources of bibd, selected by scope:
+
+  --containers   containers and pods of the deployment 'bib setup' created
+                 (Kubernetes: Deployments and StatefulSets) and the
+                 PostgreSQL containers bibd manages
+  --volumes      volumes and PVCs, except the ones holding the database
+  --networks     networks (Kubernetes: Services, Ingresses and
+                 NetworkPolicies)
+  --certs        TLS certificates of bibd and the managed PostgreSQL
+  --backups      backup files
+  --logs         log and audit log files
+  --data         the database: its volumes and PVCs, the managed PostgreSQL
+                 data and the SQLite database
+
+--all selects every scope but --data, and removes the config directory.
+The database is only ever removed with an explicit --data; --all --data
+also removes the data directory.
+
+The deployment is detected from the files in --dir (default: the
+directories 'bib setup' writes for each target). With
+database.postgres.delete_on_cleanup set to false, Kubernetes workloads are
+scaled to 0 and no Kubernetes resource is deleted.
+
+The plan lists the resources that exist, with the size of each path, and
+is confirmed before anything is removed. Use --dry-run to print it and exit.`,
+	Example: `  # Tear down the deployment, keeping the database (interactive confirmation)
+  bib admin cleanup --all
+
+  # Show what removing everything, including the database, would remove
+  bib admin cleanup --all --data --dry-run
+
+  # Remove only the containers of a Kubernetes deployment in namespace bib
+  bib admin cleanup --containers --target kubernetes --namespace bib
+
+  # Clean up backups and logs without confirmation
+  bib admin cleanup --backups --logs --force`,
+	Args: cobra.NoArgs,
 	RunE: runCleanup,
 }
 
 func init() {
+	cleanupCmd.Flags().BoolVar(&cleanupAll, "all", false, "Clean up every scope but --data, and the config directory")
+	cleanupCmd.Flags().BoolVar(&cleanupContainers, "containers", false, "Remove containers and pods (Kubernetes: workloads)")
+	cleanupCmd.Flags().BoolVar(&cleanupVolumes, "volumes", false, "Remove volumes and PVCs, except the database's")
+	cleanupCmd.Flags().BoolVar(&cleanupNetworks, "networks", false, "Remove networks (Kubernetes: Services, Ingresses, NetworkPolicies)")
+	cleanupCmd.Flags().BoolVar(&cleanupCerts, "certs", false, "Remove TLS certificates")
+	cleanupCmd.Flags().BoolVar(&cleanupData, "data", false, "Remove the database (volumes, PVCs, PostgreSQL data, SQLite database)")
+	cleanupCmd.Flags().BoolVar(&cleanupPostgres, "postgres", false, "Remove the managed PostgreSQL containers")
+	cleanupCmd.Flags().BoolVar(&cleanupBackups, "backups", false, "Remove backup files")
+	cleanupCmd.Flags().BoolVar(&cleanupLogs, "logs", false, "Remove log files")
+	cleanupCmd.Flags().BoolVar(&cleanupCache, "cache", false, "Remove the SQLite database (requires --data)")
+	cleanupCmd.Flags().BoolVar(&cleanupForce, "force", false, "Clean up without confirmation")
+	cleanupCmd.Flags().BoolVar(&cleanupDryRun, "dry-run", false, "Show what would be removed without removing it")
+	cleanupCmd.Flags().StringVar(&cleanupContainer, "container", "", "Specific container name to remove")
+	cleanupCmd.Flags().StringVar(&cleanupTarget, "target", "", "Deploy target: docker, podman, kubernetes (default: detect)")
+	cleanupCmd.Flags().StringVar(&cleanupDir, "dir", "", "Directory generated by 'bib setup' for the target")
+	cleanupCmd.Flags().StringVar(&cleanupNamespace, "namespace", kubernetes.DefaultManifestConfig().Namespace, "Kubernetes namespace")
+	_ = cleanupCmd.Flags().MarkDeprecated("cache", "use --data, which removes the SQLite database")
+}
+
+// cleanupItem is one action of the cleanup plan
+type cleanupItem struct {
+	Kind      string   `json:"kind" yaml:"kind"`
+	Name      string   `json:"name" yaml:"name"`
+	Action    string   `json:"action" yaml:"action"`
+	SizeBytes int64    `json:"size_bytes,omitempty" yaml:"size_bytes,omitempty"`
+	Command   []string `json:"command,omitempty" yaml:"command,omitempty"`
 
-	cleanupCmd.Flags().BoolVar(&cleanupAll, "all", false, "Clean up all resources (config, data, containers)")
-	cleanupCmd.Flags().BoolVar(&cleanupPostgres, "postgres", false, "Clean up managed PostgreSQL containers")
-	cleanupCmd.Flags().BoolVar(&cleanupBackups, "backups", false, "Clean up backup files")
-	cleanupCmd.Flags().BoolVar(&cleanupLogs, "logs", false, "Clean up log files")
-	cleanupCmd.Flags().BoolVar(&cleanupCache, "cache", false, "Clean up cache data")
-	cleanupCmd.Flags().BoolVar(&cleanupForce, "force", false, "Force cleanup without confirmation")
-	cleanupCmd.Flags().StringVar(&cleanupContainer, "container", "", "Specific container name to clean up")
+	run func(ctx context.Context) error
 }
 
-func runCleanup(cmd *cobra.Command, args []string) error {
-	_ = cmd  // unused
-	_ = args // unused
-	ctx := context.Background()
+// cleanupPlan is the output of bib admin cleanup
+type cleanupPlan struct {
+	Target    string        `json:"target,omitempty" yaml:"target,omitempty"`
+	ScaleDown bool          `json:"scale_down,omitempty" yaml:"scale_down,omitempty"`
+	Items     []cleanupItem `json:"items" yaml:"items"`
+	DryRun    bool          `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+}
+
+func runCleanup(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !cleanupAll && !cleanupContainers && !cleanupVolumes && !cleanupNetworks && !cleanupCerts && !cleanupData &&
+		!cleanupPostgres && !cleanupBackups && !cleanupLogs && !cleanupCache && cleanupContainer == "" {
+		return fmt.Errorf("please specify what to clean up (--all, --containers, --volumes, --networks, --certs, --data, --postgres, --backups, --logs, or --container)")
+	}
+	if cleanupCache && !cleanupData {
+		return fmt.Errorf("--cache removes the SQLite database; use --data to remove the database")
+	}
+	if cleanupAll {
+		cleanupContainers, cleanupVolumes, cleanupNetworks = true, true, true
+		cleanupCerts, cleanupBackups, cleanupLogs = true, true, true
+	}
 
 	// Load configuration if it exists
 	cfg, err := config.LoadBibd("")
@@ -78,297 +146,322 @@ func runCleanup(cmd *cobra.Command, args []string) error {
 		cfg = &defaultCfg
 	}
 
-	// Determine what to clean up
-	if !cleanupAll && !cleanupPostgres && !cleanupBackups && !cleanupLogs && !cleanupCache && cleanupContainer == "" {
-		return fmt.Errorf("please specify what to clean up (--all, --postgres, --backups, --logs, --cache, or --container)")
+	plan, err := buildCleanupPlan(ctx, cfg)
+	if err != nil {
+		return err
 	}
+	plan.DryRun = cleanupDryRun
 
-	// Build cleanup plan
-	plan := buildCleanupPlan(cfg)
+	w := NewOutputWriter()
+	w.out = cmd.OutOrStdout()
+	if w.format != "table" {
+		if err := w.Write(plan); err != nil {
+			return err
+		}
+	} else {
+		writeCleanupPlan(w, plan)
+	}
 
-	// Show cleanup plan
-	fmt.Println("Cleanup Plan:")
-	fmt.Println("=============")
-	for _, item := range plan {
-		fmt.Printf("  - %s\n", item)
+	if len(plan.Items) == 0 || cleanupDryRun {
+		return nil
 	}
-	fmt.Println()
 
 	// Confirm unless --force is used
 	if !cleanupForce {
-		fmt.Print("Proceed with cleanup? (y/N): ")
+		fmt.Fprint(cmd.OutOrStdout(), "Proceed with cleanup? (y/N): ")
 		var response string
-		_, _ = fmt.Scanln(&response)
+		_, _ = fmt.Fscanln(cmd.InOrStdin(), &response)
 		if response != "y" && response != "Y" {
-			fmt.Println("Cancelled")
+			w.Println("Cancelled")
 			return nil
 		}
 	}
 
-	// Execute cleanup
-	fmt.Println("\nCleaning up...")
-
-	var errors []error
-
-	// Clean up PostgreSQL containers
-	if cleanupAll || cleanupPostgres || cleanupContainer != "" {
-		if err := cleanupPostgresContainers(ctx, cfg, cleanupContainer); err != nil {
-			errors = append(errors, err)
+	var errs []error
+	for _, item := range plan.Items {
+		if err := item.run(ctx); err != nil {
+			w.WriteWarning(fmt.Sprintf("Failed to %s %s %s: %v", item.Action, item.Kind, item.Name, err))
+			errs = append(errs, err)
+			continue
 		}
-	}
-
-	// Clean up backups
-	if cleanupAll || cleanupBackups {
-		if err := cleanupBackupFiles(cfg); err != nil {
-			errors = append(errors, err)
+		if w.format == "table" {
+			w.WriteSuccess(fmt.Sprintf("%s %s %s", capitalize(item.Action), item.Kind, item.Name))
 		}
 	}
 
-	// Clean up logs
-	if cleanupAll || cleanupLogs {
-		if err := cleanupLogFiles(cfg); err != nil {
-			errors = append(errors, err)
-		}
+	if len(errs) > 0 {
+		return fmt.Errorf("cleanup completed with %d error(s)", len(errs))
 	}
-
-	// Clean up cache
-	if cleanupAll || cleanupCache {
-		if err := cleanupCacheFiles(cfg); err != nil {
-			errors = append(errors, err)
-		}
+	if w.format == "table" {
+		w.WriteSuccess("Cleanup completed successfully")
 	}
-
-	// Clean up data directory (only with --all)
-	if cleanupAll {
-		if err := cleanupDataDirectory(cfg); err != nil {
-			errors = append(errors, err)
-		}
-	}
-
-	// Report results
-	if len(errors) > 0 {
-		fmt.Println("\n⚠ Cleanup completed with errors:")
-		for _, err := range errors {
-			fmt.Printf("  - %v\n", err)
-		}
-		return fmt.Errorf("cleanup completed with %d error(s)", len(errors))
-	}
-
-	fmt.Println("\n✓ Cleanup completed successfully")
 	return nil
 }
 
-func buildCleanupPlan(cfg *config.BibdConfig) []string {
-	var plan []string
-
-	if cleanupAll || cleanupPostgres || cleanupContainer != "" {
-		if cleanupContainer != "" {
-			plan = append(plan, fmt.Sprintf("Stop and remove PostgreSQL container: %s", cleanupContainer))
-		} else {
-			plan = append(plan, "Stop and remove all bibd PostgreSQL containers")
-		}
-		plan = append(plan, "Remove PostgreSQL data volumes")
+// writeCleanupPlan prints the plan as a list
+func writeCleanupPlan(w *OutputWriter, plan *cleanupPlan) {
+	if len(plan.Items) == 0 {
+		w.WriteInfo("Nothing to clean up")
+		return
 	}
 
-	if cleanupAll || cleanupBackups {
-		backupPath := filepath.Join(cfg.Server.DataDir, "backups")
-		plan = append(plan, fmt.Sprintf("Remove backup files from: %s", backupPath))
+	w.Println("Cleanup Plan:")
+	w.Println("=============")
+	if plan.Target != "" {
+		w.Printf("Deployment: %s\n", plan.Target)
 	}
-
-	if cleanupAll || cleanupLogs {
-		logPath := cfg.Log.FilePath
-		if logPath == "" {
-			logPath = filepath.Join(cfg.Server.DataDir, "bibd.log")
-		}
-		plan = append(plan, fmt.Sprintf("Remove log files from: %s", logPath))
-		if cfg.Log.AuditPath != "" {
-			plan = append(plan, fmt.Sprintf("Remove audit logs from: %s", cfg.Log.AuditPath))
+	for _, item := range plan.Items {
+		size := ""
+		if item.SizeBytes > 0 {
+			size = fmt.Sprintf(" (%s)", formatBytes(item.SizeBytes))
 		}
+		w.Printf("  - %s %s %s%s\n", item.Action, item.Kind, item.Name, size)
 	}
+	w.Println()
 
-	if cleanupAll || cleanupCache {
-		if cfg.Database.Backend == "sqlite" {
-			cachePath := cfg.Database.SQLite.Path
-			if cachePath == "" {
-				cachePath = filepath.Join(cfg.Server.DataDir, "cache.db")
-			}
-			plan = append(plan, fmt.Sprintf("Remove SQLite cache: %s", cachePath))
-		}
+	if plan.ScaleDown && (cleanupVolumes || cleanupNetworks || cleanupData) {
+		w.WriteInfo("database.postgres.delete_on_cleanup is false: Kubernetes workloads are scaled to 0 and their resources kept")
 	}
-
-	if cleanupAll {
-		plan = append(plan, fmt.Sprintf("Remove data directory: %s", cfg.Server.DataDir))
-		configDir, _ := config.UserConfigDir(config.AppBibd)
-		plan = append(plan, fmt.Sprintf("Remove config directory: %s", configDir))
+	if !cleanupData {
+		w.WriteInfo("The database is kept; add --data to remove it")
+	}
+	if plan.DryRun {
+		w.Println("Dry run: no changes were made.")
 	}
-
-	return plan
 }
 
-func cleanupPostgresContainers(ctx context.Context, cfg *config.BibdConfig, specificContainer string) error {
-	// Detect available container runtime
-	containerRuntime := detectContainerRuntime()
-	if containerRuntime == "" {
-		fmt.Println("  ⚠ No container runtime detected, skipping PostgreSQL cleanup")
-		return nil
+// buildCleanupPlan lists the resources in scope that exist. Workloads come
+// first, so that networks and volumes are no longer in use when they are
+// removed.
+func buildCleanupPlan(ctx context.Context, cfg *config.BibdConfig) (*cleanupPlan, error) {
+	plan := &cleanupPlan{}
+
+	// Deployment created by bib setup
+	if cleanupContainers || cleanupVolumes || cleanupNetworks || cleanupData {
+		target, dir, err := resolveDeployTarget(deploy.TargetType(cleanupTarget), cleanupDir)
+		switch {
+		case err == nil:
+			cleaner, err := newCleaner(target, dir, cleanupNamespace)
+			if err != nil {
+				return nil, err
+			}
+			scope := deploy.CleanupScope{
+				Workloads: cleanupContainers,
+				Volumes:   cleanupVolumes,
+				Networks:  cleanupNetworks,
+				Data:      cleanupData,
+				ScaleDown: target == deploy.TargetKubernetes && !cfg.Database.Postgres.DeleteOnCleanup,
+			}
+			steps, err := cleaner.PlanCleanup(ctx, scope)
+			if err != nil {
+				return nil, fmt.Errorf("failed to plan %s cleanup: %w", target, err)
+			}
+			plan.Target = string(target)
+			plan.ScaleDown = scope.ScaleDown
+			for _, step := range steps {
+				plan.Items = append(plan.Items, cleanupItem{
+					Kind:    step.Kind,
+					Name:    step.Name,
+					Action:  step.Action,
+					Command: step.Command,
+					run:     step.Run,
+				})
+			}
+		case cleanupTarget != "" || cleanupDir != "":
+			return nil, err
+		}
 	}
 
-	fmt.Printf("  Using %s container runtime\n", containerRuntime)
-
-	// List containers to clean up
-	var containers []string
-	if specificContainer != "" {
-		containers = []string{specificContainer}
-	} else {
-		// Find all bibd-postgres containers
-		listCmd := exec.CommandContext(ctx, containerRuntime, "ps", "-a", "--filter", "name=bibd-postgres", "--format", "{{.Names}}")
-		output, err := listCmd.Output()
+	// Containers and network of the PostgreSQL bibd manages
+	if cleanupContainers || cleanupPostgres || cleanupNetworks || cleanupContainer != "" {
+		items, err := planManagedPostgres(ctx, cfg)
 		if err != nil {
-			return fmt.Errorf("failed to list containers: %w", err)
+			return nil, err
 		}
-
-		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-		for _, line := range lines {
-			if line != "" {
-				containers = append(containers, line)
+		for _, item := range items {
+			if !plan.has(item.Kind, item.Name) {
+				plan.Items = append(plan.Items, item)
 			}
 		}
 	}
 
-	if len(containers) == 0 {
-		fmt.Println("  ✓ No PostgreSQL containers found")
-		return nil
+	dataDir := cfg.Server.DataDir
+	pgDataDir := cfg.Database.Postgres.DataDir
+	if pgDataDir == "" {
+		pgDataDir = filepath.Join(dataDir, "postgres")
 	}
+	configDir, _ := config.UserConfigDir(config.AppBibd)
 
-	// Stop and remove each container
-	for _, container := range containers {
-		fmt.Printf("  Stopping container: %s\n", container)
-		stopCmd := exec.CommandContext(ctx, containerRuntime, "stop", container)
-		if err := stopCmd.Run(); err != nil {
-			fmt.Printf("  ⚠ Failed to stop %s: %v\n", container, err)
+	var paths []string
+	if cleanupCerts {
+		if configDir != "" {
+			paths = append(paths, filepath.Join(configDir, "certs"))
 		}
-
-		fmt.Printf("  Removing container: %s\n", container)
-		rmCmd := exec.CommandContext(ctx, containerRuntime, "rm", "-f", container)
-		if err := rmCmd.Run(); err != nil {
-			fmt.Printf("  ⚠ Failed to remove %s: %v\n", container, err)
+		pgCertDir := cfg.Database.Postgres.TLS.CertDir
+		if pgCertDir == "" {
+			pgCertDir = filepath.Join(pgDataDir, "certs")
 		}
+		paths = append(paths, pgCertDir)
 	}
-
-	// Remove bridge network if it exists
-	networkName := "bibd-network"
-	fmt.Printf("  Checking for bridge network: %s\n", networkName)
-	rmNetCmd := exec.CommandContext(ctx, containerRuntime, "network", "rm", networkName)
-	if err := rmNetCmd.Run(); err != nil {
-		// Non-fatal, network might not exist
-		fmt.Printf("  ⚠ Could not remove network %s (may not exist)\n", networkName)
-	} else {
-		fmt.Printf("  ✓ Removed bridge network: %s\n", networkName)
+	if cleanupBackups {
+		paths = append(paths, filepath.Join(dataDir, "backups"))
 	}
-
-	// Remove PostgreSQL data directory
-	pgDataDir := filepath.Join(cfg.Server.DataDir, "postgres")
-	if _, err := os.Stat(pgDataDir); err == nil {
-		fmt.Printf("  Removing PostgreSQL data: %s\n", pgDataDir)
-		if err := os.RemoveAll(pgDataDir); err != nil {
-			return fmt.Errorf("failed to remove PostgreSQL data: %w", err)
+	if cleanupLogs {
+		logPath := cfg.Log.FilePath
+		if logPath == "" {
+			logPath = filepath.Join(dataDir, "bibd.log")
 		}
-		fmt.Println("  ✓ PostgreSQL data removed")
+		paths = append(paths, logPath, cfg.Log.AuditPath)
 	}
-
-	fmt.Printf("  ✓ Cleaned up %d PostgreSQL container(s)\n", len(containers))
-	return nil
-}
-
-func cleanupBackupFiles(cfg *config.BibdConfig) error {
-	backupPath := filepath.Join(cfg.Server.DataDir, "backups")
-
-	if _, err := os.Stat(backupPath); os.IsNotExist(err) {
-		fmt.Println("  ✓ No backup directory found")
-		return nil
+	if cleanupData {
+		sqlitePath := cfg.Database.SQLite.Path
+		if sqlitePath == "" {
+			sqlitePath = filepath.Join(dataDir, "cache.db")
+		}
+		paths = append(paths, pgDataDir, sqlitePath, sqlitePath+"-wal", sqlitePath+"-shm")
+		if cleanupAll {
+			paths = append(paths, dataDir)
+		}
+	}
+	if cleanupAll && configDir != "" {
+		paths = append(paths, configDir)
 	}
 
-	fmt.Printf("  Removing backups: %s\n", backupPath)
-	if err := os.RemoveAll(backupPath); err != nil {
-		return fmt.Errorf("failed to remove backups: %w", err)
+	for _, path := range paths {
+		if path == "" || plan.has("path", path) {
+			continue
+		}
+		if _, err := os.Lstat(path); err != nil {
+			continue
+		}
+		path := path
+		plan.Items = append(plan.Items, cleanupItem{
+			Kind:      "path",
+			Name:      path,
+			Action:    "remove",
+			SizeBytes: pathSize(path),
+			run:       func(context.Context) error { return os.RemoveAll(path) },
+		})
 	}
 
-	fmt.Println("  ✓ Backups removed")
-	return nil
+	return plan, nil
 }
 
-func cleanupLogFiles(cfg *config.BibdConfig) error {
-	// Remove main log
-	logPath := cfg.Log.FilePath
-	if logPath == "" {
-		logPath = filepath.Join(cfg.Server.DataDir, "bibd.log")
-	}
-	if logPath != "" {
-		if _, err := os.Stat(logPath); err == nil {
-			fmt.Printf("  Removing log file: %s\n", logPath)
-			if err := os.Remove(logPath); err != nil {
-				return fmt.Errorf("failed to remove log file: %w", err)
-			}
+// has reports whether the plan already contains the resource
+func (p *cleanupPlan) has(kind, name string) bool {
+	for _, item := range p.Items {
+		if item.Kind == kind && item.Name == name {
+			return true
 		}
 	}
+	return false
+}
 
-	// Remove audit log
-	if cfg.Log.AuditPath != "" {
-		if _, err := os.Stat(cfg.Log.AuditPath); err == nil {
-			fmt.Printf("  Removing audit log: %s\n", cfg.Log.AuditPath)
-			if err := os.Remove(cfg.Log.AuditPath); err != nil {
-				return fmt.Errorf("failed to remove audit log: %w", err)
-			}
+// planManagedPostgres lists the PostgreSQL containers bibd manages (or
+// --container) and, with --networks, their bridge network.
+func planManagedPostgres(ctx context.Context, cfg *config.BibdConfig) ([]cleanupItem, error) {
+	containerRuntime := cfg.Database.Postgres.ContainerRuntime
+	if containerRuntime != "docker" && containerRuntime != "podman" {
+		containerRuntime = detectContainerRuntime()
+	}
+	if containerRuntime == "" {
+		if cleanupContainer != "" {
+			return nil, fmt.Errorf("no container runtime detected to remove %s", cleanupContainer)
 		}
+		return nil, nil
 	}
 
-	fmt.Println("  ✓ Log files removed")
-	return nil
-}
-
-func cleanupCacheFiles(cfg *config.BibdConfig) error {
-	if cfg.Database.Backend != "sqlite" {
-		fmt.Println("  ✓ No cache files to remove (not using SQLite)")
-		return nil
+	var containers []string
+	if cleanupContainer != "" {
+		containers = append(containers, cleanupContainer)
+	}
+	if cleanupContainers || cleanupPostgres {
+		output, err := exec.CommandContext(ctx, containerRuntime, "ps", "-a",
+			"--filter", "name=bibd-postgres", "--format", "{{.Names}}").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list containers: %w", err)
+		}
+		containers = append(containers, strings.Fields(string(output))...)
 	}
 
-	cachePath := cfg.Database.SQLite.Path
-	if cachePath == "" {
-		cachePath = filepath.Join(cfg.Server.DataDir, "cache.db")
+	var items []cleanupItem
+	for _, name := range containers {
+		step := deploy.CleanupStep{Kind: "container", Name: name, Action: "remove",
+			Command: []string{containerRuntime, "rm", "-f", name}}
+		items = append(items, cleanupItem{Kind: step.Kind, Name: step.Name, Action: step.Action,
+			Command: step.Command, run: step.Run})
 	}
 
-	if _, err := os.Stat(cachePath); err == nil {
-		fmt.Printf("  Removing SQLite cache: %s\n", cachePath)
-		if err := os.Remove(cachePath); err != nil {
-			return fmt.Errorf("failed to remove cache: %w", err)
+	if network := cfg.Database.Postgres.Network.BridgeNetworkName; cleanupNetworks && network != "" {
+		if exec.CommandContext(ctx, containerRuntime, "network", "inspect", network).Run() == nil {
+			step := deploy.CleanupStep{Kind: "network", Name: network, Action: "remove",
+				Command: []string{containerRuntime, "network", "rm", network}}
+			items = append(items, cleanupItem{Kind: step.Kind, Name: step.Name, Action: step.Action,
+				Command: step.Command, run: step.Run})
 		}
-
-		// Also remove SQLite journal files (ignore errors)
-		_ = os.Remove(cachePath + "-wal")
-		_ = os.Remove(cachePath + "-shm")
 	}
 
-	fmt.Println("  ✓ Cache files removed")
-	return nil
+	return items, nil
 }
 
-func cleanupDataDirectory(cfg *config.BibdConfig) error {
-	fmt.Printf("  Removing data directory: %s\n", cfg.Server.DataDir)
-	if err := os.RemoveAll(cfg.Server.DataDir); err != nil {
-		return fmt.Errorf("failed to remove data directory: %w", err)
+// newCleaner returns the deployer for target, which implements deploy.Cleaner
+func newCleaner(target deploy.TargetType, dir, namespace string) (deploy.Cleaner, error) {
+	switch target {
+	case deploy.TargetDocker:
+		cfg := docker.DefaultDeployConfig()
+		cfg.OutputDir = dir
+		return docker.NewDeployer(cfg), nil
+	case deploy.TargetPodman:
+		cfg := podman.DefaultDeployConfig()
+		cfg.OutputDir = dir
+		return podman.NewDeployer(cfg), nil
+	case deploy.TargetKubernetes:
+		cfg := kubernetes.DefaultDeployConfig()
+		if dir != "" {
+			cfg.OutputDir = dir
+		}
+		cfg.ManifestConfig.Namespace = namespace
+		return kubernetes.NewDeployer(cfg), nil
+	default:
+		return nil, fmt.Errorf("cleanup is not supported for target %q", target)
 	}
+}
 
-	// Remove config directory
-	configDir, err := config.UserConfigDir(config.AppBibd)
-	if err == nil {
-		fmt.Printf("  Removing config directory: %s\n", configDir)
-		if err := os.RemoveAll(configDir); err != nil {
-			return fmt.Errorf("failed to remove config directory: %w", err)
+// pathSize returns the size of the files under path
+func pathSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
 		}
+		return nil
+	})
+	return size
+}
+
+// formatBytes formats bytes into human-readable format
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
 	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
 
-	fmt.Println("  ✓ All data removed")
-	return nil
+// capitalize upper-cases the first letter of an action for messages
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
 }
 
 func detectContainerRuntime() string {
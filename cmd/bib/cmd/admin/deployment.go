@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"bib/internal/deploy"
+	"bib/internal/deploy/docker"
+	"bib/internal/deploy/kubernetes"
+	"bib/internal/deploy/podman"
+)
+
+// deployMarkers identify a deploy target by the files setup generated
+var deployMarkers = []struct {
+	target deploy.TargetType
+	file   string
+}{
+	{deploy.TargetDocker, "docker-compose.yaml"},
+	{deploy.TargetPodman, "pod.yaml"},
+	{deploy.TargetPodman, "podman-compose.yaml"},
+	{deploy.TargetKubernetes, "kustomization.yaml"},
+}
+
+// resolveDeployTarget determines the deploy target and its directory. With
+// no --dir, the directories 'bib setup' writes for each target (in the home
+// directory) and the deployers' defaults (in the working directory) are
+// tried.
+func resolveDeployTarget(target deploy.TargetType, dir string) (deploy.TargetType, string, error) {
+	dirs := []string{dir}
+	if dir == "" {
+		dirs = nil
+		home, _ := os.UserHomeDir()
+		for _, d := range []string{
+			docker.DefaultDeployConfig().OutputDir,
+			podman.DefaultDeployConfig().OutputDir,
+			kubernetes.DefaultDeployConfig().OutputDir,
+		} {
+			if home != "" {
+				dirs = append(dirs, filepath.Join(home, filepath.Base(d)))
+			}
+			dirs = append(dirs, d)
+		}
+	}
+
+	for _, d := range dirs {
+		for _, m := range deployMarkers {
+			if target != "" && m.target != target {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(d, m.file)); err == nil {
+				return m.target, d, nil
+			}
+		}
+	}
+
+	// Kubernetes state lives in the cluster, so the directory is optional
+	if target == deploy.TargetKubernetes {
+		return target, dir, nil
+	}
+	if target != "" {
+		return "", "", fmt.Errorf("no %s deployment found; use --dir to point at the directory generated by 'bib setup'", target)
+	}
+	return "", "", fmt.Errorf("no deployment found; use --target and --dir")
+}
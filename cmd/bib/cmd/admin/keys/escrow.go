@@ -0,0 +1,101 @@
+package keys
+
+import (
+	"fmt"
+
+	"bib/internal/logger"
+	"bib/internal/storage/postgres/encryption"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	escrowRecipient string
+	escrowOut       string
+	escrowShares    string
+)
+
+// escrowCmd seals the encryption at rest key to an administrator key
+var escrowCmd = &cobra.Command{
+	Use:   "escrow",
+	Short: "Seal the encryption at rest key to an administrator key",
+	Long: `Seal the master key of the encrypted volume to an administrator's
+public key (see bib admin keys generate), so the volume can be recovered
+with the matching private key if the node identity is lost.
+
+bibd writes recovery shares, and an escrow if recovery.escrow_recipient is
+set, when it creates the volume. Use this command to escrow the key of a
+volume that already exists, or to hand out a new set of shares with
+--shares. Shares of different sets cannot be combined.
+
+Store the escrow apart from the node and from the recovery shares.`,
+	Example: `  # Escrow to the configured recipient
+  bib admin keys escrow
+
+  # Escrow to another administrator and write new shares
+  bib admin keys escrow --recipient ops.key.pub --out ops-escrow.json --shares ./shares`,
+	RunE: runEscrow,
+}
+
+func runEscrow(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	recipient := escrowRecipient
+	if recipient == "" {
+		recipient = cfg.Database.EncryptionAtRest.Recovery.EscrowRecipient
+	}
+	if recipient == "" {
+		return fmt.Errorf("no recipient: pass --recipient or set database.encryption_at_rest.recovery.escrow_recipient")
+	}
+	publicKey, err := readKey(recipient)
+	if err != nil {
+		return err
+	}
+
+	out := escrowOut
+	if out == "" {
+		out = cfg.RecoveryEscrowPath()
+	}
+
+	mgr, err := newManager(cfg)
+	if err != nil {
+		return err
+	}
+
+	escrow, err := mgr.Escrow(publicKey)
+	if err != nil {
+		return err
+	}
+	if err := encryption.WriteEscrow(out, escrow); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Escrow written to %s\n", out)
+
+	metadata := map[string]any{
+		"event":     "key_escrowed",
+		"recipient": escrow.Recipient,
+	}
+
+	if escrowShares != "" {
+		shares, err := mgr.GenerateRecoveryShares()
+		if err != nil {
+			return err
+		}
+		if err := encryption.WriteShares(escrowShares, shares); err != nil {
+			return err
+		}
+		metadata["shares"] = len(shares)
+		fmt.Printf("✓ %d recovery shares written to %s (%d needed to recover)\n",
+			len(shares), escrowShares, shares[0].Threshold)
+	}
+
+	audit(ctx, cmd, cfg, logger.AuditOutcomeSuccess, metadata)
+
+	fmt.Println("\nMove the escrow and the shares off this node and store them apart.")
+	return nil
+}
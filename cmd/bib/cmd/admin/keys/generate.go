@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"bib/internal/storage/postgres/encryption"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	generateOut   string
+	generateForce bool
+)
+
+// generateCmd generates an escrow key pair
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate an administrator key pair for escrows",
+	Long: `Generate an X25519 key pair that escrows are sealed to.
+
+The private key is written to --out and the public key to <out>.pub, both
+base64. Set the public key as database.encryption_at_rest.recovery.escrow_recipient
+or pass it to bib admin keys escrow, and keep the private key offline: it
+unlocks every volume escrowed to it.`,
+	Example: `  bib admin keys generate --out ~/bib-escrow.key`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		publicPath := generateOut + ".pub"
+		if !generateForce {
+			for _, path := range []string{generateOut, publicPath} {
+				if _, err := os.Stat(path); err == nil {
+					return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+				}
+			}
+		}
+
+		publicKey, privateKey, err := encryption.GenerateEscrowKey()
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(generateOut, []byte(base64.StdEncoding.EncodeToString(privateKey)+"\n"), 0600); err != nil {
+			return fmt.Errorf("failed to write private key: %w", err)
+		}
+		encodedPublic := base64.StdEncoding.EncodeToString(publicKey)
+		if err := os.WriteFile(publicPath, []byte(encodedPublic+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write public key: %w", err)
+		}
+
+		fmt.Printf("Private key: %s\n", generateOut)
+		fmt.Printf("Public key:  %s\n", publicPath)
+		fmt.Printf("\n  escrow_recipient: %q\n", encodedPublic)
+		fmt.Println("\nKeep the private key offline; it unlocks every volume escrowed to it.")
+		return nil
+	},
+}
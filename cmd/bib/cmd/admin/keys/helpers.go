@@ -0,0 +1,116 @@
+package keys
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+	"bib/internal/p2p"
+	"bib/internal/storage/postgres/encryption"
+
+	"github.com/spf13/cobra"
+)
+
+// loadConfig loads the bibd configuration and checks that encryption at
+// rest with LUKS is enabled.
+func loadConfig() (*config.BibdConfig, error) {
+	cfg, err := config.LoadBibd("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	encCfg := cfg.Database.EncryptionAtRest
+	if !encCfg.Enabled {
+		return nil, fmt.Errorf("encryption at rest is not enabled (database.encryption_at_rest.enabled)")
+	}
+	if encryption.Method(encCfg.Method) != encryption.MethodLUKS {
+		return nil, fmt.Errorf("unsupported encryption at rest method %q: only luks is supported", encCfg.Method)
+	}
+
+	return cfg, nil
+}
+
+// newManager creates the encryption manager of the node, keyed by its
+// identity.
+func newManager(cfg *config.BibdConfig) (*encryption.Manager, error) {
+	configDir, err := config.UserConfigDir(config.AppBibd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	identity, err := p2p.LoadIdentity(cfg.P2P.Identity.KeyPath, configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bibd identity: %w", err)
+	}
+	identityKey, err := identity.RawPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity key: %w", err)
+	}
+
+	return encryption.NewManager(encryptionConfig(&cfg.Database.EncryptionAtRest), identityKey)
+}
+
+// encryptionConfig converts the encryption at rest configuration to the
+// encryption configuration.
+func encryptionConfig(encCfg *config.EncryptionAtRestConfig) encryption.Config {
+	cfg := encryption.DefaultConfig()
+	cfg.Enabled = encCfg.Enabled
+	cfg.Method = encryption.Method(encCfg.Method)
+	cfg.LUKS = encryption.LUKSConfig{
+		VolumeSize:    encCfg.LUKS.VolumeSize,
+		Cipher:        encCfg.LUKS.Cipher,
+		KeySize:       encCfg.LUKS.KeySize,
+		HashAlgorithm: encCfg.LUKS.HashAlgorithm,
+	}
+	if encCfg.Recovery.TotalShares > 0 {
+		cfg.Recovery.Shamir.TotalShares = encCfg.Recovery.TotalShares
+	}
+	if encCfg.Recovery.Threshold > 0 {
+		cfg.Recovery.Shamir.Threshold = encCfg.Recovery.Threshold
+	}
+
+	return cfg
+}
+
+// readKey decodes a base64 key given directly or as the path of a file
+// holding it.
+func readKey(value string) ([]byte, error) {
+	encoded := value
+	if data, err := os.ReadFile(value); err == nil {
+		encoded = string(data)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a key file nor a base64 key", value)
+	}
+	return key, nil
+}
+
+// audit records an event in the bibd audit log. bibd is stopped while the
+// keys are recovered, so the log is written directly.
+func audit(ctx context.Context, cmd *cobra.Command, cfg *config.BibdConfig, outcome logger.AuditOutcome, metadata map[string]any) {
+	if cfg.Log.AuditPath == "" {
+		fmt.Println("WARNING: log.audit_path is not set in the bibd configuration; this is not audited")
+		return
+	}
+
+	auditLog, err := logger.NewAuditLoggerFromConfig(cfg.Log)
+	if err != nil {
+		fmt.Printf("WARNING: failed to open the bibd audit log: %v\n", err)
+		return
+	}
+	defer auditLog.Close()
+
+	auditLog.Log(ctx, logger.AuditEvent{
+		Action:   logger.AuditActionAccess,
+		Actor:    logger.NewCommandContext(cmd, nil).User,
+		Resource: "encryption_at_rest",
+		Outcome:  outcome,
+		Metadata: metadata,
+	})
+}
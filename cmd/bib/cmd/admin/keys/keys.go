@@ -0,0 +1,51 @@
+package keys
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Cmd represents the keys admin command group
+var Cmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Escrow and recover the encryption at rest key",
+	Long: `Escrow and recover the key of the encrypted volume that holds the
+bibd database (database.encryption_at_rest).
+
+The volume key is derived from the node identity. If the identity is
+lost, the volume can only be unlocked with the recovery shares written
+when it was created, or with an escrow sealed to an administrator's key.
+Recovery requires the configured threshold of shares and is recorded in
+the bibd audit log.
+
+These commands read the bibd configuration and identity, so they must
+run on the node, as the user bibd runs as.`,
+}
+
+// NewCommand returns the keys command with all subcommands registered
+func NewCommand() *cobra.Command {
+	Cmd.AddCommand(generateCmd)
+	Cmd.AddCommand(escrowCmd)
+	Cmd.AddCommand(recoverCmd)
+
+	return Cmd
+}
+
+func init() {
+	// Generate flags
+	generateCmd.Flags().StringVar(&generateOut, "out", "escrow.key", "Private key file; the public key is written to <out>.pub")
+	generateCmd.Flags().BoolVar(&generateForce, "force", false, "Overwrite existing key files")
+
+	// Escrow flags
+	escrowCmd.Flags().StringVar(&escrowRecipient, "recipient", "", "Administrator public key, base64 or a .pub file (default: recovery.escrow_recipient)")
+	escrowCmd.Flags().StringVar(&escrowOut, "out", "", "Escrow file (default: recovery.escrow_path)")
+	escrowCmd.Flags().StringVar(&escrowShares, "shares", "", "Also write a new set of recovery shares to this directory")
+
+	// Recover flags
+	recoverCmd.Flags().StringVar(&recoverShares, "shares", "", "Directory holding the recovery shares")
+	recoverCmd.Flags().StringVar(&recoverEscrow, "escrow", "", "Escrow file")
+	recoverCmd.Flags().StringVar(&recoverKey, "key", "", "Private key file of the escrow recipient")
+	recoverCmd.Flags().BoolVar(&recoverForce, "force", false, "Skip the confirmation prompt")
+	recoverCmd.MarkFlagsMutuallyExclusive("shares", "escrow")
+	recoverCmd.MarkFlagsOneRequired("shares", "escrow")
+	recoverCmd.MarkFlagsRequiredTogether("escrow", "key")
+}
@@ -0,0 +1,139 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"bib/internal/logger"
+	"bib/internal/storage/postgres/encryption"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	recoverShares string
+	recoverEscrow string
+	recoverKey    string
+	recoverForce  bool
+)
+
+// recoverCmd unlocks the encrypted volume without the identity key
+var recoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Unlock the encrypted volume with recovery shares or an escrow",
+	Long: `Unlock the encrypted volume when the node identity no longer does, and
+add the key derived from the current identity to it, so bibd unlocks it on
+its own again.
+
+The master key is recovered either from recovery shares, of which at least
+the configured threshold must be given, or from an escrow and the private
+key of its recipient. Every attempt is recorded in the bibd audit log.
+
+Requires root and cryptsetup. The bibd daemon must be stopped.`,
+	Example: `  # Recover with shares collected from their holders
+  sudo bib admin keys recover --shares ./shares
+
+  # Recover with an escrow
+  sudo bib admin keys recover --escrow escrow.json --key ~/bib-escrow.key`,
+	RunE: runRecover,
+}
+
+func runRecover(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	volumePath := encryption.LUKSVolumePath(cfg.Server.DataDir)
+	if _, err := os.Stat(volumePath); err != nil {
+		return fmt.Errorf("no encrypted volume at %s: %w", volumePath, err)
+	}
+
+	// Read the recovery material before asking anything
+	var (
+		shares     []encryption.Share
+		escrow     *encryption.Escrow
+		privateKey []byte
+		metadata   = map[string]any{"event": "volume_recovered"}
+	)
+	if recoverShares != "" {
+		shares, err = encryption.ReadShares(recoverShares)
+		if err != nil {
+			return fmt.Errorf("failed to read shares: %w", err)
+		}
+		if threshold := cfg.Database.EncryptionAtRest.Recovery.Threshold; len(shares) < threshold {
+			return fmt.Errorf("%w: need %d, got %d", encryption.ErrInsufficientShares, threshold, len(shares))
+		}
+		metadata["method"] = "shares"
+		metadata["shares"] = len(shares)
+	} else {
+		escrow, err = encryption.ReadEscrow(recoverEscrow)
+		if err != nil {
+			return fmt.Errorf("failed to read escrow: %w", err)
+		}
+		privateKey, err = readKey(recoverKey)
+		if err != nil {
+			return err
+		}
+		metadata["method"] = "escrow"
+		metadata["recipient"] = escrow.Recipient
+	}
+
+	mgr, err := newManager(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Recovering the encrypted volume %s\n", volumePath)
+	if !recoverForce {
+		fmt.Println("WARNING: The bibd daemon must be stopped.")
+		fmt.Print("Continue? (y/N): ")
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	err = unlock(ctx, mgr, cfg.Server.DataDir, shares, escrow, privateKey)
+	if err != nil {
+		metadata["error"] = err.Error()
+		audit(ctx, cmd, cfg, logger.AuditOutcomeFailure, metadata)
+		return fmt.Errorf("recovery failed: %w", err)
+	}
+	audit(ctx, cmd, cfg, logger.AuditOutcomeSuccess, metadata)
+
+	fmt.Println("\n✓ Volume recovered; bibd unlocks it with its identity again")
+	fmt.Println("Consider handing out new shares with bib admin keys escrow --shares.")
+	return nil
+}
+
+// unlock mounts the volume with the recovered master key, which adds the
+// identity key to it, and unmounts it again for bibd.
+func unlock(ctx context.Context, mgr *encryption.Manager, dataDir string, shares []encryption.Share, escrow *encryption.Escrow, privateKey []byte) error {
+	if err := mgr.Initialize(ctx, dataDir); err != nil {
+		return err
+	}
+
+	var err error
+	if escrow != nil {
+		err = mgr.MountWithEscrow(ctx, escrow, privateKey)
+	} else {
+		err = mgr.MountWithShares(ctx, shares)
+	}
+	if err != nil {
+		return err
+	}
+
+	unmountCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := mgr.Close(unmountCtx); err != nil {
+		return fmt.Errorf("volume recovered, but could not be unmounted: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package admin
+
+import (
+	"strings"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// maintenanceItem is the maintenance state as written by bib admin maintenance
+type maintenanceItem struct {
+	Paused   bool       `json:"paused" yaml:"paused"`
+	Reason   string     `json:"reason,omitempty" yaml:"reason,omitempty"`
+	PausedBy string     `json:"paused_by,omitempty" yaml:"paused_by,omitempty"`
+	PausedAt *time.Time `json:"paused_at,omitempty" yaml:"paused_at,omitempty"`
+	Workers  []string   `json:"workers" yaml:"workers"`
+	Waiting  []string   `json:"waiting,omitempty" yaml:"waiting,omitempty"`
+}
+
+func toMaintenanceItem(s *services.MaintenanceState) maintenanceItem {
+	item := maintenanceItem{
+		Paused:   s.GetPaused(),
+		Reason:   s.GetReason(),
+		PausedBy: s.GetPausedBy(),
+		Workers:  s.GetWorkers(),
+		Waiting:  s.GetWaiting(),
+	}
+	if s.GetPausedAt() != nil {
+		t := s.GetPausedAt().AsTime()
+		item.PausedAt = &t
+	}
+	return item
+}
+
+func newMaintenanceCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Pause and resume background maintenance",
+		Long: `Pause and resume the daemon's background maintenance workers, such as
+blob garbage collection, vacuum, credential rotation and sync. The status
+command lists the workers the daemon runs.
+
+Paused workers finish the step they are in and hold there; on resume they
+continue where they stopped. Work that fell due while paused runs once
+maintenance resumes. Pausing and resuming are recorded in the audit log.
+
+Requires the admin role.`,
+	}
+
+	cmd.AddCommand(newMaintenancePauseCommand(getClient))
+	cmd.AddCommand(newMaintenanceResumeCommand(getClient))
+	cmd.AddCommand(newMaintenanceStatusCommand(getClient))
+
+	return cmd
+}
+
+func newMaintenancePauseCommand(getClient ClientFunc) *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:     "pause",
+		Short:   "Pause the background maintenance workers",
+		Example: `  bib admin maintenance pause --reason "storage migration"`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.PauseMaintenance(ctx, &services.PauseMaintenanceRequest{Reason: reason})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(toMaintenanceItem(resp.GetState()))
+			}
+			if resp.GetChanged() {
+				w.Success("Maintenance paused")
+			} else {
+				w.Info("Maintenance was already paused")
+			}
+			writeMaintenanceState(w, toMaintenanceItem(resp.GetState()))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "why maintenance is paused, recorded in the audit log")
+
+	return cmd
+}
+
+func newMaintenanceResumeCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:     "resume",
+		Short:   "Resume the paused maintenance workers",
+		Example: `  bib admin maintenance resume`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.ResumeMaintenance(ctx, &services.ResumeMaintenanceRequest{})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(toMaintenanceItem(resp.GetState()))
+			}
+			if resp.GetChanged() {
+				w.Success("Maintenance resumed")
+			} else {
+				w.Info("Maintenance was not paused")
+			}
+			return nil
+		},
+	}
+}
+
+func newMaintenanceStatusCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:     "status",
+		Short:   "Show whether background maintenance is paused",
+		Example: `  bib admin maintenance status`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.GetSystemInfo(ctx, &services.GetSystemInfoRequest{})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(toMaintenanceItem(resp.GetMaintenance()))
+			}
+			writeMaintenanceState(w, toMaintenanceItem(resp.GetMaintenance()))
+			return nil
+		},
+	}
+}
+
+// writeMaintenanceState writes the maintenance state as text.
+func writeMaintenanceState(w *output.Writer, item maintenanceItem) {
+	if !item.Paused {
+		w.Println("State:   running")
+	} else {
+		state := "paused"
+		if item.PausedAt != nil {
+			state += " since " + item.PausedAt.Local().Format(time.DateTime)
+		}
+		if item.PausedBy != "" {
+			state += " by " + item.PausedBy
+		}
+		w.Println("State:   " + state)
+		if item.Reason != "" {
+			w.Println("Reason:  " + item.Reason)
+		}
+	}
+	if len(item.Workers) > 0 {
+		w.Println("Workers: " + strings.Join(item.Workers, ", "))
+	}
+	if len(item.Waiting) > 0 {
+		w.Println("Holding: " + strings.Join(item.Waiting, ", "))
+	}
+}
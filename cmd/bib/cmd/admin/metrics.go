@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"bib/internal/metrics"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func newMetricsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Work with bibd's Prometheus metrics",
+		Long: `Work with the Prometheus metrics bibd serves when server.grpc.metrics
+is enabled.`,
+	}
+
+	cmd.AddCommand(newMetricsRulesCommand())
+
+	return cmd
+}
+
+func newMetricsRulesCommand() *cobra.Command {
+	opts := metrics.DefaultRuleOptions()
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "rules",
+		Short: "Generate Prometheus alerting rules for bibd",
+		Long: `Generate a Prometheus alerting rules file for bibd's metrics.
+
+The rules alert when a node is down, a certificate is close to expiry,
+the cluster has lost quorum, a node has few P2P peers, storage is down, or
+a large share of requests is rate limited. Flags set the thresholds.
+
+The rules are always YAML, whatever the output format. Load the file with
+rule_files in prometheus.yml. --selector restricts the rules to bibd's
+scrape targets; it must match the labels of your scrape configuration.
+
+The daemon is not contacted.`,
+		Example: `  # Print the rules with the default thresholds
+  bib admin metrics rules
+
+  # Write rules for a scrape job named "bib-nodes", alerting on fewer than 5 peers
+  bib admin metrics rules --selector 'job="bib-nodes"' --min-peers 5 -f bibd-rules.yml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rules, err := metrics.Rules(opts)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			enc := yaml.NewEncoder(&buf)
+			enc.SetIndent(2)
+			if err := enc.Encode(rules); err != nil {
+				return fmt.Errorf("failed to encode rules: %w", err)
+			}
+			if err := enc.Close(); err != nil {
+				return fmt.Errorf("failed to encode rules: %w", err)
+			}
+
+			if file == "" {
+				_, err := cmd.OutOrStdout().Write(buf.Bytes())
+				return err
+			}
+			if err := os.WriteFile(file, buf.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write rules: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d rules to %s\n", len(rules.Groups[0].Rules), file)
+			return nil
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&file, "file", "f", "", "write the rules to a file instead of stdout")
+	f.StringVar(&opts.Group, "group", opts.Group, "name of the rule group")
+	f.StringVar(&opts.Selector, "selector", opts.Selector, "label matchers added to every expression (empty: none)")
+	f.IntVar(&opts.CertWarningDays, "cert-warning-days", opts.CertWarningDays, "warn when a certificate expires within this many days")
+	f.IntVar(&opts.CertCriticalDays, "cert-critical-days", opts.CertCriticalDays, "page when a certificate expires within this many days")
+	f.IntVar(&opts.MinPeers, "min-peers", opts.MinPeers, "warn when a node has fewer P2P peers")
+	f.Float64Var(&opts.RateLimitRatio, "rate-limit-ratio", opts.RateLimitRatio, "warn when this share of requests (0-1) is rate limited")
+	f.DurationVar(&opts.RateWindow, "rate-window", opts.RateWindow, "range over which request rates are computed")
+
+	return cmd
+}
@@ -36,6 +36,8 @@ func (o *OutputWriter) Write(data any) error {
 	switch o.format {
 	case "json":
 		return o.writeJSON(data)
+	case "jsonl":
+		return o.writeJSONL(data)
 	case "yaml":
 		return o.writeYAML(data)
 	case "quiet":
@@ -54,6 +56,15 @@ func (o *OutputWriter) writeJSON(data any) error {
 	return nil
 }
 
+func (o *OutputWriter) writeJSONL(data any) error {
+	output, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Fprintln(o.out, string(output))
+	return nil
+}
+
 func (o *OutputWriter) writeYAML(data any) error {
 	output, err := yaml.Marshal(data)
 	if err != nil {
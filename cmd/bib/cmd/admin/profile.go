@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// profileTypes maps the profile names accepted by bib admin profile
+var profileTypes = map[string]services.ProfileType{
+	"cpu":       services.ProfileType_PROFILE_TYPE_CPU,
+	"heap":      services.ProfileType_PROFILE_TYPE_HEAP,
+	"allocs":    services.ProfileType_PROFILE_TYPE_ALLOCS,
+	"goroutine": services.ProfileType_PROFILE_TYPE_GOROUTINE,
+	"block":     services.ProfileType_PROFILE_TYPE_BLOCK,
+	"mutex":     services.ProfileType_PROFILE_TYPE_MUTEX,
+}
+
+// profileResult is the output of bib admin profile
+type profileResult struct {
+	NodeID     string        `json:"node_id" yaml:"node_id"`
+	Type       string        `json:"type" yaml:"type"`
+	File       string        `json:"file" yaml:"file"`
+	Bytes      int           `json:"bytes" yaml:"bytes"`
+	Duration   time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+	CapturedAt time.Time     `json:"captured_at" yaml:"captured_at"`
+}
+
+func newProfileCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		duration time.Duration
+		file     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "profile <cpu|heap|allocs|goroutine|block|mutex>",
+		Short: "Capture a pprof profile of the daemon",
+		Long: `Capture a pprof profile of the daemon.
+
+heap, allocs and goroutine profiles are snapshots taken immediately. cpu,
+block and mutex profiles are sampled for --duration; only one of them can
+be captured at a time.
+
+The profile is written in the gzipped protobuf format of pprof:
+
+  go tool pprof -http :8081 cpu-20261015T120000.pb.gz
+
+Profiling is disabled by default. Enable it on the daemon with
+server.grpc.profiling.enabled; server.grpc.profiling.max_duration bounds
+--duration. Requires the admin role.`,
+		Example: `  # Sample CPU usage for 30 seconds
+  bib admin profile cpu
+
+  # Snapshot the heap into a given file
+  bib admin profile heap --file heap.pb.gz
+
+  # Find contended locks over a minute
+  bib admin profile mutex --duration 1m`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"cpu", "heap", "allocs", "goroutine", "block", "mutex"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			name := strings.ToLower(args[0])
+			typ, ok := profileTypes[name]
+			if !ok {
+				return fmt.Errorf("unknown profile type %q (use cpu, heap, allocs, goroutine, block or mutex)", args[0])
+			}
+			if file == "" {
+				file = fmt.Sprintf("%s-%s.pb.gz", name, time.Now().Format("20060102T150405"))
+			}
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			req := &services.CaptureProfileRequest{Type: typ}
+			if duration > 0 {
+				req.Duration = durationpb.New(duration)
+			}
+			resp, err := adminClient.CaptureProfile(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(file, resp.GetData(), 0600); err != nil {
+				return fmt.Errorf("failed to write profile: %w", err)
+			}
+
+			result := profileResult{
+				NodeID:     resp.GetNodeId(),
+				Type:       name,
+				File:       file,
+				Bytes:      len(resp.GetData()),
+				Duration:   resp.GetDuration().AsDuration(),
+				CapturedAt: resp.GetCapturedAt().AsTime(),
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(result)
+			}
+			w.Success(fmt.Sprintf("Wrote %s profile to %s; inspect it with 'go tool pprof %s'", name, file, file))
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&duration, "duration", 0, "how long to sample cpu, block and mutex profiles (default: 30s)")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write the profile to (default: <type>-<time>.pb.gz)")
+
+	return cmd
+}
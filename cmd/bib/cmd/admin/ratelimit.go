@@ -0,0 +1,140 @@
+package admin
+
+import (
+	"fmt"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// blockItem is a rate limit block as written by bib admin ratelimit
+type blockItem struct {
+	Key       string    `json:"key" yaml:"key"`
+	Kind      string    `json:"kind" yaml:"kind"`
+	Subject   string    `json:"subject" yaml:"subject"`
+	Rule      string    `json:"rule,omitempty" yaml:"rule,omitempty"`
+	Reason    string    `json:"reason,omitempty" yaml:"reason,omitempty"`
+	BlockedAt time.Time `json:"blocked_at" yaml:"blocked_at"`
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+func toBlockItem(b *services.RateLimitBlock) blockItem {
+	return blockItem{
+		Key:       b.GetKey(),
+		Kind:      b.GetKind(),
+		Subject:   b.GetSubject(),
+		Rule:      b.GetRule(),
+		Reason:    b.GetReason(),
+		BlockedAt: b.GetBlockedAt().AsTime(),
+		ExpiresAt: b.GetExpiresAt().AsTime(),
+	}
+}
+
+func newRateLimitCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ratelimit",
+		Short: "Manage users and roles blocked by audit alerts",
+		Long: `Manage the users and roles blocked by audit alerts.
+
+When an audit alert rule with trigger_rate_limit fires, bibd blocks the
+user who caused it, or their role for rules grouping by role, for the
+configured block duration. Their requests are rejected as rate limited
+until the block expires or an administrator lifts it. Admins and the
+bypass roles are never blocked.
+
+Requires the admin role.`,
+	}
+
+	cmd.AddCommand(newRateLimitListCommand(getClient))
+	cmd.AddCommand(newRateLimitUnblockCommand(getClient))
+
+	return cmd
+}
+
+func newRateLimitListCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List the active blocks",
+		Example: `  bib admin ratelimit list`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.ListRateLimitBlocks(ctx, &services.ListRateLimitBlocksRequest{})
+			if err != nil {
+				return err
+			}
+
+			s := newBlockStream(cmd)
+			for _, b := range resp.GetBlocks() {
+				if err := s.Write(toBlockItem(b)); err != nil {
+					return err
+				}
+			}
+			return s.Close()
+		},
+	}
+}
+
+func newRateLimitUnblockCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unblock <key>",
+		Short: "Lift a block before it expires",
+		Long: `Lift a block before it expires.
+
+The key is the KEY column of 'bib admin ratelimit list', e.g.
+actor:3f2a9c1e-... for a user or role:readonly for a role.`,
+		Example: `  bib admin ratelimit unblock role:readonly`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.UnblockRateLimit(ctx, &services.UnblockRateLimitRequest{Key: args[0]})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() == output.FormatTable {
+				w.Success(fmt.Sprintf("Unblocked %s", resp.GetBlock().GetKey()))
+				return nil
+			}
+			return w.Write(toBlockItem(resp.GetBlock()))
+		},
+	}
+}
+
+// newBlockStream creates the output stream for rate limit blocks.
+func newBlockStream(cmd *cobra.Command) *output.Stream[blockItem] {
+	w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+	return output.NewStream(w,
+		[]string{"KEY", "RULE", "BLOCKED", "EXPIRES"},
+		func(b blockItem) []string {
+			return []string{
+				b.Key, b.Rule,
+				b.BlockedAt.Local().Format(time.DateTime),
+				b.ExpiresAt.Local().Format(time.DateTime),
+			}
+		})
+}
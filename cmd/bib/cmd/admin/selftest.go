@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// selfTestStepItem is a self-test step as written by bib admin selftest
+type selfTestStepItem struct {
+	Name     string        `json:"name" yaml:"name"`
+	Status   string        `json:"status" yaml:"status"`
+	Duration time.Duration `json:"duration" yaml:"duration"`
+	Message  string        `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// selfTestResult is the output of bib admin selftest
+type selfTestResult struct {
+	NodeID   string             `json:"node_id" yaml:"node_id"`
+	Passed   bool               `json:"passed" yaml:"passed"`
+	Duration time.Duration      `json:"duration" yaml:"duration"`
+	Steps    []selfTestStepItem `json:"steps" yaml:"steps"`
+}
+
+func toSelfTestResult(resp *services.SelfTestResponse) selfTestResult {
+	result := selfTestResult{
+		NodeID:   resp.GetNodeId(),
+		Passed:   resp.GetPassed(),
+		Duration: resp.GetDuration().AsDuration(),
+	}
+	for _, s := range resp.GetSteps() {
+		result.Steps = append(result.Steps, selfTestStepItem{
+			Name:     s.GetName(),
+			Status:   selfTestStatusName(s.GetStatus()),
+			Duration: s.GetDuration().AsDuration(),
+			Message:  s.GetMessage(),
+		})
+	}
+	return result
+}
+
+// selfTestStatusName turns SELF_TEST_STATUS_PASSED into "passed"
+func selfTestStatusName(s services.SelfTestStatus) string {
+	return strings.ToLower(strings.TrimPrefix(s.String(), "SELF_TEST_STATUS_"))
+}
+
+func newSelfTestCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		peerID  string
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Exercise the daemon's storage and P2P paths end to end",
+		Long: `Exercise the daemon's storage and P2P paths end to end.
+
+Unlike the health checks, which only report state, the self test actively
+uses the data path:
+
+  storage_write    writes a temporary node record
+  storage_read     reads the record back
+  storage_delete   deletes the record and checks it is gone
+  pubsub           publishes a message on a private topic and receives it
+  peer_ping        pings --peer, or the first connected peer
+
+Each step reports passed, failed or skipped with its duration. Steps are
+skipped when the node lacks what they test, e.g. P2P is disabled or no
+peer is connected. The command exits non-zero if any step failed.
+
+Run it right after 'bib setup' or 'bib admin upgrade'. Requires the admin
+role.`,
+		Example: `  # Run the self test
+  bib admin selftest
+
+  # Ping a specific peer and allow each step 30 seconds
+  bib admin selftest --peer 12D3KooW... --timeout 30s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			req := &services.SelfTestRequest{PeerId: peerID}
+			if timeout > 0 {
+				req.Timeout = durationpb.New(timeout)
+			}
+			resp, err := adminClient.SelfTest(ctx, req)
+			if err != nil {
+				return err
+			}
+			result := toSelfTestResult(resp)
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				if err := w.Write(result); err != nil {
+					return err
+				}
+			} else {
+				s := output.NewStream(w,
+					[]string{"STEP", "STATUS", "DURATION", "MESSAGE"},
+					func(s selfTestStepItem) []string {
+						return []string{s.Name, s.Status, s.Duration.Round(time.Microsecond).String(), s.Message}
+					})
+				for _, step := range result.Steps {
+					if err := s.Write(step); err != nil {
+						return err
+					}
+				}
+				if err := s.Close(); err != nil {
+					return err
+				}
+				if result.Passed {
+					w.Success(fmt.Sprintf("Self test passed in %s", result.Duration.Round(time.Millisecond)))
+				}
+			}
+
+			if !result.Passed {
+				return fmt.Errorf("self test failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&peerID, "peer", "", "peer ID to ping (default: first connected peer)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "timeout for each step (default: 10s)")
+
+	return cmd
+}
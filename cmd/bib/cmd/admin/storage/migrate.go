@@ -0,0 +1,263 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bib/internal/config"
+	"bib/internal/p2p"
+	"bib/internal/storage"
+	"bib/internal/storage/blob"
+	_ "bib/internal/storage/postgres"
+	"bib/internal/storage/postgres/encryption"
+	_ "bib/internal/storage/sqlite"
+	"bib/internal/storage/transfer"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateTo     string
+	migrateDryRun bool
+	migrateForce  bool
+)
+
+// migrateCmd copies all data to another storage backend
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move all data to another storage backend",
+	Long: `Copy all data from the configured storage backend to another one.
+
+Users with their keys and preferences, topics with their members,
+datasets with their versions and chunks, and the audit log are copied
+into the target in a single transaction, keeping their IDs, timestamps
+and audit hashes. The target is then read back and compared with the
+source. Jobs, sessions, node records, invitations, peer lists and query
+history are not copied.
+
+Blobs are stored by content hash outside the database and are shared by
+both backends, so they stay where they are; the migration checks that
+every blob a chunk refers to is present.
+
+The target is read from the same configuration file: a PostgreSQL target
+must be an external server set in database.postgres.advanced, and must
+not hold any data yet. The source is left unchanged. Once the migration
+succeeds, set database.backend to the target and start bibd.
+
+WARNING: The bibd daemon must be stopped while migrating.`,
+	Example: `  # Check what would be copied
+  bib admin storage migrate --to postgres --dry-run
+
+  # Move from SQLite to PostgreSQL
+  bib admin storage migrate --to postgres`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrate(cmd.Context())
+	},
+}
+
+func runMigrate(ctx context.Context) error {
+	// Load configuration
+	cfg, err := config.LoadBibd("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	from := storage.BackendType(cfg.Database.Backend)
+	if from == "" {
+		from = storage.BackendSQLite
+	}
+	to := storage.BackendType(migrateTo)
+
+	switch {
+	case to != storage.BackendSQLite && to != storage.BackendPostgres:
+		return fmt.Errorf("unsupported target backend %q: use postgres or sqlite", migrateTo)
+	case from == storage.BackendMemory:
+		return fmt.Errorf("memory storage keeps no data after bibd stops; there is nothing to migrate")
+	case from == to:
+		return fmt.Errorf("bibd already uses the %s backend", to)
+	}
+
+	srcCfg := storageConfig(&cfg.Database, from)
+	dstCfg := storageConfig(&cfg.Database, to)
+	if from == storage.BackendSQLite || to == storage.BackendSQLite {
+		key, err := sqliteKey(cfg)
+		if err != nil {
+			return err
+		}
+		srcCfg.SQLite.Key = key
+		dstCfg.SQLite.Key = key
+	}
+	// Managed PostgreSQL only runs while bibd does
+	if (from == storage.BackendPostgres || to == storage.BackendPostgres) && dstCfg.Postgres.Advanced == nil {
+		return fmt.Errorf("migrating with PostgreSQL requires an external server: set database.postgres.advanced in the bibd configuration")
+	}
+
+	nodeID := cfg.Cluster.NodeID
+	if nodeID == "" {
+		nodeID = "standalone"
+	}
+
+	fmt.Printf("Migrating storage from %s to %s\n", from, to)
+	if migrateDryRun {
+		fmt.Println("Dry run: nothing will be copied")
+	} else if !migrateForce {
+		fmt.Println("WARNING: The bibd daemon must be stopped while migrating.")
+		fmt.Print("Continue? (y/N): ")
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if response != "y" && response != "Y" {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	ctx = storage.WithOperationContext(ctx, storage.NewOperationContext(storage.RoleAdmin, "admin-cli"))
+
+	// Open the source with its blob store; garbage collection must not
+	// run while the data is being moved
+	srcCfg.Blob.GC.Enabled = false
+	src, blobMgrIface, err := storage.OpenWithBlob(ctx, srcCfg, cfg.Server.DataDir, nodeID, "proxy", nil)
+	if err != nil {
+		return fmt.Errorf("failed to open %s storage: %w", from, err)
+	}
+	defer src.Close()
+
+	blobManager, ok := blobMgrIface.(*blob.Manager)
+	if !ok {
+		return fmt.Errorf("blob manager type assertion failed")
+	}
+	defer blobManager.Close()
+
+	dst, err := storage.Open(ctx, dstCfg, cfg.Server.DataDir, nodeID, "proxy")
+	if err != nil {
+		return fmt.Errorf("failed to open %s storage: %w", to, err)
+	}
+	defer dst.Close()
+
+	report, err := transfer.Run(ctx, src, dst, transfer.Options{
+		DryRun:   migrateDryRun,
+		Blobs:    blobManager.Store(),
+		Progress: printProgress,
+	})
+	if report != nil {
+		printReport(report)
+	}
+	if err != nil {
+		if errors.Is(err, transfer.ErrTargetNotEmpty) {
+			return fmt.Errorf("%w; migrate into a fresh %s database", err, to)
+		}
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	if migrateDryRun {
+		fmt.Println("\n✓ Dry run passed")
+		return nil
+	}
+
+	fmt.Println("\n✓ Migration completed and verified")
+	fmt.Printf("\nSet database.backend to %q in the bibd configuration and start bibd.\n", to)
+	fmt.Printf("The %s data is left unchanged; remove it once the new backend works.\n", from)
+	return nil
+}
+
+// printProgress prints how far the copy of an entity is, on one line.
+func printProgress(entity string, done, total int) {
+	fmt.Printf("\r  Copying %s: %d/%d", entity, done, total)
+	if done == total {
+		fmt.Println()
+	}
+}
+
+// printReport prints the records and blobs of a migration.
+func printReport(report *transfer.Report) {
+	fmt.Println()
+	if report.Target != nil {
+		fmt.Printf("%-20s %10s %10s\n", "ENTITY", "SOURCE", "TARGET")
+		for i, count := range report.Source {
+			fmt.Printf("%-20s %10d %10d\n", count.Entity, count.Records, report.Target[i].Records)
+		}
+	} else {
+		fmt.Printf("%-20s %10s\n", "ENTITY", "SOURCE")
+		for _, count := range report.Source {
+			fmt.Printf("%-20s %10d\n", count.Entity, count.Records)
+		}
+	}
+
+	fmt.Printf("\nBlobs referenced: %d\n", report.Blobs)
+	if len(report.MissingBlobs) > 0 {
+		fmt.Printf("WARNING: %d blobs are missing from the blob store:\n", len(report.MissingBlobs))
+		for _, hash := range report.MissingBlobs {
+			fmt.Printf("  %s\n", hash)
+		}
+	}
+	fmt.Printf("Duration: %s\n", report.Duration.Round(time.Millisecond))
+}
+
+// storageConfig converts the database configuration to the storage
+// configuration of backend.
+// sqliteKey returns the SQLCipher key bibd opens the SQLite database with,
+// or nil if database.sqlite.encryption is disabled.
+func sqliteKey(cfg *config.BibdConfig) (*storage.SQLiteKey, error) {
+	encCfg := cfg.Database.SQLite.Encryption
+	if !encCfg.Enabled {
+		return nil, nil
+	}
+
+	switch encCfg.KeySource {
+	case "", "identity":
+		configDir, err := config.UserConfigDir(config.AppBibd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config directory: %w", err)
+		}
+		identity, err := p2p.LoadIdentity(cfg.P2P.Identity.KeyPath, configDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bibd identity: %w", err)
+		}
+		identityKey, err := identity.RawPrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get identity key: %w", err)
+		}
+		key, err := encryption.DeriveSQLiteKey(identityKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive the SQLite key: %w", err)
+		}
+		return &storage.SQLiteKey{Raw: key}, nil
+	case "passphrase":
+		if encCfg.Passphrase == "" {
+			return nil, fmt.Errorf("database.sqlite.encryption.key_source passphrase requires database.sqlite.encryption.passphrase")
+		}
+		return &storage.SQLiteKey{Passphrase: encCfg.Passphrase}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database.sqlite.encryption.key_source %q: use identity or passphrase", encCfg.KeySource)
+	}
+}
+
+func storageConfig(cfg *config.DatabaseConfig, backend storage.BackendType) storage.Config {
+	storageConfig := storage.DefaultConfig()
+	storageConfig.Backend = backend
+
+	storageConfig.SQLite.Path = cfg.SQLite.Path
+	if cfg.SQLite.MaxOpenConns > 0 {
+		storageConfig.SQLite.MaxOpenConns = cfg.SQLite.MaxOpenConns
+	}
+
+	if advanced := cfg.Postgres.Advanced; advanced != nil {
+		storageConfig.Postgres.Managed = false
+		storageConfig.Postgres.Advanced = &storage.AdvancedPostgresConfig{
+			Host:     advanced.Host,
+			Port:     advanced.Port,
+			Database: advanced.Database,
+			User:     advanced.User,
+			Password: advanced.Password,
+			SSLMode:  advanced.SSLMode,
+		}
+	}
+
+	storageConfig.Audit.Enabled = cfg.Audit.Enabled
+	storageConfig.Audit.RetentionDays = cfg.Audit.RetentionDays
+	storageConfig.Audit.HashChain = cfg.Audit.HashChain
+
+	return storageConfig
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Cmd represents the storage admin command group
+var Cmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Manage the storage backend",
+	Long: `Manage the storage backend of the bibd daemon.
+
+These commands work on the database directly and read the bibd
+configuration; the bibd daemon should be stopped before running them.`,
+}
+
+// NewCommand returns the storage command with all subcommands registered
+func NewCommand() *cobra.Command {
+	Cmd.AddCommand(migrateCmd)
+
+	return Cmd
+}
+
+func init() {
+	// Migrate flags
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "Target backend: postgres or sqlite")
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Check the migration without copying anything")
+	migrateCmd.Flags().BoolVar(&migrateForce, "force", false, "Skip the confirmation prompt")
+	_ = migrateCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,210 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/deploy"
+	"bib/internal/deploy/docker"
+	"bib/internal/deploy/kubernetes"
+	"bib/internal/deploy/podman"
+
+	"github.com/spf13/cobra"
+)
+
+// upgradeResult is the output of bib admin upgrade
+type upgradeResult struct {
+	Target          string `json:"target" yaml:"target"`
+	PreviousVersion string `json:"previous_version" yaml:"previous_version"`
+	Version         string `json:"version" yaml:"version"`
+	PreviousImage   string `json:"previous_image" yaml:"previous_image"`
+	Image           string `json:"image" yaml:"image"`
+	BackupID        string `json:"backup_id,omitempty" yaml:"backup_id,omitempty"`
+	RolledBack      bool   `json:"rolled_back" yaml:"rolled_back"`
+	DryRun          bool   `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+}
+
+func newUpgradeCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		targetVersion  string
+		target         string
+		dir            string
+		namespace      string
+		image          string
+		timeout        time.Duration
+		skipBackup     bool
+		allowDowngrade bool
+		dryRun         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade a deployed bibd to a new version",
+		Long: `Upgrade a bibd deployed by 'bib setup' to a new version.
+
+The daemon first takes a backup and records the upgrade in the audit log.
+The new image is then pulled and bibd restarted on it:
+
+  docker       the bibd container is recreated with docker compose
+  podman       the pod is replaced (or the container recreated with compose)
+  kubernetes   the bibd Deployment image is patched (rolling update)
+
+bibd must become healthy and report the new version before the timeout,
+otherwise the previous image is restored.
+
+The deploy target is detected from the files in --dir (default: the
+directories 'bib setup' writes for each target).`,
+		Example: `  # Upgrade a Docker deployment
+  bib admin upgrade --version 0.2.0
+
+  # Upgrade a Kubernetes deployment in a custom namespace
+  bib admin upgrade --version 0.2.0 --target kubernetes --namespace bib
+
+  # Show what would change
+  bib admin upgrade --version 0.2.0 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if targetVersion == "" {
+				return fmt.Errorf("--version is required")
+			}
+
+			targetType, targetDir, err := resolveDeployTarget(deploy.TargetType(target), dir)
+			if err != nil {
+				return err
+			}
+			upgrader, err := newUpgrader(targetType, targetDir, namespace)
+			if err != nil {
+				return err
+			}
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+			healthClient, err := c.Health()
+			if err != nil {
+				return err
+			}
+
+			prep, err := adminClient.Upgrade(ctx, &services.UpgradeRequest{
+				Version:        targetVersion,
+				SkipBackup:     skipBackup,
+				DryRun:         dryRun,
+				AllowDowngrade: allowDowngrade,
+			})
+			if err != nil {
+				return fmt.Errorf("daemon refused upgrade: %w", err)
+			}
+			if !prep.GetAccepted() {
+				fmt.Println(prep.GetMessage())
+				return nil
+			}
+			if prep.GetBackupId() != "" {
+				fmt.Printf("Pre-upgrade backup: %s\n", prep.GetBackupId())
+			}
+
+			out := upgradeResult{
+				Target:          string(targetType),
+				PreviousVersion: prep.GetCurrentVersion(),
+				BackupID:        prep.GetBackupId(),
+				DryRun:          dryRun,
+			}
+
+			result, err := deploy.Upgrade(ctx, upgrader, deploy.UpgradeOptions{
+				Version: targetVersion,
+				Image:   image,
+				Timeout: timeout,
+				DryRun:  dryRun,
+				VersionCheck: func(ctx context.Context) (string, error) {
+					callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+					defer cancel()
+					info, err := healthClient.GetNodeInfo(callCtx, &services.GetNodeInfoRequest{})
+					if err != nil {
+						return "", err
+					}
+					return info.GetVersion(), nil
+				},
+				Log: func(msg string) { fmt.Fprintln(os.Stderr, msg) },
+			})
+			if result != nil {
+				out.PreviousImage = result.PreviousImage
+				out.Image = result.Image
+				out.Version = result.Version
+				out.RolledBack = result.RolledBack
+			}
+			if err != nil {
+				if result != nil && result.RolledBack {
+					_ = writeUpgradeResult(out)
+				}
+				return err
+			}
+
+			if dryRun {
+				out.Version = targetVersion
+			}
+			return writeUpgradeResult(out)
+		},
+	}
+
+	cmd.Flags().StringVar(&targetVersion, "version", "", "Version to upgrade to (used as the image tag)")
+	cmd.Flags().StringVar(&target, "target", "", "Deploy target: docker, podman, kubernetes (default: detect)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory generated by 'bib setup' for the target")
+	cmd.Flags().StringVar(&namespace, "namespace", kubernetes.DefaultManifestConfig().Namespace, "Kubernetes namespace")
+	cmd.Flags().StringVar(&image, "image", "", "Image repository (default: the deployed one)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Time allowed for bibd to become healthy on the new version")
+	cmd.Flags().BoolVar(&skipBackup, "skip-backup", false, "Do not take a backup before upgrading")
+	cmd.Flags().BoolVar(&allowDowngrade, "allow-downgrade", false, "Allow upgrading to an older version")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the upgrade without changing anything")
+
+	return cmd
+}
+
+// writeUpgradeResult prints the result; table output is a short summary
+func writeUpgradeResult(out upgradeResult) error {
+	w := NewOutputWriter()
+	if w.format != "table" {
+		return w.Write(out)
+	}
+
+	switch {
+	case out.RolledBack:
+		w.WriteError(fmt.Sprintf("Upgrade to %s failed; rolled back to %s", out.Image, out.PreviousImage))
+	case out.DryRun:
+		w.WriteInfo(fmt.Sprintf("Would upgrade %s deployment from %s to %s", out.Target, out.PreviousImage, out.Image))
+	default:
+		w.WriteSuccess(fmt.Sprintf("Upgraded bibd from %s to %s", out.PreviousVersion, out.Version))
+		w.Printf("  Image: %s\n", out.Image)
+	}
+	return nil
+}
+
+// newUpgrader returns the deployer for target, which implements deploy.Upgrader
+func newUpgrader(target deploy.TargetType, dir, namespace string) (deploy.Upgrader, error) {
+	switch target {
+	case deploy.TargetDocker:
+		cfg := docker.DefaultDeployConfig()
+		cfg.OutputDir = dir
+		return docker.NewDeployer(cfg), nil
+	case deploy.TargetPodman:
+		cfg := podman.DefaultDeployConfig()
+		cfg.OutputDir = dir
+		return podman.NewDeployer(cfg), nil
+	case deploy.TargetKubernetes:
+		cfg := kubernetes.DefaultDeployConfig()
+		if dir != "" {
+			cfg.OutputDir = dir
+		}
+		cfg.ManifestConfig.Namespace = namespace
+		return kubernetes.NewDeployer(cfg), nil
+	default:
+		return nil, fmt.Errorf("upgrade is not supported for target %q", target)
+	}
+}
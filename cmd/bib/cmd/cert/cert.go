@@ -17,7 +17,8 @@ The cert command provides subcommands for:
 - Generating client certificates
 - Listing and inspecting certificates
 - Exporting certificates in various formats
-- Revoking certificates`,
+- Revoking certificates
+- Requesting and importing certificates from an external CA`,
 		Aliases: []string{"certs", "certificate"},
 	}
 
@@ -28,6 +29,8 @@ The cert command provides subcommands for:
 		newInfoCommand(),
 		newExportCommand(),
 		newRevokeCommand(),
+		newCSRCommand(),
+		newImportCommand(),
 	)
 
 	return cmd
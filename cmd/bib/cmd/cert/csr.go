@@ -0,0 +1,195 @@
+package cert
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bib/internal/certs"
+	"bib/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// serverTLSFiles returns the certificate, key and CA files configured for
+// bibd, used as defaults of the external PKI commands.
+func serverTLSFiles() (certFile, keyFile, caFile string) {
+	cfg, err := config.LoadBibd("")
+	if err != nil {
+		return "", "", ""
+	}
+	return cfg.Server.TLS.CertFile, cfg.Server.TLS.KeyFile, cfg.Server.TLS.CAFile
+}
+
+func newCSRCommand() *cobra.Command {
+	var (
+		keyFile    string
+		outFile    string
+		commonName string
+		dnsNames   []string
+		ipAddrs    []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "csr",
+		Short: "Create a certificate signing request for bibd",
+		Long: `Create a certificate signing request (CSR) for the bibd server
+certificate, to have it signed by your organization's CA.
+
+The private key is generated if it doesn't exist. Have the CSR signed,
+then import the certificate with 'bib cert import'. bibd also writes a CSR
+on startup when server.tls.auto_generate is false and the certificate in
+server.tls.cert_file doesn't exist yet.
+
+The key defaults to server.tls.key_file of the bibd configuration.
+
+Example:
+  bib cert csr --key /etc/bibd/server.key --dns bibd.example.com
+  bib cert csr --dns bibd.example.com --ip 10.0.0.5 --out bibd.csr`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if keyFile == "" {
+				_, keyFile, _ = serverTLSFiles()
+			}
+			if keyFile == "" {
+				return fmt.Errorf("--key is required (or set server.tls.key_file)")
+			}
+			if outFile == "" {
+				outFile = strings.TrimSuffix(keyFile, filepath.Ext(keyFile)) + ".csr"
+			}
+
+			key, err := os.ReadFile(keyFile)
+			if os.IsNotExist(err) {
+				if key, err = certs.GenerateKey(); err != nil {
+					return err
+				}
+				if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+					return fmt.Errorf("failed to create key directory: %w", err)
+				}
+				if err := os.WriteFile(keyFile, key, 0600); err != nil {
+					return fmt.Errorf("failed to save key: %w", err)
+				}
+				fmt.Printf("Generated private key: %s\n", keyFile)
+			} else if err != nil {
+				return fmt.Errorf("failed to read key: %w", err)
+			}
+
+			cfg := certs.DefaultConfig("standalone")
+			if commonName != "" {
+				cfg.ServerCommonName = commonName
+			}
+			cfg.DNSNames = append(cfg.DNSNames, dnsNames...)
+			for _, addr := range ipAddrs {
+				ip := net.ParseIP(addr)
+				if ip == nil {
+					return fmt.Errorf("invalid IP address %q", addr)
+				}
+				cfg.IPAddresses = append(cfg.IPAddresses, ip)
+			}
+
+			csr, err := certs.GenerateServerCSR(key, cfg)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(outFile, csr, 0644); err != nil {
+				return fmt.Errorf("failed to save CSR: %w", err)
+			}
+
+			fmt.Printf("✓ Certificate signing request created\n\n")
+			fmt.Printf("  CSR:         %s\n", outFile)
+			fmt.Printf("  Private Key: %s\n", keyFile)
+			fmt.Printf("\nHave the CSR signed by your CA, then run 'bib cert import <certificate>'.\n")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&keyFile, "key", "", "Private key file (generated if missing)")
+	cmd.Flags().StringVarP(&outFile, "out", "o", "", "CSR output file (default: the key file with extension .csr)")
+	cmd.Flags().StringVar(&commonName, "cn", "", "Common name of the certificate")
+	cmd.Flags().StringSliceVar(&dnsNames, "dns", nil, "DNS names of the certificate, besides localhost")
+	cmd.Flags().StringSliceVar(&ipAddrs, "ip", nil, "IP addresses of the certificate, besides the loopback addresses")
+
+	return cmd
+}
+
+func newImportCommand() *cobra.Command {
+	var (
+		keyFile  string
+		caFile   string
+		certFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <certificate>",
+		Short: "Import a server certificate signed by your CA",
+		Long: `Import a bibd server certificate signed by your organization's CA.
+
+The certificate must match the private key and chain to the CA certificates,
+and is then saved where bibd loads it. The files default to
+server.tls.key_file, server.tls.ca_file and server.tls.cert_file of the
+bibd configuration.
+
+Example:
+  bib cert import signed.crt
+  bib cert import signed.crt --key server.key --ca corp-ca.pem --cert /etc/bibd/server.crt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defaultCert, defaultKey, defaultCA := serverTLSFiles()
+			if keyFile == "" {
+				keyFile = defaultKey
+			}
+			if caFile == "" {
+				caFile = defaultCA
+			}
+			if certFile == "" {
+				certFile = defaultCert
+			}
+			if keyFile == "" || caFile == "" || certFile == "" {
+				return fmt.Errorf("--key, --ca and --cert are required (or set server.tls.key_file, ca_file and cert_file)")
+			}
+
+			signed, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read certificate: %w", err)
+			}
+			key, err := os.ReadFile(keyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read key: %w", err)
+			}
+			ca, err := os.ReadFile(caFile)
+			if err != nil {
+				return fmt.Errorf("failed to read CA certificates: %w", err)
+			}
+
+			if err := certs.ValidateServerCert(signed, key, ca); err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(filepath.Dir(certFile), 0700); err != nil {
+				return fmt.Errorf("failed to create certificate directory: %w", err)
+			}
+			if err := os.WriteFile(certFile, signed, 0644); err != nil {
+				return fmt.Errorf("failed to save certificate: %w", err)
+			}
+
+			info, err := certs.ParseCertificate(signed)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Certificate imported\n\n")
+			fmt.Printf("  Certificate: %s\n", certFile)
+			fmt.Printf("  Subject:     %s\n", info.Subject)
+			fmt.Printf("  Issuer:      %s\n", info.Issuer)
+			fmt.Printf("  Expires:     %s\n", info.ExpiresAt.Format("2006-01-02"))
+			fmt.Printf("\nRestart bibd to use it.\n")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&keyFile, "key", "", "Private key the certificate was requested with")
+	cmd.Flags().StringVar(&caFile, "ca", "", "CA certificates the certificate chains to")
+	cmd.Flags().StringVar(&certFile, "cert", "", "Where to save the certificate")
+
+	return cmd
+}
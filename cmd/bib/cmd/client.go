@@ -4,11 +4,15 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
+	configcmd "bib/cmd/bib/cmd/config"
 	"bib/internal/config"
 	client "bib/internal/grpc/client"
+	"bib/internal/tui/i18n"
 )
 
 var (
@@ -45,6 +49,30 @@ func GetClient(ctx context.Context) (*client.Client, error) {
 	return daemonClient, clientErr
 }
 
+// GetUnauthenticatedClient returns a new client connected to the daemon
+// without signing in, for commands that establish a session themselves.
+func GetUnauthenticatedClient(ctx context.Context) (*client.Client, error) {
+	opts, err := buildClientOptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client options: %w", err)
+	}
+
+	c, err := client.New(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if err := c.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Connected to node %s (%s)\n", c.ConnectedNode(), c.ConnectedTo())
+	}
+
+	return c, nil
+}
+
 // initClient initializes the daemon client from configuration.
 func initClient(ctx context.Context) (*client.Client, error) {
 	// Build client options from config
@@ -64,17 +92,70 @@ func initClient(ctx context.Context) (*client.Client, error) {
 		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
 	}
 
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "Connected to node %s (%s)\n", c.ConnectedNode(), c.ConnectedTo())
+	}
+
 	// Ensure authenticated
 	if err := c.EnsureAuthenticated(ctx); err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
+	syncPreferences(ctx, c)
+
 	return c, nil
 }
 
+// syncPreferences applies the user's preferences stored on the daemon.
+// Local settings win unless --sync is given, in which case the merged
+// config is also saved. Failures only matter with --sync.
+func syncPreferences(ctx context.Context, c *client.Client) {
+	if cfg == nil {
+		return
+	}
+
+	changed, err := configcmd.PullPreferences(ctx, c, cfg, syncPrefs)
+	if err != nil {
+		if syncPrefs || IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		return
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	// Apply to this invocation unless overridden by flags
+	if !rootCmd.PersistentFlags().Changed("output") {
+		outputFormat = cfg.Output.Format
+		applyOutputFormat()
+	}
+	if localeFlag == "" {
+		_ = i18n.Global().SetLocale(i18n.ResolveLocale("", cfg.Locale))
+	}
+
+	if syncPrefs {
+		path := cfgFile
+		if path == "" {
+			path = config.ConfigFileUsed(config.AppBib)
+		}
+		if err := config.SaveBib(cfg, path); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save synced preferences: %v\n", err)
+			return
+		}
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Synced %s from the daemon\n", strings.Join(changed, ", "))
+		}
+	}
+}
+
 // buildClientOptions builds client options from configuration.
 func buildClientOptions() (client.Options, error) {
 	opts := client.DefaultOptions()
+	opts.VersionSkewCallback = func(skew *client.VersionSkew) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", skew.Message)
+	}
+	opts.Locale = i18n.Global().Locale()
 
 	// Load config
 	bibCfg, err := config.LoadBib("")
@@ -111,6 +192,11 @@ func buildClientOptions() (client.Options, error) {
 		opts.PoolSize = connCfg.PoolSize
 	}
 
+	// Version skew handling
+	if connCfg.VersionPolicy != "" {
+		opts.VersionPolicy = client.VersionPolicy(connCfg.VersionPolicy)
+	}
+
 	// TLS settings
 	opts.TLS.InsecureSkipVerify = connCfg.TLS.SkipVerify
 	opts.TLS.CAFile = connCfg.TLS.CAFile
@@ -133,11 +219,16 @@ func buildClientOptions() (client.Options, error) {
 	if target.P2PPeerID != "" {
 		opts.P2PPeerID = target.P2PPeerID
 	}
+	if len(target.Nodes) > 0 {
+		opts.Nodes = target.Nodes
+	}
 
 	// SSH key settings from identity config
 	if bibCfg.Identity.Key != "" {
 		opts.Auth.SSHKeyPath = bibCfg.Identity.Key
 	}
+	opts.Auth.Name = bibCfg.Identity.Name
+	opts.Auth.Email = bibCfg.Identity.Email
 
 	return opts, nil
 }
@@ -147,6 +238,9 @@ type connectionTarget struct {
 	UnixSocket string
 	TCPAddress string
 	P2PPeerID  string
+
+	// Nodes holds failover candidates when no single node is pinned
+	Nodes []client.NodeTarget
 }
 
 // resolveConnectionTarget determines the connection target from config and flags.
@@ -162,36 +256,37 @@ func resolveConnectionTarget(cfg *config.BibConfig) (connectionTarget, error) {
 	// 2. Default node from config
 	if cfg.Connection.DefaultNode != "" {
 		// Look up in favorite nodes
-		for _, node := range cfg.Connection.FavoriteNodes {
-			if node.Alias == cfg.Connection.DefaultNode || node.ID == cfg.Connection.DefaultNode {
-				if node.UnixSocket != "" {
-					target.UnixSocket = node.UnixSocket
-				}
-				if node.Address != "" {
-					target.TCPAddress = node.Address
-				}
-				if node.ID != "" {
-					target.P2PPeerID = node.ID
-				}
-				return target, nil
+		if node := cfg.Connection.FindFavorite(cfg.Connection.DefaultNode); node != nil {
+			if node.UnixSocket != "" {
+				target.UnixSocket = node.UnixSocket
 			}
+			if node.Address != "" {
+				target.TCPAddress = node.Address
+			}
+			if node.ID != "" {
+				target.P2PPeerID = node.ID
+			}
+			return target, nil
 		}
 		// Treat as direct address
 		target.TCPAddress = cfg.Connection.DefaultNode
 		return target, nil
 	}
 
-	// 3. First favorite node
+	// 3. Favorite nodes, failing over by priority
 	if len(cfg.Connection.FavoriteNodes) > 0 {
-		node := cfg.Connection.FavoriteNodes[0]
-		if node.UnixSocket != "" {
-			target.UnixSocket = node.UnixSocket
-		}
-		if node.Address != "" {
-			target.TCPAddress = node.Address
-		}
-		if node.ID != "" {
-			target.P2PPeerID = node.ID
+		for _, node := range cfg.Connection.FavoriteNodes {
+			name := node.Alias
+			if name == "" {
+				name = node.ID
+			}
+			target.Nodes = append(target.Nodes, client.NodeTarget{
+				Name:       name,
+				Priority:   node.Priority,
+				UnixSocket: node.UnixSocket,
+				TCPAddress: node.Address,
+				P2PPeerID:  node.ID,
+			})
 		}
 		return target, nil
 	}
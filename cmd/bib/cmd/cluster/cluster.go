@@ -0,0 +1,35 @@
+// Package cluster provides the bib cluster commands, which inspect the
+// connected node's HA cluster.
+package cluster
+
+import (
+	"context"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// NewCommand creates the cluster command and subcommands.
+func NewCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Inspect and manage the HA cluster",
+		Long: `Inspect and manage the HA cluster of the connected node.
+
+The lock commands manage the cluster-wide lease locks that serialize
+maintenance tasks across nodes, for debugging. remove and snapshot change
+the cluster; both take --dry-run to report what they would affect first.`,
+	}
+
+	cmd.AddCommand(
+		newLockCommand(getClient),
+		newRemoveCommand(getClient),
+		newSnapshotCommand(getClient),
+	)
+
+	return cmd
+}
@@ -0,0 +1,233 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// lockItem is a lock as written by bib cluster lock
+type lockItem struct {
+	Name       string    `json:"name" yaml:"name"`
+	Holder     string    `json:"holder" yaml:"holder"`
+	Token      string    `json:"token" yaml:"token"`
+	AcquiredAt time.Time `json:"acquired_at" yaml:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+func toLockItem(l *services.ClusterLock) lockItem {
+	return lockItem{
+		Name:       l.GetName(),
+		Holder:     l.GetHolder(),
+		Token:      l.GetToken(),
+		AcquiredAt: l.GetAcquiredAt().AsTime(),
+		ExpiresAt:  l.GetExpiresAt().AsTime(),
+	}
+}
+
+func newLockCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Manage cluster-wide locks",
+		Long: `Manage the cluster-wide lease locks. A lock is held until released or
+until its lease expires, so a lock held by a crashed node frees itself.
+Maintenance workers take "maintenance/<worker>" locks, e.g. maintenance/gc,
+so only one node runs them at a time; acquiring one by hand holds the
+worker off cluster-wide.
+
+Locks are granted by the cluster leader; run acquire, renew and release
+against the leader. Requires the admin role.`,
+	}
+
+	cmd.AddCommand(
+		newLockListCommand(getClient),
+		newLockAcquireCommand(getClient),
+		newLockRenewCommand(getClient),
+		newLockReleaseCommand(getClient),
+	)
+
+	return cmd
+}
+
+func newLockListCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the locks currently held",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.ListLocks(ctx, &services.ListLocksRequest{})
+			if err != nil {
+				return err
+			}
+
+			items := make([]lockItem, 0, len(resp.GetLocks()))
+			for _, l := range resp.GetLocks() {
+				items = append(items, toLockItem(l))
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(items)
+			}
+			if len(items) == 0 {
+				w.Info("No locks held")
+				return nil
+			}
+
+			table := output.NewTable("NAME", "HOLDER", "ACQUIRED", "EXPIRES IN")
+			for _, item := range items {
+				table.AddRow(item.Name, item.Holder,
+					item.AcquiredAt.Local().Format(time.DateTime),
+					time.Until(item.ExpiresAt).Round(time.Second).String())
+			}
+			return w.Write(table)
+		},
+	}
+}
+
+func newLockAcquireCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		ttl    time.Duration
+		holder string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "acquire <name>",
+		Short: "Acquire a lock",
+		Long: `Acquire a lock and print its lease token, which renew and release need.
+Fails if another holder has the lock.`,
+		Example: `  bib cluster lock acquire maintenance/gc --ttl 10m`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.AcquireLock(ctx, &services.AcquireLockRequest{
+				Name:   args[0],
+				Ttl:    durationpb.New(ttl),
+				Holder: holder,
+			})
+			if err != nil {
+				return err
+			}
+
+			item := toLockItem(resp.GetLock())
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(item)
+			}
+			w.Success(fmt.Sprintf("Acquired %s until %s", item.Name, item.ExpiresAt.Local().Format(time.DateTime)))
+			w.Println("Token: " + item.Token)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", 30*time.Second, "lease duration")
+	cmd.Flags().StringVar(&holder, "holder", "", "holder recorded on the lock (default: your user name)")
+
+	return cmd
+}
+
+func newLockRenewCommand(getClient ClientFunc) *cobra.Command {
+	var ttl time.Duration
+
+	cmd := &cobra.Command{
+		Use:     "renew <name> <token>",
+		Short:   "Extend a lease",
+		Example: `  bib cluster lock renew maintenance/gc 3f2a... --ttl 10m`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.RenewLock(ctx, &services.RenewLockRequest{
+				Name:  args[0],
+				Token: args[1],
+				Ttl:   durationpb.New(ttl),
+			})
+			if err != nil {
+				return err
+			}
+
+			item := toLockItem(resp.GetLock())
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(item)
+			}
+			w.Success(fmt.Sprintf("Renewed %s until %s", item.Name, item.ExpiresAt.Local().Format(time.DateTime)))
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", 30*time.Second, "lease duration from now")
+
+	return cmd
+}
+
+func newLockReleaseCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:     "release <name> <token>",
+		Short:   "Release a lock",
+		Example: `  bib cluster lock release maintenance/gc 3f2a...`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			if _, err := adminClient.ReleaseLock(ctx, &services.ReleaseLockRequest{
+				Name:  args[0],
+				Token: args[1],
+			}); err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(map[string]any{"name": args[0], "released": true})
+			}
+			w.Success("Released " + args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,239 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// dryRunItem is a dry run report as written by bib cluster
+type dryRunItem struct {
+	Summary    string         `json:"summary" yaml:"summary"`
+	TotalBytes int64          `json:"total_bytes" yaml:"total_bytes"`
+	Resources  []affectedItem `json:"resources" yaml:"resources"`
+
+	ConfirmationToken     string     `json:"confirmation_token,omitempty" yaml:"confirmation_token,omitempty"`
+	ConfirmationExpiresAt *time.Time `json:"confirmation_expires_at,omitempty" yaml:"confirmation_expires_at,omitempty"`
+}
+
+type affectedItem struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	ID        string `json:"id" yaml:"id"`
+	SizeBytes int64  `json:"size_bytes,omitempty" yaml:"size_bytes,omitempty"`
+	Detail    string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+func toDryRunItem(r *services.DryRunReport) dryRunItem {
+	item := dryRunItem{
+		Summary:    r.GetSummary(),
+		TotalBytes: r.GetTotalBytes(),
+		Resources:  make([]affectedItem, 0, len(r.GetResources())),
+
+		ConfirmationToken: r.GetConfirmationToken(),
+	}
+	if r.GetConfirmationExpiresAt() != nil {
+		expiresAt := r.GetConfirmationExpiresAt().AsTime()
+		item.ConfirmationExpiresAt = &expiresAt
+	}
+	for _, res := range r.GetResources() {
+		item.Resources = append(item.Resources, affectedItem{
+			Kind:      res.GetKind(),
+			ID:        res.GetId(),
+			SizeBytes: res.GetSizeBytes(),
+			Detail:    res.GetDetail(),
+		})
+	}
+	return item
+}
+
+// writeDryRun writes a dry run report; nothing was changed.
+func writeDryRun(w *output.Writer, r *services.DryRunReport) error {
+	item := toDryRunItem(r)
+	if w.Format() != output.FormatTable {
+		return w.Write(item)
+	}
+	if err := writePreview(w, item); err != nil {
+		return err
+	}
+	w.Println("No changes were made.")
+	if item.ConfirmationToken != "" {
+		w.Printf("Confirmation token: %s (valid until %s)\n",
+			item.ConfirmationToken, item.ConfirmationExpiresAt.Local().Format(time.DateTime))
+	}
+	return nil
+}
+
+// writePreview writes what an operation would affect.
+func writePreview(w *output.Writer, item dryRunItem) error {
+	w.Info("Dry run: " + item.Summary)
+	if len(item.Resources) > 0 {
+		table := output.NewTable("KIND", "ID", "SIZE", "DETAIL")
+		for _, res := range item.Resources {
+			size := "-"
+			if res.SizeBytes > 0 {
+				size = formatBytes(res.SizeBytes)
+			}
+			table.AddRow(res.Kind, res.ID, size, res.Detail)
+		}
+		return w.Write(table)
+	}
+	return nil
+}
+
+func newRemoveCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		dryRun, force bool
+		token         string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "remove <node-id>",
+		Short: "Remove a member from the cluster",
+		Long: `Remove a member from the cluster. Run it against the leader. Voters are
+never removed below the minimum of 3.
+
+Removal is irreversible, so the leader only removes a member with the
+confirmation token of a dry run of the same removal. The command previews
+the removal, asks for confirmation and passes the token on. Use --dry-run
+to only preview it and print the token, and --confirm to remove with a
+token printed earlier, e.g. from a script. Tokens are single-use and expire
+after 5 minutes.`,
+		Example: `  bib cluster remove node-4
+  bib cluster remove node-4 --dry-run
+  bib cluster remove node-4 --confirm 9f86d081884c7d65...`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if token == "" {
+				preview, err := adminClient.RemoveClusterMember(ctx, &services.RemoveClusterMemberRequest{
+					NodeId: args[0],
+					DryRun: true,
+				})
+				if err != nil {
+					return err
+				}
+				if dryRun {
+					return writeDryRun(w, preview.GetDryRun())
+				}
+
+				if !force {
+					if err := writePreview(w, toDryRunItem(preview.GetDryRun())); err != nil {
+						return err
+					}
+					fmt.Fprintf(cmd.OutOrStdout(), "Remove %s from the cluster? (y/N): ", args[0])
+					var response string
+					_, _ = fmt.Fscanln(cmd.InOrStdin(), &response)
+					if response != "y" && response != "Y" {
+						w.Println("Cancelled")
+						return nil
+					}
+				}
+				token = preview.GetDryRun().GetConfirmationToken()
+			}
+
+			resp, err := adminClient.RemoveClusterMember(ctx, &services.RemoveClusterMemberRequest{
+				NodeId:            args[0],
+				ConfirmationToken: token,
+			})
+			if err != nil {
+				return err
+			}
+
+			if w.Format() != output.FormatTable {
+				return w.Write(resp)
+			}
+			w.Success(fmt.Sprintf("Removed %s (%s); %d voter(s) remain",
+				resp.GetMember().GetId(), resp.GetMember().GetRole(), resp.GetRemainingVoters()))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report what would be removed and print a confirmation token, without removing it")
+	cmd.Flags().BoolVar(&force, "force", false, "remove without asking; the removal is still previewed")
+	cmd.Flags().StringVar(&token, "confirm", "", "remove with the confirmation token of an earlier dry run")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "confirm")
+
+	return cmd
+}
+
+func newSnapshotCommand(getClient ClientFunc) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Take a Raft snapshot",
+		Long: `Take a Raft snapshot on the connected node. Snapshots beyond
+cluster.snapshot.retain_count are deleted afterwards.
+
+Use --dry-run to report the snapshots that would be deleted without taking
+one.`,
+		Example: `  bib cluster snapshot --dry-run`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			adminClient, err := c.Admin()
+			if err != nil {
+				return err
+			}
+
+			resp, err := adminClient.TriggerSnapshot(ctx, &services.TriggerSnapshotRequest{DryRun: dryRun})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if dryRun {
+				return writeDryRun(w, resp.GetDryRun())
+			}
+			if w.Format() != output.FormatTable {
+				return w.Write(resp)
+			}
+
+			snap := resp.GetSnapshot()
+			w.Success(fmt.Sprintf("Took snapshot %s at index %d (%s, %s)", snap.GetId(), snap.GetIndex(),
+				formatBytes(snap.GetSize()), snap.GetCreatedAt().AsTime().Local().Format(time.DateTime)))
+			if n := len(resp.GetPruned()); n > 0 {
+				w.Info(fmt.Sprintf("Deleted %d old snapshot(s)", n))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report what would be deleted without taking a snapshot")
+
+	return cmd
+}
+
+// formatBytes formats bytes into human-readable format
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
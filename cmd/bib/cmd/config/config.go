@@ -22,7 +22,7 @@ var Cmd = &cobra.Command{
 }
 
 // NewCommand returns the config command with all subcommands registered
-func NewCommand() *cobra.Command {
+func NewCommand(getClient ClientFunc) *cobra.Command {
 	// Register subcommands
 	Cmd.AddCommand(configShowCmd)
 	Cmd.AddCommand(configPathCmd)
@@ -32,6 +32,7 @@ func NewCommand() *cobra.Command {
 	Cmd.AddCommand(configValidateCmd)
 	Cmd.AddCommand(configEditCmd)
 	Cmd.AddCommand(NewResetCommand())
+	Cmd.AddCommand(newSyncCommand(getClient))
 
 	// Add flags
 	Cmd.PersistentFlags().BoolVar(&configDaemon, "daemon", false, "Manage bibd daemon configuration")
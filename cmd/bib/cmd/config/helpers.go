@@ -86,7 +86,7 @@ func validateBibConfig(cfg *config.BibConfig) []string {
 	}
 
 	// Validate output format
-	validFormats := map[string]bool{"text": true, "json": true, "yaml": true, "table": true}
+	validFormats := map[string]bool{"text": true, "json": true, "jsonl": true, "yaml": true, "table": true}
 	if cfg.Output.Format != "" && !validFormats[cfg.Output.Format] {
 		errors = append(errors, fmt.Sprintf("invalid output.format: %s", cfg.Output.Format))
 	}
@@ -38,6 +38,8 @@ func (o *OutputWriter) Write(data any) error {
 	switch o.format {
 	case "json":
 		return o.writeJSON(data)
+	case "jsonl":
+		return o.writeJSONL(data)
 	case "yaml":
 		return o.writeYAML(data)
 	case "quiet":
@@ -59,6 +61,16 @@ func (o *OutputWriter) writeJSON(data any) error {
 	return nil
 }
 
+// writeJSONL outputs data as a single line of JSON
+func (o *OutputWriter) writeJSONL(data any) error {
+	output, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Fprintln(o.out, string(output))
+	return nil
+}
+
 // writeYAML outputs data as YAML
 func (o *OutputWriter) writeYAML(data any) error {
 	output, err := yaml.Marshal(data)
@@ -0,0 +1,128 @@
+package configcmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/config"
+	"bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+func newSyncCommand(getClient ClientFunc) *cobra.Command {
+	var push bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync CLI preferences with the daemon",
+		Long: `Sync your CLI preferences (output format, color, locale) with the daemon,
+so other machines you log in from use the same settings.
+
+By default the preferences stored on the daemon replace the local ones and
+are written to the config file. With --push the local preferences are
+stored on the daemon instead.
+
+Preferences are also fetched whenever bib connects; settings changed in the
+local config file take precedence unless the global --sync flag is given.`,
+		Example: `  # Store this machine's preferences on the daemon
+  bib config sync --push
+
+  # Apply the stored preferences on a new machine
+  bib config sync`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			out := NewOutputWriter()
+
+			cfg := Config()
+			if cfg == nil {
+				return fmt.Errorf("no config loaded; run 'bib config init' first")
+			}
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			if push {
+				if err := PushPreferences(ctx, c, cfg); err != nil {
+					return err
+				}
+				out.WriteSuccess("Preferences stored on the daemon")
+				return nil
+			}
+
+			changed, err := PullPreferences(ctx, c, cfg, true)
+			if err != nil {
+				return err
+			}
+			if len(changed) == 0 {
+				out.WriteSuccess("Preferences are up to date")
+				return nil
+			}
+
+			path := ConfigFile()
+			if path == "" {
+				return fmt.Errorf("no config file found; run 'bib config init' first")
+			}
+			if err := config.SaveBib(cfg, path); err != nil {
+				return err
+			}
+			out.WriteSuccess(fmt.Sprintf("Updated %s", strings.Join(changed, ", ")))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&push, "push", false, "Store the local preferences on the daemon")
+
+	return cmd
+}
+
+// PullPreferences fetches the user's preferences from the daemon and merges
+// them into cfg (see config.BibConfig.ApplyPreferences). It returns the keys
+// that changed; cfg is not saved.
+func PullPreferences(ctx context.Context, c *client.Client, cfg *config.BibConfig, overwrite bool) ([]string, error) {
+	userClient, err := c.User()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := userClient.GetPreferences(ctx, &services.GetPreferencesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	p := resp.GetPreferences()
+	return cfg.ApplyPreferences(config.Preferences{
+		OutputFormat: p.GetOutputFormat(),
+		Color:        p.Color,
+		Locale:       p.GetLocale(),
+	}, overwrite), nil
+}
+
+// PushPreferences stores the preferences of cfg on the daemon.
+func PushPreferences(ctx context.Context, c *client.Client, cfg *config.BibConfig) error {
+	userClient, err := c.User()
+	if err != nil {
+		return err
+	}
+
+	p := cfg.Preferences()
+	_, err = userClient.SetPreferences(ctx, &services.SetPreferencesRequest{
+		Preferences: &services.CLIPreferences{
+			OutputFormat: p.OutputFormat,
+			Color:        p.Color,
+			Locale:       p.Locale,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store preferences: %w", err)
+	}
+	return nil
+}
@@ -2,39 +2,50 @@
 package connect
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	services "bib/api/gen/go/bib/v1/services"
 	"bib/internal/certs"
 	"bib/internal/config"
+	"bib/internal/discovery"
 	client "bib/internal/grpc/client"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	// Flags
-	saveFlag       bool
+	defaultFlag    bool
 	aliasFlag      string
+	priorityFlag   int
 	testOnly       bool
 	timeoutFlag    time.Duration
 	trustFirstUse  bool
 	skipTrustCheck bool
+	autoFlag       bool
 )
 
 // NewCommand creates the connect command.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "connect [address]",
-		Short: "Connect to a bibd daemon",
-		Long: `Connect to a bibd daemon and optionally save it as the default.
+		Short: "Connect to a bibd daemon and add it to your favorite nodes",
+		Long: `Connect to a bibd daemon and add it to your favorite nodes.
 
 This command tests the connection to a bibd daemon, authenticates using
-your SSH key, and optionally saves the connection as your default node.
+your SSH key, and adds the node to connection.favorite_nodes in your config,
+or updates it if it is already there. bib fails over between favorite nodes
+by priority (lower first) unless a default node is set. Remove a node with
+bib disconnect.
 
 TRUST ON FIRST USE (TOFU):
 When connecting to a new node for the first time, you will be prompted
@@ -47,30 +58,44 @@ Examples:
   # Connect to local daemon
   bib connect localhost:4000
 
-  # Connect and save as default
-  bib connect --save node1.example.com:4000
+  # Connect and make it the default node
+  bib connect --default node1.example.com:4000
 
-  # Connect with alias
-  bib connect --save --alias mynode node1.example.com:4000
+  # Connect with alias and priority
+  bib connect --alias mynode --priority 1 node1.example.com:4000
 
-  # Test connection only (no auth)
+  # Reconnect to a favorite by alias
+  bib connect mynode
+
+  # Test connection only (no auth, nothing saved)
   bib connect --test node1.example.com:4000
 
   # Auto-trust new certificate (use with caution!)
-  bib connect --trust-first-use node1.example.com:4000`,
+  bib connect --trust-first-use node1.example.com:4000
+
+  # Pick the best node found by discovery and among favorites
+  bib connect --auto --default
+
+Without an address or a default node, bib connect ranks the nodes it can
+discover and the favorite nodes by latency and reachability and connects to
+the best one, as with --auto.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: runConnect,
 	}
 
-	cmd.Flags().BoolVar(&saveFlag, "save", false, "Save as default node in config")
-	cmd.Flags().StringVar(&aliasFlag, "alias", "", "Alias for the node (used with --save)")
-	cmd.Flags().BoolVar(&testOnly, "test", false, "Test connection only, don't authenticate")
+	cmd.Flags().BoolVar(&defaultFlag, "default", false, "Make the node the default node")
+	cmd.Flags().BoolVar(&defaultFlag, "save", false, "Make the node the default node")
+	cmd.Flags().StringVar(&aliasFlag, "alias", "", "Alias for the node in favorite nodes")
+	cmd.Flags().IntVar(&priorityFlag, "priority", -1, "Failover priority, lower first; -1 places the node after existing favorites")
+	cmd.Flags().BoolVar(&testOnly, "test", false, "Test connection only, don't authenticate or save")
 	cmd.Flags().DurationVar(&timeoutFlag, "timeout", 10*time.Second, "Connection timeout")
 	cmd.Flags().BoolVar(&trustFirstUse, "trust-first-use", false, "Automatically trust new certificates (less secure)")
 	cmd.Flags().BoolVar(&skipTrustCheck, "insecure-skip-verify", false, "Skip TLS certificate verification entirely (dangerous!)")
+	cmd.Flags().BoolVar(&autoFlag, "auto", false, "Connect to the best ranked node instead of the default")
 
 	// Mark dangerous flags
 	cmd.Flags().MarkHidden("insecure-skip-verify")
+	cmd.Flags().MarkDeprecated("save", "use --default instead")
 
 	return cmd
 }
@@ -81,17 +106,36 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		ctx = context.Background()
 	}
 
+	cfg, err := config.LoadBib("")
+	if err != nil {
+		cfg = nil
+	}
+
 	// Determine target address
 	var address string
-	if len(args) > 0 {
+	switch {
+	case len(args) > 0:
 		address = args[0]
-	} else {
-		// Try to load from config
-		cfg, err := config.LoadBib("")
-		if err == nil && cfg.GetDefaultServerAddress() != "" {
-			address = cfg.GetDefaultServerAddress()
-		} else {
-			address = "localhost:4000"
+	case cfg != nil && cfg.GetDefaultServerAddress() != "" && !autoFlag:
+		address = cfg.GetDefaultServerAddress()
+	default:
+		address = pickBestNode(ctx, cfg)
+	}
+
+	// Accept the alias of a favorite node
+	if cfg != nil {
+		if fav := cfg.Connection.FindFavorite(address); fav != nil && fav.Address != "" {
+			address = fav.Address
+		}
+	}
+
+	// Warn about nodes that failed recently before trying again
+	history := openHistory()
+	if history != nil {
+		if h, err := history.Get(address); err == nil {
+			if warning := h.Warning(); warning != "" {
+				fmt.Printf("⚠️  %s: %s (see bib status)\n", address, warning)
+			}
 		}
 	}
 
@@ -157,9 +201,21 @@ func runConnect(cmd *cobra.Command, args []string) error {
 	connectCtx, cancel := context.WithTimeout(ctx, timeoutFlag)
 	defer cancel()
 
+	run := discovery.TestRun{TestedAt: time.Now()}
+	defer func() {
+		if history != nil && run.Connection != "" {
+			_ = history.Record(address, run)
+		}
+	}()
+
+	start := time.Now()
 	if err := c.Connect(connectCtx); err != nil {
+		run.Connection = discovery.ClassifyConnectionError(err)
+		run.Error = err.Error()
 		return fmt.Errorf("connection failed: %w", err)
 	}
+	run.Connection = discovery.StatusConnected
+	run.Latency = time.Since(start)
 
 	fmt.Printf("✓ Connected to %s\n", c.ConnectedTo())
 
@@ -183,16 +239,36 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Version: %s\n", nodeInfo.Version)
 		fmt.Printf("  Mode: %s\n", nodeInfo.Mode)
 		fmt.Printf("  Uptime: %s\n", nodeInfo.Uptime.AsDuration())
+
+		if cluster := nodeInfo.GetCluster(); cluster != nil {
+			run.ClockSkew = cluster.GetMaxClockSkew().AsDuration()
+			run.ClockSkewExceeded = cluster.GetClockSkewExceeded()
+			if run.ClockSkewExceeded {
+				fmt.Printf("⚠ Clock skew of %s to cluster member %s; check NTP on the cluster nodes\n",
+					run.ClockSkew.Abs().Round(time.Millisecond), cluster.GetMaxClockSkewNode())
+			}
+		}
 	}
 
 	// Authenticate unless test-only
 	if !testOnly {
 		fmt.Println("\nAuthenticating...")
 
-		if err := c.Authenticate(ctx); err != nil {
-			return fmt.Errorf("authentication failed: %w", err)
+		identity := registrationInfo(ctx, c, cfg)
+		c.SetRegistrationInfo(identity.Name, identity.Email)
+
+		resp, err := c.SignIn(ctx)
+		if err != nil {
+			run.Auth = discovery.AuthStatusFailed
+			run.Error = err.Error()
+			return authError(address, err)
 		}
+		run.Auth = discovery.AuthStatusSuccess
 
+		if resp.IsNewUser {
+			run.Auth = discovery.AuthStatusAutoRegistered
+			fmt.Printf("✓ Registered as %s (%s)\n", resp.GetUser().GetName(), resp.GetUser().GetRole())
+		}
 		fmt.Println("✓ Authenticated successfully")
 
 		// Show public key fingerprint used
@@ -206,64 +282,178 @@ func runConnect(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Save to config if requested
-	if saveFlag {
-		if err := saveConnection(address, aliasFlag); err != nil {
-			return fmt.Errorf("failed to save connection: %w", err)
-		}
-		fmt.Printf("\n✓ Saved as default node")
-		if aliasFlag != "" {
-			fmt.Printf(" (alias: %s)", aliasFlag)
-		}
-		fmt.Println()
+	if testOnly {
+		return nil
 	}
 
+	// Add to favorite nodes
+	node := config.FavoriteNode{
+		Alias:    aliasFlag,
+		Address:  address,
+		Priority: priorityFlag,
+	}
+	added, err := saveFavorite(cfg, node, defaultFlag)
+	if err != nil {
+		return fmt.Errorf("failed to save connection: %w", err)
+	}
+
+	name := address
+	if aliasFlag != "" {
+		name = fmt.Sprintf("%s (%s)", aliasFlag, address)
+	}
+	if added {
+		fmt.Printf("\n✓ Added %s to favorite nodes", name)
+	} else {
+		fmt.Printf("\n✓ Updated %s in favorite nodes", name)
+	}
+	if defaultFlag {
+		fmt.Printf(" as the default node")
+	}
+	fmt.Println()
+
 	return nil
 }
 
-// saveConnection saves the connection to config.
-func saveConnection(address, alias string) error {
-	// Load existing config
-	cfg, err := config.LoadBib("")
+// registrationInfo returns the identity to sign in with. If the node
+// registers unknown identities on sign-in, it says so, and asks for an email
+// the node requires but the identity config lacks when stdin is a terminal.
+// An email entered is kept in cfg, so it is saved with the favorite node.
+func registrationInfo(ctx context.Context, c *client.Client, cfg *config.BibConfig) config.IdentityConfig {
+	var identity config.IdentityConfig
+	if cfg != nil {
+		identity = cfg.Identity
+	}
+
+	authClient, err := c.Auth()
 	if err != nil {
-		// Create new config
-		cfg = &config.BibConfig{}
+		return identity
+	}
+	authCfg, err := authClient.GetAuthConfig(ctx, &services.GetAuthConfigRequest{})
+	if err != nil || !authCfg.GetAllowAutoRegistration() {
+		return identity
 	}
 
-	// Set default node
-	if alias != "" {
-		cfg.Connection.DefaultNode = alias
-	} else {
-		cfg.Connection.DefaultNode = address
-	}
-
-	// Add to favorite nodes if alias provided
-	if alias != "" {
-		// Check if alias already exists
-		found := false
-		for i, node := range cfg.Connection.FavoriteNodes {
-			if node.Alias == alias {
-				cfg.Connection.FavoriteNodes[i].Address = address
-				found = true
-				break
-			}
+	as := identity.Name
+	if as == "" {
+		as = "an unnamed user"
+	}
+	if identity.Email != "" {
+		as = fmt.Sprintf("%s <%s>", as, identity.Email)
+	}
+	fmt.Printf("  This node registers new identities on first sign-in; if your key is new, it is registered as %s\n", as)
+
+	if identity.Email != "" || !authCfg.GetRequireEmail() || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return identity
+	}
+
+	fmt.Print("  The node requires an email to register. Email (leave empty if already registered): ")
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if email := strings.TrimSpace(line); email != "" {
+		identity.Email = email
+		if cfg != nil {
+			cfg.Identity.Email = email
 		}
-		if !found {
-			cfg.Connection.FavoriteNodes = append(cfg.Connection.FavoriteNodes, config.FavoriteNode{
-				Alias:    alias,
-				Address:  address,
-				Priority: len(cfg.Connection.FavoriteNodes),
-			})
+	}
+	return identity
+}
+
+// authError explains why the node at address did not sign the identity in
+func authError(address string, err error) error {
+	st, _ := status.FromError(err)
+	switch {
+	case st.Code() == codes.PermissionDenied && strings.Contains(st.Message(), "pending approval"):
+		return fmt.Errorf("your identity is registered on %s but awaits approval by an administrator of the node; run bib connect again once it is approved", address)
+	case st.Code() == codes.PermissionDenied && strings.Contains(st.Message(), "auto-registration is disabled"):
+		return fmt.Errorf("%s does not register new identities; ask an administrator of the node to add your public key", address)
+	case st.Code() == codes.FailedPrecondition && strings.Contains(st.Message(), "email is required"):
+		return fmt.Errorf("%s requires an email to register a new identity; set identity.email in your config and run bib connect again", address)
+	}
+	return fmt.Errorf("authentication failed: %w", err)
+}
+
+// openHistory returns the connection history, or nil if the config
+// directory is unknown
+func openHistory() *discovery.HistoryStore {
+	dir, err := discovery.DefaultHistoryDir()
+	if err != nil {
+		return nil
+	}
+	return discovery.NewHistoryStore(dir, 0)
+}
+
+// pickBestNode discovers nodes, tests the connection to them and to the
+// favorite nodes of cfg, and returns the best ranked one. It falls back to
+// localhost:4000 if no node is reachable.
+func pickBestNode(ctx context.Context, cfg *config.BibConfig) string {
+	fmt.Println("Discovering nodes...")
+
+	conn := config.DefaultBibConfig().Connection
+	if cfg != nil {
+		conn = cfg.Connection
+	}
+
+	discoverer := discovery.NewWithDefaults(conn)
+	var favorites []string
+	for _, node := range conn.FavoriteNodes {
+		if node.Address != "" {
+			favorites = append(favorites, node.Address)
 		}
 	}
+	if len(favorites) > 0 {
+		discoverer.AddProvider(discovery.NewStaticProvider(favorites...))
+	}
+
+	discoverCtx, cancel := context.WithTimeout(ctx, timeoutFlag)
+	defer cancel()
+	result := discoverer.Discover(discoverCtx)
+
+	addresses := make([]string, len(result.Nodes))
+	for i, node := range result.Nodes {
+		addresses[i] = node.Address
+	}
+
+	ranker := discovery.NewRanker(result.Nodes)
+	ranker.AddConnectionResults(discovery.NewConnectionTester().
+		WithTimeout(timeoutFlag).
+		TestConnections(ctx, addresses))
+
+	ranked := ranker.Rank()
+	best, ok := discovery.BestNode(ranked)
+	if !ok {
+		fmt.Println("No reachable node found, trying localhost:4000")
+		return "localhost:4000"
+	}
+
+	fmt.Print(discovery.FormatRankedNodes(ranked))
+	fmt.Println()
+	return best.Address
+}
 
-	// Determine config path
-	configDir, err := os.UserConfigDir()
+// saveFavorite adds node to the favorite nodes of cfg, or updates it, and
+// writes the config. cfg may be nil if no config could be loaded. It returns
+// whether the node was added.
+func saveFavorite(cfg *config.BibConfig, node config.FavoriteNode, makeDefault bool) (bool, error) {
+	if cfg == nil {
+		cfg = config.DefaultBibConfig()
+	}
+
+	added := cfg.Connection.AddFavorite(node, makeDefault)
+
+	path, err := configPath()
 	if err != nil {
-		return fmt.Errorf("failed to get config directory: %w", err)
+		return false, err
 	}
-	configPath := configDir + "/bib/config.yaml"
+	return added, config.SaveBib(cfg, path)
+}
 
-	// Save config
-	return config.SaveBib(cfg, configPath)
+// configPath returns the config file in use, or where a new one goes
+func configPath() (string, error) {
+	if path := config.ConfigFileUsed(config.AppBib); path != "" {
+		return path, nil
+	}
+	configDir, err := config.UserConfigDir(config.AppBib)
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return filepath.Join(configDir, "config.yaml"), nil
 }
@@ -0,0 +1,54 @@
+package connect
+
+import (
+	"fmt"
+
+	"bib/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// NewDisconnectCommand creates the disconnect command.
+func NewDisconnectCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disconnect <alias|address>",
+		Short: "Remove a node from your favorite nodes",
+		Long: `Remove a node added with bib connect or bib setup from your favorite
+nodes, by alias, address or node ID. If it was the default node, bib fails
+over between the remaining favorites until you pick a new default with
+bib connect --default.`,
+		Example: `  bib disconnect mynode
+  bib disconnect node1.example.com:4000`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadBib("")
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			defaultNode := cfg.Connection.DefaultNode
+			node, ok := cfg.Connection.RemoveFavorite(args[0])
+			if !ok {
+				return fmt.Errorf("%s is not a favorite node", args[0])
+			}
+
+			path, err := configPath()
+			if err != nil {
+				return err
+			}
+			if err := config.SaveBib(cfg, path); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+
+			name := node.Address
+			if node.Alias != "" {
+				name = fmt.Sprintf("%s (%s)", node.Alias, node.Address)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Removed %s from favorite nodes\n", name)
+			if wasDefault := node.Default || defaultNode != cfg.Connection.DefaultNode; wasDefault && len(cfg.Connection.FavoriteNodes) > 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "  No default node is set; pick one with bib connect --default")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+// Package dataset provides the bib dataset commands.
+package dataset
+
+import (
+	"context"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// NewCommand creates the dataset command group. getClient is called lazily
+// by subcommands that need the daemon.
+func NewCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dataset",
+		Short: "Work with datasets",
+		Long:  `Work with datasets stored on a bibd node.`,
+	}
+
+	cmd.AddCommand(newExportCommand(getClient))
+	cmd.AddCommand(newImportCommand(getClient))
+	cmd.AddCommand(newListCommand(getClient))
+	cmd.AddCommand(newRevertCommand(getClient))
+	cmd.AddCommand(newSchemaCommand(getClient))
+	cmd.AddCommand(newVersionsCommand(getClient))
+
+	return cmd
+}
+
+// addRevisionFlag adds --if-revision, which makes a change fail instead of
+// overwriting changes someone else made since the dataset was read.
+func addRevisionFlag(cmd *cobra.Command, revision *int64) {
+	cmd.Flags().Int64Var(revision, "if-revision", 0, "Only apply if the dataset is still at this revision")
+}
@@ -0,0 +1,120 @@
+package dataset
+
+import (
+	"fmt"
+	"os"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+func newExportCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		topicID string
+		version int32
+		quiet   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export [dataset-id] <dir>",
+		Short: "Export dataset content to a local directory",
+		Long: `Export dataset content to a local directory.
+
+Each dataset is written to <dir>/<dataset-id>/ as:
+  data              the dataset content
+  dataset.json      the dataset's metadata
+  .bib-export.json  export progress, used to resume
+
+Every chunk is verified as it arrives and the complete file is checked
+against the dataset's content hash. If an export is interrupted, run the
+same command again to continue from the last verified chunk. Datasets that
+were already exported at the same version are skipped.
+
+Examples:
+  # Export a dataset
+  bib dataset export 3f2a9c1e ./exports
+
+  # Export a specific version
+  bib dataset export --version 2 3f2a9c1e ./exports
+
+  # Export every dataset in a topic
+  bib dataset export --topic weather ./exports`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if topicID != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			opts := client.ExportOptions{Version: version}
+			if !quiet {
+				opts.Progress = printProgress
+			}
+
+			var results []*client.ExportResult
+			if topicID != "" {
+				results, err = c.ExportTopic(ctx, topicID, args[0], opts)
+			} else {
+				var result *client.ExportResult
+				result, err = c.ExportDataset(ctx, args[0], args[1], opts)
+				if result != nil {
+					results = append(results, result)
+				}
+			}
+
+			for _, r := range results {
+				printResult(cmd, r)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&topicID, "topic", "", "Export all datasets in this topic")
+	cmd.Flags().Int32Var(&version, "version", 0, "Dataset version to export (0 = latest)")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Don't show progress")
+
+	return cmd
+}
+
+// printProgress redraws a per-dataset progress line on stderr.
+func printProgress(p client.ExportProgress) {
+	end := "\r"
+	if p.CompletedChunks == p.TotalChunks {
+		end = "\n"
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d/%d chunks (%s)%s", p.DatasetID, p.CompletedChunks, p.TotalChunks, formatBytes(p.TotalSize), end)
+}
+
+// printResult reports how a dataset export finished.
+func printResult(cmd *cobra.Command, r *client.ExportResult) {
+	switch {
+	case r.Skipped:
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: already exported to %s\n", r.DatasetID, r.Path)
+	case r.Resumed:
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: resumed and verified %s\n", r.DatasetID, r.Path)
+	default:
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: exported and verified %s\n", r.DatasetID, r.Path)
+	}
+}
+
+// formatBytes formats bytes into human-readable format
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,108 @@
+package dataset
+
+import (
+	"fmt"
+	"os"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+func newImportCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		topicID string
+		dryRun  bool
+		quiet   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <dir>",
+		Short: "Import files from a local directory as datasets",
+		Long: `Import files from a local directory as datasets.
+
+Every file under <dir> becomes a dataset named after its relative path.
+Directories written by 'bib dataset export' are imported as one dataset
+and keep their name, description and tags. Hidden files are skipped.
+
+Files are split into chunks and only chunks the node doesn't already store
+are uploaded. If an import is interrupted, run it again: chunks that made
+it are not sent twice. A manifest (.bib-import.json) remembers which
+dataset each file went to, so later imports add new versions to the same
+datasets and skip files whose content hasn't changed.
+
+Examples:
+  # See what would be uploaded
+  bib dataset import --topic weather --dry-run ./data
+
+  # Import into a topic
+  bib dataset import --topic weather ./data
+
+  # Re-import a directory created by 'bib dataset export'
+  bib dataset import ./exports`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			opts := client.ImportOptions{TopicID: topicID, DryRun: dryRun}
+			if !quiet && !dryRun {
+				opts.Progress = printImportProgress
+			}
+
+			results, err := c.ImportDirectory(ctx, args[0], opts)
+
+			var upload, present int64
+			for _, r := range results {
+				printImportResult(cmd, r, dryRun)
+				upload += r.UploadBytes
+				present += r.PresentBytes
+			}
+
+			verb := "uploaded"
+			if dryRun {
+				verb = "to upload"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d files, %s %s, %s already present\n",
+				len(results), formatBytes(upload), verb, formatBytes(present))
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&topicID, "topic", "", "Topic to create new datasets in")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would be uploaded without uploading")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Don't show progress")
+
+	return cmd
+}
+
+// printImportProgress redraws a per-file upload progress line on stderr.
+func printImportProgress(p client.ImportProgress) {
+	end := "\r"
+	if p.SentChunks == p.ToSend {
+		end = "\n"
+	}
+	fmt.Fprintf(os.Stderr, "%s: %d/%d chunks%s", p.Path, p.SentChunks, p.ToSend, end)
+}
+
+// printImportResult reports what happened to one imported file.
+func printImportResult(cmd *cobra.Command, r *client.ImportResult, dryRun bool) {
+	out := cmd.OutOrStdout()
+	switch {
+	case dryRun:
+		fmt.Fprintf(out, "%s: %s to upload, %s already present\n", r.Path, formatBytes(r.UploadBytes), formatBytes(r.PresentBytes))
+	case r.Unchanged:
+		fmt.Fprintf(out, "%s: unchanged (%s)\n", r.Path, r.DatasetID)
+	case r.Created:
+		fmt.Fprintf(out, "%s: created dataset %s\n", r.Path, r.DatasetID)
+	default:
+		fmt.Fprintf(out, "%s: added version %d to %s\n", r.Path, r.Version, r.DatasetID)
+	}
+	for _, v := range r.SchemaViolations {
+		fmt.Fprintf(out, "%s: warning: does not match the data schema: %s\n", r.Path, v)
+	}
+}
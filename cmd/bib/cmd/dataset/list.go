@@ -0,0 +1,116 @@
+package dataset
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// datasetItem is a dataset as written by bib dataset list
+type datasetItem struct {
+	ID          string    `json:"id" yaml:"id"`
+	TopicID     string    `json:"topic_id" yaml:"topic_id"`
+	Name        string    `json:"name" yaml:"name"`
+	Status      string    `json:"status" yaml:"status"`
+	Version     int32     `json:"version" yaml:"version"`
+	Size        int64     `json:"size" yaml:"size"`
+	ContentType string    `json:"content_type,omitempty" yaml:"content_type,omitempty"`
+	OwnerID     string    `json:"owner_id,omitempty" yaml:"owner_id,omitempty"`
+	Tags        []string  `json:"tags,omitempty" yaml:"tags,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at" yaml:"updated_at"`
+	Revision    int64     `json:"revision" yaml:"revision"`
+}
+
+func toDatasetItem(d *services.Dataset) datasetItem {
+	return datasetItem{
+		ID:          d.GetId(),
+		TopicID:     d.GetTopicId(),
+		Name:        d.GetName(),
+		Status:      d.GetStatus(),
+		Version:     d.GetVersion(),
+		Size:        d.GetSize(),
+		ContentType: d.GetContentType(),
+		OwnerID:     d.GetOwnerId(),
+		Tags:        d.GetTags(),
+		UpdatedAt:   d.GetUpdatedAt().AsTime(),
+		Revision:    d.GetRevision(),
+	}
+}
+
+func newListCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		topicID string
+		status  string
+		ownerID string
+		tags    []string
+		limit   int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List datasets",
+		Long: `List the datasets on a bibd node.
+
+Datasets are streamed from the daemon. With --output jsonl each dataset is
+printed as one line of JSON as soon as it arrives, which suits large
+listings and tools such as jq. The other formats print once the listing is
+complete.
+
+If the stream fails part way, the datasets received so far are still
+printed and the command exits with an error.`,
+		Example: `  # List all datasets
+  bib dataset list
+
+  # List the datasets of a topic as JSON lines
+  bib dataset list --topic weather -o jsonl | jq -r .name`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			datasetClient, err := c.Dataset()
+			if err != nil {
+				return err
+			}
+
+			stream, err := datasetClient.StreamDatasets(ctx, &services.ListDatasetsRequest{
+				TopicId: topicID,
+				Status:  status,
+				OwnerId: ownerID,
+				Tags:    tags,
+				Page:    &bibv1.PageRequest{Limit: limit},
+			})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			s := output.NewStream(w,
+				[]string{"ID", "TOPIC", "NAME", "STATUS", "VERSION", "SIZE", "TAGS"},
+				func(d datasetItem) []string {
+					return []string{
+						d.ID, d.TopicID, d.Name, d.Status,
+						fmt.Sprint(d.Version), formatBytes(d.Size), strings.Join(d.Tags, ","),
+					}
+				})
+			return output.Copy(s, stream, toDatasetItem)
+		},
+	}
+
+	cmd.Flags().StringVar(&topicID, "topic", "", "Only list datasets in this topic")
+	cmd.Flags().StringVar(&status, "status", "", "Only list datasets with this status")
+	cmd.Flags().StringVar(&ownerID, "owner", "", "Only list datasets owned by this user")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only list datasets with this tag (repeatable)")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Maximum number of datasets (0 = all)")
+
+	return cmd
+}
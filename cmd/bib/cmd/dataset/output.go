@@ -0,0 +1,9 @@
+package dataset
+
+// outputFormat stores the current output format (set by root command)
+var outputFormat = "table"
+
+// SetOutputFormat sets the output format (called from root command)
+func SetOutputFormat(format string) {
+	outputFormat = format
+}
@@ -0,0 +1,165 @@
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaItem is a data schema as written by bib dataset schema
+type schemaItem struct {
+	Mode   string `json:"mode" yaml:"mode"`
+	Schema any    `json:"json_schema" yaml:"json_schema"`
+
+	// Revision of the dataset, for --if-revision
+	Revision int64 `json:"revision" yaml:"revision"`
+}
+
+func newSchemaCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		file     string
+		mode     string
+		remove   bool
+		revision int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "schema <dataset-id>",
+		Short: "Show or set the data schema of a dataset",
+		Long: `Show or set the JSON Schema that a dataset's content must match.
+
+Content is checked on every upload as a sequence of JSON values, so both a
+single document and JSON Lines work; each value must match the schema.
+
+  enforce  reject non-conforming uploads, listing each violation
+  warn     accept them, but record the violations in the audit log
+
+A dataset's own schema takes precedence over its topic's. Setting or
+clearing the schema requires ownership of the dataset.`,
+		Example: `  # Show the schema
+  bib dataset schema 7c9e6679-...
+
+  # Require every record to match record.schema.json
+  bib dataset schema 7c9e6679-... --set record.schema.json
+
+  # Only report violations
+  bib dataset schema 7c9e6679-... --set record.schema.json --mode warn
+
+  # Fall back to the topic's schema
+  bib dataset schema 7c9e6679-... --clear`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if file != "" && remove {
+				return fmt.Errorf("--set and --clear are mutually exclusive")
+			}
+			if file == "" && cmd.Flags().Changed("mode") {
+				return fmt.Errorf("--mode requires --set")
+			}
+			if file == "" && !remove && revision != 0 {
+				return fmt.Errorf("--if-revision requires --set or --clear")
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			datasetClient, err := c.Dataset()
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+
+			if file == "" && !remove {
+				resp, err := datasetClient.GetDataset(ctx, &services.GetDatasetRequest{Id: args[0]})
+				if err != nil {
+					return err
+				}
+				ds := resp.GetDataset().GetDataSchema()
+				if ds == nil {
+					w.Info("The dataset has no data schema of its own; its topic's schema applies")
+					return nil
+				}
+				return writeSchema(w, ds, resp.GetDataset().GetRevision())
+			}
+
+			req := &services.UpdateDatasetRequest{Id: args[0], UpdateDataSchema: true, ExpectedRevision: revision}
+			if file != "" {
+				schema, err := readSchemaFile(cmd, file)
+				if err != nil {
+					return err
+				}
+				req.DataSchema = &bibv1.DataSchema{JsonSchema: schema, Mode: mode}
+			}
+			resp, err := datasetClient.UpdateDataset(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			if w.Format() != output.FormatTable {
+				if ds := resp.GetDataset().GetDataSchema(); ds != nil {
+					return writeSchema(w, ds, resp.GetDataset().GetRevision())
+				}
+				return w.Write(struct{}{})
+			}
+			if remove {
+				w.Success(fmt.Sprintf("Removed the data schema of %s", resp.GetDataset().GetName()))
+			} else {
+				w.Success(fmt.Sprintf("Set the data schema of %s (%s)", resp.GetDataset().GetName(), mode))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "set", "", "Set the schema from a JSON Schema file (- for stdin)")
+	cmd.Flags().StringVar(&mode, "mode", "enforce", "How to handle non-conforming uploads: enforce or warn")
+	cmd.Flags().BoolVar(&remove, "clear", false, "Remove the dataset's schema")
+	addRevisionFlag(cmd, &revision)
+
+	return cmd
+}
+
+// writeSchema writes a data schema; tables get the mode and the schema
+// document as is.
+func writeSchema(w *output.Writer, ds *bibv1.DataSchema, revision int64) error {
+	if w.Format() == output.FormatTable {
+		w.Printf("Mode: %s\n", ds.GetMode())
+		w.Printf("Revision: %d\n", revision)
+		w.Println(ds.GetJsonSchema())
+		return nil
+	}
+	item := schemaItem{Mode: ds.GetMode(), Revision: revision}
+	if err := json.Unmarshal([]byte(ds.GetJsonSchema()), &item.Schema); err != nil {
+		item.Schema = ds.GetJsonSchema()
+	}
+	return w.Write(item)
+}
+
+// readSchemaFile reads a schema document from a file, or stdin for "-".
+func readSchemaFile(cmd *cobra.Command, path string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read schema: %w", err)
+	}
+	if !json.Valid(data) {
+		return "", fmt.Errorf("%s is not valid JSON", path)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,167 @@
+package dataset
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// versionItem is a dataset version as written by the version commands
+type versionItem struct {
+	Version      int32     `json:"version" yaml:"version"`
+	ID           string    `json:"id" yaml:"id"`
+	DatasetID    string    `json:"dataset_id" yaml:"dataset_id"`
+	Size         int64     `json:"size" yaml:"size"`
+	Hash         string    `json:"hash,omitempty" yaml:"hash,omitempty"`
+	ChunkCount   int32     `json:"chunk_count" yaml:"chunk_count"`
+	CreatedBy    string    `json:"created_by" yaml:"created_by"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
+	Message      string    `json:"message,omitempty" yaml:"message,omitempty"`
+	Parent       int32     `json:"parent_version,omitempty" yaml:"parent_version,omitempty"`
+	RevertedFrom int32     `json:"reverted_from,omitempty" yaml:"reverted_from,omitempty"`
+}
+
+func toVersionItem(v *services.DatasetVersion) versionItem {
+	return versionItem{
+		Version:      v.GetVersion(),
+		ID:           v.GetId(),
+		DatasetID:    v.GetDatasetId(),
+		Size:         v.GetSize(),
+		Hash:         v.GetHash(),
+		ChunkCount:   v.GetChunkCount(),
+		CreatedBy:    v.GetCreatedBy(),
+		CreatedAt:    v.GetCreatedAt().AsTime(),
+		Message:      v.GetMessage(),
+		Parent:       v.GetParentVersion(),
+		RevertedFrom: v.GetRevertedFrom(),
+	}
+}
+
+func newVersionsCommand(getClient ClientFunc) *cobra.Command {
+	var limit int32
+
+	cmd := &cobra.Command{
+		Use:   "versions <dataset-id>",
+		Short: "List the versions of a dataset",
+		Long: `List the versions of a dataset, newest first.
+
+Each upload that changes a dataset's content records a new version. Old
+versions may be removed by the node's retention policy; the latest version
+is always kept.`,
+		Example: `  bib dataset versions 7c9e6679-...
+  bib dataset versions 7c9e6679-... -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			datasetClient, err := c.Dataset()
+			if err != nil {
+				return err
+			}
+
+			resp, err := datasetClient.GetDatasetVersions(ctx, &services.GetDatasetVersionsRequest{
+				DatasetId: args[0],
+				Page:      &bibv1.PageRequest{Limit: limit},
+			})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			s := output.NewStream(w,
+				[]string{"VERSION", "CREATED", "AUTHOR", "SIZE", "HASH", "MESSAGE"},
+				func(v versionItem) []string {
+					hash := v.Hash
+					if len(hash) > 12 {
+						hash = hash[:12]
+					}
+					return []string{
+						fmt.Sprint(v.Version), v.CreatedAt.Local().Format(time.DateTime), v.CreatedBy,
+						formatBytes(v.Size), hash, v.Message,
+					}
+				})
+			for _, v := range resp.GetVersions() {
+				if err := s.Write(toVersionItem(v)); err != nil {
+					return err
+				}
+			}
+			return s.Close()
+		},
+	}
+
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Maximum number of versions (default 50)")
+
+	return cmd
+}
+
+func newRevertCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		message  string
+		revision int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revert <dataset-id> <version>",
+		Short: "Restore an earlier version of a dataset",
+		Long: `Restore an earlier version of a dataset.
+
+A new version is recorded with the content of the given version; the
+versions in between stay in the history. No content is copied, as the new
+version shares the earlier version's stored chunks. Requires ownership of
+the dataset.`,
+		Example: `  bib dataset revert 7c9e6679-... 3
+  bib dataset revert 7c9e6679-... 3 --message "Undo bad import"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil || version <= 0 {
+				return fmt.Errorf("invalid version %q: must be a positive number", args[1])
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			datasetClient, err := c.Dataset()
+			if err != nil {
+				return err
+			}
+
+			resp, err := datasetClient.RevertToVersion(ctx, &services.RevertToVersionRequest{
+				DatasetId:        args[0],
+				Version:          int32(version),
+				Message:          message,
+				ExpectedRevision: revision,
+			})
+			if err != nil {
+				return err
+			}
+
+			item := toVersionItem(resp.GetVersion())
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() == output.FormatTable {
+				w.Success(fmt.Sprintf("Restored version %d of %s as version %d", item.RevertedFrom, resp.GetDataset().GetName(), item.Version))
+				return nil
+			}
+			return w.Write(item)
+		},
+	}
+
+	cmd.Flags().StringVar(&message, "message", "", "Change description (default \"Revert to version N\")")
+	addRevisionFlag(cmd, &revision)
+
+	return cmd
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"bib/internal/grpc/client"
+	grpcerrors "bib/internal/grpc/errors"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// Exit codes of bib. Scripts rely on them, so never renumber one.
+const (
+	ExitOK                 = 0
+	ExitError              = 1 // any error not listed below
+	ExitUsage              = 2 // invalid flags or arguments
+	ExitInvalidArgument    = 2 // the daemon rejected the input
+	ExitConfig             = 3
+	ExitUnavailable        = 4 // daemon unreachable
+	ExitUnauthenticated    = 5
+	ExitPermissionDenied   = 6
+	ExitNotFound           = 7
+	ExitAlreadyExists      = 8
+	ExitResourceExhausted  = 9 // quota exceeded or rate limited
+	ExitFailedPrecondition = 10
+	ExitTimeout            = 11 // the daemon did not answer in time
+)
+
+// configError marks errors loading the configuration
+type configError struct {
+	err error
+}
+
+func (e *configError) Error() string { return e.err.Error() }
+func (e *configError) Unwrap() error { return e.err }
+
+// usageError marks invalid flags or arguments of cmd
+type usageError struct {
+	cmd *cobra.Command
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// markUsageErrors makes flag and argument errors of cmd and its children
+// usage errors, so they exit with ExitUsage.
+func markUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return &usageError{cmd: c, err: err}
+	})
+	if validate := cmd.Args; validate != nil {
+		cmd.Args = func(c *cobra.Command, args []string) error {
+			if err := validate(c, args); err != nil {
+				return &usageError{cmd: c, err: err}
+			}
+			return nil
+		}
+	}
+	for _, child := range cmd.Commands() {
+		markUsageErrors(child)
+	}
+}
+
+// reasonExitCodes maps error reasons to exit codes
+var reasonExitCodes = map[grpcerrors.Reason]int{
+	grpcerrors.ReasonInvalidArgument:    ExitInvalidArgument,
+	grpcerrors.ReasonNotFound:           ExitNotFound,
+	grpcerrors.ReasonAlreadyExists:      ExitAlreadyExists,
+	grpcerrors.ReasonPermissionDenied:   ExitPermissionDenied,
+	grpcerrors.ReasonUnauthenticated:    ExitUnauthenticated,
+	grpcerrors.ReasonResourceExhausted:  ExitResourceExhausted,
+	grpcerrors.ReasonQuotaExceeded:      ExitResourceExhausted,
+	grpcerrors.ReasonRateLimited:        ExitResourceExhausted,
+	grpcerrors.ReasonFailedPrecondition: ExitFailedPrecondition,
+	grpcerrors.ReasonUnavailable:        ExitUnavailable,
+	grpcerrors.ReasonDeadlineExceeded:   ExitTimeout,
+}
+
+// reasonHints tell the user what to do about an error
+var reasonHints = map[grpcerrors.Reason]string{
+	grpcerrors.ReasonUnauthenticated:  "Your session is missing or expired; run 'bib connect' to sign in again.",
+	grpcerrors.ReasonQuotaExceeded:    "A quota is used up; ask an administrator to raise it or free some space.",
+	grpcerrors.ReasonRateLimited:      "Too many requests; wait a moment and try again.",
+	grpcerrors.ReasonUnavailable:      "Is bibd running and reachable? Check with 'bib connect --test'.",
+	grpcerrors.ReasonDeadlineExceeded: "The daemon did not answer in time; check its health or raise connection.timeout.",
+}
+
+// subreasonHints explain failed preconditions
+var subreasonHints = map[string]string{
+	grpcerrors.SubreasonTopicArchived: "The topic is archived; unarchive it first.",
+	grpcerrors.SubreasonTopicNotEmpty: "The topic still has datasets; delete them or force the deletion.",
+	grpcerrors.SubreasonLastOwner:     "You are the last owner; transfer ownership to someone else first.",
+	grpcerrors.SubreasonNoContent:     "This version only stores instructions, there is no data to download.",
+	grpcerrors.SubreasonMissingChunks: "Some chunks never reached the daemon; retry the upload.",
+	grpcerrors.SubreasonDowngrade:     "Pass --allow-downgrade to install an older version.",
+
+	grpcerrors.SubreasonRevisionConflict:     "Someone else changed it since it was read; check the current state and retry.",
+	grpcerrors.SubreasonLockHeld:             "Another holder has the lock; wait for it to be released or to expire.",
+	grpcerrors.SubreasonConfirmationRequired: "Preview the operation with --dry-run and pass the confirmation token it prints.",
+	grpcerrors.SubreasonBackupUnavailable:    "The daemon can't take backups; back up the database yourself and pass --skip-backup.",
+}
+
+// cliError is the machine-readable form of an error, written with -o json/yaml
+type cliError struct {
+	Error       string `json:"error" yaml:"error"`
+	Code        string `json:"code,omitempty" yaml:"code,omitempty"`
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Subreason   string `json:"subreason,omitempty" yaml:"subreason,omitempty"`
+	MessageCode string `json:"message_code,omitempty" yaml:"message_code,omitempty"`
+	Hint        string `json:"hint,omitempty" yaml:"hint,omitempty"`
+	ExitCode    int    `json:"exit_code" yaml:"exit_code"`
+}
+
+// describeError turns err into its CLI representation.
+func describeError(err error) cliError {
+	out := cliError{
+		Error:    friendlyMessage(err),
+		ExitCode: ExitError,
+	}
+
+	reason := grpcerrors.ReasonOf(err)
+	if reason == "" {
+		// Connection and session errors raised by the client itself
+		switch {
+		case client.IsUnavailable(err):
+			reason = grpcerrors.ReasonUnavailable
+		case client.IsUnauthenticated(err):
+			reason = grpcerrors.ReasonUnauthenticated
+		case errors.Is(err, context.DeadlineExceeded):
+			reason = grpcerrors.ReasonDeadlineExceeded
+		}
+	}
+	if st, ok := grpcStatus(err); ok {
+		out.Code = st.Code().String()
+	}
+
+	out.Reason = string(reason)
+	out.Subreason = grpcerrors.SubreasonOf(err)
+	out.MessageCode = grpcerrors.MessageCode(err)
+	if code, ok := reasonExitCodes[reason]; ok {
+		out.ExitCode = code
+	}
+	var (
+		cfgErr   *configError
+		usageErr *usageError
+	)
+	switch {
+	case errors.As(err, &cfgErr):
+		out.ExitCode = ExitConfig
+	case errors.As(err, &usageErr):
+		out.ExitCode = ExitUsage
+		out.Hint = fmt.Sprintf("Run '%s --help' for usage.", usageErr.cmd.CommandPath())
+		return out
+	}
+
+	out.Hint = reasonHints[reason]
+	if hint, ok := subreasonHints[out.Subreason]; ok {
+		out.Hint = hint
+	}
+	if current := grpcerrors.ErrorInfo(err).GetMetadata()[grpcerrors.CurrentRevisionKey]; current != "" && out.Subreason == grpcerrors.SubreasonRevisionConflict {
+		out.Hint = fmt.Sprintf("Someone else changed it since it was read; it is now at revision %s. Check the current state and retry with --if-revision %s.", current, current)
+	}
+	if role := grpcerrors.ErrorInfo(err).GetMetadata()["required_role"]; role != "" && reason == grpcerrors.ReasonPermissionDenied {
+		out.Hint = fmt.Sprintf("This requires the %s role; ask an administrator for access.", role)
+	}
+
+	return out
+}
+
+// friendlyMessage is the error text without gRPC framing, localized if the
+// daemon sent a localized message.
+func friendlyMessage(err error) string {
+	msg := client.LocalizedError(err)
+	if st, ok := grpcStatus(err); ok {
+		msg = strings.Replace(msg, fmt.Sprintf("rpc error: code = %s desc = ", st.Code()), "", 1)
+	}
+	return msg
+}
+
+// grpcStatus finds the gRPC status of err, which may be wrapped.
+func grpcStatus(err error) (*status.Status, bool) {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return nil, false
+	}
+	return grpcErr.GRPCStatus(), true
+}
+
+// writeError reports err on w in the given output format and returns the
+// exit code to use.
+func writeError(w io.Writer, format string, err error) int {
+	out := describeError(err)
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(out)
+	case "jsonl":
+		_ = json.NewEncoder(w).Encode(out)
+	case "yaml":
+		_ = yaml.NewEncoder(w).Encode(out)
+	default:
+		fmt.Fprintf(w, "Error: %s\n", out.Error)
+		if out.Hint != "" {
+			fmt.Fprintf(w, "Hint: %s\n", out.Hint)
+		}
+	}
+
+	return out.ExitCode
+}
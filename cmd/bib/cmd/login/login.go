@@ -0,0 +1,223 @@
+// Package login provides the bib login command.
+package login
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+	client "bib/internal/grpc/client"
+	"bib/internal/version"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// session is a signed-in session as written by bib login
+type session struct {
+	UserID      string    `json:"user_id" yaml:"user_id"`
+	Name        string    `json:"name" yaml:"name"`
+	Role        string    `json:"role" yaml:"role"`
+	SessionID   string    `json:"session_id" yaml:"session_id"`
+	ExpiresAt   time.Time `json:"expires_at" yaml:"expires_at"`
+	ConnectedTo string    `json:"connected_to" yaml:"connected_to"`
+}
+
+// NewCommand creates the login command. connect returns a client that is
+// not yet signed in; getClient returns a signed-in client for approving.
+func NewCommand(connect, getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Sign in by approving this device from another one",
+		Long: `Sign in without an identity key on this machine.
+
+bib login shows a short code. Approve it from a device where you are already
+signed in, with bib login approve or at the verification URL if the node has
+one, and this machine receives its own session. This avoids copying identity
+keys to headless servers.
+
+The session is attributed to the key of the approving session, so removing
+that key with bib user keys remove also signs this machine out.`,
+		Example: `  bib login
+  bib login approve BCDF-GHJK`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogin(cmd, connect)
+		},
+	}
+
+	cmd.AddCommand(newApproveCommand(getClient))
+
+	return cmd
+}
+
+func runLogin(cmd *cobra.Command, connect ClientFunc) error {
+	ctx := cmd.Context()
+
+	c, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	authClient, err := c.Auth()
+	if err != nil {
+		return err
+	}
+
+	hostname, _ := os.Hostname()
+	start, err := authClient.StartDeviceAuth(ctx, &services.StartDeviceAuthRequest{
+		ClientInfo: &services.ClientInfo{
+			UserAgent: "bib-cli",
+			Version:   version.Version,
+			Metadata:  map[string]string{"hostname": hostname},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Instructions go to stderr for structured output, so stdout holds only
+	// the result
+	w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+	prompt := cmd.OutOrStdout()
+	if w.Format() != output.FormatTable {
+		prompt = cmd.ErrOrStderr()
+	}
+
+	fmt.Fprintf(prompt, "Your code is %s\n\n", start.UserCode)
+	if uri := start.VerificationUri; uri != "" {
+		fmt.Fprintf(prompt, "Approve it at %s\n", uri)
+		fmt.Fprintf(prompt, "or run on a signed-in device:\n\n")
+	} else {
+		fmt.Fprintf(prompt, "Approve it by running on a signed-in device:\n\n")
+	}
+	fmt.Fprintf(prompt, "  bib login approve %s\n\n", start.UserCode)
+	fmt.Fprintf(prompt, "Waiting for approval (expires %s)...\n",
+		start.ExpiresAt.AsTime().Local().Format(time.TimeOnly))
+
+	resp, err := pollApproval(ctx, authClient, start)
+	if err != nil {
+		return err
+	}
+
+	if err := c.SetSession(resp.SessionToken); err != nil {
+		return fmt.Errorf("signed in, but failed to save the session: %w", err)
+	}
+
+	s := session{
+		UserID:      resp.GetUser().GetId(),
+		Name:        resp.GetUser().GetName(),
+		Role:        resp.GetUser().GetRole(),
+		SessionID:   resp.GetSession().GetId(),
+		ConnectedTo: c.ConnectedTo(),
+	}
+	if resp.GetExpiresAt() != nil {
+		s.ExpiresAt = resp.GetExpiresAt().AsTime()
+	}
+
+	if w.Format() != output.FormatTable {
+		return w.Write(s)
+	}
+	w.Success(fmt.Sprintf("Signed in as %s (%s) on %s", s.Name, s.Role, s.ConnectedTo))
+	return nil
+}
+
+// pollApproval polls until the authorization is approved, respecting the
+// interval the node asks for.
+func pollApproval(ctx context.Context, authClient services.AuthServiceClient, start *services.StartDeviceAuthResponse) (*services.PollDeviceAuthResponse, error) {
+	interval := time.Duration(start.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		resp, err := authClient.PollDeviceAuth(ctx, &services.PollDeviceAuthRequest{
+			DeviceCode: start.DeviceCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.IntervalSeconds > 0 {
+			interval = time.Duration(resp.IntervalSeconds) * time.Second
+		}
+
+		switch resp.Status {
+		case services.DeviceAuthStatus_DEVICE_AUTH_STATUS_APPROVED:
+			return resp, nil
+		case services.DeviceAuthStatus_DEVICE_AUTH_STATUS_DENIED:
+			return nil, fmt.Errorf("login was denied")
+		case services.DeviceAuthStatus_DEVICE_AUTH_STATUS_EXPIRED:
+			return nil, fmt.Errorf("code expired before it was approved; run bib login again")
+		}
+	}
+}
+
+func newApproveCommand(getClient ClientFunc) *cobra.Command {
+	var deny bool
+
+	cmd := &cobra.Command{
+		Use:   "approve <code>",
+		Short: "Approve a device waiting in bib login",
+		Long: `Approve the code shown by bib login on another device, signing that
+device in as you. Only approve codes you started yourself.`,
+		Example: `  bib login approve BCDF-GHJK
+  bib login approve BCDF-GHJK --deny`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			authClient, err := c.Auth()
+			if err != nil {
+				return err
+			}
+
+			resp, err := authClient.ApproveDeviceAuth(ctx, &services.ApproveDeviceAuthRequest{
+				UserCode: args[0],
+				Deny:     deny,
+			})
+			if err != nil {
+				return err
+			}
+
+			device := resp.GetClientInfo().GetMetadata()["hostname"]
+			if device == "" {
+				device = resp.GetClientInfo().GetIpAddress()
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(map[string]any{
+					"code":     args[0],
+					"approved": resp.GetApproved(),
+					"device":   device,
+				})
+			}
+			if !resp.GetApproved() {
+				w.Success(fmt.Sprintf("Denied login of %s", device))
+				return nil
+			}
+			w.Success(fmt.Sprintf("Approved login of %s", device))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&deny, "deny", false, "Deny the login instead of approving it")
+
+	return cmd
+}
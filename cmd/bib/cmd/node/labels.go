@@ -0,0 +1,228 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// peerItem is a peer as written by bib node peers
+type peerItem struct {
+	ID        string            `json:"id" yaml:"id"`
+	Mode      string            `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Connected bool              `json:"connected" yaml:"connected"`
+	LatencyMs int64             `json:"latency_ms,omitempty" yaml:"latency_ms,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+func newPeersCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		selector map[string]string
+		all      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "peers",
+		Short: "List the node's peers and their labels",
+		Long: `List the peers of the connected node with their labels. Labels are
+exchanged when peers connect, so only connected peers are listed unless
+--all is given.
+
+--label restricts the list to peers having all of the given labels.`,
+		Example: `  bib node peers
+  bib node peers --label region=eu-west
+  bib node peers --label region=eu-west,role=archive --all`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			nodeClient, err := c.Node()
+			if err != nil {
+				return err
+			}
+
+			resp, err := nodeClient.ListNodes(ctx, &services.ListNodesRequest{
+				ConnectedOnly: !all,
+				LabelSelector: selector,
+			})
+			if err != nil {
+				return err
+			}
+
+			items := make([]peerItem, 0, len(resp.GetNodes()))
+			for _, n := range resp.GetNodes() {
+				items = append(items, peerItem{
+					ID:        n.GetId(),
+					Mode:      n.GetMode(),
+					Connected: n.GetConnected(),
+					LatencyMs: n.GetLatencyMs(),
+					Labels:    n.GetLabels(),
+				})
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(items)
+			}
+			if len(items) == 0 {
+				w.Info("No peers")
+				return nil
+			}
+
+			table := output.NewTable("PEER ID", "CONNECTED", "LATENCY", "LABELS")
+			for _, item := range items {
+				latency := "-"
+				if item.LatencyMs > 0 {
+					latency = fmt.Sprintf("%dms", item.LatencyMs)
+				}
+				table.AddRow(item.ID, fmt.Sprint(item.Connected), latency, formatLabels(item.Labels))
+			}
+			return w.Write(table)
+		},
+	}
+
+	cmd.Flags().StringToStringVarP(&selector, "label", "l", nil, "only peers with these labels (key=value)")
+	cmd.Flags().BoolVar(&all, "all", false, "include known peers that are not connected")
+
+	return cmd
+}
+
+func newLabelCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		remove  []string
+		replace bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "label [key=value ...]",
+		Short: "Show or change the node's labels",
+		Long: `Show or change the labels of the connected node. Labels describe the
+node (region, role, capacity class) and are pushed to the connected peers,
+which can then select nodes by label.
+
+Given key=value pairs are added to the labels, or overwrite them;
+--remove drops labels by key and --replace drops all labels not given.
+Without arguments, the current labels are shown. Changes are written to the
+daemon's config file and require the admin role.
+
+Keys are lowercase letters, digits, '-' and '_'; values are letters,
+digits, '.', '-' and '_', up to 63 characters each.`,
+		Example: `  bib node label
+  bib node label region=eu-west role=archive
+  bib node label --remove role
+  bib node label --replace region=us-east`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			labels, err := parseLabels(args)
+			if err != nil {
+				return err
+			}
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			nodeClient, err := c.Node()
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+
+			if len(labels) == 0 && len(remove) == 0 && !replace {
+				resp, err := nodeClient.GetSelfNode(ctx, &services.GetSelfNodeRequest{})
+				if err != nil {
+					return err
+				}
+				return writeLabels(w, resp.GetNode().GetLabels())
+			}
+
+			resp, err := nodeClient.SetLabels(ctx, &services.SetLabelsRequest{
+				Labels:  labels,
+				Remove:  remove,
+				Replace: replace,
+			})
+			if err != nil {
+				return err
+			}
+
+			if w.Format() != output.FormatTable {
+				return w.Write(map[string]any{"labels": resp.GetLabels(), "persisted": resp.GetPersisted()})
+			}
+			w.Success("Labels updated")
+			if !resp.GetPersisted() {
+				w.Warn("The labels could not be written to the daemon's config file; they revert on restart")
+			}
+			return writeLabels(w, resp.GetLabels())
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&remove, "remove", nil, "label keys to remove")
+	cmd.Flags().BoolVar(&replace, "replace", false, "drop the labels not given")
+
+	return cmd
+}
+
+// writeLabels writes labels as a KEY/VALUE table, or as a map in other formats
+func writeLabels(w *output.Writer, labels map[string]string) error {
+	if w.Format() != output.FormatTable {
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		return w.Write(labels)
+	}
+	if len(labels) == 0 {
+		w.Info("No labels")
+		return nil
+	}
+
+	table := output.NewTable("KEY", "VALUE")
+	for _, k := range sortedKeys(labels) {
+		table.AddRow(k, labels[k])
+	}
+	return w.Write(table)
+}
+
+// parseLabels parses key=value arguments
+func parseLabels(args []string) (map[string]string, error) {
+	labels := make(map[string]string, len(args))
+	for _, arg := range args {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", arg)
+		}
+		labels[k] = v
+	}
+	return labels, nil
+}
+
+// formatLabels formats labels as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, k := range sortedKeys(labels) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// modeItem is a mode switch as written by bib node mode
+type modeItem struct {
+	PreviousMode string `json:"previous_mode" yaml:"previous_mode"`
+	Mode         string `json:"mode" yaml:"mode"`
+	Changed      bool   `json:"changed" yaml:"changed"`
+	Persisted    bool   `json:"persisted" yaml:"persisted"`
+}
+
+func newModeCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		purge bool
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "mode <proxy|selective|full>",
+		Short: "Switch the node's P2P mode at runtime",
+		Long: `Switch the node's P2P mode without a restart. The new mode is written to
+the daemon's config file, so it survives restarts.
+
+Full mode requires the PostgreSQL backend and starts an initial sync with
+the connected peers. When leaving full mode, the replicated catalogs and
+sync checkpoints are kept so a later switch back resumes where it stopped;
+--purge removes them instead, after confirmation.
+
+Requires the admin role.`,
+		Example: `  bib node mode selective
+  bib node mode full
+  bib node mode proxy --purge`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"proxy", "selective", "full"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if purge && !force {
+				fmt.Fprint(cmd.OutOrStdout(), "Purge the replicated data if the node leaves full mode? (y/N): ")
+				response, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if r := strings.TrimSpace(response); r != "y" && r != "Y" {
+					fmt.Fprintln(cmd.OutOrStdout(), "Cancelled")
+					return nil
+				}
+			}
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			nodeClient, err := c.Node()
+			if err != nil {
+				return err
+			}
+
+			resp, err := nodeClient.SetMode(ctx, &services.SetModeRequest{
+				Mode:         args[0],
+				PurgeReplica: purge,
+			})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(modeItem{
+					PreviousMode: resp.GetPreviousMode(),
+					Mode:         resp.GetMode(),
+					Changed:      resp.GetChanged(),
+					Persisted:    resp.GetPersisted(),
+				})
+			}
+			if !resp.GetChanged() {
+				w.Info("Node already in " + resp.GetMode() + " mode")
+				return nil
+			}
+			w.Success(fmt.Sprintf("Switched from %s to %s mode", resp.GetPreviousMode(), resp.GetMode()))
+			if !resp.GetPersisted() {
+				w.Warn("The mode could not be written to the daemon's config file; it reverts on restart")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&purge, "purge", false, "remove the replicated data when leaving full mode")
+	cmd.Flags().BoolVar(&force, "force", false, "purge without confirmation")
+
+	return cmd
+}
@@ -0,0 +1,42 @@
+// Package node provides the bib node commands, which manage the connected
+// node.
+package node
+
+import (
+	"context"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// NewCommand creates the node command and subcommands.
+func NewCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Manage the connected node",
+		Long: `Manage the connected node.
+
+The mode command switches the node between proxy, selective and full mode
+at runtime. A selective node replicates the topics matching its subscriptions. The
+subscribe and unsubscribe commands change them at runtime; the change is
+persisted to the node's subscription store and survives restarts. The send
+command delivers a direct message to a peer. The label command sets the
+node's labels, which peers exchange; peers lists the peers with theirs.`,
+	}
+
+	cmd.AddCommand(
+		newModeCommand(getClient),
+		newSubscribeCommand(getClient),
+		newUnsubscribeCommand(getClient),
+		newSubscriptionsCommand(getClient),
+		newSendCommand(getClient),
+		newLabelCommand(getClient),
+		newPeersCommand(getClient),
+	)
+
+	return cmd
+}
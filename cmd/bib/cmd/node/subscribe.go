@@ -0,0 +1,161 @@
+package node
+
+import (
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// subscriptionItem is a subscription as written by bib node
+type subscriptionItem struct {
+	Pattern   string     `json:"pattern" yaml:"pattern"`
+	CreatedAt time.Time  `json:"created_at" yaml:"created_at"`
+	LastSync  *time.Time `json:"last_sync,omitempty" yaml:"last_sync,omitempty"`
+}
+
+func toSubscriptionItem(s *services.NodeSubscription) subscriptionItem {
+	item := subscriptionItem{
+		Pattern:   s.GetTopicPattern(),
+		CreatedAt: s.GetCreatedAt().AsTime(),
+	}
+	if s.GetLastSync() != nil {
+		t := s.GetLastSync().AsTime()
+		item.LastSync = &t
+	}
+	return item
+}
+
+func newSubscribeCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:   "subscribe <pattern>",
+		Short: "Subscribe a selective node to a topic pattern",
+		Long: `Subscribe a selective node to a topic pattern. The matching topics are
+synced right away and replicated from then on.
+
+Patterns match topic names: "weather" matches the topic only, "weather/*"
+its sub-topics as well. Requires the admin role.`,
+		Example: `  bib node subscribe "weather/*"
+  bib node subscribe finance/stocks`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			nodeClient, err := c.Node()
+			if err != nil {
+				return err
+			}
+
+			resp, err := nodeClient.AddSubscription(ctx, &services.AddSubscriptionRequest{Pattern: args[0]})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(toSubscriptionItem(resp.GetSubscription()))
+			}
+			if resp.GetAdded() {
+				w.Success("Subscribed to " + args[0] + "; syncing matching topics")
+			} else {
+				w.Info("Already subscribed to " + args[0])
+			}
+			return nil
+		},
+	}
+}
+
+func newUnsubscribeCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unsubscribe <pattern>",
+		Short: "Unsubscribe a selective node from a topic pattern",
+		Long: `Unsubscribe a selective node from a topic pattern. The matching topics
+are no longer replicated. Requires the admin role.`,
+		Example: `  bib node unsubscribe "weather/*"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			nodeClient, err := c.Node()
+			if err != nil {
+				return err
+			}
+
+			resp, err := nodeClient.RemoveSubscription(ctx, &services.RemoveSubscriptionRequest{Pattern: args[0]})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(map[string]any{"pattern": args[0], "removed": resp.GetRemoved()})
+			}
+			if resp.GetRemoved() {
+				w.Success("Unsubscribed from " + args[0])
+			} else {
+				w.Info("Not subscribed to " + args[0])
+			}
+			return nil
+		},
+	}
+}
+
+func newSubscriptionsCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:     "subscriptions",
+		Short:   "List the topic patterns a selective node replicates",
+		Example: `  bib node subscriptions`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			nodeClient, err := c.Node()
+			if err != nil {
+				return err
+			}
+
+			resp, err := nodeClient.ListSubscriptions(ctx, &services.ListNodeSubscriptionsRequest{})
+			if err != nil {
+				return err
+			}
+
+			items := make([]subscriptionItem, 0, len(resp.GetSubscriptions()))
+			for _, s := range resp.GetSubscriptions() {
+				items = append(items, toSubscriptionItem(s))
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(items)
+			}
+			if len(items) == 0 {
+				w.Info("No subscriptions")
+				return nil
+			}
+
+			table := output.NewTable("PATTERN", "SUBSCRIBED", "LAST SYNC")
+			for _, item := range items {
+				lastSync := "never"
+				if item.LastSync != nil {
+					lastSync = item.LastSync.Local().Format(time.DateTime)
+				}
+				table.AddRow(item.Pattern, item.CreatedAt.Local().Format(time.DateTime), lastSync)
+			}
+			return w.Write(table)
+		},
+	}
+}
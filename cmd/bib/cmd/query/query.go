@@ -0,0 +1,28 @@
+// Package query provides the bib query commands.
+package query
+
+import (
+	"context"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// NewCommand creates the query command group. getClient is called lazily
+// by subcommands that need the daemon.
+func NewCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Run and manage queries",
+		Long:  `Run and manage CEL queries on a bibd node.`,
+	}
+
+	cmd.AddCommand(newRunCommand(getClient))
+	cmd.AddCommand(newSavedCommand(getClient))
+
+	return cmd
+}
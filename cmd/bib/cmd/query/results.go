@@ -0,0 +1,146 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"bib/internal/cli/export"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// exportFlags are the flags that export query results to a file.
+type exportFlags struct {
+	path   string
+	format string
+}
+
+func (f *exportFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.path, "export", "", "Export the results to a file (- for stdout)")
+	cmd.Flags().StringVar(&f.format, "export-format", "", "Export format: csv or parquet (default: from the file extension)")
+}
+
+// exportFormat validates the flags and returns the export format, or ""
+// without --export.
+func (f *exportFlags) exportFormat() (export.Format, error) {
+	switch {
+	case f.path == "":
+		if f.format != "" {
+			return "", fmt.Errorf("--export-format requires --export")
+		}
+		return "", nil
+	case f.format != "":
+		return export.ParseFormat(f.format)
+	case f.path == "-":
+		return export.FormatCSV, nil
+	default:
+		return export.FormatFromPath(f.path)
+	}
+}
+
+// resultSink receives the rows of a query result, either exporting them to
+// a file or writing them to the output.
+type resultSink struct {
+	w        *output.Writer
+	flags    exportFlags
+	file     *os.File
+	exporter *export.Writer
+	rows     []map[string]any
+}
+
+// newResultSink creates a sink for the results of cmd, creating the export
+// file if one was requested.
+func newResultSink(cmd *cobra.Command, flags exportFlags) (*resultSink, error) {
+	s := &resultSink{
+		w:     output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout()),
+		flags: flags,
+	}
+	if flags.path == "" {
+		return s, nil
+	}
+
+	format, err := flags.exportFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	var out io.Writer = cmd.OutOrStdout()
+	if flags.path != "-" {
+		if s.file, err = os.Create(flags.path); err != nil {
+			return nil, fmt.Errorf("failed to create export file: %w", err)
+		}
+		out = s.file
+	}
+	s.exporter = export.NewWriter(out, format)
+	return s, nil
+}
+
+// Write receives a row of the result.
+func (s *resultSink) Write(row map[string]any) error {
+	if s.exporter != nil {
+		return s.exporter.Write(row)
+	}
+	s.rows = append(s.rows, row)
+	return nil
+}
+
+// Abort discards a partial export.
+func (s *resultSink) Abort() {
+	if s.file != nil {
+		_ = s.file.Close()
+		_ = os.Remove(s.file.Name())
+	}
+}
+
+// Close completes the export, or writes the rows received; structured
+// output formats write what item returns for them.
+func (s *resultSink) Close(warnings []string, item func(rows []map[string]any) any) error {
+	if s.exporter != nil {
+		if err := s.exporter.Close(); err != nil {
+			s.Abort()
+			return fmt.Errorf("failed to export results: %w", err)
+		}
+		if s.file != nil {
+			if err := s.file.Close(); err != nil {
+				_ = os.Remove(s.file.Name())
+				return fmt.Errorf("failed to export results: %w", err)
+			}
+		}
+		for _, warning := range warnings {
+			s.w.Warn(warning)
+		}
+		if dropped := s.exporter.Dropped(); len(dropped) > 0 {
+			s.w.Warn(fmt.Sprintf("Columns first seen after the first rows were not exported: %v", dropped))
+		}
+		if s.file != nil {
+			s.w.Success(fmt.Sprintf("Exported %d rows to %s", s.exporter.Rows(), s.flags.path))
+		}
+		return nil
+	}
+
+	rows := s.rows
+	if rows == nil {
+		rows = []map[string]any{}
+	}
+	if s.w.Format() != output.FormatTable {
+		return s.w.Write(item(rows))
+	}
+	for _, warning := range warnings {
+		s.w.Warn(warning)
+	}
+	if len(rows) == 0 {
+		s.w.Info("No results")
+		return nil
+	}
+	for _, row := range rows {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		s.w.Println(string(data))
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package query
+
+import (
+	"errors"
+	"io"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+
+	"github.com/spf13/cobra"
+)
+
+// resultItem is the result of a query as written by bib query run
+type resultItem struct {
+	Expression string           `json:"expression" yaml:"expression"`
+	Results    []map[string]any `json:"results" yaml:"results"`
+	Warnings   []string         `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+func newRunCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		topics   []string
+		datasets []string
+		params   []string
+		limit    int32
+		exportTo exportFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run <expression>",
+		Short: "Run a query",
+		Long: `Run a CEL query and write or export its results.
+
+Results are streamed from the daemon. With --export they are written to a
+file as they arrive, as CSV or Parquet depending on the file extension or
+--export-format. Column types are inferred from the first rows: integral
+numbers become int64 columns, other numbers double, and lists and objects
+are written as JSON text.
+
+Placeholders of the form ${name} in the expression are filled in with
+--param values, read as JSON with a fallback to plain strings.`,
+		Example: `  bib query run 'temperature > 30' --topic weather
+  bib query run 'city == ${city}' --param city=Berlin --export berlin.csv
+  bib query run 'temperature > 30' --export hot.parquet
+  bib query run 'temperature > 30' --export - --export-format csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			values, err := parseParams(params)
+			if err != nil {
+				return err
+			}
+			if _, err := exportTo.exportFormat(); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			queryClient, err := c.Query()
+			if err != nil {
+				return err
+			}
+
+			req := &services.ExecuteQueryRequest{
+				Expression: args[0],
+				TopicIds:   topics,
+				DatasetIds: datasets,
+				Parameters: values,
+			}
+			if limit > 0 {
+				req.Page = &bibv1.PageRequest{Limit: limit}
+			}
+
+			sink, err := newResultSink(cmd, exportTo)
+			if err != nil {
+				return err
+			}
+			stream, err := queryClient.ExecuteStream(ctx, req)
+			if err != nil {
+				sink.Abort()
+				return err
+			}
+			for {
+				result, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					sink.Abort()
+					return err
+				}
+				if result.GetData() == nil {
+					continue
+				}
+				if err := sink.Write(result.GetData().AsMap()); err != nil {
+					sink.Abort()
+					return err
+				}
+			}
+
+			return sink.Close(nil, func(rows []map[string]any) any {
+				return resultItem{Expression: args[0], Results: rows}
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&topics, "topic", nil, "Query the datasets of this topic (repeatable)")
+	cmd.Flags().StringSliceVar(&datasets, "dataset", nil, "Query this dataset (repeatable)")
+	cmd.Flags().StringArrayVar(&params, "param", nil, "Parameter value as key=value (repeatable)")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Maximum number of results")
+	exportTo.register(cmd)
+
+	return cmd
+}
@@ -0,0 +1,377 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// savedQueryItem is a saved query as written by the saved query commands
+type savedQueryItem struct {
+	ID          string         `json:"id" yaml:"id"`
+	Name        string         `json:"name" yaml:"name"`
+	Expression  string         `json:"expression" yaml:"expression"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Parameters  []string       `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Defaults    map[string]any `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	Owner       string         `json:"owner_id" yaml:"owner_id"`
+	Public      bool           `json:"public" yaml:"public"`
+	Tags        []string       `json:"tags,omitempty" yaml:"tags,omitempty"`
+	UseCount    int64          `json:"use_count" yaml:"use_count"`
+	UpdatedAt   time.Time      `json:"updated_at" yaml:"updated_at"`
+}
+
+func toSavedQueryItem(q *services.SavedQuery) savedQueryItem {
+	item := savedQueryItem{
+		ID:          q.GetId(),
+		Name:        q.GetName(),
+		Expression:  q.GetExpression(),
+		Description: q.GetDescription(),
+		Owner:       q.GetOwnerId(),
+		Public:      q.GetIsPublic(),
+		Tags:        q.GetTags(),
+		UseCount:    q.GetUseCount(),
+	}
+	for _, p := range q.GetParameters() {
+		item.Parameters = append(item.Parameters, p.GetName())
+	}
+	if len(q.GetDefaultParameters()) > 0 {
+		item.Defaults = make(map[string]any, len(q.GetDefaultParameters()))
+		for name, v := range q.GetDefaultParameters() {
+			item.Defaults[name] = v.AsInterface()
+		}
+	}
+	if q.GetUpdatedAt() != nil {
+		item.UpdatedAt = q.GetUpdatedAt().AsTime()
+	}
+	return item
+}
+
+// runItem is the result of a saved query as written by bib query saved run
+type runItem struct {
+	Query      string           `json:"query" yaml:"query"`
+	Expression string           `json:"expression" yaml:"expression"`
+	Results    []map[string]any `json:"results" yaml:"results"`
+	Warnings   []string         `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+func newSavedCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "saved",
+		Short: "Work with saved queries",
+		Long: `Save queries under a name and run them later.
+
+A saved query belongs to the user who saved it; public queries can be run
+by everyone. Expressions may contain placeholders of the form ${name},
+which are filled in with parameters when the query is run.`,
+	}
+
+	cmd.AddCommand(newSaveCommand(getClient))
+	cmd.AddCommand(newRunSavedCommand(getClient))
+	cmd.AddCommand(newListCommand(getClient))
+	cmd.AddCommand(newDeleteCommand(getClient))
+
+	return cmd
+}
+
+func newSaveCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		description string
+		public      bool
+		tags        []string
+		defaults    []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "save <name> <expression>",
+		Short: "Save a query",
+		Long: `Save a query under a name, replacing any query of yours with that name.
+
+Placeholders of the form ${name} in the expression become parameters.
+Parameters without a default must be given each time the query is run.`,
+		Example: `  bib query saved save hot-days 'temperature > ${min}' --default min=30
+  bib query saved save by-city 'city == ${city}' --public --tag weather`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params, err := parseParams(defaults)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			queryClient, err := c.Query()
+			if err != nil {
+				return err
+			}
+
+			resp, err := queryClient.SaveQuery(ctx, &services.SaveQueryRequest{
+				Name:              args[0],
+				Expression:        args[1],
+				Description:       description,
+				DefaultParameters: params,
+				IsPublic:          public,
+				Tags:              tags,
+			})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(toSavedQueryItem(resp.GetQuery()))
+			}
+			w.Success(fmt.Sprintf("Saved query %s", resp.GetQuery().GetName()))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&description, "description", "", "Description of the query")
+	cmd.Flags().BoolVar(&public, "public", false, "Let everyone run the query")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Tag the query (repeatable)")
+	cmd.Flags().StringArrayVar(&defaults, "default", nil, "Default parameter value as key=value (repeatable)")
+
+	return cmd
+}
+
+func newRunSavedCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		id       string
+		params   []string
+		exportTo exportFlags
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run <name>",
+		Short: "Run a saved query",
+		Long: `Run a saved query, filling in its parameters.
+
+Your own query of that name is run if you have one, else the public query
+of that name. Parameter values are read as JSON, so numbers, booleans,
+lists and objects keep their type; anything else is a string.`,
+		Example: `  bib query saved run hot-days
+  bib query saved run hot-days --param min=35
+  bib query saved run by-city --param city=Berlin
+  bib query saved run --id 7c9e6679-... --param cities='["Berlin","Paris"]'
+  bib query saved run hot-days --export hot-days.parquet`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 0) == (id == "") {
+				return fmt.Errorf("exactly one of <name> or --id is required")
+			}
+			values, err := parseParams(params)
+			if err != nil {
+				return err
+			}
+			if _, err := exportTo.exportFormat(); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			queryClient, err := c.Query()
+			if err != nil {
+				return err
+			}
+
+			req := &services.RunSavedQueryRequest{Id: id, Parameters: values}
+			if len(args) > 0 {
+				req.Name = args[0]
+			}
+			sink, err := newResultSink(cmd, exportTo)
+			if err != nil {
+				return err
+			}
+			resp, err := queryClient.RunSavedQuery(ctx, req)
+			if err != nil {
+				sink.Abort()
+				return err
+			}
+
+			for _, r := range resp.GetResult().GetResults() {
+				if err := sink.Write(r.GetData().AsMap()); err != nil {
+					sink.Abort()
+					return err
+				}
+			}
+			return sink.Close(resp.GetResult().GetWarnings(), func(rows []map[string]any) any {
+				return runItem{
+					Query:      resp.GetQuery().GetName(),
+					Expression: resp.GetExpression(),
+					Results:    rows,
+					Warnings:   resp.GetResult().GetWarnings(),
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Run the saved query with this ID instead of by name")
+	cmd.Flags().StringArrayVar(&params, "param", nil, "Parameter value as key=value (repeatable)")
+	exportTo.register(cmd)
+
+	return cmd
+}
+
+func newListCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		public bool
+		tags   []string
+		search string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List saved queries",
+		Long:  `List your saved queries and, with --public, everyone's public queries.`,
+		Example: `  bib query saved list
+  bib query saved list --public --tag weather`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			queryClient, err := c.Query()
+			if err != nil {
+				return err
+			}
+
+			resp, err := queryClient.ListSavedQueries(ctx, &services.ListSavedQueriesRequest{
+				IncludePublic: public,
+				Tags:          tags,
+				Search:        search,
+			})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			s := output.NewStream(w,
+				[]string{"ID", "NAME", "PARAMETERS", "PUBLIC", "RUNS", "UPDATED"},
+				func(i savedQueryItem) []string {
+					return []string{
+						i.ID, i.Name, strings.Join(i.Parameters, ","), fmt.Sprint(i.Public),
+						fmt.Sprint(i.UseCount), i.UpdatedAt.Local().Format(time.DateTime),
+					}
+				})
+			for _, q := range resp.GetQueries() {
+				if err := s.Write(toSavedQueryItem(q)); err != nil {
+					return err
+				}
+			}
+			return s.Close()
+		},
+	}
+
+	cmd.Flags().BoolVar(&public, "public", false, "Include other users' public queries")
+	cmd.Flags().StringSliceVar(&tags, "tag", nil, "Only list queries with this tag (repeatable)")
+	cmd.Flags().StringVar(&search, "search", "", "Only list queries whose name, description or expression contains this")
+
+	return cmd
+}
+
+func newDeleteCommand(getClient ClientFunc) *cobra.Command {
+	var id string
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a saved query",
+		Long: `Delete one of your saved queries, by name or with --id.
+
+Admins can delete other users' public queries by ID.`,
+		Example: `  bib query saved delete hot-days
+  bib query saved delete --id 7c9e6679-...`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 0) == (id == "") {
+				return fmt.Errorf("exactly one of <name> or --id is required")
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			queryClient, err := c.Query()
+			if err != nil {
+				return err
+			}
+
+			name := id
+			if id == "" {
+				name = args[0]
+				resp, err := queryClient.ListSavedQueries(ctx, &services.ListSavedQueriesRequest{Search: name})
+				if err != nil {
+					return err
+				}
+				for _, q := range resp.GetQueries() {
+					if q.GetName() == name {
+						id = q.GetId()
+						break
+					}
+				}
+				if id == "" {
+					return fmt.Errorf("you have no saved query named %s", name)
+				}
+			}
+
+			if _, err := queryClient.DeleteSavedQuery(ctx, &services.DeleteSavedQueryRequest{Id: id}); err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(map[string]any{"id": id, "deleted": true})
+			}
+			w.Success(fmt.Sprintf("Deleted saved query %s", name))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&id, "id", "", "Delete the saved query with this ID instead of by name")
+
+	return cmd
+}
+
+// parseParams parses key=value pairs. Values are read as JSON, falling back
+// to a plain string.
+func parseParams(pairs []string) (map[string]*structpb.Value, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	params := make(map[string]*structpb.Value, len(pairs))
+	for _, pair := range pairs {
+		key, raw, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid parameter %q: expected key=value", pair)
+		}
+		var v any
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			v = raw
+		}
+		value, err := structpb.NewValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %q: %w", pair, err)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
@@ -8,13 +8,23 @@ import (
 
 	"bib/cmd/bib/cmd/admin"
 	certcmd "bib/cmd/bib/cmd/cert"
+	clustercmd "bib/cmd/bib/cmd/cluster"
 	configcmd "bib/cmd/bib/cmd/config"
 	connectcmd "bib/cmd/bib/cmd/connect"
+	datasetcmd "bib/cmd/bib/cmd/dataset"
 	"bib/cmd/bib/cmd/demo"
+	logincmd "bib/cmd/bib/cmd/login"
+	nodecmd "bib/cmd/bib/cmd/node"
+	querycmd "bib/cmd/bib/cmd/query"
+	servicecmd "bib/cmd/bib/cmd/service"
 	"bib/cmd/bib/cmd/setup"
+	statuscmd "bib/cmd/bib/cmd/status"
+	topiccmd "bib/cmd/bib/cmd/topic"
 	trustcmd "bib/cmd/bib/cmd/trust"
 	"bib/cmd/bib/cmd/tui"
+	usercmd "bib/cmd/bib/cmd/user"
 	"bib/cmd/bib/cmd/version"
+	whoamicmd "bib/cmd/bib/cmd/whoami"
 	clii18n "bib/internal/cli/i18n"
 	"bib/internal/config"
 	"bib/internal/logger"
@@ -47,6 +57,7 @@ var (
 	outputFormat string
 	verboseMode  bool
 	localeFlag   string
+	syncPrefs    bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -55,6 +66,10 @@ var rootCmd = &cobra.Command{
 	Short:       "bib.short",
 	Long:        "bib.long",
 	Annotations: map[string]string{"i18n": "true"},
+	// Errors are reported by Execute, with hints and exit codes
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Args:          cobra.NoArgs,
 	// Allow flags before or after subcommand
 	TraverseChildren: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
@@ -64,8 +79,9 @@ var rootCmd = &cobra.Command{
 		}
 
 		if err := loadConfig(cmd); err != nil {
-			return err
+			return &configError{err: err}
 		}
+		applyOutputFormat()
 
 		// Initialize logger
 		var err error
@@ -76,7 +92,7 @@ var rootCmd = &cobra.Command{
 
 		// Initialize audit logger if configured
 		if cfg.Log.AuditPath != "" {
-			auditLog, err = logger.NewAuditLogger(cfg.Log.AuditPath, cfg.Log.AuditMaxAgeDays)
+			auditLog, err = logger.NewAuditLoggerFromConfig(cfg.Log)
 			if err != nil {
 				log.Warn("failed to initialize audit logger", "error", err)
 			}
@@ -141,8 +157,7 @@ var rootCmd = &cobra.Command{
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(writeError(os.Stderr, outputFormat, err))
 	}
 }
 
@@ -151,25 +166,38 @@ func init() {
 
 	// Global persistent flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/bib/config.yaml)")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (json, yaml, table, quiet)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format (json, jsonl, yaml, table, quiet)")
 	rootCmd.PersistentFlags().BoolVarP(&verboseMode, "verbose", "v", false, "verbose output (includes full log output)")
 	rootCmd.PersistentFlags().StringVarP(&localeFlag, "locale", "L", "", "UI locale (en, de, fr, ru, zh-tw). Overrides config and system locale")
 	rootCmd.PersistentFlags().StringVar(GetNodeFlag(), "node", "", "daemon address to connect to (overrides config)")
+	rootCmd.PersistentFlags().BoolVar(&syncPrefs, "sync", false, "apply preferences stored on the daemon over local settings and save them")
 
 	// Bind flags to viper
 	viper.BindPFlag("output.format", rootCmd.PersistentFlags().Lookup("output"))
 	viper.BindPFlag("locale", rootCmd.PersistentFlags().Lookup("locale"))
 
 	// Add subcommands from subdirectories
-	rootCmd.AddCommand(admin.NewCommand())
+	rootCmd.AddCommand(admin.NewCommand(GetClient))
 	rootCmd.AddCommand(certcmd.NewCommand())
-	rootCmd.AddCommand(configcmd.NewCommand())
+	rootCmd.AddCommand(clustercmd.NewCommand(GetClient))
+	rootCmd.AddCommand(configcmd.NewCommand(GetClient))
 	rootCmd.AddCommand(connectcmd.NewCommand())
+	rootCmd.AddCommand(connectcmd.NewDisconnectCommand())
+	rootCmd.AddCommand(datasetcmd.NewCommand(GetClient))
 	rootCmd.AddCommand(demo.NewCommand())
+	rootCmd.AddCommand(logincmd.NewCommand(GetUnauthenticatedClient, GetClient))
+	rootCmd.AddCommand(nodecmd.NewCommand(GetClient))
+	rootCmd.AddCommand(querycmd.NewCommand(GetClient))
+	rootCmd.AddCommand(servicecmd.NewCommand())
 	rootCmd.AddCommand(setup.NewCommand())
+	rootCmd.AddCommand(statuscmd.NewCommand())
+	rootCmd.AddCommand(topiccmd.NewCommand(GetClient))
 	rootCmd.AddCommand(trustcmd.NewCommand())
 	rootCmd.AddCommand(tui.NewCommand())
+	rootCmd.AddCommand(usercmd.NewCommand(GetClient))
 	rootCmd.AddCommand(version.NewCommand())
+	rootCmd.AddCommand(whoamicmd.NewCommand(GetClient))
+	markUsageErrors(rootCmd)
 
 	// Initialize i18n early for help text translation
 	// This happens before flags are parsed, so we use config + system locale only
@@ -241,6 +269,10 @@ func loadConfig(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	for _, warning := range config.TakeWarnings() {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s\n", warning)
+	}
+
 	// Apply flag overrides
 	if cmd.Flags().Changed("output") {
 		cfg.Output.Format = viper.GetString("output.format")
@@ -259,6 +291,22 @@ func loadConfig(cmd *cobra.Command) error {
 	return nil
 }
 
+// applyOutputFormat passes the output format to the subcommand packages
+func applyOutputFormat() {
+	admin.SetOutputFormat(outputFormat)
+	clustercmd.SetOutputFormat(outputFormat)
+	configcmd.SetOutputFormat(outputFormat)
+	datasetcmd.SetOutputFormat(outputFormat)
+	logincmd.SetOutputFormat(outputFormat)
+	nodecmd.SetOutputFormat(outputFormat)
+	querycmd.SetOutputFormat(outputFormat)
+	servicecmd.SetOutputFormat(outputFormat)
+	statuscmd.SetOutputFormat(outputFormat)
+	topiccmd.SetOutputFormat(outputFormat)
+	usercmd.SetOutputFormat(outputFormat)
+	whoamicmd.SetOutputFormat(outputFormat)
+}
+
 // Config returns the current configuration (for use by subcommands)
 func Config() *config.BibConfig {
 	return cfg
@@ -284,7 +332,7 @@ func Context() context.Context {
 	return cmdCtx
 }
 
-// OutputFormat returns the current output format (json, yaml, table, quiet)
+// OutputFormat returns the current output format (json, jsonl, yaml, table, quiet)
 func OutputFormat() string {
 	return outputFormat
 }
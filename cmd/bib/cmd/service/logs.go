@@ -0,0 +1,74 @@
+package service
+
+import (
+	"time"
+
+	"bib/internal/cli/output"
+	"bib/internal/deploy/local"
+
+	"github.com/spf13/cobra"
+)
+
+func newLogsCommand() *cobra.Command {
+	var (
+		flags serviceFlags
+		lines int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show recent output of the bibd service",
+		Long: `Show recent output of the bibd service.
+
+On Linux this reads the systemd journal; reading a system service's
+journal may require membership of the systemd-journal group. On macOS it
+reads the log files the launchd job redirects stdout and stderr to,
+showing up to --lines from each. Windows services do not capture output;
+set log.file_path in the bibd config to log to a file instead.`,
+		Example: `  # Last 50 lines
+  bib service logs
+
+  # Last 200 lines of a user-level service
+  bib service logs --user -n 200`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := flags.installer().Logs(cmd.Context(), lines)
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(entries)
+			}
+
+			if len(entries) == 0 {
+				w.Info("No log entries")
+				return nil
+			}
+
+			// Plain lines read best for logs
+			for _, e := range entries {
+				w.Println(formatLogEntry(e))
+			}
+			return nil
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().IntVarP(&lines, "lines", "n", 50, "number of lines to show")
+
+	return cmd
+}
+
+// formatLogEntry renders an entry like tail or journalctl would
+func formatLogEntry(e local.ServiceLogEntry) string {
+	line := e.Message
+	if e.Stream == "stderr" {
+		line = "[stderr] " + line
+	}
+	if !e.Time.IsZero() {
+		line = e.Time.Local().Format(time.DateTime) + " " + line
+	}
+	return line
+}
@@ -0,0 +1,53 @@
+// Package service provides commands for the locally installed bibd service.
+package service
+
+import (
+	"path/filepath"
+
+	"bib/internal/config"
+	"bib/internal/deploy/local"
+
+	"github.com/spf13/cobra"
+)
+
+// serviceFlags select the installed service
+type serviceFlags struct {
+	name        string
+	userService bool
+}
+
+func (f *serviceFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.name, "name", "bibd", "service name")
+	cmd.Flags().BoolVar(&f.userService, "user", false, "user-level service (systemd --user or a launchd agent)")
+}
+
+// installer returns a service installer matching what bib setup installs
+func (f *serviceFlags) installer() *local.ServiceInstaller {
+	cfg := local.DefaultServiceConfig()
+	cfg.Name = f.name
+	cfg.UserService = f.userService
+	if configDir, err := config.UserConfigDir(config.AppBibd); err == nil {
+		cfg.ConfigPath = filepath.Join(configDir, "config.yaml")
+		cfg.WorkingDirectory = configDir
+	}
+	return local.NewServiceInstaller(cfg)
+}
+
+// NewCommand creates the service command group.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "Inspect the locally installed bibd service",
+		Long: `Inspect bibd running as a service on this machine.
+
+Works with the service installed by 'bib setup --daemon': a systemd unit
+on Linux, a launchd job on macOS or a Windows service. The commands call
+the platform service manager, so there is no need to remember
+systemctl, launchctl or sc.exe syntax.`,
+	}
+
+	cmd.AddCommand(newStatusCommand())
+	cmd.AddCommand(newLogsCommand())
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+func newStatusCommand() *cobra.Command {
+	var flags serviceFlags
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the bibd service is loaded and running",
+		Long: `Show whether the bibd service is loaded and running.
+
+Reports the service manager, whether the service is loaded (installed and
+known to the manager), whether bibd is running, its PID and the exit
+status of the previous run where the platform records them.
+
+System launchd daemons are only visible when run as root.`,
+		Example: `  # Status of the system service
+  bib service status
+
+  # Status of a user-level service, as JSON
+  bib service status --user -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, err := flags.installer().Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(status)
+			}
+
+			table := output.NewTable("FIELD", "VALUE").
+				AddRow("Manager", string(status.Type)).
+				AddRow("Loaded", strconv.FormatBool(status.Loaded)).
+				AddRow("Running", strconv.FormatBool(status.Running)).
+				AddRow("State", status.State)
+			if status.PID > 0 {
+				table.AddRow("PID", strconv.Itoa(status.PID))
+			}
+			if status.LastExitStatus != 0 {
+				table.AddRow("Last exit", strconv.Itoa(status.LastExitStatus))
+			}
+			if err := w.Write(table); err != nil {
+				return err
+			}
+
+			if !status.Loaded {
+				w.Info(fmt.Sprintf("Service %s is not installed; run 'bib setup --daemon' to install it", flags.name))
+			}
+			return nil
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
@@ -99,6 +99,10 @@ var (
 	setupTarget      string
 	setupReconfigure string
 	setupFresh       bool
+
+	// Image overrides for container targets; may be pinned by digest
+	setupBibdImage     string
+	setupPostgresImage string
 )
 
 // Cmd represents the setup command
@@ -141,7 +145,10 @@ For daemon setup, use --target to specify where bibd will run:
   bib setup --daemon --reconfigure p2p-mode
 
   # Reset and start fresh
-  bib setup --fresh`,
+  bib setup --fresh
+
+  # Docker setup with PostgreSQL pinned by digest
+  bib setup --daemon --target docker --postgres-image postgres:16-alpine@sha256:<digest>`,
 	Annotations: map[string]string{"i18n": "true"},
 	RunE:        runSetup,
 }
@@ -163,6 +170,8 @@ func init() {
 	Cmd.Flags().StringVarP(&setupTarget, "target", "t", "local", "deployment target: local, docker, podman, kubernetes (requires --daemon)")
 	Cmd.Flags().StringVar(&setupReconfigure, "reconfigure", "", "reconfigure a specific section without full wizard")
 	Cmd.Flags().BoolVar(&setupFresh, "fresh", false, "reset configuration and start fresh (deletes existing config)")
+	Cmd.Flags().StringVar(&setupBibdImage, "bibd-image", "", "bibd image for container targets, e.g. ghcr.io/bib-project/bibd:0.2.0 or pinned with @sha256:<digest>")
+	Cmd.Flags().StringVar(&setupPostgresImage, "postgres-image", "", "PostgreSQL image for container targets, e.g. postgres:16-alpine or pinned with @sha256:<digest>")
 }
 
 func runSetup(cmd *cobra.Command, args []string) error {
@@ -235,6 +244,17 @@ func runSetup(cmd *cobra.Command, args []string) error {
 }
 
 // validateSetupFlags validates flag combinations and values
+// overrideImages replaces the default images with --bibd-image and
+// --postgres-image. The flags hold full references, so the tag is cleared.
+func overrideImages(bibdImage, bibdTag, postgresImage, postgresTag *string) {
+	if setupBibdImage != "" {
+		*bibdImage, *bibdTag = setupBibdImage, ""
+	}
+	if setupPostgresImage != "" {
+		*postgresImage, *postgresTag = setupPostgresImage, ""
+	}
+}
+
 func validateSetupFlags() error {
 	// Validate --target: only valid with --daemon
 	if setupTarget != "local" && !setupDaemon {
@@ -276,6 +296,21 @@ func validateSetupFlags() error {
 		}
 	}
 
+	// Validate --bibd-image and --postgres-image: only for container targets
+	if setupBibdImage != "" || setupPostgresImage != "" {
+		if DeploymentTarget(setupTarget) == TargetLocal {
+			return fmt.Errorf("--bibd-image and --postgres-image require a container --target")
+		}
+		for _, ref := range []string{setupBibdImage, setupPostgresImage} {
+			if ref == "" {
+				continue
+			}
+			if err := deploy.ValidateImageRef(ref); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Validate --format value
 	validFormats := []string{"yaml", "toml", "json"}
 	isValidFormat := false
@@ -714,14 +749,14 @@ func setupBibQuick() error {
 
 	// Step 3: Auto-discover local nodes
 	fmt.Println("\n🔍 Discovering local nodes...")
-	discoverer := discovery.NewWithDefaults()
+	discoverer := discovery.NewWithDefaults(discoveryConnectionConfig())
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	result := discoverer.Discover(ctx)
 
 	localNodes := []discovery.DiscoveredNode{}
 	for _, node := range result.Nodes {
-		if node.Method == discovery.MethodLocal || node.Method == discovery.MethodMDNS {
+		if node.Method == discovery.MethodLocal || node.Method == discovery.MethodMDNS || node.Method == discovery.MethodDNS {
 			localNodes = append(localNodes, node)
 		}
 	}
@@ -792,6 +827,7 @@ func setupBibQuick() error {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		results := tester.TestConnections(ctx, addresses)
 		cancel()
+		recordTestHistory(results, nil, nil)
 
 		connected := 0
 		for _, r := range results {
@@ -1113,6 +1149,17 @@ func setupBibdQuickLocal() error {
 		serviceConfig.ConfigPath = configPath
 		serviceConfig.WorkingDirectory = configDir
 		serviceConfig.UserService = userService
+
+		// The hardened systemd unit only lets bibd write to directories
+		// that exist when it starts, so create the data directory now
+		dataDir := cfg.Server.DataDir
+		if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(dataDir, "~/") {
+			dataDir = filepath.Join(home, dataDir[2:])
+		}
+		serviceConfig.DataDir = dataDir
+		if err := os.MkdirAll(dataDir, 0755); err != nil {
+			fmt.Printf("   ⚠️  Could not create data directory %s: %v\n", dataDir, err)
+		}
 		serviceInstaller = local.NewServiceInstaller(serviceConfig)
 
 		// Generate and save service file
@@ -1138,8 +1185,24 @@ func setupBibdQuickLocal() error {
 			}
 		}
 
-		// Show installation instructions
-		fmt.Println("\n" + serviceInstaller.InstallInstructions())
+		if serviceType == local.ServiceTypeWindows {
+			// Register with the service control manager directly
+			winService := serviceInstaller.NewWindowsService()
+			if err := winService.Install(context.Background()); err != nil {
+				fmt.Printf("   ⚠️  Could not install service: %v\n", err)
+				fmt.Println("\n" + serviceInstaller.InstallInstructions())
+				fmt.Println(serviceContent)
+				installService = false
+			} else if err := winService.Start(context.Background()); err != nil {
+				fmt.Printf("   ✓ Service %s installed\n", winService.Definition.Name)
+				fmt.Printf("   ⚠️  Could not start service: %v\n", err)
+			} else {
+				fmt.Printf("   ✓ Service %s installed and started\n", winService.Definition.Name)
+			}
+		} else {
+			// Show installation instructions
+			fmt.Println("\n" + serviceInstaller.InstallInstructions())
+		}
 	}
 
 	// Step 7: Show summary and next steps
@@ -1158,7 +1221,11 @@ func setupBibdQuickLocal() error {
 	}
 	fmt.Printf("  Config:    %s\n", configPath)
 	if installService && serviceInstaller != nil {
-		fmt.Printf("  Service:   %s\n", serviceInstaller.GetServiceFilePath())
+		if serviceType == local.ServiceTypeWindows {
+			fmt.Printf("  Service:   %s (Windows Service)\n", serviceInstaller.Config.Name)
+		} else {
+			fmt.Printf("  Service:   %s\n", serviceInstaller.GetServiceFilePath())
+		}
 	}
 	fmt.Println()
 	fmt.Println("Next steps:")
@@ -1175,7 +1242,8 @@ func setupBibdQuickLocal() error {
 		case local.ServiceTypeLaunchd:
 			fmt.Printf("  • Load service: launchctl load %s\n", serviceInstaller.GetServiceFilePath())
 		case local.ServiceTypeWindows:
-			fmt.Printf("  • Start service: Start-Service bibd\n")
+			fmt.Printf("  • Check status: Get-Service bibd\n")
+			fmt.Printf("  • Restart:      Restart-Service bibd\n")
 		}
 	} else {
 		fmt.Printf("  • Start daemon: bibd serve --config %s\n", configPath)
@@ -1367,6 +1435,7 @@ func setupBibdQuickDocker() error {
 	composeConfig.StorageBackend = "sqlite" // Default to SQLite for quick setup
 	composeConfig.P2PEnabled = true
 	composeConfig.P2PMode = "proxy"
+	overrideImages(&composeConfig.BibdImage, &composeConfig.BibdTag, &composeConfig.PostgresImage, &composeConfig.PostgresTag)
 
 	deployConfig := &docker.DeployConfig{
 		ComposeConfig:  composeConfig,
@@ -1474,7 +1543,8 @@ func setupBibdQuickPodman() error {
 
 	fmt.Printf("   ✓ Podman %s\n", podmanInfo.Version)
 	if podmanInfo.Rootless {
-		fmt.Printf("   ✓ Rootless mode (UID %d)\n", podmanInfo.RootlessUID)
+		fmt.Printf("   ✓ Rootless mode (UID %d, %s networking)\n", podmanInfo.RootlessUID, podmanInfo.RootlessNetwork)
+		fmt.Printf("   ✓ Socket: %s\n", podmanInfo.SocketPath)
 	} else {
 		fmt.Println("   ✓ Rootful mode")
 	}
@@ -1637,8 +1707,12 @@ func setupBibdQuickPodman() error {
 	podConfig.StorageBackend = "sqlite"
 	podConfig.P2PEnabled = true
 	podConfig.P2PMode = "proxy"
+	overrideImages(&podConfig.BibdImage, &podConfig.BibdTag, &podConfig.PostgresImage, &podConfig.PostgresTag)
 	podConfig.DeployStyle = deployStyle
 	podConfig.Rootless = podmanInfo.Rootless
+	if podmanInfo.RootlessNetwork != "" {
+		podConfig.RootlessNetwork = podmanInfo.RootlessNetwork
+	}
 
 	deployConfig := &podman.DeployConfig{
 		PodConfig:      podConfig,
@@ -1668,7 +1742,7 @@ func setupBibdQuickPodman() error {
 	fmt.Printf("  Storage:     SQLite (proxy mode)\n")
 	fmt.Printf("  Deploy:      %s\n", deployStyle)
 	if podmanInfo.Rootless {
-		fmt.Printf("  Mode:        Rootless\n")
+		fmt.Printf("  Mode:        Rootless (%s networking)\n", podConfig.RootlessNetwork)
 	} else {
 		fmt.Printf("  Mode:        Rootful\n")
 	}
@@ -1974,6 +2048,7 @@ func setupBibdQuickKubernetes() error {
 	manifestConfig.Email = email
 	manifestConfig.UsePublicBootstrap = usePublicNetwork
 	manifestConfig.StorageBackend = "postgres" // Use PostgreSQL for Kubernetes
+	overrideImages(&manifestConfig.BibdImage, &manifestConfig.BibdTag, &manifestConfig.PostgresImage, &manifestConfig.PostgresTag)
 	manifestConfig.PostgresMode = "statefulset"
 	manifestConfig.ServiceType = serviceType
 	manifestConfig.P2PEnabled = true
@@ -2595,6 +2670,7 @@ Public Key:  %s...
 
 				sb.WriteString(fmt.Sprintf("%s%s %s %s - %s\n",
 					cursor, icon, status, deploy.TargetDisplayName(target.Type), target.Status))
+				sb.WriteString(deploy.FormatHealth(target))
 			}
 
 			// Show summary
@@ -2696,10 +2772,10 @@ Public Key:  %s...
 				WithLatency(true)
 
 			if m.discoveryResult != nil {
-				m.nodeSelector.WithNodes(m.discoveryResult.Nodes)
+				m.nodeSelector.WithRankedNodes(discovery.RankNodes(m.discoveryResult.Nodes, nil))
 			}
 
-			// Auto-select first local node if available
+			// Auto-select the best ranked node
 			m.nodeSelector.SelectFirst()
 		}
 
@@ -3724,6 +3800,17 @@ func (m *SetupWizardModel) handleStepCompletion() bool {
 	}
 }
 
+// discoveryConnectionConfig returns the connection settings that configure
+// discovery, such as an SRV record, from an existing config file or the
+// environment
+func discoveryConnectionConfig() config.ConnectionConfig {
+	cfg, err := config.LoadBib("")
+	if err != nil {
+		return config.DefaultBibConfig().Connection
+	}
+	return cfg.Connection
+}
+
 // runNodeDiscovery runs node discovery for CLI setup
 func (m *SetupWizardModel) runNodeDiscovery() {
 	if m.discoveryDone {
@@ -3731,7 +3818,7 @@ func (m *SetupWizardModel) runNodeDiscovery() {
 	}
 
 	// Create discoverer with default options
-	discoverer := discovery.NewWithDefaults()
+	discoverer := discovery.NewWithDefaults(discoveryConnectionConfig())
 
 	// Run discovery with a short timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -3740,15 +3827,15 @@ func (m *SetupWizardModel) runNodeDiscovery() {
 	m.discoveryResult = discoverer.Discover(ctx)
 	m.discoveryDone = true
 
-	// Initialize node selector with results
+	// Initialize node selector with results, best ranked first
 	m.nodeSelector = component.NewNodeSelector().
-		WithNodes(m.discoveryResult.Nodes).
+		WithRankedNodes(discovery.RankNodes(m.discoveryResult.Nodes, nil)).
 		WithBibDev(true).
 		WithAddCustom(true).
 		WithMultiSelect(true).
 		WithLatency(true)
 
-	// Auto-select first local node if available
+	// Auto-select the best ranked node
 	m.nodeSelector.SelectFirst()
 }
 
@@ -3951,6 +4038,8 @@ func (m *SetupWizardModel) runConnectionTests() {
 
 	m.connectionResults = tester.TestConnections(ctx, addresses)
 	m.connectionTested = true
+
+	m.rankSelectedNodes()
 }
 
 // runAuthTests tests authentication with all connected nodes
@@ -3989,6 +4078,8 @@ func (m *SetupWizardModel) runAuthTests() {
 
 	m.authResults = tester.TestAuths(ctx, connectedAddresses)
 	m.authTested = true
+
+	m.rankSelectedNodes()
 }
 
 // runNetworkHealthCheck checks network health of all connected nodes
@@ -3997,6 +4088,12 @@ func (m *SetupWizardModel) runNetworkHealthCheck() {
 		return
 	}
 
+	// The health check is the last test, so the run is complete once it
+	// returns
+	defer func() {
+		recordTestHistory(m.connectionResults, m.authResults, m.networkHealthResults)
+	}()
+
 	// Only check nodes that passed connection test
 	var connectedAddresses []string
 	for _, r := range m.connectionResults {
@@ -4020,6 +4117,42 @@ func (m *SetupWizardModel) runNetworkHealthCheck() {
 
 	m.networkHealthResults = checker.CheckHealthMultiple(ctx, connectedAddresses)
 	m.networkHealthChecked = true
+
+	m.rankSelectedNodes()
+}
+
+// recordTestHistory saves test results to the connection history, so bib
+// status can show how nodes fare over time. Failing to save is not worth
+// interrupting setup for.
+func recordTestHistory(conns []*discovery.ConnectionTestResult, auths []*discovery.AuthTestResult, healths []*discovery.NetworkHealthResult) {
+	dir, err := discovery.DefaultHistoryDir()
+	if err != nil {
+		return
+	}
+	_ = discovery.NewHistoryStore(dir, 0).RecordResults(conns, auths, healths)
+}
+
+// rankSelectedNodes ranks the selected nodes with the test results so far
+// and makes the best one the default, unless the user picked it
+func (m *SetupWizardModel) rankSelectedNodes() {
+	if m.nodeSelector == nil {
+		return
+	}
+
+	ranker := discovery.NewRanker(m.nodeSelector.SelectedNodes())
+	ranker.AddConnectionResults(m.connectionResults)
+	ranker.AddAuthResults(m.authResults)
+	ranker.AddHealthResults(m.networkHealthResults)
+
+	if !m.nodeSelector.DefaultToBest(ranker.Rank()) {
+		return
+	}
+
+	defaultNode := m.nodeSelector.GetDefaultNode()
+	m.data.ServerAddr = defaultNode.Node.Address
+	for i := range m.data.SelectedNodes {
+		m.data.SelectedNodes[i].IsDefault = m.data.SelectedNodes[i].Address == defaultNode.Node.Address
+	}
 }
 
 func (m *SetupWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -0,0 +1,182 @@
+// Package status provides the bib status command.
+package status
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"bib/internal/cli/output"
+	"bib/internal/discovery"
+
+	"github.com/spf13/cobra"
+)
+
+// trendWindow is the number of recent runs the failure trend covers
+const trendWindow = 10
+
+// nodeStatus is a node's test history as written by bib status
+type nodeStatus struct {
+	Address  string              `json:"address" yaml:"address"`
+	LastTest *discovery.TestRun  `json:"last_test,omitempty" yaml:"last_test,omitempty"`
+	Failures int                 `json:"recent_failures" yaml:"recent_failures"`
+	Tests    int                 `json:"recent_tests" yaml:"recent_tests"`
+	Warning  string              `json:"warning,omitempty" yaml:"warning,omitempty"`
+	Runs     []discovery.TestRun `json:"runs,omitempty" yaml:"runs,omitempty"`
+}
+
+func toNodeStatus(h *discovery.NodeHistory, withRuns bool) nodeStatus {
+	s := nodeStatus{
+		Address: h.Address,
+		Warning: h.Warning(),
+	}
+	if last, ok := h.Last(); ok {
+		s.LastTest = &last
+	}
+
+	recent := h.Runs
+	if len(recent) > trendWindow {
+		recent = recent[len(recent)-trendWindow:]
+	}
+	s.Tests = len(recent)
+	for _, r := range recent {
+		if r.Failed() {
+			s.Failures++
+		}
+	}
+
+	if withRuns {
+		s.Runs = h.Runs
+	}
+	return s
+}
+
+// NewCommand creates the status command.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [address]",
+		Short: "Show how nodes fared in recent connection tests",
+		Long: `Show the history of connection, authentication and network health tests
+run against nodes by bib setup and bib connect.
+
+A single test only shows how a node is doing right now. The history shows
+trends, such as a node that has been failing authentication for days or one
+that fails every few connections. Give an address to list its test runs.
+
+Clustered nodes also report the clock skew to their cluster members; skew
+beyond the node's cluster.max_clock_skew is noted, since it breaks leases
+and certificate validity.`,
+		Example: `  bib status
+  bib status node1.example.com:4000
+  bib status -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := discovery.DefaultHistoryDir()
+			if err != nil {
+				return fmt.Errorf("failed to get config directory: %w", err)
+			}
+			store := discovery.NewHistoryStore(dir, 0)
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+
+			if len(args) == 1 {
+				h, err := store.Get(args[0])
+				if err != nil {
+					return err
+				}
+				return writeNode(w, toNodeStatus(h, true))
+			}
+
+			histories, err := store.List()
+			if err != nil {
+				return err
+			}
+			statuses := make([]nodeStatus, len(histories))
+			for i, h := range histories {
+				statuses[i] = toNodeStatus(h, false)
+			}
+			return writeNodes(w, statuses)
+		},
+	}
+
+	return cmd
+}
+
+func writeNodes(w *output.Writer, statuses []nodeStatus) error {
+	if w.Format() != output.FormatTable {
+		return w.Write(statuses)
+	}
+	if len(statuses) == 0 {
+		w.Info("No nodes tested yet. Run bib connect or bib setup to test one.")
+		return nil
+	}
+
+	table := output.NewTable("ADDRESS", "LAST TEST", "RESULT", "LATENCY", "FAILED", "NOTE")
+	for _, s := range statuses {
+		table.AddRow(s.Address, formatTime(s.LastTest), formatResult(s.LastTest),
+			formatLatency(s.LastTest), fmt.Sprintf("%d/%d", s.Failures, s.Tests), s.Warning)
+	}
+	return w.Write(table)
+}
+
+func writeNode(w *output.Writer, s nodeStatus) error {
+	if w.Format() != output.FormatTable {
+		return w.Write(s)
+	}
+	if len(s.Runs) == 0 {
+		w.Info(fmt.Sprintf("%s has not been tested yet.", s.Address))
+		return nil
+	}
+
+	if s.Warning != "" {
+		w.Warn(fmt.Sprintf("%s: %s", s.Address, s.Warning))
+	}
+
+	table := output.NewTable("TESTED", "CONNECTION", "LATENCY", "AUTH", "HEALTH", "CLOCK SKEW", "ERROR")
+	for i := len(s.Runs) - 1; i >= 0; i-- {
+		r := s.Runs[i]
+		table.AddRow(r.TestedAt.Local().Format(time.DateTime), string(r.Connection),
+			formatLatency(&r), string(r.Auth), string(r.Health), formatClockSkew(r), r.Error)
+	}
+	return w.Write(table)
+}
+
+func formatTime(r *discovery.TestRun) string {
+	if r == nil {
+		return "-"
+	}
+	return r.TestedAt.Local().Format(time.DateTime)
+}
+
+func formatResult(r *discovery.TestRun) string {
+	switch {
+	case r == nil:
+		return "-"
+	case r.ConnectionFailed():
+		return string(r.Connection)
+	case r.AuthFailed():
+		return "auth " + string(r.Auth)
+	case r.HealthFailed():
+		return "network " + string(r.Health)
+	default:
+		return "ok"
+	}
+}
+
+func formatLatency(r *discovery.TestRun) string {
+	if r == nil || r.Latency <= 0 {
+		return "-"
+	}
+	ms := r.Latency.Round(time.Millisecond).Milliseconds()
+	return strconv.FormatInt(ms, 10) + "ms"
+}
+
+func formatClockSkew(r discovery.TestRun) string {
+	if r.ClockSkew == 0 {
+		return "-"
+	}
+	skew := r.ClockSkew.Round(time.Millisecond).String()
+	if r.ClockSkewExceeded {
+		skew += " (too large)"
+	}
+	return skew
+}
@@ -0,0 +1,249 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// aclItem is a topic ACL entry as written by bib topic acl
+type aclItem struct {
+	PrincipalType string    `json:"principal_type" yaml:"principal_type"`
+	Principal     string    `json:"principal" yaml:"principal"`
+	Permission    string    `json:"permission" yaml:"permission"`
+	GrantedBy     string    `json:"granted_by,omitempty" yaml:"granted_by,omitempty"`
+	GrantedAt     time.Time `json:"granted_at,omitempty" yaml:"granted_at,omitempty"`
+}
+
+func toACLItem(e *bibv1.TopicACLEntry) aclItem {
+	item := aclItem{
+		PrincipalType: e.GetPrincipalType(),
+		Principal:     e.GetPrincipal(),
+		Permission:    e.GetPermission(),
+		GrantedBy:     e.GetGrantedBy(),
+	}
+	if e.GetGrantedAt() != nil {
+		item.GrantedAt = e.GetGrantedAt().AsTime()
+	}
+	return item
+}
+
+func newACLCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:   "acl <topic>",
+		Short: "Show who has access to a topic",
+		Long: `Show the access control list of a topic.
+
+Owners always have admin access and are listed as well. A topic without
+grants is open to all users; once it has any, only owners, members and
+the users and roles granted access can see it.`,
+		Example: `  bib topic acl weather`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			topicClient, topicID, err := resolveTopic(ctx, getClient, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := topicClient.GetTopicACL(ctx, &services.GetTopicACLRequest{TopicId: topicID})
+			if err != nil {
+				return err
+			}
+
+			s := newACLStream(cmd)
+			for _, owner := range resp.GetOwners() {
+				if err := s.Write(aclItem{PrincipalType: "user", Principal: owner, Permission: "owner"}); err != nil {
+					return err
+				}
+			}
+			for _, e := range resp.GetEntries() {
+				if err := s.Write(toACLItem(e)); err != nil {
+					return err
+				}
+			}
+			return s.Close()
+		},
+	}
+}
+
+func newGrantCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		users    []string
+		roles    []string
+		revision int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "grant <topic> <read|write|admin>",
+		Short: "Grant access to a topic",
+		Long: `Grant users or roles access to a topic.
+
+  read   view the topic and subscribe to it
+  write  also publish datasets to it
+  admin  also change who has access
+
+A grant replaces any earlier grant to the same user or role. Granting
+access restricts the topic: from then on it is no longer world-readable
+and only owners, members and grantees can see it.
+
+Requires ownership of the topic or admin access to it.`,
+		Example: `  # Let a user publish to a topic
+  bib topic grant weather write --user 3f2a9c1e-...
+
+  # Let every user with the readonly role read a topic
+  bib topic grant weather read --role readonly`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(users) == 0 && len(roles) == 0 {
+				return fmt.Errorf("at least one --user or --role is required")
+			}
+
+			ctx := cmd.Context()
+
+			topicClient, topicID, err := resolveTopic(ctx, getClient, args[0])
+			if err != nil {
+				return err
+			}
+
+			req := &services.SetTopicACLRequest{
+				TopicId:          topicID,
+				Grant:            principals(users, roles, args[1]),
+				ExpectedRevision: revision,
+			}
+			resp, err := topicClient.SetTopicACL(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			return writeACLResult(cmd, resp, fmt.Sprintf("Granted %s on %s", args[1], args[0]))
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&users, "user", nil, "User ID to grant access to (repeatable)")
+	cmd.Flags().StringSliceVar(&roles, "role", nil, "Role to grant access to: admin, user or readonly (repeatable)")
+	addRevisionFlag(cmd, &revision)
+
+	return cmd
+}
+
+func newRevokeCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		users    []string
+		roles    []string
+		revision int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revoke <topic>",
+		Short: "Revoke access to a topic",
+		Long: `Revoke the grants of users or roles on a topic.
+
+Revoking does not remove topic members or owners. Once the last grant is
+revoked the topic is open to all users again.`,
+		Example: `  bib topic revoke weather --user 3f2a9c1e-...`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(users) == 0 && len(roles) == 0 {
+				return fmt.Errorf("at least one --user or --role is required")
+			}
+
+			ctx := cmd.Context()
+
+			topicClient, topicID, err := resolveTopic(ctx, getClient, args[0])
+			if err != nil {
+				return err
+			}
+
+			req := &services.SetTopicACLRequest{
+				TopicId:          topicID,
+				Revoke:           principals(users, roles, ""),
+				ExpectedRevision: revision,
+			}
+			resp, err := topicClient.SetTopicACL(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			return writeACLResult(cmd, resp, fmt.Sprintf("Revoked access to %s", args[0]))
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&users, "user", nil, "User ID to revoke (repeatable)")
+	cmd.Flags().StringSliceVar(&roles, "role", nil, "Role to revoke (repeatable)")
+	addRevisionFlag(cmd, &revision)
+
+	return cmd
+}
+
+// resolveTopic returns the topic client and the ID of the topic named by
+// ref, which is a topic ID or name.
+func resolveTopic(ctx context.Context, getClient ClientFunc, ref string) (services.TopicServiceClient, string, error) {
+	c, err := getClient(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	topicClient, err := c.Topic()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := uuid.Parse(ref); err == nil {
+		return topicClient, ref, nil
+	}
+	resp, err := topicClient.GetTopic(ctx, &services.GetTopicRequest{Name: ref})
+	if err != nil {
+		return nil, "", err
+	}
+	return topicClient, resp.GetTopic().GetId(), nil
+}
+
+// principals builds ACL entries for users and roles.
+func principals(users, roles []string, permission string) []*bibv1.TopicACLEntry {
+	entries := make([]*bibv1.TopicACLEntry, 0, len(users)+len(roles))
+	for _, u := range users {
+		entries = append(entries, &bibv1.TopicACLEntry{PrincipalType: "user", Principal: u, Permission: permission})
+	}
+	for _, r := range roles {
+		entries = append(entries, &bibv1.TopicACLEntry{PrincipalType: "role", Principal: r, Permission: permission})
+	}
+	return entries
+}
+
+// writeACLResult prints message for the table format, the resulting ACL
+// otherwise.
+func writeACLResult(cmd *cobra.Command, resp *services.SetTopicACLResponse, message string) error {
+	w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+	if w.Format() == output.FormatTable {
+		w.Success(message)
+		return nil
+	}
+
+	items := make([]aclItem, len(resp.GetEntries()))
+	for i, e := range resp.GetEntries() {
+		items[i] = toACLItem(e)
+	}
+	return w.Write(items)
+}
+
+// newACLStream creates the output stream for topic ACL entries.
+func newACLStream(cmd *cobra.Command) *output.Stream[aclItem] {
+	w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+	return output.NewStream(w,
+		[]string{"TYPE", "PRINCIPAL", "PERMISSION", "GRANTED BY", "GRANTED"},
+		func(a aclItem) []string {
+			granted := ""
+			if !a.GrantedAt.IsZero() {
+				granted = a.GrantedAt.Local().Format(time.DateTime)
+			}
+			return []string{a.PrincipalType, a.Principal, a.Permission, a.GrantedBy, granted}
+		})
+}
@@ -0,0 +1,378 @@
+package topic
+
+import (
+	"fmt"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// invitationItem is an invitation or access request as written by the
+// invitation commands
+type invitationItem struct {
+	ID          string    `json:"id" yaml:"id"`
+	Kind        string    `json:"kind" yaml:"kind"`
+	Topic       string    `json:"topic" yaml:"topic"`
+	TopicID     string    `json:"topic_id" yaml:"topic_id"`
+	User        string    `json:"user,omitempty" yaml:"user,omitempty"`
+	Email       string    `json:"email,omitempty" yaml:"email,omitempty"`
+	InviterID   string    `json:"inviter_id" yaml:"inviter_id"`
+	Role        string    `json:"role" yaml:"role"`
+	Message     string    `json:"message,omitempty" yaml:"message,omitempty"`
+	Status      string    `json:"status" yaml:"status"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at" yaml:"expires_at"`
+	RespondedBy string    `json:"responded_by,omitempty" yaml:"responded_by,omitempty"`
+	Token       string    `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+func toInvitationItem(inv *services.TopicInvitation) invitationItem {
+	item := invitationItem{
+		ID:          inv.GetId(),
+		Kind:        inv.GetKind(),
+		Topic:       inv.GetTopicName(),
+		TopicID:     inv.GetTopicId(),
+		User:        inv.GetUserId(),
+		Email:       inv.GetEmail(),
+		InviterID:   inv.GetInviterId(),
+		Role:        inv.GetRole(),
+		Message:     inv.GetMessage(),
+		Status:      inv.GetStatus(),
+		RespondedBy: inv.GetRespondedBy(),
+	}
+	if inv.GetCreatedAt() != nil {
+		item.CreatedAt = inv.GetCreatedAt().AsTime()
+	}
+	if inv.GetExpiresAt() != nil {
+		item.ExpiresAt = inv.GetExpiresAt().AsTime()
+	}
+	return item
+}
+
+func newInviteCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		user    string
+		email   string
+		role    string
+		message string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "invite <topic>",
+		Short: "Invite a user to a topic",
+		Long: `Invite a user to join a topic, by user ID or email address.
+
+The invitee is notified and joins with the given role once they accept.
+Invitations expire after 7 days. Requires ownership of the topic.`,
+		Example: `  bib topic invite weather --user 3f2a9c1e-... --role editor
+  bib topic invite weather --email alex@example.com --message "Welcome aboard"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (user == "") == (email == "") {
+				return fmt.Errorf("exactly one of --user or --email is required")
+			}
+
+			ctx := cmd.Context()
+
+			topicClient, topicID, err := resolveTopic(ctx, getClient, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := topicClient.InviteToTopic(ctx, &services.InviteToTopicRequest{
+				TopicId: topicID,
+				UserId:  user,
+				Email:   email,
+				Role:    role,
+				Message: message,
+			})
+			if err != nil {
+				return err
+			}
+
+			item := toInvitationItem(resp.GetInvitation())
+			item.Token = resp.GetToken()
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() == output.FormatTable {
+				w.Success(fmt.Sprintf("Invited %s to %s as %s", item.User+item.Email, args[0], item.Role))
+				w.Info(fmt.Sprintf("Invitation token: %s", item.Token))
+				return nil
+			}
+			return w.Write(item)
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "User ID to invite")
+	cmd.Flags().StringVar(&email, "email", "", "Email address to invite")
+	cmd.Flags().StringVar(&role, "role", "viewer", "Role on acceptance: owner, editor or viewer")
+	cmd.Flags().StringVar(&message, "message", "", "Message to the invitee")
+
+	return cmd
+}
+
+func newRequestCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		role    string
+		message string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "request <topic-id>",
+		Short: "Ask to join a topic",
+		Long: `Ask the owners of a topic to let you join it.
+
+The owners are notified and approve or deny the request. Use the topic
+ID for restricted topics, which you cannot look up by name yet.`,
+		Example: `  bib topic request 9b1d3c2e-... --role editor --message "I maintain the station feeds"`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			topicClient, topicID, err := resolveTopic(ctx, getClient, args[0])
+			if err != nil {
+				return err
+			}
+
+			resp, err := topicClient.RequestTopicAccess(ctx, &services.RequestTopicAccessRequest{
+				TopicId: topicID,
+				Role:    role,
+				Message: message,
+			})
+			if err != nil {
+				return err
+			}
+
+			return writeInvitation(cmd, resp.GetRequest(), fmt.Sprintf("Requested %s access to %s; waiting for an owner to review it", role, args[0]))
+		},
+	}
+
+	cmd.Flags().StringVar(&role, "role", "viewer", "Requested role: editor or viewer")
+	cmd.Flags().StringVar(&message, "message", "", "Message to the owners")
+
+	return cmd
+}
+
+func newRespondCommand(getClient ClientFunc, accept bool) *cobra.Command {
+	var token string
+
+	use, short, done := "accept", "Accept a topic invitation", "Joined"
+	if !accept {
+		use, short, done = "decline", "Decline a topic invitation", "Declined invitation to"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " [invitation-id]",
+		Short: short,
+		Long: short + `.
+
+Name the invitation by ID, as listed by bib topic invitations, or pass the
+token from the invitation email with --token.`,
+		Example: fmt.Sprintf(`  bib topic %s 7c9e6679-...
+  bib topic %s --token 4f1e...`, use, use),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 0) == (token == "") {
+				return fmt.Errorf("either an invitation ID or --token is required")
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			topicClient, err := c.Topic()
+			if err != nil {
+				return err
+			}
+
+			req := &services.RespondToTopicInvitationRequest{Token: token, Accept: accept}
+			if len(args) > 0 {
+				req.InvitationId = args[0]
+			}
+			resp, err := topicClient.RespondToTopicInvitation(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			inv := resp.GetInvitation()
+			return writeInvitation(cmd, inv, fmt.Sprintf("%s %s", done, inv.GetTopicName()))
+		},
+	}
+
+	cmd.Flags().StringVar(&token, "token", "", "Invitation token")
+
+	return cmd
+}
+
+func newReviewCommand(getClient ClientFunc, approve bool) *cobra.Command {
+	var role string
+
+	use, short, done := "approve", "Approve an access request", "Approved"
+	if !approve {
+		use, short, done = "deny", "Deny an access request", "Denied"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " <request-id>",
+		Short: short,
+		Long: short + ` to a topic.
+
+The requester is notified. Requires ownership of the topic.`,
+		Example: fmt.Sprintf(`  bib topic %s 7c9e6679-...`, use),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			topicClient, err := c.Topic()
+			if err != nil {
+				return err
+			}
+
+			resp, err := topicClient.ReviewTopicAccessRequest(ctx, &services.ReviewTopicAccessRequestRequest{
+				RequestId: args[0],
+				Approve:   approve,
+				Role:      role,
+			})
+			if err != nil {
+				return err
+			}
+
+			req := resp.GetRequest()
+			return writeInvitation(cmd, req, fmt.Sprintf("%s %s's request to join %s", done, req.GetUserId(), req.GetTopicName()))
+		},
+	}
+
+	if approve {
+		cmd.Flags().StringVar(&role, "role", "", "Role to grant instead of the requested one")
+	}
+
+	return cmd
+}
+
+func newCancelCommand(getClient ClientFunc) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <invitation-id>",
+		Short: "Cancel an invitation or access request",
+		Long: `Withdraw a pending invitation you sent, or an access request you made.
+
+Topic owners can cancel any pending invitation to their topics.`,
+		Example: `  bib topic cancel 7c9e6679-...`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			topicClient, err := c.Topic()
+			if err != nil {
+				return err
+			}
+
+			resp, err := topicClient.CancelTopicInvitation(ctx, &services.CancelTopicInvitationRequest{InvitationId: args[0]})
+			if err != nil {
+				return err
+			}
+
+			return writeInvitation(cmd, resp.GetInvitation(), fmt.Sprintf("Cancelled %s", args[0]))
+		},
+	}
+}
+
+func newInvitationsCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		kind   string
+		status string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "invitations [topic]",
+		Short: "List invitations and access requests",
+		Long: `List the invitations and access requests of a topic, or your own.
+
+Without a topic, lists the invitations you received and the requests you
+made. With a topic, lists everything sent to or asked of its owners;
+this requires ownership of the topic.`,
+		Example: `  # Your pending invitations
+  bib topic invitations --kind invite --status pending
+
+  # Requests waiting for review on a topic you own
+  bib topic invitations weather --kind request --status pending`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			req := &services.ListTopicInvitationsRequest{Kind: kind, Status: status}
+
+			var topicClient services.TopicServiceClient
+			if len(args) > 0 {
+				var err error
+				topicClient, req.TopicId, err = resolveTopic(ctx, getClient, args[0])
+				if err != nil {
+					return err
+				}
+			} else {
+				c, err := getClient(ctx)
+				if err != nil {
+					return err
+				}
+				if topicClient, err = c.Topic(); err != nil {
+					return err
+				}
+			}
+
+			resp, err := topicClient.ListTopicInvitations(ctx, req)
+			if err != nil {
+				return err
+			}
+
+			s := newInvitationStream(cmd)
+			for _, inv := range resp.GetInvitations() {
+				if err := s.Write(toInvitationItem(inv)); err != nil {
+					return err
+				}
+			}
+			return s.Close()
+		},
+	}
+
+	cmd.Flags().StringVar(&kind, "kind", "", "Filter by kind: invite or request")
+	cmd.Flags().StringVar(&status, "status", "", "Filter by status: pending, accepted, declined, expired or cancelled")
+
+	return cmd
+}
+
+// writeInvitation prints message for the table format, the invitation
+// otherwise.
+func writeInvitation(cmd *cobra.Command, inv *services.TopicInvitation, message string) error {
+	w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+	if w.Format() == output.FormatTable {
+		w.Success(message)
+		return nil
+	}
+	return w.Write(toInvitationItem(inv))
+}
+
+// newInvitationStream creates the output stream for invitations.
+func newInvitationStream(cmd *cobra.Command) *output.Stream[invitationItem] {
+	w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+	return output.NewStream(w,
+		[]string{"ID", "KIND", "TOPIC", "USER", "ROLE", "STATUS", "CREATED"},
+		func(i invitationItem) []string {
+			user := i.User
+			if user == "" {
+				user = i.Email
+			}
+			return []string{i.ID, i.Kind, i.Topic, user, i.Role, i.Status, i.CreatedAt.Local().Format(time.DateTime)}
+		})
+}
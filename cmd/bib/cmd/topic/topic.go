@@ -0,0 +1,44 @@
+// Package topic provides the bib topic commands.
+package topic
+
+import (
+	"context"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// NewCommand creates the topic command group. getClient is called lazily
+// by subcommands that need the daemon.
+func NewCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "topic",
+		Short: "Work with topics",
+		Long:  `Work with topics on a bibd node.`,
+	}
+
+	cmd.AddCommand(newACLCommand(getClient))
+	cmd.AddCommand(newGrantCommand(getClient))
+	cmd.AddCommand(newRevokeCommand(getClient))
+	cmd.AddCommand(newSchemaCommand(getClient))
+	cmd.AddCommand(newInviteCommand(getClient))
+	cmd.AddCommand(newRequestCommand(getClient))
+	cmd.AddCommand(newRespondCommand(getClient, true))
+	cmd.AddCommand(newRespondCommand(getClient, false))
+	cmd.AddCommand(newReviewCommand(getClient, true))
+	cmd.AddCommand(newReviewCommand(getClient, false))
+	cmd.AddCommand(newCancelCommand(getClient))
+	cmd.AddCommand(newInvitationsCommand(getClient))
+
+	return cmd
+}
+
+// addRevisionFlag adds --if-revision, which makes a change fail instead of
+// overwriting changes someone else made since the topic was read.
+func addRevisionFlag(cmd *cobra.Command, revision *int64) {
+	cmd.Flags().Int64Var(revision, "if-revision", 0, "Only apply if the topic is still at this revision")
+}
@@ -0,0 +1,242 @@
+package user
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/auth"
+	"bib/internal/cli/output"
+
+	"github.com/spf13/cobra"
+)
+
+// keyItem is an identity key as written by the keys commands
+type keyItem struct {
+	Fingerprint string    `json:"fingerprint" yaml:"fingerprint"`
+	KeyType     string    `json:"key_type" yaml:"key_type"`
+	Label       string    `json:"label,omitempty" yaml:"label,omitempty"`
+	Primary     bool      `json:"primary" yaml:"primary"`
+	CreatedAt   time.Time `json:"created_at" yaml:"created_at"`
+}
+
+func toKeyItem(k *services.UserPublicKey) keyItem {
+	item := keyItem{
+		Fingerprint: k.GetFingerprint(),
+		KeyType:     k.GetKeyType(),
+		Label:       k.GetLabel(),
+		Primary:     k.GetPrimary(),
+	}
+	if k.GetCreatedAt() != nil {
+		item.CreatedAt = k.GetCreatedAt().AsTime()
+	}
+	return item
+}
+
+func newKeysCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage identity keys",
+		Long: `Manage the identity keys that can authenticate as a user.
+
+Every user has a primary key, the one their user ID was derived from. Each
+additional device can register its own key under a label instead of copying
+the primary key, and a lost or retired device is deprovisioned by removing
+its key. Removing a key ends the sessions opened with it.
+
+Admins can manage other users' keys with --user.`,
+	}
+
+	cmd.AddCommand(newKeysAddCommand(getClient))
+	cmd.AddCommand(newKeysListCommand(getClient))
+	cmd.AddCommand(newKeysRemoveCommand(getClient))
+
+	return cmd
+}
+
+func newKeysAddCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		label  string
+		userID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <public-key-file>",
+		Short: "Register a device key",
+		Long: `Register a public key in authorized_keys format for a device.
+
+Run bib setup on the new device to generate its identity key and print its
+public key with ssh-keygen -y, then add it from a device that is already
+signed in. Use - to read the key from stdin.`,
+		Example: `  bib user keys add --label laptop laptop.pub
+  ssh laptop ssh-keygen -y -f .config/bib/identity.pem | bib user keys add --label laptop -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if label == "" {
+				return fmt.Errorf("--label is required")
+			}
+
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read public key: %w", err)
+			}
+
+			publicKey, keyType, err := auth.ParseAuthorizedKey(data)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			userClient, err := c.User()
+			if err != nil {
+				return err
+			}
+
+			resp, err := userClient.AddPublicKey(ctx, &services.AddPublicKeyRequest{
+				UserId:    userID,
+				PublicKey: publicKey,
+				KeyType:   string(keyType),
+				Label:     label,
+			})
+			if err != nil {
+				return err
+			}
+
+			item := toKeyItem(resp.GetKey())
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(item)
+			}
+			w.Success(fmt.Sprintf("Registered key %s for %s", item.Fingerprint, item.Label))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&label, "label", "", "Device the key belongs to, e.g. laptop (required)")
+	cmd.Flags().StringVar(&userID, "user", "", "Register the key for this user ID (admin only)")
+
+	return cmd
+}
+
+func newKeysListCommand(getClient ClientFunc) *cobra.Command {
+	var userID string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List identity keys",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			userClient, err := c.User()
+			if err != nil {
+				return err
+			}
+
+			resp, err := userClient.ListPublicKeys(ctx, &services.ListPublicKeysRequest{UserId: userID})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			s := output.NewStream(w,
+				[]string{"FINGERPRINT", "TYPE", "LABEL", "PRIMARY", "ADDED"},
+				func(i keyItem) []string {
+					return []string{
+						i.Fingerprint, i.KeyType, i.Label, fmt.Sprint(i.Primary),
+						i.CreatedAt.Local().Format(time.DateTime),
+					}
+				})
+			for _, k := range resp.GetKeys() {
+				if err := s.Write(toKeyItem(k)); err != nil {
+					return err
+				}
+			}
+			return s.Close()
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "List the keys of this user ID (admin only)")
+
+	return cmd
+}
+
+func newKeysRemoveCommand(getClient ClientFunc) *cobra.Command {
+	var userID string
+
+	cmd := &cobra.Command{
+		Use:   "remove <label|fingerprint>",
+		Short: "Revoke a device key",
+		Long: `Revoke a device key by label or fingerprint and end the sessions that
+were opened with it. The primary key cannot be removed.`,
+		Example: `  bib user keys remove laptop`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			userClient, err := c.User()
+			if err != nil {
+				return err
+			}
+
+			list, err := userClient.ListPublicKeys(ctx, &services.ListPublicKeysRequest{UserId: userID})
+			if err != nil {
+				return err
+			}
+			fingerprint := ""
+			for _, k := range list.GetKeys() {
+				if k.GetLabel() == args[0] || k.GetFingerprint() == args[0] {
+					fingerprint = k.GetFingerprint()
+					break
+				}
+			}
+			if fingerprint == "" {
+				return fmt.Errorf("no key with label or fingerprint %s", args[0])
+			}
+
+			resp, err := userClient.RemovePublicKey(ctx, &services.RemovePublicKeyRequest{
+				UserId:      userID,
+				Fingerprint: fingerprint,
+			})
+			if err != nil {
+				return err
+			}
+
+			w := output.NewWriter(output.ParseFormat(outputFormat)).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(map[string]any{
+					"fingerprint":    fingerprint,
+					"removed":        true,
+					"sessions_ended": resp.GetSessionsEnded(),
+				})
+			}
+			w.Success(fmt.Sprintf("Removed key %s (%d sessions ended)", args[0], resp.GetSessionsEnded()))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "Remove the key from this user ID (admin only)")
+
+	return cmd
+}
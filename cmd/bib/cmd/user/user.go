@@ -0,0 +1,27 @@
+// Package user provides the bib user commands.
+package user
+
+import (
+	"context"
+
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// NewCommand creates the user command group. getClient is called lazily
+// by subcommands that need the daemon.
+func NewCommand(getClient ClientFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage your user identity",
+		Long:  `Manage your user identity on a bibd node.`,
+	}
+
+	cmd.AddCommand(newKeysCommand(getClient))
+
+	return cmd
+}
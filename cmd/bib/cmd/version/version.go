@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"bib/internal/config"
+	buildversion "bib/internal/version"
 
 	"github.com/spf13/cobra"
 )
@@ -26,6 +27,7 @@ type VersionInfo struct {
 	Version     string `json:"version" yaml:"version"`
 	Commit      string `json:"commit" yaml:"commit"`
 	BuildDate   string `json:"build_date" yaml:"build_date"`
+	APIVersion  string `json:"api_version" yaml:"api_version"`
 	GoVersion   string `json:"go_version" yaml:"go_version"`
 	Platform    string `json:"platform" yaml:"platform"`
 	NodeID      string `json:"node_id,omitempty" yaml:"node_id,omitempty"`
@@ -50,11 +52,12 @@ func NewCommand() *cobra.Command {
 
 func runVersion(cmd *cobra.Command, args []string) error {
 	info := VersionInfo{
-		Version:   Version,
-		Commit:    Commit,
-		BuildDate: BuildDate,
-		GoVersion: runtime.Version(),
-		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		Version:    Version,
+		Commit:     Commit,
+		BuildDate:  BuildDate,
+		APIVersion: buildversion.APIVersion,
+		GoVersion:  runtime.Version(),
+		Platform:   fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
 	}
 
 	// Try to load config to get storage type and node ID
@@ -74,6 +77,7 @@ func runVersion(cmd *cobra.Command, args []string) error {
 	fmt.Printf("bib version %s\n", info.Version)
 	fmt.Printf("  commit:     %s\n", info.Commit)
 	fmt.Printf("  built:      %s\n", info.BuildDate)
+	fmt.Printf("  api:        %s\n", info.APIVersion)
 	fmt.Printf("  go version: %s\n", info.GoVersion)
 	fmt.Printf("  platform:   %s\n", info.Platform)
 
@@ -0,0 +1,155 @@
+// Package whoami provides the bib whoami command.
+package whoami
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cli/output"
+	client "bib/internal/grpc/client"
+
+	"github.com/spf13/cobra"
+)
+
+// ClientFunc returns a connected daemon client.
+type ClientFunc func(ctx context.Context) (*client.Client, error)
+
+// identity is the caller's identity as written by bib whoami
+type identity struct {
+	UserID             string    `json:"user_id" yaml:"user_id"`
+	Name               string    `json:"name" yaml:"name"`
+	Email              string    `json:"email,omitempty" yaml:"email,omitempty"`
+	Status             string    `json:"status" yaml:"status"`
+	Roles              []string  `json:"roles" yaml:"roles"`
+	Fingerprint        string    `json:"fingerprint" yaml:"fingerprint"`
+	PrimaryFingerprint string    `json:"primary_fingerprint" yaml:"primary_fingerprint"`
+	SessionID          string    `json:"session_id" yaml:"session_id"`
+	SessionType        string    `json:"session_type,omitempty" yaml:"session_type,omitempty"`
+	ExpiresAt          time.Time `json:"expires_at" yaml:"expires_at"`
+	NodeID             string    `json:"node_id" yaml:"node_id"`
+	NodeMode           string    `json:"node_mode,omitempty" yaml:"node_mode,omitempty"`
+	NodeVersion        string    `json:"node_version,omitempty" yaml:"node_version,omitempty"`
+	ConnectedTo        string    `json:"connected_to" yaml:"connected_to"`
+	Permissions        []string  `json:"permissions" yaml:"permissions"`
+}
+
+func toIdentity(resp *services.WhoAmIResponse, connectedTo string) identity {
+	id := identity{
+		UserID:             resp.GetUser().GetId(),
+		Name:               resp.GetUser().GetName(),
+		Email:              resp.GetUser().GetEmail(),
+		Status:             resp.GetUser().GetStatus(),
+		Roles:              resp.GetRoles(),
+		Fingerprint:        resp.GetPublicKeyFingerprint(),
+		PrimaryFingerprint: resp.GetUser().GetPublicKeyFingerprint(),
+		SessionID:          resp.GetSession().GetId(),
+		SessionType:        resp.GetSession().GetType(),
+		NodeID:             resp.GetNodeId(),
+		NodeMode:           resp.GetNodeMode(),
+		NodeVersion:        resp.GetVersion(),
+		ConnectedTo:        connectedTo,
+		Permissions:        resp.GetPermissions(),
+	}
+	if resp.GetExpiresAt() != nil {
+		id.ExpiresAt = resp.GetExpiresAt().AsTime()
+	}
+	return id
+}
+
+// NewCommand creates the whoami command.
+func NewCommand(getClient ClientFunc) *cobra.Command {
+	var (
+		asJSON      bool
+		permissions bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show who you are authenticated as",
+		Long: `Show who you are authenticated as on the connected node.
+
+Reports the user, the fingerprint of the key the session was opened with,
+the effective roles, when the session expires and which node answered.
+The RPC methods your roles allow are counted; list them with --permissions.`,
+		Example: `  bib whoami
+  bib whoami --permissions
+  bib whoami --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			c, err := getClient(ctx)
+			if err != nil {
+				return err
+			}
+			authClient, err := c.Auth()
+			if err != nil {
+				return err
+			}
+
+			resp, err := authClient.WhoAmI(ctx, &services.WhoAmIRequest{})
+			if err != nil {
+				return err
+			}
+			id := toIdentity(resp, c.ConnectedTo())
+
+			format := output.ParseFormat(outputFormat)
+			if asJSON {
+				format = output.FormatJSON
+			}
+			w := output.NewWriter(format).WithOutput(cmd.OutOrStdout())
+			if w.Format() != output.FormatTable {
+				return w.Write(id)
+			}
+
+			key := id.Fingerprint
+			if id.Fingerprint != "" && id.Fingerprint != id.PrimaryFingerprint {
+				key += " (device key)"
+			}
+			node := id.NodeID
+			if id.NodeMode != "" {
+				node += " (" + id.NodeMode + ")"
+			}
+
+			table := output.NewTable("FIELD", "VALUE").
+				AddRow("User", id.Name).
+				AddRow("User ID", id.UserID)
+			if id.Email != "" {
+				table.AddRow("Email", id.Email)
+			}
+			table.AddRow("Status", id.Status).
+				AddRow("Roles", strings.Join(id.Roles, ", ")).
+				AddRow("Key", key).
+				AddRow("Session", id.SessionID).
+				AddRow("Expires", fmt.Sprintf("%s (in %s)",
+					id.ExpiresAt.Local().Format(time.DateTime), time.Until(id.ExpiresAt).Round(time.Minute))).
+				AddRow("Node", node).
+				AddRow("Connected to", id.ConnectedTo)
+			if id.NodeVersion != "" {
+				table.AddRow("Node version", id.NodeVersion)
+			}
+			table.AddRow("Permissions", fmt.Sprintf("%d methods", len(id.Permissions)))
+			if err := w.Write(table); err != nil {
+				return err
+			}
+
+			if permissions {
+				w.Println()
+				methods := output.NewTable("METHOD")
+				for _, m := range id.Permissions {
+					methods.AddRow(m)
+				}
+				return w.Write(methods)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Write the identity as JSON (same as -o json)")
+	cmd.Flags().BoolVar(&permissions, "permissions", false, "List the RPC methods you may call")
+
+	return cmd
+}
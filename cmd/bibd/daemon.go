@@ -6,6 +6,7 @@ import (
 	"bib/internal/certs"
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,38 +17,62 @@ import (
 
 	"bib/internal/cluster"
 	"bib/internal/config"
+	"bib/internal/domain"
 	grpcpkg "bib/internal/grpc"
 	"bib/internal/grpc/interfaces"
 	"bib/internal/grpc/middleware"
+	"bib/internal/grpc/services/admin"
+	"bib/internal/grpc/services/node"
 	"bib/internal/logger"
+	"bib/internal/maintenance"
 	"bib/internal/p2p"
 	sshserver "bib/internal/ssh"
 	"bib/internal/storage"
+	"bib/internal/storage/audit"
+	"bib/internal/storage/backup"
+	"bib/internal/storage/postgres/encryption"
 	pglifecycle "bib/internal/storage/postgres/lifecycle"
+	"bib/internal/watchdog"
 
 	// Import storage backends to register factories
+	_ "bib/internal/storage/memory"
 	_ "bib/internal/storage/postgres"
 	_ "bib/internal/storage/sqlite"
 )
 
 // Daemon manages all bibd components and their lifecycle.
 type Daemon struct {
-	cfg       *config.BibdConfig
-	configDir string
-	log       *logger.Logger
-	auditLog  *logger.AuditLogger
+	cfg        *config.BibdConfig
+	configDir  string
+	configFile string // config file loaded, "" if none; mode switches are persisted to it
+	log        *logger.Logger
+	auditLog   *logger.AuditLogger
 
 	store       storage.Store
 	pgLifecycle *pglifecycle.Manager // PostgreSQL lifecycle manager (nil if not using managed postgres)
+	encryption  *encryption.Manager  // Encrypted volume holding the database files (nil if disabled)
 	p2pIdentity *p2p.Identity        // P2P identity (also used for CA key encryption)
 	p2pHost     *p2p.Host
 	p2pDisc     *p2p.Discovery
 	p2pMode     *p2p.ModeManager
+	p2pMsg      *p2p.Messenger      // Direct peer messaging (nil if disabled)
+	p2pMsgAuth  *p2p.PeerAuthorizer // Authorizes senders of direct messages
+	p2pLabels   *p2p.LabelManager   // Node labels, exchanged with peers
+	p2pNodes    p2p.NodeManager
 	cluster     *cluster.Cluster
 	certMgr     *certs.Manager    // TLS certificate manager
 	grpcServer  *grpcpkg.Server   // gRPC server
 	authService *auth.Service     // Authentication service
 	sshServer   *sshserver.Server // SSH server for TUI access
+	maintenance *maintenance.Gate // Pauses the background maintenance workers
+
+	watchdog   *watchdog.Watchdog // Flags goroutine leaks and hangs (nil if disabled)
+	watchdogCh chan struct{}      // Closed to stop the daemon heartbeat
+
+	logBuffer *admin.LogRingBuffer // Recent log entries, streamed by the admin service
+
+	started         []string // Components in the order they started; Stop stops them in reverse
+	releaseInstance func()   // Releases the single-instance guard (nil if not held)
 
 	mu        sync.Mutex
 	running   bool
@@ -56,21 +81,38 @@ type Daemon struct {
 
 // NewDaemon creates a new daemon instance.
 func NewDaemon(cfg *config.BibdConfig, configDir string, log *logger.Logger, auditLog *logger.AuditLogger) *Daemon {
+	// Keep the recent log entries for admins to stream
+	logBuffer := admin.NewLogRingBuffer(1000)
+	log = log.Tee(logBuffer.Handler())
+
 	// Set logger for all components
 	storage.SetLogger(log)
 	p2p.SetLogger(log)
 	cluster.SetLogger(log)
 
-	return &Daemon{
-		cfg:       cfg,
-		configDir: configDir,
-		log:       log,
-		auditLog:  auditLog,
-	}
+	d := &Daemon{
+		cfg:         cfg,
+		configDir:   configDir,
+		log:         log,
+		auditLog:    auditLog,
+		maintenance: maintenance.NewGate(),
+		logBuffer:   logBuffer,
+	}
+	if wd := cfg.Server.Watchdog; wd.Enabled {
+		d.watchdog = watchdog.New(watchdog.Config{
+			Interval:      wd.Interval,
+			MaxGoroutines: wd.MaxGoroutines,
+			GrowthSamples: wd.GrowthSamples,
+			StallTimeout:  wd.StallTimeout,
+		}, log)
+	}
+	return d
 }
 
 // Start initializes and starts all daemon components in the correct order.
 // Order: Identity -> Certificates -> Storage -> P2P -> Cluster
+// Components failing for a transient reason are retried with backoff, see
+// retryStartup.
 func (d *Daemon) Start(ctx context.Context) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -80,9 +122,13 @@ func (d *Daemon) Start(ctx context.Context) error {
 	}
 
 	d.log.Info("starting daemon components")
+	d.started = nil
 
-	// 1. Write PID file
+	// 1. Refuse to start next to another daemon, and write PID file
 	if err := d.writePIDFile(); err != nil {
+		if errors.Is(err, errAlreadyRunning) {
+			return err
+		}
 		d.log.Warn("failed to write PID file", "error", err, "path", d.cfg.Server.PIDFile)
 		// Non-fatal, continue
 	}
@@ -97,35 +143,40 @@ func (d *Daemon) Start(ctx context.Context) error {
 		if err := d.initCertificates(); err != nil {
 			return fmt.Errorf("failed to initialize certificates: %w", err)
 		}
+		d.started = append(d.started, "certificates")
 	}
 
 	// 4. Initialize storage
-	if err := d.startStorage(ctx); err != nil {
+	if err := d.retryStartup(ctx, "storage", d.startStorage, func() { d.stopStorage() }); err != nil {
+		d.stopEncryption()
 		d.stopCertificates()
 		return fmt.Errorf("failed to start storage: %w", err)
 	}
+	d.started = append(d.started, "storage")
 
 	// 5. Initialize P2P networking
 	if d.cfg.P2P.Enabled {
-		if err := d.startP2P(ctx); err != nil {
+		if err := d.retryStartup(ctx, "p2p", d.startP2P, nil); err != nil {
 			d.stopStorage()
 			d.stopCertificates()
 			return fmt.Errorf("failed to start P2P: %w", err)
 		}
+		d.started = append(d.started, "p2p")
 	}
 
 	// 6. Initialize cluster (requires P2P for DHT discovery)
 	if d.cfg.Cluster.Enabled {
-		if err := d.startCluster(ctx); err != nil {
+		if err := d.retryStartup(ctx, "cluster", d.startCluster, nil); err != nil {
 			d.stopP2P()
 			d.stopStorage()
 			d.stopCertificates()
 			return fmt.Errorf("failed to start cluster: %w", err)
 		}
+		d.started = append(d.started, "cluster")
 	}
 
 	// 7. Wait for storage to be ready (includes waiting for managed PostgreSQL)
-	if err := d.waitForStorageReady(ctx); err != nil {
+	if err := d.retryStartup(ctx, "storage", d.waitForStorageReady, d.closeManagedStore); err != nil {
 		d.stopCluster()
 		d.stopP2P()
 		d.stopStorage()
@@ -135,13 +186,14 @@ func (d *Daemon) Start(ctx context.Context) error {
 
 	// 8. Initialize gRPC server (after storage and P2P are ready)
 	if d.cfg.Server.GRPC.Enabled {
-		if err := d.startGRPCServer(ctx); err != nil {
+		if err := d.retryStartup(ctx, "grpc", d.startGRPCServer, nil); err != nil {
 			d.stopCluster()
 			d.stopP2P()
 			d.stopStorage()
 			d.stopCertificates()
 			return fmt.Errorf("failed to start gRPC server: %w", err)
 		}
+		d.started = append(d.started, "grpc")
 	}
 
 	// 9. Initialize auth service (after storage is ready)
@@ -149,7 +201,7 @@ func (d *Daemon) Start(ctx context.Context) error {
 
 	// 10. Initialize SSH server for TUI access
 	if d.cfg.SSH.Enabled {
-		if err := d.startSSHServer(ctx); err != nil {
+		if err := d.retryStartup(ctx, "ssh", d.startSSHServer, nil); err != nil {
 			d.stopGRPCServer(ctx)
 			d.stopCluster()
 			d.stopP2P()
@@ -157,8 +209,12 @@ func (d *Daemon) Start(ctx context.Context) error {
 			d.stopCertificates()
 			return fmt.Errorf("failed to start SSH server: %w", err)
 		}
+		d.started = append(d.started, "ssh")
 	}
 
+	// 11. Start the watchdog once startup no longer holds the daemon lock
+	d.startWatchdog()
+
 	d.running = true
 	d.startedAt = time.Now()
 	d.log.Info("daemon started successfully")
@@ -166,8 +222,10 @@ func (d *Daemon) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop gracefully shuts down all daemon components in reverse order.
-// Order: SSH -> gRPC -> Cluster -> P2P -> Storage -> Certificates
+// Stop gracefully shuts down all daemon components in reverse order of
+// startup, usually SSH -> gRPC -> Cluster -> P2P -> Storage -> Certificates,
+// then removes the PID file. Each phase gets a share of the time until the
+// deadline of ctx, see runShutdownPhases.
 func (d *Daemon) Stop(ctx context.Context) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -177,43 +235,12 @@ func (d *Daemon) Stop(ctx context.Context) error {
 	}
 
 	d.log.Info("stopping daemon components")
+	started := time.Now()
 
-	var errs []error
-
-	// 1. Stop SSH server first
-	if err := d.stopSSHServer(ctx); err != nil {
-		errs = append(errs, fmt.Errorf("ssh: %w", err))
-	}
-
-	// 2. Stop gRPC server (drain connections)
-	if err := d.stopGRPCServer(ctx); err != nil {
-		errs = append(errs, fmt.Errorf("grpc: %w", err))
-	}
-
-	// 2. Stop cluster
-	if err := d.stopCluster(); err != nil {
-		errs = append(errs, fmt.Errorf("cluster: %w", err))
-	}
+	d.stopWatchdog()
 
-	// 3. Stop P2P
-	if err := d.stopP2P(); err != nil {
-		errs = append(errs, fmt.Errorf("p2p: %w", err))
-	}
-
-	// 4. Stop storage
-	if err := d.stopStorage(); err != nil {
-		errs = append(errs, fmt.Errorf("storage: %w", err))
-	}
-
-	// 5. Stop certificate manager
-	if err := d.stopCertificates(); err != nil {
-		errs = append(errs, fmt.Errorf("certs: %w", err))
-	}
-
-	// 6. Remove PID file
-	if err := d.removePIDFile(); err != nil {
-		d.log.Warn("failed to remove PID file", "error", err)
-	}
+	errs := d.runShutdownPhases(ctx, d.shutdownPhases())
+	d.started = nil
 
 	d.running = false
 
@@ -222,10 +249,63 @@ func (d *Daemon) Stop(ctx context.Context) error {
 		return fmt.Errorf("shutdown errors: %v", errs)
 	}
 
-	d.log.Info("daemon stopped successfully")
+	d.log.Info("daemon stopped successfully", "took", time.Since(started).Round(time.Millisecond))
 	return nil
 }
 
+// startWatchdog starts the watchdog, if enabled, and the daemon heartbeat.
+// The heartbeat takes the daemon lock, so it stops if a call holding the
+// lock hangs.
+func (d *Daemon) startWatchdog() {
+	if d.watchdog == nil {
+		return
+	}
+
+	hb := d.watchdog.Heartbeat("daemon")
+	stop := make(chan struct{})
+	d.watchdogCh = stop
+	d.watchdog.Start(context.Background())
+
+	go func() {
+		ticker := time.NewTicker(d.watchdog.Interval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				d.beat(hb)
+			}
+		}
+	}()
+
+	d.log.Info("watchdog started",
+		"interval", d.watchdog.Interval(),
+		"max_goroutines", d.cfg.Server.Watchdog.MaxGoroutines,
+		"stall_timeout", d.cfg.Server.Watchdog.StallTimeout,
+	)
+}
+
+// beat beats the daemon heartbeat once the daemon lock is free.
+func (d *Daemon) beat(hb *watchdog.Heartbeat) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	hb.Beat()
+}
+
+// stopWatchdog stops the watchdog and the daemon heartbeat. It is called
+// with the daemon lock held, so it doesn't wait for the heartbeat, which
+// may be waiting for the lock.
+func (d *Daemon) stopWatchdog() {
+	if d.watchdog == nil || d.watchdogCh == nil {
+		return
+	}
+	close(d.watchdogCh)
+	d.watchdogCh = nil
+	d.watchdog.Stop()
+}
+
 // loadIdentity loads or generates the P2P identity.
 // This is needed early for certificate key encryption.
 func (d *Daemon) loadIdentity() error {
@@ -268,6 +348,15 @@ func (d *Daemon) initCertificates() error {
 		}
 	}
 
+	minVersion, err := certs.ParseTLSVersion(d.cfg.Server.TLS.MinVersion)
+	if err != nil {
+		return fmt.Errorf("invalid server.tls.min_version: %w", err)
+	}
+	cipherSuites, err := certs.ParseCipherSuites(d.cfg.Server.TLS.CipherSuites, minVersion)
+	if err != nil {
+		return fmt.Errorf("invalid server.tls.cipher_suites: %w", err)
+	}
+
 	// Create certificate manager config
 	certCfg := certs.ManagerConfig{
 		ConfigDir:              d.configDir,
@@ -279,6 +368,40 @@ func (d *Daemon) initCertificates() error {
 		ServerCertValidityDays: d.cfg.Server.TLS.ServerCertValidityDays,
 		ClientCertValidityDays: d.cfg.Server.TLS.ClientCertValidityDays,
 		RenewalThresholdDays:   d.cfg.Server.TLS.RenewalThresholdDays,
+		MinVersion:             minVersion,
+		CipherSuites:           cipherSuites,
+	}
+
+	if spiffe := d.cfg.Server.TLS.SPIFFE; spiffe.Enabled {
+		certCfg.SPIFFE = &certs.SPIFFEConfig{TrustDomain: spiffe.TrustDomain}
+		if spiffe.TrustBundleFile != "" {
+			bundle, err := os.ReadFile(spiffe.TrustBundleFile)
+			if err != nil {
+				return fmt.Errorf("failed to read SPIFFE trust bundle: %w", err)
+			}
+			certCfg.SPIFFE.TrustBundle = bundle
+		}
+	}
+
+	// Chain to an external PKI when certificates are provided rather than
+	// generated
+	if tlsCfg := d.cfg.Server.TLS; !tlsCfg.AutoGenerate && (tlsCfg.CAFile != "" || tlsCfg.CertFile != "") {
+		certCfg.External = &certs.ExternalPKI{
+			CAFile:    tlsCfg.CAFile,
+			CAKeyFile: tlsCfg.CAKeyFile,
+			CertFile:  tlsCfg.CertFile,
+			KeyFile:   tlsCfg.KeyFile,
+		}
+	}
+
+	if p11 := d.cfg.Server.TLS.PKCS11; p11.Enabled {
+		certCfg.PKCS11 = &certs.PKCS11Config{
+			ModulePath: p11.ModulePath,
+			Slot:       p11.Slot,
+			TokenLabel: p11.TokenLabel,
+			KeyLabel:   p11.KeyLabel,
+			PIN:        p11.PIN,
+		}
 	}
 
 	// Create certificate manager
@@ -323,13 +446,34 @@ func (d *Daemon) startStorage(ctx context.Context) error {
 		"data_dir", d.cfg.Server.DataDir,
 	)
 
+	// Unlock the encrypted volume before anything opens the database files
+	if err := d.startEncryption(ctx); err != nil {
+		d.log.Error("failed to start encryption at rest", "error", err)
+		return fmt.Errorf("failed to start encryption at rest: %w", err)
+	}
+
 	// Convert config types
 	storageCfg := d.convertStorageConfig()
+	if d.encryption != nil && storageCfg.Postgres.DataDir == "" {
+		storageCfg.Postgres.DataDir = filepath.Join(d.storageDataDir(), "postgres")
+	}
+	if storageCfg.Backend == storage.BackendSQLite {
+		if err := d.prepareSQLite(storageCfg.SQLite.Path); err != nil {
+			d.log.Error("failed to prepare the SQLite database", "error", err)
+			return fatalStartup(err)
+		}
+		key, err := d.sqliteKey()
+		if err != nil {
+			d.log.Error("failed to get the SQLite encryption key", "error", err)
+			return fatalStartup(err)
+		}
+		storageCfg.SQLite.Key = key
+	}
 
 	// Validate configuration early (fail fast)
 	if err := storageCfg.Validate(); err != nil {
 		d.log.Error("invalid storage configuration", "error", err)
-		return fmt.Errorf("invalid storage configuration: %w", err)
+		return fatalStartup(fmt.Errorf("invalid storage configuration: %w", err))
 	}
 
 	// Determine node ID for storage
@@ -341,7 +485,7 @@ func (d *Daemon) startStorage(ctx context.Context) error {
 	// Validate mode/backend compatibility early (fail fast)
 	if err := storage.ValidateModeBackend(d.cfg.P2P.Mode, storageCfg.Backend); err != nil {
 		d.log.Error("incompatible mode and backend", "error", err, "mode", d.cfg.P2P.Mode, "backend", storageCfg.Backend)
-		return fmt.Errorf("incompatible mode and backend: %w", err)
+		return fatalStartup(fmt.Errorf("incompatible mode and backend: %w", err))
 	}
 
 	// Handle managed PostgreSQL lifecycle
@@ -355,7 +499,7 @@ func (d *Daemon) startStorage(ctx context.Context) error {
 	}
 
 	// For non-managed backends (SQLite or external Postgres), open immediately
-	store, err := storage.Open(ctx, storageCfg, d.cfg.Server.DataDir, nodeID, d.cfg.P2P.Mode)
+	store, err := storage.Open(ctx, storageCfg, d.storageDataDir(), nodeID, d.cfg.P2P.Mode)
 	if err != nil {
 		d.log.Error("failed to open storage", "error", err)
 		return fmt.Errorf("failed to open storage: %w", err)
@@ -385,6 +529,11 @@ func (d *Daemon) startManagedPostgres(ctx context.Context, storageCfg storage.Co
 
 	// Create lifecycle config from storage config
 	lifecycleCfg := d.convertToLifecycleConfig(storageCfg.Postgres)
+	image := lifecycleCfg.Image
+	lifecycleCfg.PullProgress = func(line string) {
+		d.log.Info("pulling PostgreSQL image", "image", image, "status", line)
+	}
+	lifecycleCfg.OnFailover = d.handlePostgresFailover
 
 	// Determine node ID
 	nodeID := d.cfg.Cluster.NodeID
@@ -399,44 +548,106 @@ func (d *Daemon) startManagedPostgres(ctx context.Context, storageCfg storage.Co
 		return fmt.Errorf("failed to create PostgreSQL lifecycle manager: %w", err)
 	}
 
-	// Validate detected runtime
-	runtime := mgr.Runtime()
-	d.log.Debug("detected container runtime", "runtime", runtime)
-
-	// Start PostgreSQL (asynchronous - container/pod starts but we don't wait)
-	if err := mgr.Start(ctx); err != nil {
-		// If Kubernetes fails, check if we can fallback to container runtime
-		if runtime == "kubernetes" {
-			d.log.Warn("Kubernetes deployment failed, checking for container runtime fallback", "error", err)
-
-			// Try to detect Docker or Podman as fallback
-			fallbackMgr, fallbackErr := pglifecycle.NewManager(lifecycleCfg, nodeID, d.cfg.Server.DataDir)
-			if fallbackErr == nil && (fallbackMgr.Runtime() == "docker" || fallbackMgr.Runtime() == "podman") {
-				d.log.Info("falling back to container runtime", "runtime", fallbackMgr.Runtime())
-				if startErr := fallbackMgr.Start(ctx); startErr == nil {
-					d.pgLifecycle = fallbackMgr
-					d.log.Info("managed PostgreSQL started with fallback",
-						"runtime", fallbackMgr.Runtime(),
-						"container", lifecycleCfg.ContainerName,
-					)
-					return nil
-				}
-			}
-		}
-
+	// Start PostgreSQL on the first runtime that works (asynchronous -
+	// container/pod starts but we don't wait)
+	err = mgr.Start(ctx)
+	attempts := mgr.Attempts()
+	d.logRuntimeAttempts(attempts)
+	if err != nil {
 		d.log.Error("failed to start managed PostgreSQL", "error", err)
+		if len(attempts) > 0 && attempts[len(attempts)-1].Diagnostics != nil {
+			return fmt.Errorf("failed to start managed PostgreSQL: %w; %s", err, attempts[len(attempts)-1].Diagnostics)
+		}
 		return fmt.Errorf("failed to start managed PostgreSQL: %w", err)
 	}
 
 	d.pgLifecycle = mgr
 	d.log.Info("managed PostgreSQL started",
-		"runtime", runtime,
+		"runtime", mgr.Runtime(),
 		"identifier", lifecycleCfg.ContainerName,
 	)
+	for _, warning := range mgr.Warnings() {
+		d.log.Warn("managed PostgreSQL", "warning", warning)
+	}
 
 	return nil
 }
 
+// logRuntimeAttempts logs the runtimes managed PostgreSQL could not start
+// on, with their diagnostics.
+func (d *Daemon) logRuntimeAttempts(attempts []pglifecycle.RuntimeAttempt) {
+	for _, attempt := range attempts {
+		switch {
+		case attempt.Skipped:
+			d.log.Debug("container runtime not available", "runtime", attempt.Runtime)
+		case attempt.Diagnostics != nil:
+			d.log.Warn("managed PostgreSQL failed to start", "runtime", attempt.Runtime, "error", attempt.Error)
+			d.logDiagnostics(attempt.Diagnostics)
+		}
+	}
+}
+
+// handlePostgresFailover moves the store to the new primary after managed
+// PostgreSQL failed over, and audits the failover.
+func (d *Daemon) handlePostgresFailover(event pglifecycle.FailoverEvent) {
+	if event.Error != "" {
+		d.log.Error("managed PostgreSQL failover failed",
+			"runtime", event.Runtime,
+			"from", event.From,
+			"error", event.Error,
+		)
+	} else {
+		d.log.Warn("managed PostgreSQL failed over",
+			"runtime", event.Runtime,
+			"from", event.From,
+			"to", event.To,
+		)
+
+		// A store opened later connects to the new primary by itself
+		if store, ok := d.Store().(storage.Retargetable); ok {
+			if err := store.Retarget(event.Host, event.Port); err != nil {
+				d.log.Error("failed to reconnect to the new PostgreSQL primary", "error", err)
+			}
+		}
+	}
+
+	d.auditFailover(event)
+}
+
+// auditFailover records a managed PostgreSQL failover in the audit log,
+// if storage is up.
+func (d *Daemon) auditFailover(event pglifecycle.FailoverEvent) {
+	store := d.Store()
+	if store == nil {
+		return
+	}
+
+	metadata := map[string]any{
+		"event":   "postgres_failover",
+		"runtime": string(event.Runtime),
+		"from":    event.From,
+		"to":      event.To,
+	}
+	if event.Error != "" {
+		metadata["error"] = event.Error
+	}
+
+	entry := &storage.AuditEntry{
+		Timestamp:       event.At,
+		NodeID:          d.NodeID(),
+		OperationID:     audit.GenerateOperationID(),
+		RoleUsed:        "storage",
+		Action:          string(audit.ActionOther),
+		TableName:       "storage",
+		SourceComponent: "storage",
+		Actor:           "system",
+		Metadata:        metadata,
+	}
+	if err := store.Audit().Log(context.Background(), entry); err != nil {
+		d.log.Warn("failed to audit PostgreSQL failover", "error", err)
+	}
+}
+
 // waitForStorageReady waits for storage to become healthy before the node becomes ready.
 // This is called after all components are started but before marking the node as ready.
 func (d *Daemon) waitForStorageReady(ctx context.Context) error {
@@ -448,7 +659,9 @@ func (d *Daemon) waitForStorageReady(ctx context.Context) error {
 
 		// The lifecycle manager's Start() already waits, but we check again for safety
 		if !d.pgLifecycle.IsReady() {
-			return fmt.Errorf("managed PostgreSQL is not ready")
+			// Retrying does not restart PostgreSQL, so this cannot recover
+			diag := d.logPostgresDiagnostics(ctx, d.pgLifecycle)
+			return fatalStartup(fmt.Errorf("managed PostgreSQL is not ready; %s", diag))
 		}
 
 		// Now that PostgreSQL is ready, connect to it
@@ -489,6 +702,42 @@ func (d *Daemon) waitForStorageReady(ctx context.Context) error {
 	return nil
 }
 
+// closeManagedStore closes the store connected to managed PostgreSQL, so
+// waitForStorageReady opens a new one when retried. Other stores are opened
+// by startStorage and kept.
+func (d *Daemon) closeManagedStore() {
+	if d.pgLifecycle == nil || d.store == nil {
+		return
+	}
+	if err := d.store.Close(); err != nil {
+		d.log.Warn("error closing storage", "error", err)
+	}
+	d.store = nil
+}
+
+// logPostgresDiagnostics logs why managed PostgreSQL failed to start, with
+// the container or pod logs, and returns the diagnostics.
+func (d *Daemon) logPostgresDiagnostics(ctx context.Context, mgr *pglifecycle.Manager) *pglifecycle.Diagnostics {
+	diag := mgr.Diagnostics(ctx)
+	d.logDiagnostics(diag)
+	return diag
+}
+
+// logDiagnostics logs the diagnostics of managed PostgreSQL.
+func (d *Daemon) logDiagnostics(diag *pglifecycle.Diagnostics) {
+	d.log.Error("managed PostgreSQL diagnostics",
+		"runtime", diag.Runtime,
+		"name", diag.Name,
+		"state", diag.State,
+		"last_health_error", diag.LastHealthError,
+		"likely_cause", diag.Cause,
+		"hint", diag.Hint,
+	)
+	if diag.Logs != "" {
+		d.log.Error("managed PostgreSQL logs (last lines)\n" + diag.Logs)
+	}
+}
+
 // openManagedPostgresStore opens a PostgreSQL store connected to a managed instance.
 func (d *Daemon) openManagedPostgresStore(ctx context.Context, pgCfg storage.PostgresConfig, nodeID string) (storage.Store, error) {
 	d.log.Debug("connecting to managed PostgreSQL", "node_id", nodeID)
@@ -504,91 +753,18 @@ func (d *Daemon) openManagedPostgresStore(ctx context.Context, pgCfg storage.Pos
 	// Set Managed to false to pass validation (we're just connecting, not managing)
 	modifiedCfg.Postgres.Managed = false
 
-	// Parse connection string to populate Advanced config
-	// Format: "host=X port=Y user=Z password=W dbname=D sslmode=S"
-	// For simplicity, we'll pass the connection components
-	if modifiedCfg.Postgres.Advanced == nil {
-		modifiedCfg.Postgres.Advanced = &storage.AdvancedPostgresConfig{}
-	}
-
-	// Extract components from lifecycle manager
-
-	// For Kubernetes, get connection info from the manager
-	runtime := d.pgLifecycle.Runtime()
-	if runtime == "kubernetes" {
-		// Use the connection string method which handles Kubernetes properly
-		connStr := d.pgLifecycle.ConnectionString()
-		d.log.Debug("using Kubernetes connection", "connection_string_format", "parsed")
-
-		// Parse the connection string to populate Advanced config
-		// This is a simple parser - in production you might want to use url.Parse
-		parts := make(map[string]string)
-		for _, part := range splitConnectionString(connStr) {
-			kv := strings.SplitN(part, "=", 2)
-			if len(kv) == 2 {
-				parts[kv[0]] = kv[1]
-			}
-		}
-
-		if host, ok := parts["host"]; ok {
-			modifiedCfg.Postgres.Advanced.Host = host
-		}
-		if port, ok := parts["port"]; ok {
-			if p, err := strconv.Atoi(port); err == nil {
-				modifiedCfg.Postgres.Advanced.Port = p
-			}
-		}
-		if user, ok := parts["user"]; ok {
-			modifiedCfg.Postgres.Advanced.User = user
-		}
-		if password, ok := parts["password"]; ok {
-			modifiedCfg.Postgres.Advanced.Password = password
-		}
-		if dbname, ok := parts["dbname"]; ok {
-			modifiedCfg.Postgres.Advanced.Database = dbname
-		}
-		if sslmode, ok := parts["sslmode"]; ok {
-			modifiedCfg.Postgres.Advanced.SSLMode = sslmode
-		}
-	} else {
-		// For Docker/Podman, parse the connection string from the lifecycle manager
-		// The lifecycle manager has already applied Windows-specific fixes (forcing TCP on Windows)
-		connStr := d.pgLifecycle.ConnectionString()
-		d.log.Debug("using Docker/Podman connection", "connection_string_format", "parsed")
-
-		// Parse the connection string to populate Advanced config
-		parts := make(map[string]string)
-		for _, part := range splitConnectionString(connStr) {
-			kv := strings.SplitN(part, "=", 2)
-			if len(kv) == 2 {
-				parts[kv[0]] = kv[1]
-			}
-		}
-
-		if host, ok := parts["host"]; ok {
-			modifiedCfg.Postgres.Advanced.Host = host
-		}
-		if port, ok := parts["port"]; ok {
-			if p, err := strconv.Atoi(port); err == nil {
-				modifiedCfg.Postgres.Advanced.Port = p
-			}
-		}
-		if user, ok := parts["user"]; ok {
-			modifiedCfg.Postgres.Advanced.User = user
-		}
-		if password, ok := parts["password"]; ok {
-			modifiedCfg.Postgres.Advanced.Password = password
-		}
-		if dbname, ok := parts["dbname"]; ok {
-			modifiedCfg.Postgres.Advanced.Database = dbname
-		}
-		if sslmode, ok := parts["sslmode"]; ok {
-			modifiedCfg.Postgres.Advanced.SSLMode = sslmode
-		}
+	// Connect with the settings of the lifecycle manager's connection
+	// string. It has already applied runtime specifics, such as the
+	// Kubernetes Service address or forcing TCP on Windows.
+	advanced, err := storage.ParseAdvancedPostgresConfig(d.pgLifecycle.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid managed PostgreSQL connection string: %w", err)
 	}
+	modifiedCfg.Postgres.Advanced = advanced
+	d.log.Debug("using managed PostgreSQL connection", "runtime", d.pgLifecycle.Runtime(), "host", advanced.Host)
 
 	// Open with the advanced config
-	store, err := storage.Open(ctx, modifiedCfg, d.cfg.Server.DataDir, nodeID, d.cfg.P2P.Mode)
+	store, err := storage.Open(ctx, modifiedCfg, d.storageDataDir(), nodeID, d.cfg.P2P.Mode)
 	if err != nil {
 		return nil, err
 	}
@@ -596,39 +772,15 @@ func (d *Daemon) openManagedPostgresStore(ctx context.Context, pgCfg storage.Pos
 	return store, nil
 }
 
-// splitConnectionString splits a PostgreSQL connection string into parts.
-func splitConnectionString(connStr string) []string {
-	// Simple space-based split, respecting quoted values
-	var parts []string
-	var current strings.Builder
-	inQuote := false
-
-	for _, ch := range connStr {
-		switch ch {
-		case '\'':
-			inQuote = !inQuote
-			current.WriteRune(ch)
-		case ' ':
-			if inQuote {
-				current.WriteRune(ch)
-			} else if current.Len() > 0 {
-				parts = append(parts, current.String())
-				current.Reset()
-			}
-		default:
-			current.WriteRune(ch)
-		}
-	}
-
-	if current.Len() > 0 {
-		parts = append(parts, current.String())
-	}
-
-	return parts
-}
-
 // stopStorage shuts down the storage backend and lifecycle manager gracefully.
 func (d *Daemon) stopStorage() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return d.stopStorageContext(ctx)
+}
+
+// stopStorageContext is stopStorage within the deadline of ctx.
+func (d *Daemon) stopStorageContext(ctx context.Context) error {
 	var errs []error
 
 	// 1. Drain active connections (if store supports it)
@@ -636,7 +788,10 @@ func (d *Daemon) stopStorage() error {
 		d.log.Debug("draining storage connections")
 		// TODO: Implement connection draining in store interface
 		// For now, we'll give a brief grace period for active operations
-		time.Sleep(1 * time.Second)
+		select {
+		case <-time.After(1 * time.Second):
+		case <-ctx.Done():
+		}
 	}
 
 	// 2. Close the store connection (this completes in-flight transactions)
@@ -654,8 +809,6 @@ func (d *Daemon) stopStorage() error {
 	// 3. Gracefully stop PostgreSQL lifecycle manager if present
 	if d.pgLifecycle != nil {
 		d.log.Debug("stopping managed PostgreSQL gracefully")
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
 
 		// Perform checkpoint before shutdown (PostgreSQL specific)
 		d.log.Debug("requesting PostgreSQL checkpoint")
@@ -670,6 +823,11 @@ func (d *Daemon) stopStorage() error {
 		d.pgLifecycle = nil
 	}
 
+	// 4. Unmount the encrypted volume once nothing uses the files on it
+	if err := d.stopEncryption(); err != nil {
+		errs = append(errs, err)
+	}
+
 	if len(errs) > 0 {
 		d.log.Warn("storage shutdown completed with errors", "error_count", len(errs))
 		return fmt.Errorf("storage shutdown errors: %v", errs)
@@ -680,12 +838,24 @@ func (d *Daemon) stopStorage() error {
 }
 
 // startP2P initializes P2P networking components.
-func (d *Daemon) startP2P(ctx context.Context) error {
+func (d *Daemon) startP2P(ctx context.Context) (err error) {
 	d.log.Debug("initializing P2P networking",
 		"mode", d.cfg.P2P.Mode,
 		"listen_addresses", d.cfg.P2P.ListenAddresses,
 	)
 
+	if err := p2p.ValidateLabels(d.cfg.P2P.Labels); err != nil {
+		return fatalStartup(fmt.Errorf("invalid p2p.labels: %w", err))
+	}
+
+	// The failure paths close what they created; forget it, so neither a
+	// retry nor stopP2P closes it again
+	defer func() {
+		if err != nil {
+			d.p2pHost, d.p2pDisc, d.p2pMode = nil, nil, nil
+		}
+	}()
+
 	// Create P2P host
 	host, err := p2p.NewHost(ctx, d.cfg.P2P, d.configDir)
 	if err != nil {
@@ -731,6 +901,17 @@ func (d *Daemon) startP2P(ctx context.Context) error {
 		return err
 	}
 	d.p2pMode = modeManager
+	modeManager.SetPauseGate(d.maintenance)
+	modeManager.OnConflict(func(c domain.SyncConflict) {
+		d.log.Warn("sync conflict",
+			"dataset", c.DatasetID,
+			"current_peer", c.CurrentPeer,
+			"incoming_peer", c.IncomingPeer,
+			"winner", c.WinnerHash,
+			"pending", c.Pending,
+		)
+		d.auditSyncConflict(c)
+	})
 
 	if err := modeManager.Start(ctx); err != nil {
 		discovery.Stop()
@@ -739,6 +920,14 @@ func (d *Daemon) startP2P(ctx context.Context) error {
 		return err
 	}
 
+	if d.cfg.P2P.Messaging.Enabled {
+		d.startMessaging(host)
+	}
+
+	// Labels were validated above
+	d.p2pLabels, _ = p2p.NewLabelManager(host.Host, d.cfg.P2P.Labels)
+	d.p2pNodes = p2p.NewNodeManager(host, p2p.DefaultNodeManagerConfigDefaults())
+
 	d.log.Info("P2P networking initialized",
 		"mode", d.cfg.P2P.Mode,
 		"peer_id", host.PeerID().String(),
@@ -752,10 +941,125 @@ func (d *Daemon) startP2P(ctx context.Context) error {
 	return nil
 }
 
+// startMessaging registers the direct messaging protocol. Senders must be
+// listed in p2p.messaging.allowed_peers or in the allowed-peers table.
+func (d *Daemon) startMessaging(host *p2p.Host) {
+	d.p2pMsgAuth = p2p.NewPeerAuthorizer(p2p.PeerAuthorizerConfig{
+		AllowedPeerRepo: d.store.AllowedPeers(),
+		RateLimitConfig: p2p.DefaultRateLimitConfig(),
+		BootstrapPeers:  d.cfg.P2P.Messaging.AllowedPeers,
+	})
+	d.p2pMsg = p2p.NewMessenger(host.Host, d.cfg.P2P.Messaging, d.p2pMsgAuth)
+	d.p2pMsg.OnMessage(func(ctx context.Context, msg p2p.DirectMessage) error {
+		d.log.Info("direct message received",
+			"message_id", msg.ID,
+			"from", msg.From,
+			"kind", msg.Kind,
+			"size", len(msg.Payload),
+		)
+		return nil
+	})
+
+	d.log.Debug("direct messaging enabled",
+		"max_payload_size", d.cfg.P2P.Messaging.MaxPayloadSize,
+		"allowed_peers", len(d.cfg.P2P.Messaging.AllowedPeers),
+	)
+}
+
+// SetNodeMode switches the P2P mode of the running node. Full mode is only
+// allowed on the PostgreSQL backend. The new mode is written to the config
+// file; if that fails, the switch still holds until restart.
+func (d *Daemon) SetNodeMode(ctx context.Context, mode p2p.NodeMode, opts p2p.ModeChangeOptions) (node.ModeChange, error) {
+	if d.p2pMode == nil {
+		return node.ModeChange{}, p2p.ErrP2PDisabled
+	}
+
+	change := node.ModeChange{Previous: d.p2pMode.Mode(), Mode: mode}
+	if change.Previous == mode {
+		return change, nil
+	}
+
+	if err := storage.ValidateModeBackend(string(mode), d.convertStorageConfig().Backend); err != nil {
+		return node.ModeChange{}, err
+	}
+
+	if err := d.p2pMode.SwitchMode(mode, opts); err != nil {
+		return node.ModeChange{}, err
+	}
+
+	d.mu.Lock()
+	d.cfg.P2P.Mode = string(mode)
+	d.mu.Unlock()
+
+	if err := config.UpdateFile(d.configFile, map[string]any{"p2p.mode": string(mode)}); err != nil {
+		d.log.Warn("failed to persist P2P mode", "mode", mode, "error", err)
+	} else {
+		change.Persisted = true
+	}
+
+	d.log.Info("P2P mode switched",
+		"from", change.Previous,
+		"to", mode,
+		"purge_replica", opts.PurgeReplica,
+		"persisted", change.Persisted,
+	)
+	return change, nil
+}
+
+// NodeLabels returns the labels of the running node.
+func (d *Daemon) NodeLabels() (map[string]string, error) {
+	if d.p2pLabels == nil {
+		return nil, p2p.ErrP2PDisabled
+	}
+	return d.p2pLabels.Labels(), nil
+}
+
+// SetNodeLabels replaces the labels of the running node and pushes them to
+// the connected peers. The labels are written to the config file; if that
+// fails, they still hold until restart.
+func (d *Daemon) SetNodeLabels(ctx context.Context, labels map[string]string) (bool, error) {
+	if d.p2pLabels == nil {
+		return false, p2p.ErrP2PDisabled
+	}
+
+	if err := d.p2pLabels.SetLabels(labels); err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	d.cfg.P2P.Labels = labels
+	d.mu.Unlock()
+
+	persisted := true
+	if err := config.UpdateFile(d.configFile, map[string]any{"p2p.labels": labels}); err != nil {
+		d.log.Warn("failed to persist P2P labels", "error", err)
+		persisted = false
+	}
+
+	d.log.Info("node labels changed", "labels", labels, "persisted", persisted)
+	return persisted, nil
+}
+
 // stopP2P shuts down P2P networking.
 func (d *Daemon) stopP2P() error {
 	var errs []error
 
+	if d.p2pLabels != nil {
+		d.p2pLabels.Close()
+		d.p2pLabels = nil
+	}
+	if d.p2pNodes != nil {
+		d.p2pNodes.Close()
+		d.p2pNodes = nil
+	}
+
+	if d.p2pMsg != nil {
+		d.p2pMsg.Close()
+		d.p2pMsgAuth.Close()
+		d.p2pMsg = nil
+		d.p2pMsgAuth = nil
+	}
+
 	if d.p2pMode != nil {
 		d.log.Debug("stopping P2P mode manager")
 		if err := d.p2pMode.Stop(); err != nil {
@@ -810,6 +1114,15 @@ func (d *Daemon) startCluster(ctx context.Context) error {
 		return nil
 	}
 
+	// Snapshots are encrypted with the identity key, like the CA key
+	if d.p2pIdentity != nil {
+		identityKey, err := d.p2pIdentity.RawPrivateKey()
+		if err != nil {
+			return fmt.Errorf("failed to get identity key: %w", err)
+		}
+		clusterInstance.SetSnapshotKey(identityKey)
+	}
+
 	if err := clusterInstance.Start(ctx); err != nil {
 		d.log.Error("failed to start cluster", "error", err)
 		return err
@@ -821,6 +1134,16 @@ func (d *Daemon) startCluster(ctx context.Context) error {
 		d.log.Info("cluster leader changed", "leader", leaderID)
 	})
 
+	clusterInstance.OnFence(func(event cluster.FencingEvent) {
+		d.log.Warn("cluster leader fenced",
+			"reason", event.Reason,
+			"term", event.Term,
+			"observed_term", event.ObservedTerm,
+			"observed_from", event.ObservedFrom,
+		)
+		d.auditFencing(event)
+	})
+
 	clusterInstance.OnMemberChange(func(members []cluster.ClusterMember) {
 		d.log.Info("cluster membership changed", "member_count", len(members))
 		for _, m := range members {
@@ -841,6 +1164,71 @@ func (d *Daemon) startCluster(ctx context.Context) error {
 	return nil
 }
 
+// auditFencing records a fencing event in the audit log, if storage is up.
+func (d *Daemon) auditFencing(event cluster.FencingEvent) {
+	store := d.Store()
+	if store == nil {
+		return
+	}
+
+	entry := &storage.AuditEntry{
+		Timestamp:       event.Timestamp,
+		NodeID:          event.NodeID,
+		OperationID:     audit.GenerateOperationID(),
+		RoleUsed:        "cluster",
+		Action:          string(audit.ActionOther),
+		TableName:       "cluster",
+		SourceComponent: "cluster",
+		Actor:           "system",
+		Metadata: map[string]any{
+			"event":         "leader_fenced",
+			"reason":        string(event.Reason),
+			"term":          event.Term,
+			"observed_term": event.ObservedTerm,
+			"observed_from": event.ObservedFrom,
+		},
+		Flags: storage.AuditEntryFlags{Suspicious: true},
+	}
+	if err := store.Audit().Log(context.Background(), entry); err != nil {
+		d.log.Warn("failed to audit fencing event", "error", err)
+	}
+}
+
+// auditSyncConflict records a full replica sync conflict in the audit log,
+// if storage is up.
+func (d *Daemon) auditSyncConflict(c domain.SyncConflict) {
+	store := d.Store()
+	if store == nil {
+		return
+	}
+
+	entry := &storage.AuditEntry{
+		Timestamp:       c.DetectedAt,
+		NodeID:          d.NodeID(),
+		OperationID:     audit.GenerateOperationID(),
+		RoleUsed:        "p2p",
+		Action:          string(audit.ActionOther),
+		TableName:       "catalog",
+		SourceComponent: "p2p",
+		Actor:           "system",
+		Metadata: map[string]any{
+			"event":         "sync_conflict",
+			"dataset_id":    string(c.DatasetID),
+			"current_hash":  c.Current.Hash,
+			"current_peer":  c.CurrentPeer,
+			"incoming_hash": c.Incoming.Hash,
+			"incoming_peer": c.IncomingPeer,
+			"strategy":      string(c.Strategy),
+			"winner_hash":   c.WinnerHash,
+			"concurrent":    c.Concurrent,
+			"pending":       c.Pending,
+		},
+	}
+	if err := store.Audit().Log(context.Background(), entry); err != nil {
+		d.log.Warn("failed to audit sync conflict", "error", err)
+	}
+}
+
 // stopCluster shuts down the Raft cluster.
 func (d *Daemon) stopCluster() error {
 	if d.cluster == nil {
@@ -875,21 +1263,65 @@ func (d *Daemon) startGRPCServer(ctx context.Context) error {
 
 	// Create gRPC server configuration
 	serverCfg := grpcpkg.ServerConfig{
-		GRPCConfig:     d.cfg.Server.GRPC,
-		ServerHost:     d.cfg.Server.Host,
-		TLSConfig:      tlsConfig,
-		HealthProvider: d, // Daemon implements HealthProvider
+		GRPCConfig:      d.cfg.Server.GRPC,
+		ServerHost:      d.cfg.Server.Host,
+		TLSConfig:       tlsConfig,
+		HealthProvider:  d, // Daemon implements HealthProvider
+		GatewayConfig:   d.cfg.Server.Gateway,
+		Maintenance:     d.maintenance,
+		ModeManager:     d.p2pMode,
+		ModeController:  d,
+		Messenger:       d.p2pMsg,
+		NodeManager:     d.p2pNodes,
+		LabelController: d,
+		Cluster:         d.cluster,
+		LogBuffer:       d.logBuffer,
+		Logger:          d.log,
+		RedactFields:    d.cfg.Log.RedactFields,
+	}
+	if d.watchdog != nil {
+		serverCfg.Collectors = append(serverCfg.Collectors, d.watchdog)
+	}
+	if d.store != nil {
+		backupMgr, err := d.newBackupManager()
+		if err != nil {
+			d.log.Warn("backups are not available through the admin service", "error", err)
+		} else {
+			serverCfg.BackupMgr = backupMgr
+		}
+	}
+
+	if d.cfg.Server.Gateway.Enabled && d.cfg.Server.Gateway.TLS.Enabled {
+		gatewayTLS, err := d.gatewayTLSConfig(tlsConfig)
+		if err != nil {
+			return err
+		}
+		serverCfg.GatewayTLSConfig = gatewayTLS
+	}
+
+	if d.cfg.Server.GRPC.Metrics.Enabled && d.cfg.Server.GRPC.Metrics.Auth.MTLS {
+		metricsTLS, err := metricsTLSConfig(tlsConfig)
+		if err != nil {
+			return err
+		}
+		serverCfg.MetricsTLSConfig = metricsTLS
 	}
 
 	// Create audit middleware if audit logging is enabled
 	if d.cfg.Database.Audit.Enabled && d.store != nil {
 		auditRepo := d.store.Audit()
 		if auditRepo != nil {
+			alerts, blocks, err := d.newAuditAlerts()
+			if err != nil {
+				return err
+			}
 			serverCfg.AuditMiddleware = middleware.NewAuditMiddleware(auditRepo, middleware.AuditConfig{
 				Enabled:             true,
 				LogFailedOperations: true,
 				NodeID:              d.NodeID(),
+				Alerts:              alerts,
 			})
+			serverCfg.AuditRateLimiter = blocks
 		}
 	}
 
@@ -902,6 +1334,8 @@ func (d *Daemon) startGRPCServer(ctx context.Context) error {
 
 	// Start the server
 	if err := server.Start(ctx); err != nil {
+		// Release what did start, so a retry can take the ports
+		_ = server.Stop(ctx)
 		d.log.Error("failed to start gRPC server", "error", err)
 		return fmt.Errorf("failed to start gRPC server: %w", err)
 	}
@@ -916,6 +1350,150 @@ func (d *Daemon) startGRPCServer(ctx context.Context) error {
 	return nil
 }
 
+// newBackupManager returns the manager of the backups of the database the
+// store was opened on. Backups are kept in <data_dir>/backups, where
+// bib admin backup create and list look for them.
+func (d *Daemon) newBackupManager() (*backup.Manager, error) {
+	backupCfg := backup.DefaultBackupConfig()
+	backupCfg.LocalPath = filepath.Join(d.cfg.Server.DataDir, "backups")
+
+	mgr, err := backup.NewManager(backupCfg, d.store.Backend(), d.cfg.Server.DataDir, d.cfg.Cluster.NodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch d.store.Backend() {
+	case storage.BackendSQLite:
+		path := d.cfg.Database.SQLite.Path
+		if path == "" {
+			path = filepath.Join(d.storageDataDir(), sqliteFileName)
+		}
+		mgr.SetDatabasePath(path)
+	case storage.BackendPostgres:
+		pg, ok := d.store.(interface{ ConnString() string })
+		if !ok {
+			return nil, fmt.Errorf("no connection string for the PostgreSQL store")
+		}
+		mgr.SetConnectionString(pg.ConnString())
+	}
+	return mgr, nil
+}
+
+// gatewayTLSConfig returns the TLS configuration of the HTTP/JSON gateway:
+// the configured certificate, or else the node's server certificate. HTTP
+// clients authenticate with API keys and tokens, not client certificates.
+func (d *Daemon) gatewayTLSConfig(nodeTLS *tls.Config) (*tls.Config, error) {
+	tlsCfg := d.cfg.Server.Gateway.TLS
+	if tlsCfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gateway certificate: %w", err)
+		}
+		return &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}, nil
+	}
+
+	if nodeTLS == nil {
+		return nil, fmt.Errorf("gateway TLS enabled without a certificate: set server.gateway.tls.cert_file or enable node certificates")
+	}
+	cfg := nodeTLS.Clone()
+	cfg.ClientAuth = tls.NoClientCert
+	cfg.ClientCAs = nil
+	return cfg, nil
+}
+
+// metricsTLSConfig returns the TLS configuration of the metrics endpoint:
+// the node's server certificate, requiring scrapers to present a client
+// certificate issued by the node's CA.
+func metricsTLSConfig(nodeTLS *tls.Config) (*tls.Config, error) {
+	if nodeTLS == nil {
+		return nil, fmt.Errorf("metrics mTLS enabled without node certificates: enable server.tls")
+	}
+	cfg := nodeTLS.Clone()
+	cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	return cfg, nil
+}
+
+// newAuditAlerts creates the alert detector for audited gRPC calls and the
+// rate limiter blocking users and roles whose calls trigger alerts. Rules and
+// limits are the storage defaults.
+func (d *Daemon) newAuditAlerts() (*audit.AlertDetector, *audit.RateLimiter, error) {
+	defaults := storage.DefaultConfig().Audit
+
+	alerts, err := audit.NewAlertDetector(auditAlertConfig(defaults.Alerts))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create audit alert detector: %w", err)
+	}
+	alerts.OnAlert(func(_ context.Context, alert *audit.Alert) {
+		d.log.Warn("audit alert triggered",
+			"rule", alert.RuleName,
+			"actor", alert.Entry.Actor,
+			"role", alert.Entry.RoleUsed,
+			"rate_limit", alert.TriggerRateLimit,
+		)
+	})
+
+	rateLimit := auditRateLimitConfig(defaults.RateLimit)
+	// Admins must be able to lift blocks
+	rateLimit.BypassRoles = append(rateLimit.BypassRoles, string(domain.UserRoleAdmin))
+	blocks := audit.NewRateLimiter(rateLimit)
+	alerts.OnAlert(blocks.BlockOnAlert)
+
+	return alerts, blocks, nil
+}
+
+// auditAlertConfig converts the storage alert configuration for the audit
+// alert detector.
+func auditAlertConfig(cfg storage.AlertDetectionConfig) audit.AlertConfig {
+	out := audit.AlertConfig{
+		Enabled:         cfg.Enabled,
+		WindowDuration:  cfg.WindowDuration,
+		CleanupInterval: time.Minute,
+	}
+	if !cfg.Enabled {
+		return out
+	}
+
+	// Storage rules carry no severity
+	for _, rule := range cfg.ThresholdRules {
+		out.ThresholdRules = append(out.ThresholdRules, audit.ThresholdRule{
+			Name:             rule.Name,
+			Description:      rule.Description,
+			Enabled:          rule.Enabled,
+			Action:           audit.Action(rule.Action),
+			Threshold:        rule.Threshold,
+			Window:           time.Duration(rule.WindowSeconds) * time.Second,
+			GroupBy:          rule.GroupBy,
+			Severity:         audit.AlertSeverityMedium,
+			TriggerRateLimit: rule.TriggerRateLimit,
+		})
+	}
+	for _, rule := range cfg.CELRules {
+		out.CELRules = append(out.CELRules, audit.CELRuleConfig{
+			Name:             rule.Name,
+			Description:      rule.Description,
+			Enabled:          rule.Enabled,
+			Expression:       rule.Expression,
+			Severity:         audit.AlertSeverityMedium,
+			TriggerRateLimit: rule.TriggerRateLimit,
+		})
+	}
+	return out
+}
+
+// auditRateLimitConfig converts the storage rate limit configuration.
+func auditRateLimitConfig(cfg storage.RateLimitConfig) audit.RateLimitConfig {
+	return audit.RateLimitConfig{
+		Enabled:       cfg.Enabled,
+		DefaultLimit:  cfg.DefaultLimit,
+		DefaultWindow: time.Duration(cfg.WindowSeconds) * time.Second,
+		BlockDuration: time.Duration(cfg.BlockDurationSeconds) * time.Second,
+		BypassRoles:   append([]string(nil), cfg.BypassRoles...),
+	}
+}
+
 // stopGRPCServer shuts down the gRPC server gracefully.
 func (d *Daemon) stopGRPCServer(ctx context.Context) error {
 	if d.grpcServer == nil {
@@ -945,6 +1523,7 @@ func (d *Daemon) convertStorageConfig() storage.Config {
 		Postgres: storage.PostgresConfig{
 			Managed:                    d.cfg.Database.Postgres.Managed,
 			ContainerRuntime:           d.cfg.Database.Postgres.ContainerRuntime,
+			RuntimeFallback:            d.cfg.Database.Postgres.RuntimeFallback,
 			SocketPath:                 d.cfg.Database.Postgres.SocketPath,
 			KubeconfigPath:             d.cfg.Database.Postgres.KubeconfigPath,
 			Image:                      d.cfg.Database.Postgres.Image,
@@ -970,6 +1549,7 @@ func (d *Daemon) convertStorageConfig() storage.Config {
 				Action:         d.cfg.Database.Postgres.Health.Action,
 				MaxRetries:     d.cfg.Database.Postgres.Health.MaxRetries,
 				RetryBackoff:   d.cfg.Database.Postgres.Health.RetryBackoff,
+				Standby:        d.cfg.Database.Postgres.Health.Standby,
 			},
 			TLS: storage.TLSConfig{
 				Enabled:      d.cfg.Database.Postgres.TLS.Enabled,
@@ -984,6 +1564,8 @@ func (d *Daemon) convertStorageConfig() storage.Config {
 		},
 	}
 
+	cfg.Postgres.Kubernetes.DeleteOnCleanup = d.cfg.Database.Postgres.DeleteOnCleanup
+
 	// Handle advanced postgres config if present
 	if d.cfg.Database.Postgres.Advanced != nil {
 		cfg.Postgres.Advanced = &storage.AdvancedPostgresConfig{
@@ -1007,6 +1589,12 @@ func (d *Daemon) convertToLifecycleConfig(pgCfg storage.PostgresConfig) pglifecy
 	if pgCfg.ContainerRuntime != "" {
 		lifecycleCfg.Runtime = pglifecycle.RuntimeType(pgCfg.ContainerRuntime)
 	}
+	if pgCfg.RuntimeFallback != nil {
+		lifecycleCfg.RuntimeFallback = nil
+		for _, rt := range pgCfg.RuntimeFallback {
+			lifecycleCfg.RuntimeFallback = append(lifecycleCfg.RuntimeFallback, pglifecycle.RuntimeType(rt))
+		}
+	}
 	if pgCfg.SocketPath != "" {
 		lifecycleCfg.SocketPath = pgCfg.SocketPath
 	}
@@ -1023,6 +1611,8 @@ func (d *Daemon) convertToLifecycleConfig(pgCfg storage.PostgresConfig) pglifecy
 		lifecycleCfg.Port = pgCfg.Port
 	}
 
+	lifecycleCfg.Kubernetes.DeleteOnCleanup = pgCfg.Kubernetes.DeleteOnCleanup
+
 	// Network configuration
 	lifecycleCfg.Network = pglifecycle.NetworkConfig{
 		UseBridgeNetwork:  pgCfg.Network.UseBridgeNetwork,
@@ -1056,6 +1646,7 @@ func (d *Daemon) convertToLifecycleConfig(pgCfg storage.PostgresConfig) pglifecy
 	if pgCfg.Health.RetryBackoff > 0 {
 		lifecycleCfg.Health.RetryBackoff = pgCfg.Health.RetryBackoff
 	}
+	lifecycleCfg.Health.Standby = pgCfg.Health.Standby
 
 	// TLS configuration
 	lifecycleCfg.TLS = pglifecycle.TLSConfig{
@@ -1075,21 +1666,41 @@ func (d *Daemon) convertToLifecycleConfig(pgCfg storage.PostgresConfig) pglifecy
 	return lifecycleCfg
 }
 
-// writePIDFile writes the daemon's PID to a file.
+// errAlreadyRunning is returned by writePIDFile if another daemon runs.
+var errAlreadyRunning = errors.New("bibd is already running")
+
+// writePIDFile guards against a second daemon, then writes the PID file.
+// It fails with errAlreadyRunning if another daemon holds the data
+// directory. The lock decides: the PID file is only advisory, so one left
+// by a daemon that did not stop cleanly is replaced, even if its PID has
+// since been reused by an unrelated process.
 func (d *Daemon) writePIDFile() error {
+	release, err := acquireInstanceLock(d.cfg.Server.DataDir)
+	if err != nil {
+		return err
+	}
+
 	if d.cfg.Server.PIDFile == "" {
+		d.releaseInstance = release
 		return nil
 	}
 
-	// Expand the path
-	pidFile := d.cfg.Server.PIDFile
-	if pidFile[0] == '~' {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return fmt.Errorf("failed to expand home dir: %w", err)
+	pidFile, err := d.pidFilePath()
+	if err != nil {
+		release()
+		return fmt.Errorf("failed to expand home dir: %w", err)
+	}
+
+	if pid, err := readPIDFile(pidFile); err == nil && pid != os.Getpid() {
+		if processAlive(pid) {
+			// Not a daemon of this data directory, as we hold its lock
+			d.log.Warn("replacing PID file naming a running process that does not hold the data directory; if it is a bibd, it uses the same pid_file for another data directory",
+				"path", pidFile, "pid", pid)
+		} else {
+			d.log.Info("replacing stale PID file", "path", pidFile, "pid", pid)
 		}
-		pidFile = filepath.Join(home, pidFile[1:])
 	}
+	d.releaseInstance = release
 
 	// Create directory if needed
 	if err := os.MkdirAll(filepath.Dir(pidFile), 0755); err != nil {
@@ -1106,26 +1717,56 @@ func (d *Daemon) writePIDFile() error {
 	return nil
 }
 
-// removePIDFile removes the PID file.
+// removePIDFile removes the PID file and releases the single-instance
+// guard.
 func (d *Daemon) removePIDFile() error {
+	if d.releaseInstance != nil {
+		defer func() {
+			d.releaseInstance()
+			d.releaseInstance = nil
+		}()
+	}
+
 	if d.cfg.Server.PIDFile == "" {
 		return nil
 	}
 
+	pidFile, err := d.pidFilePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(pidFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// pidFilePath returns the path of the PID file, with ~ expanded.
+func (d *Daemon) pidFilePath() (string, error) {
 	pidFile := d.cfg.Server.PIDFile
 	if pidFile[0] == '~' {
 		home, err := os.UserHomeDir()
 		if err != nil {
-			return err
+			return "", err
 		}
 		pidFile = filepath.Join(home, pidFile[1:])
 	}
+	return pidFile, nil
+}
 
-	if err := os.Remove(pidFile); err != nil && !os.IsNotExist(err) {
-		return err
+// readPIDFile reads the PID in a PID file.
+func readPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
 	}
-
-	return nil
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid PID file %s", path)
+	}
+	return pid, nil
 }
 
 // Store returns the storage instance for use by other components.
@@ -1186,6 +1827,66 @@ func (d *Daemon) ListenAddresses() []string {
 	return []string{addr}
 }
 
+// CertificateExpiry returns when the node's CA and server certificates
+// expire, for the certificate metrics.
+func (d *Daemon) CertificateExpiry() map[string]time.Time {
+	if d.certMgr == nil {
+		return nil
+	}
+	expiry := make(map[string]time.Time, 2)
+	for name, pem := range map[string][]byte{"ca": d.certMgr.CACert(), "server": d.certMgr.ServerCert()} {
+		if cert, err := certs.ParseCertificate(pem); err == nil {
+			expiry[name] = cert.ExpiresAt
+		}
+	}
+	return expiry
+}
+
+// SyncStats returns the full replica sync metrics, or false if the node is
+// not a full replica.
+func (d *Daemon) SyncStats() (p2p.SyncStats, bool) {
+	if d.p2pMode == nil {
+		return p2p.SyncStats{}, false
+	}
+	return d.p2pMode.SyncStats()
+}
+
+// StorageRuntime returns the runtime managed PostgreSQL was started with,
+// or "" if the database is not managed.
+func (d *Daemon) StorageRuntime() string {
+	if d.pgLifecycle == nil {
+		return ""
+	}
+	return string(d.pgLifecycle.Runtime())
+}
+
+// StorageFailover returns the last failover of managed PostgreSQL, or false
+// if it never failed over.
+func (d *Daemon) StorageFailover() (interfaces.FailoverStatus, bool) {
+	if d.pgLifecycle == nil {
+		return interfaces.FailoverStatus{}, false
+	}
+	failovers := d.pgLifecycle.Failovers()
+	if len(failovers) == 0 {
+		return interfaces.FailoverStatus{}, false
+	}
+
+	last := failovers[len(failovers)-1]
+	return interfaces.FailoverStatus{
+		At:    last.At,
+		From:  last.From,
+		To:    last.To,
+		Error: last.Error,
+		Count: len(failovers),
+	}, true
+}
+
+// MaintenanceState reports whether the background maintenance workers are
+// paused.
+func (d *Daemon) MaintenanceState() maintenance.State {
+	return d.maintenance.State()
+}
+
 // HealthConfig returns configuration relevant to health reporting.
 func (d *Daemon) HealthConfig() interfaces.HealthProviderConfig {
 	return interfaces.HealthProviderConfig{
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// newPIDTestDaemon returns a test daemon with its data directory and PID
+// file in a temp dir.
+func newPIDTestDaemon(t *testing.T) *Daemon {
+	t.Helper()
+
+	d := newTestDaemon(t)
+	dir := t.TempDir()
+	d.cfg.Server.DataDir = dir
+	d.cfg.Server.PIDFile = filepath.Join(dir, "run", "bibd.pid")
+	return d
+}
+
+// exitedPID returns the PID of a process that has exited.
+func exitedPID(t *testing.T) int {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to run a process: %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+func TestWritePIDFile(t *testing.T) {
+	// The instance lock decides whether a daemon runs, so any PID file
+	// found while holding it is replaced
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no PID file", ""},
+		{"own PID", strconv.Itoa(os.Getpid())},
+		{"live process", strconv.Itoa(os.Getppid())}, // PID reused after a crash
+		{"exited process", strconv.Itoa(exitedPID(t))},
+		{"unparsable", "not a pid\n"},
+		{"negative", "-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newPIDTestDaemon(t)
+			pidFile := d.cfg.Server.PIDFile
+			if tt.content != "" {
+				if err := os.MkdirAll(filepath.Dir(pidFile), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(pidFile, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := d.writePIDFile(); err != nil {
+				t.Fatalf("writePIDFile() error = %v", err)
+			}
+			defer d.removePIDFile()
+
+			pid, err := readPIDFile(pidFile)
+			if err != nil || pid != os.Getpid() {
+				t.Errorf("PID file = %d, %v; want %d", pid, err, os.Getpid())
+			}
+		})
+	}
+}
+
+func TestWritePIDFile_InstanceLock(t *testing.T) {
+	first := newPIDTestDaemon(t)
+	if err := first.writePIDFile(); err != nil {
+		t.Fatalf("writePIDFile() error = %v", err)
+	}
+
+	// A second daemon on the same data directory is refused, even with its
+	// own PID file
+	second := newTestDaemon(t)
+	second.cfg.Server.DataDir = first.cfg.Server.DataDir
+	second.cfg.Server.PIDFile = filepath.Join(t.TempDir(), "bibd.pid")
+	if err := second.writePIDFile(); !errors.Is(err, errAlreadyRunning) {
+		t.Fatalf("second writePIDFile() error = %v, want %v", err, errAlreadyRunning)
+	}
+
+	// Once the first stops, the PID file is gone and the lock is free
+	if err := first.removePIDFile(); err != nil {
+		t.Fatalf("removePIDFile() error = %v", err)
+	}
+	if _, err := os.Stat(first.cfg.Server.PIDFile); !os.IsNotExist(err) {
+		t.Errorf("PID file not removed: %v", err)
+	}
+	if err := second.writePIDFile(); err != nil {
+		t.Fatalf("writePIDFile() after the first stopped: %v", err)
+	}
+	_ = second.removePIDFile()
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"bib/internal/storage"
+	"bib/internal/storage/postgres/encryption"
+)
+
+// startEncryption creates, unlocks and mounts the LUKS volume that holds
+// the database files, if encryption at rest is enabled. The volume key is
+// derived from the node identity, so after a reboot the volume is unlocked
+// again without any input. If the identity no longer unlocks it, the
+// volume has to be recovered with bib admin keys recover first.
+func (d *Daemon) startEncryption(ctx context.Context) error {
+	encCfg := d.cfg.Database.EncryptionAtRest
+	if !encCfg.Enabled {
+		return nil
+	}
+	if encryption.Method(encCfg.Method) != encryption.MethodLUKS {
+		return fmt.Errorf("unsupported encryption at rest method %q: only luks is supported", encCfg.Method)
+	}
+
+	identityKey, err := d.p2pIdentity.RawPrivateKey()
+	if err != nil {
+		return fmt.Errorf("failed to get identity key: %w", err)
+	}
+
+	mgr, err := encryption.NewManager(d.convertEncryptionConfig(), identityKey)
+	if err != nil {
+		return fmt.Errorf("failed to create encryption manager: %w", err)
+	}
+
+	if err := mgr.Initialize(ctx, d.cfg.Server.DataDir); err != nil {
+		if errors.Is(err, encryption.ErrNotSupported) {
+			return fmt.Errorf("LUKS requires Linux, cryptsetup, blkid, mkfs.ext4 and /dev/mapper: %w", err)
+		}
+		return fmt.Errorf("failed to initialize encrypted volume: %w", err)
+	}
+
+	// A new volume without its recovery material would be lost with the
+	// identity, so it is removed again and bibd does not start
+	if mgr.VolumeCreated() {
+		if err := d.escrowVolumeKey(mgr); err != nil {
+			if rmErr := mgr.RemoveCreatedVolume(); rmErr != nil {
+				return fmt.Errorf("%w; removing the new encrypted volume failed too: %v", err, rmErr)
+			}
+			return fmt.Errorf("%w; removed the new encrypted volume", err)
+		}
+	}
+
+	err = mgr.Mount(ctx)
+	if errors.Is(err, encryption.ErrInvalidKey) {
+		return fmt.Errorf("%w; stop bibd and unlock it with bib admin keys recover, using the recovery shares or the escrow", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to mount encrypted volume: %w", err)
+	}
+
+	d.encryption = mgr
+	d.log.Info("encrypted volume mounted", "mount_point", mgr.MountPoint())
+
+	return nil
+}
+
+// stopEncryption unmounts and closes the encrypted volume.
+func (d *Daemon) stopEncryption() error {
+	if d.encryption == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := d.encryption.Close(ctx); err != nil {
+		d.log.Error("error unmounting encrypted volume", "error", err)
+		return fmt.Errorf("unmount encrypted volume: %w", err)
+	}
+	d.encryption = nil
+
+	d.log.Info("encrypted volume unmounted")
+	return nil
+}
+
+// escrowVolumeKey writes the recovery shares of a new volume, and the
+// escrow if an escrow recipient is configured. If any of them can't be
+// written, the shares written are removed and an error is returned.
+func (d *Daemon) escrowVolumeKey(mgr *encryption.Manager) error {
+	recovery := d.cfg.Database.EncryptionAtRest.Recovery
+
+	sharesDir := d.cfg.RecoverySharesDir()
+	shares, err := mgr.GenerateRecoveryShares()
+	if err != nil {
+		return fmt.Errorf("failed to generate recovery shares: %w", err)
+	}
+	if err := encryption.WriteShares(sharesDir, shares); err != nil {
+		return fmt.Errorf("failed to write recovery shares to %s: %w", sharesDir, err)
+	}
+
+	if recovery.EscrowRecipient != "" {
+		if err := d.writeEscrow(mgr); err != nil {
+			if rmErr := encryption.RemoveShares(sharesDir, shares); rmErr != nil {
+				d.log.Error("failed to remove the recovery shares of the new volume", "error", rmErr, "dir", sharesDir)
+			}
+			return err
+		}
+	}
+
+	d.log.Warn("encrypted volume created; move the recovery shares off this node",
+		"dir", sharesDir,
+		"shares", len(shares),
+		"threshold", recovery.Threshold,
+	)
+	return nil
+}
+
+// writeEscrow seals the master key to the configured escrow recipient and
+// writes the escrow.
+func (d *Daemon) writeEscrow(mgr *encryption.Manager) error {
+	escrowPath := d.cfg.RecoveryEscrowPath()
+	recipient, err := base64.StdEncoding.DecodeString(d.cfg.Database.EncryptionAtRest.Recovery.EscrowRecipient)
+	if err != nil {
+		return fmt.Errorf("invalid escrow recipient: %w", err)
+	}
+	escrow, err := mgr.Escrow(recipient)
+	if err != nil {
+		return fmt.Errorf("failed to seal escrow: %w", err)
+	}
+	if err := encryption.WriteEscrow(escrowPath, escrow); err != nil {
+		return fmt.Errorf("failed to write escrow to %s: %w", escrowPath, err)
+	}
+
+	d.log.Warn("encryption key escrowed; store the escrow apart from the recovery shares", "path", escrowPath)
+	return nil
+}
+
+// storageDataDir returns the directory the storage backend keeps its files
+// in: the mounted encrypted volume, or the data directory.
+func (d *Daemon) storageDataDir() string {
+	if d.encryption != nil {
+		return d.encryption.MountPoint()
+	}
+	return d.cfg.Server.DataDir
+}
+
+// sqliteFileName is the name of the SQLite database in the storage data
+// directory, unless database.sqlite.path is set.
+const sqliteFileName = "cache.db"
+
+// prepareSQLite keeps the SQLite database at path (empty for the default)
+// in the encrypted volume. A database left in the data directory from
+// before encryption was enabled is moved onto the volume.
+func (d *Daemon) prepareSQLite(path string) error {
+	if d.encryption == nil || path != "" {
+		return nil
+	}
+
+	src := filepath.Join(d.cfg.Server.DataDir, sqliteFileName)
+	dst := filepath.Join(d.encryption.MountPoint(), sqliteFileName)
+
+	moved, err := moveSQLiteDatabase(src, dst)
+	if err != nil {
+		return fmt.Errorf("failed to move the SQLite database into the encrypted volume: %w", err)
+	}
+	if moved {
+		d.log.Warn("moved the unencrypted SQLite database into the encrypted volume; its old blocks may remain on disk until overwritten",
+			"from", src, "to", dst)
+	} else if _, err := os.Stat(src); err == nil {
+		d.log.Warn("an unencrypted SQLite database remains in the data directory; remove it once the encrypted one is in use",
+			"path", src)
+	}
+	return nil
+}
+
+// sqliteKey returns the SQLCipher key of the SQLite database, or nil if
+// database.sqlite.encryption is disabled.
+func (d *Daemon) sqliteKey() (*storage.SQLiteKey, error) {
+	encCfg := d.cfg.Database.SQLite.Encryption
+	if !encCfg.Enabled {
+		return nil, nil
+	}
+
+	switch encCfg.KeySource {
+	case "", "identity":
+		identityKey, err := d.p2pIdentity.RawPrivateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get identity key: %w", err)
+		}
+		key, err := encryption.DeriveSQLiteKey(identityKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive the SQLite key: %w", err)
+		}
+		return &storage.SQLiteKey{Raw: key}, nil
+	case "passphrase":
+		if encCfg.Passphrase == "" {
+			return nil, errors.New("database.sqlite.encryption.key_source passphrase requires database.sqlite.encryption.passphrase")
+		}
+		return &storage.SQLiteKey{Passphrase: encCfg.Passphrase}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database.sqlite.encryption.key_source %q: use identity or passphrase", encCfg.KeySource)
+	}
+}
+
+// moveSQLiteDatabase moves the SQLite database at src, with its write-ahead
+// log and rollback journal, to dst on another filesystem. It returns false
+// if there is nothing to move, or dst already exists; an existing database
+// is never overwritten. The files are copied and synced before the
+// originals are removed, and the database itself is renamed into place
+// last, so an interrupted move leaves src in use.
+func moveSQLiteDatabase(src, dst string) (bool, error) {
+	if _, err := os.Stat(src); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	var moved []string
+	for _, suffix := range []string{"-wal", "-journal", ""} {
+		if _, err := os.Stat(src + suffix); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := copyFileSynced(src+suffix, dst+suffix); err != nil {
+			for _, suffix := range moved {
+				os.Remove(dst + suffix)
+			}
+			return false, err
+		}
+		moved = append(moved, suffix)
+	}
+
+	for _, suffix := range append(moved, "-shm") {
+		if err := os.Remove(src + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return true, fmt.Errorf("moved, but failed to remove %s: %w", src+suffix, err)
+		}
+	}
+	return true, nil
+}
+
+// copyFileSynced copies src to dst through a temporary file that is synced
+// and renamed into place, so dst is either complete or absent.
+func copyFileSynced(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
+
+// convertEncryptionConfig converts the bibd config to encryption config.
+func (d *Daemon) convertEncryptionConfig() encryption.Config {
+	encCfg := d.cfg.Database.EncryptionAtRest
+
+	cfg := encryption.DefaultConfig()
+	cfg.Enabled = encCfg.Enabled
+	cfg.Method = encryption.Method(encCfg.Method)
+	cfg.LUKS = encryption.LUKSConfig{
+		VolumeSize:    encCfg.LUKS.VolumeSize,
+		Cipher:        encCfg.LUKS.Cipher,
+		KeySize:       encCfg.LUKS.KeySize,
+		HashAlgorithm: encCfg.LUKS.HashAlgorithm,
+	}
+	if encCfg.Recovery.TotalShares > 0 {
+		cfg.Recovery.Shamir.TotalShares = encCfg.Recovery.TotalShares
+	}
+	if encCfg.Recovery.Threshold > 0 {
+		cfg.Recovery.Shamir.Threshold = encCfg.Recovery.Threshold
+	}
+
+	return cfg
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bib/internal/config"
+	"bib/internal/p2p"
+	"bib/internal/storage"
+)
+
+func TestMoveSQLiteDatabase(t *testing.T) {
+	dataDir, volume := t.TempDir(), t.TempDir()
+	src := filepath.Join(dataDir, "cache.db")
+	dst := filepath.Join(volume, "cache.db")
+
+	// Nothing to move
+	if moved, err := moveSQLiteDatabase(src, dst); err != nil || moved {
+		t.Fatalf("moveSQLiteDatabase() without a database = %v, %v; want false, nil", moved, err)
+	}
+
+	files := map[string]string{"": "database", "-wal": "log", "-shm": "shared memory"}
+	for suffix, content := range files {
+		if err := os.WriteFile(src+suffix, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moved, err := moveSQLiteDatabase(src, dst)
+	if err != nil || !moved {
+		t.Fatalf("moveSQLiteDatabase() = %v, %v; want true, nil", moved, err)
+	}
+	for suffix, content := range files {
+		if _, err := os.Stat(src + suffix); !os.IsNotExist(err) {
+			t.Errorf("%s was not removed: %v", src+suffix, err)
+		}
+		if suffix == "-shm" {
+			continue // rebuilt from the log on open
+		}
+		got, err := os.ReadFile(dst + suffix)
+		if err != nil || string(got) != content {
+			t.Errorf("%s = %q, %v; want %q", dst+suffix, got, err, content)
+		}
+	}
+	if info, err := os.Stat(dst); err == nil && info.Mode().Perm()&0077 != 0 {
+		t.Errorf("moved database has mode %v, want no group or other access", info.Mode().Perm())
+	}
+
+	// An existing database is never overwritten
+	if err := os.WriteFile(src, []byte("stale"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if moved, err := moveSQLiteDatabase(src, dst); err != nil || moved {
+		t.Fatalf("moveSQLiteDatabase() onto an existing database = %v, %v; want false, nil", moved, err)
+	}
+	if got, _ := os.ReadFile(dst); string(got) != "database" {
+		t.Errorf("existing database overwritten with %q", got)
+	}
+}
+
+func TestSQLiteKey(t *testing.T) {
+	dir := t.TempDir()
+	identity, err := p2p.GenerateIdentity(filepath.Join(dir, "identity.pem"), dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		enc     config.SQLiteEncryptionConfig
+		want    func(*storage.SQLiteKey) bool
+		wantErr bool
+	}{
+		{"disabled", config.SQLiteEncryptionConfig{KeySource: "passphrase"},
+			func(k *storage.SQLiteKey) bool { return k == nil }, false},
+		{"identity", config.SQLiteEncryptionConfig{Enabled: true, KeySource: "identity"},
+			func(k *storage.SQLiteKey) bool { return len(k.Raw) == 32 && k.Passphrase == "" }, false},
+		{"passphrase", config.SQLiteEncryptionConfig{Enabled: true, KeySource: "passphrase", Passphrase: "correct horse"},
+			func(k *storage.SQLiteKey) bool { return k.Passphrase == "correct horse" && k.Raw == nil }, false},
+		{"passphrase missing", config.SQLiteEncryptionConfig{Enabled: true, KeySource: "passphrase"}, nil, true},
+		{"unknown source", config.SQLiteEncryptionConfig{Enabled: true, KeySource: "tpm"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultBibdConfig()
+			cfg.Database.SQLite.Encryption = tt.enc
+			d := &Daemon{cfg: &cfg, p2pIdentity: identity}
+
+			key, err := d.sqliteKey()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sqliteKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !tt.want(key) {
+				t.Errorf("sqliteKey() = %+v", key)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+)
+
+// checkFilesystems checks that the data directory and databases are not on
+// a network filesystem such as NFS or SMB, where file locking is unreliable
+// and SQLite, PostgreSQL and the P2P peer store report "database is locked"
+// or corrupt their files. With server.filesystem_check set to strict, such
+// paths stop the daemon from starting.
+func checkFilesystems(cfg *config.BibdConfig, configDir string, log *logger.Logger) error {
+	if cfg.Server.FilesystemCheck == config.FilesystemCheckOff {
+		return nil
+	}
+
+	issues := config.CheckFilesystems(cfg.DatabasePaths(configDir))
+	if len(issues) == 0 {
+		return nil
+	}
+
+	for _, issue := range issues {
+		log.Warn("database path is on a network filesystem",
+			"path", issue.Path,
+			"filesystem", issue.Type,
+		)
+	}
+
+	const hint = "move the paths to a local disk (server.data_dir, database.sqlite.path, database.postgres.data_dir, p2p.peer_store.path); to share data between machines, use PostgreSQL or P2P replication instead of a shared mount"
+	if cfg.Server.FilesystemCheck == config.FilesystemCheckStrict {
+		return fmt.Errorf("%d database paths are on a network filesystem, where file locking is unreliable; %s", len(issues), hint)
+	}
+	log.Warn("file locking is unreliable on network filesystems and may corrupt the databases; "+hint, "paths", len(issues))
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build !windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// instanceLockFile is the file in the data directory a running daemon
+// holds an exclusive lock on.
+const instanceLockFile = "bibd.lock"
+
+// acquireInstanceLock takes an exclusive lock on the data directory, so a
+// second daemon on the same directory refuses to start. The lock is
+// released by the returned func, or by the kernel when the process exits.
+func acquireInstanceLock(dataDir string) (func(), error) {
+	path := filepath.Join(dataDir, instanceLockFile)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open instance lock: %w", err)
+	}
+
+	if err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%w: data directory %s is locked by another bibd (%s)", errAlreadyRunning, dataDir, path)
+		}
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return func() {
+		_ = unix.Flock(int(f.Fd()), unix.LOCK_UN)
+		f.Close()
+	}, nil
+}
+
+// processAlive reports whether a process with pid exists. A process of
+// another user counts as alive.
+func processAlive(pid int) bool {
+	err := unix.Kill(pid, 0)
+	return err == nil || errors.Is(err, unix.EPERM)
+}
@@ -0,0 +1,67 @@
+//go:build windows
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// stillActive is the exit code of a process that has not exited.
+const stillActive = 259
+
+// acquireInstanceLock creates a named mutex for the data directory, so a
+// second daemon on the same directory refuses to start. The mutex is
+// global, covering services and interactive sessions alike, and released
+// by the returned func or when the process exits.
+func acquireInstanceLock(dataDir string) (func(), error) {
+	name, err := windows.UTF16PtrFromString(instanceMutexName(dataDir))
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := windows.CreateMutex(nil, false, name)
+	if errors.Is(err, windows.ERROR_ALREADY_EXISTS) {
+		windows.CloseHandle(handle)
+		return nil, fmt.Errorf("%w: data directory %s is in use by another bibd", errAlreadyRunning, dataDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create instance mutex: %w", err)
+	}
+
+	return func() { windows.CloseHandle(handle) }, nil
+}
+
+// instanceMutexName names the mutex of a data directory. Paths are case
+// insensitive on Windows and may be longer than a mutex name, so the name
+// holds a hash of the cleaned, lower-cased path.
+func instanceMutexName(dataDir string) string {
+	path, err := filepath.Abs(dataDir)
+	if err != nil {
+		path = dataDir
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(filepath.Clean(path))))
+	return `Global\bibd-` + hex.EncodeToString(sum[:16])
+}
+
+// processAlive reports whether a process with pid is running. A process
+// bibd may not open counts as running.
+func processAlive(pid int) bool {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return errors.Is(err, windows.ERROR_ACCESS_DENIED)
+	}
+	defer windows.CloseHandle(handle)
+
+	var code uint32
+	if err := windows.GetExitCodeProcess(handle, &code); err != nil {
+		return true
+	}
+	return code == stillActive
+}
@@ -8,7 +8,6 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"bib/internal/config"
 	"bib/internal/logger"
@@ -16,13 +15,17 @@ import (
 )
 
 var (
-	cfgFile     string
-	showVersion bool
+	cfgFile        string
+	workDir        string
+	showVersion    bool
+	fixPermissions bool
 )
 
 func init() {
 	flag.StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/bibd/config.yaml)")
+	flag.StringVar(&workDir, "workdir", "", "working directory (used by the Windows service, which starts in the system directory)")
 	flag.BoolVar(&showVersion, "version", false, "show version")
+	flag.BoolVar(&fixPermissions, "fix-permissions", false, "tighten permissions of credentials, keys and database files to owner-only, then start")
 }
 
 func main() {
@@ -35,6 +38,18 @@ func main() {
 		os.Exit(0)
 	}
 
+	if workDir != "" {
+		if err := os.Chdir(workDir); err != nil {
+			stdlog.Fatalf("Failed to change to working directory %q: %v", workDir, err)
+		}
+	}
+
+	// Set up shutdown signals first so a Windows service stop request
+	// arriving during startup is not lost
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	serviceStopped := startServiceHandler("bibd", sigChan)
+
 	// Auto-generate config on first run
 	if cfgFile == "" {
 		path, created, err := config.GenerateConfigIfNotExists(config.AppBibd, "yaml")
@@ -59,12 +74,14 @@ func main() {
 	// Expand data directory
 	dataDir := expandPath(cfg.Server.DataDir)
 	cfg.Server.DataDir = dataDir
+	cfg.Server.GRPC.UnixSocket = expandPath(cfg.Server.GRPC.UnixSocket)
 
 	// Debug: log what we're trying to create
 	stdlog.Printf("Creating data directory: %q", dataDir)
 
-	// Create data directory if it doesn't exist
-	if err := os.MkdirAll(dataDir, 0755); err != nil {
+	// Create data directory if it doesn't exist. It holds credentials,
+	// keys and the database, so only the daemon's user may access it.
+	if err := os.MkdirAll(dataDir, config.SensitiveDirMode); err != nil {
 		stdlog.Fatalf("Failed to create data directory %q: %v", dataDir, err)
 	}
 
@@ -75,10 +92,20 @@ func main() {
 	}
 	defer func() { _ = log.Close() }()
 
+	if err := checkPermissions(cfg, configDir, log); err != nil {
+		log.Error("permission check failed", "error", err)
+		os.Exit(1)
+	}
+
+	if err := checkFilesystems(cfg, configDir, log); err != nil {
+		log.Error("filesystem check failed", "error", err)
+		os.Exit(1)
+	}
+
 	// Initialize audit logger if configured
 	var auditLog *logger.AuditLogger
 	if cfg.Log.AuditPath != "" {
-		auditLog, err = logger.NewAuditLogger(cfg.Log.AuditPath, cfg.Log.AuditMaxAgeDays)
+		auditLog, err = logger.NewAuditLoggerFromConfig(cfg.Log)
 		if err != nil {
 			log.Warn("failed to initialize audit logger", "error", err)
 		} else {
@@ -147,6 +174,10 @@ func main() {
 
 	// Create and start daemon
 	daemon := NewDaemon(cfg, configDir, log, auditLog)
+	daemon.configFile = cfgFile
+	if daemon.configFile == "" {
+		daemon.configFile = config.ConfigFileUsed(config.AppBibd)
+	}
 
 	if err := daemon.Start(ctx); err != nil {
 		log.Error("failed to start daemon", "error", err)
@@ -165,10 +196,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Setup graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
 	// Wait for shutdown signal
 	sig := <-sigChan
 	log.Info("received shutdown signal",
@@ -176,8 +203,8 @@ func main() {
 		"request_id", cc.RequestID,
 	)
 
-	// Create shutdown context with timeout
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Create shutdown context with timeout, shared out among the shutdown phases
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 
 	// Stop daemon
@@ -200,6 +227,7 @@ func main() {
 	}
 
 	log.Info("bibd stopped", "request_id", cc.RequestID)
+	serviceStopped()
 }
 
 // expandPath expands ~ to the user's home directory.
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+)
+
+// checkPermissions checks that only the daemon's user may access its
+// credentials, keys and database files, tightening them with
+// --fix-permissions. With server.permission_check set to strict, paths
+// that are too open stop the daemon from starting.
+func checkPermissions(cfg *config.BibdConfig, configDir string, log *logger.Logger) error {
+	if cfg.Server.PermissionCheck == config.PermissionCheckOff && !fixPermissions {
+		return nil
+	}
+
+	issues := config.CheckPermissions(cfg.SensitivePaths(configDir))
+	if len(issues) == 0 {
+		return nil
+	}
+
+	if fixPermissions {
+		for _, issue := range issues {
+			log.Info("tightening permissions",
+				"path", issue.Path,
+				"mode", fmt.Sprintf("%04o", issue.Mode),
+				"new_mode", fmt.Sprintf("%04o", issue.Want),
+			)
+		}
+		if err := config.FixPermissions(issues); err != nil {
+			return fmt.Errorf("failed to fix permissions: %w", err)
+		}
+		return nil
+	}
+
+	for _, issue := range issues {
+		log.Warn("sensitive path is accessible by other users",
+			"path", issue.Path,
+			"mode", fmt.Sprintf("%04o", issue.Mode),
+			"want", fmt.Sprintf("%04o", issue.Want),
+		)
+	}
+
+	if cfg.Server.PermissionCheck == config.PermissionCheckStrict {
+		return fmt.Errorf("%d sensitive paths are accessible by other users; run bibd --fix-permissions to tighten them", len(issues))
+	}
+	log.Warn("run bibd --fix-permissions to tighten permissions", "paths", len(issues))
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package main
+
+import "os"
+
+// startServiceHandler is a no-op outside Windows, where service managers
+// stop bibd with signals.
+func startServiceHandler(name string, stop chan<- os.Signal) func() {
+	return func() {}
+}
@@ -0,0 +1,66 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+
+	"golang.org/x/sys/windows/svc"
+)
+
+// serviceHandler reports bibd's state to the Windows service control
+// manager and turns stop and shutdown requests into SIGTERM.
+type serviceHandler struct {
+	stop chan<- os.Signal
+	done <-chan struct{}
+}
+
+// Execute implements svc.Handler.
+func (h *serviceHandler) Execute(args []string, r <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	const accepted = svc.AcceptStop | svc.AcceptShutdown
+
+	changes <- svc.Status{State: svc.StartPending}
+	changes <- svc.Status{State: svc.Running, Accepts: accepted}
+
+	for {
+		select {
+		case <-h.done:
+			// bibd stopped on its own, e.g. it failed to start
+			return false, 0
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Interrogate:
+				changes <- c.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				h.stop <- syscall.SIGTERM
+				<-h.done
+				return false, 0
+			}
+		}
+	}
+}
+
+// startServiceHandler connects to the service control manager when bibd
+// runs as a Windows service, so the SCM sees it as running and can stop
+// it. Stop requests are delivered on stop. The returned function must be
+// called once the daemon has stopped.
+func startServiceHandler(name string, stop chan<- os.Signal) func() {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		_ = svc.Run(name, &serviceHandler{stop: stop, done: done})
+	}()
+
+	return func() {
+		close(done)
+		<-exited
+	}
+}
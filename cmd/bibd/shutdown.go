@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// shutdownWeights is the share of the shutdown budget of each phase,
+// relative to the other phases still to run. Storage gets the largest
+// share, so PostgreSQL can checkpoint even if draining calls was slow.
+var shutdownWeights = map[string]int{
+	"ssh":          2,
+	"grpc":         6,
+	"cluster":      2,
+	"p2p":          2,
+	"storage":      8,
+	"certificates": 1,
+	"pid_file":     1,
+}
+
+// minShutdownPhase is the least time a phase gets while the deadline
+// allows, so a phase of small weight is not cut off after a few
+// milliseconds. A variable so tests can shorten it.
+var minShutdownPhase = time.Second
+
+// shutdownPhase is a step of Stop.
+type shutdownPhase struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// shutdownPhases returns the phases of Stop: the components in the reverse
+// of the order they started in, then the PID file.
+func (d *Daemon) shutdownPhases() []shutdownPhase {
+	stops := map[string]func(ctx context.Context) error{
+		"ssh":          d.stopSSHServer,
+		"grpc":         d.stopGRPCServer,
+		"cluster":      func(context.Context) error { return d.stopCluster() },
+		"p2p":          func(context.Context) error { return d.stopP2P() },
+		"storage":      d.stopStorageContext,
+		"certificates": func(context.Context) error { return d.stopCertificates() },
+	}
+
+	var phases []shutdownPhase
+	for i := len(d.started) - 1; i >= 0; i-- {
+		name := d.started[i]
+		phases = append(phases, shutdownPhase{name: name, stop: stops[name]})
+	}
+
+	return append(phases, shutdownPhase{name: "pid_file", stop: func(context.Context) error {
+		if err := d.removePIDFile(); err != nil {
+			d.log.Warn("failed to remove PID file", "error", err)
+		}
+		return nil
+	}})
+}
+
+// runShutdownPhases runs the phases in order, each within its share of the
+// time left until the deadline of ctx, or server.shutdown_timeout if it has
+// none. Time a phase does not use goes to the later ones; a phase that
+// overruns its share is abandoned, so it cannot starve the later ones, and
+// no phase runs past the deadline: once it has passed, the phases left are
+// skipped. It returns the errors of the phases, prefixed with their names.
+func (d *Daemon) runShutdownPhases(ctx context.Context, phases []shutdownPhase) []error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(d.cfg.Server.ShutdownTimeout)
+	}
+
+	// Phases run past a cancellation of ctx, but not past the deadline
+	shutdownCtx, cancel := context.WithDeadline(context.WithoutCancel(ctx), deadline)
+	defer cancel()
+
+	remainingWeight := 0
+	for _, phase := range phases {
+		remainingWeight += shutdownWeights[phase.name]
+	}
+
+	var errs []error
+	for _, phase := range phases {
+		weight := shutdownWeights[phase.name]
+		budget := phaseBudget(time.Until(deadline), weight, remainingWeight)
+		remainingWeight -= weight
+
+		if budget <= 0 {
+			d.log.Warn("shutdown phase skipped, deadline passed", "phase", phase.name)
+			errs = append(errs, fmt.Errorf("%s: skipped, shutdown deadline passed", phase.name))
+			continue
+		}
+
+		started := time.Now()
+		err := runShutdownPhase(shutdownCtx, budget, phase.stop)
+		took := time.Since(started).Round(time.Millisecond)
+
+		if err != nil {
+			d.log.Warn("shutdown phase failed", "phase", phase.name, "took", took, "budget", budget, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", phase.name, err))
+			continue
+		}
+		d.log.Info("shutdown phase done", "phase", phase.name, "took", took, "budget", budget)
+	}
+	return errs
+}
+
+// phaseBudget returns the share of the time left of a phase of weight,
+// with remainingWeight the weight of it and all later phases. It is at
+// least minShutdownPhase, but never more than left, and 0 once the
+// deadline has passed.
+func phaseBudget(left time.Duration, weight, remainingWeight int) time.Duration {
+	if left <= 0 {
+		return 0
+	}
+	if remainingWeight <= 0 {
+		return left
+	}
+	return min(max(left*time.Duration(weight)/time.Duration(remainingWeight), minShutdownPhase), left)
+}
+
+// runShutdownPhase runs stop with a context ending after budget, or with
+// ctx if that is earlier, and returns once it is done or the budget is used
+// up. A phase that overruns keeps running in the background while the next
+// phase starts.
+func runShutdownPhase(ctx context.Context, budget time.Duration, stop func(ctx context.Context) error) error {
+	phaseCtx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- stop(phaseCtx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-phaseCtx.Done():
+		return fmt.Errorf("did not finish within %s", budget)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+)
+
+// newTestDaemon returns a daemon with the default config and a quiet
+// logger, not started.
+func newTestDaemon(t *testing.T) *Daemon {
+	t.Helper()
+
+	log, err := logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stderr"})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	cfg := config.DefaultBibdConfig()
+	return &Daemon{cfg: &cfg, log: log}
+}
+
+// shortShutdownPhases shortens minShutdownPhase for the test.
+func shortShutdownPhases(t *testing.T) {
+	saved := minShutdownPhase
+	minShutdownPhase = 10 * time.Millisecond
+	t.Cleanup(func() { minShutdownPhase = saved })
+}
+
+// phaseRecorder records, per phase, the deadline it was given and whether
+// it ran.
+type phaseRecorder struct {
+	mu        sync.Mutex
+	deadlines map[string]time.Time
+}
+
+// phase returns a phase of name that records its deadline, then waits for
+// work or until its context ends, whichever is first.
+func (r *phaseRecorder) phase(name string, work time.Duration) shutdownPhase {
+	return shutdownPhase{name: name, stop: func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		r.mu.Lock()
+		r.deadlines[name] = deadline
+		r.mu.Unlock()
+
+		select {
+		case <-time.After(work):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}}
+}
+
+func (r *phaseRecorder) deadline(name string) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deadline, ok := r.deadlines[name]
+	return deadline, ok
+}
+
+func TestRunShutdownPhases_SlowPhase(t *testing.T) {
+	shortShutdownPhases(t)
+	d := newTestDaemon(t)
+	rec := &phaseRecorder{deadlines: make(map[string]time.Time)}
+
+	// gRPC draining hangs; the phases after it must still run, within the
+	// one shutdown deadline
+	const timeout = 440 * time.Millisecond
+	phases := []shutdownPhase{
+		rec.phase("grpc", time.Hour),
+		rec.phase("p2p", 0),
+		rec.phase("storage", 0),
+		rec.phase("pid_file", 0),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	deadline, _ := ctx.Deadline()
+
+	started := time.Now()
+	errs := d.runShutdownPhases(ctx, phases)
+	took := time.Since(started)
+
+	if took > timeout+50*time.Millisecond {
+		t.Errorf("shutdown took %v, want at most the timeout %v", took, timeout)
+	}
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "grpc: ") {
+		t.Errorf("errors = %v, want only the gRPC phase overrunning", errs)
+	}
+
+	// gRPC got its share, 6 of 17, and was abandoned at the end of it
+	grpcDeadline, _ := rec.deadline("grpc")
+	if share := grpcDeadline.Sub(started); share < 100*time.Millisecond || share > 200*time.Millisecond {
+		t.Errorf("gRPC budget = %v, want about %v", share, timeout*6/17)
+	}
+	for _, phase := range phases {
+		got, ok := rec.deadline(phase.name)
+		if !ok {
+			t.Errorf("phase %s did not run", phase.name)
+			continue
+		}
+		if got.After(deadline) {
+			t.Errorf("phase %s deadline %v is after the shutdown deadline %v", phase.name, got, deadline)
+		}
+	}
+}
+
+func TestRunShutdownPhases_SharedDeadline(t *testing.T) {
+	shortShutdownPhases(t)
+	d := newTestDaemon(t)
+	d.cfg.Server.ShutdownTimeout = 300 * time.Millisecond
+	rec := &phaseRecorder{deadlines: make(map[string]time.Time)}
+
+	// Without a deadline on ctx, server.shutdown_timeout applies. The slow
+	// phase finishes within its share; what it used is gone for the next
+	// ones, what it left goes to them.
+	started := time.Now()
+	errs := d.runShutdownPhases(context.Background(), []shutdownPhase{
+		rec.phase("ssh", 40*time.Millisecond),
+		rec.phase("storage", 0),
+		rec.phase("pid_file", 0),
+	})
+	if len(errs) != 0 {
+		t.Fatalf("errors = %v, want none", errs)
+	}
+
+	// The deadline is taken a moment after started
+	deadline := started.Add(d.cfg.Server.ShutdownTimeout + time.Millisecond)
+	storage, _ := rec.deadline("storage")
+	pidFile, _ := rec.deadline("pid_file")
+	if storage.After(deadline) || pidFile.After(deadline) {
+		t.Errorf("phase deadlines %v and %v are after the shutdown deadline %v", storage, pidFile, deadline)
+	}
+	// storage gets 8 of the 9 weights left, of what ssh did not use
+	if left := deadline.Sub(storage); left > 60*time.Millisecond {
+		t.Errorf("storage budget ends %v before the deadline, want it to get most of what ssh left", left)
+	}
+}
+
+func TestRunShutdownPhases_DeadlinePassed(t *testing.T) {
+	d := newTestDaemon(t)
+	rec := &phaseRecorder{deadlines: make(map[string]time.Time)}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	started := time.Now()
+	errs := d.runShutdownPhases(ctx, []shutdownPhase{rec.phase("storage", time.Hour), rec.phase("pid_file", 0)})
+	if took := time.Since(started); took > 50*time.Millisecond {
+		t.Errorf("shutdown after the deadline took %v, want the phases skipped", took)
+	}
+	if len(errs) != 2 {
+		t.Errorf("errors = %v, want both phases skipped", errs)
+	}
+	if _, ok := rec.deadline("storage"); ok {
+		t.Error("phase ran after the shutdown deadline")
+	}
+}
+
+func TestPhaseBudget(t *testing.T) {
+	tests := []struct {
+		name            string
+		left            time.Duration
+		weight          int
+		remainingWeight int
+		want            time.Duration
+	}{
+		{"share", 22 * time.Second, 8, 22, 8 * time.Second},
+		{"last phase gets the rest", 5 * time.Second, 1, 1, 5 * time.Second},
+		{"at least the minimum", 10 * time.Second, 1, 22, minShutdownPhase},
+		{"minimum capped at time left", 500 * time.Millisecond, 1, 22, 500 * time.Millisecond},
+		{"deadline passed", -time.Second, 8, 22, 0},
+		{"no weight", 3 * time.Second, 0, 0, 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := phaseBudget(tt.left, tt.weight, tt.remainingWeight); got != tt.want {
+				t.Errorf("phaseBudget(%v, %d, %d) = %v, want %v", tt.left, tt.weight, tt.remainingWeight, got, tt.want)
+			}
+		})
+	}
+}
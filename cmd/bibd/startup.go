@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// fatalStartupError marks a startup error that retrying cannot fix, such as
+// an invalid configuration.
+type fatalStartupError struct {
+	err error
+}
+
+func (e *fatalStartupError) Error() string { return e.err.Error() }
+func (e *fatalStartupError) Unwrap() error { return e.err }
+
+// fatalStartup marks err as fatal, so startup fails without retrying.
+func fatalStartup(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &fatalStartupError{err: err}
+}
+
+// transientErrnos are the system errors of resources that are expected to
+// become available shortly after boot.
+var transientErrnos = []error{
+	syscall.EADDRINUSE,
+	syscall.ECONNREFUSED,
+	syscall.ECONNRESET,
+	syscall.ETIMEDOUT,
+	syscall.EAGAIN,
+}
+
+// transientMessages match errors that only carry their cause as text, such
+// as the output of the docker and podman CLIs.
+var transientMessages = []string{
+	"cannot connect to the docker daemon",
+	"is the docker daemon running",
+	"no container runtime found",
+	"cannot connect to podman",
+	"connection refused",
+	"connection reset by peer",
+	"address already in use",
+	"i/o timeout",
+	"the database system is starting up",
+	"no route to host",
+	"network is unreachable",
+}
+
+// isTransientStartupError reports whether a component that failed to start
+// with err may start if tried again: the container runtime, a port or a
+// remote service is not available yet. Everything else is fatal.
+func isTransientStartupError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var fatal *fatalStartupError
+	if errors.As(err, &fatal) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	for _, errno := range transientErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, m := range transientMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// startupBackoff returns the wait before retry n (starting at 1): the
+// initial backoff, doubled on each retry and capped at the max backoff.
+func startupBackoff(initial, maxBackoff time.Duration, n int) time.Duration {
+	backoff := initial
+	for i := 1; i < n && (maxBackoff <= 0 || backoff < maxBackoff); i++ {
+		backoff *= 2
+	}
+	if maxBackoff > 0 && backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// retryStartup starts a component, retrying transient failures with
+// backoff as configured in server.startup. cleanup, if set, releases what a
+// failed attempt left behind before the next one. Fatal errors are
+// returned as is.
+func (d *Daemon) retryStartup(ctx context.Context, component string, start func(context.Context) error, cleanup func()) error {
+	retry := d.cfg.Server.Startup.Component(component)
+	attempts := max(retry.Attempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		err := start(ctx)
+		if err == nil {
+			if attempt > 1 {
+				d.log.Info("component started after retrying", "component", component, "attempts", attempt)
+			}
+			return nil
+		}
+
+		if !isTransientStartupError(err) {
+			return err
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+		}
+
+		backoff := startupBackoff(retry.InitialBackoff, retry.MaxBackoff, attempt)
+		d.log.Warn("component failed to start, retrying",
+			"component", component,
+			"attempt", attempt,
+			"max_attempts", attempts,
+			"backoff", backoff,
+			"error", err,
+		)
+
+		if cleanup != nil {
+			cleanup()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (startup canceled while retrying)", err)
+		case <-time.After(backoff):
+		}
+	}
+}
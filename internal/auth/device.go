@@ -0,0 +1,354 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"bib/internal/domain"
+)
+
+// Device authorization errors.
+var (
+	// ErrDeviceCodeNotFound is returned for unknown or expired codes.
+	ErrDeviceCodeNotFound = errors.New("device code not found or expired")
+
+	// ErrSlowDown is returned when a device polls faster than its interval.
+	ErrSlowDown = errors.New("polling too fast")
+
+	// ErrTooManyDeviceRequests is returned when too many requests are pending.
+	ErrTooManyDeviceRequests = errors.New("too many pending device authorization requests")
+)
+
+// userCodeAlphabet excludes vowels, so codes do not spell words, and
+// characters that are easily confused.
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// userCodeLength is the number of characters in a user code, shown as two
+// groups of four.
+const userCodeLength = 8
+
+// slowDownIncrement is added to a device's polling interval each time it
+// polls too fast.
+const slowDownIncrement = 5 * time.Second
+
+// maxPendingDeviceRequests bounds the pending requests, since starting a
+// request needs no authentication.
+const maxPendingDeviceRequests = 1000
+
+// DeviceAuthStatus is the state of a device authorization request.
+type DeviceAuthStatus string
+
+const (
+	// DeviceAuthPending is waiting for a user to approve or deny.
+	DeviceAuthPending DeviceAuthStatus = "pending"
+
+	// DeviceAuthApproved was approved; the device can collect a session.
+	DeviceAuthApproved DeviceAuthStatus = "approved"
+
+	// DeviceAuthDenied was denied by the user.
+	DeviceAuthDenied DeviceAuthStatus = "denied"
+)
+
+// DeviceClient describes the device requesting authorization.
+type DeviceClient struct {
+	// IPAddress is the device's address.
+	IPAddress string
+
+	// UserAgent identifies the client software.
+	UserAgent string
+
+	// Metadata holds additional client details, such as its hostname.
+	Metadata map[string]string
+}
+
+// DeviceAuthorization is a pending login of a device that has no identity
+// key, approved from a device that is signed in.
+type DeviceAuthorization struct {
+	// DeviceCode is the secret the device polls with.
+	DeviceCode string
+
+	// UserCode is the short code the user enters to approve, e.g. "BCDF-GHJK".
+	UserCode string
+
+	// Client describes the requesting device.
+	Client DeviceClient
+
+	// Status is the request state.
+	Status DeviceAuthStatus
+
+	// Interval is the minimum time between polls.
+	Interval time.Duration
+
+	// UserID is the approving user, once approved.
+	UserID domain.UserID
+
+	// PublicKeyFingerprint is the key of the approving session, recorded on
+	// the device's session so revoking that key also ends it.
+	PublicKeyFingerprint string
+
+	// CreatedAt is when the request was started.
+	CreatedAt time.Time
+
+	// ExpiresAt is when the request expires if not completed.
+	ExpiresAt time.Time
+
+	// lastPoll is when the device last polled.
+	lastPoll time.Time
+}
+
+// IsExpired returns true if the request has expired.
+func (d *DeviceAuthorization) IsExpired() bool {
+	return time.Now().After(d.ExpiresAt)
+}
+
+// DeviceAuthStore manages device authorization requests with automatic
+// expiry. Requests are stored in-memory and are not persisted across
+// restarts.
+type DeviceAuthStore struct {
+	mu        sync.Mutex
+	byDevice  map[string]*DeviceAuthorization
+	byUser    map[string]*DeviceAuthorization
+	ttl       time.Duration
+	interval  time.Duration
+	stopCh    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewDeviceAuthStore creates a store whose requests expire after ttl and
+// may be polled once per interval.
+func NewDeviceAuthStore(ttl, interval time.Duration) *DeviceAuthStore {
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ds := &DeviceAuthStore{
+		byDevice: make(map[string]*DeviceAuthorization),
+		byUser:   make(map[string]*DeviceAuthorization),
+		ttl:      ttl,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+
+	go ds.cleanupLoop()
+
+	return ds
+}
+
+// Create starts a device authorization request.
+func (ds *DeviceAuthStore) Create(client DeviceClient) (*DeviceAuthorization, error) {
+	deviceBytes := make([]byte, 32)
+	if _, err := rand.Read(deviceBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate device code: %w", err)
+	}
+
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	if len(ds.byDevice) >= maxPendingDeviceRequests {
+		ds.cleanupLocked()
+		if len(ds.byDevice) >= maxPendingDeviceRequests {
+			return nil, ErrTooManyDeviceRequests
+		}
+	}
+
+	var userCode string
+	for {
+		code, err := generateUserCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, taken := ds.byUser[code]; !taken {
+			userCode = code
+			break
+		}
+	}
+
+	now := time.Now()
+	d := &DeviceAuthorization{
+		DeviceCode: hex.EncodeToString(deviceBytes),
+		UserCode:   userCode,
+		Client:     client,
+		Status:     DeviceAuthPending,
+		Interval:   ds.interval,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ds.ttl),
+	}
+	ds.byDevice[d.DeviceCode] = d
+	ds.byUser[userCode] = d
+
+	copied := *d
+	return &copied, nil
+}
+
+// Poll returns the state of a request. Polling before the interval has
+// passed returns ErrSlowDown and lengthens the interval. Approved and
+// denied requests are removed once polled, so a session is handed out
+// only once.
+func (ds *DeviceAuthStore) Poll(deviceCode string) (*DeviceAuthorization, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	d, ok := ds.byDevice[deviceCode]
+	if !ok || d.IsExpired() {
+		if ok {
+			ds.removeLocked(d)
+		}
+		return nil, ErrDeviceCodeNotFound
+	}
+
+	now := time.Now()
+	if !d.lastPoll.IsZero() && now.Sub(d.lastPoll) < d.Interval {
+		d.Interval += slowDownIncrement
+		d.lastPoll = now
+		copied := *d
+		return &copied, ErrSlowDown
+	}
+	d.lastPoll = now
+
+	if d.Status != DeviceAuthPending {
+		ds.removeLocked(d)
+	}
+
+	copied := *d
+	return &copied, nil
+}
+
+// Lookup returns a pending request by user code, for showing the
+// requesting device before approving.
+func (ds *DeviceAuthStore) Lookup(userCode string) (*DeviceAuthorization, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	d, err := ds.pendingLocked(userCode)
+	if err != nil {
+		return nil, err
+	}
+	copied := *d
+	return &copied, nil
+}
+
+// Approve approves a pending request for a user. fingerprint is the key of
+// the approving session.
+func (ds *DeviceAuthStore) Approve(userCode string, userID domain.UserID, fingerprint string) (*DeviceAuthorization, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	d, err := ds.pendingLocked(userCode)
+	if err != nil {
+		return nil, err
+	}
+	d.Status = DeviceAuthApproved
+	d.UserID = userID
+	d.PublicKeyFingerprint = fingerprint
+
+	copied := *d
+	return &copied, nil
+}
+
+// Deny denies a pending request.
+func (ds *DeviceAuthStore) Deny(userCode string) (*DeviceAuthorization, error) {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+
+	d, err := ds.pendingLocked(userCode)
+	if err != nil {
+		return nil, err
+	}
+	d.Status = DeviceAuthDenied
+
+	copied := *d
+	return &copied, nil
+}
+
+// Count returns the number of requests in the store.
+func (ds *DeviceAuthStore) Count() int {
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	return len(ds.byDevice)
+}
+
+// Stop stops the background cleanup goroutine.
+func (ds *DeviceAuthStore) Stop() {
+	ds.closeOnce.Do(func() { close(ds.stopCh) })
+}
+
+// pendingLocked returns the pending, unexpired request with a user code.
+func (ds *DeviceAuthStore) pendingLocked(userCode string) (*DeviceAuthorization, error) {
+	d, ok := ds.byUser[NormalizeUserCode(userCode)]
+	if !ok || d.IsExpired() || d.Status != DeviceAuthPending {
+		return nil, ErrDeviceCodeNotFound
+	}
+	return d, nil
+}
+
+// removeLocked removes a request from both indexes.
+func (ds *DeviceAuthStore) removeLocked(d *DeviceAuthorization) {
+	delete(ds.byDevice, d.DeviceCode)
+	delete(ds.byUser, d.UserCode)
+}
+
+// cleanupLoop periodically removes expired requests.
+func (ds *DeviceAuthStore) cleanupLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ds.stopCh:
+			return
+		case <-ticker.C:
+			ds.mu.Lock()
+			ds.cleanupLocked()
+			ds.mu.Unlock()
+		}
+	}
+}
+
+// cleanupLocked removes all expired requests.
+func (ds *DeviceAuthStore) cleanupLocked() {
+	now := time.Now()
+	for _, d := range ds.byDevice {
+		if now.After(d.ExpiresAt) {
+			ds.removeLocked(d)
+		}
+	}
+}
+
+// NormalizeUserCode converts a user code as typed, in any case and with or
+// without the separator, to its canonical "XXXX-XXXX" form.
+func NormalizeUserCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+// generateUserCode returns a random user code in "XXXX-XXXX" form.
+func generateUserCode() (string, error) {
+	buf := make([]byte, userCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate user code: %w", err)
+	}
+	code := make([]byte, userCodeLength)
+	for i, b := range buf {
+		// 256 is not a multiple of the alphabet size; the bias is negligible
+		// for a code that expires in minutes
+		code[i] = userCodeAlphabet[int(b)%len(userCodeAlphabet)]
+	}
+	return NormalizeUserCode(string(code)), nil
+}
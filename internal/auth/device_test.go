@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"bib/internal/domain"
+)
+
+func TestDeviceAuthStore_Create(t *testing.T) {
+	store := NewDeviceAuthStore(time.Minute, time.Second)
+	defer store.Stop()
+
+	d, err := store.Create(DeviceClient{IPAddress: "10.0.0.1", UserAgent: "bib-cli"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if len(d.DeviceCode) != 64 {
+		t.Errorf("DeviceCode should be 64 hex characters, got %d", len(d.DeviceCode))
+	}
+	if len(d.UserCode) != 9 || d.UserCode[4] != '-' {
+		t.Errorf("UserCode should look like XXXX-XXXX, got %q", d.UserCode)
+	}
+	for _, r := range strings.ReplaceAll(d.UserCode, "-", "") {
+		if !strings.ContainsRune(userCodeAlphabet, r) {
+			t.Errorf("UserCode contains %q outside the alphabet", r)
+		}
+	}
+	if d.Status != DeviceAuthPending {
+		t.Errorf("Status should be pending, got %s", d.Status)
+	}
+	if d.Interval != time.Second {
+		t.Errorf("Interval should be 1s, got %s", d.Interval)
+	}
+	if d.IsExpired() {
+		t.Error("New request should not be expired")
+	}
+}
+
+func TestDeviceAuthStore_ApproveAndPoll(t *testing.T) {
+	store := NewDeviceAuthStore(time.Minute, time.Millisecond)
+	defer store.Stop()
+
+	d, _ := store.Create(DeviceClient{})
+
+	polled, err := store.Poll(d.DeviceCode)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if polled.Status != DeviceAuthPending {
+		t.Errorf("Status should be pending, got %s", polled.Status)
+	}
+
+	// User codes are accepted in any case and without the separator
+	typed := strings.ToLower(strings.ReplaceAll(d.UserCode, "-", ""))
+	if _, err := store.Approve(typed, domain.UserID("user-1"), "SHA256:abc"); err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+
+	// A request can only be decided once
+	if _, err := store.Deny(d.UserCode); !errors.Is(err, ErrDeviceCodeNotFound) {
+		t.Errorf("Deny after approve should fail, got %v", err)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	polled, err = store.Poll(d.DeviceCode)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if polled.Status != DeviceAuthApproved {
+		t.Errorf("Status should be approved, got %s", polled.Status)
+	}
+	if polled.UserID != "user-1" || polled.PublicKeyFingerprint != "SHA256:abc" {
+		t.Errorf("Approval not recorded: %+v", polled)
+	}
+
+	// The approval is handed out only once
+	time.Sleep(2 * time.Millisecond)
+	if _, err := store.Poll(d.DeviceCode); !errors.Is(err, ErrDeviceCodeNotFound) {
+		t.Errorf("Poll after approval was collected should fail, got %v", err)
+	}
+	if store.Count() != 0 {
+		t.Errorf("Count should be 0, got %d", store.Count())
+	}
+}
+
+func TestDeviceAuthStore_Deny(t *testing.T) {
+	store := NewDeviceAuthStore(time.Minute, time.Millisecond)
+	defer store.Stop()
+
+	d, _ := store.Create(DeviceClient{})
+	if _, err := store.Deny(d.UserCode); err != nil {
+		t.Fatalf("Deny failed: %v", err)
+	}
+
+	polled, err := store.Poll(d.DeviceCode)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if polled.Status != DeviceAuthDenied {
+		t.Errorf("Status should be denied, got %s", polled.Status)
+	}
+}
+
+func TestDeviceAuthStore_SlowDown(t *testing.T) {
+	store := NewDeviceAuthStore(time.Minute, time.Hour)
+	defer store.Stop()
+
+	d, _ := store.Create(DeviceClient{})
+
+	if _, err := store.Poll(d.DeviceCode); err != nil {
+		t.Fatalf("First poll failed: %v", err)
+	}
+
+	polled, err := store.Poll(d.DeviceCode)
+	if !errors.Is(err, ErrSlowDown) {
+		t.Fatalf("Second poll should be told to slow down, got %v", err)
+	}
+	if polled.Interval != time.Hour+slowDownIncrement {
+		t.Errorf("Interval should grow by %s, got %s", slowDownIncrement, polled.Interval)
+	}
+}
+
+func TestDeviceAuthStore_Expiry(t *testing.T) {
+	store := NewDeviceAuthStore(time.Millisecond, time.Millisecond)
+	defer store.Stop()
+
+	d, _ := store.Create(DeviceClient{})
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := store.Approve(d.UserCode, "user-1", ""); !errors.Is(err, ErrDeviceCodeNotFound) {
+		t.Errorf("Approve of expired request should fail, got %v", err)
+	}
+	if _, err := store.Poll(d.DeviceCode); !errors.Is(err, ErrDeviceCodeNotFound) {
+		t.Errorf("Poll of expired request should fail, got %v", err)
+	}
+	if _, err := store.Poll("unknown"); !errors.Is(err, ErrDeviceCodeNotFound) {
+		t.Errorf("Poll of unknown code should fail, got %v", err)
+	}
+}
+
+func TestNormalizeUserCode(t *testing.T) {
+	tests := map[string]string{
+		"BCDF-GHJK": "BCDF-GHJK",
+		"bcdfghjk":  "BCDF-GHJK",
+		"bcdf ghjk": "BCDF-GHJK",
+		"bcd":       "BCD",
+	}
+	for in, want := range tests {
+		if got := NormalizeUserCode(in); got != want {
+			t.Errorf("NormalizeUserCode(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -56,7 +56,7 @@ func (s *Service) Authenticate(ctx context.Context, req AuthenticateRequest) (*A
 
 		// Check if email is required
 		if s.cfg.RequireEmail && req.Email == "" {
-			return nil, fmt.Errorf("email is required for registration")
+			return nil, domain.ErrEmailRequired
 		}
 
 		// Check if this is the first user (will be admin)
@@ -100,9 +100,76 @@ func (s *Service) Authenticate(ctx context.Context, req AuthenticateRequest) (*A
 		// Non-fatal, just log
 	}
 
+	session, err := s.startSession(ctx, user.ID, SessionRequest{
+		SessionType:          req.SessionType,
+		ClientIP:             req.ClientIP,
+		ClientAgent:          req.ClientAgent,
+		Metadata:             req.Metadata,
+		PublicKeyFingerprint: domain.PublicKeyFingerprint(req.PublicKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthenticateResult{
+		User:    user,
+		Session: session,
+		IsNew:   isNew,
+	}, nil
+}
+
+// AuthenticateRequest contains the data needed to authenticate a user.
+type AuthenticateRequest struct {
+	PublicKey   []byte
+	KeyType     domain.KeyType
+	Name        string // Used for auto-registration
+	Email       string // Used for auto-registration
+	Locale      string // User's preferred locale
+	SessionType storage.SessionType
+	ClientIP    string
+	ClientAgent string
+	Metadata    map[string]string
+}
+
+// SessionRequest describes a session to open for an already identified user.
+type SessionRequest struct {
+	SessionType storage.SessionType
+	ClientIP    string
+	ClientAgent string
+	Metadata    map[string]string
+
+	// PublicKeyFingerprint is the key the session is attributed to, so the
+	// session ends when that key is revoked.
+	PublicKeyFingerprint string
+}
+
+// CreateSession opens a session for a user who was identified by other
+// means than a key challenge, such as an approved device authorization.
+func (s *Service) CreateSession(ctx context.Context, userID domain.UserID, req SessionRequest) (*storage.Session, error) {
+	user, err := s.store.Users().Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkUserStatus(user); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	user.LastLoginAt = &now
+	if err := s.store.Users().Update(ctx, user); err != nil {
+		// Non-fatal, just log
+	}
+
+	return s.startSession(ctx, user.ID, req)
+}
+
+// startSession creates a session, ending the user's oldest session if they
+// are at the session limit.
+func (s *Service) startSession(ctx context.Context, userID domain.UserID, req SessionRequest) (*storage.Session, error) {
 	// Check session limits
 	if s.cfg.MaxSessionsPerUser > 0 {
-		activeSessions, err := s.store.Sessions().GetByUser(ctx, user.ID)
+		activeSessions, err := s.store.Sessions().GetByUser(ctx, userID)
 		if err == nil && len(activeSessions) >= s.cfg.MaxSessionsPerUser {
 			// End oldest session
 			if len(activeSessions) > 0 {
@@ -112,14 +179,14 @@ func (s *Service) Authenticate(ctx context.Context, req AuthenticateRequest) (*A
 		}
 	}
 
-	// Create session
+	now := time.Now().UTC()
 	session := &storage.Session{
 		ID:                   generateSessionID(),
-		UserID:               user.ID,
+		UserID:               userID,
 		Type:                 req.SessionType,
 		ClientIP:             req.ClientIP,
 		ClientAgent:          req.ClientAgent,
-		PublicKeyFingerprint: user.PublicKeyFingerprint,
+		PublicKeyFingerprint: req.PublicKeyFingerprint,
 		NodeID:               s.nodeID,
 		StartedAt:            now,
 		LastActivityAt:       now,
@@ -130,24 +197,7 @@ func (s *Service) Authenticate(ctx context.Context, req AuthenticateRequest) (*A
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	return &AuthenticateResult{
-		User:    user,
-		Session: session,
-		IsNew:   isNew,
-	}, nil
-}
-
-// AuthenticateRequest contains the data needed to authenticate a user.
-type AuthenticateRequest struct {
-	PublicKey   []byte
-	KeyType     domain.KeyType
-	Name        string // Used for auto-registration
-	Email       string // Used for auto-registration
-	Locale      string // User's preferred locale
-	SessionType storage.SessionType
-	ClientIP    string
-	ClientAgent string
-	Metadata    map[string]string
+	return session, nil
 }
 
 // checkUserStatus verifies the user can authenticate.
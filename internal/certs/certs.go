@@ -4,6 +4,7 @@
 package certs
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -15,6 +16,7 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"time"
@@ -81,6 +83,10 @@ type GeneratorConfig struct {
 
 	// UserID is the user ID for client certificates
 	UserID string
+
+	// SPIFFEID is the SPIFFE ID included as URI SAN in server and client
+	// certificates (optional)
+	SPIFFEID *url.URL
 }
 
 // DefaultConfig returns sensible defaults for certificate generation.
@@ -110,6 +116,22 @@ func GenerateCA(cfg GeneratorConfig) (cert, key []byte, err error) {
 		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
 	}
 
+	cert, err = selfSignCA(caKey, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal CA key: %w", err)
+	}
+	key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return cert, key, nil
+}
+
+// selfSignCA creates a self-signed CA certificate for the given key.
+func selfSignCA(caKey crypto.Signer, cfg GeneratorConfig) ([]byte, error) {
 	validDuration := cfg.CAValidDuration
 	if validDuration == 0 {
 		validDuration = 10 * 365 * 24 * time.Hour // 10 years default
@@ -129,20 +151,12 @@ func GenerateCA(cfg GeneratorConfig) (cert, key []byte, err error) {
 		MaxPathLen:            1,
 	}
 
-	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &caKey.PublicKey, caKey)
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
 	}
 
-	cert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
-
-	keyDER, err := x509.MarshalECPrivateKey(caKey)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to marshal CA key: %w", err)
-	}
-	key = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
-
-	return cert, key, nil
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), nil
 }
 
 // GenerateServerCert generates a server certificate signed by the CA.
@@ -151,7 +165,11 @@ func GenerateServerCert(caCert, caKey []byte, cfg GeneratorConfig) (cert, key []
 	if err != nil {
 		return nil, nil, err
 	}
+	return issueServerCert(ca, caPriv, cfg)
+}
 
+// issueServerCert issues a server certificate with the given CA signer.
+func issueServerCert(ca *x509.Certificate, caPriv crypto.Signer, cfg GeneratorConfig) (cert, key []byte, err error) {
 	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to generate server key: %w", err)
@@ -181,6 +199,9 @@ func GenerateServerCert(caCert, caKey []byte, cfg GeneratorConfig) (cert, key []
 		DNSNames:    dnsNames,
 		IPAddresses: cfg.IPAddresses,
 	}
+	if cfg.SPIFFEID != nil {
+		template.URIs = []*url.URL{cfg.SPIFFEID}
+	}
 
 	certDER, err := x509.CreateCertificate(rand.Reader, template, ca, &serverKey.PublicKey, caPriv)
 	if err != nil {
@@ -205,7 +226,11 @@ func GenerateClientCert(caCert, caKey []byte, cfg GeneratorConfig) (cert, key []
 	if err != nil {
 		return nil, nil, err
 	}
+	return issueClientCert(ca, caPriv, cfg)
+}
 
+// issueClientCert issues a client certificate with the given CA signer.
+func issueClientCert(ca *x509.Certificate, caPriv crypto.Signer, cfg GeneratorConfig) (cert, key []byte, err error) {
 	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to generate client key: %w", err)
@@ -240,6 +265,9 @@ func GenerateClientCert(caCert, caKey []byte, cfg GeneratorConfig) (cert, key []
 		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
 		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 	}
+	if cfg.SPIFFEID != nil {
+		template.URIs = []*url.URL{cfg.SPIFFEID}
+	}
 
 	certDER, err := x509.CreateCertificate(rand.Reader, template, ca, &clientKey.PublicKey, caPriv)
 	if err != nil {
@@ -349,9 +377,11 @@ func VerifyChain(certPEM, caCertPEM []byte) error {
 }
 
 // VerifyChainWithUsage verifies that a certificate is signed by the given CA
-// and optionally checks for specific extended key usages.
+// and optionally checks for specific extended key usages. caCertPEM may hold
+// a chain of CAs, e.g. an external root and its intermediates, and certPEM
+// may be followed by the intermediates that issued it.
 func VerifyChainWithUsage(certPEM, caCertPEM []byte, keyUsages []x509.ExtKeyUsage) error {
-	block, _ := pem.Decode(certPEM)
+	block, rest := pem.Decode(certPEM)
 	if block == nil {
 		return fmt.Errorf("failed to decode certificate PEM")
 	}
@@ -361,22 +391,21 @@ func VerifyChainWithUsage(certPEM, caCertPEM []byte, keyUsages []x509.ExtKeyUsag
 		return fmt.Errorf("failed to parse certificate: %w", err)
 	}
 
-	caBlock, _ := pem.Decode(caCertPEM)
-	if caBlock == nil {
+	if caBlock, _ := pem.Decode(caCertPEM); caBlock == nil {
 		return fmt.Errorf("failed to decode CA PEM")
 	}
-
-	caCert, err := x509.ParseCertificate(caBlock.Bytes)
-	if err != nil {
-		return fmt.Errorf("failed to parse CA certificate: %w", err)
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caCertPEM) {
+		return fmt.Errorf("failed to parse CA certificate")
 	}
 
-	roots := x509.NewCertPool()
-	roots.AddCert(caCert)
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM(rest)
 
 	opts := x509.VerifyOptions{
-		Roots:       roots,
-		CurrentTime: time.Now(),
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   time.Now(),
 	}
 	if len(keyUsages) > 0 {
 		opts.KeyUsages = keyUsages
@@ -470,27 +499,56 @@ func newSerialNumber() *big.Int {
 	return serial
 }
 
-// parseCABundle parses CA certificate and key from PEM.
-func parseCABundle(caCertPEM, caKeyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+// parseCABundle parses CA certificate and key from PEM. The key may be an
+// EC, PKCS#8 or PKCS#1 key, so that CAs of an external PKI can sign.
+func parseCABundle(caCertPEM, caKeyPEM []byte) (*x509.Certificate, crypto.Signer, error) {
+	caCert, err := parseCACert(caCertPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caKey, err := parsePrivateKey(caKeyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+
+	return caCert, caKey, nil
+}
+
+// parseCACert parses the first certificate of a PEM CA bundle.
+func parseCACert(caCertPEM []byte) (*x509.Certificate, error) {
 	block, _ := pem.Decode(caCertPEM)
 	if block == nil {
-		return nil, nil, fmt.Errorf("failed to decode CA certificate PEM")
+		return nil, fmt.Errorf("failed to decode CA certificate PEM")
 	}
 
 	caCert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
 	}
+	return caCert, nil
+}
 
-	keyBlock, _ := pem.Decode(caKeyPEM)
-	if keyBlock == nil {
-		return nil, nil, fmt.Errorf("failed to decode CA key PEM")
+// parsePrivateKey parses an EC, PKCS#8 or PKCS#1 private key from PEM.
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode key PEM")
 	}
 
-	caKey, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse CA key: %w", err)
+		return nil, fmt.Errorf("unsupported private key: %w", err)
 	}
-
-	return caCert, caKey, nil
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
 }
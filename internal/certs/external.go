@@ -0,0 +1,209 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrCertificatePending is returned by Initialize when the server
+// certificate is to be issued by an external PKI and has not been imported
+// yet. The error message names the CSR to submit.
+var ErrCertificatePending = errors.New("server certificate pending")
+
+// ExternalPKI configures the manager to chain to an organization's PKI
+// instead of generating a CA. CAFile is required, plus either CAKeyFile, to
+// issue certificates with the provided CA, or CertFile and KeyFile, a
+// server certificate issued by the PKI.
+type ExternalPKI struct {
+	// CAFile holds the CA certificates the node's certificates chain to:
+	// the issuing CA first, then its intermediates and root
+	CAFile string
+
+	// CAKeyFile is the key of the issuing CA in CAFile (optional)
+	CAKeyFile string
+
+	// CertFile is the server certificate, optionally followed by its
+	// intermediates. If it doesn't exist, a CSR for it is written next to
+	// it (CertFile with extension .csr), to be signed by the PKI.
+	CertFile string
+
+	// KeyFile is the server private key. It is generated with the CSR if
+	// it doesn't exist.
+	KeyFile string
+}
+
+// CSRPath returns where the CSR for CertFile is written.
+func (e *ExternalPKI) CSRPath() string {
+	return strings.TrimSuffix(e.CertFile, filepath.Ext(e.CertFile)) + ".csr"
+}
+
+// GenerateKey generates an ECDSA P-256 private key in PEM.
+func GenerateKey() ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// GenerateServerCSR creates a certificate signing request for a server
+// certificate with the names of cfg, signed by keyPEM.
+func GenerateServerCSR(keyPEM []byte, cfg GeneratorConfig) ([]byte, error) {
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	dnsNames := cfg.DNSNames
+	if cfg.PeerID != "" {
+		dnsNames = append(dnsNames, cfg.PeerID)
+	}
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   cfg.ServerCommonName,
+			Organization: []string{cfg.Organization},
+		},
+		DNSNames:    dnsNames,
+		IPAddresses: cfg.IPAddresses,
+	}
+	if cfg.SPIFFEID != nil {
+		template.URIs = []*url.URL{cfg.SPIFFEID}
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSR: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}), nil
+}
+
+// ValidateServerCert checks that certPEM is a current server certificate
+// for keyPEM that chains to caPEM.
+func ValidateServerCert(certPEM, keyPEM, caPEM []byte) error {
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return fmt.Errorf("certificate does not match key: %w", err)
+	}
+	if err := VerifyChainWithUsage(certPEM, caPEM, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}); err != nil {
+		return fmt.Errorf("certificate does not chain to the CA: %w", err)
+	}
+	return nil
+}
+
+// validateCA checks that caKeyPEM is the key of the first certificate in
+// caPEM, and that it is a CA.
+func validateCA(caPEM, caKeyPEM []byte) error {
+	if _, err := tls.X509KeyPair(caPEM, caKeyPEM); err != nil {
+		return fmt.Errorf("CA key does not match the CA certificate: %w", err)
+	}
+	ca, _, err := parseCABundle(caPEM, caKeyPEM)
+	if err != nil {
+		return err
+	}
+	if !ca.IsCA || ca.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return fmt.Errorf("%s is not a CA certificate", ca.Subject.CommonName)
+	}
+	if time.Now().After(ca.NotAfter) {
+		return fmt.Errorf("CA certificate expired on %s", ca.NotAfter.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// initializeExternal loads the CA and server certificate of an external
+// PKI, validating the chain. Without a server certificate, it writes a CSR
+// and returns ErrCertificatePending.
+func (m *Manager) initializeExternal() error {
+	ext := m.cfg.External
+
+	caCert, err := os.ReadFile(ext.CAFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CA file: %w", err)
+	}
+	m.caCert = caCert
+
+	if m.cfg.PKCS11 != nil {
+		if err := m.openCASigner(caCert); err != nil {
+			return err
+		}
+		return m.initializeServerCert()
+	}
+
+	if ext.CAKeyFile != "" {
+		caKey, err := os.ReadFile(ext.CAKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA key: %w", err)
+		}
+		if err := validateCA(caCert, caKey); err != nil {
+			return err
+		}
+		m.caKey = caKey
+		return m.initializeServerCert()
+	}
+
+	if _, err := os.Stat(ext.CertFile); os.IsNotExist(err) {
+		return m.writeServerCSR()
+	}
+
+	serverCert, err := os.ReadFile(ext.CertFile)
+	if err != nil {
+		return fmt.Errorf("failed to read server cert: %w", err)
+	}
+	serverKey, err := os.ReadFile(ext.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read server key: %w", err)
+	}
+	if err := ValidateServerCert(serverCert, serverKey, caCert); err != nil {
+		return fmt.Errorf("invalid server certificate %s: %w", ext.CertFile, err)
+	}
+
+	m.serverCert = serverCert
+	m.serverKey = serverKey
+	return nil
+}
+
+// writeServerCSR writes a CSR for the server certificate, generating the
+// key first if needed.
+func (m *Manager) writeServerCSR() error {
+	ext := m.cfg.External
+
+	key, err := os.ReadFile(ext.KeyFile)
+	if os.IsNotExist(err) {
+		if key, err = GenerateKey(); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(ext.KeyFile), 0700); err != nil {
+			return fmt.Errorf("failed to create key directory: %w", err)
+		}
+		if err := os.WriteFile(ext.KeyFile, key, 0600); err != nil {
+			return fmt.Errorf("failed to save server key: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to read server key: %w", err)
+	}
+
+	csr, err := GenerateServerCSR(key, m.generatorConfig())
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(ext.CSRPath(), csr, 0644); err != nil {
+		return fmt.Errorf("failed to save CSR: %w", err)
+	}
+
+	return fmt.Errorf("%w: have %s signed by your CA and save the certificate to %s, or import it with 'bib cert import'",
+		ErrCertificatePending, ext.CSRPath(), ext.CertFile)
+}
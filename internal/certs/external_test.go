@@ -0,0 +1,179 @@
+package certs
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// signCSR issues a server certificate for a CSR, as an external CA would.
+func signCSR(t *testing.T, csrPEM, caCert, caKey []byte) []byte {
+	t.Helper()
+	block, _ := pem.Decode(csrPEM)
+	if block == nil {
+		t.Fatal("failed to decode CSR")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		t.Fatalf("invalid CSR signature: %v", err)
+	}
+	ca, key, err := parseCABundle(caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: newSerialNumber(),
+		Subject:      csr.Subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     csr.DNSNames,
+		IPAddresses:  csr.IPAddresses,
+		URIs:         csr.URIs,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, csr.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newExternalManager(t *testing.T, ext *ExternalPKI) *Manager {
+	t.Helper()
+	m, err := NewManager(ManagerConfig{
+		ConfigDir:      t.TempDir(),
+		NodeID:         "node-1",
+		P2PIdentityKey: []byte("0123456789abcdef0123456789abcdef"),
+		External:       ext,
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	return m
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManager_ExternalCAKey(t *testing.T) {
+	rootCert, rootKey, err := GenerateCA(DefaultConfig("corporate-root"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Corporate CA keys are often PKCS#8
+	ecKey, err := parsePrivateKey(rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	ext := &ExternalPKI{CAFile: filepath.Join(dir, "ca.pem"), CAKeyFile: filepath.Join(dir, "ca.key")}
+	writeFile(t, ext.CAFile, rootCert)
+	writeFile(t, ext.CAKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+
+	m := newExternalManager(t, ext)
+	if err := m.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if err := VerifyChainWithUsage(m.ServerCert(), rootCert, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}); err != nil {
+		t.Errorf("server cert does not chain to the external CA: %v", err)
+	}
+	if _, _, err := m.GenerateClientCert("cli", "alice", ""); err != nil {
+		t.Errorf("GenerateClientCert failed: %v", err)
+	}
+
+	// A CA key that doesn't match the CA certificate is rejected
+	_, otherKey, err := GenerateCA(DefaultConfig("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, ext.CAKeyFile, otherKey)
+	if err := newExternalManager(t, ext).Initialize(); err == nil {
+		t.Error("expected error for a mismatched CA key")
+	}
+}
+
+func TestManager_ExternalCSR(t *testing.T) {
+	rootCert, rootKey, err := GenerateCA(DefaultConfig("corporate-root"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	ext := &ExternalPKI{
+		CAFile:   filepath.Join(dir, "ca.pem"),
+		CertFile: filepath.Join(dir, "server.crt"),
+		KeyFile:  filepath.Join(dir, "server.key"),
+	}
+	writeFile(t, ext.CAFile, rootCert)
+
+	// Without a certificate, a key and CSR are written
+	err = newExternalManager(t, ext).Initialize()
+	if !errors.Is(err, ErrCertificatePending) {
+		t.Fatalf("expected ErrCertificatePending, got %v", err)
+	}
+	csr, err := os.ReadFile(ext.CSRPath())
+	if err != nil {
+		t.Fatalf("CSR not written: %v", err)
+	}
+	if _, err := os.Stat(ext.KeyFile); err != nil {
+		t.Fatalf("key not written: %v", err)
+	}
+
+	// A certificate of another CA is rejected
+	otherCert, otherKey, err := GenerateCA(DefaultConfig("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, ext.CertFile, signCSR(t, csr, otherCert, otherKey))
+	if err := newExternalManager(t, ext).Initialize(); err == nil || errors.Is(err, ErrCertificatePending) {
+		t.Errorf("expected chain validation error, got %v", err)
+	}
+
+	// The signed certificate is loaded
+	writeFile(t, ext.CertFile, signCSR(t, csr, rootCert, rootKey))
+	m := newExternalManager(t, ext)
+	if err := m.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if m.TLSConfig() == nil {
+		t.Error("expected a TLS config")
+	}
+	if _, _, err := m.GenerateClientCert("cli", "alice", ""); err == nil {
+		t.Error("expected error issuing client certs without the CA key")
+	}
+}
+
+func TestNewManager_ExternalValidation(t *testing.T) {
+	for name, ext := range map[string]*ExternalPKI{
+		"no ca":   {CertFile: "server.crt", KeyFile: "server.key"},
+		"no cert": {CAFile: "ca.pem"},
+		"no key":  {CAFile: "ca.pem", CertFile: "server.crt"},
+	} {
+		if _, err := NewManager(ManagerConfig{
+			ConfigDir:      t.TempDir(),
+			NodeID:         "node-1",
+			P2PIdentityKey: []byte("key"),
+			External:       ext,
+		}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -38,6 +38,23 @@ type ManagerConfig struct {
 
 	// RenewalThresholdDays is how many days before expiry to renew (default: 30)
 	RenewalThresholdDays int
+
+	// MinVersion is the minimum TLS version of the server (default: TLS 1.3)
+	MinVersion uint16
+
+	// CipherSuites are the TLS 1.2 cipher suites of the server (default:
+	// ECDHE with AES-GCM)
+	CipherSuites []uint16
+
+	// SPIFFE enables SPIFFE identities (optional)
+	SPIFFE *SPIFFEConfig
+
+	// External uses an external PKI instead of generating a CA (optional)
+	External *ExternalPKI
+
+	// PKCS11 keeps the CA key on a hardware token instead of encrypted on
+	// disk (optional)
+	PKCS11 *PKCS11Config
 }
 
 // Manager handles certificate lifecycle for bibd.
@@ -49,7 +66,8 @@ type Manager struct {
 	revocationPath string
 
 	caCert     []byte
-	caKey      []byte // Decrypted CA key (only kept in memory)
+	caKey      []byte      // Decrypted CA key (only kept in memory)
+	caSigner   tokenSigner // CA key on a PKCS#11 token
 	serverCert []byte
 	serverKey  []byte
 
@@ -87,6 +105,36 @@ func NewManager(cfg ManagerConfig) (*Manager, error) {
 	if cfg.RenewalThresholdDays == 0 {
 		cfg.RenewalThresholdDays = 30
 	}
+	if cfg.MinVersion == 0 {
+		cfg.MinVersion = tls.VersionTLS13
+	}
+	if len(cfg.CipherSuites) == 0 {
+		cfg.CipherSuites = defaultCipherSuites
+	}
+	if ext := cfg.External; ext != nil {
+		if ext.CAFile == "" {
+			return nil, fmt.Errorf("external PKI requires a CA file")
+		}
+		if ext.CAKeyFile == "" && cfg.PKCS11 == nil && (ext.CertFile == "" || ext.KeyFile == "") {
+			return nil, fmt.Errorf("external PKI requires the CA key or a server certificate and key")
+		}
+		if ext.CAKeyFile != "" && cfg.PKCS11 != nil {
+			return nil, fmt.Errorf("external PKI CA key file and PKCS#11 token are mutually exclusive")
+		}
+	}
+	if cfg.PKCS11 != nil {
+		if err := cfg.PKCS11.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.SPIFFE != nil {
+		if err := ValidateTrustDomain(cfg.SPIFFE.TrustDomain); err != nil {
+			return nil, err
+		}
+		if len(cfg.SPIFFE.TrustBundle) > 0 && !x509.NewCertPool().AppendCertsFromPEM(cfg.SPIFFE.TrustBundle) {
+			return nil, fmt.Errorf("SPIFFE trust bundle contains no certificates")
+		}
+	}
 
 	m := &Manager{
 		cfg:            cfg,
@@ -112,14 +160,21 @@ func (m *Manager) Initialize() error {
 		}
 	}
 
-	// Load or create CA
-	if err := m.initializeCA(); err != nil {
-		return fmt.Errorf("failed to initialize CA: %w", err)
-	}
+	if m.cfg.External != nil {
+		// Load the CA and certificates of the external PKI
+		if err := m.initializeExternal(); err != nil {
+			return fmt.Errorf("failed to initialize external PKI: %w", err)
+		}
+	} else {
+		// Load or create CA
+		if err := m.initializeCA(); err != nil {
+			return fmt.Errorf("failed to initialize CA: %w", err)
+		}
 
-	// Load or create server certificate
-	if err := m.initializeServerCert(); err != nil {
-		return fmt.Errorf("failed to initialize server certificate: %w", err)
+		// Load or create server certificate
+		if err := m.initializeServerCert(); err != nil {
+			return fmt.Errorf("failed to initialize server certificate: %w", err)
+		}
 	}
 
 	// Initialize TLS config
@@ -146,6 +201,10 @@ func (m *Manager) Initialize() error {
 
 // initializeCA loads or creates the CA certificate.
 func (m *Manager) initializeCA() error {
+	if m.cfg.PKCS11 != nil {
+		return m.initializeTokenCA()
+	}
+
 	caCertPath := filepath.Join(m.certsDir, "ca.crt")
 	caKeyPath := filepath.Join(m.secretsDir, "ca.key.enc")
 
@@ -213,6 +272,10 @@ func (m *Manager) initializeServerCert() error {
 		}
 
 		needsRenewal, err := NeedsRenewal(serverCert, renewalThreshold)
+		if err == nil && !needsRenewal {
+			needsRenewal = !m.hasSPIFFEID(serverCert) ||
+				VerifyChainWithUsage(serverCert, m.caCert, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}) != nil
+		}
 		if err != nil || needsRenewal {
 			// Regenerate
 			if err := m.generateServerCert(); err != nil {
@@ -240,10 +303,18 @@ func (m *Manager) initializeServerCert() error {
 func (m *Manager) generateServerCert() error {
 	cfg := m.generatorConfig()
 
-	serverCert, serverKey, err := GenerateServerCert(m.caCert, m.caKey, cfg)
+	ca, signer, err := m.issuer()
+	if err != nil {
+		return err
+	}
+	serverCert, serverKey, err := issueServerCert(ca, signer, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to generate server cert: %w", err)
 	}
+	if m.cfg.External != nil {
+		// Present the chain to the external root
+		serverCert = append(serverCert, m.caCert...)
+	}
 
 	serverCertPath := filepath.Join(m.certsDir, "server.crt")
 	serverKeyPath := filepath.Join(m.certsDir, "server.key")
@@ -274,18 +345,28 @@ func (m *Manager) initializeTLSConfig() error {
 	if !m.caPool.AppendCertsFromPEM(m.caCert) {
 		return fmt.Errorf("failed to add CA cert to pool")
 	}
+	if m.cfg.SPIFFE != nil && len(m.cfg.SPIFFE.TrustBundle) > 0 {
+		m.caPool.AppendCertsFromPEM(m.cfg.SPIFFE.TrustBundle)
+	}
 
 	m.tlsConfig = &tls.Config{
 		Certificates: []tls.Certificate{cert},
 		ClientCAs:    m.caPool,
 		ClientAuth:   tls.VerifyClientCertIfGiven, // Allow both mTLS and server-only TLS
-		MinVersion:   tls.VersionTLS12,
-		CipherSuites: []uint16{
-			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
-			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
-			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
-		},
+		MinVersion:   m.cfg.MinVersion,
+		CipherSuites: m.cfg.CipherSuites,
+	}
+	if m.cfg.SPIFFE != nil {
+		trustDomain := m.cfg.SPIFFE.TrustDomain
+		m.tlsConfig.VerifyPeerCertificate = func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+			// Clients without a certificate are left to the authentication
+			// of the call
+			if len(verifiedChains) == 0 {
+				return nil
+			}
+			_, err := VerifySVID(verifiedChains[0][0], trustDomain)
+			return err
+		}
 	}
 
 	return nil
@@ -298,6 +379,9 @@ func (m *Manager) generatorConfig() GeneratorConfig {
 	cfg.CAValidDuration = time.Duration(m.cfg.CAValidityYears) * 365 * 24 * time.Hour
 	cfg.ServerValidDuration = time.Duration(m.cfg.ServerCertValidityDays) * 24 * time.Hour
 	cfg.ClientValidDuration = time.Duration(m.cfg.ClientCertValidityDays) * 24 * time.Hour
+	if m.cfg.SPIFFE != nil {
+		cfg.SPIFFEID = SPIFFEID(m.cfg.SPIFFE.TrustDomain, "node", m.cfg.NodeID)
+	}
 
 	// Parse listen addresses for SANs
 	for _, addr := range m.cfg.ListenAddresses {
@@ -352,12 +436,26 @@ func (m *Manager) GenerateClientCert(name, userID, sshFingerprint string) (cert,
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	if !m.canSign() {
+		return nil, nil, fmt.Errorf("client certificates are issued by the external PKI, which did not provide a CA key")
+	}
+
 	cfg := m.generatorConfig()
 	cfg.ClientCommonName = name
 	cfg.UserID = userID
 	cfg.SSHKeyFingerprint = sshFingerprint
+	if m.cfg.SPIFFE != nil {
+		cfg.SPIFFEID = SPIFFEID(m.cfg.SPIFFE.TrustDomain, "client", name)
+		if userID != "" {
+			cfg.SPIFFEID = SPIFFEID(m.cfg.SPIFFE.TrustDomain, "user", userID)
+		}
+	}
 
-	return GenerateClientCert(m.caCert, m.caKey, cfg)
+	ca, signer, err := m.issuer()
+	if err != nil {
+		return nil, nil, err
+	}
+	return issueClientCert(ca, signer, cfg)
 }
 
 // RevocationList returns the revocation list.
@@ -380,6 +478,9 @@ func (m *Manager) CheckRenewal() error {
 
 	needsRenewal, err := NeedsRenewal(m.serverCert, renewalThreshold)
 	if err != nil || needsRenewal {
+		if !m.canSign() {
+			return fmt.Errorf("server certificate %s is due for renewal by the external PKI", m.cfg.External.CertFile)
+		}
 		if err := m.generateServerCert(); err != nil {
 			return fmt.Errorf("failed to renew server cert: %w", err)
 		}
@@ -400,7 +501,9 @@ func (m *Manager) VerifyClientCert(certPEM []byte) error {
 
 	// Verify chain
 	if err := VerifyChain(certPEM, m.caCert); err != nil {
-		return fmt.Errorf("certificate chain verification failed: %w", err)
+		if m.cfg.SPIFFE == nil || verifyTrustBundle(certPEM, m.cfg.SPIFFE.TrustBundle) != nil {
+			return fmt.Errorf("certificate chain verification failed: %w", err)
+		}
 	}
 
 	// Check revocation
@@ -409,6 +512,12 @@ func (m *Manager) VerifyClientCert(certPEM []byte) error {
 		return err
 	}
 
+	if m.cfg.SPIFFE != nil {
+		if _, err := VerifySVID(cert.Cert, m.cfg.SPIFFE.TrustDomain); err != nil {
+			return err
+		}
+	}
+
 	if m.revocationList.IsRevoked(cert.Fingerprint) {
 		return fmt.Errorf("certificate has been revoked")
 	}
@@ -416,6 +525,20 @@ func (m *Manager) VerifyClientCert(certPEM []byte) error {
 	return nil
 }
 
+// hasSPIFFEID reports whether a server certificate carries the node's
+// SPIFFE ID, or carries none when SPIFFE is disabled.
+func (m *Manager) hasSPIFFEID(certPEM []byte) bool {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return false
+	}
+	if m.cfg.SPIFFE == nil {
+		return len(cert.Cert.URIs) == 0
+	}
+	id, err := VerifySVID(cert.Cert, m.cfg.SPIFFE.TrustDomain)
+	return err == nil && id.String() == SPIFFEID(m.cfg.SPIFFE.TrustDomain, "node", m.cfg.NodeID).String()
+}
+
 // parseIP extracts an IP address from a listen address string.
 func parseIP(addr string) []byte {
 	// Handle multiaddr format like /ip4/0.0.0.0/tcp/4001
@@ -435,5 +558,11 @@ func (m *Manager) Close() error {
 	}
 	m.caKey = nil
 
+	if m.caSigner != nil {
+		err := m.caSigner.Close()
+		m.caSigner = nil
+		return err
+	}
+
 	return nil
 }
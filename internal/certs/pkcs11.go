@@ -0,0 +1,165 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrTokenUnavailable is returned when the PKCS#11 token holding the CA
+// key can't be used. The manager never falls back to a software key.
+var ErrTokenUnavailable = errors.New("PKCS#11 token unavailable")
+
+// PKCS11Config keeps the CA private key on a hardware token (HSM or
+// smartcard): the key never leaves the token, which signs certificates.
+type PKCS11Config struct {
+	// ModulePath is the token's PKCS#11 module, e.g.
+	// /usr/lib/softhsm/libsofthsm2.so
+	ModulePath string
+
+	// Slot is the slot of the token, used when TokenLabel is empty
+	Slot uint
+
+	// TokenLabel selects the token by label (optional)
+	TokenLabel string
+
+	// KeyLabel is the label of the CA key pair on the token
+	KeyLabel string
+
+	// PIN is the user PIN of the token
+	PIN string
+}
+
+// Validate checks the configuration for required fields.
+func (c *PKCS11Config) Validate() error {
+	if c.ModulePath == "" {
+		return fmt.Errorf("PKCS#11 module path is required")
+	}
+	if c.KeyLabel == "" {
+		return fmt.Errorf("PKCS#11 key label is required")
+	}
+	if c.PIN == "" {
+		return fmt.Errorf("PKCS#11 PIN is required")
+	}
+	return nil
+}
+
+// tokenSigner signs with a key held by a PKCS#11 token.
+type tokenSigner interface {
+	crypto.Signer
+
+	// Close logs out of the token and unloads the module
+	Close() error
+}
+
+// openToken opens the token and checks that its key matches the CA
+// certificate, if one exists yet.
+func openToken(cfg *PKCS11Config, caCertPEM []byte) (tokenSigner, error) {
+	signer, err := openPKCS11(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTokenUnavailable, err)
+	}
+	if caCertPEM == nil {
+		return signer, nil
+	}
+
+	ca, err := parseCACert(caCertPEM)
+	if err != nil {
+		signer.Close()
+		return nil, err
+	}
+	if !publicKeyEqual(ca.PublicKey, signer.Public()) {
+		signer.Close()
+		return nil, fmt.Errorf("PKCS#11 key %q does not match the CA certificate", cfg.KeyLabel)
+	}
+	return signer, nil
+}
+
+// publicKeyEqual reports whether two public keys are the same.
+func publicKeyEqual(a, b crypto.PublicKey) bool {
+	switch k := a.(type) {
+	case *ecdsa.PublicKey:
+		return k.Equal(b)
+	case *rsa.PublicKey:
+		return k.Equal(b)
+	default:
+		return false
+	}
+}
+
+// initializeTokenCA loads the CA certificate of the token key, or creates
+// it self-signed by the token on first start. The CA key is never written
+// to disk.
+func (m *Manager) initializeTokenCA() error {
+	caCertPath := filepath.Join(m.certsDir, "ca.crt")
+
+	caCert, err := os.ReadFile(caCertPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read CA cert: %w", err)
+	}
+	if err := m.openCASigner(caCert); err != nil {
+		return err
+	}
+
+	if caCert != nil {
+		m.caCert = caCert
+		fp, _ := Fingerprint(m.caCert)
+		fmt.Printf("Loaded CA certificate of PKCS#11 key %q (fingerprint: %s)\n", m.cfg.PKCS11.KeyLabel, fp[:16]+"...")
+		return nil
+	}
+
+	caCert, err = selfSignCA(m.caSigner, m.generatorConfig())
+	if err != nil {
+		return fmt.Errorf("failed to generate CA: %w", err)
+	}
+	if err := os.WriteFile(caCertPath, caCert, 0644); err != nil {
+		return fmt.Errorf("failed to save CA cert: %w", err)
+	}
+	m.caCert = caCert
+
+	fp, _ := Fingerprint(m.caCert)
+	fmt.Printf("Generated new CA certificate for PKCS#11 key %q (fingerprint: %s)\n", m.cfg.PKCS11.KeyLabel, fp[:16]+"...")
+
+	return nil
+}
+
+// openCASigner opens the token holding the key of caCert (nil if the CA
+// is yet to be created).
+func (m *Manager) openCASigner(caCert []byte) error {
+	if m.caSigner != nil {
+		m.caSigner.Close()
+		m.caSigner = nil
+	}
+
+	signer, err := openToken(m.cfg.PKCS11, caCert)
+	if err != nil {
+		return err
+	}
+	m.caSigner = signer
+	return nil
+}
+
+// canSign reports whether the manager holds a CA key to issue
+// certificates with.
+func (m *Manager) canSign() bool {
+	return m.caSigner != nil || m.caKey != nil
+}
+
+// issuer returns the CA certificate and the signer of its key, on the
+// token or in memory.
+func (m *Manager) issuer() (*x509.Certificate, crypto.Signer, error) {
+	if m.caSigner == nil {
+		return parseCABundle(m.caCert, m.caKey)
+	}
+
+	ca, err := parseCACert(m.caCert)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ca, m.caSigner, nil
+}
@@ -0,0 +1,10 @@
+//go:build !pkcs11 || !cgo
+
+package certs
+
+import "fmt"
+
+// openPKCS11 fails: PKCS#11 support requires cgo and the pkcs11 build tag.
+func openPKCS11(cfg *PKCS11Config) (tokenSigner, error) {
+	return nil, fmt.Errorf("bibd was built without PKCS#11 support (rebuild with CGO_ENABLED=1 and -tags pkcs11)")
+}
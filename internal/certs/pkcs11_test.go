@@ -0,0 +1,150 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeToken stands in for a PKCS#11 token holding an in-memory key.
+type fakeToken struct {
+	*ecdsa.PrivateKey
+	closed bool
+}
+
+func (t *fakeToken) Close() error {
+	t.closed = true
+	return nil
+}
+
+func newTokenManager(t *testing.T) *Manager {
+	t.Helper()
+	m, err := NewManager(ManagerConfig{
+		ConfigDir:      t.TempDir(),
+		NodeID:         "node-1",
+		P2PIdentityKey: []byte("0123456789abcdef0123456789abcdef"),
+		PKCS11: &PKCS11Config{
+			ModulePath: "/nonexistent/libtoken.so",
+			KeyLabel:   "bib-ca",
+			PIN:        "1234",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	return m
+}
+
+func TestPKCS11Config_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     PKCS11Config
+		wantErr bool
+	}{
+		{"valid", PKCS11Config{ModulePath: "/usr/lib/libtoken.so", KeyLabel: "ca", PIN: "1234"}, false},
+		{"missing module", PKCS11Config{KeyLabel: "ca", PIN: "1234"}, true},
+		{"missing key label", PKCS11Config{ModulePath: "/usr/lib/libtoken.so", PIN: "1234"}, true},
+		{"missing PIN", PKCS11Config{ModulePath: "/usr/lib/libtoken.so", KeyLabel: "ca"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_PKCS11TokenUnavailable(t *testing.T) {
+	m := newTokenManager(t)
+
+	err := m.Initialize()
+	if !errors.Is(err, ErrTokenUnavailable) {
+		t.Fatalf("expected ErrTokenUnavailable, got %v", err)
+	}
+
+	// No software CA must have been created instead
+	for _, path := range []string{
+		filepath.Join(m.secretsDir, "ca.key.enc"),
+		filepath.Join(m.certsDir, "ca.crt"),
+		filepath.Join(m.certsDir, "server.crt"),
+	} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s was created without a token", path)
+		}
+	}
+}
+
+func TestManager_PKCS11Signing(t *testing.T) {
+	m := newTokenManager(t)
+	if err := os.MkdirAll(m.certsDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &fakeToken{PrivateKey: key}
+	m.caSigner = token
+	m.caCert, err = selfSignCA(token, m.generatorConfig())
+	if err != nil {
+		t.Fatalf("selfSignCA failed: %v", err)
+	}
+
+	if err := m.generateServerCert(); err != nil {
+		t.Fatalf("generateServerCert failed: %v", err)
+	}
+	if err := VerifyChainWithUsage(m.serverCert, m.caCert, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}); err != nil {
+		t.Errorf("server cert does not chain to token CA: %v", err)
+	}
+
+	clientCert, _, err := m.GenerateClientCert("alice", "user-1", "")
+	if err != nil {
+		t.Fatalf("GenerateClientCert failed: %v", err)
+	}
+	if err := VerifyChainWithUsage(clientCert, m.caCert, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}); err != nil {
+		t.Errorf("client cert does not chain to token CA: %v", err)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !token.closed {
+		t.Error("Close did not close the token")
+	}
+}
+
+func TestNewManager_PKCS11Validation(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewManager(ManagerConfig{
+		ConfigDir:      dir,
+		NodeID:         "node-1",
+		P2PIdentityKey: []byte("0123456789abcdef0123456789abcdef"),
+		External: &ExternalPKI{
+			CAFile:    filepath.Join(dir, "ca.crt"),
+			CAKeyFile: filepath.Join(dir, "ca.key"),
+		},
+		PKCS11: &PKCS11Config{ModulePath: "/usr/lib/libtoken.so", KeyLabel: "ca", PIN: "1234"},
+	})
+	if err == nil {
+		t.Error("expected error for CA key file with PKCS#11 token")
+	}
+
+	_, err = NewManager(ManagerConfig{
+		ConfigDir:      dir,
+		NodeID:         "node-1",
+		P2PIdentityKey: []byte("0123456789abcdef0123456789abcdef"),
+		External:       &ExternalPKI{CAFile: filepath.Join(dir, "ca.crt")},
+		PKCS11:         &PKCS11Config{ModulePath: "/usr/lib/libtoken.so", KeyLabel: "ca", PIN: "1234"},
+	})
+	if err != nil {
+		t.Errorf("external CA with PKCS#11 token should be valid: %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+//go:build pkcs11 && cgo
+
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// curveOIDs maps the named curves supported for token keys.
+var curveOIDs = map[string]elliptic.Curve{
+	"1.2.840.10045.3.1.7": elliptic.P256(),
+	"1.3.132.0.34":        elliptic.P384(),
+	"1.3.132.0.35":        elliptic.P521(),
+}
+
+// digestInfoPrefixes are the DER DigestInfo prefixes for PKCS#1 v1.5
+// signatures, which the token computes over the prefixed digest.
+var digestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// pkcs11Signer signs with a private key on a PKCS#11 token.
+type pkcs11Signer struct {
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	open    bool
+	key     pkcs11.ObjectHandle
+	pub     crypto.PublicKey
+
+	// mu serializes operations on the session
+	mu sync.Mutex
+}
+
+// openPKCS11 loads the module, logs in to the token and looks up the key
+// pair labeled cfg.KeyLabel.
+func openPKCS11(cfg *PKCS11Config) (tokenSigner, error) {
+	ctx := pkcs11.New(cfg.ModulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", cfg.ModulePath)
+	}
+	if err := ctx.Initialize(); err != nil {
+		ctx.Destroy()
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+
+	s := &pkcs11Signer{ctx: ctx}
+	if err := s.login(cfg); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// login opens a session on the token, logs in and loads the key pair.
+func (s *pkcs11Signer) login(cfg *PKCS11Config) error {
+	slot, err := findSlot(s.ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	s.session, err = s.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("failed to open session on slot %d: %w", slot, err)
+	}
+	s.open = true
+	if err := s.ctx.Login(s.session, pkcs11.CKU_USER, cfg.PIN); err != nil {
+		var p11Err pkcs11.Error
+		if !errors.As(err, &p11Err) || p11Err != pkcs11.CKR_USER_ALREADY_LOGGED_IN {
+			return fmt.Errorf("failed to log in to token: %w", err)
+		}
+	}
+
+	if s.key, err = s.findObject(pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel); err != nil {
+		return err
+	}
+	pubHandle, err := s.findObject(pkcs11.CKO_PUBLIC_KEY, cfg.KeyLabel)
+	if err != nil {
+		return err
+	}
+	s.pub, err = s.readPublicKey(pubHandle)
+	return err
+}
+
+// findSlot returns the slot of the token labeled cfg.TokenLabel, or
+// cfg.Slot if no label is set.
+func findSlot(ctx *pkcs11.Ctx, cfg *PKCS11Config) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list slots: %w", err)
+	}
+	for _, slot := range slots {
+		if cfg.TokenLabel == "" {
+			if slot == cfg.Slot {
+				return slot, nil
+			}
+			continue
+		}
+		info, err := ctx.GetTokenInfo(slot)
+		if err == nil && info.Label == cfg.TokenLabel {
+			return slot, nil
+		}
+	}
+	if cfg.TokenLabel != "" {
+		return 0, fmt.Errorf("no token labeled %q present", cfg.TokenLabel)
+	}
+	return 0, fmt.Errorf("no token present in slot %d", cfg.Slot)
+}
+
+// findObject returns the single object of the class labeled label.
+func (s *pkcs11Signer) findObject(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := s.ctx.FindObjectsInit(s.session, template); err != nil {
+		return 0, fmt.Errorf("failed to search token: %w", err)
+	}
+	objects, _, err := s.ctx.FindObjects(s.session, 2)
+	s.ctx.FindObjectsFinal(s.session)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search token: %w", err)
+	}
+
+	kind := "private"
+	if class == pkcs11.CKO_PUBLIC_KEY {
+		kind = "public"
+	}
+	switch len(objects) {
+	case 0:
+		return 0, fmt.Errorf("no %s key labeled %q on token", kind, label)
+	case 1:
+		return objects[0], nil
+	default:
+		return 0, fmt.Errorf("multiple %s keys labeled %q on token", kind, label)
+	}
+}
+
+// readPublicKey reads an EC or RSA public key from the token.
+func (s *pkcs11Signer) readPublicKey(handle pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	attrs, err := s.ctx.GetAttributeValue(s.session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key type: %w", err)
+	}
+	if len(attrs[0].Value) != 8 && len(attrs[0].Value) != 4 {
+		return nil, fmt.Errorf("invalid key type attribute")
+	}
+	keyType := uint64(binary.NativeEndian.Uint32(attrs[0].Value))
+	if len(attrs[0].Value) == 8 {
+		keyType = binary.NativeEndian.Uint64(attrs[0].Value)
+	}
+
+	switch keyType {
+	case pkcs11.CKK_EC:
+		attrs, err := s.ctx.GetAttributeValue(s.session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read EC public key: %w", err)
+		}
+		return parseECPoint(attrs[0].Value, attrs[1].Value)
+
+	case pkcs11.CKK_RSA:
+		attrs, err := s.ctx.GetAttributeValue(s.session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read RSA public key: %w", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(attrs[0].Value),
+			E: int(new(big.Int).SetBytes(attrs[1].Value).Int64()),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported token key type %d", keyType)
+	}
+}
+
+// parseECPoint decodes the DER curve OID and DER-wrapped uncompressed
+// point of a token EC key.
+func parseECPoint(params, point []byte) (*ecdsa.PublicKey, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &oid); err != nil {
+		return nil, fmt.Errorf("failed to parse EC parameters: %w", err)
+	}
+	curve, ok := curveOIDs[oid.String()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported curve %s", oid)
+	}
+
+	var raw []byte
+	if _, err := asn1.Unmarshal(point, &raw); err != nil {
+		// Some modules return the point without the octet string
+		raw = point
+	}
+	x, y := elliptic.Unmarshal(curve, raw)
+	if x == nil {
+		return nil, fmt.Errorf("invalid EC point")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// Public returns the public key of the token key pair.
+func (s *pkcs11Signer) Public() crypto.PublicKey {
+	return s.pub
+}
+
+// Sign signs digest with the token key. ECDSA signatures are returned in
+// ASN.1, RSA signatures use PKCS#1 v1.5.
+func (s *pkcs11Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var (
+		mechanism uint
+		data      []byte
+	)
+	switch s.pub.(type) {
+	case *ecdsa.PublicKey:
+		mechanism, data = pkcs11.CKM_ECDSA, digest
+	case *rsa.PublicKey:
+		if _, ok := opts.(*rsa.PSSOptions); ok {
+			return nil, fmt.Errorf("RSA-PSS is not supported by the token signer")
+		}
+		prefix, ok := digestInfoPrefixes[opts.HashFunc()]
+		if !ok {
+			return nil, fmt.Errorf("unsupported hash %v", opts.HashFunc())
+		}
+		mechanism, data = pkcs11.CKM_RSA_PKCS, append(append([]byte{}, prefix...), digest...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ctx.SignInit(s.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(mechanism, nil)}, s.key); err != nil {
+		return nil, fmt.Errorf("%w: failed to sign: %v", ErrTokenUnavailable, err)
+	}
+	sig, err := s.ctx.Sign(s.session, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to sign: %v", ErrTokenUnavailable, err)
+	}
+
+	if mechanism == pkcs11.CKM_ECDSA {
+		// The token returns r || s
+		half := len(sig) / 2
+		return asn1.Marshal(struct{ R, S *big.Int }{
+			R: new(big.Int).SetBytes(sig[:half]),
+			S: new(big.Int).SetBytes(sig[half:]),
+		})
+	}
+	return sig, nil
+}
+
+// Close logs out and unloads the module.
+func (s *pkcs11Signer) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.open {
+		s.ctx.Logout(s.session)
+		s.ctx.CloseSession(s.session)
+		s.open = false
+	}
+	s.ctx.Finalize()
+	s.ctx.Destroy()
+	return nil
+}
@@ -0,0 +1,91 @@
+package certs
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// defaultCipherSuites are the TLS 1.2 cipher suites offered when none are
+// configured: ECDHE key exchange with AES-GCM.
+var defaultCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+}
+
+// ParseTLSVersion parses a minimum TLS version, "1.2" or "1.3". Empty
+// means 1.3. Versions before 1.2 are rejected as insecure.
+func ParseTLSVersion(version string) (uint16, error) {
+	switch strings.TrimPrefix(strings.ToLower(version), "tls") {
+	case "", "1.3":
+		return tls.VersionTLS13, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.0", "1.1":
+		return 0, fmt.Errorf("TLS %s is insecure; use 1.2 or 1.3", version)
+	default:
+		return 0, fmt.Errorf("unknown TLS version %q; use 1.2 or 1.3", version)
+	}
+}
+
+// ParseCipherSuites parses TLS 1.2 cipher suites by their IANA names, as
+// listed by crypto/tls (e.g. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256").
+// Suites are rejected if crypto/tls considers them insecure or they lack
+// forward secrecy. TLS 1.3 suites are not configurable, so naming them, or
+// naming any suite with a minimum version of 1.3, is an error. HTTP/2,
+// which gRPC runs on, requires an ECDHE AES-128-GCM suite to be allowed.
+func ParseCipherSuites(names []string, minVersion uint16) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if minVersion >= tls.VersionTLS13 {
+		return nil, fmt.Errorf("cipher suites only apply to TLS 1.2, but the minimum version is 1.3")
+	}
+
+	secure := make(map[string]*tls.CipherSuite)
+	for _, s := range tls.CipherSuites() {
+		secure[s.Name] = s
+	}
+	insecure := make(map[string]bool)
+	for _, s := range tls.InsecureCipherSuites() {
+		insecure[s.Name] = true
+	}
+
+	var (
+		ids     []uint16
+		http2OK bool
+	)
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		suite, ok := secure[name]
+		switch {
+		case insecure[name]:
+			return nil, fmt.Errorf("cipher suite %s is insecure", name)
+		case !ok:
+			return nil, fmt.Errorf("unknown cipher suite %s", name)
+		case !supportsTLS12(suite):
+			return nil, fmt.Errorf("cipher suite %s is a TLS 1.3 suite, which is not configurable", name)
+		case !strings.HasPrefix(name, "TLS_ECDHE_"):
+			return nil, fmt.Errorf("cipher suite %s lacks forward secrecy", name)
+		}
+		ids = append(ids, suite.ID)
+		if suite.ID == tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 || suite.ID == tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256 {
+			http2OK = true
+		}
+	}
+	if !http2OK {
+		return nil, fmt.Errorf("cipher suites must include TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 or TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, which HTTP/2 requires")
+	}
+	return ids, nil
+}
+
+func supportsTLS12(suite *tls.CipherSuite) bool {
+	for _, v := range suite.SupportedVersions {
+		if v == tls.VersionTLS12 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package certs
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestParseTLSVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    uint16
+		wantErr bool
+	}{
+		{"", tls.VersionTLS13, false},
+		{"1.3", tls.VersionTLS13, false},
+		{"1.2", tls.VersionTLS12, false},
+		{"TLS1.2", tls.VersionTLS12, false},
+		{"1.1", 0, true},
+		{"1.0", 0, true},
+		{"2.0", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTLSVersion(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTLSVersion(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTLSVersion(%q) = %x, want %x", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	suites, err := ParseCipherSuites([]string{
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"tls_ecdhe_rsa_with_aes_128_gcm_sha256",
+	}, tls.VersionTLS12)
+	if err != nil {
+		t.Fatalf("ParseCipherSuites failed: %v", err)
+	}
+	want := []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+	if len(suites) != len(want) || suites[0] != want[0] || suites[1] != want[1] {
+		t.Errorf("ParseCipherSuites = %v, want %v", suites, want)
+	}
+
+	if suites, err := ParseCipherSuites(nil, tls.VersionTLS13); err != nil || suites != nil {
+		t.Errorf("expected no suites and no error without names, got %v (%v)", suites, err)
+	}
+
+	rejected := map[string][]string{
+		"min version 1.3": {"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		"insecure":        {"TLS_ECDHE_RSA_WITH_RC4_128_SHA", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		"unknown":         {"TLS_FOO", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		"tls 1.3 suite":   {"TLS_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		"no ecdhe":        {"TLS_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		"no http/2 suite": {"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+	}
+	for name, names := range rejected {
+		minVersion := uint16(tls.VersionTLS12)
+		if name == "min version 1.3" {
+			minVersion = tls.VersionTLS13
+		}
+		if _, err := ParseCipherSuites(names, minVersion); err == nil {
+			t.Errorf("%s: expected error for %v", name, names)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SPIFFEConfig enables SPIFFE identities: certificates carry a SPIFFE ID as
+// URI SAN, and peers presenting a certificate must present an SVID of the
+// trust domain.
+type SPIFFEConfig struct {
+	// TrustDomain is the SPIFFE trust domain, e.g. "example.org"
+	TrustDomain string
+
+	// TrustBundle are PEM CA certificates of the trust domain (e.g. from
+	// SPIRE) trusted besides the node's CA
+	TrustBundle []byte
+}
+
+// ValidateTrustDomain checks a trust domain name: lowercase letters,
+// digits, dots, dashes and underscores, without scheme or path.
+func ValidateTrustDomain(trustDomain string) error {
+	if trustDomain == "" {
+		return fmt.Errorf("trust domain is required")
+	}
+	for _, r := range trustDomain {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-' || r == '_') {
+			return fmt.Errorf("invalid trust domain %q: only lowercase letters, digits, '.', '-' and '_' are allowed", trustDomain)
+		}
+	}
+	return nil
+}
+
+// SPIFFEID returns the SPIFFE ID of a bibd workload in trustDomain, e.g.
+// spiffe://example.org/bibd/node/<node-id> for a node.
+func SPIFFEID(trustDomain, kind, name string) *url.URL {
+	return &url.URL{
+		Scheme: "spiffe",
+		Host:   trustDomain,
+		Path:   "/bibd/" + kind + "/" + url.PathEscape(name),
+	}
+}
+
+// SVID returns the SPIFFE ID of an X.509-SVID. An SVID has exactly one URI
+// SAN, a spiffe:// URI with a trust domain and no query or fragment.
+func SVID(cert *x509.Certificate) (*url.URL, error) {
+	if len(cert.URIs) != 1 {
+		return nil, fmt.Errorf("certificate has %d URI SANs, an SVID has exactly one", len(cert.URIs))
+	}
+	id := cert.URIs[0]
+	if id.Scheme != "spiffe" || id.Host == "" || id.User != nil || id.RawQuery != "" || id.Fragment != "" {
+		return nil, fmt.Errorf("URI SAN %s is not a SPIFFE ID", id)
+	}
+	return id, nil
+}
+
+// VerifySVID checks a certificate is an SVID of trustDomain and returns its
+// SPIFFE ID. The certificate chain is not verified.
+func VerifySVID(cert *x509.Certificate, trustDomain string) (*url.URL, error) {
+	id, err := SVID(cert)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(id.Host, trustDomain) {
+		return nil, fmt.Errorf("SPIFFE ID %s is not in trust domain %s", id, trustDomain)
+	}
+	return id, nil
+}
+
+// verifyTrustBundle verifies a client certificate against the CAs of a
+// SPIFFE trust bundle.
+func verifyTrustBundle(certPEM, bundle []byte) error {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("trust bundle contains no certificates")
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return fmt.Errorf("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
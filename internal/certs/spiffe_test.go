@@ -0,0 +1,160 @@
+package certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"net/url"
+	"testing"
+)
+
+func parsePEM(t *testing.T, certPEM []byte) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		t.Fatal("failed to decode PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestValidateTrustDomain(t *testing.T) {
+	for _, td := range []string{"example.org", "prod-cluster_1.example.org"} {
+		if err := ValidateTrustDomain(td); err != nil {
+			t.Errorf("ValidateTrustDomain(%q) = %v", td, err)
+		}
+	}
+	for _, td := range []string{"", "Example.org", "spiffe://example.org", "example.org/path"} {
+		if err := ValidateTrustDomain(td); err == nil {
+			t.Errorf("ValidateTrustDomain(%q) expected error", td)
+		}
+	}
+}
+
+func TestSPIFFEID(t *testing.T) {
+	id := SPIFFEID("example.org", "node", "node-1")
+	if got := id.String(); got != "spiffe://example.org/bibd/node/node-1" {
+		t.Errorf("unexpected SPIFFE ID %s", got)
+	}
+}
+
+func TestGenerateCerts_SPIFFE(t *testing.T) {
+	cfg := DefaultConfig("test-node-id-12345678")
+	caCert, caKey, err := GenerateCA(cfg)
+	if err != nil {
+		t.Fatalf("GenerateCA failed: %v", err)
+	}
+
+	cfg.SPIFFEID = SPIFFEID("example.org", "node", "test-node")
+	serverCert, _, err := GenerateServerCert(caCert, caKey, cfg)
+	if err != nil {
+		t.Fatalf("GenerateServerCert failed: %v", err)
+	}
+	id, err := VerifySVID(parsePEM(t, serverCert), "example.org")
+	if err != nil {
+		t.Fatalf("VerifySVID failed: %v", err)
+	}
+	if id.String() != cfg.SPIFFEID.String() {
+		t.Errorf("expected %s, got %s", cfg.SPIFFEID, id)
+	}
+	if _, err := VerifySVID(parsePEM(t, serverCert), "other.org"); err == nil {
+		t.Error("expected error for another trust domain")
+	}
+
+	cfg.SPIFFEID = SPIFFEID("example.org", "user", "alice")
+	clientCert, _, err := GenerateClientCert(caCert, caKey, cfg)
+	if err != nil {
+		t.Fatalf("GenerateClientCert failed: %v", err)
+	}
+	if _, err := VerifySVID(parsePEM(t, clientCert), "example.org"); err != nil {
+		t.Errorf("VerifySVID of client cert failed: %v", err)
+	}
+
+	cfg.SPIFFEID = nil
+	plainCert, _, err := GenerateServerCert(caCert, caKey, cfg)
+	if err != nil {
+		t.Fatalf("GenerateServerCert failed: %v", err)
+	}
+	if _, err := SVID(parsePEM(t, plainCert)); err == nil {
+		t.Error("expected error for a certificate without SPIFFE ID")
+	}
+}
+
+func TestSVID_RejectsNonSPIFFEURI(t *testing.T) {
+	cert := &x509.Certificate{URIs: []*url.URL{{Scheme: "https", Host: "example.org"}}}
+	if _, err := SVID(cert); err == nil {
+		t.Error("expected error for https URI SAN")
+	}
+	cert.URIs = append(cert.URIs, SPIFFEID("example.org", "node", "a"))
+	if _, err := SVID(cert); err == nil {
+		t.Error("expected error for two URI SANs")
+	}
+}
+
+func TestManager_SPIFFE(t *testing.T) {
+	newManager := func(spiffe *SPIFFEConfig, dir string) *Manager {
+		m, err := NewManager(ManagerConfig{
+			ConfigDir:      dir,
+			NodeID:         "node-1",
+			P2PIdentityKey: []byte("0123456789abcdef0123456789abcdef"),
+			SPIFFE:         spiffe,
+		})
+		if err != nil {
+			t.Fatalf("NewManager failed: %v", err)
+		}
+		if err := m.Initialize(); err != nil {
+			t.Fatalf("Initialize failed: %v", err)
+		}
+		return m
+	}
+
+	dir := t.TempDir()
+	if _, err := SVID(parsePEM(t, newManager(nil, dir).ServerCert())); err == nil {
+		t.Error("expected no SPIFFE ID without SPIFFE")
+	}
+
+	// Enabling SPIFFE reissues the server certificate with the node's ID
+	m := newManager(&SPIFFEConfig{TrustDomain: "example.org"}, dir)
+	id, err := VerifySVID(parsePEM(t, m.ServerCert()), "example.org")
+	if err != nil {
+		t.Fatalf("server cert is not an SVID: %v", err)
+	}
+	if want := "spiffe://example.org/bibd/node/node-1"; id.String() != want {
+		t.Errorf("expected %s, got %s", want, id)
+	}
+
+	clientCert, _, err := m.GenerateClientCert("cli", "alice", "")
+	if err != nil {
+		t.Fatalf("GenerateClientCert failed: %v", err)
+	}
+	verify := m.TLSConfig().VerifyPeerCertificate
+	if verify == nil {
+		t.Fatal("expected SVID verification in the TLS config")
+	}
+	if err := verify(nil, [][]*x509.Certificate{{parsePEM(t, clientCert)}}); err != nil {
+		t.Errorf("SVID of the trust domain rejected: %v", err)
+	}
+	if err := verify(nil, nil); err != nil {
+		t.Errorf("client without certificate rejected: %v", err)
+	}
+
+	other := newManager(&SPIFFEConfig{TrustDomain: "other.org"}, t.TempDir())
+	otherCert, _, err := other.GenerateClientCert("cli", "bob", "")
+	if err != nil {
+		t.Fatalf("GenerateClientCert failed: %v", err)
+	}
+	if err := verify(nil, [][]*x509.Certificate{{parsePEM(t, otherCert)}}); err == nil {
+		t.Error("expected SVID of another trust domain to be rejected")
+	}
+
+	if _, err := NewManager(ManagerConfig{
+		ConfigDir:      t.TempDir(),
+		NodeID:         "node-1",
+		P2PIdentityKey: []byte("key"),
+		SPIFFE:         &SPIFFEConfig{TrustDomain: "example.org", TrustBundle: []byte("not pem")},
+	}); err == nil {
+		t.Error("expected error for a trust bundle without certificates")
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Type is the type of an exported column.
+type Type string
+
+const (
+	TypeBool   Type = "bool"
+	TypeInt    Type = "int64"
+	TypeDouble Type = "double"
+	TypeString Type = "string"
+)
+
+// Column is a named, typed column of an export.
+type Column struct {
+	Name string `json:"name" yaml:"name"`
+	Type Type   `json:"type" yaml:"type"`
+}
+
+// InferColumns returns the columns of rows, ordered by name. A column's type
+// is the narrowest one that holds all of its values: integral numbers are
+// int64, other numbers double. Columns mixing kinds, holding lists or
+// objects, or holding only nulls are strings; lists and objects are
+// exported as JSON.
+func InferColumns(rows []map[string]any) []Column {
+	types := make(map[string]Type)
+	for _, row := range rows {
+		for name, v := range row {
+			t, seen := types[name]
+			vt := typeOf(v)
+			switch {
+			case vt == "":
+				if !seen {
+					types[name] = ""
+				}
+			case !seen || t == "":
+				types[name] = vt
+			default:
+				types[name] = unify(t, vt)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	columns := make([]Column, len(names))
+	for i, name := range sortedNames(names) {
+		t := types[name]
+		if t == "" {
+			t = TypeString
+		}
+		columns[i] = Column{Name: name, Type: t}
+	}
+	return columns
+}
+
+// typeOf returns the column type a value needs, or "" for null.
+func typeOf(v any) Type {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return TypeBool
+	case int, int32, int64:
+		return TypeInt
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<63 {
+			return TypeInt
+		}
+		return TypeDouble
+	default:
+		return TypeString
+	}
+}
+
+// unify returns the type holding values of both types.
+func unify(a, b Type) Type {
+	switch {
+	case a == b:
+		return a
+	case (a == TypeInt && b == TypeDouble) || (a == TypeDouble && b == TypeInt):
+		return TypeDouble
+	default:
+		return TypeString
+	}
+}
+
+// convert converts a value to the column type; nil stays nil.
+func (t Type) convert(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch t {
+	case TypeBool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case TypeInt:
+		switch n := v.(type) {
+		case int:
+			return int64(n), nil
+		case int32:
+			return int64(n), nil
+		case int64:
+			return n, nil
+		case float64:
+			if n == math.Trunc(n) && math.Abs(n) < 1<<63 {
+				return int64(n), nil
+			}
+		}
+	case TypeDouble:
+		switch n := v.(type) {
+		case int:
+			return float64(n), nil
+		case int32:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		}
+	case TypeString:
+		return formatValue(v)
+	}
+	return nil, fmt.Errorf("%v does not fit type %s", v, t)
+}
+
+// formatValue renders a value as text: strings as is, numbers in their
+// shortest form, lists and objects as JSON.
+func formatValue(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+func sortedNames(names []string) []string {
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,45 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// csvEncoder writes rows as CSV, after a header row naming the columns.
+type csvEncoder struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVEncoder(out io.Writer, columns []Column) (*csvEncoder, error) {
+	e := &csvEncoder{w: csv.NewWriter(out), record: make([]string, len(columns))}
+	if len(columns) == 0 {
+		return e, nil
+	}
+	for i, c := range columns {
+		e.record[i] = c.Name
+	}
+	if err := e.w.Write(e.record); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *csvEncoder) encode(row []any) error {
+	if len(row) == 0 {
+		return nil
+	}
+	for i, v := range row {
+		s, err := formatValue(v)
+		if err != nil {
+			return err
+		}
+		e.record[i] = s
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,172 @@
+// Package export writes query results to files in analysis-friendly
+// formats.
+//
+// Supported formats:
+//   - csv: RFC 4180 CSV with a header row
+//   - parquet: Apache Parquet with typed, nullable columns
+//
+// Rows are written as they arrive. The columns and their types are inferred
+// from the first rows, so only those are held in memory before writing
+// starts.
+package export
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format represents an export format.
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+)
+
+// sampleSize is the number of rows the column types are inferred from.
+const sampleSize = 1000
+
+// ParseFormat parses a format name.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "csv":
+		return FormatCSV, nil
+	case "parquet", "pq":
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q (use csv or parquet)", s)
+	}
+}
+
+// FormatFromPath returns the export format matching the extension of path.
+func FormatFromPath(path string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf("cannot tell the export format of %s; use --export-format", path)
+	}
+	return ParseFormat(ext)
+}
+
+// encoder writes rows with a fixed set of columns.
+type encoder interface {
+	encode(row []any) error
+	close() error
+}
+
+// Writer writes rows of a query result in an export format.
+type Writer struct {
+	format  Format
+	out     io.Writer
+	enc     encoder
+	sample  []map[string]any
+	columns []Column
+	index   map[string]int
+	dropped map[string]bool
+	rows    int64
+}
+
+// NewWriter creates a writer that exports rows to out in the given format.
+func NewWriter(out io.Writer, format Format) *Writer {
+	return &Writer{format: format, out: out}
+}
+
+// Write exports a row. Rows are buffered until the columns are known.
+func (w *Writer) Write(row map[string]any) error {
+	if w.enc == nil {
+		w.sample = append(w.sample, row)
+		if len(w.sample) < sampleSize {
+			return nil
+		}
+		return w.start()
+	}
+	return w.encode(row)
+}
+
+// Close writes any buffered rows and completes the export. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+	if w.enc == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	return w.enc.close()
+}
+
+// Columns returns the exported columns, once they are known.
+func (w *Writer) Columns() []Column {
+	return w.columns
+}
+
+// Rows returns the number of rows exported so far.
+func (w *Writer) Rows() int64 {
+	return w.rows
+}
+
+// Dropped returns the names of columns that only appeared after the columns
+// were inferred, and so were left out of the export.
+func (w *Writer) Dropped() []string {
+	names := make([]string, 0, len(w.dropped))
+	for name := range w.dropped {
+		names = append(names, name)
+	}
+	return sortedNames(names)
+}
+
+// start infers the columns from the sampled rows and writes them.
+func (w *Writer) start() error {
+	w.columns = InferColumns(w.sample)
+	w.index = make(map[string]int, len(w.columns))
+	for i, c := range w.columns {
+		w.index[c.Name] = i
+	}
+
+	var err error
+	switch w.format {
+	case FormatCSV:
+		w.enc, err = newCSVEncoder(w.out, w.columns)
+	case FormatParquet:
+		w.enc, err = newParquetEncoder(w.out, w.columns)
+	default:
+		err = fmt.Errorf("unsupported export format %q", w.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	sample := w.sample
+	w.sample = nil
+	for _, row := range sample {
+		if err := w.encode(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encode converts a row to the columns and writes it.
+func (w *Writer) encode(row map[string]any) error {
+	values := make([]any, len(w.columns))
+	for name, v := range row {
+		i, ok := w.index[name]
+		if !ok {
+			if w.dropped == nil {
+				w.dropped = make(map[string]bool)
+			}
+			w.dropped[name] = true
+			continue
+		}
+		cv, err := w.columns[i].Type.convert(v)
+		if err != nil {
+			return fmt.Errorf("row %d: column %s: %w", w.rows+1, name, err)
+		}
+		values[i] = cv
+	}
+	if err := w.enc.encode(values); err != nil {
+		return err
+	}
+	w.rows++
+	return nil
+}
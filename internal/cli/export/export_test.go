@@ -0,0 +1,190 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    Format
+		wantErr bool
+	}{
+		{"results.csv", FormatCSV, false},
+		{"out/results.PARQUET", FormatParquet, false},
+		{"results.pq", FormatParquet, false},
+		{"results.xlsx", "", true},
+		{"results", "", true},
+	}
+	for _, tt := range tests {
+		got, err := FormatFromPath(tt.path)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FormatFromPath(%q) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("FormatFromPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestInferColumns(t *testing.T) {
+	rows := []map[string]any{
+		{"id": 1.0, "temp": 20.0, "ok": true, "city": "Berlin", "tags": []any{"a"}, "empty": nil, "mixed": 1.0},
+		{"id": 2.0, "temp": 21.5, "ok": false, "city": nil, "mixed": "x"},
+	}
+	want := []Column{
+		{Name: "city", Type: TypeString},
+		{Name: "empty", Type: TypeString},
+		{Name: "id", Type: TypeInt},
+		{Name: "mixed", Type: TypeString},
+		{Name: "ok", Type: TypeBool},
+		{Name: "tags", Type: TypeString},
+		{Name: "temp", Type: TypeDouble},
+	}
+	if got := InferColumns(rows); !reflect.DeepEqual(got, want) {
+		t.Errorf("InferColumns() = %v, want %v", got, want)
+	}
+}
+
+func TestWriterCSV(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, FormatCSV)
+	rows := []map[string]any{
+		{"name": `say "hi"`, "note": "a,b\nc", "n": 1.0, "loc": map[string]any{"lat": 52.5}},
+		{"name": "plain", "n": 2.0},
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("exported CSV does not parse: %v", err)
+	}
+	want := [][]string{
+		{"loc", "n", "name", "note"},
+		{`{"lat":52.5}`, "1", `say "hi"`, "a,b\nc"},
+		{"", "2", "plain", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %q, want %q", records, want)
+	}
+	if w.Rows() != 2 {
+		t.Errorf("Rows() = %d, want 2", w.Rows())
+	}
+}
+
+func TestWriterDropsLateColumns(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, FormatCSV)
+	for i := range sampleSize + 1 {
+		row := map[string]any{"i": float64(i)}
+		if i == sampleSize {
+			row["late"] = "x"
+		}
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := w.Dropped(); !reflect.DeepEqual(got, []string{"late"}) {
+		t.Errorf("Dropped() = %v, want [late]", got)
+	}
+	if w.Rows() != sampleSize+1 {
+		t.Errorf("Rows() = %d, want %d", w.Rows(), sampleSize+1)
+	}
+}
+
+func TestWriterRejectsMismatchedValue(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, FormatParquet)
+	for i := range sampleSize {
+		if err := w.Write(map[string]any{"n": float64(i)}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Write(map[string]any{"n": 1.5}); err == nil {
+		t.Error("Write() of a double to an int64 column succeeded")
+	}
+}
+
+func TestWriterParquet(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, FormatParquet)
+	rows := []map[string]any{
+		{"city": "Berlin", "temp": 20.5, "count": 3.0, "ok": true},
+		{"city": nil, "temp": 18.0, "count": 4.0},
+	}
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("exported Parquet does not open: %v", err)
+	}
+	if f.NumRows() != 2 {
+		t.Errorf("NumRows() = %d, want 2", f.NumRows())
+	}
+
+	types := make(map[string]string)
+	for _, field := range f.Schema().Fields() {
+		if !field.Optional() {
+			t.Errorf("column %s is not optional", field.Name())
+		}
+		types[field.Name()] = field.Type().String()
+	}
+	want := map[string]string{
+		"city":  "STRING",
+		"count": "INT(64,true)",
+		"ok":    "BOOLEAN",
+		"temp":  "DOUBLE",
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("column types = %v, want %v", types, want)
+	}
+
+	r := parquet.NewReader(bytes.NewReader(buf.Bytes()))
+	var got []string
+	for {
+		row := make(map[string]any)
+		if err := r.Read(&row); err != nil {
+			break
+		}
+		got = append(got, fmt.Sprintf("%v %v %v %v", row["city"], row["count"], row["ok"], row["temp"]))
+	}
+	if want := []string{"Berlin 3 true 20.5", "<nil> 4 <nil> 18"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %q, want %q", got, want)
+	}
+}
+
+func TestWriterEmpty(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatParquet} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, format)
+		if err := w.Close(); err != nil {
+			t.Errorf("%s: Close() error = %v", format, err)
+		}
+		if format == FormatCSV && buf.Len() != 0 {
+			t.Errorf("csv: wrote %q for no rows", buf.String())
+		}
+	}
+}
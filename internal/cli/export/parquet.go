@@ -0,0 +1,89 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+)
+
+// rowGroupSize bounds the rows held in memory before a row group is written.
+const rowGroupSize = 64 * 1024
+
+// parquetEncoder writes rows as a Parquet file with one optional column per
+// export column.
+type parquetEncoder struct {
+	w *parquet.Writer
+	// leaf maps export columns to Parquet leaf columns, which are ordered
+	// by the schema.
+	leaf []int
+	row  parquet.Row
+}
+
+func newParquetEncoder(out io.Writer, columns []Column) (*parquetEncoder, error) {
+	group := make(parquet.Group, len(columns))
+	for _, c := range columns {
+		var node parquet.Node
+		switch c.Type {
+		case TypeBool:
+			node = parquet.Leaf(parquet.BooleanType)
+		case TypeInt:
+			node = parquet.Int(64)
+		case TypeDouble:
+			node = parquet.Leaf(parquet.DoubleType)
+		default:
+			node = parquet.String()
+		}
+		group[c.Name] = parquet.Optional(node)
+	}
+	schema := parquet.NewSchema("query_result", group)
+
+	leaves := make(map[string]int, len(columns))
+	for i, path := range schema.Columns() {
+		leaves[path[0]] = i
+	}
+	e := &parquetEncoder{
+		w: parquet.NewWriter(out, schema,
+			parquet.Compression(&zstd.Codec{}),
+			parquet.MaxRowsPerRowGroup(rowGroupSize)),
+		leaf: make([]int, len(columns)),
+		row:  make(parquet.Row, len(columns)),
+	}
+	for i, c := range columns {
+		leaf, ok := leaves[c.Name]
+		if !ok {
+			return nil, fmt.Errorf("parquet schema has no column %s", c.Name)
+		}
+		e.leaf[i] = leaf
+	}
+	return e, nil
+}
+
+func (e *parquetEncoder) encode(row []any) error {
+	for i, v := range row {
+		var pv parquet.Value
+		switch v := v.(type) {
+		case nil:
+			e.row[e.leaf[i]] = parquet.NullValue().Level(0, 0, e.leaf[i])
+			continue
+		case bool:
+			pv = parquet.BooleanValue(v)
+		case int64:
+			pv = parquet.Int64Value(v)
+		case float64:
+			pv = parquet.DoubleValue(v)
+		case string:
+			pv = parquet.ByteArrayValue([]byte(v))
+		default:
+			return fmt.Errorf("cannot write %T to parquet", v)
+		}
+		e.row[e.leaf[i]] = pv.Level(0, 1, e.leaf[i])
+	}
+	_, err := e.w.WriteRows([]parquet.Row{e.row})
+	return err
+}
+
+func (e *parquetEncoder) close() error {
+	return e.w.Close()
+}
@@ -3,6 +3,7 @@
 // Output supports multiple formats:
 //   - table: Human-readable tables (default)
 //   - json: Machine-readable JSON
+//   - jsonl: One JSON object per line, streamed as results arrive
 //   - yaml: Machine-readable YAML
 //   - quiet: Minimal output (IDs only)
 //
@@ -15,6 +16,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strings"
 	"text/tabwriter"
 
@@ -27,6 +29,7 @@ type Format string
 const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
+	FormatJSONL Format = "jsonl"
 	FormatYAML  Format = "yaml"
 	FormatQuiet Format = "quiet"
 )
@@ -36,6 +39,8 @@ func ParseFormat(s string) Format {
 	switch strings.ToLower(s) {
 	case "json":
 		return FormatJSON
+	case "jsonl", "ndjson":
+		return FormatJSONL
 	case "yaml", "yml":
 		return FormatYAML
 	case "quiet", "q":
@@ -83,6 +88,8 @@ func (w *Writer) Write(data any) error {
 	switch w.format {
 	case FormatJSON:
 		return w.writeJSON(data)
+	case FormatJSONL:
+		return w.writeJSONL(data)
 	case FormatYAML:
 		return w.writeYAML(data)
 	case FormatQuiet:
@@ -99,6 +106,22 @@ func (w *Writer) writeJSON(data any) error {
 	return encoder.Encode(data)
 }
 
+// writeJSONL outputs data as compact JSON, one line per element of a slice.
+func (w *Writer) writeJSONL(data any) error {
+	encoder := json.NewEncoder(w.out)
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return encoder.Encode(data)
+	}
+	for i := range v.Len() {
+		if err := encoder.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // writeYAML outputs data as YAML.
 func (w *Writer) writeYAML(data any) error {
 	return yaml.NewEncoder(w.out).Encode(data)
@@ -0,0 +1,131 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Stream writes a listing item by item as results arrive.
+//
+// With jsonl each item is written as one line of JSON as soon as it is
+// written to the stream, so memory stays flat and consumers such as jq see
+// results incrementally. quiet output is streamed too. The other formats
+// need every item before they can render (a JSON array, table column
+// widths), so their items are collected and written by Close.
+type Stream[T any] struct {
+	w     *Writer
+	row   func(T) []string
+	enc   *json.Encoder
+	items []T
+	table *Table
+	count int
+}
+
+// NewStream creates a stream writing to w. row renders an item as a table
+// row matching headers; its first cell is the item's ID in quiet output.
+func NewStream[T any](w *Writer, headers []string, row func(T) []string) *Stream[T] {
+	s := &Stream[T]{
+		w:     w,
+		row:   row,
+		items: make([]T, 0),
+	}
+	switch w.format {
+	case FormatJSONL:
+		s.enc = json.NewEncoder(w.out)
+	case FormatTable:
+		s.table = NewTable(headers...)
+	}
+	return s
+}
+
+// Write adds an item to the stream.
+func (s *Stream[T]) Write(item T) error {
+	s.count++
+
+	switch s.w.format {
+	case FormatJSONL:
+		return s.enc.Encode(item)
+	case FormatQuiet:
+		if cells := s.row(item); len(cells) > 0 {
+			fmt.Fprintln(s.w.out, cells[0])
+		}
+	case FormatJSON, FormatYAML:
+		s.items = append(s.items, item)
+	default:
+		s.table.AddRow(s.row(item)...)
+	}
+	return nil
+}
+
+// Count returns the number of items written so far.
+func (s *Stream[T]) Count() int {
+	return s.count
+}
+
+// Close writes the collected items of formats that cannot stream.
+func (s *Stream[T]) Close() error {
+	switch s.w.format {
+	case FormatJSONL, FormatQuiet:
+		return nil
+	case FormatJSON:
+		return s.w.writeJSON(s.items)
+	case FormatYAML:
+		return s.w.writeYAML(s.items)
+	default:
+		return s.w.renderTable(s.table)
+	}
+}
+
+// Receiver is a server stream, such as a generated gRPC stream client.
+type Receiver[M any] interface {
+	Recv() (M, error)
+}
+
+// StreamError is returned by Copy if a stream fails before its end. The
+// items received before the failure have already been written.
+type StreamError struct {
+	// Received is the number of items received before the failure
+	Received int
+
+	// Err is the stream error
+	Err error
+}
+
+// Error implements error.
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("stream interrupted after %d items: %v", e.Received, e.Err)
+}
+
+// Unwrap returns the stream error.
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// Copy receives messages from r until the end of the stream, converting and
+// writing each one to s, then closes s. It returns nil only if the stream
+// ended cleanly (io.EOF); a failure mid-stream is a *StreamError.
+func Copy[M, T any](s *Stream[T], r Receiver[M], convert func(M) T) error {
+	var streamErr error
+	for {
+		msg, err := r.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			streamErr = &StreamError{Received: s.Count(), Err: err}
+			break
+		}
+		if err := s.Write(convert(msg)); err != nil {
+			streamErr = err
+			break
+		}
+	}
+
+	// Items received before a failure are still written
+	if err := s.Close(); err != nil && streamErr == nil {
+		return err
+	}
+	return streamErr
+}
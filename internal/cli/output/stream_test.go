@@ -0,0 +1,142 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+}
+
+func itemRow(i item) []string {
+	return []string{i.ID, i.Name}
+}
+
+// fakeReceiver returns msgs, then err (io.EOF for a clean end)
+type fakeReceiver struct {
+	msgs []string
+	err  error
+}
+
+func (r *fakeReceiver) Recv() (string, error) {
+	if len(r.msgs) == 0 {
+		return "", r.err
+	}
+	msg := r.msgs[0]
+	r.msgs = r.msgs[1:]
+	return msg, nil
+}
+
+func toItem(s string) item {
+	return item{ID: s, Name: "name-" + s}
+}
+
+func TestStream_JSONLWritesImmediately(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewStream(NewWriter(FormatJSONL).WithOutput(&buf), []string{"id", "name"}, itemRow)
+
+	if err := s.Write(item{ID: "a", Name: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != `{"id":"a","name":"first"}`+"\n" {
+		t.Errorf("expected first line before Close, got %q", got)
+	}
+
+	_ = s.Write(item{ID: "b", Name: "second"})
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 {
+		t.Errorf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+}
+
+func TestStream_BufferedFormats(t *testing.T) {
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{FormatJSON, "[\n  {\n    \"id\": \"a\",\n    \"name\": \"first\"\n  }\n]\n"},
+		{FormatYAML, "- id: a\n  name: first\n"},
+		{FormatTable, "ID  NAME\na   first\n"},
+		{FormatQuiet, "a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			s := NewStream(NewWriter(tt.format).WithOutput(&buf), []string{"id", "name"}, itemRow)
+			_ = s.Write(item{ID: "a", Name: "first"})
+			if err := s.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("got %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestStream_EmptyJSON(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewStream(NewWriter(FormatJSON).WithOutput(&buf), []string{"id"}, itemRow)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("expected empty array, got %q", got)
+	}
+}
+
+func TestCopy_CleanEnd(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewStream(NewWriter(FormatJSONL).WithOutput(&buf), []string{"id", "name"}, itemRow)
+
+	err := Copy(s, &fakeReceiver{msgs: []string{"a", "b", "c"}, err: io.EOF}, toItem)
+	if err != nil {
+		t.Fatalf("expected nil at clean end of stream, got %v", err)
+	}
+	if s.Count() != 3 {
+		t.Errorf("expected 3 items, got %d", s.Count())
+	}
+}
+
+func TestCopy_MidStreamError(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewStream(NewWriter(FormatJSONL).WithOutput(&buf), []string{"id", "name"}, itemRow)
+	cause := errors.New("connection reset")
+
+	err := Copy(s, &fakeReceiver{msgs: []string{"a", "b"}, err: cause}, toItem)
+
+	var streamErr *StreamError
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("expected *StreamError, got %v", err)
+	}
+	if streamErr.Received != 2 {
+		t.Errorf("expected 2 items received, got %d", streamErr.Received)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected StreamError to unwrap to the cause")
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2 {
+		t.Errorf("expected received items to be written, got %q", buf.String())
+	}
+}
+
+func TestWriter_JSONL(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(ParseFormat("jsonl")).WithOutput(&buf)
+
+	if err := w.Write([]item{{ID: "a"}, {ID: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"a","name":""}` + "\n" + `{"id":"b","name":""}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
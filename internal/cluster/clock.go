@@ -0,0 +1,114 @@
+package cluster
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultMaxClockSkew is the clock skew tolerated between cluster members
+// when cluster.max_clock_skew is not set.
+const DefaultMaxClockSkew = time.Second
+
+// clockSampleTTL is how long a skew measurement is reported after the last
+// message from a peer.
+const clockSampleTTL = 5 * time.Minute
+
+// PeerClockSkew is the measured clock offset of a cluster member. Skew is
+// positive if the peer's clock is ahead of this node's. It includes the
+// network delay of the message it was measured on, which is small next to
+// the skews that matter.
+type PeerClockSkew struct {
+	NodeID     string        `json:"node_id"`
+	Skew       time.Duration `json:"skew"`
+	ObservedAt time.Time     `json:"observed_at"`
+}
+
+// ClockSkewStatus reports the clock skew between this node and the cluster
+// members it recently heard from. Large skew breaks leases and makes
+// certificates appear not yet valid or expired.
+type ClockSkewStatus struct {
+	// Threshold is the largest skew tolerated
+	Threshold time.Duration `json:"threshold"`
+
+	// Max is the member with the largest absolute skew, if any was measured
+	Max *PeerClockSkew `json:"max,omitempty"`
+
+	// Exceeded reports whether any member's skew is beyond Threshold
+	Exceeded bool `json:"exceeded"`
+
+	// Peers are the measurements, sorted by node ID
+	Peers []PeerClockSkew `json:"peers"`
+}
+
+// recordClockSkew records the skew of a message sent at sentAt (Unix
+// nanoseconds) by from and logs when the peer's skew crosses the threshold.
+// Must be called with rn.mu held.
+func (rn *RaftNode) recordClockSkew(from string, sentAt int64, now time.Time) {
+	if sentAt == 0 || from == "" {
+		return
+	}
+
+	skew := time.Unix(0, sentAt).Sub(now)
+	prev, seen := rn.clockSkew[from]
+	rn.clockSkew[from] = PeerClockSkew{NodeID: from, Skew: skew, ObservedAt: now}
+
+	threshold := rn.maxClockSkew()
+	exceeded := absDuration(skew) > threshold
+	wasExceeded := seen && absDuration(prev.Skew) > threshold
+	switch {
+	case exceeded && !wasExceeded:
+		getLogger("raft").Warn("clock skew with cluster member exceeds threshold; leases and certificates may misbehave",
+			"node_id", from,
+			"skew", skew,
+			"threshold", threshold,
+		)
+	case !exceeded && wasExceeded:
+		getLogger("raft").Info("clock skew with cluster member back within threshold",
+			"node_id", from,
+			"skew", skew,
+		)
+	}
+}
+
+// ClockSkew returns the clock skew to the members heard from recently.
+func (rn *RaftNode) ClockSkew() ClockSkewStatus {
+	rn.mu.RLock()
+	defer rn.mu.RUnlock()
+
+	status := ClockSkewStatus{Threshold: rn.maxClockSkew()}
+	now := rn.now()
+	for _, sample := range rn.clockSkew {
+		if now.Sub(sample.ObservedAt) > clockSampleTTL {
+			continue
+		}
+		status.Peers = append(status.Peers, sample)
+	}
+	sort.Slice(status.Peers, func(i, j int) bool {
+		return status.Peers[i].NodeID < status.Peers[j].NodeID
+	})
+
+	for i, sample := range status.Peers {
+		if status.Max == nil || absDuration(sample.Skew) > absDuration(status.Max.Skew) {
+			status.Max = &status.Peers[i]
+		}
+	}
+	if status.Max != nil {
+		status.Exceeded = absDuration(status.Max.Skew) > status.Threshold
+	}
+	return status
+}
+
+// maxClockSkew returns the configured skew threshold.
+func (rn *RaftNode) maxClockSkew() time.Duration {
+	if rn.cfg.MaxClockSkew > 0 {
+		return rn.cfg.MaxClockSkew
+	}
+	return DefaultMaxClockSkew
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"bib/internal/config"
+)
+
+func TestRaftNode_ClockSkew(t *testing.T) {
+	rn := newTestRaftNode(t, "node-1")
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rn.mu.Lock()
+	rn.now = func() time.Time { return now }
+	for _, id := range []string{"node-2", "node-3", "node-4"} {
+		rn.members[id] = "127.0.0.1:1"
+	}
+	rn.mu.Unlock()
+
+	if status := rn.ClockSkew(); status.Max != nil || status.Exceeded || status.Threshold != DefaultMaxClockSkew {
+		t.Fatalf("ClockSkew() before any message = %+v", status)
+	}
+
+	// node-2 is 200ms ahead, node-3 3s behind
+	rn.handleMessage("node-2", &RaftMessage{Type: MsgTypeAppendEntriesResp, From: "node-2", SentAt: now.Add(200 * time.Millisecond).UnixNano()})
+	rn.handleMessage("node-3", &RaftMessage{Type: MsgTypeAppendEntriesResp, From: "node-3", SentAt: now.Add(-3 * time.Second).UnixNano()})
+	rn.handleMessage("node-4", &RaftMessage{Type: MsgTypeAppendEntriesResp, From: "node-4"})
+
+	status := rn.ClockSkew()
+	if len(status.Peers) != 2 || status.Peers[0].NodeID != "node-2" || status.Peers[0].Skew != 200*time.Millisecond {
+		t.Fatalf("Peers = %+v", status.Peers)
+	}
+	if status.Max == nil || status.Max.NodeID != "node-3" || status.Max.Skew != -3*time.Second {
+		t.Errorf("Max = %+v, want node-3 at -3s", status.Max)
+	}
+	if !status.Exceeded {
+		t.Error("Exceeded = false with 3s skew")
+	}
+
+	t.Run("skew back within threshold", func(t *testing.T) {
+		rn.handleMessage("node-3", &RaftMessage{Type: MsgTypeAppendEntriesResp, From: "node-3", SentAt: now.UnixNano()})
+		if status := rn.ClockSkew(); status.Exceeded || status.Max.NodeID != "node-2" {
+			t.Errorf("ClockSkew() = %+v, want node-2 max and not exceeded", status)
+		}
+	})
+
+	t.Run("old measurements expire", func(t *testing.T) {
+		rn.mu.Lock()
+		rn.now = func() time.Time { return now.Add(clockSampleTTL + time.Second) }
+		rn.mu.Unlock()
+
+		if status := rn.ClockSkew(); len(status.Peers) != 0 || status.Max != nil {
+			t.Errorf("ClockSkew() = %+v, want no measurements", status)
+		}
+	})
+}
+
+func TestTransport_StampsSentAt(t *testing.T) {
+	a := newTestTransport(t, "node-a")
+	b := newTestTransport(t, "node-b")
+
+	received := make(chan *RaftMessage, 1)
+	b.SetHandler(func(_ string, msg *RaftMessage) { received <- msg })
+
+	if err := a.Connect("node-b", b.LocalAddr()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	before := time.Now()
+	if err := a.Send(&RaftMessage{Type: MsgTypeAppendEntries, From: "node-a", To: "node-b"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		sentAt := time.Unix(0, msg.SentAt)
+		if sentAt.Before(before) || sentAt.After(time.Now()) {
+			t.Errorf("SentAt = %v, want the time of sending", sentAt)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+}
+
+func newTestTransport(t *testing.T, nodeID string) *Transport {
+	t.Helper()
+	cfg := config.ClusterConfig{ListenAddr: "127.0.0.1:0", TLS: clusterCA().issue(t, nodeID)}
+	transport, err := NewTransport(cfg, nodeID)
+	if err != nil {
+		t.Fatalf("failed to create transport: %v", err)
+	}
+	t.Cleanup(func() { transport.Close() })
+	return transport
+}
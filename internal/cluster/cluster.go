@@ -21,6 +21,7 @@ var (
 	ErrNodeNotFound    = errors.New("node not found")
 	ErrAlreadyMember   = errors.New("node is already a cluster member")
 	ErrMinimumNodes    = errors.New("minimum cluster size is 3 voting nodes")
+	ErrFenced          = errors.New("write fenced: leadership was superseded")
 )
 
 // MinimumVoters is the minimum number of voting nodes for a cluster
@@ -93,12 +94,16 @@ type Cluster struct {
 	transport *Transport
 	raft      *RaftNode
 
+	// snapshotKey encrypts snapshots at rest
+	snapshotKey []byte
+
 	// FSM for state machine
 	fsm *FSM
 
 	// Event callbacks
 	onLeaderChange func(leaderID string)
 	onMemberChange func(members []ClusterMember)
+	onFence        func(event FencingEvent)
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -164,6 +169,16 @@ func (c *Cluster) Start(ctx context.Context) error {
 	}
 	c.storage = storage
 
+	if c.snapshotKey != nil {
+		if err := storage.SetSnapshotKey(c.snapshotKey); err != nil {
+			_ = storage.Close()
+			return fmt.Errorf("failed to set snapshot key: %w", err)
+		}
+	} else if c.cfg.Snapshot.Encrypt {
+		_ = storage.Close()
+		return fmt.Errorf("snapshot encryption enabled but no snapshot key set: %w", ErrSnapshotKeyRequired)
+	}
+
 	// Initialize FSM
 	clusterLog.Debug("initializing FSM")
 	c.fsm = NewFSM(c.storage)
@@ -188,6 +203,7 @@ func (c *Cluster) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize raft node: %w", err)
 	}
 	c.raft = raftNode
+	raftNode.OnFence(c.handleFence)
 
 	// Bootstrap if this is the first node
 	if c.cfg.Bootstrap {
@@ -359,26 +375,42 @@ func (c *Cluster) AddNonVoter(nodeID, address string) error {
 
 // RemoveNode removes a node from the cluster (leader only)
 func (c *Cluster) RemoveNode(nodeID string) error {
+	if _, err := c.CheckRemoveNode(nodeID); err != nil {
+		return err
+	}
+	return c.raft.RemoveNode(nodeID)
+}
+
+// MemberRemoval describes the effect of removing a cluster member.
+type MemberRemoval struct {
+	Member          ClusterMember
+	RemainingVoters int
+}
+
+// CheckRemoveNode reports what RemoveNode would remove, or the error it
+// would fail with, without changing the cluster.
+func (c *Cluster) CheckRemoveNode(nodeID string) (*MemberRemoval, error) {
 	if !c.IsLeader() {
-		return ErrNotLeader
+		return nil, ErrNotLeader
 	}
 
-	c.mu.Lock()
-	if _, exists := c.members[nodeID]; !exists {
-		c.mu.Unlock()
-		return ErrNodeNotFound
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	member, exists := c.members[nodeID]
+	if !exists {
+		return nil, ErrNodeNotFound
 	}
 
 	// Check minimum cluster size
-	voterCount := c.countVoters()
-	member := c.members[nodeID]
-	if member.Role == RoleVoter && voterCount <= MinimumVoters {
-		c.mu.Unlock()
-		return ErrMinimumNodes
+	voters := c.countVoters()
+	if member.Role == RoleVoter {
+		if voters <= MinimumVoters {
+			return nil, ErrMinimumNodes
+		}
+		voters--
 	}
-	c.mu.Unlock()
-
-	return c.raft.RemoveNode(nodeID)
+	return &MemberRemoval{Member: *member, RemainingVoters: voters}, nil
 }
 
 // GenerateJoinToken generates a join token for new nodes (leader only)
@@ -448,6 +480,42 @@ func (c *Cluster) ListSnapshots() ([]SnapshotMeta, error) {
 	return c.storage.ListSnapshots()
 }
 
+// LatestSnapshot returns the most recent snapshot, or nil if there is none
+func (c *Cluster) LatestSnapshot() (*SnapshotMeta, error) {
+	return c.storage.GetLatestSnapshot()
+}
+
+// SnapshotsToPrune returns the snapshots that taking a snapshot now would
+// remove to stay within snapshot.retain_count.
+func (c *Cluster) SnapshotsToPrune() ([]SnapshotMeta, error) {
+	return c.storage.SnapshotsToPrune(1)
+}
+
+// ClockSkew returns the clock skew between this node and the members it
+// recently heard from.
+func (c *Cluster) ClockSkew() ClockSkewStatus {
+	c.mu.RLock()
+	raft := c.raft
+	c.mu.RUnlock()
+
+	if raft == nil {
+		threshold := c.cfg.MaxClockSkew
+		if threshold <= 0 {
+			threshold = DefaultMaxClockSkew
+		}
+		return ClockSkewStatus{Threshold: threshold}
+	}
+	return raft.ClockSkew()
+}
+
+// SetSnapshotKey sets the key snapshots are encrypted with, normally the
+// node's identity key. It must be called before Start.
+func (c *Cluster) SetSnapshotKey(key []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshotKey = key
+}
+
 // OnLeaderChange sets a callback for leader changes
 func (c *Cluster) OnLeaderChange(fn func(leaderID string)) {
 	c.mu.Lock()
@@ -462,6 +530,26 @@ func (c *Cluster) OnMemberChange(fn func(members []ClusterMember)) {
 	c.onMemberChange = fn
 }
 
+// OnFence sets a callback for fencing events, raised when this node steps
+// down as leader because it may have been superseded. Writes it had not
+// committed yet fail with ErrFenced.
+func (c *Cluster) OnFence(fn func(event FencingEvent)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onFence = fn
+}
+
+// handleFence passes a fencing event of the Raft node on.
+func (c *Cluster) handleFence(event FencingEvent) {
+	c.mu.RLock()
+	fn := c.onFence
+	c.mu.RUnlock()
+
+	if fn != nil {
+		fn(event)
+	}
+}
+
 // Apply applies a command to the cluster (leader only)
 // This is used to replicate metadata changes across the cluster
 func (c *Cluster) Apply(cmd []byte) error {
@@ -472,6 +560,16 @@ func (c *Cluster) Apply(cmd []byte) error {
 	return c.raft.Apply(cmd)
 }
 
+// ReplicateTopicMembership replicates a user's topic membership state
+// to the cluster (leader only)
+func (c *Cluster) ReplicateTopicMembership(m *ReplicatedTopicMembership) error {
+	cmd, err := CreateCommand(CmdTopicMembership, m)
+	if err != nil {
+		return err
+	}
+	return c.Apply(cmd)
+}
+
 // bootstrap initializes a new cluster
 func (c *Cluster) bootstrap() error {
 	return c.raft.Bootstrap(c.nodeID, c.cfg.ListenAddr)
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -363,6 +364,94 @@ func TestStorageSnapshot(t *testing.T) {
 	}
 }
 
+func TestStorageSnapshotsToPrune(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := config.ClusterConfig{
+		DataDir: tempDir,
+		Snapshot: config.SnapshotConfig{
+			RetainCount: 3,
+		},
+	}
+
+	s, err := NewStorage(cfg, tempDir)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	for i := uint64(1); i <= 3; i++ {
+		if _, err := s.CreateSnapshot(i*100, 1, nil, []byte("snapshot data")); err != nil {
+			t.Fatalf("failed to create snapshot: %v", err)
+		}
+	}
+
+	pruned, err := s.SnapshotsToPrune(0)
+	if err != nil {
+		t.Fatalf("SnapshotsToPrune(0) error = %v", err)
+	}
+	if len(pruned) != 0 {
+		t.Errorf("SnapshotsToPrune(0) = %+v, want none within retention", pruned)
+	}
+
+	pruned, err = s.SnapshotsToPrune(1)
+	if err != nil {
+		t.Fatalf("SnapshotsToPrune(1) error = %v", err)
+	}
+	if len(pruned) != 1 || pruned[0].Index != 100 {
+		t.Errorf("SnapshotsToPrune(1) = %+v, want the oldest snapshot", pruned)
+	}
+
+	// Nothing was removed
+	snapshots, err := s.ListSnapshots()
+	if err != nil {
+		t.Fatalf("failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 3 {
+		t.Errorf("expected 3 snapshots, got %d", len(snapshots))
+	}
+}
+
+func TestCluster_CheckRemoveNode(t *testing.T) {
+	c := newTestLeader(t)
+	c.members = map[string]*ClusterMember{
+		"node-a": {NodeID: "node-a", Role: RoleVoter},
+		"node-b": {NodeID: "node-b", Role: RoleVoter},
+		"node-c": {NodeID: "node-c", Role: RoleVoter},
+		"node-d": {NodeID: "node-d", Role: RoleVoter},
+		"node-e": {NodeID: "node-e", Role: RoleNonVoter},
+	}
+
+	removal, err := c.CheckRemoveNode("node-d")
+	if err != nil {
+		t.Fatalf("CheckRemoveNode() error = %v", err)
+	}
+	if removal.Member.NodeID != "node-d" || removal.RemainingVoters != 3 {
+		t.Errorf("CheckRemoveNode() = %+v", removal)
+	}
+	if len(c.members) != 5 {
+		t.Error("CheckRemoveNode() changed the members")
+	}
+
+	removal, err = c.CheckRemoveNode("node-e")
+	if err != nil || removal.RemainingVoters != 4 {
+		t.Errorf("CheckRemoveNode(non-voter) = %+v, %v", removal, err)
+	}
+
+	if _, err := c.CheckRemoveNode("node-x"); !errors.Is(err, ErrNodeNotFound) {
+		t.Errorf("CheckRemoveNode(unknown) error = %v, want ErrNodeNotFound", err)
+	}
+
+	delete(c.members, "node-d")
+	if _, err := c.CheckRemoveNode("node-c"); !errors.Is(err, ErrMinimumNodes) {
+		t.Errorf("CheckRemoveNode() at minimum voters error = %v, want ErrMinimumNodes", err)
+	}
+
+	c.state = StateFollower
+	if _, err := c.CheckRemoveNode("node-e"); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("CheckRemoveNode() on follower error = %v, want ErrNotLeader", err)
+	}
+}
+
 func TestFSM(t *testing.T) {
 	tempDir := t.TempDir()
 	cfg := config.ClusterConfig{
@@ -453,3 +542,66 @@ func TestFSM(t *testing.T) {
 		t.Errorf("expected restored config value 'test-value', got %s", string(value2))
 	}
 }
+
+func TestFSM_TopicMembership(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := config.ClusterConfig{
+		DataDir: tempDir,
+	}
+
+	s, err := NewStorage(cfg, tempDir)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	fsm := NewFSM(s)
+
+	apply := func(state, role string) {
+		t.Helper()
+		cmd, err := CreateCommand(CmdTopicMembership, ReplicatedTopicMembership{
+			TopicID:   "topic-1",
+			UserID:    "user-1",
+			State:     state,
+			Role:      role,
+			UpdatedAt: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("failed to create command: %v", err)
+		}
+		if err := fsm.Apply(cmd); err != nil {
+			t.Fatalf("failed to apply command: %v", err)
+		}
+	}
+
+	apply("pending", "viewer")
+	if m := fsm.GetTopicMembership("topic-1", "user-1"); m == nil || m.State != "pending" {
+		t.Fatalf("expected pending membership, got %+v", m)
+	}
+
+	apply("member", "viewer")
+	snapshotData, err := fsm.Snapshot()
+	if err != nil {
+		t.Fatalf("failed to create snapshot: %v", err)
+	}
+
+	s2, err := NewStorage(cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage2: %v", err)
+	}
+	defer s2.Close()
+
+	fsm2 := NewFSM(s2)
+	if err := fsm2.Restore(snapshotData); err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+	if m := fsm2.GetTopicMembership("topic-1", "user-1"); m == nil || m.State != "member" || m.Role != "viewer" {
+		t.Errorf("expected restored membership, got %+v", m)
+	}
+
+	// Leaving the topic removes the membership
+	apply("none", "")
+	if m := fsm.GetTopicMembership("topic-1", "user-1"); m != nil {
+		t.Errorf("expected no membership, got %+v", m)
+	}
+}
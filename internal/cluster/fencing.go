@@ -0,0 +1,170 @@
+package cluster
+
+import (
+	"time"
+)
+
+// FenceReason is why a leader was fenced
+type FenceReason string
+
+const (
+	// FenceHigherTerm means another node was seen with a higher term, so a
+	// newer leader may have been elected
+	FenceHigherTerm FenceReason = "higher_term"
+)
+
+// FencingEvent records a leader stepping down so it cannot commit writes
+// after being superseded, e.g. when a partition heals.
+type FencingEvent struct {
+	NodeID       string      `json:"node_id"`
+	Reason       FenceReason `json:"reason"`
+	Term         uint64      `json:"term"`
+	ObservedTerm uint64      `json:"observed_term,omitempty"`
+	ObservedFrom string      `json:"observed_from,omitempty"`
+	Timestamp    time.Time   `json:"timestamp"`
+}
+
+// proposal is a write waiting to be committed. It may only commit in the
+// term it was proposed in.
+type proposal struct {
+	term uint64
+	data []byte
+	done chan error
+
+	// applied receives the FSM's result; nil if nobody waits for it
+	applied chan error
+}
+
+// OnFence sets a callback for fencing events
+func (rn *RaftNode) OnFence(fn func(FencingEvent)) {
+	rn.mu.Lock()
+	defer rn.mu.Unlock()
+	rn.onFence = fn
+}
+
+// checkLeadership returns the term a write may be proposed in.
+func (rn *RaftNode) checkLeadership() (uint64, error) {
+	rn.mu.RLock()
+	defer rn.mu.RUnlock()
+	if rn.state != StateLeader {
+		return 0, ErrNotLeader
+	}
+	return rn.term, nil
+}
+
+// handleMessage processes a message from a peer, received on a connection
+// authenticated as from. A higher term makes a leader step down; a request
+// from a lower term is rejected with the current term, so a stale leader
+// learns it was superseded. Messages from nodes that are not members, or
+// claiming to be sent by another node than the connection's, are dropped.
+func (rn *RaftNode) handleMessage(from string, msg *RaftMessage) {
+	if msg.From != from {
+		getLogger("raft").Warn("dropping message with spoofed sender", "from", from, "claimed_from", msg.From)
+		return
+	}
+
+	rn.mu.Lock()
+	if _, ok := rn.members[from]; !ok {
+		rn.mu.Unlock()
+		getLogger("raft").Debug("dropping message from non-member", "from", from)
+		return
+	}
+
+	rn.recordClockSkew(from, msg.SentAt, rn.now())
+
+	var event *FencingEvent
+	var reply *RaftMessage
+	switch {
+	case msg.Term > rn.term:
+		leader := ""
+		if msg.Type == MsgTypeAppendEntries || msg.Type == MsgTypeInstallSnapshot {
+			leader = from
+		}
+		event = rn.stepDown(FenceHigherTerm, msg.Term, from, leader)
+	case msg.Term < rn.term:
+		if respType, ok := responseType(msg.Type); ok {
+			reply = &RaftMessage{
+				Type:   respType,
+				From:   rn.nodeID,
+				To:     from,
+				Term:   rn.term,
+				Reject: true,
+			}
+		}
+	}
+	rn.mu.Unlock()
+
+	rn.fenced(event)
+	if reply != nil && rn.transport != nil {
+		if err := rn.transport.Send(reply); err != nil {
+			getLogger("raft").Debug("failed to reject stale request", "to", from, "error", err)
+		}
+	}
+}
+
+// stepDown moves to term as a follower of leader (empty if unknown) and
+// persists the new term. It returns a fencing event if this node was the
+// leader. Must be called with rn.mu held.
+func (rn *RaftNode) stepDown(reason FenceReason, term uint64, from, leader string) *FencingEvent {
+	wasLeader := rn.state == StateLeader
+	oldTerm := rn.term
+
+	if term > rn.term {
+		rn.term = term
+		if err := rn.storage.SetHardState(&HardState{Term: rn.term, Commit: rn.commitIndex}); err != nil {
+			getLogger("raft").Error("failed to persist term", "term", rn.term, "error", err)
+		}
+	}
+	rn.state = StateFollower
+	rn.leader = leader
+
+	if !wasLeader {
+		return nil
+	}
+	event := &FencingEvent{
+		NodeID:    rn.nodeID,
+		Reason:    reason,
+		Term:      oldTerm,
+		Timestamp: rn.now(),
+	}
+	if reason == FenceHigherTerm {
+		event.ObservedTerm = term
+		event.ObservedFrom = from
+	}
+	return event
+}
+
+// fenced logs event and passes it to the fencing callback.
+func (rn *RaftNode) fenced(event *FencingEvent) {
+	if event == nil {
+		return
+	}
+
+	getLogger("raft").Warn("leader fenced, stepping down",
+		"reason", event.Reason,
+		"term", event.Term,
+		"observed_term", event.ObservedTerm,
+		"observed_from", event.ObservedFrom,
+	)
+
+	rn.mu.RLock()
+	fn := rn.onFence
+	rn.mu.RUnlock()
+	if fn != nil {
+		fn(*event)
+	}
+}
+
+// responseType returns the response type of a request message type.
+func responseType(msgType uint8) (uint8, bool) {
+	switch msgType {
+	case MsgTypeRequestVote:
+		return MsgTypeRequestVoteResp, true
+	case MsgTypeAppendEntries:
+		return MsgTypeAppendEntriesResp, true
+	case MsgTypeInstallSnapshot:
+		return MsgTypeInstallSnapshotResp, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,201 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+)
+
+func newTestRaftNode(t *testing.T, nodeID string) *RaftNode {
+	t.Helper()
+	tempDir := t.TempDir()
+	cfg := config.ClusterConfig{
+		DataDir:    tempDir,
+		ListenAddr: "127.0.0.1:0",
+		TLS:        clusterCA().issue(t, nodeID),
+		Raft: config.RaftConfig{
+			ElectionTimeout: time.Second,
+		},
+	}
+
+	s, err := NewStorage(cfg, tempDir)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	transport, err := NewTransport(cfg, nodeID)
+	if err != nil {
+		t.Fatalf("failed to create transport: %v", err)
+	}
+	t.Cleanup(func() { transport.Close() })
+
+	rn, err := NewRaftNode(cfg, nodeID, s, transport, NewFSM(s))
+	if err != nil {
+		t.Fatalf("failed to create raft node: %v", err)
+	}
+	t.Cleanup(func() { rn.Shutdown() })
+
+	if err := rn.Bootstrap(nodeID, transport.LocalAddr()); err != nil {
+		t.Fatalf("failed to bootstrap: %v", err)
+	}
+	return rn
+}
+
+func configSetCommand(t *testing.T, key, value string) []byte {
+	t.Helper()
+	cmd, err := CreateCommand(CmdConfigSet, struct {
+		Key   string `json:"key"`
+		Value []byte `json:"value"`
+	}{Key: key, Value: []byte(value)})
+	if err != nil {
+		t.Fatalf("failed to create command: %v", err)
+	}
+	return cmd
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFencing_PartitionAndRemerge(t *testing.T) {
+	// node-a leads term 1
+	a := newTestRaftNode(t, "node-a")
+	fences := make(chan FencingEvent, 1)
+	a.OnFence(func(event FencingEvent) { fences <- event })
+
+	if err := a.Apply(configSetCommand(t, "before", "ok")); err != nil {
+		t.Fatalf("Apply() as leader error = %v", err)
+	}
+	waitFor(t, "the write to apply", func() bool { return a.fsm.GetConfig("before") != nil })
+
+	// A partition cuts node-a off; the majority side elects node-b in term 2
+	b := newTestRaftNode(t, "node-b")
+	b.mu.Lock()
+	b.term = 2
+	b.members["node-a"] = a.transport.LocalAddr()
+	b.mu.Unlock()
+	a.mu.Lock()
+	a.members["node-b"] = b.transport.LocalAddr()
+	a.mu.Unlock()
+
+	// A write node-a accepted before the partition healed
+	inFlight := &proposal{term: a.Term(), data: configSetCommand(t, "stale", "lost"), done: make(chan error, 1)}
+
+	// The partition heals: node-a's heartbeat reaches node-b, which rejects
+	// it with the newer term
+	if err := a.transport.Connect("node-b", b.transport.LocalAddr()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if err := a.transport.Send(&RaftMessage{Type: MsgTypeAppendEntries, From: "node-a", To: "node-b", Term: 1}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	var event FencingEvent
+	select {
+	case event = <-fences:
+	case <-time.After(5 * time.Second):
+		t.Fatal("node-a was not fenced")
+	}
+	if event.Reason != FenceHigherTerm || event.Term != 1 || event.ObservedTerm != 2 || event.ObservedFrom != "node-b" {
+		t.Errorf("fencing event = %+v", event)
+	}
+
+	if state := a.State(); state != StateFollower {
+		t.Errorf("node-a state = %s, want follower", state)
+	}
+	if term := a.Term(); term != 2 {
+		t.Errorf("node-a term = %d, want 2", term)
+	}
+	hs, err := a.storage.GetHardState()
+	if err != nil || hs.Term != 2 {
+		t.Errorf("persisted term = %+v (%v), want 2", hs, err)
+	}
+
+	// The in-flight write is rejected rather than committed
+	a.proposeCh <- inFlight
+	if err := <-inFlight.done; !errors.Is(err, ErrFenced) {
+		t.Errorf("in-flight write error = %v, want ErrFenced", err)
+	}
+	if a.fsm.GetConfig("stale") != nil {
+		t.Error("stale write was committed")
+	}
+
+	// New writes are refused, the new leader keeps serving
+	if err := a.Apply(configSetCommand(t, "after", "refused")); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("Apply() after fencing error = %v, want ErrNotLeader", err)
+	}
+	if state := b.State(); state != StateLeader {
+		t.Errorf("node-b state = %s, want leader", state)
+	}
+	if err := b.Apply(configSetCommand(t, "after", "ok")); err != nil {
+		t.Errorf("Apply() on new leader error = %v", err)
+	}
+}
+
+func TestFencing_UntrustedMessages(t *testing.T) {
+	a := newTestRaftNode(t, "node-a")
+	a.mu.Lock()
+	a.members["node-b"] = "127.0.0.1:1"
+	a.mu.Unlock()
+
+	tests := []struct {
+		name string
+		from string
+		msg  *RaftMessage
+	}{
+		{"spoofed sender", "node-x", &RaftMessage{Type: MsgTypeAppendEntries, From: "node-b", Term: 99}},
+		{"not a member", "node-z", &RaftMessage{Type: MsgTypeAppendEntries, From: "node-z", Term: 99}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a.handleMessage(tt.from, tt.msg)
+			if state, term := a.State(), a.Term(); state != StateLeader || term != 1 {
+				t.Errorf("after message: state = %s, term = %d; want leader in term 1", state, term)
+			}
+		})
+	}
+
+	// Messages of a member on its own connection are processed
+	a.handleMessage("node-b", &RaftMessage{Type: MsgTypeAppendEntries, From: "node-b", Term: 2})
+	if state, leader := a.State(), a.Leader(); state != StateFollower || leader != "node-b" {
+		t.Errorf("after message from node-b: state = %s, leader = %q; want follower of node-b", state, leader)
+	}
+}
+
+func TestNewRaftNode_UnauthenticatedTransport(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.ClusterConfig{DataDir: dir, ListenAddr: "127.0.0.1:0"}
+	s, err := NewStorage(cfg, dir)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+	transport, err := NewTransport(cfg, "node-a")
+	if err != nil {
+		t.Fatalf("failed to create transport: %v", err)
+	}
+	defer transport.Close()
+
+	rn, err := NewRaftNode(cfg, "node-a", s, transport, NewFSM(s))
+	if err != nil {
+		t.Fatalf("failed to create raft node: %v", err)
+	}
+	defer rn.Shutdown()
+
+	// Without TLS, peers can't be trusted, so their messages are ignored
+	transport.mu.RLock()
+	defer transport.mu.RUnlock()
+	if transport.handler != nil {
+		t.Error("message handler set on a plaintext transport")
+	}
+}
@@ -26,6 +26,14 @@ const (
 	CmdConfigDelete
 	// CmdJoinTokenCreate creates a join token
 	CmdJoinTokenCreate
+	// CmdTopicMembership sets a user's membership state in a topic
+	CmdTopicMembership
+	// CmdLockAcquire acquires a lease on a named lock
+	CmdLockAcquire
+	// CmdLockRenew extends a lease
+	CmdLockRenew
+	// CmdLockRelease releases a lease
+	CmdLockRelease
 )
 
 // Command represents a command to be applied to the FSM
@@ -40,6 +48,8 @@ type Command struct {
 // - Global catalog (authoritative source)
 // - Job scheduling/assignments
 // - Global configuration
+// - Topic membership state
+// - Distributed lock leases
 type FSM struct {
 	storage *Storage
 	mu      sync.RWMutex
@@ -48,6 +58,9 @@ type FSM struct {
 	catalog map[string]*ReplicatedCatalogEntry // key: topicID/datasetID
 	jobs    map[string]*ReplicatedJob
 	config  map[string][]byte
+
+	memberships map[string]*ReplicatedTopicMembership // key: topicID/userID
+	locks       map[string]*Lock                      // key: lock name
 }
 
 // NewFSM creates a new FSM
@@ -57,6 +70,9 @@ func NewFSM(storage *Storage) *FSM {
 		catalog: make(map[string]*ReplicatedCatalogEntry),
 		jobs:    make(map[string]*ReplicatedJob),
 		config:  make(map[string][]byte),
+
+		memberships: make(map[string]*ReplicatedTopicMembership),
+		locks:       make(map[string]*Lock),
 	}
 }
 
@@ -85,6 +101,10 @@ func (f *FSM) Apply(data []byte) error {
 		return f.applyConfigDelete(cmd.Data)
 	case CmdJoinTokenCreate:
 		return f.applyJoinTokenCreate(cmd.Data)
+	case CmdTopicMembership:
+		return f.applyTopicMembership(cmd.Data)
+	case CmdLockAcquire, CmdLockRenew, CmdLockRelease:
+		return f.applyLock(cmd.Type, cmd.Data)
 	default:
 		return fmt.Errorf("unknown command type: %d", cmd.Type)
 	}
@@ -214,6 +234,31 @@ func (f *FSM) applyJoinTokenCreate(data []byte) error {
 	return f.storage.StoreJoinToken(&token)
 }
 
+// applyTopicMembership handles topic membership commands. A membership
+// without a state, or in state "none", is removed.
+func (f *FSM) applyTopicMembership(data []byte) error {
+	var m ReplicatedTopicMembership
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s/%s", m.TopicID, m.UserID)
+	if m.State == "" || m.State == "none" {
+		if err := f.storage.DeleteTopicMembership(m.TopicID, m.UserID); err != nil {
+			return err
+		}
+		delete(f.memberships, key)
+		return nil
+	}
+
+	if err := f.storage.StoreTopicMembership(&m); err != nil {
+		return err
+	}
+	f.memberships[key] = &m
+
+	return nil
+}
+
 // Snapshot creates a snapshot of the FSM state
 func (f *FSM) Snapshot() ([]byte, error) {
 	f.mu.RLock()
@@ -223,10 +268,16 @@ func (f *FSM) Snapshot() ([]byte, error) {
 		Catalog map[string]*ReplicatedCatalogEntry `json:"catalog"`
 		Jobs    map[string]*ReplicatedJob          `json:"jobs"`
 		Config  map[string][]byte                  `json:"config"`
+
+		Memberships map[string]*ReplicatedTopicMembership `json:"memberships,omitempty"`
+		Locks       map[string]*Lock                      `json:"locks,omitempty"`
 	}{
 		Catalog: f.catalog,
 		Jobs:    f.jobs,
 		Config:  f.config,
+
+		Memberships: f.memberships,
+		Locks:       f.locks,
 	}
 
 	return json.Marshal(snapshot)
@@ -238,6 +289,9 @@ func (f *FSM) Restore(data []byte) error {
 		Catalog map[string]*ReplicatedCatalogEntry `json:"catalog"`
 		Jobs    map[string]*ReplicatedJob          `json:"jobs"`
 		Config  map[string][]byte                  `json:"config"`
+
+		Memberships map[string]*ReplicatedTopicMembership `json:"memberships,omitempty"`
+		Locks       map[string]*Lock                      `json:"locks,omitempty"`
 	}
 
 	if err := json.Unmarshal(data, &snapshot); err != nil {
@@ -251,6 +305,14 @@ func (f *FSM) Restore(data []byte) error {
 	f.catalog = snapshot.Catalog
 	f.jobs = snapshot.Jobs
 	f.config = snapshot.Config
+	f.memberships = snapshot.Memberships
+	if f.memberships == nil {
+		f.memberships = make(map[string]*ReplicatedTopicMembership)
+	}
+	f.locks = snapshot.Locks
+	if f.locks == nil {
+		f.locks = make(map[string]*Lock)
+	}
 
 	// Restore storage
 	for _, entry := range f.catalog {
@@ -271,6 +333,12 @@ func (f *FSM) Restore(data []byte) error {
 		}
 	}
 
+	for _, m := range f.memberships {
+		if err := f.storage.StoreTopicMembership(m); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -322,6 +390,14 @@ func (f *FSM) GetConfig(key string) []byte {
 	return f.config[key]
 }
 
+// GetTopicMembership returns a user's membership in a topic, or nil if
+// they have none
+func (f *FSM) GetTopicMembership(topicID, userID string) *ReplicatedTopicMembership {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.memberships[fmt.Sprintf("%s/%s", topicID, userID)]
+}
+
 // CreateCommand creates a command for the given type and data
 func CreateCommand(cmdType CommandType, data interface{}) ([]byte, error) {
 	jsonData, err := json.Marshal(data)
@@ -0,0 +1,231 @@
+package cluster
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"bib/internal/maintenance"
+)
+
+// Lock errors
+var (
+	ErrLockHeld    = errors.New("lock is held by another holder")
+	ErrLockNotHeld = errors.New("lock is not held with this token")
+)
+
+// Lock is a lease on a named lock, replicated through the Raft FSM. The
+// lease expires at ExpiresAt unless renewed, after which any holder may
+// acquire the lock; a crashed holder's lock is thereby released.
+type Lock struct {
+	Name       string    `json:"name"`
+	Holder     string    `json:"holder"`
+	Token      string    `json:"token"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the lease has expired at the given time.
+func (l *Lock) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// lockCommand is the payload of the lock commands. Now is the leader's
+// clock when proposing, so every node evaluates expiry the same way.
+type lockCommand struct {
+	Name   string        `json:"name"`
+	Holder string        `json:"holder,omitempty"`
+	Token  string        `json:"token"`
+	TTL    time.Duration `json:"ttl,omitempty"`
+	Now    time.Time     `json:"now"`
+}
+
+// applyLock handles the lock commands. Expired leases are dropped first.
+func (f *FSM) applyLock(cmdType CommandType, data []byte) error {
+	var cmd lockCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return err
+	}
+
+	for name, l := range f.locks {
+		if l.Expired(cmd.Now) {
+			delete(f.locks, name)
+		}
+	}
+
+	current := f.locks[cmd.Name]
+	switch cmdType {
+	case CmdLockAcquire:
+		if current != nil && current.Holder != cmd.Holder {
+			return ErrLockHeld
+		}
+		if current != nil {
+			// Re-acquiring a held lock extends the holder's lease
+			current.ExpiresAt = cmd.Now.Add(cmd.TTL)
+			return nil
+		}
+		f.locks[cmd.Name] = &Lock{
+			Name:       cmd.Name,
+			Holder:     cmd.Holder,
+			Token:      cmd.Token,
+			AcquiredAt: cmd.Now,
+			ExpiresAt:  cmd.Now.Add(cmd.TTL),
+		}
+	case CmdLockRenew:
+		if current == nil || current.Token != cmd.Token {
+			return ErrLockNotHeld
+		}
+		current.ExpiresAt = cmd.Now.Add(cmd.TTL)
+	case CmdLockRelease:
+		if current == nil || current.Token != cmd.Token {
+			return ErrLockNotHeld
+		}
+		delete(f.locks, cmd.Name)
+	}
+
+	return nil
+}
+
+// GetLock returns the named lock, or nil if it is not held at the given time
+func (f *FSM) GetLock(name string, now time.Time) *Lock {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	l, ok := f.locks[name]
+	if !ok || l.Expired(now) {
+		return nil
+	}
+	lock := *l
+	return &lock
+}
+
+// GetLocks returns the locks held at the given time, sorted by name
+func (f *FSM) GetLocks(now time.Time) []Lock {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	locks := make([]Lock, 0, len(f.locks))
+	for _, l := range f.locks {
+		if !l.Expired(now) {
+			locks = append(locks, *l)
+		}
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].Name < locks[j].Name })
+	return locks
+}
+
+// AcquireLock acquires the named lock for holder with a lease of ttl (leader
+// only). It fails with ErrLockHeld if another holder has an unexpired lease;
+// if holder already has it, the lease is extended and the token kept.
+func (c *Cluster) AcquireLock(name, holder string, ttl time.Duration) (*Lock, error) {
+	if err := validateLock(name, ttl); err != nil {
+		return nil, err
+	}
+	if holder == "" {
+		holder = c.nodeID
+	}
+
+	token, err := generateLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := lockCommand{Name: name, Holder: holder, Token: token, TTL: ttl}
+	return c.applyLock(CmdLockAcquire, cmd)
+}
+
+// RenewLock extends the lease identified by token to ttl from now (leader
+// only). It fails with ErrLockNotHeld if the lease expired or was released.
+func (c *Cluster) RenewLock(name, token string, ttl time.Duration) (*Lock, error) {
+	if err := validateLock(name, ttl); err != nil {
+		return nil, err
+	}
+	return c.applyLock(CmdLockRenew, lockCommand{Name: name, Token: token, TTL: ttl})
+}
+
+// ReleaseLock releases the lease identified by token (leader only).
+func (c *Cluster) ReleaseLock(name, token string) error {
+	if name == "" {
+		return fmt.Errorf("lock name is required")
+	}
+	_, err := c.applyLock(CmdLockRelease, lockCommand{Name: name, Token: token})
+	return err
+}
+
+// GetLock returns the named lock, or nil if it is not held
+func (c *Cluster) GetLock(name string) *Lock {
+	return c.fsm.GetLock(name, time.Now())
+}
+
+// Locks returns the locks currently held, sorted by name
+func (c *Cluster) Locks() []Lock {
+	return c.fsm.GetLocks(time.Now())
+}
+
+// MaintenanceLocker returns the cluster's locks as a maintenance.Locker, so
+// maintenance workers run on one node at a time.
+func (c *Cluster) MaintenanceLocker() maintenance.Locker {
+	return maintenanceLocker{c}
+}
+
+// applyLock replicates a lock command and returns the resulting lock.
+func (c *Cluster) applyLock(cmdType CommandType, cmd lockCommand) (*Lock, error) {
+	if !c.IsLeader() {
+		return nil, ErrNotLeader
+	}
+
+	cmd.Now = time.Now().UTC()
+	data, err := CreateCommand(cmdType, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.raft.ApplySync(data); err != nil {
+		return nil, err
+	}
+
+	return c.fsm.GetLock(cmd.Name, cmd.Now), nil
+}
+
+func validateLock(name string, ttl time.Duration) error {
+	if name == "" {
+		return fmt.Errorf("lock name is required")
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("lock TTL must be positive")
+	}
+	return nil
+}
+
+func generateLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// maintenanceLocker adapts the cluster's locks to maintenance.Locker.
+type maintenanceLocker struct {
+	c *Cluster
+}
+
+func (m maintenanceLocker) TryLock(name, holder string, ttl time.Duration) (string, error) {
+	lock, err := m.c.AcquireLock(name, holder, ttl)
+	if err != nil {
+		return "", err
+	}
+	return lock.Token, nil
+}
+
+func (m maintenanceLocker) Renew(name, token string, ttl time.Duration) error {
+	_, err := m.c.RenewLock(name, token, ttl)
+	return err
+}
+
+func (m maintenanceLocker) Unlock(name, token string) error {
+	return m.c.ReleaseLock(name, token)
+}
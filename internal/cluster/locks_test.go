@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bib/internal/maintenance"
+)
+
+func newTestLeader(t *testing.T) *Cluster {
+	t.Helper()
+	rn := newTestRaftNode(t, "node-a")
+	return &Cluster{
+		nodeID: "node-a",
+		state:  StateLeader,
+		raft:   rn,
+		fsm:    rn.fsm,
+	}
+}
+
+func TestCluster_Locks(t *testing.T) {
+	c := newTestLeader(t)
+
+	lock, err := c.AcquireLock("gc", "node-a", time.Minute)
+	if err != nil {
+		t.Fatalf("AcquireLock() error = %v", err)
+	}
+	if lock.Holder != "node-a" || lock.Token == "" {
+		t.Fatalf("lock = %+v", lock)
+	}
+
+	if _, err := c.AcquireLock("gc", "node-b", time.Minute); !errors.Is(err, ErrLockHeld) {
+		t.Errorf("AcquireLock() by another holder error = %v, want ErrLockHeld", err)
+	}
+
+	again, err := c.AcquireLock("gc", "node-a", time.Minute)
+	if err != nil || again.Token != lock.Token {
+		t.Errorf("re-acquire by holder = %+v, %v; want the same token", again, err)
+	}
+
+	renewed, err := c.RenewLock("gc", lock.Token, time.Hour)
+	if err != nil {
+		t.Fatalf("RenewLock() error = %v", err)
+	}
+	if !renewed.ExpiresAt.After(lock.ExpiresAt) {
+		t.Errorf("renewed lease expires %v, not after %v", renewed.ExpiresAt, lock.ExpiresAt)
+	}
+	if _, err := c.RenewLock("gc", "wrong", time.Hour); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("RenewLock() with a wrong token error = %v, want ErrLockNotHeld", err)
+	}
+
+	if locks := c.Locks(); len(locks) != 1 || locks[0].Name != "gc" {
+		t.Errorf("Locks() = %+v", locks)
+	}
+
+	if err := c.ReleaseLock("gc", lock.Token); err != nil {
+		t.Fatalf("ReleaseLock() error = %v", err)
+	}
+	if err := c.ReleaseLock("gc", lock.Token); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("second ReleaseLock() error = %v, want ErrLockNotHeld", err)
+	}
+	if _, err := c.AcquireLock("gc", "node-b", time.Minute); err != nil {
+		t.Errorf("AcquireLock() after release error = %v", err)
+	}
+}
+
+func TestCluster_LockExpiry(t *testing.T) {
+	c := newTestLeader(t)
+
+	if _, err := c.AcquireLock("vacuum", "crashed", 20*time.Millisecond); err != nil {
+		t.Fatalf("AcquireLock() error = %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	if locks := c.Locks(); len(locks) != 0 {
+		t.Errorf("Locks() after expiry = %+v", locks)
+	}
+	lock, err := c.AcquireLock("vacuum", "node-a", time.Minute)
+	if err != nil {
+		t.Fatalf("AcquireLock() of an expired lock error = %v", err)
+	}
+	if lock.Holder != "node-a" {
+		t.Errorf("holder = %q, want node-a", lock.Holder)
+	}
+}
+
+func TestCluster_LocksFollower(t *testing.T) {
+	c := newTestLeader(t)
+	c.state = StateFollower
+
+	if _, err := c.AcquireLock("gc", "node-a", time.Minute); !errors.Is(err, ErrNotLeader) {
+		t.Errorf("AcquireLock() on a follower error = %v, want ErrNotLeader", err)
+	}
+}
+
+func TestMaintenanceLocker_Exclusive(t *testing.T) {
+	c := newTestLeader(t)
+	l := c.MaintenanceLocker()
+	name := maintenance.LockName(maintenance.WorkerGC)
+
+	if _, err := c.AcquireLock(name, "node-b", time.Minute); err != nil {
+		t.Fatalf("AcquireLock() error = %v", err)
+	}
+	ran, err := maintenance.Exclusive(t.Context(), l, name, "node-a", time.Minute, func(ctx context.Context) error {
+		return nil
+	})
+	if ran || !errors.Is(err, ErrLockHeld) {
+		t.Errorf("Exclusive() while held = %v, %v; want not run with ErrLockHeld", ran, err)
+	}
+}
@@ -27,9 +27,16 @@ type RaftNode struct {
 	commitIndex  uint64
 	appliedIndex uint64
 	members      map[string]string // nodeID -> address
+	clockSkew    map[string]PeerClockSkew
+
+	// onFence is called when this node steps down as leader
+	onFence func(FencingEvent)
+
+	// now returns the current time; tests replace it
+	now func() time.Time
 
 	// Channels for Raft operations
-	proposeCh    chan []byte
+	proposeCh    chan *proposal
 	confChangeCh chan confChange
 	commitCh     chan *commit
 
@@ -49,6 +56,9 @@ type commit struct {
 	index uint64
 	term  uint64
 	data  []byte
+
+	// applied receives the FSM's result, if the proposer waits for it
+	applied chan error
 }
 
 // NewRaftNode creates a new Raft node
@@ -63,7 +73,9 @@ func NewRaftNode(cfg config.ClusterConfig, nodeID string, storage *Storage, tran
 		fsm:          fsm,
 		state:        StateFollower,
 		members:      make(map[string]string),
-		proposeCh:    make(chan []byte, 256),
+		clockSkew:    make(map[string]PeerClockSkew),
+		now:          time.Now,
+		proposeCh:    make(chan *proposal, 256),
 		confChangeCh: make(chan confChange, 16),
 		commitCh:     make(chan *commit, 256),
 		ctx:          ctx,
@@ -76,8 +88,19 @@ func NewRaftNode(cfg config.ClusterConfig, nodeID string, storage *Storage, tran
 		return nil, fmt.Errorf("failed to load raft state: %w", err)
 	}
 
+	// Receive messages from peers, but only if the transport authenticates
+	// them: a message's term can make the leader step down
+	if transport != nil {
+		if transport.Authenticated() {
+			transport.SetHandler(rn.handleMessage)
+		} else {
+			getLogger("raft").Warn("cluster transport has no TLS configured, ignoring messages from other members")
+		}
+	}
+
 	// Start background loops
-	rn.wg.Add(2)
+	rn.wg.Add(3)
+	go rn.proposeLoop()
 	go rn.applyLoop()
 	go rn.snapshotLoop()
 
@@ -201,23 +224,53 @@ func (rn *RaftNode) Members() map[string]string {
 	return result
 }
 
-// Apply proposes a command to be applied to the FSM
+// Apply proposes a command to be applied to the FSM and waits until it is
+// committed. The write is fenced: if this node stops being the leader of the
+// term it was proposed in before it commits, it fails with ErrFenced.
 func (rn *RaftNode) Apply(cmd []byte) error {
-	rn.mu.RLock()
-	if rn.state != StateLeader {
-		rn.mu.RUnlock()
-		return ErrNotLeader
+	return rn.propose(cmd, nil)
+}
+
+// ApplySync is like Apply, but also waits until the FSM applied the command
+// and returns the FSM's error. It is used for commands whose outcome depends
+// on the replicated state, such as lock acquisition.
+func (rn *RaftNode) ApplySync(cmd []byte) error {
+	applied := make(chan error, 1)
+	if err := rn.propose(cmd, applied); err != nil {
+		return err
 	}
-	rn.mu.RUnlock()
 
 	select {
-	case rn.proposeCh <- cmd:
-		return nil
+	case err := <-applied:
+		return err
+	case <-rn.ctx.Done():
+		return rn.ctx.Err()
+	}
+}
+
+// propose submits a command and waits until it is committed. If applied is
+// not nil, it receives the FSM's result once the command is applied.
+func (rn *RaftNode) propose(cmd []byte, applied chan error) error {
+	term, err := rn.checkLeadership()
+	if err != nil {
+		return err
+	}
+
+	p := &proposal{term: term, data: cmd, done: make(chan error, 1), applied: applied}
+	select {
+	case rn.proposeCh <- p:
 	case <-rn.ctx.Done():
 		return rn.ctx.Err()
 	default:
 		return fmt.Errorf("proposal channel full")
 	}
+
+	select {
+	case err := <-p.done:
+		return err
+	case <-rn.ctx.Done():
+		return rn.ctx.Err()
+	}
 }
 
 // AddVoter adds a voting member
@@ -338,6 +391,41 @@ func (rn *RaftNode) TransferLeadership(targetNodeID string) error {
 	return nil
 }
 
+// proposeLoop commits proposals that are still valid for the current term
+func (rn *RaftNode) proposeLoop() {
+	defer rn.wg.Done()
+
+	for {
+		select {
+		case <-rn.ctx.Done():
+			return
+		case p := <-rn.proposeCh:
+			rn.mu.Lock()
+			if rn.state != StateLeader || rn.term != p.term {
+				term := rn.term
+				rn.mu.Unlock()
+				getLogger("raft").Warn("rejected write from a superseded term",
+					"proposed_term", p.term,
+					"term", term,
+				)
+				p.done <- ErrFenced
+				continue
+			}
+			rn.commitIndex++
+			c := &commit{index: rn.commitIndex, term: rn.term, data: p.data, applied: p.applied}
+			rn.mu.Unlock()
+
+			select {
+			case rn.commitCh <- c:
+				p.done <- nil
+			case <-rn.ctx.Done():
+				p.done <- rn.ctx.Err()
+				return
+			}
+		}
+	}
+}
+
 // applyLoop applies committed entries to the FSM
 func (rn *RaftNode) applyLoop() {
 	defer rn.wg.Done()
@@ -347,7 +435,11 @@ func (rn *RaftNode) applyLoop() {
 		case <-rn.ctx.Done():
 			return
 		case c := <-rn.commitCh:
-			if err := rn.fsm.Apply(c.data); err != nil {
+			err := rn.fsm.Apply(c.data)
+			if c.applied != nil {
+				c.applied <- err
+			}
+			if err != nil {
 				// Log error
 				continue
 			}
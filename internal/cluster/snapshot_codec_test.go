@@ -0,0 +1,169 @@
+package cluster
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bib/internal/config"
+)
+
+func TestSnapshotCodec(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 64)
+	data := bytes.Repeat([]byte(`{"catalog":{"topic-1":{"hash":"abc123"}},"config":{}}`), 100)
+
+	tests := []struct {
+		compression string
+		encrypt     bool
+	}{
+		{"none", false},
+		{"gzip", false},
+		{"zstd", false},
+		{"none", true},
+		{"gzip", true},
+		{"zstd", true},
+	}
+
+	for _, tt := range tests {
+		name := tt.compression
+		if tt.encrypt {
+			name += "+encrypt"
+		}
+		t.Run(name, func(t *testing.T) {
+			c, err := newSnapshotCodec(tt.compression, tt.encrypt, key)
+			if err != nil {
+				t.Fatalf("newSnapshotCodec() error = %v", err)
+			}
+
+			encoded, err := c.encode(data)
+			if err != nil {
+				t.Fatalf("encode() error = %v", err)
+			}
+			if tt.compression != "none" && len(encoded) >= len(data) {
+				t.Errorf("encoded size %d, want less than %d", len(encoded), len(data))
+			}
+			if tt.encrypt && bytes.Contains(encoded, []byte("topic-1")) {
+				t.Error("encrypted snapshot contains plaintext")
+			}
+
+			decoded, err := c.decode(encoded)
+			if err != nil {
+				t.Fatalf("decode() error = %v", err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Error("decoded data differs from the original")
+			}
+		})
+	}
+
+	t.Run("legacy snapshots are read as is", func(t *testing.T) {
+		c, err := newSnapshotCodec("zstd", true, key)
+		if err != nil {
+			t.Fatalf("newSnapshotCodec() error = %v", err)
+		}
+		decoded, err := c.decode(data)
+		if err != nil || !bytes.Equal(decoded, data) {
+			t.Errorf("decode(legacy) = %q, %v", decoded, err)
+		}
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		c, _ := newSnapshotCodec("gzip", true, key)
+		encoded, err := c.encode(data)
+		if err != nil {
+			t.Fatalf("encode() error = %v", err)
+		}
+
+		other, _ := newSnapshotCodec("gzip", true, bytes.Repeat([]byte{0x17}, 64))
+		if _, err := other.decode(encoded); !errors.Is(err, ErrSnapshotDecryption) {
+			t.Errorf("decode() error = %v, want ErrSnapshotDecryption", err)
+		}
+
+		noKey, _ := newSnapshotCodec("gzip", false, nil)
+		if _, err := noKey.decode(encoded); !errors.Is(err, ErrSnapshotKeyRequired) {
+			t.Errorf("decode() error = %v, want ErrSnapshotKeyRequired", err)
+		}
+	})
+
+	t.Run("tampered header", func(t *testing.T) {
+		c, _ := newSnapshotCodec("none", true, key)
+		encoded, err := c.encode(data)
+		if err != nil {
+			t.Fatalf("encode() error = %v", err)
+		}
+		encoded[5] = snapshotCompressionGzip
+		if _, err := c.decode(encoded); !errors.Is(err, ErrSnapshotDecryption) {
+			t.Errorf("decode() error = %v, want ErrSnapshotDecryption", err)
+		}
+	})
+
+	t.Run("encryption without key", func(t *testing.T) {
+		c, _ := newSnapshotCodec("zstd", true, nil)
+		if _, err := c.encode(data); !errors.Is(err, ErrSnapshotKeyRequired) {
+			t.Errorf("encode() error = %v, want ErrSnapshotKeyRequired", err)
+		}
+	})
+
+	t.Run("invalid compression", func(t *testing.T) {
+		if _, err := newSnapshotCodec("lz4", false, nil); err == nil {
+			t.Error("newSnapshotCodec(lz4) succeeded")
+		}
+	})
+}
+
+func TestStorageSnapshotEncryption(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := config.ClusterConfig{
+		DataDir: tempDir,
+		Snapshot: config.SnapshotConfig{
+			RetainCount: 3,
+			Compression: "zstd",
+			Encrypt:     true,
+		},
+	}
+
+	s, err := NewStorage(cfg, tempDir)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.SetSnapshotKey(bytes.Repeat([]byte{0x42}, 64)); err != nil {
+		t.Fatalf("SetSnapshotKey() error = %v", err)
+	}
+
+	// A snapshot written before compression and encryption existed
+	legacy := []byte(`{"catalog":{},"config":{"legacy":"dmFsdWU="}}`)
+	legacyPath := filepath.Join(tempDir, "snapshots", "1-10-1.snap")
+	if err := os.WriteFile(legacyPath, legacy, 0644); err != nil {
+		t.Fatalf("failed to write legacy snapshot: %v", err)
+	}
+	got, err := s.ReadSnapshot("1-10-1")
+	if err != nil || !bytes.Equal(got, legacy) {
+		t.Errorf("ReadSnapshot(legacy) = %q, %v", got, err)
+	}
+
+	data := []byte(`{"catalog":{},"config":{"secret":"c2VjcmV0"}}`)
+	meta, err := s.CreateSnapshot(100, 5, nil, data)
+	if err != nil {
+		t.Fatalf("CreateSnapshot() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(tempDir, "snapshots", meta.ID+".snap"))
+	if err != nil {
+		t.Fatalf("failed to read snapshot file: %v", err)
+	}
+	if bytes.Contains(raw, []byte("c2VjcmV0")) {
+		t.Error("snapshot file contains plaintext")
+	}
+	if meta.Size != int64(len(raw)) {
+		t.Errorf("meta.Size = %d, want the file size %d", meta.Size, len(raw))
+	}
+
+	got, err = s.ReadSnapshot(meta.ID)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Errorf("ReadSnapshot() = %q, %v", got, err)
+	}
+}
@@ -20,6 +20,7 @@ type Storage struct {
 	db      *sql.DB
 	dataDir string
 	cfg     config.ClusterConfig
+	codec   *snapshotCodec
 	mu      sync.RWMutex
 }
 
@@ -50,6 +51,11 @@ type HardState struct {
 
 // NewStorage creates a new SQLite-backed storage for Raft
 func NewStorage(cfg config.ClusterConfig, configDir string) (*Storage, error) {
+	codec, err := newSnapshotCodec(cfg.Snapshot.Compression, cfg.Snapshot.Encrypt, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	dataDir := cfg.DataDir
 	if dataDir == "" {
 		dataDir = filepath.Join(configDir, "raft")
@@ -84,6 +90,7 @@ func NewStorage(cfg config.ClusterConfig, configDir string) (*Storage, error) {
 		db:      db,
 		dataDir: dataDir,
 		cfg:     cfg,
+		codec:   codec,
 	}
 
 	if err := s.init(); err != nil {
@@ -182,6 +189,16 @@ func (s *Storage) init() error {
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	);
 
+	-- Replicated metadata: topic membership state
+	CREATE TABLE IF NOT EXISTS replicated_topic_memberships (
+		topic_id TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		state TEXT NOT NULL,
+		role TEXT,
+		updated_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (topic_id, user_id)
+	);
+
 	-- Initialize hard state if not exists
 	INSERT OR IGNORE INTO raft_state (id, term, vote, commit_index) VALUES (1, 0, '', 0);
 
@@ -359,16 +376,21 @@ func (s *Storage) CreateSnapshot(index, term uint64, configuration []byte, data
 
 	id := fmt.Sprintf("%d-%d-%d", term, index, time.Now().UnixNano())
 
+	encoded, err := s.codec.encode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
 	// Write snapshot data to file
 	snapshotPath := filepath.Join(s.dataDir, "snapshots", id+".snap")
-	if err := os.WriteFile(snapshotPath, data, 0644); err != nil {
+	if err := os.WriteFile(snapshotPath, encoded, 0600); err != nil {
 		return nil, fmt.Errorf("failed to write snapshot file: %w", err)
 	}
 
 	// Store metadata
-	_, err := s.db.Exec(
+	_, err = s.db.Exec(
 		"INSERT INTO snapshots (id, log_index, term, configuration, size) VALUES (?, ?, ?, ?, ?)",
-		id, index, term, configuration, len(data),
+		id, index, term, configuration, len(encoded),
 	)
 	if err != nil {
 		err := os.Remove(snapshotPath)
@@ -386,7 +408,7 @@ func (s *Storage) CreateSnapshot(index, term uint64, configuration []byte, data
 		Index:         index,
 		Term:          term,
 		Configuration: configuration,
-		Size:          int64(len(data)),
+		Size:          int64(len(encoded)),
 		CreatedAt:     time.Now(),
 	}, nil
 }
@@ -410,10 +432,35 @@ func (s *Storage) GetLatestSnapshot() (*SnapshotMeta, error) {
 	return &meta, nil
 }
 
-// ReadSnapshot reads snapshot data
+// ReadSnapshot reads snapshot data, decompressing and decrypting it as
+// needed. Legacy snapshots written without a header are returned as is.
 func (s *Storage) ReadSnapshot(id string) ([]byte, error) {
 	snapshotPath := filepath.Join(s.dataDir, "snapshots", id+".snap")
-	return os.ReadFile(snapshotPath)
+	data, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	codec := s.codec
+	s.mu.RUnlock()
+
+	return codec.decode(data)
+}
+
+// SetSnapshotKey sets the key snapshots are encrypted with, normally the
+// node's identity key. It is needed to write snapshots when
+// snapshot.encrypt is enabled and to read encrypted snapshots.
+func (s *Storage) SetSnapshotKey(key []byte) error {
+	codec, err := newSnapshotCodec(s.cfg.Snapshot.Compression, s.cfg.Snapshot.Encrypt, key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.codec = codec
+	s.mu.Unlock()
+	return nil
 }
 
 // ListSnapshots returns all snapshot metadata
@@ -445,19 +492,37 @@ func (s *Storage) ListSnapshots() ([]SnapshotMeta, error) {
 	return snapshots, rows.Err()
 }
 
+// SnapshotsToPrune returns the snapshots the retention cleanup removes
+// once pending more snapshots are created, newest first.
+func (s *Storage) SnapshotsToPrune(pending int) ([]SnapshotMeta, error) {
+	snapshots, err := s.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	keep := max(s.retainCount()-pending, 0)
+	if len(snapshots) <= keep {
+		return nil, nil
+	}
+	return snapshots[keep:], nil
+}
+
+// retainCount returns how many snapshots are retained
+func (s *Storage) retainCount() int {
+	if s.cfg.Snapshot.RetainCount <= 0 {
+		return 3
+	}
+	return s.cfg.Snapshot.RetainCount
+}
+
 // cleanupSnapshots removes old snapshots beyond retention count
 func (s *Storage) cleanupSnapshots() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	retainCount := s.cfg.Snapshot.RetainCount
-	if retainCount <= 0 {
-		retainCount = 3
-	}
-
 	rows, err := s.db.Query(
 		"SELECT id FROM snapshots ORDER BY log_index DESC LIMIT -1 OFFSET ?",
-		retainCount,
+		s.retainCount(),
 	)
 	if err != nil {
 		return
@@ -762,3 +827,40 @@ func (s *Storage) GetConfig(key string) ([]byte, error) {
 	}
 	return value, err
 }
+
+// --- Replicated Topic Membership Operations ---
+
+// ReplicatedTopicMembership is where a user stands in joining a topic,
+// replicated so every node enforces the same membership
+type ReplicatedTopicMembership struct {
+	TopicID   string    `json:"topic_id"`
+	UserID    string    `json:"user_id"`
+	State     string    `json:"state"`
+	Role      string    `json:"role,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StoreTopicMembership stores a topic membership
+func (s *Storage) StoreTopicMembership(m *ReplicatedTopicMembership) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(`
+		INSERT INTO replicated_topic_memberships (topic_id, user_id, state, role, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(topic_id, user_id) DO UPDATE SET
+			state = excluded.state,
+			role = excluded.role,
+			updated_at = excluded.updated_at
+	`, m.TopicID, m.UserID, m.State, m.Role, m.UpdatedAt)
+	return err
+}
+
+// DeleteTopicMembership removes a topic membership
+func (s *Storage) DeleteTopicMembership(topicID, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec("DELETE FROM replicated_topic_memberships WHERE topic_id = ? AND user_id = ?", topicID, userID)
+	return err
+}
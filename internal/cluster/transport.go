@@ -2,10 +2,14 @@ package cluster
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
+	"os"
 	"sync"
 	"time"
 
@@ -18,8 +22,13 @@ type Transport struct {
 	nodeID   string
 	listener net.Listener
 
-	mu    sync.RWMutex
-	peers map[string]*peerConn
+	// tlsConfig authenticates peers with mutual TLS; nil if the transport
+	// is plaintext
+	tlsConfig *tls.Config
+
+	mu      sync.RWMutex
+	peers   map[string]*peerConn
+	handler func(from string, msg *RaftMessage)
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -59,29 +68,47 @@ type RaftMessage struct {
 	Success  bool   `json:"success"`
 	Reject   bool   `json:"reject"`
 	Data     []byte `json:"data,omitempty"`
+
+	// SentAt is the sender's clock when sending, in Unix nanoseconds, so
+	// the receiver can measure clock skew
+	SentAt int64 `json:"sent_at,omitempty"`
 }
 
-// NewTransport creates a new transport
+// NewTransport creates a new transport. With cfg.TLS set, peers
+// authenticate each other with mutual TLS, and a peer's certificate must
+// name the node ID it connects as.
 func NewTransport(cfg config.ClusterConfig, nodeID string) (*Transport, error) {
 	addr := cfg.ListenAddr
 	if addr == "" {
 		addr = "0.0.0.0:4002"
 	}
 
+	var tlsConfig *tls.Config
+	if cfg.TLS.CertFile != "" {
+		var err error
+		if tlsConfig, err = loadTransportTLS(cfg.TLS); err != nil {
+			return nil, err
+		}
+	}
+
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
 	t := &Transport{
-		cfg:      cfg,
-		nodeID:   nodeID,
-		listener: listener,
-		peers:    make(map[string]*peerConn),
-		ctx:      ctx,
-		cancel:   cancel,
+		cfg:       cfg,
+		nodeID:    nodeID,
+		listener:  listener,
+		tlsConfig: tlsConfig,
+		peers:     make(map[string]*peerConn),
+		ctx:       ctx,
+		cancel:    cancel,
 	}
 
 	go t.acceptLoop()
@@ -107,6 +134,48 @@ func (t *Transport) Close() error {
 	return t.listener.Close()
 }
 
+// loadTransportTLS loads the mutual TLS configuration of the transport.
+func loadTransportTLS(cfg config.ClusterTLSConfig) (*tls.Config, error) {
+	if cfg.KeyFile == "" || cfg.CAFile == "" {
+		return nil, errors.New("cluster TLS requires cert_file, key_file and ca_file")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cluster certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates in cluster CA file %s", cfg.CAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// Authenticated reports whether peers are authenticated with mutual TLS,
+// so the node ID a message arrives from can be trusted.
+func (t *Transport) Authenticated() bool {
+	return t.tlsConfig != nil
+}
+
+// SetHandler sets the function receiving messages from peers, with the node
+// ID of the connection a message arrived on
+func (t *Transport) SetHandler(fn func(from string, msg *RaftMessage)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.handler = fn
+}
+
 // LocalAddr returns the local address
 func (t *Transport) LocalAddr() string {
 	return t.listener.Addr().String()
@@ -121,11 +190,25 @@ func (t *Transport) Connect(nodeID, addr string) error {
 		return nil // Already connected
 	}
 
-	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+	var conn net.Conn
+	var err error
+	if t.tlsConfig != nil {
+		// The peer's certificate must name the node we meant to reach
+		cfg := t.tlsConfig.Clone()
+		cfg.ServerName = nodeID
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", addr, cfg)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, 10*time.Second)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to connect to %s: %w", addr, err)
 	}
 
+	if err := t.writeHandshake(conn); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to send handshake to %s: %w", addr, err)
+	}
+
 	t.peers[nodeID] = &peerConn{
 		nodeID: nodeID,
 		addr:   addr,
@@ -174,6 +257,10 @@ func (t *Transport) Send(msg *RaftMessage) error {
 		return fmt.Errorf("peer %s connection is nil", msg.To)
 	}
 
+	if msg.SentAt == 0 {
+		msg.SentAt = time.Now().UnixNano()
+	}
+
 	// Simple wire protocol: length-prefixed protobuf or JSON
 	// For now, using a simple format
 	data, err := encodeMessage(msg)
@@ -233,6 +320,16 @@ func (t *Transport) handleIncomingConn(conn net.Conn) {
 	if err != nil {
 		return
 	}
+	if tc, ok := conn.(*tls.Conn); ok {
+		// The client certificate was verified against the CA while
+		// reading the handshake; it must also name the node ID sent
+		certs := tc.ConnectionState().PeerCertificates
+		if len(certs) == 0 || certs[0].VerifyHostname(nodeID) != nil {
+			getLogger("transport").Warn("rejecting peer whose certificate does not match its node ID",
+				"node_id", nodeID, "remote_addr", conn.RemoteAddr().String())
+			return
+		}
+	}
 
 	t.mu.Lock()
 	t.peers[nodeID] = &peerConn{
@@ -268,11 +365,30 @@ func (t *Transport) handleConn(conn net.Conn, nodeID string) {
 			return
 		}
 
-		// TODO: Dispatch message to Raft node
-		_ = msg
+		t.mu.RLock()
+		handler := t.handler
+		t.mu.RUnlock()
+		if handler != nil {
+			handler(nodeID, msg)
+		}
 	}
 }
 
+// writeHandshake sends the local node ID on a new connection
+func (t *Transport) writeHandshake(conn net.Conn) error {
+	length := uint32(len(t.nodeID))
+	data := append([]byte{
+		byte(length >> 24),
+		byte(length >> 16),
+		byte(length >> 8),
+		byte(length),
+	}, t.nodeID...)
+
+	conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	_, err := conn.Write(data)
+	return err
+}
+
 // readHandshake reads the initial handshake from a connection
 func (t *Transport) readHandshake(conn net.Conn) (string, error) {
 	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
@@ -1,12 +1,95 @@
 package cluster
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"bib/internal/config"
 )
 
+// testCA issues the member certificates of transport tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var clusterCA = sync.OnceValue(func() *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test cluster CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+})
+
+// issue writes a certificate for name, with the CA, to a temporary
+// directory and returns the transport TLS configuration using them.
+func (ca *testCA) issue(t *testing.T, name string) config.ClusterTLSConfig {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	cfg := config.ClusterTLSConfig{
+		CertFile: filepath.Join(dir, "node.crt"),
+		KeyFile:  filepath.Join(dir, "node.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	for path, data := range map[string][]byte{
+		cfg.CertFile: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		cfg.KeyFile:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		cfg.CAFile:   ca.pem,
+	} {
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cfg
+}
+
 func TestNewTransport(t *testing.T) {
 	cfg := config.ClusterConfig{
 		ListenAddr: "127.0.0.1:0", // Random port
@@ -115,3 +198,63 @@ func TestRaftMessage(t *testing.T) {
 		t.Errorf("log index mismatch: expected %d, got %d", msg.LogIndex, decoded.LogIndex)
 	}
 }
+
+func TestTransport_MutualTLS(t *testing.T) {
+	a := newTestTransport(t, "node-a")
+	b := newTestTransport(t, "node-b")
+	if !a.Authenticated() {
+		t.Fatal("Authenticated() = false with TLS configured")
+	}
+
+	type delivery struct {
+		from string
+		msg  *RaftMessage
+	}
+	received := make(chan delivery, 2)
+	b.SetHandler(func(from string, msg *RaftMessage) { received <- delivery{from, msg} })
+
+	// The peer's certificate must name the node dialed
+	if err := a.Connect("node-c", b.LocalAddr()); err == nil {
+		t.Error("Connect() to a peer with another node's certificate succeeded")
+	}
+
+	// A node can't connect with the node ID of another one
+	impostor, err := NewTransport(config.ClusterConfig{ListenAddr: "127.0.0.1:0", TLS: clusterCA().issue(t, "node-x")}, "node-a")
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+	defer impostor.Close()
+	if err := impostor.Connect("node-b", b.LocalAddr()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	_ = impostor.Send(&RaftMessage{Type: MsgTypeAppendEntries, From: "node-a", To: "node-b", Term: 99})
+
+	if err := a.Connect("node-b", b.LocalAddr()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if err := a.Send(&RaftMessage{Type: MsgTypeAppendEntries, From: "node-a", To: "node-b", Term: 1}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	select {
+	case d := <-received:
+		if d.from != "node-a" || d.msg.Term != 1 {
+			t.Errorf("received term %d from %q, want term 1 from node-a", d.msg.Term, d.from)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+	select {
+	case d := <-received:
+		t.Errorf("received term %d from the impostor", d.msg.Term)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNewTransport_IncompleteTLS(t *testing.T) {
+	tlsCfg := clusterCA().issue(t, "node-a")
+	tlsCfg.CAFile = ""
+	if _, err := NewTransport(config.ClusterConfig{ListenAddr: "127.0.0.1:0", TLS: tlsCfg}, "node-a"); err == nil {
+		t.Error("NewTransport() without a CA file succeeded")
+	}
+}
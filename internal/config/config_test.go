@@ -3,8 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"testing"
+	"time"
 )
 
 // setTestHomeDir sets the home directory environment variables for testing.
@@ -186,6 +188,23 @@ func TestServerConfigFields(t *testing.T) {
 	}
 }
 
+func TestStartupConfigComponent(t *testing.T) {
+	cfg := DefaultBibdConfig().Server.Startup
+	cfg.Storage = StartupRetryConfig{Attempts: 10, MaxBackoff: time.Minute}
+
+	storage := cfg.Component("storage")
+	if storage.Attempts != 10 || storage.MaxBackoff != time.Minute {
+		t.Errorf("storage overrides not applied: %+v", storage)
+	}
+	if storage.InitialBackoff != cfg.Retry.InitialBackoff {
+		t.Errorf("expected unset initial backoff to be inherited, got %v", storage.InitialBackoff)
+	}
+
+	if grpc := cfg.Component("grpc"); grpc != cfg.Retry {
+		t.Errorf("expected grpc to use the defaults, got %+v", grpc)
+	}
+}
+
 // ==================== Generator Tests ====================
 
 func TestIsValidFormat(t *testing.T) {
@@ -695,6 +714,84 @@ server:
 	}
 }
 
+func TestLoadBibd_RateLimitMethods(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `
+server:
+  grpc:
+    rate_limit:
+      requests_per_second: 50
+      methods:
+        QueryService/Execute:
+          requests_per_second: 2
+          burst: 5
+        HealthService:
+          requests_per_second: 500
+          burst: 1000
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadBibd(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rl := cfg.Server.GRPC.RateLimit
+	if rl.RequestsPerSecond != 50 || rl.Burst != 200 {
+		t.Errorf("expected global limit 50/200, got %v/%d", rl.RequestsPerSecond, rl.Burst)
+	}
+	if len(rl.Methods) != 2 {
+		t.Fatalf("expected 2 method limits, got %v", rl.Methods)
+	}
+	// Keys are case-insensitive, viper lowercases them
+	if got := rl.Methods["queryservice/execute"]; got.RequestsPerSecond != 2 || got.Burst != 5 {
+		t.Errorf("unexpected QueryService/Execute limit %+v", got)
+	}
+	if got := rl.Methods["healthservice"]; got.RequestsPerSecond != 500 || got.Burst != 1000 {
+		t.Errorf("unexpected HealthService limit %+v", got)
+	}
+}
+
+func TestLoadBibd_RateLimitAdaptive(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `
+server:
+  grpc:
+    rate_limit:
+      adaptive:
+        enabled: true
+        cpu_percent: 60
+        memory_mb: 512
+        interval: 500ms
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadBibd(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	adaptive := cfg.Server.GRPC.RateLimit.Adaptive
+	if !adaptive.Enabled || adaptive.CPUPercent != 60 || adaptive.MemoryMB != 512 {
+		t.Errorf("unexpected targets %+v", adaptive)
+	}
+	if adaptive.Interval != 500*time.Millisecond {
+		t.Errorf("expected interval 500ms, got %v", adaptive.Interval)
+	}
+	// Unset targets keep their defaults
+	if adaptive.MaxInFlight != 100 || adaptive.MinFactor != 0.1 {
+		t.Errorf("expected default max_in_flight 100 and min_factor 0.1, got %d and %v", adaptive.MaxInFlight, adaptive.MinFactor)
+	}
+}
+
 func TestLoadBibd_WithSecrets(t *testing.T) {
 	os.Setenv("TEST_TLS_KEY_SECRET", "secret-key-content")
 	defer os.Unsetenv("TEST_TLS_KEY_SECRET")
@@ -722,6 +819,45 @@ server:
 	}
 }
 
+func TestLoadBibd_Gateway(t *testing.T) {
+	os.Setenv("TEST_GATEWAY_API_KEY", "key-from-env")
+	defer os.Unsetenv("TEST_GATEWAY_API_KEY")
+
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+
+	configContent := `
+server:
+  gateway:
+    enabled: true
+    port: 8443
+    auth:
+      api_keys:
+        - plain-key
+        - "env://TEST_GATEWAY_API_KEY"
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadBibd(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gw := cfg.Server.Gateway
+	if !gw.Enabled || gw.Port != 8443 {
+		t.Errorf("unexpected gateway settings %+v", gw)
+	}
+	if want := []string{"plain-key", "key-from-env"}; !reflect.DeepEqual(gw.Auth.APIKeys, want) {
+		t.Errorf("expected API keys %v, got %v", want, gw.Auth.APIKeys)
+	}
+	// Unset settings keep their defaults
+	if gw.Host != "127.0.0.1" || !gw.Auth.RequireToken || gw.MaxBodySize != 1<<20 {
+		t.Errorf("expected default host, require_token and max_body_size, got %+v", gw)
+	}
+}
+
 func TestNewViperFromConfig_Bib(t *testing.T) {
 	cfg := &BibConfig{
 		Log: LogConfig{
@@ -1083,3 +1219,86 @@ func TestFavoriteNode_NewFields(t *testing.T) {
 		t.Errorf("expected DiscoveryMethod 'local', got %q", node.DiscoveryMethod)
 	}
 }
+
+func TestBibConfig_ApplyPreferences(t *testing.T) {
+	off := false
+	server := Preferences{OutputFormat: "json", Color: &off, Locale: "de"}
+
+	t.Run("fills settings left at their defaults", func(t *testing.T) {
+		cfg := DefaultBibConfig()
+		changed := cfg.ApplyPreferences(server, false)
+
+		if len(changed) != 3 {
+			t.Errorf("expected 3 changes, got %v", changed)
+		}
+		if cfg.Output.Format != "json" || cfg.Output.Color || cfg.Locale != "de" {
+			t.Errorf("preferences not applied: %+v %q", cfg.Output, cfg.Locale)
+		}
+	})
+
+	t.Run("keeps local changes", func(t *testing.T) {
+		cfg := DefaultBibConfig()
+		cfg.Output.Format = "yaml"
+		cfg.Locale = "fr"
+		changed := cfg.ApplyPreferences(server, false)
+
+		if len(changed) != 1 || changed[0] != "output.color" {
+			t.Errorf("expected only output.color to change, got %v", changed)
+		}
+		if cfg.Output.Format != "yaml" || cfg.Locale != "fr" {
+			t.Errorf("local settings overwritten: %+v %q", cfg.Output, cfg.Locale)
+		}
+	})
+
+	t.Run("overwrite replaces local changes", func(t *testing.T) {
+		cfg := DefaultBibConfig()
+		cfg.Output.Format = "yaml"
+		cfg.ApplyPreferences(server, true)
+
+		if cfg.Output.Format != "json" {
+			t.Errorf("expected json, got %q", cfg.Output.Format)
+		}
+	})
+
+	t.Run("ignores unset preferences", func(t *testing.T) {
+		cfg := DefaultBibConfig()
+		if changed := cfg.ApplyPreferences(Preferences{}, true); len(changed) != 0 {
+			t.Errorf("expected no changes, got %v", changed)
+		}
+	})
+}
+
+func TestUpdateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "log:\n  level: debug\np2p:\n  mode: proxy\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if err := UpdateFile(path, map[string]any{"p2p.mode": "selective"}); err != nil {
+		t.Fatalf("UpdateFile: %v", err)
+	}
+
+	cfg, err := LoadBibd(path)
+	if err != nil {
+		t.Fatalf("LoadBibd: %v", err)
+	}
+	if cfg.P2P.Mode != "selective" {
+		t.Errorf("p2p.mode = %q, want selective", cfg.P2P.Mode)
+	}
+	if cfg.Log.Level != "debug" {
+		t.Errorf("log.level = %q, want the other keys kept", cfg.Log.Level)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	if err := UpdateFile(filepath.Join(t.TempDir(), "missing.yaml"), map[string]any{"p2p.mode": "full"}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+// FindFavorite returns the favorite node matching name by alias, address or
+// ID, or nil if there is none.
+func (c *ConnectionConfig) FindFavorite(name string) *FavoriteNode {
+	if name == "" {
+		return nil
+	}
+	for i := range c.FavoriteNodes {
+		node := &c.FavoriteNodes[i]
+		if node.Alias == name || node.Address == name || node.ID == name {
+			return node
+		}
+	}
+	return nil
+}
+
+// AddFavorite adds node to the favorite nodes, or updates the favorite with
+// the same alias or address. A negative priority places a new node after
+// the existing ones and keeps the priority of an existing one. With
+// makeDefault the node becomes the default node. It returns whether the
+// node was added rather than updated.
+func (c *ConnectionConfig) AddFavorite(node FavoriteNode, makeDefault bool) bool {
+	existing := c.FindFavorite(node.Alias)
+	if existing == nil {
+		existing = c.FindFavorite(node.Address)
+	}
+
+	added := existing == nil
+	if added {
+		if node.Priority < 0 {
+			node.Priority = c.nextFavoritePriority()
+		}
+		node.Default = false
+		c.FavoriteNodes = append(c.FavoriteNodes, node)
+		existing = &c.FavoriteNodes[len(c.FavoriteNodes)-1]
+	} else {
+		if node.Alias != "" {
+			existing.Alias = node.Alias
+		}
+		if node.Address != "" {
+			existing.Address = node.Address
+		}
+		if node.ID != "" {
+			existing.ID = node.ID
+		}
+		if node.Priority >= 0 {
+			existing.Priority = node.Priority
+		}
+		if node.DiscoveryMethod != "" {
+			existing.DiscoveryMethod = node.DiscoveryMethod
+		}
+	}
+
+	if makeDefault {
+		for i := range c.FavoriteNodes {
+			c.FavoriteNodes[i].Default = false
+		}
+		existing.Default = true
+		c.DefaultNode = existing.Alias
+		if c.DefaultNode == "" {
+			c.DefaultNode = existing.Address
+		}
+	}
+
+	return added
+}
+
+// RemoveFavorite removes the favorite node matching name by alias, address
+// or ID. If it was the default node, no node is default afterwards. It
+// returns the removed node and whether one matched.
+func (c *ConnectionConfig) RemoveFavorite(name string) (FavoriteNode, bool) {
+	for i, node := range c.FavoriteNodes {
+		if node.Alias != name && node.Address != name && node.ID != name {
+			continue
+		}
+
+		c.FavoriteNodes = append(c.FavoriteNodes[:i], c.FavoriteNodes[i+1:]...)
+		if d := c.DefaultNode; d != "" && (d == node.Alias || d == node.Address || d == node.ID) {
+			c.DefaultNode = ""
+		}
+		return node, true
+	}
+	return FavoriteNode{}, false
+}
+
+// nextFavoritePriority returns a priority after every favorite node
+func (c *ConnectionConfig) nextFavoritePriority() int {
+	next := 0
+	for _, node := range c.FavoriteNodes {
+		if node.Priority >= next {
+			next = node.Priority + 1
+		}
+	}
+	return next
+}
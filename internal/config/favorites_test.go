@@ -0,0 +1,64 @@
+package config
+
+import "testing"
+
+func TestAddFavorite(t *testing.T) {
+	c := &ConnectionConfig{}
+
+	if !c.AddFavorite(FavoriteNode{Address: "node1:4000", Priority: -1}, false) {
+		t.Error("expected node1 to be added")
+	}
+	if !c.AddFavorite(FavoriteNode{Alias: "two", Address: "node2:4000", Priority: -1}, true) {
+		t.Error("expected node2 to be added")
+	}
+
+	if len(c.FavoriteNodes) != 2 {
+		t.Fatalf("expected 2 favorites, got %d", len(c.FavoriteNodes))
+	}
+	if c.FavoriteNodes[0].Priority != 0 || c.FavoriteNodes[1].Priority != 1 {
+		t.Errorf("expected priorities 0 and 1, got %d and %d", c.FavoriteNodes[0].Priority, c.FavoriteNodes[1].Priority)
+	}
+	if c.DefaultNode != "two" || !c.FavoriteNodes[1].Default {
+		t.Errorf("expected node2 to be default, got %q", c.DefaultNode)
+	}
+
+	// Updating by address keeps the priority unless one is given
+	if c.AddFavorite(FavoriteNode{Alias: "one", Address: "node1:4000", Priority: -1}, true) {
+		t.Error("expected node1 to be updated")
+	}
+	if len(c.FavoriteNodes) != 2 {
+		t.Fatalf("expected 2 favorites after update, got %d", len(c.FavoriteNodes))
+	}
+	if c.FavoriteNodes[0].Alias != "one" || c.FavoriteNodes[0].Priority != 0 {
+		t.Errorf("unexpected updated node %+v", c.FavoriteNodes[0])
+	}
+	if c.DefaultNode != "one" || c.FavoriteNodes[1].Default {
+		t.Errorf("expected the default to move to node1, got %q", c.DefaultNode)
+	}
+
+	c.AddFavorite(FavoriteNode{Alias: "two", Priority: 5}, false)
+	if c.FavoriteNodes[1].Priority != 5 || c.FavoriteNodes[1].Address != "node2:4000" {
+		t.Errorf("expected node2 updated by alias, got %+v", c.FavoriteNodes[1])
+	}
+}
+
+func TestRemoveFavorite(t *testing.T) {
+	c := &ConnectionConfig{DefaultNode: "one"}
+	c.AddFavorite(FavoriteNode{Alias: "one", Address: "node1:4000", Priority: -1}, true)
+	c.AddFavorite(FavoriteNode{Address: "node2:4000", Priority: -1}, false)
+
+	if _, ok := c.RemoveFavorite("missing"); ok {
+		t.Error("expected no node removed")
+	}
+
+	node, ok := c.RemoveFavorite("node1:4000")
+	if !ok || node.Alias != "one" {
+		t.Fatalf("expected node1 removed, got %+v", node)
+	}
+	if c.DefaultNode != "" {
+		t.Errorf("expected the default cleared, got %q", c.DefaultNode)
+	}
+	if len(c.FavoriteNodes) != 1 || c.FindFavorite("node2:4000") == nil {
+		t.Errorf("expected node2 left, got %+v", c.FavoriteNodes)
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Filesystem check modes of ServerConfig.FilesystemCheck
+const (
+	FilesystemCheckWarn   = "warn"   // log database paths on network filesystems
+	FilesystemCheckStrict = "strict" // refuse to start while any is on one
+	FilesystemCheckOff    = "off"    // don't check
+)
+
+// FilesystemIssue is a database path on a network filesystem, where file
+// locking, which SQLite, PostgreSQL and the P2P peer store rely on, is
+// unreliable
+type FilesystemIssue struct {
+	Path string
+
+	// Type is the filesystem, e.g. "nfs" or "cifs"
+	Type string
+}
+
+func (i FilesystemIssue) String() string {
+	return fmt.Sprintf("%s is on a network filesystem (%s)", i.Path, i.Type)
+}
+
+// DatabasePaths returns the directories of the daemon holding databases:
+// the data directory, the SQLite database and the managed PostgreSQL data
+// if they are configured elsewhere, and the P2P peer store, which defaults
+// to configDir.
+func (c *BibdConfig) DatabasePaths(configDir string) []string {
+	paths := []string{c.Server.DataDir}
+	if c.Database.SQLite.Path != "" {
+		paths = append(paths, filepath.Dir(c.Database.SQLite.Path))
+	}
+	if c.Database.Postgres.DataDir != "" {
+		paths = append(paths, c.Database.Postgres.DataDir)
+	}
+	if c.P2P.Enabled {
+		if c.P2P.PeerStore.Path != "" {
+			paths = append(paths, filepath.Dir(c.P2P.PeerStore.Path))
+		} else {
+			paths = append(paths, configDir)
+		}
+	}
+	return paths
+}
+
+// CheckFilesystems returns the paths that are on a network filesystem.
+// A path that doesn't exist yet is checked at its closest existing parent.
+// Paths whose filesystem can't be determined are skipped.
+func CheckFilesystems(paths []string) []FilesystemIssue {
+	var issues []FilesystemIssue
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		fsType, network, err := networkFilesystem(existingParent(path))
+		if err != nil || !network {
+			continue
+		}
+		issues = append(issues, FilesystemIssue{Path: path, Type: fsType})
+	}
+	return issues
+}
+
+// existingParent returns path, or its closest parent that exists.
+func existingParent(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
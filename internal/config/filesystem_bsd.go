@@ -0,0 +1,31 @@
+//go:build darwin || freebsd
+
+package config
+
+import (
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// networkFilesystems are the names of network filesystems in statfs
+var networkFilesystems = map[string]bool{
+	"nfs":    true,
+	"smbfs":  true,
+	"afpfs":  true,
+	"webdav": true,
+	"cifs":   true,
+	"afs":    true,
+	"ftp":    true,
+}
+
+// networkFilesystem reports the filesystem type of path, and whether it is
+// a network filesystem.
+func networkFilesystem(path string) (string, bool, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return "", false, err
+	}
+	name := unix.ByteSliceToString(st.Fstypename[:])
+	return name, networkFilesystems[strings.ToLower(name)], nil
+}
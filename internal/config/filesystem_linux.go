@@ -0,0 +1,32 @@
+package config
+
+import "golang.org/x/sys/unix"
+
+// networkFilesystems maps the statfs magic numbers of network filesystems
+// to their names
+var networkFilesystems = map[uint32]string{
+	0x6969:     "nfs",
+	0x517b:     "smb",
+	0xff534d42: "cifs",
+	0xfe534d42: "smb2",
+	0x564c:     "ncp",
+	0x73757245: "coda",
+	0x5346414f: "afs",
+	0x6b414653: "afs",
+	0x00c36400: "ceph",
+	0x01021997: "9p",
+	0x0bd00bd0: "lustre",
+}
+
+// networkFilesystem reports the filesystem type of path, and whether it is
+// a network filesystem.
+func networkFilesystem(path string) (string, bool, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return "", false, err
+	}
+	if name, ok := networkFilesystems[uint32(st.Type)]; ok {
+		return name, true, nil
+	}
+	return "", false, nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package config
+
+import "errors"
+
+// networkFilesystem can't determine filesystem types on this platform.
+func networkFilesystem(path string) (string, bool, error) {
+	return "", false, errors.New("filesystem type detection not supported")
+}
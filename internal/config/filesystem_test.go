@@ -0,0 +1,40 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCheckFilesystems_Local(t *testing.T) {
+	dir := t.TempDir()
+
+	// Paths that don't exist yet are checked at their parent
+	issues := CheckFilesystems([]string{dir, filepath.Join(dir, "missing", "cache.db"), ""})
+	if len(issues) != 0 {
+		t.Errorf("expected no issues for a local directory, got %v", issues)
+	}
+}
+
+func TestExistingParent(t *testing.T) {
+	dir := t.TempDir()
+
+	if got := existingParent(filepath.Join(dir, "a", "b")); got != dir {
+		t.Errorf("existingParent() = %q, want %q", got, dir)
+	}
+	if got := existingParent(dir); got != dir {
+		t.Errorf("existingParent() = %q, want %q", got, dir)
+	}
+}
+
+func TestDatabasePaths(t *testing.T) {
+	cfg := DefaultBibdConfig()
+	cfg.Server.DataDir = "/data"
+	cfg.Database.SQLite.Path = "/db/cache.db"
+	cfg.P2P.Enabled = true
+
+	want := []string{"/data", filepath.Dir("/db/cache.db"), "/config"}
+	if got := cfg.DatabasePaths("/config"); !reflect.DeepEqual(got, want) {
+		t.Errorf("DatabasePaths() = %v, want %v", got, want)
+	}
+}
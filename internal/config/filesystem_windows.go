@@ -0,0 +1,24 @@
+package config
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+// networkFilesystem reports whether path is on a network drive: a UNC path
+// or a mapped drive.
+func networkFilesystem(path string) (string, bool, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false, err
+	}
+	root, err := windows.UTF16PtrFromString(filepath.VolumeName(abs) + `\`)
+	if err != nil {
+		return "", false, err
+	}
+	if windows.GetDriveType(root) == windows.DRIVE_REMOTE {
+		return "remote", true, nil
+	}
+	return "", false, nil
+}
@@ -89,6 +89,8 @@ func LoadBib(cfgFile string) (*BibConfig, error) {
 			return nil, fmt.Errorf("failed to read config: %w", err)
 		}
 		// Config file not found; use defaults + env vars
+	} else if err := migrateLoadedConfig(v, AppBib); err != nil {
+		return nil, fmt.Errorf("failed to migrate config: %w", err)
 	}
 
 	var cfg BibConfig
@@ -131,6 +133,7 @@ func SaveBib(cfg *BibConfig, path string) error {
 	v.SetConfigType("yaml")
 
 	// Set all config values
+	v.Set("config_version", BibConfigVersion)
 	v.Set("log.level", cfg.Log.Level)
 	v.Set("log.format", cfg.Log.Format)
 	v.Set("log.output", cfg.Log.Output)
@@ -145,10 +148,12 @@ func SaveBib(cfg *BibConfig, path string) error {
 	v.Set("connection.default_node", cfg.Connection.DefaultNode)
 	v.Set("connection.mode", cfg.Connection.Mode)
 	v.Set("connection.auto_detect", cfg.Connection.AutoDetect)
+	v.Set("connection.srv_record", cfg.Connection.SRVRecord)
 	v.Set("connection.timeout", cfg.Connection.Timeout)
 	v.Set("connection.retry_attempts", cfg.Connection.RetryAttempts)
 	v.Set("connection.pool_size", cfg.Connection.PoolSize)
 	v.Set("connection.bib_dev_confirmed", cfg.Connection.BibDevConfirmed)
+	v.Set("connection.version_policy", cfg.Connection.VersionPolicy)
 	v.Set("connection.tls.skip_verify", cfg.Connection.TLS.SkipVerify)
 	v.Set("connection.tls.ca_file", cfg.Connection.TLS.CAFile)
 	v.Set("connection.tls.cert_file", cfg.Connection.TLS.CertFile)
@@ -202,6 +207,8 @@ func LoadBibd(cfgFile string) (*BibdConfig, error) {
 			return nil, fmt.Errorf("failed to read config: %w", err)
 		}
 		// Config file not found; use defaults + env vars
+	} else if err := migrateLoadedConfig(v, AppBibd); err != nil {
+		return nil, fmt.Errorf("failed to migrate config: %w", err)
 	}
 
 	var cfg BibdConfig
@@ -217,10 +224,42 @@ func LoadBibd(cfgFile string) (*BibdConfig, error) {
 	return &cfg, nil
 }
 
+// UpdateFile sets keys in a config file, keeping the rest of it. Keys are
+// dotted paths such as "p2p.mode". Defaults and environment variables are
+// not written.
+func UpdateFile(path string, values map[string]any) error {
+	if path == "" {
+		return fmt.Errorf("no config file to update")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if ext := filepath.Ext(path); ext != "" {
+		v.SetConfigType(ext[1:])
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	for key, value := range values {
+		v.Set(key, value)
+	}
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return os.Chmod(path, info.Mode().Perm())
+}
+
 // setViperDefaults sets default values in Viper from a config struct
 func setViperDefaults(v *viper.Viper, cfg interface{}) {
 	switch c := cfg.(type) {
 	case *BibConfig:
+		v.SetDefault("config_version", c.ConfigVersion)
 		v.SetDefault("log.level", c.Log.Level)
 		v.SetDefault("log.format", c.Log.Format)
 		v.SetDefault("log.output", c.Log.Output)
@@ -231,7 +270,9 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("output.color", c.Output.Color)
 		v.SetDefault("connection.default_node", c.Connection.DefaultNode)
 		v.SetDefault("connection.auto_detect", c.Connection.AutoDetect)
+		v.SetDefault("connection.srv_record", c.Connection.SRVRecord)
 	case *BibdConfig:
+		v.SetDefault("config_version", c.ConfigVersion)
 		v.SetDefault("log.level", c.Log.Level)
 		v.SetDefault("log.format", c.Log.Format)
 		v.SetDefault("log.output", c.Log.Output)
@@ -243,8 +284,33 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("server.tls.enabled", c.Server.TLS.Enabled)
 		v.SetDefault("server.tls.cert_file", c.Server.TLS.CertFile)
 		v.SetDefault("server.tls.key_file", c.Server.TLS.KeyFile)
+		v.SetDefault("server.tls.auto_generate", c.Server.TLS.AutoGenerate)
+		v.SetDefault("server.tls.ca_file", c.Server.TLS.CAFile)
+		v.SetDefault("server.tls.ca_key_file", c.Server.TLS.CAKeyFile)
+		v.SetDefault("server.tls.min_version", c.Server.TLS.MinVersion)
+		v.SetDefault("server.tls.cipher_suites", c.Server.TLS.CipherSuites)
+		v.SetDefault("server.tls.spiffe.enabled", c.Server.TLS.SPIFFE.Enabled)
+		v.SetDefault("server.tls.spiffe.trust_domain", c.Server.TLS.SPIFFE.TrustDomain)
+		v.SetDefault("server.tls.spiffe.trust_bundle_file", c.Server.TLS.SPIFFE.TrustBundleFile)
+		v.SetDefault("server.tls.pkcs11.enabled", c.Server.TLS.PKCS11.Enabled)
+		v.SetDefault("server.tls.pkcs11.module_path", c.Server.TLS.PKCS11.ModulePath)
+		v.SetDefault("server.tls.pkcs11.slot", c.Server.TLS.PKCS11.Slot)
+		v.SetDefault("server.tls.pkcs11.token_label", c.Server.TLS.PKCS11.TokenLabel)
+		v.SetDefault("server.tls.pkcs11.key_label", c.Server.TLS.PKCS11.KeyLabel)
+		v.SetDefault("server.tls.pkcs11.pin", c.Server.TLS.PKCS11.PIN)
 		v.SetDefault("server.pid_file", c.Server.PIDFile)
 		v.SetDefault("server.data_dir", c.Server.DataDir)
+		v.SetDefault("server.permission_check", c.Server.PermissionCheck)
+		v.SetDefault("server.filesystem_check", c.Server.FilesystemCheck)
+		v.SetDefault("server.watchdog.enabled", c.Server.Watchdog.Enabled)
+		v.SetDefault("server.watchdog.interval", c.Server.Watchdog.Interval)
+		v.SetDefault("server.watchdog.max_goroutines", c.Server.Watchdog.MaxGoroutines)
+		v.SetDefault("server.watchdog.growth_samples", c.Server.Watchdog.GrowthSamples)
+		v.SetDefault("server.watchdog.stall_timeout", c.Server.Watchdog.StallTimeout)
+		v.SetDefault("server.startup.retry.attempts", c.Server.Startup.Retry.Attempts)
+		v.SetDefault("server.startup.retry.initial_backoff", c.Server.Startup.Retry.InitialBackoff)
+		v.SetDefault("server.startup.retry.max_backoff", c.Server.Startup.Retry.MaxBackoff)
+		v.SetDefault("server.shutdown_timeout", c.Server.ShutdownTimeout)
 		// GRPC defaults
 		v.SetDefault("server.grpc.enabled", c.Server.GRPC.Enabled)
 		v.SetDefault("server.grpc.host", c.Server.GRPC.Host)
@@ -253,23 +319,54 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("server.grpc.max_recv_msg_size", c.Server.GRPC.MaxRecvMsgSize)
 		v.SetDefault("server.grpc.max_send_msg_size", c.Server.GRPC.MaxSendMsgSize)
 		v.SetDefault("server.grpc.max_concurrent_streams", c.Server.GRPC.MaxConcurrentStreams)
+		v.SetDefault("server.grpc.concurrency.max_in_flight", c.Server.GRPC.Concurrency.MaxInFlight)
+		v.SetDefault("server.grpc.concurrency.max_in_flight_per_connection", c.Server.GRPC.Concurrency.MaxInFlightPerConnection)
 		v.SetDefault("server.grpc.keepalive.time", c.Server.GRPC.Keepalive.Time)
 		v.SetDefault("server.grpc.keepalive.timeout", c.Server.GRPC.Keepalive.Timeout)
 		v.SetDefault("server.grpc.keepalive.min_time", c.Server.GRPC.Keepalive.MinTime)
 		v.SetDefault("server.grpc.keepalive.permit_without_stream", c.Server.GRPC.Keepalive.PermitWithoutStream)
+		v.SetDefault("server.grpc.keepalive.max_connection_idle", c.Server.GRPC.Keepalive.MaxConnectionIdle)
 		v.SetDefault("server.grpc.reflection", c.Server.GRPC.Reflection)
 		v.SetDefault("server.grpc.rate_limit.enabled", c.Server.GRPC.RateLimit.Enabled)
 		v.SetDefault("server.grpc.rate_limit.requests_per_second", c.Server.GRPC.RateLimit.RequestsPerSecond)
 		v.SetDefault("server.grpc.rate_limit.burst", c.Server.GRPC.RateLimit.Burst)
+		v.SetDefault("server.grpc.rate_limit.adaptive.enabled", c.Server.GRPC.RateLimit.Adaptive.Enabled)
+		v.SetDefault("server.grpc.rate_limit.adaptive.cpu_percent", c.Server.GRPC.RateLimit.Adaptive.CPUPercent)
+		v.SetDefault("server.grpc.rate_limit.adaptive.memory_mb", c.Server.GRPC.RateLimit.Adaptive.MemoryMB)
+		v.SetDefault("server.grpc.rate_limit.adaptive.max_in_flight", c.Server.GRPC.RateLimit.Adaptive.MaxInFlight)
+		v.SetDefault("server.grpc.rate_limit.adaptive.min_factor", c.Server.GRPC.RateLimit.Adaptive.MinFactor)
+		v.SetDefault("server.grpc.rate_limit.adaptive.interval", c.Server.GRPC.RateLimit.Adaptive.Interval)
 		v.SetDefault("server.grpc.metrics.enabled", c.Server.GRPC.Metrics.Enabled)
 		v.SetDefault("server.grpc.metrics.http_port", c.Server.GRPC.Metrics.HTTPPort)
 		v.SetDefault("server.grpc.metrics.http_host", c.Server.GRPC.Metrics.HTTPHost)
 		v.SetDefault("server.grpc.metrics.path", c.Server.GRPC.Metrics.Path)
 		v.SetDefault("server.grpc.metrics.enable_latency_histograms", c.Server.GRPC.Metrics.EnableLatencyHistograms)
+		v.SetDefault("server.grpc.metrics.auth.mtls", c.Server.GRPC.Metrics.Auth.MTLS)
+		v.SetDefault("server.grpc.compression.enabled", c.Server.GRPC.Compression.Enabled)
+		v.SetDefault("server.grpc.compression.algorithm", c.Server.GRPC.Compression.Algorithm)
+		v.SetDefault("server.grpc.compression.min_size", c.Server.GRPC.Compression.MinSize)
+		v.SetDefault("server.grpc.profiling.enabled", c.Server.GRPC.Profiling.Enabled)
+		v.SetDefault("server.grpc.profiling.max_duration", c.Server.GRPC.Profiling.MaxDuration)
+		v.SetDefault("server.grpc.recovery.crash_report_dir", c.Server.GRPC.Recovery.CrashReportDir)
+		v.SetDefault("server.grpc.recovery.max_crash_reports", c.Server.GRPC.Recovery.MaxCrashReports)
+		v.SetDefault("server.grpc.readiness.enabled", c.Server.GRPC.Readiness.Enabled)
+		v.SetDefault("server.grpc.readiness.max_wait", c.Server.GRPC.Readiness.MaxWait)
 		v.SetDefault("server.grpc.shutdown_grace_period", c.Server.GRPC.ShutdownGracePeriod)
+		// Gateway defaults
+		v.SetDefault("server.gateway.enabled", c.Server.Gateway.Enabled)
+		v.SetDefault("server.gateway.host", c.Server.Gateway.Host)
+		v.SetDefault("server.gateway.port", c.Server.Gateway.Port)
+		v.SetDefault("server.gateway.tls.enabled", c.Server.Gateway.TLS.Enabled)
+		v.SetDefault("server.gateway.auth.require_token", c.Server.Gateway.Auth.RequireToken)
+		v.SetDefault("server.gateway.max_body_size", c.Server.Gateway.MaxBodySize)
+		// Auth defaults
+		v.SetDefault("auth.device_auth.enabled", c.Auth.DeviceAuth.Enabled)
+		v.SetDefault("auth.device_auth.code_ttl", c.Auth.DeviceAuth.CodeTTL)
+		v.SetDefault("auth.device_auth.poll_interval", c.Auth.DeviceAuth.PollInterval)
 		// P2P defaults
 		v.SetDefault("p2p.enabled", c.P2P.Enabled)
 		v.SetDefault("p2p.mode", c.P2P.Mode)
+		v.SetDefault("p2p.labels", c.P2P.Labels)
 		v.SetDefault("p2p.identity.key_path", c.P2P.Identity.KeyPath)
 		v.SetDefault("p2p.listen_addresses", c.P2P.ListenAddresses)
 		v.SetDefault("p2p.connection_manager.low_watermark", c.P2P.ConnManager.LowWatermark)
@@ -290,13 +387,29 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("p2p.peer_store.path", c.P2P.PeerStore.Path)
 		// Full replica mode defaults
 		v.SetDefault("p2p.full_replica.sync_interval", c.P2P.FullReplica.SyncInterval)
+		v.SetDefault("p2p.full_replica.full_sync_interval", c.P2P.FullReplica.FullSyncInterval)
+		v.SetDefault("p2p.full_replica.conflict_resolution", c.P2P.FullReplica.ConflictResolution)
+		v.SetDefault("p2p.full_replica.manual_conflict_resolution", c.P2P.FullReplica.ManualConflictResolution)
+		v.SetDefault("p2p.full_replica.peer_selection.policy", c.P2P.FullReplica.PeerSelection.Policy)
+		v.SetDefault("p2p.full_replica.peer_selection.match_labels", c.P2P.FullReplica.PeerSelection.MatchLabels)
 		// Selective mode defaults
 		v.SetDefault("p2p.selective.subscriptions", c.P2P.Selective.Subscriptions)
 		v.SetDefault("p2p.selective.subscription_store_path", c.P2P.Selective.SubscriptionStorePath)
+		v.SetDefault("p2p.selective.fetch_cache_ttl", c.P2P.Selective.FetchCacheTTL)
+		v.SetDefault("p2p.selective.fetch_cache_size", c.P2P.Selective.FetchCacheSize)
 		// Proxy mode defaults
 		v.SetDefault("p2p.proxy.cache_ttl", c.P2P.Proxy.CacheTTL)
 		v.SetDefault("p2p.proxy.max_cache_size", c.P2P.Proxy.MaxCacheSize)
 		v.SetDefault("p2p.proxy.favorite_peers", c.P2P.Proxy.FavoritePeers)
+		v.SetDefault("p2p.proxy.peer_selection.policy", c.P2P.Proxy.PeerSelection.Policy)
+		v.SetDefault("p2p.proxy.peer_selection.match_labels", c.P2P.Proxy.PeerSelection.MatchLabels)
+		// Messaging defaults
+		v.SetDefault("p2p.messaging.enabled", c.P2P.Messaging.Enabled)
+		v.SetDefault("p2p.messaging.max_payload_size", c.P2P.Messaging.MaxPayloadSize)
+		v.SetDefault("p2p.messaging.allowed_peers", c.P2P.Messaging.AllowedPeers)
+		v.SetDefault("p2p.messaging.timeout", c.P2P.Messaging.Timeout)
+		v.SetDefault("p2p.messaging.retries", c.P2P.Messaging.Retries)
+		v.SetDefault("p2p.messaging.retry_backoff", c.P2P.Messaging.RetryBackoff)
 		// Cluster defaults
 		v.SetDefault("cluster.enabled", c.Cluster.Enabled)
 		v.SetDefault("cluster.node_id", c.Cluster.NodeID)
@@ -309,6 +422,10 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("cluster.join_token", c.Cluster.JoinToken)
 		v.SetDefault("cluster.join_addrs", c.Cluster.JoinAddrs)
 		v.SetDefault("cluster.enable_dht_discovery", c.Cluster.EnableDHTDiscovery)
+		v.SetDefault("cluster.max_clock_skew", c.Cluster.MaxClockSkew)
+		v.SetDefault("cluster.tls.cert_file", c.Cluster.TLS.CertFile)
+		v.SetDefault("cluster.tls.key_file", c.Cluster.TLS.KeyFile)
+		v.SetDefault("cluster.tls.ca_file", c.Cluster.TLS.CAFile)
 		v.SetDefault("cluster.raft.heartbeat_timeout", c.Cluster.Raft.HeartbeatTimeout)
 		v.SetDefault("cluster.raft.election_timeout", c.Cluster.Raft.ElectionTimeout)
 		v.SetDefault("cluster.raft.commit_timeout", c.Cluster.Raft.CommitTimeout)
@@ -318,14 +435,20 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("cluster.snapshot.interval", c.Cluster.Snapshot.Interval)
 		v.SetDefault("cluster.snapshot.threshold", c.Cluster.Snapshot.Threshold)
 		v.SetDefault("cluster.snapshot.retain_count", c.Cluster.Snapshot.RetainCount)
+		v.SetDefault("cluster.snapshot.compression", c.Cluster.Snapshot.Compression)
+		v.SetDefault("cluster.snapshot.encrypt", c.Cluster.Snapshot.Encrypt)
 		// Database defaults
 		v.SetDefault("database.backend", c.Database.Backend)
 		// SQLite defaults
 		v.SetDefault("database.sqlite.path", c.Database.SQLite.Path)
 		v.SetDefault("database.sqlite.max_open_conns", c.Database.SQLite.MaxOpenConns)
+		v.SetDefault("database.sqlite.encryption.enabled", c.Database.SQLite.Encryption.Enabled)
+		v.SetDefault("database.sqlite.encryption.key_source", c.Database.SQLite.Encryption.KeySource)
+		v.SetDefault("database.sqlite.encryption.passphrase", c.Database.SQLite.Encryption.Passphrase)
 		// PostgreSQL defaults
 		v.SetDefault("database.postgres.managed", c.Database.Postgres.Managed)
 		v.SetDefault("database.postgres.container_runtime", c.Database.Postgres.ContainerRuntime)
+		v.SetDefault("database.postgres.runtime_fallback", c.Database.Postgres.RuntimeFallback)
 		v.SetDefault("database.postgres.socket_path", c.Database.Postgres.SocketPath)
 		v.SetDefault("database.postgres.kubeconfig_path", c.Database.Postgres.KubeconfigPath)
 		v.SetDefault("database.postgres.image", c.Database.Postgres.Image)
@@ -336,6 +459,7 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("database.postgres.cpu_cores", c.Database.Postgres.CPUCores)
 		v.SetDefault("database.postgres.ssl_mode", c.Database.Postgres.SSLMode)
 		v.SetDefault("database.postgres.credential_rotation_interval", c.Database.Postgres.CredentialRotationInterval)
+		v.SetDefault("database.postgres.delete_on_cleanup", c.Database.Postgres.DeleteOnCleanup)
 		// PostgreSQL network defaults
 		v.SetDefault("database.postgres.network.use_bridge_network", c.Database.Postgres.Network.UseBridgeNetwork)
 		v.SetDefault("database.postgres.network.bridge_network_name", c.Database.Postgres.Network.BridgeNetworkName)
@@ -348,6 +472,7 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("database.postgres.health.action", c.Database.Postgres.Health.Action)
 		v.SetDefault("database.postgres.health.max_retries", c.Database.Postgres.Health.MaxRetries)
 		v.SetDefault("database.postgres.health.retry_backoff", c.Database.Postgres.Health.RetryBackoff)
+		v.SetDefault("database.postgres.health.standby", c.Database.Postgres.Health.Standby)
 		// PostgreSQL TLS defaults
 		v.SetDefault("database.postgres.tls.enabled", c.Database.Postgres.TLS.Enabled)
 		v.SetDefault("database.postgres.tls.cert_dir", c.Database.Postgres.TLS.CertDir)
@@ -356,6 +481,21 @@ func setViperDefaults(v *viper.Viper, cfg interface{}) {
 		v.SetDefault("database.audit.enabled", c.Database.Audit.Enabled)
 		v.SetDefault("database.audit.retention_days", c.Database.Audit.RetentionDays)
 		v.SetDefault("database.audit.hash_chain", c.Database.Audit.HashChain)
+		// Dataset version retention defaults
+		v.SetDefault("database.dataset_versions.keep_last", c.Database.DatasetVersions.KeepLast)
+		v.SetDefault("database.dataset_versions.max_age", c.Database.DatasetVersions.MaxAge)
+		// Encryption at rest defaults
+		v.SetDefault("database.encryption_at_rest.enabled", c.Database.EncryptionAtRest.Enabled)
+		v.SetDefault("database.encryption_at_rest.method", c.Database.EncryptionAtRest.Method)
+		v.SetDefault("database.encryption_at_rest.luks.volume_size", c.Database.EncryptionAtRest.LUKS.VolumeSize)
+		v.SetDefault("database.encryption_at_rest.luks.cipher", c.Database.EncryptionAtRest.LUKS.Cipher)
+		v.SetDefault("database.encryption_at_rest.luks.key_size", c.Database.EncryptionAtRest.LUKS.KeySize)
+		v.SetDefault("database.encryption_at_rest.luks.hash_algorithm", c.Database.EncryptionAtRest.LUKS.HashAlgorithm)
+		v.SetDefault("database.encryption_at_rest.recovery.total_shares", c.Database.EncryptionAtRest.Recovery.TotalShares)
+		v.SetDefault("database.encryption_at_rest.recovery.threshold", c.Database.EncryptionAtRest.Recovery.Threshold)
+		v.SetDefault("database.encryption_at_rest.recovery.shares_dir", c.Database.EncryptionAtRest.Recovery.SharesDir)
+		v.SetDefault("database.encryption_at_rest.recovery.escrow_recipient", c.Database.EncryptionAtRest.Recovery.EscrowRecipient)
+		v.SetDefault("database.encryption_at_rest.recovery.escrow_path", c.Database.EncryptionAtRest.Recovery.EscrowPath)
 	}
 }
 
@@ -372,6 +512,7 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 
 	switch c := cfg.(type) {
 	case *BibConfig:
+		v.Set("config_version", c.ConfigVersion)
 		v.Set("log.level", c.Log.Level)
 		v.Set("log.format", c.Log.Format)
 		v.Set("log.output", c.Log.Output)
@@ -382,7 +523,9 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("output.color", c.Output.Color)
 		v.Set("connection.default_node", c.Connection.DefaultNode)
 		v.Set("connection.auto_detect", c.Connection.AutoDetect)
+		v.Set("connection.srv_record", c.Connection.SRVRecord)
 	case *BibdConfig:
+		v.Set("config_version", c.ConfigVersion)
 		v.Set("log.level", c.Log.Level)
 		v.Set("log.format", c.Log.Format)
 		v.Set("log.output", c.Log.Output)
@@ -394,8 +537,33 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("server.tls.enabled", c.Server.TLS.Enabled)
 		v.Set("server.tls.cert_file", c.Server.TLS.CertFile)
 		v.Set("server.tls.key_file", c.Server.TLS.KeyFile)
+		v.Set("server.tls.auto_generate", c.Server.TLS.AutoGenerate)
+		v.Set("server.tls.ca_file", c.Server.TLS.CAFile)
+		v.Set("server.tls.ca_key_file", c.Server.TLS.CAKeyFile)
+		v.Set("server.tls.min_version", c.Server.TLS.MinVersion)
+		v.Set("server.tls.cipher_suites", c.Server.TLS.CipherSuites)
+		v.Set("server.tls.spiffe.enabled", c.Server.TLS.SPIFFE.Enabled)
+		v.Set("server.tls.spiffe.trust_domain", c.Server.TLS.SPIFFE.TrustDomain)
+		v.Set("server.tls.spiffe.trust_bundle_file", c.Server.TLS.SPIFFE.TrustBundleFile)
+		v.Set("server.tls.pkcs11.enabled", c.Server.TLS.PKCS11.Enabled)
+		v.Set("server.tls.pkcs11.module_path", c.Server.TLS.PKCS11.ModulePath)
+		v.Set("server.tls.pkcs11.slot", c.Server.TLS.PKCS11.Slot)
+		v.Set("server.tls.pkcs11.token_label", c.Server.TLS.PKCS11.TokenLabel)
+		v.Set("server.tls.pkcs11.key_label", c.Server.TLS.PKCS11.KeyLabel)
+		v.Set("server.tls.pkcs11.pin", c.Server.TLS.PKCS11.PIN)
 		v.Set("server.pid_file", c.Server.PIDFile)
 		v.Set("server.data_dir", c.Server.DataDir)
+		v.Set("server.permission_check", c.Server.PermissionCheck)
+		v.Set("server.filesystem_check", c.Server.FilesystemCheck)
+		v.Set("server.watchdog.enabled", c.Server.Watchdog.Enabled)
+		v.Set("server.watchdog.interval", c.Server.Watchdog.Interval)
+		v.Set("server.watchdog.max_goroutines", c.Server.Watchdog.MaxGoroutines)
+		v.Set("server.watchdog.growth_samples", c.Server.Watchdog.GrowthSamples)
+		v.Set("server.watchdog.stall_timeout", c.Server.Watchdog.StallTimeout)
+		v.Set("server.startup.retry.attempts", c.Server.Startup.Retry.Attempts)
+		v.Set("server.startup.retry.initial_backoff", c.Server.Startup.Retry.InitialBackoff)
+		v.Set("server.startup.retry.max_backoff", c.Server.Startup.Retry.MaxBackoff)
+		v.Set("server.shutdown_timeout", c.Server.ShutdownTimeout)
 		// GRPC settings
 		v.Set("server.grpc.enabled", c.Server.GRPC.Enabled)
 		v.Set("server.grpc.host", c.Server.GRPC.Host)
@@ -404,23 +572,48 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("server.grpc.max_recv_msg_size", c.Server.GRPC.MaxRecvMsgSize)
 		v.Set("server.grpc.max_send_msg_size", c.Server.GRPC.MaxSendMsgSize)
 		v.Set("server.grpc.max_concurrent_streams", c.Server.GRPC.MaxConcurrentStreams)
+		v.Set("server.grpc.concurrency.max_in_flight", c.Server.GRPC.Concurrency.MaxInFlight)
+		v.Set("server.grpc.concurrency.max_in_flight_per_connection", c.Server.GRPC.Concurrency.MaxInFlightPerConnection)
 		v.Set("server.grpc.keepalive.time", c.Server.GRPC.Keepalive.Time)
 		v.Set("server.grpc.keepalive.timeout", c.Server.GRPC.Keepalive.Timeout)
 		v.Set("server.grpc.keepalive.min_time", c.Server.GRPC.Keepalive.MinTime)
 		v.Set("server.grpc.keepalive.permit_without_stream", c.Server.GRPC.Keepalive.PermitWithoutStream)
+		v.Set("server.grpc.keepalive.max_connection_idle", c.Server.GRPC.Keepalive.MaxConnectionIdle)
 		v.Set("server.grpc.reflection", c.Server.GRPC.Reflection)
 		v.Set("server.grpc.rate_limit.enabled", c.Server.GRPC.RateLimit.Enabled)
 		v.Set("server.grpc.rate_limit.requests_per_second", c.Server.GRPC.RateLimit.RequestsPerSecond)
 		v.Set("server.grpc.rate_limit.burst", c.Server.GRPC.RateLimit.Burst)
+		v.Set("server.grpc.rate_limit.adaptive.enabled", c.Server.GRPC.RateLimit.Adaptive.Enabled)
+		v.Set("server.grpc.rate_limit.adaptive.cpu_percent", c.Server.GRPC.RateLimit.Adaptive.CPUPercent)
+		v.Set("server.grpc.rate_limit.adaptive.memory_mb", c.Server.GRPC.RateLimit.Adaptive.MemoryMB)
+		v.Set("server.grpc.rate_limit.adaptive.max_in_flight", c.Server.GRPC.RateLimit.Adaptive.MaxInFlight)
+		v.Set("server.grpc.rate_limit.adaptive.min_factor", c.Server.GRPC.RateLimit.Adaptive.MinFactor)
+		v.Set("server.grpc.rate_limit.adaptive.interval", c.Server.GRPC.RateLimit.Adaptive.Interval)
 		v.Set("server.grpc.metrics.enabled", c.Server.GRPC.Metrics.Enabled)
 		v.Set("server.grpc.metrics.http_port", c.Server.GRPC.Metrics.HTTPPort)
 		v.Set("server.grpc.metrics.http_host", c.Server.GRPC.Metrics.HTTPHost)
 		v.Set("server.grpc.metrics.path", c.Server.GRPC.Metrics.Path)
 		v.Set("server.grpc.metrics.enable_latency_histograms", c.Server.GRPC.Metrics.EnableLatencyHistograms)
+		v.Set("server.grpc.metrics.auth.mtls", c.Server.GRPC.Metrics.Auth.MTLS)
+		v.Set("server.grpc.compression.enabled", c.Server.GRPC.Compression.Enabled)
+		v.Set("server.grpc.compression.algorithm", c.Server.GRPC.Compression.Algorithm)
+		v.Set("server.grpc.compression.min_size", c.Server.GRPC.Compression.MinSize)
+		v.Set("server.grpc.profiling.enabled", c.Server.GRPC.Profiling.Enabled)
+		v.Set("server.grpc.profiling.max_duration", c.Server.GRPC.Profiling.MaxDuration)
+		v.Set("server.grpc.recovery.crash_report_dir", c.Server.GRPC.Recovery.CrashReportDir)
+		v.Set("server.grpc.recovery.max_crash_reports", c.Server.GRPC.Recovery.MaxCrashReports)
+		v.Set("server.grpc.readiness.enabled", c.Server.GRPC.Readiness.Enabled)
+		v.Set("server.grpc.readiness.max_wait", c.Server.GRPC.Readiness.MaxWait)
 		v.Set("server.grpc.shutdown_grace_period", c.Server.GRPC.ShutdownGracePeriod)
+		// Auth settings
+		v.Set("auth.device_auth.enabled", c.Auth.DeviceAuth.Enabled)
+		v.Set("auth.device_auth.code_ttl", c.Auth.DeviceAuth.CodeTTL)
+		v.Set("auth.device_auth.poll_interval", c.Auth.DeviceAuth.PollInterval)
+		v.Set("auth.device_auth.verification_uri", c.Auth.DeviceAuth.VerificationURI)
 		// P2P settings
 		v.Set("p2p.enabled", c.P2P.Enabled)
 		v.Set("p2p.mode", c.P2P.Mode)
+		v.Set("p2p.labels", c.P2P.Labels)
 		v.Set("p2p.identity.key_path", c.P2P.Identity.KeyPath)
 		v.Set("p2p.listen_addresses", c.P2P.ListenAddresses)
 		v.Set("p2p.connection_manager.low_watermark", c.P2P.ConnManager.LowWatermark)
@@ -441,13 +634,29 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("p2p.peer_store.path", c.P2P.PeerStore.Path)
 		// Full replica mode settings
 		v.Set("p2p.full_replica.sync_interval", c.P2P.FullReplica.SyncInterval)
+		v.Set("p2p.full_replica.full_sync_interval", c.P2P.FullReplica.FullSyncInterval)
+		v.Set("p2p.full_replica.conflict_resolution", c.P2P.FullReplica.ConflictResolution)
+		v.Set("p2p.full_replica.manual_conflict_resolution", c.P2P.FullReplica.ManualConflictResolution)
+		v.Set("p2p.full_replica.peer_selection.policy", c.P2P.FullReplica.PeerSelection.Policy)
+		v.Set("p2p.full_replica.peer_selection.match_labels", c.P2P.FullReplica.PeerSelection.MatchLabels)
 		// Selective mode settings
 		v.Set("p2p.selective.subscriptions", c.P2P.Selective.Subscriptions)
 		v.Set("p2p.selective.subscription_store_path", c.P2P.Selective.SubscriptionStorePath)
+		v.Set("p2p.selective.fetch_cache_ttl", c.P2P.Selective.FetchCacheTTL)
+		v.Set("p2p.selective.fetch_cache_size", c.P2P.Selective.FetchCacheSize)
 		// Proxy mode settings
 		v.Set("p2p.proxy.cache_ttl", c.P2P.Proxy.CacheTTL)
 		v.Set("p2p.proxy.max_cache_size", c.P2P.Proxy.MaxCacheSize)
 		v.Set("p2p.proxy.favorite_peers", c.P2P.Proxy.FavoritePeers)
+		v.Set("p2p.proxy.peer_selection.policy", c.P2P.Proxy.PeerSelection.Policy)
+		v.Set("p2p.proxy.peer_selection.match_labels", c.P2P.Proxy.PeerSelection.MatchLabels)
+		// Messaging settings
+		v.Set("p2p.messaging.enabled", c.P2P.Messaging.Enabled)
+		v.Set("p2p.messaging.max_payload_size", c.P2P.Messaging.MaxPayloadSize)
+		v.Set("p2p.messaging.allowed_peers", c.P2P.Messaging.AllowedPeers)
+		v.Set("p2p.messaging.timeout", c.P2P.Messaging.Timeout)
+		v.Set("p2p.messaging.retries", c.P2P.Messaging.Retries)
+		v.Set("p2p.messaging.retry_backoff", c.P2P.Messaging.RetryBackoff)
 		// Cluster settings
 		v.Set("cluster.enabled", c.Cluster.Enabled)
 		v.Set("cluster.node_id", c.Cluster.NodeID)
@@ -460,6 +669,10 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("cluster.join_token", c.Cluster.JoinToken)
 		v.Set("cluster.join_addrs", c.Cluster.JoinAddrs)
 		v.Set("cluster.enable_dht_discovery", c.Cluster.EnableDHTDiscovery)
+		v.Set("cluster.max_clock_skew", c.Cluster.MaxClockSkew)
+		v.Set("cluster.tls.cert_file", c.Cluster.TLS.CertFile)
+		v.Set("cluster.tls.key_file", c.Cluster.TLS.KeyFile)
+		v.Set("cluster.tls.ca_file", c.Cluster.TLS.CAFile)
 		v.Set("cluster.raft.heartbeat_timeout", c.Cluster.Raft.HeartbeatTimeout)
 		v.Set("cluster.raft.election_timeout", c.Cluster.Raft.ElectionTimeout)
 		v.Set("cluster.raft.commit_timeout", c.Cluster.Raft.CommitTimeout)
@@ -469,14 +682,20 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("cluster.snapshot.interval", c.Cluster.Snapshot.Interval)
 		v.Set("cluster.snapshot.threshold", c.Cluster.Snapshot.Threshold)
 		v.Set("cluster.snapshot.retain_count", c.Cluster.Snapshot.RetainCount)
+		v.Set("cluster.snapshot.compression", c.Cluster.Snapshot.Compression)
+		v.Set("cluster.snapshot.encrypt", c.Cluster.Snapshot.Encrypt)
 		// Database settings
 		v.Set("database.backend", c.Database.Backend)
 		// SQLite settings
 		v.Set("database.sqlite.path", c.Database.SQLite.Path)
 		v.Set("database.sqlite.max_open_conns", c.Database.SQLite.MaxOpenConns)
+		v.Set("database.sqlite.encryption.enabled", c.Database.SQLite.Encryption.Enabled)
+		v.Set("database.sqlite.encryption.key_source", c.Database.SQLite.Encryption.KeySource)
+		v.Set("database.sqlite.encryption.passphrase", c.Database.SQLite.Encryption.Passphrase)
 		// PostgreSQL settings
 		v.Set("database.postgres.managed", c.Database.Postgres.Managed)
 		v.Set("database.postgres.container_runtime", c.Database.Postgres.ContainerRuntime)
+		v.Set("database.postgres.runtime_fallback", c.Database.Postgres.RuntimeFallback)
 		v.Set("database.postgres.socket_path", c.Database.Postgres.SocketPath)
 		v.Set("database.postgres.kubeconfig_path", c.Database.Postgres.KubeconfigPath)
 		v.Set("database.postgres.image", c.Database.Postgres.Image)
@@ -487,6 +706,7 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("database.postgres.cpu_cores", c.Database.Postgres.CPUCores)
 		v.Set("database.postgres.ssl_mode", c.Database.Postgres.SSLMode)
 		v.Set("database.postgres.credential_rotation_interval", c.Database.Postgres.CredentialRotationInterval)
+		v.Set("database.postgres.delete_on_cleanup", c.Database.Postgres.DeleteOnCleanup)
 		// PostgreSQL network settings
 		v.Set("database.postgres.network.use_bridge_network", c.Database.Postgres.Network.UseBridgeNetwork)
 		v.Set("database.postgres.network.bridge_network_name", c.Database.Postgres.Network.BridgeNetworkName)
@@ -499,6 +719,7 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("database.postgres.health.action", c.Database.Postgres.Health.Action)
 		v.Set("database.postgres.health.max_retries", c.Database.Postgres.Health.MaxRetries)
 		v.Set("database.postgres.health.retry_backoff", c.Database.Postgres.Health.RetryBackoff)
+		v.Set("database.postgres.health.standby", c.Database.Postgres.Health.Standby)
 		// PostgreSQL TLS settings
 		v.Set("database.postgres.tls.enabled", c.Database.Postgres.TLS.Enabled)
 		v.Set("database.postgres.tls.cert_dir", c.Database.Postgres.TLS.CertDir)
@@ -507,6 +728,21 @@ func NewViperFromConfig(appName string, cfg interface{}) *viper.Viper {
 		v.Set("database.audit.enabled", c.Database.Audit.Enabled)
 		v.Set("database.audit.retention_days", c.Database.Audit.RetentionDays)
 		v.Set("database.audit.hash_chain", c.Database.Audit.HashChain)
+		// Dataset version retention settings
+		v.Set("database.dataset_versions.keep_last", c.Database.DatasetVersions.KeepLast)
+		v.Set("database.dataset_versions.max_age", c.Database.DatasetVersions.MaxAge)
+		// Encryption at rest settings
+		v.Set("database.encryption_at_rest.enabled", c.Database.EncryptionAtRest.Enabled)
+		v.Set("database.encryption_at_rest.method", c.Database.EncryptionAtRest.Method)
+		v.Set("database.encryption_at_rest.luks.volume_size", c.Database.EncryptionAtRest.LUKS.VolumeSize)
+		v.Set("database.encryption_at_rest.luks.cipher", c.Database.EncryptionAtRest.LUKS.Cipher)
+		v.Set("database.encryption_at_rest.luks.key_size", c.Database.EncryptionAtRest.LUKS.KeySize)
+		v.Set("database.encryption_at_rest.luks.hash_algorithm", c.Database.EncryptionAtRest.LUKS.HashAlgorithm)
+		v.Set("database.encryption_at_rest.recovery.total_shares", c.Database.EncryptionAtRest.Recovery.TotalShares)
+		v.Set("database.encryption_at_rest.recovery.threshold", c.Database.EncryptionAtRest.Recovery.Threshold)
+		v.Set("database.encryption_at_rest.recovery.shares_dir", c.Database.EncryptionAtRest.Recovery.SharesDir)
+		v.Set("database.encryption_at_rest.recovery.escrow_recipient", c.Database.EncryptionAtRest.Recovery.EscrowRecipient)
+		v.Set("database.encryption_at_rest.recovery.escrow_path", c.Database.EncryptionAtRest.Recovery.EscrowPath)
 	}
 
 	return v
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// Config schema versions of this release, stamped as config_version in
+// written config files. Files without a config_version are version 0.
+const (
+	BibConfigVersion  = 1
+	BibdConfigVersion = 1
+)
+
+// Migration upgrades a raw config to the next schema version
+type Migration struct {
+	// Version is the schema version the migration upgrades to
+	Version int
+
+	// Description says what the migration changes
+	Description string
+
+	// Apply changes the raw config in place: renaming keys, filling new
+	// defaults or transforming values
+	Apply func(raw map[string]any) error
+
+	// Deprecates are the keys the migration replaces, reported as
+	// deprecated when a config still has them
+	Deprecates []string
+}
+
+// bibMigrations upgrade bib CLI configs, ordered by version
+var bibMigrations = []Migration{
+	{
+		Version:     1,
+		Description: "move the legacy server and nodes keys to connection.favorite_nodes",
+		Apply:       migrateBibLegacyServer,
+		Deprecates:  []string{"server", "nodes"},
+	},
+}
+
+// bibdMigrations upgrade bibd configs, ordered by version
+var bibdMigrations = []Migration{
+	{
+		Version:     1,
+		Description: "stamp config_version",
+		Apply:       func(map[string]any) error { return nil },
+	},
+}
+
+// MigrationResult describes the upgrade of a config file
+type MigrationResult struct {
+	// Path is the upgraded config file
+	Path string
+
+	// FromVersion and ToVersion are the schema versions before and after
+	FromVersion int
+	ToVersion   int
+
+	// Applied are the migrations run, in order
+	Applied []Migration
+
+	// Deprecated are the deprecated keys the config had
+	Deprecated []string
+
+	// BackupPath holds the original file
+	BackupPath string
+}
+
+// Changed reports whether the config was upgraded
+func (r *MigrationResult) Changed() bool {
+	return r.ToVersion != r.FromVersion
+}
+
+// Warning describes the deprecated keys that were migrated, or returns ""
+// if there were none
+func (r *MigrationResult) Warning() string {
+	if len(r.Deprecated) == 0 {
+		return ""
+	}
+	msg := fmt.Sprintf("config %s: %s deprecated and moved to connection.favorite_nodes",
+		r.Path, deprecatedKeys(r.Deprecated))
+	if r.BackupPath != "" {
+		return fmt.Sprintf("%s (original kept in %s)", msg, r.BackupPath)
+	}
+	return msg + "; the file could not be rewritten, please update it"
+}
+
+// deprecatedKeys formats keys for a warning
+func deprecatedKeys(keys []string) string {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = fmt.Sprintf("%q", key)
+	}
+	if len(keys) == 1 {
+		return "the key " + quoted[0] + " is"
+	}
+	return "the keys " + strings.Join(quoted, " and ") + " are"
+}
+
+var (
+	warningsMu sync.Mutex
+	warnings   []string
+)
+
+// addWarning queues a warning for TakeWarnings, once per process
+func addWarning(warning string) {
+	warningsMu.Lock()
+	defer warningsMu.Unlock()
+	for _, w := range warnings {
+		if w == warning {
+			return
+		}
+	}
+	warnings = append(warnings, warning)
+}
+
+// TakeWarnings returns the warnings of loading configs, such as deprecated
+// keys that were migrated, and clears them so each is shown once.
+func TakeWarnings() []string {
+	warningsMu.Lock()
+	defer warningsMu.Unlock()
+	taken := warnings
+	warnings = nil
+	return taken
+}
+
+// migrationsFor returns the migrations and current schema version of appName
+func migrationsFor(appName string) ([]Migration, int, error) {
+	switch appName {
+	case AppBib:
+		return bibMigrations, BibConfigVersion, nil
+	case AppBibd:
+		return bibdMigrations, BibdConfigVersion, nil
+	default:
+		return nil, 0, fmt.Errorf("unknown app: %s", appName)
+	}
+}
+
+// MigrateConfig upgrades raw to the current schema version of appName by
+// running, in order, the migrations newer than its config_version, and
+// stamps the new version. Configs from a newer release are left alone.
+func MigrateConfig(appName string, raw map[string]any) (*MigrationResult, error) {
+	migrations, current, err := migrationsFor(appName)
+	if err != nil {
+		return nil, err
+	}
+
+	from := rawConfigVersion(raw)
+	result := &MigrationResult{FromVersion: from, ToVersion: from}
+	if from >= current {
+		return result, nil
+	}
+
+	for _, m := range migrations {
+		if m.Version <= from || m.Version > current {
+			continue
+		}
+		for _, key := range m.Deprecates {
+			if _, ok := raw[key]; ok {
+				result.Deprecated = append(result.Deprecated, key)
+			}
+		}
+		if err := m.Apply(raw); err != nil {
+			return nil, fmt.Errorf("config migration to version %d (%s): %w", m.Version, m.Description, err)
+		}
+		result.Applied = append(result.Applied, m)
+	}
+
+	raw["config_version"] = current
+	result.ToVersion = current
+	return result, nil
+}
+
+// MigrateConfigFile upgrades the config file of appName at path in place,
+// keeping the original next to it as <path>.v<version>.bak. It returns nil
+// if the file is already current.
+func MigrateConfigFile(appName, path string) (*MigrationResult, error) {
+	raw, err := readRawConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := MigrateConfig(appName, raw)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Changed() {
+		return nil, nil
+	}
+
+	result.Path = path
+	result.BackupPath, err = writeMigratedConfig(path, raw, result.FromVersion)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// migrateLoadedConfig upgrades the config file v read, queueing a warning
+// about deprecated keys for TakeWarnings. If the upgraded file cannot be
+// written, for example because it is system-wide, the upgraded values are
+// used without saving them.
+func migrateLoadedConfig(v *viper.Viper, appName string) error {
+	path := v.ConfigFileUsed()
+	if path == "" {
+		return nil
+	}
+
+	raw, err := readRawConfig(path)
+	if err != nil {
+		return err
+	}
+	result, err := MigrateConfig(appName, raw)
+	if err != nil {
+		return err
+	}
+	if !result.Changed() {
+		return nil
+	}
+
+	result.Path = path
+	result.BackupPath, err = writeMigratedConfig(path, raw, result.FromVersion)
+	if warning := result.Warning(); warning != "" {
+		addWarning(warning)
+	}
+	if err != nil {
+		return v.MergeConfigMap(raw)
+	}
+	return v.ReadInConfig()
+}
+
+// readRawConfig reads a config file into a map without defaults or
+// environment overrides
+func readRawConfig(path string) (map[string]any, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if ext := filepath.Ext(path); ext != "" {
+		v.SetConfigType(ext[1:])
+	}
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	return v.AllSettings(), nil
+}
+
+// writeMigratedConfig backs up the config file at path as version from and
+// writes raw in its place, in the same format. It returns the backup path.
+func writeMigratedConfig(path string, raw map[string]any, from int) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat config: %w", err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config: %w", err)
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, from)
+	if err := os.WriteFile(backup, original, info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to back up config: %w", err)
+	}
+
+	v := viper.New()
+	if ext := filepath.Ext(path); ext != "" {
+		v.SetConfigType(ext[1:])
+	}
+	for key, value := range raw {
+		v.Set(key, value)
+	}
+	if err := v.WriteConfigAs(path); err != nil {
+		return "", fmt.Errorf("failed to write config: %w", err)
+	}
+	return backup, nil
+}
+
+// rawConfigVersion returns the config_version of raw, 0 if unset
+func rawConfigVersion(raw map[string]any) int {
+	switch v := raw["config_version"].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	}
+	return 0
+}
+
+// migrateBibLegacyServer moves the single server address and the nodes
+// list of early bib configs to connection.favorite_nodes. The server
+// becomes the default node unless one is set already.
+func migrateBibLegacyServer(raw map[string]any) error {
+	conn, _ := raw["connection"].(map[string]any)
+	if conn == nil {
+		conn = make(map[string]any)
+	}
+	favorites, _ := conn["favorite_nodes"].([]any)
+
+	known := func(address string) bool {
+		for _, f := range favorites {
+			if node, ok := f.(map[string]any); ok && node["address"] == address {
+				return true
+			}
+		}
+		return false
+	}
+	hasDefault := func() bool {
+		if node, _ := conn["default_node"].(string); node != "" {
+			return true
+		}
+		for _, f := range favorites {
+			if node, ok := f.(map[string]any); ok && node["default"] == true {
+				return true
+			}
+		}
+		return false
+	}
+
+	if nodes, ok := raw["nodes"].([]any); ok {
+		for _, n := range nodes {
+			node, ok := n.(map[string]any)
+			if !ok {
+				continue
+			}
+			address, _ := node["address"].(string)
+			if address == "" || known(address) {
+				continue
+			}
+			if _, ok := node["priority"]; !ok {
+				node["priority"] = len(favorites)
+			}
+			favorites = append(favorites, node)
+		}
+		delete(raw, "nodes")
+	}
+
+	if server, ok := raw["server"].(string); ok {
+		if server != "" {
+			if !hasDefault() {
+				conn["default_node"] = server
+			}
+			if !known(server) {
+				favorites = append(favorites, map[string]any{
+					"address":  server,
+					"priority": len(favorites),
+				})
+			}
+		}
+		delete(raw, "server")
+	}
+
+	if len(favorites) > 0 {
+		conn["favorite_nodes"] = favorites
+	}
+	raw["connection"] = conn
+	return nil
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const legacyBibConfig = `identity:
+  name: Jane
+server: "localhost:4000"
+nodes:
+  - address: "node1.example.com:4000"
+    alias: "one"
+  - address: "localhost:4000"
+    alias: "local"
+`
+
+func TestMigrateConfig_LegacyServer(t *testing.T) {
+	raw := map[string]any{
+		"server": "localhost:4000",
+		"nodes": []any{
+			map[string]any{"address": "node1.example.com:4000", "alias": "one"},
+		},
+	}
+
+	result, err := MigrateConfig(AppBib, raw)
+	if err != nil {
+		t.Fatalf("MigrateConfig failed: %v", err)
+	}
+	if result.FromVersion != 0 || len(result.Applied) != 1 {
+		t.Errorf("expected one migration from version 0, got %d from %d", len(result.Applied), result.FromVersion)
+	}
+	if strings.Join(result.Deprecated, ",") != "server,nodes" {
+		t.Errorf("expected server and nodes reported as deprecated, got %v", result.Deprecated)
+	}
+	if raw["config_version"] != BibConfigVersion {
+		t.Errorf("expected config_version %d, got %v", BibConfigVersion, raw["config_version"])
+	}
+	if _, ok := raw["server"]; ok {
+		t.Error("expected the legacy server key to be removed")
+	}
+	if _, ok := raw["nodes"]; ok {
+		t.Error("expected the legacy nodes key to be removed")
+	}
+
+	conn := raw["connection"].(map[string]any)
+	if conn["default_node"] != "localhost:4000" {
+		t.Errorf("expected the server as default node, got %v", conn["default_node"])
+	}
+	favorites := conn["favorite_nodes"].([]any)
+	if len(favorites) != 2 {
+		t.Fatalf("expected 2 favorite nodes, got %d", len(favorites))
+	}
+	server := favorites[1].(map[string]any)
+	if server["address"] != "localhost:4000" || server["priority"] != 1 {
+		t.Errorf("unexpected server node %v", server)
+	}
+}
+
+func TestMigrateConfig_KeepsDefaultNode(t *testing.T) {
+	raw := map[string]any{
+		"server": "old:4000",
+		"connection": map[string]any{
+			"default_node": "new",
+			"favorite_nodes": []any{
+				map[string]any{"address": "new:4000", "alias": "new"},
+			},
+		},
+	}
+
+	if _, err := MigrateConfig(AppBib, raw); err != nil {
+		t.Fatalf("MigrateConfig failed: %v", err)
+	}
+
+	conn := raw["connection"].(map[string]any)
+	if conn["default_node"] != "new" {
+		t.Errorf("expected the default node to be kept, got %v", conn["default_node"])
+	}
+	if n := len(conn["favorite_nodes"].([]any)); n != 2 {
+		t.Errorf("expected the server added as a second favorite, got %d favorites", n)
+	}
+}
+
+func TestMigrateConfig_CurrentAndNewer(t *testing.T) {
+	for _, version := range []int{BibConfigVersion, BibConfigVersion + 1} {
+		raw := map[string]any{"config_version": version, "server": "localhost:4000"}
+		result, err := MigrateConfig(AppBib, raw)
+		if err != nil {
+			t.Fatalf("MigrateConfig failed: %v", err)
+		}
+		if result.Changed() || raw["server"] == nil {
+			t.Errorf("expected version %d config to be left alone", version)
+		}
+	}
+}
+
+func TestMigrateConfig_UnknownApp(t *testing.T) {
+	if _, err := MigrateConfig("other", map[string]any{}); err == nil {
+		t.Error("expected error for unknown app")
+	}
+}
+
+func TestMigrateConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(legacyBibConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := MigrateConfigFile(AppBib, path)
+	if err != nil {
+		t.Fatalf("MigrateConfigFile failed: %v", err)
+	}
+	if result == nil || result.FromVersion != 0 || result.ToVersion != BibConfigVersion {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	backup, err := os.ReadFile(result.BackupPath)
+	if err != nil {
+		t.Fatalf("expected a backup: %v", err)
+	}
+	if string(backup) != legacyBibConfig {
+		t.Error("expected the backup to hold the original file")
+	}
+	if result.BackupPath != path+".v0.bak" {
+		t.Errorf("unexpected backup path %s", result.BackupPath)
+	}
+
+	again, err := MigrateConfigFile(AppBib, path)
+	if err != nil || again != nil {
+		t.Errorf("expected an upgraded file to be left alone, got %+v, %v", again, err)
+	}
+}
+
+func TestLoadBib_MigratesLegacyConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(legacyBibConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadBib(path)
+	if err != nil {
+		t.Fatalf("LoadBib failed: %v", err)
+	}
+
+	if cfg.ConfigVersion != BibConfigVersion {
+		t.Errorf("expected config version %d, got %d", BibConfigVersion, cfg.ConfigVersion)
+	}
+	if cfg.Identity.Name != "Jane" {
+		t.Errorf("expected identity to survive the migration, got %q", cfg.Identity.Name)
+	}
+	if cfg.GetDefaultServerAddress() != "localhost:4000" {
+		t.Errorf("expected default server localhost:4000, got %s", cfg.GetDefaultServerAddress())
+	}
+	if len(cfg.Connection.FavoriteNodes) != 2 {
+		t.Errorf("expected 2 favorite nodes, got %d", len(cfg.Connection.FavoriteNodes))
+	}
+	if _, err := os.Stat(path + ".v0.bak"); err != nil {
+		t.Errorf("expected a backup of the original: %v", err)
+	}
+
+	warnings := TakeWarnings()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `the keys "server" and "nodes" are deprecated`) {
+		t.Errorf("expected one deprecation warning, got %q", warnings)
+	}
+
+	if _, err := LoadBib(path); err != nil {
+		t.Fatalf("LoadBib failed: %v", err)
+	}
+	if warnings := TakeWarnings(); len(warnings) != 0 {
+		t.Errorf("expected no warning once migrated, got %q", warnings)
+	}
+}
+
+func TestSaveBib_StampsVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := SaveBib(DefaultBibConfig(), path); err != nil {
+		t.Fatalf("SaveBib failed: %v", err)
+	}
+
+	raw, err := readRawConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rawConfigVersion(raw) != BibConfigVersion {
+		t.Errorf("expected config_version %d, got %v", BibConfigVersion, raw["config_version"])
+	}
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Permission check modes of ServerConfig.PermissionCheck
+const (
+	PermissionCheckWarn   = "warn"   // log sensitive paths that are too open
+	PermissionCheckStrict = "strict" // refuse to start while any is too open
+	PermissionCheckOff    = "off"    // don't check
+)
+
+// Permissions of sensitive paths: only the owner may access them
+const (
+	SensitiveDirMode  os.FileMode = 0700
+	SensitiveFileMode os.FileMode = 0600
+)
+
+// SensitivePath is a file or directory holding credentials, keys or
+// database files
+type SensitivePath struct {
+	Path string
+
+	// Recursive also checks everything below a directory
+	Recursive bool
+}
+
+// PermissionIssue is a sensitive path that users other than its owner
+// may access
+type PermissionIssue struct {
+	Path  string
+	IsDir bool
+
+	// Mode is the current permissions, Want the tightened ones
+	Mode os.FileMode
+	Want os.FileMode
+}
+
+func (i PermissionIssue) String() string {
+	return fmt.Sprintf("%s has mode %04o, want %04o", i.Path, i.Mode, i.Want)
+}
+
+// SensitivePaths returns the paths of the daemon holding credentials, keys
+// or database files: the data directory itself, the managed PostgreSQL
+// data and certificates, secrets, the SQLite database, backups, and the
+// certificates and keys in configDir. Paths that don't exist are skipped
+// by CheckPermissions.
+func (c *BibdConfig) SensitivePaths(configDir string) []SensitivePath {
+	dataDir := c.Server.DataDir
+	paths := []SensitivePath{
+		{Path: dataDir},
+		{Path: filepath.Join(dataDir, "postgres"), Recursive: true},
+		{Path: filepath.Join(dataDir, "secrets"), Recursive: true},
+		{Path: filepath.Join(dataDir, "backups"), Recursive: true},
+		{Path: filepath.Join(configDir, "certs"), Recursive: true},
+		{Path: filepath.Join(configDir, "secrets"), Recursive: true},
+	}
+
+	sqlitePath := c.Database.SQLite.Path
+	if sqlitePath == "" {
+		sqlitePath = filepath.Join(dataDir, "cache.db")
+	}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		paths = append(paths, SensitivePath{Path: sqlitePath + suffix})
+	}
+
+	if dir := c.Database.Postgres.DataDir; dir != "" {
+		paths = append(paths, SensitivePath{Path: dir, Recursive: true})
+	}
+	if dir := c.Database.Postgres.TLS.CertDir; dir != "" {
+		paths = append(paths, SensitivePath{Path: dir, Recursive: true})
+	}
+	if dir := c.Database.EncryptionAtRest.Recovery.SharesDir; dir != "" {
+		paths = append(paths, SensitivePath{Path: dir, Recursive: true})
+	}
+
+	identityKey := c.P2P.Identity.KeyPath
+	if identityKey == "" {
+		identityKey = filepath.Join(configDir, "identity.pem")
+	}
+	hostKey := c.SSH.HostKeyPath
+	if hostKey == "" {
+		hostKey = filepath.Join(configDir, "ssh_host_key")
+	}
+	paths = append(paths, SensitivePath{Path: identityKey}, SensitivePath{Path: hostKey})
+
+	if c.Server.TLS.KeyFile != "" {
+		paths = append(paths, SensitivePath{Path: c.Server.TLS.KeyFile})
+	}
+	if c.Server.TLS.CAKeyFile != "" {
+		paths = append(paths, SensitivePath{Path: c.Server.TLS.CAKeyFile})
+	}
+
+	return paths
+}
+
+// CheckPermissions returns the paths that users other than their owner may
+// access, which should be SensitiveDirMode for directories and
+// SensitiveFileMode for files. Paths that don't exist, can't be read or
+// are symlinks are skipped. On Windows, where permission bits don't apply,
+// it returns nothing.
+func CheckPermissions(paths []SensitivePath) []PermissionIssue {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	var issues []PermissionIssue
+	seen := make(map[string]bool)
+	check := func(path string, info fs.FileInfo) {
+		if seen[path] || info.Mode()&fs.ModeSymlink != 0 {
+			return
+		}
+		seen[path] = true
+
+		mode := info.Mode().Perm()
+		if mode&0077 == 0 {
+			return
+		}
+		want := SensitiveFileMode
+		if info.IsDir() {
+			want = SensitiveDirMode
+		}
+		issues = append(issues, PermissionIssue{Path: path, IsDir: info.IsDir(), Mode: mode, Want: want})
+	}
+
+	for _, p := range paths {
+		if p.Path == "" {
+			continue
+		}
+		info, err := os.Lstat(p.Path)
+		if err != nil {
+			continue
+		}
+		if !p.Recursive || !info.IsDir() {
+			check(p.Path, info)
+			continue
+		}
+
+		_ = filepath.WalkDir(p.Path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Skip what can't be read
+			}
+			if info, err := d.Info(); err == nil {
+				check(path, info)
+			}
+			return nil
+		})
+	}
+
+	return issues
+}
+
+// FixPermissions tightens the permissions of issues to what they want
+func FixPermissions(issues []PermissionIssue) error {
+	var errs []error
+	for _, issue := range issues {
+		if err := os.Chmod(issue.Path, issue.Want); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits don't apply on Windows")
+	}
+
+	dir := t.TempDir()
+	certs := filepath.Join(dir, "certs")
+	if err := os.MkdirAll(certs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	open := filepath.Join(certs, "ca.key")
+	closed := filepath.Join(certs, "server.key")
+	if err := os.WriteFile(open, []byte("key"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(closed, []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(open, 0644); err != nil { // Ignore the umask
+		t.Fatal(err)
+	}
+	if err := os.Chmod(certs, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := CheckPermissions([]SensitivePath{
+		{Path: certs, Recursive: true},
+		{Path: filepath.Join(dir, "missing")},
+	})
+
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	byPath := map[string]PermissionIssue{}
+	for _, issue := range issues {
+		byPath[issue.Path] = issue
+	}
+	if issue := byPath[certs]; !issue.IsDir || issue.Mode != 0755 || issue.Want != SensitiveDirMode {
+		t.Errorf("unexpected directory issue %+v", issue)
+	}
+	if issue := byPath[open]; issue.IsDir || issue.Mode != 0644 || issue.Want != SensitiveFileMode {
+		t.Errorf("unexpected file issue %+v", issue)
+	}
+
+	if err := FixPermissions(issues); err != nil {
+		t.Fatalf("FixPermissions failed: %v", err)
+	}
+	if again := CheckPermissions([]SensitivePath{{Path: certs, Recursive: true}}); len(again) != 0 {
+		t.Errorf("expected no issues after fixing, got %v", again)
+	}
+}
+
+func TestCheckPermissions_NotRecursive(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits don't apply on Windows")
+	}
+
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	blob := filepath.Join(dir, "blob")
+	if err := os.WriteFile(blob, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if issues := CheckPermissions([]SensitivePath{{Path: dir}}); len(issues) != 0 {
+		t.Errorf("expected only the directory itself checked, got %v", issues)
+	}
+}
+
+func TestBibdConfig_SensitivePaths(t *testing.T) {
+	cfg := DefaultBibdConfig()
+	cfg.Server.DataDir = "/data"
+	cfg.Database.Postgres.DataDir = "/pg"
+
+	paths := map[string]bool{}
+	for _, p := range cfg.SensitivePaths("/config") {
+		paths[p.Path] = p.Recursive
+	}
+
+	for path, recursive := range map[string]bool{
+		"/data":                false,
+		"/data/postgres":       true,
+		"/data/secrets":        true,
+		"/data/cache.db":       false,
+		"/pg":                  true,
+		"/config/certs":        true,
+		"/config/identity.pem": false,
+		"/config/ssh_host_key": false,
+	} {
+		got, ok := paths[path]
+		if !ok {
+			t.Errorf("expected %s among the sensitive paths", path)
+		} else if got != recursive {
+			t.Errorf("%s: recursive = %v, want %v", path, got, recursive)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+// Preferences are the bib settings a user can sync across machines through
+// the daemon. Empty strings and a nil Color mean "not set".
+type Preferences struct {
+	OutputFormat string
+	Color        *bool
+	Locale       string
+}
+
+// Preferences returns the synced settings of c.
+func (c *BibConfig) Preferences() Preferences {
+	color := c.Output.Color
+	return Preferences{
+		OutputFormat: c.Output.Format,
+		Color:        &color,
+		Locale:       c.Locale,
+	}
+}
+
+// ApplyPreferences merges preferences stored on the daemon into c and
+// returns the keys it changed. Settings the user changed locally (those that
+// differ from the defaults) win, unless overwrite is set.
+func (c *BibConfig) ApplyPreferences(p Preferences, overwrite bool) []string {
+	defaults := DefaultBibConfig()
+	var changed []string
+
+	if p.OutputFormat != "" && p.OutputFormat != c.Output.Format &&
+		(overwrite || c.Output.Format == "" || c.Output.Format == defaults.Output.Format) {
+		c.Output.Format = p.OutputFormat
+		changed = append(changed, "output.format")
+	}
+	if p.Color != nil && *p.Color != c.Output.Color &&
+		(overwrite || c.Output.Color == defaults.Output.Color) {
+		c.Output.Color = *p.Color
+		changed = append(changed, "output.color")
+	}
+	if p.Locale != "" && p.Locale != c.Locale &&
+		(overwrite || c.Locale == defaults.Locale) {
+		c.Locale = p.Locale
+		changed = append(changed, "locale")
+	}
+
+	return changed
+}
@@ -39,6 +39,12 @@ func resolveSecretsRecursive(v reflect.Value) error {
 				}
 			}
 		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := resolveSecretsRecursive(v.Index(i)); err != nil {
+				return err
+			}
+		}
 	case reflect.String:
 		if v.CanSet() {
 			resolved, err := resolveSecretValue(v.String())
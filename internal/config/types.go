@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"runtime"
 	"time"
 )
@@ -18,6 +19,8 @@ type LogConfig struct {
 	NoColor         bool     `mapstructure:"no_color"`           // disable colored output (pretty format only)
 	AuditPath       string   `mapstructure:"audit_path"`         // path to audit log file
 	AuditMaxAgeDays int      `mapstructure:"audit_max_age_days"` // max days to retain audit logs
+	AuditMaxSizeMB  int      `mapstructure:"audit_max_size_mb"`  // max audit log size in MB before rotation
+	AuditMaxBackups int      `mapstructure:"audit_max_backups"`  // max rotated audit logs to keep (0 = all within max age)
 	RedactFields    []string `mapstructure:"redact_fields"`      // field names to redact from logs
 }
 
@@ -36,12 +39,171 @@ type OutputConfig struct {
 
 // ServerConfig holds daemon server configuration (bibd only)
 type ServerConfig struct {
-	Host    string     `mapstructure:"host"`
-	Port    int        `mapstructure:"port"`
-	TLS     TLSConfig  `mapstructure:"tls"`
-	GRPC    GRPCConfig `mapstructure:"grpc"`
-	PIDFile string     `mapstructure:"pid_file"`
-	DataDir string     `mapstructure:"data_dir"`
+	Host    string        `mapstructure:"host"`
+	Port    int           `mapstructure:"port"`
+	TLS     TLSConfig     `mapstructure:"tls"`
+	GRPC    GRPCConfig    `mapstructure:"grpc"`
+	Gateway GatewayConfig `mapstructure:"gateway"`
+	PIDFile string        `mapstructure:"pid_file"`
+	DataDir string        `mapstructure:"data_dir"`
+
+	// PermissionCheck is how startup treats credentials, keys and database
+	// files that other users may access: "warn" (default), "strict" to
+	// refuse to start, or "off"
+	PermissionCheck string `mapstructure:"permission_check"`
+
+	// FilesystemCheck is how startup treats a data directory or database on
+	// a network filesystem, where file locking is unreliable: "warn"
+	// (default), "strict" to refuse to start, or "off"
+	FilesystemCheck string `mapstructure:"filesystem_check"`
+
+	// Watchdog flags goroutine leaks and a hung daemon
+	Watchdog WatchdogConfig `mapstructure:"watchdog"`
+
+	// Startup retries components that fail to start for transient reasons
+	Startup StartupConfig `mapstructure:"startup"`
+
+	// ShutdownTimeout is how long a graceful shutdown may take. Each
+	// shutdown phase gets a share of it, so a slow phase cannot starve the
+	// later ones, such as stopping PostgreSQL (default: 60s)
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+}
+
+// StartupConfig holds how the daemon retries components that fail to start
+// for a transient reason, such as a container runtime that is not up yet
+// or a port that is briefly in use. Fatal errors, such as an invalid
+// configuration, are never retried.
+type StartupConfig struct {
+	// Retry applies to every component without settings of its own
+	Retry StartupRetryConfig `mapstructure:"retry"`
+
+	// Storage, P2P, Cluster, GRPC and SSH override Retry per component.
+	// Unset fields fall back to Retry.
+	Storage StartupRetryConfig `mapstructure:"storage"`
+	P2P     StartupRetryConfig `mapstructure:"p2p"`
+	Cluster StartupRetryConfig `mapstructure:"cluster"`
+	GRPC    StartupRetryConfig `mapstructure:"grpc"`
+	SSH     StartupRetryConfig `mapstructure:"ssh"`
+}
+
+// StartupRetryConfig holds the retry settings of a component.
+type StartupRetryConfig struct {
+	// Attempts is how often the component is started before startup
+	// fails. 1 disables retries (default: 5)
+	Attempts int `mapstructure:"attempts"`
+
+	// InitialBackoff is the wait before the first retry, doubled on each
+	// retry (default: 1s)
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+
+	// MaxBackoff caps the wait between retries (default: 30s)
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+// Component returns the retry settings of a component: its own settings,
+// with unset fields taken from Retry.
+func (c StartupConfig) Component(name string) StartupRetryConfig {
+	var own StartupRetryConfig
+	switch name {
+	case "storage":
+		own = c.Storage
+	case "p2p":
+		own = c.P2P
+	case "cluster":
+		own = c.Cluster
+	case "grpc":
+		own = c.GRPC
+	case "ssh":
+		own = c.SSH
+	}
+
+	if own.Attempts == 0 {
+		own.Attempts = c.Retry.Attempts
+	}
+	if own.InitialBackoff == 0 {
+		own.InitialBackoff = c.Retry.InitialBackoff
+	}
+	if own.MaxBackoff == 0 {
+		own.MaxBackoff = c.Retry.MaxBackoff
+	}
+	return own
+}
+
+// WatchdogConfig holds the settings of the daemon watchdog. The watchdog
+// samples the goroutine count and a liveness heartbeat of the daemon, and
+// logs a goroutine dump when either looks wrong.
+type WatchdogConfig struct {
+	// Enabled controls whether the watchdog runs (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is how often the watchdog samples (default: 30s)
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxGoroutines is the goroutine count above which the watchdog fires.
+	// 0 disables the check (default: 10000)
+	MaxGoroutines int `mapstructure:"max_goroutines"`
+
+	// GrowthSamples is the number of consecutive samples the goroutine
+	// count must grow in before the watchdog fires. 0 disables the check
+	// (default: 10)
+	GrowthSamples int `mapstructure:"growth_samples"`
+
+	// StallTimeout is how long the daemon's heartbeat may be missing
+	// before the watchdog fires (default: 2m)
+	StallTimeout time.Duration `mapstructure:"stall_timeout"`
+}
+
+// GatewayConfig holds the HTTP/JSON gateway settings. The gateway serves a
+// subset of the gRPC API (health, queries and reading datasets) as JSON over
+// HTTP, for browsers and scripts, on a listener of its own.
+type GatewayConfig struct {
+	// Enabled controls whether the gateway is active (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Host is the address to bind to (default: "127.0.0.1")
+	Host string `mapstructure:"host"`
+
+	// Port is the gateway port (default: 8080)
+	Port int `mapstructure:"port"`
+
+	// TLS configures HTTPS for the gateway, independently of the gRPC server
+	TLS GatewayTLSConfig `mapstructure:"tls"`
+
+	// Auth configures how gateway requests are authenticated
+	Auth GatewayAuthConfig `mapstructure:"auth"`
+
+	// CORSOrigins are the origins browsers may call the gateway from;
+	// "*" allows any origin (default: none)
+	CORSOrigins []string `mapstructure:"cors_origins"`
+
+	// MaxBodySize is the largest request body accepted, in bytes (default: 1MB)
+	MaxBodySize int64 `mapstructure:"max_body_size"`
+}
+
+// GatewayTLSConfig holds the gateway's TLS settings
+type GatewayTLSConfig struct {
+	// Enabled controls whether the gateway serves HTTPS (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// CertFile is the path to the gateway certificate. If empty, the node's
+	// server certificate is used.
+	CertFile string `mapstructure:"cert_file"`
+
+	// KeyFile is the path to the gateway private key
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// GatewayAuthConfig holds the gateway's authentication settings. Health
+// endpoints are always public; every other request must pass all the
+// checks configured here, and at least one must be.
+type GatewayAuthConfig struct {
+	// APIKeys are the keys accepted in the X-API-Key header. If set, every
+	// request must carry one of them (default: none)
+	APIKeys []string `mapstructure:"api_keys"`
+
+	// RequireToken requires a session token in an "Authorization: Bearer"
+	// header, which is passed on to the gRPC services (default: true)
+	RequireToken bool `mapstructure:"require_token"`
 }
 
 // GRPCConfig holds gRPC server configuration
@@ -57,8 +219,11 @@ type GRPCConfig struct {
 	Port int `mapstructure:"port"`
 
 	// UnixSocket is the path to the Unix socket for local CLI connections.
-	// If empty, Unix socket is disabled.
-	// On Windows, this creates a named pipe instead.
+	// It defaults to the path clients probe, see getDefaultUnixSocket. If
+	// empty, Unix socket is disabled. The socket is only accessible to the
+	// daemon's user.
+	// On Windows, this creates a named pipe instead. A full pipe name
+	// (\\.\pipe\name) is used as-is; otherwise the file's base name is used.
 	UnixSocket string `mapstructure:"unix_socket"`
 
 	// MaxRecvMsgSize is the maximum receive message size in bytes (default: 16MB)
@@ -80,9 +245,24 @@ type GRPCConfig struct {
 	// RateLimit configures per-user rate limiting
 	RateLimit GRPCRateLimitConfig `mapstructure:"rate_limit"`
 
+	// Concurrency caps the calls processed at once
+	Concurrency GRPCConcurrencyConfig `mapstructure:"concurrency"`
+
 	// Metrics configures Prometheus metrics
 	Metrics GRPCMetricsConfig `mapstructure:"metrics"`
 
+	// Compression configures message compression negotiation
+	Compression GRPCCompressionConfig `mapstructure:"compression"`
+
+	// Profiling configures pprof profiles captured through the admin service
+	Profiling GRPCProfilingConfig `mapstructure:"profiling"`
+
+	// Recovery configures how panics in handlers are reported
+	Recovery GRPCRecoveryConfig `mapstructure:"recovery"`
+
+	// Readiness holds back calls until the daemon is ready after start
+	Readiness GRPCReadinessConfig `mapstructure:"readiness"`
+
 	// ShutdownGracePeriod is how long to wait for connections to drain (default: 30s)
 	ShutdownGracePeriod time.Duration `mapstructure:"shutdown_grace_period"`
 }
@@ -101,6 +281,58 @@ type GRPCKeepaliveConfig struct {
 
 	// PermitWithoutStream allows pings even without active streams (default: false)
 	PermitWithoutStream bool `mapstructure:"permit_without_stream"`
+
+	// MaxConnectionIdle is how long a connection may have no active streams
+	// before the server closes it (default: 30m). Clients reconnect
+	// transparently on their next call. 0 keeps idle connections open.
+	MaxConnectionIdle time.Duration `mapstructure:"max_connection_idle"`
+}
+
+// GRPCConcurrencyConfig caps the calls being processed at once. Calls over
+// a cap are rejected with ResourceExhausted rather than queued, bounding the
+// memory a burst can take. Health checks are never rejected.
+type GRPCConcurrencyConfig struct {
+	// MaxInFlight is the maximum number of calls, unary and streaming, being
+	// processed on the node. 0 means unlimited (default: 1000)
+	MaxInFlight int `mapstructure:"max_in_flight"`
+
+	// MaxInFlightPerConnection is the maximum number of calls being
+	// processed for one client connection. 0 means unlimited (default: 50)
+	MaxInFlightPerConnection int `mapstructure:"max_in_flight_per_connection"`
+}
+
+// GRPCProfilingConfig holds the settings of AdminService.CaptureProfile,
+// which lets admins capture pprof profiles of the daemon.
+type GRPCProfilingConfig struct {
+	// Enabled allows admins to capture profiles (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxDuration is the longest a profile may be sampled (default: 2m)
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+}
+
+// GRPCRecoveryConfig holds the settings of panic reporting. Panics in
+// handlers are always recovered and logged; crash reports are optional.
+type GRPCRecoveryConfig struct {
+	// CrashReportDir is the directory a crash report is written to for
+	// every panic. Empty disables crash reports (default: "")
+	CrashReportDir string `mapstructure:"crash_report_dir"`
+
+	// MaxCrashReports is the number of crash reports kept; older ones are
+	// removed. 0 keeps all (default: 50)
+	MaxCrashReports int `mapstructure:"max_crash_reports"`
+}
+
+// GRPCReadinessConfig holds the settings of the readiness gate. Until the
+// daemon is running and all enabled components are healthy, calls other
+// than health checks fail with Unavailable and reason STARTING.
+type GRPCReadinessConfig struct {
+	// Enabled controls whether the readiness gate is active (default: true)
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxWait opens the gate after this long even if the daemon is not
+	// ready. 0 waits indefinitely (default: 5m)
+	MaxWait time.Duration `mapstructure:"max_wait"`
 }
 
 // GRPCRateLimitConfig holds gRPC rate limiting settings
@@ -113,6 +345,66 @@ type GRPCRateLimitConfig struct {
 
 	// Burst is the maximum burst size (default: 200)
 	Burst int `mapstructure:"burst"`
+
+	// Methods overrides the limit for individual methods or whole services,
+	// keyed by "Service/Method" (e.g. "QueryService/Execute") or "Service"
+	// (e.g. "HealthService"). Names are case-insensitive. Calls of a method
+	// with an override are counted separately from the global limit.
+	Methods map[string]GRPCMethodRateLimit `mapstructure:"methods"`
+
+	// Adaptive sheds load while the node is over its load targets
+	Adaptive GRPCAdaptiveRateLimitConfig `mapstructure:"adaptive"`
+}
+
+// GRPCMethodRateLimit is the rate limit of a method or service
+type GRPCMethodRateLimit struct {
+	// RequestsPerSecond is the maximum requests per second per user
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+
+	// Burst is the maximum burst size
+	Burst int `mapstructure:"burst"`
+}
+
+// GRPCAdaptiveRateLimitConfig configures load shedding. While the node is
+// over one of its load targets, bibd admits a shrinking share of calls and
+// rejects the rest with Unavailable; once it is back under all of them, the
+// share grows again.
+type GRPCAdaptiveRateLimitConfig struct {
+	// Enabled controls whether load shedding is active (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// CPUPercent is the target CPU use of bibd, as a percentage of the CPUs
+	// available to it (default: 80)
+	CPUPercent float64 `mapstructure:"cpu_percent"`
+
+	// MemoryMB is the target memory use of bibd in megabytes. 0 uses 90% of
+	// the Go memory limit (GOMEMLIMIT) if one is set (default: 0)
+	MemoryMB int `mapstructure:"memory_mb"`
+
+	// MaxInFlight is the target number of unary calls handled at once
+	// (default: 100)
+	MaxInFlight int `mapstructure:"max_in_flight"`
+
+	// MinFactor is the smallest share of calls admitted while overloaded
+	// (default: 0.1)
+	MinFactor float64 `mapstructure:"min_factor"`
+
+	// Interval is how often the load is sampled (default: 1s)
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// GRPCCompressionConfig holds gRPC message compression settings.
+// Compression trades CPU for bandwidth, so it is opt-in. Responses are only
+// compressed when the client advertises support for the algorithm.
+type GRPCCompressionConfig struct {
+	// Enabled controls whether responses are compressed (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// Algorithm is the compressor to use: "gzip" or "zstd" (default: "zstd")
+	Algorithm string `mapstructure:"algorithm"`
+
+	// MinSize is the smallest response, in bytes, that gets compressed (default: 1024)
+	MinSize int `mapstructure:"min_size"`
 }
 
 // GRPCMetricsConfig holds gRPC metrics settings
@@ -133,6 +425,27 @@ type GRPCMetricsConfig struct {
 	// EnableLatencyHistograms enables detailed latency histograms (default: true)
 	// Disable for reduced memory usage in high-throughput scenarios.
 	EnableLatencyHistograms bool `mapstructure:"enable_latency_histograms"`
+
+	// Auth configures how scrapes of the HTTP endpoint are authenticated
+	Auth GRPCMetricsAuthConfig `mapstructure:"auth"`
+
+	// AllowedCIDRs are the source networks allowed to scrape the HTTP
+	// endpoint, e.g. "10.0.0.0/8". If empty, any address may (default: none)
+	AllowedCIDRs []string `mapstructure:"allowed_cidrs"`
+}
+
+// GRPCMetricsAuthConfig holds the metrics endpoint's authentication
+// settings. A scrape must pass every check configured here; with none, the
+// endpoint is public.
+type GRPCMetricsAuthConfig struct {
+	// BearerTokens are the tokens accepted in an "Authorization: Bearer"
+	// header. If set, every scrape must carry one of them (default: none)
+	BearerTokens []string `mapstructure:"bearer_tokens"`
+
+	// MTLS serves the endpoint over HTTPS with the node's server
+	// certificate and requires a client certificate issued by the node's
+	// CA (default: false)
+	MTLS bool `mapstructure:"mtls"`
 }
 
 // TLSConfig holds TLS/SSL configuration
@@ -150,13 +463,34 @@ type TLSConfig struct {
 	// KeyFile is the path to the server private key (optional if AutoGenerate is true)
 	KeyFile string `mapstructure:"key_file"`
 
-	// CAFile is the path to the CA certificate (optional if AutoGenerate is true)
+	// CAFile is the path to the CA certificate (optional if AutoGenerate is true).
+	// With AutoGenerate false, the CA certificates of an external PKI: the
+	// issuing CA first, then its intermediates and root.
 	CAFile string `mapstructure:"ca_file"`
 
+	// CAKeyFile is the key of the external issuing CA in CAFile. With it,
+	// bibd issues its certificates itself instead of loading CertFile.
+	CAKeyFile string `mapstructure:"ca_key_file"`
+
 	// ClientAuth controls client certificate verification mode
 	// Options: "none", "optional", "required" (default: "optional")
 	ClientAuth string `mapstructure:"client_auth"`
 
+	// MinVersion is the minimum TLS version the server negotiates: "1.2"
+	// or "1.3" (default: "1.3")
+	MinVersion string `mapstructure:"min_version"`
+
+	// CipherSuites are the TLS 1.2 cipher suites the server allows, by
+	// IANA name. Only valid with min_version "1.2"; TLS 1.3 suites are not
+	// configurable. Empty allows ECDHE with AES-GCM.
+	CipherSuites []string `mapstructure:"cipher_suites"`
+
+	// SPIFFE enables SPIFFE identities for mTLS (default: disabled)
+	SPIFFE SPIFFEConfig `mapstructure:"spiffe"`
+
+	// PKCS11 keeps the CA private key on a hardware token (default: disabled)
+	PKCS11 PKCS11Config `mapstructure:"pkcs11"`
+
 	// Validity settings for auto-generated certificates
 	CAValidityYears        int `mapstructure:"ca_validity_years"`         // Default: 10
 	ServerCertValidityDays int `mapstructure:"server_cert_validity_days"` // Default: 365
@@ -164,6 +498,46 @@ type TLSConfig struct {
 	RenewalThresholdDays   int `mapstructure:"renewal_threshold_days"`    // Default: 30
 }
 
+// SPIFFEConfig holds the SPIFFE settings of the node certificates.
+type SPIFFEConfig struct {
+	// Enabled adds a SPIFFE ID (spiffe://<trust_domain>/bibd/node/<node-id>)
+	// to the server certificate and requires client certificates to be
+	// SVIDs of the trust domain (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// TrustDomain is the SPIFFE trust domain, e.g. "example.org"
+	TrustDomain string `mapstructure:"trust_domain"`
+
+	// TrustBundleFile is a PEM file of the trust domain's CA certificates,
+	// e.g. exported from SPIRE, trusted besides the node's CA (optional)
+	TrustBundleFile string `mapstructure:"trust_bundle_file"`
+}
+
+// PKCS11Config locates the CA private key on a PKCS#11 token (HSM or
+// smartcard). Requires a bibd built with cgo and the pkcs11 build tag.
+type PKCS11Config struct {
+	// Enabled signs certificates with the token key instead of a CA key
+	// stored encrypted on disk (default: false)
+	Enabled bool `mapstructure:"enabled"`
+
+	// ModulePath is the token's PKCS#11 module, e.g.
+	// /usr/lib/softhsm/libsofthsm2.so
+	ModulePath string `mapstructure:"module_path"`
+
+	// Slot is the token's slot, used when TokenLabel is empty (default: 0)
+	Slot uint `mapstructure:"slot"`
+
+	// TokenLabel selects the token by label instead of slot (optional)
+	TokenLabel string `mapstructure:"token_label"`
+
+	// KeyLabel is the label of the CA key pair on the token
+	KeyLabel string `mapstructure:"key_label"`
+
+	// PIN is the token's user PIN. Use a secret reference such as
+	// env://BIBD_PKCS11_PIN or file:///run/secrets/pkcs11-pin.
+	PIN string `mapstructure:"pin"`
+}
+
 // SSHConfig holds SSH server configuration for TUI access.
 type SSHConfig struct {
 	// Enabled controls whether the SSH server is active (default: true)
@@ -200,6 +574,11 @@ type P2PConfig struct {
 	// Identity configuration
 	Identity P2PIdentityConfig `mapstructure:"identity"`
 
+	// Labels are key/value pairs describing this node (region, role,
+	// capacity class), exchanged with connected peers. Keys are lowercase
+	// letters, digits, '-' and '_'.
+	Labels map[string]string `mapstructure:"labels"`
+
 	// Listen addresses in multiaddr format
 	// Defaults: ["/ip4/0.0.0.0/tcp/4001", "/ip4/0.0.0.0/udp/4001/quic-v1"]
 	ListenAddresses []string `mapstructure:"listen_addresses"`
@@ -228,6 +607,9 @@ type P2PConfig struct {
 	// Proxy mode configuration
 	Proxy ProxyConfig `mapstructure:"proxy"`
 
+	// Messaging configures direct node-to-node messages
+	Messaging MessagingConfig `mapstructure:"messaging"`
+
 	// GRPC configuration for gRPC-over-P2P
 	GRPC P2PGRPCConfig `mapstructure:"grpc"`
 
@@ -365,6 +747,36 @@ type PeerStoreConfig struct {
 type FullReplicaConfig struct {
 	// SyncInterval is how often to poll peers for new data
 	SyncInterval time.Duration `mapstructure:"sync_interval"`
+
+	// FullSyncInterval is how often to fetch a peer's full catalog instead
+	// of the changes since the last sync, to catch removed entries
+	FullSyncInterval time.Duration `mapstructure:"full_sync_interval"`
+
+	// ConflictResolution selects how entries of a dataset that diverged on
+	// different peers are resolved: "last-writer-wins" or "version-vector"
+	ConflictResolution string `mapstructure:"conflict_resolution"`
+
+	// ManualConflictResolution holds conflicts for an operator to resolve.
+	// Until resolved, the entry picked by ConflictResolution is kept
+	ManualConflictResolution bool `mapstructure:"manual_conflict_resolution"`
+
+	// PeerSelection selects the peers to sync from
+	PeerSelection PeerSelectionConfig `mapstructure:"peer_selection"`
+}
+
+// PeerSelectionConfig holds the policy by which a node prefers some peers
+// over others
+type PeerSelectionConfig struct {
+	// Policy is "any" (no preference), "latency" (lowest measured latency
+	// first) or "topology" (peers sharing this node's MatchLabels first,
+	// then by latency)
+	Policy string `mapstructure:"policy"`
+
+	// MatchLabels are the label keys, broadest first such as ["region",
+	// "zone"], on which a peer must match this node's labels to be
+	// preferred by the topology policy. A peer matching more of the leading
+	// keys is preferred over one matching fewer
+	MatchLabels []string `mapstructure:"match_labels"`
 }
 
 // SelectiveConfig holds configuration for selective mode
@@ -376,6 +788,14 @@ type SelectiveConfig struct {
 	// SubscriptionStorePath is where to persist subscriptions
 	// If empty, defaults to config directory + "/subscriptions.json"
 	SubscriptionStorePath string `mapstructure:"subscription_store_path"`
+
+	// FetchCacheTTL is how long a dataset fetched on demand, outside the
+	// subscriptions, is cached
+	FetchCacheTTL time.Duration `mapstructure:"fetch_cache_ttl"`
+
+	// FetchCacheSize is the maximum number of datasets cached on demand
+	// The least recently used dataset is evicted when full
+	FetchCacheSize int `mapstructure:"fetch_cache_size"`
 }
 
 // ProxyConfig holds configuration for proxy mode
@@ -389,6 +809,33 @@ type ProxyConfig struct {
 	// FavoritePeers is a list of preferred peers for forwarding requests
 	// If empty, forwards to any discovered peer
 	FavoritePeers []string `mapstructure:"favorite_peers"`
+
+	// PeerSelection orders the peers requests are forwarded to, after the
+	// favorites
+	PeerSelection PeerSelectionConfig `mapstructure:"peer_selection"`
+}
+
+// MessagingConfig holds configuration for direct peer-to-peer messaging
+type MessagingConfig struct {
+	// Enabled controls whether this node sends and accepts direct messages
+	Enabled bool `mapstructure:"enabled"`
+
+	// MaxPayloadSize is the largest payload, in bytes, sent or accepted
+	MaxPayloadSize int `mapstructure:"max_payload_size"`
+
+	// AllowedPeers are peer IDs allowed to message this node, in addition
+	// to the peers in the allowed-peers table. "*" allows any peer.
+	AllowedPeers []string `mapstructure:"allowed_peers"`
+
+	// Timeout bounds a single delivery attempt, including the acknowledgment
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Retries is how many times a failed delivery is retried
+	// Rejections by the receiving peer are never retried
+	Retries int `mapstructure:"retries"`
+
+	// RetryBackoff is the wait before the first retry, doubled on each retry
+	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
 }
 
 // ClusterConfig holds HA cluster configuration using Raft consensus
@@ -435,6 +882,14 @@ type ClusterConfig struct {
 	// EnableDHTDiscovery allows automatic cluster discovery via DHT
 	EnableDHTDiscovery bool `mapstructure:"enable_dht_discovery"`
 
+	// MaxClockSkew is the clock skew to other members above which the
+	// cluster health reports a warning
+	MaxClockSkew time.Duration `mapstructure:"max_clock_skew"`
+
+	// TLS secures the Raft transport with mutual TLS. Without it, messages
+	// from other members are ignored, as their sender can't be trusted.
+	TLS ClusterTLSConfig `mapstructure:"tls"`
+
 	// Raft-specific settings
 	Raft RaftConfig `mapstructure:"raft"`
 
@@ -442,6 +897,21 @@ type ClusterConfig struct {
 	Snapshot SnapshotConfig `mapstructure:"snapshot"`
 }
 
+// ClusterTLSConfig holds the certificates of the Raft transport. Every
+// member's certificate must be issued by the CA in CAFile and name the
+// member's node ID as a DNS name.
+type ClusterTLSConfig struct {
+	// CertFile is the path to this node's certificate
+	CertFile string `mapstructure:"cert_file"`
+
+	// KeyFile is the path to this node's private key
+	KeyFile string `mapstructure:"key_file"`
+
+	// CAFile is the path to the CA certificate member certificates are
+	// verified against
+	CAFile string `mapstructure:"ca_file"`
+}
+
 // RaftConfig holds Raft consensus algorithm settings
 type RaftConfig struct {
 	// HeartbeatTimeout is the interval for leader heartbeats
@@ -474,6 +944,14 @@ type SnapshotConfig struct {
 
 	// RetainCount is how many snapshots to retain
 	RetainCount int `mapstructure:"retain_count"`
+
+	// Compression is the algorithm snapshot files are compressed with:
+	// "none", "gzip", or "zstd"
+	Compression string `mapstructure:"compression"`
+
+	// Encrypt encrypts snapshot files at rest with a key derived from the
+	// node's identity key
+	Encrypt bool `mapstructure:"encrypt"`
 }
 
 // FavoriteNode represents a preferred node for connection
@@ -534,6 +1012,10 @@ type ConnectionConfig struct {
 	// AutoDetect enables automatic node discovery via mDNS
 	AutoDetect bool `mapstructure:"auto_detect"`
 
+	// SRVRecord is a DNS SRV record listing the nodes to discover, such as
+	// _bibd._tcp.example.com, for networks without mDNS (empty to disable)
+	SRVRecord string `mapstructure:"srv_record,omitempty"`
+
 	// Timeout is the connection timeout
 	Timeout string `mapstructure:"timeout"`
 
@@ -543,17 +1025,22 @@ type ConnectionConfig struct {
 	// PoolSize is the connection pool size (0 = single connection)
 	PoolSize int `mapstructure:"pool_size"`
 
+	// VersionPolicy is what to do when the daemon's API version is
+	// incompatible with the CLI: "warn" (default), "refuse" or "ignore"
+	VersionPolicy string `mapstructure:"version_policy"`
+
 	// TLS holds TLS configuration for connections
 	TLS ConnectionTLSConfig `mapstructure:"tls"`
 }
 
 // BibConfig is the complete configuration for the bib CLI
 type BibConfig struct {
-	Log        LogConfig        `mapstructure:"log"`
-	Identity   IdentityConfig   `mapstructure:"identity"`
-	Output     OutputConfig     `mapstructure:"output"`
-	Locale     string           `mapstructure:"locale"`     // UI locale (en, de, fr, ru, zh-tw). Empty = auto-detect from system
-	Connection ConnectionConfig `mapstructure:"connection"` // Connection settings with nodes
+	ConfigVersion int              `mapstructure:"config_version"` // Schema version, see BibConfigVersion
+	Log           LogConfig        `mapstructure:"log"`
+	Identity      IdentityConfig   `mapstructure:"identity"`
+	Output        OutputConfig     `mapstructure:"output"`
+	Locale        string           `mapstructure:"locale"`     // UI locale (en, de, fr, ru, zh-tw). Empty = auto-detect from system
+	Connection    ConnectionConfig `mapstructure:"connection"` // Connection settings with nodes
 }
 
 // GetDefaultServerAddress returns the default server address.
@@ -596,14 +1083,15 @@ func (c *BibConfig) IsBibDevConfirmed() bool {
 
 // BibdConfig is the complete configuration for the bibd daemon
 type BibdConfig struct {
-	Log      LogConfig      `mapstructure:"log"`
-	Identity IdentityConfig `mapstructure:"identity"`
-	Server   ServerConfig   `mapstructure:"server"`
-	SSH      SSHConfig      `mapstructure:"ssh"`
-	Auth     AuthConfig     `mapstructure:"auth"`
-	P2P      P2PConfig      `mapstructure:"p2p"`
-	Cluster  ClusterConfig  `mapstructure:"cluster"`
-	Database DatabaseConfig `mapstructure:"database"`
+	ConfigVersion int            `mapstructure:"config_version"` // Schema version, see BibdConfigVersion
+	Log           LogConfig      `mapstructure:"log"`
+	Identity      IdentityConfig `mapstructure:"identity"`
+	Server        ServerConfig   `mapstructure:"server"`
+	SSH           SSHConfig      `mapstructure:"ssh"`
+	Auth          AuthConfig     `mapstructure:"auth"`
+	P2P           P2PConfig      `mapstructure:"p2p"`
+	Cluster       ClusterConfig  `mapstructure:"cluster"`
+	Database      DatabaseConfig `mapstructure:"database"`
 }
 
 // AuthConfig holds authentication and user management configuration.
@@ -626,11 +1114,36 @@ type AuthConfig struct {
 	// MaxSessionsPerUser is the maximum number of concurrent sessions per user.
 	// 0 means unlimited.
 	MaxSessionsPerUser int `mapstructure:"max_sessions_per_user"`
+
+	// DeviceAuth configures the device authorization flow used by bib login
+	// on machines without an identity key.
+	DeviceAuth DeviceAuthConfig `mapstructure:"device_auth"`
+}
+
+// DeviceAuthConfig holds device authorization settings. A device is shown a
+// short user code, which a signed-in user approves from another device.
+type DeviceAuthConfig struct {
+	// Enabled allows devices to log in by device authorization.
+	Enabled bool `mapstructure:"enabled"`
+
+	// CodeTTL is how long a code can be approved before it expires.
+	// Default: 10m
+	CodeTTL time.Duration `mapstructure:"code_ttl"`
+
+	// PollInterval is the minimum time between polls by a device. Devices
+	// polling faster are told to slow down.
+	// Default: 5s
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// VerificationURI is shown to the user as where to approve the code.
+	// If empty, users approve with bib login approve on a signed-in device.
+	VerificationURI string `mapstructure:"verification_uri"`
 }
 
 // DatabaseConfig holds storage layer configuration
 type DatabaseConfig struct {
-	// Backend is the storage backend type: "sqlite" or "postgres"
+	// Backend is the storage backend type: "sqlite", "postgres", or
+	// "memory" (tests and ephemeral runs only)
 	Backend string `mapstructure:"backend"`
 
 	// SQLite configuration (used when Backend is "sqlite")
@@ -642,6 +1155,12 @@ type DatabaseConfig struct {
 	// Audit configuration
 	Audit AuditDatabaseConfig `mapstructure:"audit"`
 
+	// DatasetVersions configures how long old dataset versions are kept
+	DatasetVersions DatasetVersionsConfig `mapstructure:"dataset_versions"`
+
+	// EncryptionAtRest keeps the database files in an encrypted volume
+	EncryptionAtRest EncryptionAtRestConfig `mapstructure:"encryption_at_rest"`
+
 	// BreakGlass holds emergency access configuration
 	BreakGlass BreakGlassConfig `mapstructure:"break_glass"`
 }
@@ -654,6 +1173,28 @@ type SQLiteDatabaseConfig struct {
 
 	// MaxOpenConns is the maximum number of open connections
 	MaxOpenConns int `mapstructure:"max_open_conns"`
+
+	// Encryption encrypts the pages of the database with SQLCipher
+	Encryption SQLiteEncryptionConfig `mapstructure:"encryption"`
+}
+
+// SQLiteEncryptionConfig holds the SQLCipher settings of the SQLite
+// database. Encryption requires a bibd built with cgo and the sqlcipher
+// build tag.
+type SQLiteEncryptionConfig struct {
+	// Enabled encrypts the database. An unencrypted database is encrypted
+	// in place on the next start
+	Enabled bool `mapstructure:"enabled"`
+
+	// KeySource is where the key comes from: "identity" derives it from
+	// the node identity, like the encryption at rest keys; "passphrase"
+	// has SQLCipher derive it from Passphrase
+	KeySource string `mapstructure:"key_source"`
+
+	// Passphrase is the passphrase of the passphrase key source. Use a
+	// secret reference such as env://BIBD_SQLITE_PASSPHRASE or
+	// file:///run/secrets/sqlite-passphrase.
+	Passphrase string `mapstructure:"passphrase"`
 }
 
 // PostgresDatabaseConfig holds PostgreSQL-specific configuration
@@ -664,6 +1205,10 @@ type PostgresDatabaseConfig struct {
 	// ContainerRuntime is the container runtime: "docker", "podman", or "kubernetes"
 	ContainerRuntime string `mapstructure:"container_runtime"`
 
+	// RuntimeFallback is the order in which the other runtimes are tried if
+	// ContainerRuntime fails to start, or detected if it is empty
+	RuntimeFallback []string `mapstructure:"runtime_fallback"`
+
 	// SocketPath is the path to the container runtime socket (auto-detected if empty)
 	SocketPath string `mapstructure:"socket_path"`
 
@@ -694,6 +1239,11 @@ type PostgresDatabaseConfig struct {
 	// CredentialRotationInterval is how often to rotate database credentials
 	CredentialRotationInterval time.Duration `mapstructure:"credential_rotation_interval"`
 
+	// DeleteOnCleanup deletes the Kubernetes resources on cleanup (bibd
+	// shutdown and bib admin cleanup). If false, they are scaled to 0 and
+	// kept, with their data.
+	DeleteOnCleanup bool `mapstructure:"delete_on_cleanup"`
+
 	// Network configuration
 	Network PostgresNetworkConfig `mapstructure:"network"`
 
@@ -733,14 +1283,19 @@ type PostgresHealthConfig struct {
 	// StartupTimeout is how long to wait for initial startup
 	StartupTimeout time.Duration `mapstructure:"startup_timeout"`
 
-	// Action defines what happens on repeated failures: "shutdown", "retry_always", "retry_limit"
+	// Action defines what happens on repeated failures: "shutdown", "retry_always", "retry_limit", "failover"
 	Action string `mapstructure:"action"`
 
-	// MaxRetries is the maximum retries (for "retry_limit" action)
+	// MaxRetries is the maximum retries (for "retry_limit" and "failover" actions)
 	MaxRetries int `mapstructure:"max_retries"`
 
 	// RetryBackoff is the backoff duration between retries
 	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	// Standby is the host:port of the streaming replica promoted by the
+	// "failover" action on Docker/Podman; on Kubernetes a CNPG replica is
+	// promoted instead
+	Standby string `mapstructure:"standby"`
 }
 
 // PostgresTLSConfig holds TLS configuration for PostgreSQL connections
@@ -777,6 +1332,95 @@ type AuditDatabaseConfig struct {
 	HashChain bool `mapstructure:"hash_chain"`
 }
 
+// DatasetVersionsConfig holds the retention policy for dataset versions.
+// Old versions are removed whenever a dataset gets a new version; the
+// latest version is always kept.
+type DatasetVersionsConfig struct {
+	// KeepLast is how many of the newest versions of each dataset to keep.
+	// 0 means unlimited.
+	KeepLast int `mapstructure:"keep_last"`
+
+	// MaxAge is how long to keep a version after it was created.
+	// 0 means unlimited.
+	MaxAge time.Duration `mapstructure:"max_age"`
+}
+
+// EncryptionAtRestConfig holds the configuration for encrypting the
+// database files on disk.
+type EncryptionAtRestConfig struct {
+	// Enabled turns encryption at rest on.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Method is the encryption method. Only "luks" is supported: the
+	// SQLite database and the managed PostgreSQL data directory are kept
+	// in a LUKS volume unlocked with a key derived from the node identity.
+	// Requires Linux, cryptsetup and root.
+	Method string `mapstructure:"method"`
+
+	// LUKS configures the encrypted volume
+	LUKS LUKSVolumeConfig `mapstructure:"luks"`
+
+	// Recovery configures the recovery shares of the volume key
+	Recovery EncryptionRecoveryConfig `mapstructure:"recovery"`
+}
+
+// LUKSVolumeConfig holds the LUKS volume settings.
+type LUKSVolumeConfig struct {
+	// VolumeSize is the size of the volume file (e.g. "50GB"). The file is
+	// sparse, so it only takes the space the data uses.
+	VolumeSize string `mapstructure:"volume_size"`
+
+	// Cipher is the dm-crypt cipher
+	Cipher string `mapstructure:"cipher"`
+
+	// KeySize is the key size in bits
+	KeySize int `mapstructure:"key_size"`
+
+	// HashAlgorithm is the hash used for the LUKS key slots
+	HashAlgorithm string `mapstructure:"hash_algorithm"`
+}
+
+// EncryptionRecoveryConfig holds the Shamir's Secret Sharing settings used
+// to recover the volume key if the node identity is lost.
+type EncryptionRecoveryConfig struct {
+	// TotalShares is how many recovery shares are created
+	TotalShares int `mapstructure:"total_shares"`
+
+	// Threshold is how many shares are needed to unlock the volume
+	Threshold int `mapstructure:"threshold"`
+
+	// SharesDir is where the shares are written when the volume is
+	// created. bibd never reads them; recover with bib admin keys recover.
+	// Defaults to <data_dir>/secrets/recovery-shares
+	SharesDir string `mapstructure:"shares_dir"`
+
+	// EscrowRecipient is an administrator's base64 X25519 public key, from
+	// bib admin keys generate. If set, the master key is also sealed to it
+	// when the volume is created.
+	EscrowRecipient string `mapstructure:"escrow_recipient"`
+
+	// EscrowPath is where the sealed master key is written.
+	// Defaults to <data_dir>/secrets/recovery-escrow.json
+	EscrowPath string `mapstructure:"escrow_path"`
+}
+
+// RecoverySharesDir returns the directory of the encryption at rest
+// recovery shares.
+func (c *BibdConfig) RecoverySharesDir() string {
+	if dir := c.Database.EncryptionAtRest.Recovery.SharesDir; dir != "" {
+		return dir
+	}
+	return filepath.Join(c.Server.DataDir, "secrets", "recovery-shares")
+}
+
+// RecoveryEscrowPath returns the path of the encryption at rest escrow.
+func (c *BibdConfig) RecoveryEscrowPath() string {
+	if path := c.Database.EncryptionAtRest.Recovery.EscrowPath; path != "" {
+		return path
+	}
+	return filepath.Join(c.Server.DataDir, "secrets", "recovery-escrow.json")
+}
+
 // BreakGlassConfig holds emergency access configuration.
 // Break glass provides controlled emergency access to the database
 // for disaster recovery and debugging scenarios.
@@ -844,6 +1488,7 @@ type BreakGlassNotification struct {
 // DefaultBibConfig returns sensible defaults for bib CLI
 func DefaultBibConfig() *BibConfig {
 	return &BibConfig{
+		ConfigVersion: BibConfigVersion,
 		Log: LogConfig{
 			Level:           "info",
 			Format:          "text",
@@ -855,6 +1500,8 @@ func DefaultBibConfig() *BibConfig {
 			EnableCaller:    false,
 			AuditPath:       "",
 			AuditMaxAgeDays: 365,
+			AuditMaxSizeMB:  100,
+			AuditMaxBackups: 0,
 			RedactFields:    []string{"password", "token", "key", "secret", "credential", "auth"},
 		},
 		Identity: IdentityConfig{},
@@ -867,6 +1514,7 @@ func DefaultBibConfig() *BibConfig {
 			AutoDetect:    true,
 			Timeout:       "30s",
 			RetryAttempts: 3,
+			VersionPolicy: "warn",
 		},
 	}
 }
@@ -881,12 +1529,23 @@ func getDefaultDataDir() string {
 	return "~/.local/share/bibd"
 }
 
+// getDefaultUnixSocket returns the default local socket of the daemon: a
+// socket in the default data directory, or a named pipe on Windows. It must
+// match client.DefaultSocketPath, which clients probe for a local daemon.
+func getDefaultUnixSocket() string {
+	if runtime.GOOS == "windows" {
+		return `\\.\pipe\bibd-grpc`
+	}
+	return "~/.local/share/bibd/bibd.sock"
+}
+
 // DefaultBibdConfig returns the default bibd configuration.
 func DefaultBibdConfig() BibdConfig {
 	// On Windows, we can't use Unix sockets for PostgreSQL connections from host to Docker container
 	useUnixSocket := runtime.GOOS != "windows"
 
 	return BibdConfig{
+		ConfigVersion: BibdConfigVersion,
 		Log: LogConfig{
 			Level:           "info",
 			Format:          "pretty",
@@ -899,22 +1558,42 @@ func DefaultBibdConfig() BibdConfig {
 			NoColor:         false,
 			AuditPath:       "",
 			AuditMaxAgeDays: 365,
+			AuditMaxSizeMB:  100,
+			AuditMaxBackups: 0,
 			RedactFields:    []string{"password", "token", "key", "secret", "credential", "auth"},
 		},
 		Identity: IdentityConfig{},
 		Server: ServerConfig{
-			Host:    "0.0.0.0",
-			Port:    8080,
-			PIDFile: "~/bibd.pid",
-			DataDir: getDefaultDataDir(),
+			Host:            "0.0.0.0",
+			Port:            8080,
+			PIDFile:         "~/bibd.pid",
+			DataDir:         getDefaultDataDir(),
+			PermissionCheck: PermissionCheckWarn,
+			FilesystemCheck: FilesystemCheckWarn,
+			Watchdog: WatchdogConfig{
+				Enabled:       false,
+				Interval:      30 * time.Second,
+				MaxGoroutines: 10000,
+				GrowthSamples: 10,
+				StallTimeout:  2 * time.Minute,
+			},
+			ShutdownTimeout: 60 * time.Second,
+			Startup: StartupConfig{
+				Retry: StartupRetryConfig{
+					Attempts:       5,
+					InitialBackoff: time.Second,
+					MaxBackoff:     30 * time.Second,
+				},
+			},
 			TLS: TLSConfig{
-				Enabled: false,
+				Enabled:    false,
+				MinVersion: "1.3",
 			},
 			GRPC: GRPCConfig{
 				Enabled:              true,
 				Host:                 "", // Uses Server.Host if empty
 				Port:                 4000,
-				UnixSocket:           "",               // Disabled by default; set to path like /var/run/bibd/grpc.sock
+				UnixSocket:           getDefaultUnixSocket(),
 				MaxRecvMsgSize:       16 * 1024 * 1024, // 16MB
 				MaxSendMsgSize:       16 * 1024 * 1024, // 16MB
 				MaxConcurrentStreams: 100,
@@ -923,12 +1602,24 @@ func DefaultBibdConfig() BibdConfig {
 					Timeout:             20 * time.Second,
 					MinTime:             5 * time.Minute,
 					PermitWithoutStream: false,
+					MaxConnectionIdle:   30 * time.Minute,
 				},
 				Reflection: false, // Only works in dev builds anyway
 				RateLimit: GRPCRateLimitConfig{
 					Enabled:           true,
 					RequestsPerSecond: 100,
 					Burst:             200,
+					Adaptive: GRPCAdaptiveRateLimitConfig{
+						Enabled:     false,
+						CPUPercent:  80,
+						MaxInFlight: 100,
+						MinFactor:   0.1,
+						Interval:    time.Second,
+					},
+				},
+				Concurrency: GRPCConcurrencyConfig{
+					MaxInFlight:              1000,
+					MaxInFlightPerConnection: 50,
 				},
 				Metrics: GRPCMetricsConfig{
 					Enabled:                 true,
@@ -937,8 +1628,34 @@ func DefaultBibdConfig() BibdConfig {
 					Path:                    "/metrics",
 					EnableLatencyHistograms: true,
 				},
+				Compression: GRPCCompressionConfig{
+					Enabled:   false,
+					Algorithm: "zstd",
+					MinSize:   1024,
+				},
+				Profiling: GRPCProfilingConfig{
+					Enabled:     false,
+					MaxDuration: 2 * time.Minute,
+				},
+				Recovery: GRPCRecoveryConfig{
+					CrashReportDir:  "",
+					MaxCrashReports: 50,
+				},
+				Readiness: GRPCReadinessConfig{
+					Enabled: true,
+					MaxWait: 5 * time.Minute,
+				},
 				ShutdownGracePeriod: 30 * time.Second,
 			},
+			Gateway: GatewayConfig{
+				Enabled:     false,
+				Host:        "127.0.0.1",
+				Port:        8080,
+				MaxBodySize: 1 << 20, // 1MB
+				Auth: GatewayAuthConfig{
+					RequireToken: true,
+				},
+			},
 		},
 		SSH: SSHConfig{
 			Enabled:        true,
@@ -948,10 +1665,18 @@ func DefaultBibdConfig() BibdConfig {
 			IdleTimeout:    30 * time.Minute,
 			MaxConnections: 100,
 		},
+		Auth: AuthConfig{
+			DeviceAuth: DeviceAuthConfig{
+				Enabled:      true,
+				CodeTTL:      10 * time.Minute,
+				PollInterval: 5 * time.Second,
+			},
+		},
 		P2P: P2PConfig{
 			Enabled:  true,
 			Mode:     "proxy", // Default to proxy mode
 			Identity: P2PIdentityConfig{},
+			Labels:   map[string]string{},
 			ListenAddresses: []string{
 				"/ip4/0.0.0.0/tcp/4001",
 				"/ip4/0.0.0.0/udp/4001/quic-v1",
@@ -983,16 +1708,36 @@ func DefaultBibdConfig() BibdConfig {
 				Path: "", // defaults to config dir + "/peers.db"
 			},
 			FullReplica: FullReplicaConfig{
-				SyncInterval: 5 * time.Minute,
+				SyncInterval:       5 * time.Minute,
+				FullSyncInterval:   time.Hour,
+				ConflictResolution: "last-writer-wins",
+				PeerSelection: PeerSelectionConfig{
+					Policy:      "any",
+					MatchLabels: []string{"region"},
+				},
 			},
 			Selective: SelectiveConfig{
 				Subscriptions:         []string{},
 				SubscriptionStorePath: "", // defaults to config dir + "/subscriptions.json"
+				FetchCacheTTL:         10 * time.Minute,
+				FetchCacheSize:        500,
 			},
 			Proxy: ProxyConfig{
 				CacheTTL:      2 * time.Minute,
 				MaxCacheSize:  1000,
 				FavoritePeers: []string{},
+				PeerSelection: PeerSelectionConfig{
+					Policy:      "any",
+					MatchLabels: []string{"region"},
+				},
+			},
+			Messaging: MessagingConfig{
+				Enabled:        true,
+				MaxPayloadSize: 64 * 1024,
+				AllowedPeers:   []string{},
+				Timeout:        10 * time.Second,
+				Retries:        2,
+				RetryBackoff:   500 * time.Millisecond,
 			},
 		},
 		Cluster: ClusterConfig{
@@ -1007,6 +1752,7 @@ func DefaultBibdConfig() BibdConfig {
 			JoinToken:          "",
 			JoinAddrs:          []string{},
 			EnableDHTDiscovery: false,
+			MaxClockSkew:       time.Second,
 			Raft: RaftConfig{
 				HeartbeatTimeout: 1 * time.Second,
 				ElectionTimeout:  5 * time.Second,
@@ -1019,6 +1765,8 @@ func DefaultBibdConfig() BibdConfig {
 				Interval:    30 * time.Minute, // Automatic snapshots every 30 minutes
 				Threshold:   8192,             // Also snapshot after 8192 log entries
 				RetainCount: 3,
+				Compression: "zstd",
+				Encrypt:     true,
 			},
 		},
 		Database: DatabaseConfig{
@@ -1026,10 +1774,14 @@ func DefaultBibdConfig() BibdConfig {
 			SQLite: SQLiteDatabaseConfig{
 				Path:         "", // defaults to <data_dir>/cache.db
 				MaxOpenConns: 10,
+				Encryption: SQLiteEncryptionConfig{
+					KeySource: "identity",
+				},
 			},
 			Postgres: PostgresDatabaseConfig{
 				Managed:                    true,
 				ContainerRuntime:           "", // Auto-detect
+				RuntimeFallback:            []string{"docker", "podman", "kubernetes"},
 				SocketPath:                 "", // Auto-detect
 				KubeconfigPath:             "",
 				Image:                      "postgres:16-alpine",
@@ -1040,6 +1792,7 @@ func DefaultBibdConfig() BibdConfig {
 				CPUCores:                   1.0,
 				SSLMode:                    "require",
 				CredentialRotationInterval: 7 * 24 * time.Hour, // 7 days
+				DeleteOnCleanup:            true,
 				Network: PostgresNetworkConfig{
 					UseBridgeNetwork:  true,
 					BridgeNetworkName: "bibd-network",
@@ -1065,6 +1818,26 @@ func DefaultBibdConfig() BibdConfig {
 				RetentionDays: 90,
 				HashChain:     true,
 			},
+			DatasetVersions: DatasetVersionsConfig{
+				KeepLast: 0, // Keep full history
+				MaxAge:   0,
+			},
+			EncryptionAtRest: EncryptionAtRestConfig{
+				Enabled: false,
+				Method:  "luks",
+				LUKS: LUKSVolumeConfig{
+					VolumeSize:    "50GB",
+					Cipher:        "aes-xts-plain64",
+					KeySize:       512,
+					HashAlgorithm: "sha512",
+				},
+				Recovery: EncryptionRecoveryConfig{
+					TotalShares: 5,
+					Threshold:   3,
+					SharesDir:   "", // defaults to <data_dir>/secrets/recovery-shares
+					EscrowPath:  "", // defaults to <data_dir>/secrets/recovery-escrow.json
+				},
+			},
 			BreakGlass: BreakGlassConfig{
 				Enabled:               false, // Disabled by default for security
 				RequireRestart:        true,  // Must restart bibd to enable
@@ -0,0 +1,199 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CleanupScope selects what a cleanup removes from a deployment.
+type CleanupScope struct {
+	// Workloads removes containers and pods (Kubernetes: Deployments and
+	// StatefulSets)
+	Workloads bool
+
+	// Volumes removes volumes, except the ones holding the database
+	Volumes bool
+
+	// Networks removes networks (Kubernetes: Services, Ingresses and
+	// NetworkPolicies)
+	Networks bool
+
+	// Data removes the volumes holding the database
+	Data bool
+
+	// ScaleDown keeps Kubernetes resources, scaling workloads to 0 instead
+	// of deleting anything (delete_on_cleanup: false)
+	ScaleDown bool
+}
+
+// CleanupStep is one action of a cleanup plan.
+type CleanupStep struct {
+	// Kind is the resource kind, e.g. "container", "volume", "pvc"
+	Kind string `json:"kind" yaml:"kind"`
+
+	Name string `json:"name" yaml:"name"`
+
+	// Action is "remove" or "scale to 0"
+	Action string `json:"action" yaml:"action"`
+
+	// Command runs the step
+	Command []string `json:"command" yaml:"command"`
+}
+
+// Run runs the step's command.
+func (s CleanupStep) Run(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, s.Command[0], s.Command[1:]...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s: %v: %s", s.Action, s.Name, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// Cleaner plans the teardown of a deployment created by bib setup. Plans
+// list the resources that exist, so they double as a preview.
+type Cleaner interface {
+	PlanCleanup(ctx context.Context, scope CleanupScope) ([]CleanupStep, error)
+}
+
+// databaseVolumes are the volumes that hold the database in the
+// deployments bib setup generates.
+var databaseVolumes = []string{"postgres-data", "bibd-sqlite"}
+
+// IsDatabaseVolume reports whether a volume holds the database. name is a
+// volume name as generated (e.g. "postgres-data"), as prefixed by compose
+// (e.g. "bibd-docker_postgres-data") or as a StatefulSet PVC (e.g.
+// "postgres-data-postgres-0").
+func IsDatabaseVolume(name string) bool {
+	for _, v := range databaseVolumes {
+		if name == v || strings.HasSuffix(name, "_"+v) || strings.HasPrefix(name, v+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// ComposeProject is the teardown-relevant part of a compose file.
+type ComposeProject struct {
+	// Name is the compose project name
+	Name string
+
+	// Containers are the container names of the services
+	Containers []string
+
+	// Volumes are the names of the named volumes
+	Volumes []string
+
+	// Networks are the names of the networks, including the default
+	// network compose creates for services that name none
+	Networks []string
+}
+
+// ParseComposeProject reads the compose file in dir. The project is named
+// after dir, as compose does when the file sets no name, and volumes and
+// networks are prefixed with it.
+func ParseComposeProject(dir, file string) (*ComposeProject, error) {
+	content, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return nil, err
+	}
+
+	var compose struct {
+		Name     string `yaml:"name"`
+		Services map[string]struct {
+			ContainerName string `yaml:"container_name"`
+			NetworkMode   string `yaml:"network_mode"`
+			Networks      any    `yaml:"networks"`
+		} `yaml:"services"`
+		Volumes  map[string]any `yaml:"volumes"`
+		Networks map[string]any `yaml:"networks"`
+	}
+	if err := yaml.Unmarshal(content, &compose); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	project := &ComposeProject{Name: compose.Name}
+	if project.Name == "" {
+		project.Name = composeProjectName(filepath.Base(absDir))
+	}
+
+	defaultNetwork := false
+	for name, svc := range compose.Services {
+		container := svc.ContainerName
+		if container == "" {
+			container = project.Name + "-" + name + "-1"
+		}
+		project.Containers = append(project.Containers, container)
+		if svc.NetworkMode == "" && svc.Networks == nil {
+			defaultNetwork = true
+		}
+	}
+	for name := range compose.Volumes {
+		project.Volumes = append(project.Volumes, project.Name+"_"+name)
+	}
+	for name := range compose.Networks {
+		project.Networks = append(project.Networks, project.Name+"_"+name)
+	}
+	if defaultNetwork {
+		project.Networks = append(project.Networks, project.Name+"_default")
+	}
+
+	sort.Strings(project.Containers)
+	sort.Strings(project.Volumes)
+	sort.Strings(project.Networks)
+	return project, nil
+}
+
+// CleanupSteps returns the steps removing the project's resources in
+// scope with runtime ("docker" or "podman"). Containers go first, so the
+// networks and volumes are no longer in use when they are removed.
+func (p *ComposeProject) CleanupSteps(runtime string, scope CleanupScope) []CleanupStep {
+	var steps []CleanupStep
+	if scope.Workloads {
+		for _, c := range p.Containers {
+			steps = append(steps, CleanupStep{Kind: "container", Name: c, Action: "remove",
+				Command: []string{runtime, "rm", "-f", c}})
+		}
+	}
+	if scope.Networks {
+		for _, n := range p.Networks {
+			steps = append(steps, CleanupStep{Kind: "network", Name: n, Action: "remove",
+				Command: []string{runtime, "network", "rm", n}})
+		}
+	}
+	for _, v := range p.Volumes {
+		if IsDatabaseVolume(v) && !scope.Data || !IsDatabaseVolume(v) && !scope.Volumes {
+			continue
+		}
+		steps = append(steps, CleanupStep{Kind: "volume", Name: v, Action: "remove",
+			Command: []string{runtime, "volume", "rm", v}})
+	}
+	return steps
+}
+
+// composeProjectName normalizes a directory name into a compose project
+// name: lowercase letters, digits, dashes and underscores.
+func composeProjectName(dir string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(dir) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
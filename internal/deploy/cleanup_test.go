@@ -0,0 +1,109 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIsDatabaseVolume(t *testing.T) {
+	tests := map[string]bool{
+		"postgres-data":             true,
+		"bibd-docker_postgres-data": true,
+		"postgres-data-postgres-0":  true,
+		"bibd-sqlite":               true,
+		"bibd-podman_bibd-sqlite":   true,
+		"bibd-data":                 false,
+		"bibd-docker_bibd-data":     false,
+		"postgres":                  false,
+	}
+	for name, want := range tests {
+		if got := IsDatabaseVolume(name); got != want {
+			t.Errorf("IsDatabaseVolume(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestParseComposeProject(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Bibd-Docker")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	compose := `services:
+  bibd:
+    container_name: bibd-bibd
+    networks: [bibd-network]
+  postgres:
+    container_name: bibd-postgres
+  helper:
+    network_mode: host
+volumes:
+  bibd-data:
+  postgres-data:
+networks:
+  bibd-network:
+`
+	if err := os.WriteFile(filepath.Join(dir, "docker-compose.yaml"), []byte(compose), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := ParseComposeProject(dir, "docker-compose.yaml")
+	if err != nil {
+		t.Fatalf("ParseComposeProject failed: %v", err)
+	}
+	if project.Name != "bibd-docker" {
+		t.Errorf("expected project bibd-docker, got %s", project.Name)
+	}
+	if want := []string{"bibd-bibd", "bibd-docker-helper-1", "bibd-postgres"}; !reflect.DeepEqual(project.Containers, want) {
+		t.Errorf("containers = %v, want %v", project.Containers, want)
+	}
+	if want := []string{"bibd-docker_bibd-data", "bibd-docker_postgres-data"}; !reflect.DeepEqual(project.Volumes, want) {
+		t.Errorf("volumes = %v, want %v", project.Volumes, want)
+	}
+	if want := []string{"bibd-docker_bibd-network", "bibd-docker_default"}; !reflect.DeepEqual(project.Networks, want) {
+		t.Errorf("networks = %v, want %v", project.Networks, want)
+	}
+
+	if _, err := ParseComposeProject(t.TempDir(), "docker-compose.yaml"); err == nil {
+		t.Error("expected error without compose file")
+	}
+}
+
+func TestComposeProject_CleanupSteps(t *testing.T) {
+	project := &ComposeProject{
+		Name:       "bibd",
+		Containers: []string{"bibd-bibd"},
+		Volumes:    []string{"bibd_bibd-data", "bibd_postgres-data"},
+		Networks:   []string{"bibd_default"},
+	}
+
+	names := func(steps []CleanupStep) []string {
+		var out []string
+		for _, s := range steps {
+			out = append(out, s.Kind+":"+s.Name)
+		}
+		return out
+	}
+
+	steps := project.CleanupSteps("docker", CleanupScope{Workloads: true, Volumes: true, Networks: true})
+	want := []string{"container:bibd-bibd", "network:bibd_default", "volume:bibd_bibd-data"}
+	if !reflect.DeepEqual(names(steps), want) {
+		t.Errorf("steps = %v, want %v", names(steps), want)
+	}
+	if got := steps[0].Command; !reflect.DeepEqual(got, []string{"docker", "rm", "-f", "bibd-bibd"}) {
+		t.Errorf("unexpected command %v", got)
+	}
+
+	steps = project.CleanupSteps("podman", CleanupScope{Data: true})
+	if want := []string{"volume:bibd_postgres-data"}; !reflect.DeepEqual(names(steps), want) {
+		t.Errorf("steps = %v, want %v", names(steps), want)
+	}
+	if steps[0].Command[0] != "podman" {
+		t.Errorf("expected podman command, got %v", steps[0].Command)
+	}
+
+	if steps := project.CleanupSteps("docker", CleanupScope{}); len(steps) != 0 {
+		t.Errorf("expected no steps for an empty scope, got %v", names(steps))
+	}
+}
@@ -40,18 +40,25 @@ type TargetInfo struct {
 
 	// Details contains additional target-specific information
 	Details map[string]string
+
+	// Health contains runtime health checks (Docker and Podman only)
+	Health *RuntimeHealth
 }
 
 // TargetDetector detects available deployment targets
 type TargetDetector struct {
 	// Timeout for detection commands
 	Timeout time.Duration
+
+	// PostgresImage is the image container targets are checked for
+	PostgresImage string
 }
 
 // NewTargetDetector creates a new target detector
 func NewTargetDetector() *TargetDetector {
 	return &TargetDetector{
-		Timeout: 5 * time.Second,
+		Timeout:       5 * time.Second,
+		PostgresImage: DefaultPostgresImage,
 	}
 }
 
@@ -61,6 +68,12 @@ func (d *TargetDetector) WithTimeout(timeout time.Duration) *TargetDetector {
 	return d
 }
 
+// WithPostgresImage sets the PostgreSQL image to check for
+func (d *TargetDetector) WithPostgresImage(image string) *TargetDetector {
+	d.PostgresImage = image
+	return d
+}
+
 // DetectAll detects all available deployment targets
 func (d *TargetDetector) DetectAll(ctx context.Context) []*TargetInfo {
 	results := make([]*TargetInfo, 4)
@@ -141,16 +154,24 @@ func (d *TargetDetector) DetectDocker(ctx context.Context) *TargetInfo {
 	// Get docker version
 	version, err := d.runCommand(ctx, "docker", "version", "--format", "{{.Server.Version}}")
 	if err != nil {
-		// Docker command exists but daemon might not be running
+		// Docker command exists but the daemon is unreachable; the socket
+		// check tells a stopped daemon apart from a permission problem
 		info.Available = false
-		info.Status = "Daemon not running"
 		info.Error = err.Error()
+		d.checkDockerHealth(ctx, info)
+		if info.Health.SocketError != "" {
+			info.Status = "Installed but " + info.Health.SocketError
+		} else {
+			info.Status = "Installed but daemon not running"
+		}
 		return info
 	}
 
 	info.Available = true
 	info.Version = strings.TrimSpace(version)
 	info.Status = fmt.Sprintf("Available (v%s)", info.Version)
+	d.checkDockerHealth(ctx, info)
+	info.Details["mode"] = info.Health.Mode()
 
 	// Get additional info
 	if apiVersion, err := d.runCommand(ctx, "docker", "version", "--format", "{{.Server.APIVersion}}"); err == nil {
@@ -207,15 +228,12 @@ func (d *TargetDetector) DetectPodman(ctx context.Context) *TargetInfo {
 	info.Version = strings.TrimSpace(version)
 	info.Status = fmt.Sprintf("Available (v%s)", info.Version)
 
-	// Detect rootful vs rootless
-	if uid, err := d.runCommand(ctx, "podman", "info", "--format", "{{.Host.Security.Rootless}}"); err == nil {
-		rootless := strings.TrimSpace(uid)
-		if rootless == "true" {
-			info.Details["mode"] = "rootless"
-		} else {
-			info.Details["mode"] = "rootful"
-		}
+	// Detect rootful vs rootless, storage and image state
+	d.checkPodmanHealth(ctx, info)
+	if !info.Available {
+		return info
 	}
+	info.Details["mode"] = info.Health.Mode()
 
 	// Check for podman-compose
 	if d.commandExists(ctx, "podman-compose") {
@@ -366,6 +384,53 @@ func FormatTargetInfo(info *TargetInfo) string {
 	return fmt.Sprintf("%s %s %s - %s", icon, statusIcon, name, info.Status)
 }
 
+// FormatHealth formats the runtime health of a target as indented lines,
+// or returns "" if the target has no health information.
+func FormatHealth(info *TargetInfo) string {
+	h := info.Health
+	if h == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	line := func(format string, args ...interface{}) {
+		sb.WriteString("    • ")
+		sb.WriteString(fmt.Sprintf(format, args...))
+		sb.WriteString("\n")
+	}
+
+	switch {
+	case h.DaemonVersion != "":
+		line("Daemon: v%s (%s)", h.DaemonVersion, h.ModeDescription())
+	case h.ClientVersion != "" && info.Available:
+		line("Version: v%s (%s)", h.ClientVersion, h.ModeDescription())
+	case h.ClientVersion != "":
+		line("Client: v%s", h.ClientVersion)
+	}
+	if h.Socket != "" {
+		if h.SocketAccessible {
+			line("Socket: %s (writable)", h.Socket)
+		} else {
+			line("Socket: %s", h.SocketError)
+		}
+	}
+	if h.DataRoot != "" {
+		if h.DiskFree > 0 {
+			line("Disk: %s free in %s", formatBytes(h.DiskFree), h.DataRoot)
+		} else {
+			line("Disk: %s", h.DataRoot)
+		}
+	}
+	if info.Available && h.PostgresImagePulled {
+		line("Image: %s present", h.PostgresImage)
+	}
+	for _, w := range h.Warnings {
+		line("⚠ %s", w)
+	}
+
+	return sb.String()
+}
+
 // TargetDisplayName returns a human-readable name for a target
 func TargetDisplayName(t TargetType) string {
 	switch t {
@@ -0,0 +1,17 @@
+package docker
+
+import (
+	"context"
+
+	"bib/internal/deploy"
+)
+
+// PlanCleanup plans the removal of the compose project's containers,
+// networks and volumes in scope.
+func (d *Deployer) PlanCleanup(_ context.Context, scope deploy.CleanupScope) ([]deploy.CleanupStep, error) {
+	project, err := deploy.ParseComposeProject(d.Config.OutputDir, composeFile)
+	if err != nil {
+		return nil, err
+	}
+	return project.CleanupSteps("docker", scope), nil
+}
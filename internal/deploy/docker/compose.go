@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+
+	"bib/internal/deploy"
 )
 
 // ComposeConfig contains configuration for Docker Compose generation
@@ -19,7 +21,8 @@ type ComposeConfig struct {
 	// BibdImage is the bibd Docker image
 	BibdImage string
 
-	// BibdTag is the bibd Docker image tag
+	// BibdTag is the bibd Docker image tag, or a digest
+	// (sha256:...) to pin the image
 	BibdTag string
 
 	// P2P configuration
@@ -101,6 +104,16 @@ func DefaultComposeConfig() *ComposeConfig {
 	}
 }
 
+// BibdImageRef returns the bibd image reference
+func (c *ComposeConfig) BibdImageRef() string {
+	return deploy.ImageRef(c.BibdImage, c.BibdTag)
+}
+
+// PostgresImageRef returns the PostgreSQL image reference
+func (c *ComposeConfig) PostgresImageRef() string {
+	return deploy.ImageRef(c.PostgresImage, c.PostgresTag)
+}
+
 // ComposeGenerator generates Docker Compose files
 type ComposeGenerator struct {
 	Config *ComposeConfig
@@ -197,7 +210,7 @@ func (g *ComposeGenerator) generateCompose() (string, error) {
 
 services:
   bibd:
-    image: {{ .BibdImage }}:{{ .BibdTag }}
+    image: {{ .BibdImageRef }}
     container_name: {{ .ProjectName }}-bibd
     restart: unless-stopped
     ports:
@@ -240,7 +253,7 @@ services:
 {{- if eq .StorageBackend "postgres" }}
 
   postgres:
-    image: {{ .PostgresImage }}:{{ .PostgresTag }}
+    image: {{ .PostgresImageRef }}
     container_name: {{ .ProjectName }}-postgres
     restart: unless-stopped
 {{- if .PostgresExposePort }}
@@ -147,6 +147,29 @@ func TestComposeGenerator_generateCompose_Postgres(t *testing.T) {
 	}
 }
 
+func TestComposeGenerator_generateCompose_PinnedDigest(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	config := DefaultComposeConfig()
+	config.StorageBackend = "postgres"
+	config.PostgresPassword = "testpassword"
+	config.PostgresTag = "16-alpine@" + digest
+	config.BibdTag = digest
+
+	compose, err := NewComposeGenerator(config).generateCompose()
+	if err != nil {
+		t.Fatalf("generateCompose failed: %v", err)
+	}
+
+	for _, check := range []string{
+		"image: postgres:16-alpine@" + digest,
+		"image: ghcr.io/bib-project/bibd@" + digest,
+	} {
+		if !strings.Contains(compose, check) {
+			t.Errorf("compose missing %q", check)
+		}
+	}
+}
+
 func TestComposeGenerator_generateEnvFile(t *testing.T) {
 	config := DefaultComposeConfig()
 	config.StorageBackend = "postgres"
@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"bib/internal/deploy"
 )
 
 // DeployConfig contains configuration for Docker deployment
@@ -163,10 +165,9 @@ func (d *Deployer) Deploy(ctx context.Context) (*DeployResult, error) {
 	// Step 6: Pull images (if enabled)
 	if d.Config.PullImages {
 		d.log(result, "📥 Pulling images...")
-		if err := d.pullImages(ctx); err != nil {
-			d.log(result, fmt.Sprintf("   ⚠️ Failed to pull images: %v (continuing anyway)", err))
-		} else {
-			d.log(result, "   ✓ Images pulled")
+		if err := d.pullImages(ctx, result); err != nil {
+			result.Error = fmt.Sprintf("Failed to pull images: %v", err)
+			return result, err
 		}
 	}
 
@@ -229,19 +230,27 @@ func (d *Deployer) generateIdentityKey(path string) error {
 	return os.WriteFile(path, []byte(placeholder), 0600)
 }
 
-// pullImages pulls the required Docker images
-func (d *Deployer) pullImages(ctx context.Context) error {
-	composeCmd := d.DockerInfo.GetComposeCommand()
-	args := append(composeCmd[1:], "pull")
-
-	cmd := exec.CommandContext(ctx, composeCmd[0], args...)
-	cmd.Dir = d.Config.OutputDir
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+// pullImages pulls the required images that are not present locally,
+// showing the pull output. It fails before anything is started if the
+// registry is unreachable.
+func (d *Deployer) pullImages(ctx context.Context, result *DeployResult) error {
+	images := []string{d.Config.ComposeConfig.BibdImageRef()}
+	if d.Config.ComposeConfig.StorageBackend == "postgres" {
+		images = append(images, d.Config.ComposeConfig.PostgresImageRef())
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%v: %s", err, stderr.String())
+	for _, image := range images {
+		pulled, err := deploy.PullImage(ctx, "docker", image, func(line string) {
+			d.log(result, "      "+line)
+		})
+		if err != nil {
+			return err
+		}
+		if pulled {
+			d.log(result, fmt.Sprintf("   ✓ Pulled %s", image))
+		} else {
+			d.log(result, fmt.Sprintf("   ✓ %s already present", image))
+		}
 	}
 	return nil
 }
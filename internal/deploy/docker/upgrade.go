@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeFile is the compose file written by Deploy
+const composeFile = "docker-compose.yaml"
+
+// CurrentImage returns the bibd image in the deployed compose file
+func (d *Deployer) CurrentImage(_ context.Context) (string, error) {
+	content, err := os.ReadFile(filepath.Join(d.Config.OutputDir, composeFile))
+	if err != nil {
+		return "", err
+	}
+	return composeServiceImage(content, "bibd")
+}
+
+// PullImage pulls image
+func (d *Deployer) PullImage(ctx context.Context, image string) error {
+	cmd := exec.CommandContext(ctx, "docker", "pull", image)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// SetImage rewrites the bibd image in the compose file and recreates the
+// bibd container. PostgreSQL is left running.
+func (d *Deployer) SetImage(ctx context.Context, image string) error {
+	path := filepath.Join(d.Config.OutputDir, composeFile)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	current, err := composeServiceImage(content, "bibd")
+	if err != nil {
+		return err
+	}
+	updated := bytes.ReplaceAll(content, []byte("image: "+current), []byte("image: "+image))
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return err
+	}
+
+	if d.DockerInfo == nil {
+		d.DockerInfo = NewDetector().Detect(ctx)
+	}
+	composeCmd := d.DockerInfo.GetComposeCommand()
+	args := append(composeCmd[1:], "up", "-d", "--no-deps", "--force-recreate", "bibd")
+
+	cmd := exec.CommandContext(ctx, composeCmd[0], args...)
+	cmd.Dir = d.Config.OutputDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// WaitReady waits for the containers to report healthy
+func (d *Deployer) WaitReady(ctx context.Context, timeout time.Duration) error {
+	if d.DockerInfo == nil {
+		d.DockerInfo = NewDetector().Detect(ctx)
+	}
+	saved := d.Config.HealthTimeout
+	d.Config.HealthTimeout = timeout
+	defer func() { d.Config.HealthTimeout = saved }()
+
+	return d.waitForHealthy(ctx)
+}
+
+// composeServiceImage returns the image of a service in a compose file
+func composeServiceImage(content []byte, service string) (string, error) {
+	var compose struct {
+		Services map[string]struct {
+			Image string `yaml:"image"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(content, &compose); err != nil {
+		return "", fmt.Errorf("failed to parse compose file: %w", err)
+	}
+
+	svc, ok := compose.Services[service]
+	if !ok || strings.TrimSpace(svc.Image) == "" {
+		return "", fmt.Errorf("no image for service %q in compose file", service)
+	}
+	return svc.Image, nil
+}
@@ -0,0 +1,101 @@
+package docker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bib/internal/deploy"
+)
+
+func TestDeployer_CurrentImage(t *testing.T) {
+	config := DefaultComposeConfig()
+	config.StorageBackend = "postgres"
+	config.PostgresPassword = "testpassword"
+	config.BibdTag = "0.1.0"
+
+	compose, err := NewComposeGenerator(config).generateCompose()
+	if err != nil {
+		t.Fatalf("generateCompose failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, composeFile), []byte(compose), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	deployCfg := DefaultDeployConfig()
+	deployCfg.OutputDir = dir
+	image, err := NewDeployer(deployCfg).CurrentImage(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentImage failed: %v", err)
+	}
+	if want := config.BibdImage + ":0.1.0"; image != want {
+		t.Errorf("expected %s, got %s", want, image)
+	}
+}
+
+func TestComposeServiceImage(t *testing.T) {
+	content := []byte(`services:
+  bibd:
+    image: ghcr.io/bib-project/bibd:0.1.0
+  postgres:
+    image: postgres:16-alpine
+`)
+
+	image, err := composeServiceImage(content, "postgres")
+	if err != nil || image != "postgres:16-alpine" {
+		t.Errorf("unexpected postgres image %q (%v)", image, err)
+	}
+	if _, err := composeServiceImage(content, "missing"); err == nil {
+		t.Error("expected error for missing service")
+	}
+	if _, err := composeServiceImage([]byte("services: ["), "bibd"); err == nil {
+		t.Error("expected error for invalid yaml")
+	}
+}
+
+func TestDeployer_PlanCleanup(t *testing.T) {
+	config := DefaultComposeConfig()
+	config.StorageBackend = "postgres"
+	config.PostgresPassword = "testpassword"
+
+	compose, err := NewComposeGenerator(config).generateCompose()
+	if err != nil {
+		t.Fatalf("generateCompose failed: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, composeFile), []byte(compose), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	deployCfg := DefaultDeployConfig()
+	deployCfg.OutputDir = dir
+	deployer := NewDeployer(deployCfg)
+
+	steps, err := deployer.PlanCleanup(context.Background(), deploy.CleanupScope{Workloads: true, Volumes: true, Networks: true})
+	if err != nil {
+		t.Fatalf("PlanCleanup failed: %v", err)
+	}
+	var containers int
+	for _, s := range steps {
+		if s.Kind == "container" {
+			containers++
+		}
+		if s.Kind == "volume" && deploy.IsDatabaseVolume(s.Name) {
+			t.Errorf("database volume %s planned without Data", s.Name)
+		}
+	}
+	if containers != 2 {
+		t.Errorf("expected bibd and postgres containers, got %+v", steps)
+	}
+
+	steps, err = deployer.PlanCleanup(context.Background(), deploy.CleanupScope{Data: true})
+	if err != nil {
+		t.Fatalf("PlanCleanup failed: %v", err)
+	}
+	if len(steps) != 1 || !deploy.IsDatabaseVolume(steps[0].Name) {
+		t.Errorf("expected only the postgres volume, got %+v", steps)
+	}
+}
@@ -0,0 +1,252 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultPostgresImage is the PostgreSQL image checked for by the detector
+const DefaultPostgresImage = "postgres:16-alpine"
+
+// minVolumeSpace is the free space below which a runtime's data root is
+// reported as low
+const minVolumeSpace = 2 << 30
+
+// RuntimeHealth contains health information for a container runtime
+type RuntimeHealth struct {
+	// ClientVersion is the version of the CLI
+	ClientVersion string
+
+	// DaemonVersion is the version of the daemon (empty if unreachable)
+	DaemonVersion string
+
+	// Socket is the path of the runtime's API socket
+	Socket string
+
+	// SocketAccessible indicates the current user can connect to Socket
+	SocketAccessible bool
+
+	// SocketError explains why Socket is not accessible
+	SocketError string
+
+	// Rootless indicates the runtime runs without root privileges
+	Rootless bool
+
+	// RootlessNetwork is the user-mode network stack of a rootless runtime
+	// ("slirp4netns" or "pasta")
+	RootlessNetwork string
+
+	// DataRoot is the directory holding images and volumes
+	DataRoot string
+
+	// DiskFree is the free space in bytes under DataRoot (0 if unknown)
+	DiskFree int64
+
+	// PostgresImage is the PostgreSQL image that was checked
+	PostgresImage string
+
+	// PostgresImagePulled indicates PostgresImage is present locally
+	PostgresImagePulled bool
+
+	// Warnings are non-fatal problems that may affect deployment
+	Warnings []string
+}
+
+// checkDockerHealth fills in Docker health for info. It is called for
+// every installed docker CLI, whether or not the daemon answered.
+func (d *TargetDetector) checkDockerHealth(ctx context.Context, info *TargetInfo) {
+	health := &RuntimeHealth{
+		DaemonVersion: info.Version,
+		PostgresImage: d.postgresImage(),
+	}
+	info.Health = health
+
+	if version, err := d.runCommand(ctx, "docker", "version", "--format", "{{.Client.Version}}"); err == nil {
+		health.ClientVersion = strings.TrimSpace(version)
+	}
+
+	health.Socket = d.dockerSocket(ctx)
+	if health.Socket != "" {
+		health.SocketAccessible, health.SocketError = probeSocket(health.Socket)
+	}
+
+	if !info.Available {
+		return
+	}
+
+	if secOpts, err := d.runCommand(ctx, "docker", "info", "--format", "{{json .SecurityOptions}}"); err == nil {
+		health.Rootless = strings.Contains(secOpts, "rootless")
+	}
+	if root, err := d.runCommand(ctx, "docker", "info", "--format", "{{.DockerRootDir}}"); err == nil {
+		health.DataRoot = strings.TrimSpace(root)
+	}
+	_, err := d.runCommand(ctx, "docker", "image", "inspect", "--format", "{{.Id}}", health.PostgresImage)
+	health.PostgresImagePulled = err == nil
+
+	health.finish()
+}
+
+// checkPodmanHealth fills in Podman health for info
+func (d *TargetDetector) checkPodmanHealth(ctx context.Context, info *TargetInfo) {
+	health := &RuntimeHealth{
+		ClientVersion: info.Version,
+		PostgresImage: d.postgresImage(),
+	}
+	info.Health = health
+
+	// Podman is daemonless; a failing "podman info" means it cannot run
+	// containers for this user (broken storage, missing subuids, ...)
+	out, err := d.runCommand(ctx, "podman", "info", "--format",
+		"{{.Host.Security.Rootless}}\t{{.Store.GraphRoot}}\t{{.Host.RemoteSocket.Path}}")
+	if err != nil {
+		info.Available = false
+		info.Status = "Installed but not usable (podman info failed)"
+		info.Error = err.Error()
+		return
+	}
+
+	fields := strings.Split(strings.TrimSpace(out), "\t")
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+	health.Rootless = fields[0] == "true"
+	if health.Rootless {
+		// Not in the combined query: older podman rejects the field
+		health.RootlessNetwork = "slirp4netns"
+		if out, err := d.runCommand(ctx, "podman", "info", "--format", "{{.Host.RootlessNetworkCmd}}"); err == nil {
+			if cmd := strings.TrimSpace(out); cmd != "" && !strings.Contains(cmd, "no value") {
+				health.RootlessNetwork = cmd
+			}
+		}
+	}
+	health.DataRoot = fields[1]
+	health.Socket = strings.TrimPrefix(fields[2], "unix://")
+	if health.Socket != "" {
+		health.SocketAccessible, health.SocketError = probeSocket(health.Socket)
+	}
+
+	_, err = d.runCommand(ctx, "podman", "image", "exists", health.PostgresImage)
+	health.PostgresImagePulled = err == nil
+
+	health.finish()
+}
+
+// dockerSocket returns the socket path of the current docker context.
+// Non-unix endpoints (tcp://, npipe://) return "".
+func (d *TargetDetector) dockerSocket(ctx context.Context) string {
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		if out, err := d.runCommand(ctx, "docker", "context", "inspect", "--format", "{{.Endpoints.docker.Host}}"); err == nil {
+			host = strings.TrimSpace(out)
+		}
+	}
+	if host == "" {
+		host = "unix:///var/run/docker.sock"
+	}
+	if !strings.HasPrefix(host, "unix://") {
+		return ""
+	}
+	return strings.TrimPrefix(host, "unix://")
+}
+
+// postgresImage returns the image to check for
+func (d *TargetDetector) postgresImage() string {
+	if d.PostgresImage != "" {
+		return d.PostgresImage
+	}
+	return DefaultPostgresImage
+}
+
+// finish computes disk space and warnings once the checks have run
+func (h *RuntimeHealth) finish() {
+	if h.DataRoot != "" {
+		h.DiskFree = availableSpace(h.DataRoot)
+	}
+	if h.DiskFree > 0 && h.DiskFree < minVolumeSpace {
+		h.Warnings = append(h.Warnings, fmt.Sprintf("only %s free in %s", formatBytes(h.DiskFree), h.DataRoot))
+	}
+	if !h.PostgresImagePulled {
+		h.Warnings = append(h.Warnings, fmt.Sprintf("%s not pulled; it will be downloaded on first start", h.PostgresImage))
+	}
+}
+
+// Mode returns "rootless" or "rootful"
+func (h *RuntimeHealth) Mode() string {
+	if h.Rootless {
+		return "rootless"
+	}
+	return "rootful"
+}
+
+// ModeDescription returns the mode with the rootless network stack, if known
+func (h *RuntimeHealth) ModeDescription() string {
+	if h.Rootless && h.RootlessNetwork != "" {
+		return fmt.Sprintf("rootless, %s networking", h.RootlessNetwork)
+	}
+	return h.Mode()
+}
+
+// probeSocket reports whether the current user can connect to a unix
+// socket, and if not, why.
+func probeSocket(path string) (bool, string) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, fmt.Sprintf("socket %s not found", path)
+		}
+		if os.IsPermission(err) {
+			return false, fmt.Sprintf("socket %s permission denied", path)
+		}
+		return false, err.Error()
+	}
+
+	conn, err := net.DialTimeout("unix", path, 2*time.Second)
+	if err != nil {
+		switch {
+		case errors.Is(err, syscall.EACCES), errors.Is(err, syscall.EPERM):
+			return false, fmt.Sprintf("socket %s permission denied", path)
+		case errors.Is(err, syscall.ECONNREFUSED):
+			return false, fmt.Sprintf("nothing listening on socket %s", path)
+		default:
+			return false, err.Error()
+		}
+	}
+	conn.Close()
+	return true, ""
+}
+
+// availableSpace returns the free space for path, walking up to the
+// nearest directory that can be queried (data roots are often not
+// readable by unprivileged users).
+func availableSpace(path string) int64 {
+	for {
+		if space := getAvailableSpace(path); space > 0 {
+			return space
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return 0
+		}
+		path = parent
+	}
+}
+
+// formatBytes formats bytes into human-readable format
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,105 @@
+package deploy
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProbeSocket(t *testing.T) {
+	dir := t.TempDir()
+
+	ok, reason := probeSocket(filepath.Join(dir, "missing.sock"))
+	if ok || !strings.Contains(reason, "not found") {
+		t.Errorf("expected missing socket to be reported, got %v %q", ok, reason)
+	}
+
+	path := filepath.Join(dir, "live.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("unix sockets not supported: %v", err)
+	}
+
+	ok, reason = probeSocket(path)
+	if !ok {
+		t.Errorf("expected listening socket to be accessible, got %q", reason)
+	}
+
+	listener.Close()
+	if ok, _ = probeSocket(path); ok {
+		t.Error("expected closed socket to be inaccessible")
+	}
+}
+
+func TestTargetDetector_DockerSocket(t *testing.T) {
+	detector := NewTargetDetector()
+	ctx := context.Background()
+
+	t.Setenv("DOCKER_HOST", "unix:///run/user/1000/docker.sock")
+	if got := detector.dockerSocket(ctx); got != "/run/user/1000/docker.sock" {
+		t.Errorf("expected rootless socket path, got %q", got)
+	}
+
+	t.Setenv("DOCKER_HOST", "tcp://10.0.0.1:2376")
+	if got := detector.dockerSocket(ctx); got != "" {
+		t.Errorf("expected no socket for a tcp host, got %q", got)
+	}
+}
+
+func TestRuntimeHealth_Warnings(t *testing.T) {
+	health := &RuntimeHealth{PostgresImage: DefaultPostgresImage, PostgresImagePulled: false}
+	health.finish()
+	if len(health.Warnings) != 1 || !strings.Contains(health.Warnings[0], DefaultPostgresImage) {
+		t.Errorf("expected a missing image warning, got %v", health.Warnings)
+	}
+
+	health = &RuntimeHealth{PostgresImagePulled: true, DataRoot: t.TempDir()}
+	health.finish()
+	if health.DiskFree <= 0 {
+		t.Error("expected free disk space to be measured")
+	}
+}
+
+func TestAvailableSpace_WalksUp(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "does", "not", "exist")
+	if availableSpace(missing) <= 0 {
+		t.Error("expected space of the nearest existing parent")
+	}
+}
+
+func TestFormatHealth(t *testing.T) {
+	if FormatHealth(&TargetInfo{Type: TargetLocal}) != "" {
+		t.Error("expected no health output for local target")
+	}
+
+	info := &TargetInfo{
+		Type: TargetDocker,
+		Health: &RuntimeHealth{
+			ClientVersion: "27.1.0",
+			Socket:        "/var/run/docker.sock",
+			SocketError:   "socket /var/run/docker.sock permission denied",
+		},
+	}
+	out := FormatHealth(info)
+	if !strings.Contains(out, "Client: v27.1.0") || !strings.Contains(out, "permission denied") {
+		t.Errorf("unexpected health output:\n%s", out)
+	}
+
+	info = &TargetInfo{
+		Type:      TargetPodman,
+		Available: true,
+		Health: &RuntimeHealth{
+			ClientVersion:       "5.0.0",
+			Rootless:            true,
+			RootlessNetwork:     "pasta",
+			PostgresImage:       DefaultPostgresImage,
+			PostgresImagePulled: true,
+		},
+	}
+	out = FormatHealth(info)
+	if !strings.Contains(out, "v5.0.0 (rootless, pasta networking)") || !strings.Contains(out, DefaultPostgresImage+" present") {
+		t.Errorf("unexpected health output:\n%s", out)
+	}
+}
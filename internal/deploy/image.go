@@ -0,0 +1,169 @@
+package deploy
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrRegistryUnreachable is returned by PullImage when the registry cannot
+// be contacted, as opposed to the image not existing
+var ErrRegistryUnreachable = errors.New("registry unreachable")
+
+// digestPattern matches a content digest such as sha256:<64 hex>
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// unreachablePatterns appear in pull output when the registry cannot be
+// contacted at all
+var unreachablePatterns = []string{
+	"no such host",
+	"i/o timeout",
+	"connection refused",
+	"network is unreachable",
+	"tls handshake timeout",
+	"request canceled while waiting for connection",
+	"client.timeout exceeded",
+	"temporary failure in name resolution",
+}
+
+// ImageRef joins an image repository and a tag. The tag may pin a digest,
+// either alone (sha256:...) or after a tag (16-alpine@sha256:...); a pinned
+// image is pulled by digest, so it is reproducible even if the tag moves.
+func ImageRef(image, tag string) string {
+	switch {
+	case tag == "":
+		return image
+	case strings.HasPrefix(tag, "sha256:"):
+		return image + "@" + tag
+	default:
+		return image + ":" + tag
+	}
+}
+
+// ValidateImageRef checks the digest of a pinned image reference
+func ValidateImageRef(ref string) error {
+	if ref == "" {
+		return fmt.Errorf("image is empty")
+	}
+	if i := strings.Index(ref, "@"); i >= 0 {
+		if !digestPattern.MatchString(ref[i+1:]) {
+			return fmt.Errorf("invalid digest in %q: expected sha256:<64 hex characters>", ref)
+		}
+	}
+	return nil
+}
+
+// ImageRegistry returns the registry host of an image reference. Images
+// without one (postgres:16-alpine) come from Docker Hub.
+func ImageRegistry(ref string) string {
+	first, _, found := strings.Cut(ref, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return first
+	}
+	return "docker.io"
+}
+
+// ImagePresent reports whether ref is already in the runtime's local store
+func ImagePresent(ctx context.Context, runtime, ref string) bool {
+	return exec.CommandContext(ctx, runtime, "image", "inspect", ref).Run() == nil
+}
+
+// PullImage pulls ref with a container runtime (docker or podman) unless it
+// is already present locally, and reports whether it was pulled. Images
+// tagged latest (or untagged) are always pulled, since the tag moves. Each
+// line of pull output is passed to progress, which may be nil. Cancelling
+// ctx stops the pull.
+func PullImage(ctx context.Context, runtime, ref string, progress func(line string)) (bool, error) {
+	if err := ValidateImageRef(ref); err != nil {
+		return false, err
+	}
+	if !isLatest(ref) && ImagePresent(ctx, runtime, ref) {
+		return false, nil
+	}
+	if progress == nil {
+		progress = func(string) {}
+	}
+
+	cmd := exec.CommandContext(ctx, runtime, "pull", ref)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return false, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return false, err
+	}
+	if err := cmd.Start(); err != nil {
+		return false, fmt.Errorf("failed to run %s pull: %w", runtime, err)
+	}
+
+	// docker reports progress on stdout and errors on stderr; podman
+	// writes both to stderr, so the last stderr line holds the error
+	var (
+		mu   sync.Mutex
+		last string
+		wg   sync.WaitGroup
+	)
+	stream := func(r io.Reader, isStderr bool) {
+		defer wg.Done()
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			mu.Lock()
+			if isStderr {
+				last = line
+			}
+			progress(line)
+			mu.Unlock()
+		}
+	}
+	wg.Add(2)
+	go stream(stdout, false)
+	go stream(stderr, true)
+	wg.Wait()
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if isRegistryUnreachable(last) {
+			return false, fmt.Errorf("%w: cannot reach %s to pull %s (%s); check the network and proxy settings, or load the image with '%s load'",
+				ErrRegistryUnreachable, ImageRegistry(ref), ref, last, runtime)
+		}
+		if last != "" {
+			return false, fmt.Errorf("failed to pull %s: %s", ref, last)
+		}
+		return false, fmt.Errorf("failed to pull %s: %w", ref, err)
+	}
+	return true, nil
+}
+
+// isLatest reports whether ref refers to the latest tag, explicitly or by
+// omitting the tag
+func isLatest(ref string) bool {
+	if strings.Contains(ref, "@") {
+		return false
+	}
+	repo := ImageRepository(ref)
+	return repo == ref || ref == repo+":latest"
+}
+
+// isRegistryUnreachable reports whether pull output shows a network failure
+func isRegistryUnreachable(output string) bool {
+	output = strings.ToLower(output)
+	for _, p := range unreachablePatterns {
+		if strings.Contains(output, p) {
+			return true
+		}
+	}
+	return false
+}
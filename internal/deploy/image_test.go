@@ -0,0 +1,149 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestImageRef(t *testing.T) {
+	tests := []struct {
+		image, tag, want string
+	}{
+		{"postgres", "16-alpine", "postgres:16-alpine"},
+		{"postgres", testDigest, "postgres@" + testDigest},
+		{"postgres", "16-alpine@" + testDigest, "postgres:16-alpine@" + testDigest},
+		{"postgres:16-alpine@" + testDigest, "", "postgres:16-alpine@" + testDigest},
+	}
+
+	for _, tt := range tests {
+		if got := ImageRef(tt.image, tt.tag); got != tt.want {
+			t.Errorf("ImageRef(%q, %q) = %q, want %q", tt.image, tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestValidateImageRef(t *testing.T) {
+	valid := []string{"postgres:16-alpine", "postgres@" + testDigest, "registry:5000/bibd:1.0@" + testDigest}
+	for _, ref := range valid {
+		if err := ValidateImageRef(ref); err != nil {
+			t.Errorf("ValidateImageRef(%q) = %v", ref, err)
+		}
+	}
+
+	invalid := []string{"", "postgres@sha256:abc", "postgres@md5:0123"}
+	for _, ref := range invalid {
+		if err := ValidateImageRef(ref); err == nil {
+			t.Errorf("ValidateImageRef(%q) should fail", ref)
+		}
+	}
+}
+
+func TestImageRegistry(t *testing.T) {
+	tests := map[string]string{
+		"postgres:16-alpine":                "docker.io",
+		"bitnami/postgresql:16":             "docker.io",
+		"ghcr.io/bib-project/bibd:latest":   "ghcr.io",
+		"registry:5000/bibd":                "registry:5000",
+		"localhost/bibd@" + testDigest:      "localhost",
+		"docker.io/library/postgres:16-alp": "docker.io",
+	}
+
+	for ref, want := range tests {
+		if got := ImageRegistry(ref); got != want {
+			t.Errorf("ImageRegistry(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+// fakeRuntime writes a shell script standing in for docker/podman. inspect
+// is the exit code of "image inspect"; pull is the script body for "pull".
+func fakeRuntime(t *testing.T, inspect int, pull string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake runtime requires a POSIX shell")
+	}
+	script := "#!/bin/sh\n" +
+		"case \"$1\" in\n" +
+		"image) exit " + strconv.Itoa(inspect) + " ;;\n" +
+		"pull) " + pull + " ;;\n" +
+		"esac\n"
+	path := filepath.Join(t.TempDir(), "runtime")
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPullImage_Present(t *testing.T) {
+	rt := fakeRuntime(t, 0, "exit 1")
+
+	pulled, err := PullImage(context.Background(), rt, "postgres:16-alpine", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pulled {
+		t.Error("expected a present image not to be pulled")
+	}
+}
+
+func TestPullImage_Progress(t *testing.T) {
+	rt := fakeRuntime(t, 1, `echo "16-alpine: Pulling from library/postgres"; echo "Status: Downloaded newer image"`)
+
+	var lines []string
+	pulled, err := PullImage(context.Background(), rt, "postgres:16-alpine", func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !pulled {
+		t.Error("expected the image to be pulled")
+	}
+	if len(lines) != 2 || !strings.Contains(lines[1], "Downloaded") {
+		t.Errorf("unexpected progress: %v", lines)
+	}
+}
+
+func TestPullImage_LatestAlwaysPulled(t *testing.T) {
+	rt := fakeRuntime(t, 0, "echo pulled")
+
+	pulled, err := PullImage(context.Background(), rt, "ghcr.io/bib-project/bibd:latest", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !pulled {
+		t.Error("expected latest to be pulled even when present")
+	}
+}
+
+func TestPullImage_RegistryUnreachable(t *testing.T) {
+	rt := fakeRuntime(t, 1, `echo "Error response from daemon: Get \"https://registry-1.docker.io/v2/\": dial tcp: lookup registry-1.docker.io: no such host" >&2; exit 1`)
+
+	_, err := PullImage(context.Background(), rt, "postgres:16-alpine", nil)
+	if !errors.Is(err, ErrRegistryUnreachable) {
+		t.Fatalf("expected ErrRegistryUnreachable, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "docker.io") {
+		t.Errorf("expected the registry in the error, got %v", err)
+	}
+}
+
+func TestPullImage_NotFound(t *testing.T) {
+	rt := fakeRuntime(t, 1, `echo "Error response from daemon: manifest for postgres:99 not found: manifest unknown" >&2; exit 1`)
+
+	_, err := PullImage(context.Background(), rt, "postgres:99", nil)
+	if err == nil || errors.Is(err, ErrRegistryUnreachable) {
+		t.Fatalf("expected a plain pull error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "manifest unknown") {
+		t.Errorf("expected the runtime's message, got %v", err)
+	}
+}
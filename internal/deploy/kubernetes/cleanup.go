@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"bib/internal/deploy"
+)
+
+// appSelector selects the resources of the generated manifests
+const appSelector = "app.kubernetes.io/name in (bibd,postgres)"
+
+// PlanCleanup plans the teardown of the resources in the deployment
+// namespace. With scope.ScaleDown, the workloads are scaled to 0 and
+// nothing is deleted.
+func (d *Deployer) PlanCleanup(ctx context.Context, scope deploy.CleanupScope) ([]deploy.CleanupStep, error) {
+	var workloads, network, pvcs []string
+	var err error
+
+	if scope.Workloads {
+		if workloads, err = d.listResources(ctx, "deployments,statefulsets", appSelector); err != nil {
+			return nil, err
+		}
+	}
+	if scope.ScaleDown {
+		return cleanupSteps(d.Config.ManifestConfig.Namespace, scope, workloads, nil, nil), nil
+	}
+	if scope.Networks {
+		if network, err = d.listResources(ctx, "services,ingresses,networkpolicies", appSelector); err != nil {
+			return nil, err
+		}
+	}
+	if scope.Volumes || scope.Data {
+		// StatefulSet claims carry the labels of the claim template, which
+		// has none, so PVCs are matched by name
+		if pvcs, err = d.listResources(ctx, "persistentvolumeclaims", ""); err != nil {
+			return nil, err
+		}
+	}
+	return cleanupSteps(d.Config.ManifestConfig.Namespace, scope, workloads, network, pvcs), nil
+}
+
+// cleanupSteps returns the steps tearing down the listed resources, as
+// kind/name, in scope.
+func cleanupSteps(namespace string, scope deploy.CleanupScope, workloads, network, pvcs []string) []deploy.CleanupStep {
+	var steps []deploy.CleanupStep
+	for _, res := range workloads {
+		kind, name := splitResource(res)
+		if scope.ScaleDown {
+			steps = append(steps, deploy.CleanupStep{Kind: kind, Name: name, Action: "scale to 0",
+				Command: []string{"kubectl", "scale", res, "-n", namespace, "--replicas=0"}})
+			continue
+		}
+		steps = append(steps, deploy.CleanupStep{Kind: kind, Name: name, Action: "remove",
+			Command: []string{"kubectl", "delete", res, "-n", namespace}})
+	}
+	if scope.ScaleDown {
+		return steps
+	}
+
+	for _, res := range network {
+		kind, name := splitResource(res)
+		steps = append(steps, deploy.CleanupStep{Kind: kind, Name: name, Action: "remove",
+			Command: []string{"kubectl", "delete", res, "-n", namespace}})
+	}
+	for _, res := range pvcs {
+		_, name := splitResource(res)
+		if name != "bibd-data" && !deploy.IsDatabaseVolume(name) {
+			continue
+		}
+		if deploy.IsDatabaseVolume(name) && !scope.Data || !deploy.IsDatabaseVolume(name) && !scope.Volumes {
+			continue
+		}
+		steps = append(steps, deploy.CleanupStep{Kind: "pvc", Name: name, Action: "remove",
+			Command: []string{"kubectl", "delete", res, "-n", namespace}})
+	}
+	return steps
+}
+
+// listResources lists the resources of kinds in the deployment namespace
+// as kind/name. A missing namespace lists nothing.
+func (d *Deployer) listResources(ctx context.Context, kinds, selector string) ([]string, error) {
+	args := []string{"get", kinds, "-n", d.Config.ManifestConfig.Namespace, "-o", "name", "--ignore-not-found"}
+	if selector != "" {
+		args = append(args, "-l", selector)
+	}
+	cmd := exec.CommandContext(ctx, "kubectl", args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// splitResource splits kind/name as printed by kubectl -o name, e.g.
+// "deployment.apps/bibd", into kind and name.
+func splitResource(res string) (string, string) {
+	kind, name, ok := strings.Cut(res, "/")
+	if !ok {
+		return "", res
+	}
+	kind, _, _ = strings.Cut(kind, ".")
+	return kind, name
+}
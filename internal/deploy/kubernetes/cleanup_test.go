@@ -0,0 +1,49 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"bib/internal/deploy"
+)
+
+func TestCleanupSteps(t *testing.T) {
+	workloads := []string{"deployment.apps/bibd", "statefulset.apps/postgres"}
+	network := []string{"service/bibd", "ingress.networking.k8s.io/bibd"}
+	pvcs := []string{"persistentvolumeclaim/bibd-data", "persistentvolumeclaim/postgres-data-postgres-0", "persistentvolumeclaim/other"}
+
+	names := func(steps []deploy.CleanupStep) []string {
+		var out []string
+		for _, s := range steps {
+			out = append(out, s.Kind+":"+s.Name+":"+s.Action)
+		}
+		return out
+	}
+
+	steps := cleanupSteps("bibd", deploy.CleanupScope{Workloads: true, Volumes: true, Networks: true}, workloads, network, pvcs)
+	want := []string{
+		"deployment:bibd:remove", "statefulset:postgres:remove",
+		"service:bibd:remove", "ingress:bibd:remove",
+		"pvc:bibd-data:remove",
+	}
+	if !reflect.DeepEqual(names(steps), want) {
+		t.Errorf("steps = %v, want %v", names(steps), want)
+	}
+	if got := steps[0].Command; !reflect.DeepEqual(got, []string{"kubectl", "delete", "deployment.apps/bibd", "-n", "bibd"}) {
+		t.Errorf("unexpected command %v", got)
+	}
+
+	steps = cleanupSteps("bibd", deploy.CleanupScope{Data: true}, nil, nil, pvcs)
+	if want := []string{"pvc:postgres-data-postgres-0:remove"}; !reflect.DeepEqual(names(steps), want) {
+		t.Errorf("steps = %v, want %v", names(steps), want)
+	}
+
+	steps = cleanupSteps("bibd", deploy.CleanupScope{Workloads: true, Data: true, ScaleDown: true}, workloads, network, pvcs)
+	want = []string{"deployment:bibd:scale to 0", "statefulset:postgres:scale to 0"}
+	if !reflect.DeepEqual(names(steps), want) {
+		t.Errorf("steps = %v, want %v", names(steps), want)
+	}
+	if got := steps[1].Command; !reflect.DeepEqual(got, []string{"kubectl", "scale", "statefulset.apps/postgres", "-n", "bibd", "--replicas=0"}) {
+		t.Errorf("unexpected command %v", got)
+	}
+}
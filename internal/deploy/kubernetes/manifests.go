@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+
+	"bib/internal/deploy"
 )
 
 // ManifestConfig contains configuration for Kubernetes manifest generation
@@ -20,7 +22,8 @@ type ManifestConfig struct {
 	// BibdImage is the bibd container image
 	BibdImage string
 
-	// BibdTag is the bibd container image tag
+	// BibdTag is the bibd container image tag, or a digest
+	// (sha256:...) to pin the image
 	BibdTag string
 
 	// Replicas is the number of bibd replicas
@@ -119,6 +122,16 @@ func DefaultManifestConfig() *ManifestConfig {
 	}
 }
 
+// BibdImageRef returns the bibd image reference
+func (c *ManifestConfig) BibdImageRef() string {
+	return deploy.ImageRef(c.BibdImage, c.BibdTag)
+}
+
+// PostgresImageRef returns the PostgreSQL image reference
+func (c *ManifestConfig) PostgresImageRef() string {
+	return deploy.ImageRef(c.PostgresImage, c.PostgresTag)
+}
+
 // ManifestGenerator generates Kubernetes manifests
 type ManifestGenerator struct {
 	Config *ManifestConfig
@@ -374,7 +387,7 @@ spec:
       serviceAccountName: bibd
       containers:
         - name: bibd
-          image: {{ .BibdImage }}:{{ .BibdTag }}
+          image: {{ .BibdImageRef }}
           imagePullPolicy: Always
           ports:
             - name: api
@@ -605,7 +618,7 @@ spec:
     spec:
       containers:
         - name: postgres
-          image: %s:%s
+          image: %s
           imagePullPolicy: IfNotPresent
           ports:
             - name: postgres
@@ -663,7 +676,7 @@ spec:
         resources:
           requests:
             storage: %s
-`, g.Config.Namespace, g.Config.PostgresImage, g.Config.PostgresTag,
+`, g.Config.Namespace, g.Config.PostgresImageRef(),
 		g.Config.PostgresDatabase, g.Config.PostgresUser,
 		g.Config.PostgresUser, g.Config.PostgresDatabase,
 		g.Config.PostgresUser, g.Config.PostgresDatabase,
@@ -709,7 +722,7 @@ metadata:
     app.kubernetes.io/component: database
 spec:
   instances: 1
-  imageName: {{ .PostgresImage }}:{{ .PostgresTag }}
+  imageName: {{ .PostgresImageRef }}
   
   postgresql:
     parameters:
@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// CurrentImage returns the image of the bibd container in the bibd Deployment
+func (d *Deployer) CurrentImage(ctx context.Context) (string, error) {
+	cmd := exec.CommandContext(ctx, "kubectl", "get", "deployment/bibd",
+		"-n", d.Config.ManifestConfig.Namespace,
+		"-o", `jsonpath={.spec.template.spec.containers[?(@.name=="bibd")].image}`)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	image := strings.TrimSpace(string(output))
+	if image == "" {
+		return "", fmt.Errorf("deployment/bibd has no bibd container")
+	}
+	return image, nil
+}
+
+// PullImage is a no-op: the kubelet pulls the image during the rollout, and
+// a failed pull stalls the rollout, which WaitReady reports.
+func (d *Deployer) PullImage(_ context.Context, _ string) error {
+	return nil
+}
+
+// SetImage patches the bibd Deployment, which starts a rolling update
+func (d *Deployer) SetImage(ctx context.Context, image string) error {
+	cmd := exec.CommandContext(ctx, "kubectl", "set", "image",
+		"-n", d.Config.ManifestConfig.Namespace,
+		"deployment/bibd", "bibd="+image)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// WaitReady waits for the rollout of the bibd Deployment to finish
+func (d *Deployer) WaitReady(ctx context.Context, timeout time.Duration) error {
+	cmd := exec.CommandContext(ctx, "kubectl", "rollout", "status",
+		"-n", d.Config.ManifestConfig.Namespace, "deployment/bibd",
+		"--timeout", fmt.Sprintf("%ds", int(timeout.Seconds())))
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("bibd rollout not ready: %v: %s", err, stderr.String())
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrLogsUnavailable is returned by Logs when the service manager does not
+// capture the daemon's output
+var ErrLogsUnavailable = errors.New("service logs unavailable")
+
+// ServiceLogEntry is a line of daemon output
+type ServiceLogEntry struct {
+	// Time is when the line was logged, if the service manager records it
+	Time time.Time `json:"time,omitempty"`
+
+	// Stream is stdout or stderr when known
+	Stream string `json:"stream,omitempty"`
+
+	// Message is the log line
+	Message string `json:"message"`
+}
+
+// Logs returns the last lines of the daemon's output. On systemd this
+// reads the journal; on launchd it reads the files the plist redirects
+// stdout and stderr to, returning up to lines from each, stdout first.
+// Windows services have no captured output, so Logs returns
+// ErrLogsUnavailable; configure log.file_path instead.
+func (s *ServiceInstaller) Logs(ctx context.Context, lines int) ([]ServiceLogEntry, error) {
+	if lines <= 0 {
+		lines = 50
+	}
+
+	switch serviceType := DetectServiceType(); serviceType {
+	case ServiceTypeSystemd:
+		return s.journalLogs(ctx, lines)
+	case ServiceTypeLaunchd:
+		return s.launchdLogs(lines)
+	case ServiceTypeWindows:
+		return nil, fmt.Errorf("%w: Windows services do not capture output; set log.file_path in the bibd config and read that file", ErrLogsUnavailable)
+	default:
+		return nil, fmt.Errorf("unsupported service type: %s", serviceType)
+	}
+}
+
+// journalLogs reads the unit's journal as JSON
+func (s *ServiceInstaller) journalLogs(ctx context.Context, lines int) ([]ServiceLogEntry, error) {
+	args := []string{"-u", s.Config.Name + ".service", "-n", strconv.Itoa(lines), "-o", "json", "--no-pager"}
+	if s.Config.UserService {
+		args = append([]string{"--user"}, args...)
+	}
+
+	out, code, err := s.runner()(ctx, "journalctl", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run journalctl: %w", err)
+	}
+	if code != 0 {
+		return nil, fmt.Errorf("journalctl failed (code %d): %s; reading system logs may require the systemd-journal group",
+			code, strings.TrimSpace(out))
+	}
+	return parseJournal(out)
+}
+
+// journalEntry holds the journal fields Logs uses. MESSAGE is a string, or
+// an array of bytes when it is not valid UTF-8.
+type journalEntry struct {
+	Timestamp string          `json:"__REALTIME_TIMESTAMP"`
+	Transport string          `json:"_TRANSPORT"`
+	Message   json.RawMessage `json:"MESSAGE"`
+}
+
+// parseJournal parses journalctl -o json output, one object per line
+func parseJournal(out string) ([]ServiceLogEntry, error) {
+	var entries []ServiceLogEntry
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			// journalctl prints "-- No entries --" when the unit has none
+			continue
+		}
+
+		var je journalEntry
+		if err := json.Unmarshal(line, &je); err != nil {
+			return nil, fmt.Errorf("failed to parse journal entry: %w", err)
+		}
+
+		entry := ServiceLogEntry{Message: journalMessage(je.Message)}
+		if je.Transport == "stdout" {
+			entry.Stream = "stdout"
+		}
+		if usec, err := strconv.ParseInt(je.Timestamp, 10, 64); err == nil {
+			entry.Time = time.UnixMicro(usec)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// journalMessage decodes a MESSAGE field
+func journalMessage(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var b []byte
+	var ints []int
+	if json.Unmarshal(raw, &ints) == nil {
+		for _, i := range ints {
+			b = append(b, byte(i))
+		}
+		return string(b)
+	}
+	return string(raw)
+}
+
+// launchdLogs tails the stdout and stderr files from the plist
+func (s *ServiceInstaller) launchdLogs(lines int) ([]ServiceLogEntry, error) {
+	logDir := expandHome(s.logDir())
+	var entries []ServiceLogEntry
+	found := false
+
+	for _, stream := range []string{"stdout", "stderr"} {
+		name := s.Config.Name + ".log"
+		if stream == "stderr" {
+			name = s.Config.Name + ".error.log"
+		}
+
+		tail, err := tailFile(filepath.Join(logDir, name), lines)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, line := range tail {
+			entries = append(entries, ServiceLogEntry{Stream: stream, Message: line})
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: no log files in %s; is the service installed?", ErrLogsUnavailable, logDir)
+	}
+	return entries, nil
+}
+
+// tailFile returns the last n lines of a file, reading backwards so large
+// logs are not read in full
+func tailFile(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	const chunkSize = 64 * 1024
+	var buf []byte
+	for offset := info.Size(); offset > 0 && bytes.Count(buf, []byte{'\n'}) <= n; {
+		start := max(offset-chunkSize, 0)
+		chunk := make([]byte, offset-start)
+		if _, err := f.ReadAt(chunk, start); err != nil {
+			return nil, err
+		}
+		buf = append(chunk, buf...)
+		offset = start
+	}
+
+	text := strings.TrimRight(string(buf), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
@@ -0,0 +1,116 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseJournal(t *testing.T) {
+	out := `{"__REALTIME_TIMESTAMP":"1700000000000000","_TRANSPORT":"stdout","MESSAGE":"starting bibd"}
+{"__REALTIME_TIMESTAMP":"1700000001000000","_TRANSPORT":"journal","MESSAGE":[104,105]}
+`
+	entries, err := parseJournal(out)
+	if err != nil {
+		t.Fatalf("parseJournal() error = %v", err)
+	}
+
+	want := []ServiceLogEntry{
+		{Time: time.UnixMicro(1700000000000000), Stream: "stdout", Message: "starting bibd"},
+		{Time: time.UnixMicro(1700000001000000), Message: "hi"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("parseJournal() = %+v, want %+v", entries, want)
+	}
+
+	entries, err = parseJournal("-- No entries --\n")
+	if err != nil || len(entries) != 0 {
+		t.Errorf("parseJournal(no entries) = %v, %v", entries, err)
+	}
+}
+
+func TestServiceInstaller_JournalLogs(t *testing.T) {
+	runner := &fakeRunner{out: `{"__REALTIME_TIMESTAMP":"1700000000000000","MESSAGE":"ready"}` + "\n"}
+	installer := NewServiceInstaller(&ServiceConfig{Name: "bibd", UserService: true})
+	installer.run = runner.run
+
+	entries, err := installer.journalLogs(context.Background(), 20)
+	if err != nil {
+		t.Fatalf("journalLogs() error = %v", err)
+	}
+
+	want := []string{"journalctl", "--user", "-u", "bibd.service", "-n", "20", "-o", "json", "--no-pager"}
+	if !reflect.DeepEqual(runner.cmd, want) {
+		t.Errorf("ran %q, want %q", runner.cmd, want)
+	}
+	if len(entries) != 1 || entries[0].Message != "ready" {
+		t.Errorf("journalLogs() = %+v", entries)
+	}
+
+	runner.out, runner.code = "Failed to open journal: Permission denied", 1
+	if _, err := installer.journalLogs(context.Background(), 20); err == nil || !strings.Contains(err.Error(), "Permission denied") {
+		t.Errorf("expected journalctl's error, got %v", err)
+	}
+}
+
+func TestServiceInstaller_LaunchdLogs(t *testing.T) {
+	logDir := t.TempDir()
+	var stdout strings.Builder
+	for i := 1; i <= 100; i++ {
+		fmt.Fprintf(&stdout, "line %d\n", i)
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "bibd.log"), []byte(stdout.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "bibd.error.log"), []byte("panic: oops\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	installer := NewServiceInstaller(&ServiceConfig{Name: "bibd", LogDir: logDir})
+
+	entries, err := installer.launchdLogs(3)
+	if err != nil {
+		t.Fatalf("launchdLogs() error = %v", err)
+	}
+
+	want := []ServiceLogEntry{
+		{Stream: "stdout", Message: "line 98"},
+		{Stream: "stdout", Message: "line 99"},
+		{Stream: "stdout", Message: "line 100"},
+		{Stream: "stderr", Message: "panic: oops"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("launchdLogs() = %+v, want %+v", entries, want)
+	}
+
+	installer.Config.LogDir = t.TempDir()
+	if _, err := installer.launchdLogs(3); !errors.Is(err, ErrLogsUnavailable) {
+		t.Errorf("expected ErrLogsUnavailable without log files, got %v", err)
+	}
+}
+
+func TestTailFile_LargeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "big.log")
+	line := strings.Repeat("x", 1000)
+	var sb strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&sb, "%d %s\n", i, line)
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := tailFile(path, 100)
+	if err != nil {
+		t.Fatalf("tailFile() error = %v", err)
+	}
+	if len(lines) != 100 || !strings.HasPrefix(lines[0], "400 ") || !strings.HasPrefix(lines[99], "499 ") {
+		t.Errorf("tailFile() returned %d lines starting %.10q", len(lines), lines[0])
+	}
+}
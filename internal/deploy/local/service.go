@@ -39,6 +39,10 @@ type ServiceConfig struct {
 	// WorkingDirectory is the working directory for the service
 	WorkingDirectory string
 
+	// DataDir is bibd's data directory (server.data_dir). It must be
+	// writable, so it is added to ReadWritePaths in systemd units.
+	DataDir string
+
 	// User is the user to run the service as (Linux/macOS)
 	User string
 
@@ -56,6 +60,74 @@ type ServiceConfig struct {
 
 	// RestartDelaySec is the delay before restart in seconds
 	RestartDelaySec int
+
+	// ThrottleIntervalSec is the minimum time between launches (launchd).
+	// It keeps a crash-looping daemon from being respawned in a tight loop;
+	// zero falls back to RestartDelaySec.
+	ThrottleIntervalSec int
+
+	// LogDir is where stdout and stderr are written (launchd). Defaults to
+	// the logs directory under WorkingDirectory.
+	LogDir string
+
+	// Hardening configures systemd sandboxing. Nil uses
+	// DefaultSystemdHardening; an empty value disables it.
+	Hardening *SystemdHardening
+}
+
+// SystemdHardening configures the sandboxing and resource limit directives
+// of generated systemd units. bibd holds identity keys and database
+// credentials, so the defaults confine it to its own directories.
+type SystemdHardening struct {
+	// NoNewPrivileges prevents bibd and its children gaining privileges
+	NoNewPrivileges bool
+
+	// ProtectSystem mounts /usr, /boot and /etc read-only: "strict" makes
+	// the whole file system read-only except ReadWritePaths. Empty omits it.
+	ProtectSystem string
+
+	// ProtectHome restricts /home, /root and /run/user: "read-only",
+	// "tmpfs" or "true". Empty omits it.
+	ProtectHome string
+
+	// PrivateTmp gives bibd its own /tmp
+	PrivateTmp bool
+
+	// PrivateDevices hides physical devices
+	PrivateDevices bool
+
+	// ProtectKernel makes kernel tunables, modules and control groups
+	// inaccessible
+	ProtectKernel bool
+
+	// ReadWritePaths are writable in addition to the working, config and
+	// data directories
+	ReadWritePaths []string
+
+	// LimitNOFILE is the open file limit; libp2p holds many connections.
+	// Zero omits it.
+	LimitNOFILE int
+
+	// MemoryMax caps memory use, e.g. "2G". Empty omits it.
+	MemoryMax string
+
+	// TasksMax caps the number of threads and processes. Zero omits it.
+	TasksMax int
+}
+
+// DefaultSystemdHardening returns the hardening applied when
+// ServiceConfig.Hardening is nil
+func DefaultSystemdHardening() *SystemdHardening {
+	return &SystemdHardening{
+		NoNewPrivileges: true,
+		ProtectSystem:   "strict",
+		ProtectHome:     "read-only",
+		PrivateTmp:      true,
+		PrivateDevices:  true,
+		ProtectKernel:   true,
+		LimitNOFILE:     65536,
+		TasksMax:        4096,
+	}
 }
 
 // DefaultServiceConfig returns a default service configuration
@@ -67,6 +139,7 @@ func DefaultServiceConfig() *ServiceConfig {
 
 	homeDir, _ := os.UserHomeDir()
 	configPath := filepath.Join(homeDir, ".config", "bibd", "config.yaml")
+	dataDir := filepath.Join(homeDir, ".local", "share", "bibd")
 
 	currentUser, _ := user.Current()
 	username := "bibd"
@@ -75,24 +148,29 @@ func DefaultServiceConfig() *ServiceConfig {
 	}
 
 	return &ServiceConfig{
-		Name:             "bibd",
-		DisplayName:      "bib Daemon",
-		Description:      "bib distributed database daemon",
-		ExecutablePath:   execPath,
-		ConfigPath:       configPath,
-		WorkingDirectory: filepath.Dir(configPath),
-		User:             username,
-		Group:            username,
-		UserService:      false,
-		Environment:      make(map[string]string),
-		RestartPolicy:    "on-failure",
-		RestartDelaySec:  5,
+		Name:                "bibd",
+		DisplayName:         "bib Daemon",
+		Description:         "bib distributed database daemon",
+		ExecutablePath:      execPath,
+		ConfigPath:          configPath,
+		WorkingDirectory:    filepath.Dir(configPath),
+		DataDir:             dataDir,
+		User:                username,
+		Group:               username,
+		UserService:         false,
+		Environment:         make(map[string]string),
+		RestartPolicy:       "on-failure",
+		RestartDelaySec:     5,
+		ThrottleIntervalSec: 10,
 	}
 }
 
 // ServiceInstaller provides methods to install and manage services
 type ServiceInstaller struct {
 	Config *ServiceConfig
+
+	// run executes a service manager command; replaced in tests
+	run commandRunner
 }
 
 // NewServiceInstaller creates a new service installer
@@ -100,7 +178,7 @@ func NewServiceInstaller(config *ServiceConfig) *ServiceInstaller {
 	if config == nil {
 		config = DefaultServiceConfig()
 	}
-	return &ServiceInstaller{Config: config}
+	return &ServiceInstaller{Config: config, run: runCommand}
 }
 
 // DetectServiceType detects the appropriate service type for this system
@@ -137,9 +215,9 @@ func (s *ServiceInstaller) GetServiceFilePath() string {
 	case ServiceTypeLaunchd:
 		if s.Config.UserService {
 			homeDir, _ := os.UserHomeDir()
-			return filepath.Join(homeDir, "Library", "LaunchAgents", "dev.bib."+s.Config.Name+".plist")
+			return filepath.Join(homeDir, "Library", "LaunchAgents", s.launchdLabel()+".plist")
 		}
-		return filepath.Join("/Library/LaunchDaemons", "dev.bib."+s.Config.Name+".plist")
+		return filepath.Join("/Library/LaunchDaemons", s.launchdLabel()+".plist")
 
 	case ServiceTypeWindows:
 		// Windows services don't use file paths in the same way
@@ -205,19 +283,7 @@ func (s *ServiceInstaller) generateSystemd() string {
 		sb.WriteString(fmt.Sprintf("Environment=%s=%s\n", key, value))
 	}
 
-	// Security hardening
-	sb.WriteString("\n# Security hardening\n")
-	sb.WriteString("NoNewPrivileges=true\n")
-	sb.WriteString("ProtectSystem=strict\n")
-	sb.WriteString("ProtectHome=read-only\n")
-	sb.WriteString("PrivateTmp=true\n")
-	sb.WriteString("PrivateDevices=true\n")
-	sb.WriteString("ProtectKernelTunables=true\n")
-	sb.WriteString("ProtectKernelModules=true\n")
-	sb.WriteString("ProtectControlGroups=true\n")
-
-	// Allow writing to config and data directories
-	sb.WriteString(fmt.Sprintf("ReadWritePaths=%s\n", s.Config.WorkingDirectory))
+	s.writeSystemdHardening(&sb)
 
 	sb.WriteString("\n")
 	sb.WriteString("[Install]\n")
@@ -230,6 +296,100 @@ func (s *ServiceInstaller) generateSystemd() string {
 	return sb.String()
 }
 
+// writeSystemdHardening writes the sandboxing and resource limit directives
+func (s *ServiceInstaller) writeSystemdHardening(sb *strings.Builder) {
+	h := s.Config.Hardening
+	if h == nil {
+		h = DefaultSystemdHardening()
+	}
+
+	var lines []string
+	if h.NoNewPrivileges {
+		lines = append(lines, "NoNewPrivileges=true")
+	}
+	if h.ProtectSystem != "" {
+		lines = append(lines, "ProtectSystem="+h.ProtectSystem)
+	}
+	if h.ProtectHome != "" {
+		lines = append(lines, "ProtectHome="+h.ProtectHome)
+	}
+	if h.PrivateTmp {
+		lines = append(lines, "PrivateTmp=true")
+	}
+	if h.PrivateDevices {
+		lines = append(lines, "PrivateDevices=true")
+	}
+	if h.ProtectKernel {
+		lines = append(lines, "ProtectKernelTunables=true", "ProtectKernelModules=true", "ProtectControlGroups=true")
+	}
+
+	// A read-only file system or home needs explicit exceptions for the
+	// directories bibd writes to
+	if h.ProtectSystem != "" || h.ProtectHome != "" {
+		for _, path := range s.readWritePaths(h) {
+			lines = append(lines, "ReadWritePaths="+path)
+		}
+	}
+
+	if len(lines) > 0 {
+		sb.WriteString("\n# Security hardening\n")
+		sb.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+
+	var limits []string
+	if h.LimitNOFILE > 0 {
+		limits = append(limits, fmt.Sprintf("LimitNOFILE=%d", h.LimitNOFILE))
+	}
+	if h.MemoryMax != "" {
+		limits = append(limits, "MemoryMax="+h.MemoryMax)
+	}
+	if h.TasksMax > 0 {
+		limits = append(limits, fmt.Sprintf("TasksMax=%d", h.TasksMax))
+	}
+	if len(limits) > 0 {
+		sb.WriteString("\n# Resource limits\n")
+		sb.WriteString(strings.Join(limits, "\n") + "\n")
+	}
+}
+
+// readWritePaths returns the directories bibd writes to: its working,
+// config and data directories and any extra paths, without duplicates.
+// systemd does not expand ~, so it is expanded here.
+func (s *ServiceInstaller) readWritePaths(h *SystemdHardening) []string {
+	candidates := []string{s.Config.WorkingDirectory}
+	if s.Config.ConfigPath != "" {
+		candidates = append(candidates, filepath.Dir(s.Config.ConfigPath))
+	}
+	candidates = append(candidates, s.Config.DataDir)
+	candidates = append(candidates, h.ReadWritePaths...)
+
+	seen := make(map[string]bool)
+	var paths []string
+	for _, path := range candidates {
+		if path == "" {
+			continue
+		}
+		path = filepath.Clean(expandHome(path))
+		if !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// expandHome expands a leading ~ to the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[1:])
+}
+
 // generateLaunchd generates a launchd plist file
 func (s *ServiceInstaller) generateLaunchd() string {
 	var sb strings.Builder
@@ -242,7 +402,7 @@ func (s *ServiceInstaller) generateLaunchd() string {
 	sb.WriteString("\n<dict>\n")
 
 	// Label
-	sb.WriteString(fmt.Sprintf("    <key>Label</key>\n    <string>dev.bib.%s</string>\n", s.Config.Name))
+	sb.WriteString(fmt.Sprintf("    <key>Label</key>\n    <string>%s</string>\n", s.launchdLabel()))
 
 	// Program arguments
 	sb.WriteString("    <key>ProgramArguments</key>\n    <array>\n")
@@ -258,7 +418,8 @@ func (s *ServiceInstaller) generateLaunchd() string {
 	// Run at load
 	sb.WriteString("    <key>RunAtLoad</key>\n    <true/>\n")
 
-	// Keep alive
+	// Keep alive: restart after a non-zero exit or a crash (signal), but
+	// not after a clean shutdown
 	if s.Config.RestartPolicy == "always" || s.Config.RestartPolicy == "on-failure" {
 		sb.WriteString("    <key>KeepAlive</key>\n")
 		if s.Config.RestartPolicy == "always" {
@@ -266,12 +427,15 @@ func (s *ServiceInstaller) generateLaunchd() string {
 		} else {
 			sb.WriteString("    <dict>\n")
 			sb.WriteString("        <key>SuccessfulExit</key>\n        <false/>\n")
+			sb.WriteString("        <key>Crashed</key>\n        <true/>\n")
 			sb.WriteString("    </dict>\n")
 		}
 	}
 
-	// Throttle interval (restart delay)
-	sb.WriteString(fmt.Sprintf("    <key>ThrottleInterval</key>\n    <integer>%d</integer>\n", s.Config.RestartDelaySec))
+	// Throttle interval: launchd waits at least this long between launches
+	if throttle := s.throttleInterval(); throttle > 0 {
+		sb.WriteString(fmt.Sprintf("    <key>ThrottleInterval</key>\n    <integer>%d</integer>\n", throttle))
+	}
 
 	// Environment variables
 	if len(s.Config.Environment) > 0 {
@@ -283,42 +447,62 @@ func (s *ServiceInstaller) generateLaunchd() string {
 	}
 
 	// Standard output and error logs
-	logDir := filepath.Join(s.Config.WorkingDirectory, "logs")
-	sb.WriteString(fmt.Sprintf("    <key>StandardOutPath</key>\n    <string>%s/bibd.log</string>\n", logDir))
-	sb.WriteString(fmt.Sprintf("    <key>StandardErrorPath</key>\n    <string>%s/bibd.error.log</string>\n", logDir))
+	logDir := s.logDir()
+	sb.WriteString(fmt.Sprintf("    <key>StandardOutPath</key>\n    <string>%s</string>\n", filepath.Join(logDir, s.Config.Name+".log")))
+	sb.WriteString(fmt.Sprintf("    <key>StandardErrorPath</key>\n    <string>%s</string>\n", filepath.Join(logDir, s.Config.Name+".error.log")))
 
 	sb.WriteString("</dict>\n</plist>\n")
 
 	return sb.String()
 }
 
-// generateWindowsPowerShell generates PowerShell commands for Windows service installation
+// launchdLabel returns the launchd job label
+func (s *ServiceInstaller) launchdLabel() string {
+	return "dev.bib." + s.Config.Name
+}
+
+// throttleInterval returns the launchd ThrottleInterval in seconds
+func (s *ServiceInstaller) throttleInterval() int {
+	if s.Config.ThrottleIntervalSec > 0 {
+		return s.Config.ThrottleIntervalSec
+	}
+	return s.Config.RestartDelaySec
+}
+
+// logDir returns the directory launchd redirects output to
+func (s *ServiceInstaller) logDir() string {
+	if s.Config.LogDir != "" {
+		return s.Config.LogDir
+	}
+	return filepath.Join(s.Config.WorkingDirectory, "logs")
+}
+
+// generateWindowsPowerShell generates a PowerShell script that registers
+// bibd with the Windows service control manager, running the same sc.exe
+// commands as WindowsService.Install
 func (s *ServiceInstaller) generateWindowsPowerShell() string {
+	def := s.WindowsServiceDefinition()
 	var sb strings.Builder
 
 	sb.WriteString("# PowerShell script to install bibd as a Windows Service\n")
 	sb.WriteString("# Run this script as Administrator\n\n")
 
-	// Check for NSSM or use native sc.exe
-	sb.WriteString("# Option 1: Using NSSM (recommended)\n")
-	sb.WriteString("# Download NSSM from https://nssm.cc/download\n")
-	sb.WriteString(fmt.Sprintf("# nssm install %s \"%s\"\n", s.Config.Name, s.Config.ExecutablePath))
-	sb.WriteString(fmt.Sprintf("# nssm set %s AppParameters \"serve --config %s\"\n", s.Config.Name, s.Config.ConfigPath))
-	sb.WriteString(fmt.Sprintf("# nssm set %s AppDirectory \"%s\"\n", s.Config.Name, s.Config.WorkingDirectory))
-	sb.WriteString(fmt.Sprintf("# nssm set %s Description \"%s\"\n", s.Config.Name, s.Config.Description))
-	sb.WriteString(fmt.Sprintf("# nssm set %s Start SERVICE_AUTO_START\n", s.Config.Name))
-	sb.WriteString(fmt.Sprintf("# nssm start %s\n\n", s.Config.Name))
-
-	// Native sc.exe approach
-	sb.WriteString("# Option 2: Using sc.exe (native)\n")
-	binPath := fmt.Sprintf("\"%s\" serve --config \"%s\"", s.Config.ExecutablePath, s.Config.ConfigPath)
-	sb.WriteString(fmt.Sprintf("$serviceName = \"%s\"\n", s.Config.Name))
-	sb.WriteString(fmt.Sprintf("$displayName = \"%s\"\n", s.Config.DisplayName))
-	sb.WriteString(fmt.Sprintf("$description = \"%s\"\n", s.Config.Description))
-	sb.WriteString(fmt.Sprintf("$binPath = '%s'\n\n", binPath))
+	sb.WriteString(fmt.Sprintf("$serviceName = \"%s\"\n", def.Name))
+	sb.WriteString(fmt.Sprintf("$displayName = \"%s\"\n", def.DisplayName))
+	sb.WriteString(fmt.Sprintf("$description = \"%s\"\n", def.Description))
+	sb.WriteString(fmt.Sprintf("$binPath = '%s'\n\n", def.BinaryPath))
 
 	sb.WriteString("# Create the service\n")
-	sb.WriteString("New-Service -Name $serviceName -BinaryPathName $binPath -DisplayName $displayName -Description $description -StartupType Automatic\n\n")
+	sb.WriteString(fmt.Sprintf("sc.exe create $serviceName binPath= $binPath start= %s DisplayName= $displayName\n", def.StartType))
+	sb.WriteString("sc.exe description $serviceName $description\n\n")
+
+	actions := def.FailureArgs()[5]
+	if actions == "" {
+		actions = `'""'`
+	}
+	sb.WriteString("# Recovery: restart on failure\n")
+	sb.WriteString(fmt.Sprintf("sc.exe failure $serviceName reset= %d actions= %s\n", def.ResetPeriodSec, actions))
+	sb.WriteString(fmt.Sprintf("sc.exe failureflag $serviceName %s\n\n", def.FailureFlagArgs()[2]))
 
 	sb.WriteString("# Start the service\n")
 	sb.WriteString("Start-Service -Name $serviceName\n\n")
@@ -360,28 +544,28 @@ func (s *ServiceInstaller) InstallInstructions() string {
 		sb.WriteString("📋 Launchd Service Installation\n\n")
 		if s.Config.UserService {
 			sb.WriteString("User agent (no root required):\n\n")
-			sb.WriteString(fmt.Sprintf("1. Create the logs directory:\n   mkdir -p %s/logs\n\n", s.Config.WorkingDirectory))
+			sb.WriteString(fmt.Sprintf("1. Create the logs directory:\n   mkdir -p %s\n\n", s.logDir()))
 			sb.WriteString(fmt.Sprintf("2. Save the plist to: %s\n\n", filePath))
 			sb.WriteString(fmt.Sprintf("3. Load the agent:\n   launchctl load %s\n\n", filePath))
-			sb.WriteString(fmt.Sprintf("4. Check status:\n   launchctl list | grep %s\n", s.Config.Name))
+			sb.WriteString(fmt.Sprintf("4. Check status:\n   launchctl list %s\n", s.launchdLabel()))
 		} else {
 			sb.WriteString("System daemon (requires root):\n\n")
-			sb.WriteString(fmt.Sprintf("1. Save the plist to: %s\n\n", filePath))
-			sb.WriteString(fmt.Sprintf("2. Set permissions:\n   sudo chown root:wheel %s\n", filePath))
+			sb.WriteString(fmt.Sprintf("1. Create the logs directory:\n   sudo mkdir -p %s\n\n", s.logDir()))
+			sb.WriteString(fmt.Sprintf("2. Save the plist to: %s\n\n", filePath))
+			sb.WriteString(fmt.Sprintf("3. Set permissions:\n   sudo chown root:wheel %s\n", filePath))
 			sb.WriteString(fmt.Sprintf("   sudo chmod 644 %s\n\n", filePath))
-			sb.WriteString(fmt.Sprintf("3. Load the daemon:\n   sudo launchctl load %s\n\n", filePath))
-			sb.WriteString(fmt.Sprintf("4. Check status:\n   sudo launchctl list | grep %s\n", s.Config.Name))
+			sb.WriteString(fmt.Sprintf("4. Load the daemon:\n   sudo launchctl load %s\n\n", filePath))
+			sb.WriteString(fmt.Sprintf("5. Check status:\n   sudo launchctl list %s\n", s.launchdLabel()))
 		}
 
 	case ServiceTypeWindows:
 		sb.WriteString("📋 Windows Service Installation\n\n")
-		sb.WriteString("Run the generated PowerShell script as Administrator.\n\n")
-		sb.WriteString("Using NSSM (recommended):\n")
-		sb.WriteString("1. Download NSSM from https://nssm.cc/download\n")
-		sb.WriteString("2. Run the NSSM commands from the script\n\n")
-		sb.WriteString("Using native sc.exe:\n")
-		sb.WriteString("1. Open PowerShell as Administrator\n")
-		sb.WriteString("2. Run the New-Service command from the script\n")
+		sb.WriteString("System service (requires Administrator):\n\n")
+		sb.WriteString("1. Open PowerShell as Administrator\n\n")
+		sb.WriteString("2. Run the generated script\n\n")
+		sb.WriteString(fmt.Sprintf("3. Check status:\n   Get-Service %s\n\n", s.Config.Name))
+		sb.WriteString("The service starts at boot and is restarted if bibd exits with an error.\n")
+		sb.WriteString(fmt.Sprintf("To remove it:\n   Stop-Service %s; sc.exe delete %s\n", s.Config.Name, s.Config.Name))
 	}
 
 	return sb.String()
@@ -1,6 +1,8 @@
 package local
 
 import (
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -170,6 +172,93 @@ func TestServiceInstaller_GenerateSystemd_UserService(t *testing.T) {
 	}
 }
 
+func TestServiceInstaller_GenerateSystemd_Hardening(t *testing.T) {
+	config := &ServiceConfig{
+		Name:             "bibd",
+		ExecutablePath:   "/usr/local/bin/bibd",
+		ConfigPath:       "/etc/bibd/config.yaml",
+		WorkingDirectory: "/etc/bibd",
+		DataDir:          "/var/lib/bibd/",
+		User:             "bibd",
+		Group:            "bibd",
+	}
+
+	installer := NewServiceInstaller(config)
+	content := installer.generateSystemd()
+
+	for _, directive := range []string{
+		"NoNewPrivileges=true",
+		"ProtectSystem=strict",
+		"ProtectHome=read-only",
+		"PrivateTmp=true",
+		"ProtectKernelModules=true",
+		"LimitNOFILE=65536",
+		"TasksMax=4096",
+	} {
+		if !strings.Contains(content, directive+"\n") {
+			t.Errorf("missing default directive %s", directive)
+		}
+	}
+
+	// The working and config directories are the same and listed once
+	if got := strings.Count(content, "ReadWritePaths="); got != 2 {
+		t.Errorf("expected 2 ReadWritePaths, got %d:\n%s", got, content)
+	}
+	if !strings.Contains(content, "ReadWritePaths=/etc/bibd\nReadWritePaths=/var/lib/bibd\n") {
+		t.Errorf("ReadWritePaths should cover the config and data directories:\n%s", content)
+	}
+	if strings.Contains(content, "MemoryMax=") {
+		t.Error("MemoryMax should be omitted by default")
+	}
+
+	t.Run("custom", func(t *testing.T) {
+		config.Hardening = &SystemdHardening{
+			NoNewPrivileges: true,
+			ProtectSystem:   "full",
+			ReadWritePaths:  []string{"/srv/bib"},
+			MemoryMax:       "2G",
+		}
+		content := installer.generateSystemd()
+
+		if !strings.Contains(content, "ProtectSystem=full\n") || !strings.Contains(content, "MemoryMax=2G\n") {
+			t.Errorf("missing custom directives:\n%s", content)
+		}
+		if strings.Contains(content, "ProtectHome") || strings.Contains(content, "PrivateTmp") || strings.Contains(content, "LimitNOFILE") {
+			t.Errorf("disabled directives should be omitted:\n%s", content)
+		}
+		if !strings.Contains(content, "ReadWritePaths=/srv/bib\n") {
+			t.Error("missing extra ReadWritePaths")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		config.Hardening = &SystemdHardening{}
+		content := installer.generateSystemd()
+
+		if strings.Contains(content, "# Security hardening") || strings.Contains(content, "ReadWritePaths") {
+			t.Errorf("hardening should be omitted:\n%s", content)
+		}
+	})
+}
+
+func TestServiceInstaller_ReadWritePaths_ExpandsHome(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+
+	installer := NewServiceInstaller(&ServiceConfig{
+		WorkingDirectory: "/etc/bibd",
+		DataDir:          "~/.local/share/bibd",
+	})
+
+	paths := installer.readWritePaths(DefaultSystemdHardening())
+	want := filepath.Join(homeDir, ".local", "share", "bibd")
+	if len(paths) != 2 || paths[1] != want {
+		t.Errorf("readWritePaths() = %v, want data dir %s", paths, want)
+	}
+}
+
 func TestServiceInstaller_GenerateLaunchd(t *testing.T) {
 	config := &ServiceConfig{
 		Name:             "bibd",
@@ -220,6 +309,58 @@ func TestServiceInstaller_GenerateLaunchd(t *testing.T) {
 	}
 }
 
+func TestServiceInstaller_GenerateLaunchd_KeepAliveAndLogs(t *testing.T) {
+	config := &ServiceConfig{
+		Name:                "bibd",
+		ExecutablePath:      "/usr/local/bin/bibd",
+		ConfigPath:          "/etc/bibd/config.yaml",
+		WorkingDirectory:    "/var/lib/bibd",
+		RestartPolicy:       "on-failure",
+		RestartDelaySec:     5,
+		ThrottleIntervalSec: 30,
+		LogDir:              "/var/log/bibd",
+	}
+
+	installer := NewServiceInstaller(config)
+	content := installer.generateLaunchd()
+
+	keepAlive := "<key>KeepAlive</key>\n    <dict>\n" +
+		"        <key>SuccessfulExit</key>\n        <false/>\n" +
+		"        <key>Crashed</key>\n        <true/>\n    </dict>"
+	if !strings.Contains(content, keepAlive) {
+		t.Errorf("missing KeepAlive with crash restart:\n%s", content)
+	}
+	if !strings.Contains(content, "<key>ThrottleInterval</key>\n    <integer>30</integer>") {
+		t.Error("ThrottleInterval should use ThrottleIntervalSec")
+	}
+	if !strings.Contains(content, "<string>/var/log/bibd/bibd.log</string>") {
+		t.Error("missing StandardOutPath in LogDir")
+	}
+	if !strings.Contains(content, "<string>/var/log/bibd/bibd.error.log</string>") {
+		t.Error("missing StandardErrorPath in LogDir")
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		config.ThrottleIntervalSec = 0
+		config.LogDir = ""
+		content := installer.generateLaunchd()
+
+		if !strings.Contains(content, "<key>ThrottleInterval</key>\n    <integer>5</integer>") {
+			t.Error("ThrottleInterval should fall back to RestartDelaySec")
+		}
+		if !strings.Contains(content, "<string>/var/lib/bibd/logs/bibd.log</string>") {
+			t.Error("logs should default to the working directory")
+		}
+	})
+
+	t.Run("never restart", func(t *testing.T) {
+		config.RestartPolicy = "never"
+		if content := installer.generateLaunchd(); strings.Contains(content, "KeepAlive") {
+			t.Error("KeepAlive should be omitted when restarts are disabled")
+		}
+	})
+}
+
 func TestServiceInstaller_GenerateWindowsPowerShell(t *testing.T) {
 	config := &ServiceConfig{
 		Name:             "bibd",
@@ -237,11 +378,14 @@ func TestServiceInstaller_GenerateWindowsPowerShell(t *testing.T) {
 	if !strings.Contains(content, "# PowerShell script") {
 		t.Error("missing PowerShell header")
 	}
-	if !strings.Contains(content, "nssm install") {
-		t.Error("missing NSSM instructions")
+	if !strings.Contains(content, "sc.exe create $serviceName") {
+		t.Error("missing sc.exe create command")
+	}
+	if !strings.Contains(content, `'"C:\Program Files\bib\bibd.exe" --config "C:\ProgramData\bib\config.yaml" --workdir "C:\ProgramData\bib"'`) {
+		t.Error("missing binary path")
 	}
-	if !strings.Contains(content, "New-Service") {
-		t.Error("missing New-Service command")
+	if !strings.Contains(content, "actions= restart/5000/restart/5000/restart/5000") {
+		t.Error("missing restart on failure")
 	}
 	if !strings.Contains(content, "Start-Service") {
 		t.Error("missing Start-Service command")
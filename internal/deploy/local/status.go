@@ -0,0 +1,161 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// commandRunner runs a command and returns its combined output and exit
+// code. A non-zero exit code is not an error, so callers can tell expected
+// failures (e.g. service not loaded) apart from the command not running.
+type commandRunner func(ctx context.Context, name string, args ...string) (string, int, error)
+
+// runCommand is the default commandRunner
+func runCommand(ctx context.Context, name string, args ...string) (string, int, error) {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode(), nil
+	}
+	return string(out), 0, err
+}
+
+// ServiceStatus reports the state of the service as seen by the service
+// manager
+type ServiceStatus struct {
+	// Type is the service manager that was queried
+	Type ServiceType `json:"type"`
+
+	// Loaded is true when the service manager knows the service: the unit
+	// is loaded (systemd), the job is loaded (launchd) or the service is
+	// registered (Windows)
+	Loaded bool `json:"loaded"`
+
+	// Running is true when bibd is running under the service manager
+	Running bool `json:"running"`
+
+	// PID is the process ID of the running daemon, if known
+	PID int `json:"pid,omitempty"`
+
+	// LastExitStatus is the exit status of the previous run, if known
+	LastExitStatus int `json:"last_exit_status,omitempty"`
+
+	// State is the service manager's own description, e.g. "active
+	// (running)" or "STOPPED"
+	State string `json:"state,omitempty"`
+}
+
+// Status queries the service manager for the service's state. A service
+// that is not installed is reported as not loaded rather than as an error.
+// System launchd daemons are only visible to launchctl when run as root.
+func (s *ServiceInstaller) Status(ctx context.Context) (*ServiceStatus, error) {
+	switch serviceType := DetectServiceType(); serviceType {
+	case ServiceTypeSystemd:
+		return s.systemdStatus(ctx)
+	case ServiceTypeLaunchd:
+		return s.launchdStatus(ctx)
+	case ServiceTypeWindows:
+		return s.windowsStatus(ctx)
+	default:
+		return nil, fmt.Errorf("unsupported service type: %s", serviceType)
+	}
+}
+
+// runner returns the command runner, defaulting to runCommand
+func (s *ServiceInstaller) runner() commandRunner {
+	if s.run == nil {
+		return runCommand
+	}
+	return s.run
+}
+
+// systemdStatus queries systemctl show
+func (s *ServiceInstaller) systemdStatus(ctx context.Context) (*ServiceStatus, error) {
+	args := []string{"show", s.Config.Name + ".service",
+		"--property=LoadState,ActiveState,SubState,MainPID,ExecMainStatus"}
+	if s.Config.UserService {
+		args = append([]string{"--user"}, args...)
+	}
+
+	out, code, err := s.runner()(ctx, "systemctl", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run systemctl: %w", err)
+	}
+	if code != 0 {
+		return nil, fmt.Errorf("systemctl show %s failed (code %d): %s", s.Config.Name, code, strings.TrimSpace(out))
+	}
+
+	props := parseProperties(out)
+	status := &ServiceStatus{
+		Type:    ServiceTypeSystemd,
+		Loaded:  props["LoadState"] == "loaded",
+		Running: props["ActiveState"] == "active",
+		State:   fmt.Sprintf("%s (%s)", props["ActiveState"], props["SubState"]),
+	}
+	status.PID, _ = strconv.Atoi(props["MainPID"])
+	status.LastExitStatus, _ = strconv.Atoi(props["ExecMainStatus"])
+	return status, nil
+}
+
+// launchdStatus queries launchctl list, which prints the job as a
+// dictionary and exits non-zero if the job is not loaded
+func (s *ServiceInstaller) launchdStatus(ctx context.Context) (*ServiceStatus, error) {
+	out, code, err := s.runner()(ctx, "launchctl", "list", s.launchdLabel())
+	if err != nil {
+		return nil, fmt.Errorf("failed to run launchctl: %w", err)
+	}
+
+	status := &ServiceStatus{Type: ServiceTypeLaunchd, State: "not loaded"}
+	if code != 0 {
+		return status, nil
+	}
+
+	props := parseProperties(out)
+	status.Loaded = true
+	status.State = "loaded"
+	if pid, err := strconv.Atoi(props["PID"]); err == nil && pid > 0 {
+		status.Running = true
+		status.PID = pid
+		status.State = "running"
+	}
+	status.LastExitStatus, _ = strconv.Atoi(props["LastExitStatus"])
+	return status, nil
+}
+
+// windowsStatus queries the service control manager
+func (s *ServiceInstaller) windowsStatus(ctx context.Context) (*ServiceStatus, error) {
+	status := &ServiceStatus{Type: ServiceTypeWindows, State: "not installed"}
+
+	state, err := s.NewWindowsService().Status(ctx)
+	if errors.Is(err, ErrServiceNotInstalled) {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status.Loaded = true
+	status.Running = state == "RUNNING"
+	status.State = state
+	return status, nil
+}
+
+// parseProperties parses "key=value" lines, as printed by systemctl
+// show, and the `"key" = value;` lines printed by launchctl list
+func parseProperties(out string) map[string]string {
+	props := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			continue
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		value = strings.Trim(strings.TrimSuffix(strings.TrimSpace(value), ";"), `"`)
+		props[key] = strings.TrimSpace(value)
+	}
+	return props
+}
@@ -0,0 +1,130 @@
+package local
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// fakeRunner returns canned output and records the command
+type fakeRunner struct {
+	out  string
+	code int
+	cmd  []string
+}
+
+func (f *fakeRunner) run(ctx context.Context, name string, args ...string) (string, int, error) {
+	f.cmd = append([]string{name}, args...)
+	return f.out, f.code, nil
+}
+
+func TestServiceInstaller_LaunchdStatus(t *testing.T) {
+	runner := &fakeRunner{out: `{
+	"LimitLoadToSessionType" = "System";
+	"Label" = "dev.bib.bibd";
+	"OnDemand" = false;
+	"LastExitStatus" = 256;
+	"PID" = 4242;
+	"Program" = "/usr/local/bin/bibd";
+};
+`}
+	installer := NewServiceInstaller(&ServiceConfig{Name: "bibd"})
+	installer.run = runner.run
+
+	status, err := installer.launchdStatus(context.Background())
+	if err != nil {
+		t.Fatalf("launchdStatus() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(runner.cmd, []string{"launchctl", "list", "dev.bib.bibd"}) {
+		t.Errorf("ran %q", runner.cmd)
+	}
+	want := &ServiceStatus{Type: ServiceTypeLaunchd, Loaded: true, Running: true, PID: 4242, LastExitStatus: 256, State: "running"}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("launchdStatus() = %+v, want %+v", status, want)
+	}
+}
+
+func TestServiceInstaller_LaunchdStatus_LoadedNotRunning(t *testing.T) {
+	runner := &fakeRunner{out: "{\n\t\"Label\" = \"dev.bib.bibd\";\n\t\"LastExitStatus\" = 0;\n};\n"}
+	installer := NewServiceInstaller(&ServiceConfig{Name: "bibd"})
+	installer.run = runner.run
+
+	status, err := installer.launchdStatus(context.Background())
+	if err != nil {
+		t.Fatalf("launchdStatus() error = %v", err)
+	}
+	if !status.Loaded || status.Running || status.State != "loaded" {
+		t.Errorf("launchdStatus() = %+v", status)
+	}
+}
+
+func TestServiceInstaller_LaunchdStatus_NotLoaded(t *testing.T) {
+	runner := &fakeRunner{out: `Could not find service "dev.bib.bibd" in domain for port`, code: 113}
+	installer := NewServiceInstaller(&ServiceConfig{Name: "bibd"})
+	installer.run = runner.run
+
+	status, err := installer.launchdStatus(context.Background())
+	if err != nil {
+		t.Fatalf("launchdStatus() error = %v", err)
+	}
+	if status.Loaded || status.Running {
+		t.Errorf("launchdStatus() = %+v, want not loaded", status)
+	}
+}
+
+func TestServiceInstaller_SystemdStatus(t *testing.T) {
+	runner := &fakeRunner{out: "LoadState=loaded\nActiveState=active\nSubState=running\nMainPID=1234\nExecMainStatus=0\n"}
+	installer := NewServiceInstaller(&ServiceConfig{Name: "bibd", UserService: true})
+	installer.run = runner.run
+
+	status, err := installer.systemdStatus(context.Background())
+	if err != nil {
+		t.Fatalf("systemdStatus() error = %v", err)
+	}
+
+	if runner.cmd[0] != "systemctl" || runner.cmd[1] != "--user" || runner.cmd[3] != "bibd.service" {
+		t.Errorf("ran %q", runner.cmd)
+	}
+	want := &ServiceStatus{Type: ServiceTypeSystemd, Loaded: true, Running: true, PID: 1234, State: "active (running)"}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("systemdStatus() = %+v, want %+v", status, want)
+	}
+}
+
+func TestServiceInstaller_SystemdStatus_NotFound(t *testing.T) {
+	runner := &fakeRunner{out: "LoadState=not-found\nActiveState=inactive\nSubState=dead\nMainPID=0\nExecMainStatus=0\n"}
+	installer := NewServiceInstaller(&ServiceConfig{Name: "bibd"})
+	installer.run = runner.run
+
+	status, err := installer.systemdStatus(context.Background())
+	if err != nil {
+		t.Fatalf("systemdStatus() error = %v", err)
+	}
+	if status.Loaded || status.Running || status.PID != 0 {
+		t.Errorf("systemdStatus() = %+v, want not loaded", status)
+	}
+}
+
+func TestServiceInstaller_WindowsStatus(t *testing.T) {
+	scm := &fakeSCM{}
+	installer := testWindowsInstaller()
+	installer.run = scm.run
+
+	status, err := installer.windowsStatus(context.Background())
+	if err != nil {
+		t.Fatalf("windowsStatus() error = %v", err)
+	}
+	if status.Loaded {
+		t.Errorf("windowsStatus() = %+v, want not installed", status)
+	}
+
+	scm.installed, scm.running = true, true
+	status, err = installer.windowsStatus(context.Background())
+	if err != nil {
+		t.Fatalf("windowsStatus() error = %v", err)
+	}
+	if !status.Loaded || !status.Running || status.State != "RUNNING" {
+		t.Errorf("windowsStatus() = %+v", status)
+	}
+}
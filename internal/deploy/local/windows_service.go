@@ -0,0 +1,269 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrServiceNotInstalled is returned when the Windows service does not exist
+var ErrServiceNotInstalled = errors.New("service not installed")
+
+// sc.exe exit codes, which are Win32 error codes
+const (
+	scErrAccessDenied       = 5
+	scErrServiceNotActive   = 1062
+	scErrServiceExists      = 1073
+	scErrServiceNotFound    = 1060
+	scErrServiceAlreadyRuns = 1056
+	scErrMarkedForDelete    = 1072
+)
+
+// failureResetSec is how long the service must run without failing before
+// the SCM resets its failure count
+const failureResetSec = 86400
+
+// WindowsServiceDefinition describes a service registered with the Windows
+// service control manager
+type WindowsServiceDefinition struct {
+	// Name is the service name used by sc.exe and Start-Service
+	Name string
+
+	// DisplayName is shown in services.msc
+	DisplayName string
+
+	// Description is shown in services.msc
+	Description string
+
+	// BinaryPath is the full command line the SCM runs, with the executable
+	// and its arguments quoted
+	BinaryPath string
+
+	// StartType is the sc.exe start type (auto, demand, disabled)
+	StartType string
+
+	// RestartOnFailure makes the SCM restart the service when it exits
+	// with an error or crashes
+	RestartOnFailure bool
+
+	// RestartDelayMs is the delay before each restart in milliseconds
+	RestartDelayMs int
+
+	// ResetPeriodSec is how long the service must run cleanly before the
+	// failure count is reset
+	ResetPeriodSec int
+}
+
+// CreateArgs returns the sc.exe arguments that create the service
+func (d *WindowsServiceDefinition) CreateArgs() []string {
+	return []string{"create", d.Name,
+		"binPath=", d.BinaryPath,
+		"start=", d.StartType,
+		"DisplayName=", d.DisplayName,
+	}
+}
+
+// ConfigArgs returns the sc.exe arguments that update an existing service
+// to match the definition
+func (d *WindowsServiceDefinition) ConfigArgs() []string {
+	args := d.CreateArgs()
+	args[0] = "config"
+	return args
+}
+
+// DescriptionArgs returns the sc.exe arguments that set the description
+func (d *WindowsServiceDefinition) DescriptionArgs() []string {
+	return []string{"description", d.Name, d.Description}
+}
+
+// FailureArgs returns the sc.exe arguments that configure recovery. The
+// first three failures restart the service; with restarts disabled the
+// recovery actions are cleared.
+func (d *WindowsServiceDefinition) FailureArgs() []string {
+	actions := ""
+	if d.RestartOnFailure {
+		restart := "restart/" + strconv.Itoa(d.RestartDelayMs)
+		actions = strings.Join([]string{restart, restart, restart}, "/")
+	}
+	return []string{"failure", d.Name, "reset=", strconv.Itoa(d.ResetPeriodSec), "actions=", actions}
+}
+
+// FailureFlagArgs returns the sc.exe arguments that make the recovery
+// actions apply when the service stops with a non-zero exit code, not only
+// when it crashes
+func (d *WindowsServiceDefinition) FailureFlagArgs() []string {
+	flag := "0"
+	if d.RestartOnFailure {
+		flag = "1"
+	}
+	return []string{"failureflag", d.Name, flag}
+}
+
+// WindowsServiceDefinition returns the service definition for the config.
+// The SCM starts services in the system directory, so the working directory
+// is passed to bibd with --workdir.
+func (s *ServiceInstaller) WindowsServiceDefinition() *WindowsServiceDefinition {
+	delay := s.Config.RestartDelaySec
+	if delay <= 0 {
+		delay = 5
+	}
+
+	return &WindowsServiceDefinition{
+		Name:             s.Config.Name,
+		DisplayName:      s.Config.DisplayName,
+		Description:      s.Config.Description,
+		BinaryPath:       s.windowsBinaryPath(),
+		StartType:        "auto",
+		RestartOnFailure: s.Config.RestartPolicy != "never",
+		RestartDelayMs:   delay * 1000,
+		ResetPeriodSec:   failureResetSec,
+	}
+}
+
+// windowsBinaryPath returns the quoted command line for the service
+func (s *ServiceInstaller) windowsBinaryPath() string {
+	binPath := fmt.Sprintf(`"%s" --config "%s"`, s.Config.ExecutablePath, s.Config.ConfigPath)
+	if s.Config.WorkingDirectory != "" {
+		binPath += fmt.Sprintf(` --workdir "%s"`, s.Config.WorkingDirectory)
+	}
+	return binPath
+}
+
+// WindowsService manages bibd as a Windows service through sc.exe
+type WindowsService struct {
+	Definition *WindowsServiceDefinition
+
+	// run executes sc.exe; replaced in tests
+	run commandRunner
+}
+
+// NewWindowsService creates a Windows service manager for the installer's
+// config
+func (s *ServiceInstaller) NewWindowsService() *WindowsService {
+	return &WindowsService{
+		Definition: s.WindowsServiceDefinition(),
+		run:        s.runner(),
+	}
+}
+
+// sc runs sc.exe and turns a non-zero exit code into an error
+func (w *WindowsService) sc(ctx context.Context, args ...string) (int, error) {
+	out, code, err := w.run(ctx, "sc.exe", args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to run sc.exe %s: %w", args[0], err)
+	}
+	if code == 0 {
+		return 0, nil
+	}
+
+	msg := scMessage(out)
+	switch code {
+	case scErrServiceNotFound:
+		return code, fmt.Errorf("%w: %s", ErrServiceNotInstalled, w.Definition.Name)
+	case scErrAccessDenied:
+		return code, fmt.Errorf("sc.exe %s %s: access denied; run as Administrator", args[0], w.Definition.Name)
+	}
+	return code, fmt.Errorf("sc.exe %s %s failed (code %d): %s", args[0], w.Definition.Name, code, msg)
+}
+
+// Install registers the service with auto start and restart on failure. An
+// existing service is reconfigured in place, so Install can be re-run after
+// the binary or config path changes.
+func (w *WindowsService) Install(ctx context.Context) error {
+	code, err := w.sc(ctx, w.Definition.CreateArgs()...)
+	if code == scErrServiceExists {
+		_, err = w.sc(ctx, w.Definition.ConfigArgs()...)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, args := range [][]string{
+		w.Definition.DescriptionArgs(),
+		w.Definition.FailureArgs(),
+		w.Definition.FailureFlagArgs(),
+	} {
+		if _, err := w.sc(ctx, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Uninstall stops the service if it is running and removes it
+func (w *WindowsService) Uninstall(ctx context.Context) error {
+	if err := w.Stop(ctx); err != nil {
+		return err
+	}
+
+	code, err := w.sc(ctx, "delete", w.Definition.Name)
+	if code == scErrMarkedForDelete {
+		// Removal completes once every handle to the service is closed
+		return nil
+	}
+	return err
+}
+
+// Start starts the service. Starting a running service is not an error.
+func (w *WindowsService) Start(ctx context.Context) error {
+	code, err := w.sc(ctx, "start", w.Definition.Name)
+	if code == scErrServiceAlreadyRuns {
+		return nil
+	}
+	return err
+}
+
+// Stop stops the service. Stopping a stopped service is not an error.
+func (w *WindowsService) Stop(ctx context.Context) error {
+	code, err := w.sc(ctx, "stop", w.Definition.Name)
+	if code == scErrServiceNotActive {
+		return nil
+	}
+	return err
+}
+
+// Status returns the service state as reported by sc.exe, e.g. "RUNNING"
+// or "STOPPED"
+func (w *WindowsService) Status(ctx context.Context) (string, error) {
+	out, code, err := w.run(ctx, "sc.exe", "query", w.Definition.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to run sc.exe query: %w", err)
+	}
+	if code == scErrServiceNotFound {
+		return "", fmt.Errorf("%w: %s", ErrServiceNotInstalled, w.Definition.Name)
+	}
+	if code != 0 {
+		return "", fmt.Errorf("sc.exe query %s failed (code %d): %s", w.Definition.Name, code, scMessage(out))
+	}
+	return parseSCState(out), nil
+}
+
+// parseSCState extracts the state name from sc.exe query output, whose
+// state line reads "STATE : 4  RUNNING"
+func parseSCState(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(key) != "STATE" {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) >= 2 {
+			return fields[1]
+		}
+	}
+	return "UNKNOWN"
+}
+
+// scMessage returns the last non-empty line of sc.exe output, which holds
+// the error description
+func scMessage(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return "no output"
+}
@@ -0,0 +1,231 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testWindowsInstaller() *ServiceInstaller {
+	return NewServiceInstaller(&ServiceConfig{
+		Name:             "bibd",
+		DisplayName:      "bib Daemon",
+		Description:      "bib distributed database daemon",
+		ExecutablePath:   `C:\Program Files\bib\bibd.exe`,
+		ConfigPath:       `C:\ProgramData\bib\config.yaml`,
+		WorkingDirectory: `C:\ProgramData\bib`,
+		RestartPolicy:    "on-failure",
+		RestartDelaySec:  10,
+	})
+}
+
+func TestServiceInstaller_WindowsServiceDefinition(t *testing.T) {
+	def := testWindowsInstaller().WindowsServiceDefinition()
+
+	wantBinPath := `"C:\Program Files\bib\bibd.exe" --config "C:\ProgramData\bib\config.yaml" --workdir "C:\ProgramData\bib"`
+	if def.BinaryPath != wantBinPath {
+		t.Errorf("BinaryPath = %s, want %s", def.BinaryPath, wantBinPath)
+	}
+	if def.StartType != "auto" {
+		t.Errorf("StartType = %q, want auto", def.StartType)
+	}
+
+	wantCreate := []string{"create", "bibd", "binPath=", wantBinPath, "start=", "auto", "DisplayName=", "bib Daemon"}
+	if got := def.CreateArgs(); !reflect.DeepEqual(got, wantCreate) {
+		t.Errorf("CreateArgs() = %q, want %q", got, wantCreate)
+	}
+	if got := def.ConfigArgs(); got[0] != "config" || !reflect.DeepEqual(got[1:], wantCreate[1:]) {
+		t.Errorf("ConfigArgs() = %q", got)
+	}
+
+	wantFailure := []string{"failure", "bibd", "reset=", "86400", "actions=", "restart/10000/restart/10000/restart/10000"}
+	if got := def.FailureArgs(); !reflect.DeepEqual(got, wantFailure) {
+		t.Errorf("FailureArgs() = %q, want %q", got, wantFailure)
+	}
+	if got := def.FailureFlagArgs(); !reflect.DeepEqual(got, []string{"failureflag", "bibd", "1"}) {
+		t.Errorf("FailureFlagArgs() = %q", got)
+	}
+}
+
+func TestServiceInstaller_WindowsServiceDefinition_NoRestart(t *testing.T) {
+	installer := testWindowsInstaller()
+	installer.Config.RestartPolicy = "never"
+	installer.Config.WorkingDirectory = ""
+
+	def := installer.WindowsServiceDefinition()
+
+	if strings.Contains(def.BinaryPath, "--workdir") {
+		t.Errorf("BinaryPath should omit --workdir: %s", def.BinaryPath)
+	}
+	if got := def.FailureArgs(); got[len(got)-1] != "" {
+		t.Errorf("expected recovery actions to be cleared, got %q", got)
+	}
+	if got := def.FailureFlagArgs(); got[2] != "0" {
+		t.Errorf("FailureFlagArgs() = %q", got)
+	}
+}
+
+// fakeSCM records sc.exe invocations and emulates the service control
+// manager's state
+type fakeSCM struct {
+	calls     [][]string
+	installed bool
+	running   bool
+	binPath   string
+	failure   string
+}
+
+func (f *fakeSCM) run(ctx context.Context, name string, args ...string) (string, int, error) {
+	f.calls = append(f.calls, args)
+
+	switch args[0] {
+	case "create":
+		if f.installed {
+			return "[SC] CreateService FAILED 1073:\n\nThe specified service already exists.", scErrServiceExists, nil
+		}
+		f.installed, f.binPath = true, args[3]
+		return "[SC] CreateService SUCCESS", 0, nil
+	case "config":
+		f.binPath = args[3]
+		return "[SC] ChangeServiceConfig SUCCESS", 0, nil
+	case "description", "failureflag":
+	case "failure":
+		f.failure = args[5]
+	case "start":
+		if f.running {
+			return "[SC] StartService FAILED 1056:\n\nAn instance of the service is already running.", scErrServiceAlreadyRuns, nil
+		}
+		f.running = true
+	case "stop":
+		if !f.installed {
+			return "[SC] OpenService FAILED 1060:\n\nThe specified service does not exist as an installed service.", scErrServiceNotFound, nil
+		}
+		if !f.running {
+			return "[SC] ControlService FAILED 1062:\n\nThe service has not been started.", scErrServiceNotActive, nil
+		}
+		f.running = false
+	case "delete":
+		f.installed = false
+		return "[SC] DeleteService SUCCESS", 0, nil
+	case "query":
+		if !f.installed {
+			return "[SC] EnumQueryServicesStatus:OpenService FAILED 1060:", scErrServiceNotFound, nil
+		}
+		state := "1  STOPPED"
+		if f.running {
+			state = "4  RUNNING"
+		}
+		return "SERVICE_NAME: bibd\n        TYPE               : 10  WIN32_OWN_PROCESS\n        STATE              : " + state + "\n", 0, nil
+	}
+	if !f.installed {
+		return "[SC] OpenService FAILED 1060:", scErrServiceNotFound, nil
+	}
+	return "[SC] SUCCESS", 0, nil
+}
+
+func newFakeWindowsService() (*WindowsService, *fakeSCM) {
+	scm := &fakeSCM{}
+	w := testWindowsInstaller().NewWindowsService()
+	w.run = scm.run
+	return w, scm
+}
+
+func TestWindowsService_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	w, scm := newFakeWindowsService()
+
+	if _, err := w.Status(ctx); !errors.Is(err, ErrServiceNotInstalled) {
+		t.Fatalf("Status() before install: expected ErrServiceNotInstalled, got %v", err)
+	}
+
+	if err := w.Install(ctx); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if scm.binPath != w.Definition.BinaryPath {
+		t.Errorf("installed binPath = %s", scm.binPath)
+	}
+	if scm.failure != "restart/10000/restart/10000/restart/10000" {
+		t.Errorf("recovery actions = %q", scm.failure)
+	}
+
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := w.Start(ctx); err != nil {
+		t.Errorf("Start() of a running service should succeed: %v", err)
+	}
+	if state, err := w.Status(ctx); err != nil || state != "RUNNING" {
+		t.Errorf("Status() = %q, %v; want RUNNING", state, err)
+	}
+
+	if err := w.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if err := w.Stop(ctx); err != nil {
+		t.Errorf("Stop() of a stopped service should succeed: %v", err)
+	}
+	if state, _ := w.Status(ctx); state != "STOPPED" {
+		t.Errorf("Status() = %q, want STOPPED", state)
+	}
+
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := w.Uninstall(ctx); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	if scm.installed || scm.running {
+		t.Error("expected the service to be stopped and removed")
+	}
+
+	if err := w.Uninstall(ctx); !errors.Is(err, ErrServiceNotInstalled) {
+		t.Errorf("Uninstall() of a missing service: expected ErrServiceNotInstalled, got %v", err)
+	}
+}
+
+func TestWindowsService_InstallReconfigures(t *testing.T) {
+	ctx := context.Background()
+	w, scm := newFakeWindowsService()
+	scm.installed = true
+	scm.binPath = `"C:\old\bibd.exe"`
+
+	if err := w.Install(ctx); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if scm.binPath != w.Definition.BinaryPath {
+		t.Errorf("expected the existing service to be reconfigured, binPath = %s", scm.binPath)
+	}
+
+	var verbs []string
+	for _, c := range scm.calls {
+		verbs = append(verbs, c[0])
+	}
+	want := []string{"create", "config", "description", "failure", "failureflag"}
+	if !reflect.DeepEqual(verbs, want) {
+		t.Errorf("sc.exe calls = %v, want %v", verbs, want)
+	}
+}
+
+func TestWindowsService_AccessDenied(t *testing.T) {
+	w, _ := newFakeWindowsService()
+	w.run = func(ctx context.Context, name string, args ...string) (string, int, error) {
+		return "[SC] OpenSCManager FAILED 5:\n\nAccess is denied.", scErrAccessDenied, nil
+	}
+
+	err := w.Install(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "run as Administrator") {
+		t.Errorf("expected an Administrator hint, got %v", err)
+	}
+}
+
+func TestParseSCState(t *testing.T) {
+	out := "\r\nSERVICE_NAME: bibd\r\n        TYPE               : 10  WIN32_OWN_PROCESS\r\n        STATE              : 2  START_PENDING\r\n"
+	if got := parseSCState(out); got != "START_PENDING" {
+		t.Errorf("parseSCState() = %q, want START_PENDING", got)
+	}
+	if got := parseSCState("garbage"); got != "UNKNOWN" {
+		t.Errorf("parseSCState() = %q, want UNKNOWN", got)
+	}
+}
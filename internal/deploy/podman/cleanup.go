@@ -0,0 +1,83 @@
+package podman
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"bib/internal/deploy"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PlanCleanup plans the removal of the pod or compose project's
+// containers, networks and volumes in scope.
+func (d *Deployer) PlanCleanup(_ context.Context, scope deploy.CleanupScope) ([]deploy.CleanupStep, error) {
+	path, err := d.deployedFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Config.PodConfig.DeployStyle != "pod" {
+		project, err := deploy.ParseComposeProject(d.Config.OutputDir, filepath.Base(path))
+		if err != nil {
+			return nil, err
+		}
+		return project.CleanupSteps("podman", scope), nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pod, volumes, err := podVolumes(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []deploy.CleanupStep
+	if scope.Workloads {
+		steps = append(steps, deploy.CleanupStep{Kind: "pod", Name: pod, Action: "remove",
+			Command: []string{"podman", "pod", "rm", "-f", pod}})
+	}
+	for _, v := range volumes {
+		if deploy.IsDatabaseVolume(v) && !scope.Data || !deploy.IsDatabaseVolume(v) && !scope.Volumes {
+			continue
+		}
+		steps = append(steps, deploy.CleanupStep{Kind: "volume", Name: v, Action: "remove",
+			Command: []string{"podman", "volume", "rm", v}})
+	}
+	return steps, nil
+}
+
+// podVolumes returns the pod name and the volumes backing its persistent
+// volume claims, which podman kube play creates under the claim names.
+func podVolumes(content []byte) (string, []string, error) {
+	var pod struct {
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+		Spec struct {
+			Volumes []struct {
+				PersistentVolumeClaim *struct {
+					ClaimName string `yaml:"claimName"`
+				} `yaml:"persistentVolumeClaim"`
+			} `yaml:"volumes"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(content, &pod); err != nil {
+		return "", nil, fmt.Errorf("failed to parse pod.yaml: %w", err)
+	}
+	if pod.Metadata.Name == "" {
+		return "", nil, fmt.Errorf("pod.yaml names no pod")
+	}
+
+	var volumes []string
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			volumes = append(volumes, v.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	return pod.Metadata.Name, volumes, nil
+}
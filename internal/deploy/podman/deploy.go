@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"bib/internal/deploy"
 )
 
 // DeployConfig contains configuration for Podman deployment
@@ -127,8 +129,11 @@ func (d *Deployer) Deploy(ctx context.Context) (*DeployResult, error) {
 
 	d.log(result, fmt.Sprintf("   ✓ Podman %s", d.PodmanInfo.Version))
 	if d.PodmanInfo.Rootless {
-		d.log(result, fmt.Sprintf("   ✓ Rootless mode (UID %d)", d.PodmanInfo.RootlessUID))
+		d.log(result, fmt.Sprintf("   ✓ Rootless mode (UID %d, %s networking)", d.PodmanInfo.RootlessUID, d.PodmanInfo.RootlessNetwork))
 		d.Config.PodConfig.Rootless = true
+		if d.PodmanInfo.RootlessNetwork != "" {
+			d.Config.PodConfig.RootlessNetwork = d.PodmanInfo.RootlessNetwork
+		}
 	} else {
 		d.log(result, "   ✓ Rootful mode")
 		d.Config.PodConfig.Rootless = false
@@ -188,10 +193,9 @@ func (d *Deployer) Deploy(ctx context.Context) (*DeployResult, error) {
 	// Step 6: Pull images (if enabled)
 	if d.Config.PullImages {
 		d.log(result, "📥 Pulling images...")
-		if err := d.pullImages(ctx); err != nil {
-			d.log(result, fmt.Sprintf("   ⚠️ Failed to pull images: %v (continuing anyway)", err))
-		} else {
-			d.log(result, "   ✓ Images pulled")
+		if err := d.pullImages(ctx, result); err != nil {
+			result.Error = fmt.Sprintf("Failed to pull images: %v", err)
+			return result, err
 		}
 	}
 
@@ -252,27 +256,28 @@ func (d *Deployer) generateIdentityKey(path string) error {
 	return os.WriteFile(path, []byte(placeholder), 0600)
 }
 
-// pullImages pulls the required container images
-func (d *Deployer) pullImages(ctx context.Context) error {
-	images := []string{
-		fmt.Sprintf("%s:%s", d.Config.PodConfig.BibdImage, d.Config.PodConfig.BibdTag),
-	}
-
+// pullImages pulls the required images that are not present locally,
+// showing the pull output. It fails before anything is started if the
+// registry is unreachable.
+func (d *Deployer) pullImages(ctx context.Context, result *DeployResult) error {
+	images := []string{d.Config.PodConfig.BibdImageRef()}
 	if d.Config.PodConfig.StorageBackend == "postgres" {
-		images = append(images, fmt.Sprintf("%s:%s",
-			d.Config.PodConfig.PostgresImage, d.Config.PodConfig.PostgresTag))
+		images = append(images, d.Config.PodConfig.PostgresImageRef())
 	}
 
 	for _, image := range images {
-		cmd := exec.CommandContext(ctx, "podman", "pull", image)
-		var stderr bytes.Buffer
-		cmd.Stderr = &stderr
-
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("failed to pull %s: %v: %s", image, err, stderr.String())
+		pulled, err := deploy.PullImage(ctx, "podman", image, func(line string) {
+			d.log(result, "      "+line)
+		})
+		if err != nil {
+			return err
+		}
+		if pulled {
+			d.log(result, fmt.Sprintf("   ✓ Pulled %s", image))
+		} else {
+			d.log(result, fmt.Sprintf("   ✓ %s already present", image))
 		}
 	}
-
 	return nil
 }
 
@@ -288,7 +293,7 @@ func (d *Deployer) startContainers(ctx context.Context) error {
 func (d *Deployer) startPod(ctx context.Context) error {
 	podYamlPath := filepath.Join(d.Config.OutputDir, "pod.yaml")
 
-	cmd := exec.CommandContext(ctx, "podman", "kube", "play", podYamlPath)
+	cmd := exec.CommandContext(ctx, "podman", d.Generator.KubePlayArgs(podYamlPath)...)
 	cmd.Dir = d.Config.OutputDir
 
 	var stderr bytes.Buffer
@@ -33,6 +33,10 @@ type PodmanInfo struct {
 	// SocketPath is the path to the Podman socket
 	SocketPath string
 
+	// RootlessNetwork is the user-mode network stack used for rootless
+	// containers ("slirp4netns" or "pasta"); empty in rootful mode
+	RootlessNetwork string
+
 	// MachineRunning indicates if Podman machine is running (macOS/Windows)
 	MachineRunning bool
 
@@ -103,11 +107,12 @@ func (d *Detector) Detect(ctx context.Context) *PodmanInfo {
 	}
 
 	// Check if rootless
-	info.Rootless = d.isRootless()
+	info.Rootless = d.isRootless(ctx)
 	if info.Rootless {
 		if currentUser, err := user.Current(); err == nil {
 			fmt.Sscanf(currentUser.Uid, "%d", &info.RootlessUID)
 		}
+		info.RootlessNetwork = d.rootlessNetwork(ctx)
 	}
 
 	// Check for Podman machine (macOS/Windows)
@@ -116,7 +121,7 @@ func (d *Detector) Detect(ctx context.Context) *PodmanInfo {
 	}
 
 	// Get socket path
-	info.SocketPath = d.getSocketPath()
+	info.SocketPath = d.getSocketPath(info.Rootless, info.RootlessUID)
 
 	// Check for podman-compose
 	d.detectCompose(ctx, info)
@@ -131,41 +136,61 @@ func (d *Detector) Detect(ctx context.Context) *PodmanInfo {
 }
 
 // isRootless checks if running in rootless mode
-func (d *Detector) isRootless() bool {
-	// Check if running as root
-	if os.Geteuid() == 0 {
-		return false
-	}
-
-	// On Linux, check for rootless indicators
-	if runtime.GOOS == "linux" {
-		// Check XDG_RUNTIME_DIR
-		if xdgRuntime := os.Getenv("XDG_RUNTIME_DIR"); xdgRuntime != "" {
+func (d *Detector) isRootless(ctx context.Context) bool {
+	// Ask podman; this also covers remote clients and podman machine
+	if out, err := d.runCommand(ctx, "podman", "info", "--format", "{{.Host.Security.Rootless}}"); err == nil {
+		switch strings.TrimSpace(out) {
+		case "true":
 			return true
+		case "false":
+			return false
 		}
 	}
 
-	// Default to rootless for non-root users
-	return true
+	// Fall back to the effective UID
+	return os.Geteuid() != 0
+}
+
+// rootlessNetwork returns the network stack podman uses for rootless
+// containers. Podman 5 defaults to pasta; older versions use slirp4netns
+// and do not report it.
+func (d *Detector) rootlessNetwork(ctx context.Context) string {
+	if out, err := d.runCommand(ctx, "podman", "info", "--format", "{{.Host.RootlessNetworkCmd}}"); err == nil {
+		if cmd := strings.TrimSpace(out); cmd != "" && !strings.Contains(cmd, "no value") {
+			return cmd
+		}
+	}
+	return "slirp4netns"
 }
 
 // getSocketPath returns the Podman socket path
-func (d *Detector) getSocketPath() string {
+func (d *Detector) getSocketPath(rootless bool, uid int) string {
 	// Check for explicit socket path
 	if socket := os.Getenv("CONTAINER_HOST"); socket != "" {
 		return socket
 	}
+	return socketPath(runtime.GOOS, rootless, uid, os.Getenv("XDG_RUNTIME_DIR"))
+}
 
-	// Check for rootless socket
-	if d.isRootless() {
-		if xdgRuntime := os.Getenv("XDG_RUNTIME_DIR"); xdgRuntime != "" {
-			return fmt.Sprintf("unix://%s/podman/podman.sock", xdgRuntime)
-		}
+// socketPath computes the default Podman API socket. Rootless podman
+// serves the API from the user's runtime directory, which is
+// $XDG_RUNTIME_DIR or /run/user/<uid> when the variable is not set (e.g.
+// in a su or sudo -u session). On macOS and Windows the socket belongs to
+// the podman machine.
+func socketPath(goos string, rootless bool, uid int, xdgRuntimeDir string) string {
+	if goos == "darwin" || goos == "windows" {
 		if home, err := os.UserHomeDir(); err == nil {
 			return fmt.Sprintf("unix://%s/.local/share/containers/podman/machine/podman.sock", home)
 		}
 	}
 
+	if rootless {
+		if xdgRuntimeDir != "" {
+			return fmt.Sprintf("unix://%s/podman/podman.sock", xdgRuntimeDir)
+		}
+		return fmt.Sprintf("unix:///run/user/%d/podman/podman.sock", uid)
+	}
+
 	// Default root socket
 	return "unix:///run/podman/podman.sock"
 }
@@ -283,7 +308,9 @@ func FormatPodmanInfo(info *PodmanInfo) string {
 		sb.WriteString(fmt.Sprintf("   API Version: %s\n", info.APIVersion))
 	}
 
-	if info.Rootless {
+	if info.Rootless && info.RootlessNetwork != "" {
+		sb.WriteString(fmt.Sprintf("   Mode: Rootless (UID %d, %s networking)\n", info.RootlessUID, info.RootlessNetwork))
+	} else if info.Rootless {
 		sb.WriteString(fmt.Sprintf("   Mode: Rootless (UID %d)\n", info.RootlessUID))
 	} else {
 		sb.WriteString("   Mode: Rootful\n")
@@ -282,13 +282,34 @@ func TestDetector_isRootless(t *testing.T) {
 	detector := NewDetector()
 
 	// Just verify it doesn't panic
-	result := detector.isRootless()
+	result := detector.isRootless(context.Background())
 
 	// On most test systems, we should be rootless
 	// (unless running as root)
 	_ = result
 }
 
+func TestSocketPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		rootless bool
+		xdg      string
+		expected string
+	}{
+		{"rootless with XDG_RUNTIME_DIR", true, "/run/user/1000", "unix:///run/user/1000/podman/podman.sock"},
+		{"rootless without XDG_RUNTIME_DIR", true, "", "unix:///run/user/1000/podman/podman.sock"},
+		{"rootful", false, "/run/user/1000", "unix:///run/podman/podman.sock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := socketPath("linux", tt.rootless, 1000, tt.xdg); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestPodmanInfo_Fields(t *testing.T) {
 	info := PodmanInfo{
 		Available:              true,
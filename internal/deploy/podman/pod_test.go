@@ -175,6 +175,59 @@ func TestPodGenerator_generateCompose_Rootless(t *testing.T) {
 	}
 }
 
+func TestPodGenerator_generateCompose_RootlessPostgres(t *testing.T) {
+	config := DefaultPodConfig()
+	config.DeployStyle = "compose"
+	config.Rootless = true
+	config.StorageBackend = "postgres"
+
+	generator := NewPodGenerator(config)
+	compose, err := generator.generateCompose()
+	if err != nil {
+		t.Fatalf("generateCompose failed: %v", err)
+	}
+
+	checks := []string{
+		"network_mode: slirp4netns",
+		"network_mode: service:bibd",
+		"@127.0.0.1:5432/",
+	}
+	for _, check := range checks {
+		if !strings.Contains(compose, check) {
+			t.Errorf("compose missing %q", check)
+		}
+	}
+	if strings.Contains(compose, "@postgres:5432/") {
+		t.Error("rootless compose should not resolve postgres by service name")
+	}
+}
+
+func TestPodGenerator_Generate_RootlessPrivilegedPorts(t *testing.T) {
+	config := DefaultPodConfig()
+	config.Rootless = true
+	config.P2PPort = 401
+
+	generator := NewPodGenerator(config)
+	if _, err := generator.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if config.PortOffset != 8000 {
+		t.Errorf("expected port offset for privileged P2P port, got %d", config.PortOffset)
+	}
+
+	config = DefaultPodConfig()
+	config.Rootless = false
+	config.APIPort = 443
+
+	generator = NewPodGenerator(config)
+	if _, err := generator.Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if config.PortOffset != 0 {
+		t.Errorf("expected no port offset in rootful mode, got %d", config.PortOffset)
+	}
+}
+
 func TestPodGenerator_generateEnvFile(t *testing.T) {
 	config := DefaultPodConfig()
 	config.StorageBackend = "postgres"
@@ -253,6 +306,19 @@ func TestPodGenerator_generateStartScript(t *testing.T) {
 		if !strings.Contains(script, "#!/bin/bash") {
 			t.Error("script missing shebang")
 		}
+		if !strings.Contains(script, "podman kube play --network slirp4netns pod.yaml") {
+			t.Error("script missing rootless podman kube play command")
+		}
+	})
+
+	t.Run("pod style rootful", func(t *testing.T) {
+		config := DefaultPodConfig()
+		config.DeployStyle = "pod"
+		config.Rootless = false
+
+		generator := NewPodGenerator(config)
+		script := generator.generateStartScript()
+
 		if !strings.Contains(script, "podman kube play pod.yaml") {
 			t.Error("script missing podman kube play command")
 		}
@@ -0,0 +1,164 @@
+package podman
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// deployedFile returns the pod or compose file written by Deploy and sets
+// the deploy style to match it.
+func (d *Deployer) deployedFile() (string, error) {
+	for _, style := range []struct{ name, file string }{
+		{"pod", "pod.yaml"},
+		{"compose", "podman-compose.yaml"},
+	} {
+		path := filepath.Join(d.Config.OutputDir, style.file)
+		if _, err := os.Stat(path); err == nil {
+			d.Config.PodConfig.DeployStyle = style.name
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no pod.yaml or podman-compose.yaml in %s", d.Config.OutputDir)
+}
+
+// CurrentImage returns the bibd image in the deployed pod or compose file
+func (d *Deployer) CurrentImage(_ context.Context) (string, error) {
+	path, err := d.deployedFile()
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return bibdImage(content, d.Config.PodConfig.DeployStyle)
+}
+
+// PullImage pulls image
+func (d *Deployer) PullImage(ctx context.Context, image string) error {
+	cmd := exec.CommandContext(ctx, "podman", "pull", image)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// SetImage rewrites the bibd image and restarts bibd. A pod is replaced as
+// a whole; with compose only the bibd container is recreated.
+func (d *Deployer) SetImage(ctx context.Context, image string) error {
+	path, err := d.deployedFile()
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	current, err := bibdImage(content, d.Config.PodConfig.DeployStyle)
+	if err != nil {
+		return err
+	}
+	updated := bytes.ReplaceAll(content, []byte("image: "+current), []byte("image: "+image))
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return err
+	}
+
+	d.detect(ctx)
+
+	var cmd *exec.Cmd
+	if d.Config.PodConfig.DeployStyle == "pod" {
+		args := d.Generator.KubePlayArgs(path)
+		args = append(args[:2], append([]string{"--replace"}, args[2:]...)...)
+		cmd = exec.CommandContext(ctx, "podman", args...)
+	} else {
+		composeCmd := d.PodmanInfo.GetComposeCommand()
+		args := append(composeCmd[1:], "up", "-d", "--no-deps", "--force-recreate", "bibd")
+		cmd = exec.CommandContext(ctx, composeCmd[0], args...)
+	}
+	cmd.Dir = d.Config.OutputDir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v: %s", err, stderr.String())
+	}
+	return nil
+}
+
+// WaitReady waits for the containers to be running
+func (d *Deployer) WaitReady(ctx context.Context, timeout time.Duration) error {
+	if _, err := d.deployedFile(); err != nil {
+		return err
+	}
+	d.detect(ctx)
+
+	saved := d.Config.WaitTimeout
+	d.Config.WaitTimeout = timeout
+	defer func() { d.Config.WaitTimeout = saved }()
+
+	return d.waitForRunning(ctx)
+}
+
+// detect fills in PodmanInfo and the rootless settings used by kube play
+func (d *Deployer) detect(ctx context.Context) {
+	if d.PodmanInfo != nil {
+		return
+	}
+	d.PodmanInfo = NewDetector().Detect(ctx)
+	d.Config.PodConfig.Rootless = d.PodmanInfo.Rootless
+	if d.PodmanInfo.RootlessNetwork != "" {
+		d.Config.PodConfig.RootlessNetwork = d.PodmanInfo.RootlessNetwork
+	}
+}
+
+// bibdImage returns the image of the bibd container in a pod or compose file
+func bibdImage(content []byte, style string) (string, error) {
+	var image string
+	if style == "pod" {
+		var pod struct {
+			Spec struct {
+				Containers []struct {
+					Name  string `yaml:"name"`
+					Image string `yaml:"image"`
+				} `yaml:"containers"`
+			} `yaml:"spec"`
+		}
+		if err := yaml.Unmarshal(content, &pod); err != nil {
+			return "", fmt.Errorf("failed to parse pod file: %w", err)
+		}
+		for _, c := range pod.Spec.Containers {
+			if c.Name == "bibd" {
+				image = c.Image
+			}
+		}
+	} else {
+		var compose struct {
+			Services map[string]struct {
+				Image string `yaml:"image"`
+			} `yaml:"services"`
+		}
+		if err := yaml.Unmarshal(content, &compose); err != nil {
+			return "", fmt.Errorf("failed to parse compose file: %w", err)
+		}
+		image = compose.Services["bibd"].Image
+	}
+
+	if strings.TrimSpace(image) == "" {
+		return "", fmt.Errorf("no bibd image in %s file", style)
+	}
+	return image, nil
+}
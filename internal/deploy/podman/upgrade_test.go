@@ -0,0 +1,107 @@
+package podman
+
+import (
+	"context"
+	"testing"
+
+	"bib/internal/deploy"
+)
+
+func TestDeployer_CurrentImage(t *testing.T) {
+	for _, style := range []string{"pod", "compose"} {
+		t.Run(style, func(t *testing.T) {
+			config := DefaultPodConfig()
+			config.DeployStyle = style
+			config.BibdTag = "0.1.0"
+			config.StorageBackend = "postgres"
+			config.PostgresPassword = "testpassword"
+
+			files, err := NewPodGenerator(config).Generate()
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+			dir := t.TempDir()
+			if err := files.WriteToDir(dir); err != nil {
+				t.Fatalf("WriteToDir failed: %v", err)
+			}
+
+			deployCfg := DefaultDeployConfig()
+			deployCfg.OutputDir = dir
+			deployCfg.PodConfig.DeployStyle = ""
+			deployer := NewDeployer(deployCfg)
+
+			image, err := deployer.CurrentImage(context.Background())
+			if err != nil {
+				t.Fatalf("CurrentImage failed: %v", err)
+			}
+			if want := config.BibdImage + ":0.1.0"; image != want {
+				t.Errorf("expected %s, got %s", want, image)
+			}
+			if deployer.Config.PodConfig.DeployStyle != style {
+				t.Errorf("expected deploy style %s, got %s", style, deployer.Config.PodConfig.DeployStyle)
+			}
+		})
+	}
+}
+
+func TestDeployer_CurrentImage_NoDeployment(t *testing.T) {
+	deployCfg := DefaultDeployConfig()
+	deployCfg.OutputDir = t.TempDir()
+
+	if _, err := NewDeployer(deployCfg).CurrentImage(context.Background()); err == nil {
+		t.Error("expected error without pod.yaml or podman-compose.yaml")
+	}
+}
+
+func TestBibdImage_Pod(t *testing.T) {
+	content := []byte(`apiVersion: v1
+kind: Pod
+spec:
+  containers:
+    - name: postgres
+      image: docker.io/library/postgres:16-alpine
+    - name: bibd
+      image: ghcr.io/bib-project/bibd:0.1.0
+`)
+	image, err := bibdImage(content, "pod")
+	if err != nil || image != "ghcr.io/bib-project/bibd:0.1.0" {
+		t.Errorf("unexpected image %q (%v)", image, err)
+	}
+	if _, err := bibdImage([]byte("spec: {}"), "pod"); err == nil {
+		t.Error("expected error without a bibd container")
+	}
+}
+
+func TestDeployer_PlanCleanup(t *testing.T) {
+	config := DefaultPodConfig()
+	config.StorageBackend = "postgres"
+	config.PostgresPassword = "testpassword"
+
+	files, err := NewPodGenerator(config).Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	dir := t.TempDir()
+	if err := files.WriteToDir(dir); err != nil {
+		t.Fatalf("WriteToDir failed: %v", err)
+	}
+
+	deployCfg := DefaultDeployConfig()
+	deployCfg.OutputDir = dir
+	deployCfg.PodConfig.DeployStyle = ""
+
+	steps, err := NewDeployer(deployCfg).PlanCleanup(context.Background(), deploy.CleanupScope{Workloads: true, Volumes: true})
+	if err != nil {
+		t.Fatalf("PlanCleanup failed: %v", err)
+	}
+	var names []string
+	for _, s := range steps {
+		names = append(names, s.Kind+":"+s.Name)
+		if deploy.IsDatabaseVolume(s.Name) {
+			t.Errorf("database volume %s planned without Data", s.Name)
+		}
+	}
+	if len(steps) == 0 || steps[0].Kind != "pod" || steps[0].Name != config.PodName {
+		t.Errorf("expected pod %s to be removed first, got %v", config.PodName, names)
+	}
+}
@@ -0,0 +1,173 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Upgrader replaces the bibd image of a running deployment. It is
+// implemented by the docker, podman and kubernetes deployers.
+type Upgrader interface {
+	// CurrentImage returns the image bibd is deployed with
+	CurrentImage(ctx context.Context) (string, error)
+
+	// PullImage fetches image ahead of the restart, so a missing tag fails
+	// before anything is stopped
+	PullImage(ctx context.Context, image string) error
+
+	// SetImage switches bibd to image and restarts it
+	SetImage(ctx context.Context, image string) error
+
+	// WaitReady waits for the restarted bibd to pass its health checks
+	WaitReady(ctx context.Context, timeout time.Duration) error
+}
+
+// UpgradeOptions configures Upgrade
+type UpgradeOptions struct {
+	// Version is the version to upgrade to; it is used as the image tag
+	Version string
+
+	// Image overrides the image repository (default: the current one)
+	Image string
+
+	// Timeout bounds the wait for health and version checks
+	Timeout time.Duration
+
+	// VersionCheck returns the version reported by the running bibd.
+	// If nil, the new version is not verified.
+	VersionCheck func(ctx context.Context) (string, error)
+
+	// DryRun resolves the images without changing the deployment
+	DryRun bool
+
+	// Log receives progress messages
+	Log func(msg string)
+}
+
+// UpgradeResult describes an upgrade attempt
+type UpgradeResult struct {
+	// PreviousImage is the image bibd ran before the upgrade
+	PreviousImage string
+
+	// Image is the image bibd was upgraded to
+	Image string
+
+	// Version is the version reported by bibd after the upgrade
+	Version string
+
+	// RolledBack indicates the upgrade failed and PreviousImage was restored
+	RolledBack bool
+}
+
+// Upgrade moves a deployment to a new bibd version. The new image is pulled
+// first; after the restart bibd must become healthy and report the requested
+// version within the timeout, otherwise the previous image is restored.
+func Upgrade(ctx context.Context, u Upgrader, opts UpgradeOptions) (*UpgradeResult, error) {
+	if opts.Version == "" {
+		return nil, fmt.Errorf("version is required")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Minute
+	}
+	log := opts.Log
+	if log == nil {
+		log = func(string) {}
+	}
+
+	current, err := u.CurrentImage(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine current image: %w", err)
+	}
+
+	repo := opts.Image
+	if repo == "" {
+		repo = ImageRepository(current)
+	}
+	result := &UpgradeResult{
+		PreviousImage: current,
+		Image:         repo + ":" + opts.Version,
+	}
+	if current == result.Image {
+		return result, fmt.Errorf("already running %s", current)
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	log(fmt.Sprintf("Pulling %s", result.Image))
+	if err := u.PullImage(ctx, result.Image); err != nil {
+		return result, fmt.Errorf("failed to pull %s: %w", result.Image, err)
+	}
+
+	log(fmt.Sprintf("Restarting bibd with %s", result.Image))
+	err = u.SetImage(ctx, result.Image)
+	if err == nil {
+		log("Waiting for bibd to become healthy")
+		err = u.WaitReady(ctx, opts.Timeout)
+	}
+	if err == nil && opts.VersionCheck != nil {
+		log("Verifying version")
+		result.Version, err = waitForVersion(ctx, opts.VersionCheck, opts.Version, opts.Timeout)
+	}
+	if err == nil {
+		return result, nil
+	}
+
+	log(fmt.Sprintf("Upgrade failed (%v); rolling back to %s", err, current))
+	if rbErr := rollback(ctx, u, current, opts.Timeout); rbErr != nil {
+		return result, fmt.Errorf("upgrade failed: %w; rollback to %s also failed: %v", err, current, rbErr)
+	}
+	result.RolledBack = true
+	return result, fmt.Errorf("upgrade failed, rolled back to %s: %w", current, err)
+}
+
+// rollback restores image and waits for bibd to come back
+func rollback(ctx context.Context, u Upgrader, image string, timeout time.Duration) error {
+	if err := u.SetImage(ctx, image); err != nil {
+		return err
+	}
+	return u.WaitReady(ctx, timeout)
+}
+
+// waitForVersion polls check until it reports want. The daemon may refuse
+// connections for a while after the restart, so errors are retried.
+func waitForVersion(ctx context.Context, check func(context.Context) (string, error), want string, timeout time.Duration) (string, error) {
+	want = strings.TrimPrefix(want, "v")
+	deadline := time.Now().Add(timeout)
+
+	var got string
+	var lastErr error
+	for {
+		got, lastErr = check(ctx)
+		if lastErr == nil && strings.TrimPrefix(got, "v") == want {
+			return got, nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return got, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+
+	if lastErr != nil {
+		return got, fmt.Errorf("version check failed: %w", lastErr)
+	}
+	return got, fmt.Errorf("bibd reports version %s, expected %s", got, want)
+}
+
+// ImageRepository strips the tag or digest from an image reference. A colon
+// in the registry host (registry:5000/bibd) is not a tag separator.
+func ImageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
@@ -0,0 +1,146 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeUpgrader records image changes and fails on demand
+type fakeUpgrader struct {
+	image     string
+	pulled    []string
+	set       []string
+	pullErr   error
+	readyErrs map[string]error
+}
+
+func (f *fakeUpgrader) CurrentImage(context.Context) (string, error) { return f.image, nil }
+
+func (f *fakeUpgrader) PullImage(_ context.Context, image string) error {
+	f.pulled = append(f.pulled, image)
+	return f.pullErr
+}
+
+func (f *fakeUpgrader) SetImage(_ context.Context, image string) error {
+	f.set = append(f.set, image)
+	f.image = image
+	return nil
+}
+
+func (f *fakeUpgrader) WaitReady(context.Context, time.Duration) error {
+	return f.readyErrs[f.image]
+}
+
+func TestUpgrade_Success(t *testing.T) {
+	u := &fakeUpgrader{image: "ghcr.io/bib-project/bibd:0.1.0"}
+
+	result, err := Upgrade(context.Background(), u, UpgradeOptions{
+		Version:      "0.2.0",
+		VersionCheck: func(context.Context) (string, error) { return "v0.2.0", nil },
+	})
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+	if result.Image != "ghcr.io/bib-project/bibd:0.2.0" || result.Version != "v0.2.0" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if len(u.pulled) != 1 || len(u.set) != 1 || u.image != result.Image {
+		t.Errorf("expected one pull and one restart, got pulled=%v set=%v", u.pulled, u.set)
+	}
+}
+
+func TestUpgrade_RollsBackOnWrongVersion(t *testing.T) {
+	u := &fakeUpgrader{image: "bibd:0.1.0"}
+
+	result, err := Upgrade(context.Background(), u, UpgradeOptions{
+		Version:      "0.2.0",
+		Timeout:      time.Millisecond,
+		VersionCheck: func(context.Context) (string, error) { return "0.1.0", nil },
+	})
+	if err == nil || !strings.Contains(err.Error(), "expected 0.2.0") {
+		t.Fatalf("expected version mismatch error, got %v", err)
+	}
+	if !result.RolledBack || u.image != "bibd:0.1.0" {
+		t.Errorf("expected rollback to bibd:0.1.0, got %+v (image %s)", result, u.image)
+	}
+}
+
+func TestUpgrade_RollsBackWhenUnhealthy(t *testing.T) {
+	u := &fakeUpgrader{
+		image:     "bibd:0.1.0",
+		readyErrs: map[string]error{"bibd:0.2.0": errors.New("crash loop")},
+	}
+
+	result, err := Upgrade(context.Background(), u, UpgradeOptions{Version: "0.2.0"})
+	if err == nil || !result.RolledBack {
+		t.Fatalf("expected rollback, got %v %+v", err, result)
+	}
+	if got := strings.Join(u.set, ","); got != "bibd:0.2.0,bibd:0.1.0" {
+		t.Errorf("unexpected image changes %s", got)
+	}
+}
+
+func TestUpgrade_FailedRollback(t *testing.T) {
+	u := &fakeUpgrader{
+		image: "bibd:0.1.0",
+		readyErrs: map[string]error{
+			"bibd:0.2.0": errors.New("crash loop"),
+			"bibd:0.1.0": errors.New("volume gone"),
+		},
+	}
+
+	result, err := Upgrade(context.Background(), u, UpgradeOptions{Version: "0.2.0"})
+	if err == nil || !strings.Contains(err.Error(), "rollback") || result.RolledBack {
+		t.Errorf("expected failed rollback to be reported, got %v %+v", err, result)
+	}
+}
+
+func TestUpgrade_PullFailureLeavesDeployment(t *testing.T) {
+	u := &fakeUpgrader{image: "bibd:0.1.0", pullErr: errors.New("manifest unknown")}
+
+	if _, err := Upgrade(context.Background(), u, UpgradeOptions{Version: "0.2.0"}); err == nil {
+		t.Fatal("expected pull error")
+	}
+	if len(u.set) != 0 {
+		t.Errorf("expected no restart after a failed pull, got %v", u.set)
+	}
+}
+
+func TestUpgrade_DryRunAndOverrides(t *testing.T) {
+	u := &fakeUpgrader{image: "bibd:0.1.0"}
+
+	result, err := Upgrade(context.Background(), u, UpgradeOptions{
+		Version: "0.2.0",
+		Image:   "registry.local:5000/bibd",
+		DryRun:  true,
+	})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if result.Image != "registry.local:5000/bibd:0.2.0" || len(u.pulled)+len(u.set) != 0 {
+		t.Errorf("dry run changed the deployment or resolved %s", result.Image)
+	}
+
+	if _, err := Upgrade(context.Background(), u, UpgradeOptions{Version: "0.1.0"}); err == nil {
+		t.Error("expected an error when already running the version")
+	}
+}
+
+func TestImageRepository(t *testing.T) {
+	tests := map[string]string{
+		"bibd":                              "bibd",
+		"bibd:latest":                       "bibd",
+		"ghcr.io/bib-project/bibd:0.1.0":    "ghcr.io/bib-project/bibd",
+		"registry.local:5000/bibd":          "registry.local:5000/bibd",
+		"registry.local:5000/bibd:1.0":      "registry.local:5000/bibd",
+		"ghcr.io/bib-project/bibd@sha256:0": "ghcr.io/bib-project/bibd",
+	}
+	for image, want := range tests {
+		if got := ImageRepository(image); got != want {
+			t.Errorf("ImageRepository(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
@@ -245,6 +245,8 @@ func (t *AuthTester) authenticate(ctx context.Context, client services.AuthServi
 	verifyResp, err := client.VerifyChallenge(ctx, &services.VerifyChallengeRequest{
 		ChallengeId: challengeResp.GetChallengeId(),
 		Signature:   signature.Blob,
+		Name:        t.Name,
+		Email:       t.Email,
 	})
 	if err != nil {
 		return "", nil, fmt.Errorf("challenge verification failed: %w", err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -308,12 +309,22 @@ func (t *ConnectionTester) getNodeInfo(ctx context.Context, conn *grpc.ClientCon
 
 // classifyError classifies a connection error into a status
 func (t *ConnectionTester) classifyError(err error) ConnectionStatus {
+	return ClassifyConnectionError(err)
+}
+
+// ClassifyConnectionError classifies an error connecting to a node into a
+// status, for callers dialing nodes themselves
+func ClassifyConnectionError(err error) ConnectionStatus {
 	if err == nil {
 		return StatusConnected
 	}
 
 	errStr := err.Error()
 
+	if errors.Is(err, context.DeadlineExceeded) {
+		return StatusTimeout
+	}
+
 	// Check for common error patterns
 	if netErr, ok := err.(net.Error); ok {
 		if netErr.Timeout() {
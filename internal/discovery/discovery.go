@@ -5,9 +5,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+
+	"bib/internal/config"
 )
 
 // DiscoveryMethod indicates how a node was discovered
@@ -19,6 +22,7 @@ const (
 	MethodP2P    DiscoveryMethod = "p2p"    // P2P DHT discovery
 	MethodManual DiscoveryMethod = "manual" // Manually entered
 	MethodPublic DiscoveryMethod = "public" // Public network (bib.dev)
+	MethodDNS    DiscoveryMethod = "dns"    // DNS SRV lookup
 )
 
 // DiscoveredNode represents a discovered bibd node
@@ -35,6 +39,11 @@ type DiscoveredNode struct {
 	// NodeInfo contains additional node information if available
 	NodeInfo *NodeInfo
 
+	// Priority and Weight rank nodes without a measured latency, as in DNS
+	// SRV records: lower priority first, then higher weight
+	Priority uint16
+	Weight   uint16
+
 	// DiscoveredAt is when the node was discovered
 	DiscoveredAt time.Time
 }
@@ -172,6 +181,10 @@ type DiscoveryOptions struct {
 
 	// LatencyTimeout is the timeout for latency measurement
 	LatencyTimeout time.Duration
+
+	// SRVRecord is a DNS SRV record to resolve to nodes, such as
+	// _bibd._tcp.example.com (empty to disable)
+	SRVRecord string
 }
 
 // DefaultOptions returns sensible default discovery options
@@ -188,25 +201,71 @@ func DefaultOptions() DiscoveryOptions {
 	}
 }
 
-// Discoverer discovers bibd nodes using multiple methods
+// Discoverer discovers bibd nodes by running a set of providers and
+// merging their results
 type Discoverer struct {
-	opts DiscoveryOptions
-	mu   sync.Mutex
+	opts      DiscoveryOptions
+	providers []Provider
+	mu        sync.Mutex
 }
 
-// New creates a new Discoverer with the given options
+// New creates a new Discoverer with the built-in providers enabled by opts
 func New(opts DiscoveryOptions) *Discoverer {
-	return &Discoverer{
+	d := &Discoverer{
 		opts: opts,
 	}
+
+	d.providers = append(d.providers, d.LocalhostProvider())
+	if opts.EnableMDNS {
+		d.providers = append(d.providers, d.MDNSProvider())
+	}
+	if opts.EnableP2P {
+		d.providers = append(d.providers, d.P2PProvider())
+	}
+	if opts.SRVRecord != "" {
+		d.providers = append(d.providers, NewSRVRecordProvider(opts.SRVRecord))
+	}
+
+	return d
+}
+
+// NewWithDefaults creates a new Discoverer with default options, adding the
+// providers configured in conn if given
+func NewWithDefaults(conn ...config.ConnectionConfig) *Discoverer {
+	opts := DefaultOptions()
+	for _, c := range conn {
+		if c.SRVRecord != "" {
+			opts.SRVRecord = c.SRVRecord
+		}
+	}
+	return New(opts)
+}
+
+// NewWithProviders creates a Discoverer running only the given providers.
+// The built-in providers can be included with LocalhostProvider,
+// MDNSProvider and P2PProvider of a Discoverer created with New.
+func NewWithProviders(opts DiscoveryOptions, providers ...Provider) *Discoverer {
+	return &Discoverer{
+		opts:      opts,
+		providers: providers,
+	}
 }
 
-// NewWithDefaults creates a new Discoverer with default options
-func NewWithDefaults() *Discoverer {
-	return New(DefaultOptions())
+// AddProvider adds a provider to run on the next discovery
+func (d *Discoverer) AddProvider(p Provider) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.providers = append(d.providers, p)
 }
 
-// Discover runs all enabled discovery methods and returns combined results
+// Providers returns the providers run by the discoverer
+func (d *Discoverer) Providers() []Provider {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Provider(nil), d.providers...)
+}
+
+// Discover runs all providers in parallel and returns combined results
 func (d *Discoverer) Discover(ctx context.Context) *DiscoveryResult {
 	start := time.Now()
 
@@ -222,49 +281,26 @@ func (d *Discoverer) Discover(ctx context.Context) *DiscoveryResult {
 		Errors: []error{},
 	}
 
-	// Channel for collecting results from parallel discovery methods
-	nodesChan := make(chan []DiscoveredNode, 3)
-	errsChan := make(chan error, 3)
-	var wg sync.WaitGroup
-
-	// Run localhost discovery
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		nodes, err := d.discoverLocalhost(ctx)
-		if err != nil {
-			errsChan <- fmt.Errorf("localhost discovery: %w", err)
-		}
-		nodesChan <- nodes
-	}()
+	providers := d.Providers()
 
-	// Run mDNS discovery if enabled
-	if d.opts.EnableMDNS {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			nodes, err := d.discoverMDNS(ctx)
-			if err != nil {
-				errsChan <- fmt.Errorf("mDNS discovery: %w", err)
-			}
-			nodesChan <- nodes
-		}()
-	}
+	// Channel for collecting results from parallel providers
+	nodesChan := make(chan []DiscoveredNode, len(providers))
+	errsChan := make(chan error, len(providers))
+	var wg sync.WaitGroup
 
-	// Run P2P discovery if enabled
-	if d.opts.EnableP2P {
+	for _, p := range providers {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			nodes, err := d.discoverP2P(ctx)
+			nodes, err := p.Discover(ctx)
 			if err != nil {
-				errsChan <- fmt.Errorf("P2P discovery: %w", err)
+				errsChan <- fmt.Errorf("%s discovery: %w", p.Name(), err)
 			}
 			nodesChan <- nodes
 		}()
 	}
 
-	// Wait for all discovery methods to complete
+	// Wait for all providers to complete
 	go func() {
 		wg.Wait()
 		close(nodesChan)
@@ -274,16 +310,7 @@ func (d *Discoverer) Discover(ctx context.Context) *DiscoveryResult {
 	// Collect results
 	nodeMap := make(map[string]DiscoveredNode)
 	for nodes := range nodesChan {
-		for _, node := range nodes {
-			// Deduplicate by address, keep the one with lower latency
-			if existing, ok := nodeMap[node.Address]; ok {
-				if node.Latency > 0 && (existing.Latency == 0 || node.Latency < existing.Latency) {
-					nodeMap[node.Address] = node
-				}
-			} else {
-				nodeMap[node.Address] = node
-			}
-		}
+		addNodes(nodeMap, nodes)
 	}
 
 	for err := range errsChan {
@@ -306,6 +333,20 @@ func (d *Discoverer) Discover(ctx context.Context) *DiscoveryResult {
 	return result
 }
 
+// addNodes adds nodes to nodeMap, deduplicating by address and keeping the
+// one with lower latency
+func addNodes(nodeMap map[string]DiscoveredNode, nodes []DiscoveredNode) {
+	for _, node := range nodes {
+		if existing, ok := nodeMap[node.Address]; ok {
+			if node.Latency > 0 && (existing.Latency == 0 || node.Latency < existing.Latency) {
+				nodeMap[node.Address] = node
+			}
+		} else {
+			nodeMap[node.Address] = node
+		}
+	}
+}
+
 // DiscoverLocalhost discovers bibd nodes running on localhost
 func (d *Discoverer) DiscoverLocalhost(ctx context.Context) ([]DiscoveredNode, error) {
 	return d.discoverLocalhost(ctx)
@@ -323,17 +364,20 @@ func (d *Discoverer) MeasureLatency(ctx context.Context, address string) (time.D
 
 // sortNodesByLatency sorts nodes by latency (lowest first)
 func sortNodesByLatency(nodes []DiscoveredNode) {
-	// Simple bubble sort (small list expected)
-	for i := 0; i < len(nodes)-1; i++ {
-		for j := 0; j < len(nodes)-i-1; j++ {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		switch {
+		case a.Latency > 0 && b.Latency > 0:
+			return a.Latency < b.Latency
+		case a.Latency > 0 || b.Latency > 0:
 			// Nodes without latency go last
-			if nodes[j].Latency == 0 && nodes[j+1].Latency > 0 {
-				nodes[j], nodes[j+1] = nodes[j+1], nodes[j]
-			} else if nodes[j].Latency > 0 && nodes[j+1].Latency > 0 && nodes[j].Latency > nodes[j+1].Latency {
-				nodes[j], nodes[j+1] = nodes[j+1], nodes[j]
-			}
+			return a.Latency > 0
+		case a.Priority != b.Priority:
+			return a.Priority < b.Priority
+		default:
+			return a.Weight > b.Weight
 		}
-	}
+	})
 }
 
 // measureLatency measures TCP connection latency to an address
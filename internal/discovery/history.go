@@ -0,0 +1,355 @@
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"bib/internal/config"
+)
+
+// DefaultHistoryLimit is the number of test runs kept per node
+const DefaultHistoryLimit = 50
+
+// TestRun is the outcome of testing a node once. Checks that were not run
+// are left empty.
+type TestRun struct {
+	// TestedAt is when the node was tested
+	TestedAt time.Time `json:"tested_at"`
+
+	// Connection is the connection status
+	Connection ConnectionStatus `json:"connection,omitempty"`
+
+	// Latency is the connection latency
+	Latency time.Duration `json:"latency,omitempty"`
+
+	// Auth is the authentication status
+	Auth AuthStatus `json:"auth,omitempty"`
+
+	// Health is the network health status
+	Health NetworkHealthStatus `json:"health,omitempty"`
+
+	// ClockSkew is the largest clock skew the node reported to a member
+	// of its cluster
+	ClockSkew time.Duration `json:"clock_skew,omitempty"`
+
+	// ClockSkewExceeded reports whether the node found ClockSkew beyond
+	// its threshold
+	ClockSkewExceeded bool `json:"clock_skew_exceeded,omitempty"`
+
+	// Error is the first error reported by a check
+	Error string `json:"error,omitempty"`
+}
+
+// ConnectionFailed reports whether the node could not be connected to
+func (r TestRun) ConnectionFailed() bool {
+	return r.Connection != "" && r.Connection != StatusConnected
+}
+
+// AuthFailed reports whether authentication was attempted and failed
+func (r TestRun) AuthFailed() bool {
+	return r.Auth != "" && r.Auth != AuthStatusSuccess && r.Auth != AuthStatusAutoRegistered
+}
+
+// HealthFailed reports whether the node's network was poor or offline
+func (r TestRun) HealthFailed() bool {
+	return r.Health == NetworkHealthPoor || r.Health == NetworkHealthOffline
+}
+
+// Failed reports whether any check of the run failed
+func (r TestRun) Failed() bool {
+	return r.ConnectionFailed() || r.AuthFailed() || r.HealthFailed()
+}
+
+// NodeHistory is the test runs of one node, oldest first
+type NodeHistory struct {
+	Address string    `json:"address"`
+	Runs    []TestRun `json:"runs"`
+}
+
+// Last returns the latest run, or false if the node was never tested
+func (h *NodeHistory) Last() (TestRun, bool) {
+	if len(h.Runs) == 0 {
+		return TestRun{}, false
+	}
+	return h.Runs[len(h.Runs)-1], true
+}
+
+// FailingSince returns when the current streak of runs where failed(run)
+// holds began, and how many runs it spans. It returns zero if the latest
+// run did not fail.
+func (h *NodeHistory) FailingSince(failed func(TestRun) bool) (time.Time, int) {
+	var since time.Time
+	count := 0
+	for i := len(h.Runs) - 1; i >= 0 && failed(h.Runs[i]); i-- {
+		since = h.Runs[i].TestedAt
+		count++
+	}
+	return since, count
+}
+
+// Warning describes a recent problem with the node worth mentioning before
+// connecting to it, or returns "" if its recent runs look fine.
+func (h *NodeHistory) Warning() string {
+	checks := []struct {
+		name   string
+		failed func(TestRun) bool
+	}{
+		{"connection", TestRun.ConnectionFailed},
+		{"authentication", TestRun.AuthFailed},
+		{"network health", TestRun.HealthFailed},
+	}
+	for _, c := range checks {
+		since, count := h.FailingSince(c.failed)
+		if count >= 2 {
+			return fmt.Sprintf("%s has been failing for %s (%d tests)",
+				c.name, formatSince(since), count)
+		}
+	}
+
+	// Clock skew is not a failure of the test, but it breaks leases and
+	// certificate validity in the node's cluster
+	if last, ok := h.Last(); ok && last.ClockSkewExceeded {
+		return fmt.Sprintf("cluster clock skew of %s", last.ClockSkew.Abs().Round(time.Millisecond))
+	}
+
+	const window = 5
+	if runs := h.recent(window); len(runs) >= 3 {
+		failed := 0
+		for _, r := range runs {
+			if r.Failed() {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Sprintf("flaky: %d of the last %d tests failed", failed, len(runs))
+		}
+	}
+	return ""
+}
+
+// recent returns the last n runs
+func (h *NodeHistory) recent(n int) []TestRun {
+	if n <= 0 || n > len(h.Runs) {
+		return h.Runs
+	}
+	return h.Runs[len(h.Runs)-n:]
+}
+
+// formatSince formats how long ago t was in a coarse unit
+func formatSince(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%d days", int(d/(24*time.Hour)))
+	case d >= 2*time.Hour:
+		return fmt.Sprintf("%d hours", int(d/time.Hour))
+	case d >= 2*time.Minute:
+		return fmt.Sprintf("%d minutes", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%d seconds", max(int(d/time.Second), 1))
+	}
+}
+
+// HistoryStore persists the recent test runs of each node, one JSON file
+// per node, so intermittent problems show up as trends rather than as a
+// single point-in-time result.
+type HistoryStore struct {
+	dir   string
+	limit int
+	mu    sync.Mutex
+}
+
+// NewHistoryStore creates a store in dir keeping the last limit runs per
+// node (DefaultHistoryLimit if limit is 0). The directory is created on
+// the first write.
+func NewHistoryStore(dir string, limit int) *HistoryStore {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+	return &HistoryStore{dir: dir, limit: limit}
+}
+
+// DefaultHistoryDir returns the history directory of the bib CLI
+func DefaultHistoryDir() (string, error) {
+	configDir, err := config.UserConfigDir(config.AppBib)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "connection_history"), nil
+}
+
+// Record appends a run to the history of address, dropping the oldest runs
+// over the limit.
+func (s *HistoryStore) Record(address string, run TestRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, err := s.read(address)
+	if err != nil {
+		return err
+	}
+	if run.TestedAt.IsZero() {
+		run.TestedAt = time.Now()
+	}
+	h.Runs = append(h.Runs, run)
+	if len(h.Runs) > s.limit {
+		h.Runs = h.Runs[len(h.Runs)-s.limit:]
+	}
+	return s.write(h)
+}
+
+// RecordResults records one run per tested node from the results of the
+// connection, auth and health testers. Any of them may be nil.
+func (s *HistoryStore) RecordResults(conns []*ConnectionTestResult, auths []*AuthTestResult, healths []*NetworkHealthResult) error {
+	now := time.Now()
+	runs := make(map[string]*TestRun)
+	run := func(address string) *TestRun {
+		if r, ok := runs[address]; ok {
+			return r
+		}
+		r := &TestRun{TestedAt: now}
+		runs[address] = r
+		return r
+	}
+
+	for _, res := range conns {
+		r := run(res.Address)
+		r.Connection = res.Status
+		r.Latency = res.Latency
+		if r.Error == "" {
+			r.Error = res.Error
+		}
+	}
+	for _, res := range auths {
+		r := run(res.Address)
+		r.Auth = res.Status
+		if r.Error == "" {
+			r.Error = res.Error
+		}
+	}
+	for _, res := range healths {
+		r := run(res.Address)
+		r.Health = res.Status
+		r.ClockSkew = res.ClockSkew
+		r.ClockSkewExceeded = res.ClockSkewExceeded
+		if r.Error == "" {
+			r.Error = res.Error
+		}
+	}
+
+	var errs []error
+	for address, r := range runs {
+		if err := s.Record(address, *r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Get returns the history of address, which is empty if the node was never
+// tested.
+func (s *HistoryStore) Get(address string) (*NodeHistory, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read(address)
+}
+
+// List returns the history of every tested node, sorted by address
+func (s *HistoryStore) List() ([]*NodeHistory, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var histories []*NodeHistory
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			continue // Skip unreadable files
+		}
+		var h NodeHistory
+		if err := json.Unmarshal(data, &h); err != nil {
+			continue // Skip invalid files
+		}
+		histories = append(histories, &h)
+	}
+
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].Address < histories[j].Address
+	})
+	return histories, nil
+}
+
+// Clear removes the history of address
+func (s *HistoryStore) Clear(address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(address)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove history: %w", err)
+	}
+	return nil
+}
+
+// path returns the file holding the history of address
+func (s *HistoryStore) path(address string) string {
+	safe := make([]byte, 0, len(address))
+	for i := 0; i < len(address); i++ {
+		c := address[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '.' {
+			safe = append(safe, c)
+		} else {
+			safe = append(safe, '_')
+		}
+	}
+	return filepath.Join(s.dir, string(safe)+".json")
+}
+
+// read loads the history of address
+func (s *HistoryStore) read(address string) (*NodeHistory, error) {
+	data, err := os.ReadFile(s.path(address))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &NodeHistory{Address: address}, nil
+		}
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var h NodeHistory
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("failed to parse history of %s: %w", address, err)
+	}
+	h.Address = address
+	return &h, nil
+}
+
+// write saves a node's history
+func (s *HistoryStore) write(h *NodeHistory) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+	if err := os.WriteFile(s.path(h.Address), data, 0600); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
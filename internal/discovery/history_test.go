@@ -0,0 +1,140 @@
+package discovery
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryStore_RecordAndTrim(t *testing.T) {
+	store := NewHistoryStore(t.TempDir(), 3)
+
+	for i := 0; i < 5; i++ {
+		if err := store.Record("node1:4000", TestRun{Connection: StatusConnected, Latency: time.Duration(i+1) * time.Millisecond}); err != nil {
+			t.Fatalf("failed to record: %v", err)
+		}
+	}
+
+	h, err := store.Get("node1:4000")
+	if err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	if len(h.Runs) != 3 {
+		t.Fatalf("expected 3 runs kept, got %d", len(h.Runs))
+	}
+	if h.Runs[0].Latency != 3*time.Millisecond {
+		t.Errorf("expected the oldest runs dropped, first latency is %v", h.Runs[0].Latency)
+	}
+	if h.Runs[0].TestedAt.IsZero() {
+		t.Error("expected TestedAt to be set")
+	}
+}
+
+func TestHistoryStore_GetUnknown(t *testing.T) {
+	store := NewHistoryStore(t.TempDir()+"/missing", 0)
+
+	h, err := store.Get("node1:4000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(h.Runs) != 0 || h.Address != "node1:4000" {
+		t.Errorf("expected an empty history, got %+v", h)
+	}
+
+	histories, err := store.List()
+	if err != nil || len(histories) != 0 {
+		t.Errorf("expected no histories, got %v, %v", histories, err)
+	}
+}
+
+func TestHistoryStore_RecordResults(t *testing.T) {
+	store := NewHistoryStore(t.TempDir(), 0)
+
+	err := store.RecordResults(
+		[]*ConnectionTestResult{
+			{Address: "node1:4000", Status: StatusConnected, Latency: time.Millisecond},
+			{Address: "node2:4000", Status: StatusRefused, Error: "connection refused"},
+		},
+		[]*AuthTestResult{{Address: "node1:4000", Status: AuthStatusKeyRejected, Error: "key rejected"}},
+		[]*NetworkHealthResult{{Address: "node1:4000", Status: NetworkHealthGood, ClockSkew: 2 * time.Second, ClockSkewExceeded: true}},
+	)
+	if err != nil {
+		t.Fatalf("failed to record results: %v", err)
+	}
+
+	histories, err := store.List()
+	if err != nil {
+		t.Fatalf("failed to list: %v", err)
+	}
+	if len(histories) != 2 || histories[0].Address != "node1:4000" {
+		t.Fatalf("unexpected histories %+v", histories)
+	}
+
+	run, _ := histories[0].Last()
+	if run.Connection != StatusConnected || run.Auth != AuthStatusKeyRejected || run.Health != NetworkHealthGood {
+		t.Errorf("expected the results merged into one run, got %+v", run)
+	}
+	if run.ClockSkew != 2*time.Second || !run.ClockSkewExceeded {
+		t.Errorf("expected the clock skew recorded, got %+v", run)
+	}
+	if run.Error != "key rejected" || !run.AuthFailed() {
+		t.Errorf("expected a failed auth run, got %+v", run)
+	}
+
+	if err := store.Clear("node2:4000"); err != nil {
+		t.Fatalf("failed to clear: %v", err)
+	}
+	if histories, _ := store.List(); len(histories) != 1 {
+		t.Errorf("expected 1 history after clearing, got %d", len(histories))
+	}
+}
+
+func TestNodeHistory_Warning(t *testing.T) {
+	now := time.Now()
+	ok := TestRun{Connection: StatusConnected, Auth: AuthStatusSuccess}
+	authFailed := TestRun{Connection: StatusConnected, Auth: AuthStatusKeyRejected}
+	refused := TestRun{Connection: StatusRefused}
+
+	at := func(r TestRun, ago time.Duration) TestRun {
+		r.TestedAt = now.Add(-ago)
+		return r
+	}
+
+	tests := []struct {
+		name string
+		runs []TestRun
+		want string
+	}{
+		{"never tested", nil, ""},
+		{"healthy", []TestRun{ok, ok, ok}, ""},
+		{"single failure", []TestRun{ok, authFailed}, ""},
+		{
+			"failing auth for days",
+			[]TestRun{ok, at(authFailed, 72*time.Hour), at(authFailed, time.Hour), at(authFailed, 0)},
+			"authentication has been failing for 3 days (3 tests)",
+		},
+		{"flaky", []TestRun{ok, refused, ok, ok}, "flaky: 1 of the last 4 tests failed"},
+		{
+			"clock skew",
+			[]TestRun{ok, {Connection: StatusConnected, ClockSkew: -2500 * time.Millisecond, ClockSkewExceeded: true}},
+			"cluster clock skew of 2.5s",
+		},
+		{"clock skew within threshold", []TestRun{ok, {Connection: StatusConnected, ClockSkew: 300 * time.Millisecond}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &NodeHistory{Address: "node1:4000", Runs: tt.runs}
+			if got := h.Warning(); got != tt.want {
+				t.Errorf("Warning() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryStore_PathSanitized(t *testing.T) {
+	store := NewHistoryStore(t.TempDir(), 0)
+	if path := store.path("[::1]:4000/../x"); filepath.Dir(path) != store.dir {
+		t.Errorf("expected a file directly in the store, got %s", path)
+	}
+}
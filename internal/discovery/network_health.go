@@ -37,6 +37,14 @@ type NetworkHealthResult struct {
 	// Network contains network statistics
 	Network *NetworkStats
 
+	// ClockSkew is the largest clock skew the node measured to a member
+	// of its cluster, zero if it is not clustered
+	ClockSkew time.Duration
+
+	// ClockSkewExceeded reports whether ClockSkew exceeds the node's
+	// threshold
+	ClockSkewExceeded bool
+
 	// Error contains any error message
 	Error string
 
@@ -166,6 +174,11 @@ func (c *NetworkHealthChecker) CheckHealth(ctx context.Context, address string)
 		PeerID:  resp.GetNodeId(),
 	}
 
+	if cluster := resp.GetCluster(); cluster != nil {
+		result.ClockSkew = cluster.GetMaxClockSkew().AsDuration()
+		result.ClockSkewExceeded = cluster.GetClockSkewExceeded()
+	}
+
 	// Extract network stats
 	if network := resp.GetNetwork(); network != nil {
 		result.Network = &NetworkStats{
@@ -27,50 +27,15 @@ func (d *Discoverer) DiscoverWithProgress(ctx context.Context, callback Progress
 	}
 
 	nodeMap := make(map[string]DiscoveredNode)
-	addNodes := func(nodes []DiscoveredNode) {
-		for _, node := range nodes {
-			if existing, ok := nodeMap[node.Address]; ok {
-				if node.Latency > 0 && (existing.Latency == 0 || node.Latency < existing.Latency) {
-					nodeMap[node.Address] = node
-				}
-			} else {
-				nodeMap[node.Address] = node
-			}
-		}
-	}
-
-	// Localhost discovery
-	if callback != nil {
-		callback("Scanning localhost...", len(nodeMap))
-	}
-	nodes, err := d.discoverLocalhost(ctx)
-	if err != nil {
-		result.Errors = append(result.Errors, fmt.Errorf("localhost: %w", err))
-	}
-	addNodes(nodes)
-
-	// mDNS discovery
-	if d.opts.EnableMDNS {
-		if callback != nil {
-			callback("Scanning local network (mDNS)...", len(nodeMap))
-		}
-		nodes, err := d.discoverMDNS(ctx)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("mDNS: %w", err))
-		}
-		addNodes(nodes)
-	}
-
-	// P2P discovery
-	if d.opts.EnableP2P {
+	for _, p := range d.Providers() {
 		if callback != nil {
-			callback("Discovering nearby peers (P2P)...", len(nodeMap))
+			callback(progressStage(p), len(nodeMap))
 		}
-		nodes, err := d.discoverP2P(ctx)
+		nodes, err := p.Discover(ctx)
 		if err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("P2P: %w", err))
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %w", p.Name(), err))
 		}
-		addNodes(nodes)
+		addNodes(nodeMap, nodes)
 	}
 
 	// Convert map to slice
@@ -106,11 +71,12 @@ func FormatDiscoveryResult(result *DiscoveryResult) string {
 		byMethod[node.Method] = append(byMethod[node.Method], node)
 	}
 
-	methodOrder := []DiscoveryMethod{MethodLocal, MethodMDNS, MethodP2P, MethodManual, MethodPublic}
+	methodOrder := []DiscoveryMethod{MethodLocal, MethodMDNS, MethodP2P, MethodDNS, MethodManual, MethodPublic}
 	methodNames := map[DiscoveryMethod]string{
 		MethodLocal:  "Local",
 		MethodMDNS:   "Local Network (mDNS)",
 		MethodP2P:    "Nearby Peers (P2P)",
+		MethodDNS:    "DNS",
 		MethodManual: "Manual",
 		MethodPublic: "Public Network",
 	}
@@ -0,0 +1,192 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Provider discovers bibd nodes by one method. A Discoverer runs its
+// providers in parallel and merges their nodes, so environments without
+// mDNS can plug in their own sources, such as a static list, DNS SRV
+// records or a service registry.
+type Provider interface {
+	// Name identifies the provider in errors and progress, e.g. "mDNS".
+	Name() string
+
+	// Discover returns the nodes the provider finds. It may return the
+	// nodes found so far together with an error.
+	Discover(ctx context.Context) ([]DiscoveredNode, error)
+}
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc struct {
+	name string
+	fn   func(ctx context.Context) ([]DiscoveredNode, error)
+}
+
+// NewProviderFunc creates a provider named name that discovers with fn.
+func NewProviderFunc(name string, fn func(ctx context.Context) ([]DiscoveredNode, error)) *ProviderFunc {
+	return &ProviderFunc{name: name, fn: fn}
+}
+
+// Name implements Provider.
+func (p *ProviderFunc) Name() string {
+	return p.name
+}
+
+// Discover implements Provider.
+func (p *ProviderFunc) Discover(ctx context.Context) ([]DiscoveredNode, error) {
+	return p.fn(ctx)
+}
+
+// builtinProvider is one of the discovery methods of the Discoverer itself.
+type builtinProvider struct {
+	ProviderFunc
+
+	// progress is the stage reported by DiscoverWithProgress
+	progress string
+}
+
+// LocalhostProvider returns a provider scanning the configured local ports
+// and the default Unix sockets.
+func (d *Discoverer) LocalhostProvider() Provider {
+	return &builtinProvider{
+		ProviderFunc: ProviderFunc{name: "localhost", fn: d.discoverLocalhost},
+		progress:     "Scanning localhost...",
+	}
+}
+
+// MDNSProvider returns a provider browsing the local network with mDNS.
+func (d *Discoverer) MDNSProvider() Provider {
+	return &builtinProvider{
+		ProviderFunc: ProviderFunc{name: "mDNS", fn: d.discoverMDNS},
+		progress:     "Scanning local network (mDNS)...",
+	}
+}
+
+// P2PProvider returns a provider probing the P2P bootstrap peers.
+func (d *Discoverer) P2PProvider() Provider {
+	return &builtinProvider{
+		ProviderFunc: ProviderFunc{name: "P2P", fn: d.discoverP2P},
+		progress:     "Discovering nearby peers (P2P)...",
+	}
+}
+
+// progressStage returns the progress message shown while p runs.
+func progressStage(p Provider) string {
+	if b, ok := p.(*builtinProvider); ok {
+		return b.progress
+	}
+	return fmt.Sprintf("Discovering nodes (%s)...", p.Name())
+}
+
+// StaticProvider returns nodes from a fixed list of addresses, such as the
+// nodes of a company deployment. Nodes are reported with MethodManual and
+// without probing them.
+type StaticProvider struct {
+	addresses []string
+}
+
+// NewStaticProvider creates a provider for addresses in host:port form.
+func NewStaticProvider(addresses ...string) *StaticProvider {
+	return &StaticProvider{addresses: addresses}
+}
+
+// Name implements Provider.
+func (p *StaticProvider) Name() string {
+	return "static"
+}
+
+// Discover implements Provider.
+func (p *StaticProvider) Discover(_ context.Context) ([]DiscoveredNode, error) {
+	now := time.Now()
+	nodes := make([]DiscoveredNode, 0, len(p.addresses))
+	for _, address := range p.addresses {
+		nodes = append(nodes, DiscoveredNode{
+			Address:      address,
+			Method:       MethodManual,
+			DiscoveredAt: now,
+		})
+	}
+	return nodes, nil
+}
+
+// SRVProvider discovers nodes from a DNS SRV record, e.g.
+// _bibd._tcp.example.com, for networks with DNS but without mDNS. Nodes are
+// returned in the order the record prefers: by ascending priority and, within
+// a priority, weighted by weight.
+type SRVProvider struct {
+	record   string
+	resolver *net.Resolver
+}
+
+// NewSRVProvider creates a provider looking up _service._proto.domain.
+// Empty service and proto default to "bibd" and "tcp".
+func NewSRVProvider(service, proto, domain string) *SRVProvider {
+	if service == "" {
+		service = "bibd"
+	}
+	if proto == "" {
+		proto = "tcp"
+	}
+	return NewSRVRecordProvider(fmt.Sprintf("_%s._%s.%s", service, proto, domain))
+}
+
+// NewSRVRecordProvider creates a provider looking up the SRV record with the
+// full name record, such as _bibd._tcp.example.com.
+func NewSRVRecordProvider(record string) *SRVProvider {
+	return &SRVProvider{
+		record:   record,
+		resolver: net.DefaultResolver,
+	}
+}
+
+// WithResolver sets the resolver used for lookups.
+func (p *SRVProvider) WithResolver(r *net.Resolver) *SRVProvider {
+	p.resolver = r
+	return p
+}
+
+// Record returns the name of the SRV record looked up.
+func (p *SRVProvider) Record() string {
+	return p.record
+}
+
+// Name implements Provider.
+func (p *SRVProvider) Name() string {
+	return "DNS SRV"
+}
+
+// Discover implements Provider.
+func (p *SRVProvider) Discover(ctx context.Context) ([]DiscoveredNode, error) {
+	_, records, err := p.resolver.LookupSRV(ctx, "", "", p.record)
+	if err != nil {
+		return nil, fmt.Errorf("SRV lookup for %s failed: %w", p.record, err)
+	}
+	return srvNodes(records), nil
+}
+
+// srvNodes converts SRV records to nodes, skipping the "." target that
+// marks a service as unavailable.
+func srvNodes(records []*net.SRV) []DiscoveredNode {
+	now := time.Now()
+	nodes := make([]DiscoveredNode, 0, len(records))
+	for _, srv := range records {
+		host := strings.TrimSuffix(srv.Target, ".")
+		if host == "" {
+			continue
+		}
+		nodes = append(nodes, DiscoveredNode{
+			Address:      net.JoinHostPort(host, strconv.Itoa(int(srv.Port))),
+			Method:       MethodDNS,
+			Priority:     srv.Priority,
+			Weight:       srv.Weight,
+			DiscoveredAt: now,
+		})
+	}
+	return nodes
+}
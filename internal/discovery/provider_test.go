@@ -0,0 +1,198 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+)
+
+func TestNew_BuiltinProviders(t *testing.T) {
+	opts := DefaultOptions()
+	opts.EnableP2P = true
+
+	var names []string
+	for _, p := range New(opts).Providers() {
+		names = append(names, p.Name())
+	}
+	if got := strings.Join(names, ","); got != "localhost,mDNS,P2P" {
+		t.Errorf("providers = %s, want localhost,mDNS,P2P", got)
+	}
+
+	opts.EnableMDNS = false
+	opts.EnableP2P = false
+	if n := len(New(opts).Providers()); n != 1 {
+		t.Errorf("expected only the localhost provider, got %d providers", n)
+	}
+}
+
+func TestDiscover_CustomProviders(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MeasureLatency = false
+
+	fast := NewProviderFunc("fast", func(context.Context) ([]DiscoveredNode, error) {
+		return []DiscoveredNode{
+			{Address: "node1:4000", Method: MethodDNS, Latency: 5 * time.Millisecond},
+			{Address: "node2:4000", Method: MethodDNS},
+		}, nil
+	})
+	slow := NewProviderFunc("slow", func(context.Context) ([]DiscoveredNode, error) {
+		return []DiscoveredNode{
+			{Address: "node1:4000", Method: MethodManual, Latency: 50 * time.Millisecond},
+		}, nil
+	})
+
+	d := NewWithProviders(opts, slow, fast)
+	result := d.Discover(context.Background())
+
+	if len(result.Nodes) != 2 {
+		t.Fatalf("expected 2 deduplicated nodes, got %d", len(result.Nodes))
+	}
+	if result.Nodes[0].Address != "node1:4000" || result.Nodes[0].Method != MethodDNS {
+		t.Errorf("expected the lower latency node1 first, got %+v", result.Nodes[0])
+	}
+	if result.MethodCounts[MethodDNS] != 2 {
+		t.Errorf("expected 2 DNS nodes, got %d", result.MethodCounts[MethodDNS])
+	}
+	if result.HasErrors() {
+		t.Errorf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestDiscover_ProviderError(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MeasureLatency = false
+
+	errBackend := errors.New("backend unavailable")
+	d := NewWithProviders(opts,
+		NewProviderFunc("consul", func(context.Context) ([]DiscoveredNode, error) {
+			return nil, errBackend
+		}),
+		NewStaticProvider("node1:4000"),
+	)
+
+	result := d.Discover(context.Background())
+
+	if len(result.Nodes) != 1 {
+		t.Errorf("expected the static node despite the failing provider, got %d", len(result.Nodes))
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(result.Errors))
+	}
+	if !errors.Is(result.Errors[0], errBackend) {
+		t.Errorf("expected wrapped backend error, got %v", result.Errors[0])
+	}
+	if !strings.HasPrefix(result.Errors[0].Error(), "consul discovery:") {
+		t.Errorf("expected error prefixed with the provider name, got %v", result.Errors[0])
+	}
+}
+
+func TestDiscoverWithProgress_Providers(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MeasureLatency = false
+
+	d := NewWithProviders(opts, NewStaticProvider("node1:4000"))
+	d.AddProvider(NewStaticProvider("node1:4000", "node2:4000"))
+
+	var stages []string
+	result := d.DiscoverWithProgress(context.Background(), func(stage string, _ int) {
+		stages = append(stages, stage)
+	})
+
+	if len(result.Nodes) != 2 {
+		t.Errorf("expected 2 nodes, got %d", len(result.Nodes))
+	}
+	want := []string{"Discovering nodes (static)...", "Discovering nodes (static)...", "Discovery complete"}
+	if strings.Join(stages, "|") != strings.Join(want, "|") {
+		t.Errorf("stages = %q, want %q", stages, want)
+	}
+}
+
+func TestStaticProvider(t *testing.T) {
+	nodes, err := NewStaticProvider("a:4000", "b:4000").Discover(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(nodes))
+	}
+	for _, node := range nodes {
+		if node.Method != MethodManual {
+			t.Errorf("expected method %s, got %s", MethodManual, node.Method)
+		}
+	}
+}
+
+func TestNewSRVProvider_Defaults(t *testing.T) {
+	p := NewSRVProvider("", "", "example.com")
+	if p.Record() != "_bibd._tcp.example.com" {
+		t.Errorf("expected _bibd._tcp.example.com, got %s", p.Record())
+	}
+}
+
+func TestSRVNodes(t *testing.T) {
+	nodes := srvNodes([]*net.SRV{
+		{Target: "node1.example.com.", Port: 4000, Priority: 10, Weight: 60},
+		{Target: ".", Port: 0},
+		{Target: "node2.example.com.", Port: 4001, Priority: 20, Weight: 10},
+	})
+
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes without the unavailable target, got %d", len(nodes))
+	}
+	if nodes[0].Address != "node1.example.com:4000" {
+		t.Errorf("unexpected address %s", nodes[0].Address)
+	}
+	if nodes[0].Method != MethodDNS || nodes[0].Priority != 10 || nodes[0].Weight != 60 {
+		t.Errorf("unexpected node %+v", nodes[0])
+	}
+}
+
+func TestDiscover_SRVOrdering(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MeasureLatency = false
+
+	d := NewWithProviders(opts, NewProviderFunc("srv", func(context.Context) ([]DiscoveredNode, error) {
+		return srvNodes([]*net.SRV{
+			{Target: "backup.example.com.", Port: 4000, Priority: 20, Weight: 100},
+			{Target: "light.example.com.", Port: 4000, Priority: 10, Weight: 10},
+			{Target: "heavy.example.com.", Port: 4000, Priority: 10, Weight: 90},
+		}), nil
+	}), NewProviderFunc("local", func(context.Context) ([]DiscoveredNode, error) {
+		return []DiscoveredNode{{Address: "localhost:4000", Method: MethodLocal, Latency: time.Millisecond}}, nil
+	}))
+
+	result := d.Discover(context.Background())
+
+	var got []string
+	for _, node := range result.Nodes {
+		got = append(got, node.Address)
+	}
+	want := "localhost:4000,heavy.example.com:4000,light.example.com:4000,backup.example.com:4000"
+	if strings.Join(got, ",") != want {
+		t.Errorf("order = %s, want %s", strings.Join(got, ","), want)
+	}
+}
+
+func TestNewWithDefaults_SRVRecord(t *testing.T) {
+	d := NewWithDefaults(config.ConnectionConfig{SRVRecord: "_bibd._tcp.example.com"})
+
+	providers := d.Providers()
+	srv, ok := providers[len(providers)-1].(*SRVProvider)
+	if !ok {
+		t.Fatalf("expected an SRV provider last, got %T", providers[len(providers)-1])
+	}
+	if srv.Record() != "_bibd._tcp.example.com" {
+		t.Errorf("unexpected record %s", srv.Record())
+	}
+
+	for _, p := range NewWithDefaults().Providers() {
+		if _, ok := p.(*SRVProvider); ok {
+			t.Error("expected no SRV provider without a configured record")
+		}
+	}
+}
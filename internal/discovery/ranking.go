@@ -0,0 +1,263 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Score weights of the ranking. A node that connects, authenticates and
+// reports good network health with negligible latency scores 100.
+const (
+	scoreLatency   = 40.0
+	scoreConnected = 20.0
+	scoreAuth      = 25.0
+	scoreHealth    = 15.0
+
+	// scoreLatencyHalf is the latency at which a node gets half the
+	// latency score
+	scoreLatencyHalf = 50 * time.Millisecond
+)
+
+// NodeEvidence is what is known about a node beyond its discovery, from
+// the connection, authentication and network health testers. Nil results
+// were not tested.
+type NodeEvidence struct {
+	Connection *ConnectionTestResult
+	Auth       *AuthTestResult
+	Health     *NetworkHealthResult
+}
+
+// RankedNode is a discovered node with its ranking score
+type RankedNode struct {
+	DiscoveredNode
+
+	// Score is the composite score from 0 to 100, higher is better
+	Score float64
+
+	// Reachable is false if a connection test failed
+	Reachable bool
+}
+
+// ScoreNode computes the composite score of a node: a latency score that
+// halves every 50ms, plus points for connecting, authenticating and network
+// health. Untested checks earn half their points, so a tested healthy node
+// outranks an untested one, and an untested one outranks a failing one.
+// Unreachable nodes score 0.
+func ScoreNode(node DiscoveredNode, ev NodeEvidence) float64 {
+	latency := node.Latency
+	var score float64
+
+	switch {
+	case ev.Connection == nil:
+		score += scoreConnected / 2
+	case ev.Connection.Status != StatusConnected:
+		return 0
+	default:
+		score += scoreConnected
+		if ev.Connection.Latency > 0 {
+			latency = ev.Connection.Latency
+		}
+	}
+
+	if latency > 0 {
+		score += scoreLatency * float64(scoreLatencyHalf) / float64(scoreLatencyHalf+latency)
+	}
+
+	switch {
+	case ev.Auth == nil:
+		score += scoreAuth / 2
+	case ev.Auth.Status == AuthStatusSuccess || ev.Auth.Status == AuthStatusAutoRegistered:
+		score += scoreAuth
+	}
+
+	if ev.Health == nil {
+		score += scoreHealth / 2
+	} else {
+		switch ev.Health.Status {
+		case NetworkHealthGood:
+			score += scoreHealth
+		case NetworkHealthDegraded:
+			score += scoreHealth / 2
+		case NetworkHealthPoor:
+			score += scoreHealth / 5
+		}
+	}
+
+	return score
+}
+
+// RankNodes scores nodes with the evidence known by address and returns
+// them best first. Equal scores keep the latency order of discovery.
+func RankNodes(nodes []DiscoveredNode, evidence map[string]NodeEvidence) []RankedNode {
+	sorted := append([]DiscoveredNode(nil), nodes...)
+	sortNodesByLatency(sorted)
+
+	ranked := make([]RankedNode, len(sorted))
+	for i, node := range sorted {
+		ev := evidence[node.Address]
+		ranked[i] = RankedNode{
+			DiscoveredNode: node,
+			Score:          ScoreNode(node, ev),
+			Reachable:      ev.Connection == nil || ev.Connection.Status == StatusConnected,
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// Ranker keeps the evidence gathered about a set of nodes and ranks them,
+// re-probing their latency on demand or periodically with Watch.
+type Ranker struct {
+	mu       sync.Mutex
+	nodes    []DiscoveredNode
+	evidence map[string]NodeEvidence
+	timeout  time.Duration
+}
+
+// NewRanker creates a ranker for nodes
+func NewRanker(nodes []DiscoveredNode) *Ranker {
+	return &Ranker{
+		nodes:    append([]DiscoveredNode(nil), nodes...),
+		evidence: make(map[string]NodeEvidence),
+		timeout:  2 * time.Second,
+	}
+}
+
+// WithLatencyTimeout sets the timeout of each latency probe
+func (r *Ranker) WithLatencyTimeout(timeout time.Duration) *Ranker {
+	r.timeout = timeout
+	return r
+}
+
+// AddConnectionResults records connection test results
+func (r *Ranker) AddConnectionResults(results []*ConnectionTestResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, res := range results {
+		ev := r.evidence[res.Address]
+		ev.Connection = res
+		r.evidence[res.Address] = ev
+	}
+}
+
+// AddAuthResults records authentication test results
+func (r *Ranker) AddAuthResults(results []*AuthTestResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, res := range results {
+		ev := r.evidence[res.Address]
+		ev.Auth = res
+		r.evidence[res.Address] = ev
+	}
+}
+
+// AddHealthResults records network health results
+func (r *Ranker) AddHealthResults(results []*NetworkHealthResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, res := range results {
+		ev := r.evidence[res.Address]
+		ev.Health = res
+		r.evidence[res.Address] = ev
+	}
+}
+
+// Rank returns the nodes best first
+func (r *Ranker) Rank() []RankedNode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return RankNodes(r.nodes, r.evidence)
+}
+
+// Best returns the best reachable node, or false if there is none
+func (r *Ranker) Best() (RankedNode, bool) {
+	return BestNode(r.Rank())
+}
+
+// BestNode returns the first reachable node of ranked, or false if there
+// is none
+func BestNode(ranked []RankedNode) (RankedNode, bool) {
+	for _, node := range ranked {
+		if node.Reachable {
+			return node, true
+		}
+	}
+	return RankedNode{}, false
+}
+
+// Reprobe measures the latency of every node again. Nodes that no longer
+// answer lose their latency, which ranks them after the responsive ones.
+func (r *Ranker) Reprobe(ctx context.Context) {
+	r.mu.Lock()
+	addresses := make([]string, len(r.nodes))
+	for i, node := range r.nodes {
+		addresses[i] = node.Address
+	}
+	r.mu.Unlock()
+
+	latencies := make([]time.Duration, len(addresses))
+	var wg sync.WaitGroup
+	for i, address := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if latency, err := measureLatency(ctx, address, r.timeout); err == nil {
+				latencies[i] = latency
+			}
+		}()
+	}
+	wg.Wait()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.nodes {
+		if i < len(latencies) && r.nodes[i].Address == addresses[i] {
+			r.nodes[i].Latency = latencies[i]
+		}
+	}
+}
+
+// Watch re-probes the nodes every interval and calls fn with the new
+// ranking, until ctx is done.
+func (r *Ranker) Watch(ctx context.Context, interval time.Duration, fn func([]RankedNode)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Reprobe(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			fn(r.Rank())
+		}
+	}
+}
+
+// FormatRankedNodes formats a ranking for display
+func FormatRankedNodes(ranked []RankedNode) string {
+	var sb strings.Builder
+	for i, node := range ranked {
+		latency := "N/A"
+		if node.Latency > 0 {
+			latency = node.Latency.Round(time.Millisecond).String()
+		}
+		status := ""
+		if !node.Reachable {
+			status = " unreachable"
+		}
+		sb.WriteString(fmt.Sprintf("%2d. %-30s score %5.1f  latency %-8s [%s]%s\n",
+			i+1, node.Address, node.Score, latency, node.Method, status))
+	}
+	return sb.String()
+}
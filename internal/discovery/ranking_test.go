@@ -0,0 +1,160 @@
+package discovery
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestScoreNode(t *testing.T) {
+	node := DiscoveredNode{Address: "node1:4000", Latency: 50 * time.Millisecond}
+
+	untested := ScoreNode(node, NodeEvidence{})
+	healthy := ScoreNode(node, NodeEvidence{
+		Connection: &ConnectionTestResult{Status: StatusConnected},
+		Auth:       &AuthTestResult{Status: AuthStatusSuccess},
+		Health:     &NetworkHealthResult{Status: NetworkHealthGood},
+	})
+	failing := ScoreNode(node, NodeEvidence{
+		Connection: &ConnectionTestResult{Status: StatusConnected},
+		Auth:       &AuthTestResult{Status: AuthStatusKeyRejected},
+		Health:     &NetworkHealthResult{Status: NetworkHealthOffline},
+	})
+	unreachable := ScoreNode(node, NodeEvidence{
+		Connection: &ConnectionTestResult{Status: StatusRefused},
+	})
+
+	// Half of the latency score at 50ms plus every check
+	if healthy != 80 {
+		t.Errorf("expected healthy score 80, got %v", healthy)
+	}
+	if !(healthy > untested && untested > failing) {
+		t.Errorf("expected healthy > untested > failing, got %v, %v, %v", healthy, untested, failing)
+	}
+	if unreachable != 0 {
+		t.Errorf("expected unreachable score 0, got %v", unreachable)
+	}
+}
+
+func TestScoreNode_ConnectionLatency(t *testing.T) {
+	node := DiscoveredNode{Address: "node1:4000", Latency: 500 * time.Millisecond}
+
+	fast := ScoreNode(node, NodeEvidence{
+		Connection: &ConnectionTestResult{Status: StatusConnected, Latency: time.Millisecond},
+	})
+	slow := ScoreNode(node, NodeEvidence{
+		Connection: &ConnectionTestResult{Status: StatusConnected},
+	})
+
+	if fast <= slow {
+		t.Errorf("expected the tested latency to be used, got %v <= %v", fast, slow)
+	}
+}
+
+func TestRankNodes(t *testing.T) {
+	nodes := []DiscoveredNode{
+		{Address: "fast:4000", Latency: time.Millisecond},
+		{Address: "slow:4000", Latency: 20 * time.Millisecond},
+		{Address: "down:4000", Latency: time.Microsecond},
+	}
+	evidence := map[string]NodeEvidence{
+		"fast:4000": {Auth: &AuthTestResult{Status: AuthStatusFailed}},
+		"slow:4000": {Auth: &AuthTestResult{Status: AuthStatusSuccess}},
+		"down:4000": {Connection: &ConnectionTestResult{Status: StatusTimeout}},
+	}
+
+	ranked := RankNodes(nodes, evidence)
+
+	if ranked[0].Address != "slow:4000" {
+		t.Errorf("expected the authenticated node first, got %s", ranked[0].Address)
+	}
+	if ranked[2].Address != "down:4000" || ranked[2].Reachable {
+		t.Errorf("expected the unreachable node last, got %+v", ranked[2])
+	}
+
+	best, ok := BestNode(ranked)
+	if !ok || best.Address != "slow:4000" {
+		t.Errorf("unexpected best node %+v", best)
+	}
+}
+
+func TestBestNode_NoneReachable(t *testing.T) {
+	ranked := RankNodes([]DiscoveredNode{{Address: "down:4000"}}, map[string]NodeEvidence{
+		"down:4000": {Connection: &ConnectionTestResult{Status: StatusRefused}},
+	})
+	if _, ok := BestNode(ranked); ok {
+		t.Error("expected no best node")
+	}
+}
+
+func TestRanker_Reprobe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	// A closed listener gives an address that refuses connections
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	r := NewRanker([]DiscoveredNode{
+		{Address: closedAddr, Latency: time.Microsecond},
+		{Address: listener.Addr().String()},
+	}).WithLatencyTimeout(time.Second)
+
+	if r.Rank()[0].Address != closedAddr {
+		t.Fatal("expected the stale latency to rank the closed node first")
+	}
+
+	r.Reprobe(context.Background())
+
+	ranked := r.Rank()
+	if ranked[0].Address != listener.Addr().String() || ranked[0].Latency == 0 {
+		t.Errorf("expected the listening node first after reprobing, got %+v", ranked[0])
+	}
+	if ranked[1].Latency != 0 {
+		t.Errorf("expected the closed node to lose its latency, got %v", ranked[1].Latency)
+	}
+}
+
+func TestRanker_Watch(t *testing.T) {
+	r := NewRanker([]DiscoveredNode{{Address: "127.0.0.1:1"}}).WithLatencyTimeout(100 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	rankings := 0
+	go func() {
+		defer close(done)
+		r.Watch(ctx, 10*time.Millisecond, func(ranked []RankedNode) {
+			rankings++
+			if rankings == 2 {
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		cancel()
+		t.Fatal("Watch did not return after cancel")
+	}
+	if rankings < 2 {
+		t.Errorf("expected at least 2 rankings, got %d", rankings)
+	}
+}
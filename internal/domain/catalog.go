@@ -45,6 +45,14 @@ type CatalogEntry struct {
 
 	// UpdatedAt is when this entry was last updated.
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// CatalogVersion is the catalog version at which this entry was last
+	// added or changed. Zero if unknown.
+	CatalogVersion uint64 `json:"catalog_version,omitempty"`
+
+	// VersionVector counts the writes each node made to the dataset, for
+	// conflict resolution between full replicas. Nil if not tracked.
+	VersionVector VersionVector `json:"version_vector,omitempty"`
 }
 
 // Catalog represents a peer's available data.
@@ -60,6 +68,38 @@ type Catalog struct {
 
 	// Version is the catalog version for change detection.
 	Version uint64 `json:"version"`
+
+	// Incremental indicates Entries only holds the entries changed since
+	// the version the catalog was requested with. Removed entries are not
+	// reported; a full catalog is needed to detect them.
+	Incremental bool `json:"incremental,omitempty"`
+}
+
+// ChangesSince returns the catalog holding only the entries changed after
+// version, for incremental sync. It returns false if the changes cannot be
+// told apart: the catalog is older than version, e.g. because its owner
+// reset it, or an entry does not record its catalog version.
+func (c *Catalog) ChangesSince(version uint64) (*Catalog, bool) {
+	if version == 0 || c.Version < version {
+		return nil, false
+	}
+
+	changes := &Catalog{
+		PeerID:      c.PeerID,
+		Entries:     []CatalogEntry{},
+		LastUpdated: c.LastUpdated,
+		Version:     c.Version,
+		Incremental: true,
+	}
+	for _, e := range c.Entries {
+		if e.CatalogVersion == 0 {
+			return nil, false
+		}
+		if e.CatalogVersion > version {
+			changes.Entries = append(changes.Entries, e)
+		}
+	}
+	return changes, true
 }
 
 // HasEntry checks if the catalog has an entry with the given hash.
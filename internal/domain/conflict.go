@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConflictStrategy selects how full replicas resolve catalog entries of the
+// same dataset that diverged on different peers.
+type ConflictStrategy string
+
+const (
+	// ConflictLastWriterWins keeps the entry updated last. Ties go to the
+	// greater hash, so every replica picks the same entry.
+	ConflictLastWriterWins ConflictStrategy = "last-writer-wins"
+
+	// ConflictVersionVector keeps the entry whose version vector includes
+	// the other's writes. Concurrent writes fall back to last-writer-wins,
+	// and the kept entry's vector is merged with the other's.
+	ConflictVersionVector ConflictStrategy = "version-vector"
+)
+
+// ParseConflictStrategy parses a conflict strategy. Empty selects
+// last-writer-wins.
+func ParseConflictStrategy(s string) (ConflictStrategy, error) {
+	switch ConflictStrategy(s) {
+	case "", ConflictLastWriterWins:
+		return ConflictLastWriterWins, nil
+	case ConflictVersionVector:
+		return ConflictVersionVector, nil
+	default:
+		return "", fmt.Errorf("unknown conflict resolution strategy %q (valid: %s, %s)", s, ConflictLastWriterWins, ConflictVersionVector)
+	}
+}
+
+// VersionVector counts the writes each node made to a dataset, keyed by
+// node ID.
+type VersionVector map[string]uint64
+
+// VectorOrder is how two version vectors relate.
+type VectorOrder int
+
+const (
+	// VectorEqual means both vectors saw the same writes.
+	VectorEqual VectorOrder = iota
+
+	// VectorBefore means the other vector saw every write of this one,
+	// and more.
+	VectorBefore
+
+	// VectorAfter means this vector saw every write of the other, and more.
+	VectorAfter
+
+	// VectorConcurrent means each vector saw writes the other did not.
+	VectorConcurrent
+)
+
+// Compare returns how v relates to other.
+func (v VersionVector) Compare(other VersionVector) VectorOrder {
+	var behind, ahead bool
+	for node, n := range v {
+		if n > other[node] {
+			ahead = true
+		}
+	}
+	for node, n := range other {
+		if n > v[node] {
+			behind = true
+		}
+	}
+
+	switch {
+	case ahead && behind:
+		return VectorConcurrent
+	case ahead:
+		return VectorAfter
+	case behind:
+		return VectorBefore
+	default:
+		return VectorEqual
+	}
+}
+
+// Merge returns the vector holding the writes of both vectors.
+func (v VersionVector) Merge(other VersionVector) VersionVector {
+	merged := make(VersionVector, len(v))
+	for node, n := range v {
+		merged[node] = n
+	}
+	for node, n := range other {
+		if n > merged[node] {
+			merged[node] = n
+		}
+	}
+	return merged
+}
+
+// SyncConflict records catalog entries of a dataset that diverged on two
+// peers, and how the conflict was resolved.
+type SyncConflict struct {
+	// DatasetID is the dataset the entries describe.
+	DatasetID DatasetID `json:"dataset_id"`
+
+	// Current is the entry held before the sync, from CurrentPeer.
+	Current     CatalogEntry `json:"current"`
+	CurrentPeer string       `json:"current_peer"`
+
+	// Incoming is the diverging entry, from IncomingPeer.
+	Incoming     CatalogEntry `json:"incoming"`
+	IncomingPeer string       `json:"incoming_peer"`
+
+	// Strategy is the strategy that resolved the conflict.
+	Strategy ConflictStrategy `json:"strategy"`
+
+	// WinnerHash is the hash of the entry kept.
+	WinnerHash string `json:"winner_hash"`
+
+	// Concurrent indicates the writes were concurrent, as told by the
+	// version vectors; false if the entries have none.
+	Concurrent bool `json:"concurrent"`
+
+	// Pending indicates the conflict awaits manual resolution. Until then
+	// the strategy's pick is kept.
+	Pending bool `json:"pending"`
+
+	// DetectedAt is when the conflict was detected.
+	DetectedAt time.Time `json:"detected_at"`
+
+	// ResolvedAt is when the conflict was resolved manually. Zero if it
+	// was not.
+	ResolvedAt time.Time `json:"resolved_at,omitempty"`
+}
+
+// Resolved reports whether the conflict was resolved manually.
+func (c *SyncConflict) Resolved() bool {
+	return !c.ResolvedAt.IsZero()
+}
+
+// Key identifies the conflict by dataset and diverging hashes, in a stable
+// order.
+func (c *SyncConflict) Key() string {
+	a, b := c.Current.Hash, c.Incoming.Hash
+	if a > b {
+		a, b = b, a
+	}
+	return string(c.DatasetID) + ":" + a + ":" + b
+}
+
+// ResolveConflict picks which of two diverging entries of a dataset to
+// keep. It reports whether the entries conflict: if both carry version
+// vectors and one includes the other's writes, the entries are ordered and
+// the later one merely supersedes the other. Without vectors every
+// divergence is a conflict.
+func ResolveConflict(strategy ConflictStrategy, current, incoming CatalogEntry) (winner CatalogEntry, conflict, concurrent bool) {
+	order := VectorConcurrent
+	if current.VersionVector != nil && incoming.VersionVector != nil {
+		order = current.VersionVector.Compare(incoming.VersionVector)
+		// The same writes with different content are concurrent too
+		concurrent = order == VectorConcurrent || order == VectorEqual
+	}
+	conflict = order != VectorBefore && order != VectorAfter
+
+	if strategy != ConflictVersionVector || current.VersionVector == nil || incoming.VersionVector == nil {
+		return lastWriter(current, incoming), conflict, concurrent
+	}
+
+	switch order {
+	case VectorAfter:
+		return current, false, false
+	case VectorBefore:
+		return incoming, false, false
+	}
+
+	// Keep the last writer, with the writes of both
+	winner = lastWriter(current, incoming)
+	winner.VersionVector = current.VersionVector.Merge(incoming.VersionVector)
+	return winner, true, true
+}
+
+// lastWriter returns the entry updated last, breaking ties by hash.
+func lastWriter(a, b CatalogEntry) CatalogEntry {
+	switch {
+	case a.UpdatedAt.After(b.UpdatedAt):
+		return a
+	case b.UpdatedAt.After(a.UpdatedAt):
+		return b
+	case a.Hash >= b.Hash:
+		return a
+	default:
+		return b
+	}
+}
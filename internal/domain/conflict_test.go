@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseConflictStrategy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ConflictStrategy
+		wantErr bool
+	}{
+		{"", ConflictLastWriterWins, false},
+		{"last-writer-wins", ConflictLastWriterWins, false},
+		{"version-vector", ConflictVersionVector, false},
+		{"newest", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseConflictStrategy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseConflictStrategy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseConflictStrategy(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestVersionVector_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b VersionVector
+		want VectorOrder
+	}{
+		{"equal", VersionVector{"n1": 1}, VersionVector{"n1": 1}, VectorEqual},
+		{"before", VersionVector{"n1": 1}, VersionVector{"n1": 2}, VectorBefore},
+		{"after", VersionVector{"n1": 2, "n2": 1}, VersionVector{"n1": 2}, VectorAfter},
+		{"concurrent", VersionVector{"n1": 2}, VersionVector{"n2": 1}, VectorConcurrent},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%s: Compare = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	merged := VersionVector{"n1": 2, "n2": 1}.Merge(VersionVector{"n1": 1, "n3": 4})
+	if len(merged) != 3 || merged["n1"] != 2 || merged["n2"] != 1 || merged["n3"] != 4 {
+		t.Errorf("Merge = %v", merged)
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	now := time.Now()
+	older := CatalogEntry{Hash: "a", UpdatedAt: now.Add(-time.Minute)}
+	newer := CatalogEntry{Hash: "b", UpdatedAt: now}
+
+	t.Run("last writer wins", func(t *testing.T) {
+		winner, conflict, concurrent := ResolveConflict(ConflictLastWriterWins, older, newer)
+		if winner.Hash != "b" || !conflict || concurrent {
+			t.Errorf("got winner %q, conflict %v, concurrent %v", winner.Hash, conflict, concurrent)
+		}
+	})
+
+	t.Run("ties go to the greater hash", func(t *testing.T) {
+		a := CatalogEntry{Hash: "a", UpdatedAt: now}
+		b := CatalogEntry{Hash: "b", UpdatedAt: now}
+		if winner, _, _ := ResolveConflict(ConflictLastWriterWins, b, a); winner.Hash != "b" {
+			t.Errorf("winner = %q, want b", winner.Hash)
+		}
+	})
+
+	t.Run("ordered vectors supersede", func(t *testing.T) {
+		current := older
+		current.VersionVector = VersionVector{"n1": 2}
+		incoming := newer
+		incoming.VersionVector = VersionVector{"n1": 1}
+
+		winner, conflict, _ := ResolveConflict(ConflictVersionVector, current, incoming)
+		if winner.Hash != "a" || conflict {
+			t.Errorf("got winner %q, conflict %v; want the entry with the later vector and no conflict", winner.Hash, conflict)
+		}
+	})
+
+	t.Run("concurrent vectors merge", func(t *testing.T) {
+		current := older
+		current.VersionVector = VersionVector{"n1": 2}
+		incoming := newer
+		incoming.VersionVector = VersionVector{"n2": 1}
+
+		winner, conflict, concurrent := ResolveConflict(ConflictVersionVector, current, incoming)
+		if winner.Hash != "b" || !conflict || !concurrent {
+			t.Errorf("got winner %q, conflict %v, concurrent %v", winner.Hash, conflict, concurrent)
+		}
+		if winner.VersionVector["n1"] != 2 || winner.VersionVector["n2"] != 1 {
+			t.Errorf("vector not merged: %v", winner.VersionVector)
+		}
+	})
+}
@@ -1,6 +1,11 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -93,7 +98,8 @@ type Dataset struct {
 	// LatestVersionID is the most recent version ID.
 	LatestVersionID DatasetVersionID `json:"latest_version_id,omitempty"`
 
-	// VersionCount is the total number of versions.
+	// VersionCount is the number of stored versions. Versions removed by
+	// the retention policy are not counted.
 	VersionCount int `json:"version_count"`
 
 	// HasContent indicates if the dataset has actual data content.
@@ -119,6 +125,14 @@ type Dataset struct {
 
 	// Metadata holds additional key-value pairs.
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// DataSchema constrains the dataset's content. It overrides the
+	// topic's schema (optional).
+	DataSchema *DataSchema `json:"data_schema,omitempty"`
+
+	// Revision is incremented by every update. Storage rejects updates of
+	// a dataset whose revision changed since it was read.
+	Revision int64 `json:"revision"`
 }
 
 // Validate validates the dataset.
@@ -138,6 +152,9 @@ func (d *Dataset) Validate() error {
 	if len(d.Owners) == 0 {
 		return ErrNoOwners
 	}
+	if d.DataSchema != nil {
+		return d.DataSchema.Validate()
+	}
 	return nil
 }
 
@@ -206,6 +223,23 @@ func (v *DatasetVersion) Validate() error {
 	return nil
 }
 
+// Number returns the version number, the major part of Version. Numbers
+// increase by one with each version of a dataset and are never reused.
+// It returns 0 if Version is not numbered.
+func (v *DatasetVersion) Number() int {
+	major, _, _ := strings.Cut(v.Version, ".")
+	n, err := strconv.Atoi(major)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// VersionString returns the Version of the numbered version n.
+func VersionString(n int) string {
+	return fmt.Sprintf("%d.0.0", n)
+}
+
 // HasContent returns true if this version has data content.
 func (v *DatasetVersion) HasContent() bool {
 	return v.Content != nil
@@ -322,6 +356,76 @@ type SourceMetadata struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// SchemaMode is how writes that do not match a data schema are handled.
+type SchemaMode string
+
+const (
+	// SchemaModeEnforce rejects non-conforming writes.
+	SchemaModeEnforce SchemaMode = "enforce"
+
+	// SchemaModeWarn accepts non-conforming writes and records the
+	// violations in the audit log.
+	SchemaModeWarn SchemaMode = "warn"
+)
+
+// IsValid checks if the mode is valid.
+func (m SchemaMode) IsValid() bool {
+	switch m {
+	case SchemaModeEnforce, SchemaModeWarn:
+		return true
+	default:
+		return false
+	}
+}
+
+// DataSchema is a JSON Schema that dataset content must match. Content is
+// checked as a sequence of JSON values, such as a single document or
+// JSON Lines, each of which must match the schema.
+type DataSchema struct {
+	// JSONSchema is the schema document.
+	JSONSchema string `json:"json_schema"`
+
+	// Mode is how violations are handled.
+	Mode SchemaMode `json:"mode"`
+}
+
+// Validate validates the schema settings. It does not compile JSONSchema.
+func (s *DataSchema) Validate() error {
+	if s.JSONSchema == "" {
+		return ErrEmptySchema
+	}
+	if !s.Mode.IsValid() {
+		return ErrInvalidSchemaMode
+	}
+	return nil
+}
+
+// VersionRetention decides which old versions of a dataset are kept.
+// The zero value keeps every version.
+type VersionRetention struct {
+	// KeepLast is how many of the newest versions to keep (0 = no limit).
+	KeepLast int `json:"keep_last"`
+
+	// MaxAge is how long to keep a version after it was created
+	// (0 = no limit).
+	MaxAge time.Duration `json:"max_age"`
+}
+
+// Expired returns the versions the policy no longer keeps. versions are
+// ordered newest first; the newest version is always kept.
+func (r VersionRetention) Expired(versions []*DatasetVersion, now time.Time) []*DatasetVersion {
+	var expired []*DatasetVersion
+	for i, v := range versions {
+		if i == 0 {
+			continue
+		}
+		if (r.KeepLast > 0 && i >= r.KeepLast) || (r.MaxAge > 0 && now.Sub(v.CreatedAt) > r.MaxAge) {
+			expired = append(expired, v)
+		}
+	}
+	return expired
+}
+
 // Chunk represents a piece of a dataset for chunked transfer.
 type Chunk struct {
 	// ID is the unique chunk identifier.
@@ -365,3 +469,12 @@ func (c *Chunk) Validate() error {
 	}
 	return nil
 }
+
+// VerifyData returns true if Data is non-empty and its SHA-256 matches Hash.
+func (c *Chunk) VerifyData() bool {
+	if len(c.Data) == 0 {
+		return false
+	}
+	hash := sha256.Sum256(c.Data)
+	return hex.EncodeToString(hash[:]) == c.Hash
+}
@@ -153,6 +153,39 @@ func TestDataset_Validate(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "valid data schema",
+			dataset: &Dataset{
+				ID:         "dataset-1",
+				TopicID:    "topic-1",
+				Name:       "Test Dataset",
+				Owners:     []UserID{owner},
+				DataSchema: &DataSchema{JSONSchema: `{"type": "object"}`, Mode: SchemaModeWarn},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "empty data schema",
+			dataset: &Dataset{
+				ID:         "dataset-1",
+				TopicID:    "topic-1",
+				Name:       "Test Dataset",
+				Owners:     []UserID{owner},
+				DataSchema: &DataSchema{Mode: SchemaModeEnforce},
+			},
+			wantErr: ErrEmptySchema,
+		},
+		{
+			name: "invalid schema mode",
+			dataset: &Dataset{
+				ID:         "dataset-1",
+				TopicID:    "topic-1",
+				Name:       "Test Dataset",
+				Owners:     []UserID{owner},
+				DataSchema: &DataSchema{JSONSchema: `{}`, Mode: "strict"},
+			},
+			wantErr: ErrInvalidSchemaMode,
+		},
 	}
 
 	for _, tt := range tests {
@@ -285,6 +318,75 @@ func TestDatasetVersion_HasInstructions(t *testing.T) {
 	}
 }
 
+func TestDatasetVersion_Number(t *testing.T) {
+	tests := []struct {
+		version string
+		want    int
+	}{
+		{"1.0.0", 1},
+		{"12.0.0", 12},
+		{VersionString(7), 7},
+		{"3", 3},
+		{"", 0},
+		{"v1.2.3", 0},
+		{"-1.0.0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := &DatasetVersion{Version: tt.version}
+			if got := v.Number(); got != tt.want {
+				t.Errorf("Number() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRetention_Expired(t *testing.T) {
+	now := time.Now()
+	versions := []*DatasetVersion{
+		{ID: "v4", CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: "v3", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "v2", CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "v1", CreatedAt: now.Add(-72 * time.Hour)},
+	}
+
+	ids := func(vs []*DatasetVersion) []DatasetVersionID {
+		var out []DatasetVersionID
+		for _, v := range vs {
+			out = append(out, v.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		retention VersionRetention
+		want      []DatasetVersionID
+	}{
+		{"keep all", VersionRetention{}, nil},
+		{"keep last 2", VersionRetention{KeepLast: 2}, []DatasetVersionID{"v2", "v1"}},
+		{"keep last 10", VersionRetention{KeepLast: 10}, nil},
+		{"max age", VersionRetention{MaxAge: 24 * time.Hour}, []DatasetVersionID{"v2", "v1"}},
+		{"both", VersionRetention{KeepLast: 3, MaxAge: 60 * time.Hour}, []DatasetVersionID{"v1"}},
+		{"latest always kept", VersionRetention{KeepLast: 1, MaxAge: time.Minute}, []DatasetVersionID{"v3", "v2", "v1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(tt.retention.Expired(versions, now))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expired() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expired() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
 func TestDatasetContent_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -460,6 +562,29 @@ func TestChunk_Validate(t *testing.T) {
 	}
 }
 
+func TestChunk_VerifyData(t *testing.T) {
+	// sha256("hello")
+	const helloHash = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	tests := []struct {
+		name  string
+		chunk *Chunk
+		want  bool
+	}{
+		{"matching hash", &Chunk{Hash: helloHash, Data: []byte("hello")}, true},
+		{"mismatched hash", &Chunk{Hash: helloHash, Data: []byte("hellO")}, false},
+		{"empty data", &Chunk{Hash: helloHash}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chunk.VerifyData(); got != tt.want {
+				t.Errorf("Chunk.VerifyData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCatalog_HasEntry(t *testing.T) {
 	catalog := &Catalog{
 		PeerID: "peer-1",
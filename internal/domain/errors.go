@@ -24,6 +24,10 @@ var (
 	ErrInvalidChunkCount    = errors.New("invalid chunk count")
 	ErrNoOwners             = errors.New("no owners specified")
 
+	// Schema errors
+	ErrEmptySchema       = errors.New("schema must not be empty")
+	ErrInvalidSchemaMode = errors.New("invalid schema mode")
+
 	// Version errors
 	ErrInvalidVersionID     = errors.New("invalid version ID")
 	ErrInvalidVersionString = errors.New("invalid version string")
@@ -49,6 +53,11 @@ var (
 	ErrInvalidOperation  = errors.New("invalid operation")
 	ErrUnauthorized      = errors.New("unauthorized")
 	ErrAutoRegDisabled   = errors.New("auto-registration is disabled")
+	ErrEmailRequired     = errors.New("email is required for registration")
+	ErrKeyNotFound       = errors.New("public key not found")
+	ErrKeyExists         = errors.New("public key already registered")
+	ErrPrimaryKey        = errors.New("cannot remove the primary key")
+	ErrInvalidKeyLabel   = errors.New("invalid key label")
 
 	// Session errors
 	ErrSessionNotFound = errors.New("session not found")
@@ -20,6 +20,10 @@ type SyncStatus struct {
 
 	// SyncedEntries is the total number of synced entries.
 	SyncedEntries int `json:"synced_entries"`
+
+	// PendingConflicts is the number of conflicts awaiting manual
+	// resolution.
+	PendingConflicts int `json:"pending_conflicts,omitempty"`
 }
 
 // Subscription represents a topic subscription for selective mode.
@@ -157,3 +157,41 @@ func TestDownload_WithError(t *testing.T) {
 		t.Errorf("expected 'connection timeout', got %q", download.Error)
 	}
 }
+
+func TestCatalog_ChangesSince(t *testing.T) {
+	catalog := &Catalog{
+		PeerID:  "peer",
+		Version: 5,
+		Entries: []CatalogEntry{
+			{Hash: "a", CatalogVersion: 2},
+			{Hash: "b", CatalogVersion: 4},
+			{Hash: "c", CatalogVersion: 5},
+		},
+	}
+
+	changes, ok := catalog.ChangesSince(3)
+	if !ok {
+		t.Fatal("expected changes since version 3")
+	}
+	if !changes.Incremental || changes.Version != 5 {
+		t.Errorf("unexpected catalog: %+v", changes)
+	}
+	if len(changes.Entries) != 2 || changes.Entries[0].Hash != "b" || changes.Entries[1].Hash != "c" {
+		t.Errorf("unexpected entries: %+v", changes.Entries)
+	}
+
+	if changes, ok := catalog.ChangesSince(5); !ok || len(changes.Entries) != 0 {
+		t.Errorf("expected no changes since the current version, got %+v", changes)
+	}
+	if _, ok := catalog.ChangesSince(0); ok {
+		t.Error("version 0 should need the full catalog")
+	}
+	if _, ok := catalog.ChangesSince(6); ok {
+		t.Error("a newer version than the catalog's should need the full catalog")
+	}
+
+	catalog.Entries = append(catalog.Entries, CatalogEntry{Hash: "d"})
+	if _, ok := catalog.ChangesSince(3); ok {
+		t.Error("an entry without a catalog version should need the full catalog")
+	}
+}
@@ -76,6 +76,18 @@ type Topic struct {
 
 	// Metadata holds additional key-value pairs.
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// ACL grants access to the topic to specific users or roles.
+	// A topic without entries is open to all users.
+	ACL []TopicACLEntry `json:"acl,omitempty"`
+
+	// DataSchema constrains the content of the topic's datasets, unless a
+	// dataset declares its own (optional).
+	DataSchema *DataSchema `json:"data_schema,omitempty"`
+
+	// Revision is incremented by every update. Storage rejects updates of
+	// a topic whose revision changed since it was read.
+	Revision int64 `json:"revision"`
 }
 
 // Validate validates the topic.
@@ -92,6 +104,9 @@ func (t *Topic) Validate() error {
 	if len(t.Owners) == 0 {
 		return ErrNoOwners
 	}
+	if t.DataSchema != nil {
+		return t.DataSchema.Validate()
+	}
 	return nil
 }
 
@@ -147,6 +162,134 @@ func (t *Topic) RemoveOwner(userID UserID) error {
 	return ErrOwnerNotFound
 }
 
+// TopicPermission is a level of access to a topic. Each level includes
+// the ones below it: admin includes write, write includes read.
+type TopicPermission string
+
+const (
+	// TopicPermissionRead allows viewing and subscribing to the topic.
+	TopicPermissionRead TopicPermission = "read"
+
+	// TopicPermissionWrite allows publishing datasets to the topic.
+	TopicPermissionWrite TopicPermission = "write"
+
+	// TopicPermissionAdmin allows managing the topic and its ACL.
+	TopicPermissionAdmin TopicPermission = "admin"
+)
+
+// IsValid checks if the permission is valid.
+func (p TopicPermission) IsValid() bool {
+	return p.level() > 0
+}
+
+// Includes reports whether p grants at least other.
+func (p TopicPermission) Includes(other TopicPermission) bool {
+	return p.level() > 0 && p.level() >= other.level()
+}
+
+func (p TopicPermission) level() int {
+	switch p {
+	case TopicPermissionRead:
+		return 1
+	case TopicPermissionWrite:
+		return 2
+	case TopicPermissionAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// TopicPrincipalType is the kind of principal a topic ACL entry applies to.
+type TopicPrincipalType string
+
+const (
+	// TopicPrincipalUser grants to a single user by ID.
+	TopicPrincipalUser TopicPrincipalType = "user"
+
+	// TopicPrincipalRole grants to all users with a role.
+	TopicPrincipalRole TopicPrincipalType = "role"
+)
+
+// IsValid checks if the principal type is valid.
+func (t TopicPrincipalType) IsValid() bool {
+	return t == TopicPrincipalUser || t == TopicPrincipalRole
+}
+
+// TopicACLEntry grants a permission on a topic to a user or role.
+type TopicACLEntry struct {
+	// PrincipalType is whether Principal is a user ID or a role.
+	PrincipalType TopicPrincipalType `json:"principal_type"`
+
+	// Principal is the user ID or role name.
+	Principal string `json:"principal"`
+
+	// Permission is the access granted.
+	Permission TopicPermission `json:"permission"`
+
+	// GrantedBy is the user who made the grant.
+	GrantedBy UserID `json:"granted_by,omitempty"`
+
+	// GrantedAt is when the grant was made.
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+// IsRestricted returns true if access to the topic is limited by its ACL.
+func (t *Topic) IsRestricted() bool {
+	return len(t.ACL) > 0
+}
+
+// IsPublic returns true unless the topic's metadata marks it private, with
+// "is_public" or "public" set to anything but "true". Topics are public by
+// default.
+func (t *Topic) IsPublic() bool {
+	if val, ok := t.Metadata["is_public"]; ok {
+		return val == "true"
+	}
+	if val, ok := t.Metadata["public"]; ok {
+		return val == "true"
+	}
+	return true
+}
+
+// PermissionFor returns the highest permission the ACL grants to a user
+// with the given role, or "" if none. Owners always have admin.
+func (t *Topic) PermissionFor(userID UserID, role UserRole) TopicPermission {
+	if t.IsOwner(userID) {
+		return TopicPermissionAdmin
+	}
+
+	var granted TopicPermission
+	for _, entry := range t.ACL {
+		matches := (entry.PrincipalType == TopicPrincipalUser && entry.Principal == string(userID)) ||
+			(entry.PrincipalType == TopicPrincipalRole && entry.Principal == string(role))
+		if matches && entry.Permission.level() > granted.level() {
+			granted = entry.Permission
+		}
+	}
+	return granted
+}
+
+// Grant sets the permission of a principal, replacing any earlier grant.
+func (t *Topic) Grant(entry TopicACLEntry) {
+	t.Revoke(entry.PrincipalType, entry.Principal)
+	t.ACL = append(t.ACL, entry)
+	t.UpdatedAt = time.Now()
+}
+
+// Revoke removes the grant of a principal. It returns false if the
+// principal had none.
+func (t *Topic) Revoke(principalType TopicPrincipalType, principal string) bool {
+	for i, entry := range t.ACL {
+		if entry.PrincipalType == principalType && entry.Principal == principal {
+			t.ACL = append(t.ACL[:i], t.ACL[i+1:]...)
+			t.UpdatedAt = time.Now()
+			return true
+		}
+	}
+	return false
+}
+
 // TopicTree represents a hierarchical view of topics.
 type TopicTree struct {
 	// Topic is the topic at this node.
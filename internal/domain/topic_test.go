@@ -295,3 +295,99 @@ func TestParseTopicPath(t *testing.T) {
 		})
 	}
 }
+
+func TestTopic_IsPublic(t *testing.T) {
+	tests := []struct {
+		metadata map[string]string
+		want     bool
+	}{
+		{nil, true},
+		{map[string]string{"is_public": "true"}, true},
+		{map[string]string{"is_public": "false"}, false},
+		{map[string]string{"public": "no"}, false},
+		{map[string]string{"is_public": "true", "public": "false"}, true},
+	}
+
+	for _, tt := range tests {
+		topic := &Topic{Metadata: tt.metadata}
+		if got := topic.IsPublic(); got != tt.want {
+			t.Errorf("IsPublic() with metadata %v = %v, want %v", tt.metadata, got, tt.want)
+		}
+	}
+}
+
+func TestTopicPermission_Includes(t *testing.T) {
+	tests := []struct {
+		p, other TopicPermission
+		want     bool
+	}{
+		{TopicPermissionAdmin, TopicPermissionWrite, true},
+		{TopicPermissionWrite, TopicPermissionRead, true},
+		{TopicPermissionRead, TopicPermissionRead, true},
+		{TopicPermissionRead, TopicPermissionWrite, false},
+		{TopicPermission(""), TopicPermissionRead, false},
+		{TopicPermission("owner"), TopicPermissionRead, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.Includes(tt.other); got != tt.want {
+			t.Errorf("TopicPermission(%q).Includes(%q) = %v, want %v", tt.p, tt.other, got, tt.want)
+		}
+	}
+}
+
+func TestTopic_PermissionFor(t *testing.T) {
+	topic := &Topic{
+		ID:     "topic-1",
+		Owners: []UserID{"owner"},
+	}
+	topic.Grant(TopicACLEntry{PrincipalType: TopicPrincipalRole, Principal: string(UserRoleReadonly), Permission: TopicPermissionRead})
+	topic.Grant(TopicACLEntry{PrincipalType: TopicPrincipalUser, Principal: "alice", Permission: TopicPermissionWrite})
+
+	tests := []struct {
+		name string
+		user UserID
+		role UserRole
+		want TopicPermission
+	}{
+		{"owner", "owner", UserRoleUser, TopicPermissionAdmin},
+		{"user grant", "alice", UserRoleUser, TopicPermissionWrite},
+		{"highest of user and role grant", "alice", UserRoleReadonly, TopicPermissionWrite},
+		{"role grant", "bob", UserRoleReadonly, TopicPermissionRead},
+		{"no grant", "bob", UserRoleUser, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topic.PermissionFor(tt.user, tt.role); got != tt.want {
+				t.Errorf("PermissionFor(%q, %q) = %q, want %q", tt.user, tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopic_GrantRevoke(t *testing.T) {
+	topic := &Topic{ID: "topic-1", Owners: []UserID{"owner"}}
+	if topic.IsRestricted() {
+		t.Fatal("topic without ACL should not be restricted")
+	}
+
+	topic.Grant(TopicACLEntry{PrincipalType: TopicPrincipalUser, Principal: "alice", Permission: TopicPermissionRead})
+	topic.Grant(TopicACLEntry{PrincipalType: TopicPrincipalUser, Principal: "alice", Permission: TopicPermissionAdmin})
+	if len(topic.ACL) != 1 || topic.ACL[0].Permission != TopicPermissionAdmin {
+		t.Errorf("expected the second grant to replace the first, got %+v", topic.ACL)
+	}
+	if !topic.IsRestricted() {
+		t.Error("topic with ACL should be restricted")
+	}
+
+	if topic.Revoke(TopicPrincipalRole, "alice") {
+		t.Error("Revoke() of a role without grant = true, want false")
+	}
+	if !topic.Revoke(TopicPrincipalUser, "alice") {
+		t.Error("Revoke() = false, want true")
+	}
+	if topic.IsRestricted() {
+		t.Error("topic should be open again after the last revoke")
+	}
+}
@@ -120,37 +120,93 @@ type User struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// UserPublicKey is an additional identity key registered to a user, so each
+// of the user's devices can have its own key. The key the user ID was derived
+// from stays on the User and is not stored as a UserPublicKey.
+type UserPublicKey struct {
+	// UserID is the user the key belongs to.
+	UserID UserID `json:"user_id"`
+
+	// PublicKey is the raw public key bytes.
+	PublicKey []byte `json:"public_key"`
+
+	// KeyType is the type of public key.
+	KeyType KeyType `json:"key_type"`
+
+	// Fingerprint is the SHA256 fingerprint of the public key.
+	Fingerprint string `json:"fingerprint"`
+
+	// Label names the device the key belongs to, unique per user.
+	Label string `json:"label"`
+
+	// CreatedAt is when the key was registered.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewUserPublicKey creates an additional key for a user.
+func NewUserPublicKey(userID UserID, publicKey []byte, keyType KeyType, label string) *UserPublicKey {
+	return &UserPublicKey{
+		UserID:      userID,
+		PublicKey:   publicKey,
+		KeyType:     keyType,
+		Fingerprint: PublicKeyFingerprint(publicKey),
+		Label:       label,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// Validate validates the key.
+func (k *UserPublicKey) Validate() error {
+	if k.UserID == "" {
+		return ErrInvalidUserID
+	}
+	if err := validatePublicKey(k.PublicKey, k.KeyType); err != nil {
+		return err
+	}
+	if k.Label == "" {
+		return ErrInvalidKeyLabel
+	}
+	return nil
+}
+
 // Validate validates the user.
 func (u *User) Validate() error {
 	if u.ID == "" {
 		return ErrInvalidUserID
 	}
-	if len(u.PublicKey) == 0 {
+	if err := validatePublicKey(u.PublicKey, u.KeyType); err != nil {
+		return err
+	}
+	if u.Name == "" {
+		return ErrInvalidUserName
+	}
+	if !u.Status.IsValid() {
+		return ErrInvalidUserStatus
+	}
+	if !u.Role.IsValid() {
+		return ErrInvalidUserRole
+	}
+	return nil
+}
+
+// validatePublicKey checks the key size for its type.
+func validatePublicKey(publicKey []byte, keyType KeyType) error {
+	if len(publicKey) == 0 {
 		return ErrInvalidPublicKey
 	}
-	// Validate key size based on type
-	switch u.KeyType {
+	switch keyType {
 	case KeyTypeEd25519:
-		if len(u.PublicKey) != ed25519.PublicKeySize {
+		if len(publicKey) != ed25519.PublicKeySize {
 			return ErrInvalidPublicKey
 		}
 	case KeyTypeRSA:
 		// RSA keys have variable size, just check it's not empty
-		if len(u.PublicKey) < 64 {
+		if len(publicKey) < 64 {
 			return ErrInvalidPublicKey
 		}
 	default:
 		return ErrInvalidKeyType
 	}
-	if u.Name == "" {
-		return ErrInvalidUserName
-	}
-	if !u.Status.IsValid() {
-		return ErrInvalidUserStatus
-	}
-	if !u.Role.IsValid() {
-		return ErrInvalidUserRole
-	}
 	return nil
 }
 
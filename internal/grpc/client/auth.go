@@ -38,30 +38,47 @@ func NewAuthenticator(opts AuthOptions) *Authenticator {
 
 // Authenticate performs the challenge-response authentication flow.
 func (a *Authenticator) Authenticate(ctx context.Context, authClient services.AuthServiceClient) (string, error) {
+	resp, err := a.SignIn(ctx, authClient)
+	if err != nil {
+		return "", err
+	}
+	return resp.SessionToken, nil
+}
+
+// SignIn performs the challenge-response authentication flow and returns
+// the node's response, which tells whether the identity was registered by
+// signing in.
+func (a *Authenticator) SignIn(ctx context.Context, authClient services.AuthServiceClient) (*services.VerifyChallengeResponse, error) {
 	// Get available signers
 	signers, err := a.getSigners()
 	if err != nil {
-		return "", fmt.Errorf("failed to get SSH signers: %w", err)
+		return nil, fmt.Errorf("failed to get SSH signers: %w", err)
 	}
 	if len(signers) == 0 {
-		return "", fmt.Errorf("no SSH keys available for authentication")
+		return nil, fmt.Errorf("no SSH keys available for authentication")
 	}
 
 	// Try each signer until one works
 	var lastErr error
 	for _, signer := range signers {
-		token, err := a.authenticateWithSigner(ctx, authClient, signer)
+		resp, err := a.authenticateWithSigner(ctx, authClient, signer)
 		if err == nil {
-			return token, nil
+			return resp, nil
 		}
 		lastErr = err
 	}
 
-	return "", fmt.Errorf("authentication failed with all available keys: %w", lastErr)
+	return nil, fmt.Errorf("authentication failed with all available keys: %w", lastErr)
+}
+
+// SetRegistrationInfo sets the name and email sent when signing in
+func (a *Authenticator) SetRegistrationInfo(name, email string) {
+	a.opts.Name = name
+	a.opts.Email = email
 }
 
 // authenticateWithSigner performs authentication with a specific signer.
-func (a *Authenticator) authenticateWithSigner(ctx context.Context, authClient services.AuthServiceClient, signer ssh.Signer) (string, error) {
+func (a *Authenticator) authenticateWithSigner(ctx context.Context, authClient services.AuthServiceClient, signer ssh.Signer) (*services.VerifyChallengeResponse, error) {
 	pubKey := signer.PublicKey()
 
 	// Request challenge
@@ -69,25 +86,27 @@ func (a *Authenticator) authenticateWithSigner(ctx context.Context, authClient s
 		PublicKey: ssh.MarshalAuthorizedKey(pubKey),
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get challenge: %w", err)
+		return nil, fmt.Errorf("failed to get challenge: %w", err)
 	}
 
 	// Sign the challenge
 	signature, err := signer.Sign(rand.Reader, challengeResp.Challenge)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign challenge: %w", err)
+		return nil, fmt.Errorf("failed to sign challenge: %w", err)
 	}
 
 	// Verify challenge
 	verifyResp, err := authClient.VerifyChallenge(ctx, &services.VerifyChallengeRequest{
 		ChallengeId: challengeResp.ChallengeId,
 		Signature:   signature.Blob,
+		Name:        a.opts.Name,
+		Email:       a.opts.Email,
 	})
 	if err != nil {
-		return "", fmt.Errorf("challenge verification failed: %w", err)
+		return nil, fmt.Errorf("challenge verification failed: %w", err)
 	}
 
-	return verifyResp.SessionToken, nil
+	return verifyResp, nil
 }
 
 // getSigners returns available SSH signers.
@@ -369,27 +388,40 @@ func (a *Authenticator) GetPublicKey() (string, error) {
 
 // Authenticate performs authentication and caches the session token.
 func (c *Client) Authenticate(ctx context.Context) error {
+	_, err := c.SignIn(ctx)
+	return err
+}
+
+// SignIn authenticates like Authenticate and returns the node's response,
+// whose IsNewUser tells whether the node registered the identity.
+func (c *Client) SignIn(ctx context.Context) (*services.VerifyChallengeResponse, error) {
 	authClient, err := c.Auth()
 	if err != nil {
-		return fmt.Errorf("failed to get auth client: %w", err)
+		return nil, fmt.Errorf("failed to get auth client: %w", err)
 	}
 
-	token, err := c.auth.Authenticate(ctx, authClient)
+	resp, err := c.auth.SignIn(ctx, authClient)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	c.tokenLock.Lock()
-	c.sessionToken = token
+	c.sessionToken = resp.SessionToken
 	c.tokenLock.Unlock()
 
 	// Save token to disk
-	if err := c.auth.SaveSessionToken(token); err != nil {
+	if err := c.auth.SaveSessionToken(resp.SessionToken); err != nil {
 		// Log warning but don't fail
 		_ = err
 	}
 
-	return nil
+	return resp, nil
+}
+
+// SetRegistrationInfo sets the name and email sent when signing in, used by
+// nodes that allow auto-registration to register an unknown identity.
+func (c *Client) SetRegistrationInfo(name, email string) {
+	c.auth.SetRegistrationInfo(name, email)
 }
 
 // LoadSession tries to load a cached session token.
@@ -418,6 +450,16 @@ func (c *Client) ClearSession() error {
 	return c.auth.ClearSessionToken()
 }
 
+// SetSession uses a session token obtained outside the key challenge, such
+// as by device authorization, and caches it like Authenticate does.
+func (c *Client) SetSession(token string) error {
+	c.tokenLock.Lock()
+	c.sessionToken = token
+	c.tokenLock.Unlock()
+
+	return c.auth.SaveSessionToken(token)
+}
+
 // SessionToken returns the current session token.
 func (c *Client) SessionToken() string {
 	c.tokenLock.RLock()
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/grpc"
+)
+
+// fakeAuthService answers the challenge flow and records the verify request
+type fakeAuthService struct {
+	services.AuthServiceClient
+	verify *services.VerifyChallengeRequest
+}
+
+func (f *fakeAuthService) Challenge(context.Context, *services.ChallengeRequest, ...grpc.CallOption) (*services.ChallengeResponse, error) {
+	return &services.ChallengeResponse{ChallengeId: "c1", Challenge: []byte("nonce")}, nil
+}
+
+func (f *fakeAuthService) VerifyChallenge(_ context.Context, req *services.VerifyChallengeRequest, _ ...grpc.CallOption) (*services.VerifyChallengeResponse, error) {
+	f.verify = req
+	return &services.VerifyChallengeResponse{SessionToken: "token", IsNewUser: true}, nil
+}
+
+func TestAuthenticator_SignInSendsRegistrationInfo(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAuthenticator(AuthOptions{Name: "Ada"})
+	a.signers = []ssh.Signer{signer}
+	a.SetRegistrationInfo("Ada Lovelace", "ada@example.com")
+
+	svc := &fakeAuthService{}
+	resp, err := a.SignIn(context.Background(), svc)
+	if err != nil {
+		t.Fatalf("SignIn failed: %v", err)
+	}
+	if !resp.IsNewUser || resp.SessionToken != "token" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if svc.verify.Name != "Ada Lovelace" || svc.verify.Email != "ada@example.com" {
+		t.Errorf("registration info not sent: name=%q email=%q", svc.verify.Name, svc.verify.Email)
+	}
+
+	token, err := a.Authenticate(context.Background(), svc)
+	if err != nil || token != "token" {
+		t.Errorf("Authenticate = %q, %v", token, err)
+	}
+}
@@ -4,17 +4,18 @@ package client
 import (
 	"context"
 	"fmt"
-	"net"
-	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	services "bib/api/gen/go/bib/v1/services"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 )
 
 // Client is the main gRPC client for connecting to bibd.
@@ -52,9 +53,17 @@ type Client struct {
 	jobSvc      services.JobServiceClient
 
 	// Connection state
-	connected   bool
-	connectedTo string
-	connLock    sync.RWMutex
+	connected     bool
+	connectedTo   string
+	node          NodeTarget // Node currently serving calls
+	nodeCheckedAt time.Time  // Last connect or failback attempt
+	serverVersion *ServerVersion
+	connLock      sync.RWMutex
+
+	// Failover state
+	failures    map[NodeTarget]time.Time // Last failure per node
+	failureLock sync.Mutex
+	failingBack atomic.Bool
 }
 
 // New creates a new Client with the given options.
@@ -64,7 +73,8 @@ func New(opts Options) (*Client, error) {
 	}
 
 	c := &Client{
-		opts: opts,
+		opts:     opts,
+		failures: make(map[NodeTarget]time.Time),
 	}
 
 	// Initialize authenticator
@@ -82,28 +92,72 @@ func (c *Client) Connect(ctx context.Context) error {
 		return nil
 	}
 
-	var conn *grpc.ClientConn
-	var target string
-	var err error
+	return c.connectLocked(ctx)
+}
 
-	if c.opts.Mode == ConnectionModeParallel {
-		conn, target, err = c.connectParallel(ctx)
-	} else {
-		conn, target, err = c.connectSequential(ctx)
+// connectLocked connects to the best available node and initializes the pool.
+// The caller must hold connLock.
+func (c *Client) connectLocked(ctx context.Context) error {
+	nodes := c.rankedNodes()
+	var lastErr error
+
+	for _, node := range nodes {
+		conn, target, err := c.connectNode(ctx, node)
+		if err != nil {
+			c.markFailed(node)
+			lastErr = err
+			continue
+		}
+
+		// With several candidates, don't settle on a node that reports itself unhealthy
+		if len(nodes) > 1 {
+			if err := c.checkHealth(ctx, conn); err != nil {
+				_ = conn.Close()
+				c.markFailed(node)
+				lastErr = fmt.Errorf("node %s: %w", node.displayName(target), err)
+				continue
+			}
+		}
+
+		server, err := c.negotiateVersion(ctx, conn)
+		if err != nil {
+			_ = conn.Close()
+			lastErr = fmt.Errorf("node %s: %w", node.displayName(target), err)
+			continue
+		}
+
+		c.pool = c.buildPool(ctx, conn, target)
+		c.serverVersion = server
+		c.connected = true
+		c.connectedTo = target
+		c.node = node
+		c.nodeCheckedAt = time.Now()
+		return nil
 	}
 
-	if err != nil {
-		return err
+	if len(nodes) > 1 {
+		return fmt.Errorf("failed to connect to any node: %w", lastErr)
 	}
+	return lastErr
+}
 
-	// Initialize pool
+// connectNode connects to a single node using the configured mode.
+func (c *Client) connectNode(ctx context.Context, node NodeTarget) (*grpc.ClientConn, string, error) {
+	if c.opts.Mode == ConnectionModeParallel {
+		return c.connectParallel(ctx, node)
+	}
+	return c.connectSequential(ctx, node)
+}
+
+// buildPool creates the connection pool around an established connection.
+func (c *Client) buildPool(ctx context.Context, conn *grpc.ClientConn, target string) []*grpc.ClientConn {
 	poolSize := c.opts.PoolSize
 	if poolSize <= 0 {
 		poolSize = 1
 	}
 
-	c.pool = make([]*grpc.ClientConn, poolSize)
-	c.pool[0] = conn
+	pool := make([]*grpc.ClientConn, poolSize)
+	pool[0] = conn
 
 	// Create additional connections for pool
 	for i := 1; i < poolSize; i++ {
@@ -112,18 +166,15 @@ func (c *Client) Connect(ctx context.Context) error {
 			// Log warning but continue with fewer connections
 			break
 		}
-		c.pool[i] = poolConn
+		pool[i] = poolConn
 	}
 
-	c.connected = true
-	c.connectedTo = target
-
-	return nil
+	return pool
 }
 
 // connectSequential tries targets in order.
-func (c *Client) connectSequential(ctx context.Context) (*grpc.ClientConn, string, error) {
-	targets := c.buildTargetList()
+func (c *Client) connectSequential(ctx context.Context, node NodeTarget) (*grpc.ClientConn, string, error) {
+	targets := c.buildTargetList(ctx, node)
 	var lastErr error
 
 	for _, target := range targets {
@@ -138,8 +189,8 @@ func (c *Client) connectSequential(ctx context.Context) (*grpc.ClientConn, strin
 }
 
 // connectParallel tries all targets in parallel, uses first success.
-func (c *Client) connectParallel(ctx context.Context) (*grpc.ClientConn, string, error) {
-	targets := c.buildTargetList()
+func (c *Client) connectParallel(ctx context.Context, node NodeTarget) (*grpc.ClientConn, string, error) {
+	targets := c.buildTargetList(ctx, node)
 	if len(targets) == 0 {
 		return nil, "", fmt.Errorf("no connection targets configured")
 	}
@@ -175,27 +226,23 @@ func (c *Client) connectParallel(ctx context.Context) (*grpc.ClientConn, string,
 	return nil, "", fmt.Errorf("failed to connect to any target: %w", lastErr)
 }
 
-// buildTargetList returns targets in priority order.
-func (c *Client) buildTargetList() []string {
+// buildTargetList returns a node's targets in priority order.
+func (c *Client) buildTargetList(ctx context.Context, node NodeTarget) []string {
 	var targets []string
 
 	// 1. Unix socket / named pipe (highest priority for local connections)
-	if c.opts.UnixSocket != "" {
-		if runtime.GOOS == "windows" {
-			targets = append(targets, "pipe:"+c.opts.UnixSocket)
-		} else {
-			targets = append(targets, "unix:"+c.opts.UnixSocket)
-		}
+	if node.UnixSocket != "" {
+		targets = append(targets, localScheme+":"+node.UnixSocket)
 	}
 
-	// 2. TCP address
-	if c.opts.TCPAddress != "" {
-		targets = append(targets, c.opts.TCPAddress)
+	// 2. TCP address (preceded by the local socket for loopback addresses)
+	if node.TCPAddress != "" {
+		targets = append(targets, c.tcpTargets(ctx, node)...)
 	}
 
 	// 3. P2P peer ID (lowest priority, requires P2P network)
-	if c.opts.P2PPeerID != "" {
-		targets = append(targets, "p2p:"+c.opts.P2PPeerID)
+	if node.P2PPeerID != "" {
+		targets = append(targets, "p2p:"+node.P2PPeerID)
 	}
 
 	return targets
@@ -204,15 +251,17 @@ func (c *Client) buildTargetList() []string {
 // dialWithRetry attempts to dial with retry logic.
 func (c *Client) dialWithRetry(ctx context.Context, target string) (*grpc.ClientConn, error) {
 	var lastErr error
-	backoff := c.opts.RetryBackoff
+
+	// Jittered exponential backoff starting at RetryBackoff
+	policy := c.opts.RetryPolicy
+	policy.InitialBackoff = c.opts.RetryBackoff
 
 	for attempt := 0; attempt <= c.opts.RetryAttempts; attempt++ {
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(backoff):
-				backoff *= 2 // Exponential backoff
+			case <-time.After(policy.Backoff(attempt)):
 			}
 		}
 
@@ -230,6 +279,15 @@ func (c *Client) dialWithRetry(ctx context.Context, target string) (*grpc.Client
 func (c *Client) dialTarget(ctx context.Context, target string) (*grpc.ClientConn, error) {
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
+		grpc.WithConnectParams(c.connectParams()),
+	}
+
+	if c.opts.Keepalive.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.opts.Keepalive.Time,
+			Timeout:             c.opts.Keepalive.Timeout,
+			PermitWithoutStream: c.opts.Keepalive.PermitWithoutStream,
+		}))
 	}
 
 	// Determine transport credentials
@@ -256,15 +314,13 @@ func (c *Client) dialTarget(ctx context.Context, target string) (*grpc.ClientCon
 
 	// Handle different target types
 	dialTarget := target
-	if len(target) > 5 && target[:5] == "unix:" {
-		dialTarget = target
-		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
-			return net.DialTimeout("unix", addr[5:], c.opts.Timeout)
-		}))
-	} else if len(target) > 5 && target[:5] == "pipe:" {
-		// Windows named pipe
-		dialTarget = target[5:]
-		opts = append(opts, grpc.WithContextDialer(dialNamedPipe))
+	if isLocalTarget(target) {
+		// Unix socket or Windows named pipe; the dialer knows the real path
+		dialTarget = "passthrough:///localhost"
+		opts = append(opts,
+			grpc.WithContextDialer(localDialer(target[5:])),
+			grpc.WithAuthority("localhost"),
+		)
 	} else if len(target) > 4 && target[:4] == "p2p:" {
 		// P2P connection - would need libp2p integration
 		return nil, fmt.Errorf("P2P connections not yet implemented")
@@ -276,6 +332,28 @@ func (c *Client) dialTarget(ctx context.Context, target string) (*grpc.ClientCon
 	return grpc.DialContext(dialCtx, dialTarget, opts...)
 }
 
+// connectParams returns the reconnection backoff for established connections.
+// gRPC reconnects on its own after a daemon restart; this keeps its delays in
+// line with the retry policy instead of the library's 2-minute maximum.
+func (c *Client) connectParams() grpc.ConnectParams {
+	policy := c.opts.RetryPolicy
+	params := grpc.ConnectParams{
+		Backoff:           backoff.DefaultConfig,
+		MinConnectTimeout: c.opts.Timeout,
+	}
+	if policy.InitialBackoff > 0 {
+		params.Backoff.BaseDelay = policy.InitialBackoff
+	}
+	if policy.MaxBackoff > 0 {
+		params.Backoff.MaxDelay = policy.MaxBackoff
+	}
+	if policy.Multiplier >= 1 {
+		params.Backoff.Multiplier = policy.Multiplier
+	}
+	params.Backoff.Jitter = policy.Jitter
+	return params
+}
+
 // isLocalTarget returns true if the target is a local connection.
 func isLocalTarget(target string) bool {
 	if len(target) > 5 && (target[:5] == "unix:" || target[:5] == "pipe:") {
@@ -306,12 +384,27 @@ func (c *Client) getConn() (*grpc.ClientConn, error) {
 		}
 	}
 
-	// Return first non-nil connection
+	// No ready connection: wake idle ones so they reconnect, and hand out
+	// the first usable one; calls queue until it is ready or fail fast
+	var fallback *grpc.ClientConn
 	for _, conn := range c.pool {
-		if conn != nil {
-			return conn, nil
+		if conn == nil {
+			continue
+		}
+		state := conn.GetState()
+		if state == connectivity.Shutdown {
+			continue
+		}
+		if state == connectivity.Idle {
+			conn.Connect()
+		}
+		if fallback == nil {
+			fallback = conn
 		}
 	}
+	if fallback != nil {
+		return fallback, nil
+	}
 
 	return nil, fmt.Errorf("no available connections in pool")
 }
@@ -321,18 +414,13 @@ func (c *Client) Close() error {
 	c.connLock.Lock()
 	defer c.connLock.Unlock()
 
-	var errs []error
-	for _, conn := range c.pool {
-		if conn != nil {
-			if err := conn.Close(); err != nil {
-				errs = append(errs, err)
-			}
-		}
-	}
+	errs := closePool(c.pool)
 
 	c.pool = nil
 	c.connected = false
 	c.connectedTo = ""
+	c.node = NodeTarget{}
+	c.serverVersion = nil
 
 	// Reset service clients
 	c.healthOnce = sync.Once{}
@@ -351,6 +439,19 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// closePool closes every connection in pool and returns the errors.
+func closePool(pool []*grpc.ClientConn) []error {
+	var errs []error
+	for _, conn := range pool {
+		if conn != nil {
+			if err := conn.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
 // IsConnected returns whether the client is connected.
 func (c *Client) IsConnected() bool {
 	c.connLock.RLock()
@@ -365,114 +466,113 @@ func (c *Client) ConnectedTo() string {
 	return c.connectedTo
 }
 
+// ConnectedNode returns the name of the node currently serving calls,
+// or the connected target if the node has no name.
+func (c *Client) ConnectedNode() string {
+	c.connLock.RLock()
+	defer c.connLock.RUnlock()
+	return c.node.displayName(c.connectedTo)
+}
+
 // ============================================================================
 // Service Accessors
 // ============================================================================
 
 // Health returns the HealthService client.
 func (c *Client) Health() (services.HealthServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.healthOnce.Do(func() {
-		c.healthSvc = services.NewHealthServiceClient(conn)
+		c.healthSvc = services.NewHealthServiceClient(c)
 	})
 	return c.healthSvc, nil
 }
 
 // Auth returns the AuthService client.
 func (c *Client) Auth() (services.AuthServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.authOnce.Do(func() {
-		c.authSvc = services.NewAuthServiceClient(conn)
+		c.authSvc = services.NewAuthServiceClient(c)
 	})
 	return c.authSvc, nil
 }
 
 // User returns the UserService client.
 func (c *Client) User() (services.UserServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.userOnce.Do(func() {
-		c.userSvc = services.NewUserServiceClient(conn)
+		c.userSvc = services.NewUserServiceClient(c)
 	})
 	return c.userSvc, nil
 }
 
 // Node returns the NodeService client.
 func (c *Client) Node() (services.NodeServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.nodeOnce.Do(func() {
-		c.nodeSvc = services.NewNodeServiceClient(conn)
+		c.nodeSvc = services.NewNodeServiceClient(c)
 	})
 	return c.nodeSvc, nil
 }
 
 // Topic returns the TopicService client.
 func (c *Client) Topic() (services.TopicServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.topicOnce.Do(func() {
-		c.topicSvc = services.NewTopicServiceClient(conn)
+		c.topicSvc = services.NewTopicServiceClient(c)
 	})
 	return c.topicSvc, nil
 }
 
 // Dataset returns the DatasetService client.
 func (c *Client) Dataset() (services.DatasetServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.datasetOnce.Do(func() {
-		c.datasetSvc = services.NewDatasetServiceClient(conn)
+		c.datasetSvc = services.NewDatasetServiceClient(c)
 	})
 	return c.datasetSvc, nil
 }
 
 // Admin returns the AdminService client.
 func (c *Client) Admin() (services.AdminServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.adminOnce.Do(func() {
-		c.adminSvc = services.NewAdminServiceClient(conn)
+		c.adminSvc = services.NewAdminServiceClient(c)
 	})
 	return c.adminSvc, nil
 }
 
 // Query returns the QueryService client.
 func (c *Client) Query() (services.QueryServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.queryOnce.Do(func() {
-		c.querySvc = services.NewQueryServiceClient(conn)
+		c.querySvc = services.NewQueryServiceClient(c)
 	})
 	return c.querySvc, nil
 }
 
 // Job returns the JobService client.
 func (c *Client) Job() (services.JobServiceClient, error) {
-	conn, err := c.getConn()
-	if err != nil {
+	if _, err := c.getConn(); err != nil {
 		return nil, err
 	}
 	c.jobOnce.Do(func() {
-		c.jobSvc = services.NewJobServiceClient(conn)
+		c.jobSvc = services.NewJobServiceClient(c)
 	})
 	return c.jobSvc, nil
 }
@@ -4,11 +4,21 @@ package client
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// localeMetadataKey is the metadata key the daemon reads the preferred
+// locale from; messageCodeKey is the ErrorInfo metadata key of the stable
+// message code. Both match internal/grpc/errors.
+const (
+	localeMetadataKey = "accept-language"
+	messageCodeKey    = "message_code"
+)
+
 // Common client errors
 var (
 	// ErrNotConnected indicates the client is not connected.
@@ -59,6 +69,14 @@ type Error struct {
 	// Details contains additional error details.
 	Details map[string]string
 
+	// MessageCode is the stable code of the error (e.g. "dataset_not_found"),
+	// empty if the daemon did not send one.
+	MessageCode string
+
+	// LocalizedMessage is the message in the locale requested with
+	// Options.Locale, empty if the daemon did not send one.
+	LocalizedMessage string
+
 	// Cause is the underlying error.
 	Cause error
 }
@@ -101,11 +119,47 @@ func FromGRPCError(err error) *Error {
 		}
 	}
 
-	return &Error{
+	e := &Error{
 		Code:    st.Code(),
 		Message: st.Message(),
 		Cause:   err,
 	}
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			if code := d.GetMetadata()[messageCodeKey]; code != "" {
+				e.MessageCode = code
+			}
+		case *errdetails.LocalizedMessage:
+			e.LocalizedMessage = d.GetMessage()
+		}
+	}
+	return e
+}
+
+// LocalizedError returns the text of err with the daemon's message replaced
+// by its localized message, if the daemon sent one. Context added by
+// wrapping err is kept.
+func LocalizedError(err error) string {
+	if err == nil {
+		return ""
+	}
+	// Not status.FromError: for wrapped errors it returns the full text
+	// as the message
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return err.Error()
+	}
+	st := grpcErr.GRPCStatus()
+	for _, d := range st.Details() {
+		if lm, ok := d.(*errdetails.LocalizedMessage); ok && lm.GetMessage() != "" {
+			if st.Message() == "" {
+				return err.Error()
+			}
+			return strings.Replace(err.Error(), st.Message(), lm.GetMessage(), 1)
+		}
+	}
+	return err.Error()
 }
 
 // IsNotFound returns true if the error indicates a not found condition.
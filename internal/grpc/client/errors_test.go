@@ -1,9 +1,14 @@
 package client
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestErrorTypes(t *testing.T) {
@@ -190,3 +195,77 @@ func TestWrapError(t *testing.T) {
 		t.Error("expected nil for nil error")
 	}
 }
+
+// localizedStatusError builds an error like the daemon sends for a
+// localized domain error.
+func localizedStatusError(t *testing.T) error {
+	t.Helper()
+	st, err := status.New(codes.NotFound, "Dataset not found").WithDetails(
+		&errdetails.ErrorInfo{
+			Reason:   "NOT_FOUND",
+			Domain:   "bib.dev",
+			Metadata: map[string]string{messageCodeKey: "dataset_not_found"},
+		},
+		&errdetails.LocalizedMessage{Locale: "de", Message: "Datensatz nicht gefunden"},
+	)
+	if err != nil {
+		t.Fatalf("WithDetails failed: %v", err)
+	}
+	return st.Err()
+}
+
+func TestFromGRPCError_Localized(t *testing.T) {
+	e := FromGRPCError(localizedStatusError(t))
+
+	if e.Code != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", e.Code)
+	}
+	if e.Message != "Dataset not found" {
+		t.Errorf("expected English message, got %q", e.Message)
+	}
+	if e.MessageCode != "dataset_not_found" {
+		t.Errorf("expected message code dataset_not_found, got %q", e.MessageCode)
+	}
+	if e.LocalizedMessage != "Datensatz nicht gefunden" {
+		t.Errorf("expected localized message, got %q", e.LocalizedMessage)
+	}
+}
+
+func TestLocalizedError(t *testing.T) {
+	err := localizedStatusError(t)
+
+	want := "rpc error: code = NotFound desc = Datensatz nicht gefunden"
+	if got := LocalizedError(err); got != want {
+		t.Errorf("LocalizedError() = %q, want %q", got, want)
+	}
+
+	wrapped := fmt.Errorf("get dataset: %w", err)
+	want = "get dataset: " + want
+	if got := LocalizedError(wrapped); got != want {
+		t.Errorf("LocalizedError(wrapped) = %q, want %q", got, want)
+	}
+
+	plain := status.Error(codes.NotFound, "Dataset not found")
+	if got := LocalizedError(plain); got != plain.Error() {
+		t.Errorf("expected unlocalized error unchanged, got %q", got)
+	}
+
+	if got := LocalizedError(nil); got != "" {
+		t.Errorf("expected empty string for nil, got %q", got)
+	}
+}
+
+func TestEnsureLocale(t *testing.T) {
+	ctx := ensureLocale(context.Background(), "fr")
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if got := md.Get(localeMetadataKey); len(got) != 1 || got[0] != "fr" {
+		t.Errorf("expected accept-language fr, got %v", got)
+	}
+
+	// A locale set by the caller wins
+	ctx = metadata.AppendToOutgoingContext(context.Background(), localeMetadataKey, "ru")
+	md, _ = metadata.FromOutgoingContext(ensureLocale(ctx, "fr"))
+	if got := md.Get(localeMetadataKey); len(got) != 1 || got[0] != "ru" {
+		t.Errorf("expected caller's locale ru, got %v", got)
+	}
+}
@@ -0,0 +1,358 @@
+// Package client provides a gRPC client library for connecting to bibd.
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Export layout: each dataset is written to <dir>/<dataset-id>/.
+const (
+	exportDataFile     = "data"
+	exportMetadataFile = "dataset.json"
+	exportStateFile    = ".bib-export.json"
+)
+
+// ExportOptions configures ExportDataset and ExportTopic.
+type ExportOptions struct {
+	// Version to export (0 = latest)
+	Version int32
+
+	// Progress is called after each chunk is written (optional)
+	Progress func(ExportProgress)
+}
+
+// ExportProgress reports the state of a running export.
+type ExportProgress struct {
+	DatasetID       string
+	CompletedChunks int
+	TotalChunks     int
+	TotalSize       int64
+}
+
+// ExportResult describes a finished export.
+type ExportResult struct {
+	DatasetID string
+	Path      string // Path of the data file
+	Size      int64
+	Hash      string
+
+	// Resumed is true if a partial export was continued
+	Resumed bool
+
+	// Skipped is true if the same version was already fully exported
+	Skipped bool
+}
+
+// exportState is persisted next to the data file so an interrupted export
+// can continue where it stopped. The chunk bitmap is the same one used for
+// P2P downloads.
+type exportState struct {
+	domain.Download
+	VersionID string `json:"version_id"`
+	ChunkSize int64  `json:"chunk_size"`
+	TotalSize int64  `json:"total_size"`
+}
+
+// ExportDataset downloads a dataset into dir/<datasetID>/, verifying every
+// chunk and the reassembled content against the server's digests. Running
+// it again on the same directory resumes a partial export, or does nothing
+// if that version was already exported.
+func (c *Client) ExportDataset(ctx context.Context, datasetID, dir string, opts ExportOptions) (*ExportResult, error) {
+	datasets, err := c.Dataset()
+	if err != nil {
+		return nil, err
+	}
+
+	target := filepath.Join(dir, datasetID)
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	state, err := loadExportState(target)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := exportOnce(ctx, datasets, datasetID, target, state, opts)
+	if errors.Is(err, errExportStale) {
+		// The partial export belongs to another version; start over
+		result, err = exportOnce(ctx, datasets, datasetID, target, nil, opts)
+	}
+	return result, err
+}
+
+// ExportTopic exports every dataset in a topic, one subdirectory each.
+// It stops at the first failure; completed datasets are kept and skipped
+// on the next run.
+func (c *Client) ExportTopic(ctx context.Context, topicID, dir string, opts ExportOptions) ([]*ExportResult, error) {
+	datasets, err := c.Dataset()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	page := &services.ListDatasetsRequest{TopicId: topicID, Page: &bibv1.PageRequest{Limit: 100}}
+	for {
+		resp, err := datasets.ListDatasets(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, ds := range resp.GetDatasets() {
+			ids = append(ids, ds.GetId())
+		}
+		if !resp.GetPageInfo().GetHasMore() || len(resp.GetDatasets()) == 0 {
+			break
+		}
+		page.Page.Offset += int32(len(resp.GetDatasets()))
+	}
+
+	results := make([]*ExportResult, 0, len(ids))
+	for _, id := range ids {
+		result, err := c.ExportDataset(ctx, id, dir, opts)
+		if err != nil {
+			return results, fmt.Errorf("dataset %s: %w", id, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// errExportStale means the saved state doesn't match the server's version.
+var errExportStale = errors.New("partial export is for a different version")
+
+func exportOnce(ctx context.Context, datasets services.DatasetServiceClient, datasetID, target string, state *exportState, opts ExportOptions) (*ExportResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Ask only for what's missing. A completed export asks for no chunks,
+	// which still returns the metadata needed to check it's current.
+	start := 0
+	if state != nil {
+		start = state.TotalChunks
+		if missing := state.MissingChunks(); len(missing) > 0 {
+			start = missing[0]
+		}
+	}
+
+	stream, err := datasets.DownloadDataset(ctx, &services.DownloadDatasetRequest{
+		Id:         datasetID,
+		Version:    opts.Version,
+		StartChunk: int32(start),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return nil, fmt.Errorf("download stream did not start with metadata")
+	}
+
+	dataPath := filepath.Join(target, exportDataFile)
+	result := &ExportResult{
+		DatasetID: datasetID,
+		Path:      dataPath,
+		Size:      meta.GetTotalSize(),
+		Hash:      meta.GetContentHash(),
+	}
+
+	if state != nil && !state.matches(meta) {
+		if start > 0 {
+			return nil, errExportStale
+		}
+		state = nil
+	}
+	if state != nil && state.Status == domain.DownloadStatusCompleted {
+		result.Skipped = true
+		return result, nil
+	}
+	result.Resumed = state != nil && state.CompletedChunks > 0
+
+	if state == nil {
+		state = newExportState(datasetID, meta)
+	}
+	state.Status = domain.DownloadStatusActive
+	state.Error = ""
+	if state.TotalChunks > 1 && state.ChunkSize <= 0 {
+		return nil, fmt.Errorf("server did not report a chunk size")
+	}
+
+	if err := writeExportMetadata(target, meta.GetDataset()); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(dataPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data file: %w", err)
+	}
+	defer file.Close()
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data := resp.GetChunk()
+		if data == nil {
+			continue
+		}
+		chunk := &domain.Chunk{Index: int(data.GetIndex()), Hash: data.GetHash(), Data: data.GetData()}
+		if !chunk.VerifyData() {
+			return nil, fmt.Errorf("chunk %d: %w", chunk.Index, domain.ErrHashMismatch)
+		}
+
+		if _, err := file.WriteAt(chunk.Data, int64(chunk.Index)*state.ChunkSize); err != nil {
+			return nil, fmt.Errorf("failed to write chunk %d: %w", chunk.Index, err)
+		}
+
+		state.SetChunkCompleted(chunk.Index)
+		state.UpdatedAt = time.Now()
+		if err := state.save(target); err != nil {
+			return nil, err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(ExportProgress{
+				DatasetID:       datasetID,
+				CompletedChunks: state.CompletedChunks,
+				TotalChunks:     state.TotalChunks,
+				TotalSize:       state.TotalSize,
+			})
+		}
+	}
+
+	if !state.IsComplete() && state.TotalChunks > 0 {
+		return nil, fmt.Errorf("download ended after %d of %d chunks; run the export again to resume",
+			state.CompletedChunks, state.TotalChunks)
+	}
+
+	if err := file.Truncate(state.TotalSize); err != nil {
+		return nil, fmt.Errorf("failed to size data file: %w", err)
+	}
+	if err := verifyExportedFile(file, state.DatasetHash); err != nil {
+		// Start from scratch next time rather than trusting any chunk
+		state.ChunkBitmap = make([]byte, len(state.ChunkBitmap))
+		state.CompletedChunks = 0
+		state.Status = domain.DownloadStatusFailed
+		state.Error = err.Error()
+		_ = state.save(target)
+		return nil, err
+	}
+
+	state.Status = domain.DownloadStatusCompleted
+	state.UpdatedAt = time.Now()
+	if err := state.save(target); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func newExportState(datasetID string, meta *services.DownloadMetadata) *exportState {
+	total := int(meta.GetTotalChunks())
+	return &exportState{
+		Download: domain.Download{
+			ID:          meta.GetVersionId(),
+			DatasetID:   domain.DatasetID(datasetID),
+			DatasetHash: meta.GetContentHash(),
+			TotalChunks: total,
+			ChunkBitmap: make([]byte, (total+7)/8),
+			Status:      domain.DownloadStatusActive,
+			StartedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		},
+		VersionID: meta.GetVersionId(),
+		ChunkSize: meta.GetChunkSize(),
+		TotalSize: meta.GetTotalSize(),
+	}
+}
+
+// matches reports whether the saved state is for the version being served.
+func (s *exportState) matches(meta *services.DownloadMetadata) bool {
+	return s.VersionID == meta.GetVersionId() &&
+		s.DatasetHash == meta.GetContentHash() &&
+		s.TotalChunks == int(meta.GetTotalChunks()) &&
+		s.ChunkSize == meta.GetChunkSize()
+}
+
+func (s *exportState) save(dir string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, exportStateFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to save export state: %w", err)
+	}
+	return nil
+}
+
+// loadExportState returns the saved state in dir, or nil if there is none.
+func loadExportState(dir string) (*exportState, error) {
+	data, err := os.ReadFile(filepath.Join(dir, exportStateFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export state: %w", err)
+	}
+
+	var state exportState
+	if err := json.Unmarshal(data, &state); err != nil {
+		// A corrupt state file only costs a fresh download
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func writeExportMetadata(dir string, dataset *services.Dataset) error {
+	data, err := protojson.MarshalOptions{Multiline: true}.Marshal(dataset)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, exportMetadataFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write dataset metadata: %w", err)
+	}
+	return nil
+}
+
+// verifyExportedFile checks the SHA-256 of the whole file against hash.
+// An empty hash means the server has none to offer.
+func verifyExportedFile(file *os.File, hash string) error {
+	if hash == "" {
+		return nil
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return fmt.Errorf("failed to hash exported data: %w", err)
+	}
+	if hex.EncodeToString(hasher.Sum(nil)) != hash {
+		return fmt.Errorf("exported content: %w", domain.ErrHashMismatch)
+	}
+	return nil
+}
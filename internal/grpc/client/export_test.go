@@ -0,0 +1,213 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testChunkSize = 4
+
+// fakeDatasetServer serves a fixed content split into testChunkSize chunks.
+type fakeDatasetServer struct {
+	services.UnimplementedDatasetServiceServer
+	versionID string
+	content   []byte
+
+	// failAfter ends the stream with Unavailable after this many chunks (0 = never)
+	failAfter int
+	// corrupt flips a byte in the first chunk sent after hashing it
+	corrupt bool
+
+	starts []int32
+}
+
+func (s *fakeDatasetServer) chunks() [][]byte {
+	var chunks [][]byte
+	for off := 0; off < len(s.content); off += testChunkSize {
+		end := min(off+testChunkSize, len(s.content))
+		chunks = append(chunks, s.content[off:end])
+	}
+	return chunks
+}
+
+func (s *fakeDatasetServer) DownloadDataset(req *services.DownloadDatasetRequest, stream grpc.ServerStreamingServer[services.DownloadDatasetResponse]) error {
+	s.starts = append(s.starts, req.GetStartChunk())
+	chunks := s.chunks()
+	sum := sha256.Sum256(s.content)
+
+	if err := stream.Send(&services.DownloadDatasetResponse{
+		Data: &services.DownloadDatasetResponse_Metadata{Metadata: &services.DownloadMetadata{
+			Dataset:     &services.Dataset{Id: req.GetId()},
+			TotalChunks: int32(len(chunks)),
+			TotalSize:   int64(len(s.content)),
+			ContentHash: hex.EncodeToString(sum[:]),
+			ChunkSize:   testChunkSize,
+			VersionId:   s.versionID,
+		}},
+	}); err != nil {
+		return err
+	}
+
+	sent := 0
+	for i := int(req.GetStartChunk()); i < len(chunks); i++ {
+		if s.failAfter > 0 && sent == s.failAfter {
+			s.failAfter = 0
+			return status.Error(codes.Unavailable, "connection lost")
+		}
+
+		data := bytes.Clone(chunks[i])
+		hash := sha256.Sum256(data)
+		hashHex := hex.EncodeToString(hash[:])
+		if s.corrupt {
+			data[0] ^= 0xff
+			s.corrupt = false
+		}
+
+		if err := stream.Send(&services.DownloadDatasetResponse{
+			Data: &services.DownloadDatasetResponse_Chunk{Chunk: &services.ChunkData{
+				Index: int32(i), Data: data, Hash: hashHex, Size: int64(len(data)),
+			}},
+		}); err != nil {
+			return err
+		}
+		sent++
+	}
+	return nil
+}
+
+func startDatasetServer(t *testing.T, ds services.DatasetServiceServer) *Client {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	services.RegisterHealthServiceServer(srv, &fakeHealthServer{status: services.ServingStatus_SERVING_STATUS_SERVING})
+	services.RegisterDatasetServiceServer(srv, ds)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	opts := failoverOptions(NodeTarget{Name: "node", TCPAddress: lis.Addr().String()})
+	opts.RetryPolicy.MaxAttempts = 1
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestExportDataset(t *testing.T) {
+	ds := &fakeDatasetServer{versionID: "v1", content: []byte("the quick brown fox jumps")}
+	c := startDatasetServer(t, ds)
+	dir := t.TempDir()
+
+	var progress []int
+	result, err := c.ExportDataset(context.Background(), "ds-1", dir, ExportOptions{
+		Progress: func(p ExportProgress) { progress = append(progress, p.CompletedChunks) },
+	})
+	if err != nil {
+		t.Fatalf("ExportDataset failed: %v", err)
+	}
+
+	got, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if !bytes.Equal(got, ds.content) {
+		t.Errorf("exported %q, expected %q", got, ds.content)
+	}
+	if len(progress) != 7 || progress[6] != 7 {
+		t.Errorf("unexpected progress reports: %v", progress)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ds-1", exportMetadataFile)); err != nil {
+		t.Errorf("metadata file missing: %v", err)
+	}
+
+	// A second run only fetches metadata
+	result, err = c.ExportDataset(context.Background(), "ds-1", dir, ExportOptions{})
+	if err != nil {
+		t.Fatalf("second ExportDataset failed: %v", err)
+	}
+	if !result.Skipped {
+		t.Error("expected an already-exported dataset to be skipped")
+	}
+}
+
+func TestExportDataset_Resume(t *testing.T) {
+	ds := &fakeDatasetServer{versionID: "v1", content: []byte("the quick brown fox jumps"), failAfter: 3}
+	c := startDatasetServer(t, ds)
+	dir := t.TempDir()
+
+	if _, err := c.ExportDataset(context.Background(), "ds-1", dir, ExportOptions{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected interrupted export, got %v", err)
+	}
+
+	result, err := c.ExportDataset(context.Background(), "ds-1", dir, ExportOptions{})
+	if err != nil {
+		t.Fatalf("resumed ExportDataset failed: %v", err)
+	}
+	if !result.Resumed {
+		t.Error("expected export to be resumed")
+	}
+	if ds.starts[1] != 3 {
+		t.Errorf("expected resume from chunk 3, got %d", ds.starts[1])
+	}
+
+	got, _ := os.ReadFile(result.Path)
+	if !bytes.Equal(got, ds.content) {
+		t.Errorf("exported %q, expected %q", got, ds.content)
+	}
+}
+
+func TestExportDataset_NewVersionRestarts(t *testing.T) {
+	ds := &fakeDatasetServer{versionID: "v1", content: []byte("the quick brown fox jumps"), failAfter: 3}
+	c := startDatasetServer(t, ds)
+	dir := t.TempDir()
+
+	_, _ = c.ExportDataset(context.Background(), "ds-1", dir, ExportOptions{})
+
+	ds.versionID = "v2"
+	ds.content = []byte("a shorter one")
+
+	result, err := c.ExportDataset(context.Background(), "ds-1", dir, ExportOptions{})
+	if err != nil {
+		t.Fatalf("ExportDataset failed: %v", err)
+	}
+	if result.Resumed {
+		t.Error("a new version must not resume the old partial export")
+	}
+
+	got, _ := os.ReadFile(result.Path)
+	if !bytes.Equal(got, ds.content) {
+		t.Errorf("exported %q, expected %q", got, ds.content)
+	}
+}
+
+func TestExportDataset_RejectsCorruptChunk(t *testing.T) {
+	ds := &fakeDatasetServer{versionID: "v1", content: []byte("the quick brown fox jumps"), corrupt: true}
+	c := startDatasetServer(t, ds)
+
+	_, err := c.ExportDataset(context.Background(), "ds-1", t.TempDir(), ExportOptions{})
+	if !errors.Is(err, domain.ErrHashMismatch) {
+		t.Errorf("expected hash mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+// Package client provides a gRPC client library for connecting to bibd.
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// NodeTarget is a daemon the client can connect to. When Options.Nodes
+// holds several targets, the client fails over between them by priority.
+type NodeTarget struct {
+	// Name identifies the node in logs and ServedBy (alias or node ID)
+	Name string
+
+	// Priority orders failover candidates (lower = preferred)
+	Priority int
+
+	// Connection targets for this node
+	UnixSocket string
+	TCPAddress string
+	P2PPeerID  string
+}
+
+// displayName returns the node's name, or fallback if it has none.
+func (n NodeTarget) displayName(fallback string) string {
+	if n.Name != "" {
+		return n.Name
+	}
+	return fallback
+}
+
+// nodes returns the configured failover candidates. Without Options.Nodes
+// the top-level targets form a single unnamed node.
+func (o *Options) nodes() []NodeTarget {
+	if len(o.Nodes) > 0 {
+		return o.Nodes
+	}
+	return []NodeTarget{{
+		UnixSocket: o.UnixSocket,
+		TCPAddress: o.TCPAddress,
+		P2PPeerID:  o.P2PPeerID,
+	}}
+}
+
+// rankedNodes returns nodes ordered by priority. Nodes that failed within
+// the cooldown are moved behind healthy ones, so a recovered higher-priority
+// node is preferred again once its cooldown expires.
+func (c *Client) rankedNodes() []NodeTarget {
+	nodes := append([]NodeTarget(nil), c.opts.nodes()...)
+
+	c.failureLock.Lock()
+	cooling := make(map[NodeTarget]bool, len(c.failures))
+	for node, failedAt := range c.failures {
+		if time.Since(failedAt) < c.opts.FailoverCooldown {
+			cooling[node] = true
+		}
+	}
+	c.failureLock.Unlock()
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if cooling[nodes[i]] != cooling[nodes[j]] {
+			return !cooling[nodes[i]]
+		}
+		return nodes[i].Priority < nodes[j].Priority
+	})
+
+	return nodes
+}
+
+// markFailed records a connection failure or unhealthy report for node.
+func (c *Client) markFailed(node NodeTarget) {
+	c.failureLock.Lock()
+	defer c.failureLock.Unlock()
+	c.failures[node] = time.Now()
+}
+
+// checkHealth asks the node whether it is serving.
+func (c *Client) checkHealth(ctx context.Context, conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	resp, err := services.NewHealthServiceClient(conn).Check(ctx, &services.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	if resp.GetStatus() != services.ServingStatus_SERVING_STATUS_SERVING {
+		return fmt.Errorf("node reports %s", resp.GetStatus())
+	}
+	return nil
+}
+
+// transportFailureWait bounds how long shouldFailover waits for a
+// connection that failed a call to leave the ready state.
+const transportFailureWait = 250 * time.Millisecond
+
+// shouldFailover reports whether err means the node behind conn is down:
+// the call failed with Unavailable in the transport, and the connection
+// left the ready state. Unavailable errors the node answered with itself,
+// such as load shedding or the readiness gate while it starts, carry
+// details or leave the connection ready, and are returned to the caller.
+func (c *Client) shouldFailover(ctx context.Context, conn *grpc.ClientConn, err error) bool {
+	if len(c.opts.Nodes) < 2 || ctx.Err() != nil {
+		return false
+	}
+	st := status.Convert(err)
+	if st.Code() != codes.Unavailable || len(st.Details()) > 0 {
+		return false
+	}
+
+	// A broken transport fails the call before the connection reports it
+	if conn.GetState() != connectivity.Ready {
+		return true
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, transportFailureWait)
+	defer cancel()
+	return conn.WaitForStateChange(waitCtx, connectivity.Ready)
+}
+
+// failover marks the node behind failed as down and connects to the next
+// best node. It is a no-op if another call already replaced the pool. The
+// replaced pool is drained, so other calls in flight on it can complete.
+// If no node can be reached, the pool is kept: its connections keep
+// reconnecting, and the next failed call tries the other nodes again.
+func (c *Client) failover(ctx context.Context, failed *grpc.ClientConn) error {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+
+	if !poolContains(c.pool, failed) {
+		return nil
+	}
+
+	c.markFailed(c.node)
+	old := c.pool
+	if err := c.connectLocked(ctx); err != nil {
+		return err
+	}
+	c.drainPool(old)
+	return nil
+}
+
+// drainPool closes a replaced pool once the calls in flight on it had time
+// to complete.
+func (c *Client) drainPool(pool []*grpc.ClientConn) {
+	go func() {
+		time.Sleep(c.opts.Timeout)
+		_ = closePool(pool)
+	}()
+}
+
+// maybeFailback starts a background attempt to move back to a
+// higher-priority node once the cooldown has passed.
+func (c *Client) maybeFailback() {
+	if len(c.opts.Nodes) < 2 {
+		return
+	}
+
+	c.connLock.RLock()
+	current, checkedAt, connected := c.node, c.nodeCheckedAt, c.connected
+	c.connLock.RUnlock()
+
+	if !connected || time.Since(checkedAt) < c.opts.FailoverCooldown {
+		return
+	}
+	if best := c.rankedNodes()[0]; best.Priority >= current.Priority {
+		return
+	}
+	if !c.failingBack.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer c.failingBack.Store(false)
+		ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+		defer cancel()
+		c.failback(ctx, current)
+	}()
+}
+
+// failback connects to the best node preferred over from and swaps the
+// pool over to it. Calls in flight on the old pool are allowed to finish.
+func (c *Client) failback(ctx context.Context, from NodeTarget) {
+	for _, node := range c.rankedNodes() {
+		if node.Priority >= from.Priority {
+			continue
+		}
+
+		conn, target, err := c.connectNode(ctx, node)
+		if err != nil {
+			c.markFailed(node)
+			continue
+		}
+		if err := c.checkHealth(ctx, conn); err != nil {
+			_ = conn.Close()
+			c.markFailed(node)
+			continue
+		}
+		pool := c.buildPool(ctx, conn, target)
+
+		c.connLock.Lock()
+		if !c.connected || c.node != from {
+			// Closed or failed over meanwhile; keep the current state
+			c.connLock.Unlock()
+			_ = closePool(pool)
+			return
+		}
+		old := c.pool
+		c.pool = pool
+		c.connectedTo = target
+		c.node = node
+		c.nodeCheckedAt = time.Now()
+		c.connLock.Unlock()
+
+		c.drainPool(old)
+		return
+	}
+
+	c.connLock.Lock()
+	if c.node == from {
+		c.nodeCheckedAt = time.Now()
+	}
+	c.connLock.Unlock()
+}
+
+// poolContains reports whether conn belongs to pool.
+func poolContains(pool []*grpc.ClientConn, conn *grpc.ClientConn) bool {
+	for _, pc := range pool {
+		if pc == conn {
+			return true
+		}
+	}
+	return false
+}
+
+// Invoke implements grpc.ClientConnInterface. Each call uses a pooled
+// connection; if the node is unavailable the client fails over and
+// idempotent calls are re-sent to the new node.
+func (c *Client) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	c.maybeFailback()
+
+	conn, err := c.getConn()
+	if err != nil {
+		return err
+	}
+
+	err = conn.Invoke(ctx, method, args, reply, opts...)
+	if err != nil && c.shouldFailover(ctx, conn, err) {
+		if ferr := c.failover(ctx, conn); ferr == nil && IsIdempotentMethod(method) {
+			if conn, cerr := c.getConn(); cerr == nil {
+				err = conn.Invoke(ctx, method, args, reply, opts...)
+			}
+		}
+	}
+
+	c.recordServedBy(opts)
+	return err
+}
+
+// NewStream implements grpc.ClientConnInterface. Stream creation is
+// retried on the next node after a failover since nothing has been sent yet.
+func (c *Client) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	c.maybeFailback()
+
+	conn, err := c.getConn()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := conn.NewStream(ctx, desc, method, opts...)
+	if err != nil && c.shouldFailover(ctx, conn, err) {
+		if ferr := c.failover(ctx, conn); ferr == nil {
+			if conn, cerr := c.getConn(); cerr == nil {
+				stream, err = conn.NewStream(ctx, desc, method, opts...)
+			}
+		}
+	}
+
+	c.recordServedBy(opts)
+	return stream, err
+}
+
+// servedByOption is a CallOption that receives the serving node's name.
+type servedByOption struct {
+	grpc.EmptyCallOption
+	node *string
+}
+
+// ServedBy returns a call option that stores the name of the node that
+// served the call in node, for debugging multi-node deployments.
+//
+//	var node string
+//	resp, err := topics.GetTopic(ctx, req, client.ServedBy(&node))
+func ServedBy(node *string) grpc.CallOption {
+	return servedByOption{node: node}
+}
+
+// recordServedBy fills any ServedBy options with the current node.
+func (c *Client) recordServedBy(opts []grpc.CallOption) {
+	for _, opt := range opts {
+		if o, ok := opt.(servedByOption); ok && o.node != nil {
+			*o.node = c.ConnectedNode()
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
+)
+
+// fakeHealthServer reports a fixed serving status.
+type fakeHealthServer struct {
+	services.UnimplementedHealthServiceServer
+	status services.ServingStatus
+}
+
+func (s *fakeHealthServer) Check(ctx context.Context, req *services.HealthCheckRequest) (*services.HealthCheckResponse, error) {
+	return &services.HealthCheckResponse{Status: s.status}, nil
+}
+
+func (s *fakeHealthServer) Ping(ctx context.Context, req *services.PingRequest) (*services.PingResponse, error) {
+	return &services.PingResponse{}, nil
+}
+
+// startHealthServer starts a TCP gRPC server and returns its address.
+func startHealthServer(t *testing.T, status services.ServingStatus) (string, *grpc.Server) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	services.RegisterHealthServiceServer(srv, &fakeHealthServer{status: status})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), srv
+}
+
+// unusedAddress returns a TCP address nothing is listening on.
+func unusedAddress(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	return addr
+}
+
+func failoverOptions(nodes ...NodeTarget) Options {
+	return DefaultOptions().
+		WithNodes(nodes...).
+		WithInsecure().
+		WithAutoLocal(false).
+		WithTimeout(2*time.Second).
+		WithRetry(0, 10*time.Millisecond).
+		WithPoolSize(1)
+}
+
+func TestRankedNodes(t *testing.T) {
+	primary := NodeTarget{Name: "primary", Priority: 1, TCPAddress: "a:4000"}
+	secondary := NodeTarget{Name: "secondary", Priority: 2, TCPAddress: "b:4000"}
+	tertiary := NodeTarget{Name: "tertiary", Priority: 3, TCPAddress: "c:4000"}
+
+	c, err := New(failoverOptions(tertiary, primary, secondary))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ranked := c.rankedNodes()
+	if ranked[0] != primary || ranked[1] != secondary || ranked[2] != tertiary {
+		t.Errorf("expected priority order, got %v", ranked)
+	}
+
+	// A recent failure moves the node to the back
+	c.markFailed(primary)
+	ranked = c.rankedNodes()
+	if ranked[0] != secondary || ranked[2] != primary {
+		t.Errorf("expected failed node last, got %v", ranked)
+	}
+
+	// After the cooldown it is preferred again
+	c.failures[primary] = time.Now().Add(-2 * c.opts.FailoverCooldown)
+	if ranked = c.rankedNodes(); ranked[0] != primary {
+		t.Errorf("expected recovered node first, got %v", ranked)
+	}
+}
+
+func TestConnect_FailsOverToNextNode(t *testing.T) {
+	addr, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+
+	c, err := New(failoverOptions(
+		NodeTarget{Name: "down", Priority: 1, TCPAddress: unusedAddress(t)},
+		NodeTarget{Name: "up", Priority: 2, TCPAddress: addr},
+	))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if got := c.ConnectedNode(); got != "up" {
+		t.Errorf("ConnectedNode() = %q, expected %q", got, "up")
+	}
+}
+
+func TestConnect_SkipsUnhealthyNode(t *testing.T) {
+	sick, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_NOT_SERVING)
+	healthy, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+
+	c, err := New(failoverOptions(
+		NodeTarget{Name: "sick", Priority: 1, TCPAddress: sick},
+		NodeTarget{Name: "healthy", Priority: 2, TCPAddress: healthy},
+	))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if got := c.ConnectedNode(); got != "healthy" {
+		t.Errorf("ConnectedNode() = %q, expected %q", got, "healthy")
+	}
+}
+
+func TestInvoke_FailsOverAndReportsServedBy(t *testing.T) {
+	first, firstSrv := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+	second, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+
+	opts := failoverOptions(
+		NodeTarget{Name: "first", Priority: 1, TCPAddress: first},
+		NodeTarget{Name: "second", Priority: 2, TCPAddress: second},
+	)
+	opts.RetryPolicy.MaxAttempts = 1
+
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	health, err := c.Health()
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+
+	var node string
+	if _, err := health.Ping(ctx, &services.PingRequest{}, ServedBy(&node)); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if node != "first" {
+		t.Errorf("expected first node to serve, got %q", node)
+	}
+
+	firstSrv.Stop()
+
+	if _, err := health.Ping(ctx, &services.PingRequest{}, ServedBy(&node)); err != nil {
+		t.Fatalf("Ping after failover failed: %v", err)
+	}
+	if node != "second" {
+		t.Errorf("expected second node to serve after failover, got %q", node)
+	}
+}
+
+func TestFailback_ReturnsToPreferredNode(t *testing.T) {
+	primaryAddr := unusedAddress(t)
+	backup, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+
+	c, err := New(failoverOptions(
+		NodeTarget{Name: "primary", Priority: 1, TCPAddress: primaryAddr},
+		NodeTarget{Name: "backup", Priority: 2, TCPAddress: backup},
+	))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if got := c.ConnectedNode(); got != "backup" {
+		t.Fatalf("expected backup while primary is down, got %q", got)
+	}
+
+	// Primary recovers
+	lis, err := net.Listen("tcp", primaryAddr)
+	if err != nil {
+		t.Skipf("could not reuse primary address: %v", err)
+	}
+	srv := grpc.NewServer()
+	services.RegisterHealthServiceServer(srv, &fakeHealthServer{status: services.ServingStatus_SERVING_STATUS_SERVING})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	c.connLock.RLock()
+	from := c.node
+	c.connLock.RUnlock()
+
+	c.failback(ctx, from)
+
+	if got := c.ConnectedNode(); got != "primary" {
+		t.Errorf("expected failback to primary, got %q", got)
+	}
+}
+
+// overloadedHealthServer sheds every Ping the way the load shedding
+// middleware does.
+type overloadedHealthServer struct {
+	fakeHealthServer
+}
+
+func (s *overloadedHealthServer) Ping(ctx context.Context, req *services.PingRequest) (*services.PingResponse, error) {
+	st, _ := status.New(codes.Unavailable, "node overloaded, try again later").WithDetails(&errdetails.ErrorInfo{
+		Reason: "OVERLOADED",
+		Domain: "bib.dev",
+	})
+	return nil, st.Err()
+}
+
+func TestInvoke_NoFailoverWhenNodeAnswers(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	services.RegisterHealthServiceServer(srv, &overloadedHealthServer{
+		fakeHealthServer{status: services.ServingStatus_SERVING_STATUS_SERVING},
+	})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	second, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+
+	opts := failoverOptions(
+		NodeTarget{Name: "busy", Priority: 1, TCPAddress: lis.Addr().String()},
+		NodeTarget{Name: "second", Priority: 2, TCPAddress: second},
+	)
+	opts.RetryPolicy.MaxAttempts = 1
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	health, _ := c.Health()
+
+	// A shed call is the node's answer; the client stays on the node
+	var node string
+	if _, err := health.Ping(ctx, &services.PingRequest{}, ServedBy(&node)); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Ping error = %v, want Unavailable", err)
+	}
+	if node != "busy" {
+		t.Errorf("expected no failover on a shed call, served by %q", node)
+	}
+
+	// So is an Unavailable error without details on a ready connection
+	conn, err := c.getConn()
+	if err != nil {
+		t.Fatalf("getConn failed: %v", err)
+	}
+	if c.shouldFailover(ctx, conn, status.Error(codes.Unavailable, "service not initialized")) {
+		t.Error("expected no failover for an error the node answered with")
+	}
+}
+
+func TestFailover_DrainsOldPool(t *testing.T) {
+	first, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+	second, _ := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+
+	c, err := New(failoverOptions(
+		NodeTarget{Name: "first", Priority: 1, TCPAddress: first},
+		NodeTarget{Name: "second", Priority: 2, TCPAddress: second},
+	))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	old, err := c.getConn()
+	if err != nil {
+		t.Fatalf("getConn failed: %v", err)
+	}
+
+	if err := c.failover(ctx, old); err != nil {
+		t.Fatalf("failover failed: %v", err)
+	}
+	if got := c.ConnectedNode(); got != "second" {
+		t.Errorf("ConnectedNode() = %q, expected %q", got, "second")
+	}
+
+	// Calls in flight on the old pool can still complete
+	if old.GetState() == connectivity.Shutdown {
+		t.Fatal("old pool closed while calls may be in flight on it")
+	}
+	if _, err := services.NewHealthServiceClient(old).Ping(ctx, &services.PingRequest{}); err != nil {
+		t.Errorf("Ping on the old pool failed: %v", err)
+	}
+}
+
+func TestInvoke_ReconnectsAfterAllNodesFailed(t *testing.T) {
+	firstAddr := unusedAddress(t)
+	lis, err := net.Listen("tcp", firstAddr)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	firstSrv := grpc.NewServer()
+	services.RegisterHealthServiceServer(firstSrv, &fakeHealthServer{status: services.ServingStatus_SERVING_STATUS_SERVING})
+	go func() { _ = firstSrv.Serve(lis) }()
+	second, secondSrv := startHealthServer(t, services.ServingStatus_SERVING_STATUS_SERVING)
+
+	opts := failoverOptions(
+		NodeTarget{Name: "first", Priority: 1, TCPAddress: firstAddr},
+		NodeTarget{Name: "second", Priority: 2, TCPAddress: second},
+	)
+	opts.RetryPolicy.MaxAttempts = 1
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	ctx := context.Background()
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	health, _ := c.Health()
+
+	firstSrv.Stop()
+	secondSrv.Stop()
+	if _, err := health.Ping(ctx, &services.PingRequest{}); err == nil {
+		t.Fatal("expected Ping to fail with every node down")
+	}
+	if !c.IsConnected() {
+		t.Fatal("expected the client to keep its pool with every node down")
+	}
+
+	// The first node comes back
+	lis, err = net.Listen("tcp", firstAddr)
+	if err != nil {
+		t.Skipf("could not reuse the first node's address: %v", err)
+	}
+	srv := grpc.NewServer()
+	services.RegisterHealthServiceServer(srv, &fakeHealthServer{status: services.ServingStatus_SERVING_STATUS_SERVING})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	var node string
+	if _, err := health.Ping(ctx, &services.PingRequest{}, ServedBy(&node)); err != nil {
+		t.Fatalf("Ping after a node recovered failed: %v", err)
+	}
+	if node != "first" {
+		t.Errorf("expected the recovered node to serve, got %q", node)
+	}
+}
@@ -0,0 +1,427 @@
+// Package client provides a gRPC client library for connecting to bibd.
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// importManifestFile records what was imported from a directory, so a
+	// re-run adds versions to the same datasets and skips re-hashing
+	// unchanged files.
+	importManifestFile = ".bib-import.json"
+
+	// DefaultImportChunkSize is the chunk size used to split imported files.
+	DefaultImportChunkSize = 1024 * 1024
+
+	// findMissingBatch matches the server's per-request limit.
+	findMissingBatch = 10000
+)
+
+// ImportOptions configures ImportDirectory.
+type ImportOptions struct {
+	// TopicID is the topic new datasets are created in. Exported datasets
+	// (with a dataset.json) default to their original topic.
+	TopicID string
+
+	// ChunkSize splits files into chunks (0 = DefaultImportChunkSize)
+	ChunkSize int64
+
+	// DryRun reports what would be uploaded without uploading
+	DryRun bool
+
+	// Progress is called after each chunk is sent (optional)
+	Progress func(ImportProgress)
+}
+
+// ImportProgress reports the state of a running import.
+type ImportProgress struct {
+	Path       string
+	SentChunks int
+	ToSend     int
+}
+
+// ImportResult describes one imported file.
+type ImportResult struct {
+	Path      string // Relative to the imported directory
+	Name      string
+	DatasetID string // Empty for new datasets in a dry run
+	Size      int64
+	Chunks    int
+
+	// PresentBytes are already stored on the node and were not sent
+	PresentBytes int64
+	// UploadBytes were (or in a dry run, would be) sent
+	UploadBytes int64
+
+	Created   bool  // A new dataset was created
+	Unchanged bool  // Content matched the latest version
+	Version   int32 // Version number on the node
+
+	// SchemaViolations lists where the content does not match the data
+	// schema; only set when the schema is in "warn" mode
+	SchemaViolations []string
+}
+
+// importEntry is one file to import.
+type importEntry struct {
+	path    string // Absolute
+	rel     string // Slash-separated, relative to the import root
+	dataset *services.Dataset
+}
+
+// importRecord is the manifest entry for one imported file.
+type importRecord struct {
+	DatasetID   string    `json:"dataset_id,omitempty"`
+	Size        int64     `json:"size"`
+	ModTime     time.Time `json:"mod_time"`
+	ChunkSize   int64     `json:"chunk_size"`
+	ContentHash string    `json:"content_hash"`
+	ChunkHashes []string  `json:"chunk_hashes"`
+}
+
+// ImportDirectory uploads every file under dir as a dataset. Directories
+// written by ExportDataset are recognised and keep their name, description
+// and tags; any other file becomes a dataset named after its path.
+//
+// Files are split into chunks and only chunks the node doesn't already have
+// are sent, so re-running an interrupted import, or importing data the node
+// partly holds, only transfers what is missing.
+func (c *Client) ImportDirectory(ctx context.Context, dir string, opts ImportOptions) ([]*ImportResult, error) {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultImportChunkSize
+	}
+
+	datasets, err := c.Dataset()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := findImportEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := loadImportManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ImportResult, 0, len(entries))
+	for _, entry := range entries {
+		result, err := importEntryFile(ctx, datasets, entry, manifest, opts)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", entry.rel, err)
+		}
+		results = append(results, result)
+
+		if !opts.DryRun {
+			if err := saveImportManifest(dir, manifest); err != nil {
+				return results, err
+			}
+		}
+	}
+	return results, nil
+}
+
+// importEntryFile hashes one file and uploads the chunks the node lacks.
+func importEntryFile(ctx context.Context, datasets services.DatasetServiceClient, entry importEntry, manifest map[string]*importRecord, opts ImportOptions) (*ImportResult, error) {
+	info, err := os.Stat(entry.path)
+	if err != nil {
+		return nil, err
+	}
+
+	record := manifest[entry.rel]
+	if record == nil || record.Size != info.Size() || !record.ModTime.Equal(info.ModTime()) || record.ChunkSize != opts.ChunkSize {
+		contentHash, chunkHashes, err := hashFileChunks(entry.path, opts.ChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		datasetID := ""
+		if record != nil {
+			datasetID = record.DatasetID
+		}
+		record = &importRecord{
+			DatasetID:   datasetID,
+			Size:        info.Size(),
+			ModTime:     info.ModTime(),
+			ChunkSize:   opts.ChunkSize,
+			ContentHash: contentHash,
+			ChunkHashes: chunkHashes,
+		}
+		manifest[entry.rel] = record
+	}
+
+	result := &ImportResult{
+		Path:      entry.rel,
+		Name:      entry.dataset.GetName(),
+		DatasetID: record.DatasetID,
+		Size:      record.Size,
+		Chunks:    len(record.ChunkHashes),
+	}
+
+	missing, err := findMissing(ctx, datasets, record.ChunkHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send each missing hash once, even if the file repeats it
+	var toSend []int
+	for idx, h := range record.ChunkHashes {
+		size := chunkLength(record.Size, record.ChunkSize, idx)
+		if missing[h] {
+			delete(missing, h)
+			toSend = append(toSend, idx)
+			result.UploadBytes += size
+		} else {
+			result.PresentBytes += size
+		}
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	meta := &services.UploadMetadata{
+		DatasetId:    record.DatasetID,
+		TotalSize:    record.Size,
+		ExpectedHash: record.ContentHash,
+		ChunkSize:    record.ChunkSize,
+		ChunkHashes:  record.ChunkHashes,
+	}
+	if record.DatasetID == "" {
+		meta.TopicId = opts.TopicID
+		if meta.TopicId == "" {
+			meta.TopicId = entry.dataset.GetTopicId()
+		}
+		if meta.TopicId == "" {
+			return nil, fmt.Errorf("no topic to create the dataset in (use a topic ID)")
+		}
+		meta.Name = entry.dataset.GetName()
+		meta.Description = entry.dataset.GetDescription()
+		meta.Tags = entry.dataset.GetTags()
+		meta.Metadata = entry.dataset.GetMetadata()
+	}
+
+	resp, err := uploadChunks(ctx, datasets, entry, meta, toSend, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Created = record.DatasetID == ""
+	result.DatasetID = resp.GetDataset().GetId()
+	result.Unchanged = resp.GetUnchanged()
+	result.Version = resp.GetVersion()
+	for _, v := range resp.GetSchemaViolations() {
+		result.SchemaViolations = append(result.SchemaViolations,
+			fmt.Sprintf("record %d, %s: %s", v.GetRecord(), v.GetPath(), v.GetMessage()))
+	}
+	record.DatasetID = result.DatasetID
+	return result, nil
+}
+
+// uploadChunks streams the metadata and the chunks at the given indices.
+func uploadChunks(ctx context.Context, datasets services.DatasetServiceClient, entry importEntry, meta *services.UploadMetadata, indices []int, opts ImportOptions) (*services.UploadDatasetResponse, error) {
+	file, err := os.Open(entry.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stream, err := datasets.UploadDataset(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(&services.UploadDatasetRequest{
+		Data: &services.UploadDatasetRequest_Metadata{Metadata: meta},
+	}); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, meta.GetChunkSize())
+	for sent, idx := range indices {
+		n, err := file.ReadAt(buf, int64(idx)*meta.GetChunkSize())
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read chunk %d: %w", idx, err)
+		}
+
+		if err := stream.Send(&services.UploadDatasetRequest{
+			Data: &services.UploadDatasetRequest_IndexedChunk{IndexedChunk: &services.UploadChunk{
+				Index: int32(idx),
+				Data:  buf[:n],
+			}},
+		}); err != nil {
+			// The server's status explains why it ended the stream
+			if _, recvErr := stream.CloseAndRecv(); recvErr != nil {
+				return nil, recvErr
+			}
+			return nil, err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(ImportProgress{Path: entry.rel, SentChunks: sent + 1, ToSend: len(indices)})
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// findMissing returns the set of hashes the node does not have.
+func findMissing(ctx context.Context, datasets services.DatasetServiceClient, hashes []string) (map[string]bool, error) {
+	missing := make(map[string]bool)
+	for start := 0; start < len(hashes); start += findMissingBatch {
+		end := min(start+findMissingBatch, len(hashes))
+		resp, err := datasets.FindMissingChunks(ctx, &services.FindMissingChunksRequest{Hashes: hashes[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range resp.GetMissing() {
+			missing[h] = true
+		}
+	}
+	return missing, nil
+}
+
+// hashFileChunks returns the SHA-256 of the whole file and of each chunk.
+func hashFileChunks(path string, chunkSize int64) (string, []string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	total := sha256.New()
+	var chunks []string
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := io.ReadFull(file, buf)
+		if n > 0 {
+			sum := sha256.Sum256(buf[:n])
+			chunks = append(chunks, hex.EncodeToString(sum[:]))
+			total.Write(buf[:n])
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return hex.EncodeToString(total.Sum(nil)), chunks, nil
+}
+
+// chunkLength returns the length of chunk idx of a file of the given size.
+func chunkLength(size, chunkSize int64, idx int) int64 {
+	return min(chunkSize, size-int64(idx)*chunkSize)
+}
+
+// findImportEntries lists the files to import under dir, in walk order.
+func findImportEntries(dir string) ([]importEntry, error) {
+	var entries []importEntry
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			// A directory written by ExportDataset is one dataset
+			dataset, ok, err := readExportedDataset(path)
+			if err != nil {
+				return err
+			}
+			if ok {
+				dataPath := filepath.Join(path, exportDataFile)
+				entries = append(entries, importEntry{path: dataPath, rel: relSlash(dir, dataPath), dataset: dataset})
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		// Empty files have no content to make a version from
+		if info, err := d.Info(); err != nil || info.Size() == 0 {
+			return err
+		}
+		rel := relSlash(dir, path)
+		entries = append(entries, importEntry{path: path, rel: rel, dataset: &services.Dataset{Name: rel}})
+		return nil
+	})
+	return entries, err
+}
+
+// readExportedDataset reads dataset.json if dir has the export layout.
+func readExportedDataset(dir string) (*services.Dataset, bool, error) {
+	if _, err := os.Stat(filepath.Join(dir, exportDataFile)); err != nil {
+		return nil, false, nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, exportMetadataFile))
+	if err != nil {
+		return nil, false, nil
+	}
+
+	var dataset services.Dataset
+	if err := protojson.Unmarshal(data, &dataset); err != nil {
+		return nil, false, fmt.Errorf("invalid %s in %s: %w", exportMetadataFile, dir, err)
+	}
+	if dataset.GetName() == "" {
+		dataset.Name = filepath.Base(dir)
+	}
+	return &dataset, true, nil
+}
+
+func relSlash(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func loadImportManifest(dir string) (map[string]*importRecord, error) {
+	manifest := make(map[string]*importRecord)
+	data, err := os.ReadFile(filepath.Join(dir, importManifestFile))
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid import manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func saveImportManifest(dir string, manifest map[string]*importRecord) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, importManifestFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to save import manifest: %w", err)
+	}
+	return nil
+}
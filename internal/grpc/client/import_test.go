@@ -0,0 +1,193 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"google.golang.org/grpc"
+)
+
+// fakeUploadServer stores uploaded chunks by hash, like blob storage.
+type fakeUploadServer struct {
+	fakeDatasetServer
+	blobs    map[string][]byte
+	datasets map[string]string // dataset ID -> latest content hash
+	sent     int
+}
+
+func newFakeUploadServer() *fakeUploadServer {
+	return &fakeUploadServer{blobs: make(map[string][]byte), datasets: make(map[string]string)}
+}
+
+func (s *fakeUploadServer) FindMissingChunks(ctx context.Context, req *services.FindMissingChunksRequest) (*services.FindMissingChunksResponse, error) {
+	resp := &services.FindMissingChunksResponse{}
+	for _, h := range req.GetHashes() {
+		if _, ok := s.blobs[h]; !ok {
+			resp.Missing = append(resp.Missing, h)
+		}
+	}
+	return resp, nil
+}
+
+func (s *fakeUploadServer) UploadDataset(stream grpc.ClientStreamingServer[services.UploadDatasetRequest, services.UploadDatasetResponse]) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		chunk := req.GetIndexedChunk()
+		s.blobs[meta.GetChunkHashes()[chunk.GetIndex()]] = bytes.Clone(chunk.GetData())
+		s.sent++
+	}
+
+	var content []byte
+	for _, h := range meta.GetChunkHashes() {
+		content = append(content, s.blobs[h]...)
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != meta.GetExpectedHash() {
+		return io.ErrUnexpectedEOF
+	}
+
+	id := meta.GetDatasetId()
+	if id == "" {
+		id = "ds-" + meta.GetName()
+	}
+	unchanged := s.datasets[id] == meta.GetExpectedHash()
+	s.datasets[id] = meta.GetExpectedHash()
+
+	return stream.SendAndClose(&services.UploadDatasetResponse{
+		Dataset:   &services.Dataset{Id: id, Name: meta.GetName(), TopicId: meta.GetTopicId()},
+		Unchanged: unchanged,
+	})
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestImportDirectory(t *testing.T) {
+	srv := newFakeUploadServer()
+	c := startDatasetServer(t, srv)
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "a.csv"), []byte("aaaabbbbcccc"))
+	writeFile(t, filepath.Join(dir, "nested", "b.csv"), []byte("aaaabbbbdd"))
+	writeFile(t, filepath.Join(dir, ".hidden"), []byte("ignored"))
+
+	opts := ImportOptions{TopicID: "weather", ChunkSize: 4}
+
+	// Dry run: b.csv shares two chunks with a.csv but nothing is stored yet
+	results, err := c.ImportDirectory(context.Background(), dir, ImportOptions{TopicID: "weather", ChunkSize: 4, DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(results))
+	}
+	if srv.sent != 0 {
+		t.Errorf("dry run sent %d chunks", srv.sent)
+	}
+
+	results, err = c.ImportDirectory(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("ImportDirectory failed: %v", err)
+	}
+	if !results[0].Created || results[0].UploadBytes != 12 {
+		t.Errorf("a.csv: expected 12 bytes uploaded to a new dataset, got %+v", results[0])
+	}
+	if results[1].PresentBytes != 8 || results[1].UploadBytes != 2 {
+		t.Errorf("nested/b.csv: expected 8 bytes deduplicated and 2 uploaded, got %+v", results[1])
+	}
+	if results[1].Name != "nested/b.csv" {
+		t.Errorf("expected dataset named after its path, got %q", results[1].Name)
+	}
+
+	// A re-run sends nothing and reuses the recorded datasets
+	results, err = c.ImportDirectory(context.Background(), dir, opts)
+	if err != nil {
+		t.Fatalf("second ImportDirectory failed: %v", err)
+	}
+	for _, r := range results {
+		if r.Created || !r.Unchanged || r.UploadBytes != 0 {
+			t.Errorf("%s: expected unchanged re-import, got %+v", r.Path, r)
+		}
+	}
+	if len(srv.datasets) != 2 {
+		t.Errorf("expected 2 datasets, got %d", len(srv.datasets))
+	}
+}
+
+func TestImportDirectory_ExportLayout(t *testing.T) {
+	srv := newFakeUploadServer()
+	c := startDatasetServer(t, srv)
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "ds-1", exportDataFile), []byte("exported"))
+	writeFile(t, filepath.Join(dir, "ds-1", exportMetadataFile), []byte(`{"name": "Daily Temps", "topicId": "weather"}`))
+	writeFile(t, filepath.Join(dir, "ds-1", exportStateFile), []byte(`{}`))
+
+	results, err := c.ImportDirectory(context.Background(), dir, ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportDirectory failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected the export directory to be one dataset, got %d", len(results))
+	}
+	if results[0].Name != "Daily Temps" || results[0].DatasetID != "ds-Daily Temps" {
+		t.Errorf("expected exported metadata to be kept, got %+v", results[0])
+	}
+}
+
+func TestImportDirectory_RequiresTopic(t *testing.T) {
+	c := startDatasetServer(t, newFakeUploadServer())
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.csv"), []byte("data"))
+
+	if _, err := c.ImportDirectory(context.Background(), dir, ImportOptions{}); err == nil {
+		t.Error("expected an error without a topic for new datasets")
+	}
+}
+
+func TestHashFileChunks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f")
+	writeFile(t, path, []byte("abcdefghij"))
+
+	total, chunks, err := hashFileChunks(path, 4)
+	if err != nil {
+		t.Fatalf("hashFileChunks failed: %v", err)
+	}
+	if len(chunks) != 3 {
+		t.Errorf("expected 3 chunks, got %d", len(chunks))
+	}
+	sum := sha256.Sum256([]byte("abcdefghij"))
+	if total != hex.EncodeToString(sum[:]) {
+		t.Error("content hash does not cover the whole file")
+	}
+	if chunkLength(10, 4, 2) != 2 {
+		t.Errorf("expected a 2 byte final chunk, got %d", chunkLength(10, 4, 2))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 )
 
 // buildUnaryInterceptors builds the chain of unary interceptors.
@@ -19,9 +20,23 @@ func (c *Client) buildUnaryInterceptors() []grpc.UnaryClientInterceptor {
 		interceptors = append(interceptors, requestIDUnaryInterceptor())
 	}
 
+	// Locale interceptor (asks the daemon for localized error messages)
+	if c.opts.Locale != "" {
+		interceptors = append(interceptors, localeUnaryInterceptor(c.opts.Locale))
+	}
+
+	// Retry interceptor (after request ID so all attempts share one ID,
+	// before auth so each attempt picks up the current token)
+	interceptors = append(interceptors, retryUnaryInterceptor(c.opts.RetryPolicy))
+
 	// Auth interceptor (adds session token)
 	interceptors = append(interceptors, c.authUnaryInterceptor())
 
+	// Compression interceptor
+	if c.opts.Compression.Algorithm != "" {
+		interceptors = append(interceptors, compressionUnaryInterceptor(c.opts.Compression))
+	}
+
 	// Logging interceptor
 	if c.opts.LoggingEnabled {
 		interceptors = append(interceptors, loggingUnaryInterceptor())
@@ -39,9 +54,19 @@ func (c *Client) buildStreamInterceptors() []grpc.StreamClientInterceptor {
 		interceptors = append(interceptors, requestIDStreamInterceptor())
 	}
 
+	// Locale interceptor (asks the daemon for localized error messages)
+	if c.opts.Locale != "" {
+		interceptors = append(interceptors, localeStreamInterceptor(c.opts.Locale))
+	}
+
 	// Auth interceptor (adds session token)
 	interceptors = append(interceptors, c.authStreamInterceptor())
 
+	// Compression interceptor
+	if c.opts.Compression.Algorithm != "" {
+		interceptors = append(interceptors, compressionStreamInterceptor(c.opts.Compression))
+	}
+
 	// Logging interceptor
 	if c.opts.LoggingEnabled {
 		interceptors = append(interceptors, loggingStreamInterceptor())
@@ -81,6 +106,58 @@ func ensureRequestID(ctx context.Context) context.Context {
 	return metadata.NewOutgoingContext(ctx, md)
 }
 
+// localeUnaryInterceptor sends the preferred locale with outgoing calls.
+func localeUnaryInterceptor(locale string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = ensureLocale(ctx, locale)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// localeStreamInterceptor sends the preferred locale with streaming calls.
+func localeStreamInterceptor(locale string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = ensureLocale(ctx, locale)
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// ensureLocale sets the accept-language metadata unless the caller already did.
+func ensureLocale(ctx context.Context, locale string) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		md = metadata.New(nil)
+	}
+
+	if len(md.Get(localeMetadataKey)) == 0 {
+		md = md.Copy()
+		md.Set(localeMetadataKey, locale)
+	}
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// compressionUnaryInterceptor compresses requests of at least MinSize bytes.
+func compressionUnaryInterceptor(opts CompressionOptions) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		if msg, ok := req.(proto.Message); ok && proto.Size(msg) >= opts.MinSize {
+			callOpts = append(callOpts, grpc.UseCompressor(opts.Algorithm))
+		}
+		return invoker(ctx, method, req, reply, cc, callOpts...)
+	}
+}
+
+// compressionStreamInterceptor compresses all messages sent on a stream.
+// Message sizes aren't known when the stream opens, so MinSize doesn't apply.
+func compressionStreamInterceptor(opts CompressionOptions) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if desc.ClientStreams {
+			callOpts = append(callOpts, grpc.UseCompressor(opts.Algorithm))
+		}
+		return streamer(ctx, desc, cc, method, callOpts...)
+	}
+}
+
 // authUnaryInterceptor adds the session token to outgoing calls.
 func (c *Client) authUnaryInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
@@ -0,0 +1,39 @@
+//go:build !windows
+
+package client
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// localScheme is the target scheme for local daemon connections.
+const localScheme = "unix"
+
+// localDialer returns a dialer for the daemon's Unix socket.
+// The address passed by gRPC is ignored in favour of path.
+func localDialer(path string) func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", path)
+	}
+}
+
+// DefaultSocketPath returns the socket a local daemon listens on by default,
+// the default of server.grpc.unix_socket. It is probed when connecting to a
+// loopback TCP address.
+func DefaultSocketPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share", "bibd", "bibd.sock")
+}
+
+// localSocketExists reports whether path is a Unix socket.
+func localSocketExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
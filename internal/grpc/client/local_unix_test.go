@@ -0,0 +1,135 @@
+//go:build !windows
+
+package client
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+
+	"google.golang.org/grpc"
+)
+
+func TestLocalDialer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bibd.sock")
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := localDialer(path)(ctx, "ignored")
+	if err != nil {
+		t.Fatalf("localDialer failed: %v", err)
+	}
+	conn.Close()
+}
+
+func TestClient_ConnectUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bibd.sock")
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	// TLS stays enabled; local targets must bypass it
+	opts := DefaultOptions().
+		WithUnixSocket(path).
+		WithTimeout(5 * time.Second).
+		WithPoolSize(1)
+
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+
+	if got, want := c.ConnectedTo(), "unix:"+path; got != want {
+		t.Errorf("ConnectedTo() = %q, want %q", got, want)
+	}
+}
+
+func TestTCPTargets_AutoLocal(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	c := &Client{opts: DefaultOptions()}
+
+	// No socket present: TCP only
+	got := c.tcpTargets(context.Background(), NodeTarget{TCPAddress: "localhost:4000"})
+	if len(got) != 1 || got[0] != "localhost:4000" {
+		t.Fatalf("expected TCP fallback without socket, got %v", got)
+	}
+
+	path := DefaultSocketPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatalf("failed to create socket dir: %v", err)
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer lis.Close()
+
+	got = c.tcpTargets(context.Background(), NodeTarget{TCPAddress: "localhost:4000"})
+	if len(got) != 2 || got[0] != "unix:"+path || got[1] != "localhost:4000" {
+		t.Errorf("expected socket then TCP, got %v", got)
+	}
+
+	// Remote addresses never use the socket
+	got = c.tcpTargets(context.Background(), NodeTarget{TCPAddress: "192.168.1.1:4000"})
+	if len(got) != 1 {
+		t.Errorf("expected TCP only for remote address, got %v", got)
+	}
+
+	// Disabled auto-selection
+	c.opts = c.opts.WithAutoLocal(false)
+	got = c.tcpTargets(context.Background(), NodeTarget{TCPAddress: "localhost:4000"})
+	if len(got) != 1 {
+		t.Errorf("expected TCP only with AutoLocal disabled, got %v", got)
+	}
+}
+
+func TestDefaultSocketPath_MatchesDaemon(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	daemon := config.DefaultBibdConfig().Server.GRPC.UnixSocket
+	if daemon == "" {
+		t.Fatal("daemon has no local socket by default")
+	}
+	if daemon[0] == '~' {
+		daemon = home + daemon[1:]
+	}
+	if got := DefaultSocketPath(); got != daemon {
+		t.Errorf("DefaultSocketPath() = %q, want the daemon default %q", got, daemon)
+	}
+}
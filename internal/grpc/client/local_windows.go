@@ -0,0 +1,59 @@
+//go:build windows
+
+package client
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// localScheme is the target scheme for local daemon connections.
+const localScheme = "pipe"
+
+const (
+	// pipePrefix is the namespace all local named pipes live in.
+	pipePrefix = `\\.\pipe\`
+
+	// defaultPipeName matches the daemon's default pipe.
+	defaultPipeName = pipePrefix + "bibd-grpc"
+)
+
+// localDialer returns a dialer for the daemon's named pipe.
+// The address passed by gRPC is ignored in favour of path.
+func localDialer(path string) func(context.Context, string) (net.Conn, error) {
+	name := pipeName(path)
+	return func(ctx context.Context, _ string) (net.Conn, error) {
+		return winio.DialPipeContext(ctx, name)
+	}
+}
+
+// pipeName maps a configured socket path onto the named pipe namespace.
+// This must stay in sync with the daemon's mapping.
+func pipeName(path string) string {
+	if path == "" {
+		return defaultPipeName
+	}
+	if strings.HasPrefix(path, pipePrefix) {
+		return path
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return pipePrefix + name
+}
+
+// DefaultSocketPath returns the pipe a local daemon listens on by default,
+// the default of server.grpc.unix_socket. It is probed when connecting to a
+// loopback TCP address.
+func DefaultSocketPath() string {
+	return defaultPipeName
+}
+
+// localSocketExists reports whether the named pipe exists.
+func localSocketExists(path string) bool {
+	_, err := os.Stat(pipeName(path))
+	return err == nil
+}
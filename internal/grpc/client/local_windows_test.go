@@ -0,0 +1,33 @@
+//go:build windows
+
+package client
+
+import (
+	"testing"
+
+	"bib/internal/config"
+)
+
+func TestPipeName(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", `\\.\pipe\bibd-grpc`},
+		{`\\.\pipe\custom`, `\\.\pipe\custom`},
+		{`C:\ProgramData\bibd\bibd.sock`, `\\.\pipe\bibd`},
+	}
+
+	for _, tt := range tests {
+		if got := pipeName(tt.path); got != tt.want {
+			t.Errorf("pipeName(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultSocketPath_MatchesDaemon(t *testing.T) {
+	daemon := config.DefaultBibdConfig().Server.GRPC.UnixSocket
+	if got := DefaultSocketPath(); got != pipeName(daemon) {
+		t.Errorf("DefaultSocketPath() = %q, want the daemon default %q", got, pipeName(daemon))
+	}
+}
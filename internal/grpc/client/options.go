@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"os"
 	"time"
+
+	"bib/internal/grpc/compression"
 )
 
 // ConnectionMode defines how connection targets are tried.
@@ -24,9 +26,21 @@ const (
 type Options struct {
 	// Connection targets (in priority order for sequential mode)
 	UnixSocket string // Unix socket path (or named pipe on Windows)
-	TCPAddress string // Direct TCP address (host:port)
+	TCPAddress string // Direct TCP address (host:port, or tcp://, unix://, pipe:// to force a transport)
 	P2PPeerID  string // P2P peer ID for libp2p connection
 
+	// Nodes lists failover candidates (e.g. favorite nodes). When set, it
+	// replaces the single target above and nodes are tried by Priority.
+	Nodes []NodeTarget
+
+	// FailoverCooldown is how long a failed node is ranked last, and how
+	// often the client tries to move back to a preferred node
+	FailoverCooldown time.Duration
+
+	// AutoLocal prefers the default local socket over TCP when TCPAddress
+	// is a loopback address without an explicit scheme
+	AutoLocal bool
+
 	// Connection behavior
 	Mode          ConnectionMode // How to try multiple targets
 	Timeout       time.Duration  // Connection timeout
@@ -36,6 +50,15 @@ type Options struct {
 	// Connection pool
 	PoolSize int // Maximum connections in pool (0 = single connection)
 
+	// Keepalive configures client-side keepalive pings
+	Keepalive KeepaliveOptions
+
+	// RetryPolicy configures per-call retries and reconnection backoff
+	RetryPolicy RetryPolicy
+
+	// Compression configures request compression for this connection
+	Compression CompressionOptions
+
 	// TLS configuration
 	TLS TLSOptions
 
@@ -47,6 +70,17 @@ type Options struct {
 	// Returns (trusted, error) - if false, connection is rejected
 	TOFUCallback TOFUCallbackFunc
 
+	// VersionPolicy decides how an incompatible daemon API version is
+	// handled after connecting (default: warn)
+	VersionPolicy VersionPolicy
+
+	// VersionSkewCallback receives version skew warnings
+	VersionSkewCallback VersionSkewFunc
+
+	// Locale asks the daemon for error messages in this locale, e.g. "de"
+	// (empty = English)
+	Locale string
+
 	// Interceptors
 	RequestIDEnabled bool // Add request ID to all calls
 	LoggingEnabled   bool // Log all RPC calls
@@ -57,6 +91,30 @@ type Options struct {
 // Returns (trusted, error) - return true to trust, false to reject
 type TOFUCallbackFunc func(nodeID string, certPEM []byte) (bool, error)
 
+// KeepaliveOptions configures client keepalive pings. Values should respect
+// the server's GRPCKeepaliveConfig.MinTime, or the server closes the
+// connection for pinging too often.
+type KeepaliveOptions struct {
+	// Time is the interval between pings when the connection is idle (0 = disabled)
+	Time time.Duration
+
+	// Timeout is how long to wait for a ping ack before closing the connection
+	Timeout time.Duration
+
+	// PermitWithoutStream sends pings even without active streams
+	PermitWithoutStream bool
+}
+
+// CompressionOptions configures request compression. Responses are
+// decompressed regardless; the server decides whether to compress them.
+type CompressionOptions struct {
+	// Algorithm is "gzip", "zstd", or empty to send requests uncompressed
+	Algorithm string
+
+	// MinSize is the smallest unary request, in bytes, that gets compressed
+	MinSize int
+}
+
 // TLSOptions configures TLS for the connection.
 type TLSOptions struct {
 	// Enabled enables TLS (default true for TCP, false for Unix socket)
@@ -97,6 +155,11 @@ type AuthOptions struct {
 
 	// AutoAuth automatically authenticates when token is missing/expired
 	AutoAuth bool
+
+	// Name and Email are sent when signing in, so nodes that allow
+	// auto-registration register an unknown identity under them
+	Name  string
+	Email string
 }
 
 // DefaultOptions returns sensible default options.
@@ -107,6 +170,14 @@ func DefaultOptions() Options {
 		RetryAttempts:    3,
 		RetryBackoff:     1 * time.Second,
 		PoolSize:         5,
+		AutoLocal:        true,
+		FailoverCooldown: 1 * time.Minute,
+		Keepalive: KeepaliveOptions{
+			Time:    5 * time.Minute, // Server default MinTime
+			Timeout: 20 * time.Second,
+		},
+		RetryPolicy:      DefaultRetryPolicy(),
+		VersionPolicy:    VersionPolicyWarn,
 		RequestIDEnabled: true,
 		LoggingEnabled:   false,
 		TLS: TLSOptions{
@@ -137,6 +208,18 @@ func (o Options) WithP2PPeerID(peerID string) Options {
 	return o
 }
 
+// WithAutoLocal enables or disables automatic local socket selection.
+func (o Options) WithAutoLocal(enabled bool) Options {
+	o.AutoLocal = enabled
+	return o
+}
+
+// WithNodes sets the failover candidates.
+func (o Options) WithNodes(nodes ...NodeTarget) Options {
+	o.Nodes = nodes
+	return o
+}
+
 // WithMode sets the connection mode.
 func (o Options) WithMode(mode ConnectionMode) Options {
 	o.Mode = mode
@@ -162,6 +245,31 @@ func (o Options) WithPoolSize(size int) Options {
 	return o
 }
 
+// WithKeepalive configures keepalive options.
+func (o Options) WithKeepalive(keepalive KeepaliveOptions) Options {
+	o.Keepalive = keepalive
+	return o
+}
+
+// WithRetryPolicy sets the per-call retry and reconnection policy.
+func (o Options) WithRetryPolicy(policy RetryPolicy) Options {
+	o.RetryPolicy = policy
+	return o
+}
+
+// WithCompression compresses requests of at least minSize bytes with the
+// named algorithm. An empty algorithm disables request compression.
+func (o Options) WithCompression(algorithm string, minSize int) Options {
+	o.Compression = CompressionOptions{Algorithm: algorithm, MinSize: minSize}
+	return o
+}
+
+// WithLocale sets the locale the daemon localizes error messages in.
+func (o Options) WithLocale(locale string) Options {
+	o.Locale = locale
+	return o
+}
+
 // WithTLS configures TLS options.
 func (o Options) WithTLS(tls TLSOptions) Options {
 	o.TLS = tls
@@ -192,6 +300,14 @@ func (o Options) WithTOFUCallback(callback TOFUCallbackFunc) Options {
 	return o
 }
 
+// WithVersionPolicy sets how version skew with the daemon is handled and
+// where warnings are reported.
+func (o Options) WithVersionPolicy(policy VersionPolicy, callback VersionSkewFunc) Options {
+	o.VersionPolicy = policy
+	o.VersionSkewCallback = callback
+	return o
+}
+
 // BuildTLSConfig builds a tls.Config from the options.
 func (o *TLSOptions) BuildTLSConfig() (*tls.Config, error) {
 	if !o.Enabled {
@@ -230,10 +346,16 @@ func (o *TLSOptions) BuildTLSConfig() (*tls.Config, error) {
 
 // Validate checks that the options are valid.
 func (o *Options) Validate() error {
-	if o.UnixSocket == "" && o.TCPAddress == "" && o.P2PPeerID == "" {
+	if len(o.Nodes) == 0 && o.UnixSocket == "" && o.TCPAddress == "" && o.P2PPeerID == "" {
 		return fmt.Errorf("at least one connection target must be specified")
 	}
 
+	for _, node := range o.Nodes {
+		if node.UnixSocket == "" && node.TCPAddress == "" && node.P2PPeerID == "" {
+			return fmt.Errorf("node %q has no connection target", node.Name)
+		}
+	}
+
 	if o.Timeout <= 0 {
 		return fmt.Errorf("timeout must be positive")
 	}
@@ -246,5 +368,23 @@ func (o *Options) Validate() error {
 		return fmt.Errorf("pool size must be non-negative")
 	}
 
+	if o.RetryPolicy.Jitter < 0 || o.RetryPolicy.Jitter > 1 {
+		return fmt.Errorf("retry jitter must be between 0 and 1")
+	}
+
+	if o.Keepalive.Time < 0 || o.Keepalive.Timeout < 0 {
+		return fmt.Errorf("keepalive durations must be non-negative")
+	}
+
+	switch o.VersionPolicy {
+	case "", VersionPolicyWarn, VersionPolicyRefuse, VersionPolicyIgnore:
+	default:
+		return fmt.Errorf("invalid version policy %q", o.VersionPolicy)
+	}
+
+	if err := compression.Validate(o.Compression.Algorithm); err != nil {
+		return err
+	}
+
 	return nil
 }
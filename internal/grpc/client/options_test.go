@@ -24,6 +24,18 @@ func TestDefaultOptions(t *testing.T) {
 		t.Errorf("expected pool size 5, got %d", opts.PoolSize)
 	}
 
+	if opts.RetryPolicy.MaxAttempts != 3 {
+		t.Errorf("expected 3 call attempts, got %d", opts.RetryPolicy.MaxAttempts)
+	}
+
+	if opts.Keepalive.Time != 5*time.Minute {
+		t.Errorf("expected 5m keepalive, got %v", opts.Keepalive.Time)
+	}
+
+	if !opts.AutoLocal {
+		t.Error("expected auto local socket selection enabled by default")
+	}
+
 	if !opts.TLS.Enabled {
 		t.Error("expected TLS enabled by default")
 	}
@@ -132,6 +144,24 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "jitter out of range",
+			opts: Options{
+				TCPAddress:  "localhost:4000",
+				Timeout:     30 * time.Second,
+				RetryPolicy: RetryPolicy{Jitter: 1.5},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown compression",
+			opts: Options{
+				TCPAddress:  "localhost:4000",
+				Timeout:     30 * time.Second,
+				Compression: CompressionOptions{Algorithm: "brotli"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "negative pool size",
 			opts: Options{
@@ -0,0 +1,136 @@
+// Package client provides a gRPC client library for connecting to bibd.
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures per-call retries for idempotent unary methods and
+// the backoff used when (re)connecting to the daemon.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per call, including the
+	// first one. Values <= 1 disable call retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+
+	// Multiplier grows the backoff after each attempt.
+	Multiplier float64
+
+	// Jitter randomizes each delay by ±Jitter (0.0-1.0) so that clients
+	// restarted together don't retry in lockstep.
+	Jitter float64
+
+	// RetryableCodes are the status codes that trigger a retry.
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetryPolicy returns the retry policy used by DefaultOptions.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2.0,
+		Jitter:         0.2,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.DeadlineExceeded},
+	}
+}
+
+// Backoff returns the jittered delay before retry number attempt (1-based).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 || p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay *= 1 + jitter*(2*rand.Float64()-1)
+	}
+
+	return time.Duration(delay)
+}
+
+// isRetryableCode reports whether code is in the policy's retryable set.
+func (p RetryPolicy) isRetryableCode(code codes.Code) bool {
+	for _, c := range p.RetryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// idempotentPrefixes are RPC name prefixes that are safe to repeat.
+var idempotentPrefixes = []string{
+	"Get", "List", "Search", "Check", "Ping", "Validate", "Explain",
+}
+
+// IsIdempotentMethod reports whether a full gRPC method name
+// (/package.Service/Method) refers to a read-only, repeatable call.
+func IsIdempotentMethod(fullMethod string) bool {
+	name := fullMethod
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		name = fullMethod[i+1:]
+	}
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryUnaryInterceptor retries idempotent unary calls on transient errors.
+func retryUnaryInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if policy.MaxAttempts <= 1 || !IsIdempotentMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		var err error
+		for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return err
+				case <-time.After(policy.Backoff(attempt)):
+				}
+			}
+
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil {
+				return nil
+			}
+
+			// The caller's own deadline or cancellation is final
+			if ctx.Err() != nil || !policy.isRetryableCode(status.Code(err)) {
+				return err
+			}
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+		Multiplier:     2.0,
+	}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, 0},
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, 1 * time.Second}, // capped
+	}
+
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempt); got != tt.expected {
+			t.Errorf("Backoff(%d) = %v, expected %v", tt.attempt, got, tt.expected)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffJitter(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		Multiplier:     2.0,
+		Jitter:         0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		got := policy.Backoff(1)
+		if got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("jittered backoff %v outside [50ms, 150ms]", got)
+		}
+	}
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	tests := []struct {
+		method   string
+		expected bool
+	}{
+		{"/bib.v1.services.TopicService/GetTopic", true},
+		{"/bib.v1.services.TopicService/ListTopics", true},
+		{"/bib.v1.services.HealthService/Check", true},
+		{"/bib.v1.services.TopicService/CreateTopic", false},
+		{"/bib.v1.services.DatasetService/DeleteDataset", false},
+		{"/bib.v1.services.JobService/RetryJob", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsIdempotentMethod(tt.method); got != tt.expected {
+			t.Errorf("IsIdempotentMethod(%s) = %v, expected %v", tt.method, got, tt.expected)
+		}
+	}
+}
+
+// failingInvoker fails with code for the first n calls, then succeeds.
+func failingInvoker(code codes.Code, n int, calls *int) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= n {
+			return status.Error(code, "transient")
+		}
+		return nil
+	}
+}
+
+func TestRetryUnaryInterceptor(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	interceptor := retryUnaryInterceptor(policy)
+
+	tests := []struct {
+		name      string
+		method    string
+		code      codes.Code
+		failures  int
+		wantCalls int
+		wantErr   bool
+	}{
+		{"recovers from unavailable", "/svc/GetTopic", codes.Unavailable, 2, 3, false},
+		{"gives up after max attempts", "/svc/GetTopic", codes.Unavailable, 5, 3, true},
+		{"retries deadline exceeded", "/svc/ListTopics", codes.DeadlineExceeded, 1, 2, false},
+		{"no retry for non-idempotent", "/svc/CreateTopic", codes.Unavailable, 1, 1, true},
+		{"no retry for permanent error", "/svc/GetTopic", codes.NotFound, 1, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, failingInvoker(tt.code, tt.failures, &calls))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, expected %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryUnaryInterceptor_CanceledContext(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Hour
+	interceptor := retryUnaryInterceptor(policy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		cancel()
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	if err := interceptor(ctx, "/svc/GetTopic", nil, nil, nil, invoker); err == nil {
+		t.Error("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected no retry after cancellation, got %d calls", calls)
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+)
+
+// Explicit schemes accepted in Options.TCPAddress. Any explicit scheme
+// disables automatic local socket selection for that address.
+const (
+	schemeTCP  = "tcp://"
+	schemeUnix = "unix://"
+	schemePipe = "pipe://"
+)
+
+// resolveTimeout bounds the hostname lookup used to detect loopback targets.
+const resolveTimeout = 500 * time.Millisecond
+
+// tcpTargets expands a node's TCP address into the dial targets to try.
+// Loopback addresses without an explicit scheme are preceded by the local
+// socket when it exists, so same-host connections skip TCP and TLS.
+func (c *Client) tcpTargets(ctx context.Context, node NodeTarget) []string {
+	addr := node.TCPAddress
+
+	switch {
+	case strings.HasPrefix(addr, schemeTCP):
+		return []string{strings.TrimPrefix(addr, schemeTCP)}
+	case strings.HasPrefix(addr, schemeUnix):
+		return []string{localScheme + ":" + strings.TrimPrefix(addr, schemeUnix)}
+	case strings.HasPrefix(addr, schemePipe):
+		return []string{localScheme + ":" + strings.TrimPrefix(addr, schemePipe)}
+	}
+
+	// An explicit UnixSocket has already been added ahead of TCP
+	if !c.opts.AutoLocal || node.UnixSocket != "" {
+		return []string{addr}
+	}
+
+	if isLoopbackAddress(ctx, addr) {
+		if path := DefaultSocketPath(); path != "" && localSocketExists(path) {
+			return []string{localScheme + ":" + path, addr}
+		}
+	}
+
+	return []string{addr}
+}
+
+// isLoopbackAddress reports whether a host:port address refers to this host.
+// Hostnames are resolved and only count as local if every address is loopback.
+func isLoopbackAddress(ctx context.Context, addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return false
+	}
+
+	for _, a := range addrs {
+		ip := net.ParseIP(a)
+		if ip == nil || !ip.IsLoopback() {
+			return false
+		}
+	}
+	return true
+}
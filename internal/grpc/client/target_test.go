@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestIsLoopbackAddress(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected bool
+	}{
+		{"localhost:4000", true},
+		{"LOCALHOST:4000", true},
+		{"127.0.0.1:4000", true},
+		{"127.0.0.2:4000", true},
+		{"[::1]:4000", true},
+		{"localhost", true},
+		{"192.168.1.1:4000", false},
+		{"[2001:db8::1]:4000", false},
+		{"bibd.invalid:4000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := isLoopbackAddress(context.Background(), tt.addr); got != tt.expected {
+				t.Errorf("isLoopbackAddress(%s) = %v, expected %v", tt.addr, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTCPTargets_ExplicitScheme(t *testing.T) {
+	c := &Client{opts: DefaultOptions()}
+
+	tests := []struct {
+		addr     string
+		expected []string
+	}{
+		{"tcp://localhost:4000", []string{"localhost:4000"}},
+		{"unix:///var/run/bibd.sock", []string{localScheme + ":/var/run/bibd.sock"}},
+		{"pipe://bibd", []string{localScheme + ":bibd"}},
+		{"192.168.1.1:4000", []string{"192.168.1.1:4000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			got := c.tcpTargets(context.Background(), NodeTarget{TCPAddress: tt.addr})
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("tcpTargets(%s) = %v, expected %v", tt.addr, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTCPTargets_ExplicitUnixSocket(t *testing.T) {
+	c := &Client{opts: DefaultOptions()}
+
+	got := c.tcpTargets(context.Background(), NodeTarget{UnixSocket: "/var/run/bibd.sock", TCPAddress: "localhost:4000"})
+	if !reflect.DeepEqual(got, []string{"localhost:4000"}) {
+		t.Errorf("expected only TCP target when UnixSocket is set, got %v", got)
+	}
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/version"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VersionPolicy decides what happens when the daemon's API version does not
+// match the client's.
+type VersionPolicy string
+
+const (
+	// VersionPolicyWarn reports skew through the VersionSkewCallback and connects
+	VersionPolicyWarn VersionPolicy = "warn"
+
+	// VersionPolicyRefuse refuses to connect to an incompatible daemon;
+	// skew that only limits features is still just reported
+	VersionPolicyRefuse VersionPolicy = "refuse"
+
+	// VersionPolicyIgnore skips the version check
+	VersionPolicyIgnore VersionPolicy = "ignore"
+)
+
+// VersionSkewFunc is called when the connected daemon's API version differs
+// from the client's in a way the user should know about.
+type VersionSkewFunc func(skew *VersionSkew)
+
+// ServerVersion is the version information reported by the daemon.
+type ServerVersion struct {
+	Version       string
+	Commit        string
+	BuildTime     time.Time
+	APIVersion    string
+	MinAPIVersion string
+	Features      []string
+}
+
+// HasFeature reports whether the daemon supports an optional feature.
+func (v *ServerVersion) HasFeature(name string) bool {
+	return v != nil && slices.Contains(v.Features, name)
+}
+
+// VersionSkew describes a mismatch between client and daemon versions.
+type VersionSkew struct {
+	// ClientAPIVersion is the API version the client was built against
+	ClientAPIVersion string
+
+	// Server is the daemon version, or nil if the daemon predates GetVersion
+	Server *ServerVersion
+
+	// Incompatible is true if the client cannot work with the daemon
+	Incompatible bool
+
+	// Message explains the skew
+	Message string
+}
+
+// Error implements error.
+func (s *VersionSkew) Error() string {
+	return s.Message
+}
+
+// checkVersion asks the daemon for its version and compares API versions.
+// It returns a non-nil skew if the versions differ in a way that matters.
+// Transport errors are not skew; they are left to the calls that follow.
+func (c *Client) checkVersion(ctx context.Context, conn *grpc.ClientConn) (*ServerVersion, *VersionSkew) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	resp, err := services.NewHealthServiceClient(conn).GetVersion(ctx, &services.GetDaemonVersionRequest{
+		ClientVersion:    version.Version,
+		ClientApiVersion: version.APIVersion,
+	})
+	if err != nil && status.Code(err) != codes.Unimplemented {
+		return nil, nil
+	}
+	if err != nil || resp.GetApiVersion() == "" {
+		return nil, &VersionSkew{
+			ClientAPIVersion: version.APIVersion,
+			Message:          "daemon does not report its API version; it is older than this client, consider upgrading it",
+		}
+	}
+
+	server := &ServerVersion{
+		Version:       resp.GetVersion(),
+		Commit:        resp.GetCommit(),
+		APIVersion:    resp.GetApiVersion(),
+		MinAPIVersion: resp.GetMinApiVersion(),
+		Features:      resp.GetFeatures(),
+	}
+	if resp.GetBuildTime() != nil {
+		server.BuildTime = resp.GetBuildTime().AsTime()
+	}
+
+	return server, compareVersions(version.APIVersion, server)
+}
+
+// negotiateVersion applies the version policy to a new connection. It only
+// fails if the policy is to refuse and the daemon is incompatible.
+func (c *Client) negotiateVersion(ctx context.Context, conn *grpc.ClientConn) (*ServerVersion, error) {
+	if c.opts.VersionPolicy == VersionPolicyIgnore {
+		return nil, nil
+	}
+
+	server, skew := c.checkVersion(ctx, conn)
+	if skew == nil {
+		return server, nil
+	}
+	if skew.Incompatible && c.opts.VersionPolicy == VersionPolicyRefuse {
+		return nil, skew
+	}
+	if c.opts.VersionSkewCallback != nil {
+		c.opts.VersionSkewCallback(skew)
+	}
+	return server, nil
+}
+
+// compareVersions checks the client API version against the daemon's.
+func compareVersions(clientAPI string, server *ServerVersion) *VersionSkew {
+	warning, err := version.CheckAPICompatibility(clientAPI, server.APIVersion, server.MinAPIVersion)
+	switch {
+	case err != nil:
+		return &VersionSkew{
+			ClientAPIVersion: clientAPI,
+			Server:           server,
+			Incompatible:     true,
+			Message:          fmt.Sprintf("daemon %s: %v", server.Version, err),
+		}
+	case warning != "":
+		return &VersionSkew{
+			ClientAPIVersion: clientAPI,
+			Server:           server,
+			Message:          fmt.Sprintf("daemon %s: %s", server.Version, warning),
+		}
+	default:
+		return nil
+	}
+}
+
+// ServerVersion returns the version of the connected daemon, or nil if it
+// is unknown (not connected, check disabled, or the daemon predates it).
+func (c *Client) ServerVersion() *ServerVersion {
+	c.connLock.RLock()
+	defer c.connLock.RUnlock()
+	return c.serverVersion
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/version"
+
+	"google.golang.org/grpc"
+)
+
+// versionHealthServer reports a fixed API version.
+type versionHealthServer struct {
+	fakeHealthServer
+	apiVersion string
+	minVersion string
+}
+
+func (s *versionHealthServer) GetVersion(ctx context.Context, req *services.GetDaemonVersionRequest) (*services.GetDaemonVersionResponse, error) {
+	return &services.GetDaemonVersionResponse{
+		Version:       "9.9.9",
+		ApiVersion:    s.apiVersion,
+		MinApiVersion: s.minVersion,
+		Features:      []string{"cluster"},
+	}, nil
+}
+
+// startVersionServer starts a server reporting apiVersion and returns its
+// address. An empty apiVersion serves a daemon without GetVersion.
+func startVersionServer(t *testing.T, apiVersion, minVersion string) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	serving := fakeHealthServer{status: services.ServingStatus_SERVING_STATUS_SERVING}
+	if apiVersion == "" {
+		services.RegisterHealthServiceServer(srv, &serving)
+	} else {
+		services.RegisterHealthServiceServer(srv, &versionHealthServer{
+			fakeHealthServer: serving,
+			apiVersion:       apiVersion,
+			minVersion:       minVersion,
+		})
+	}
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// connectWithPolicy connects to addr and returns the client and the skews
+// it reported.
+func connectWithPolicy(t *testing.T, addr string, policy VersionPolicy) (*Client, *[]*VersionSkew, error) {
+	t.Helper()
+
+	skews := &[]*VersionSkew{}
+	opts := failoverOptions(NodeTarget{TCPAddress: addr}).
+		WithVersionPolicy(policy, func(skew *VersionSkew) { *skews = append(*skews, skew) })
+
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c, skews, c.Connect(context.Background())
+}
+
+func TestVersionCheck_Compatible(t *testing.T) {
+	addr := startVersionServer(t, version.APIVersion, version.MinAPIVersion)
+
+	c, skews, err := connectWithPolicy(t, addr, VersionPolicyRefuse)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if len(*skews) != 0 {
+		t.Errorf("expected no skew, got %v", (*skews)[0])
+	}
+
+	server := c.ServerVersion()
+	if server == nil || server.Version != "9.9.9" || !server.HasFeature("cluster") {
+		t.Fatalf("unexpected server version %+v", server)
+	}
+	if server.HasFeature("missing") {
+		t.Error("expected HasFeature to be false for an unknown feature")
+	}
+}
+
+func TestVersionCheck_IncompatibleRefused(t *testing.T) {
+	addr := startVersionServer(t, "99.0", "99.0")
+
+	c, _, err := connectWithPolicy(t, addr, VersionPolicyRefuse)
+	if err == nil || !strings.Contains(err.Error(), "incompatible") {
+		t.Fatalf("expected connect to be refused, got %v", err)
+	}
+	if c.IsConnected() {
+		t.Error("expected client to stay disconnected")
+	}
+}
+
+func TestVersionCheck_IncompatibleWarned(t *testing.T) {
+	addr := startVersionServer(t, "99.0", "99.0")
+
+	c, skews, err := connectWithPolicy(t, addr, VersionPolicyWarn)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if len(*skews) != 1 || !(*skews)[0].Incompatible {
+		t.Errorf("expected one incompatible skew, got %v", *skews)
+	}
+	if c.ServerVersion() == nil {
+		t.Error("expected server version to be recorded")
+	}
+}
+
+func TestVersionCheck_OlderDaemon(t *testing.T) {
+	addr := startVersionServer(t, "", "")
+
+	// A daemon without GetVersion is reported but not refused
+	c, skews, err := connectWithPolicy(t, addr, VersionPolicyRefuse)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if len(*skews) != 1 || (*skews)[0].Incompatible || (*skews)[0].Server != nil {
+		t.Errorf("expected one warning without server version, got %v", *skews)
+	}
+	if c.ServerVersion() != nil {
+		t.Error("expected unknown server version")
+	}
+}
+
+func TestVersionCheck_Ignore(t *testing.T) {
+	addr := startVersionServer(t, "99.0", "99.0")
+
+	c, skews, err := connectWithPolicy(t, addr, VersionPolicyIgnore)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if len(*skews) != 0 || c.ServerVersion() != nil {
+		t.Error("expected the version check to be skipped")
+	}
+}
+
+func TestOptions_ValidateVersionPolicy(t *testing.T) {
+	opts := DefaultOptions().WithTCPAddress("localhost:4000").WithVersionPolicy("sometimes", nil)
+	if err := opts.Validate(); err == nil {
+		t.Error("expected invalid version policy to be rejected")
+	}
+}
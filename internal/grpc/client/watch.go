@@ -0,0 +1,225 @@
+// Package client provides a gRPC client library for connecting to bibd.
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecvStream is the receive side of a server-streaming RPC.
+type RecvStream[T any] interface {
+	Recv() (T, error)
+}
+
+// StreamOpener opens a server stream. A non-empty resumeToken asks the
+// server to continue after the event that carried it.
+type StreamOpener[T any] func(ctx context.Context, resumeToken string) (RecvStream[T], error)
+
+// WatchOptions configures a Watcher.
+type WatchOptions struct {
+	// Backoff controls the delay between reconnect attempts
+	// (InitialBackoff, MaxBackoff, Multiplier, Jitter and RetryableCodes are used).
+	Backoff RetryPolicy
+
+	// MaxConsecutiveFailures ends the watch after this many reconnects
+	// without receiving an event (0 = keep trying until ctx is done).
+	MaxConsecutiveFailures int
+
+	// BufferSize is the capacity of the events channel.
+	BufferSize int
+}
+
+// DefaultWatchOptions returns options suited to long-running watches.
+func DefaultWatchOptions() WatchOptions {
+	backoff := DefaultRetryPolicy()
+	backoff.InitialBackoff = 500 * time.Millisecond
+	backoff.MaxBackoff = 30 * time.Second
+	backoff.RetryableCodes = []codes.Code{codes.Unavailable, codes.Internal, codes.Unknown}
+
+	return WatchOptions{
+		Backoff:    backoff,
+		BufferSize: 16,
+	}
+}
+
+// Watcher delivers events from a server stream, transparently reopening the
+// stream after transient failures and resuming from the last-seen event.
+type Watcher[T any] struct {
+	events chan T
+	done   chan struct{}
+	err    error
+}
+
+// Events returns the channel of received events. It is closed when the
+// watch ends; Err then reports why.
+func (w *Watcher[T]) Events() <-chan T {
+	return w.events
+}
+
+// Done is closed when the watch has ended.
+func (w *Watcher[T]) Done() <-chan struct{} {
+	return w.done
+}
+
+// Err returns the terminal error once Done is closed. It is nil when the
+// server ended the stream normally, and ctx.Err() when the caller stopped it.
+func (w *Watcher[T]) Err() error {
+	select {
+	case <-w.done:
+		return w.err
+	default:
+		return nil
+	}
+}
+
+// Watch starts a reconnecting watch. tokenOf extracts the resume token from
+// an event; events without a token leave the resume position unchanged.
+func Watch[T any](ctx context.Context, open StreamOpener[T], tokenOf func(T) string, opts WatchOptions) *Watcher[T] {
+	w := &Watcher[T]{
+		events: make(chan T, opts.BufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(w.done)
+		defer close(w.events)
+		w.err = w.run(ctx, open, tokenOf, opts)
+	}()
+
+	return w
+}
+
+// run receives events until the stream ends or fails permanently.
+func (w *Watcher[T]) run(ctx context.Context, open StreamOpener[T], tokenOf func(T) string, opts WatchOptions) error {
+	var resumeToken string
+	failures := 0
+
+	for {
+		if failures > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(opts.Backoff.Backoff(failures)):
+			}
+		}
+
+		stream, err := open(ctx, resumeToken)
+		if err == nil {
+			for {
+				var event T
+				event, err = stream.Recv()
+				if err != nil {
+					break
+				}
+
+				failures = 0
+				if token := tokenOf(event); token != "" {
+					resumeToken = token
+				}
+
+				select {
+				case w.events <- event:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !opts.Backoff.isRetryableCode(status.Code(err)) {
+			return err
+		}
+
+		failures++
+		if opts.MaxConsecutiveFailures > 0 && failures > opts.MaxConsecutiveFailures {
+			return err
+		}
+	}
+}
+
+// WatchJobLogs follows a job's log output across reconnects.
+func (c *Client) WatchJobLogs(ctx context.Context, req *services.StreamJobLogsRequest) (*Watcher[*services.JobLogEntry], error) {
+	jobs, err := c.Job()
+	if err != nil {
+		return nil, err
+	}
+
+	open := func(ctx context.Context, resumeToken string) (RecvStream[*services.JobLogEntry], error) {
+		r := &services.StreamJobLogsRequest{
+			Id:          req.GetId(),
+			Follow:      req.GetFollow(),
+			TailLines:   req.GetTailLines(),
+			ResumeToken: resumeToken,
+		}
+		return jobs.StreamJobLogs(ctx, r)
+	}
+
+	return Watch(ctx, open, (*services.JobLogEntry).GetResumeToken, DefaultWatchOptions()), nil
+}
+
+// WatchJobStatus follows a job's status updates across reconnects.
+func (c *Client) WatchJobStatus(ctx context.Context, jobID string) (*Watcher[*services.JobStatusUpdate], error) {
+	jobs, err := c.Job()
+	if err != nil {
+		return nil, err
+	}
+
+	open := func(ctx context.Context, resumeToken string) (RecvStream[*services.JobStatusUpdate], error) {
+		return jobs.StreamJobStatus(ctx, &services.StreamJobStatusRequest{Id: jobID, ResumeToken: resumeToken})
+	}
+
+	return Watch(ctx, open, (*services.JobStatusUpdate).GetResumeToken, DefaultWatchOptions()), nil
+}
+
+// WatchTopicUpdates follows topic updates across reconnects.
+func (c *Client) WatchTopicUpdates(ctx context.Context, req *services.StreamTopicUpdatesRequest) (*Watcher[*services.TopicUpdate], error) {
+	topics, err := c.Topic()
+	if err != nil {
+		return nil, err
+	}
+
+	open := func(ctx context.Context, resumeToken string) (RecvStream[*services.TopicUpdate], error) {
+		r := &services.StreamTopicUpdatesRequest{
+			TopicIds:        req.GetTopicIds(),
+			IncludeDatasets: req.GetIncludeDatasets(),
+			ResumeToken:     resumeToken,
+		}
+		return topics.StreamTopicUpdates(ctx, r)
+	}
+
+	return Watch(ctx, open, (*services.TopicUpdate).GetResumeToken, DefaultWatchOptions()), nil
+}
+
+// TailLogs follows daemon logs across reconnects.
+func (c *Client) TailLogs(ctx context.Context, req *services.StreamLogsRequest) (*Watcher[*services.LogEntry], error) {
+	admin, err := c.Admin()
+	if err != nil {
+		return nil, err
+	}
+
+	open := func(ctx context.Context, resumeToken string) (RecvStream[*services.LogEntry], error) {
+		r := &services.StreamLogsRequest{
+			Level:          req.GetLevel(),
+			Component:      req.GetComponent(),
+			Pattern:        req.GetPattern(),
+			IncludeHistory: req.GetIncludeHistory(),
+			HistoryCount:   req.GetHistoryCount(),
+			ResumeToken:    resumeToken,
+		}
+		return admin.StreamLogs(ctx, r)
+	}
+
+	return Watch(ctx, open, (*services.LogEntry).GetResumeToken, DefaultWatchOptions()), nil
+}
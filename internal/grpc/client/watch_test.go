@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStream replays events, then returns err.
+type fakeStream struct {
+	events []string
+	err    error
+}
+
+func (s *fakeStream) Recv() (string, error) {
+	if len(s.events) == 0 {
+		return "", s.err
+	}
+	e := s.events[0]
+	s.events = s.events[1:]
+	return e, nil
+}
+
+func testWatchOptions() WatchOptions {
+	opts := DefaultWatchOptions()
+	opts.Backoff.InitialBackoff = time.Millisecond
+	return opts
+}
+
+func identity(s string) string { return s }
+
+func collect(t *testing.T, w *Watcher[string]) []string {
+	t.Helper()
+
+	var got []string
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e, ok := <-w.Events():
+			if !ok {
+				return got
+			}
+			got = append(got, e)
+		case <-timeout:
+			t.Fatal("watch did not finish")
+		}
+	}
+}
+
+func TestWatch_ResumesAfterTransientFailure(t *testing.T) {
+	var tokens []string
+	streams := []*fakeStream{
+		{events: []string{"1", "2"}, err: status.Error(codes.Unavailable, "daemon restarting")},
+		{events: []string{"3"}, err: io.EOF},
+	}
+
+	open := func(ctx context.Context, resumeToken string) (RecvStream[string], error) {
+		tokens = append(tokens, resumeToken)
+		s := streams[0]
+		streams = streams[1:]
+		return s, nil
+	}
+
+	w := Watch(context.Background(), open, identity, testWatchOptions())
+	got := collect(t, w)
+
+	if len(got) != 3 || got[2] != "3" {
+		t.Errorf("expected events 1,2,3, got %v", got)
+	}
+	if len(tokens) != 2 || tokens[0] != "" || tokens[1] != "2" {
+		t.Errorf("expected reopen with last token, got %v", tokens)
+	}
+	if err := w.Err(); err != nil {
+		t.Errorf("expected clean end, got %v", err)
+	}
+}
+
+func TestWatch_RetriesFailedOpen(t *testing.T) {
+	attempts := 0
+	open := func(ctx context.Context, resumeToken string) (RecvStream[string], error) {
+		attempts++
+		if attempts < 3 {
+			return nil, status.Error(codes.Unavailable, "connection refused")
+		}
+		return &fakeStream{events: []string{"a"}, err: io.EOF}, nil
+	}
+
+	w := Watch(context.Background(), open, identity, testWatchOptions())
+	if got := collect(t, w); len(got) != 1 {
+		t.Errorf("expected one event, got %v", got)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 open attempts, got %d", attempts)
+	}
+}
+
+func TestWatch_PermanentErrorIsTerminal(t *testing.T) {
+	attempts := 0
+	open := func(ctx context.Context, resumeToken string) (RecvStream[string], error) {
+		attempts++
+		return &fakeStream{err: status.Error(codes.PermissionDenied, "nope")}, nil
+	}
+
+	w := Watch(context.Background(), open, identity, testWatchOptions())
+	collect(t, w)
+
+	if status.Code(w.Err()) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", w.Err())
+	}
+	if attempts != 1 {
+		t.Errorf("expected no reconnect, got %d attempts", attempts)
+	}
+}
+
+func TestWatch_MaxConsecutiveFailures(t *testing.T) {
+	open := func(ctx context.Context, resumeToken string) (RecvStream[string], error) {
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+
+	opts := testWatchOptions()
+	opts.MaxConsecutiveFailures = 2
+
+	w := Watch(context.Background(), open, identity, opts)
+	collect(t, w)
+
+	if status.Code(w.Err()) != codes.Unavailable {
+		t.Errorf("expected Unavailable after giving up, got %v", w.Err())
+	}
+}
+
+func TestWatch_CancelStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	open := func(ctx context.Context, resumeToken string) (RecvStream[string], error) {
+		cancel()
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+
+	w := Watch(ctx, open, identity, testWatchOptions())
+	collect(t, w)
+
+	if w.Err() != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", w.Err())
+	}
+}
@@ -0,0 +1,177 @@
+// Package compression registers the message compressors supported by bibd
+// and its clients.
+//
+// Importing this package registers both gzip and zstd with grpc-go, which
+// makes clients advertise them in grpc-accept-encoding. Whether a message is
+// actually compressed is decided per call by the interceptors in the client
+// and middleware packages, so registering has no effect on its own.
+//
+// grpc-go enforces MaxRecvMsgSize on the decompressed message, so a small
+// compressed payload cannot expand past the configured receive limit. The
+// zstd decoder is bounded by the same limit (see SetMaxRecvMsgSize), so a
+// frame declaring a huge window or size is rejected before its memory is
+// allocated.
+package compression
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+const (
+	// None disables compression.
+	None = ""
+
+	// Gzip is the gzip compressor name. It is universally supported but
+	// costs noticeably more CPU than zstd for a similar ratio.
+	Gzip = gzip.Name
+
+	// Zstd is the zstd compressor name.
+	Zstd = "zstd"
+)
+
+// DefaultMinSize is the message size below which compression is skipped.
+// Small messages gain little and pay the per-message encoder overhead.
+const DefaultMinSize = 1024
+
+// DefaultMaxRecvMsgSize is the receive limit the zstd decoder is bounded
+// by until SetMaxRecvMsgSize raises it. It matches the grpc-go default.
+const DefaultMaxRecvMsgSize = 4 * 1024 * 1024
+
+// zstdWindowSize is the window of the zstd encoder. Protobuf messages gain
+// little from a longer history, and a decoder never has to accept a larger
+// window from bib peers than this or the receive limit.
+const zstdWindowSize = 1 << 20
+
+// zstdCodec is the registered zstd compressor.
+var zstdCodec = newZstdCompressor()
+
+func init() {
+	encoding.RegisterCompressor(zstdCodec)
+}
+
+// SetMaxRecvMsgSize bounds the memory and window of the zstd decoder by the
+// gRPC receive limit n. The compressor is registered for the whole process,
+// so the limit only grows: it covers the largest MaxRecvMsgSize of the
+// servers and clients in the process. Decoders pooled under a lower limit
+// are replaced on their next use.
+func SetMaxRecvMsgSize(n int) {
+	if n <= 0 {
+		return
+	}
+	for {
+		current := zstdCodec.maxSize.Load()
+		if uint64(n) <= current || zstdCodec.maxSize.CompareAndSwap(current, uint64(n)) {
+			return
+		}
+	}
+}
+
+// Validate checks that name is a supported compressor, or None.
+func Validate(name string) error {
+	switch name {
+	case None, Gzip, Zstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression algorithm %q (use %q or %q)", name, Gzip, Zstd)
+	}
+}
+
+// zstdCompressor implements encoding.Compressor using pooled zstd encoders
+// and decoders.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+	maxSize  atomic.Uint64 // Decoded size and window limit
+}
+
+func newZstdCompressor() *zstdCompressor {
+	c := &zstdCompressor{}
+	c.maxSize.Store(DefaultMaxRecvMsgSize)
+	c.encoders.New = func() any {
+		// SpeedDefault is roughly zstd level 3; anything higher costs
+		// far more CPU for little extra ratio on protobuf payloads.
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1),
+			zstd.WithWindowSize(zstdWindowSize))
+		return &zstdWriter{Encoder: enc, pool: &c.encoders}
+	}
+	c.decoders.New = func() any {
+		return c.newReader()
+	}
+	return c
+}
+
+// newReader creates a decoder bounded by the current limit. The window is
+// capped by the limit too, as frames never need to look back further than
+// the message they decode, but always accepts the window bib encodes with.
+func (c *zstdCompressor) newReader() *zstdReader {
+	maxSize := c.maxSize.Load()
+	window := min(max(maxSize, zstdWindowSize), zstd.MaxWindowSize)
+	dec, _ := zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxMemory(maxSize),
+		zstd.WithDecoderMaxWindow(window),
+	)
+	return &zstdReader{Decoder: dec, pool: &c.decoders, maxSize: maxSize}
+}
+
+// Name returns the registered compressor name.
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+// Compress returns a writer that compresses into w. The encoder is returned
+// to the pool when the writer is closed.
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	zw := c.encoders.Get().(*zstdWriter)
+	zw.Reset(w)
+	return zw, nil
+}
+
+// Decompress returns a reader that decompresses r. The decoder is returned
+// to the pool once the stream has been read to the end.
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	zr := c.decoders.Get().(*zstdReader)
+	if zr.maxSize != c.maxSize.Load() {
+		zr.Decoder.Close()
+		zr = c.newReader()
+	}
+	if err := zr.Reset(r); err != nil {
+		c.decoders.Put(zr)
+		return nil, err
+	}
+	return zr, nil
+}
+
+type zstdWriter struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.Encoder.Close()
+	w.pool.Put(w)
+	return err
+}
+
+type zstdReader struct {
+	*zstd.Decoder
+	pool    *sync.Pool
+	maxSize uint64 // Limit the decoder was created with
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	n, err := r.Decoder.Read(p)
+	if err == io.EOF {
+		// Drop the reference to the source before pooling
+		_ = r.Decoder.Reset(nil)
+		r.pool.Put(r)
+	}
+	return n, err
+}
@@ -0,0 +1,200 @@
+package compression
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistered(t *testing.T) {
+	for _, name := range []string{Gzip, Zstd} {
+		if encoding.GetCompressor(name) == nil {
+			t.Errorf("compressor %q is not registered", name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, name := range []string{None, Gzip, Zstd} {
+		if err := Validate(name); err != nil {
+			t.Errorf("Validate(%q) = %v", name, err)
+		}
+	}
+	if err := Validate("brotli"); err == nil {
+		t.Error("Validate(\"brotli\") should fail")
+	}
+}
+
+func TestZstd_RoundTrip(t *testing.T) {
+	c := encoding.GetCompressor(Zstd)
+	input := []byte(strings.Repeat("dataset row,", 4096))
+
+	// Run twice so pooled encoders and decoders are reused
+	for i := 0; i < 2; i++ {
+		compressed := compress(t, c, input)
+		if len(compressed) >= len(input) {
+			t.Errorf("compressed size %d >= input size %d", len(compressed), len(input))
+		}
+
+		r, err := c.Decompress(bytes.NewReader(compressed))
+		if err != nil {
+			t.Fatalf("Decompress() error = %v", err)
+		}
+		output, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if !bytes.Equal(output, input) {
+			t.Fatal("round trip changed the payload")
+		}
+	}
+}
+
+// pingServer answers pings without looking at the payload.
+type pingServer struct {
+	services.UnimplementedHealthServiceServer
+}
+
+func (pingServer) Ping(ctx context.Context, req *services.PingRequest) (*services.PingResponse, error) {
+	return &services.PingResponse{}, nil
+}
+
+func TestMaxRecvMsgSize_AppliesToDecompressedSize(t *testing.T) {
+	const limit = 64 * 1024
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	srv := grpc.NewServer(grpc.MaxRecvMsgSize(limit))
+	services.RegisterHealthServiceServer(srv, pingServer{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	client := services.NewHealthServiceClient(conn)
+
+	// A highly compressible payload: tiny on the wire, far over the limit
+	// once decompressed
+	req := &services.PingRequest{Payload: bytes.Repeat([]byte{0}, 16*limit)}
+
+	for _, name := range []string{Gzip, Zstd} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.Ping(context.Background(), req, grpc.UseCompressor(name))
+			if status.Code(err) != codes.ResourceExhausted {
+				t.Errorf("Ping() error = %v, expected ResourceExhausted", err)
+			}
+		})
+	}
+}
+
+// BenchmarkCompressors reports the compression ratio alongside the CPU cost
+// for a compressible payload (repeated field names and values, typical of
+// query results) and an incompressible one (already-compressed blobs).
+func BenchmarkCompressors(b *testing.B) {
+	random := make([]byte, 256*1024)
+	_, _ = rand.Read(random)
+
+	payloads := map[string][]byte{
+		"rows":   []byte(strings.Repeat(`{"id":12345,"name":"sensor-a","value":21.5,"unit":"celsius"}`, 4096)),
+		"random": random,
+	}
+
+	for _, name := range []string{Gzip, Zstd} {
+		c := encoding.GetCompressor(name)
+		for kind, payload := range payloads {
+			b.Run(name+"/"+kind, func(b *testing.B) {
+				var compressed []byte
+				b.SetBytes(int64(len(payload)))
+				for i := 0; i < b.N; i++ {
+					compressed = compress(b, c, payload)
+				}
+				b.ReportMetric(float64(len(payload))/float64(len(compressed)), "ratio")
+			})
+		}
+	}
+}
+
+func compress(tb testing.TB, c encoding.Compressor, input []byte) []byte {
+	tb.Helper()
+	var buf bytes.Buffer
+	w, err := c.Compress(&buf)
+	if err != nil {
+		tb.Fatalf("Compress() error = %v", err)
+	}
+	if _, err := w.Write(input); err != nil {
+		tb.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		tb.Fatalf("Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestZstd_DecoderBoundedByMaxRecvMsgSize(t *testing.T) {
+	c := newZstdCompressor()
+	input := bytes.Repeat([]byte{0}, 2*DefaultMaxRecvMsgSize)
+
+	// A peer encoding with a window larger than the receive limit
+	enc, err := zstd.NewWriter(nil, zstd.WithWindowSize(2*DefaultMaxRecvMsgSize))
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	compressed := enc.EncodeAll(input, nil)
+
+	// The frame needs more memory than the receive limit allows
+	r, err := c.Decompress(bytes.NewReader(compressed))
+	if err == nil {
+		_, err = io.Copy(io.Discard, r)
+	}
+	if err == nil {
+		t.Fatal("Decompress() of a frame over the limit succeeded")
+	}
+
+	// Raising the limit replaces the pooled decoders
+	c.maxSize.Store(4 * DefaultMaxRecvMsgSize)
+	r, err = c.Decompress(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("Decompress() error = %v", err)
+	}
+	output, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(output, input) {
+		t.Fatalf("ReadAll() = %d bytes, %v; want the %d byte payload", len(output), err, len(input))
+	}
+}
+
+func TestSetMaxRecvMsgSize(t *testing.T) {
+	defer zstdCodec.maxSize.Store(zstdCodec.maxSize.Load())
+
+	for _, tt := range []struct {
+		n    int
+		want uint64
+	}{
+		{16 * 1024 * 1024, 16 * 1024 * 1024},
+		{1024 * 1024, 16 * 1024 * 1024}, // only grows
+		{0, 16 * 1024 * 1024},
+		{32 * 1024 * 1024, 32 * 1024 * 1024},
+	} {
+		SetMaxRecvMsgSize(tt.n)
+		if got := zstdCodec.maxSize.Load(); got != tt.want {
+			t.Errorf("SetMaxRecvMsgSize(%d): limit = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+
+	"bib/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/stats"
+)
+
+// connTracker counts open client connections. It is installed as a stats
+// handler, which the transport notifies of every connection it accepts and
+// closes, whether closed by the client, for idleness or for ping abuse.
+type connTracker struct {
+	open atomic.Int64
+}
+
+// Open returns the number of open connections.
+func (t *connTracker) Open() int64 {
+	return t.open.Load()
+}
+
+// Collector returns a gauge of the open connections, sampled on scrape.
+func (t *connTracker) Collector() prometheus.Collector {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: metrics.GRPCOpenConnections,
+		Help: "Number of open client connections to the gRPC server.",
+	}, func() float64 {
+		return float64(t.Open())
+	})
+}
+
+// TagConn implements stats.Handler.
+func (t *connTracker) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements stats.Handler.
+func (t *connTracker) HandleConn(_ context.Context, s stats.ConnStats) {
+	switch s.(type) {
+	case *stats.ConnBegin:
+		t.open.Add(1)
+	case *stats.ConnEnd:
+		t.open.Add(-1)
+	}
+}
+
+// TagRPC implements stats.Handler.
+func (t *connTracker) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC implements stats.Handler.
+func (t *connTracker) HandleRPC(context.Context, stats.RPCStats) {}
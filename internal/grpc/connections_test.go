@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// startKeepaliveServer serves a gRPC server with the keepalive settings of
+// cfg and returns its address.
+func startKeepaliveServer(t *testing.T, keepalive config.GRPCKeepaliveConfig) (string, *Server) {
+	t.Helper()
+
+	s := &Server{
+		cfg: config.GRPCConfig{
+			MaxRecvMsgSize:       1 << 20,
+			MaxSendMsgSize:       1 << 20,
+			MaxConcurrentStreams: 10,
+			Keepalive:            keepalive,
+		},
+		conns: &connTracker{},
+	}
+	gs := grpc.NewServer(s.buildServerOptions(nil, nil)...)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	return lis.Addr().String(), s
+}
+
+// dialHTTP2 opens a raw HTTP/2 connection to addr, so the test controls
+// exactly which frames the server sees.
+func dialHTTP2(t *testing.T, addr string) (net.Conn, *http2.Framer) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if _, err := conn.Write([]byte(http2.ClientPreface)); err != nil {
+		t.Fatalf("failed to write preface: %v", err)
+	}
+	framer := http2.NewFramer(conn, conn)
+	if err := framer.WriteSettings(); err != nil {
+		t.Fatalf("failed to write settings: %v", err)
+	}
+	return conn, framer
+}
+
+// readGoAway reads frames until the server sends GOAWAY.
+func readGoAway(t *testing.T, conn net.Conn, framer *http2.Framer, timeout time.Duration) *http2.GoAwayFrame {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	for {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			t.Fatalf("connection ended without GOAWAY: %v", err)
+		}
+		switch f := frame.(type) {
+		case *http2.GoAwayFrame:
+			return f
+		case *http2.SettingsFrame:
+			if !f.IsAck() {
+				_ = framer.WriteSettingsAck()
+			}
+		}
+	}
+}
+
+func TestKeepalive_PingFloodRejected(t *testing.T) {
+	addr, _ := startKeepaliveServer(t, config.GRPCKeepaliveConfig{
+		MinTime: 5 * time.Minute,
+	})
+	conn, framer := dialHTTP2(t, addr)
+
+	// The server tolerates a few pings inside MinTime before it counts
+	// them as abuse
+	for i := 0; i < 10; i++ {
+		if err := framer.WritePing(false, [8]byte{byte(i)}); err != nil {
+			t.Fatalf("failed to write ping: %v", err)
+		}
+	}
+
+	goAway := readGoAway(t, conn, framer, 5*time.Second)
+	if goAway.ErrCode != http2.ErrCodeEnhanceYourCalm {
+		t.Errorf("GOAWAY code = %v, want %v", goAway.ErrCode, http2.ErrCodeEnhanceYourCalm)
+	}
+	if !strings.Contains(string(goAway.DebugData()), "too_many_pings") {
+		t.Errorf("GOAWAY debug data = %q, want too_many_pings", goAway.DebugData())
+	}
+}
+
+func TestKeepalive_PermittedPingsAccepted(t *testing.T) {
+	addr, _ := startKeepaliveServer(t, config.GRPCKeepaliveConfig{
+		MinTime:             time.Millisecond,
+		PermitWithoutStream: true,
+	})
+	conn, framer := dialHTTP2(t, addr)
+
+	for i := 0; i < 5; i++ {
+		time.Sleep(10 * time.Millisecond)
+		if err := framer.WritePing(false, [8]byte{byte(i)}); err != nil {
+			t.Fatalf("failed to write ping: %v", err)
+		}
+	}
+
+	acks := 0
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for acks < 5 {
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			t.Fatalf("failed to read frame after %d acks: %v", acks, err)
+		}
+		switch f := frame.(type) {
+		case *http2.PingFrame:
+			if f.IsAck() {
+				acks++
+			}
+		case *http2.GoAwayFrame:
+			t.Fatalf("server sent GOAWAY for pings within policy: %v", f.ErrCode)
+		}
+	}
+}
+
+func TestKeepalive_IdleConnectionClosed(t *testing.T) {
+	addr, _ := startKeepaliveServer(t, config.GRPCKeepaliveConfig{
+		MinTime:           5 * time.Minute,
+		MaxConnectionIdle: 100 * time.Millisecond,
+	})
+	conn, framer := dialHTTP2(t, addr)
+
+	goAway := readGoAway(t, conn, framer, 5*time.Second)
+	if goAway.ErrCode != http2.ErrCodeNo {
+		t.Errorf("GOAWAY code = %v, want %v", goAway.ErrCode, http2.ErrCodeNo)
+	}
+}
+
+func TestConnTracker_CountsConnections(t *testing.T) {
+	addr, s := startKeepaliveServer(t, config.GRPCKeepaliveConfig{
+		MinTime: 5 * time.Minute,
+	})
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	cc.Connect()
+
+	waitFor(t, func() bool { return cc.GetState() == connectivity.Ready })
+	waitFor(t, func() bool { return s.conns.Open() == 1 })
+
+	cc.Close()
+	waitFor(t, func() bool { return s.conns.Open() == 0 })
+}
+
+// waitFor polls cond until it holds or the test times out.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within 5s")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -4,6 +4,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strconv"
 
 	"bib/internal/domain"
 	"bib/internal/storage"
@@ -16,91 +17,109 @@ import (
 // domainErrorMapping maps domain errors to gRPC codes and descriptions.
 var domainErrorMapping = map[error]struct {
 	code codes.Code
+	key  string // stable message code, also the catalog key
 	desc string
 }{
 	// Storage errors (generic)
-	storage.ErrNotFound:      {codes.NotFound, "Resource not found"},
-	storage.ErrAlreadyExists: {codes.AlreadyExists, "Resource already exists"},
-	storage.ErrInvalidInput:  {codes.InvalidArgument, "Invalid input"},
+	storage.ErrNotFound:      {codes.NotFound, "not_found", "Resource not found"},
+	storage.ErrAlreadyExists: {codes.AlreadyExists, "already_exists", "Resource already exists"},
+	storage.ErrInvalidInput:  {codes.InvalidArgument, "invalid_input", "Invalid input"},
+	storage.ErrConflict:      {codes.FailedPrecondition, "revision_conflict", "Modified concurrently, fetch it again and retry"},
 
 	// Topic errors
-	domain.ErrInvalidTopicID:        {codes.InvalidArgument, "Invalid topic ID"},
-	domain.ErrInvalidTopicName:      {codes.InvalidArgument, "Invalid topic name"},
-	domain.ErrInvalidTopicStatus:    {codes.InvalidArgument, "Invalid topic status"},
-	domain.ErrTopicNotFound:         {codes.NotFound, "Topic not found"},
-	domain.ErrTopicArchived:         {codes.FailedPrecondition, "Topic is archived"},
-	domain.ErrCannotRemoveLastOwner: {codes.FailedPrecondition, "Cannot remove the last owner"},
-	domain.ErrOwnerNotFound:         {codes.NotFound, "Owner not found"},
+	domain.ErrInvalidTopicID:        {codes.InvalidArgument, "invalid_topic_id", "Invalid topic ID"},
+	domain.ErrInvalidTopicName:      {codes.InvalidArgument, "invalid_topic_name", "Invalid topic name"},
+	domain.ErrInvalidTopicStatus:    {codes.InvalidArgument, "invalid_topic_status", "Invalid topic status"},
+	domain.ErrTopicNotFound:         {codes.NotFound, "topic_not_found", "Topic not found"},
+	domain.ErrTopicArchived:         {codes.FailedPrecondition, "topic_archived", "Topic is archived"},
+	domain.ErrCannotRemoveLastOwner: {codes.FailedPrecondition, "cannot_remove_last_owner", "Cannot remove the last owner"},
+	domain.ErrOwnerNotFound:         {codes.NotFound, "owner_not_found", "Owner not found"},
 
 	// Dataset errors
-	domain.ErrInvalidDatasetID:     {codes.InvalidArgument, "Invalid dataset ID"},
-	domain.ErrInvalidDatasetName:   {codes.InvalidArgument, "Invalid dataset name"},
-	domain.ErrInvalidDatasetStatus: {codes.InvalidArgument, "Invalid dataset status"},
-	domain.ErrDatasetNotFound:      {codes.NotFound, "Dataset not found"},
-	domain.ErrInvalidHash:          {codes.InvalidArgument, "Invalid hash"},
-	domain.ErrHashMismatch:         {codes.DataLoss, "Hash mismatch - data may be corrupted"},
-	domain.ErrInvalidSize:          {codes.InvalidArgument, "Invalid size"},
-	domain.ErrInvalidChunkCount:    {codes.InvalidArgument, "Invalid chunk count"},
-	domain.ErrNoOwners:             {codes.InvalidArgument, "No owners specified"},
+	domain.ErrInvalidDatasetID:     {codes.InvalidArgument, "invalid_dataset_id", "Invalid dataset ID"},
+	domain.ErrInvalidDatasetName:   {codes.InvalidArgument, "invalid_dataset_name", "Invalid dataset name"},
+	domain.ErrInvalidDatasetStatus: {codes.InvalidArgument, "invalid_dataset_status", "Invalid dataset status"},
+	domain.ErrDatasetNotFound:      {codes.NotFound, "dataset_not_found", "Dataset not found"},
+	domain.ErrInvalidHash:          {codes.InvalidArgument, "invalid_hash", "Invalid hash"},
+	domain.ErrHashMismatch:         {codes.DataLoss, "hash_mismatch", "Hash mismatch - data may be corrupted"},
+	domain.ErrInvalidSize:          {codes.InvalidArgument, "invalid_size", "Invalid size"},
+	domain.ErrInvalidChunkCount:    {codes.InvalidArgument, "invalid_chunk_count", "Invalid chunk count"},
+	domain.ErrNoOwners:             {codes.InvalidArgument, "no_owners", "No owners specified"},
+
+	// Schema errors
+	domain.ErrEmptySchema:       {codes.InvalidArgument, "empty_schema", "Schema must not be empty"},
+	domain.ErrInvalidSchemaMode: {codes.InvalidArgument, "invalid_schema_mode", "Invalid schema mode"},
 
 	// Version errors
-	domain.ErrInvalidVersionID:     {codes.InvalidArgument, "Invalid version ID"},
-	domain.ErrInvalidVersionString: {codes.InvalidArgument, "Invalid version string"},
-	domain.ErrVersionNotFound:      {codes.NotFound, "Version not found"},
-	domain.ErrEmptyVersion:         {codes.InvalidArgument, "Version must have content or instructions"},
+	domain.ErrInvalidVersionID:     {codes.InvalidArgument, "invalid_version_id", "Invalid version ID"},
+	domain.ErrInvalidVersionString: {codes.InvalidArgument, "invalid_version_string", "Invalid version string"},
+	domain.ErrVersionNotFound:      {codes.NotFound, "version_not_found", "Version not found"},
+	domain.ErrEmptyVersion:         {codes.InvalidArgument, "empty_version", "Version must have content or instructions"},
 
 	// Chunk errors
-	domain.ErrInvalidChunkIndex: {codes.InvalidArgument, "Invalid chunk index"},
-	domain.ErrChunkNotFound:     {codes.NotFound, "Chunk not found"},
+	domain.ErrInvalidChunkIndex: {codes.InvalidArgument, "invalid_chunk_index", "Invalid chunk index"},
+	domain.ErrChunkNotFound:     {codes.NotFound, "chunk_not_found", "Chunk not found"},
 
 	// User errors
-	domain.ErrInvalidUserID:     {codes.InvalidArgument, "Invalid user ID"},
-	domain.ErrInvalidUserName:   {codes.InvalidArgument, "Invalid user name"},
-	domain.ErrInvalidPublicKey:  {codes.InvalidArgument, "Invalid public key"},
-	domain.ErrInvalidKeyType:    {codes.InvalidArgument, "Invalid key type"},
-	domain.ErrInvalidUserStatus: {codes.InvalidArgument, "Invalid user status"},
-	domain.ErrInvalidUserRole:   {codes.InvalidArgument, "Invalid user role"},
-	domain.ErrUserNotFound:      {codes.NotFound, "User not found"},
-	domain.ErrUserExists:        {codes.AlreadyExists, "User already exists"},
-	domain.ErrUserSuspended:     {codes.PermissionDenied, "User account is suspended"},
-	domain.ErrUserPending:       {codes.PermissionDenied, "User account is pending approval"},
-	domain.ErrInvalidSignature:  {codes.Unauthenticated, "Invalid signature"},
-	domain.ErrInvalidOperation:  {codes.InvalidArgument, "Invalid operation"},
-	domain.ErrUnauthorized:      {codes.PermissionDenied, "Unauthorized"},
-	domain.ErrAutoRegDisabled:   {codes.PermissionDenied, "Auto-registration is disabled"},
+	domain.ErrInvalidUserID:     {codes.InvalidArgument, "invalid_user_id", "Invalid user ID"},
+	domain.ErrInvalidUserName:   {codes.InvalidArgument, "invalid_user_name", "Invalid user name"},
+	domain.ErrInvalidPublicKey:  {codes.InvalidArgument, "invalid_public_key", "Invalid public key"},
+	domain.ErrInvalidKeyType:    {codes.InvalidArgument, "invalid_key_type", "Invalid key type"},
+	domain.ErrInvalidUserStatus: {codes.InvalidArgument, "invalid_user_status", "Invalid user status"},
+	domain.ErrInvalidUserRole:   {codes.InvalidArgument, "invalid_user_role", "Invalid user role"},
+	domain.ErrUserNotFound:      {codes.NotFound, "user_not_found", "User not found"},
+	domain.ErrUserExists:        {codes.AlreadyExists, "user_exists", "User already exists"},
+	domain.ErrUserSuspended:     {codes.PermissionDenied, "user_suspended", "User account is suspended"},
+	domain.ErrUserPending:       {codes.PermissionDenied, "user_pending", "User account is pending approval"},
+	domain.ErrKeyNotFound:       {codes.NotFound, "key_not_found", "Public key not found"},
+	domain.ErrKeyExists:         {codes.AlreadyExists, "key_exists", "Public key already registered"},
+	domain.ErrPrimaryKey:        {codes.FailedPrecondition, "primary_key", "Cannot remove the primary key"},
+	domain.ErrInvalidKeyLabel:   {codes.InvalidArgument, "invalid_key_label", "Invalid key label"},
+	domain.ErrInvalidSignature:  {codes.Unauthenticated, "invalid_signature", "Invalid signature"},
+	domain.ErrInvalidOperation:  {codes.InvalidArgument, "invalid_operation", "Invalid operation"},
+	domain.ErrUnauthorized:      {codes.PermissionDenied, "unauthorized", "Unauthorized"},
+	domain.ErrAutoRegDisabled:   {codes.PermissionDenied, "auto_reg_disabled", "Auto-registration is disabled"},
+	domain.ErrEmailRequired:     {codes.FailedPrecondition, "email_required", "Email is required for registration"},
 
 	// Session errors
-	domain.ErrSessionNotFound: {codes.NotFound, "Session not found"},
-	domain.ErrSessionExpired:  {codes.Unauthenticated, "Session has expired"},
+	domain.ErrSessionNotFound: {codes.NotFound, "session_not_found", "Session not found"},
+	domain.ErrSessionExpired:  {codes.Unauthenticated, "session_expired", "Session has expired"},
 
 	// Ownership errors
-	domain.ErrInvalidResourceType:  {codes.InvalidArgument, "Invalid resource type"},
-	domain.ErrInvalidResourceID:    {codes.InvalidArgument, "Invalid resource ID"},
-	domain.ErrInvalidOwnershipRole: {codes.InvalidArgument, "Invalid ownership role"},
-	domain.ErrOwnershipDenied:      {codes.PermissionDenied, "Ownership denied"},
-	domain.ErrSelfTransfer:         {codes.InvalidArgument, "Cannot transfer to self"},
-	domain.ErrNotOwner:             {codes.PermissionDenied, "Not an owner of this resource"},
+	domain.ErrInvalidResourceType:  {codes.InvalidArgument, "invalid_resource_type", "Invalid resource type"},
+	domain.ErrInvalidResourceID:    {codes.InvalidArgument, "invalid_resource_id", "Invalid resource ID"},
+	domain.ErrInvalidOwnershipRole: {codes.InvalidArgument, "invalid_ownership_role", "Invalid ownership role"},
+	domain.ErrOwnershipDenied:      {codes.PermissionDenied, "ownership_denied", "Ownership denied"},
+	domain.ErrSelfTransfer:         {codes.InvalidArgument, "self_transfer", "Cannot transfer to self"},
+	domain.ErrNotOwner:             {codes.PermissionDenied, "not_owner", "Not an owner of this resource"},
 
 	// Instruction errors
-	domain.ErrInvalidInstruction: {codes.InvalidArgument, "Invalid instruction"},
-	domain.ErrNoInstructions:     {codes.InvalidArgument, "No instructions provided"},
+	domain.ErrInvalidInstruction: {codes.InvalidArgument, "invalid_instruction", "Invalid instruction"},
+	domain.ErrNoInstructions:     {codes.InvalidArgument, "no_instructions", "No instructions provided"},
 
 	// Task errors
-	domain.ErrInvalidTaskID:   {codes.InvalidArgument, "Invalid task ID"},
-	domain.ErrInvalidTaskName: {codes.InvalidArgument, "Invalid task name"},
-	domain.ErrTaskNotFound:    {codes.NotFound, "Task not found"},
-	domain.ErrEmptyTask:       {codes.InvalidArgument, "Task has no instructions"},
+	domain.ErrInvalidTaskID:   {codes.InvalidArgument, "invalid_task_id", "Invalid task ID"},
+	domain.ErrInvalidTaskName: {codes.InvalidArgument, "invalid_task_name", "Invalid task name"},
+	domain.ErrTaskNotFound:    {codes.NotFound, "task_not_found", "Task not found"},
+	domain.ErrEmptyTask:       {codes.InvalidArgument, "empty_task", "Task has no instructions"},
 
 	// Schedule errors
-	domain.ErrInvalidScheduleType: {codes.InvalidArgument, "Invalid schedule type"},
-	domain.ErrInvalidCronExpr:     {codes.InvalidArgument, "Invalid cron expression"},
-	domain.ErrInvalidRepeatCount:  {codes.InvalidArgument, "Invalid repeat count"},
-	domain.ErrInvalidInterval:     {codes.InvalidArgument, "Invalid interval"},
-	domain.ErrInvalidTimeRange:    {codes.InvalidArgument, "End time must be after start time"},
+	domain.ErrInvalidScheduleType: {codes.InvalidArgument, "invalid_schedule_type", "Invalid schedule type"},
+	domain.ErrInvalidCronExpr:     {codes.InvalidArgument, "invalid_cron_expr", "Invalid cron expression"},
+	domain.ErrInvalidRepeatCount:  {codes.InvalidArgument, "invalid_repeat_count", "Invalid repeat count"},
+	domain.ErrInvalidInterval:     {codes.InvalidArgument, "invalid_interval", "Invalid interval"},
+	domain.ErrInvalidTimeRange:    {codes.InvalidArgument, "invalid_time_range", "End time must be after start time"},
 
 	// Job errors
-	domain.ErrInvalidJobID: {codes.InvalidArgument, "Invalid job ID"},
-	domain.ErrJobNotFound:  {codes.NotFound, "Job not found"},
+	domain.ErrInvalidJobID: {codes.InvalidArgument, "invalid_job_id", "Invalid job ID"},
+	domain.ErrJobNotFound:  {codes.NotFound, "job_not_found", "Job not found"},
+}
+
+// preconditionSubreasons qualifies the FailedPrecondition domain errors.
+var preconditionSubreasons = map[error]string{
+	domain.ErrTopicArchived:         SubreasonTopicArchived,
+	domain.ErrCannotRemoveLastOwner: SubreasonLastOwner,
+	storage.ErrConflict:             SubreasonRevisionConflict,
 }
 
 // MapDomainError converts a domain error to a gRPC status error with rich details.
@@ -117,7 +136,7 @@ func MapDomainError(err error) error {
 	// Look up the error in our mapping
 	for domainErr, mapping := range domainErrorMapping {
 		if errors.Is(err, domainErr) {
-			return NewDetailedError(mapping.code, mapping.desc, err)
+			return newDetailedError(mapping.code, mapping.key, preconditionSubreasons[domainErr], mapping.desc, err)
 		}
 	}
 
@@ -127,35 +146,33 @@ func MapDomainError(err error) error {
 
 // NewDetailedError creates a gRPC error with rich error details.
 func NewDetailedError(code codes.Code, message string, cause error) error {
-	st := status.New(code, message)
-
-	// Add error info details
-	details := &errdetails.ErrorInfo{
-		Reason: codeToReason(code),
-		Domain: "bib.dev",
-		Metadata: map[string]string{
-			"error_type": fmt.Sprintf("%T", cause),
-		},
+	return newDetailedError(code, "", "", message, cause)
+}
+
+// newDetailedError is NewDetailedError with an optional message code, which
+// clients use to identify the error and servers to localize the message,
+// and an optional subreason.
+func newDetailedError(code codes.Code, messageCode, subreason, message string, cause error) error {
+	metadata := map[string]string{
+		"error_type": fmt.Sprintf("%T", cause),
 	}
 
 	// Add the original error message if different
 	if cause != nil && cause.Error() != message {
-		details.Metadata["original_error"] = cause.Error()
+		metadata["original_error"] = cause.Error()
 	}
-
-	st, err := st.WithDetails(details)
-	if err != nil {
-		// Fall back to simple error if details can't be added
-		return status.Error(code, message)
+	if messageCode != "" {
+		metadata[MessageCodeKey] = messageCode
+	}
+	if subreason != "" {
+		metadata[SubreasonKey] = subreason
 	}
 
-	return st.Err()
+	return New(code, codeToReason(code), message, metadata)
 }
 
 // NewValidationError creates a gRPC error for validation failures with field-level details.
 func NewValidationError(message string, fieldViolations map[string]string) error {
-	st := status.New(codes.InvalidArgument, message)
-
 	br := &errdetails.BadRequest{}
 	for field, desc := range fieldViolations {
 		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
@@ -164,36 +181,37 @@ func NewValidationError(message string, fieldViolations map[string]string) error
 		})
 	}
 
-	st, err := st.WithDetails(br)
-	if err != nil {
-		return status.Error(codes.InvalidArgument, message)
-	}
-
-	return st.Err()
+	return New(codes.InvalidArgument, ReasonInvalidArgument, message, nil, br)
 }
 
 // NewResourceNotFoundError creates a NotFound error with resource details.
 func NewResourceNotFoundError(resourceType, resourceID string) error {
-	st := status.New(codes.NotFound, fmt.Sprintf("%s not found: %s", resourceType, resourceID))
-
 	ri := &errdetails.ResourceInfo{
 		ResourceType: resourceType,
 		ResourceName: resourceID,
 		Description:  fmt.Sprintf("The requested %s could not be found", resourceType),
 	}
 
-	st, err := st.WithDetails(ri)
-	if err != nil {
-		return status.Errorf(codes.NotFound, "%s not found: %s", resourceType, resourceID)
+	return New(codes.NotFound, ReasonNotFound, fmt.Sprintf("%s not found: %s", resourceType, resourceID),
+		map[string]string{"resource_type": resourceType}, ri)
+}
+
+// NewAlreadyExistsError creates an AlreadyExists error with resource details.
+func NewAlreadyExistsError(resourceType, resourceID string) error {
+	ri := &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: resourceID,
+		Description:  fmt.Sprintf("A %s with this name already exists", resourceType),
 	}
 
-	return st.Err()
+	return New(codes.AlreadyExists, ReasonAlreadyExists, fmt.Sprintf("%s already exists: %s", resourceType, resourceID),
+		map[string]string{"resource_type": resourceType}, ri)
 }
 
 // NewPreconditionError creates a FailedPrecondition error with details.
-func NewPreconditionError(message string, violations map[string]string) error {
-	st := status.New(codes.FailedPrecondition, message)
-
+// subreason (one of the Subreason constants) tells clients which
+// precondition failed.
+func NewPreconditionError(subreason, message string, violations map[string]string) error {
 	pf := &errdetails.PreconditionFailure{}
 	for condType, desc := range violations {
 		pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{
@@ -202,18 +220,33 @@ func NewPreconditionError(message string, violations map[string]string) error {
 		})
 	}
 
-	st, err := st.WithDetails(pf)
-	if err != nil {
-		return status.Error(codes.FailedPrecondition, message)
+	return New(codes.FailedPrecondition, ReasonFailedPrecondition, message,
+		map[string]string{SubreasonKey: subreason}, pf)
+}
+
+// NewRevisionConflictError creates a FailedPrecondition error for an update
+// based on a stale revision of a resource. It carries the current revision
+// in the "current_revision" metadata so clients can refetch and retry.
+func NewRevisionConflictError(resourceType, resourceID string, expected, current int64) error {
+	pf := &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        "REVISION",
+			Subject:     resourceType + "/" + resourceID,
+			Description: fmt.Sprintf("expected revision %d, current revision is %d", expected, current),
+		}},
 	}
 
-	return st.Err()
+	return New(codes.FailedPrecondition, ReasonFailedPrecondition,
+		fmt.Sprintf("%s %s was modified concurrently (revision %d, expected %d)", resourceType, resourceID, current, expected),
+		map[string]string{
+			SubreasonKey:       SubreasonRevisionConflict,
+			"resource_type":    resourceType,
+			CurrentRevisionKey: strconv.FormatInt(current, 10),
+		}, pf)
 }
 
 // NewQuotaExceededError creates a ResourceExhausted error for quota violations.
 func NewQuotaExceededError(resourceType, subject string, limit, current int64) error {
-	st := status.New(codes.ResourceExhausted, fmt.Sprintf("%s quota exceeded", resourceType))
-
 	qi := &errdetails.QuotaFailure{
 		Violations: []*errdetails.QuotaFailure_Violation{
 			{
@@ -223,64 +256,53 @@ func NewQuotaExceededError(resourceType, subject string, limit, current int64) e
 		},
 	}
 
-	st, err := st.WithDetails(qi)
-	if err != nil {
-		return status.Errorf(codes.ResourceExhausted, "%s quota exceeded: limit=%d", resourceType, limit)
-	}
-
-	return st.Err()
+	return New(codes.ResourceExhausted, ReasonQuotaExceeded, fmt.Sprintf("%s quota exceeded", resourceType),
+		map[string]string{
+			"resource_type": resourceType,
+			"limit":         strconv.FormatInt(limit, 10),
+		}, qi)
 }
 
 // NewPermissionDeniedError creates a PermissionDenied error with details.
 func NewPermissionDeniedError(action, resource string, requiredRole string) error {
-	message := fmt.Sprintf("permission denied: cannot %s %s", action, resource)
-	st := status.New(codes.PermissionDenied, message)
-
-	ei := &errdetails.ErrorInfo{
-		Reason: "PERMISSION_DENIED",
-		Domain: "bib.dev",
-		Metadata: map[string]string{
+	return New(codes.PermissionDenied, ReasonPermissionDenied,
+		fmt.Sprintf("permission denied: cannot %s %s", action, resource),
+		map[string]string{
 			"action":        action,
 			"resource":      resource,
 			"required_role": requiredRole,
-		},
-	}
-
-	st, err := st.WithDetails(ei)
-	if err != nil {
-		return status.Error(codes.PermissionDenied, message)
-	}
-
-	return st.Err()
+		})
 }
 
-// codeToReason converts a gRPC code to a reason string.
-func codeToReason(code codes.Code) string {
+// codeToReason converts a gRPC code to its generic reason.
+func codeToReason(code codes.Code) Reason {
 	switch code {
 	case codes.InvalidArgument:
-		return "INVALID_ARGUMENT"
+		return ReasonInvalidArgument
 	case codes.NotFound:
-		return "NOT_FOUND"
+		return ReasonNotFound
 	case codes.AlreadyExists:
-		return "ALREADY_EXISTS"
+		return ReasonAlreadyExists
 	case codes.PermissionDenied:
-		return "PERMISSION_DENIED"
+		return ReasonPermissionDenied
 	case codes.Unauthenticated:
-		return "UNAUTHENTICATED"
+		return ReasonUnauthenticated
 	case codes.ResourceExhausted:
-		return "RESOURCE_EXHAUSTED"
+		return ReasonResourceExhausted
 	case codes.FailedPrecondition:
-		return "FAILED_PRECONDITION"
+		return ReasonFailedPrecondition
 	case codes.Aborted:
-		return "ABORTED"
+		return ReasonAborted
+	case codes.DeadlineExceeded:
+		return ReasonDeadlineExceeded
 	case codes.Internal:
-		return "INTERNAL"
+		return ReasonInternal
 	case codes.Unavailable:
-		return "UNAVAILABLE"
+		return ReasonUnavailable
 	case codes.DataLoss:
-		return "DATA_LOSS"
+		return ReasonDataLoss
 	default:
-		return "UNKNOWN"
+		return ReasonUnknown
 	}
 }
 
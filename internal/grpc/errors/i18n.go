@@ -0,0 +1,151 @@
+package errors
+
+import (
+	"context"
+	"embed"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"bib/internal/tui/i18n"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MessageCodeKey is the ErrorInfo metadata key holding the stable message
+// code of an error, e.g. "dataset_not_found". Codes never change once
+// released; clients may match on them.
+const MessageCodeKey = "message_code"
+
+// LocaleMetadataKey is the request metadata key carrying the client's
+// preferred locales, in the format of the HTTP Accept-Language header.
+const LocaleMetadataKey = "accept-language"
+
+//go:embed locales/*.yaml
+var catalogFS embed.FS
+
+var (
+	catalogsOnce sync.Once
+	catalogs     map[string]*i18n.I18n
+)
+
+// catalog returns the message catalog for locale, or nil if there is none.
+func catalog(locale string) *i18n.I18n {
+	catalogsOnce.Do(func() {
+		catalogs = make(map[string]*i18n.I18n, len(i18n.SupportedLocales))
+		for _, l := range i18n.SupportedLocales {
+			// Falling back to the locale itself makes Has report only
+			// messages that are actually translated
+			catalogs[l] = i18n.New(
+				i18n.WithLocale(l),
+				i18n.WithFallback(l),
+				i18n.WithEmbeddedFS(&catalogFS, "locales"),
+			)
+		}
+	})
+	return catalogs[locale]
+}
+
+// LocalizedMessage returns the message for code in locale, falling back to
+// English. It also returns the locale of the message; ok is false if no
+// catalog knows the code.
+func LocalizedMessage(code, locale string) (message, msgLocale string, ok bool) {
+	key := "errors." + code
+	for _, l := range []string{locale, i18n.DefaultLocale} {
+		if c := catalog(l); c != nil && c.Has(key) {
+			return c.T(key), l, true
+		}
+	}
+	return "", "", false
+}
+
+// MessageCode returns the message code attached to a gRPC error, or "".
+func MessageCode(err error) string {
+	return ErrorInfo(err).GetMetadata()[MessageCodeKey]
+}
+
+// Localize adds a LocalizedMessage detail in locale to a gRPC error that
+// carries a message code. The status code, message and other details are
+// left as they are. Errors without a message code are returned unchanged.
+func Localize(err error, locale string) error {
+	code := MessageCode(err)
+	if code == "" {
+		return err
+	}
+	st, _ := status.FromError(err)
+	for _, d := range st.Details() {
+		if _, ok := d.(*errdetails.LocalizedMessage); ok {
+			return err
+		}
+	}
+
+	message, msgLocale, ok := LocalizedMessage(code, locale)
+	if !ok {
+		return err
+	}
+	localized, detailErr := st.WithDetails(&errdetails.LocalizedMessage{
+		Locale:  msgLocale,
+		Message: message,
+	})
+	if detailErr != nil {
+		return err
+	}
+	return localized.Err()
+}
+
+// LocaleFromContext returns the supported locale the client prefers most,
+// read from the incoming request metadata. It returns "en" if the client
+// sent no preference or none of its locales is supported.
+func LocaleFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return i18n.DefaultLocale
+	}
+	return ParseAcceptLanguage(strings.Join(md.Get(LocaleMetadataKey), ","))
+}
+
+// ParseAcceptLanguage picks the best supported locale from an
+// Accept-Language style list such as "de-AT,de;q=0.9,en;q=0.5".
+// It returns "en" if none of the listed locales is supported.
+func ParseAcceptLanguage(header string) string {
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if locale := i18n.MatchLocale(strings.TrimSpace(tag)); locale != "" && q > 0 {
+			candidates = append(candidates, candidate{locale, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return i18n.DefaultLocale
+	}
+
+	// Stable, so equal weights keep the client's order
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].q > candidates[b].q
+	})
+	return candidates[0].locale
+}
+
+// LocalizeError is Localize using the locale the client asked for in ctx.
+func LocalizeError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	return Localize(err, LocaleFromContext(ctx))
+}
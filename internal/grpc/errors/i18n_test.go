@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"context"
+	"testing"
+
+	"bib/internal/domain"
+	"bib/internal/tui/i18n"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCatalogsComplete(t *testing.T) {
+	for _, locale := range i18n.SupportedLocales {
+		c := catalog(locale)
+		if c == nil {
+			t.Fatalf("no catalog for %s", locale)
+		}
+		for _, mapping := range domainErrorMapping {
+			if !c.Has("errors." + mapping.key) {
+				t.Errorf("%s: missing message for %q", locale, mapping.key)
+			}
+		}
+	}
+}
+
+func TestMapDomainError_MessageCode(t *testing.T) {
+	err := MapDomainError(domain.ErrDatasetNotFound)
+
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", status.Code(err))
+	}
+	if got := MessageCode(err); got != "dataset_not_found" {
+		t.Errorf("expected message code dataset_not_found, got %q", got)
+	}
+	if got := MessageCode(status.Error(codes.Internal, "boom")); got != "" {
+		t.Errorf("expected no message code, got %q", got)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	err := Localize(MapDomainError(domain.ErrDatasetNotFound), "de")
+
+	st, _ := status.FromError(err)
+	if st.Message() != "Dataset not found" {
+		t.Errorf("expected English status message to stay, got %q", st.Message())
+	}
+	if got := MessageCode(err); got != "dataset_not_found" {
+		t.Errorf("expected message code to stay, got %q", got)
+	}
+
+	var lm *errdetails.LocalizedMessage
+	for _, d := range st.Details() {
+		if m, ok := d.(*errdetails.LocalizedMessage); ok {
+			lm = m
+		}
+	}
+	if lm == nil {
+		t.Fatal("expected a LocalizedMessage detail")
+	}
+	if lm.GetLocale() != "de" || lm.GetMessage() != "Datensatz nicht gefunden" {
+		t.Errorf("unexpected localized message: %s %q", lm.GetLocale(), lm.GetMessage())
+	}
+
+	// Localizing twice keeps the first message
+	again, _ := status.FromError(Localize(err, "fr"))
+	if len(again.Details()) != len(st.Details()) {
+		t.Errorf("expected no second LocalizedMessage, got %d details", len(again.Details()))
+	}
+}
+
+func TestLocalizedMessage_Fallback(t *testing.T) {
+	msg, locale, ok := LocalizedMessage("dataset_not_found", "es")
+	if !ok || locale != "en" || msg != "Dataset not found" {
+		t.Errorf("expected English fallback, got %q %q %v", msg, locale, ok)
+	}
+
+	if _, _, ok := LocalizedMessage("no_such_code", "de"); ok {
+		t.Error("expected unknown code to fail")
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"", "en"},
+		{"de", "de"},
+		{"de-AT", "de"},
+		{"zh-TW", "zh-tw"},
+		{"zh_TW", "zh-tw"},
+		{"es, fr;q=0.8", "fr"},
+		{"en;q=0.5, ru;q=0.9", "ru"},
+		{"fr, de", "fr"},
+		{"de;q=0, fr;q=0.1", "fr"},
+		{"es, pt", "en"},
+		{"de;q=bad", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); got != tt.expected {
+				t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	if got := LocaleFromContext(context.Background()); got != "en" {
+		t.Errorf("expected en without metadata, got %q", got)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(LocaleMetadataKey, "ru-RU"))
+	if got := LocaleFromContext(ctx); got != "ru" {
+		t.Errorf("expected ru, got %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the domain of every google.rpc.ErrorInfo bibd sends.
+const ErrorDomain = "bib.dev"
+
+// SubreasonKey is the ErrorInfo metadata key that qualifies a reason,
+// e.g. why a precondition failed.
+const SubreasonKey = "subreason"
+
+// Reason is a machine-readable error identifier, sent as the reason of the
+// google.rpc.ErrorInfo detail. Clients should branch on the reason rather
+// than the message. Reasons are part of the API: never rename one.
+type Reason string
+
+const (
+	ReasonInvalidArgument    Reason = "INVALID_ARGUMENT"
+	ReasonNotFound           Reason = "NOT_FOUND"
+	ReasonAlreadyExists      Reason = "ALREADY_EXISTS"
+	ReasonPermissionDenied   Reason = "PERMISSION_DENIED"
+	ReasonUnauthenticated    Reason = "UNAUTHENTICATED"
+	ReasonResourceExhausted  Reason = "RESOURCE_EXHAUSTED"
+	ReasonQuotaExceeded      Reason = "QUOTA_EXCEEDED"
+	ReasonRateLimited        Reason = "RATE_LIMITED"
+	ReasonFailedPrecondition Reason = "FAILED_PRECONDITION"
+	ReasonAborted            Reason = "ABORTED"
+	ReasonDeadlineExceeded   Reason = "DEADLINE_EXCEEDED"
+	ReasonInternal           Reason = "INTERNAL"
+	ReasonUnavailable        Reason = "UNAVAILABLE"
+	ReasonOverloaded         Reason = "OVERLOADED"
+	ReasonStarting           Reason = "STARTING"
+	ReasonDataLoss           Reason = "DATA_LOSS"
+	ReasonUnknown            Reason = "UNKNOWN"
+)
+
+// Subreasons of ReasonFailedPrecondition.
+const (
+	SubreasonTopicArchived = "TOPIC_ARCHIVED"
+	SubreasonTopicNotEmpty = "TOPIC_NOT_EMPTY"
+	SubreasonLastOwner     = "LAST_OWNER"
+	SubreasonNoContent     = "NO_CONTENT"
+	SubreasonMissingChunks = "MISSING_CHUNKS"
+	SubreasonDowngrade     = "DOWNGRADE"
+	SubreasonInvalidSchema = "INVALID_SCHEMA"
+
+	// SubreasonBackupUnavailable: an operation that backs up the database
+	// first was called on a daemon that can't take backups.
+	SubreasonBackupUnavailable = "BACKUP_UNAVAILABLE"
+
+	// SubreasonRevisionConflict: the resource changed since the client
+	// read it. CurrentRevisionKey holds the revision it has now.
+	SubreasonRevisionConflict = "REVISION_CONFLICT"
+
+	SubreasonInvitationExpired    = "INVITATION_EXPIRED"
+	SubreasonInvitationNotPending = "INVITATION_NOT_PENDING"
+	SubreasonMembershipPending    = "MEMBERSHIP_PENDING"
+
+	// SubreasonLockHeld: another holder has the cluster-wide lock.
+	SubreasonLockHeld = "LOCK_HELD"
+
+	// SubreasonConfirmationRequired: an irreversible operation was called
+	// without a valid confirmation token from a dry run of it.
+	SubreasonConfirmationRequired = "CONFIRMATION_REQUIRED"
+)
+
+// CurrentRevisionKey is the ErrorInfo metadata key holding the current
+// revision of a resource that failed with SubreasonRevisionConflict.
+const CurrentRevisionKey = "current_revision"
+
+// New creates a gRPC error with an ErrorInfo detail carrying reason and
+// metadata, followed by any extra details.
+func New(code codes.Code, reason Reason, message string, metadata map[string]string, extra ...protoadapt.MessageV1) error {
+	info := &errdetails.ErrorInfo{
+		Reason:   string(reason),
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	}
+
+	st, err := status.New(code, message).WithDetails(append([]protoadapt.MessageV1{info}, extra...)...)
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+// ErrorInfo returns the bib ErrorInfo detail of err, or nil if it has none.
+// Wrapped status errors are found too.
+func ErrorInfo(err error) *errdetails.ErrorInfo {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return nil
+	}
+	for _, d := range grpcErr.GRPCStatus().Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetDomain() == ErrorDomain {
+			return info
+		}
+	}
+	return nil
+}
+
+// ReasonOf returns the reason of err. Errors without an ErrorInfo detail get
+// the reason matching their status code, so every gRPC error has one.
+// It returns "" for nil and for errors that are not gRPC errors.
+func ReasonOf(err error) Reason {
+	if info := ErrorInfo(err); info != nil {
+		return Reason(info.GetReason())
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return ""
+	}
+	return codeToReason(grpcErr.GRPCStatus().Code())
+}
+
+// SubreasonOf returns the subreason of err, or "" if it has none.
+func SubreasonOf(err error) string {
+	return ErrorInfo(err).GetMetadata()[SubreasonKey]
+}
@@ -0,0 +1,106 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReasonOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Reason
+	}{
+		{"nil", nil, ""},
+		{"not grpc", fmt.Errorf("plain"), ""},
+		{"plain status", status.Error(codes.Unauthenticated, "no token"), ReasonUnauthenticated},
+		{"domain error", MapDomainError(domain.ErrUserExists), ReasonAlreadyExists},
+		{"not found", NewResourceNotFoundError("subscription", "t1"), ReasonNotFound},
+		{"already exists", NewAlreadyExistsError("topic", "weather"), ReasonAlreadyExists},
+		{"quota", NewQuotaExceededError("storage", "user:1", 10, 11), ReasonQuotaExceeded},
+		{"permission", NewPermissionDeniedError("delete", "topic", "owner"), ReasonPermissionDenied},
+		{"validation", NewValidationError("bad", map[string]string{"name": "empty"}), ReasonInvalidArgument},
+		{"custom", New(codes.ResourceExhausted, ReasonRateLimited, "slow down", nil), ReasonRateLimited},
+		{"wrapped", fmt.Errorf("ctx: %w", NewQuotaExceededError("storage", "u", 1, 2)), ReasonQuotaExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReasonOf(tt.err); got != tt.expected {
+				t.Errorf("ReasonOf() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubreasonOf(t *testing.T) {
+	err := NewPreconditionError(SubreasonTopicNotEmpty, "topic has datasets", map[string]string{
+		"dataset_count": "use force",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", status.Code(err))
+	}
+	if got := SubreasonOf(err); got != SubreasonTopicNotEmpty {
+		t.Errorf("SubreasonOf() = %q, want %q", got, SubreasonTopicNotEmpty)
+	}
+
+	// The PreconditionFailure detail is kept next to the ErrorInfo
+	st, _ := status.FromError(err)
+	var found bool
+	for _, d := range st.Details() {
+		if _, ok := d.(*errdetails.PreconditionFailure); ok {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a PreconditionFailure detail")
+	}
+
+	if got := SubreasonOf(MapDomainError(domain.ErrCannotRemoveLastOwner)); got != SubreasonLastOwner {
+		t.Errorf("expected domain error subreason %q, got %q", SubreasonLastOwner, got)
+	}
+	if got := SubreasonOf(status.Error(codes.FailedPrecondition, "x")); got != "" {
+		t.Errorf("expected no subreason, got %q", got)
+	}
+}
+
+func TestNewRevisionConflictError(t *testing.T) {
+	err := NewRevisionConflictError("topic", "t1", 3, 5)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", status.Code(err))
+	}
+	if got := SubreasonOf(err); got != SubreasonRevisionConflict {
+		t.Errorf("SubreasonOf() = %q, want %q", got, SubreasonRevisionConflict)
+	}
+	if got := ErrorInfo(err).GetMetadata()[CurrentRevisionKey]; got != "5" {
+		t.Errorf("expected current revision 5, got %q", got)
+	}
+
+	if got := SubreasonOf(MapDomainError(storage.ErrConflict)); got != SubreasonRevisionConflict {
+		t.Errorf("expected storage conflict subreason %q, got %q", SubreasonRevisionConflict, got)
+	}
+}
+
+func TestErrorInfo(t *testing.T) {
+	info := ErrorInfo(NewPermissionDeniedError("update", "dataset", "owner"))
+	if info == nil {
+		t.Fatal("expected ErrorInfo")
+	}
+	if info.GetDomain() != ErrorDomain {
+		t.Errorf("expected domain %q, got %q", ErrorDomain, info.GetDomain())
+	}
+	if info.GetMetadata()["required_role"] != "owner" {
+		t.Errorf("expected required_role owner, got %v", info.GetMetadata())
+	}
+
+	if ErrorInfo(status.Error(codes.Internal, "x")) != nil {
+		t.Error("expected no ErrorInfo for a plain status")
+	}
+}
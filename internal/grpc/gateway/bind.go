@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// bindValues sets the fields of msg named by values, as for query
+// parameters: a dotted name sets a field of a nested message, and repeated
+// fields take every value. Names are proto or JSON field names.
+func bindValues(msg protoreflect.Message, values url.Values) error {
+	for name, vals := range values {
+		if len(vals) == 0 {
+			continue
+		}
+		if err := bindField(msg, strings.Split(name, "."), vals); err != nil {
+			return fmt.Errorf("parameter %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func bindField(msg protoreflect.Message, path []string, vals []string) error {
+	fields := msg.Descriptor().Fields()
+	fd := fields.ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		fd = fields.ByJSONName(path[0])
+	}
+	if fd == nil {
+		return fmt.Errorf("no such field")
+	}
+
+	if len(path) > 1 {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("%s is not a message", fd.Name())
+		}
+		return bindField(msg.Mutable(fd).Message(), path[1:], vals)
+	}
+
+	switch {
+	case fd.IsMap():
+		return fmt.Errorf("map fields cannot be set by parameters")
+	case fd.IsList():
+		list := msg.Mutable(fd).List()
+		for _, s := range vals {
+			v, err := parseValue(fd, list.NewElement, s)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+	default:
+		v, err := parseValue(fd, func() protoreflect.Value { return msg.NewField(fd) }, vals[len(vals)-1])
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+// parseValue parses a parameter as a value of the field's kind. Messages,
+// such as timestamps and durations, are parsed from their JSON string form.
+func parseValue(fd protoreflect.FieldDescriptor, newValue func() protoreflect.Value, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(s)
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown value %q", s)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.MessageKind:
+		v := newValue()
+		if err := protojson.Unmarshal([]byte(strconv.Quote(s)), v.Message().Interface()); err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid value %q", s)
+		}
+		return v, nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+}
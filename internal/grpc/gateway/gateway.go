@@ -0,0 +1,190 @@
+// Package gateway serves a subset of the bib gRPC API as JSON over HTTP.
+//
+// The gateway lets browsers, curl and simple scripts use a daemon without a
+// gRPC client. Each route translates an HTTP request into a call on a gRPC
+// connection, normally an in-process one to the daemon's own server, so
+// calls pass through the same interceptors as any other client's:
+//
+//   - POST bodies are the JSON form (protojson) of the request message
+//   - path and query parameters set request fields by name; a dotted name
+//     such as page.limit sets a field of a nested message
+//   - responses are the JSON form of the response message, with the proto
+//     field names
+//   - errors are the JSON form of the gRPC status, with a matching HTTP
+//     status code
+//
+// Server streaming methods that deliver live updates are also bridged to
+// WebSockets under /v1/ws/, for browsers; see serveSocket.
+//
+// Health endpoints are public. Every other request must pass the configured
+// authentication: an API key in the X-API-Key header, a session token in an
+// "Authorization: Bearer" header, or both. Session tokens are passed on to
+// the gRPC services as the authorization metadata.
+package gateway
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"bib/internal/config"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyHeader is the HTTP header carrying an API key.
+const APIKeyHeader = "X-API-Key"
+
+// defaultMaxBodySize is the largest request body accepted if the
+// configuration sets no limit.
+const defaultMaxBodySize = 1 << 20
+
+// Gateway is an http.Handler translating HTTP/JSON requests into gRPC calls.
+type Gateway struct {
+	conn        grpc.ClientConnInterface
+	cfg         config.GatewayConfig
+	mux         *http.ServeMux
+	upgrader    websocket.Upgrader
+	maxBodySize int64
+}
+
+// Validate checks that a gateway configuration authenticates requests.
+func Validate(cfg config.GatewayConfig) error {
+	if len(cfg.Auth.APIKeys) == 0 && !cfg.Auth.RequireToken {
+		return fmt.Errorf("gateway: no authentication configured; set auth.api_keys or auth.require_token")
+	}
+	for _, key := range cfg.Auth.APIKeys {
+		if key == "" {
+			return fmt.Errorf("gateway: empty API key")
+		}
+	}
+	return nil
+}
+
+// New creates a gateway calling the services on conn.
+func New(conn grpc.ClientConnInterface, cfg config.GatewayConfig) (*Gateway, error) {
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
+
+	g := &Gateway{
+		conn:        conn,
+		cfg:         cfg,
+		mux:         http.NewServeMux(),
+		maxBodySize: cfg.MaxBodySize,
+	}
+	if g.maxBodySize <= 0 {
+		g.maxBodySize = defaultMaxBodySize
+	}
+	g.upgrader = g.newUpgrader()
+	for _, rt := range routes {
+		g.mux.Handle(rt.pattern, g.handler(rt))
+	}
+	g.mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, status.Error(codes.NotFound, "no such endpoint"))
+	})
+	return g, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && g.allowOrigin(origin) {
+		h := w.Header()
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Add("Vary", "Origin")
+		if r.Method == http.MethodOptions {
+			h.Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			h.Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept-Language, "+APIKeyHeader)
+			h.Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		h.Set("Access-Control-Expose-Headers", "X-Request-Id")
+	}
+	g.mux.ServeHTTP(w, r)
+}
+
+// allowOrigin reports whether browsers may call the gateway from origin.
+func (g *Gateway) allowOrigin(origin string) bool {
+	for _, allowed := range g.cfg.CORSOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate checks the credentials of a request to a non-public route.
+func (g *Gateway) authenticate(r *http.Request) error {
+	return g.checkCredentials(r.Header.Get(APIKeyHeader), bearerToken(r))
+}
+
+// checkCredentials checks an API key and session token against the
+// configured authentication.
+func (g *Gateway) checkCredentials(key, token string) error {
+	if len(g.cfg.Auth.APIKeys) > 0 {
+		if key == "" {
+			return status.Error(codes.Unauthenticated, "missing API key")
+		}
+		if !g.validAPIKey(key) {
+			return status.Error(codes.Unauthenticated, "invalid API key")
+		}
+	}
+	if g.cfg.Auth.RequireToken && token == "" {
+		return status.Error(codes.Unauthenticated, "missing authentication token")
+	}
+	return nil
+}
+
+// validAPIKey compares key with every configured key in constant time.
+func (g *Gateway) validAPIKey(key string) bool {
+	valid := 0
+	for _, k := range g.cfg.Auth.APIKeys {
+		valid |= subtle.ConstantTimeCompare([]byte(k), []byte(key))
+	}
+	return valid == 1
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+	return ""
+}
+
+// outgoingContext returns the context of the gRPC call made for r, carrying
+// the caller's token, locale and request ID as metadata.
+func outgoingContext(ctx context.Context, r *http.Request, token string) context.Context {
+	md := metadata.MD{}
+	if token != "" {
+		md.Set("authorization", "Bearer "+token)
+	}
+	if lang := r.Header.Get("Accept-Language"); lang != "" {
+		md.Set(grpcerrors.LocaleMetadataKey, lang)
+	}
+	if id := r.Header.Get("X-Request-Id"); id != "" {
+		md.Set(middleware.RequestIDHeader, id)
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		md.Set("x-forwarded-for", host)
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// setResponseHeaders copies the response metadata worth exposing over HTTP.
+func setResponseHeaders(w http.ResponseWriter, md metadata.MD) {
+	if ids := md.Get(middleware.RequestIDHeader); len(ids) > 0 {
+		w.Header().Set("X-Request-Id", ids[0])
+	}
+}
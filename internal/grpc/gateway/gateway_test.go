@@ -0,0 +1,356 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type testHealthServer struct {
+	services.UnimplementedHealthServiceServer
+}
+
+func (testHealthServer) Check(context.Context, *services.HealthCheckRequest) (*services.HealthCheckResponse, error) {
+	return &services.HealthCheckResponse{Status: services.ServingStatus_SERVING_STATUS_SERVING}, nil
+}
+
+type testQueryServer struct {
+	services.UnimplementedQueryServiceServer
+}
+
+// Execute echoes the caller's authorization metadata and the request.
+func (testQueryServer) Execute(ctx context.Context, req *services.ExecuteQueryRequest) (*services.ExecuteQueryResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("authorization")) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no token")
+	}
+	data, _ := structpb.NewStruct(map[string]any{
+		"authorization": md.Get("authorization")[0],
+		"expression":    req.GetExpression(),
+	})
+	return &services.ExecuteQueryResponse{Results: []*services.QueryResult{{Data: data}}}, nil
+}
+
+func (testQueryServer) ExecuteStream(req *services.ExecuteQueryRequest, stream grpc.ServerStreamingServer[services.QueryResult]) error {
+	for i := range 2 {
+		if err := stream.Send(&services.QueryResult{Index: int64(i)}); err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.DeadlineExceeded, "query timed out")
+}
+
+type testDatasetServer struct {
+	services.UnimplementedDatasetServiceServer
+}
+
+// ListDatasets returns one dataset describing the request.
+func (testDatasetServer) ListDatasets(_ context.Context, req *services.ListDatasetsRequest) (*services.ListDatasetsResponse, error) {
+	return &services.ListDatasetsResponse{Datasets: []*services.Dataset{{
+		TopicId: req.GetTopicId(),
+		Name:    strings.Join(req.GetTags(), ","),
+		Version: req.GetPage().GetLimit(),
+	}}}, nil
+}
+
+func (testDatasetServer) GetDataset(_ context.Context, req *services.GetDatasetRequest) (*services.GetDatasetResponse, error) {
+	return nil, status.Errorf(codes.NotFound, "dataset %s not found", req.GetId())
+}
+
+func (testDatasetServer) DownloadDataset(req *services.DownloadDatasetRequest, stream grpc.ServerStreamingServer[services.DownloadDatasetResponse]) error {
+	_ = stream.Send(&services.DownloadDatasetResponse{Data: &services.DownloadDatasetResponse_Metadata{
+		Metadata: &services.DownloadMetadata{
+			Dataset:   &services.Dataset{Id: req.GetId(), Name: "data.csv", ContentType: "text/csv"},
+			TotalSize: 8,
+		},
+	}})
+	for _, part := range []string{"a,b\n", "1,2\n"} {
+		_ = stream.Send(&services.DownloadDatasetResponse{Data: &services.DownloadDatasetResponse_Chunk{
+			Chunk: &services.ChunkData{Data: []byte(part)},
+		}})
+	}
+	return nil
+}
+
+// newTestGateway serves the test services in-process and returns an HTTP
+// server for a gateway calling them.
+func newTestGateway(t *testing.T, cfg config.GatewayConfig) *httptest.Server {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	services.RegisterHealthServiceServer(srv, testHealthServer{})
+	services.RegisterQueryServiceServer(srv, testQueryServer{})
+	services.RegisterDatasetServiceServer(srv, testDatasetServer{})
+	services.RegisterJobServiceServer(srv, testJobServer{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///gateway",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	gw, err := New(conn, cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	ts := httptest.NewServer(gw)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func doRequest(t *testing.T, method, url, body string, header map[string]string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest failed: %v", err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp, string(data)
+}
+
+func tokenConfig() config.GatewayConfig {
+	return config.GatewayConfig{Auth: config.GatewayAuthConfig{RequireToken: true}}
+}
+
+func TestGateway_HealthIsPublic(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	resp, body := doRequest(t, "GET", ts.URL+"/v1/health", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(body, `"status":"SERVING_STATUS_SERVING"`) {
+		t.Errorf("body = %s", body)
+	}
+}
+
+func TestGateway_Authentication(t *testing.T) {
+	cfg := config.GatewayConfig{Auth: config.GatewayAuthConfig{APIKeys: []string{"k1", "k2"}, RequireToken: true}}
+	ts := newTestGateway(t, cfg)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"no credentials", nil, http.StatusUnauthorized},
+		{"token only", map[string]string{"Authorization": "Bearer tok"}, http.StatusUnauthorized},
+		{"wrong key", map[string]string{"Authorization": "Bearer tok", APIKeyHeader: "nope"}, http.StatusUnauthorized},
+		{"key only", map[string]string{APIKeyHeader: "k2"}, http.StatusUnauthorized},
+		{"key and token", map[string]string{"Authorization": "Bearer tok", APIKeyHeader: "k2"}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := doRequest(t, "POST", ts.URL+"/v1/query", `{"expression":"true"}`, tt.header)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.want, body)
+			}
+		})
+	}
+}
+
+func TestGateway_ForwardsToken(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	resp, body := doRequest(t, "POST", ts.URL+"/v1/query", `{"expression":"x > 1"}`,
+		map[string]string{"Authorization": "Bearer secret", "Content-Type": "application/json"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+	}
+
+	var out struct {
+		Results []struct {
+			Data map[string]string `json:"data"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(body), &out); err != nil {
+		t.Fatalf("invalid JSON %s: %v", body, err)
+	}
+	if len(out.Results) != 1 {
+		t.Fatalf("results = %d, want 1", len(out.Results))
+	}
+	if got := out.Results[0].Data["authorization"]; got != "Bearer secret" {
+		t.Errorf("authorization = %q, want %q", got, "Bearer secret")
+	}
+	if got := out.Results[0].Data["expression"]; got != "x > 1" {
+		t.Errorf("expression = %q, want %q", got, "x > 1")
+	}
+}
+
+func TestGateway_QueryParameters(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+	auth := map[string]string{"Authorization": "Bearer tok"}
+
+	resp, body := doRequest(t, "GET", ts.URL+"/v1/datasets?topic_id=t1&tags=a&tags=b&page.limit=7", "", auth)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+	}
+	for _, want := range []string{`"topic_id":"t1"`, `"name":"a,b"`, `"version":7`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body %s does not contain %s", body, want)
+		}
+	}
+
+	resp, body = doRequest(t, "GET", ts.URL+"/v1/datasets?bogus=1", "", auth)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown parameter: status = %d, want 400: %s", resp.StatusCode, body)
+	}
+	resp, body = doRequest(t, "GET", ts.URL+"/v1/datasets?page.limit=many", "", auth)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid number: status = %d, want 400: %s", resp.StatusCode, body)
+	}
+}
+
+func TestGateway_Errors(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+	auth := map[string]string{"Authorization": "Bearer tok"}
+
+	resp, body := doRequest(t, "GET", ts.URL+"/v1/datasets/ds-1", "", auth)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(body, `"message":"dataset ds-1 not found"`) {
+		t.Errorf("body = %s", body)
+	}
+
+	resp, _ = doRequest(t, "GET", ts.URL+"/v1/nothing", "", auth)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown path: status = %d, want 404", resp.StatusCode)
+	}
+
+	resp, _ = doRequest(t, "POST", ts.URL+"/v1/query", "{", auth)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid body: status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestGateway_Stream(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	resp, body := doRequest(t, "POST", ts.URL+"/v1/query/stream", `{"expression":"true"}`,
+		map[string]string{"Authorization": "Bearer tok"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("lines = %q, want 2 results and an error", lines)
+	}
+	if !strings.Contains(lines[1], `"index":"1"`) {
+		t.Errorf("second line = %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], `{"error":`) || !strings.Contains(lines[2], "query timed out") {
+		t.Errorf("last line = %s", lines[2])
+	}
+}
+
+func TestGateway_Download(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	resp, body := doRequest(t, "GET", ts.URL+"/v1/datasets/ds-1/content", "",
+		map[string]string{"Authorization": "Bearer tok"})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+	}
+	if body != "a,b\n1,2\n" {
+		t.Errorf("body = %q", body)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/csv" {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+	if cd := resp.Header.Get("Content-Disposition"); cd != `attachment; filename=data.csv` {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+}
+
+func TestGateway_CORS(t *testing.T) {
+	cfg := tokenConfig()
+	cfg.CORSOrigins = []string{"https://ui.example.com"}
+	ts := newTestGateway(t, cfg)
+
+	resp, _ := doRequest(t, "OPTIONS", ts.URL+"/v1/query", "", map[string]string{
+		"Origin":                        "https://ui.example.com",
+		"Access-Control-Request-Method": "POST",
+	})
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("preflight status = %d, want 204", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "https://ui.example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+
+	resp, _ = doRequest(t, "GET", ts.URL+"/v1/health", "", map[string]string{"Origin": "https://evil.example.com"})
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("disallowed origin got Access-Control-Allow-Origin = %q", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    config.GatewayAuthConfig
+		wantErr bool
+	}{
+		{"no auth", config.GatewayAuthConfig{}, true},
+		{"empty key", config.GatewayAuthConfig{APIKeys: []string{""}}, true},
+		{"api key", config.GatewayAuthConfig{APIKeys: []string{"k"}}, false},
+		{"token", config.GatewayAuthConfig{RequireToken: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(config.GatewayConfig{Auth: tt.auth})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoutes_PathWildcardsAreFields(t *testing.T) {
+	for _, rt := range routes {
+		fields := rt.newRequest().ProtoReflect().Descriptor().Fields()
+		for _, name := range pathWildcards(rt.pattern) {
+			if fields.ByName(protoreflect.Name(name)) == nil {
+				t.Errorf("%s: request has no field %q", rt.pattern, name)
+			}
+		}
+	}
+}
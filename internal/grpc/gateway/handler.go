@@ -0,0 +1,287 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	services "bib/api/gen/go/bib/v1/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// handler returns the HTTP handler for a route.
+func (g *Gateway) handler(rt route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rt.kind == socket {
+			// Credentials and request arrive in the first frame
+			g.serveSocket(w, r, rt)
+			return
+		}
+
+		if !rt.public {
+			if err := g.authenticate(r); err != nil {
+				writeError(w, err)
+				return
+			}
+		}
+
+		req, err := g.decodeRequest(w, r, rt)
+		if err != nil {
+			writeError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+
+		switch rt.kind {
+		case stream:
+			g.serveStream(w, r, rt, req)
+		case download:
+			g.serveDownload(w, r, rt, req)
+		default:
+			g.serveUnary(w, r, rt, req)
+		}
+	})
+}
+
+// decodeRequest builds the request message of a route from the body, the
+// path wildcards and the query parameters of r, in that order.
+func (g *Gateway) decodeRequest(w http.ResponseWriter, r *http.Request, rt route) (proto.Message, error) {
+	req := rt.newRequest()
+
+	if r.Method == http.MethodPost {
+		if ct := r.Header.Get("Content-Type"); ct != "" {
+			if mt, _, _ := mime.ParseMediaType(ct); mt != "application/json" {
+				return nil, fmt.Errorf("unsupported content type %q", ct)
+			}
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, g.maxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, fmt.Errorf("request body larger than %d bytes", tooLarge.Limit)
+			}
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+		if len(strings.TrimSpace(string(body))) > 0 {
+			if err := unmarshalOptions.Unmarshal(body, req); err != nil {
+				return nil, fmt.Errorf("invalid request body: %w", err)
+			}
+		}
+	}
+
+	if err := bindRequest(req, r, rt); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// bindRequest sets the fields of req named by the path wildcards and query
+// parameters of r.
+func bindRequest(req proto.Message, r *http.Request, rt route) error {
+	msg := req.ProtoReflect()
+	for _, name := range pathWildcards(rt.pattern) {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		msg.Set(fd, protoreflect.ValueOfString(r.PathValue(name)))
+	}
+	return bindValues(msg, r.URL.Query())
+}
+
+// pathWildcards returns the names of the wildcards in a ServeMux pattern.
+func pathWildcards(pattern string) []string {
+	var names []string
+	for _, seg := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			names = append(names, strings.TrimSuffix(strings.Trim(seg, "{}"), "..."))
+		}
+	}
+	return names
+}
+
+// serveUnary calls a unary method and writes its response.
+func (g *Gateway) serveUnary(w http.ResponseWriter, r *http.Request, rt route, req proto.Message) {
+	resp := rt.newResponse()
+	var header metadata.MD
+	if err := g.conn.Invoke(outgoingContext(r.Context(), r, bearerToken(r)), rt.method, req, resp, grpc.Header(&header)); err != nil {
+		setResponseHeaders(w, header)
+		writeError(w, err)
+		return
+	}
+
+	body, err := marshalOptions.Marshal(resp)
+	if err != nil {
+		writeError(w, status.Errorf(codes.Internal, "encode response: %v", err))
+		return
+	}
+	setResponseHeaders(w, header)
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(append(body, '\n'))
+}
+
+// openStream starts a server streaming call and waits for its headers, so
+// that an error before the first message can still be written with a
+// matching HTTP status.
+func (g *Gateway) openStream(ctx context.Context, rt route, req proto.Message) (grpc.ClientStream, metadata.MD, error) {
+	desc := &grpc.StreamDesc{StreamName: rt.method, ServerStreams: true}
+	cs, err := g.conn.NewStream(ctx, desc, rt.method)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cs.SendMsg(req); err != nil {
+		return nil, nil, err
+	}
+	if err := cs.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+	header, err := cs.Header()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cs, header, nil
+}
+
+// serveStream calls a server streaming method and writes one JSON line per
+// message. An error after the first message is written as a final line
+// holding the JSON form of the status, wrapped as {"error": ...}.
+func (g *Gateway) serveStream(w http.ResponseWriter, r *http.Request, rt route, req proto.Message) {
+	cs, header, err := g.openStream(outgoingContext(r.Context(), r, bearerToken(r)), rt, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	first := rt.newResponse()
+	if err := cs.RecvMsg(first); err != nil && err != io.EOF {
+		setResponseHeaders(w, header)
+		writeError(w, err)
+		return
+	} else if err == io.EOF {
+		first = nil
+	}
+
+	setResponseHeaders(w, header)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+
+	for msg := first; msg != nil; {
+		line, err := marshalOptions.Marshal(msg)
+		if err != nil {
+			writeStreamError(w, status.Errorf(codes.Internal, "encode response: %v", err))
+			return
+		}
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		msg = rt.newResponse()
+		if err := cs.RecvMsg(msg); err != nil {
+			if err != io.EOF {
+				writeStreamError(w, err)
+			}
+			return
+		}
+	}
+}
+
+// writeStreamError writes the final error line of a stream.
+func writeStreamError(w io.Writer, err error) {
+	body, merr := marshalOptions.Marshal(status.Convert(err).Proto())
+	if merr != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "{\"error\":%s}\n", body)
+}
+
+// serveDownload streams the content of a dataset. The metadata in the first
+// message sets the content type, length and file name; an error after the
+// content has started aborts the response, so clients never mistake a
+// truncated body for a complete one.
+func (g *Gateway) serveDownload(w http.ResponseWriter, r *http.Request, rt route, req proto.Message) {
+	cs, header, err := g.openStream(outgoingContext(r.Context(), r, bearerToken(r)), rt, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	setResponseHeaders(w, header)
+
+	started := false
+	for {
+		msg := &services.DownloadDatasetResponse{}
+		if err := cs.RecvMsg(msg); err != nil {
+			if err == io.EOF {
+				if !started {
+					w.WriteHeader(http.StatusOK)
+				}
+				return
+			}
+			if !started {
+				writeError(w, err)
+				return
+			}
+			panic(http.ErrAbortHandler)
+		}
+
+		if md := msg.GetMetadata(); md != nil && !started {
+			setContentHeaders(w, md, isPartial(req))
+			continue
+		}
+		if chunk := msg.GetChunk(); chunk != nil {
+			if !started {
+				if w.Header().Get("Content-Type") == "" {
+					w.Header().Set("Content-Type", "application/octet-stream")
+				}
+				w.WriteHeader(http.StatusOK)
+				started = true
+			}
+			if _, err := w.Write(chunk.GetData()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// isPartial reports whether a download request asks for a range of chunks
+// rather than the whole content.
+func isPartial(req proto.Message) bool {
+	dr, ok := req.(*services.DownloadDatasetRequest)
+	return ok && (dr.GetStartChunk() > 0 || dr.GetEndChunk() > 0)
+}
+
+// setContentHeaders sets the headers describing a dataset download. The
+// length is only known for the whole content.
+func setContentHeaders(w http.ResponseWriter, md *services.DownloadMetadata, partial bool) {
+	h := w.Header()
+	contentType := md.GetDataset().GetContentType()
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h.Set("Content-Type", contentType)
+	if md.GetTotalSize() > 0 && !partial {
+		h.Set("Content-Length", strconv.FormatInt(md.GetTotalSize(), 10))
+	}
+	if name := md.GetDataset().GetName(); name != "" {
+		h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	}
+	if hash := md.GetContentHash(); hash != "" && !partial {
+		h.Set("X-Content-SHA256", hash)
+	}
+}
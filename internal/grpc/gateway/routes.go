@@ -0,0 +1,181 @@
+package gateway
+
+import (
+	services "bib/api/gen/go/bib/v1/services"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// routeKind selects how a route's gRPC call is made and its result written.
+type routeKind int
+
+const (
+	// unary calls a unary method and writes its response.
+	unary routeKind = iota
+
+	// stream calls a server streaming method and writes each message as a
+	// line of JSON (application/x-ndjson).
+	stream
+
+	// download calls DatasetService.DownloadDataset and writes the content
+	// of the dataset.
+	download
+
+	// socket bridges a server streaming method to a WebSocket.
+	socket
+)
+
+// route maps an HTTP method and path to a gRPC method.
+type route struct {
+	// pattern is the http.ServeMux pattern. Wildcards name the request
+	// fields they set.
+	pattern string
+
+	// method is the full gRPC method name.
+	method string
+
+	kind routeKind
+
+	// public routes need no authentication.
+	public bool
+
+	newRequest  func() proto.Message
+	newResponse func() proto.Message
+}
+
+// routes are the endpoints of the gateway.
+var routes = []route{
+	// Health
+	{
+		pattern:     "GET /v1/health",
+		method:      "/bib.v1.services.HealthService/Check",
+		public:      true,
+		newRequest:  func() proto.Message { return &services.HealthCheckRequest{} },
+		newResponse: func() proto.Message { return &services.HealthCheckResponse{} },
+	},
+	{
+		pattern:     "GET /v1/version",
+		method:      "/bib.v1.services.HealthService/GetVersion",
+		public:      true,
+		newRequest:  func() proto.Message { return &services.GetDaemonVersionRequest{} },
+		newResponse: func() proto.Message { return &services.GetDaemonVersionResponse{} },
+	},
+	{
+		pattern:     "GET /v1/node",
+		method:      "/bib.v1.services.HealthService/GetNodeInfo",
+		newRequest:  func() proto.Message { return &services.GetNodeInfoRequest{} },
+		newResponse: func() proto.Message { return &services.GetNodeInfoResponse{} },
+	},
+
+	// Queries
+	{
+		pattern:     "POST /v1/query",
+		method:      "/bib.v1.services.QueryService/Execute",
+		newRequest:  func() proto.Message { return &services.ExecuteQueryRequest{} },
+		newResponse: func() proto.Message { return &services.ExecuteQueryResponse{} },
+	},
+	{
+		pattern:     "POST /v1/query/stream",
+		method:      "/bib.v1.services.QueryService/ExecuteStream",
+		kind:        stream,
+		newRequest:  func() proto.Message { return &services.ExecuteQueryRequest{} },
+		newResponse: func() proto.Message { return &services.QueryResult{} },
+	},
+	{
+		pattern:     "POST /v1/query/validate",
+		method:      "/bib.v1.services.QueryService/ValidateQuery",
+		newRequest:  func() proto.Message { return &services.ValidateQueryRequest{} },
+		newResponse: func() proto.Message { return &services.ValidateQueryResponse{} },
+	},
+	{
+		pattern:     "GET /v1/queries",
+		method:      "/bib.v1.services.QueryService/ListSavedQueries",
+		newRequest:  func() proto.Message { return &services.ListSavedQueriesRequest{} },
+		newResponse: func() proto.Message { return &services.ListSavedQueriesResponse{} },
+	},
+	{
+		pattern:     "POST /v1/queries/{name}/run",
+		method:      "/bib.v1.services.QueryService/RunSavedQuery",
+		newRequest:  func() proto.Message { return &services.RunSavedQueryRequest{} },
+		newResponse: func() proto.Message { return &services.RunSavedQueryResponse{} },
+	},
+
+	// Datasets (read only)
+	{
+		pattern:     "GET /v1/datasets",
+		method:      "/bib.v1.services.DatasetService/ListDatasets",
+		newRequest:  func() proto.Message { return &services.ListDatasetsRequest{} },
+		newResponse: func() proto.Message { return &services.ListDatasetsResponse{} },
+	},
+	{
+		pattern:     "GET /v1/datasets/{id}",
+		method:      "/bib.v1.services.DatasetService/GetDataset",
+		newRequest:  func() proto.Message { return &services.GetDatasetRequest{} },
+		newResponse: func() proto.Message { return &services.GetDatasetResponse{} },
+	},
+	{
+		pattern:     "GET /v1/datasets/{dataset_id}/versions",
+		method:      "/bib.v1.services.DatasetService/GetDatasetVersions",
+		newRequest:  func() proto.Message { return &services.GetDatasetVersionsRequest{} },
+		newResponse: func() proto.Message { return &services.GetDatasetVersionsResponse{} },
+	},
+	{
+		pattern:     "GET /v1/datasets/{id}/content",
+		method:      "/bib.v1.services.DatasetService/DownloadDataset",
+		kind:        download,
+		newRequest:  func() proto.Message { return &services.DownloadDatasetRequest{} },
+		newResponse: func() proto.Message { return &services.DownloadDatasetResponse{} },
+	},
+
+	// Live updates over WebSocket
+	{
+		pattern:     "GET /v1/ws/health",
+		method:      "/bib.v1.services.HealthService/Watch",
+		kind:        socket,
+		public:      true,
+		newRequest:  func() proto.Message { return &services.HealthCheckRequest{} },
+		newResponse: func() proto.Message { return &services.HealthCheckResponse{} },
+	},
+	{
+		pattern:     "GET /v1/ws/query",
+		method:      "/bib.v1.services.QueryService/ExecuteStream",
+		kind:        socket,
+		newRequest:  func() proto.Message { return &services.ExecuteQueryRequest{} },
+		newResponse: func() proto.Message { return &services.QueryResult{} },
+	},
+	{
+		pattern:     "GET /v1/ws/topics/updates",
+		method:      "/bib.v1.services.TopicService/StreamTopicUpdates",
+		kind:        socket,
+		newRequest:  func() proto.Message { return &services.StreamTopicUpdatesRequest{} },
+		newResponse: func() proto.Message { return &services.TopicUpdate{} },
+	},
+	{
+		pattern:     "GET /v1/ws/datasets/events",
+		method:      "/bib.v1.services.DatasetService/StreamDatasetEvents",
+		kind:        socket,
+		newRequest:  func() proto.Message { return &services.StreamDatasetEventsRequest{} },
+		newResponse: func() proto.Message { return &services.DatasetEvent{} },
+	},
+	{
+		pattern:     "GET /v1/ws/jobs/{id}/status",
+		method:      "/bib.v1.services.JobService/StreamJobStatus",
+		kind:        socket,
+		newRequest:  func() proto.Message { return &services.StreamJobStatusRequest{} },
+		newResponse: func() proto.Message { return &services.JobStatusUpdate{} },
+	},
+	{
+		pattern:     "GET /v1/ws/jobs/{id}/logs",
+		method:      "/bib.v1.services.JobService/StreamJobLogs",
+		kind:        socket,
+		newRequest:  func() proto.Message { return &services.StreamJobLogsRequest{} },
+		newResponse: func() proto.Message { return &services.JobLogEntry{} },
+	},
+	{
+		pattern:     "GET /v1/ws/logs",
+		method:      "/bib.v1.services.AdminService/StreamLogs",
+		kind:        socket,
+		newRequest:  func() proto.Message { return &services.StreamLogsRequest{} },
+		newResponse: func() proto.Message { return &services.LogEntry{} },
+	},
+}
@@ -0,0 +1,236 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"bib/internal/grpc/middleware"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WebSocket timing. A client must send its hello frame within helloTimeout
+// and answer pings within pongWait. A message the client does not take
+// within writeWait ends the stream, so a stalled client cannot hold a
+// stream open indefinitely.
+const (
+	helloTimeout = 10 * time.Second
+	pongWait     = 60 * time.Second
+	pingPeriod   = pongWait / 2
+	writeWait    = 30 * time.Second
+)
+
+// closeCodeBase is added to the gRPC code of a failed stream to give the
+// WebSocket close code, in the range reserved for applications.
+const closeCodeBase = 4000
+
+// socketHello is the first frame a client sends on a WebSocket. Browsers
+// cannot set headers on WebSocket requests, so credentials travel here.
+type socketHello struct {
+	Token   string          `json:"token"`
+	APIKey  string          `json:"api_key"`
+	Request json.RawMessage `json:"request"`
+}
+
+// newUpgrader returns the WebSocket upgrader of the gateway. Connections from
+// browsers must come from the gateway's own origin or an allowed one.
+func (g *Gateway) newUpgrader() websocket.Upgrader {
+	return websocket.Upgrader{
+		HandshakeTimeout: helloTimeout,
+		CheckOrigin: func(r *http.Request) bool {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+				return true
+			}
+			return g.allowOrigin(origin)
+		},
+		Error: func(w http.ResponseWriter, _ *http.Request, code int, reason error) {
+			writeError(w, status.Error(codes.InvalidArgument, reason.Error()))
+		},
+	}
+}
+
+// serveSocket bridges a server streaming method to a WebSocket.
+//
+// The client opens the socket and sends a hello frame holding its
+// credentials and the JSON form of the request. Path wildcards and query
+// parameters are then applied to the request as for other routes. Each
+// message of the stream is sent as a text frame holding its JSON form. A
+// message is only received from the stream once the previous one is
+// written, so a slow client slows the stream down rather than filling
+// buffers. When the stream ends the socket is closed normally; if it fails,
+// a final {"error": <status>} frame is sent and the close code is 4000 plus
+// the gRPC code.
+func (g *Gateway) serveSocket(w http.ResponseWriter, r *http.Request, rt route) {
+	conn, err := g.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has written the response
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(g.maxBodySize)
+
+	hello, err := readHello(conn)
+	if err != nil {
+		closeSocket(conn, err)
+		return
+	}
+	if err := g.authorizeSocket(rt, hello); err != nil {
+		closeSocket(conn, err)
+		return
+	}
+	req, err := socketRequest(r, rt, hello)
+	if err != nil {
+		closeSocket(conn, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(outgoingContext(r.Context(), r, hello.Token))
+	defer cancel()
+
+	// The client sends nothing after its hello, but reading processes
+	// pongs and close frames, and notices when the client goes away.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	go keepAlive(ctx, conn)
+
+	cs, _, err := g.openStream(ctx, rt, req)
+	if err != nil {
+		closeSocket(conn, err)
+		return
+	}
+	for {
+		msg := rt.newResponse()
+		if err := cs.RecvMsg(msg); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			closeSocket(conn, err)
+			return
+		}
+
+		data, err := marshalOptions.Marshal(msg)
+		if err != nil {
+			closeSocket(conn, status.Errorf(codes.Internal, "encode response: %v", err))
+			return
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			return
+		}
+	}
+}
+
+// readHello reads the hello frame of a WebSocket and arms the keepalive
+// deadline for the rest of the connection.
+func readHello(conn *websocket.Conn) (*socketHello, error) {
+	_ = conn.SetReadDeadline(time.Now().Add(helloTimeout))
+	kind, data, err := conn.ReadMessage()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "no hello frame received")
+	}
+	if kind != websocket.TextMessage {
+		return nil, status.Error(codes.InvalidArgument, "hello frame must be a text frame")
+	}
+
+	var hello socketHello
+	if err := json.Unmarshal(data, &hello); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid hello frame: %v", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	return &hello, nil
+}
+
+// authorizeSocket checks the credentials of a hello frame. Beyond the
+// gateway's own checks, methods the RBAC table requires authentication for
+// need a session token, whatever the gateway configuration, and methods
+// missing from the table are denied as the RBAC interceptors deny them.
+// Role checks are left to the interceptors of the gRPC server.
+func (g *Gateway) authorizeSocket(rt route, hello *socketHello) error {
+	if rt.public {
+		return nil
+	}
+	if err := g.checkCredentials(hello.APIKey, hello.Token); err != nil {
+		return err
+	}
+	perm, ok := middleware.PermissionFor(rt.method)
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "unknown method: %s", rt.method)
+	}
+	if perm.RequiresAuth && hello.Token == "" {
+		return status.Error(codes.Unauthenticated, "missing authentication token")
+	}
+	return nil
+}
+
+// socketRequest builds the request message of a WebSocket route from the
+// hello frame, the path wildcards and the query parameters.
+func socketRequest(r *http.Request, rt route, hello *socketHello) (proto.Message, error) {
+	req := rt.newRequest()
+	if len(hello.Request) > 0 && string(hello.Request) != "null" {
+		if err := unmarshalOptions.Unmarshal(hello.Request, req); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
+	if err := bindRequest(req, r, rt); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// keepAlive pings the client until ctx is done.
+func keepAlive(ctx context.Context, conn *websocket.Conn) {
+	ticker := time.NewTicker(pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeSocket closes a WebSocket, normally if err is nil, or else after an
+// error frame with a close code carrying the gRPC code.
+func closeSocket(conn *websocket.Conn, err error) {
+	code, reason := websocket.CloseNormalClosure, ""
+	if err != nil {
+		st := status.Convert(err)
+		if errors.Is(err, context.Canceled) {
+			st = status.New(codes.Canceled, err.Error())
+		}
+		if body, merr := marshalOptions.Marshal(st.Proto()); merr == nil {
+			_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			_ = conn.WriteMessage(websocket.TextMessage, fmt.Appendf(nil, "{\"error\":%s}", body))
+		}
+		code, reason = closeCodeBase+int(st.Code()), st.Code().String()
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+}
@@ -0,0 +1,203 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/config"
+	"bib/internal/grpc/middleware"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testJobServer struct {
+	services.UnimplementedJobServiceServer
+}
+
+// StreamJobStatus sends three updates naming the job and the caller's
+// token, unless the job is "wait", which waits for the caller to go away.
+func (testJobServer) StreamJobStatus(req *services.StreamJobStatusRequest, stream grpc.ServerStreamingServer[services.JobStatusUpdate]) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if req.GetId() == "wait" {
+		<-stream.Context().Done()
+		socketClosed <- struct{}{}
+		return stream.Context().Err()
+	}
+	if req.GetId() == "missing" {
+		return status.Error(codes.NotFound, "job missing not found")
+	}
+	for i := range 3 {
+		update := &services.JobStatusUpdate{
+			Job:         &services.Job{Id: req.GetId()},
+			Event:       strings.Join(md.Get("authorization"), ","),
+			ResumeToken: req.GetResumeToken() + string(rune('a'+i)),
+		}
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// socketClosed receives a value when a waiting stream sees its caller go.
+var socketClosed = make(chan struct{}, 1)
+
+// dialSocket opens a WebSocket on the test gateway and sends hello.
+func dialSocket(t *testing.T, url string, hello any) *websocket.Conn {
+	t.Helper()
+
+	conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(url, "http"), nil)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	resp.Body.Close()
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.WriteJSON(hello); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	return conn
+}
+
+// readAll reads text frames until the socket closes, returning the frames
+// and the close code.
+func readAll(t *testing.T, conn *websocket.Conn) ([]string, int) {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var frames []string
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			var ce *websocket.CloseError
+			if !errors.As(err, &ce) {
+				t.Fatalf("ReadMessage failed: %v", err)
+			}
+			return frames, ce.Code
+		}
+		frames = append(frames, string(data))
+	}
+}
+
+func TestSocket_Stream(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	conn := dialSocket(t, ts.URL+"/v1/ws/jobs/job-1/status", map[string]any{
+		"token":   "tok",
+		"request": map[string]string{"resume_token": "r"},
+	})
+	frames, code := readAll(t, conn)
+	if code != websocket.CloseNormalClosure {
+		t.Errorf("close code = %d, want %d", code, websocket.CloseNormalClosure)
+	}
+	if len(frames) != 3 {
+		t.Fatalf("frames = %q, want 3", frames)
+	}
+
+	var update struct {
+		Job         struct{ ID string } `json:"job"`
+		Event       string              `json:"event"`
+		ResumeToken string              `json:"resume_token"`
+	}
+	if err := json.Unmarshal([]byte(frames[2]), &update); err != nil {
+		t.Fatalf("invalid frame %s: %v", frames[2], err)
+	}
+	if update.Job.ID != "job-1" {
+		t.Errorf("job id = %q, want job-1 from the path", update.Job.ID)
+	}
+	if update.Event != "Bearer tok" {
+		t.Errorf("authorization = %q, want the token of the hello frame", update.Event)
+	}
+	if update.ResumeToken != "rc" {
+		t.Errorf("resume_token = %q, want the request of the hello frame", update.ResumeToken)
+	}
+}
+
+func TestSocket_Errors(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	tests := []struct {
+		name  string
+		path  string
+		hello any
+		code  codes.Code
+	}{
+		{"no token", "/v1/ws/jobs/job-1/status", map[string]any{}, codes.Unauthenticated},
+		{"invalid hello", "/v1/ws/jobs/job-1/status", "not an object", codes.InvalidArgument},
+		{"invalid request", "/v1/ws/jobs/job-1/status", map[string]any{"token": "tok", "request": map[string]int{"bogus": 1}}, codes.InvalidArgument},
+		{"stream error", "/v1/ws/jobs/missing/status", map[string]any{"token": "tok"}, codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dialSocket(t, ts.URL+tt.path, tt.hello)
+			frames, code := readAll(t, conn)
+			if want := closeCodeBase + int(tt.code); code != want {
+				t.Errorf("close code = %d, want %d", code, want)
+			}
+			if len(frames) != 1 || !strings.HasPrefix(frames[0], `{"error":`) {
+				t.Errorf("frames = %q, want one error frame", frames)
+			}
+		})
+	}
+}
+
+func TestSocket_TokenRequiredByRBAC(t *testing.T) {
+	// API keys alone admit requests to the gateway, but the method
+	// requires an authenticated user
+	cfg := config.GatewayConfig{Auth: config.GatewayAuthConfig{APIKeys: []string{"key"}}}
+	ts := newTestGateway(t, cfg)
+
+	conn := dialSocket(t, ts.URL+"/v1/ws/jobs/job-1/status", map[string]any{"api_key": "key"})
+	if _, code := readAll(t, conn); code != closeCodeBase+int(codes.Unauthenticated) {
+		t.Errorf("close code = %d, want Unauthenticated", code)
+	}
+}
+
+func TestSocket_ClientCloseEndsStream(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	conn := dialSocket(t, ts.URL+"/v1/ws/jobs/wait/status", map[string]any{"token": "tok"})
+	time.Sleep(50 * time.Millisecond)
+	conn.Close()
+
+	select {
+	case <-socketClosed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream still running after the client went away")
+	}
+}
+
+func TestSocket_Origin(t *testing.T) {
+	ts := newTestGateway(t, tokenConfig())
+
+	header := http.Header{"Origin": {"https://evil.example.com"}}
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/v1/ws/health", header)
+	if err == nil {
+		t.Fatal("Dial from a disallowed origin succeeded")
+	}
+	if resp == nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("response = %v, want 400", resp)
+	}
+}
+
+func TestRoutes_KnownToRBAC(t *testing.T) {
+	for _, rt := range routes {
+		perm, ok := middleware.PermissionFor(rt.method)
+		if !ok {
+			t.Errorf("%s: %s is missing from the RBAC table", rt.pattern, rt.method)
+			continue
+		}
+		if rt.public && perm.RequiresAuth {
+			t.Errorf("%s is public, but %s requires authentication", rt.pattern, rt.method)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"bib/internal/cluster"
+	"bib/internal/maintenance"
 	"bib/internal/p2p"
 	"bib/internal/storage"
 )
@@ -45,6 +46,62 @@ type HealthProvider interface {
 	HealthConfig() HealthProviderConfig
 }
 
+// CertificateProvider is implemented by health providers that manage TLS
+// certificates, to report when they expire.
+type CertificateProvider interface {
+	// CertificateExpiry returns the expiry time of each certificate, keyed
+	// by name ("ca", "server").
+	CertificateExpiry() map[string]time.Time
+}
+
+// MaintenanceProvider is implemented by health providers that run
+// background maintenance workers, to report whether they are paused.
+type MaintenanceProvider interface {
+	// MaintenanceState returns the state of the maintenance pause signal.
+	MaintenanceState() maintenance.State
+}
+
+// StorageRuntimeProvider is implemented by health providers that manage
+// PostgreSQL, to report the runtime it runs on.
+type StorageRuntimeProvider interface {
+	// StorageRuntime returns the runtime managed PostgreSQL was started
+	// with ("docker", "podman", "kubernetes" or "manual"), or "" if the
+	// database is not managed.
+	StorageRuntime() string
+}
+
+// FailoverStatus describes the last failover of managed PostgreSQL.
+type FailoverStatus struct {
+	// At is when the failover was attempted.
+	At time.Time
+
+	// From and To are the failed and the new primary.
+	From string
+	To   string
+
+	// Error is why the failover failed, empty if it succeeded.
+	Error string
+
+	// Count is how many failovers were attempted since the daemon started.
+	Count int
+}
+
+// StorageFailoverProvider is implemented by health providers that fail
+// managed PostgreSQL over to a standby, to report the last failover.
+type StorageFailoverProvider interface {
+	// StorageFailover returns the last failover, or false if there was
+	// none.
+	StorageFailover() (FailoverStatus, bool)
+}
+
+// SyncStatsProvider is implemented by health providers that sync peer
+// catalogs, to export the sync metrics.
+type SyncStatsProvider interface {
+	// SyncStats returns the sync metrics, or false if the node does not
+	// sync (it is not a full replica).
+	SyncStats() (p2p.SyncStats, bool)
+}
+
 // HealthProviderConfig contains configuration relevant to health reporting.
 type HealthProviderConfig struct {
 	// P2PEnabled indicates if P2P networking is enabled.
@@ -81,6 +138,44 @@ type ComponentHealthStatus struct {
 	SubComponents map[string]ComponentHealthStatus
 }
 
+// LoadSignal reports how loaded the daemon is. It is implemented by the
+// health service's load monitor and drives load shedding.
+type LoadSignal interface {
+	// Load returns the latest load sample.
+	Load() LoadStatus
+
+	// TrackCall counts a call as in flight until the returned func is called.
+	TrackCall() (done func())
+}
+
+// LoadStatus is a sample of the daemon's load.
+type LoadStatus struct {
+	// CPUPercent is the CPU used since the previous sample, as a percentage
+	// of the CPUs available to the daemon.
+	CPUPercent float64
+
+	// MemoryBytes is the memory held by the daemon.
+	MemoryBytes uint64
+
+	// InFlight is the number of calls being handled.
+	InFlight int64
+
+	// Overloaded names the signals over their target: "cpu", "memory" or
+	// "in_flight". It is empty while the daemon is healthy.
+	Overloaded []string
+
+	// Timestamp is when the sample was taken.
+	Timestamp time.Time
+}
+
+// ReadinessSignal reports whether the daemon is ready to serve. It is
+// implemented by the health service and drives the readiness gate.
+type ReadinessSignal interface {
+	// Ready reports whether the daemon is running and all enabled
+	// components are healthy. If not, pending names what is not ready yet.
+	Ready(ctx context.Context) (ready bool, pending []string)
+}
+
 // AuditLogger defines the interface for audit logging in services.
 type AuditLogger interface {
 	// LogServiceAction logs a service-level action for auditing.
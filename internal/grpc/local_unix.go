@@ -0,0 +1,55 @@
+//go:build !windows
+
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// localListener creates the listener for local CLI connections.
+// On Unix this is a Unix domain socket that only the daemon's user can connect to.
+func localListener(path string) (net.Listener, error) {
+	// A socket someone still accepts on belongs to another daemon
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("socket %s is in use by another process", path)
+	}
+
+	// Unix sockets leave files behind after an unclean shutdown
+	if err := removeLocalListener(path); err != nil {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restrict access to the owning user
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = lis.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return lis, nil
+}
+
+// removeLocalListener removes a Unix socket file if it exists.
+func removeLocalListener(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return os.Remove(path)
+	}
+	return nil
+}
+
+// localAddress returns a display form of the local endpoint.
+func localAddress(path string) string {
+	return "unix://" + path
+}
@@ -0,0 +1,85 @@
+//go:build !windows
+
+package grpc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalListener_Permissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bibd.sock")
+
+	lis, err := localListener(path)
+	if err != nil {
+		t.Fatalf("localListener failed: %v", err)
+	}
+	defer lis.Close()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("socket not created: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("socket permissions = %o, want 600", perm)
+	}
+}
+
+func TestLocalListener_RemovesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bibd.sock")
+
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatalf("failed to create stale file: %v", err)
+	}
+
+	lis, err := localListener(path)
+	if err != nil {
+		t.Fatalf("localListener failed with stale socket present: %v", err)
+	}
+	lis.Close()
+
+	if err := removeLocalListener(path); err != nil {
+		t.Errorf("removeLocalListener failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("socket file should be removed")
+	}
+}
+
+func TestLocalListener_SocketInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bibd.sock")
+
+	first, err := localListener(path)
+	if err != nil {
+		t.Fatalf("localListener failed: %v", err)
+	}
+	defer first.Close()
+
+	// A second daemon must not take over a socket that is still served
+	if lis, err := localListener(path); err == nil {
+		lis.Close()
+		t.Fatal("localListener took over a socket in use")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("socket in use was removed: %v", err)
+	}
+}
+
+func TestLocalListener_CreatesDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "bibd", "bibd.sock")
+
+	lis, err := localListener(path)
+	if err != nil {
+		t.Fatalf("localListener failed: %v", err)
+	}
+	defer lis.Close()
+
+	info, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("socket directory not created: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0700 {
+		t.Errorf("socket directory permissions = %o, want 700", perm)
+	}
+}
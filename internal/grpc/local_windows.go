@@ -0,0 +1,74 @@
+//go:build windows
+
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/Microsoft/go-winio"
+)
+
+const (
+	// pipePrefix is the namespace all local named pipes live in.
+	pipePrefix = `\\.\pipe\`
+
+	// defaultPipeName is used when no socket path is configured.
+	defaultPipeName = pipePrefix + "bibd-grpc"
+)
+
+// localListener creates the listener for local CLI connections.
+// On Windows this is a named pipe whose DACL only admits the daemon's user.
+func localListener(path string) (net.Listener, error) {
+	sd, err := currentUserSecurityDescriptor()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &winio.PipeConfig{
+		SecurityDescriptor: sd,
+		MessageMode:        false,
+		InputBufferSize:    65536,
+		OutputBufferSize:   65536,
+	}
+
+	return winio.ListenPipe(pipeName(path), cfg)
+}
+
+// currentUserSecurityDescriptor returns an SDDL string granting full
+// access to the current user only. The protected DACL (P) stops
+// inheritance of the default Everyone/Administrators entries.
+func currentUserSecurityDescriptor() (string, error) {
+	u, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine current user: %w", err)
+	}
+	// On Windows, user.User.Uid is the account SID
+	return "D:P(A;;GA;;;" + u.Uid + ")", nil
+}
+
+// pipeName maps a configured socket path onto the named pipe namespace.
+// Full pipe names are used as-is; filesystem-style paths use their base name.
+func pipeName(path string) string {
+	if path == "" {
+		return defaultPipeName
+	}
+	if strings.HasPrefix(path, pipePrefix) {
+		return path
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return pipePrefix + name
+}
+
+// removeLocalListener is a no-op on Windows since named pipes don't leave files.
+func removeLocalListener(_ string) error {
+	return nil
+}
+
+// localAddress returns a display form of the local endpoint.
+func localAddress(path string) string {
+	return "pipe://" + pipeName(path)
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Microsoft/go-winio"
+)
+
+func TestLocalListener_NamedPipe(t *testing.T) {
+	name := `\\.\pipe\bibd-test-` + time.Now().Format("150405.000")
+
+	lis, err := localListener(name)
+	if err != nil {
+		t.Fatalf("localListener failed: %v", err)
+	}
+	defer lis.Close()
+
+	go func() {
+		conn, err := lis.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	timeout := 5 * time.Second
+	conn, err := winio.DialPipe(name, &timeout)
+	if err != nil {
+		t.Fatalf("same-user dial failed: %v", err)
+	}
+	conn.Close()
+}
+
+func TestCurrentUserSecurityDescriptor(t *testing.T) {
+	sd, err := currentUserSecurityDescriptor()
+	if err != nil {
+		t.Fatalf("currentUserSecurityDescriptor failed: %v", err)
+	}
+	if len(sd) < len("D:P(A;;GA;;;S-)") || sd[:12] != "D:P(A;;GA;;;" {
+		t.Errorf("unexpected security descriptor %q", sd)
+	}
+}
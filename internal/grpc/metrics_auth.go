@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"bib/internal/config"
+)
+
+// metricsAuth guards the Prometheus metrics endpoint with a source network
+// allow-list and bearer tokens. Client certificates are checked by the TLS
+// listener before a request reaches it.
+type metricsAuth struct {
+	networks []*net.IPNet
+	tokens   []string
+}
+
+// newMetricsAuth parses the allow-list and tokens of cfg.
+func newMetricsAuth(cfg config.GRPCMetricsConfig) (*metricsAuth, error) {
+	a := &metricsAuth{tokens: cfg.Auth.BearerTokens}
+	for _, token := range a.tokens {
+		if token == "" {
+			return nil, fmt.Errorf("metrics: empty bearer token")
+		}
+	}
+	for _, cidr := range cfg.AllowedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("metrics: invalid allowed CIDR %q: %w", cidr, err)
+		}
+		a.networks = append(a.networks, network)
+	}
+	return a, nil
+}
+
+// wrap returns a handler serving next to the scrapes that pass the checks.
+func (a *metricsAuth) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.allowAddr(r.RemoteAddr) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		if len(a.tokens) > 0 && !a.validToken(metricsBearerToken(r)) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowAddr reports whether the remote address is in an allowed network.
+// Forwarding headers are ignored: they are set by the client.
+func (a *metricsAuth) allowAddr(remoteAddr string) bool {
+	if len(a.networks) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range a.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// validToken compares token with every configured token in constant time.
+func (a *metricsAuth) validToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	valid := 0
+	for _, t := range a.tokens {
+		valid |= subtle.ConstantTimeCompare([]byte(t), []byte(token))
+	}
+	return valid == 1
+}
+
+// metricsBearerToken returns the token of an "Authorization: Bearer" header.
+func metricsBearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+	return ""
+}
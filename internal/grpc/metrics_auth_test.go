@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bib/internal/config"
+)
+
+func TestMetricsAuth(t *testing.T) {
+	auth, err := newMetricsAuth(config.GRPCMetricsConfig{
+		Auth:         config.GRPCMetricsAuthConfig{BearerTokens: []string{"scrape-token"}},
+		AllowedCIDRs: []string{"10.0.0.0/8", "::1/128"},
+	})
+	if err != nil {
+		t.Fatalf("newMetricsAuth failed: %v", err)
+	}
+	handler := auth.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		auth       string
+		want       int
+	}{
+		{"allowed with token", "10.1.2.3:5000", "Bearer scrape-token", http.StatusOK},
+		{"allowed IPv6 with token", "[::1]:5000", "bearer scrape-token", http.StatusOK},
+		{"missing token", "10.1.2.3:5000", "", http.StatusUnauthorized},
+		{"wrong token", "10.1.2.3:5000", "Bearer other", http.StatusUnauthorized},
+		{"outside allow-list", "192.168.1.1:5000", "Bearer scrape-token", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", "10.0.0.1")
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetricsAuth_Public(t *testing.T) {
+	auth, err := newMetricsAuth(config.GRPCMetricsConfig{})
+	if err != nil {
+		t.Fatalf("newMetricsAuth failed: %v", err)
+	}
+	handler := auth.wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestNewMetricsAuth_Invalid(t *testing.T) {
+	if _, err := newMetricsAuth(config.GRPCMetricsConfig{AllowedCIDRs: []string{"10.0.0.1"}}); err == nil {
+		t.Error("expected error for CIDR without prefix length")
+	}
+	if _, err := newMetricsAuth(config.GRPCMetricsConfig{Auth: config.GRPCMetricsAuthConfig{BearerTokens: []string{""}}}); err == nil {
+		t.Error("expected error for empty bearer token")
+	}
+}
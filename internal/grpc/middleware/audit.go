@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"bib/internal/storage"
+	"bib/internal/storage/audit"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -28,6 +29,10 @@ type AuditConfig struct {
 
 	// NodeID is the ID of this node for audit entries.
 	NodeID string
+
+	// Alerts checks each entry against the audit alert rules (optional).
+	// Register callbacks on it, e.g. to block users who trigger alerts.
+	Alerts *audit.AlertDetector
 }
 
 // DefaultAuditConfig returns the default audit configuration.
@@ -64,32 +69,58 @@ var mutationMethods = map[string]string{
 	"/bib.v1.services.UserService/SetUserRole":           "UPDATE",
 	"/bib.v1.services.UserService/UpdateCurrentUser":     "UPDATE",
 	"/bib.v1.services.UserService/UpdateUserPreferences": "UPDATE",
+	"/bib.v1.services.UserService/SetPreferences":        "UPDATE",
 	"/bib.v1.services.UserService/EndUserSession":        "DELETE",
 	"/bib.v1.services.UserService/EndAllUserSessions":    "DELETE",
+	"/bib.v1.services.UserService/AddPublicKey":          "CREATE",
+	"/bib.v1.services.UserService/RemovePublicKey":       "DELETE",
 
 	// NodeService mutations
-	"/bib.v1.services.NodeService/ConnectPeer":    "CREATE",
-	"/bib.v1.services.NodeService/DisconnectPeer": "DELETE",
-	"/bib.v1.services.NodeService/BanPeer":        "CREATE",
-	"/bib.v1.services.NodeService/UnbanPeer":      "DELETE",
+	"/bib.v1.services.NodeService/ConnectPeer":        "CREATE",
+	"/bib.v1.services.NodeService/DisconnectPeer":     "DELETE",
+	"/bib.v1.services.NodeService/BanPeer":            "CREATE",
+	"/bib.v1.services.NodeService/UnbanPeer":          "DELETE",
+	"/bib.v1.services.NodeService/AddSubscription":    "CREATE",
+	"/bib.v1.services.NodeService/RemoveSubscription": "DELETE",
+	"/bib.v1.services.NodeService/SetMode":            "UPDATE",
+	"/bib.v1.services.NodeService/SendMessage":        "CREATE",
+	"/bib.v1.services.NodeService/SetLabels":          "UPDATE",
 
 	// TopicService mutations
-	"/bib.v1.services.TopicService/CreateTopic": "CREATE",
-	"/bib.v1.services.TopicService/UpdateTopic": "UPDATE",
-	"/bib.v1.services.TopicService/DeleteTopic": "DELETE",
-	"/bib.v1.services.TopicService/Subscribe":   "CREATE",
-	"/bib.v1.services.TopicService/Unsubscribe": "DELETE",
+	"/bib.v1.services.TopicService/CreateTopic":              "CREATE",
+	"/bib.v1.services.TopicService/UpdateTopic":              "UPDATE",
+	"/bib.v1.services.TopicService/DeleteTopic":              "DELETE",
+	"/bib.v1.services.TopicService/Subscribe":                "CREATE",
+	"/bib.v1.services.TopicService/Unsubscribe":              "DELETE",
+	"/bib.v1.services.TopicService/SetTopicACL":              "UPDATE",
+	"/bib.v1.services.TopicService/InviteToTopic":            "CREATE",
+	"/bib.v1.services.TopicService/RequestTopicAccess":       "CREATE",
+	"/bib.v1.services.TopicService/RespondToTopicInvitation": "UPDATE",
+	"/bib.v1.services.TopicService/ReviewTopicAccessRequest": "UPDATE",
+	"/bib.v1.services.TopicService/CancelTopicInvitation":    "UPDATE",
 
 	// DatasetService mutations
-	"/bib.v1.services.DatasetService/CreateDataset": "CREATE",
-	"/bib.v1.services.DatasetService/UpdateDataset": "UPDATE",
-	"/bib.v1.services.DatasetService/DeleteDataset": "DELETE",
-	"/bib.v1.services.DatasetService/UploadDataset": "CREATE",
+	"/bib.v1.services.DatasetService/CreateDataset":   "CREATE",
+	"/bib.v1.services.DatasetService/UpdateDataset":   "UPDATE",
+	"/bib.v1.services.DatasetService/DeleteDataset":   "DELETE",
+	"/bib.v1.services.DatasetService/UploadDataset":   "CREATE",
+	"/bib.v1.services.DatasetService/RevertToVersion": "UPDATE",
+
+	// QueryService mutations
+	"/bib.v1.services.QueryService/SaveQuery":        "CREATE",
+	"/bib.v1.services.QueryService/DeleteSavedQuery": "DELETE",
 
 	// AdminService mutations
-	"/bib.v1.services.AdminService/UpdateConfig":  "UPDATE",
-	"/bib.v1.services.AdminService/TriggerBackup": "CREATE",
-	"/bib.v1.services.AdminService/Shutdown":      "DDL",
+	"/bib.v1.services.AdminService/UpdateConfig":      "UPDATE",
+	"/bib.v1.services.AdminService/TriggerBackup":     "CREATE",
+	"/bib.v1.services.AdminService/Shutdown":          "DDL",
+	"/bib.v1.services.AdminService/Upgrade":           "DDL",
+	"/bib.v1.services.AdminService/UnblockRateLimit":  "DELETE",
+	"/bib.v1.services.AdminService/PauseMaintenance":  "DDL",
+	"/bib.v1.services.AdminService/ResumeMaintenance": "DDL",
+	"/bib.v1.services.AdminService/AcquireLock":       "CREATE",
+	"/bib.v1.services.AdminService/RenewLock":         "UPDATE",
+	"/bib.v1.services.AdminService/ReleaseLock":       "DELETE",
 
 	// JobService mutations
 	"/bib.v1.services.JobService/CreateJob": "CREATE",
@@ -97,7 +128,8 @@ var mutationMethods = map[string]string{
 	"/bib.v1.services.JobService/RetryJob":  "UPDATE",
 
 	// AuthService mutations
-	"/bib.v1.services.AuthService/Logout": "DELETE",
+	"/bib.v1.services.AuthService/Logout":            "DELETE",
+	"/bib.v1.services.AuthService/ApproveDeviceAuth": "CREATE",
 
 	// BreakGlassService mutations
 	"/bib.v1.services.BreakGlassService/InitiateBreakGlass": "DDL",
@@ -228,6 +260,7 @@ func (am *AuditMiddleware) logAuditEntry(ctx context.Context, method, action str
 			Suspicious: suspicious,
 		},
 	}
+	am.checkAlerts(ctx, entry)
 
 	// Calculate entry hash
 	entry.EntryHash = am.calculateEntryHash(entry)
@@ -239,6 +272,49 @@ func (am *AuditMiddleware) logAuditEntry(ctx context.Context, method, action str
 	}
 }
 
+// checkAlerts runs the alert rules on entry and flags it if any triggered.
+// The rules see the caller's user role, so rules grouping by role and
+// bypass roles refer to bib roles rather than the "grpc" database role.
+func (am *AuditMiddleware) checkAlerts(ctx context.Context, entry *storage.AuditEntry) {
+	if am.cfg.Alerts == nil {
+		return
+	}
+
+	role := entry.RoleUsed
+	if user, ok := UserFromContext(ctx); ok {
+		role = string(user.Role)
+	}
+	alerts := am.cfg.Alerts.Check(ctx, &audit.Entry{
+		Timestamp:       entry.Timestamp,
+		NodeID:          entry.NodeID,
+		OperationID:     entry.OperationID,
+		RoleUsed:        role,
+		Action:          audit.Action(entry.Action),
+		TableName:       entry.TableName,
+		Query:           entry.Query,
+		QueryHash:       entry.QueryHash,
+		RowsAffected:    entry.RowsAffected,
+		DurationMS:      entry.DurationMS,
+		SourceComponent: entry.SourceComponent,
+		Actor:           entry.Actor,
+		Metadata:        entry.Metadata,
+		Flags: audit.EntryFlags{
+			Suspicious: entry.Flags.Suspicious,
+		},
+	})
+	if len(alerts) == 0 {
+		return
+	}
+
+	rules := make([]string, len(alerts))
+	for i, alert := range alerts {
+		rules[i] = alert.RuleName
+	}
+	entry.Flags.AlertTriggered = true
+	entry.Flags.Suspicious = true
+	entry.Metadata["alerts"] = rules
+}
+
 // calculateEntryHash calculates a hash for the audit entry.
 func (am *AuditMiddleware) calculateEntryHash(entry *storage.AuditEntry) string {
 	data := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%d",
@@ -300,6 +376,7 @@ func (am *AuditMiddleware) LogServiceAction(ctx context.Context, action, resourc
 		Metadata:        metadata,
 		PrevHash:        lastHash,
 	}
+	am.checkAlerts(ctx, entry)
 
 	entry.EntryHash = am.calculateEntryHash(entry)
 
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	// Register gzip and zstd so they can be negotiated
+	_ "bib/internal/grpc/compression"
+)
+
+// ============================================================================
+// Compression Interceptor
+// ============================================================================
+
+// CompressionUnaryInterceptor compresses responses of at least minSize bytes
+// with the named compressor, provided the client advertised support for it.
+// Clients that don't support the algorithm get uncompressed responses.
+func CompressionUnaryInterceptor(name string, minSize int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil && messageSize(resp) >= minSize {
+			setSendCompressor(ctx, name)
+		}
+		return resp, err
+	}
+}
+
+// CompressionStreamInterceptor is the streaming counterpart of
+// CompressionUnaryInterceptor. The compressor applies to the whole stream,
+// so the decision is made on the first message sent.
+func CompressionStreamInterceptor(name string, minSize int) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &compressingServerStream{ServerStream: ss, name: name, minSize: minSize})
+	}
+}
+
+// compressingServerStream picks the stream's compressor on its first SendMsg.
+type compressingServerStream struct {
+	grpc.ServerStream
+	name    string
+	minSize int
+	once    sync.Once
+}
+
+func (s *compressingServerStream) SendMsg(m interface{}) error {
+	s.once.Do(func() {
+		if messageSize(m) >= s.minSize {
+			setSendCompressor(s.Context(), s.name)
+		}
+	})
+	return s.ServerStream.SendMsg(m)
+}
+
+// setSendCompressor enables name for the current call if the client accepts it.
+func setSendCompressor(ctx context.Context, name string) {
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil || !slices.Contains(supported, name) {
+		return
+	}
+	// Fails only once headers are already sent, in which case the
+	// response simply goes out uncompressed
+	_ = grpc.SetSendCompressor(ctx, name)
+}
+
+// messageSize returns the encoded size of m, or 0 if it isn't a proto message.
+func messageSize(m interface{}) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+	return 0
+}
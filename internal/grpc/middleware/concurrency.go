@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	grpcerrors "bib/internal/grpc/errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ============================================================================
+// Concurrency Limit Interceptor
+// ============================================================================
+
+// concurrencyRetryAfter is the delay suggested to clients rejected by the
+// concurrency limiter. Calls in flight usually finish within it.
+const concurrencyRetryAfter = time.Second
+
+// ConcurrencyLimiter caps the calls being processed at once, on the node
+// and on each connection, so a burst that slips past the rate limiter
+// cannot exhaust memory. Calls over a cap are rejected with
+// ResourceExhausted instead of queued. Health checks are always admitted.
+//
+// The limiter is also a stats.Handler: it must be installed on the server
+// with grpc.StatsHandler to count calls per connection.
+type ConcurrencyLimiter struct {
+	maxInFlight      int64
+	maxPerConnection int64
+	inFlight         atomic.Int64
+}
+
+// connInFlightKey is the context key of a connection's in-flight counter.
+type connInFlightKey struct{}
+
+// NewConcurrencyLimiter creates a limiter admitting maxInFlight calls on the
+// node and maxPerConnection calls on each connection (0 for no limit).
+func NewConcurrencyLimiter(maxInFlight, maxPerConnection int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		maxInFlight:      int64(max(maxInFlight, 0)),
+		maxPerConnection: int64(max(maxPerConnection, 0)),
+	}
+}
+
+// InFlight returns the number of calls being processed.
+func (l *ConcurrencyLimiter) InFlight() int64 {
+	return l.inFlight.Load()
+}
+
+// acquire admits a call of method, returning a function that releases it,
+// or an error if a cap is reached.
+func (l *ConcurrencyLimiter) acquire(ctx context.Context, method string) (func(), error) {
+	if strings.Contains(method, ".HealthService/") {
+		return func() {}, nil
+	}
+
+	if n := l.inFlight.Add(1); l.maxInFlight > 0 && n > l.maxInFlight {
+		l.inFlight.Add(-1)
+		return nil, concurrencyError("node", l.maxInFlight)
+	}
+
+	conn, _ := ctx.Value(connInFlightKey{}).(*atomic.Int64)
+	if conn != nil {
+		if n := conn.Add(1); l.maxPerConnection > 0 && n > l.maxPerConnection {
+			conn.Add(-1)
+			l.inFlight.Add(-1)
+			return nil, concurrencyError("connection", l.maxPerConnection)
+		}
+	}
+
+	return func() {
+		if conn != nil {
+			conn.Add(-1)
+		}
+		l.inFlight.Add(-1)
+	}, nil
+}
+
+// concurrencyError rejects a call over the cap of scope ("node" or
+// "connection").
+func concurrencyError(scope string, limit int64) error {
+	retry := &errdetails.RetryInfo{RetryDelay: durationpb.New(concurrencyRetryAfter)}
+	return grpcerrors.New(codes.ResourceExhausted, grpcerrors.ReasonResourceExhausted,
+		"too many requests in flight, try again later",
+		map[string]string{"scope": scope, "limit": strconv.FormatInt(limit, 10)}, retry)
+}
+
+// TagConn implements stats.Handler by giving each connection its own
+// in-flight counter.
+func (l *ConcurrencyLimiter) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return context.WithValue(ctx, connInFlightKey{}, new(atomic.Int64))
+}
+
+// HandleConn implements stats.Handler.
+func (l *ConcurrencyLimiter) HandleConn(context.Context, stats.ConnStats) {}
+
+// TagRPC implements stats.Handler.
+func (l *ConcurrencyLimiter) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+// HandleRPC implements stats.Handler.
+func (l *ConcurrencyLimiter) HandleRPC(context.Context, stats.RPCStats) {}
+
+// ConcurrencyLimitUnaryInterceptor rejects unary calls over the limiter's
+// caps.
+func ConcurrencyLimitUnaryInterceptor(limiter *ConcurrencyLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		release, err := limiter.acquire(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		return handler(ctx, req)
+	}
+}
+
+// ConcurrencyLimitStreamInterceptor rejects streams over the limiter's caps.
+// A stream holds its slot until it ends.
+func ConcurrencyLimitStreamInterceptor(limiter *ConcurrencyLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		release, err := limiter.acquire(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		return handler(srv, ss)
+	}
+}
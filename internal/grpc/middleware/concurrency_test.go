@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	grpcerrors "bib/internal/grpc/errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+var testUnaryInfo = &grpc.UnaryServerInfo{FullMethod: "/bib.v1.services.DatasetService/GetDataset"}
+
+// testConn returns the context of a new connection tagged by limiter.
+func testConn(limiter *ConcurrencyLimiter) context.Context {
+	return limiter.TagConn(context.Background(), &stats.ConnTagInfo{})
+}
+
+// connInFlight returns the in-flight count of the connection of ctx.
+func connInFlight(ctx context.Context) int64 {
+	return ctx.Value(connInFlightKey{}).(*atomic.Int64).Load()
+}
+
+// blockingCalls starts n unary calls through interceptor that block until
+// release is closed, and waits until they are all in the handler.
+func blockingCalls(t *testing.T, interceptor grpc.UnaryServerInterceptor, ctx context.Context, n int, release chan struct{}) *sync.WaitGroup {
+	t.Helper()
+
+	var started, done sync.WaitGroup
+	started.Add(n)
+	done.Add(n)
+	for range n {
+		go func() {
+			defer done.Done()
+			_, err := interceptor(ctx, nil, testUnaryInfo, func(context.Context, interface{}) (interface{}, error) {
+				started.Done()
+				<-release
+				return nil, nil
+			})
+			if err != nil {
+				t.Errorf("blocking call rejected: %v", err)
+				started.Done()
+			}
+		}()
+	}
+	started.Wait()
+	return &done
+}
+
+func TestConcurrencyLimit_NodeSaturation(t *testing.T) {
+	limiter := NewConcurrencyLimiter(2, 0)
+	interceptor := ConcurrencyLimitUnaryInterceptor(limiter)
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	release := make(chan struct{})
+	done := blockingCalls(t, interceptor, testConn(limiter), 2, release)
+	if got := limiter.InFlight(); got != 2 {
+		t.Fatalf("InFlight() = %d, want 2", got)
+	}
+
+	// A third call, from another connection, is over the node cap
+	_, err := interceptor(testConn(limiter), nil, testUnaryInfo, handler)
+	if got := status.Code(err); got != codes.ResourceExhausted {
+		t.Fatalf("call over the node cap: code = %v, want %v", got, codes.ResourceExhausted)
+	}
+	if info := grpcerrors.ErrorInfo(err); info == nil || info.GetMetadata()["scope"] != "node" || info.GetMetadata()["limit"] != "2" {
+		t.Errorf("call over the node cap: error info = %v, want scope node and limit 2", info)
+	}
+	if got := limiter.InFlight(); got != 2 {
+		t.Errorf("InFlight() after a rejection = %d, want 2", got)
+	}
+
+	// Health checks are admitted regardless
+	health := &grpc.UnaryServerInfo{FullMethod: "/bib.v1.services.HealthService/Check"}
+	if _, err := interceptor(context.Background(), nil, health, handler); err != nil {
+		t.Errorf("health check rejected: %v", err)
+	}
+
+	close(release)
+	done.Wait()
+	if got := limiter.InFlight(); got != 0 {
+		t.Fatalf("InFlight() after the calls ended = %d, want 0", got)
+	}
+	if _, err := interceptor(testConn(limiter), nil, testUnaryInfo, handler); err != nil {
+		t.Errorf("call after the calls ended: %v", err)
+	}
+}
+
+func TestConcurrencyLimit_PerConnection(t *testing.T) {
+	limiter := NewConcurrencyLimiter(0, 1)
+	interceptor := ConcurrencyLimitUnaryInterceptor(limiter)
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	busy := testConn(limiter)
+	release := make(chan struct{})
+	done := blockingCalls(t, interceptor, busy, 1, release)
+
+	_, err := interceptor(busy, nil, testUnaryInfo, handler)
+	if got := status.Code(err); got != codes.ResourceExhausted {
+		t.Fatalf("second call on a connection: code = %v, want %v", got, codes.ResourceExhausted)
+	}
+	if info := grpcerrors.ErrorInfo(err); info == nil || info.GetMetadata()["scope"] != "connection" {
+		t.Errorf("second call on a connection: error info = %v, want scope connection", info)
+	}
+	if got := connInFlight(busy); got != 1 {
+		t.Errorf("connection in flight after a rejection = %d, want 1", got)
+	}
+	if got := limiter.InFlight(); got != 1 {
+		t.Errorf("InFlight() after a rejection = %d, want 1", got)
+	}
+
+	// Other connections are not affected
+	if _, err := interceptor(testConn(limiter), nil, testUnaryInfo, handler); err != nil {
+		t.Errorf("call on another connection: %v", err)
+	}
+
+	close(release)
+	done.Wait()
+	if got := connInFlight(busy); got != 0 {
+		t.Errorf("connection in flight after the call ended = %d, want 0", got)
+	}
+}
+
+func TestConcurrencyLimit_StreamRelease(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 1)
+	interceptor := ConcurrencyLimitStreamInterceptor(limiter)
+	info := &grpc.StreamServerInfo{FullMethod: "/bib.v1.services.DatasetService/StreamDatasets"}
+	ctx := testConn(limiter)
+	stream := &testServerStream{ctx: ctx}
+
+	handlerErr := errors.New("stream failed")
+	tests := []struct {
+		name    string
+		handler grpc.StreamHandler
+	}{
+		{"stream ends", func(interface{}, grpc.ServerStream) error {
+			if got := limiter.InFlight(); got != 1 {
+				t.Errorf("InFlight() during the stream = %d, want 1", got)
+			}
+			return nil
+		}},
+		{"stream fails", func(interface{}, grpc.ServerStream) error { return handlerErr }},
+		{"handler panics", func(interface{}, grpc.ServerStream) error { panic("boom") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			func() {
+				defer func() { _ = recover() }()
+				_ = interceptor(nil, stream, info, tt.handler)
+			}()
+
+			if got := limiter.InFlight(); got != 0 {
+				t.Errorf("InFlight() after the stream = %d, want 0", got)
+			}
+			if got := connInFlight(ctx); got != 0 {
+				t.Errorf("connection in flight after the stream = %d, want 0", got)
+			}
+		})
+	}
+
+	// The slot is free again
+	if err := interceptor(nil, stream, info, func(interface{}, grpc.ServerStream) error { return nil }); err != nil {
+		t.Errorf("stream after release: %v", err)
+	}
+}
+
+func TestConcurrencyLimit_NeverNegative(t *testing.T) {
+	limiter := NewConcurrencyLimiter(4, 2)
+	interceptor := ConcurrencyLimitUnaryInterceptor(limiter)
+	conns := []context.Context{testConn(limiter), testConn(limiter), testConn(limiter)}
+
+	var negative atomic.Bool
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		if limiter.InFlight() < 0 || connInFlight(ctx) < 0 {
+			negative.Store(true)
+		}
+		if limiter.InFlight() > 4 || connInFlight(ctx) > 2 {
+			t.Errorf("over the caps: node %d, connection %d", limiter.InFlight(), connInFlight(ctx))
+		}
+		return nil, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := range 200 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := conns[i%len(conns)]
+			_, err := interceptor(ctx, nil, testUnaryInfo, handler)
+			if err != nil && status.Code(err) != codes.ResourceExhausted {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if limiter.InFlight() < 0 || connInFlight(ctx) < 0 {
+				negative.Store(true)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if negative.Load() {
+		t.Error("in-flight count went negative")
+	}
+	if got := limiter.InFlight(); got != 0 {
+		t.Errorf("InFlight() after all calls = %d, want 0", got)
+	}
+	for i, ctx := range conns {
+		if got := connInFlight(ctx); got != 0 {
+			t.Errorf("connection %d in flight after all calls = %d, want 0", i, got)
+		}
+	}
+}
+
+// testServerStream is a grpc.ServerStream with a fixed context.
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
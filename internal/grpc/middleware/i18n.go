@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+
+	grpcerrors "bib/internal/grpc/errors"
+
+	"google.golang.org/grpc"
+)
+
+// ============================================================================
+// Error Localization Interceptor
+// ============================================================================
+
+// LocalizeErrorsUnaryInterceptor adds a LocalizedMessage detail to errors
+// that carry a message code, in the locale the client asked for with the
+// accept-language metadata (English if none is supported). The status code,
+// message and message code stay unchanged for machine consumers.
+func LocalizeErrorsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		return resp, grpcerrors.LocalizeError(ctx, err)
+	}
+}
+
+// LocalizeErrorsStreamInterceptor is the streaming counterpart of
+// LocalizeErrorsUnaryInterceptor.
+func LocalizeErrorsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return grpcerrors.LocalizeError(ss.Context(), handler(srv, ss))
+	}
+}
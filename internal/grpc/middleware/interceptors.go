@@ -4,19 +4,24 @@ package middleware
 import (
 	"context"
 	"fmt"
-	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
 	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/storage/audit"
 
 	"github.com/google/uuid"
 	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // Context keys for request metadata
@@ -161,60 +166,6 @@ func logRPCCall(requestID, method, peer string, code codes.Code, duration time.D
 	}
 }
 
-// ============================================================================
-// Recovery Interceptor
-// ============================================================================
-
-// RecoveryUnaryInterceptor catches panics and converts them to gRPC errors.
-func RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				requestID := RequestIDFromContext(ctx)
-				stack := string(debug.Stack())
-
-				prefix := requestID
-				if len(requestID) > 8 {
-					prefix = requestID[:8]
-				}
-
-				// Log the panic with full stack trace
-				fmt.Printf("[gRPC] PANIC %s %s: %v\n%s\n",
-					prefix, info.FullMethod, r, stack)
-
-				// Return internal error (don't leak panic details to client)
-				err = status.Errorf(codes.Internal, "internal server error (request_id: %s)", requestID)
-			}
-		}()
-
-		return handler(ctx, req)
-	}
-}
-
-// RecoveryStreamInterceptor catches panics in streaming RPCs.
-func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				requestID := RequestIDFromContext(ss.Context())
-				stack := string(debug.Stack())
-
-				prefix := requestID
-				if len(requestID) > 8 {
-					prefix = requestID[:8]
-				}
-
-				fmt.Printf("[gRPC] PANIC %s %s: %v\n%s\n",
-					prefix, info.FullMethod, r, stack)
-
-				err = status.Errorf(codes.Internal, "internal server error (request_id: %s)", requestID)
-			}
-		}()
-
-		return handler(srv, ss)
-	}
-}
-
 // ============================================================================
 // Rate Limiting Interceptor
 // ============================================================================
@@ -226,6 +177,13 @@ type RateLimiter struct {
 	rps      float64
 	burst    int
 
+	// methods overrides the limit of methods and services, keyed by
+	// lower-case "service/method" or "service"
+	methods map[string]Limit
+
+	// blocks holds the users and roles blocked by audit alerts (optional)
+	blocks *audit.RateLimiter
+
 	// Cleanup old limiters periodically
 	lastCleanup time.Time
 	cleanupAge  time.Duration
@@ -242,8 +200,41 @@ func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
 	}
 }
 
+// Limit is a request rate with its burst size.
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// WithMethodLimits sets limits for individual methods or whole services,
+// keyed by "Service/Method" or "Service" (case-insensitive). Calls of a
+// method with a limit of its own are counted separately from the global
+// limit.
+func (rl *RateLimiter) WithMethodLimits(limits map[string]Limit) *RateLimiter {
+	rl.methods = make(map[string]Limit, len(limits))
+	for name, limit := range limits {
+		rl.methods[strings.ToLower(name)] = limit
+	}
+	return rl
+}
+
+// WithAuditBlocks makes the limiter reject users and roles blocked by audit
+// alerts that trigger rate limiting.
+func (rl *RateLimiter) WithAuditBlocks(blocks *audit.RateLimiter) *RateLimiter {
+	rl.blocks = blocks
+	return rl
+}
+
 // Allow checks if the request should be allowed for the given key (user ID or IP).
 func (rl *RateLimiter) Allow(key string) bool {
+	_, ok := rl.AllowMethod(key, "")
+	return ok
+}
+
+// AllowMethod checks if a call of method (a full gRPC method name) should be
+// allowed for the given key. If not, retryAfter is how long until the call
+// would be allowed, or 0 if it never will.
+func (rl *RateLimiter) AllowMethod(key, method string) (retryAfter time.Duration, ok bool) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
@@ -252,13 +243,46 @@ func (rl *RateLimiter) Allow(key string) bool {
 		rl.cleanup()
 	}
 
+	limit, name := rl.limitFor(method)
+	if name != "" {
+		key += "|" + name
+	}
+
 	limiter, exists := rl.limiters[key]
 	if !exists {
-		limiter = rate.NewLimiter(rate.Limit(rl.rps), rl.burst)
+		limiter = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)
 		rl.limiters[key] = limiter
 	}
 
-	return limiter.Allow()
+	r := limiter.Reserve()
+	if !r.OK() {
+		return 0, false
+	}
+	if delay := r.Delay(); delay > 0 {
+		r.Cancel()
+		return delay, false
+	}
+	return 0, true
+}
+
+// limitFor returns the limit of method and the name of its override, or the
+// global limit and an empty name.
+func (rl *RateLimiter) limitFor(method string) (Limit, string) {
+	global := Limit{RequestsPerSecond: rl.rps, Burst: rl.burst}
+	if len(rl.methods) == 0 || method == "" {
+		return global, ""
+	}
+
+	// "/bib.v1.services.QueryService/Execute" -> "queryservice/execute"
+	name := strings.ToLower(method[strings.LastIndex(method, ".")+1:])
+	if limit, ok := rl.methods[name]; ok {
+		return limit, name
+	}
+	service, _, _ := strings.Cut(name, "/")
+	if limit, ok := rl.methods[service]; ok {
+		return limit, service
+	}
+	return global, ""
 }
 
 func (rl *RateLimiter) cleanup() {
@@ -273,10 +297,14 @@ func (rl *RateLimiter) cleanup() {
 // RateLimitUnaryInterceptor applies rate limiting to unary RPCs.
 func RateLimitUnaryInterceptor(limiter *RateLimiter, getUserFromCtx func(ctx context.Context) (*domain.User, bool)) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := limiter.checkBlocked(ctx, getUserFromCtx); err != nil {
+			return nil, err
+		}
+
 		key := getRateLimitKey(ctx, getUserFromCtx)
 
-		if !limiter.Allow(key) {
-			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		if retryAfter, ok := limiter.AllowMethod(key, info.FullMethod); !ok {
+			return nil, rateLimitError("rate limit exceeded", nil, retryAfter)
 		}
 
 		return handler(ctx, req)
@@ -286,16 +314,52 @@ func RateLimitUnaryInterceptor(limiter *RateLimiter, getUserFromCtx func(ctx con
 // RateLimitStreamInterceptor applies rate limiting to streaming RPCs.
 func RateLimitStreamInterceptor(limiter *RateLimiter, getUserFromCtx func(ctx context.Context) (*domain.User, bool)) grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limiter.checkBlocked(ss.Context(), getUserFromCtx); err != nil {
+			return err
+		}
+
 		key := getRateLimitKey(ss.Context(), getUserFromCtx)
 
-		if !limiter.Allow(key) {
-			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		if retryAfter, ok := limiter.AllowMethod(key, info.FullMethod); !ok {
+			return rateLimitError("rate limit exceeded", nil, retryAfter)
 		}
 
 		return handler(srv, ss)
 	}
 }
 
+// checkBlocked rejects the request if its user or the user's role is blocked
+// by an audit alert. Bypass roles are never blocked.
+func (rl *RateLimiter) checkBlocked(ctx context.Context, getUserFromCtx func(ctx context.Context) (*domain.User, bool)) error {
+	if rl.blocks == nil || getUserFromCtx == nil {
+		return nil
+	}
+	user, ok := getUserFromCtx(ctx)
+	if !ok || user == nil || rl.blocks.IsBypassRole(string(user.Role)) {
+		return nil
+	}
+
+	for _, key := range []string{"actor:" + user.ID.String(), "role:" + string(user.Role)} {
+		if block, blocked := rl.blocks.ActiveBlock(key); blocked {
+			return rateLimitError("blocked by audit alert", map[string]string{
+				"rule":          block.Rule,
+				"blocked_until": block.ExpiresAt.UTC().Format(time.RFC3339),
+			}, time.Until(block.ExpiresAt))
+		}
+	}
+	return nil
+}
+
+// rateLimitError rejects a call. A positive retryAfter is sent as a
+// RetryInfo detail telling the client when to try again.
+func rateLimitError(message string, metadata map[string]string, retryAfter time.Duration) error {
+	var details []protoadapt.MessageV1
+	if retryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	}
+	return grpcerrors.New(codes.ResourceExhausted, grpcerrors.ReasonRateLimited, message, metadata, details...)
+}
+
 func getRateLimitKey(ctx context.Context, getUserFromCtx func(ctx context.Context) (*domain.User, bool)) string {
 	// First, try to get user ID from context (set by auth interceptor)
 	if getUserFromCtx != nil {
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/interfaces"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ============================================================================
+// Load Shedding Interceptor
+// ============================================================================
+
+// AdaptiveLimiter sheds load while the daemon is overloaded.
+//
+// It admits a share of the calls, the factor. Each load sample over target
+// halves the factor, down to a minimum; each sample under target raises it
+// by a tenth, back up to all calls. While the factor is below one, calls
+// beyond the share, or beyond the in-flight target scaled by it, are
+// rejected with Unavailable, so clients back off or fail over to another
+// node. Health checks are always admitted.
+type AdaptiveLimiter struct {
+	signal      interfaces.LoadSignal
+	maxInFlight int64
+	minFactor   float64
+	retryAfter  time.Duration
+
+	mu      sync.Mutex
+	factor  float64
+	credit  float64
+	sampled time.Time
+}
+
+// NewAdaptiveLimiter creates a limiter driven by signal. maxInFlight is the
+// number of calls admitted at once while healthy (0 for no limit), minFactor
+// the smallest share of calls admitted, and retryAfter the delay suggested
+// to rejected clients.
+func NewAdaptiveLimiter(signal interfaces.LoadSignal, maxInFlight int, minFactor float64, retryAfter time.Duration) *AdaptiveLimiter {
+	if minFactor <= 0 || minFactor > 1 {
+		minFactor = 0.1
+	}
+	return &AdaptiveLimiter{
+		signal:      signal,
+		maxInFlight: int64(maxInFlight),
+		minFactor:   minFactor,
+		retryAfter:  retryAfter,
+		factor:      1,
+	}
+}
+
+// Admit reports whether a call of method (a full gRPC method name) may
+// proceed under the current load.
+func (a *AdaptiveLimiter) Admit(method string) bool {
+	if strings.Contains(method, ".HealthService/") {
+		return true
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	load := a.signal.Load()
+	a.adjust(load)
+	if a.factor >= 1 {
+		return true
+	}
+
+	if a.maxInFlight > 0 {
+		limit := max(int64(float64(a.maxInFlight)*a.factor), 1)
+		if load.InFlight >= limit {
+			return false
+		}
+	}
+
+	// Spread the admitted share evenly over the calls
+	a.credit += a.factor
+	if a.credit < 1 {
+		return false
+	}
+	a.credit--
+	return true
+}
+
+// adjust moves the factor once per new load sample.
+func (a *AdaptiveLimiter) adjust(load interfaces.LoadStatus) {
+	if !load.Timestamp.After(a.sampled) {
+		return
+	}
+	a.sampled = load.Timestamp
+
+	if len(load.Overloaded) > 0 {
+		a.factor = max(a.factor/2, a.minFactor)
+		return
+	}
+	a.factor += 0.1
+	if a.factor > 0.99 {
+		a.factor, a.credit = 1, 0
+	}
+}
+
+// overloadedError rejects a call shed by the limiter.
+func (a *AdaptiveLimiter) overloadedError() error {
+	retry := &errdetails.RetryInfo{RetryDelay: durationpb.New(a.retryAfter)}
+	return grpcerrors.New(codes.Unavailable, grpcerrors.ReasonOverloaded, "node overloaded, try again later", nil, retry)
+}
+
+// AdaptiveLimitUnaryInterceptor sheds unary calls while the daemon is
+// overloaded and counts the admitted ones as in flight.
+func AdaptiveLimitUnaryInterceptor(limiter *AdaptiveLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !limiter.Admit(info.FullMethod) {
+			return nil, limiter.overloadedError()
+		}
+
+		done := limiter.signal.TrackCall()
+		defer done()
+
+		return handler(ctx, req)
+	}
+}
+
+// AdaptiveLimitStreamInterceptor sheds new streams while the daemon is
+// overloaded. Streams are not counted as in flight, as watches stay open
+// for as long as the client runs.
+func AdaptiveLimitStreamInterceptor(limiter *AdaptiveLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !limiter.Admit(info.FullMethod) {
+			return limiter.overloadedError()
+		}
+
+		return handler(srv, ss)
+	}
+}
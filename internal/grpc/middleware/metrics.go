@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"bib/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// ============================================================================
+// Request Metrics Interceptor
+// ============================================================================
+
+// RequestMetrics records the rate, errors and duration of every call, per
+// service, method and status code. It is a prometheus.Collector, to be
+// registered on the server's metrics registry.
+type RequestMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec // nil if histograms are disabled
+}
+
+// NewRequestMetrics creates the request metrics. Without histograms only
+// the call counts are recorded, which saves memory on busy nodes.
+func NewRequestMetrics(histograms bool) *RequestMetrics {
+	m := &RequestMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metrics.GRPCRequests,
+			Help: "Completed gRPC calls by service, method and status code.",
+		}, []string{metrics.ServiceLabel, metrics.MethodLabel, metrics.CodeLabel}),
+	}
+	if histograms {
+		m.duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metrics.GRPCRequestDuration,
+			Help:    "Duration of gRPC calls in seconds by service and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{metrics.ServiceLabel, metrics.MethodLabel})
+	}
+	return m
+}
+
+// Describe implements prometheus.Collector.
+func (m *RequestMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	if m.duration != nil {
+		m.duration.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *RequestMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	if m.duration != nil {
+		m.duration.Collect(ch)
+	}
+}
+
+// observe records a completed call of fullMethod.
+func (m *RequestMetrics) observe(fullMethod string, start time.Time, err error) {
+	service, method := splitMethodName(fullMethod)
+	m.requests.WithLabelValues(service, method, status.Code(err).String()).Inc()
+	if m.duration != nil {
+		m.duration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// splitMethodName splits "/bib.v1.services.QueryService/Execute" into
+// "QueryService" and "Execute".
+func splitMethodName(fullMethod string) (service, method string) {
+	service, method, _ = strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service[strings.LastIndex(service, ".")+1:], method
+}
+
+// RequestMetricsUnaryInterceptor records unary calls in m.
+func RequestMetricsUnaryInterceptor(m *RequestMetrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// RequestMetricsStreamInterceptor records streaming calls in m, timed until
+// the stream ends.
+func RequestMetricsStreamInterceptor(m *RequestMetrics) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observe(info.FullMethod, start, err)
+		return err
+	}
+}
@@ -3,9 +3,12 @@ package middleware
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strings"
 
 	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -69,6 +72,7 @@ var methodPermissions = map[string]MethodPermission{
 	"/bib.v1.services.HealthService/GetStatus":   {RequiresAuth: false},
 	"/bib.v1.services.HealthService/Ping":        {RequiresAuth: false},
 	"/bib.v1.services.HealthService/GetNodeInfo": {RequiresAuth: false},
+	"/bib.v1.services.HealthService/GetVersion":  {RequiresAuth: false},
 
 	// AuthService - authentication endpoints (public for challenge, self for session management)
 	"/bib.v1.services.AuthService/Challenge":         {RequiresAuth: false},
@@ -81,6 +85,10 @@ var methodPermissions = map[string]MethodPermission{
 	"/bib.v1.services.AuthService/ValidateSession":   {RequiresAuth: false},
 	"/bib.v1.services.AuthService/ListMySessions":    {RequiresAuth: true, AllowSelf: true},
 	"/bib.v1.services.AuthService/RevokeAllSessions": {RequiresAuth: true, AllowSelf: true},
+	"/bib.v1.services.AuthService/WhoAmI":            {RequiresAuth: true, AllowSelf: true},
+	"/bib.v1.services.AuthService/StartDeviceAuth":   {RequiresAuth: false},
+	"/bib.v1.services.AuthService/PollDeviceAuth":    {RequiresAuth: false},
+	"/bib.v1.services.AuthService/ApproveDeviceAuth": {RequiresAuth: true, AllowSelf: true},
 
 	// UserService - admin endpoints except for self-management
 	"/bib.v1.services.UserService/GetUser":               {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
@@ -97,9 +105,14 @@ var methodPermissions = map[string]MethodPermission{
 	"/bib.v1.services.UserService/UpdateCurrentUser":     {RequiresAuth: true, AllowSelf: true, AllowBootstrap: true},
 	"/bib.v1.services.UserService/GetUserPreferences":    {RequiresAuth: true, AllowSelf: true},
 	"/bib.v1.services.UserService/UpdateUserPreferences": {RequiresAuth: true, AllowSelf: true},
+	"/bib.v1.services.UserService/GetPreferences":        {RequiresAuth: true, AllowSelf: true},
+	"/bib.v1.services.UserService/SetPreferences":        {RequiresAuth: true, AllowSelf: true},
 	"/bib.v1.services.UserService/ListUserSessions":      {RequiresAuth: true, AllowSelf: true},
 	"/bib.v1.services.UserService/EndUserSession":        {RequiresAuth: true, AllowSelf: true},
 	"/bib.v1.services.UserService/EndAllUserSessions":    {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.UserService/AddPublicKey":          {RequiresAuth: true, AllowSelf: true},
+	"/bib.v1.services.UserService/ListPublicKeys":        {RequiresAuth: true, AllowSelf: true},
+	"/bib.v1.services.UserService/RemovePublicKey":       {RequiresAuth: true, AllowSelf: true},
 
 	// NodeService - mostly read-only, admin for management
 	"/bib.v1.services.NodeService/GetNode":            {RequiresAuth: true},
@@ -114,20 +127,34 @@ var methodPermissions = map[string]MethodPermission{
 	"/bib.v1.services.NodeService/BanPeer":            {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
 	"/bib.v1.services.NodeService/UnbanPeer":          {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
 	"/bib.v1.services.NodeService/ListBannedPeers":    {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.NodeService/AddSubscription":    {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.NodeService/RemoveSubscription": {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.NodeService/ListSubscriptions":  {RequiresAuth: true},
+	"/bib.v1.services.NodeService/SetMode":            {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.NodeService/SendMessage":        {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.NodeService/SetLabels":          {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
 
 	// TopicService - admin for create/delete, owner-based for updates
-	"/bib.v1.services.TopicService/CreateTopic":        {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.TopicService/GetTopic":           {RequiresAuth: true},
-	"/bib.v1.services.TopicService/ListTopics":         {RequiresAuth: true},
-	"/bib.v1.services.TopicService/UpdateTopic":        {RequiresAuth: true},
-	"/bib.v1.services.TopicService/DeleteTopic":        {RequiresAuth: true},
-	"/bib.v1.services.TopicService/Subscribe":          {RequiresAuth: true},
-	"/bib.v1.services.TopicService/Unsubscribe":        {RequiresAuth: true},
-	"/bib.v1.services.TopicService/ListSubscriptions":  {RequiresAuth: true},
-	"/bib.v1.services.TopicService/GetSubscription":    {RequiresAuth: true},
-	"/bib.v1.services.TopicService/StreamTopicUpdates": {RequiresAuth: true},
-	"/bib.v1.services.TopicService/GetTopicStats":      {RequiresAuth: true},
-	"/bib.v1.services.TopicService/SearchTopics":       {RequiresAuth: true},
+	"/bib.v1.services.TopicService/CreateTopic":              {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.TopicService/GetTopic":                 {RequiresAuth: true},
+	"/bib.v1.services.TopicService/ListTopics":               {RequiresAuth: true},
+	"/bib.v1.services.TopicService/UpdateTopic":              {RequiresAuth: true},
+	"/bib.v1.services.TopicService/DeleteTopic":              {RequiresAuth: true},
+	"/bib.v1.services.TopicService/Subscribe":                {RequiresAuth: true},
+	"/bib.v1.services.TopicService/Unsubscribe":              {RequiresAuth: true},
+	"/bib.v1.services.TopicService/ListSubscriptions":        {RequiresAuth: true},
+	"/bib.v1.services.TopicService/GetSubscription":          {RequiresAuth: true},
+	"/bib.v1.services.TopicService/StreamTopicUpdates":       {RequiresAuth: true},
+	"/bib.v1.services.TopicService/GetTopicStats":            {RequiresAuth: true},
+	"/bib.v1.services.TopicService/SearchTopics":             {RequiresAuth: true},
+	"/bib.v1.services.TopicService/SetTopicACL":              {RequiresAuth: true},
+	"/bib.v1.services.TopicService/GetTopicACL":              {RequiresAuth: true},
+	"/bib.v1.services.TopicService/InviteToTopic":            {RequiresAuth: true},
+	"/bib.v1.services.TopicService/RequestTopicAccess":       {RequiresAuth: true},
+	"/bib.v1.services.TopicService/RespondToTopicInvitation": {RequiresAuth: true},
+	"/bib.v1.services.TopicService/ReviewTopicAccessRequest": {RequiresAuth: true},
+	"/bib.v1.services.TopicService/CancelTopicInvitation":    {RequiresAuth: true},
+	"/bib.v1.services.TopicService/ListTopicInvitations":     {RequiresAuth: true},
 
 	// DatasetService - authenticated for most operations
 	"/bib.v1.services.DatasetService/CreateDataset":       {RequiresAuth: true},
@@ -136,15 +163,18 @@ var methodPermissions = map[string]MethodPermission{
 	"/bib.v1.services.DatasetService/UpdateDataset":       {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/DeleteDataset":       {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/UploadDataset":       {RequiresAuth: true},
+	"/bib.v1.services.DatasetService/FindMissingChunks":   {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/DownloadDataset":     {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/GetDatasetVersions":  {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/GetVersion":          {RequiresAuth: true},
+	"/bib.v1.services.DatasetService/RevertToVersion":     {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/GetChunk":            {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/VerifyDataset":       {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/SearchDatasets":      {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/GetDatasetStats":     {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/CopyDataset":         {RequiresAuth: true},
 	"/bib.v1.services.DatasetService/StreamDatasetEvents": {RequiresAuth: true},
+	"/bib.v1.services.DatasetService/StreamDatasets":      {RequiresAuth: true},
 
 	// QueryService - authenticated users
 	"/bib.v1.services.QueryService/Execute":          {RequiresAuth: true},
@@ -154,33 +184,49 @@ var methodPermissions = map[string]MethodPermission{
 	"/bib.v1.services.QueryService/ListFunctions":    {RequiresAuth: true},
 	"/bib.v1.services.QueryService/GetQueryHistory":  {RequiresAuth: true},
 	"/bib.v1.services.QueryService/SaveQuery":        {RequiresAuth: true},
+	"/bib.v1.services.QueryService/RunSavedQuery":    {RequiresAuth: true},
 	"/bib.v1.services.QueryService/ListSavedQueries": {RequiresAuth: true},
 	"/bib.v1.services.QueryService/DeleteSavedQuery": {RequiresAuth: true},
 
 	// AdminService - all admin-only
-	"/bib.v1.services.AdminService/GetConfig":          {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/UpdateConfig":       {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/GetMetrics":         {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/StreamLogs":         {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/GetAuditLogs":       {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/TriggerBackup":      {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/ListBackups":        {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/RestoreBackup":      {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/DeleteBackup":       {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/GetClusterStatus":   {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/TriggerSnapshot":    {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/TransferLeadership": {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/Shutdown":           {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/GetSystemInfo":      {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
-	"/bib.v1.services.AdminService/RunMaintenance":     {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/GetConfig":           {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/UpdateConfig":        {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/GetMetrics":          {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/StreamLogs":          {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/GetAuditLogs":        {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/StreamAuditLogs":     {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/QueryAudit":          {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/ListRateLimitBlocks": {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/UnblockRateLimit":    {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/TriggerBackup":       {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/ListBackups":         {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/RestoreBackup":       {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/DeleteBackup":        {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/GetClusterStatus":    {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/TriggerSnapshot":     {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/TransferLeadership":  {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/RemoveClusterMember": {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/AcquireLock":         {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/RenewLock":           {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/ReleaseLock":         {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/ListLocks":           {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/Shutdown":            {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/GetSystemInfo":       {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/RunMaintenance":      {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/PauseMaintenance":    {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/ResumeMaintenance":   {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/Upgrade":             {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/SelfTest":            {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
+	"/bib.v1.services.AdminService/CaptureProfile":      {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
 
 	// JobService - authenticated users
-	"/bib.v1.services.JobService/CreateJob":        {RequiresAuth: true},
-	"/bib.v1.services.JobService/GetJob":           {RequiresAuth: true},
-	"/bib.v1.services.JobService/ListJobs":         {RequiresAuth: true},
-	"/bib.v1.services.JobService/CancelJob":        {RequiresAuth: true},
-	"/bib.v1.services.JobService/RetryJob":         {RequiresAuth: true},
-	"/bib.v1.services.JobService/StreamJobUpdates": {RequiresAuth: true},
+	"/bib.v1.services.JobService/CreateJob":       {RequiresAuth: true},
+	"/bib.v1.services.JobService/GetJob":          {RequiresAuth: true},
+	"/bib.v1.services.JobService/ListJobs":        {RequiresAuth: true},
+	"/bib.v1.services.JobService/CancelJob":       {RequiresAuth: true},
+	"/bib.v1.services.JobService/RetryJob":        {RequiresAuth: true},
+	"/bib.v1.services.JobService/StreamJobLogs":   {RequiresAuth: true},
+	"/bib.v1.services.JobService/StreamJobStatus": {RequiresAuth: true},
 
 	// BreakGlassService - admin only
 	"/bib.v1.services.BreakGlassService/InitiateBreakGlass":  {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
@@ -188,6 +234,37 @@ var methodPermissions = map[string]MethodPermission{
 	"/bib.v1.services.BreakGlassService/GetBreakGlassStatus": {RequiresAuth: true, RequiredRole: domain.UserRoleAdmin},
 }
 
+// PermissionFor returns the permission requirements of a method, and whether
+// the method is known. Unknown methods are denied by the RBAC interceptors.
+func PermissionFor(fullMethod string) (MethodPermission, bool) {
+	perm, ok := methodPermissions[fullMethod]
+	return perm, ok
+}
+
+// EffectiveRoles returns the role and the roles it implies, highest first.
+func EffectiveRoles(role domain.UserRole) []domain.UserRole {
+	var roles []domain.UserRole
+	for _, r := range []domain.UserRole{domain.UserRoleAdmin, domain.UserRoleUser, domain.UserRoleReadonly} {
+		if hasMinimumRole(role, r) {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// AllowedMethods returns the full names of the methods a user with the role
+// may call, sorted. Public methods are included.
+func AllowedMethods(role domain.UserRole) []string {
+	var methods []string
+	for method, perm := range methodPermissions {
+		if perm.RequiredRole == "" || hasMinimumRole(role, perm.RequiredRole) {
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
 // RBACInterceptor creates a unary interceptor that enforces role-based access control.
 func RBACInterceptor(cfg RBACConfig, getUserFromToken func(ctx context.Context, token string) (*domain.User, error)) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
@@ -224,7 +301,7 @@ func RBACInterceptor(cfg RBACConfig, getUserFromToken func(ctx context.Context,
 
 		// Check required role
 		if perm.RequiredRole != "" && !hasMinimumRole(user.Role, perm.RequiredRole) {
-			return nil, status.Errorf(codes.PermissionDenied, "insufficient permissions: requires %s role", perm.RequiredRole)
+			return nil, insufficientRoleError(perm.RequiredRole)
 		}
 
 		// Add user to context and continue
@@ -263,7 +340,7 @@ func RBACStreamInterceptor(cfg RBACConfig, getUserFromToken func(ctx context.Con
 		}
 
 		if perm.RequiredRole != "" && !hasMinimumRole(user.Role, perm.RequiredRole) {
-			return status.Errorf(codes.PermissionDenied, "insufficient permissions: requires %s role", perm.RequiredRole)
+			return insufficientRoleError(perm.RequiredRole)
 		}
 
 		// Wrap the stream to include user in context
@@ -275,6 +352,13 @@ func RBACStreamInterceptor(cfg RBACConfig, getUserFromToken func(ctx context.Con
 	}
 }
 
+// insufficientRoleError reports that the caller lacks the required role.
+func insufficientRoleError(role domain.UserRole) error {
+	return grpcerrors.New(codes.PermissionDenied, grpcerrors.ReasonPermissionDenied,
+		fmt.Sprintf("insufficient permissions: requires %s role", role),
+		map[string]string{"required_role": string(role)})
+}
+
 // extractAndValidateUser extracts the session token from metadata and validates the user.
 func extractAndValidateUser(ctx context.Context, getUserFromToken func(ctx context.Context, token string) (*domain.User, error)) (*domain.User, error) {
 	token := ExtractToken(ctx)
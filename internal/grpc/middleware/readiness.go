@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/interfaces"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ============================================================================
+// Readiness Gate Interceptor
+// ============================================================================
+
+// readinessRecheck is how long the gate trusts a "not ready" answer before
+// asking the signal again, so a burst of early calls checks health once.
+const readinessRecheck = time.Second
+
+// ReadinessGate rejects calls until the daemon is ready after start.
+//
+// The server accepts connections right away, but until the daemon is
+// running and all enabled components are healthy, calls fail with
+// Unavailable and reason STARTING, naming the components still pending,
+// so load balancers and clients retry rather than see confusing failures.
+// Health checks are always admitted. Once ready, the gate stays open: it
+// only covers startup, later failures are reported by the health checks.
+type ReadinessGate struct {
+	signal  interfaces.ReadinessSignal
+	maxWait time.Duration
+	recheck time.Duration
+	created time.Time
+
+	mu      sync.Mutex
+	open    bool
+	checked time.Time
+	pending []string
+}
+
+// NewReadinessGate creates a gate consulting signal. maxWait opens the gate
+// after that long even if the daemon is not ready, so a component that
+// never converges does not lock clients out; 0 waits indefinitely.
+func NewReadinessGate(signal interfaces.ReadinessSignal, maxWait time.Duration) *ReadinessGate {
+	return &ReadinessGate{
+		signal:  signal,
+		maxWait: maxWait,
+		recheck: readinessRecheck,
+		created: time.Now(),
+	}
+}
+
+// Admit returns nil if a call of method (a full gRPC method name) may
+// proceed, and the STARTING error otherwise.
+func (g *ReadinessGate) Admit(ctx context.Context, method string) error {
+	if strings.Contains(method, ".HealthService/") || strings.HasPrefix(method, "/grpc.health.") {
+		return nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.open {
+		return nil
+	}
+	if g.maxWait > 0 && time.Since(g.created) >= g.maxWait {
+		g.open = true
+		return nil
+	}
+
+	if time.Since(g.checked) >= g.recheck {
+		ready, pending := g.signal.Ready(ctx)
+		g.checked = time.Now()
+		if ready {
+			g.open, g.pending = true, nil
+			return nil
+		}
+		g.pending = pending
+	}
+
+	return g.startingError()
+}
+
+// startingError rejects a call made before the daemon is ready.
+func (g *ReadinessGate) startingError() error {
+	message := "node is still starting, try again later"
+	var metadata map[string]string
+	if len(g.pending) > 0 {
+		message = "node is still starting (waiting for " + strings.Join(g.pending, ", ") + "), try again later"
+		metadata = map[string]string{"pending": strings.Join(g.pending, ",")}
+	}
+	retry := &errdetails.RetryInfo{RetryDelay: durationpb.New(readinessRecheck)}
+	return grpcerrors.New(codes.Unavailable, grpcerrors.ReasonStarting, message, metadata, retry)
+}
+
+// ReadinessUnaryInterceptor rejects unary calls until the daemon is ready.
+func ReadinessUnaryInterceptor(gate *ReadinessGate) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := gate.Admit(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// ReadinessStreamInterceptor rejects new streams until the daemon is ready.
+func ReadinessStreamInterceptor(gate *ReadinessGate) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := gate.Admit(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	grpcerrors "bib/internal/grpc/errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testReadiness is a ReadinessSignal that reports what it is set to.
+type testReadiness struct {
+	mu      sync.Mutex
+	ready   bool
+	pending []string
+	checks  int
+}
+
+func (r *testReadiness) Ready(context.Context) (bool, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks++
+	return r.ready, r.pending
+}
+
+func (r *testReadiness) set(ready bool, pending ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready, r.pending = ready, pending
+}
+
+func (r *testReadiness) checkCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.checks
+}
+
+// checkStarting fails unless err is the STARTING error naming pending.
+func checkStarting(t *testing.T, err error, pending string) {
+	t.Helper()
+
+	if got := status.Code(err); got != codes.Unavailable {
+		t.Fatalf("code = %v, want %v (err: %v)", got, codes.Unavailable, err)
+	}
+	if got := grpcerrors.ReasonOf(err); got != grpcerrors.ReasonStarting {
+		t.Errorf("reason = %v, want %v", got, grpcerrors.ReasonStarting)
+	}
+	if got := grpcerrors.ErrorInfo(err).GetMetadata()["pending"]; got != pending {
+		t.Errorf("pending = %q, want %q", got, pending)
+	}
+
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if r, ok := d.(*errdetails.RetryInfo); ok {
+			retry = r
+		}
+	}
+	if retry == nil || retry.GetRetryDelay().AsDuration() <= 0 {
+		t.Errorf("retry info = %v, want a retry delay", retry)
+	}
+}
+
+func TestReadinessUnaryInterceptor(t *testing.T) {
+	signal := &testReadiness{pending: []string{"storage", "p2p"}}
+	gate := NewReadinessGate(signal, 0)
+	gate.recheck = 0 // ask the signal on every call
+	interceptor := ReadinessUnaryInterceptor(gate)
+
+	calls := 0
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		calls++
+		return "ok", nil
+	}
+
+	// Rejected before the daemon is ready, without reaching the handler
+	_, err := interceptor(context.Background(), nil, testUnaryInfo, handler)
+	checkStarting(t, err, "storage,p2p")
+	if calls != 0 {
+		t.Errorf("handler called %d times before ready", calls)
+	}
+
+	// Health checks are admitted while starting
+	health := &grpc.UnaryServerInfo{FullMethod: "/bib.v1.services.HealthService/Check"}
+	if _, err := interceptor(context.Background(), nil, health, handler); err != nil {
+		t.Errorf("health check rejected while starting: %v", err)
+	}
+
+	// Admitted once ready
+	signal.set(true)
+	resp, err := interceptor(context.Background(), nil, testUnaryInfo, handler)
+	if err != nil || resp != "ok" {
+		t.Fatalf("call after ready = %v, %v; want ok", resp, err)
+	}
+
+	// The gate stays open, without asking again
+	checks := signal.checkCount()
+	signal.set(false, "storage")
+	if _, err := interceptor(context.Background(), nil, testUnaryInfo, handler); err != nil {
+		t.Errorf("call after ready rejected when a component failed later: %v", err)
+	}
+	if got := signal.checkCount(); got != checks {
+		t.Errorf("signal asked %d more times after the gate opened", got-checks)
+	}
+}
+
+func TestReadinessStreamInterceptor(t *testing.T) {
+	signal := &testReadiness{pending: []string{"cluster"}}
+	gate := NewReadinessGate(signal, 0)
+	gate.recheck = 0
+	interceptor := ReadinessStreamInterceptor(gate)
+	info := &grpc.StreamServerInfo{FullMethod: "/bib.v1.services.DatasetService/StreamDatasets"}
+	stream := &testServerStream{ctx: context.Background()}
+
+	streams := 0
+	handler := func(interface{}, grpc.ServerStream) error {
+		streams++
+		return nil
+	}
+
+	checkStarting(t, interceptor(nil, stream, info, handler), "cluster")
+	if streams != 0 {
+		t.Errorf("handler called %d times before ready", streams)
+	}
+
+	signal.set(true)
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("stream after ready: %v", err)
+	}
+	if streams != 1 {
+		t.Errorf("handler called %d times, want 1", streams)
+	}
+}
+
+func TestReadinessGate_Recheck(t *testing.T) {
+	signal := &testReadiness{pending: []string{"storage"}}
+	gate := NewReadinessGate(signal, 0)
+	gate.recheck = time.Hour
+
+	// A burst of early calls asks the signal once
+	for range 10 {
+		checkStarting(t, gate.Admit(context.Background(), testUnaryInfo.FullMethod), "storage")
+	}
+	if got := signal.checkCount(); got != 1 {
+		t.Errorf("signal asked %d times, want 1", got)
+	}
+
+	// Until the recheck interval passes, the cached answer stands
+	signal.set(true)
+	checkStarting(t, gate.Admit(context.Background(), testUnaryInfo.FullMethod), "storage")
+
+	gate.recheck = 0
+	if err := gate.Admit(context.Background(), testUnaryInfo.FullMethod); err != nil {
+		t.Errorf("Admit() after the recheck = %v, want nil", err)
+	}
+}
+
+func TestReadinessGate_MaxWait(t *testing.T) {
+	signal := &testReadiness{pending: []string{"p2p"}}
+	gate := NewReadinessGate(signal, 50*time.Millisecond)
+	gate.recheck = 0
+	interceptor := ReadinessUnaryInterceptor(gate)
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	_, err := interceptor(context.Background(), nil, testUnaryInfo, handler)
+	checkStarting(t, err, "p2p")
+
+	// After max_wait the gate opens even though the daemon is not ready
+	time.Sleep(60 * time.Millisecond)
+	if _, err := interceptor(context.Background(), nil, testUnaryInfo, handler); err != nil {
+		t.Fatalf("call after max wait: %v", err)
+	}
+	checks := signal.checkCount()
+	if _, err := interceptor(context.Background(), nil, testUnaryInfo, handler); err != nil {
+		t.Errorf("second call after max wait: %v", err)
+	}
+	if got := signal.checkCount(); got != checks {
+		t.Errorf("signal asked %d more times after max wait", got-checks)
+	}
+}
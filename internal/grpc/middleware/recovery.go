@@ -0,0 +1,287 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"bib/internal/logger"
+	"bib/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ============================================================================
+// Recovery Interceptor
+// ============================================================================
+
+// maxSummaryString is the length request summaries truncate strings to.
+const maxSummaryString = 64
+
+// PanicReporterConfig configures a PanicReporter.
+type PanicReporterConfig struct {
+	// Logger receives the panic reports. If nil, the default logger is used.
+	Logger *logger.Logger
+
+	// RedactFields are the request field names, or parts of them, whose
+	// values are left out of request summaries.
+	RedactFields []string
+
+	// CrashReportDir is the directory a crash report is written to for
+	// every panic. Empty disables crash reports.
+	CrashReportDir string
+
+	// MaxCrashReports is the number of crash reports kept. 0 keeps all.
+	MaxCrashReports int
+}
+
+// PanicReporter reports the panics recovered from handlers: it logs the
+// panic with its stack, the request ID, the method and a redacted summary
+// of the request, counts it and optionally writes a crash report. It is a
+// prometheus.Collector, to be registered on the server's metrics registry.
+type PanicReporter struct {
+	cfg    PanicReporterConfig
+	log    *logger.Logger
+	panics *prometheus.CounterVec
+
+	mu sync.Mutex // serializes crash report writing and pruning
+}
+
+// NewPanicReporter creates a panic reporter.
+func NewPanicReporter(cfg PanicReporterConfig) *PanicReporter {
+	log := cfg.Logger
+	if log == nil {
+		log = logger.Default()
+	}
+	return &PanicReporter{
+		cfg: cfg,
+		log: log,
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metrics.GRPCPanics,
+			Help: "Panics recovered from gRPC handlers by service and method.",
+		}, []string{metrics.ServiceLabel, metrics.MethodLabel}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (r *PanicReporter) Describe(ch chan<- *prometheus.Desc) {
+	r.panics.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (r *PanicReporter) Collect(ch chan<- prometheus.Metric) {
+	r.panics.Collect(ch)
+}
+
+// report reports the panic p, recovered from a call of fullMethod, and
+// returns the error the call fails with. The error doesn't leak the panic
+// to the client.
+func (r *PanicReporter) report(ctx context.Context, fullMethod string, req interface{}, p interface{}) error {
+	requestID := RequestIDFromContext(ctx)
+	summary := summarizeRequest(req, r.cfg.RedactFields)
+
+	service, method := splitMethodName(fullMethod)
+	r.panics.WithLabelValues(service, method).Inc()
+
+	attrs := []any{
+		"panic", fmt.Sprint(p),
+		"method", fullMethod,
+		"request_id", requestID,
+		"peer", getPeerAddress(ctx),
+		logger.WithStackSkip(3), // from the panicking frame
+	}
+	if summary != nil {
+		attrs = append(attrs, "request", summary)
+	}
+	if r.cfg.CrashReportDir != "" {
+		path, err := r.writeCrashReport(fullMethod, requestID, summary, p)
+		if err != nil {
+			attrs = append(attrs, "crash_report_error", err)
+		} else {
+			attrs = append(attrs, "crash_report", path)
+		}
+	}
+	r.log.Error("recovered panic in gRPC handler", attrs...)
+
+	return status.Errorf(codes.Internal, "internal server error (request_id: %s)", requestID)
+}
+
+// crashReport is the content of a crash report file.
+type crashReport struct {
+	Time      time.Time      `json:"time"`
+	Method    string         `json:"method"`
+	RequestID string         `json:"request_id"`
+	Panic     string         `json:"panic"`
+	Request   map[string]any `json:"request,omitempty"`
+	Stack     string         `json:"stack"`
+}
+
+// writeCrashReport writes a crash report for the panic p and removes the
+// oldest reports beyond the configured maximum. It returns the path of the
+// report.
+func (r *PanicReporter) writeCrashReport(fullMethod, requestID string, summary map[string]any, p interface{}) (string, error) {
+	report := crashReport{
+		Time:      time.Now().UTC(),
+		Method:    fullMethod,
+		RequestID: requestID,
+		Panic:     fmt.Sprint(p),
+		Request:   summary,
+		Stack:     string(debug.Stack()),
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode crash report: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.cfg.CrashReportDir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create crash report directory: %w", err)
+	}
+	id := requestID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	name := fmt.Sprintf("panic-%s-%s.json", report.Time.Format("20060102T150405.000000000"), id)
+	path := filepath.Join(r.cfg.CrashReportDir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+
+	r.pruneCrashReports()
+	return path, nil
+}
+
+// pruneCrashReports removes the oldest crash reports beyond the maximum.
+// Report names sort by time.
+func (r *PanicReporter) pruneCrashReports() {
+	if r.cfg.MaxCrashReports <= 0 {
+		return
+	}
+	reports, err := filepath.Glob(filepath.Join(r.cfg.CrashReportDir, "panic-*.json"))
+	if err != nil || len(reports) <= r.cfg.MaxCrashReports {
+		return
+	}
+	sort.Strings(reports)
+	for _, path := range reports[:len(reports)-r.cfg.MaxCrashReports] {
+		_ = os.Remove(path)
+	}
+}
+
+// summarizeRequest summarizes the top-level fields of a request message for
+// a panic report. Values of fields whose names contain a redact field are
+// replaced, strings are truncated and bytes, lists, maps and messages are
+// only described, so that the summary is short and free of secrets.
+func summarizeRequest(req interface{}, redact []string) map[string]any {
+	msg, ok := req.(proto.Message)
+	if !ok || msg == nil {
+		return nil
+	}
+	m := msg.ProtoReflect()
+	if !m.IsValid() {
+		return nil
+	}
+
+	summary := map[string]any{"@type": string(m.Descriptor().FullName())}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := string(fd.Name())
+		switch {
+		case shouldRedactField(name, redact):
+			summary[name] = logger.RedactedValue
+		case fd.IsList():
+			summary[name] = fmt.Sprintf("<%d items>", v.List().Len())
+		case fd.IsMap():
+			summary[name] = fmt.Sprintf("<%d entries>", v.Map().Len())
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			summary[name] = "<" + string(fd.Message().FullName()) + ">"
+		case fd.Kind() == protoreflect.BytesKind:
+			summary[name] = fmt.Sprintf("<%d bytes>", len(v.Bytes()))
+		case fd.Kind() == protoreflect.StringKind:
+			summary[name] = truncateSummary(v.String())
+		case fd.Kind() == protoreflect.EnumKind:
+			if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+				summary[name] = string(ev.Name())
+			} else {
+				summary[name] = int32(v.Enum())
+			}
+		default:
+			summary[name] = v.Interface()
+		}
+		return true
+	})
+	return summary
+}
+
+// shouldRedactField reports whether the field name contains one of the
+// redact fields, ignoring case.
+func shouldRedactField(name string, redact []string) bool {
+	name = strings.ToLower(name)
+	for _, field := range redact {
+		if field != "" && strings.Contains(name, strings.ToLower(field)) {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateSummary truncates s to maxSummaryString bytes, on a rune
+// boundary.
+func truncateSummary(s string) string {
+	if len(s) <= maxSummaryString {
+		return s
+	}
+	cut := maxSummaryString
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+// RecoveryUnaryInterceptor catches panics and converts them to gRPC errors,
+// reporting them to r. A nil r logs them to the default logger.
+func RecoveryUnaryInterceptor(r *PanicReporter) grpc.UnaryServerInterceptor {
+	if r == nil {
+		r = NewPanicReporter(PanicReporterConfig{})
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				resp, err = nil, r.report(ctx, info.FullMethod, req, p)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor catches panics in streaming RPCs, reporting
+// them to r. The stream ends with an Internal status, whatever the handler
+// sent before it panicked. A nil r logs them to the default logger.
+func RecoveryStreamInterceptor(r *PanicReporter) grpc.StreamServerInterceptor {
+	if r == nil {
+		r = NewPanicReporter(PanicReporterConfig{})
+	}
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = r.report(ss.Context(), info.FullMethod, nil, p)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
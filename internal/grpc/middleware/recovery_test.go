@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/logger"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestPanicReporter returns a panic reporter logging JSON to the
+// returned buffer.
+func newTestPanicReporter(cfg PanicReporterConfig) (*PanicReporter, *bytes.Buffer) {
+	var buf bytes.Buffer
+	cfg.Logger = &logger.Logger{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+	return NewPanicReporter(cfg), &buf
+}
+
+// panicLog decodes the panic report logged to buf.
+func panicLog(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to decode log entry %q: %v", buf.String(), err)
+	}
+	return entry
+}
+
+func panickingHandler(context.Context, interface{}) (interface{}, error) {
+	panic("backup index out of range")
+}
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	dir := t.TempDir()
+	reporter, buf := newTestPanicReporter(PanicReporterConfig{
+		RedactFields:   []string{"token"},
+		CrashReportDir: dir,
+	})
+	interceptor := RecoveryUnaryInterceptor(reporter)
+	info := &grpc.UnaryServerInfo{FullMethod: "/bib.v1.services.AdminService/DeleteBackup"}
+	req := &services.DeleteBackupRequest{BackupId: "b1", ConfirmationToken: "secret-token"}
+	ctx := WithRequestID(context.Background(), "req-123456789")
+
+	resp, err := interceptor(ctx, req, info, panickingHandler)
+	if resp != nil {
+		t.Errorf("response = %v, want nil", resp)
+	}
+	if got := status.Code(err); got != codes.Internal {
+		t.Fatalf("code = %v, want %v", got, codes.Internal)
+	}
+	if msg := status.Convert(err).Message(); !strings.Contains(msg, "req-123456789") || strings.Contains(msg, "out of range") {
+		t.Errorf("message = %q, want the request ID and not the panic", msg)
+	}
+
+	if got := testutil.ToFloat64(reporter.panics.WithLabelValues("AdminService", "DeleteBackup")); got != 1 {
+		t.Errorf("panics counted = %v, want 1", got)
+	}
+
+	entry := panicLog(t, buf)
+	for key, want := range map[string]string{
+		"panic":      "backup index out of range",
+		"method":     info.FullMethod,
+		"request_id": "req-123456789",
+	} {
+		if entry[key] != want {
+			t.Errorf("logged %s = %v, want %q", key, entry[key], want)
+		}
+	}
+	request, _ := entry["request"].(map[string]any)
+	if request["backup_id"] != "b1" || request["confirmation_token"] != logger.RedactedValue {
+		t.Errorf("logged request = %v, want backup_id and a redacted confirmation_token", request)
+	}
+	if stack, _ := entry["stack"].(string); !strings.Contains(stack, "panickingHandler") {
+		t.Errorf("logged stack does not start at the panicking frame:\n%s", stack)
+	}
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Error("log contains the redacted token")
+	}
+
+	// The crash report has the same content
+	path, _ := entry["crash_report"].(string)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read crash report %q: %v", path, err)
+	}
+	var report crashReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("failed to decode crash report: %v", err)
+	}
+	if report.Method != info.FullMethod || report.RequestID != "req-123456789" || report.Panic != "backup index out of range" {
+		t.Errorf("crash report = %+v", report)
+	}
+	if report.Request["confirmation_token"] != logger.RedactedValue {
+		t.Errorf("crash report request = %v, want the token redacted", report.Request)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("crash report contains the redacted token")
+	}
+
+	// Calls that don't panic pass through
+	resp, err = interceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) { return "ok", nil })
+	if err != nil || resp != "ok" {
+		t.Errorf("call without panic = %v, %v; want ok", resp, err)
+	}
+}
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	reporter, buf := newTestPanicReporter(PanicReporterConfig{})
+	interceptor := RecoveryStreamInterceptor(reporter)
+	info := &grpc.StreamServerInfo{FullMethod: "/bib.v1.services.DatasetService/StreamDatasets"}
+	stream := &testServerStream{ctx: WithRequestID(context.Background(), "req-stream")}
+
+	err := interceptor(nil, stream, info, func(interface{}, grpc.ServerStream) error { panic("stream broke") })
+	if got := status.Code(err); got != codes.Internal {
+		t.Fatalf("code = %v, want %v", got, codes.Internal)
+	}
+	if got := testutil.ToFloat64(reporter.panics.WithLabelValues("DatasetService", "StreamDatasets")); got != 1 {
+		t.Errorf("panics counted = %v, want 1", got)
+	}
+
+	entry := panicLog(t, buf)
+	if entry["request_id"] != "req-stream" || entry["panic"] != "stream broke" {
+		t.Errorf("log entry = %v, want the request ID and the panic", entry)
+	}
+	if _, ok := entry["crash_report"]; ok {
+		t.Error("crash report written with crash reports disabled")
+	}
+}
+
+func TestPanicReporter_PruneCrashReports(t *testing.T) {
+	dir := t.TempDir()
+	reporter, buf := newTestPanicReporter(PanicReporterConfig{CrashReportDir: dir, MaxCrashReports: 2})
+	interceptor := RecoveryUnaryInterceptor(reporter)
+
+	var paths []string
+	for range 4 {
+		buf.Reset()
+		_, _ = interceptor(context.Background(), nil, testUnaryInfo, panickingHandler)
+		paths = append(paths, panicLog(t, buf)["crash_report"].(string))
+	}
+
+	reports, err := filepath.Glob(filepath.Join(dir, "panic-*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("crash reports kept = %d, want 2", len(reports))
+	}
+	for _, path := range paths[2:] {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("newest crash report %s removed: %v", path, err)
+		}
+	}
+}
+
+func TestSummarizeRequest(t *testing.T) {
+	long := strings.Repeat("é", maxSummaryString)
+
+	tests := []struct {
+		name string
+		req  interface{}
+		want map[string]any
+	}{
+		{"not a message", "request", nil},
+		{"nil message", (*services.DeleteBackupRequest)(nil), nil},
+		{"scalars", &services.DeleteBackupRequest{BackupId: "b1", DryRun: true}, map[string]any{
+			"@type":     "bib.v1.services.DeleteBackupRequest",
+			"backup_id": "b1",
+			"dry_run":   true,
+		}},
+		{"redacted", &services.DeleteBackupRequest{ConfirmationToken: "secret"}, map[string]any{
+			"@type":              "bib.v1.services.DeleteBackupRequest",
+			"confirmation_token": logger.RedactedValue,
+		}},
+		{"lists and truncation", &services.UploadMetadata{Name: long, ChunkHashes: []string{"a", "b"}, ChunkSize: 8}, map[string]any{
+			"@type":        "bib.v1.services.UploadMetadata",
+			"name":         strings.Repeat("é", maxSummaryString/2) + "...",
+			"chunk_hashes": "<2 items>",
+			"chunk_size":   int64(8),
+		}},
+		{"bytes", &services.UploadChunk{Index: 3, Data: []byte("content")}, map[string]any{
+			"@type": "bib.v1.services.UploadChunk",
+			"index": int32(3),
+			"data":  "<7 bytes>",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summarizeRequest(tt.req, []string{"TOKEN"})
+			if len(got) != len(tt.want) {
+				t.Fatalf("summarizeRequest() = %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("summarizeRequest()[%q] = %v (%T), want %v (%T)", key, got[key], got[key], want, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Package resume encodes the resume tokens of server streams. A token marks
+// a position in a stream and is bound to the caller and to the request that
+// opened the stream, so it can't be replayed by another user, or against a
+// stream with other filters, to read from a position it was not issued for.
+//
+// Positions count from the start of the daemon process, so a token also
+// carries the epoch of the process that issued it. A token of an earlier
+// process resumes from the start of the stream.
+package resume
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// tokenField is the request field carrying the resume token. It is left out
+// of the request fingerprint, as it changes on every reconnect.
+const tokenField protoreflect.Name = "resume_token"
+
+// tokenVersion is the first byte of a token, so the format can change.
+const tokenVersion = 2
+
+// fingerprintSize is the length of the request fingerprint in a token.
+const fingerprintSize = 16
+
+// headerSize is the length of a token before the position: the version,
+// the fingerprint and the epoch.
+const headerSize = 1 + fingerprintSize + 8
+
+// epoch identifies this process in the tokens it issues; tests replace it.
+var epoch = newEpoch()
+
+func newEpoch() uint64 {
+	var b [8]byte
+	// crypto/rand.Read never fails
+	_, _ = rand.Read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// Encode returns the resume token of position in the stream req opened for
+// the caller of ctx.
+func Encode(ctx context.Context, req proto.Message, position uint64) string {
+	buf := make([]byte, 0, headerSize+binary.MaxVarintLen64)
+	buf = append(buf, tokenVersion)
+	buf = append(buf, fingerprint(ctx, req)...)
+	buf = binary.BigEndian.AppendUint64(buf, epoch)
+	buf = binary.AppendUvarint(buf, position)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// Decode returns the position of token in the stream req opens for the
+// caller of ctx, or 0 if token is empty or was issued by an earlier
+// process, so the stream starts over. It fails with InvalidArgument if the
+// token is malformed, or was issued to another caller or for a request with
+// other parameters.
+func Decode(ctx context.Context, req proto.Message, token string) (uint64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) < headerSize+1 || buf[0] != tokenVersion {
+		return 0, invalidToken("malformed resume token")
+	}
+	position, n := binary.Uvarint(buf[headerSize:])
+	if n <= 0 || headerSize+n != len(buf) {
+		return 0, invalidToken("malformed resume token")
+	}
+	if !bytes.Equal(buf[1:1+fingerprintSize], fingerprint(ctx, req)) {
+		return 0, invalidToken("resume token was issued to another caller or for a request with other parameters")
+	}
+	if binary.BigEndian.Uint64(buf[1+fingerprintSize:headerSize]) != epoch {
+		return 0, nil
+	}
+	return position, nil
+}
+
+// fingerprint identifies the caller of ctx and the parameters of req,
+// other than its resume token.
+func fingerprint(ctx context.Context, req proto.Message) []byte {
+	h := sha256.New()
+
+	var caller string
+	if user, ok := middleware.UserFromContext(ctx); ok {
+		caller = string(user.ID)
+	}
+	fmt.Fprintf(h, "%d:%s", len(caller), caller)
+
+	m := proto.Clone(req).ProtoReflect()
+	if fd := m.Descriptor().Fields().ByName(tokenField); fd != nil {
+		m.Clear(fd)
+	}
+	h.Write([]byte(m.Descriptor().FullName()))
+	h.Write([]byte{0})
+	// Marshaling a message with known fields can't fail
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m.Interface())
+	h.Write(data)
+
+	return h.Sum(nil)[:fingerprintSize]
+}
+
+func invalidToken(description string) error {
+	return grpcerrors.NewValidationError("invalid resume_token", map[string]string{
+		"resume_token": description,
+	})
+}
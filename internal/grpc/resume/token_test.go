@@ -0,0 +1,91 @@
+package resume
+
+import (
+	"context"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDecode(t *testing.T) {
+	alice := middleware.WithUser(context.Background(), &domain.User{ID: "alice"})
+	bob := middleware.WithUser(context.Background(), &domain.User{ID: "bob"})
+	req := &services.StreamLogsRequest{Level: "warn", Component: "p2p"}
+	token := Encode(alice, req, 42)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *services.StreamLogsRequest
+		token    string
+		want     uint64
+		wantCode codes.Code
+	}{
+		{"valid", alice, req, token, 42, codes.OK},
+		{"resume token of the request ignored", alice, &services.StreamLogsRequest{Level: "warn", Component: "p2p", ResumeToken: token}, token, 42, codes.OK},
+		{"empty", bob, req, "", 0, codes.OK},
+		{"other caller", bob, req, token, 0, codes.InvalidArgument},
+		{"anonymous caller", context.Background(), req, token, 0, codes.InvalidArgument},
+		{"other level", alice, &services.StreamLogsRequest{Level: "debug", Component: "p2p"}, token, 0, codes.InvalidArgument},
+		{"other component", alice, &services.StreamLogsRequest{Level: "warn"}, token, 0, codes.InvalidArgument},
+		{"not base64", alice, req, "not a token!", 0, codes.InvalidArgument},
+		{"truncated", alice, req, token[:len(token)-2], 0, codes.InvalidArgument},
+		{"trailing data", alice, req, token + "AA", 0, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.ctx, tt.req, tt.token)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Decode() code = %v, want %v (err: %v)", code, tt.wantCode, err)
+			}
+			if got != tt.want {
+				t.Errorf("Decode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_OtherStream(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice"})
+
+	// A token of one stream is not valid for another, even with the same
+	// parameters
+	token := Encode(ctx, &services.StreamJobStatusRequest{Id: "job-1"}, 7)
+	if _, err := Decode(ctx, &services.StreamJobLogsRequest{Id: "job-1"}, token); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Decode() for another stream error = %v, want InvalidArgument", err)
+	}
+	if _, err := Decode(ctx, &services.StreamJobStatusRequest{Id: "job-2"}, token); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Decode() for another job error = %v, want InvalidArgument", err)
+	}
+	if got, err := Decode(ctx, &services.StreamJobStatusRequest{Id: "job-1"}, token); err != nil || got != 7 {
+		t.Errorf("Decode() = %d, %v; want 7", got, err)
+	}
+}
+
+func TestDecode_EarlierProcess(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice"})
+	req := &services.StreamLogsRequest{Level: "warn"}
+	token := Encode(ctx, req, 42)
+
+	// After a restart, positions count from the start again, so the token
+	// resumes from the start of the stream
+	defer func(e uint64) { epoch = e }(epoch)
+	epoch++
+
+	got, err := Decode(ctx, req, token)
+	if err != nil || got != 0 {
+		t.Errorf("Decode() of an earlier process = %d, %v; want 0", got, err)
+	}
+
+	// It is still bound to the caller
+	bob := middleware.WithUser(context.Background(), &domain.User{ID: "bob"})
+	if _, err := Decode(bob, req, token); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Decode() of an earlier process by another caller error = %v, want InvalidArgument", err)
+	}
+}
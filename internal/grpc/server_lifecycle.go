@@ -5,25 +5,40 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
-	"runtime"
+	"runtime/debug"
 	"sync"
 	"time"
 
 	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cluster"
 	"bib/internal/config"
+	"bib/internal/grpc/compression"
+	"bib/internal/grpc/gateway"
 	"bib/internal/grpc/interfaces"
 	"bib/internal/grpc/middleware"
+	"bib/internal/grpc/services/admin"
+	"bib/internal/grpc/services/health"
+	"bib/internal/grpc/services/node"
+	"bib/internal/logger"
+	"bib/internal/maintenance"
+	"bib/internal/p2p"
+	"bib/internal/storage/audit"
+	"bib/internal/storage/backup"
 	"bib/internal/version"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 // Server represents the gRPC server with all its listeners and lifecycle management.
@@ -38,15 +53,34 @@ type Server struct {
 	// Listeners
 	tcpListener  net.Listener
 	pipeListener net.Listener // Unix socket on Unix, named pipe on Windows
+	localServer  *grpc.Server // Serves pipeListener without TLS
+
+	// HTTP/JSON gateway
+	gatewayCfg      config.GatewayConfig
+	gatewayTLS      *tls.Config
+	gatewayServer   *http.Server
+	gatewayGRPC     *grpc.Server // Serves gatewayListener without TLS
+	gatewayListener *bufconn.Listener
+	gatewayConn     *grpc.ClientConn
 
 	// Metrics
 	metricsServer   *http.Server
 	metricsRegistry *prometheus.Registry
+	metricsAuth     *metricsAuth
+	metricsTLS      *tls.Config
 	grpcMetrics     *grpc_prometheus.ServerMetrics
+	requestMetrics  *middleware.RequestMetrics
+	conns           *connTracker
+	panics          *middleware.PanicReporter
 
 	// Interceptor dependencies
 	healthProvider  interfaces.HealthProvider
 	auditMiddleware *middleware.AuditMiddleware
+	auditBlocks     *audit.RateLimiter
+	loadMonitor     *health.LoadMonitor
+	adaptiveLimiter *middleware.AdaptiveLimiter
+	readiness       *middleware.ReadinessGate
+	concurrency     *middleware.ConcurrencyLimiter
 	rbacConfig      middleware.RBACConfig
 	getUserFunc     func(ctx context.Context, token string) (*interface{}, error)
 
@@ -75,12 +109,78 @@ type ServerConfig struct {
 	// AuditMiddleware provides audit logging (optional).
 	AuditMiddleware *middleware.AuditMiddleware
 
+	// AuditRateLimiter holds the users and roles blocked by audit alerts
+	// (optional). The rate limit interceptors reject their requests, even
+	// if rate limiting is disabled.
+	AuditRateLimiter *audit.RateLimiter
+
+	// Maintenance is the pause signal of the background maintenance
+	// workers, controlled through the admin service (optional).
+	Maintenance *maintenance.Gate
+
+	// ModeManager manages the P2P node mode; selective mode subscriptions
+	// are managed through the node service (optional).
+	ModeManager *p2p.ModeManager
+
+	// ModeController switches the P2P mode through the node service
+	// (optional).
+	ModeController node.ModeController
+
+	// Messenger sends direct messages to peers through the node service
+	// (optional).
+	Messenger *p2p.Messenger
+
+	// NodeManager lists this node and its peers through the node service
+	// (optional).
+	NodeManager p2p.NodeManager
+
+	// LabelController changes the node's labels through the node service
+	// (optional).
+	LabelController node.LabelController
+
+	// Cluster exposes the cluster status and locks through the admin
+	// service (optional).
+	Cluster *cluster.Cluster
+
+	// LogBuffer holds the daemon log entries the admin service streams
+	// (optional).
+	LogBuffer *admin.LogRingBuffer
+
+	// BackupMgr takes, lists and deletes the database backups through the
+	// admin service (optional).
+	BackupMgr *backup.Manager
+
 	// RBACConfig holds RBAC settings.
 	RBACConfig middleware.RBACConfig
 
 	// GetUserFromToken extracts user from session token for RBAC.
 	// Required if RBAC is enabled.
 	GetUserFromToken func(ctx context.Context, token string) (*interface{}, error)
+
+	// GatewayConfig configures the HTTP/JSON gateway, started with the
+	// server if enabled.
+	GatewayConfig config.GatewayConfig
+
+	// GatewayTLSConfig is the TLS configuration of the gateway listener.
+	// If nil, the gateway serves plain HTTP.
+	GatewayTLSConfig *tls.Config
+
+	// MetricsTLSConfig is the TLS configuration of the metrics listener,
+	// requiring client certificates for mTLS. If nil, metrics are served
+	// over plain HTTP.
+	MetricsTLSConfig *tls.Config
+
+	// Logger receives the reports of panics recovered from handlers. If
+	// nil, the default logger is used.
+	Logger *logger.Logger
+
+	// RedactFields are the field names left out of the request summaries
+	// of panic reports.
+	RedactFields []string
+
+	// Collectors are additional metrics served on the metrics endpoint,
+	// such as the daemon watchdog's (optional).
+	Collectors []prometheus.Collector
 }
 
 // NewServer creates a new gRPC server with all interceptors configured.
@@ -89,6 +189,25 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		return nil, fmt.Errorf("gRPC server is disabled in configuration")
 	}
 
+	if cfg.GRPCConfig.Compression.Enabled {
+		if err := compression.Validate(cfg.GRPCConfig.Compression.Algorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.GatewayConfig.Enabled {
+		if err := gateway.Validate(cfg.GatewayConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	// Bound zstd decoding by what the server receives, and by the
+	// responses the gateway's client receives
+	compression.SetMaxRecvMsgSize(cfg.GRPCConfig.MaxRecvMsgSize)
+	if cfg.GatewayConfig.Enabled {
+		compression.SetMaxRecvMsgSize(cfg.GRPCConfig.MaxSendMsgSize)
+	}
+
 	s := &Server{
 		cfg:             cfg.GRPCConfig,
 		tlsConfig:       cfg.TLSConfig,
@@ -96,12 +215,31 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 		services:        NewServiceServers(),
 		healthProvider:  cfg.HealthProvider,
 		auditMiddleware: cfg.AuditMiddleware,
+		auditBlocks:     cfg.AuditRateLimiter,
 		rbacConfig:      cfg.RBACConfig,
+		gatewayCfg:      cfg.GatewayConfig,
+		gatewayTLS:      cfg.GatewayTLSConfig,
+		metricsTLS:      cfg.MetricsTLSConfig,
+		conns:           &connTracker{},
 		stopCh:          make(chan struct{}),
 	}
+	s.panics = middleware.NewPanicReporter(middleware.PanicReporterConfig{
+		Logger:          cfg.Logger,
+		RedactFields:    cfg.RedactFields,
+		CrashReportDir:  cfg.GRPCConfig.Recovery.CrashReportDir,
+		MaxCrashReports: cfg.GRPCConfig.Recovery.MaxCrashReports,
+	})
 
 	// Set up Prometheus metrics if enabled
 	if cfg.GRPCConfig.Metrics.Enabled {
+		auth, err := newMetricsAuth(cfg.GRPCConfig.Metrics)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.GRPCConfig.Metrics.Auth.MTLS && cfg.MetricsTLSConfig == nil {
+			return nil, fmt.Errorf("metrics: mTLS enabled without a TLS configuration")
+		}
+		s.metricsAuth = auth
 		s.metricsRegistry = prometheus.NewRegistry()
 		s.grpcMetrics = grpc_prometheus.NewServerMetrics()
 
@@ -111,9 +249,74 @@ func NewServer(cfg ServerConfig) (*Server, error) {
 
 		s.metricsRegistry.MustRegister(s.grpcMetrics)
 
+		// Register per-service request metrics (rate, errors, duration)
+		s.requestMetrics = middleware.NewRequestMetrics(cfg.GRPCConfig.Metrics.EnableLatencyHistograms)
+		s.metricsRegistry.MustRegister(s.requestMetrics)
+
 		// Register standard Go metrics
 		s.metricsRegistry.MustRegister(prometheus.NewGoCollector())
 		s.metricsRegistry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+
+		// Register node gauges (storage, peers, quorum, certificates)
+		s.metricsRegistry.MustRegister(s.services.Health.Collector())
+		s.metricsRegistry.MustRegister(s.conns.Collector())
+		s.metricsRegistry.MustRegister(s.panics)
+		for _, c := range cfg.Collectors {
+			s.metricsRegistry.MustRegister(c)
+		}
+	}
+
+	// Set up load shedding if enabled
+	if adaptive := cfg.GRPCConfig.RateLimit.Adaptive; adaptive.Enabled {
+		s.loadMonitor = health.NewLoadMonitor(loadTargets(adaptive), adaptive.Interval)
+		s.adaptiveLimiter = middleware.NewAdaptiveLimiter(s.loadMonitor, adaptive.MaxInFlight, adaptive.MinFactor, s.loadMonitor.Interval())
+		s.services.Health.SetLoadMonitor(s.loadMonitor)
+	}
+
+	// Hold back calls until the daemon is ready
+	if cfg.GRPCConfig.Readiness.Enabled && cfg.HealthProvider != nil {
+		s.readiness = middleware.NewReadinessGate(s.services.Health, cfg.GRPCConfig.Readiness.MaxWait)
+	}
+
+	// Let admins pause background maintenance
+	if cfg.Maintenance != nil {
+		s.services.Admin.SetMaintenanceGate(cfg.Maintenance)
+	}
+
+	// Let admins capture pprof profiles
+	if cfg.GRPCConfig.Profiling.Enabled {
+		s.services.Admin.SetProfiling(cfg.GRPCConfig.Profiling.MaxDuration)
+	}
+
+	// Let admins manage selective mode subscriptions
+	if cfg.ModeManager != nil {
+		s.services.Node.SetSubscriptionManager(cfg.ModeManager)
+	}
+	if cfg.ModeController != nil {
+		s.services.Node.SetModeController(cfg.ModeController)
+	}
+	if cfg.Messenger != nil {
+		s.services.Node.SetMessageSender(cfg.Messenger)
+	}
+	if cfg.NodeManager != nil {
+		s.services.Node.SetNodeManager(cfg.NodeManager)
+	}
+	if cfg.LabelController != nil {
+		s.services.Node.SetLabelController(cfg.LabelController)
+	}
+	if cfg.Cluster != nil {
+		s.services.Admin.SetClusterManager(cfg.Cluster)
+	}
+	if cfg.LogBuffer != nil {
+		s.services.Admin.SetLogBuffer(cfg.LogBuffer)
+	}
+	if cfg.BackupMgr != nil {
+		s.services.Admin.SetBackupManager(cfg.BackupMgr)
+	}
+
+	// Cap the calls in flight
+	if c := cfg.GRPCConfig.Concurrency; c.MaxInFlight > 0 || c.MaxInFlightPerConnection > 0 {
+		s.concurrency = middleware.NewConcurrencyLimiter(c.MaxInFlight, c.MaxInFlightPerConnection)
 	}
 
 	// Build interceptor chains
@@ -153,10 +356,13 @@ func (s *Server) buildServerOptions(unary []grpc.UnaryServerInterceptor, stream
 		grpc.MaxConcurrentStreams(s.cfg.MaxConcurrentStreams),
 	}
 
-	// Keepalive settings
+	// Keepalive settings. Connections without streams for
+	// MaxConnectionIdle are closed with a GOAWAY, and clients pinging more
+	// often than MinTime allows are disconnected with ENHANCE_YOUR_CALM.
 	opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
-		Time:    s.cfg.Keepalive.Time,
-		Timeout: s.cfg.Keepalive.Timeout,
+		Time:              s.cfg.Keepalive.Time,
+		Timeout:           s.cfg.Keepalive.Timeout,
+		MaxConnectionIdle: s.cfg.Keepalive.MaxConnectionIdle,
 	}))
 
 	opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
@@ -164,6 +370,8 @@ func (s *Server) buildServerOptions(unary []grpc.UnaryServerInterceptor, stream
 		PermitWithoutStream: s.cfg.Keepalive.PermitWithoutStream,
 	}))
 
+	opts = append(opts, s.statsHandlers()...)
+
 	// TLS credentials
 	if s.tlsConfig != nil {
 		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
@@ -176,6 +384,20 @@ func (s *Server) buildServerOptions(unary []grpc.UnaryServerInterceptor, stream
 	return opts
 }
 
+// statsHandlers returns the options installing the connection count and
+// the per-connection call count of the concurrency limiter on the TCP
+// server.
+func (s *Server) statsHandlers() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if s.conns != nil {
+		opts = append(opts, grpc.StatsHandler(s.conns))
+	}
+	if s.concurrency != nil {
+		opts = append(opts, grpc.StatsHandler(s.concurrency))
+	}
+	return opts
+}
+
 // buildUnaryInterceptors creates the chain of unary interceptors.
 func (s *Server) buildUnaryInterceptors() []grpc.UnaryServerInterceptor {
 	var interceptors []grpc.UnaryServerInterceptor
@@ -184,30 +406,91 @@ func (s *Server) buildUnaryInterceptors() []grpc.UnaryServerInterceptor {
 	if s.grpcMetrics != nil {
 		interceptors = append(interceptors, s.grpcMetrics.UnaryServerInterceptor())
 	}
+	if s.requestMetrics != nil {
+		interceptors = append(interceptors, middleware.RequestMetricsUnaryInterceptor(s.requestMetrics))
+	}
 
-	// 2. Recovery (catch panics early)
-	interceptors = append(interceptors, middleware.RecoveryUnaryInterceptor())
-
-	// 3. Request ID
+	// 2. Request ID (before recovery, so panic reports carry it)
 	interceptors = append(interceptors, middleware.RequestIDUnaryInterceptor())
 
+	// 3. Recovery (catch panics early)
+	interceptors = append(interceptors, middleware.RecoveryUnaryInterceptor(s.panics))
+
 	// 4. Logging
 	interceptors = append(interceptors, middleware.LoggingUnaryInterceptor())
 
-	// 5. Rate limiting (per-user, after we know the user)
-	if s.cfg.RateLimit.Enabled {
-		limiter := middleware.NewRateLimiter(s.cfg.RateLimit.RequestsPerSecond, s.cfg.RateLimit.Burst)
+	// 5. Error localization (wraps everything below that can return errors)
+	interceptors = append(interceptors, middleware.LocalizeErrorsUnaryInterceptor())
+
+	// 6. Readiness gate (reject calls until the daemon is ready)
+	if s.readiness != nil {
+		interceptors = append(interceptors, middleware.ReadinessUnaryInterceptor(s.readiness))
+	}
+
+	// 7. Concurrency limit (hard cap on calls in flight)
+	if s.concurrency != nil {
+		interceptors = append(interceptors, middleware.ConcurrencyLimitUnaryInterceptor(s.concurrency))
+	}
+
+	// 8. Load shedding (before any per-call work)
+	if s.adaptiveLimiter != nil {
+		interceptors = append(interceptors, middleware.AdaptiveLimitUnaryInterceptor(s.adaptiveLimiter))
+	}
+
+	// 9. Rate limiting (per-user, after we know the user)
+	if limiter := s.newRateLimiter(); limiter != nil {
 		interceptors = append(interceptors, middleware.RateLimitUnaryInterceptor(limiter, middleware.UserFromContext))
 	}
 
-	// 6. Audit (for mutations)
+	// 10. Audit (for mutations)
 	if s.auditMiddleware != nil {
 		interceptors = append(interceptors, middleware.AuditUnaryInterceptor(s.auditMiddleware))
 	}
 
+	// 11. Compression (innermost, so it sees the final response)
+	if s.cfg.Compression.Enabled {
+		interceptors = append(interceptors, middleware.CompressionUnaryInterceptor(s.cfg.Compression.Algorithm, s.cfg.Compression.MinSize))
+	}
+
 	return interceptors
 }
 
+// newRateLimiter creates the rate limiter of an interceptor chain, or nil if
+// neither rate limiting nor blocking by audit alerts is enabled.
+func (s *Server) newRateLimiter() *middleware.RateLimiter {
+	switch {
+	case s.cfg.RateLimit.Enabled:
+		methods := make(map[string]middleware.Limit, len(s.cfg.RateLimit.Methods))
+		for name, limit := range s.cfg.RateLimit.Methods {
+			methods[name] = middleware.Limit{RequestsPerSecond: limit.RequestsPerSecond, Burst: limit.Burst}
+		}
+		limiter := middleware.NewRateLimiter(s.cfg.RateLimit.RequestsPerSecond, s.cfg.RateLimit.Burst)
+		return limiter.WithMethodLimits(methods).WithAuditBlocks(s.auditBlocks)
+	case s.auditBlocks != nil:
+		// Only enforce the blocks
+		return middleware.NewRateLimiter(float64(rate.Inf), 0).WithAuditBlocks(s.auditBlocks)
+	default:
+		return nil
+	}
+}
+
+// loadTargets converts the adaptive rate limit configuration to the targets
+// of the load monitor. Without a memory target, 90% of the Go memory limit
+// is used if one is set.
+func loadTargets(cfg config.GRPCAdaptiveRateLimitConfig) health.LoadTargets {
+	targets := health.LoadTargets{
+		CPUPercent:  cfg.CPUPercent,
+		MemoryBytes: uint64(cfg.MemoryMB) << 20,
+		InFlight:    int64(cfg.MaxInFlight),
+	}
+	if targets.MemoryBytes == 0 {
+		if limit := debug.SetMemoryLimit(-1); limit > 0 && limit < math.MaxInt64 {
+			targets.MemoryBytes = uint64(limit) / 10 * 9
+		}
+	}
+	return targets
+}
+
 // buildStreamInterceptors creates the chain of stream interceptors.
 func (s *Server) buildStreamInterceptors() []grpc.StreamServerInterceptor {
 	var interceptors []grpc.StreamServerInterceptor
@@ -216,27 +499,52 @@ func (s *Server) buildStreamInterceptors() []grpc.StreamServerInterceptor {
 	if s.grpcMetrics != nil {
 		interceptors = append(interceptors, s.grpcMetrics.StreamServerInterceptor())
 	}
+	if s.requestMetrics != nil {
+		interceptors = append(interceptors, middleware.RequestMetricsStreamInterceptor(s.requestMetrics))
+	}
 
-	// 2. Recovery
-	interceptors = append(interceptors, middleware.RecoveryStreamInterceptor())
-
-	// 3. Request ID
+	// 2. Request ID (before recovery, so panic reports carry it)
 	interceptors = append(interceptors, middleware.RequestIDStreamInterceptor())
 
+	// 3. Recovery
+	interceptors = append(interceptors, middleware.RecoveryStreamInterceptor(s.panics))
+
 	// 4. Logging
 	interceptors = append(interceptors, middleware.LoggingStreamInterceptor())
 
-	// 5. Rate limiting
-	if s.cfg.RateLimit.Enabled {
-		limiter := middleware.NewRateLimiter(s.cfg.RateLimit.RequestsPerSecond, s.cfg.RateLimit.Burst)
+	// 5. Error localization
+	interceptors = append(interceptors, middleware.LocalizeErrorsStreamInterceptor())
+
+	// 6. Readiness gate
+	if s.readiness != nil {
+		interceptors = append(interceptors, middleware.ReadinessStreamInterceptor(s.readiness))
+	}
+
+	// 7. Concurrency limit
+	if s.concurrency != nil {
+		interceptors = append(interceptors, middleware.ConcurrencyLimitStreamInterceptor(s.concurrency))
+	}
+
+	// 8. Load shedding
+	if s.adaptiveLimiter != nil {
+		interceptors = append(interceptors, middleware.AdaptiveLimitStreamInterceptor(s.adaptiveLimiter))
+	}
+
+	// 9. Rate limiting
+	if limiter := s.newRateLimiter(); limiter != nil {
 		interceptors = append(interceptors, middleware.RateLimitStreamInterceptor(limiter, middleware.UserFromContext))
 	}
 
-	// 6. Audit
+	// 10. Audit
 	if s.auditMiddleware != nil {
 		interceptors = append(interceptors, middleware.AuditStreamInterceptor(s.auditMiddleware))
 	}
 
+	// 11. Compression
+	if s.cfg.Compression.Enabled {
+		interceptors = append(interceptors, middleware.CompressionStreamInterceptor(s.cfg.Compression.Algorithm, s.cfg.Compression.MinSize))
+	}
+
 	return interceptors
 }
 
@@ -270,6 +578,11 @@ func (s *Server) Start(ctx context.Context) error {
 	s.running = true
 	s.mu.Unlock()
 
+	// Start sampling the load for load shedding
+	if s.loadMonitor != nil {
+		s.loadMonitor.Start(ctx)
+	}
+
 	// Start TCP listener
 	if err := s.startTCPListener(); err != nil {
 		return fmt.Errorf("failed to start TCP listener: %w", err)
@@ -292,6 +605,18 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}
 
+	// Start HTTP/JSON gateway if enabled
+	if s.gatewayCfg.Enabled {
+		if err := s.startGateway(); err != nil {
+			if s.metricsServer != nil {
+				_ = s.metricsServer.Close()
+			}
+			s.stopPipeListener()
+			s.stopTCPListener()
+			return fmt.Errorf("failed to start gateway: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -323,64 +648,24 @@ func (s *Server) startTCPListener() error {
 
 // startPipeListener starts the Unix socket or named pipe listener.
 func (s *Server) startPipeListener() error {
-	if runtime.GOOS == "windows" {
-		return s.startNamedPipe()
-	}
-	return s.startUnixSocket()
-}
-
-// startUnixSocket starts a Unix socket listener (Unix systems).
-func (s *Server) startUnixSocket() error {
-	// Remove existing socket file if it exists
-	// (Unix sockets leave files behind)
-	_ = removeSocketFile(s.cfg.UnixSocket)
-
-	lis, err := net.Listen("unix", s.cfg.UnixSocket)
+	lis, err := localListener(s.cfg.UnixSocket)
 	if err != nil {
-		return fmt.Errorf("failed to listen on unix socket %s: %w", s.cfg.UnixSocket, err)
+		return fmt.Errorf("failed to listen on %s: %w", localAddress(s.cfg.UnixSocket), err)
 	}
 	s.pipeListener = lis
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		// Create a separate server for Unix socket (no TLS needed for local)
-		unixServer := s.createLocalServer()
-		if err := unixServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
-			fmt.Printf("gRPC Unix socket server error: %v\n", err)
-		}
-	}()
-
-	fmt.Printf("gRPC server listening on unix://%s\n", s.cfg.UnixSocket)
-	return nil
-}
-
-// startNamedPipe starts a named pipe listener (Windows).
-func (s *Server) startNamedPipe() error {
-	// On Windows, use named pipes via npipe or winio
-	// The socket path is treated as a pipe name
-	pipeName := s.cfg.UnixSocket
-	if pipeName == "" {
-		pipeName = `\\.\pipe\bibd-grpc`
-	}
-
-	lis, err := listenNamedPipe(pipeName)
-	if err != nil {
-		return fmt.Errorf("failed to listen on named pipe %s: %w", pipeName, err)
-	}
-	s.pipeListener = lis
+	// Local connections get a separate server (no TLS needed for local)
+	s.localServer = s.createLocalServer()
 
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
-		// Create a separate server for named pipe (no TLS needed for local)
-		pipeServer := s.createLocalServer()
-		if err := pipeServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
-			fmt.Printf("gRPC named pipe server error: %v\n", err)
+		if err := s.localServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			fmt.Printf("gRPC local server error: %v\n", err)
 		}
 	}()
 
-	fmt.Printf("gRPC server listening on pipe://%s\n", pipeName)
+	fmt.Printf("gRPC server listening on %s\n", localAddress(s.cfg.UnixSocket))
 	return nil
 }
 
@@ -397,6 +682,12 @@ func (s *Server) createLocalServer() *grpc.Server {
 		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 		grpc.ChainStreamInterceptor(streamInterceptors...),
 	}
+	// Without the concurrency limiter's stats handler, calls are capped only
+	// on the node: the gateway multiplexes all of its clients over a single
+	// connection to this server.
+	if s.conns != nil {
+		opts = append(opts, grpc.StatsHandler(s.conns))
+	}
 
 	localServer := grpc.NewServer(opts...)
 
@@ -424,9 +715,19 @@ func (s *Server) startMetricsServer() error {
 	addr := fmt.Sprintf("%s:%d", s.cfg.Metrics.HTTPHost, s.cfg.Metrics.HTTPPort)
 
 	mux := http.NewServeMux()
-	mux.Handle(s.cfg.Metrics.Path, promhttp.HandlerFor(s.metricsRegistry, promhttp.HandlerOpts{
+	mux.Handle(s.cfg.Metrics.Path, s.metricsAuth.wrap(promhttp.HandlerFor(s.metricsRegistry, promhttp.HandlerOpts{
 		EnableOpenMetrics: true,
-	}))
+	})))
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	scheme := "http"
+	if s.metricsTLS != nil {
+		lis = tls.NewListener(lis, s.metricsTLS)
+		scheme = "https"
+	}
 
 	s.metricsServer = &http.Server{
 		Addr:         addr,
@@ -438,15 +739,105 @@ func (s *Server) startMetricsServer() error {
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
-		if err := s.metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := s.metricsServer.Serve(lis); err != nil && err != http.ErrServerClosed {
 			fmt.Printf("Metrics server error: %v\n", err)
 		}
 	}()
 
-	fmt.Printf("Prometheus metrics available at http://%s%s\n", addr, s.cfg.Metrics.Path)
+	fmt.Printf("Prometheus metrics available at %s://%s%s\n", scheme, addr, s.cfg.Metrics.Path)
 	return nil
 }
 
+// gatewayBufferSize is the buffer size of the in-process connection between
+// the gateway and its gRPC server.
+const gatewayBufferSize = 1 << 20
+
+// startGateway starts the HTTP/JSON gateway. The gateway calls a gRPC server
+// of its own over an in-process connection, with the same interceptors and
+// services as the other listeners.
+func (s *Server) startGateway() error {
+	addr := fmt.Sprintf("%s:%d", s.gatewayCfg.Host, s.gatewayCfg.Port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s.gatewayListener = bufconn.Listen(gatewayBufferSize)
+	s.gatewayGRPC = s.createLocalServer()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.gatewayGRPC.Serve(s.gatewayListener); err != nil && err != grpc.ErrServerStopped {
+			fmt.Printf("gRPC gateway server error: %v\n", err)
+		}
+	}()
+
+	bufLis := s.gatewayListener
+	conn, err := grpc.NewClient("passthrough:///gateway",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return bufLis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(s.cfg.MaxSendMsgSize),
+			grpc.MaxCallSendMsgSize(s.cfg.MaxRecvMsgSize),
+		),
+	)
+	if err != nil {
+		_ = lis.Close()
+		s.stopGateway()
+		return fmt.Errorf("failed to connect gateway: %w", err)
+	}
+	s.gatewayConn = conn
+
+	gw, err := gateway.New(conn, s.gatewayCfg)
+	if err != nil {
+		_ = lis.Close()
+		s.stopGateway()
+		return err
+	}
+
+	scheme := "http"
+	if s.gatewayTLS != nil {
+		lis = tls.NewListener(lis, s.gatewayTLS)
+		scheme = "https"
+	}
+	s.gatewayServer = &http.Server{
+		Handler:           gw,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.gatewayServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("Gateway server error: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("HTTP/JSON gateway listening on %s://%s\n", scheme, addr)
+	return nil
+}
+
+// stopGateway releases the in-process connection of the gateway. The HTTP
+// server must already be shut down.
+func (s *Server) stopGateway() {
+	if s.gatewayConn != nil {
+		_ = s.gatewayConn.Close()
+		s.gatewayConn = nil
+	}
+	if s.gatewayGRPC != nil {
+		s.gatewayGRPC.Stop()
+		s.gatewayGRPC = nil
+	}
+	if s.gatewayListener != nil {
+		_ = s.gatewayListener.Close()
+		s.gatewayListener = nil
+	}
+	s.gatewayServer = nil
+}
+
 // Stop gracefully stops the gRPC server with connection draining.
 func (s *Server) Stop(ctx context.Context) error {
 	s.mu.Lock()
@@ -491,10 +882,22 @@ func (s *Server) Stop(ctx context.Context) error {
 		}
 	}
 
+	// Stop gateway, draining its requests before the server behind it
+	if s.gatewayServer != nil {
+		if err := s.gatewayServer.Shutdown(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("gateway shutdown: %w", err))
+		}
+	}
+	s.stopGateway()
+
 	// Clean up listeners
 	s.stopPipeListener()
 	s.stopTCPListener()
 
+	if s.loadMonitor != nil {
+		s.loadMonitor.Stop()
+	}
+
 	// Wait for all goroutines
 	s.wg.Wait()
 
@@ -513,13 +916,17 @@ func (s *Server) stopTCPListener() {
 }
 
 func (s *Server) stopPipeListener() {
+	if s.localServer != nil {
+		s.localServer.Stop()
+		s.localServer = nil
+	}
 	if s.pipeListener != nil {
 		_ = s.pipeListener.Close()
 		s.pipeListener = nil
 	}
 	// Clean up Unix socket file
-	if s.cfg.UnixSocket != "" && runtime.GOOS != "windows" {
-		_ = removeSocketFile(s.cfg.UnixSocket)
+	if s.cfg.UnixSocket != "" {
+		_ = removeLocalListener(s.cfg.UnixSocket)
 	}
 }
 
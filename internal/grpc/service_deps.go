@@ -7,6 +7,7 @@ import (
 	internalauth "bib/internal/auth"
 	"bib/internal/cluster"
 	"bib/internal/config"
+	"bib/internal/domain"
 	"bib/internal/grpc/interfaces"
 	"bib/internal/grpc/middleware"
 	"bib/internal/grpc/services/admin"
@@ -18,8 +19,10 @@ import (
 	"bib/internal/grpc/services/query"
 	"bib/internal/grpc/services/topic"
 	"bib/internal/grpc/services/user"
+	"bib/internal/maintenance"
 	"bib/internal/p2p"
 	"bib/internal/storage"
+	"bib/internal/storage/audit"
 	"bib/internal/storage/backup"
 	"bib/internal/storage/blob"
 	breakglassmgr "bib/internal/storage/breakglass"
@@ -39,6 +42,10 @@ type ServiceDependencies struct {
 	// P2P networking
 	NodeManager p2p.NodeManager
 	P2PHost     *p2p.Host
+	PubSub      *p2p.PubSub
+	ModeManager *p2p.ModeManager
+	Messenger   *p2p.Messenger
+	Labels      node.LabelController
 
 	// Cluster
 	ClusterMgr *cluster.Cluster
@@ -49,6 +56,13 @@ type ServiceDependencies struct {
 	// Break glass emergency access
 	BreakGlassMgr *breakglassmgr.Manager
 
+	// TopicNotifier delivers topic invitation and access request
+	// notifications (optional)
+	TopicNotifier topic.Notifier
+
+	// DatasetRetention decides which old dataset versions are removed
+	DatasetRetention domain.VersionRetention
+
 	// Configuration
 	Config     interface{} // Current config for admin service
 	ConfigPath string
@@ -63,8 +77,17 @@ type ServiceDependencies struct {
 	StartedAt    time.Time
 	ShutdownFunc func()
 
+	// Maintenance is the pause signal of the background maintenance workers
+	Maintenance *maintenance.Gate
+
 	// Audit
 	AuditMiddleware *middleware.AuditMiddleware
+
+	// AuditSensitiveFields are redacted from audit entries served to clients
+	AuditSensitiveFields []string
+
+	// AuditRateLimiter holds the users and roles blocked by audit alerts
+	AuditRateLimiter *audit.RateLimiter
 }
 
 // ConfigureServices configures all service servers with the provided dependencies.
@@ -98,14 +121,33 @@ func (ss *ServiceServers) ConfigureServices(deps ServiceDependencies) {
 			EventBufferSize: 100,
 		})
 	}
+	if deps.ModeManager != nil {
+		ss.Node.SetSubscriptionManager(deps.ModeManager)
+	}
+	if deps.Messenger != nil {
+		ss.Node.SetMessageSender(deps.Messenger)
+	}
+	if deps.Labels != nil {
+		ss.Node.SetLabelController(deps.Labels)
+	}
 
 	// Configure TopicService
 	if deps.Store != nil {
-		ss.Topic = topic.NewServerWithConfig(topic.Config{
+		topicCfg := topic.Config{
 			Store:       deps.Store,
 			AuditLogger: deps.AuditMiddleware,
 			NodeMode:    deps.NodeMode,
-		})
+			Notifier:    deps.TopicNotifier,
+		}
+		if deps.ClusterMgr != nil {
+			topicCfg.Replicator = deps.ClusterMgr
+		}
+		ss.Topic = topic.NewServerWithConfig(topicCfg)
+	}
+
+	// Export blob store utilization
+	if deps.BlobStore != nil {
+		ss.Health.SetBlobStore(deps.BlobStore)
 	}
 
 	// Configure DatasetService
@@ -115,21 +157,27 @@ func (ss *ServiceServers) ConfigureServices(deps ServiceDependencies) {
 			BlobStore:   deps.BlobStore,
 			AuditLogger: deps.AuditMiddleware,
 			NodeMode:    deps.NodeMode,
+			Retention:   deps.DatasetRetention,
 		})
 	}
 
 	// Configure AdminService
 	ss.Admin = admin.NewServerWithConfig(admin.Config{
-		Store:        deps.Store,
-		ClusterMgr:   deps.ClusterMgr,
-		BackupMgr:    deps.BackupMgr,
-		AuditLogger:  deps.AuditMiddleware,
-		NodeID:       deps.NodeID,
-		ConfigPath:   deps.ConfigPath,
-		DataDir:      deps.DataDir,
-		StartedAt:    deps.StartedAt,
-		ShutdownFunc: deps.ShutdownFunc,
-		Config:       deps.Config,
+		Store:            deps.Store,
+		ClusterMgr:       deps.ClusterMgr,
+		BackupMgr:        deps.BackupMgr,
+		AuditLogger:      deps.AuditMiddleware,
+		NodeID:           deps.NodeID,
+		ConfigPath:       deps.ConfigPath,
+		DataDir:          deps.DataDir,
+		StartedAt:        deps.StartedAt,
+		ShutdownFunc:     deps.ShutdownFunc,
+		Config:           deps.Config,
+		SensitiveFields:  deps.AuditSensitiveFields,
+		AuditRateLimiter: deps.AuditRateLimiter,
+		P2PHost:          deps.P2PHost,
+		PubSub:           deps.PubSub,
+		Maintenance:      deps.Maintenance,
 	})
 
 	// Configure QueryService
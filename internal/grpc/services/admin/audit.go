@@ -0,0 +1,380 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/storage"
+	"bib/internal/storage/audit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// auditPageSize is how many entries StreamAuditLogs reads at a time
+const auditPageSize = 500
+
+// StreamAuditLogs streams the audit entries matching req, newest first.
+func (s *Server) StreamAuditLogs(req *services.GetAuditLogsRequest, stream grpc.ServerStreamingServer[services.AuditLogEntry]) error {
+	ctx := stream.Context()
+	if err := requireAdmin(ctx, "read", "audit log"); err != nil {
+		return err
+	}
+	if s.store == nil {
+		return status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	filter := storage.AuditFilter{
+		Actor:     req.GetUserId(),
+		Action:    req.GetAction(),
+		TableName: req.GetResourceType(),
+		Offset:    int(req.GetPage().GetOffset()),
+		Limit:     auditPageSize,
+	}
+	if req.GetStartTime() != nil {
+		after := req.GetStartTime().AsTime()
+		filter.After = &after
+	}
+	if req.GetEndTime() != nil {
+		before := req.GetEndTime().AsTime()
+		filter.Before = &before
+	}
+	remaining := int(req.GetPage().GetLimit()) // 0 = all
+
+	for {
+		entries, err := s.store.Audit().Query(ctx, filter)
+		if err != nil {
+			return grpcerrors.MapDomainError(err)
+		}
+
+		for _, e := range entries {
+			entry := s.auditEntryToProto(e)
+			// The resource ID lives in the metadata, so it can't be queried
+			if req.GetResourceId() != "" && entry.GetResourceId() != req.GetResourceId() {
+				continue
+			}
+			if err := stream.Send(entry); err != nil {
+				return err
+			}
+			if remaining > 0 {
+				remaining--
+				if remaining == 0 {
+					return nil
+				}
+			}
+		}
+
+		if len(entries) < filter.Limit {
+			return nil
+		}
+		filter.Offset += len(entries)
+	}
+}
+
+// auditEntryToProto converts a stored audit entry, redacting sensitive
+// fields. Well-known metadata keys fill their fields; the rest become details.
+func (s *Server) auditEntryToProto(e *storage.AuditEntry) *services.AuditLogEntry {
+	entry := &services.AuditLogEntry{
+		Id:           strconv.FormatInt(e.ID, 10),
+		Timestamp:    timestamppb.New(e.Timestamp),
+		UserId:       e.Actor,
+		Action:       e.Action,
+		ResourceType: e.TableName,
+		Result:       "success",
+		NodeId:       e.NodeID,
+		Details: map[string]string{
+			"operation_id":     e.OperationID,
+			"source_component": e.SourceComponent,
+			"role_used":        e.RoleUsed,
+		},
+	}
+	if e.Query != "" {
+		entry.Details["query"], _ = s.redactor.RedactQuery(e.Query, nil)
+	}
+
+	for k, v := range s.redactor.RedactMetadata(e.Metadata) {
+		value := fmt.Sprint(v)
+		switch k {
+		case "resource_id":
+			entry.ResourceId = value
+		case "resource_name":
+			entry.ResourceName = value
+		case "client_ip":
+			entry.ClientIp = value
+		case "request_id":
+			entry.RequestId = value
+		case "error":
+			entry.Result = "failure"
+			entry.Error = value
+		default:
+			entry.Details[k] = value
+		}
+	}
+
+	return entry
+}
+
+// Limits of QueryAudit pages
+const (
+	defaultAuditQueryLimit = 50
+	maxAuditQueryLimit     = 1000
+
+	// maxAuditScan bounds how many stored entries one QueryAudit call reads
+	// when filtering in memory. A page may come back short; its cursor
+	// continues the scan.
+	maxAuditScan = 10000
+)
+
+// QueryAudit returns a page of the audit entries matching req, newest first.
+//
+// Actor, action, resource type and time range are filtered by storage.
+// Outcome, resource ID and the CEL expression are applied to the entries
+// read, so the page cursor is the storage offset to continue reading from.
+func (s *Server) QueryAudit(ctx context.Context, req *services.QueryAuditRequest) (*services.QueryAuditResponse, error) {
+	if err := requireAdmin(ctx, "query", "audit log"); err != nil {
+		return nil, err
+	}
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	switch req.GetOutcome() {
+	case "", "success", "failure":
+	default:
+		return nil, grpcerrors.NewValidationError("invalid outcome", map[string]string{
+			"outcome": "must be success or failure",
+		})
+	}
+
+	var celFilter *audit.CELFilter
+	if req.GetExpression() != "" {
+		var err error
+		celFilter, err = audit.NewCELFilter(req.GetExpression())
+		if err != nil {
+			return nil, grpcerrors.NewValidationError("invalid expression", map[string]string{
+				"expression": err.Error(),
+			})
+		}
+	}
+
+	limit := int(req.GetPage().GetLimit())
+	if limit <= 0 {
+		limit = defaultAuditQueryLimit
+	}
+	if limit > maxAuditQueryLimit {
+		limit = maxAuditQueryLimit
+	}
+
+	offset := int(req.GetPage().GetOffset())
+	if cursor := req.GetPage().GetCursor(); cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 0 {
+			return nil, grpcerrors.NewValidationError("invalid cursor", map[string]string{
+				"page.cursor": "must be the next_cursor of a previous response",
+			})
+		}
+		offset = n
+	}
+
+	filter := storage.AuditFilter{
+		Actor:     req.GetActor(),
+		Action:    req.GetAction(),
+		TableName: req.GetResourceType(),
+		Offset:    offset,
+	}
+	if req.GetStartTime() != nil {
+		after := req.GetStartTime().AsTime()
+		filter.After = &after
+	}
+	if req.GetEndTime() != nil {
+		before := req.GetEndTime().AsTime()
+		filter.Before = &before
+	}
+
+	inMemory := req.GetOutcome() != "" || req.GetResourceId() != "" || celFilter != nil
+	if !inMemory {
+		// Storage can page by itself
+		filter.Limit = limit
+		entries, err := s.store.Audit().Query(ctx, filter)
+		if err != nil {
+			return nil, grpcerrors.MapDomainError(err)
+		}
+		total, _ := s.store.Audit().Count(ctx, filter)
+
+		resp := &services.QueryAuditResponse{
+			PageInfo: &bibv1.PageInfo{
+				TotalCount: total,
+				HasMore:    int64(offset+len(entries)) < total,
+				PageSize:   int32(len(entries)),
+			},
+		}
+		for _, e := range entries {
+			resp.Entries = append(resp.Entries, s.auditEntryToProto(e))
+		}
+		if resp.PageInfo.HasMore {
+			resp.PageInfo.NextCursor = strconv.Itoa(offset + len(entries))
+		}
+		return resp, nil
+	}
+
+	resp := &services.QueryAuditResponse{PageInfo: &bibv1.PageInfo{}}
+	scanned := 0
+	filter.Limit = auditPageSize
+	for len(resp.Entries) < limit && scanned < maxAuditScan {
+		entries, err := s.store.Audit().Query(ctx, filter)
+		if err != nil {
+			return nil, grpcerrors.MapDomainError(err)
+		}
+
+		for i, e := range entries {
+			match, err := s.matchAuditEntry(e, req, celFilter)
+			if err != nil {
+				return nil, grpcerrors.NewValidationError("invalid expression", map[string]string{
+					"expression": err.Error(),
+				})
+			}
+			if !match {
+				continue
+			}
+			resp.Entries = append(resp.Entries, s.auditEntryToProto(e))
+			if len(resp.Entries) == limit {
+				// Continue right after the last entry returned
+				resp.PageInfo.HasMore = true
+				resp.PageInfo.NextCursor = strconv.Itoa(filter.Offset + i + 1)
+				break
+			}
+		}
+		if resp.PageInfo.HasMore {
+			break
+		}
+
+		scanned += len(entries)
+		filter.Offset += len(entries)
+		if len(entries) < filter.Limit {
+			// End of the audit trail
+			break
+		}
+		if scanned >= maxAuditScan {
+			resp.PageInfo.HasMore = true
+			resp.PageInfo.NextCursor = strconv.Itoa(filter.Offset)
+		}
+	}
+	resp.PageInfo.PageSize = int32(len(resp.Entries))
+
+	return resp, nil
+}
+
+// matchAuditEntry applies the filters of req that storage cannot.
+func (s *Server) matchAuditEntry(e *storage.AuditEntry, req *services.QueryAuditRequest, celFilter *audit.CELFilter) (bool, error) {
+	_, failed := e.Metadata["error"]
+	switch req.GetOutcome() {
+	case "success":
+		if failed {
+			return false, nil
+		}
+	case "failure":
+		if !failed {
+			return false, nil
+		}
+	}
+	if id := req.GetResourceId(); id != "" && fmt.Sprint(e.Metadata["resource_id"]) != id {
+		return false, nil
+	}
+	if celFilter == nil {
+		return true, nil
+	}
+	return celFilter.Match(s.toCELEntry(e))
+}
+
+// toCELEntry converts a stored audit entry for CEL evaluation. Expressions
+// only see redacted values, so they cannot probe for secrets.
+func (s *Server) toCELEntry(e *storage.AuditEntry) *audit.Entry {
+	query, _ := s.redactor.RedactQuery(e.Query, nil)
+	return &audit.Entry{
+		ID:              e.ID,
+		Timestamp:       e.Timestamp,
+		NodeID:          e.NodeID,
+		JobID:           e.JobID,
+		OperationID:     e.OperationID,
+		RoleUsed:        e.RoleUsed,
+		Action:          audit.Action(e.Action),
+		TableName:       e.TableName,
+		Query:           query,
+		QueryHash:       e.QueryHash,
+		RowsAffected:    e.RowsAffected,
+		DurationMS:      e.DurationMS,
+		SourceComponent: e.SourceComponent,
+		Actor:           e.Actor,
+		Metadata:        s.redactor.RedactMetadata(e.Metadata),
+		Flags: audit.EntryFlags{
+			BreakGlass:     e.Flags.BreakGlass,
+			RateLimited:    e.Flags.RateLimited,
+			Suspicious:     e.Flags.Suspicious,
+			AlertTriggered: e.Flags.AlertTriggered,
+		},
+	}
+}
+
+// ListRateLimitBlocks lists the users and roles blocked by audit alerts.
+func (s *Server) ListRateLimitBlocks(_ context.Context, _ *services.ListRateLimitBlocksRequest) (*services.ListRateLimitBlocksResponse, error) {
+	if s.auditBlocks == nil {
+		return nil, status.Error(codes.Unavailable, "audit rate limiting not available")
+	}
+
+	resp := &services.ListRateLimitBlocksResponse{}
+	for _, block := range s.auditBlocks.Blocks() {
+		resp.Blocks = append(resp.Blocks, rateLimitBlockToProto(block))
+	}
+	return resp, nil
+}
+
+// UnblockRateLimit lifts a block before it expires.
+func (s *Server) UnblockRateLimit(_ context.Context, req *services.UnblockRateLimitRequest) (*services.UnblockRateLimitResponse, error) {
+	if s.auditBlocks == nil {
+		return nil, status.Error(codes.Unavailable, "audit rate limiting not available")
+	}
+	if req.GetKey() == "" {
+		return nil, grpcerrors.NewValidationError("key is required", map[string]string{
+			"key": "must be a key listed by ListRateLimitBlocks",
+		})
+	}
+
+	block, ok := s.auditBlocks.ActiveBlock(req.GetKey())
+	if !ok || !s.auditBlocks.Unblock(req.GetKey()) {
+		return nil, grpcerrors.NewResourceNotFoundError("rate limit block", req.GetKey())
+	}
+
+	return &services.UnblockRateLimitResponse{Block: rateLimitBlockToProto(block)}, nil
+}
+
+// rateLimitBlockToProto converts a block, splitting its key into kind and
+// subject.
+func rateLimitBlockToProto(block audit.Block) *services.RateLimitBlock {
+	kind, subject, _ := strings.Cut(block.Key, ":")
+	return &services.RateLimitBlock{
+		Key:       block.Key,
+		Kind:      kind,
+		Subject:   subject,
+		Rule:      block.Rule,
+		Reason:    block.Reason,
+		BlockedAt: timestamppb.New(block.BlockedAt),
+		ExpiresAt: timestamppb.New(block.ExpiresAt),
+	}
+}
+
+// newRedactor creates the redactor for audit entries served to clients.
+// Without sensitive fields the audit logger's defaults apply.
+func newRedactor(sensitiveFields []string) *audit.Redactor {
+	cfg := audit.DefaultRedactorConfig()
+	if len(sensitiveFields) > 0 {
+		cfg.SensitiveFields = sensitiveFields
+	}
+	return audit.NewRedactor(cfg)
+}
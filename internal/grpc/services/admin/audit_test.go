@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQueryAudit_RequiresAdmin(t *testing.T) {
+	s := NewServer()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"anonymous", context.Background(), codes.Unauthenticated},
+		{"user", middleware.WithUser(context.Background(), &domain.User{ID: "u1", Role: domain.UserRoleUser}), codes.PermissionDenied},
+		{"readonly", middleware.WithUser(context.Background(), &domain.User{ID: "u2", Role: domain.UserRoleReadonly}), codes.PermissionDenied},
+		// Admins get past the check, to the missing store.
+		{"admin", middleware.WithUser(context.Background(), &domain.User{ID: "a1", Role: domain.UserRoleAdmin}), codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.QueryAudit(tt.ctx, &services.QueryAuditRequest{})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("QueryAudit() code = %v, want %v (err: %v)", got, tt.want, err)
+			}
+
+			err = s.StreamAuditLogs(&services.GetAuditLogsRequest{}, &auditStream{ctx: tt.ctx})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("StreamAuditLogs() code = %v, want %v (err: %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+// auditStream is a StreamAuditLogs stream that records the entries sent.
+type auditStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	entries []*services.AuditLogEntry
+}
+
+func (s *auditStream) Context() context.Context { return s.ctx }
+
+func (s *auditStream) Send(entry *services.AuditLogEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
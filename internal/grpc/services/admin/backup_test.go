@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	"bib/internal/grpc/middleware"
+	"bib/internal/storage"
+	"bib/internal/storage/backup"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestBackupManager returns a backup manager of a SQLite database in a
+// temporary directory.
+func newTestBackupManager(t *testing.T) *backup.Manager {
+	t.Helper()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "cache.db")
+	if err := os.WriteFile(dbPath, []byte("SQLite format 3\x00"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := backup.DefaultBackupConfig()
+	cfg.LocalPath = filepath.Join(dir, "backups")
+	mgr, err := backup.NewManager(cfg, storage.BackendSQLite, dir, "node-1")
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	mgr.SetDatabasePath(dbPath)
+	return mgr
+}
+
+func TestDeleteBackup(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	mgr := newTestBackupManager(t)
+	meta, err := mgr.Backup(ctx, "")
+	if err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	s := NewServer()
+	s.SetBackupManager(mgr)
+
+	// Deleting requires the token of a dry run
+	_, err = s.DeleteBackup(ctx, &services.DeleteBackupRequest{BackupId: meta.ID})
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Fatalf("DeleteBackup() without a token code = %v, want %v", got, codes.FailedPrecondition)
+	}
+
+	preview, err := s.DeleteBackup(ctx, &services.DeleteBackupRequest{BackupId: meta.ID, DryRun: true})
+	if err != nil {
+		t.Fatalf("DeleteBackup() dry run error = %v", err)
+	}
+	report := preview.GetDryRun()
+	if len(report.GetResources()) != 1 || report.GetResources()[0].GetId() != meta.ID || report.GetTotalBytes() != meta.Size {
+		t.Errorf("dry run report = %v, want backup %s of %d bytes", report, meta.ID, meta.Size)
+	}
+	if _, err := os.Stat(meta.Path); err != nil {
+		t.Fatalf("dry run removed the backup: %v", err)
+	}
+
+	resp, err := s.DeleteBackup(ctx, &services.DeleteBackupRequest{BackupId: meta.ID, ConfirmationToken: report.GetConfirmationToken()})
+	if err != nil || !resp.GetSuccess() {
+		t.Fatalf("DeleteBackup() = %v, %v; want success", resp, err)
+	}
+	if _, err := os.Stat(meta.Path); !os.IsNotExist(err) {
+		t.Errorf("backup file still exists: %v", err)
+	}
+
+	_, err = s.DeleteBackup(ctx, &services.DeleteBackupRequest{BackupId: meta.ID, DryRun: true})
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("DeleteBackup() of a deleted backup code = %v, want %v", got, codes.NotFound)
+	}
+}
+
+func TestDeleteBackup_NoManager(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	_, err := NewServer().DeleteBackup(ctx, &services.DeleteBackupRequest{BackupId: "b1", DryRun: true})
+	if got := status.Code(err); got != codes.Unavailable {
+		t.Errorf("DeleteBackup() without a backup manager code = %v, want %v", got, codes.Unavailable)
+	}
+}
+
+func TestUpgrade_PreUpgradeBackup(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	req := &services.UpgradeRequest{Version: "v99.0.0"}
+
+	// Without a backup manager, the backup can't be taken
+	_, err := NewServer().Upgrade(ctx, req)
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Errorf("Upgrade() without a backup manager code = %v, want %v", got, codes.FailedPrecondition)
+	}
+	resp, err := NewServer().Upgrade(ctx, &services.UpgradeRequest{Version: "v99.0.0", SkipBackup: true})
+	if err != nil || !resp.GetAccepted() || resp.GetBackupId() != "" {
+		t.Errorf("Upgrade() skipping the backup = %v, %v; want accepted without a backup", resp, err)
+	}
+
+	mgr := newTestBackupManager(t)
+	s := NewServer()
+	s.SetBackupManager(mgr)
+	resp, err = s.Upgrade(ctx, req)
+	if err != nil || !resp.GetAccepted() {
+		t.Fatalf("Upgrade() = %v, %v; want accepted", resp, err)
+	}
+	backups, err := mgr.List(ctx)
+	if err != nil || len(backups) != 1 || backups[0].ID != resp.GetBackupId() {
+		t.Errorf("Upgrade() backup_id = %q, backups = %v, %v; want the one backup", resp.GetBackupId(), backups, err)
+	}
+}
@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cluster"
+	grpcerrors "bib/internal/grpc/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TriggerSnapshot takes a Raft snapshot. Snapshots beyond
+// snapshot.retain_count are pruned; a dry run reports them without taking
+// a snapshot.
+func (s *Server) TriggerSnapshot(ctx context.Context, req *services.TriggerSnapshotRequest) (*services.TriggerSnapshotResponse, error) {
+	if s.clusterMgr == nil {
+		return nil, status.Error(codes.FailedPrecondition, "clustering is not enabled")
+	}
+
+	pruned, err := s.clusterMgr.SnapshotsToPrune()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list snapshots: %v", err)
+	}
+
+	resp := &services.TriggerSnapshotResponse{Pruned: make([]*services.SnapshotInfo, len(pruned))}
+	for i := range pruned {
+		resp.Pruned[i] = snapshotToProto(&pruned[i])
+	}
+
+	if req.GetDryRun() {
+		report := &services.DryRunReport{}
+		for _, snap := range pruned {
+			report.Resources = append(report.Resources, &services.AffectedResource{
+				Kind:      "snapshot",
+				Id:        snap.ID,
+				SizeBytes: snap.Size,
+				Detail:    fmt.Sprintf("index %d, term %d", snap.Index, snap.Term),
+			})
+			report.TotalBytes += snap.Size
+		}
+		report.Summary = fmt.Sprintf("would take a snapshot and delete %d old snapshot(s), %d bytes", len(pruned), report.TotalBytes)
+		resp.DryRun = report
+		return resp, nil
+	}
+
+	if err := s.clusterMgr.TakeSnapshot(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to take snapshot: %v", err)
+	}
+
+	latest, err := s.clusterMgr.LatestSnapshot()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read snapshot: %v", err)
+	}
+	resp.Snapshot = snapshotToProto(latest)
+
+	if s.auditLogger != nil && latest != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "CREATE", "raft_snapshot", latest.ID, map[string]interface{}{
+			"index":  latest.Index,
+			"pruned": len(pruned),
+		})
+	}
+
+	return resp, nil
+}
+
+// RemoveClusterMember removes a member from the cluster. A dry run runs the
+// same checks, reports the member that would be removed and issues the
+// confirmation token the removal requires.
+func (s *Server) RemoveClusterMember(ctx context.Context, req *services.RemoveClusterMemberRequest) (*services.RemoveClusterMemberResponse, error) {
+	if s.clusterMgr == nil {
+		return nil, status.Error(codes.FailedPrecondition, "clustering is not enabled")
+	}
+	if req.GetNodeId() == "" {
+		return nil, grpcerrors.NewValidationError("node_id is required", map[string]string{
+			"node_id": "must not be empty",
+		})
+	}
+
+	removal, err := s.clusterMgr.CheckRemoveNode(req.GetNodeId())
+	if err != nil {
+		return nil, s.memberError(req.GetNodeId(), err)
+	}
+
+	member := removal.Member
+	resp := &services.RemoveClusterMemberResponse{
+		Member: &services.ClusterMember{
+			Id:          member.NodeID,
+			Address:     member.Address,
+			Role:        string(member.Role),
+			IsLeader:    member.NodeID == s.clusterMgr.Leader(),
+			LastContact: timestamppb.New(member.LastContact),
+		},
+		RemainingVoters: int32(removal.RemainingVoters),
+	}
+
+	if req.GetDryRun() {
+		resp.DryRun = &services.DryRunReport{
+			Resources: []*services.AffectedResource{{
+				Kind:   "cluster_member",
+				Id:     member.NodeID,
+				Detail: fmt.Sprintf("%s at %s", member.Role, member.Address),
+			}},
+			Summary: fmt.Sprintf("would remove %s %s, leaving %d voter(s)", member.Role, member.NodeID, removal.RemainingVoters),
+		}
+		if err := s.confirm(ctx, resp.DryRun, "remove_cluster_member", member.NodeID); err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		return resp, nil
+	}
+
+	if err := s.confirmations.consume(ctx, "remove_cluster_member", member.NodeID, req.GetConfirmationToken()); err != nil {
+		return nil, err
+	}
+
+	if err := s.clusterMgr.RemoveNode(member.NodeID); err != nil {
+		return nil, s.memberError(member.NodeID, err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "cluster_member", member.NodeID, map[string]interface{}{
+			"address":          member.Address,
+			"role":             string(member.Role),
+			"remaining_voters": removal.RemainingVoters,
+		})
+	}
+
+	return resp, nil
+}
+
+// memberError maps a cluster membership error to a gRPC status.
+func (s *Server) memberError(nodeID string, err error) error {
+	switch {
+	case errors.Is(err, cluster.ErrNodeNotFound):
+		return status.Errorf(codes.NotFound, "cluster member %s not found", nodeID)
+	case errors.Is(err, cluster.ErrMinimumNodes):
+		return status.Errorf(codes.FailedPrecondition, "cannot remove voter %s: %v", nodeID, err)
+	case errors.Is(err, cluster.ErrNotLeader):
+		return status.Errorf(codes.FailedPrecondition, "%v; members are removed by the leader %s", err, s.clusterMgr.Leader())
+	case errors.Is(err, cluster.ErrFenced):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "failed to remove cluster member: %v", err)
+	}
+}
+
+func snapshotToProto(snap *cluster.SnapshotMeta) *services.SnapshotInfo {
+	if snap == nil {
+		return nil
+	}
+	return &services.SnapshotInfo{
+		Id:        snap.ID,
+		Term:      snap.Term,
+		Index:     snap.Index,
+		CreatedAt: timestamppb.New(snap.CreatedAt),
+		Size:      snap.Size,
+	}
+}
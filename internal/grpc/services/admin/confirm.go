@@ -0,0 +1,131 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// confirmationTTL is how long a confirmation token from a dry run is valid.
+const confirmationTTL = 5 * time.Minute
+
+// confirmation is an outstanding confirmation token.
+type confirmation struct {
+	operation string
+	target    string
+	caller    string
+	expiresAt time.Time
+}
+
+// confirmations issues the tokens irreversible operations require. A dry
+// run issues a token bound to the operation, its target and the caller; the
+// operation only executes with it, so a caller can't destroy anything in
+// one shot without having been shown the impact.
+type confirmations struct {
+	mu     sync.Mutex
+	tokens map[string]confirmation
+	now    func() time.Time
+}
+
+func newConfirmations() *confirmations {
+	return &confirmations{
+		tokens: make(map[string]confirmation),
+		now:    time.Now,
+	}
+}
+
+// issue returns a token confirming operation on target for the caller of ctx.
+func (c *confirmations) issue(ctx context.Context, operation, target string) (string, time.Time, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for t, conf := range c.tokens {
+		if now.After(conf.expiresAt) {
+			delete(c.tokens, t)
+		}
+	}
+
+	expiresAt := now.Add(confirmationTTL)
+	c.tokens[token] = confirmation{
+		operation: operation,
+		target:    target,
+		caller:    callerOf(ctx),
+		expiresAt: expiresAt,
+	}
+	return token, expiresAt, nil
+}
+
+// consume checks token confirms operation on target for the caller of ctx
+// and invalidates it. Tokens are single-use even if the operation fails, so
+// a retry shows the caller the impact again.
+func (c *confirmations) consume(ctx context.Context, operation, target, token string) error {
+	if token == "" {
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonConfirmationRequired,
+			fmt.Sprintf("%s of %s is irreversible and requires the confirmation_token of a dry run", operation, target), nil)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		conf  confirmation
+		found bool
+	)
+	for t, candidate := range c.tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			conf, found = candidate, true
+			delete(c.tokens, t)
+			break
+		}
+	}
+
+	switch {
+	case !found:
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonConfirmationRequired,
+			"unknown or already used confirmation token", nil)
+	case c.now().After(conf.expiresAt):
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonConfirmationRequired,
+			"confirmation token expired", nil)
+	case conf.operation != operation || conf.target != target || conf.caller != callerOf(ctx):
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonConfirmationRequired,
+			fmt.Sprintf("confirmation token was not issued for %s of %s", operation, target), nil)
+	}
+	return nil
+}
+
+// confirm adds a confirmation token for operation on target to a dry run
+// report.
+func (s *Server) confirm(ctx context.Context, report *services.DryRunReport, operation, target string) error {
+	token, expiresAt, err := s.confirmations.issue(ctx, operation, target)
+	if err != nil {
+		return err
+	}
+	report.ConfirmationToken = token
+	report.ConfirmationExpiresAt = timestamppb.New(expiresAt)
+	return nil
+}
+
+// callerOf identifies the caller a confirmation token is bound to.
+func callerOf(ctx context.Context) string {
+	if user, ok := middleware.UserFromContext(ctx); ok {
+		return string(user.ID)
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConfirmations(t *testing.T) {
+	alice := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	bob := middleware.WithUser(context.Background(), &domain.User{ID: "bob", Role: domain.UserRoleAdmin})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		operation string
+		target    string
+		token     func(issued string) string
+		after     time.Duration
+		wantErr   bool
+	}{
+		{"valid", alice, "delete_backup", "b1", nil, 0, false},
+		{"valid just before expiry", alice, "delete_backup", "b1", nil, confirmationTTL, false},
+		{"no token", alice, "delete_backup", "b1", func(string) string { return "" }, 0, true},
+		{"unknown token", alice, "delete_backup", "b1", func(string) string { return "0123456789abcdef" }, 0, true},
+		{"expired", alice, "delete_backup", "b1", nil, confirmationTTL + time.Second, true},
+		{"other operation", alice, "remove_cluster_member", "b1", nil, 0, true},
+		{"other target", alice, "delete_backup", "b2", nil, 0, true},
+		{"other caller", bob, "delete_backup", "b1", nil, 0, true},
+		{"anonymous caller", context.Background(), "delete_backup", "b1", nil, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			c := newConfirmations()
+			c.now = func() time.Time { return now }
+
+			issued, expiresAt, err := c.issue(alice, "delete_backup", "b1")
+			if err != nil {
+				t.Fatalf("issue() error = %v", err)
+			}
+			if want := now.Add(confirmationTTL); !expiresAt.Equal(want) {
+				t.Errorf("issue() expires at %v, want %v", expiresAt, want)
+			}
+
+			token := issued
+			if tt.token != nil {
+				token = tt.token(issued)
+			}
+			now = now.Add(tt.after)
+
+			err = c.consume(tt.ctx, tt.operation, tt.target, token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("consume() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if got := status.Code(err); got != codes.FailedPrecondition {
+					t.Errorf("consume() code = %v, want %v", got, codes.FailedPrecondition)
+				}
+				if got := grpcerrors.SubreasonOf(err); got != grpcerrors.SubreasonConfirmationRequired {
+					t.Errorf("consume() subreason = %q, want %q", got, grpcerrors.SubreasonConfirmationRequired)
+				}
+			}
+		})
+	}
+}
+
+func TestConfirmations_SingleUse(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	c := newConfirmations()
+
+	token, _, err := c.issue(ctx, "remove_cluster_member", "node-2")
+	if err != nil {
+		t.Fatalf("issue() error = %v", err)
+	}
+
+	// A mismatched use still spends the token
+	if err := c.consume(ctx, "remove_cluster_member", "node-3", token); err == nil {
+		t.Fatal("consume() for another target succeeded")
+	}
+	if err := c.consume(ctx, "remove_cluster_member", "node-2", token); err == nil {
+		t.Error("consume() of a spent token succeeded")
+	}
+
+	token, _, err = c.issue(ctx, "remove_cluster_member", "node-2")
+	if err != nil {
+		t.Fatalf("issue() error = %v", err)
+	}
+	if err := c.consume(ctx, "remove_cluster_member", "node-2", token); err != nil {
+		t.Fatalf("consume() error = %v", err)
+	}
+	if err := c.consume(ctx, "remove_cluster_member", "node-2", token); err == nil {
+		t.Error("second consume() of a token succeeded")
+	}
+}
+
+func TestConfirmations_ExpiredTokensDropped(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	now := time.Now()
+	c := newConfirmations()
+	c.now = func() time.Time { return now }
+
+	for range 3 {
+		if _, _, err := c.issue(ctx, "delete_backup", "b1"); err != nil {
+			t.Fatalf("issue() error = %v", err)
+		}
+	}
+
+	// Issuing after the others expired drops them
+	now = now.Add(confirmationTTL + time.Second)
+	if _, _, err := c.issue(ctx, "delete_backup", "b1"); err != nil {
+		t.Fatalf("issue() error = %v", err)
+	}
+	if got := len(c.tokens); got != 1 {
+		t.Errorf("outstanding tokens = %d, want 1", got)
+	}
+}
+
+func TestConfirm_DryRunReport(t *testing.T) {
+	ctx := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	s := NewServer()
+
+	report := &services.DryRunReport{}
+	if err := s.confirm(ctx, report, "delete_backup", "b1"); err != nil {
+		t.Fatalf("confirm() error = %v", err)
+	}
+	if report.GetConfirmationToken() == "" || report.GetConfirmationExpiresAt() == nil {
+		t.Fatalf("report = %v, want a confirmation token and its expiry", report)
+	}
+	if err := s.confirmations.consume(ctx, "delete_backup", "b1", report.GetConfirmationToken()); err != nil {
+		t.Errorf("consume() of the reported token error = %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cluster"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultLockTTL is the lease of a lock when the request sets none.
+const defaultLockTTL = 30 * time.Second
+
+// SetClusterManager sets the cluster whose status and locks the service
+// exposes.
+func (s *Server) SetClusterManager(c *cluster.Cluster) {
+	s.clusterMgr = c
+}
+
+// AcquireLock acquires a lease on a cluster-wide lock.
+func (s *Server) AcquireLock(ctx context.Context, req *services.AcquireLockRequest) (*services.AcquireLockResponse, error) {
+	if s.clusterMgr == nil {
+		return nil, status.Error(codes.FailedPrecondition, "clustering is not enabled")
+	}
+	if req.GetName() == "" {
+		return nil, grpcerrors.NewValidationError("name is required", map[string]string{
+			"name": "must not be empty",
+		})
+	}
+
+	holder := req.GetHolder()
+	if holder == "" {
+		if user, ok := middleware.UserFromContext(ctx); ok {
+			holder = user.Name
+		}
+	}
+
+	lock, err := s.clusterMgr.AcquireLock(req.GetName(), holder, lockTTL(req.GetTtl().AsDuration()))
+	if err != nil {
+		return nil, s.lockError(req.GetName(), err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "CREATE", "cluster_lock", lock.Name, map[string]interface{}{
+			"holder":     lock.Holder,
+			"expires_at": lock.ExpiresAt,
+		})
+	}
+
+	return &services.AcquireLockResponse{Lock: lockToProto(lock)}, nil
+}
+
+// RenewLock extends a lease.
+func (s *Server) RenewLock(ctx context.Context, req *services.RenewLockRequest) (*services.RenewLockResponse, error) {
+	if s.clusterMgr == nil {
+		return nil, status.Error(codes.FailedPrecondition, "clustering is not enabled")
+	}
+	if err := validateLockRequest(req.GetName(), req.GetToken()); err != nil {
+		return nil, err
+	}
+
+	lock, err := s.clusterMgr.RenewLock(req.GetName(), req.GetToken(), lockTTL(req.GetTtl().AsDuration()))
+	if err != nil {
+		return nil, s.lockError(req.GetName(), err)
+	}
+
+	return &services.RenewLockResponse{Lock: lockToProto(lock)}, nil
+}
+
+// ReleaseLock releases a lease.
+func (s *Server) ReleaseLock(ctx context.Context, req *services.ReleaseLockRequest) (*services.ReleaseLockResponse, error) {
+	if s.clusterMgr == nil {
+		return nil, status.Error(codes.FailedPrecondition, "clustering is not enabled")
+	}
+	if err := validateLockRequest(req.GetName(), req.GetToken()); err != nil {
+		return nil, err
+	}
+
+	if err := s.clusterMgr.ReleaseLock(req.GetName(), req.GetToken()); err != nil {
+		return nil, s.lockError(req.GetName(), err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "cluster_lock", req.GetName(), nil)
+	}
+
+	return &services.ReleaseLockResponse{}, nil
+}
+
+// ListLocks lists the cluster-wide locks currently held.
+func (s *Server) ListLocks(_ context.Context, _ *services.ListLocksRequest) (*services.ListLocksResponse, error) {
+	if s.clusterMgr == nil {
+		return nil, status.Error(codes.FailedPrecondition, "clustering is not enabled")
+	}
+
+	locks := s.clusterMgr.Locks()
+	resp := &services.ListLocksResponse{Locks: make([]*services.ClusterLock, len(locks))}
+	for i := range locks {
+		resp.Locks[i] = lockToProto(&locks[i])
+	}
+	return resp, nil
+}
+
+// lockError maps a cluster lock error to a gRPC status.
+func (s *Server) lockError(name string, err error) error {
+	switch {
+	case errors.Is(err, cluster.ErrLockHeld):
+		holder := ""
+		if lock := s.clusterMgr.GetLock(name); lock != nil {
+			holder = lock.Holder
+		}
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonLockHeld, err.Error(), map[string]string{
+			"holder": holder,
+		})
+	case errors.Is(err, cluster.ErrLockNotHeld):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, cluster.ErrNotLeader):
+		return status.Errorf(codes.FailedPrecondition, "%v; locks are granted by the leader %s", err, s.clusterMgr.Leader())
+	case errors.Is(err, cluster.ErrFenced):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "lock operation failed: %v", err)
+	}
+}
+
+// validateLockRequest checks a renew or release request names the lock and
+// the lease token.
+func validateLockRequest(name, token string) error {
+	violations := make(map[string]string)
+	if name == "" {
+		violations["name"] = "must not be empty"
+	}
+	if token == "" {
+		violations["token"] = "must not be empty"
+	}
+	if len(violations) > 0 {
+		return grpcerrors.NewValidationError("invalid lock request", violations)
+	}
+	return nil
+}
+
+func lockTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return defaultLockTTL
+	}
+	return ttl
+}
+
+func lockToProto(l *cluster.Lock) *services.ClusterLock {
+	if l == nil {
+		return nil
+	}
+	return &services.ClusterLock{
+		Name:       l.Name,
+		Holder:     l.Holder,
+		Token:      l.Token,
+		AcquiredAt: timestamppb.New(l.AcquiredAt),
+		ExpiresAt:  timestamppb.New(l.ExpiresAt),
+	}
+}
@@ -0,0 +1,196 @@
+package admin
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"regexp"
+	"strings"
+
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/resume"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultLogHistory is the number of buffered entries include_history
+// sends if history_count is not set.
+const defaultLogHistory = 100
+
+// logLevels orders the log levels StreamLogs filters by.
+var logLevels = map[string]int{"debug": 0, "info": 1, "warn": 2, "error": 3}
+
+// SetLogBuffer sets the buffer of daemon log entries StreamLogs serves.
+func (s *Server) SetLogBuffer(b *LogRingBuffer) {
+	s.logBuffer = b
+}
+
+// Handler returns a slog handler adding every record it handles to the
+// buffer. Attributes become the fields of the entry, keyed by their name
+// prefixed with the names of their groups.
+func (b *LogRingBuffer) Handler() slog.Handler {
+	return &logBufferHandler{buffer: b}
+}
+
+// logBufferHandler adds log records to a LogRingBuffer.
+type logBufferHandler struct {
+	buffer *LogRingBuffer
+	fields map[string]string // attributes added with WithAttrs
+	prefix string            // groups opened with WithGroup
+}
+
+// Enabled implements slog.Handler. The logger the handler is added to
+// decides the level.
+func (h *logBufferHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle implements slog.Handler.
+func (h *logBufferHandler) Handle(_ context.Context, r slog.Record) error {
+	fields := make(map[string]string, len(h.fields)+r.NumAttrs())
+	maps.Copy(fields, h.fields)
+	r.Attrs(func(a slog.Attr) bool {
+		addLogField(fields, h.prefix, a)
+		return true
+	})
+
+	h.buffer.Add(LogEntry{
+		Timestamp: r.Time,
+		Level:     strings.ToLower(r.Level.String()),
+		Message:   r.Message,
+		Fields:    fields,
+	})
+	return nil
+}
+
+// WithAttrs implements slog.Handler.
+func (h *logBufferHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := maps.Clone(h.fields)
+	if fields == nil {
+		fields = make(map[string]string, len(attrs))
+	}
+	for _, a := range attrs {
+		addLogField(fields, h.prefix, a)
+	}
+	return &logBufferHandler{buffer: h.buffer, fields: fields, prefix: h.prefix}
+}
+
+// WithGroup implements slog.Handler.
+func (h *logBufferHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &logBufferHandler{buffer: h.buffer, fields: h.fields, prefix: h.prefix + name + "."}
+}
+
+// addLogField adds a to fields, flattening groups.
+func addLogField(fields map[string]string, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			addLogField(fields, prefix, ga)
+		}
+		return
+	}
+	fields[prefix+a.Key] = a.Value.String()
+}
+
+// StreamLogs streams the daemon logs of the log buffer: first the buffered
+// entries after the resume token, or the recent ones if include_history is
+// set, then new entries as they are logged. Every entry carries a resume
+// token bound to the caller and the filters of the request.
+func (s *Server) StreamLogs(req *services.StreamLogsRequest, stream grpc.ServerStreamingServer[services.LogEntry]) error {
+	ctx := stream.Context()
+	if err := requireAdmin(ctx, "read", "logs"); err != nil {
+		return err
+	}
+
+	after, err := resume.Decode(ctx, req, req.GetResumeToken())
+	if err != nil {
+		return err
+	}
+
+	minLevel := 0
+	if req.GetLevel() != "" {
+		level, ok := logLevels[strings.ToLower(req.GetLevel())]
+		if !ok {
+			return grpcerrors.NewValidationError("invalid level", map[string]string{
+				"level": "must be debug, info, warn or error",
+			})
+		}
+		minLevel = level
+	}
+	var pattern *regexp.Regexp
+	if req.GetPattern() != "" {
+		if pattern, err = regexp.Compile(req.GetPattern()); err != nil {
+			return grpcerrors.NewValidationError("invalid pattern", map[string]string{
+				"pattern": err.Error(),
+			})
+		}
+	}
+
+	// Subscribe before reading the history, so no entry falls in between
+	live, unsubscribe := s.logBuffer.Subscribe()
+	defer unsubscribe()
+
+	var history []LogEntry
+	switch {
+	case req.GetResumeToken() != "":
+		history = s.logBuffer.Since(after)
+	case req.GetIncludeHistory():
+		n := int(req.GetHistoryCount())
+		if n <= 0 {
+			n = defaultLogHistory
+		}
+		history = s.logBuffer.Recent(n)
+	}
+
+	send := func(e LogEntry) error {
+		if e.Seq <= after {
+			return nil // sent from the history already
+		}
+		after = e.Seq
+
+		if logLevels[strings.ToLower(e.Level)] < minLevel ||
+			(req.GetComponent() != "" && e.Fields["component"] != req.GetComponent()) ||
+			(pattern != nil && !pattern.MatchString(e.Message)) {
+			return nil
+		}
+		return stream.Send(&services.LogEntry{
+			Timestamp:   timestamppb.New(e.Timestamp),
+			Level:       e.Level,
+			Component:   e.Fields["component"],
+			Message:     e.Message,
+			Fields:      e.Fields,
+			RequestId:   e.Fields["request_id"],
+			ResumeToken: resume.Encode(ctx, req, e.Seq),
+		})
+	}
+
+	for _, e := range history {
+		if err := send(e); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-live:
+			if !ok {
+				return nil
+			}
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
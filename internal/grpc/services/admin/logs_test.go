@@ -0,0 +1,202 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// logStream is a StreamLogs stream that records the first want entries
+// sent, then ends the call.
+type logStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	cancel  context.CancelFunc
+	want    int
+	entries []*services.LogEntry
+}
+
+func newLogStream(ctx context.Context, want int) *logStream {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	return &logStream{ctx: ctx, cancel: cancel, want: want}
+}
+
+func (s *logStream) Context() context.Context { return s.ctx }
+
+func (s *logStream) Send(entry *services.LogEntry) error {
+	if len(s.entries) >= s.want {
+		return nil // sent after the client went away
+	}
+	s.entries = append(s.entries, entry)
+	if len(s.entries) >= s.want {
+		s.cancel()
+	}
+	return nil
+}
+
+func (s *logStream) messages() []string {
+	msgs := make([]string, len(s.entries))
+	for i, e := range s.entries {
+		msgs[i] = e.GetMessage()
+	}
+	return msgs
+}
+
+func TestStreamLogs_Resume(t *testing.T) {
+	s := NewServer()
+	for i := 1; i <= 5; i++ {
+		level := "info"
+		if i%2 == 0 {
+			level = "warn"
+		}
+		s.logBuffer.Add(LogEntry{Timestamp: time.Now(), Level: level, Message: fmt.Sprint("entry ", i)})
+	}
+
+	alice := middleware.WithUser(context.Background(), &domain.User{ID: "alice", Role: domain.UserRoleAdmin})
+	bob := middleware.WithUser(context.Background(), &domain.User{ID: "bob", Role: domain.UserRoleAdmin})
+	req := &services.StreamLogsRequest{IncludeHistory: true}
+
+	first := newLogStream(alice, 2)
+	if err := s.StreamLogs(req, first); err != nil {
+		t.Fatalf("StreamLogs() error = %v", err)
+	}
+	if got := first.messages(); len(got) != 2 || got[0] != "entry 1" {
+		t.Fatalf("StreamLogs() = %v, want entry 1 and 2", got)
+	}
+	token := first.entries[1].GetResumeToken()
+
+	// Resuming continues after the entry of the token, with new entries
+	// following the buffered ones
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		s.logBuffer.Add(LogEntry{Timestamp: time.Now(), Level: "info", Message: "entry 6"})
+	}()
+	resumed := newLogStream(alice, 4)
+	if err := s.StreamLogs(&services.StreamLogsRequest{IncludeHistory: true, ResumeToken: token}, resumed); err != nil {
+		t.Fatalf("StreamLogs() resumed error = %v", err)
+	}
+	want := []string{"entry 3", "entry 4", "entry 5", "entry 6"}
+	if got := resumed.messages(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("StreamLogs() resumed = %v, want %v", got, want)
+	}
+
+	// The token is bound to the caller and the filters it was issued for
+	tests := []struct {
+		name string
+		ctx  context.Context
+		req  *services.StreamLogsRequest
+	}{
+		{"other caller", bob, &services.StreamLogsRequest{IncludeHistory: true, ResumeToken: token}},
+		{"other filters", alice, &services.StreamLogsRequest{Level: "warn", IncludeHistory: true, ResumeToken: token}},
+		{"malformed", alice, &services.StreamLogsRequest{IncludeHistory: true, ResumeToken: "garbage"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := newLogStream(tt.ctx, 1)
+			err := s.StreamLogs(tt.req, stream)
+			if got := status.Code(err); got != codes.InvalidArgument {
+				t.Errorf("StreamLogs() code = %v, want %v (err: %v)", got, codes.InvalidArgument, err)
+			}
+			if len(stream.entries) > 0 {
+				t.Errorf("StreamLogs() sent %d entries with an invalid token", len(stream.entries))
+			}
+		})
+	}
+}
+
+func TestStreamLogs_Filters(t *testing.T) {
+	s := NewServer()
+	s.logBuffer.Add(LogEntry{Level: "debug", Message: "dial peer", Fields: map[string]string{"component": "p2p"}})
+	s.logBuffer.Add(LogEntry{Level: "warn", Message: "peer unreachable", Fields: map[string]string{"component": "p2p"}})
+	s.logBuffer.Add(LogEntry{Level: "error", Message: "checkpoint failed", Fields: map[string]string{"component": "storage"}})
+	s.logBuffer.Add(LogEntry{Level: "error", Message: "peer banned", Fields: map[string]string{"component": "p2p"}})
+
+	admin := middleware.WithUser(context.Background(), &domain.User{ID: "admin", Role: domain.UserRoleAdmin})
+	stream := newLogStream(admin, 2)
+	err := s.StreamLogs(&services.StreamLogsRequest{Level: "WARN", Component: "p2p", Pattern: "^peer", IncludeHistory: true}, stream)
+	if err != nil {
+		t.Fatalf("StreamLogs() error = %v", err)
+	}
+	want := []string{"peer unreachable", "peer banned"}
+	if got := stream.messages(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("StreamLogs() = %v, want %v", got, want)
+	}
+
+	for _, req := range []*services.StreamLogsRequest{{Level: "verbose"}, {Pattern: "("}} {
+		if err := s.StreamLogs(req, newLogStream(admin, 1)); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("StreamLogs(%v) error = %v, want InvalidArgument", req, err)
+		}
+	}
+
+	user := middleware.WithUser(context.Background(), &domain.User{ID: "u1", Role: domain.UserRoleUser})
+	if err := s.StreamLogs(&services.StreamLogsRequest{}, newLogStream(user, 1)); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("StreamLogs() by a user error = %v, want PermissionDenied", err)
+	}
+}
+
+func TestLogRingBuffer_Since(t *testing.T) {
+	b := NewLogRingBuffer(3)
+	if got := b.Since(0); got != nil {
+		t.Errorf("Since(0) of an empty buffer = %v, want none", got)
+	}
+	for i := 1; i <= 5; i++ {
+		b.Add(LogEntry{Message: fmt.Sprint(i)})
+	}
+
+	tests := []struct {
+		seq  uint64
+		want []uint64
+	}{
+		{0, []uint64{3, 4, 5}}, // 1 and 2 were dropped
+		{3, []uint64{4, 5}},
+		{5, nil},
+		{9, nil},
+	}
+	for _, tt := range tests {
+		var got []uint64
+		for _, e := range b.Since(tt.seq) {
+			got = append(got, e.Seq)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Since(%d) = %v, want %v", tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestLogRingBuffer_Handler(t *testing.T) {
+	b := NewLogRingBuffer(10)
+	log := slog.New(b.Handler()).With("component", "p2p")
+	log.Warn("peer unreachable", "peer", "p1", slog.Group("dial", "attempt", 3))
+	log.WithGroup("req").Info("done", "id", "r1")
+
+	entries := b.Recent(10)
+	if len(entries) != 2 {
+		t.Fatalf("buffered %d entries, want 2", len(entries))
+	}
+	want := []struct {
+		level, message string
+		fields         map[string]string
+	}{
+		{"warn", "peer unreachable", map[string]string{"component": "p2p", "peer": "p1", "dial.attempt": "3"}},
+		{"info", "done", map[string]string{"component": "p2p", "req.id": "r1"}},
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Level != w.level || e.Message != w.message || fmt.Sprint(e.Fields) != fmt.Sprint(w.fields) {
+			t.Errorf("entry %d = %s %q %v, want %s %q %v", i, e.Level, e.Message, e.Fields, w.level, w.message, w.fields)
+		}
+		if e.Timestamp.IsZero() || e.Seq != uint64(i+1) {
+			t.Errorf("entry %d timestamp = %v, seq = %d", i, e.Timestamp, e.Seq)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/grpc/middleware"
+	"bib/internal/maintenance"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetMaintenanceGate sets the pause signal of the background maintenance
+// workers controlled by PauseMaintenance and ResumeMaintenance.
+func (s *Server) SetMaintenanceGate(gate *maintenance.Gate) {
+	s.maintenance = gate
+}
+
+// PauseMaintenance pauses the background maintenance workers. Workers hold
+// at their next safe point and continue from there on resume.
+func (s *Server) PauseMaintenance(ctx context.Context, req *services.PauseMaintenanceRequest) (*services.PauseMaintenanceResponse, error) {
+	if s.maintenance == nil {
+		return nil, status.Error(codes.Unavailable, "maintenance control not available")
+	}
+
+	var by string
+	if user, ok := middleware.UserFromContext(ctx); ok {
+		by = user.Name
+	}
+
+	changed := s.maintenance.Pause(req.GetReason(), by)
+	if changed && s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DDL", "maintenance", "pause", map[string]interface{}{
+			"reason": req.GetReason(),
+		})
+	}
+
+	return &services.PauseMaintenanceResponse{
+		State:   maintenanceStateToProto(s.maintenance.State()),
+		Changed: changed,
+	}, nil
+}
+
+// ResumeMaintenance resumes the paused maintenance workers.
+func (s *Server) ResumeMaintenance(ctx context.Context, _ *services.ResumeMaintenanceRequest) (*services.ResumeMaintenanceResponse, error) {
+	if s.maintenance == nil {
+		return nil, status.Error(codes.Unavailable, "maintenance control not available")
+	}
+
+	paused := s.maintenance.State()
+	changed := s.maintenance.Resume()
+	if changed && s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DDL", "maintenance", "resume", map[string]interface{}{
+			"paused_by":    paused.PausedBy,
+			"paused_since": paused.PausedAt,
+			"reason":       paused.Reason,
+		})
+	}
+
+	return &services.ResumeMaintenanceResponse{
+		State:   maintenanceStateToProto(s.maintenance.State()),
+		Changed: changed,
+	}, nil
+}
+
+// maintenanceStateToProto converts the state of the pause signal.
+func maintenanceStateToProto(state maintenance.State) *services.MaintenanceState {
+	pb := &services.MaintenanceState{
+		Paused:   state.Paused,
+		Reason:   state.Reason,
+		PausedBy: state.PausedBy,
+		Workers:  state.Workers,
+		Waiting:  state.Waiting,
+	}
+	if !state.PausedAt.IsZero() {
+		pb.PausedAt = timestamppb.New(state.PausedAt)
+	}
+	return pb
+}
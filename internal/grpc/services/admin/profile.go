@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultProfileDuration is how long CPU, block and mutex profiles are
+// sampled if the request doesn't say.
+const defaultProfileDuration = 30 * time.Second
+
+// profiler captures pprof profiles, one sampled profile at a time: the
+// runtime supports a single CPU profile, and block and mutex sampling rates
+// are process-wide.
+type profiler struct {
+	maxDuration time.Duration
+	mu          sync.Mutex
+}
+
+// SetProfiling allows admins to capture profiles sampled for up to
+// maxDuration. Profiling is disabled until it is called.
+func (s *Server) SetProfiling(maxDuration time.Duration) {
+	s.profiler = &profiler{maxDuration: maxDuration}
+}
+
+// CaptureProfile captures a pprof profile of the daemon. Heap, allocation
+// and goroutine profiles are snapshots; CPU, block and mutex profiles are
+// sampled for the requested duration.
+func (s *Server) CaptureProfile(ctx context.Context, req *services.CaptureProfileRequest) (*services.CaptureProfileResponse, error) {
+	if err := requireAdmin(ctx, "capture", "profile"); err != nil {
+		return nil, err
+	}
+	if s.profiler == nil {
+		return nil, status.Error(codes.FailedPrecondition, "profiling is disabled; set server.grpc.profiling.enabled")
+	}
+
+	duration := defaultProfileDuration
+	if req.GetDuration() != nil {
+		duration = req.GetDuration().AsDuration()
+		if duration <= 0 {
+			return nil, grpcerrors.NewValidationError("invalid duration", map[string]string{
+				"duration": "must be positive",
+			})
+		}
+	}
+	if max := s.profiler.maxDuration; max > 0 && duration > max {
+		return nil, grpcerrors.NewValidationError("invalid duration", map[string]string{
+			"duration": "must not exceed " + max.String(),
+		})
+	}
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	resp := &services.CaptureProfileResponse{Type: req.GetType(), NodeId: s.nodeID}
+	switch req.GetType() {
+	case services.ProfileType_PROFILE_TYPE_HEAP:
+		runtime.GC() // up-to-date statistics
+		err = pprof.Lookup("heap").WriteTo(&buf, 0)
+	case services.ProfileType_PROFILE_TYPE_ALLOCS:
+		err = pprof.Lookup("allocs").WriteTo(&buf, 0)
+	case services.ProfileType_PROFILE_TYPE_GOROUTINE:
+		err = pprof.Lookup("goroutine").WriteTo(&buf, 0)
+	case services.ProfileType_PROFILE_TYPE_CPU, services.ProfileType_PROFILE_TYPE_BLOCK, services.ProfileType_PROFILE_TYPE_MUTEX:
+		if !s.profiler.mu.TryLock() {
+			return nil, status.Error(codes.Aborted, "another profile is being captured")
+		}
+		defer s.profiler.mu.Unlock()
+
+		err = sampleProfile(ctx, req.GetType(), duration, &buf)
+		resp.Duration = durationpb.New(duration)
+	default:
+		return nil, grpcerrors.NewValidationError("invalid profile type", map[string]string{
+			"type": "must be cpu, heap, allocs, goroutine, block or mutex",
+		})
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Errorf(codes.Internal, "failed to capture profile: %v", err)
+	}
+
+	resp.Data = buf.Bytes()
+	resp.CapturedAt = timestamppb.Now()
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "EXECUTE", "system", "profile", map[string]interface{}{
+			"type":     req.GetType().String(),
+			"duration": resp.GetDuration().AsDuration().String(),
+		})
+	}
+
+	return resp, nil
+}
+
+// sampleProfile samples a CPU, block or mutex profile for duration, or
+// until ctx is done, and writes it to buf.
+func sampleProfile(ctx context.Context, typ services.ProfileType, duration time.Duration, buf *bytes.Buffer) error {
+	var name string
+	switch typ {
+	case services.ProfileType_PROFILE_TYPE_CPU:
+		if err := pprof.StartCPUProfile(buf); err != nil {
+			return err
+		}
+	case services.ProfileType_PROFILE_TYPE_BLOCK:
+		name = "block"
+		runtime.SetBlockProfileRate(1)
+		defer runtime.SetBlockProfileRate(0)
+	case services.ProfileType_PROFILE_TYPE_MUTEX:
+		name = "mutex"
+		prev := runtime.SetMutexProfileFraction(1)
+		defer runtime.SetMutexProfileFraction(prev)
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	if name == "" {
+		pprof.StopCPUProfile()
+		return ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return pprof.Lookup(name).WriteTo(buf, 0)
+}
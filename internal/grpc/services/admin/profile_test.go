@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCaptureProfile_RequiresAdmin(t *testing.T) {
+	s := NewServer()
+	s.SetProfiling(time.Minute)
+	req := &services.CaptureProfileRequest{Type: services.ProfileType_PROFILE_TYPE_GOROUTINE}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"anonymous", context.Background(), codes.Unauthenticated},
+		{"user", middleware.WithUser(context.Background(), &domain.User{ID: "u1", Role: domain.UserRoleUser}), codes.PermissionDenied},
+		{"readonly", middleware.WithUser(context.Background(), &domain.User{ID: "u2", Role: domain.UserRoleReadonly}), codes.PermissionDenied},
+		{"admin", middleware.WithUser(context.Background(), &domain.User{ID: "a1", Role: domain.UserRoleAdmin}), codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.CaptureProfile(tt.ctx, req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("CaptureProfile() code = %v, want %v (err: %v)", got, tt.want, err)
+			}
+			if tt.want == codes.OK && len(resp.GetData()) == 0 {
+				t.Error("CaptureProfile() returned an empty profile")
+			}
+		})
+	}
+}
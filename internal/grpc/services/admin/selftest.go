@@ -0,0 +1,183 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// defaultSelfTestTimeout bounds each self-test step.
+const defaultSelfTestTimeout = 10 * time.Second
+
+// errSkipped marks a step whose dependency is not available on this node.
+var errSkipped = errors.New("skipped")
+
+// selfTestStep runs one step and reports its outcome. The returned string is
+// the success message; wrap errSkipped to report the step as skipped.
+type selfTestStep struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// SelfTest actively exercises the data path: a storage round trip, a pubsub
+// loopback and a peer ping. Unlike the health checks it writes and publishes,
+// so it is meant to be run after setup or an upgrade rather than polled.
+func (s *Server) SelfTest(ctx context.Context, req *services.SelfTestRequest) (*services.SelfTestResponse, error) {
+	timeout := defaultSelfTestTimeout
+	if req.GetTimeout() != nil {
+		timeout = req.GetTimeout().AsDuration()
+		if timeout <= 0 {
+			return nil, grpcerrors.NewValidationError("invalid timeout", map[string]string{
+				"timeout": "must be positive",
+			})
+		}
+	}
+
+	var target peer.ID
+	if req.GetPeerId() != "" {
+		id, err := peer.Decode(req.GetPeerId())
+		if err != nil {
+			return nil, grpcerrors.NewValidationError("invalid peer_id", map[string]string{
+				"peer_id": err.Error(),
+			})
+		}
+		target = id
+	}
+
+	start := time.Now()
+	resp := &services.SelfTestResponse{Passed: true, NodeId: s.nodeID}
+
+	for _, step := range s.selfTestSteps(target) {
+		result := runSelfTestStep(ctx, step, timeout)
+		if result.Status == services.SelfTestStatus_SELF_TEST_STATUS_FAILED {
+			resp.Passed = false
+		}
+		resp.Steps = append(resp.Steps, result)
+	}
+	resp.Duration = durationpb.New(time.Since(start))
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "EXECUTE", "system", "selftest", map[string]interface{}{
+			"passed": resp.Passed,
+		})
+	}
+
+	return resp, nil
+}
+
+// selfTestSteps returns the steps in execution order.
+func (s *Server) selfTestSteps(target peer.ID) []selfTestStep {
+	// The record is keyed by a synthetic peer ID that cannot collide with a
+	// real libp2p peer, and is removed by the delete step.
+	key := "selftest-" + uuid.NewString()
+	var written bool
+
+	return []selfTestStep{
+		{name: "storage_write", run: func(ctx context.Context) (string, error) {
+			if s.store == nil {
+				return "", fmt.Errorf("%w: storage not configured", errSkipped)
+			}
+			now := time.Now().UTC()
+			err := s.store.Nodes().Upsert(ctx, &storage.NodeInfo{
+				PeerID:      key,
+				Addresses:   []string{},
+				Mode:        "proxy",
+				StorageType: string(s.store.Backend()),
+				LastSeen:    now,
+				Metadata:    map[string]any{"selftest": true},
+			})
+			if err != nil {
+				return "", err
+			}
+			written = true
+			return fmt.Sprintf("wrote node %s", key), nil
+		}},
+		{name: "storage_read", run: func(ctx context.Context) (string, error) {
+			if !written {
+				return "", fmt.Errorf("%w: nothing was written", errSkipped)
+			}
+			node, err := s.store.Nodes().Get(ctx, key)
+			if err != nil {
+				return "", err
+			}
+			if node.PeerID != key {
+				return "", fmt.Errorf("read back node %q, want %q", node.PeerID, key)
+			}
+			return fmt.Sprintf("read node %s", key), nil
+		}},
+		{name: "storage_delete", run: func(ctx context.Context) (string, error) {
+			if !written {
+				return "", fmt.Errorf("%w: nothing was written", errSkipped)
+			}
+			if err := s.store.Nodes().Delete(ctx, key); err != nil {
+				return "", err
+			}
+			if _, err := s.store.Nodes().Get(ctx, key); !storage.IsNotFound(err) {
+				return "", fmt.Errorf("node %s still readable after delete", key)
+			}
+			return fmt.Sprintf("deleted node %s", key), nil
+		}},
+		{name: "pubsub", run: func(ctx context.Context) (string, error) {
+			if s.pubsub == nil {
+				return "", fmt.Errorf("%w: pubsub not running", errSkipped)
+			}
+			latency, err := s.pubsub.Loopback(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("message delivered in %s", latency.Round(time.Microsecond)), nil
+		}},
+		{name: "peer_ping", run: func(ctx context.Context) (string, error) {
+			if s.p2pHost == nil {
+				return "", fmt.Errorf("%w: p2p not enabled", errSkipped)
+			}
+			id := target
+			if id == "" {
+				peers := s.p2pHost.Network().Peers()
+				if len(peers) == 0 {
+					return "", fmt.Errorf("%w: no connected peers", errSkipped)
+				}
+				id = peers[0]
+			}
+			rtt, err := s.p2pHost.Ping(ctx, id)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s replied in %s", id, rtt.Round(time.Microsecond)), nil
+		}},
+	}
+}
+
+// runSelfTestStep runs a step under its own timeout and times it.
+func runSelfTestStep(ctx context.Context, step selfTestStep, timeout time.Duration) *services.SelfTestStep {
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	msg, err := step.run(stepCtx)
+	result := &services.SelfTestStep{
+		Name:     step.name,
+		Duration: durationpb.New(time.Since(start)),
+		Status:   services.SelfTestStatus_SELF_TEST_STATUS_PASSED,
+		Message:  msg,
+	}
+
+	switch {
+	case errors.Is(err, errSkipped):
+		result.Status = services.SelfTestStatus_SELF_TEST_STATUS_SKIPPED
+		result.Message = err.Error()
+	case err != nil:
+		result.Status = services.SelfTestStatus_SELF_TEST_STATUS_FAILED
+		result.Message = err.Error()
+	}
+	return result
+}
@@ -4,16 +4,26 @@ package admin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
 	services "bib/api/gen/go/bib/v1/services"
 	"bib/internal/cluster"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
 	"bib/internal/grpc/interfaces"
+	"bib/internal/grpc/middleware"
+	"bib/internal/maintenance"
+	"bib/internal/p2p"
 	"bib/internal/storage"
+	"bib/internal/storage/audit"
 	"bib/internal/storage/backup"
+	"bib/internal/version"
 
+	"golang.org/x/mod/semver"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -30,6 +40,7 @@ type LogRingBuffer struct {
 	mu        sync.RWMutex
 	listeners map[int64]chan LogEntry
 	nextID    int64
+	seq       uint64
 }
 
 // LogEntry represents a single log entry.
@@ -38,6 +49,10 @@ type LogEntry struct {
 	Level     string
 	Message   string
 	Fields    map[string]string
+
+	// Seq numbers the entries of a buffer in the order they were added,
+	// from 1. It is set by Add.
+	Seq uint64
 }
 
 // NewLogRingBuffer creates a new log ring buffer with the specified capacity.
@@ -57,6 +72,8 @@ func (b *LogRingBuffer) Add(entry LogEntry) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	b.seq++
+	entry.Seq = b.seq
 	b.entries[b.head] = entry
 	b.head = (b.head + 1) % b.size
 	if b.count < b.size {
@@ -91,6 +108,25 @@ func (b *LogRingBuffer) Recent(n int) []LogEntry {
 	return result
 }
 
+// Since returns the buffered entries added after the entry numbered seq.
+// Entries already dropped from the buffer are skipped.
+func (b *LogRingBuffer) Since(seq uint64) []LogEntry {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := int(min(b.seq-min(seq, b.seq), uint64(b.count)))
+	if n == 0 {
+		return nil
+	}
+
+	result := make([]LogEntry, n)
+	start := (b.head - n + b.size) % b.size
+	for i := 0; i < n; i++ {
+		result[i] = b.entries[(start+i)%b.size]
+	}
+	return result
+}
+
 // Subscribe creates a new subscription for log entries.
 func (b *LogRingBuffer) Subscribe() (<-chan LogEntry, func()) {
 	b.mu.Lock()
@@ -125,6 +161,21 @@ type Config struct {
 	ShutdownFunc func()
 	Config       interface{}
 	LogBuffer    *LogRingBuffer
+
+	// SensitiveFields are redacted from audit entries returned to clients.
+	// Empty uses the audit logger's defaults.
+	SensitiveFields []string
+
+	// AuditRateLimiter holds the users and roles blocked by audit alerts.
+	AuditRateLimiter *audit.RateLimiter
+
+	// P2PHost and PubSub are exercised by SelfTest (optional)
+	P2PHost *p2p.Host
+	PubSub  *p2p.PubSub
+
+	// Maintenance is the pause signal of the background maintenance
+	// workers (optional)
+	Maintenance *maintenance.Gate
 }
 
 // Server implements the AdminService gRPC service.
@@ -141,6 +192,28 @@ type Server struct {
 	shutdownFunc func()
 	config       interface{}
 	logBuffer    *LogRingBuffer
+	redactor     *audit.Redactor
+	auditBlocks  *audit.RateLimiter
+	p2pHost      *p2p.Host
+	pubsub       *p2p.PubSub
+	maintenance  *maintenance.Gate
+	profiler     *profiler
+
+	confirmations *confirmations
+}
+
+// requireAdmin returns an error unless the caller is an admin. Handlers
+// exposing daemon internals check this themselves rather than relying on
+// the RBAC interceptor alone.
+func requireAdmin(ctx context.Context, action, resource string) error {
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "not authenticated")
+	}
+	if !user.IsAdmin() {
+		return grpcerrors.NewPermissionDeniedError(action, resource, string(domain.UserRoleAdmin))
+	}
+	return nil
 }
 
 // NewServer creates a new admin service server.
@@ -148,6 +221,9 @@ func NewServer() *Server {
 	return &Server{
 		startedAt: time.Now(),
 		logBuffer: NewLogRingBuffer(1000),
+		redactor:  newRedactor(nil),
+
+		confirmations: newConfirmations(),
 	}
 }
 
@@ -169,6 +245,13 @@ func NewServerWithConfig(cfg Config) *Server {
 		shutdownFunc: cfg.ShutdownFunc,
 		config:       cfg.Config,
 		logBuffer:    logBuffer,
+		redactor:     newRedactor(cfg.SensitiveFields),
+		auditBlocks:  cfg.AuditRateLimiter,
+		p2pHost:      cfg.P2PHost,
+		pubsub:       cfg.PubSub,
+		maintenance:  cfg.Maintenance,
+
+		confirmations: newConfirmations(),
 	}
 }
 
@@ -215,6 +298,7 @@ func (s *Server) GetSystemInfo(_ context.Context, _ *services.GetSystemInfoReque
 		HeapAlloc:    int64(memStats.Alloc),
 		TotalMemory:  int64(memStats.Sys),
 		UsedMemory:   int64(memStats.Alloc),
+		Maintenance:  maintenanceStateToProto(s.maintenance.State()),
 	}, nil
 }
 
@@ -275,6 +359,11 @@ func (s *Server) GetClusterStatus(_ context.Context, _ *services.GetClusterStatu
 	}, nil
 }
 
+// SetBackupManager sets the manager of the daemon's database backups.
+func (s *Server) SetBackupManager(mgr *backup.Manager) {
+	s.backupMgr = mgr
+}
+
 // TriggerBackup triggers a backup operation.
 func (s *Server) TriggerBackup(ctx context.Context, req *services.TriggerBackupRequest) (*services.TriggerBackupResponse, error) {
 	if s.backupMgr == nil {
@@ -328,8 +417,137 @@ func (s *Server) ListBackups(ctx context.Context, _ *services.ListBackupsRequest
 	}, nil
 }
 
+// DeleteBackup deletes a backup. A dry run reports the backup and the bytes
+// it would free without deleting it, and issues the confirmation token the
+// deletion requires.
+func (s *Server) DeleteBackup(ctx context.Context, req *services.DeleteBackupRequest) (*services.DeleteBackupResponse, error) {
+	if s.backupMgr == nil {
+		return nil, status.Error(codes.Unavailable, "backup manager not available")
+	}
+	if req.GetBackupId() == "" {
+		return nil, grpcerrors.NewValidationError("backup_id is required", map[string]string{
+			"backup_id": "must not be empty",
+		})
+	}
+
+	backups, err := s.backupMgr.List(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list backups: %v", err)
+	}
+	var target *backup.BackupMetadata
+	for _, b := range backups {
+		if b.ID == req.GetBackupId() {
+			target = b
+			break
+		}
+	}
+	if target == nil {
+		return nil, status.Errorf(codes.NotFound, "backup %s not found", req.GetBackupId())
+	}
+
+	if req.GetDryRun() {
+		report := &services.DryRunReport{
+			Resources: []*services.AffectedResource{{
+				Kind:      "backup",
+				Id:        target.ID,
+				SizeBytes: target.Size,
+				Detail:    fmt.Sprintf("%s backup from %s", target.Backend, target.Timestamp.Format(time.RFC3339)),
+			}},
+			TotalBytes: target.Size,
+			Summary:    fmt.Sprintf("would delete backup %s, %d bytes", target.ID, target.Size),
+		}
+		if err := s.confirm(ctx, report, "delete_backup", target.ID); err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+		return &services.DeleteBackupResponse{DryRun: report}, nil
+	}
+
+	if err := s.confirmations.consume(ctx, "delete_backup", target.ID, req.GetConfirmationToken()); err != nil {
+		return nil, err
+	}
+
+	if err := s.backupMgr.Delete(ctx, target.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete backup: %v", err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "backup", target.ID, map[string]interface{}{
+			"size": target.Size,
+		})
+	}
+
+	return &services.DeleteBackupResponse{Success: true}, nil
+}
+
+// Upgrade validates an upgrade request and takes a pre-upgrade backup. The
+// daemon cannot replace its own container, so the client restarts it on the
+// new image afterwards.
+func (s *Server) Upgrade(ctx context.Context, req *services.UpgradeRequest) (*services.UpgradeResponse, error) {
+	target := strings.TrimSpace(req.GetVersion())
+	if target == "" {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+
+	current := version.Get().Version
+	resp := &services.UpgradeResponse{
+		CurrentVersion: current,
+		TargetVersion:  target,
+	}
+
+	// Versions that are not semver (e.g. "latest") cannot be compared
+	cur, tgt := canonicalVersion(current), canonicalVersion(target)
+	if semver.IsValid(cur) && semver.IsValid(tgt) {
+		switch c := semver.Compare(tgt, cur); {
+		case c == 0:
+			resp.Message = fmt.Sprintf("already running %s", current)
+			return resp, nil
+		case c < 0 && !req.GetAllowDowngrade():
+			return nil, grpcerrors.NewPreconditionError(grpcerrors.SubreasonDowngrade,
+				fmt.Sprintf("%s is older than the running %s; set allow_downgrade to downgrade", target, current), nil)
+		}
+	}
+
+	if req.GetDryRun() {
+		resp.Accepted = true
+		resp.Message = fmt.Sprintf("upgrade from %s to %s would be accepted", current, target)
+		return resp, nil
+	}
+
+	if !req.GetSkipBackup() {
+		if s.backupMgr == nil {
+			return nil, grpcerrors.NewPreconditionError(grpcerrors.SubreasonBackupUnavailable,
+				"backups are not available on this daemon; set skip_backup to upgrade without a pre-upgrade backup", nil)
+		}
+		meta, err := s.backupMgr.Backup(ctx, fmt.Sprintf("pre-upgrade to %s", target))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "pre-upgrade backup failed: %v", err)
+		}
+		resp.BackupId = meta.ID
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "UPDATE", "system", "upgrade", map[string]interface{}{
+			"from":      current,
+			"to":        target,
+			"backup_id": resp.BackupId,
+		})
+	}
+
+	resp.Accepted = true
+	resp.Message = fmt.Sprintf("ready to upgrade from %s to %s", current, target)
+	return resp, nil
+}
+
 // Helper functions
 
+// canonicalVersion adds the "v" prefix expected by the semver package
+func canonicalVersion(v string) string {
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	return v
+}
+
 func configToMap(cfg interface{}, includeSecrets bool) map[string]interface{} {
 	data, err := json.Marshal(cfg)
 	if err != nil {
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/auth"
+	"bib/internal/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StartDeviceAuth starts a device authorization for a device that has no
+// identity key.
+func (s *Server) StartDeviceAuth(_ context.Context, req *services.StartDeviceAuthRequest) (*services.StartDeviceAuthResponse, error) {
+	if s.deviceStore == nil || s.authService == nil {
+		return nil, status.Error(codes.Unavailable, "auth service not initialized")
+	}
+	if !s.cfg.DeviceAuth.Enabled {
+		return nil, status.Error(codes.FailedPrecondition, "device authorization is disabled on this node")
+	}
+
+	d, err := s.deviceStore.Create(clientInfoToDevice(req.ClientInfo))
+	if errors.Is(err, auth.ErrTooManyDeviceRequests) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to start device authorization: %v", err)
+	}
+
+	return &services.StartDeviceAuthResponse{
+		DeviceCode:      d.DeviceCode,
+		UserCode:        d.UserCode,
+		VerificationUri: s.cfg.DeviceAuth.VerificationURI,
+		ExpiresAt:       timestamppb.New(d.ExpiresAt),
+		IntervalSeconds: intervalSeconds(d.Interval),
+	}, nil
+}
+
+// PollDeviceAuth reports the state of a device authorization and opens the
+// device's session once it is approved.
+func (s *Server) PollDeviceAuth(ctx context.Context, req *services.PollDeviceAuthRequest) (*services.PollDeviceAuthResponse, error) {
+	if s.deviceStore == nil || s.authService == nil {
+		return nil, status.Error(codes.Unavailable, "auth service not initialized")
+	}
+
+	if req.DeviceCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_code is required")
+	}
+
+	d, err := s.deviceStore.Poll(req.DeviceCode)
+	switch {
+	case errors.Is(err, auth.ErrDeviceCodeNotFound):
+		return &services.PollDeviceAuthResponse{
+			Status: services.DeviceAuthStatus_DEVICE_AUTH_STATUS_EXPIRED,
+		}, nil
+	case errors.Is(err, auth.ErrSlowDown):
+		return &services.PollDeviceAuthResponse{
+			Status:          services.DeviceAuthStatus_DEVICE_AUTH_STATUS_SLOW_DOWN,
+			IntervalSeconds: intervalSeconds(d.Interval),
+		}, nil
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to poll device authorization: %v", err)
+	}
+
+	resp := &services.PollDeviceAuthResponse{
+		IntervalSeconds: intervalSeconds(d.Interval),
+	}
+
+	switch d.Status {
+	case auth.DeviceAuthPending:
+		resp.Status = services.DeviceAuthStatus_DEVICE_AUTH_STATUS_PENDING
+		return resp, nil
+	case auth.DeviceAuthDenied:
+		resp.Status = services.DeviceAuthStatus_DEVICE_AUTH_STATUS_DENIED
+		return resp, nil
+	}
+
+	metadata := make(map[string]string, len(d.Client.Metadata)+1)
+	for k, v := range d.Client.Metadata {
+		metadata[k] = v
+	}
+	metadata["auth_method"] = "device"
+
+	// The session is attributed to the approving session's key, so revoking
+	// that key also signs the device out
+	session, err := s.authService.CreateSession(ctx, d.UserID, auth.SessionRequest{
+		SessionType:          storage.SessionTypeGRPC,
+		ClientIP:             d.Client.IPAddress,
+		ClientAgent:          d.Client.UserAgent,
+		Metadata:             metadata,
+		PublicKeyFingerprint: d.PublicKeyFingerprint,
+	})
+	if err != nil {
+		return nil, authErrorToGRPC(err)
+	}
+
+	user, err := s.authService.GetUser(ctx, d.UserID)
+	if err != nil {
+		return nil, authErrorToGRPC(err)
+	}
+
+	resp.Status = services.DeviceAuthStatus_DEVICE_AUTH_STATUS_APPROVED
+	resp.SessionToken = session.ID
+	resp.ExpiresAt = timestamppb.New(s.sessionExpiry(session))
+	resp.User = domainUserToProto(user)
+	resp.Session = storageSessionToProto(session, true)
+	return resp, nil
+}
+
+// ApproveDeviceAuth approves or denies a device authorization as the caller.
+func (s *Server) ApproveDeviceAuth(ctx context.Context, req *services.ApproveDeviceAuthRequest) (*services.ApproveDeviceAuthResponse, error) {
+	if s.deviceStore == nil || s.authService == nil {
+		return nil, status.Error(codes.Unavailable, "auth service not initialized")
+	}
+
+	if req.UserCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_code is required")
+	}
+
+	sessionID, err := ExtractSessionToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.authService.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid session: %v", err)
+	}
+
+	var d *auth.DeviceAuthorization
+	if req.Deny {
+		d, err = s.deviceStore.Deny(req.UserCode)
+	} else {
+		d, err = s.deviceStore.Approve(req.UserCode, session.UserID, session.PublicKeyFingerprint)
+	}
+	if errors.Is(err, auth.ErrDeviceCodeNotFound) {
+		return nil, status.Error(codes.NotFound, "user code not found or expired")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to approve device authorization: %v", err)
+	}
+
+	return &services.ApproveDeviceAuthResponse{
+		Approved: !req.Deny,
+		ClientInfo: &services.ClientInfo{
+			IpAddress: d.Client.IPAddress,
+			UserAgent: d.Client.UserAgent,
+			Metadata:  d.Client.Metadata,
+		},
+	}, nil
+}
+
+// clientInfoToDevice converts request client info to the device it
+// describes.
+func clientInfoToDevice(info *services.ClientInfo) auth.DeviceClient {
+	client := auth.DeviceClient{Metadata: make(map[string]string)}
+	if info == nil {
+		return client
+	}
+
+	client.IPAddress = info.IpAddress
+	client.UserAgent = info.UserAgent
+	if info.Version != "" {
+		client.Metadata["client_version"] = info.Version
+	}
+	for k, v := range info.Metadata {
+		client.Metadata[k] = v
+	}
+	return client
+}
+
+// intervalSeconds converts a polling interval to whole seconds, rounding up.
+func intervalSeconds(d time.Duration) int32 {
+	return int32((d + time.Second - 1) / time.Second)
+}
@@ -10,6 +10,7 @@ import (
 	"bib/internal/auth"
 	"bib/internal/config"
 	"bib/internal/domain"
+	"bib/internal/grpc/middleware"
 	"bib/internal/storage"
 
 	"google.golang.org/grpc/codes"
@@ -41,6 +42,7 @@ type Server struct {
 
 	authService    *auth.Service
 	challengeStore *auth.ChallengeStore
+	deviceStore    *auth.DeviceAuthStore
 	cfg            config.AuthConfig
 	nodeID         string
 	nodeMode       string
@@ -51,6 +53,7 @@ type Server struct {
 func NewServer() *Server {
 	return &Server{
 		challengeStore: auth.NewChallengeStore(challengeTTL),
+		deviceStore:    auth.NewDeviceAuthStore(0, 0),
 	}
 }
 
@@ -59,6 +62,7 @@ func NewServerWithConfig(cfg Config) *Server {
 	return &Server{
 		authService:    cfg.AuthService,
 		challengeStore: auth.NewChallengeStore(challengeTTL),
+		deviceStore:    auth.NewDeviceAuthStore(cfg.AuthConfig.DeviceAuth.CodeTTL, cfg.AuthConfig.DeviceAuth.PollInterval),
 		cfg:            cfg.AuthConfig,
 		nodeID:         cfg.NodeID,
 		nodeMode:       cfg.NodeMode,
@@ -260,17 +264,11 @@ func (s *Server) ValidateSession(ctx context.Context, req *services.ValidateSess
 		}, nil
 	}
 
-	// Calculate expiry
-	expiresAt := session.LastActivityAt.Add(s.cfg.SessionTimeout)
-	if s.cfg.SessionTimeout == 0 {
-		expiresAt = session.LastActivityAt.Add(24 * time.Hour)
-	}
-
 	return &services.ValidateSessionResponse{
 		Valid:     true,
 		User:      domainUserToProto(user),
 		Session:   storageSessionToProto(session, true),
-		ExpiresAt: timestamppb.New(expiresAt),
+		ExpiresAt: timestamppb.New(s.sessionExpiry(session)),
 	}, nil
 }
 
@@ -449,11 +447,64 @@ func (s *Server) RevokeAllSessions(ctx context.Context, req *services.RevokeAllS
 	return &services.RevokeAllSessionsResponse{RevokedCount: revokedCount}, nil
 }
 
+// WhoAmI returns the authenticated principal of the current session.
+func (s *Server) WhoAmI(ctx context.Context, _ *services.WhoAmIRequest) (*services.WhoAmIResponse, error) {
+	if s.authService == nil {
+		return nil, status.Error(codes.Unavailable, "auth service not initialized")
+	}
+
+	sessionID, err := ExtractSessionToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.authService.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid session: %v", err)
+	}
+
+	user, err := s.authService.GetUser(ctx, session.UserID)
+	if err != nil {
+		return nil, authErrorToGRPC(err)
+	}
+
+	roles := middleware.EffectiveRoles(user.Role)
+	roleNames := make([]string, len(roles))
+	for i, role := range roles {
+		roleNames[i] = string(role)
+	}
+
+	return &services.WhoAmIResponse{
+		User:                 domainUserToProto(user),
+		PublicKeyFingerprint: session.PublicKeyFingerprint,
+		Roles:                roleNames,
+		Permissions:          middleware.AllowedMethods(user.Role),
+		Session:              storageSessionToProto(session, true),
+		ExpiresAt:            timestamppb.New(s.sessionExpiry(session)),
+		NodeId:               s.nodeID,
+		NodeMode:             s.nodeMode,
+		Version:              s.version,
+	}, nil
+}
+
+// sessionExpiry returns when a session expires without further activity.
+func (s *Server) sessionExpiry(session *storage.Session) time.Time {
+	timeout := s.cfg.SessionTimeout
+	if timeout == 0 {
+		timeout = 24 * time.Hour
+	}
+	return session.LastActivityAt.Add(timeout)
+}
+
 // SetDependencies sets the service dependencies after creation.
 // This is useful when dependencies aren't available at construction time.
 func (s *Server) SetDependencies(authSvc *auth.Service, cfg config.AuthConfig, nodeID, nodeMode, version string) {
 	s.authService = authSvc
 	s.cfg = cfg
+	if s.deviceStore != nil {
+		s.deviceStore.Stop()
+	}
+	s.deviceStore = auth.NewDeviceAuthStore(cfg.DeviceAuth.CodeTTL, cfg.DeviceAuth.PollInterval)
 	s.nodeID = nodeID
 	s.nodeMode = nodeMode
 	s.version = version
@@ -464,6 +515,9 @@ func (s *Server) Stop() {
 	if s.challengeStore != nil {
 		s.challengeStore.Stop()
 	}
+	if s.deviceStore != nil {
+		s.deviceStore.Stop()
+	}
 }
 
 // ExtractSessionToken extracts the session token from gRPC metadata.
@@ -502,6 +556,8 @@ func authErrorToGRPC(err error) error {
 		return status.Error(codes.PermissionDenied, "user account is suspended")
 	case domain.ErrAutoRegDisabled:
 		return status.Error(codes.PermissionDenied, "auto-registration is disabled")
+	case domain.ErrEmailRequired:
+		return status.Error(codes.FailedPrecondition, "email is required for registration")
 	case domain.ErrUnauthorized:
 		return status.Error(codes.Unauthenticated, "unauthorized")
 	case domain.ErrSessionExpired:
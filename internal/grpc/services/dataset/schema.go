@@ -0,0 +1,250 @@
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/jsonschema"
+	"bib/internal/storage/blob"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// schemaCacheSize bounds the number of compiled data schemas kept.
+	schemaCacheSize = 256
+
+	// maxReportedViolations bounds the schema violations returned to the
+	// client or recorded in the audit log for one write.
+	maxReportedViolations = 100
+
+	// schemaStatusKey is the version metadata key recording the result of
+	// the schema check.
+	schemaStatusKey = "schema_status"
+)
+
+// Schema check results, recorded in version metadata.
+const (
+	schemaStatusValid   = "valid"
+	schemaStatusInvalid = "invalid"
+)
+
+// schemaCheck is the result of checking content against a data schema.
+type schemaCheck struct {
+	schema     *domain.DataSchema
+	violations []*bibv1.SchemaViolation // at most maxReportedViolations
+	total      int
+}
+
+// status returns the schema status to record, or "" without a schema.
+func (c *schemaCheck) status() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.total > 0:
+		return schemaStatusInvalid
+	default:
+		return schemaStatusValid
+	}
+}
+
+// annotate records the result of the check in the version's metadata.
+func (c *schemaCheck) annotate(v *domain.DatasetVersion) {
+	if c == nil {
+		return
+	}
+	if v.Metadata == nil {
+		v.Metadata = make(map[string]string)
+	}
+	v.Metadata[schemaStatusKey] = c.status()
+}
+
+// effectiveSchema returns the data schema content of dataset must match:
+// its own, or else its topic's. It returns nil if neither declares one.
+func (s *Server) effectiveSchema(ctx context.Context, dataset *domain.Dataset) *domain.DataSchema {
+	if dataset.DataSchema != nil {
+		return dataset.DataSchema
+	}
+	topic, err := s.store.Topics().Get(ctx, dataset.TopicID)
+	if err != nil {
+		return nil
+	}
+	return topic.DataSchema
+}
+
+// compileSchema validates and compiles a data schema, reporting problems
+// against field.
+func (s *Server) compileSchema(ds *domain.DataSchema, field string) (*jsonschema.Schema, error) {
+	if err := ds.Validate(); err != nil {
+		return nil, grpcerrors.NewValidationError("invalid data schema", map[string]string{
+			field: err.Error(),
+		})
+	}
+	compiled, err := s.schemas.Compile(ds.JSONSchema)
+	if err != nil {
+		return nil, grpcerrors.NewValidationError("invalid data schema", map[string]string{
+			field + ".json_schema": err.Error(),
+		})
+	}
+	return compiled, nil
+}
+
+// checkContent validates the content made up of the given chunks against
+// the dataset's data schema. Content is read as a sequence of JSON values,
+// each of which must match. In enforce mode non-conforming content is
+// rejected with the violations as field errors; in warn mode the result is
+// returned for the caller to record. It returns nil if no schema applies.
+func (s *Server) checkContent(ctx context.Context, dataset *domain.Dataset, hashes []string) (*schemaCheck, error) {
+	ds := s.effectiveSchema(ctx, dataset)
+	if ds == nil {
+		return nil, nil
+	}
+	compiled, err := s.schemas.Compile(ds.JSONSchema)
+	if err != nil {
+		return nil, grpcerrors.NewPreconditionError(grpcerrors.SubreasonInvalidSchema, "data schema does not compile", map[string]string{
+			"data_schema": err.Error(),
+		})
+	}
+
+	check := &schemaCheck{schema: ds}
+	report := func(record int, path, message string) {
+		check.total++
+		if len(check.violations) < maxReportedViolations {
+			check.violations = append(check.violations, &bibv1.SchemaViolation{
+				Record:  int32(record),
+				Path:    path,
+				Message: message,
+			})
+		}
+	}
+
+	content := &contentReader{ctx: ctx, store: s.blobStore, hashes: hashes}
+	defer content.Close()
+
+	dec := json.NewDecoder(content)
+	dec.UseNumber()
+	for record := 0; ; record++ {
+		var doc any
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var syntaxErr *json.SyntaxError
+			if !errors.As(err, &syntaxErr) && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, status.Errorf(codes.Internal, "failed to read content: %v", err)
+			}
+			report(record, "/", "invalid JSON: "+err.Error())
+			break
+		}
+		for _, v := range compiled.Validate(doc) {
+			path := v.Path
+			if path == "" {
+				path = "/"
+			}
+			report(record, path, v.Message)
+		}
+	}
+
+	if check.total > 0 && ds.Mode == domain.SchemaModeEnforce {
+		fields := make(map[string]string, len(check.violations)+1)
+		for _, v := range check.violations {
+			fields[fmt.Sprintf("content[%d]%s", v.Record, v.Path)] = v.Message
+		}
+		if more := check.total - len(check.violations); more > 0 {
+			fields["content"] = fmt.Sprintf("%d more violations not shown", more)
+		}
+		return nil, grpcerrors.NewValidationError("content does not match the data schema", fields)
+	}
+	return check, nil
+}
+
+// auditSchemaViolations records that a version was accepted despite not
+// matching the data schema, as warn mode allows.
+func (s *Server) auditSchemaViolations(ctx context.Context, dataset *domain.Dataset, version *domain.DatasetVersion, check *schemaCheck) {
+	if s.auditLogger == nil || check == nil || check.total == 0 {
+		return
+	}
+	violations := make([]string, len(check.violations))
+	for i, v := range check.violations {
+		violations[i] = fmt.Sprintf("content[%d]%s: %s", v.Record, v.Path, v.Message)
+	}
+	_ = s.auditLogger.LogServiceAction(ctx, "UPDATE", "dataset", string(dataset.ID), map[string]interface{}{
+		"version_id":        string(version.ID),
+		"schema_mode":       string(check.schema.Mode),
+		"schema_violations": check.total,
+		"violations":        violations,
+	})
+}
+
+// contentReader reads the content of a sequence of blobs, opening each one
+// only when the previous one is exhausted.
+type contentReader struct {
+	ctx    context.Context
+	store  blob.Store
+	hashes []string
+	cur    io.ReadCloser
+}
+
+func (r *contentReader) Read(p []byte) (int, error) {
+	for {
+		if r.cur == nil {
+			if len(r.hashes) == 0 {
+				return 0, io.EOF
+			}
+			rc, err := r.store.Get(r.ctx, r.hashes[0])
+			if err != nil {
+				return 0, err
+			}
+			r.cur, r.hashes = rc, r.hashes[1:]
+		}
+
+		n, err := r.cur.Read(p)
+		if err == io.EOF {
+			_ = r.cur.Close()
+			r.cur = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+// Close closes the blob being read, if any.
+func (r *contentReader) Close() error {
+	if r.cur == nil {
+		return nil
+	}
+	err := r.cur.Close()
+	r.cur = nil
+	return err
+}
+
+// Conversion helpers
+
+func dataSchemaFromProto(p *bibv1.DataSchema) *domain.DataSchema {
+	if p == nil {
+		return nil
+	}
+	mode := domain.SchemaMode(p.GetMode())
+	if mode == "" {
+		mode = domain.SchemaModeEnforce
+	}
+	return &domain.DataSchema{JSONSchema: p.GetJsonSchema(), Mode: mode}
+}
+
+func dataSchemaToProto(ds *domain.DataSchema) *bibv1.DataSchema {
+	if ds == nil {
+		return nil
+	}
+	return &bibv1.DataSchema{JsonSchema: ds.JSONSchema, Mode: string(ds.Mode)}
+}
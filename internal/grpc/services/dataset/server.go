@@ -3,6 +3,9 @@ package dataset
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sort"
 	"time"
 
 	bibv1 "bib/api/gen/go/bib/v1"
@@ -11,10 +14,12 @@ import (
 	grpcerrors "bib/internal/grpc/errors"
 	"bib/internal/grpc/interfaces"
 	"bib/internal/grpc/middleware"
+	"bib/internal/jsonschema"
 	"bib/internal/storage"
 	"bib/internal/storage/blob"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -30,6 +35,10 @@ type Config struct {
 	BlobStore   blob.Store
 	AuditLogger interfaces.AuditLogger
 	NodeMode    string
+
+	// Retention decides which old versions are removed whenever a
+	// dataset gets a new version. The zero value keeps them all.
+	Retention domain.VersionRetention
 }
 
 // Server implements the DatasetService gRPC service.
@@ -39,11 +48,13 @@ type Server struct {
 	blobStore   blob.Store
 	auditLogger interfaces.AuditLogger
 	nodeMode    string
+	retention   domain.VersionRetention
+	schemas     *jsonschema.Cache
 }
 
 // NewServer creates a new dataset service server.
 func NewServer() *Server {
-	return &Server{}
+	return &Server{schemas: jsonschema.NewCache(schemaCacheSize)}
 }
 
 // NewServerWithConfig creates a new dataset service server with dependencies.
@@ -53,6 +64,8 @@ func NewServerWithConfig(cfg Config) *Server {
 		blobStore:   cfg.BlobStore,
 		auditLogger: cfg.AuditLogger,
 		nodeMode:    cfg.NodeMode,
+		retention:   cfg.Retention,
+		schemas:     jsonschema.NewCache(schemaCacheSize),
 	}
 }
 
@@ -93,12 +106,15 @@ func (s *Server) CreateDataset(ctx context.Context, req *services.CreateDatasetR
 		return nil, grpcerrors.MapDomainError(err)
 	}
 
-	role, err := s.store.TopicMembers().GetRole(ctx, topic.ID, user.ID)
-	if err != nil {
+	if !canPublish(ctx, s.store, topic, user) {
 		return nil, grpcerrors.NewPermissionDeniedError("create", "dataset", "contributor")
 	}
-	if role != storage.TopicMemberRoleOwner && role != storage.TopicMemberRoleEditor {
-		return nil, grpcerrors.NewPermissionDeniedError("create", "dataset", "contributor")
+
+	dataSchema := dataSchemaFromProto(req.GetDataSchema())
+	if dataSchema != nil {
+		if _, err := s.compileSchema(dataSchema, "data_schema"); err != nil {
+			return nil, err
+		}
 	}
 
 	dataset := &domain.Dataset{
@@ -113,6 +129,7 @@ func (s *Server) CreateDataset(ctx context.Context, req *services.CreateDatasetR
 		UpdatedAt:   time.Now().UTC(),
 		Tags:        req.GetTags(),
 		Metadata:    req.GetMetadata(),
+		DataSchema:  dataSchema,
 	}
 
 	if err := s.store.Datasets().Create(ctx, dataset); err != nil {
@@ -131,7 +148,8 @@ func (s *Server) CreateDataset(ctx context.Context, req *services.CreateDatasetR
 	}, nil
 }
 
-// GetDataset retrieves a dataset by ID.
+// GetDataset retrieves a dataset by ID. The content fields describe the
+// requested version, or the latest one.
 func (s *Server) GetDataset(ctx context.Context, req *services.GetDatasetRequest) (*services.GetDatasetResponse, error) {
 	if s.store == nil {
 		return nil, status.Error(codes.Unavailable, "service not initialized")
@@ -143,14 +161,25 @@ func (s *Server) GetDataset(ctx context.Context, req *services.GetDatasetRequest
 		})
 	}
 
-	dataset, err := s.store.Datasets().Get(ctx, domain.DatasetID(req.GetId()))
+	dataset, err := s.readableDataset(ctx, domain.DatasetID(req.GetId()))
 	if err != nil {
-		return nil, grpcerrors.MapDomainError(err)
+		return nil, err
 	}
 
-	return &services.GetDatasetResponse{
+	resp := &services.GetDatasetResponse{
 		Dataset: datasetToProto(dataset),
-	}, nil
+	}
+
+	version, err := s.resolveVersion(ctx, dataset.ID, req.GetVersion())
+	switch {
+	case err == nil:
+		resp.Version = s.protoVersion(ctx, version)
+		withVersion(resp.Dataset, version)
+	case req.GetVersion() > 0 || !errors.Is(err, storage.ErrNotFound):
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	return resp, nil
 }
 
 // ListDatasets lists datasets with filtering.
@@ -159,24 +188,7 @@ func (s *Server) ListDatasets(ctx context.Context, req *services.ListDatasetsReq
 		return nil, status.Error(codes.Unavailable, "service not initialized")
 	}
 
-	filter := storage.DatasetFilter{
-		Tags: req.GetTags(),
-	}
-
-	if req.GetTopicId() != "" {
-		topicID := domain.TopicID(req.GetTopicId())
-		filter.TopicID = &topicID
-	}
-
-	if req.Page != nil {
-		filter.Limit = int(req.Page.Limit)
-		filter.Offset = int(req.Page.Offset)
-	}
-	if req.Sort != nil {
-		filter.OrderBy = req.Sort.Field
-		filter.OrderDesc = req.Sort.Descending
-	}
-
+	filter := datasetFilter(req)
 	if filter.Limit <= 0 {
 		filter.Limit = 50
 	}
@@ -184,6 +196,13 @@ func (s *Server) ListDatasets(ctx context.Context, req *services.ListDatasetsReq
 		filter.Limit = 1000
 	}
 
+	readable := s.topicReader(ctx)
+	if filter.TopicID != nil {
+		if err := readable.check(*filter.TopicID); err != nil {
+			return nil, err
+		}
+	}
+
 	datasets, err := s.store.Datasets().List(ctx, filter)
 	if err != nil {
 		return nil, grpcerrors.MapDomainError(err)
@@ -191,9 +210,13 @@ func (s *Server) ListDatasets(ctx context.Context, req *services.ListDatasetsReq
 
 	total, _ := s.store.Datasets().Count(ctx, filter)
 
-	protoDatasets := make([]*services.Dataset, len(datasets))
-	for i, d := range datasets {
-		protoDatasets[i] = datasetToProto(d)
+	// Datasets in topics the caller can't read are left out, so a page may
+	// come back short; paging continues from the storage offset.
+	protoDatasets := make([]*services.Dataset, 0, len(datasets))
+	for _, d := range datasets {
+		if readable.can(d.TopicID) {
+			protoDatasets = append(protoDatasets, datasetToProto(d))
+		}
 	}
 
 	return &services.ListDatasetsResponse{
@@ -201,11 +224,87 @@ func (s *Server) ListDatasets(ctx context.Context, req *services.ListDatasetsReq
 		PageInfo: &bibv1.PageInfo{
 			TotalCount: total,
 			HasMore:    int64(filter.Offset+len(datasets)) < total,
-			PageSize:   int32(len(datasets)),
+			PageSize:   int32(len(protoDatasets)),
 		},
 	}, nil
 }
 
+// streamPageSize is how many rows StreamDatasets reads from storage at a time.
+const streamPageSize = 500
+
+// StreamDatasets streams the datasets matching req one by one.
+func (s *Server) StreamDatasets(req *services.ListDatasetsRequest, stream grpc.ServerStreamingServer[services.Dataset]) error {
+	if s.store == nil {
+		return status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	ctx := stream.Context()
+	filter := datasetFilter(req)
+	remaining := filter.Limit // 0 = all
+
+	readable := s.topicReader(ctx)
+	if filter.TopicID != nil {
+		if err := readable.check(*filter.TopicID); err != nil {
+			return err
+		}
+	}
+
+	for {
+		filter.Limit = streamPageSize
+		if remaining > 0 && remaining < streamPageSize {
+			filter.Limit = remaining
+		}
+
+		datasets, err := s.store.Datasets().List(ctx, filter)
+		if err != nil {
+			return grpcerrors.MapDomainError(err)
+		}
+		for _, d := range datasets {
+			if !readable.can(d.TopicID) {
+				continue
+			}
+			if err := stream.Send(datasetToProto(d)); err != nil {
+				return err
+			}
+		}
+
+		if remaining > 0 {
+			remaining -= len(datasets)
+			if remaining <= 0 {
+				return nil
+			}
+		}
+		if len(datasets) < filter.Limit {
+			return nil
+		}
+		filter.Offset += len(datasets)
+	}
+}
+
+// datasetFilter converts a list request to a storage filter. The limit is
+// passed through as requested.
+func datasetFilter(req *services.ListDatasetsRequest) storage.DatasetFilter {
+	filter := storage.DatasetFilter{
+		Tags: req.GetTags(),
+	}
+
+	if req.GetTopicId() != "" {
+		topicID := domain.TopicID(req.GetTopicId())
+		filter.TopicID = &topicID
+	}
+
+	if req.Page != nil {
+		filter.Limit = int(req.Page.Limit)
+		filter.Offset = int(req.Page.Offset)
+	}
+	if req.Sort != nil {
+		filter.OrderBy = req.Sort.Field
+		filter.OrderDesc = req.Sort.Descending
+	}
+
+	return filter
+}
+
 // UpdateDataset updates dataset metadata.
 func (s *Server) UpdateDataset(ctx context.Context, req *services.UpdateDatasetRequest) (*services.UpdateDatasetResponse, error) {
 	if s.store == nil {
@@ -231,6 +330,9 @@ func (s *Server) UpdateDataset(ctx context.Context, req *services.UpdateDatasetR
 	if !dataset.IsOwner(user.ID) && user.Role != domain.UserRoleAdmin {
 		return nil, grpcerrors.NewPermissionDeniedError("update", "dataset", "owner")
 	}
+	if err := checkRevision(dataset, req.GetExpectedRevision()); err != nil {
+		return nil, err
+	}
 
 	if req.Name != nil {
 		dataset.Name = *req.Name
@@ -244,11 +346,20 @@ func (s *Server) UpdateDataset(ctx context.Context, req *services.UpdateDatasetR
 	if req.UpdateMetadata {
 		dataset.Metadata = req.Metadata
 	}
+	if req.UpdateDataSchema {
+		dataSchema := dataSchemaFromProto(req.GetDataSchema())
+		if dataSchema != nil {
+			if _, err := s.compileSchema(dataSchema, "data_schema"); err != nil {
+				return nil, err
+			}
+		}
+		dataset.DataSchema = dataSchema
+	}
 
 	dataset.UpdatedAt = time.Now().UTC()
 
-	if err := s.store.Datasets().Update(ctx, dataset); err != nil {
-		return nil, grpcerrors.MapDomainError(err)
+	if err := s.updateDataset(ctx, dataset); err != nil {
+		return nil, err
 	}
 
 	if s.auditLogger != nil {
@@ -285,10 +396,17 @@ func (s *Server) DeleteDataset(ctx context.Context, req *services.DeleteDatasetR
 	if !dataset.IsOwner(user.ID) && user.Role != domain.UserRoleAdmin {
 		return nil, grpcerrors.NewPermissionDeniedError("delete", "dataset", "owner")
 	}
+	if err := checkRevision(dataset, req.GetExpectedRevision()); err != nil {
+		return nil, err
+	}
 
-	if err := s.store.Datasets().Delete(ctx, dataset.ID); err != nil {
-		return nil, grpcerrors.MapDomainError(err)
+	// Read the chunk list while it still exists
+	chunks := s.listDatasetChunks(ctx, dataset.ID)
+
+	if err := s.deleteDataset(ctx, dataset); err != nil {
+		return nil, err
 	}
+	s.releaseChunkBlobs(ctx, chunks)
 
 	if s.auditLogger != nil {
 		_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "dataset", string(dataset.ID), nil)
@@ -299,6 +417,306 @@ func (s *Server) DeleteDataset(ctx context.Context, req *services.DeleteDatasetR
 	}, nil
 }
 
+// checkRevision fails with a revision conflict unless expected is 0 or the
+// revision dataset has
+func checkRevision(dataset *domain.Dataset, expected int64) error {
+	if expected != 0 && expected != dataset.Revision {
+		return grpcerrors.NewRevisionConflictError("dataset", string(dataset.ID), expected, dataset.Revision)
+	}
+	return nil
+}
+
+// updateDataset stores dataset. If it changed since it was read, the
+// revision conflict reports the revision the concurrent update left behind.
+func (s *Server) updateDataset(ctx context.Context, dataset *domain.Dataset) error {
+	expected := dataset.Revision
+	err := s.store.Datasets().Update(ctx, dataset)
+	if storage.IsConflict(err) {
+		if current, getErr := s.store.Datasets().Get(ctx, dataset.ID); getErr == nil {
+			return grpcerrors.NewRevisionConflictError("dataset", string(dataset.ID), expected, current.Revision)
+		}
+	}
+	return grpcerrors.MapDomainError(err)
+}
+
+// deleteDataset deletes dataset at the revision it was read at. If it
+// changed since, the revision conflict reports the current revision.
+func (s *Server) deleteDataset(ctx context.Context, dataset *domain.Dataset) error {
+	err := s.store.Datasets().Delete(ctx, dataset.ID, dataset.Revision)
+	if storage.IsConflict(err) {
+		if current, getErr := s.store.Datasets().Get(ctx, dataset.ID); getErr == nil {
+			return grpcerrors.NewRevisionConflictError("dataset", string(dataset.ID), dataset.Revision, current.Revision)
+		}
+	}
+	return grpcerrors.MapDomainError(err)
+}
+
+// listDatasetChunks returns every chunk of every version of a dataset.
+func (s *Server) listDatasetChunks(ctx context.Context, id domain.DatasetID) []*domain.Chunk {
+	if s.blobStore == nil {
+		return nil
+	}
+
+	versions, err := s.store.Datasets().ListVersions(ctx, id)
+	if err != nil {
+		return nil
+	}
+
+	var chunks []*domain.Chunk
+	for _, v := range versions {
+		vc, err := s.store.Datasets().ListChunks(ctx, v.ID)
+		if err != nil {
+			continue
+		}
+		chunks = append(chunks, vc...)
+	}
+	return chunks
+}
+
+// releaseChunkBlobs drops the blob references held by deleted chunks so the
+// garbage collector can reclaim content no other dataset shares. Failures
+// only leave a blob behind, so they are not reported to the caller.
+func (s *Server) releaseChunkBlobs(ctx context.Context, chunks []*domain.Chunk) {
+	for _, c := range chunks {
+		_, _ = blob.RemoveReference(ctx, s.blobStore, c.Hash, blob.Reference{
+			DatasetID:  string(c.DatasetID),
+			VersionID:  string(c.VersionID),
+			ChunkIndex: c.Index,
+		})
+	}
+}
+
+// DownloadDataset streams a dataset version's content chunk by chunk.
+// The first message carries metadata; a client resuming an export passes
+// start_chunk and checks version_id and content_hash against what it has.
+func (s *Server) DownloadDataset(req *services.DownloadDatasetRequest, stream grpc.ServerStreamingServer[services.DownloadDatasetResponse]) error {
+	if s.store == nil || s.blobStore == nil {
+		return status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.GetId() == "" {
+		return grpcerrors.NewValidationError("id is required", map[string]string{
+			"id": "must not be empty",
+		})
+	}
+	if req.GetStartChunk() < 0 || req.GetEndChunk() < 0 {
+		return grpcerrors.NewValidationError("invalid chunk range", map[string]string{
+			"start_chunk": "must not be negative",
+		})
+	}
+
+	ctx := stream.Context()
+
+	dataset, err := s.readableDataset(ctx, domain.DatasetID(req.GetId()))
+	if err != nil {
+		return err
+	}
+
+	version, err := s.resolveVersion(ctx, dataset.ID, req.GetVersion())
+	if err != nil {
+		return grpcerrors.MapDomainError(err)
+	}
+	if !version.HasContent() {
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonNoContent, "dataset version has no content", map[string]string{
+			"version": "only instructions are stored for this version",
+		})
+	}
+
+	chunks, err := s.store.Datasets().ListChunks(ctx, version.ID)
+	if err != nil {
+		return grpcerrors.MapDomainError(err)
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].Index < chunks[j].Index })
+
+	pd := datasetToProto(dataset)
+	withVersion(pd, version)
+
+	if err := stream.Send(&services.DownloadDatasetResponse{
+		Data: &services.DownloadDatasetResponse_Metadata{
+			Metadata: &services.DownloadMetadata{
+				Dataset:     pd,
+				TotalChunks: int32(len(chunks)),
+				TotalSize:   version.Content.Size,
+				ContentHash: version.Content.Hash,
+				ChunkSize:   version.Content.ChunkSize,
+				VersionId:   string(version.ID),
+			},
+		},
+	}); err != nil {
+		return err
+	}
+
+	end := len(chunks)
+	if req.GetEndChunk() > 0 && int(req.GetEndChunk()) < end {
+		end = int(req.GetEndChunk())
+	}
+
+	for _, chunk := range chunks {
+		if chunk.Index < int(req.GetStartChunk()) || chunk.Index >= end {
+			continue
+		}
+
+		data, err := s.readChunk(ctx, chunk)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&services.DownloadDatasetResponse{
+			Data: &services.DownloadDatasetResponse_Chunk{
+				Chunk: &services.ChunkData{
+					Index: int32(chunk.Index),
+					Data:  data,
+					Hash:  chunk.Hash,
+					Size:  int64(len(data)),
+				},
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DOWNLOAD", "dataset", string(dataset.ID), map[string]interface{}{
+			"version_id":  string(version.ID),
+			"start_chunk": req.GetStartChunk(),
+		})
+	}
+
+	return nil
+}
+
+// resolveVersion returns the numbered version of a dataset, or the latest
+// version when number is 0.
+func (s *Server) resolveVersion(ctx context.Context, datasetID domain.DatasetID, number int32) (*domain.DatasetVersion, error) {
+	if number <= 0 {
+		return s.store.Datasets().GetLatestVersion(ctx, datasetID)
+	}
+
+	versions, err := s.store.Datasets().ListVersions(ctx, datasetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Number() == int(number) {
+			return v, nil
+		}
+	}
+	return nil, domain.ErrVersionNotFound
+}
+
+// readChunk reads a chunk's blob into memory. Chunks are bounded by the
+// version's chunk size, so each fits in a single message.
+func (s *Server) readChunk(ctx context.Context, chunk *domain.Chunk) ([]byte, error) {
+	reader, err := s.blobStore.Get(ctx, chunk.Hash)
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "chunk %d is missing from blob storage: %v", chunk.Index, err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read chunk %d: %v", chunk.Index, err)
+	}
+	return data, nil
+}
+
+// canPublish reports whether user may add datasets to topic: as an owner
+// or editor of it, or through a write grant in its ACL.
+func canPublish(ctx context.Context, store storage.Store, topic *domain.Topic, user *domain.User) bool {
+	if topic.PermissionFor(user.ID, user.Role).Includes(domain.TopicPermissionWrite) {
+		return true
+	}
+	role, err := store.TopicMembers().GetRole(ctx, topic.ID, user.ID)
+	return err == nil && role.CanEdit()
+}
+
+// canRead reports whether user may read the datasets of topic, as the topic
+// service decides who may read the topic itself: anyone may read a public
+// topic without an ACL; otherwise reading requires admin, a read grant in
+// the ACL or a membership.
+func canRead(ctx context.Context, store storage.Store, topic *domain.Topic, user *domain.User) bool {
+	if !topic.IsRestricted() && topic.IsPublic() {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	if user.IsAdmin() || topic.PermissionFor(user.ID, user.Role).Includes(domain.TopicPermissionRead) {
+		return true
+	}
+	role, err := store.TopicMembers().GetRole(ctx, topic.ID, user.ID)
+	return err == nil && role.Permission().Includes(domain.TopicPermissionRead)
+}
+
+// readableDataset gets a dataset, failing with PermissionDenied unless the
+// caller may read its topic.
+func (s *Server) readableDataset(ctx context.Context, id domain.DatasetID) (*domain.Dataset, error) {
+	dataset, err := s.store.Datasets().Get(ctx, id)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	if err := s.topicReader(ctx).check(dataset.TopicID); err != nil {
+		return nil, err
+	}
+	return dataset, nil
+}
+
+// topicReader decides which topics the caller of ctx may read, looking
+// each topic and dataset up once.
+type topicReader struct {
+	ctx      context.Context
+	store    storage.Store
+	user     *domain.User
+	readable map[domain.TopicID]bool
+	topics   map[domain.DatasetID]domain.TopicID
+}
+
+// topicReader returns a topicReader for the caller of ctx.
+func (s *Server) topicReader(ctx context.Context) *topicReader {
+	user, _ := middleware.UserFromContext(ctx)
+	return &topicReader{
+		ctx:      ctx,
+		store:    s.store,
+		user:     user,
+		readable: make(map[domain.TopicID]bool),
+		topics:   make(map[domain.DatasetID]domain.TopicID),
+	}
+}
+
+// can reports whether the caller may read the datasets of a topic. A topic
+// that can't be loaded is not readable.
+func (r *topicReader) can(id domain.TopicID) bool {
+	readable, ok := r.readable[id]
+	if !ok {
+		topic, err := r.store.Topics().Get(r.ctx, id)
+		readable = err == nil && canRead(r.ctx, r.store, topic, r.user)
+		r.readable[id] = readable
+	}
+	return readable
+}
+
+// canDataset reports whether the caller may read a dataset. A dataset that
+// can't be loaded is not readable.
+func (r *topicReader) canDataset(id domain.DatasetID) bool {
+	topicID, ok := r.topics[id]
+	if !ok {
+		if dataset, err := r.store.Datasets().Get(r.ctx, id); err == nil {
+			topicID = dataset.TopicID
+		}
+		r.topics[id] = topicID
+	}
+	return topicID != "" && r.can(topicID)
+}
+
+// check returns PermissionDenied unless the caller may read the datasets
+// of a topic.
+func (r *topicReader) check(id domain.TopicID) error {
+	if !r.can(id) {
+		return grpcerrors.NewPermissionDeniedError("read", "datasets of topic "+string(id), string(domain.TopicPermissionRead))
+	}
+	return nil
+}
+
 // Conversion helpers
 
 func datasetToProto(d *domain.Dataset) *services.Dataset {
@@ -322,5 +740,7 @@ func datasetToProto(d *domain.Dataset) *services.Dataset {
 		UpdatedAt:   timestamppb.New(d.UpdatedAt),
 		Tags:        d.Tags,
 		Metadata:    d.Metadata,
+		DataSchema:  dataSchemaToProto(d.DataSchema),
+		Revision:    d.Revision,
 	}
 }
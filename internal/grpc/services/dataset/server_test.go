@@ -0,0 +1,284 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/config"
+	"bib/internal/domain"
+	"bib/internal/grpc/middleware"
+	"bib/internal/logger"
+	"bib/internal/storage/blob"
+	"bib/internal/storage/memory"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	testOwner  = &domain.User{ID: "owner", Role: domain.UserRoleUser, Status: domain.UserStatusActive}
+	testReader = &domain.User{ID: "reader", Role: domain.UserRoleUser, Status: domain.UserStatusActive}
+	testOther  = &domain.User{ID: "other", Role: domain.UserRoleUser, Status: domain.UserStatusActive}
+	testAdmin  = &domain.User{ID: "admin", Role: domain.UserRoleAdmin, Status: domain.UserStatusActive}
+)
+
+// asUser returns a context authenticated as user, or anonymous if nil.
+func asUser(user *domain.User) context.Context {
+	if user == nil {
+		return context.Background()
+	}
+	return middleware.WithUser(context.Background(), user)
+}
+
+// newTestServer returns a server backed by a memory store and a local blob
+// store, with two topics owned by testOwner: "open", readable by anyone,
+// and "secret", whose ACL grants read to testReader only.
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	log, err := logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stderr"})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	dir := t.TempDir()
+	blobStore, err := blob.NewLocalStore(blob.LocalConfig{
+		Enabled:     true,
+		Path:        filepath.Join(dir, "blobs"),
+		Compression: blob.CompressionConfig{Algorithm: "none"},
+	}, dir, nil, log)
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+	t.Cleanup(func() { blobStore.Close() })
+
+	store := memory.New("test-node")
+	ctx := context.Background()
+	now := time.Now().UTC()
+	for _, topic := range []*domain.Topic{
+		{ID: "open", Name: "open"},
+		{ID: "secret", Name: "secret", ACL: []domain.TopicACLEntry{{
+			PrincipalType: domain.TopicPrincipalUser,
+			Principal:     string(testReader.ID),
+			Permission:    domain.TopicPermissionRead,
+		}}},
+	} {
+		topic.Status = domain.TopicStatusActive
+		topic.Owners = []domain.UserID{testOwner.ID}
+		topic.CreatedBy = testOwner.ID
+		topic.CreatedAt, topic.UpdatedAt = now, now
+		if err := store.Topics().Create(ctx, topic); err != nil {
+			t.Fatalf("failed to create topic %s: %v", topic.ID, err)
+		}
+	}
+
+	return NewServerWithConfig(Config{Store: store, BlobStore: blobStore})
+}
+
+// uploadContent uploads data as a new dataset in topic, in chunks of
+// chunkSize, and returns the dataset ID.
+func uploadContent(t *testing.T, s *Server, user *domain.User, topic, name string, data []byte, chunkSize int) string {
+	t.Helper()
+
+	reqs := []*services.UploadDatasetRequest{{Data: &services.UploadDatasetRequest_Metadata{
+		Metadata: &services.UploadMetadata{TopicId: topic, Name: name},
+	}}}
+	for start := 0; start < len(data); start += chunkSize {
+		reqs = append(reqs, &services.UploadDatasetRequest{Data: &services.UploadDatasetRequest_Chunk{
+			Chunk: data[start:min(start+chunkSize, len(data))],
+		}})
+	}
+
+	stream := &uploadStream{ctx: asUser(user), reqs: reqs}
+	if err := s.UploadDataset(stream); err != nil {
+		t.Fatalf("UploadDataset() error = %v", err)
+	}
+	return stream.resp.GetDataset().GetId()
+}
+
+func TestDatasetReads_TopicACL(t *testing.T) {
+	s := newTestServer(t)
+	openID := uploadContent(t, s, testOwner, "open", "open data", []byte("open content"), 4)
+	secretID := uploadContent(t, s, testOwner, "secret", "secret data", []byte("secret content"), 4)
+
+	tests := []struct {
+		name       string
+		user       *domain.User
+		wantSecret bool
+	}{
+		{"owner", testOwner, true},
+		{"ACL reader", testReader, true},
+		{"admin", testAdmin, true},
+		{"other user", testOther, false},
+		{"anonymous", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := asUser(tt.user)
+			wantCode := codes.PermissionDenied
+			if tt.wantSecret {
+				wantCode = codes.OK
+			}
+
+			if _, err := s.GetDataset(ctx, &services.GetDatasetRequest{Id: openID}); err != nil {
+				t.Errorf("GetDataset(open) error = %v", err)
+			}
+			_, err := s.GetDataset(ctx, &services.GetDatasetRequest{Id: secretID})
+			if got := status.Code(err); got != wantCode {
+				t.Errorf("GetDataset(secret) code = %v, want %v", got, wantCode)
+			}
+
+			_, err = s.GetDatasetVersions(ctx, &services.GetDatasetVersionsRequest{DatasetId: secretID})
+			if got := status.Code(err); got != wantCode {
+				t.Errorf("GetDatasetVersions(secret) code = %v, want %v", got, wantCode)
+			}
+			_, err = s.GetVersion(ctx, &services.GetVersionRequest{DatasetId: secretID})
+			if got := status.Code(err); got != wantCode {
+				t.Errorf("GetVersion(secret) code = %v, want %v", got, wantCode)
+			}
+
+			download := &downloadStream{ctx: ctx}
+			err = s.DownloadDataset(&services.DownloadDatasetRequest{Id: secretID}, download)
+			if got := status.Code(err); got != wantCode {
+				t.Errorf("DownloadDataset(secret) code = %v, want %v", got, wantCode)
+			}
+			if !tt.wantSecret && len(download.resps) > 0 {
+				t.Errorf("DownloadDataset(secret) sent %d messages to a denied reader", len(download.resps))
+			}
+
+			_, err = s.ListDatasets(ctx, &services.ListDatasetsRequest{TopicId: "secret"})
+			if got := status.Code(err); got != wantCode {
+				t.Errorf("ListDatasets(topic secret) code = %v, want %v", got, wantCode)
+			}
+			err = s.StreamDatasets(&services.ListDatasetsRequest{TopicId: "secret"}, &datasetStream{ctx: ctx})
+			if got := status.Code(err); got != wantCode {
+				t.Errorf("StreamDatasets(topic secret) code = %v, want %v", got, wantCode)
+			}
+
+			wantIDs := []string{openID}
+			if tt.wantSecret {
+				wantIDs = append(wantIDs, secretID)
+			}
+			list, err := s.ListDatasets(ctx, &services.ListDatasetsRequest{})
+			if err != nil {
+				t.Fatalf("ListDatasets() error = %v", err)
+			}
+			if got := datasetIDs(list.GetDatasets()); !sameIDs(got, wantIDs) {
+				t.Errorf("ListDatasets() = %v, want %v", got, wantIDs)
+			}
+			stream := &datasetStream{ctx: ctx}
+			if err := s.StreamDatasets(&services.ListDatasetsRequest{}, stream); err != nil {
+				t.Fatalf("StreamDatasets() error = %v", err)
+			}
+			if got := datasetIDs(stream.datasets); !sameIDs(got, wantIDs) {
+				t.Errorf("StreamDatasets() = %v, want %v", got, wantIDs)
+			}
+		})
+	}
+}
+
+func TestDownloadDataset(t *testing.T) {
+	s := newTestServer(t)
+	content := []byte("some content in a few chunks")
+	id := uploadContent(t, s, testOwner, "open", "data", content, 8)
+
+	stream := &downloadStream{ctx: asUser(testOther)}
+	if err := s.DownloadDataset(&services.DownloadDatasetRequest{Id: id}, stream); err != nil {
+		t.Fatalf("DownloadDataset() error = %v", err)
+	}
+
+	var got []byte
+	for _, resp := range stream.resps[1:] {
+		got = append(got, resp.GetChunk().GetData()...)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("DownloadDataset() content = %q, want %q", got, content)
+	}
+}
+
+func datasetIDs(datasets []*services.Dataset) []string {
+	ids := make([]string, len(datasets))
+	for i, d := range datasets {
+		ids[i] = d.GetId()
+	}
+	return ids
+}
+
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int, len(a))
+	for _, id := range a {
+		seen[id]++
+	}
+	for _, id := range b {
+		seen[id]--
+	}
+	for _, n := range seen {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// uploadStream is an UploadDataset stream replaying reqs.
+type uploadStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*services.UploadDatasetRequest
+	resp *services.UploadDatasetResponse
+}
+
+func (s *uploadStream) Context() context.Context { return s.ctx }
+
+func (s *uploadStream) Recv() (*services.UploadDatasetRequest, error) {
+	if len(s.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return req, nil
+}
+
+func (s *uploadStream) SendAndClose(resp *services.UploadDatasetResponse) error {
+	s.resp = resp
+	return nil
+}
+
+// downloadStream is a DownloadDataset stream recording the messages sent.
+type downloadStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	resps []*services.DownloadDatasetResponse
+}
+
+func (s *downloadStream) Context() context.Context { return s.ctx }
+
+func (s *downloadStream) Send(resp *services.DownloadDatasetResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
+
+// datasetStream is a StreamDatasets stream recording the datasets sent.
+type datasetStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	datasets []*services.Dataset
+}
+
+func (s *datasetStream) Context() context.Context { return s.ctx }
+
+func (s *datasetStream) Send(d *services.Dataset) error {
+	s.datasets = append(s.datasets, d)
+	return nil
+}
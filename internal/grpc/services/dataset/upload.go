@@ -0,0 +1,500 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+	"bib/internal/storage/blob"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxUploadChunkSize bounds the chunk size a client may declare, so a
+	// single chunk always fits in one message.
+	maxUploadChunkSize = 8 * 1024 * 1024
+
+	// maxFindMissingHashes bounds a single FindMissingChunks request.
+	maxFindMissingHashes = 10000
+)
+
+// upload tracks the state of one UploadDataset stream.
+type upload struct {
+	meta      *services.UploadMetadata
+	dataset   *domain.Dataset
+	isNew     bool
+	versionID domain.DatasetVersionID
+
+	hashes   []string
+	sizes    []int64
+	received []bool
+}
+
+// FindMissingChunks reports which of the given chunk hashes the caller must
+// send. Stored chunks are only reported as present to callers who can read
+// a dataset they belong to (see reusableChunk).
+func (s *Server) FindMissingChunks(ctx context.Context, req *services.FindMissingChunksRequest) (*services.FindMissingChunksResponse, error) {
+	if s.store == nil || s.blobStore == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if len(req.GetHashes()) > maxFindMissingHashes {
+		return nil, grpcerrors.NewValidationError("too many hashes", map[string]string{
+			"hashes": fmt.Sprintf("at most %d per request", maxFindMissingHashes),
+		})
+	}
+
+	readable := s.topicReader(ctx)
+	missing := make([]string, 0)
+	seen := make(map[string]bool, len(req.GetHashes()))
+	for _, h := range req.GetHashes() {
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+
+		reusable, err := s.reusableChunk(ctx, readable, h)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to check chunk: %v", err)
+		}
+		if !reusable {
+			missing = append(missing, h)
+		}
+	}
+
+	return &services.FindMissingChunksResponse{Missing: missing}, nil
+}
+
+// UploadDataset receives dataset content and stores it as a new version.
+//
+// If the metadata lists chunk_hashes, the client only sends the chunks the
+// node is missing (see FindMissingChunks); the rest are deduplicated against
+// blob storage. Because chunks are stored as they arrive, an interrupted
+// upload can be resumed by asking again which chunks are missing.
+// Otherwise each raw chunk message is stored as one chunk.
+func (s *Server) UploadDataset(stream grpc.ClientStreamingServer[services.UploadDatasetRequest, services.UploadDatasetResponse]) error {
+	if s.store == nil || s.blobStore == nil {
+		return status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	ctx := stream.Context()
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+	if meta == nil {
+		return grpcerrors.NewValidationError("first message must be metadata", map[string]string{
+			"metadata": "must be sent first",
+		})
+	}
+
+	up, err := s.startUpload(ctx, user, meta)
+	if err != nil {
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch data := req.GetData().(type) {
+		case *services.UploadDatasetRequest_IndexedChunk:
+			err = s.receiveIndexedChunk(ctx, up, data.IndexedChunk)
+		case *services.UploadDatasetRequest_Chunk:
+			err = s.receiveRawChunk(ctx, up, data.Chunk)
+		default:
+			err = grpcerrors.NewValidationError("metadata sent twice", nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := s.finishUpload(ctx, user, up)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(resp)
+}
+
+// startUpload validates the metadata and resolves the target dataset.
+func (s *Server) startUpload(ctx context.Context, user *domain.User, meta *services.UploadMetadata) (*upload, error) {
+	up := &upload{
+		meta:      meta,
+		versionID: domain.DatasetVersionID(uuid.New().String()),
+	}
+
+	if hashes := meta.GetChunkHashes(); len(hashes) > 0 {
+		if meta.GetChunkSize() <= 0 || meta.GetChunkSize() > maxUploadChunkSize {
+			return nil, grpcerrors.NewValidationError("invalid chunk size", map[string]string{
+				"chunk_size": fmt.Sprintf("must be between 1 and %d with chunk_hashes", maxUploadChunkSize),
+			})
+		}
+		up.hashes = hashes
+		up.sizes = make([]int64, len(hashes))
+		up.received = make([]bool, len(hashes))
+	}
+
+	if meta.GetDatasetId() != "" {
+		dataset, err := s.store.Datasets().Get(ctx, domain.DatasetID(meta.GetDatasetId()))
+		if err != nil {
+			return nil, grpcerrors.MapDomainError(err)
+		}
+		if !dataset.IsOwner(user.ID) && user.Role != domain.UserRoleAdmin {
+			return nil, grpcerrors.NewPermissionDeniedError("upload", "dataset", "owner")
+		}
+		up.dataset = dataset
+		return up, nil
+	}
+
+	violations := make(map[string]string)
+	if meta.GetTopicId() == "" {
+		violations["topic_id"] = "must not be empty when dataset_id is not set"
+	}
+	if meta.GetName() == "" {
+		violations["name"] = "must not be empty when dataset_id is not set"
+	}
+	if len(violations) > 0 {
+		return nil, grpcerrors.NewValidationError("invalid upload metadata", violations)
+	}
+
+	topic, err := s.store.Topics().Get(ctx, domain.TopicID(meta.GetTopicId()))
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	if !canPublish(ctx, s.store, topic, user) {
+		return nil, grpcerrors.NewPermissionDeniedError("create", "dataset", "contributor")
+	}
+
+	now := time.Now().UTC()
+	up.isNew = true
+	up.dataset = &domain.Dataset{
+		ID:          domain.DatasetID(uuid.New().String()),
+		TopicID:     topic.ID,
+		Name:        meta.GetName(),
+		Description: meta.GetDescription(),
+		Status:      domain.DatasetStatusActive,
+		Owners:      []domain.UserID{user.ID},
+		CreatedBy:   user.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Tags:        meta.GetTags(),
+		Metadata:    meta.GetMetadata(),
+	}
+	return up, nil
+}
+
+// receiveIndexedChunk verifies and stores one chunk of a manifest upload.
+func (s *Server) receiveIndexedChunk(ctx context.Context, up *upload, chunk *services.UploadChunk) error {
+	if up.received == nil {
+		return grpcerrors.NewValidationError("indexed chunks require chunk_hashes in metadata", nil)
+	}
+
+	idx := int(chunk.GetIndex())
+	if idx < 0 || idx >= len(up.hashes) {
+		return grpcerrors.NewValidationError("chunk index out of range", map[string]string{
+			"index": fmt.Sprintf("must be between 0 and %d", len(up.hashes)-1),
+		})
+	}
+
+	c := &domain.Chunk{Index: idx, Hash: up.hashes[idx], Data: chunk.GetData()}
+	if !c.VerifyData() {
+		return status.Errorf(codes.DataLoss, "chunk %d does not match its hash", idx)
+	}
+
+	if err := s.storeChunkBlob(ctx, up, c); err != nil {
+		return err
+	}
+	up.sizes[idx] = int64(len(c.Data))
+	up.received[idx] = true
+	return nil
+}
+
+// receiveRawChunk stores a raw chunk as the next chunk of the content.
+func (s *Server) receiveRawChunk(ctx context.Context, up *upload, data []byte) error {
+	if up.received != nil {
+		return grpcerrors.NewValidationError("raw chunks cannot be mixed with chunk_hashes", nil)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	sum := sha256.Sum256(data)
+	c := &domain.Chunk{Index: len(up.sizes), Hash: hex.EncodeToString(sum[:]), Data: data}
+	if err := s.storeChunkBlob(ctx, up, c); err != nil {
+		return err
+	}
+	up.hashes = append(up.hashes, c.Hash)
+	up.sizes = append(up.sizes, int64(len(data)))
+	return nil
+}
+
+// storeChunkBlob stores a chunk's content unless an identical blob exists.
+func (s *Server) storeChunkBlob(ctx context.Context, up *upload, c *domain.Chunk) error {
+	ref := blob.Reference{
+		DatasetID:  string(up.dataset.ID),
+		VersionID:  string(up.versionID),
+		ChunkIndex: c.Index,
+	}
+
+	if _, _, err := blob.PutContent(ctx, s.blobStore, bytes.NewReader(c.Data), ref); err != nil {
+		return status.Errorf(codes.Internal, "failed to store chunk %d: %v", c.Index, err)
+	}
+	return nil
+}
+
+// finishUpload checks every chunk is stored and records the new version.
+func (s *Server) finishUpload(ctx context.Context, user *domain.User, up *upload) (*services.UploadDatasetResponse, error) {
+	sent := make(map[string]bool)
+	for idx, received := range up.received {
+		if received {
+			sent[up.hashes[idx]] = true
+		}
+	}
+
+	readable := s.topicReader(ctx)
+	var deduplicated int32
+	for idx := range up.received {
+		if up.received[idx] {
+			continue
+		}
+
+		// Not sent, so it must be stored: sent as another chunk of this
+		// upload, or reusable by the caller
+		reusable := sent[up.hashes[idx]]
+		if !reusable {
+			var err error
+			if reusable, err = s.reusableChunk(ctx, readable, up.hashes[idx]); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to check chunk %d: %v", idx, err)
+			}
+		}
+		size, err := s.blobStore.Size(ctx, up.hashes[idx])
+		if !reusable || err != nil {
+			return nil, grpcerrors.NewPreconditionError(grpcerrors.SubreasonMissingChunks, "upload is missing chunks", map[string]string{
+				fmt.Sprintf("chunk_hashes[%d]", idx): "not sent and not stored on this node",
+			})
+		}
+		if err := blob.AddReference(ctx, s.blobStore, up.hashes[idx], blob.Reference{
+			DatasetID:  string(up.dataset.ID),
+			VersionID:  string(up.versionID),
+			ChunkIndex: idx,
+		}); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to reference chunk %d: %v", idx, err)
+		}
+		up.sizes[idx] = size
+		deduplicated++
+	}
+
+	if len(up.hashes) == 0 {
+		return nil, grpcerrors.NewValidationError("upload has no content", nil)
+	}
+
+	var totalSize int64
+	for _, size := range up.sizes {
+		totalSize += size
+	}
+	if expected := up.meta.GetTotalSize(); expected > 0 && expected != totalSize {
+		return nil, grpcerrors.NewValidationError("size mismatch", map[string]string{
+			"total_size": fmt.Sprintf("expected %d, received %d", expected, totalSize),
+		})
+	}
+
+	contentHash, err := s.hashContent(ctx, up.hashes)
+	if err != nil {
+		return nil, err
+	}
+	if expected := up.meta.GetExpectedHash(); expected != "" && expected != contentHash {
+		return nil, status.Errorf(codes.DataLoss, "content hash mismatch: expected %s, got %s", expected, contentHash)
+	}
+
+	var previous *domain.DatasetVersion
+	if !up.isNew {
+		previous, _ = s.store.Datasets().GetLatestVersion(ctx, up.dataset.ID)
+	}
+	if previous != nil && previous.HasContent() && previous.Content.Hash == contentHash && !up.meta.GetCreateVersion() {
+		resp := &services.UploadDatasetResponse{
+			Dataset:            datasetToProto(up.dataset),
+			BytesUploaded:      totalSize,
+			ChunksDeduplicated: deduplicated,
+			Version:            int32(previous.Number()),
+			Unchanged:          true,
+		}
+		withVersion(resp.Dataset, previous)
+		return resp, nil
+	}
+
+	check, err := s.checkContent(ctx, up.dataset, up.hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := up.meta.GetChunkSize()
+	if chunkSize == 0 {
+		chunkSize = up.sizes[0]
+	}
+
+	// Numbers continue from the latest version, so they are never reused
+	// after old versions are pruned
+	number := 1
+	if previous != nil {
+		number = previous.Number() + 1
+	}
+	version := &domain.DatasetVersion{
+		ID:        up.versionID,
+		DatasetID: up.dataset.ID,
+		Version:   domain.VersionString(number),
+		Content: &domain.DatasetContent{
+			Hash:       contentHash,
+			Size:       totalSize,
+			ChunkCount: len(up.hashes),
+			ChunkSize:  chunkSize,
+		},
+		CreatedBy: user.ID,
+		CreatedAt: time.Now().UTC(),
+		Message:   up.meta.GetVersionMessage(),
+	}
+	if previous != nil {
+		version.PreviousVersionID = previous.ID
+	}
+	check.annotate(version)
+
+	// The dataset, its version and chunks are written together, so a
+	// failure doesn't leave a version without chunks or a dataset pointing
+	// at a missing version
+	err = s.store.WithTx(ctx, func(ctx context.Context) error {
+		if up.isNew {
+			if err := s.store.Datasets().Create(ctx, up.dataset); err != nil {
+				return err
+			}
+		} else {
+			// Keep changes made to the dataset while the upload ran
+			current, err := s.store.Datasets().Get(ctx, up.dataset.ID)
+			if err != nil {
+				return err
+			}
+			up.dataset = current
+		}
+		if err := s.store.Datasets().CreateVersion(ctx, version); err != nil {
+			return err
+		}
+
+		for idx, h := range up.hashes {
+			if err := s.store.Datasets().CreateChunk(ctx, &domain.Chunk{
+				ID:        domain.ChunkID(uuid.New().String()),
+				DatasetID: up.dataset.ID,
+				VersionID: version.ID,
+				Index:     idx,
+				Hash:      h,
+				Size:      up.sizes[idx],
+				Status:    domain.ChunkStatusVerified,
+			}); err != nil {
+				return err
+			}
+		}
+
+		up.dataset.LatestVersionID = version.ID
+		up.dataset.VersionCount++
+		up.dataset.HasContent = true
+		up.dataset.UpdatedAt = time.Now().UTC()
+		return s.updateDataset(ctx, up.dataset)
+	})
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "UPLOAD", "dataset", string(up.dataset.ID), map[string]interface{}{
+			"version_id":   string(version.ID),
+			"size":         totalSize,
+			"chunks":       len(up.hashes),
+			"deduplicated": deduplicated,
+		})
+	}
+	s.auditSchemaViolations(ctx, up.dataset, version, check)
+
+	s.pruneVersions(ctx, up.dataset)
+
+	resp := &services.UploadDatasetResponse{
+		Dataset:            datasetToProto(up.dataset),
+		BytesUploaded:      totalSize,
+		ChunksCreated:      int32(len(up.hashes)) - deduplicated,
+		Version:            int32(number),
+		ChunksDeduplicated: deduplicated,
+	}
+	if check != nil {
+		resp.SchemaViolations = check.violations
+	}
+	withVersion(resp.Dataset, version)
+	return resp, nil
+}
+
+// reusableChunk reports whether the caller may use a stored chunk without
+// sending it: only if the blob belongs to a dataset the caller can already
+// read. Otherwise anyone knowing a hash could attach the content to their
+// own dataset and download it.
+func (s *Server) reusableChunk(ctx context.Context, readable *topicReader, hash string) (bool, error) {
+	exists, err := s.blobStore.Exists(ctx, hash)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	// Without its references the blob can't be shown to be readable, so
+	// the caller has to send it
+	meta, err := s.blobStore.GetMetadata(ctx, hash)
+	if err != nil {
+		return false, nil
+	}
+	for _, ref := range meta.References {
+		if readable.canDataset(domain.DatasetID(ref.DatasetID)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hashContent computes the SHA-256 of the chunks' content in order.
+func (s *Server) hashContent(ctx context.Context, hashes []string) (string, error) {
+	hasher := sha256.New()
+	for idx, h := range hashes {
+		if err := s.copyBlob(ctx, hasher, h); err != nil {
+			return "", status.Errorf(codes.Internal, "failed to read chunk %d: %v", idx, err)
+		}
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// copyBlob writes a blob's content to w.
+func (s *Server) copyBlob(ctx context.Context, w io.Writer, h string) error {
+	reader, err := s.blobStore.Get(ctx, h)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = io.Copy(w, reader)
+	return err
+}
@@ -0,0 +1,120 @@
+package dataset
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// chunkHashes splits data into chunks of chunkSize and returns their hex
+// SHA-256 hashes.
+func chunkHashes(data []byte, chunkSize int) []string {
+	var hashes []string
+	for start := 0; start < len(data); start += chunkSize {
+		sum := sha256.Sum256(data[start:min(start+chunkSize, len(data))])
+		hashes = append(hashes, hex.EncodeToString(sum[:]))
+	}
+	return hashes
+}
+
+// uploadManifest uploads a new dataset listing hashes as its chunks, and
+// sending only the chunks in send, by index.
+func uploadManifest(s *Server, user *domain.User, topic string, hashes []string, chunkSize int, send map[int][]byte) (*services.UploadDatasetResponse, error) {
+	reqs := []*services.UploadDatasetRequest{{Data: &services.UploadDatasetRequest_Metadata{
+		Metadata: &services.UploadMetadata{
+			TopicId:     topic,
+			Name:        "manifest upload " + time.Now().Format(time.RFC3339Nano),
+			ChunkHashes: hashes,
+			ChunkSize:   int64(chunkSize),
+		},
+	}}}
+	for idx, data := range send {
+		reqs = append(reqs, &services.UploadDatasetRequest{Data: &services.UploadDatasetRequest_IndexedChunk{
+			IndexedChunk: &services.UploadChunk{Index: int32(idx), Data: data},
+		}})
+	}
+
+	stream := &uploadStream{ctx: asUser(user), reqs: reqs}
+	err := s.UploadDataset(stream)
+	return stream.resp, err
+}
+
+func TestFindMissingChunks_CrossTopicClaim(t *testing.T) {
+	s := newTestServer(t)
+	secret := []byte("secret content, chunked")
+	uploadContent(t, s, testOwner, "secret", "secret data", secret, 8)
+	hashes := chunkHashes(secret, 8)
+
+	// testOther owns a topic of their own to upload to
+	now := time.Now().UTC()
+	if err := s.store.Topics().Create(context.Background(), &domain.Topic{
+		ID: "mine", Name: "mine", Status: domain.TopicStatusActive,
+		Owners: []domain.UserID{testOther.ID}, CreatedBy: testOther.ID, CreatedAt: now, UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	// The chunks are stored, but testOther can't read them, so they are
+	// reported missing rather than confirming they exist
+	resp, err := s.FindMissingChunks(asUser(testOther), &services.FindMissingChunksRequest{Hashes: hashes})
+	if err != nil {
+		t.Fatalf("FindMissingChunks() error = %v", err)
+	}
+	if len(resp.GetMissing()) != len(hashes) {
+		t.Errorf("FindMissingChunks() for a denied reader = %d missing, want %d", len(resp.GetMissing()), len(hashes))
+	}
+
+	// Claiming the hashes without sending the content fails
+	_, err = uploadManifest(s, testOther, "mine", hashes, 8, nil)
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Fatalf("upload claiming unreadable chunks: code = %v, want %v (err: %v)", got, codes.FailedPrecondition, err)
+	}
+
+	// Readers of the secret topic may reuse the chunks
+	resp, err = s.FindMissingChunks(asUser(testReader), &services.FindMissingChunksRequest{Hashes: hashes})
+	if err != nil {
+		t.Fatalf("FindMissingChunks() error = %v", err)
+	}
+	if len(resp.GetMissing()) != 0 {
+		t.Errorf("FindMissingChunks() for a reader = %v, want none missing", resp.GetMissing())
+	}
+	up, err := uploadManifest(s, testOwner, "open", hashes, 8, nil)
+	if err != nil {
+		t.Fatalf("upload reusing readable chunks: %v", err)
+	}
+	if up.GetChunksDeduplicated() != int32(len(hashes)) {
+		t.Errorf("ChunksDeduplicated = %d, want %d", up.GetChunksDeduplicated(), len(hashes))
+	}
+
+	// Sending the content works for anyone who may publish
+	send := make(map[int][]byte)
+	for idx := range hashes {
+		send[idx] = secret[idx*8 : min((idx+1)*8, len(secret))]
+	}
+	if _, err := uploadManifest(s, testOther, "mine", hashes, 8, send); err != nil {
+		t.Fatalf("upload sending the chunks: %v", err)
+	}
+}
+
+func TestUploadDataset_RepeatedChunk(t *testing.T) {
+	s := newTestServer(t)
+	chunk := []byte("same")
+	hash := chunkHashes(chunk, len(chunk))[0]
+
+	// A chunk repeated in the manifest is sent once
+	resp, err := uploadManifest(s, testOwner, "open", []string{hash, hash}, len(chunk), map[int][]byte{0: chunk})
+	if err != nil {
+		t.Fatalf("UploadDataset() error = %v", err)
+	}
+	if resp.GetBytesUploaded() != 2*int64(len(chunk)) {
+		t.Errorf("BytesUploaded = %d, want %d", resp.GetBytesUploaded(), 2*len(chunk))
+	}
+}
@@ -0,0 +1,346 @@
+package dataset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+	"bib/internal/storage/blob"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// revertedFromKey is the version metadata key recording the version a
+// revert restored.
+const revertedFromKey = "reverted_from"
+
+// GetDatasetVersions lists the versions of a dataset, newest first.
+func (s *Server) GetDatasetVersions(ctx context.Context, req *services.GetDatasetVersionsRequest) (*services.GetDatasetVersionsResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.GetDatasetId() == "" {
+		return nil, grpcerrors.NewValidationError("dataset_id is required", map[string]string{
+			"dataset_id": "must not be empty",
+		})
+	}
+
+	dataset, err := s.readableDataset(ctx, domain.DatasetID(req.GetDatasetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.store.Datasets().ListVersions(ctx, dataset.ID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	numbers := make(map[domain.DatasetVersionID]int, len(versions))
+	for _, v := range versions {
+		numbers[v.ID] = v.Number()
+	}
+
+	limit, offset := 50, 0
+	if req.Page != nil {
+		if req.Page.Limit > 0 {
+			limit = int(req.Page.Limit)
+		}
+		offset = int(req.Page.Offset)
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	page := versions[min(offset, len(versions)):min(offset+limit, len(versions))]
+
+	protoVersions := make([]*services.DatasetVersion, len(page))
+	for i, v := range page {
+		protoVersions[i] = versionToProto(v, numbers[v.PreviousVersionID])
+	}
+
+	return &services.GetDatasetVersionsResponse{
+		Versions: protoVersions,
+		PageInfo: &bibv1.PageInfo{
+			TotalCount: int64(len(versions)),
+			HasMore:    offset+len(page) < len(versions),
+			PageSize:   int32(len(page)),
+		},
+	}, nil
+}
+
+// GetVersion retrieves a numbered version of a dataset (0 = latest).
+func (s *Server) GetVersion(ctx context.Context, req *services.GetVersionRequest) (*services.GetVersionResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.GetDatasetId() == "" {
+		return nil, grpcerrors.NewValidationError("dataset_id is required", map[string]string{
+			"dataset_id": "must not be empty",
+		})
+	}
+
+	dataset, err := s.readableDataset(ctx, domain.DatasetID(req.GetDatasetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.resolveVersion(ctx, dataset.ID, req.GetVersion())
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	return &services.GetVersionResponse{
+		Version: s.protoVersion(ctx, version),
+	}, nil
+}
+
+// RevertToVersion records a new version with the content of an earlier
+// one. The new version shares the earlier version's blobs, so reverting
+// stores no content.
+func (s *Server) RevertToVersion(ctx context.Context, req *services.RevertToVersionRequest) (*services.RevertToVersionResponse, error) {
+	if s.store == nil || s.blobStore == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	violations := make(map[string]string)
+	if req.GetDatasetId() == "" {
+		violations["dataset_id"] = "must not be empty"
+	}
+	if req.GetVersion() <= 0 {
+		violations["version"] = "must be positive"
+	}
+	if len(violations) > 0 {
+		return nil, grpcerrors.NewValidationError("invalid revert request", violations)
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	dataset, err := s.store.Datasets().Get(ctx, domain.DatasetID(req.GetDatasetId()))
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	if !dataset.IsOwner(user.ID) && user.Role != domain.UserRoleAdmin {
+		return nil, grpcerrors.NewPermissionDeniedError("revert", "dataset", "owner")
+	}
+	if err := checkRevision(dataset, req.GetExpectedRevision()); err != nil {
+		return nil, err
+	}
+
+	target, err := s.resolveVersion(ctx, dataset.ID, req.GetVersion())
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	latest, err := s.store.Datasets().GetLatestVersion(ctx, dataset.ID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	if target.ID == latest.ID {
+		return nil, grpcerrors.NewValidationError("version is already the latest", map[string]string{
+			"version": fmt.Sprintf("version %d is the latest version", target.Number()),
+		})
+	}
+
+	chunks, err := s.store.Datasets().ListChunks(ctx, target.ID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].Index < chunks[j].Index })
+
+	// The restored content must match the schema as it is now
+	var check *schemaCheck
+	if target.HasContent() {
+		hashes := make([]string, len(chunks))
+		for i, c := range chunks {
+			hashes[i] = c.Hash
+		}
+		if check, err = s.checkContent(ctx, dataset, hashes); err != nil {
+			return nil, err
+		}
+	}
+
+	message := req.GetMessage()
+	if message == "" {
+		message = fmt.Sprintf("Revert to version %d", target.Number())
+	}
+
+	number := latest.Number() + 1
+	version := &domain.DatasetVersion{
+		ID:                domain.DatasetVersionID(uuid.New().String()),
+		DatasetID:         dataset.ID,
+		Version:           domain.VersionString(number),
+		PreviousVersionID: latest.ID,
+		Content:           target.Content,
+		Instructions:      target.Instructions,
+		TableSchema:       target.TableSchema,
+		CreatedBy:         user.ID,
+		CreatedAt:         time.Now().UTC(),
+		Message:           message,
+		Metadata:          map[string]string{revertedFromKey: strconv.Itoa(target.Number())},
+	}
+	check.annotate(version)
+
+	// Blob references live outside the database, so those added are
+	// released again if the transaction is rolled back
+	var referenced []*domain.Chunk
+	err = s.store.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.store.Datasets().CreateVersion(ctx, version); err != nil {
+			return err
+		}
+
+		for _, c := range chunks {
+			chunk := &domain.Chunk{
+				ID:        domain.ChunkID(uuid.New().String()),
+				DatasetID: dataset.ID,
+				VersionID: version.ID,
+				Index:     c.Index,
+				Hash:      c.Hash,
+				Size:      c.Size,
+				Status:    domain.ChunkStatusVerified,
+			}
+			if err := blob.AddReference(ctx, s.blobStore, c.Hash, blob.Reference{
+				DatasetID:  string(dataset.ID),
+				VersionID:  string(version.ID),
+				ChunkIndex: c.Index,
+			}); err != nil {
+				return status.Errorf(codes.Internal, "failed to reference chunk %d: %v", c.Index, err)
+			}
+			referenced = append(referenced, chunk)
+			if err := s.store.Datasets().CreateChunk(ctx, chunk); err != nil {
+				return err
+			}
+		}
+
+		dataset.LatestVersionID = version.ID
+		dataset.VersionCount++
+		dataset.HasContent = version.HasContent()
+		dataset.HasInstructions = version.HasInstructions()
+		dataset.UpdatedAt = time.Now().UTC()
+		return s.updateDataset(ctx, dataset)
+	})
+	if err != nil {
+		s.releaseChunkBlobs(ctx, referenced)
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "UPDATE", "dataset", string(dataset.ID), map[string]interface{}{
+			"version_id":    string(version.ID),
+			"reverted_from": target.Number(),
+		})
+	}
+	s.auditSchemaViolations(ctx, dataset, version, check)
+
+	s.pruneVersions(ctx, dataset)
+
+	resp := &services.RevertToVersionResponse{
+		Dataset: datasetToProto(dataset),
+		Version: versionToProto(version, latest.Number()),
+	}
+	withVersion(resp.Dataset, version)
+	return resp, nil
+}
+
+// pruneVersions removes the versions of a dataset the retention policy no
+// longer keeps and releases their blobs. Pruning is best effort: a version
+// that cannot be removed is retried with the next new version.
+func (s *Server) pruneVersions(ctx context.Context, dataset *domain.Dataset) {
+	if s.retention == (domain.VersionRetention{}) {
+		return
+	}
+
+	versions, err := s.store.Datasets().ListVersions(ctx, dataset.ID)
+	if err != nil {
+		return
+	}
+
+	removed := 0
+	for _, v := range s.retention.Expired(versions, time.Now()) {
+		// Read the chunk list while it still exists
+		chunks, err := s.store.Datasets().ListChunks(ctx, v.ID)
+		if err != nil {
+			continue
+		}
+		if err := s.store.Datasets().DeleteVersion(ctx, dataset.ID, v.ID); err != nil {
+			continue
+		}
+		if s.blobStore != nil {
+			s.releaseChunkBlobs(ctx, chunks)
+		}
+		removed++
+
+		if s.auditLogger != nil {
+			_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "dataset_version", string(v.ID), map[string]interface{}{
+				"dataset_id": string(dataset.ID),
+				"version":    v.Number(),
+				"reason":     "retention",
+			})
+		}
+	}
+
+	if removed > 0 {
+		dataset.VersionCount = max(0, dataset.VersionCount-removed)
+		_ = s.store.Datasets().Update(ctx, dataset)
+	}
+}
+
+// versionToProto converts a version. parent is the number of the version
+// it followed, 0 if none.
+func versionToProto(v *domain.DatasetVersion, parent int) *services.DatasetVersion {
+	pv := &services.DatasetVersion{
+		Id:            string(v.ID),
+		Version:       int32(v.Number()),
+		DatasetId:     string(v.DatasetID),
+		CreatedAt:     timestamppb.New(v.CreatedAt),
+		CreatedBy:     string(v.CreatedBy),
+		Message:       v.Message,
+		ParentVersion: int32(parent),
+	}
+	if v.HasContent() {
+		pv.Size = v.Content.Size
+		pv.Hash = v.Content.Hash
+		pv.ChunkCount = int32(v.Content.ChunkCount)
+	}
+	if from, err := strconv.Atoi(v.Metadata[revertedFromKey]); err == nil {
+		pv.RevertedFrom = int32(from)
+	}
+	return pv
+}
+
+// protoVersion converts a version, looking up the number of the version it
+// followed.
+func (s *Server) protoVersion(ctx context.Context, v *domain.DatasetVersion) *services.DatasetVersion {
+	parent := 0
+	if v.PreviousVersionID != "" {
+		if prev, err := s.store.Datasets().GetVersion(ctx, v.DatasetID, v.PreviousVersionID); err == nil {
+			parent = prev.Number()
+		}
+	}
+	return versionToProto(v, parent)
+}
+
+// withVersion fills in the content fields of a dataset from one of its
+// versions.
+func withVersion(d *services.Dataset, v *domain.DatasetVersion) {
+	d.Version = int32(v.Number())
+	if v.HasContent() {
+		d.Size = v.Content.Size
+		d.Hash = v.Content.Hash
+		d.ChunkCount = int32(v.Content.ChunkCount)
+		d.ChunkSize = v.Content.ChunkSize
+	}
+	d.SchemaStatus = v.Metadata[schemaStatusKey]
+}
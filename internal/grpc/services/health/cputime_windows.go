@@ -0,0 +1,26 @@
+//go:build windows
+
+package health
+
+import (
+	"syscall"
+	"time"
+)
+
+// processCPUTime returns the CPU time used by the process so far.
+func processCPUTime() time.Duration {
+	handle, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return 0
+	}
+	var creation, exit, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(handle, &creation, &exit, &kernel, &user); err != nil {
+		return 0
+	}
+	// Filetimes count 100ns intervals
+	return time.Duration(filetimeTicks(kernel)+filetimeTicks(user)) * 100
+}
+
+func filetimeTicks(ft syscall.Filetime) int64 {
+	return int64(ft.HighDateTime)<<32 | int64(ft.LowDateTime)
+}
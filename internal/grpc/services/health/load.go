@@ -0,0 +1,177 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bib/internal/grpc/interfaces"
+)
+
+// LoadTargets are the load levels above which the daemon is overloaded.
+// A zero target is not checked.
+type LoadTargets struct {
+	// CPUPercent is the CPU use, as a percentage of the available CPUs.
+	CPUPercent float64
+
+	// MemoryBytes is the memory held by the daemon.
+	MemoryBytes uint64
+
+	// InFlight is the number of calls handled at once.
+	InFlight int64
+}
+
+// LoadMonitor samples the load of the daemon: the CPU and memory it uses
+// and the number of calls in flight. It implements interfaces.LoadSignal.
+type LoadMonitor struct {
+	targets  LoadTargets
+	interval time.Duration
+	inFlight atomic.Int64
+
+	mu      sync.RWMutex
+	status  interfaces.LoadStatus
+	lastCPU time.Duration
+	lastAt  time.Time
+
+	// Sources of the samples; tests replace them
+	cpuTime func() time.Duration
+	memory  func() uint64
+	now     func() time.Time
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewLoadMonitor creates a monitor sampling the load every interval.
+func NewLoadMonitor(targets LoadTargets, interval time.Duration) *LoadMonitor {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	m := &LoadMonitor{
+		targets:  targets,
+		interval: interval,
+		cpuTime:  processCPUTime,
+		memory:   processMemory,
+		now:      time.Now,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+	m.lastCPU, m.lastAt = m.cpuTime(), m.now()
+	return m
+}
+
+// Interval returns how often the load is sampled.
+func (m *LoadMonitor) Interval() time.Duration {
+	return m.interval
+}
+
+// Start begins sampling the load in the background.
+func (m *LoadMonitor) Start(ctx context.Context) {
+	go m.run(ctx)
+}
+
+// Stop stops sampling the load.
+func (m *LoadMonitor) Stop() {
+	close(m.stopCh)
+	<-m.doneCh
+}
+
+func (m *LoadMonitor) run(ctx context.Context) {
+	defer close(m.doneCh)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.Sample()
+		}
+	}
+}
+
+// Sample measures the load now and returns it.
+func (m *LoadMonitor) Sample() interfaces.LoadStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now, cpu := m.now(), m.cpuTime()
+	status := interfaces.LoadStatus{
+		MemoryBytes: m.memory(),
+		InFlight:    m.inFlight.Load(),
+		Timestamp:   now,
+	}
+	if elapsed := now.Sub(m.lastAt); elapsed > 0 {
+		status.CPUPercent = 100 * float64(cpu-m.lastCPU) / float64(elapsed) / float64(runtime.GOMAXPROCS(0))
+	}
+	m.lastCPU, m.lastAt = cpu, now
+
+	if m.targets.CPUPercent > 0 && status.CPUPercent > m.targets.CPUPercent {
+		status.Overloaded = append(status.Overloaded, "cpu")
+	}
+	if m.targets.MemoryBytes > 0 && status.MemoryBytes > m.targets.MemoryBytes {
+		status.Overloaded = append(status.Overloaded, "memory")
+	}
+	if m.targets.InFlight > 0 && status.InFlight > m.targets.InFlight {
+		status.Overloaded = append(status.Overloaded, "in_flight")
+	}
+
+	m.status = status
+	return status
+}
+
+// Load returns the latest sample, with the current number of calls in flight.
+func (m *LoadMonitor) Load() interfaces.LoadStatus {
+	m.mu.RLock()
+	status := m.status
+	m.mu.RUnlock()
+
+	status.InFlight = m.inFlight.Load()
+	return status
+}
+
+// TrackCall counts a call as in flight until the returned func is called.
+func (m *LoadMonitor) TrackCall() func() {
+	m.inFlight.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() { m.inFlight.Add(-1) })
+	}
+}
+
+// checkLoadHealth reports the latest load sample. Being overloaded does not
+// make the daemon unhealthy: it sheds load instead.
+func (s *Server) checkLoadHealth(monitor *LoadMonitor) (interfaces.ComponentHealthStatus, bool) {
+	load := monitor.Load()
+	if load.Timestamp.IsZero() {
+		return interfaces.ComponentHealthStatus{}, false
+	}
+
+	message := fmt.Sprintf("cpu %.0f%%, memory %d MiB, %d calls in flight",
+		load.CPUPercent, load.MemoryBytes>>20, load.InFlight)
+	if len(load.Overloaded) > 0 {
+		message = "overloaded (" + strings.Join(load.Overloaded, ", ") + "), shedding load: " + message
+	}
+
+	return interfaces.ComponentHealthStatus{
+		Name:      "load",
+		Healthy:   len(load.Overloaded) == 0,
+		Message:   message,
+		LastCheck: load.Timestamp,
+	}, true
+}
+
+// processMemory returns the memory the Go runtime holds from the OS.
+func processMemory() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Sys - stats.HeapReleased
+}
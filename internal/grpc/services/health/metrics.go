@@ -0,0 +1,149 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/grpc/interfaces"
+	"bib/internal/metrics"
+	"bib/internal/storage"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// storagePingTimeout bounds the database ping and blob statistics of a
+// scrape.
+const storagePingTimeout = 2 * time.Second
+
+var (
+	storageUpDesc = prometheus.NewDesc(metrics.StorageUp,
+		"Whether the database answers a ping (1) or not (0).", nil, nil)
+	connectedPeersDesc = prometheus.NewDesc(metrics.P2PConnectedPeers,
+		"Number of connected libp2p peers.", nil, nil)
+	hasQuorumDesc = prometheus.NewDesc(metrics.ClusterHasQuorum,
+		"Whether the Raft cluster has quorum (1) or not (0).", nil, nil)
+	clockSkewDesc = prometheus.NewDesc(metrics.ClusterClockSkew,
+		"Largest absolute clock skew to a cluster member in seconds.", nil, nil)
+	certificateExpiryDesc = prometheus.NewDesc(metrics.CertificateExpiry,
+		"Expiry time of a TLS certificate in seconds since the epoch.", []string{metrics.CertificateLabel}, nil)
+	syncLagDesc = prometheus.NewDesc(metrics.P2PSyncLag,
+		"Seconds since the connected peer synced least recently was synced.", nil, nil)
+	syncBytesDesc = prometheus.NewDesc(metrics.P2PSyncBytes,
+		"Catalog bytes received by full replica sync.", []string{metrics.SyncKindLabel}, nil)
+	storageConnectionsDesc = prometheus.NewDesc(metrics.StorageConnections,
+		"Number of open database connections by state.", []string{metrics.ConnectionStateLabel}, nil)
+	storageMaxConnectionsDesc = prometheus.NewDesc(metrics.StorageMaxConnections,
+		"Maximum number of open database connections.", nil, nil)
+	blobStoredBytesDesc = prometheus.NewDesc(metrics.BlobStoredBytes,
+		"Size of the blobs as stored, after compression, in bytes.", nil, nil)
+	blobBytesDesc = prometheus.NewDesc(metrics.BlobBytes,
+		"Size of the blobs before compression in bytes.", nil, nil)
+	blobCountDesc = prometheus.NewDesc(metrics.BlobCount,
+		"Number of blobs.", nil, nil)
+)
+
+// collector exports the node gauges of the health service's provider,
+// sampled on every scrape.
+type collector struct {
+	s *Server
+}
+
+// Collector returns a Prometheus collector for the node's storage, blob,
+// P2P, sync, cluster and certificate state. Gauges of disabled components
+// are not exported.
+func (s *Server) Collector() prometheus.Collector {
+	return collector{s: s}
+}
+
+// Describe implements prometheus.Collector.
+func (c collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storageUpDesc
+	ch <- connectedPeersDesc
+	ch <- hasQuorumDesc
+	ch <- clockSkewDesc
+	ch <- certificateExpiryDesc
+	ch <- syncLagDesc
+	ch <- syncBytesDesc
+	ch <- storageConnectionsDesc
+	ch <- storageMaxConnectionsDesc
+	ch <- blobStoredBytesDesc
+	ch <- blobBytesDesc
+	ch <- blobCountDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c collector) Collect(ch chan<- prometheus.Metric) {
+	c.s.mu.RLock()
+	provider := c.s.provider
+	blobs := c.s.blobs
+	c.s.mu.RUnlock()
+	if provider == nil || !provider.IsRunning() {
+		return
+	}
+
+	storageUp := 0.0
+	if store := provider.Store(); store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), storagePingTimeout)
+		if store.Ping(ctx) == nil {
+			storageUp = 1
+		}
+		cancel()
+
+		if pool, ok := store.(storage.ConnectionStatsProvider); ok {
+			stats := pool.ConnectionStats()
+			ch <- prometheus.MustNewConstMetric(storageConnectionsDesc, prometheus.GaugeValue, float64(stats.InUse), "in_use")
+			ch <- prometheus.MustNewConstMetric(storageConnectionsDesc, prometheus.GaugeValue, float64(stats.Idle), "idle")
+			if stats.Max > 0 {
+				ch <- prometheus.MustNewConstMetric(storageMaxConnectionsDesc, prometheus.GaugeValue, float64(stats.Max))
+			}
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(storageUpDesc, prometheus.GaugeValue, storageUp)
+
+	if blobs != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), storagePingTimeout)
+		stats, err := blobs.Stats(ctx)
+		cancel()
+		if err == nil {
+			ch <- prometheus.MustNewConstMetric(blobStoredBytesDesc, prometheus.GaugeValue, float64(stats.TotalSizeCompressed))
+			ch <- prometheus.MustNewConstMetric(blobBytesDesc, prometheus.GaugeValue, float64(stats.TotalSize))
+			ch <- prometheus.MustNewConstMetric(blobCountDesc, prometheus.GaugeValue, float64(stats.TotalBlobs))
+		}
+	}
+
+	cfg := provider.HealthConfig()
+	if host := provider.P2PHost(); cfg.P2PEnabled && host != nil {
+		ch <- prometheus.MustNewConstMetric(connectedPeersDesc, prometheus.GaugeValue, float64(host.ConnectedPeersCount()))
+	}
+	if cl := provider.Cluster(); cfg.ClusterEnabled && cl != nil {
+		ch <- prometheus.MustNewConstMetric(hasQuorumDesc, prometheus.GaugeValue, boolToFloat(cl.Status().HasQuorum))
+
+		skew := 0.0
+		if max := cl.ClockSkew().Max; max != nil {
+			skew = max.Skew.Abs().Seconds()
+		}
+		ch <- prometheus.MustNewConstMetric(clockSkewDesc, prometheus.GaugeValue, skew)
+	}
+
+	if certs, ok := provider.(interfaces.CertificateProvider); ok {
+		for name, expiry := range certs.CertificateExpiry() {
+			ch <- prometheus.MustNewConstMetric(certificateExpiryDesc, prometheus.GaugeValue, float64(expiry.Unix()), name)
+		}
+	}
+
+	if sp, ok := provider.(interfaces.SyncStatsProvider); ok && cfg.P2PEnabled {
+		if stats, ok := sp.SyncStats(); ok {
+			ch <- prometheus.MustNewConstMetric(syncLagDesc, prometheus.GaugeValue, stats.Lag.Seconds())
+			for kind, n := range stats.BytesTransferred {
+				ch <- prometheus.MustNewConstMetric(syncBytesDesc, prometheus.CounterValue, float64(n), kind)
+			}
+		}
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -4,11 +4,14 @@ package health
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/cluster"
 	"bib/internal/grpc/interfaces"
+	"bib/internal/storage/blob"
 	"bib/internal/version"
 
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -21,6 +24,8 @@ type Server struct {
 
 	mu       sync.RWMutex
 	provider interfaces.HealthProvider
+	load     *LoadMonitor
+	blobs    blob.Store
 	started  time.Time
 }
 
@@ -39,10 +44,26 @@ func (s *Server) SetProvider(provider interfaces.HealthProvider) {
 	s.provider = provider
 }
 
+// SetLoadMonitor sets the load monitor reported as the "load" component.
+func (s *Server) SetLoadMonitor(monitor *LoadMonitor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load = monitor
+}
+
+// SetBlobStore sets the blob store whose utilization is exported as
+// metrics.
+func (s *Server) SetBlobStore(store blob.Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs = store
+}
+
 // Check performs a health check.
 func (s *Server) Check(ctx context.Context, req *services.HealthCheckRequest) (*services.HealthCheckResponse, error) {
 	s.mu.RLock()
 	provider := s.provider
+	load := s.load
 	s.mu.RUnlock()
 
 	resp := &services.HealthCheckResponse{
@@ -107,6 +128,18 @@ func (s *Server) Check(ctx context.Context, req *services.HealthCheckRequest) (*
 		}
 	}
 
+	// Report the load if it is monitored
+	if load != nil {
+		if loadHealth, ok := s.checkLoadHealth(load); ok {
+			resp.Components["load"] = componentStatusToProto(loadHealth)
+		}
+	}
+
+	// Report whether background maintenance is paused
+	if mp, ok := provider.(interfaces.MaintenanceProvider); ok {
+		resp.Components["maintenance"] = componentStatusToProto(checkMaintenanceHealth(mp))
+	}
+
 	if !allHealthy {
 		resp.Status = services.ServingStatus_SERVING_STATUS_NOT_SERVING
 	}
@@ -114,6 +147,57 @@ func (s *Server) Check(ctx context.Context, req *services.HealthCheckRequest) (*
 	return resp, nil
 }
 
+// Ready reports whether the daemon is running and all enabled components
+// are healthy, as the SERVING status of Check, and otherwise names what is
+// not ready yet: "daemon" while it is still starting, or the components.
+func (s *Server) Ready(ctx context.Context) (bool, []string) {
+	resp, err := s.Check(ctx, &services.HealthCheckRequest{})
+	if err != nil {
+		return false, []string{"daemon"}
+	}
+	if resp.Status == services.ServingStatus_SERVING_STATUS_SERVING {
+		return true, nil
+	}
+
+	var pending []string
+	for _, name := range []string{"storage", "p2p", "cluster"} {
+		if c, ok := resp.Components[name]; ok && c.Status != services.ServingStatus_SERVING_STATUS_SERVING {
+			pending = append(pending, name)
+		}
+	}
+	if len(pending) == 0 {
+		pending = []string{"daemon"}
+	}
+	return false, pending
+}
+
+// checkMaintenanceHealth reports whether background maintenance is paused.
+// Pausing is deliberate, so it does not make the daemon unhealthy.
+func checkMaintenanceHealth(provider interfaces.MaintenanceProvider) interfaces.ComponentHealthStatus {
+	state := provider.MaintenanceState()
+
+	message := "running"
+	if state.Paused {
+		message = "paused since " + state.PausedAt.Format(time.RFC3339)
+		if state.PausedBy != "" {
+			message += " by " + state.PausedBy
+		}
+		if state.Reason != "" {
+			message += ": " + state.Reason
+		}
+		if len(state.Waiting) > 0 {
+			message += " (holding " + strings.Join(state.Waiting, ", ") + ")"
+		}
+	}
+
+	return interfaces.ComponentHealthStatus{
+		Name:      "maintenance",
+		Healthy:   true,
+		Message:   message,
+		LastCheck: time.Now(),
+	}
+}
+
 // Watch streams health status changes.
 func (s *Server) Watch(req *services.HealthCheckRequest, stream services.HealthService_WatchServer) error {
 	// Send initial status
@@ -227,6 +311,58 @@ func (s *Server) Ping(ctx context.Context, req *services.PingRequest) (*services
 	}, nil
 }
 
+// GetVersion returns the daemon version, API version and features, and
+// whether the calling client's API version is supported.
+func (s *Server) GetVersion(ctx context.Context, req *services.GetDaemonVersionRequest) (*services.GetDaemonVersionResponse, error) {
+	s.mu.RLock()
+	provider := s.provider
+	s.mu.RUnlock()
+
+	info := version.Get()
+
+	features := append([]string(nil), version.Features...)
+	if provider != nil {
+		cfg := provider.HealthConfig()
+		if cfg.P2PEnabled {
+			features = append(features, "p2p")
+		}
+		if cfg.ClusterEnabled {
+			features = append(features, "cluster")
+		}
+		if cfg.BandwidthMetering {
+			features = append(features, "bandwidth_metering")
+		}
+	}
+
+	resp := &services.GetDaemonVersionResponse{
+		Version:          info.Version,
+		Commit:           info.Commit,
+		ApiVersion:       version.APIVersion,
+		MinApiVersion:    version.MinAPIVersion,
+		Features:         features,
+		GoVersion:        info.GoVersion,
+		Os:               info.OS,
+		Arch:             info.Arch,
+		DevMode:          info.DevMode,
+		ClientCompatible: true,
+	}
+	if !info.BuildTime.IsZero() {
+		resp.BuildTime = timestamppb.New(info.BuildTime)
+	}
+
+	if req.ClientApiVersion != "" {
+		warning, err := version.CheckAPICompatibility(req.ClientApiVersion, version.APIVersion, version.MinAPIVersion)
+		if err != nil {
+			resp.ClientCompatible = false
+			resp.CompatibilityMessage = err.Error()
+		} else {
+			resp.CompatibilityMessage = warning
+		}
+	}
+
+	return resp, nil
+}
+
 // checkStorageHealth checks the storage component health.
 func (s *Server) checkStorageHealth(ctx context.Context, provider interfaces.HealthProvider) interfaces.ComponentHealthStatus {
 	status := interfaces.ComponentHealthStatus{
@@ -236,6 +372,25 @@ func (s *Server) checkStorageHealth(ctx context.Context, provider interfaces.Hea
 		SubComponents: make(map[string]interfaces.ComponentHealthStatus),
 	}
 
+	// Report the runtime managed PostgreSQL runs on
+	if rp, ok := provider.(interfaces.StorageRuntimeProvider); ok {
+		if rt := rp.StorageRuntime(); rt != "" {
+			status.SubComponents["runtime"] = interfaces.ComponentHealthStatus{
+				Name:      "runtime",
+				Healthy:   true,
+				Message:   rt,
+				LastCheck: time.Now(),
+			}
+		}
+	}
+
+	// Report the last failover of managed PostgreSQL
+	if fp, ok := provider.(interfaces.StorageFailoverProvider); ok {
+		if failover, ok := fp.StorageFailover(); ok {
+			status.SubComponents["failover"] = checkFailoverHealth(failover)
+		}
+	}
+
 	store := provider.Store()
 	if store == nil {
 		status.Message = "storage not initialized"
@@ -269,6 +424,28 @@ func (s *Server) checkStorageHealth(ctx context.Context, provider interfaces.Hea
 	return status
 }
 
+// checkFailoverHealth reports the last failover of managed PostgreSQL. A
+// successful failover is healthy: the store already moved to the standby.
+func checkFailoverHealth(failover interfaces.FailoverStatus) interfaces.ComponentHealthStatus {
+	status := interfaces.ComponentHealthStatus{
+		Name:      "failover",
+		Healthy:   failover.Error == "",
+		LastCheck: time.Now(),
+	}
+
+	at := failover.At.Format(time.RFC3339)
+	if failover.Error != "" {
+		status.Message = fmt.Sprintf("failover from %s failed at %s: %s", failover.From, at, failover.Error)
+	} else {
+		status.Message = fmt.Sprintf("failed over from %s to %s at %s", failover.From, failover.To, at)
+	}
+	if failover.Count > 1 {
+		status.Message += fmt.Sprintf(" (%d failovers)", failover.Count)
+	}
+
+	return status
+}
+
 // checkP2PHealth checks the P2P component health.
 func (s *Server) checkP2PHealth(ctx context.Context, provider interfaces.HealthProvider) interfaces.ComponentHealthStatus {
 	status := interfaces.ComponentHealthStatus{
@@ -386,6 +563,28 @@ func (s *Server) checkClusterHealth(ctx context.Context, provider interfaces.Hea
 		LastCheck: time.Now(),
 	}
 
+	// Clock skew is a warning: it does not stop the cluster by itself,
+	// but leases and certificate validity depend on synchronized clocks
+	status.SubComponents["clock"] = checkClockSkew(cluster.ClockSkew())
+
+	return status
+}
+
+// checkClockSkew reports the largest clock skew to a cluster member.
+func checkClockSkew(skew cluster.ClockSkewStatus) interfaces.ComponentHealthStatus {
+	status := interfaces.ComponentHealthStatus{
+		Name:      "clock",
+		Healthy:   !skew.Exceeded,
+		Message:   "no skew measured",
+		LastCheck: time.Now(),
+	}
+	if skew.Max != nil {
+		status.Message = "max skew " + skew.Max.Skew.Round(time.Millisecond).String() + " to " + skew.Max.NodeID
+		if skew.Exceeded {
+			status.Message += ", exceeds " + skew.Threshold.String() + "; check NTP on the cluster nodes"
+		}
+	}
+
 	return status
 }
 
@@ -484,6 +683,13 @@ func (s *Server) getClusterInfo(provider interfaces.HealthProvider) *services.Cl
 		info.State = "no_quorum"
 	}
 
+	skew := cluster.ClockSkew()
+	if skew.Max != nil {
+		info.MaxClockSkew = durationpb.New(skew.Max.Skew)
+		info.MaxClockSkewNode = skew.Max.NodeID
+	}
+	info.ClockSkewExceeded = skew.Exceeded
+
 	return info
 }
 
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/p2p"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LabelController changes the labels of the running node. It is
+// implemented by the daemon, which pushes them to the connected peers and
+// persists them to the config file.
+type LabelController interface {
+	NodeLabels() (map[string]string, error)
+	SetNodeLabels(ctx context.Context, labels map[string]string) (persisted bool, err error)
+}
+
+// SetLabelController sets the controller of the node's labels.
+func (s *Server) SetLabelController(lc LabelController) {
+	s.labels = lc
+}
+
+// SetLabels changes the node's labels at runtime.
+func (s *Server) SetLabels(ctx context.Context, req *services.SetLabelsRequest) (*services.SetLabelsResponse, error) {
+	if s.labels == nil {
+		return nil, status.Error(codes.Unavailable, "labels not available")
+	}
+
+	current, err := s.labels.NodeLabels()
+	if err != nil {
+		return nil, labelError(err)
+	}
+
+	labels := make(map[string]string)
+	if !req.Replace {
+		maps.Copy(labels, current)
+	}
+	maps.Copy(labels, req.Labels)
+	for _, key := range req.Remove {
+		delete(labels, key)
+	}
+
+	if err := p2p.ValidateLabels(labels); err != nil {
+		return nil, grpcerrors.NewValidationError("invalid labels", map[string]string{
+			"labels": err.Error(),
+		})
+	}
+
+	persisted, err := s.labels.SetNodeLabels(ctx, labels)
+	if err != nil {
+		return nil, labelError(err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "UPDATE", "node", "set_labels", map[string]interface{}{
+			"previous_labels": current,
+			"labels":          labels,
+			"persisted":       persisted,
+		})
+	}
+
+	return &services.SetLabelsResponse{
+		Labels:    labels,
+		Persisted: persisted,
+	}, nil
+}
+
+func labelError(err error) error {
+	if errors.Is(err, p2p.ErrP2PDisabled) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "failed to set labels: %v", err)
+}
@@ -0,0 +1,75 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/p2p"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MessageSender delivers direct messages to peers. It is implemented by
+// p2p.Messenger.
+type MessageSender interface {
+	Send(ctx context.Context, to peer.ID, kind string, payload []byte) (p2p.MessageAck, error)
+}
+
+// SetMessageSender sets the sender of direct messages.
+func (s *Server) SetMessageSender(ms MessageSender) {
+	s.messages = ms
+}
+
+// SendMessage delivers a message to a peer and returns its acknowledgment.
+// A rejection by the peer is reported in the response, not as an error.
+func (s *Server) SendMessage(ctx context.Context, req *services.SendMessageRequest) (*services.SendMessageResponse, error) {
+	if s.messages == nil {
+		return nil, status.Error(codes.Unavailable, "direct messaging not available")
+	}
+
+	peerID, err := peer.Decode(req.PeerId)
+	if err != nil {
+		return nil, grpcerrors.NewValidationError("invalid peer_id", map[string]string{
+			"peer_id": "must be a valid peer ID",
+		})
+	}
+
+	ack, err := s.messages.Send(ctx, peerID, req.Kind, req.Payload)
+	var rejected *p2p.MessageRejectedError
+	switch {
+	case err == nil, errors.As(err, &rejected):
+	case errors.Is(err, p2p.ErrPayloadTooLarge):
+		return nil, grpcerrors.NewValidationError("payload too large", map[string]string{
+			"payload": err.Error(),
+		})
+	case errors.Is(err, p2p.ErrMessagingDisabled):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, status.Errorf(codes.DeadlineExceeded, "message not acknowledged after %d attempts", ack.Attempts)
+	case errors.Is(err, context.Canceled):
+		return nil, status.Error(codes.Canceled, err.Error())
+	default:
+		return nil, status.Errorf(codes.Unavailable, "failed to deliver message: %v", err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "CREATE", "message", ack.MessageID, map[string]interface{}{
+			"peer_id":  req.PeerId,
+			"kind":     req.Kind,
+			"size":     len(req.Payload),
+			"accepted": ack.Accepted,
+			"attempts": ack.Attempts,
+		})
+	}
+
+	return &services.SendMessageResponse{
+		MessageId: ack.MessageID,
+		Accepted:  ack.Accepted,
+		Reason:    ack.Reason,
+		Attempts:  int32(ack.Attempts),
+	}, nil
+}
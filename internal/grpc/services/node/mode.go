@@ -0,0 +1,76 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	services "bib/api/gen/go/bib/v1/services"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/p2p"
+	"bib/internal/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ModeChange is the outcome of a P2P mode switch.
+type ModeChange struct {
+	Previous  p2p.NodeMode
+	Mode      p2p.NodeMode
+	Persisted bool
+}
+
+// ModeController switches the P2P mode of the running node. It is
+// implemented by the daemon, which checks the storage backend supports the
+// mode and persists it to the config file.
+type ModeController interface {
+	SetNodeMode(ctx context.Context, mode p2p.NodeMode, opts p2p.ModeChangeOptions) (ModeChange, error)
+}
+
+// SetModeController sets the controller of the P2P mode.
+func (s *Server) SetModeController(mc ModeController) {
+	s.modeController = mc
+}
+
+// SetMode switches the node's P2P mode at runtime.
+func (s *Server) SetMode(ctx context.Context, req *services.SetModeRequest) (*services.SetModeResponse, error) {
+	if s.modeController == nil {
+		return nil, status.Error(codes.Unavailable, "mode switching not available")
+	}
+
+	mode, err := p2p.ParseNodeMode(req.Mode)
+	if err != nil {
+		return nil, grpcerrors.NewValidationError("invalid mode", map[string]string{
+			"mode": "must be proxy, selective or full",
+		})
+	}
+
+	change, err := s.modeController.SetNodeMode(ctx, mode, p2p.ModeChangeOptions{
+		PurgeReplica: req.PurgeReplica,
+	})
+	if err != nil {
+		var backendErr *storage.ModeBackendError
+		switch {
+		case errors.As(err, &backendErr), errors.Is(err, p2p.ErrP2PDisabled):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to switch mode: %v", err)
+		}
+	}
+
+	if change.Previous != change.Mode && s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "UPDATE", "node", "set_mode", map[string]interface{}{
+			"previous_mode": string(change.Previous),
+			"mode":          string(change.Mode),
+			"purge_replica": req.PurgeReplica,
+			"persisted":     change.Persisted,
+		})
+	}
+
+	return &services.SetModeResponse{
+		PreviousMode: string(change.Previous),
+		Mode:         string(change.Mode),
+		Changed:      change.Previous != change.Mode,
+		Persisted:    change.Persisted,
+	}, nil
+}
@@ -3,6 +3,7 @@ package node
 
 import (
 	"context"
+	"sort"
 
 	bibv1 "bib/api/gen/go/bib/v1"
 	services "bib/api/gen/go/bib/v1/services"
@@ -21,16 +22,21 @@ type Config struct {
 	NodeManager     p2p.NodeManager
 	Store           storage.Store
 	AuditLogger     interfaces.AuditLogger
+	Subscriptions   SubscriptionManager
 	EventBufferSize int
 }
 
 // Server implements the NodeService gRPC service.
 type Server struct {
 	services.UnimplementedNodeServiceServer
-	nodeManager  p2p.NodeManager
-	store        storage.Store
-	auditLogger  interfaces.AuditLogger
-	eventBufSize int
+	nodeManager    p2p.NodeManager
+	store          storage.Store
+	auditLogger    interfaces.AuditLogger
+	subscriptions  SubscriptionManager
+	modeController ModeController
+	messages       MessageSender
+	labels         LabelController
+	eventBufSize   int
 }
 
 // NewServer creates a new node service server.
@@ -47,13 +53,19 @@ func NewServerWithConfig(cfg Config) *Server {
 		bufSize = 100
 	}
 	return &Server{
-		nodeManager:  cfg.NodeManager,
-		store:        cfg.Store,
-		auditLogger:  cfg.AuditLogger,
-		eventBufSize: bufSize,
+		nodeManager:   cfg.NodeManager,
+		store:         cfg.Store,
+		auditLogger:   cfg.AuditLogger,
+		subscriptions: cfg.Subscriptions,
+		eventBufSize:  bufSize,
 	}
 }
 
+// SetNodeManager sets the P2P node manager.
+func (s *Server) SetNodeManager(nm p2p.NodeManager) {
+	s.nodeManager = nm
+}
+
 // GetNode returns information about a specific node by ID.
 func (s *Server) GetNode(ctx context.Context, req *services.GetNodeRequest) (*services.GetNodeResponse, error) {
 	if s.nodeManager == nil {
@@ -131,6 +143,9 @@ func (s *Server) ListNodes(ctx context.Context, req *services.ListNodesRequest)
 		if req.AuthoritativeOnly && !n.IsAuthoritative {
 			continue
 		}
+		if !p2p.MatchLabels(n.Labels, req.LabelSelector) {
+			continue
+		}
 		nodeMap[n.PeerID.String()] = nodeInfoToProto(n, nil)
 	}
 
@@ -138,7 +153,8 @@ func (s *Server) ListNodes(ctx context.Context, req *services.ListNodesRequest)
 		if existing, ok := nodeMap[dbNode.PeerID]; ok {
 			existing.StorageType = dbNode.StorageType
 			existing.IsAuthoritative = dbNode.TrustedStorage
-		} else {
+		} else if len(req.LabelSelector) == 0 {
+			// Labels are only known for peers seen over P2P
 			nodeMap[dbNode.PeerID] = dbNodeToProto(dbNode)
 		}
 	}
@@ -147,6 +163,7 @@ func (s *Server) ListNodes(ctx context.Context, req *services.ListNodesRequest)
 	for _, n := range nodeMap {
 		nodes = append(nodes, n)
 	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Id < nodes[j].Id })
 
 	offset := 0
 	limit := 50
@@ -210,6 +227,10 @@ func nodeInfoToProto(info *p2p.NodeManagerInfo, dbNode *storage.NodeInfo) *servi
 		Mode:            info.Mode,
 		IsAuthoritative: info.IsAuthoritative,
 		Version:         info.Version,
+		Connected:       info.Connected,
+		LatencyMs:       info.LatencyMs,
+		AgentVersion:    info.AgentVersion,
+		Labels:          info.Labels,
 	}
 
 	for _, addr := range info.Addresses {
@@ -0,0 +1,111 @@
+package node
+
+import (
+	"context"
+	"errors"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/p2p"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SubscriptionManager manages the topic subscriptions of a selective node.
+// It is implemented by p2p.ModeManager.
+type SubscriptionManager interface {
+	AddSubscription(pattern string) (domain.Subscription, bool, error)
+	RemoveSubscription(pattern string) (bool, error)
+	Subscriptions() ([]domain.Subscription, error)
+}
+
+// SetSubscriptionManager sets the manager of the selective mode
+// subscriptions.
+func (s *Server) SetSubscriptionManager(sm SubscriptionManager) {
+	s.subscriptions = sm
+}
+
+// AddSubscription subscribes to a topic pattern and syncs it right away.
+func (s *Server) AddSubscription(ctx context.Context, req *services.AddSubscriptionRequest) (*services.AddSubscriptionResponse, error) {
+	if s.subscriptions == nil {
+		return nil, status.Error(codes.Unavailable, "subscription management not available")
+	}
+
+	if req.Pattern == "" {
+		return nil, grpcerrors.NewValidationError("pattern is required", map[string]string{
+			"pattern": "must not be empty",
+		})
+	}
+
+	sub, added, err := s.subscriptions.AddSubscription(req.Pattern)
+	if err != nil {
+		return nil, subscriptionError(err)
+	}
+
+	return &services.AddSubscriptionResponse{
+		Subscription: subscriptionToProto(sub),
+		Added:        added,
+	}, nil
+}
+
+// RemoveSubscription unsubscribes from a topic pattern.
+func (s *Server) RemoveSubscription(ctx context.Context, req *services.RemoveSubscriptionRequest) (*services.RemoveSubscriptionResponse, error) {
+	if s.subscriptions == nil {
+		return nil, status.Error(codes.Unavailable, "subscription management not available")
+	}
+
+	if req.Pattern == "" {
+		return nil, grpcerrors.NewValidationError("pattern is required", map[string]string{
+			"pattern": "must not be empty",
+		})
+	}
+
+	removed, err := s.subscriptions.RemoveSubscription(req.Pattern)
+	if err != nil {
+		return nil, subscriptionError(err)
+	}
+
+	return &services.RemoveSubscriptionResponse{Removed: removed}, nil
+}
+
+// ListSubscriptions lists the topic patterns the node replicates.
+func (s *Server) ListSubscriptions(ctx context.Context, _ *services.ListNodeSubscriptionsRequest) (*services.ListNodeSubscriptionsResponse, error) {
+	if s.subscriptions == nil {
+		return nil, status.Error(codes.Unavailable, "subscription management not available")
+	}
+
+	subs, err := s.subscriptions.Subscriptions()
+	if err != nil {
+		return nil, subscriptionError(err)
+	}
+
+	resp := &services.ListNodeSubscriptionsResponse{}
+	for _, sub := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, subscriptionToProto(sub))
+	}
+	return resp, nil
+}
+
+// subscriptionError maps a subscription error to a gRPC status.
+func subscriptionError(err error) error {
+	if errors.Is(err, p2p.ErrNotSelectiveMode) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return grpcerrors.NewValidationError(err.Error(), map[string]string{
+		"pattern": err.Error(),
+	})
+}
+
+func subscriptionToProto(sub domain.Subscription) *services.NodeSubscription {
+	pb := &services.NodeSubscription{
+		TopicPattern: sub.TopicPattern,
+		CreatedAt:    timestamppb.New(sub.CreatedAt),
+	}
+	if !sub.LastSync.IsZero() {
+		pb.LastSync = timestamppb.New(sub.LastSync)
+	}
+	return pb
+}
@@ -0,0 +1,136 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	grpcerrors "bib/internal/grpc/errors"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// placeholderPattern matches a parameter placeholder such as ${min_temp}.
+var placeholderPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// placeholders returns the names of the placeholders in expr, in order of
+// first appearance.
+func placeholders(expr string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range placeholderPattern.FindAllStringSubmatch(expr, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// expandPlaceholders replaces each placeholder in expr with its value
+// rendered as a CEL literal. Values are never spliced in as source text, so a
+// string parameter cannot change the structure of the expression.
+func expandPlaceholders(expr string, values map[string]*structpb.Value) (string, error) {
+	violations := make(map[string]string)
+	literals := make(map[string]string)
+	for _, name := range placeholders(expr) {
+		v, ok := values[name]
+		if !ok {
+			violations["parameters."+name] = "no value given"
+			continue
+		}
+		lit, err := celLiteral(v)
+		if err != nil {
+			violations["parameters."+name] = err.Error()
+			continue
+		}
+		literals[name] = lit
+	}
+	if len(violations) > 0 {
+		return "", grpcerrors.NewValidationError("missing or invalid query parameters", violations)
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(expr, func(m string) string {
+		return literals[m[2:len(m)-1]]
+	}), nil
+}
+
+// placeholderStub returns expr with every placeholder replaced by a dynamic
+// null, so that an expression can be checked before its parameters are known.
+func placeholderStub(expr string) string {
+	return placeholderPattern.ReplaceAllString(expr, "dyn(null)")
+}
+
+// celLiteral renders a value as a CEL literal.
+func celLiteral(v *structpb.Value) (string, error) {
+	switch k := v.GetKind().(type) {
+	case nil, *structpb.Value_NullValue:
+		return "null", nil
+	case *structpb.Value_BoolValue:
+		return strconv.FormatBool(k.BoolValue), nil
+	case *structpb.Value_NumberValue:
+		n := k.NumberValue
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", fmt.Errorf("%v is not a finite number", n)
+		}
+		if n == math.Trunc(n) && math.Abs(n) < 1<<53 {
+			return strconv.FormatInt(int64(n), 10), nil
+		}
+		lit := strconv.FormatFloat(n, 'g', -1, 64)
+		if !strings.ContainsAny(lit, ".e") {
+			lit += ".0"
+		}
+		return lit, nil
+	case *structpb.Value_StringValue:
+		return strconv.Quote(k.StringValue), nil
+	case *structpb.Value_ListValue:
+		elems := make([]string, len(k.ListValue.GetValues()))
+		for i, e := range k.ListValue.GetValues() {
+			lit, err := celLiteral(e)
+			if err != nil {
+				return "", err
+			}
+			elems[i] = lit
+		}
+		return "[" + strings.Join(elems, ", ") + "]", nil
+	case *structpb.Value_StructValue:
+		fields := k.StructValue.GetFields()
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			lit, err := celLiteral(fields[key])
+			if err != nil {
+				return "", err
+			}
+			entries[i] = strconv.Quote(key) + ": " + lit
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	default:
+		return "", fmt.Errorf("unsupported value")
+	}
+}
+
+// valueType names the type of a parameter value as reported in ParameterInfo.
+func valueType(v *structpb.Value) string {
+	switch v.GetKind().(type) {
+	case *structpb.Value_BoolValue:
+		return "bool"
+	case *structpb.Value_NumberValue:
+		return "number"
+	case *structpb.Value_StringValue:
+		return "string"
+	case *structpb.Value_ListValue:
+		return "list"
+	case *structpb.Value_StructValue:
+		return "map"
+	default:
+		return "dyn"
+	}
+}
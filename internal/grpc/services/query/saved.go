@@ -0,0 +1,362 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	bibv1 "bib/api/gen/go/bib/v1"
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+	"bib/internal/storage"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// savedQueryNamePattern is the allowed form of a saved query name.
+var savedQueryNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// SaveQuery saves a named query for the caller, replacing any query the
+// caller has already saved under that name.
+func (s *Server) SaveQuery(ctx context.Context, req *services.SaveQueryRequest) (*services.SaveQueryResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	violations := make(map[string]string)
+	switch {
+	case req.GetName() == "":
+		violations["name"] = "must not be empty"
+	case !savedQueryNamePattern.MatchString(req.GetName()):
+		violations["name"] = "must be at most 128 letters, digits, '.', '_' or '-', starting with a letter or digit"
+	}
+	if req.GetExpression() == "" {
+		violations["expression"] = "must not be empty"
+	}
+	used := make(map[string]bool)
+	for _, name := range placeholders(req.GetExpression()) {
+		used[name] = true
+	}
+	defaults := make(map[string]any, len(req.GetDefaultParameters()))
+	for name, v := range req.GetDefaultParameters() {
+		if !used[name] {
+			violations["default_parameters."+name] = "not used by the expression"
+			continue
+		}
+		if _, err := celLiteral(v); err != nil {
+			violations["default_parameters."+name] = err.Error()
+			continue
+		}
+		defaults[name] = v.AsInterface()
+	}
+	if len(violations) > 0 {
+		return nil, grpcerrors.NewValidationError("invalid save query request", violations)
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	// Validate query syntax; placeholders stand in for values of any type.
+	if s.celEnv != nil {
+		_, issues := s.celEnv.Compile(placeholderStub(req.GetExpression()))
+		if issues != nil && issues.Err() != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid CEL expression: %v", issues.Err())
+		}
+	}
+
+	now := time.Now().UTC()
+	query, err := s.store.SavedQueries().GetByName(ctx, user.ID, req.GetName())
+	action := "UPDATE"
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		action = "CREATE"
+		query = &storage.SavedQuery{
+			ID:        uuid.New().String(),
+			UserID:    user.ID,
+			Name:      req.GetName(),
+			CreatedAt: now,
+		}
+	case err != nil:
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	query.Description = req.GetDescription()
+	query.Query = req.GetExpression()
+	query.Parameters = defaults
+	query.Tags = req.GetTags()
+	query.IsPublic = req.GetIsPublic()
+	query.UpdatedAt = now
+
+	if action == "CREATE" {
+		err = s.store.SavedQueries().Create(ctx, query)
+	} else {
+		err = s.store.SavedQueries().Update(ctx, query)
+	}
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, action, "saved_query", query.ID, map[string]interface{}{
+			"name":      query.Name,
+			"is_public": query.IsPublic,
+		})
+	}
+
+	return &services.SaveQueryResponse{Query: savedQueryToProto(query)}, nil
+}
+
+// RunSavedQuery runs a saved query. A query named by the caller resolves to
+// the caller's own query first, then to a public query of that name.
+func (s *Server) RunSavedQuery(ctx context.Context, req *services.RunSavedQueryRequest) (*services.RunSavedQueryResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if (req.GetName() == "") == (req.GetId() == "") {
+		return nil, grpcerrors.NewValidationError("exactly one of name or id is required", map[string]string{
+			"name": "set either name or id",
+		})
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	query, err := s.resolveSavedQuery(ctx, user, req.GetName(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]*structpb.Value, len(query.Parameters)+len(req.GetParameters()))
+	for name, v := range query.Parameters {
+		if pv, err := structpb.NewValue(v); err == nil {
+			values[name] = pv
+		}
+	}
+	used := make(map[string]bool)
+	for _, name := range placeholders(query.Query) {
+		used[name] = true
+	}
+	unknown := make(map[string]string)
+	for name, v := range req.GetParameters() {
+		if !used[name] {
+			unknown["parameters."+name] = "not a parameter of " + query.Name
+			continue
+		}
+		values[name] = v
+	}
+	if len(unknown) > 0 {
+		return nil, grpcerrors.NewValidationError("unknown query parameters", unknown)
+	}
+
+	expr, err := expandPlaceholders(query.Query, values)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.execute(ctx, &services.ExecuteQueryRequest{
+		Expression: expr,
+		Page:       req.GetPage(),
+		Timeout:    req.GetTimeout(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SavedQueries().IncrementUseCount(ctx, query.ID); err == nil {
+		query.UseCount++
+	}
+
+	return &services.RunSavedQueryResponse{
+		Query:      savedQueryToProto(query),
+		Expression: expr,
+		Result:     result,
+	}, nil
+}
+
+// resolveSavedQuery finds the saved query to run by ID or name.
+func (s *Server) resolveSavedQuery(ctx context.Context, user *domain.User, name, id string) (*storage.SavedQuery, error) {
+	if id != "" {
+		query, err := s.store.SavedQueries().Get(ctx, id)
+		if err != nil {
+			return nil, grpcerrors.MapDomainError(err)
+		}
+		if query.UserID != user.ID && !query.IsPublic {
+			return nil, grpcerrors.NewResourceNotFoundError("saved_query", id)
+		}
+		return query, nil
+	}
+
+	query, err := s.store.SavedQueries().GetByName(ctx, user.ID, name)
+	if err == nil {
+		return query, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	public, err := s.store.SavedQueries().List(ctx, storage.SavedQueryFilter{
+		Name:       name,
+		PublicOnly: true,
+		Limit:      2,
+	})
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	switch len(public) {
+	case 0:
+		return nil, grpcerrors.NewResourceNotFoundError("saved_query", name)
+	case 1:
+		return public[0], nil
+	default:
+		return nil, grpcerrors.NewValidationError("saved query name is ambiguous", map[string]string{
+			"name": "several users share a public query named " + name + "; run it by id",
+		})
+	}
+}
+
+// ListSavedQueries lists the caller's saved queries and, if requested, the
+// queries other users have made public.
+func (s *Server) ListSavedQueries(ctx context.Context, req *services.ListSavedQueriesRequest) (*services.ListSavedQueriesResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	filter := storage.SavedQueryFilter{
+		UserID:        &user.ID,
+		IncludePublic: req.GetIncludePublic(),
+		Tags:          req.GetTags(),
+		Search:        req.GetSearch(),
+	}
+	if req.Page != nil {
+		filter.Limit = int(req.Page.Limit)
+		filter.Offset = int(req.Page.Offset)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 1000 {
+		filter.Limit = 1000
+	}
+
+	queries, err := s.store.SavedQueries().List(ctx, filter)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	total, _ := s.store.SavedQueries().Count(ctx, filter)
+
+	protoQueries := make([]*services.SavedQuery, len(queries))
+	for i, q := range queries {
+		protoQueries[i] = savedQueryToProto(q)
+	}
+
+	return &services.ListSavedQueriesResponse{
+		Queries: protoQueries,
+		PageInfo: &bibv1.PageInfo{
+			TotalCount: total,
+			HasMore:    int64(filter.Offset+len(queries)) < total,
+			PageSize:   int32(len(queries)),
+		},
+	}, nil
+}
+
+// DeleteSavedQuery deletes a saved query. Only its owner or an admin may
+// delete it.
+func (s *Server) DeleteSavedQuery(ctx context.Context, req *services.DeleteSavedQueryRequest) (*services.DeleteSavedQueryResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.GetId() == "" {
+		return nil, grpcerrors.NewValidationError("id is required", map[string]string{
+			"id": "must not be empty",
+		})
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	query, err := s.store.SavedQueries().Get(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	if query.UserID != user.ID {
+		if !query.IsPublic {
+			return nil, grpcerrors.NewResourceNotFoundError("saved_query", req.GetId())
+		}
+		if user.Role != domain.UserRoleAdmin {
+			return nil, grpcerrors.NewPermissionDeniedError("delete", "saved query "+query.Name, string(domain.UserRoleAdmin))
+		}
+	}
+
+	if err := s.store.SavedQueries().Delete(ctx, query.ID); err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "saved_query", query.ID, map[string]interface{}{
+			"name": query.Name,
+		})
+	}
+
+	return &services.DeleteSavedQueryResponse{
+		Success: true,
+	}, nil
+}
+
+// Conversion helpers
+
+func savedQueryToProto(q *storage.SavedQuery) *services.SavedQuery {
+	defaults := make(map[string]*structpb.Value, len(q.Parameters))
+	for name, v := range q.Parameters {
+		if pv, err := structpb.NewValue(v); err == nil {
+			defaults[name] = pv
+		}
+	}
+
+	names := placeholders(q.Query)
+	params := make([]*services.ParameterInfo, len(names))
+	for i, name := range names {
+		info := &services.ParameterInfo{Name: name, Type: "dyn", Required: true}
+		if v, ok := defaults[name]; ok {
+			info.Type = valueType(v)
+			info.Required = false
+			info.DefaultValue = v
+		}
+		params[i] = info
+	}
+
+	return &services.SavedQuery{
+		Id:                q.ID,
+		Name:              q.Name,
+		Expression:        q.Query,
+		Description:       q.Description,
+		DefaultParameters: defaults,
+		OwnerId:           string(q.UserID),
+		IsPublic:          q.IsPublic,
+		Tags:              q.Tags,
+		CreatedAt:         timestamppb.New(q.CreatedAt),
+		UpdatedAt:         timestamppb.New(q.UpdatedAt),
+		UseCount:          q.UseCount,
+		Parameters:        params,
+	}
+}
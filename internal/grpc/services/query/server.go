@@ -12,6 +12,7 @@ import (
 	"github.com/google/cel-go/cel"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // Config holds configuration for the query service server.
@@ -67,12 +68,27 @@ func (s *Server) Execute(ctx context.Context, req *services.ExecuteQueryRequest)
 		})
 	}
 
+	expr, err := expandPlaceholders(req.GetExpression(), req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := proto.Clone(req).(*services.ExecuteQueryRequest)
+	expanded.Expression = expr
+	expanded.Parameters = nil
+	return s.execute(ctx, expanded)
+}
+
+// execute runs a query whose placeholders have been filled in.
+func (s *Server) execute(ctx context.Context, req *services.ExecuteQueryRequest) (*services.ExecuteQueryResponse, error) {
+	expr := req.GetExpression()
+
 	// Validate the query first
 	if s.celEnv == nil {
 		return nil, status.Error(codes.Unavailable, "CEL environment not initialized")
 	}
 
-	_, issues := s.celEnv.Compile(req.GetExpression())
+	_, issues := s.celEnv.Compile(expr)
 	if issues != nil && issues.Err() != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid CEL expression: %v", issues.Err())
 	}
@@ -80,7 +96,7 @@ func (s *Server) Execute(ctx context.Context, req *services.ExecuteQueryRequest)
 	// Audit log
 	if s.auditLogger != nil {
 		_ = s.auditLogger.LogServiceAction(ctx, "EXECUTE", "query", "", map[string]interface{}{
-			"expression": req.GetExpression(),
+			"expression": expr,
 		})
 	}
 
@@ -104,18 +120,23 @@ func (s *Server) ExecuteStream(req *services.ExecuteQueryRequest, stream service
 		})
 	}
 
+	expr, err := expandPlaceholders(req.GetExpression(), req.GetParameters())
+	if err != nil {
+		return err
+	}
+
 	// Validate the query first
 	if s.celEnv == nil {
 		return status.Error(codes.Unavailable, "CEL environment not initialized")
 	}
 
-	_, issues := s.celEnv.Compile(req.GetExpression())
+	_, issues := s.celEnv.Compile(expr)
 	if issues != nil && issues.Err() != nil {
 		return status.Errorf(codes.InvalidArgument, "invalid CEL expression: %v", issues.Err())
 	}
 
 	// For now, send a single result indicating the feature is pending
-	err := stream.Send(&services.QueryResult{})
+	err = stream.Send(&services.QueryResult{})
 	if err != nil {
 		return err
 	}
@@ -249,65 +270,3 @@ func (s *Server) GetHistory(_ context.Context, _ *services.GetQueryHistoryReques
 		Entries: []*services.QueryHistoryEntry{},
 	}, nil
 }
-
-// SaveQuery saves a named query.
-func (s *Server) SaveQuery(ctx context.Context, req *services.SaveQueryRequest) (*services.SaveQueryResponse, error) {
-	violations := make(map[string]string)
-	if req.GetName() == "" {
-		violations["name"] = "must not be empty"
-	}
-	if req.GetExpression() == "" {
-		violations["expression"] = "must not be empty"
-	}
-	if len(violations) > 0 {
-		return nil, grpcerrors.NewValidationError("invalid save query request", violations)
-	}
-
-	// Validate query syntax
-	if s.celEnv != nil {
-		_, issues := s.celEnv.Compile(req.GetExpression())
-		if issues != nil && issues.Err() != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid CEL expression: %v", issues.Err())
-		}
-	}
-
-	// Audit log
-	if s.auditLogger != nil {
-		_ = s.auditLogger.LogServiceAction(ctx, "CREATE", "saved_query", "", map[string]interface{}{
-			"name": req.GetName(),
-		})
-	}
-
-	return &services.SaveQueryResponse{
-		Query: &services.SavedQuery{
-			Name:       req.GetName(),
-			Expression: req.GetExpression(),
-		},
-	}, nil
-}
-
-// ListSavedQueries lists saved queries.
-func (s *Server) ListSavedQueries(_ context.Context, _ *services.ListSavedQueriesRequest) (*services.ListSavedQueriesResponse, error) {
-	// Saved queries will be implemented with storage layer support
-	return &services.ListSavedQueriesResponse{
-		Queries: []*services.SavedQuery{},
-	}, nil
-}
-
-// DeleteSavedQuery deletes a saved query.
-func (s *Server) DeleteSavedQuery(ctx context.Context, req *services.DeleteSavedQueryRequest) (*services.DeleteSavedQueryResponse, error) {
-	if req.GetId() == "" {
-		return nil, grpcerrors.NewValidationError("id is required", map[string]string{
-			"id": "must not be empty",
-		})
-	}
-
-	// Audit log
-	if s.auditLogger != nil {
-		_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "saved_query", req.GetId(), nil)
-	}
-
-	return &services.DeleteSavedQueryResponse{
-		Success: true,
-	}, nil
-}
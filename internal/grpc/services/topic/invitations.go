@@ -0,0 +1,332 @@
+package topic
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+	"bib/internal/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// InviteToTopic invites a user to join a topic.
+func (s *Server) InviteToTopic(ctx context.Context, req *services.InviteToTopicRequest) (*services.InviteToTopicResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	violations := make(map[string]string)
+	if req.TopicId == "" {
+		violations["topic_id"] = "must not be empty"
+	}
+	if req.UserId == "" && req.Email == "" {
+		violations["user_id"] = "either user_id or email must be provided"
+	}
+	if req.Role != "" && !storage.TopicMemberRole(req.Role).IsValid() {
+		violations["role"] = "must be owner, editor or viewer"
+	}
+	if len(violations) > 0 {
+		return nil, grpcerrors.NewValidationError("invalid invitation", violations)
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	if req.UserId != "" {
+		if _, err := s.store.Users().Get(ctx, domain.UserID(req.UserId)); err != nil {
+			return nil, grpcerrors.MapDomainError(err)
+		}
+	}
+
+	resp, err := s.members.InviteMember(ctx, InviteMemberRequest{
+		TopicID:      domain.TopicID(req.TopicId),
+		InviterID:    user.ID,
+		InviteeEmail: req.Email,
+		InviteeID:    domain.UserID(req.UserId),
+		Role:         storage.TopicMemberRole(req.Role),
+		Message:      req.Message,
+	})
+	if err != nil {
+		return nil, mapMembershipError(err, "")
+	}
+
+	return &services.InviteToTopicResponse{
+		Invitation: s.invitationToProto(ctx, resp.Invitation),
+		Token:      resp.Token,
+	}, nil
+}
+
+// RequestTopicAccess asks the owners of a topic to let the caller join.
+func (s *Server) RequestTopicAccess(ctx context.Context, req *services.RequestTopicAccessRequest) (*services.RequestTopicAccessResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.TopicId == "" {
+		return nil, grpcerrors.NewValidationError("topic_id is required", map[string]string{
+			"topic_id": "must not be empty",
+		})
+	}
+	if req.Role != "" && req.Role != string(storage.TopicMemberRoleEditor) && req.Role != string(storage.TopicMemberRoleViewer) {
+		return nil, grpcerrors.NewValidationError("invalid role", map[string]string{
+			"role": "must be editor or viewer",
+		})
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	request, err := s.members.RequestAccess(ctx, RequestAccessRequest{
+		TopicID: domain.TopicID(req.TopicId),
+		UserID:  user.ID,
+		Role:    storage.TopicMemberRole(req.Role),
+		Message: req.Message,
+	})
+	if errors.Is(err, domain.ErrInvalidOperation) {
+		return nil, grpcerrors.NewPreconditionError(grpcerrors.SubreasonMembershipPending, "already invited or awaiting review", map[string]string{
+			"topic_id": "accept the pending invitation or wait for the owners to review your request",
+		})
+	}
+	if err != nil {
+		return nil, mapMembershipError(err, "")
+	}
+
+	return &services.RequestTopicAccessResponse{
+		Request: s.invitationToProto(ctx, request),
+	}, nil
+}
+
+// RespondToTopicInvitation accepts or declines an invitation.
+func (s *Server) RespondToTopicInvitation(ctx context.Context, req *services.RespondToTopicInvitationRequest) (*services.RespondToTopicInvitationResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.InvitationId == "" && req.Token == "" {
+		return nil, grpcerrors.NewValidationError("invitation_id or token is required", map[string]string{
+			"invitation_id": "either invitation_id or token must be provided",
+			"token":         "either invitation_id or token must be provided",
+		})
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	var invitation *storage.TopicInvitation
+	var err error
+	if req.InvitationId != "" {
+		invitation, err = s.store.TopicInvitations().Get(ctx, req.InvitationId)
+		if err != nil {
+			return nil, mapMembershipError(err, req.InvitationId)
+		}
+		// Without the token, only the invitee may answer
+		if !addressedTo(invitation, user) {
+			return nil, grpcerrors.NewPermissionDeniedError("respond to", "invitation", "invitee")
+		}
+	} else {
+		invitation, err = s.store.TopicInvitations().GetByToken(ctx, req.Token)
+		if err != nil {
+			return nil, mapMembershipError(err, "")
+		}
+	}
+
+	resp := &services.RespondToTopicInvitationResponse{}
+	if req.Accept {
+		member, err := s.members.AcceptInvitation(ctx, invitation.Token, user.ID)
+		if err != nil {
+			return nil, mapMembershipError(err, invitation.ID)
+		}
+		if topic, err := s.store.Topics().Get(ctx, member.TopicID); err == nil {
+			resp.Subscription = memberToSubscription(member, topic)
+		}
+	} else if err := s.members.DeclineInvitation(ctx, invitation.Token, user.ID); err != nil {
+		return nil, mapMembershipError(err, invitation.ID)
+	}
+
+	if updated, err := s.store.TopicInvitations().Get(ctx, invitation.ID); err == nil {
+		invitation = updated
+	}
+	resp.Invitation = s.invitationToProto(ctx, invitation)
+
+	return resp, nil
+}
+
+// ReviewTopicAccessRequest approves or denies an access request.
+func (s *Server) ReviewTopicAccessRequest(ctx context.Context, req *services.ReviewTopicAccessRequestRequest) (*services.ReviewTopicAccessRequestResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.RequestId == "" {
+		return nil, grpcerrors.NewValidationError("request_id is required", map[string]string{
+			"request_id": "must not be empty",
+		})
+	}
+	if req.Role != "" && !storage.TopicMemberRole(req.Role).IsValid() {
+		return nil, grpcerrors.NewValidationError("invalid role", map[string]string{
+			"role": "must be owner, editor or viewer",
+		})
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	request, err := s.members.ReviewAccessRequest(ctx, req.RequestId, user.ID, req.Approve, storage.TopicMemberRole(req.Role))
+	if err != nil {
+		return nil, mapMembershipError(err, req.RequestId)
+	}
+
+	return &services.ReviewTopicAccessRequestResponse{
+		Request: s.invitationToProto(ctx, request),
+	}, nil
+}
+
+// CancelTopicInvitation withdraws a pending invitation or access request.
+func (s *Server) CancelTopicInvitation(ctx context.Context, req *services.CancelTopicInvitationRequest) (*services.CancelTopicInvitationResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	if req.InvitationId == "" {
+		return nil, grpcerrors.NewValidationError("invitation_id is required", map[string]string{
+			"invitation_id": "must not be empty",
+		})
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	if err := s.members.CancelInvitation(ctx, req.InvitationId, user.ID); err != nil {
+		return nil, mapMembershipError(err, req.InvitationId)
+	}
+
+	invitation, err := s.store.TopicInvitations().Get(ctx, req.InvitationId)
+	if err != nil {
+		return nil, mapMembershipError(err, req.InvitationId)
+	}
+
+	return &services.CancelTopicInvitationResponse{
+		Invitation: s.invitationToProto(ctx, invitation),
+	}, nil
+}
+
+// ListTopicInvitations lists the invitations and access requests of a topic,
+// or those of the caller.
+func (s *Server) ListTopicInvitations(ctx context.Context, req *services.ListTopicInvitationsRequest) (*services.ListTopicInvitationsResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	filter := storage.InvitationFilter{
+		Kind:   storage.InvitationKind(req.Kind),
+		Status: storage.InvitationStatus(req.Status),
+	}
+
+	var invitations []*storage.TopicInvitation
+	if req.TopicId != "" {
+		var err error
+		invitations, err = s.members.ListInvitations(ctx, domain.TopicID(req.TopicId), user.ID, filter)
+		if err != nil {
+			return nil, mapMembershipError(err, "")
+		}
+	} else {
+		mine, err := s.members.ListMyInvitations(ctx, user.ID)
+		if err != nil {
+			return nil, grpcerrors.MapDomainError(err)
+		}
+		if user.Email != "" {
+			byEmail, err := s.store.TopicInvitations().ListByEmail(ctx, user.Email)
+			if err != nil {
+				return nil, grpcerrors.MapDomainError(err)
+			}
+			mine = append(mine, byEmail...)
+		}
+		for _, inv := range mine {
+			if (filter.Kind == "" || inv.Kind == filter.Kind) && (filter.Status == "" || inv.Status == filter.Status) {
+				invitations = append(invitations, inv)
+			}
+		}
+	}
+
+	resp := &services.ListTopicInvitationsResponse{
+		Invitations: make([]*services.TopicInvitation, len(invitations)),
+	}
+	for i, inv := range invitations {
+		resp.Invitations[i] = s.invitationToProto(ctx, inv)
+	}
+	return resp, nil
+}
+
+// addressedTo reports whether invitation invites user, by ID or email.
+func addressedTo(invitation *storage.TopicInvitation, user *domain.User) bool {
+	if invitation.InviteeUserID != "" {
+		return invitation.InviteeUserID == user.ID
+	}
+	return user.Email != "" && strings.EqualFold(invitation.InviteeEmail, user.Email)
+}
+
+// mapMembershipError maps membership errors to gRPC errors. The invitation
+// repositories report a missing invitation as domain.ErrOwnerNotFound.
+func mapMembershipError(err error, invitationID string) error {
+	switch {
+	case errors.Is(err, domain.ErrOwnerNotFound):
+		return grpcerrors.NewResourceNotFoundError("invitation", invitationID)
+	case errors.Is(err, domain.ErrNotOwner):
+		return grpcerrors.NewPermissionDeniedError("manage members of", "topic", "owner")
+	case errors.Is(err, domain.ErrUserExists):
+		return grpcerrors.NewAlreadyExistsError("topic_member", invitationID)
+	case errors.Is(err, domain.ErrSessionExpired):
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonInvitationExpired, "invitation has expired", map[string]string{
+			"invitation": "ask an owner for a new invitation",
+		})
+	case errors.Is(err, domain.ErrInvalidOperation):
+		return grpcerrors.NewPreconditionError(grpcerrors.SubreasonInvitationNotPending, "invitation is no longer pending", nil)
+	}
+	return grpcerrors.MapDomainError(err)
+}
+
+func (s *Server) invitationToProto(ctx context.Context, inv *storage.TopicInvitation) *services.TopicInvitation {
+	pb := &services.TopicInvitation{
+		Id:          inv.ID,
+		TopicId:     string(inv.TopicID),
+		Kind:        string(inv.Kind),
+		UserId:      string(inv.InviteeUserID),
+		Email:       inv.InviteeEmail,
+		InviterId:   string(inv.InviterID),
+		Role:        string(inv.Role),
+		Message:     inv.Message,
+		Status:      string(inv.Status),
+		CreatedAt:   timestamppb.New(inv.CreatedAt),
+		ExpiresAt:   timestamppb.New(inv.ExpiresAt),
+		RespondedBy: string(inv.RespondedBy),
+	}
+	if inv.RespondedAt != nil {
+		pb.RespondedAt = timestamppb.New(*inv.RespondedAt)
+	}
+	if topic, err := s.store.Topics().Get(ctx, inv.TopicID); err == nil {
+		pb.TopicName = topic.Name
+	}
+	return pb
+}
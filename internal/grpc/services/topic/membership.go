@@ -3,8 +3,10 @@ package topic
 
 import (
 	"context"
+	"slices"
 	"time"
 
+	"bib/internal/cluster"
 	"bib/internal/domain"
 	"bib/internal/grpc/interfaces"
 	"bib/internal/storage"
@@ -12,10 +14,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// notifyTimeout bounds the delivery of a membership notification.
+const notifyTimeout = 30 * time.Second
+
+// Replicator replicates membership state to the other nodes of a cluster.
+// *cluster.Cluster implements it.
+type Replicator interface {
+	ReplicateTopicMembership(m *cluster.ReplicatedTopicMembership) error
+}
+
 // MembershipManager handles topic membership operations.
 type MembershipManager struct {
 	store       storage.Store
 	auditLogger interfaces.AuditLogger
+	notifier    Notifier
+	replicator  Replicator
 }
 
 // NewMembershipManager creates a new membership manager.
@@ -53,6 +66,17 @@ func (m *MembershipManager) InviteMember(ctx context.Context, req InviteMemberRe
 	if req.InviteeEmail == "" && req.InviteeID == "" {
 		return nil, domain.ErrInvalidOperation
 	}
+	if req.Role == "" {
+		req.Role = storage.TopicMemberRoleViewer
+	}
+	if !req.Role.IsValid() {
+		return nil, domain.ErrInvalidOperation
+	}
+
+	topic, err := m.store.Topics().Get(ctx, req.TopicID)
+	if err != nil {
+		return nil, err
+	}
 
 	if req.InviteeID != "" {
 		existing, _ := m.store.TopicMembers().Get(ctx, req.TopicID, req.InviteeID)
@@ -70,6 +94,7 @@ func (m *MembershipManager) InviteMember(ctx context.Context, req InviteMemberRe
 
 	invitation := &storage.TopicInvitation{
 		ID:            uuid.New().String(),
+		Kind:          storage.InvitationKindInvite,
 		TopicID:       req.TopicID,
 		InviterID:     req.InviterID,
 		InviteeEmail:  req.InviteeEmail,
@@ -89,9 +114,27 @@ func (m *MembershipManager) InviteMember(ctx context.Context, req InviteMemberRe
 	if m.auditLogger != nil {
 		_ = m.auditLogger.LogServiceAction(ctx, "CREATE", "topic_invitation", invitation.ID, map[string]interface{}{
 			"topic_id": string(req.TopicID),
+			"invitee":  string(req.InviteeID),
+			"role":     string(req.Role),
 		})
 	}
 
+	if req.InviteeID != "" {
+		m.replicate(req.TopicID, req.InviteeID, storage.TopicMembershipInvited, req.Role)
+	}
+	m.notify(&MembershipNotification{
+		Event:        EventInvited,
+		InvitationID: invitation.ID,
+		TopicID:      topic.ID,
+		TopicName:    topic.Name,
+		Role:         req.Role,
+		Message:      req.Message,
+		Actor:        req.InviterID,
+		Recipients:   recipients(req.InviteeID),
+		Email:        req.InviteeEmail,
+		Token:        token,
+	})
+
 	return &InviteMemberResponse{
 		Invitation: invitation,
 		Token:      token,
@@ -105,7 +148,7 @@ func (m *MembershipManager) AcceptInvitation(ctx context.Context, token string,
 		return nil, err
 	}
 
-	if invitation.Status != storage.InvitationStatusPending {
+	if invitation.Kind == storage.InvitationKindRequest || invitation.Status != storage.InvitationStatusPending {
 		return nil, domain.ErrInvalidOperation
 	}
 
@@ -134,20 +177,36 @@ func (m *MembershipManager) AcceptInvitation(ctx context.Context, token string,
 		UpdatedAt:  now,
 	}
 
-	if err := m.store.TopicMembers().Create(ctx, member); err != nil {
-		return nil, err
-	}
-
 	invitation.Status = storage.InvitationStatusAccepted
 	invitation.RespondedAt = &now
-	_ = m.store.TopicInvitations().Update(ctx, invitation)
+	invitation.RespondedBy = userID
+
+	// Joining and closing the invitation happen together, so a pending
+	// invitation can't be accepted twice
+	err = m.store.WithTx(ctx, func(ctx context.Context) error {
+		if err := m.store.TopicMembers().Create(ctx, member); err != nil {
+			return err
+		}
+		return m.store.TopicInvitations().Update(ctx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	if m.auditLogger != nil {
+		_ = m.auditLogger.LogServiceAction(ctx, "UPDATE", "topic_invitation", invitation.ID, map[string]interface{}{
+			"topic_id": string(invitation.TopicID),
+			"status":   string(invitation.Status),
+		})
 		_ = m.auditLogger.LogServiceAction(ctx, "CREATE", "topic_member", member.ID, map[string]interface{}{
 			"topic_id": string(invitation.TopicID),
+			"role":     string(member.Role),
 		})
 	}
 
+	m.replicate(invitation.TopicID, userID, storage.TopicMembershipMember, member.Role)
+	m.notifyResponse(ctx, invitation, EventInvitationAccepted, userID, recipients(invitation.InviterID))
+
 	return member, nil
 }
 
@@ -158,7 +217,7 @@ func (m *MembershipManager) DeclineInvitation(ctx context.Context, token string,
 		return err
 	}
 
-	if invitation.Status != storage.InvitationStatusPending {
+	if invitation.Kind == storage.InvitationKindRequest || invitation.Status != storage.InvitationStatusPending {
 		return domain.ErrInvalidOperation
 	}
 
@@ -169,8 +228,25 @@ func (m *MembershipManager) DeclineInvitation(ctx context.Context, token string,
 	now := time.Now().UTC()
 	invitation.Status = storage.InvitationStatusDeclined
 	invitation.RespondedAt = &now
+	invitation.RespondedBy = userID
+
+	if err := m.store.TopicInvitations().Update(ctx, invitation); err != nil {
+		return err
+	}
+
+	if m.auditLogger != nil {
+		_ = m.auditLogger.LogServiceAction(ctx, "UPDATE", "topic_invitation", invitation.ID, map[string]interface{}{
+			"topic_id": string(invitation.TopicID),
+			"status":   string(invitation.Status),
+		})
+	}
+
+	if invitation.InviteeUserID != "" {
+		m.replicate(invitation.TopicID, invitation.InviteeUserID, storage.TopicMembershipNone, "")
+	}
+	m.notifyResponse(ctx, invitation, EventInvitationDeclined, userID, recipients(invitation.InviterID))
 
-	return m.store.TopicInvitations().Update(ctx, invitation)
+	return nil
 }
 
 // CancelInvitation cancels an invitation.
@@ -194,8 +270,25 @@ func (m *MembershipManager) CancelInvitation(ctx context.Context, invitationID s
 	now := time.Now().UTC()
 	invitation.Status = storage.InvitationStatusCancelled
 	invitation.RespondedAt = &now
+	invitation.RespondedBy = cancellerID
+
+	if err := m.store.TopicInvitations().Update(ctx, invitation); err != nil {
+		return err
+	}
+
+	if m.auditLogger != nil {
+		_ = m.auditLogger.LogServiceAction(ctx, "UPDATE", "topic_invitation", invitation.ID, map[string]interface{}{
+			"topic_id": string(invitation.TopicID),
+			"kind":     string(invitation.Kind),
+			"status":   string(invitation.Status),
+		})
+	}
 
-	return m.store.TopicInvitations().Update(ctx, invitation)
+	if invitation.InviteeUserID != "" {
+		m.replicate(invitation.TopicID, invitation.InviteeUserID, storage.TopicMembershipNone, "")
+	}
+
+	return nil
 }
 
 // UpdateMemberRole updates a member's role.
@@ -268,6 +361,8 @@ func (m *MembershipManager) RemoveMember(ctx context.Context, topicID domain.Top
 		})
 	}
 
+	m.replicate(topicID, memberID, storage.TopicMembershipNone, "")
+
 	return nil
 }
 
@@ -283,14 +378,19 @@ func (m *MembershipManager) ListMembers(ctx context.Context, topicID domain.Topi
 
 // ListPendingInvitations lists pending invitations for a topic.
 func (m *MembershipManager) ListPendingInvitations(ctx context.Context, topicID domain.TopicID, requesterID domain.UserID) ([]*storage.TopicInvitation, error) {
+	return m.ListInvitations(ctx, topicID, requesterID, storage.InvitationFilter{
+		Status: storage.InvitationStatusPending,
+	})
+}
+
+// ListInvitations lists the invitations and access requests of a topic.
+func (m *MembershipManager) ListInvitations(ctx context.Context, topicID domain.TopicID, requesterID domain.UserID, filter storage.InvitationFilter) ([]*storage.TopicInvitation, error) {
 	role, err := m.store.TopicMembers().GetRole(ctx, topicID, requesterID)
 	if err != nil || role != storage.TopicMemberRoleOwner {
 		return nil, domain.ErrNotOwner
 	}
 
-	return m.store.TopicInvitations().ListByTopic(ctx, topicID, storage.InvitationFilter{
-		Status: storage.InvitationStatusPending,
-	})
+	return m.store.TopicInvitations().ListByTopic(ctx, topicID, filter)
 }
 
 // ListMyInvitations lists pending invitations for the current user.
@@ -298,6 +398,192 @@ func (m *MembershipManager) ListMyInvitations(ctx context.Context, userID domain
 	return m.store.TopicInvitations().ListByUser(ctx, userID)
 }
 
+// RequestAccessRequest contains the parameters for requesting access.
+type RequestAccessRequest struct {
+	TopicID domain.TopicID
+	UserID  domain.UserID
+	Role    storage.TopicMemberRole
+	Message string
+}
+
+// RequestAccess asks the owners of a topic to let a user join it.
+func (m *MembershipManager) RequestAccess(ctx context.Context, req RequestAccessRequest) (*storage.TopicInvitation, error) {
+	if req.Role == "" {
+		req.Role = storage.TopicMemberRoleViewer
+	}
+	if !req.Role.IsValid() || req.Role == storage.TopicMemberRoleOwner {
+		return nil, domain.ErrInvalidOperation
+	}
+
+	topic, err := m.store.Topics().Get(ctx, req.TopicID)
+	if err != nil {
+		return nil, err
+	}
+	if topic.Status != domain.TopicStatusActive {
+		return nil, domain.ErrTopicArchived
+	}
+
+	existing, _ := m.store.TopicMembers().Get(ctx, req.TopicID, req.UserID)
+	if existing != nil {
+		return nil, domain.ErrUserExists
+	}
+	if state := m.MembershipState(ctx, req.TopicID, req.UserID); state != storage.TopicMembershipNone {
+		// Already invited or waiting for review
+		return nil, domain.ErrInvalidOperation
+	}
+
+	now := time.Now().UTC()
+	request := &storage.TopicInvitation{
+		ID:            uuid.New().String(),
+		Kind:          storage.InvitationKindRequest,
+		TopicID:       req.TopicID,
+		InviterID:     req.UserID,
+		InviteeUserID: req.UserID,
+		Role:          req.Role,
+		Token:         generateSecureToken(),
+		Message:       req.Message,
+		Status:        storage.InvitationStatusPending,
+		ExpiresAt:     now.Add(30 * 24 * time.Hour),
+		CreatedAt:     now,
+	}
+
+	if err := m.store.TopicInvitations().Create(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if m.auditLogger != nil {
+		_ = m.auditLogger.LogServiceAction(ctx, "CREATE", "topic_access_request", request.ID, map[string]interface{}{
+			"topic_id": string(req.TopicID),
+			"role":     string(req.Role),
+		})
+	}
+
+	m.replicate(req.TopicID, req.UserID, storage.TopicMembershipPending, req.Role)
+	m.notify(&MembershipNotification{
+		Event:        EventAccessRequested,
+		InvitationID: request.ID,
+		TopicID:      topic.ID,
+		TopicName:    topic.Name,
+		Role:         req.Role,
+		Message:      req.Message,
+		Actor:        req.UserID,
+		Recipients:   m.owners(ctx, topic),
+		Timestamp:    now,
+	})
+
+	return request, nil
+}
+
+// ReviewAccessRequest approves or denies an access request. An approved
+// requester joins with role, or the requested role if role is empty.
+func (m *MembershipManager) ReviewAccessRequest(ctx context.Context, requestID string, reviewerID domain.UserID, approve bool, role storage.TopicMemberRole) (*storage.TopicInvitation, error) {
+	request, err := m.store.TopicInvitations().Get(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Kind != storage.InvitationKindRequest {
+		return nil, domain.ErrInvalidOperation
+	}
+
+	reviewerRole, err := m.store.TopicMembers().GetRole(ctx, request.TopicID, reviewerID)
+	if err != nil || reviewerRole != storage.TopicMemberRoleOwner {
+		return nil, domain.ErrNotOwner
+	}
+
+	if request.Status != storage.InvitationStatusPending {
+		return nil, domain.ErrInvalidOperation
+	}
+	if role != "" {
+		if !role.IsValid() {
+			return nil, domain.ErrInvalidOperation
+		}
+		request.Role = role
+	}
+
+	now := time.Now().UTC()
+	request.RespondedAt = &now
+	request.RespondedBy = reviewerID
+
+	var member *storage.TopicMember
+	if approve {
+		member = &storage.TopicMember{
+			ID:         uuid.New().String(),
+			TopicID:    request.TopicID,
+			UserID:     request.InviteeUserID,
+			Role:       request.Role,
+			InvitedBy:  reviewerID,
+			InvitedAt:  request.CreatedAt,
+			AcceptedAt: &now,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		request.Status = storage.InvitationStatusAccepted
+	} else {
+		request.Status = storage.InvitationStatusDeclined
+	}
+
+	err = m.store.WithTx(ctx, func(ctx context.Context) error {
+		if member != nil {
+			if err := m.store.TopicMembers().Create(ctx, member); err != nil {
+				return err
+			}
+		}
+		return m.store.TopicInvitations().Update(ctx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if member != nil && m.auditLogger != nil {
+		_ = m.auditLogger.LogServiceAction(ctx, "CREATE", "topic_member", member.ID, map[string]interface{}{
+			"topic_id": string(request.TopicID),
+			"role":     string(member.Role),
+		})
+	}
+
+	if m.auditLogger != nil {
+		_ = m.auditLogger.LogServiceAction(ctx, "UPDATE", "topic_access_request", request.ID, map[string]interface{}{
+			"topic_id": string(request.TopicID),
+			"status":   string(request.Status),
+			"role":     string(request.Role),
+		})
+	}
+
+	event := EventRequestDenied
+	state := storage.TopicMembershipNone
+	if approve {
+		event = EventRequestApproved
+		state = storage.TopicMembershipMember
+	}
+	m.replicate(request.TopicID, request.InviteeUserID, state, request.Role)
+	m.notifyResponse(ctx, request, event, reviewerID, recipients(request.InviteeUserID))
+
+	return request, nil
+}
+
+// MembershipState returns where a user stands in joining a topic.
+func (m *MembershipManager) MembershipState(ctx context.Context, topicID domain.TopicID, userID domain.UserID) storage.TopicMembershipState {
+	if member, _ := m.store.TopicMembers().Get(ctx, topicID, userID); member != nil {
+		return storage.TopicMembershipMember
+	}
+
+	invitations, err := m.store.TopicInvitations().ListByUser(ctx, userID)
+	if err != nil {
+		return storage.TopicMembershipNone
+	}
+	now := time.Now()
+	for _, inv := range invitations {
+		if inv.TopicID != topicID || inv.Status != storage.InvitationStatusPending || now.After(inv.ExpiresAt) {
+			continue
+		}
+		if inv.Kind == storage.InvitationKindRequest {
+			return storage.TopicMembershipPending
+		}
+		return storage.TopicMembershipInvited
+	}
+	return storage.TopicMembershipNone
+}
+
 // TransferOwnership transfers ownership to another member.
 func (m *MembershipManager) TransferOwnership(ctx context.Context, topicID domain.TopicID, fromID domain.UserID, toID domain.UserID) error {
 	fromRole, err := m.store.TopicMembers().GetRole(ctx, topicID, fromID)
@@ -370,3 +656,79 @@ func (m *MembershipManager) RestoreTopic(ctx context.Context, topicID domain.Top
 
 	return nil
 }
+
+// replicate replicates a user's membership state to the cluster, if any.
+// Only the leader can replicate; elsewhere the state stays local.
+func (m *MembershipManager) replicate(topicID domain.TopicID, userID domain.UserID, state storage.TopicMembershipState, role storage.TopicMemberRole) {
+	if m.replicator == nil {
+		return
+	}
+	_ = m.replicator.ReplicateTopicMembership(&cluster.ReplicatedTopicMembership{
+		TopicID:   string(topicID),
+		UserID:    string(userID),
+		State:     string(state),
+		Role:      string(role),
+		UpdatedAt: time.Now().UTC(),
+	})
+}
+
+// notify delivers n in the background so slow channels never hold up the
+// request.
+func (m *MembershipManager) notify(n *MembershipNotification) {
+	if m.notifier == nil {
+		return
+	}
+	if n.Timestamp.IsZero() {
+		n.Timestamp = time.Now().UTC()
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+		_ = m.notifier.NotifyMembership(ctx, n)
+	}()
+}
+
+// notifyResponse notifies recipients that actor answered invitation.
+func (m *MembershipManager) notifyResponse(ctx context.Context, invitation *storage.TopicInvitation, event MembershipEvent, actor domain.UserID, to []domain.UserID) {
+	if m.notifier == nil {
+		return
+	}
+	n := &MembershipNotification{
+		Event:        event,
+		InvitationID: invitation.ID,
+		TopicID:      invitation.TopicID,
+		Role:         invitation.Role,
+		Actor:        actor,
+		Recipients:   to,
+	}
+	if topic, err := m.store.Topics().Get(ctx, invitation.TopicID); err == nil {
+		n.TopicName = topic.Name
+	}
+	m.notify(n)
+}
+
+// owners returns the owners of topic, from the topic and its members.
+func (m *MembershipManager) owners(ctx context.Context, topic *domain.Topic) []domain.UserID {
+	owners := append([]domain.UserID(nil), topic.Owners...)
+	members, err := m.store.TopicMembers().ListByTopic(ctx, topic.ID, storage.TopicMemberFilter{Role: storage.TopicMemberRoleOwner})
+	if err != nil {
+		return owners
+	}
+	for _, member := range members {
+		if !slices.Contains(owners, member.UserID) {
+			owners = append(owners, member.UserID)
+		}
+	}
+	return owners
+}
+
+// recipients returns the non-empty user IDs.
+func recipients(ids ...domain.UserID) []domain.UserID {
+	var to []domain.UserID
+	for _, id := range ids {
+		if id != "" {
+			to = append(to, id)
+		}
+	}
+	return to
+}
@@ -0,0 +1,268 @@
+package topic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+	"bib/internal/storage/audit"
+)
+
+// MembershipEvent is a change in someone's way into a topic.
+type MembershipEvent string
+
+const (
+	// EventInvited is sent to the invitee.
+	EventInvited MembershipEvent = "invited"
+
+	// EventInvitationAccepted is sent to the inviter.
+	EventInvitationAccepted MembershipEvent = "invitation_accepted"
+
+	// EventInvitationDeclined is sent to the inviter.
+	EventInvitationDeclined MembershipEvent = "invitation_declined"
+
+	// EventAccessRequested is sent to the topic owners.
+	EventAccessRequested MembershipEvent = "access_requested"
+
+	// EventRequestApproved is sent to the requester.
+	EventRequestApproved MembershipEvent = "request_approved"
+
+	// EventRequestDenied is sent to the requester.
+	EventRequestDenied MembershipEvent = "request_denied"
+)
+
+// MembershipNotification tells users about an invitation or access request.
+type MembershipNotification struct {
+	Event        MembershipEvent         `json:"event"`
+	InvitationID string                  `json:"invitation_id"`
+	TopicID      domain.TopicID          `json:"topic_id"`
+	TopicName    string                  `json:"topic_name"`
+	Role         storage.TopicMemberRole `json:"role"`
+	Message      string                  `json:"message,omitempty"`
+
+	// Actor is the user whose action caused the notification.
+	Actor domain.UserID `json:"actor"`
+
+	// Recipients are the users to notify.
+	Recipients []domain.UserID `json:"recipients,omitempty"`
+
+	// Email is an invited address without an account.
+	Email string `json:"email,omitempty"`
+
+	// Token accepts an invitation. It is only ever mailed to the invitee.
+	Token string `json:"-"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier delivers membership notifications.
+type Notifier interface {
+	NotifyMembership(ctx context.Context, n *MembershipNotification) error
+}
+
+// NewNotifier creates a notifier delivering through the webhook and SMTP
+// server of cfg, the channels that carry audit alerts. It returns nil if
+// neither is configured.
+func NewNotifier(cfg audit.AlertNotifyConfig, store storage.Store) Notifier {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = audit.DefaultAlertNotifyConfig().Timeout
+	}
+
+	var notifiers multiNotifier
+	if cfg.Webhook != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(cfg.Webhook, cfg.Timeout))
+	}
+	if cfg.SMTP.Address != "" {
+		notifiers = append(notifiers, NewEmailNotifier(cfg.SMTP, store))
+	}
+	if len(notifiers) == 0 {
+		return nil
+	}
+	return notifiers
+}
+
+// multiNotifier delivers to every notifier.
+type multiNotifier []Notifier
+
+func (m multiNotifier) NotifyMembership(ctx context.Context, n *MembershipNotification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.NotifyMembership(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WebhookNotifier posts membership notifications as JSON to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a webhook notifier.
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// NotifyMembership posts n to the webhook. Any non-2xx response is an error.
+func (w *WebhookNotifier) NotifyMembership(ctx context.Context, n *MembershipNotification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bibd-topic-membership")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// EmailNotifier mails membership notifications to the recipients who
+// enabled email notifications, and to invited addresses.
+type EmailNotifier struct {
+	smtp  audit.SMTPConfig
+	store storage.Store
+
+	// send is smtp.SendMail; tests replace it
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailNotifier creates an email notifier.
+func NewEmailNotifier(cfg audit.SMTPConfig, store storage.Store) *EmailNotifier {
+	return &EmailNotifier{
+		smtp:  cfg,
+		store: store,
+		send:  smtp.SendMail,
+	}
+}
+
+// NotifyMembership mails n to each recipient separately.
+func (e *EmailNotifier) NotifyMembership(ctx context.Context, n *MembershipNotification) error {
+	var auth smtp.Auth
+	if e.smtp.Username != "" {
+		host, _, err := net.SplitHostPort(e.smtp.Address)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %w", e.smtp.Address, err)
+		}
+		auth = smtp.PlainAuth("", e.smtp.Username, e.smtp.Password, host)
+	}
+
+	var errs []error
+	for _, to := range e.addresses(ctx, n) {
+		msg := formatMembershipEmail(e.smtp.From, to, n)
+
+		// smtp.SendMail has no context; give up waiting once ctx is done
+		errc := make(chan error, 1)
+		go func() {
+			errc <- e.send(e.smtp.Address, auth, e.smtp.From, []string{to}, msg)
+		}()
+		select {
+		case err := <-errc:
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to send membership email to %s: %w", to, err))
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// addresses returns the email addresses to notify: the invited address and
+// the recipients with an email who opted into email notifications.
+func (e *EmailNotifier) addresses(ctx context.Context, n *MembershipNotification) []string {
+	var addrs []string
+	if n.Email != "" {
+		addrs = append(addrs, n.Email)
+	}
+	if e.store == nil {
+		return addrs
+	}
+
+	for _, id := range n.Recipients {
+		user, err := e.store.Users().Get(ctx, id)
+		if err != nil || user.Email == "" {
+			continue
+		}
+		prefs, err := e.store.UserPreferences().Get(ctx, id)
+		if err != nil || !prefs.NotificationsEnabled || !prefs.EmailNotifications {
+			continue
+		}
+		addrs = append(addrs, user.Email)
+	}
+	return addrs
+}
+
+// formatMembershipEmail builds the message for n.
+func formatMembershipEmail(from, to string, n *MembershipNotification) []byte {
+	var subject, body string
+	switch n.Event {
+	case EventInvited:
+		subject = fmt.Sprintf("You are invited to topic %s", n.TopicName)
+		body = fmt.Sprintf("%s invited you to join topic %s as %s.", n.Actor, n.TopicName, n.Role)
+	case EventInvitationAccepted:
+		subject = fmt.Sprintf("Invitation to %s accepted", n.TopicName)
+		body = fmt.Sprintf("%s accepted your invitation to topic %s.", n.Actor, n.TopicName)
+	case EventInvitationDeclined:
+		subject = fmt.Sprintf("Invitation to %s declined", n.TopicName)
+		body = fmt.Sprintf("%s declined your invitation to topic %s.", n.Actor, n.TopicName)
+	case EventAccessRequested:
+		subject = fmt.Sprintf("Access requested to %s", n.TopicName)
+		body = fmt.Sprintf("%s asks to join topic %s as %s.", n.Actor, n.TopicName, n.Role)
+	case EventRequestApproved:
+		subject = fmt.Sprintf("Access to %s approved", n.TopicName)
+		body = fmt.Sprintf("Your request to join topic %s was approved. You are now a %s.", n.TopicName, n.Role)
+	case EventRequestDenied:
+		subject = fmt.Sprintf("Access to %s denied", n.TopicName)
+		body = fmt.Sprintf("Your request to join topic %s was denied.", n.TopicName)
+	default:
+		subject = fmt.Sprintf("Membership of %s changed", n.TopicName)
+		body = fmt.Sprintf("Your membership of topic %s changed: %s.", n.TopicName, n.Event)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: [bib] %s\r\n", subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", n.Timestamp.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+
+	fmt.Fprintf(&b, "%s\r\n", body)
+	if n.Message != "" {
+		fmt.Fprintf(&b, "\r\n%s\r\n", n.Message)
+	}
+	switch n.Event {
+	case EventInvited:
+		fmt.Fprintf(&b, "\r\nTo accept, run:\r\n\r\n  bib topic accept --token %s\r\n", n.Token)
+	case EventAccessRequested:
+		fmt.Fprintf(&b, "\r\nTo review it, run:\r\n\r\n  bib topic approve %s\r\n  bib topic deny %s\r\n", n.InvitationID, n.InvitationID)
+	}
+
+	return []byte(b.String())
+}
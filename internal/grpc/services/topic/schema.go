@@ -0,0 +1,41 @@
+package topic
+
+import (
+	bibv1 "bib/api/gen/go/bib/v1"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/jsonschema"
+)
+
+// dataSchemaFromRequest converts and checks a data schema set on a topic.
+// The schema is compiled here so a broken one is rejected up front rather
+// than on the next upload to the topic.
+func dataSchemaFromRequest(p *bibv1.DataSchema) (*domain.DataSchema, error) {
+	if p == nil {
+		return nil, nil
+	}
+	mode := domain.SchemaMode(p.GetMode())
+	if mode == "" {
+		mode = domain.SchemaModeEnforce
+	}
+	ds := &domain.DataSchema{JSONSchema: p.GetJsonSchema(), Mode: mode}
+
+	if err := ds.Validate(); err != nil {
+		return nil, grpcerrors.NewValidationError("invalid data schema", map[string]string{
+			"data_schema": err.Error(),
+		})
+	}
+	if _, err := jsonschema.Compile([]byte(ds.JSONSchema)); err != nil {
+		return nil, grpcerrors.NewValidationError("invalid data schema", map[string]string{
+			"data_schema.json_schema": err.Error(),
+		})
+	}
+	return ds, nil
+}
+
+func dataSchemaToProto(ds *domain.DataSchema) *bibv1.DataSchema {
+	if ds == nil {
+		return nil
+	}
+	return &bibv1.DataSchema{JsonSchema: ds.JSONSchema, Mode: string(ds.Mode)}
+}
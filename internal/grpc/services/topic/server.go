@@ -26,6 +26,13 @@ type Config struct {
 	Store       storage.Store
 	AuditLogger interfaces.AuditLogger
 	NodeMode    string // "full", "selective", "proxy"
+
+	// Notifier delivers invitation and access request notifications
+	// (optional).
+	Notifier Notifier
+
+	// Replicator replicates membership state across the cluster (optional).
+	Replicator Replicator
 }
 
 // Server implements the TopicService gRPC service.
@@ -34,6 +41,7 @@ type Server struct {
 	store       storage.Store
 	auditLogger interfaces.AuditLogger
 	nodeMode    string
+	members     *MembershipManager
 }
 
 // NewServer creates a new topic service server.
@@ -43,10 +51,15 @@ func NewServer() *Server {
 
 // NewServerWithConfig creates a new topic service server with dependencies.
 func NewServerWithConfig(cfg Config) *Server {
+	members := NewMembershipManager(cfg.Store, cfg.AuditLogger)
+	members.notifier = cfg.Notifier
+	members.replicator = cfg.Replicator
+
 	return &Server{
 		store:       cfg.Store,
 		auditLogger: cfg.AuditLogger,
 		nodeMode:    cfg.NodeMode,
+		members:     members,
 	}
 }
 
@@ -74,6 +87,11 @@ func (s *Server) CreateTopic(ctx context.Context, req *services.CreateTopicReque
 		})
 	}
 
+	dataSchema, err := dataSchemaFromRequest(req.GetDataSchema())
+	if err != nil {
+		return nil, err
+	}
+
 	topic := &domain.Topic{
 		ID:          domain.TopicID(uuid.New().String()),
 		Name:        req.Name,
@@ -86,10 +104,7 @@ func (s *Server) CreateTopic(ctx context.Context, req *services.CreateTopicReque
 		UpdatedAt:   time.Now().UTC(),
 		Tags:        req.Tags,
 		Metadata:    req.Metadata,
-	}
-
-	if err := s.store.Topics().Create(ctx, topic); err != nil {
-		return nil, grpcerrors.MapDomainError(err)
+		DataSchema:  dataSchema,
 	}
 
 	member := &storage.TopicMember{
@@ -104,7 +119,17 @@ func (s *Server) CreateTopic(ctx context.Context, req *services.CreateTopicReque
 	}
 	now := time.Now().UTC()
 	member.AcceptedAt = &now
-	_ = s.store.TopicMembers().Create(ctx, member)
+
+	// The topic and its owner membership are created together
+	err = s.store.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.store.Topics().Create(ctx, topic); err != nil {
+			return err
+		}
+		return s.store.TopicMembers().Create(ctx, member)
+	})
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
 
 	if s.auditLogger != nil {
 		_ = s.auditLogger.LogServiceAction(ctx, "CREATE", "topic", string(topic.ID), map[string]interface{}{
@@ -147,7 +172,8 @@ func (s *Server) GetTopic(ctx context.Context, req *services.GetTopicRequest) (*
 	}
 
 	resp := &services.GetTopicResponse{
-		Topic: topicToProto(topic),
+		Topic:           topicToProto(topic),
+		MembershipState: string(storage.TopicMembershipNone),
 	}
 
 	if user != nil {
@@ -156,6 +182,7 @@ func (s *Server) GetTopic(ctx context.Context, req *services.GetTopicRequest) (*
 			resp.Subscribed = true
 			resp.Subscription = memberToSubscription(member, topic)
 		}
+		resp.MembershipState = string(s.members.MembershipState(ctx, topic.ID, user.ID))
 	}
 
 	return resp, nil
@@ -202,7 +229,7 @@ func (s *Server) ListTopics(ctx context.Context, req *services.ListTopicsRequest
 
 	visibleTopics := make([]*domain.Topic, 0, len(topics))
 	for _, t := range topics {
-		if req.PublicOnly && !s.isPublicTopic(t) {
+		if req.PublicOnly && !t.IsPublic() {
 			continue
 		}
 		if req.SubscribedOnly {
@@ -262,6 +289,9 @@ func (s *Server) UpdateTopic(ctx context.Context, req *services.UpdateTopicReque
 	if err != nil || role != storage.TopicMemberRoleOwner {
 		return nil, grpcerrors.NewPermissionDeniedError("update", "topic", "owner")
 	}
+	if err := checkRevision(topic, req.ExpectedRevision); err != nil {
+		return nil, err
+	}
 
 	if req.Name != nil {
 		topic.Name = *req.Name
@@ -278,6 +308,11 @@ func (s *Server) UpdateTopic(ctx context.Context, req *services.UpdateTopicReque
 	if req.UpdateMetadata {
 		topic.Metadata = req.Metadata
 	}
+	if req.UpdateDataSchema {
+		if topic.DataSchema, err = dataSchemaFromRequest(req.GetDataSchema()); err != nil {
+			return nil, err
+		}
+	}
 
 	topic.UpdatedAt = time.Now().UTC()
 
@@ -285,8 +320,8 @@ func (s *Server) UpdateTopic(ctx context.Context, req *services.UpdateTopicReque
 		return nil, grpcerrors.MapDomainError(err)
 	}
 
-	if err := s.store.Topics().Update(ctx, topic); err != nil {
-		return nil, grpcerrors.MapDomainError(err)
+	if err := s.updateTopic(ctx, topic); err != nil {
+		return nil, err
 	}
 
 	if s.auditLogger != nil {
@@ -324,16 +359,19 @@ func (s *Server) DeleteTopic(ctx context.Context, req *services.DeleteTopicReque
 	if err != nil || role != storage.TopicMemberRoleOwner {
 		return nil, grpcerrors.NewPermissionDeniedError("delete", "topic", "owner")
 	}
+	if err := checkRevision(topic, req.ExpectedRevision); err != nil {
+		return nil, err
+	}
 
 	// Check if has datasets and force flag
 	if topic.DatasetCount > 0 && !req.Force {
-		return nil, grpcerrors.NewPreconditionError("topic has datasets", map[string]string{
+		return nil, grpcerrors.NewPreconditionError(grpcerrors.SubreasonTopicNotEmpty, "topic has datasets", map[string]string{
 			"dataset_count": "topic has datasets, use force=true to delete anyway",
 		})
 	}
 
-	if err := s.store.Topics().Delete(ctx, topic.ID); err != nil {
-		return nil, grpcerrors.MapDomainError(err)
+	if err := s.deleteTopic(ctx, topic); err != nil {
+		return nil, err
 	}
 
 	if s.auditLogger != nil {
@@ -376,7 +414,7 @@ func (s *Server) Subscribe(ctx context.Context, req *services.SubscribeRequest)
 	}
 
 	if !s.canAccessTopic(ctx, topic, user) {
-		return nil, grpcerrors.NewPermissionDeniedError("subscribe", "topic", "member")
+		return nil, grpcerrors.NewPermissionDeniedError("subscribe", "topic", "read")
 	}
 
 	existing, _ := s.store.TopicMembers().Get(ctx, topic.ID, user.ID)
@@ -436,7 +474,7 @@ func (s *Server) Unsubscribe(ctx context.Context, req *services.UnsubscribeReque
 	if role == storage.TopicMemberRoleOwner {
 		count, _ := s.store.TopicMembers().CountOwners(ctx, topicID)
 		if count <= 1 {
-			return nil, grpcerrors.NewPreconditionError("cannot unsubscribe as last owner", map[string]string{
+			return nil, grpcerrors.NewPreconditionError(grpcerrors.SubreasonLastOwner, "cannot unsubscribe as last owner", map[string]string{
 				"owner": "you are the last owner, transfer ownership first",
 			})
 		}
@@ -595,7 +633,7 @@ func (s *Server) SearchTopics(ctx context.Context, req *services.SearchTopicsReq
 
 	visibleTopics := make([]*domain.Topic, 0, len(topics))
 	for _, t := range topics {
-		if req.PublicOnly && !s.isPublicTopic(t) {
+		if req.PublicOnly && !t.IsPublic() {
 			continue
 		}
 		if s.canAccessTopic(ctx, t, user) {
@@ -622,30 +660,47 @@ func (s *Server) SearchTopics(ctx context.Context, req *services.SearchTopicsReq
 
 // Helper methods
 
+// canAccessTopic reports whether user may read topic. A topic with an ACL
+// is never public: reading it requires a grant or membership.
 func (s *Server) canAccessTopic(ctx context.Context, topic *domain.Topic, user *domain.User) bool {
-	if s.isPublicTopic(topic) {
+	if !topic.IsRestricted() && topic.IsPublic() {
 		return true
 	}
-	if user == nil {
-		return false
-	}
-	if user.Role == domain.UserRoleAdmin {
-		return true
+	return s.permission(ctx, topic, user).Includes(domain.TopicPermissionRead)
+}
+
+// checkRevision fails with a revision conflict unless expected is 0 or the
+// revision topic has
+func checkRevision(topic *domain.Topic, expected int64) error {
+	if expected != 0 && expected != topic.Revision {
+		return grpcerrors.NewRevisionConflictError("topic", string(topic.ID), expected, topic.Revision)
 	}
-	hasAccess, _ := s.store.TopicMembers().HasAccess(ctx, topic.ID, user.ID)
-	return hasAccess
+	return nil
 }
 
-func (s *Server) isPublicTopic(topic *domain.Topic) bool {
-	if topic.Metadata != nil {
-		if val, ok := topic.Metadata["is_public"]; ok {
-			return val == "true"
+// updateTopic stores topic. If it changed since it was read, the revision
+// conflict reports the revision the concurrent update left behind.
+func (s *Server) updateTopic(ctx context.Context, topic *domain.Topic) error {
+	expected := topic.Revision
+	err := s.store.Topics().Update(ctx, topic)
+	if storage.IsConflict(err) {
+		if current, getErr := s.store.Topics().Get(ctx, topic.ID); getErr == nil {
+			return grpcerrors.NewRevisionConflictError("topic", string(topic.ID), expected, current.Revision)
 		}
-		if val, ok := topic.Metadata["public"]; ok {
-			return val == "true"
+	}
+	return grpcerrors.MapDomainError(err)
+}
+
+// deleteTopic deletes topic at the revision it was read at. If it changed
+// since, the revision conflict reports the current revision.
+func (s *Server) deleteTopic(ctx context.Context, topic *domain.Topic) error {
+	err := s.store.Topics().Delete(ctx, topic.ID, topic.Revision)
+	if storage.IsConflict(err) {
+		if current, getErr := s.store.Topics().Get(ctx, topic.ID); getErr == nil {
+			return grpcerrors.NewRevisionConflictError("topic", string(topic.ID), topic.Revision, current.Revision)
 		}
 	}
-	return true // Default to public
+	return grpcerrors.MapDomainError(err)
 }
 
 // Conversion helpers
@@ -660,8 +715,8 @@ func topicToProto(t *domain.Topic) *services.Topic {
 		ownerId = string(t.Owners[0])
 	}
 
-	isPublic := true
-	if t.Metadata != nil {
+	isPublic := !t.IsRestricted()
+	if isPublic && t.Metadata != nil {
 		if val, ok := t.Metadata["is_public"]; ok {
 			isPublic = val == "true"
 		} else if val, ok := t.Metadata["public"]; ok {
@@ -682,6 +737,9 @@ func topicToProto(t *domain.Topic) *services.Topic {
 		UpdatedAt:    timestamppb.New(t.UpdatedAt),
 		Tags:         t.Tags,
 		Metadata:     t.Metadata,
+		Restricted:   t.IsRestricted(),
+		DataSchema:   dataSchemaToProto(t.DataSchema),
+		Revision:     t.Revision,
 	}
 }
 
@@ -2,6 +2,8 @@
 package user
 
 import (
+	"strconv"
+
 	"bib/internal/domain"
 	"bib/internal/storage"
 
@@ -111,6 +113,37 @@ func prefsToProto(p *storage.UserPreferences) *services.UserPreferences {
 	}
 }
 
+// Keys of the CLI preferences in UserPreferences.Custom
+const (
+	cliOutputFormatKey = "cli.output_format"
+	cliColorKey        = "cli.color"
+	cliLocaleKey       = "cli.locale"
+)
+
+// validOutputFormats are the output formats bib accepts in its config
+var validOutputFormats = map[string]bool{"text": true, "json": true, "yaml": true, "table": true}
+
+// cliPrefsToProto extracts the CLI preferences from the custom preferences
+func cliPrefsToProto(p *storage.UserPreferences) *services.CLIPreferences {
+	if p == nil {
+		return nil
+	}
+
+	proto := &services.CLIPreferences{
+		OutputFormat: p.Custom[cliOutputFormatKey],
+		Locale:       p.Custom[cliLocaleKey],
+	}
+	if v, ok := p.Custom[cliColorKey]; ok {
+		if color, err := strconv.ParseBool(v); err == nil {
+			proto.Color = &color
+		}
+	}
+	if !p.UpdatedAt.IsZero() {
+		proto.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+	return proto
+}
+
 func sessionToProto(s *storage.Session) *services.Session {
 	if s == nil {
 		return nil
@@ -136,6 +169,16 @@ func sessionToProto(s *storage.Session) *services.Session {
 	return proto
 }
 
+func userKeyToProto(k *domain.UserPublicKey) *services.UserPublicKey {
+	return &services.UserPublicKey{
+		Fingerprint: k.Fingerprint,
+		PublicKey:   k.PublicKey,
+		KeyType:     string(k.KeyType),
+		Label:       k.Label,
+		CreatedAt:   timestamppb.New(k.CreatedAt),
+	}
+}
+
 func sessionTypeToProto(t storage.SessionType) services.SessionType {
 	switch t {
 	case storage.SessionTypeSSH:
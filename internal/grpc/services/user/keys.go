@@ -0,0 +1,185 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	services "bib/api/gen/go/bib/v1/services"
+	"bib/internal/domain"
+	grpcerrors "bib/internal/grpc/errors"
+	"bib/internal/grpc/middleware"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AddPublicKey registers an additional identity key for a user.
+func (s *Server) AddPublicKey(ctx context.Context, req *services.AddPublicKeyRequest) (*services.AddPublicKeyResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	userID, err := keyOwner(ctx, req.UserId, "add key to")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.PublicKey) == 0 {
+		return nil, grpcerrors.NewValidationError("public_key is required", map[string]string{
+			"public_key": "must not be empty",
+		})
+	}
+	if req.Label == "" {
+		return nil, grpcerrors.NewValidationError("label is required", map[string]string{
+			"label": "must name the device the key belongs to",
+		})
+	}
+
+	if _, err := s.store.Users().Get(ctx, userID); err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	// A key identifies exactly one user, whether as a primary or an
+	// additional key
+	if _, err := s.store.Users().GetByPublicKey(ctx, req.PublicKey); err == nil {
+		return nil, grpcerrors.MapDomainError(domain.ErrKeyExists)
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	keyType := domain.KeyType(req.KeyType)
+	if keyType == "" {
+		keyType = domain.KeyTypeEd25519
+	}
+
+	key := domain.NewUserPublicKey(userID, req.PublicKey, keyType, req.Label)
+	if err := s.store.Users().AddPublicKey(ctx, key); err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "CREATE", "user_key", key.Fingerprint, map[string]interface{}{
+			"user_id": string(userID),
+			"label":   key.Label,
+		})
+	}
+
+	return &services.AddPublicKeyResponse{
+		Key: userKeyToProto(key),
+	}, nil
+}
+
+// ListPublicKeys lists a user's identity keys, primary key first.
+func (s *Server) ListPublicKeys(ctx context.Context, req *services.ListPublicKeysRequest) (*services.ListPublicKeysResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	userID, err := keyOwner(ctx, req.UserId, "list keys of")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.Users().Get(ctx, userID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	keys, err := s.store.Users().ListPublicKeys(ctx, userID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	protoKeys := make([]*services.UserPublicKey, 0, len(keys)+1)
+	protoKeys = append(protoKeys, &services.UserPublicKey{
+		Fingerprint: user.PublicKeyFingerprint,
+		PublicKey:   user.PublicKey,
+		KeyType:     string(user.KeyType),
+		Primary:     true,
+		CreatedAt:   timestamppb.New(user.CreatedAt),
+	})
+	for _, key := range keys {
+		protoKeys = append(protoKeys, userKeyToProto(key))
+	}
+
+	return &services.ListPublicKeysResponse{
+		Keys: protoKeys,
+	}, nil
+}
+
+// RemovePublicKey revokes an additional identity key and ends the sessions
+// that were authenticated with it.
+func (s *Server) RemovePublicKey(ctx context.Context, req *services.RemovePublicKeyRequest) (*services.RemovePublicKeyResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	userID, err := keyOwner(ctx, req.UserId, "remove key from")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Fingerprint == "" {
+		return nil, grpcerrors.NewValidationError("fingerprint is required", map[string]string{
+			"fingerprint": "must not be empty",
+		})
+	}
+
+	user, err := s.store.Users().Get(ctx, userID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	if req.Fingerprint == user.PublicKeyFingerprint {
+		return nil, grpcerrors.MapDomainError(domain.ErrPrimaryKey)
+	}
+
+	if err := s.store.Users().RemovePublicKey(ctx, userID, req.Fingerprint); err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	// The key no longer authenticates, so neither should sessions opened
+	// with it
+	var ended int32
+	sessions, err := s.store.Sessions().GetByUser(ctx, userID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	for _, sess := range sessions {
+		if sess.PublicKeyFingerprint != req.Fingerprint {
+			continue
+		}
+		if err := s.store.Sessions().End(ctx, sess.ID); err == nil {
+			ended++
+		}
+	}
+
+	if s.auditLogger != nil {
+		_ = s.auditLogger.LogServiceAction(ctx, "DELETE", "user_key", req.Fingerprint, map[string]interface{}{
+			"user_id":        string(userID),
+			"sessions_ended": ended,
+		})
+	}
+
+	return &services.RemovePublicKeyResponse{
+		Success:       true,
+		SessionsEnded: ended,
+	}, nil
+}
+
+// keyOwner resolves the user whose keys are managed. An empty user ID means
+// the caller; managing another user's keys requires admin.
+func keyOwner(ctx context.Context, userID, action string) (domain.UserID, error) {
+	caller, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	if userID == "" || domain.UserID(userID) == caller.ID {
+		return caller.ID, nil
+	}
+	if !caller.IsAdmin() {
+		return "", grpcerrors.NewPermissionDeniedError(action, "user "+userID, string(domain.UserRoleAdmin))
+	}
+	return domain.UserID(userID), nil
+}
@@ -3,6 +3,8 @@ package user
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	bibv1 "bib/api/gen/go/bib/v1"
 	services "bib/api/gen/go/bib/v1/services"
@@ -564,6 +566,75 @@ func (s *Server) UpdateUserPreferences(ctx context.Context, req *services.Update
 	}, nil
 }
 
+// GetPreferences retrieves the current user's CLI preferences.
+func (s *Server) GetPreferences(ctx context.Context, req *services.GetPreferencesRequest) (*services.GetPreferencesResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	prefs, err := s.store.UserPreferences().Get(ctx, user.ID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+
+	return &services.GetPreferencesResponse{
+		Preferences: cliPrefsToProto(prefs),
+	}, nil
+}
+
+// SetPreferences stores the current user's CLI preferences. They are kept
+// in the custom preferences so they don't affect the profile settings.
+func (s *Server) SetPreferences(ctx context.Context, req *services.SetPreferencesRequest) (*services.SetPreferencesResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.Unavailable, "service not initialized")
+	}
+
+	user, ok := middleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	in := req.GetPreferences()
+	if in == nil {
+		return nil, status.Error(codes.InvalidArgument, "preferences are required")
+	}
+	if in.OutputFormat != "" && !validOutputFormats[in.OutputFormat] {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid output format %q", in.OutputFormat)
+	}
+
+	prefs, err := s.store.UserPreferences().Get(ctx, user.ID)
+	if err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	if prefs.Custom == nil {
+		prefs.Custom = make(map[string]string)
+	}
+
+	if in.OutputFormat != "" {
+		prefs.Custom[cliOutputFormatKey] = in.OutputFormat
+	}
+	if in.Color != nil {
+		prefs.Custom[cliColorKey] = strconv.FormatBool(in.GetColor())
+	}
+	if in.Locale != "" {
+		prefs.Custom[cliLocaleKey] = in.Locale
+	}
+
+	if err := s.store.UserPreferences().Upsert(ctx, prefs); err != nil {
+		return nil, grpcerrors.MapDomainError(err)
+	}
+	prefs.UpdatedAt = time.Now().UTC()
+
+	return &services.SetPreferencesResponse{
+		Preferences: cliPrefsToProto(prefs),
+	}, nil
+}
+
 // ListUserSessions lists sessions for a user.
 func (s *Server) ListUserSessions(ctx context.Context, req *services.ListUserSessionsRequest) (*services.ListUserSessionsResponse, error) {
 	if s.store == nil {
@@ -0,0 +1,62 @@
+package jsonschema
+
+import (
+	"crypto/sha256"
+	"sync"
+)
+
+// Cache keeps compiled schemas by content, so a schema shared by many
+// datasets is compiled once. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	max     int
+	schemas map[[sha256.Size]byte]*Schema
+}
+
+// NewCache creates a cache holding at most max schemas.
+func NewCache(max int) *Cache {
+	if max <= 0 {
+		max = 1
+	}
+	return &Cache{
+		max:     max,
+		schemas: make(map[[sha256.Size]byte]*Schema),
+	}
+}
+
+// Compile returns the compiled schema for src, compiling it on first use.
+// Schemas that fail to compile are not cached.
+func (c *Cache) Compile(src string) (*Schema, error) {
+	key := sha256.Sum256([]byte(src))
+
+	c.mu.Lock()
+	s, ok := c.schemas[key]
+	c.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	s, err := Compile([]byte(src))
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.schemas) >= c.max {
+		// Schemas change rarely; dropping an arbitrary one is good enough
+		for k := range c.schemas {
+			delete(c.schemas, k)
+			break
+		}
+	}
+	c.schemas[key] = s
+	return s, nil
+}
+
+// Len returns the number of cached schemas.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.schemas)
+}
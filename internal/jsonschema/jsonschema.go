@@ -0,0 +1,717 @@
+// Package jsonschema validates JSON documents against a JSON Schema.
+//
+// It implements the validation vocabulary of JSON Schema draft 2020-12:
+// type, enum, const, the numeric, string, array and object constraints,
+// the allOf/anyOf/oneOf/not combinators and local $ref ("#", "#/$defs/...",
+// "#/definitions/..."). Annotations, "format" and unknown keywords are
+// ignored, as the specification allows.
+//
+// Documents are expected to be decoded with json.Decoder.UseNumber, so
+// numbers keep their precision; float64 values are accepted as well.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a compiled JSON Schema. It is safe for concurrent use.
+type Schema struct {
+	root *node
+}
+
+// Violation is one way a document does not match a schema.
+type Violation struct {
+	// Path is the JSON Pointer to the offending value ("" for the root).
+	Path string `json:"path"`
+
+	// Message describes the violation.
+	Message string `json:"message"`
+}
+
+// String returns the violation as "path: message".
+func (v Violation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + v.Message
+}
+
+// Compile parses and compiles a JSON Schema.
+func Compile(src []byte) (*Schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid schema JSON: unexpected data after the schema")
+	}
+
+	c := &compiler{doc: doc, refs: make(map[string]*node)}
+	root, err := c.compile(doc, "#")
+	if err != nil {
+		return nil, err
+	}
+	if err := c.resolve(); err != nil {
+		return nil, err
+	}
+	return &Schema{root: root}, nil
+}
+
+// Validate returns the violations of doc, or nil if it matches.
+func (s *Schema) Validate(doc any) []Violation {
+	var out []Violation
+	s.root.validate(doc, "", &out)
+	return out
+}
+
+// node is one compiled (sub)schema.
+type node struct {
+	// always is set for the boolean schemas true and false
+	always *bool
+
+	types    []string
+	enum     []any
+	constVal any
+	hasConst bool
+
+	minimum, maximum                   *float64
+	exclusiveMinimum, exclusiveMaximum *float64
+	multipleOf                         *float64
+
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+
+	items       *node
+	prefixItems []*node
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	properties           map[string]*node
+	patternProperties    map[string]*node
+	patternRegexps       map[string]*regexp.Regexp
+	additionalProperties *node
+	required             []string
+	minProperties        *int
+	maxProperties        *int
+
+	allOf, anyOf, oneOf []*node
+	not                 *node
+
+	ref    string
+	target *node
+}
+
+// compiler compiles a schema document, resolving $ref once every node
+// exists so that recursive schemas work.
+type compiler struct {
+	doc     any
+	refs    map[string]*node
+	pending []*node
+}
+
+func (c *compiler) compile(v any, loc string) (*node, error) {
+	n := &node{}
+
+	if b, ok := v.(bool); ok {
+		n.always = &b
+		return n, nil
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or a boolean", loc)
+	}
+
+	var err error
+	if ref, ok := m["$ref"]; ok {
+		s, ok := ref.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s/$ref: must be a string", loc)
+		}
+		if !strings.HasPrefix(s, "#") {
+			return nil, fmt.Errorf("%s/$ref: only local references (#...) are supported, got %q", loc, s)
+		}
+		n.ref = s
+		c.pending = append(c.pending, n)
+	}
+
+	if t, ok := m["type"]; ok {
+		switch t := t.(type) {
+		case string:
+			n.types = []string{t}
+		case []any:
+			for _, e := range t {
+				s, ok := e.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s/type: must be a string or an array of strings", loc)
+				}
+				n.types = append(n.types, s)
+			}
+		default:
+			return nil, fmt.Errorf("%s/type: must be a string or an array of strings", loc)
+		}
+		for _, t := range n.types {
+			switch t {
+			case "null", "boolean", "object", "array", "number", "integer", "string":
+			default:
+				return nil, fmt.Errorf("%s/type: unknown type %q", loc, t)
+			}
+		}
+	}
+
+	if e, ok := m["enum"]; ok {
+		arr, ok := e.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/enum: must be an array", loc)
+		}
+		n.enum = arr
+	}
+	if cv, ok := m["const"]; ok {
+		n.constVal, n.hasConst = cv, true
+	}
+
+	for key, dst := range map[string]**float64{
+		"minimum":          &n.minimum,
+		"maximum":          &n.maximum,
+		"exclusiveMinimum": &n.exclusiveMinimum,
+		"exclusiveMaximum": &n.exclusiveMaximum,
+		"multipleOf":       &n.multipleOf,
+	} {
+		if *dst, err = numberKeyword(m, key, loc); err != nil {
+			return nil, err
+		}
+	}
+	if n.multipleOf != nil && *n.multipleOf <= 0 {
+		return nil, fmt.Errorf("%s/multipleOf: must be greater than 0", loc)
+	}
+
+	for key, dst := range map[string]**int{
+		"minLength":     &n.minLength,
+		"maxLength":     &n.maxLength,
+		"minItems":      &n.minItems,
+		"maxItems":      &n.maxItems,
+		"minProperties": &n.minProperties,
+		"maxProperties": &n.maxProperties,
+	} {
+		if *dst, err = countKeyword(m, key, loc); err != nil {
+			return nil, err
+		}
+	}
+
+	if p, ok := m["pattern"]; ok {
+		s, ok := p.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s/pattern: must be a string", loc)
+		}
+		if n.pattern, err = regexp.Compile(s); err != nil {
+			return nil, fmt.Errorf("%s/pattern: %w", loc, err)
+		}
+	}
+
+	if u, ok := m["uniqueItems"]; ok {
+		b, ok := u.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s/uniqueItems: must be a boolean", loc)
+		}
+		n.uniqueItems = b
+	}
+
+	if items, ok := m["items"]; ok {
+		if n.items, err = c.compile(items, loc+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if n.prefixItems, err = c.compileList(m, "prefixItems", loc); err != nil {
+		return nil, err
+	}
+
+	if props, ok := m["properties"]; ok {
+		pm, ok := props.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/properties: must be an object", loc)
+		}
+		n.properties = make(map[string]*node, len(pm))
+		for name, sub := range pm {
+			if n.properties[name], err = c.compile(sub, loc+"/properties/"+escape(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if props, ok := m["patternProperties"]; ok {
+		pm, ok := props.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/patternProperties: must be an object", loc)
+		}
+		n.patternProperties = make(map[string]*node, len(pm))
+		n.patternRegexps = make(map[string]*regexp.Regexp, len(pm))
+		for pattern, sub := range pm {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s/patternProperties: %w", loc, err)
+			}
+			n.patternRegexps[pattern] = re
+			if n.patternProperties[pattern], err = c.compile(sub, loc+"/patternProperties/"+escape(pattern)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if ap, ok := m["additionalProperties"]; ok {
+		if n.additionalProperties, err = c.compile(ap, loc+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if r, ok := m["required"]; ok {
+		arr, ok := r.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s/required: must be an array of strings", loc)
+		}
+		for _, e := range arr {
+			s, ok := e.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s/required: must be an array of strings", loc)
+			}
+			n.required = append(n.required, s)
+		}
+	}
+
+	if n.allOf, err = c.compileList(m, "allOf", loc); err != nil {
+		return nil, err
+	}
+	if n.anyOf, err = c.compileList(m, "anyOf", loc); err != nil {
+		return nil, err
+	}
+	if n.oneOf, err = c.compileList(m, "oneOf", loc); err != nil {
+		return nil, err
+	}
+	if not, ok := m["not"]; ok {
+		if n.not, err = c.compile(not, loc+"/not"); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+func (c *compiler) compileList(m map[string]any, key, loc string) ([]*node, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+	arr, ok := v.([]any)
+	if !ok || len(arr) == 0 {
+		return nil, fmt.Errorf("%s/%s: must be a non-empty array", loc, key)
+	}
+	nodes := make([]*node, len(arr))
+	for i, sub := range arr {
+		n, err := c.compile(sub, fmt.Sprintf("%s/%s/%d", loc, key, i))
+		if err != nil {
+			return nil, err
+		}
+		nodes[i] = n
+	}
+	return nodes, nil
+}
+
+// resolve links every $ref to the schema it points to. Targets are
+// compiled on first use; the pending list grows while targets are compiled.
+func (c *compiler) resolve() error {
+	for i := 0; i < len(c.pending); i++ {
+		n := c.pending[i]
+		if target, ok := c.refs[n.ref]; ok {
+			n.target = target
+			continue
+		}
+
+		v, err := lookup(c.doc, n.ref)
+		if err != nil {
+			return err
+		}
+		target, err := c.compile(v, n.ref)
+		if err != nil {
+			return err
+		}
+		c.refs[n.ref] = target
+		n.target = target
+	}
+	return nil
+}
+
+// lookup follows a "#/a/b" JSON Pointer fragment into doc.
+func lookup(doc any, ref string) (any, error) {
+	ptr := strings.TrimPrefix(ref, "#")
+	if ptr == "" {
+		return doc, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, fmt.Errorf("$ref %q: only JSON Pointer fragments are supported", ref)
+	}
+
+	cur := doc
+	for _, tok := range strings.Split(ptr[1:], "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[tok]
+			if !ok {
+				return nil, fmt.Errorf("$ref %q: not found", ref)
+			}
+			cur = next
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("$ref %q: not found", ref)
+			}
+			cur = v[i]
+		default:
+			return nil, fmt.Errorf("$ref %q: not found", ref)
+		}
+	}
+	return cur, nil
+}
+
+func numberKeyword(m map[string]any, key, loc string) (*float64, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: must be a number", loc, key)
+	}
+	return &f, nil
+}
+
+func countKeyword(m map[string]any, key, loc string) (*int, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, nil
+	}
+	f, ok := toFloat(v)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return nil, fmt.Errorf("%s/%s: must be a non-negative integer", loc, key)
+	}
+	i := int(f)
+	return &i, nil
+}
+
+func (n *node) validate(v any, path string, out *[]Violation) {
+	if n.always != nil {
+		if !*n.always {
+			add(out, path, "no value is allowed here")
+		}
+		return
+	}
+
+	if n.target != nil {
+		n.target.validate(v, path, out)
+	}
+
+	if len(n.types) > 0 && !n.matchesType(v) {
+		add(out, path, fmt.Sprintf("expected %s, got %s", strings.Join(n.types, " or "), typeOf(v)))
+		// The remaining keywords would only repeat the type mismatch
+		return
+	}
+
+	if n.enum != nil {
+		found := false
+		for _, e := range n.enum {
+			if equal(v, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add(out, path, "must be one of "+formatValues(n.enum))
+		}
+	}
+	if n.hasConst && !equal(v, n.constVal) {
+		add(out, path, "must be "+formatValue(n.constVal))
+	}
+
+	switch v := v.(type) {
+	case string:
+		n.validateString(v, path, out)
+	case []any:
+		n.validateArray(v, path, out)
+	case map[string]any:
+		n.validateObject(v, path, out)
+	default:
+		if f, ok := toFloat(v); ok {
+			n.validateNumber(f, path, out)
+		}
+	}
+
+	for _, sub := range n.allOf {
+		sub.validate(v, path, out)
+	}
+	if len(n.anyOf) > 0 {
+		matched := false
+		for _, sub := range n.anyOf {
+			if sub.matches(v) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			add(out, path, "must match at least one schema of anyOf")
+		}
+	}
+	if len(n.oneOf) > 0 {
+		matched := 0
+		for _, sub := range n.oneOf {
+			if sub.matches(v) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			add(out, path, fmt.Sprintf("must match exactly one schema of oneOf, matched %d", matched))
+		}
+	}
+	if n.not != nil && n.not.matches(v) {
+		add(out, path, "must not match the schema of not")
+	}
+}
+
+func (n *node) matches(v any) bool {
+	var out []Violation
+	n.validate(v, "", &out)
+	return len(out) == 0
+}
+
+func (n *node) matchesType(v any) bool {
+	actual := typeOf(v)
+	for _, t := range n.types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *node) validateNumber(f float64, path string, out *[]Violation) {
+	if n.minimum != nil && f < *n.minimum {
+		add(out, path, fmt.Sprintf("must be >= %v", *n.minimum))
+	}
+	if n.maximum != nil && f > *n.maximum {
+		add(out, path, fmt.Sprintf("must be <= %v", *n.maximum))
+	}
+	if n.exclusiveMinimum != nil && f <= *n.exclusiveMinimum {
+		add(out, path, fmt.Sprintf("must be > %v", *n.exclusiveMinimum))
+	}
+	if n.exclusiveMaximum != nil && f >= *n.exclusiveMaximum {
+		add(out, path, fmt.Sprintf("must be < %v", *n.exclusiveMaximum))
+	}
+	if n.multipleOf != nil {
+		q := f / *n.multipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			add(out, path, fmt.Sprintf("must be a multiple of %v", *n.multipleOf))
+		}
+	}
+}
+
+func (n *node) validateString(s, path string, out *[]Violation) {
+	length := utf8.RuneCountInString(s)
+	if n.minLength != nil && length < *n.minLength {
+		add(out, path, fmt.Sprintf("must be at least %d characters long", *n.minLength))
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		add(out, path, fmt.Sprintf("must be at most %d characters long", *n.maxLength))
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		add(out, path, fmt.Sprintf("must match pattern %q", n.pattern.String()))
+	}
+}
+
+func (n *node) validateArray(arr []any, path string, out *[]Violation) {
+	if n.minItems != nil && len(arr) < *n.minItems {
+		add(out, path, fmt.Sprintf("must have at least %d items", *n.minItems))
+	}
+	if n.maxItems != nil && len(arr) > *n.maxItems {
+		add(out, path, fmt.Sprintf("must have at most %d items", *n.maxItems))
+	}
+	if n.uniqueItems {
+	unique:
+		for i := range arr {
+			for j := 0; j < i; j++ {
+				if equal(arr[i], arr[j]) {
+					add(out, path, fmt.Sprintf("items %d and %d must be unique", j, i))
+					break unique
+				}
+			}
+		}
+	}
+
+	for i, item := range arr {
+		itemPath := path + "/" + strconv.Itoa(i)
+		switch {
+		case i < len(n.prefixItems):
+			n.prefixItems[i].validate(item, itemPath, out)
+		case n.items != nil:
+			n.items.validate(item, itemPath, out)
+		}
+	}
+}
+
+func (n *node) validateObject(obj map[string]any, path string, out *[]Violation) {
+	if n.minProperties != nil && len(obj) < *n.minProperties {
+		add(out, path, fmt.Sprintf("must have at least %d properties", *n.minProperties))
+	}
+	if n.maxProperties != nil && len(obj) > *n.maxProperties {
+		add(out, path, fmt.Sprintf("must have at most %d properties", *n.maxProperties))
+	}
+	for _, name := range n.required {
+		if _, ok := obj[name]; !ok {
+			add(out, path, fmt.Sprintf("missing required property %q", name))
+		}
+	}
+
+	// Visit properties in a stable order so violations are reproducible
+	for _, name := range sortedKeys(obj) {
+		value := obj[name]
+		propPath := path + "/" + escape(name)
+		matched := false
+
+		if sub, ok := n.properties[name]; ok {
+			sub.validate(value, propPath, out)
+			matched = true
+		}
+		for pattern, re := range n.patternRegexps {
+			if re.MatchString(name) {
+				n.patternProperties[pattern].validate(value, propPath, out)
+				matched = true
+			}
+		}
+		if !matched && n.additionalProperties != nil {
+			if a := n.additionalProperties.always; a != nil && !*a {
+				add(out, path, fmt.Sprintf("additional property %q is not allowed", name))
+				continue
+			}
+			n.additionalProperties.validate(value, propPath, out)
+		}
+	}
+}
+
+func add(out *[]Violation, path, msg string) {
+	*out = append(*out, Violation{Path: path, Message: msg})
+}
+
+// typeOf returns the JSON Schema type of a decoded value. Integral
+// numbers are "integer".
+func typeOf(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		if f, ok := toFloat(v); ok {
+			if f == math.Trunc(f) && !math.IsInf(f, 0) {
+				return "integer"
+			}
+			return "number"
+		}
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// equal compares decoded JSON values, treating numbers by value.
+func equal(a, b any) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+
+	switch a := a.(type) {
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, va := range a {
+			vb, ok := b[k]
+			if !ok || !equal(va, vb) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func formatValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func formatValues(vs []any) string {
+	parts := make([]string, len(vs))
+	for i, v := range vs {
+		parts[i] = formatValue(v)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escape escapes a property name for use in a JSON Pointer.
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
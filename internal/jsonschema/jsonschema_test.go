@@ -0,0 +1,152 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		t.Fatalf("failed to decode %q: %v", s, err)
+	}
+	return v
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{"not json", `{`},
+		{"not an object", `"string"`},
+		{"unknown type", `{"type": "float"}`},
+		{"bad pattern", `{"pattern": "("}`},
+		{"negative minLength", `{"minLength": -1}`},
+		{"zero multipleOf", `{"multipleOf": 0}`},
+		{"remote ref", `{"$ref": "https://example.com/schema.json"}`},
+		{"missing ref", `{"$ref": "#/$defs/missing"}`},
+		{"empty anyOf", `{"anyOf": []}`},
+		{"trailing data", `{} {}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Compile([]byte(tt.schema)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	const record = `{
+		"type": "object",
+		"required": ["station", "temp"],
+		"properties": {
+			"station": {"type": "string", "pattern": "^[A-Z]{4}$"},
+			"temp": {"type": "number", "minimum": -90, "maximum": 60},
+			"count": {"type": "integer", "exclusiveMinimum": 0},
+			"unit": {"enum": ["C", "F"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true}
+		},
+		"additionalProperties": false
+	}`
+
+	tests := []struct {
+		name   string
+		schema string
+		doc    string
+		want   []string // "path: message" prefixes, in order
+	}{
+		{"valid", record, `{"station": "EGLL", "temp": 12.5, "unit": "C", "tags": ["a", "b"]}`, nil},
+		{"wrong root type", record, `[1]`, []string{"/: expected object, got array"}},
+		{"missing required", record, `{"station": "EGLL"}`, []string{`/: missing required property "temp"`}},
+		{"nested violations", record, `{"station": "egll", "temp": 99, "unit": "K"}`, []string{
+			`/station: must match pattern`,
+			`/temp: must be <= 60`,
+			`/unit: must be one of ["C", "F"]`,
+		}},
+		{"integer", record, `{"station": "EGLL", "temp": 1, "count": 1.5}`, []string{"/count: expected integer, got number"}},
+		{"integral number is integer", record, `{"station": "EGLL", "temp": 1, "count": 2.0}`, nil},
+		{"additional property", record, `{"station": "EGLL", "temp": 1, "extra": true}`, []string{`/: additional property "extra" is not allowed`}},
+		{"array items", record, `{"station": "EGLL", "temp": 1, "tags": ["a", 2, "a"]}`, []string{
+			"/tags: must have at most 2 items",
+			"/tags: items 0 and 2 must be unique",
+			"/tags/1: expected string, got integer",
+		}},
+		{"string length counts runes", `{"maxLength": 2}`, `"éé"`, nil},
+		{"multipleOf", `{"multipleOf": 0.1}`, `0.3`, nil},
+		{"not multiple", `{"multipleOf": 5}`, `12`, []string{"/: must be a multiple of 5"}},
+		{"const", `{"const": {"a": [1, 2]}}`, `{"a": [1, 2.0]}`, nil},
+		{"anyOf", `{"anyOf": [{"type": "string"}, {"type": "null"}]}`, `3`, []string{"/: must match at least one schema of anyOf"}},
+		{"oneOf", `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, `3`, []string{"/: must match exactly one schema of oneOf, matched 2"}},
+		{"not", `{"not": {"type": "null"}}`, `null`, []string{"/: must not match the schema of not"}},
+		{"false schema", `{"properties": {"a": false}}`, `{"a": 1}`, []string{"/a: no value is allowed here"}},
+		{"pattern properties", `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "integer"}}`, `{"x-a": 1, "b": 2}`, []string{
+			"/x-a: expected string, got integer",
+		}},
+		{"prefix items", `{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`, `["a", "b"]`, []string{
+			"/1: expected integer, got string",
+		}},
+		{"escaped path", `{"properties": {"a/b": {"type": "string"}}}`, `{"a/b": 1}`, []string{"/a~1b: expected string, got integer"}},
+		{"recursive ref", `{
+			"$defs": {"node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}, "name": {"type": "string"}}}},
+			"$ref": "#/$defs/node"
+		}`, `{"name": "a", "children": [{"name": "b", "children": [{"name": 3}]}]}`, []string{
+			"/children/0/children/0/name: expected string, got integer",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Compile([]byte(tt.schema))
+			if err != nil {
+				t.Fatalf("failed to compile schema: %v", err)
+			}
+
+			got := s.Validate(decode(t, tt.doc))
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d violations, got %v", len(tt.want), got)
+			}
+			for i, v := range got {
+				if !strings.HasPrefix(v.String(), tt.want[i]) {
+					t.Errorf("violation %d = %q, want prefix %q", i, v.String(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache(2)
+
+	a, err := c.Compile(`{"type": "string"}`)
+	if err != nil {
+		t.Fatalf("failed to compile: %v", err)
+	}
+	again, err := c.Compile(`{"type": "string"}`)
+	if err != nil {
+		t.Fatalf("failed to compile: %v", err)
+	}
+	if a != again {
+		t.Error("expected the cached schema to be reused")
+	}
+
+	if _, err := c.Compile(`{"type": "nope"}`); err == nil {
+		t.Error("expected an error for an invalid schema")
+	}
+	if c.Len() != 1 {
+		t.Errorf("expected invalid schemas not to be cached, got %d entries", c.Len())
+	}
+
+	_, _ = c.Compile(`{"type": "integer"}`)
+	_, _ = c.Compile(`{"type": "boolean"}`)
+	if c.Len() != 2 {
+		t.Errorf("expected the cache to hold at most 2 schemas, got %d", c.Len())
+	}
+}
@@ -1,12 +1,24 @@
 package logger
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"log/slog"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"bib/internal/config"
+
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -47,44 +59,86 @@ type AuditEvent struct {
 	RequestID string         `json:"request_id,omitempty"`
 }
 
-// AuditLogger handles audit logging to a dedicated file.
+// AuditLogger handles audit logging to a dedicated file, one JSON object
+// per line. Entries are hash-chained: each records the prev_hash of the
+// entry before it and its own hash, the SHA-256 of the line as written
+// without the hash field. The chain continues across rotated files and
+// restarts, so removed or altered lines break it; VerifyAuditLog checks it.
 type AuditLogger struct {
-	logger *slog.Logger
 	closer *lumberjack.Logger
+
+	mu       sync.Mutex
+	lastHash string
+}
+
+// auditLine is the content of an audit log line. Every field but the hash
+// is covered by the hash, which is appended as the last field.
+type auditLine struct {
+	Time      time.Time      `json:"time"`
+	Level     string         `json:"level"`
+	Msg       string         `json:"msg"`
+	Action    AuditAction    `json:"action"`
+	Actor     string         `json:"actor"`
+	Resource  string         `json:"resource"`
+	Outcome   AuditOutcome   `json:"outcome"`
+	Timestamp time.Time      `json:"timestamp"`
+	RequestID string         `json:"request_id,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	PrevHash  string         `json:"prev_hash,omitempty"`
 }
 
-// NewAuditLogger creates a new audit logger.
+// auditHashSuffix is the hash field closing every line: ,"hash":"<hex>"}
+const auditHashSuffix = len(`,"hash":"`) + sha256.Size*2 + len(`"}`)
+
+// NewAuditLogger creates a new audit logger with the default rotation.
 func NewAuditLogger(auditPath string, maxAgeDays int) (*AuditLogger, error) {
+	return NewAuditLoggerFromConfig(config.LogConfig{
+		AuditPath:       auditPath,
+		AuditMaxAgeDays: maxAgeDays,
+	})
+}
+
+// NewAuditLoggerFromConfig creates an audit logger writing to
+// cfg.AuditPath. The file is rotated when it reaches AuditMaxSizeMB,
+// rotated files are gzip-compressed, and they are removed after
+// AuditMaxAgeDays or when more than AuditMaxBackups exist.
+func NewAuditLoggerFromConfig(cfg config.LogConfig) (*AuditLogger, error) {
+	auditPath := cfg.AuditPath
 	if auditPath == "" {
 		return nil, fmt.Errorf("audit path is required")
 	}
 
-	// Ensure directory exists
-	if err := os.MkdirAll(auditPath[:len(auditPath)-len("/audit.log")], 0750); err != nil {
-		// If we can't parse the directory, try creating parent of the full path
-		// This handles cases where the path doesn't end with "/audit.log"
+	if err := os.MkdirAll(filepath.Dir(auditPath), 0750); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
 	}
 
+	maxAgeDays := cfg.AuditMaxAgeDays
 	if maxAgeDays <= 0 {
 		maxAgeDays = 365 // Default to 1 year retention for audit logs
 	}
-
-	lj := &lumberjack.Logger{
-		Filename:   auditPath,
-		MaxSize:    100, // 100 MB
-		MaxBackups: 0,   // Keep all backups within MaxAge
-		MaxAge:     maxAgeDays,
-		Compress:   true,
+	maxSize := cfg.AuditMaxSizeMB
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+	maxBackups := cfg.AuditMaxBackups
+	if maxBackups < 0 {
+		maxBackups = 0 // Keep all backups within MaxAge
 	}
 
-	// Always use JSON for audit logs
-	handler := slog.NewJSONHandler(lj, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	})
+	lastHash, err := lastAuditHash(auditPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume audit hash chain: %w", err)
+	}
 
 	return &AuditLogger{
-		logger: slog.New(handler),
-		closer: lj,
+		closer: &lumberjack.Logger{
+			Filename:   auditPath,
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			MaxAge:     maxAgeDays,
+			Compress:   true,
+		},
+		lastHash: lastHash,
 	}, nil
 }
 
@@ -106,29 +160,230 @@ func (a *AuditLogger) Log(ctx context.Context, event AuditEvent) {
 		}
 	}
 
-	attrs := []slog.Attr{
-		slog.String("action", string(event.Action)),
-		slog.String("actor", event.Actor),
-		slog.String("resource", event.Resource),
-		slog.String("outcome", string(event.Outcome)),
-		slog.Time("timestamp", event.Timestamp),
+	line := auditLine{
+		Time:      time.Now(),
+		Level:     "INFO",
+		Msg:       "audit",
+		Action:    event.Action,
+		Actor:     event.Actor,
+		Resource:  event.Resource,
+		Outcome:   event.Outcome,
+		Timestamp: event.Timestamp,
+		RequestID: event.RequestID,
+		Metadata:  event.Metadata,
+	}
+
+	// Chain and write under the lock, so the file order matches the chain
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	line.PrevHash = a.lastHash
+	data, hash := encodeAuditLine(line)
+	if _, err := a.closer.Write(data); err != nil {
+		return
+	}
+	a.lastHash = hash
+}
+
+// encodeAuditLine returns the line written for an entry, newline included,
+// and its hash.
+func encodeAuditLine(line auditLine) ([]byte, string) {
+	body, err := json.Marshal(line)
+	if err != nil {
+		// Metadata that can't be encoded is recorded as text
+		line.Metadata = map[string]any{"unencodable": fmt.Sprintf("%+v", line.Metadata)}
+		body, _ = json.Marshal(line)
+	}
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+
+	data := make([]byte, 0, len(body)+auditHashSuffix)
+	data = append(data, body[:len(body)-1]...)
+	data = append(data, `,"hash":"`...)
+	data = append(data, hash...)
+	data = append(data, "\"}\n"...)
+	return data, hash
+}
+
+// decodeAuditLine checks the hash of a line as written by encodeAuditLine
+// and returns its prev_hash and hash.
+func decodeAuditLine(data []byte) (prevHash, hash string, err error) {
+	n := len(data) - auditHashSuffix
+	if n < 1 || !bytes.HasPrefix(data[n:], []byte(`,"hash":"`)) || !bytes.HasSuffix(data, []byte(`"}`)) {
+		return "", "", fmt.Errorf("no hash")
+	}
+	hash = string(data[n+len(`,"hash":"`) : len(data)-len(`"}`)])
+
+	body := append(data[:n:n], '}')
+	var line auditLine
+	if err := json.Unmarshal(body, &line); err != nil {
+		return "", "", fmt.Errorf("invalid entry: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	if hex.EncodeToString(sum[:]) != hash {
+		return "", "", fmt.Errorf("hash mismatch, the entry was altered")
+	}
+	return line.PrevHash, hash, nil
+}
+
+// AuditVerifyError reports where the hash chain of an audit log breaks.
+type AuditVerifyError struct {
+	File   string
+	Line   int
+	Reason string
+}
+
+func (e *AuditVerifyError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Reason)
+}
+
+// VerifyAuditLog checks the hash chain of the audit log at path: the
+// rotated files, oldest first and gzip-compressed or not, then the current
+// file. Every line must hash to its recorded hash and link to the line
+// before it. The first entry may link to one removed by retention. It
+// returns the number of entries checked, and an *AuditVerifyError at the
+// first break.
+func VerifyAuditLog(path string) (int, error) {
+	backups, err := rotatedAuditFiles(path)
+	if err != nil {
+		return 0, err
+	}
+	files := append(backups, path)
+
+	var count int
+	var prev string
+	for i, file := range files {
+		err := readAuditFile(file, func(n int, data []byte) error {
+			prevHash, hash, err := decodeAuditLine(data)
+			if err != nil {
+				return &AuditVerifyError{File: file, Line: n, Reason: err.Error()}
+			}
+			if count > 0 && prevHash != prev {
+				return &AuditVerifyError{File: file, Line: n, Reason: "prev_hash does not match the entry before it, entries were removed or reordered"}
+			}
+			prev = hash
+			count++
+			return nil
+		})
+		if os.IsNotExist(err) && i == len(files)-1 {
+			break // not created again since the last rotation
+		}
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// readAuditFile calls fn with the number and content of every non-empty
+// line of an audit log file, gzip-compressed if it ends in .gz.
+func readAuditFile(path string, fn func(n int, line []byte) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := fn(n, scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// rotatedAuditFiles returns the rotated files of the audit log at path,
+// oldest first. A file still being compressed is returned instead of its
+// partial .gz copy.
+func rotatedAuditFiles(path string) ([]string, error) {
+	// Rotated files are named <name>-<timestamp><ext>, optionally .gz
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if strings.HasSuffix(name, ext) || strings.HasSuffix(name, ext+".gz") {
+			names[name] = true
+		}
 	}
 
-	if event.RequestID != "" {
-		attrs = append(attrs, slog.String("request_id", event.RequestID))
+	var backups []string
+	for name := range names {
+		if strings.HasSuffix(name, ".gz") && names[strings.TrimSuffix(name, ".gz")] {
+			continue
+		}
+		backups = append(backups, filepath.Join(filepath.Dir(path), name))
 	}
+	// The timestamps sort lexically
+	sort.Strings(backups)
+	return backups, nil
+}
 
-	if len(event.Metadata) > 0 {
-		attrs = append(attrs, slog.Any("metadata", event.Metadata))
+// lastAuditHash returns the hash of the last entry written to the audit
+// log at path, looking at the newest rotated file if the current one has
+// no entries yet. It returns "" when there is no earlier entry.
+func lastAuditHash(path string) (string, error) {
+	backups, err := rotatedAuditFiles(path)
+	if err != nil {
+		return "", err
 	}
+	files := append(backups, path)
 
-	// Convert to []any for LogAttrs
-	args := make([]any, len(attrs))
-	for i, attr := range attrs {
-		args[i] = attr
+	for i := len(files) - 1; i >= 0; i-- {
+		hash, err := lastHashInFile(files[i])
+		if err != nil || hash != "" {
+			return hash, err
+		}
 	}
+	return "", nil
+}
 
-	a.logger.LogAttrs(ctx, slog.LevelInfo, "audit", attrs...)
+// lastHashInFile returns the hash of the last chained entry in an audit
+// log file. A missing file has none.
+func lastHashInFile(path string) (string, error) {
+	var last string
+	err := readAuditFile(path, func(_ int, data []byte) error {
+		var line struct {
+			Hash string `json:"hash"`
+		}
+		if json.Unmarshal(data, &line) == nil && line.Hash != "" {
+			last = line.Hash
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return last, err
 }
 
 // LogCommand records a command execution audit event.
@@ -2,6 +2,8 @@
 package logger
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -95,6 +97,47 @@ func (l *Logger) WithGroup(name string) *Logger {
 	}
 }
 
+// Tee returns a Logger that also passes every record l logs to h, at the
+// level of l and with its fields redacted. It shares the files of l, so
+// only one of them needs to be closed.
+func (l *Logger) Tee(h slog.Handler) *Logger {
+	if len(l.cfg.RedactFields) > 0 {
+		h = NewRedactingHandler(h, l.cfg.RedactFields)
+	}
+	return &Logger{
+		Logger: slog.New(&teeHandler{primary: l.Handler(), secondary: h}),
+		cfg:    l.cfg,
+		closer: l.closer,
+	}
+}
+
+// teeHandler passes the records its primary handler is enabled for to both
+// handlers.
+type teeHandler struct {
+	primary   slog.Handler
+	secondary slog.Handler
+}
+
+// Enabled implements slog.Handler.
+func (h *teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.primary.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	return errors.Join(h.primary.Handle(ctx, r.Clone()), h.secondary.Handle(ctx, r))
+}
+
+// WithAttrs implements slog.Handler.
+func (h *teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &teeHandler{primary: h.primary.WithAttrs(attrs), secondary: h.secondary.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *teeHandler) WithGroup(name string) slog.Handler {
+	return &teeHandler{primary: h.primary.WithGroup(name), secondary: h.secondary.WithGroup(name)}
+}
+
 // buildWriters creates the appropriate io.Writer based on configuration.
 func buildWriters(cfg config.LogConfig) (io.Writer, io.Closer, error) {
 	var writers []io.Writer
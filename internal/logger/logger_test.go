@@ -2,9 +2,14 @@ package logger
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -219,6 +224,32 @@ func TestLogger_WithGroup(t *testing.T) {
 	}
 }
 
+func TestLogger_Tee(t *testing.T) {
+	var primary, secondary bytes.Buffer
+	redact := []string{"password"}
+	base := &Logger{
+		Logger: slog.New(NewRedactingHandler(slog.NewJSONHandler(&primary, &slog.HandlerOptions{Level: slog.LevelInfo}), redact)),
+		cfg:    config.LogConfig{RedactFields: redact},
+	}
+
+	logger := base.Tee(slog.NewJSONHandler(&secondary, &slog.HandlerOptions{Level: slog.LevelDebug})).With("component", "p2p")
+	logger.Debug("below the level")
+	logger.Info("connected", "peer", "p1", "password", "hunter2")
+
+	for name, buf := range map[string]*bytes.Buffer{"primary": &primary, "secondary": &secondary} {
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("%s output %q is not one JSON entry: %v", name, buf.String(), err)
+		}
+		if entry["msg"] != "connected" || entry["component"] != "p2p" || entry["peer"] != "p1" {
+			t.Errorf("%s entry = %v", name, entry)
+		}
+		if entry["password"] != RedactedValue {
+			t.Errorf("%s password = %v, want it redacted", name, entry["password"])
+		}
+	}
+}
+
 func TestLogger_Close(t *testing.T) {
 	cfg := config.LogConfig{
 		Level:  "info",
@@ -1211,6 +1242,247 @@ func TestAuditEvent_Timestamp(t *testing.T) {
 	}
 }
 
+func TestAuditLogger_HashChainAcrossRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	auditPath := filepath.Join(tempDir, "audit.log")
+	ctx := context.Background()
+	event := AuditEvent{Action: AuditActionCommand, Actor: "testuser", Resource: "cmd", Outcome: AuditOutcomeSuccess}
+
+	logger, err := NewAuditLoggerFromConfig(config.LogConfig{AuditPath: auditPath, AuditMaxSizeMB: 1, AuditMaxBackups: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logger.Log(ctx, event)
+	logger.Log(ctx, event)
+	last := logger.lastHash
+	if err := logger.closer.Rotate(); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	logger.Log(ctx, event)
+	logger.Close()
+
+	lines := readAuditLines(t, auditPath)
+	if len(lines) != 1 || lines[0]["prev_hash"] != last {
+		t.Fatalf("expected the chain to continue in the new file, got %v", lines)
+	}
+
+	// Wait for the rotated file to be compressed
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		matches, _ := filepath.Glob(filepath.Join(tempDir, "audit-*.log.gz"))
+		plain, _ := filepath.Glob(filepath.Join(tempDir, "audit-*.log"))
+		if len(matches) == 1 && len(plain) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("rotated file not compressed: %v %v", matches, plain)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A restart on an empty file resumes from the compressed backup
+	tail := lines[0]["hash"].(string)
+	if err := os.Remove(auditPath); err != nil {
+		t.Fatal(err)
+	}
+	logger, err = NewAuditLoggerFromConfig(config.LogConfig{AuditPath: auditPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logger.lastHash != last {
+		t.Errorf("expected chain resumed from the rotated file, got %q want %q", logger.lastHash, last)
+	}
+	logger.Close()
+
+	// A restart with entries resumes from the current file
+	if err := os.WriteFile(auditPath, []byte(`{"msg":"audit","hash":"`+tail+`"}`+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	logger, err = NewAuditLoggerFromConfig(config.LogConfig{AuditPath: auditPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer logger.Close()
+	if logger.lastHash != tail {
+		t.Errorf("expected chain resumed from the current file, got %q want %q", logger.lastHash, tail)
+	}
+}
+
+func TestAuditLogger_HashChain(t *testing.T) {
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := NewAuditLogger(auditPath, 365)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := []AuditEvent{
+		{Action: AuditActionCreate, Actor: "a", Resource: "r1", Outcome: AuditOutcomeSuccess, Timestamp: time.Unix(1, 0).UTC()},
+		{Action: AuditActionDelete, Actor: "b", Resource: "r2", Outcome: AuditOutcomeDenied, Timestamp: time.Unix(2, 0).UTC()},
+	}
+	for _, e := range events {
+		logger.Log(context.Background(), e)
+	}
+	logger.Close()
+
+	lines := readAuditLines(t, auditPath)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(lines))
+	}
+	if _, ok := lines[0]["prev_hash"]; ok {
+		t.Error("expected no prev_hash on the first entry")
+	}
+	if lines[1]["prev_hash"] != lines[0]["hash"] {
+		t.Error("expected the second entry to link to the first")
+	}
+
+	// The hash covers the line as written, prev_hash included
+	data, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, raw := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		body := raw[:strings.LastIndex(raw, `,"hash":`)] + "}"
+		sum := sha256.Sum256([]byte(body))
+		if want := hex.EncodeToString(sum[:]); lines[i]["hash"] != want {
+			t.Errorf("entry %d: hash %v, want %s", i, lines[i]["hash"], want)
+		}
+	}
+}
+
+func TestVerifyAuditLog(t *testing.T) {
+	tempDir := t.TempDir()
+	auditPath := filepath.Join(tempDir, "audit.log")
+	ctx := context.Background()
+
+	logger, err := NewAuditLoggerFromConfig(config.LogConfig{AuditPath: auditPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		logger.Log(ctx, AuditEvent{Action: AuditActionCreate, Actor: "alice", Resource: fmt.Sprint("r", i), Outcome: AuditOutcomeSuccess,
+			Metadata: map[string]any{"note": "<b>&</b>"}})
+	}
+	if err := logger.closer.Rotate(); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	for i := 3; i < 5; i++ {
+		logger.Log(ctx, AuditEvent{Action: AuditActionDelete, Actor: "bob", Resource: fmt.Sprint("r", i), Outcome: AuditOutcomeDenied})
+	}
+	logger.Close()
+
+	// Wait for the rotated file to be compressed
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		gz, _ := filepath.Glob(filepath.Join(tempDir, "audit-*.log.gz"))
+		plain, _ := filepath.Glob(filepath.Join(tempDir, "audit-*.log"))
+		if len(gz) == 1 && len(plain) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("rotated file not compressed: %v %v", gz, plain)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if n, err := VerifyAuditLog(auditPath); err != nil || n != 5 {
+		t.Fatalf("VerifyAuditLog() = %d, %v; want 5 entries", n, err)
+	}
+
+	original, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(string(original), "\n")
+
+	tests := []struct {
+		name     string
+		content  string
+		wantLine int
+	}{
+		{"altered field", lines[0] + strings.Replace(lines[1], `"actor":"bob"`, `"actor":"eve"`, 1), 2},
+		{"altered prev_hash", strings.Replace(lines[0], `"prev_hash":"`, `"prev_hash":"0`, 1) + lines[1], 1},
+		{"removed line", lines[1], 1},
+		{"reordered lines", lines[1] + lines[0], 1},
+		{"no hash", lines[0] + `{"msg":"audit","actor":"eve"}` + "\n", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(auditPath, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := VerifyAuditLog(auditPath)
+			var verr *AuditVerifyError
+			if !errors.As(err, &verr) || verr.File != auditPath || verr.Line != tt.wantLine {
+				t.Errorf("VerifyAuditLog() error = %v, want a break at %s:%d", err, auditPath, tt.wantLine)
+			}
+		})
+	}
+
+	// A tampered line in a compressed rotated file is found too
+	if err := os.WriteFile(auditPath, original, 0600); err != nil {
+		t.Fatal(err)
+	}
+	gzPaths, _ := filepath.Glob(filepath.Join(tempDir, "audit-*.log.gz"))
+	rotated := readGzip(t, gzPaths[0])
+	writeGzip(t, gzPaths[0], strings.Replace(rotated, `"resource":"r1"`, `"resource":"r9"`, 1))
+	_, err = VerifyAuditLog(auditPath)
+	var verr *AuditVerifyError
+	if !errors.As(err, &verr) || verr.File != gzPaths[0] || verr.Line != 2 {
+		t.Errorf("VerifyAuditLog() error = %v, want a break at %s:2", err, gzPaths[0])
+	}
+}
+
+func readGzip(t *testing.T, path string) string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func writeGzip(t *testing.T, path, content string) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readAuditLines parses the JSON lines of an audit log file
+func readAuditLines(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	var lines []map[string]any
+	for _, raw := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var line map[string]any
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("invalid audit line %q: %v", raw, err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
 // ==================== Audit Actions and Outcomes ====================
 
 func TestAuditActions(t *testing.T) {
@@ -0,0 +1,177 @@
+// Package maintenance provides the pause signal shared by the daemon's
+// background maintenance workers.
+//
+// Workers call Gate.Wait before each unit of work, at points where stopping
+// is safe. While maintenance is paused Wait blocks, so a worker holds
+// between units and picks up where it stopped once maintenance resumes.
+package maintenance
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Worker names.
+const (
+	WorkerGC       = "gc"
+	WorkerVacuum   = "vacuum"
+	WorkerRotation = "rotation"
+	WorkerSync     = "sync"
+)
+
+// State is a snapshot of the pause signal.
+type State struct {
+	// Paused indicates maintenance is paused.
+	Paused bool
+
+	// Reason is why maintenance was paused.
+	Reason string
+
+	// PausedBy is who paused maintenance.
+	PausedBy string
+
+	// PausedAt is when maintenance was paused.
+	PausedAt time.Time
+
+	// Workers are the workers observing the gate, sorted.
+	Workers []string
+
+	// Waiting are the workers held by the pause, sorted.
+	Waiting []string
+}
+
+// Gate is the pause signal observed by maintenance workers. A nil Gate is
+// never paused.
+type Gate struct {
+	mu       sync.Mutex
+	paused   bool
+	reason   string
+	pausedBy string
+	pausedAt time.Time
+	resumed  chan struct{} // closed when maintenance resumes
+	workers  map[string]struct{}
+	waiting  map[string]int
+}
+
+// NewGate creates a gate that is not paused.
+func NewGate() *Gate {
+	return &Gate{
+		workers: make(map[string]struct{}),
+		waiting: make(map[string]int),
+	}
+}
+
+// Register records that a worker observes the gate, so the state lists it.
+func (g *Gate) Register(worker string) {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.workers[worker] = struct{}{}
+}
+
+// Pause pauses maintenance. It returns false if maintenance was already
+// paused, in which case the original reason is kept.
+func (g *Gate) Pause(reason, by string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused {
+		return false
+	}
+	g.paused = true
+	g.reason = reason
+	g.pausedBy = by
+	g.pausedAt = time.Now().UTC()
+	g.resumed = make(chan struct{})
+	return true
+}
+
+// Resume resumes maintenance, releasing the waiting workers. It returns
+// false if maintenance was not paused.
+func (g *Gate) Resume() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.paused {
+		return false
+	}
+	g.paused = false
+	g.reason = ""
+	g.pausedBy = ""
+	g.pausedAt = time.Time{}
+	close(g.resumed)
+	return true
+}
+
+// Paused reports whether maintenance is paused.
+func (g *Gate) Paused() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
+
+// Wait blocks the worker while maintenance is paused. It returns the
+// context's error if the context is done first.
+func (g *Gate) Wait(ctx context.Context, worker string) error {
+	if g == nil {
+		return ctx.Err()
+	}
+
+	g.mu.Lock()
+	if !g.paused {
+		g.mu.Unlock()
+		return ctx.Err()
+	}
+	resumed := g.resumed
+	g.waiting[worker]++
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		if g.waiting[worker]--; g.waiting[worker] <= 0 {
+			delete(g.waiting, worker)
+		}
+		g.mu.Unlock()
+	}()
+
+	select {
+	case <-resumed:
+		return ctx.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// State returns a snapshot of the gate.
+func (g *Gate) State() State {
+	if g == nil {
+		return State{}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return State{
+		Paused:   g.paused,
+		Reason:   g.reason,
+		PausedBy: g.pausedBy,
+		PausedAt: g.pausedAt,
+		Workers:  sortedKeys(g.workers),
+		Waiting:  sortedKeys(g.waiting),
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGate_PauseResume(t *testing.T) {
+	g := NewGate()
+	g.Register(WorkerSync)
+	g.Register(WorkerGC)
+
+	if g.Paused() {
+		t.Fatal("new gate should not be paused")
+	}
+	if !g.Pause("migration", "alice") {
+		t.Fatal("Pause should report a change")
+	}
+	if g.Pause("other", "bob") {
+		t.Error("second Pause should not report a change")
+	}
+
+	state := g.State()
+	if !state.Paused || state.Reason != "migration" || state.PausedBy != "alice" || state.PausedAt.IsZero() {
+		t.Errorf("unexpected state: %+v", state)
+	}
+	if len(state.Workers) != 2 || state.Workers[0] != WorkerGC || state.Workers[1] != WorkerSync {
+		t.Errorf("Workers = %v, want [gc sync]", state.Workers)
+	}
+
+	if !g.Resume() {
+		t.Fatal("Resume should report a change")
+	}
+	if g.Resume() {
+		t.Error("second Resume should not report a change")
+	}
+	if state := g.State(); state.Paused || state.Reason != "" {
+		t.Errorf("unexpected state after resume: %+v", state)
+	}
+}
+
+func TestGate_WaitBlocksWhilePaused(t *testing.T) {
+	g := NewGate()
+	g.Pause("test", "")
+
+	done := make(chan error, 1)
+	go func() { done <- g.Wait(context.Background(), WorkerGC) }()
+
+	deadline := time.Now().Add(time.Second)
+	for len(g.State().Waiting) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("worker never waited")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-done:
+		t.Fatal("Wait returned while paused")
+	default:
+	}
+
+	g.Resume()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Wait returned %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after resume")
+	}
+	if waiting := g.State().Waiting; len(waiting) != 0 {
+		t.Errorf("Waiting = %v after resume", waiting)
+	}
+}
+
+func TestGate_WaitHonorsContext(t *testing.T) {
+	g := NewGate()
+	g.Pause("test", "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := g.Wait(ctx, WorkerSync); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait = %v, want deadline exceeded", err)
+	}
+}
+
+func TestGate_Nil(t *testing.T) {
+	var g *Gate
+	g.Register(WorkerGC)
+	if g.Paused() {
+		t.Error("nil gate should not be paused")
+	}
+	if err := g.Wait(context.Background(), WorkerGC); err != nil {
+		t.Errorf("Wait = %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package maintenance
+
+import (
+	"context"
+	"time"
+)
+
+// Locker grants lease-based locks shared by the nodes of a cluster. A lease
+// expires unless renewed, so a lock held by a crashed node frees itself.
+type Locker interface {
+	// TryLock acquires the named lock for holder and returns the lease
+	// token. It fails if another holder has an unexpired lease.
+	TryLock(name, holder string, ttl time.Duration) (token string, err error)
+
+	// Renew extends the lease identified by token.
+	Renew(name, token string, ttl time.Duration) error
+
+	// Unlock releases the lease identified by token.
+	Unlock(name, token string) error
+}
+
+// LockName returns the name of the lock that serializes a worker's runs
+// across the cluster.
+func LockName(worker string) string {
+	return "maintenance/" + worker
+}
+
+// Exclusive runs fn while holding the named lock, so at most one node runs
+// it at a time. The lease is renewed every third of ttl; if a renewal
+// fails, fn's context is cancelled since another node may take over. It
+// reports false, without running fn, if the lock could not be acquired. A
+// nil Locker runs fn unconditionally.
+func Exclusive(ctx context.Context, l Locker, name, holder string, ttl time.Duration, fn func(context.Context) error) (bool, error) {
+	if l == nil {
+		return true, fn(ctx)
+	}
+
+	token, err := l.TryLock(name, holder, ttl)
+	if err != nil {
+		return false, err
+	}
+	defer l.Unlock(name, token)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := l.Renew(name, token, ttl); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err = fn(ctx)
+	cancel()
+	<-renewed
+	return true, err
+}
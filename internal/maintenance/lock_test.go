@@ -0,0 +1,89 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLocker grants a single lock; renewals fail once failRenew is set.
+type fakeLocker struct {
+	mu        sync.Mutex
+	holder    string
+	renewals  int
+	failRenew bool
+}
+
+func (f *fakeLocker) TryLock(name, holder string, ttl time.Duration) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holder != "" {
+		return "", errors.New("held")
+	}
+	f.holder = holder
+	return "token", nil
+}
+
+func (f *fakeLocker) Renew(name, token string, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failRenew {
+		return errors.New("lease lost")
+	}
+	f.renewals++
+	return nil
+}
+
+func (f *fakeLocker) Unlock(name, token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.holder = ""
+	return nil
+}
+
+func TestExclusive(t *testing.T) {
+	l := &fakeLocker{}
+	name := LockName(WorkerGC)
+
+	ran, err := Exclusive(context.Background(), l, name, "node-a", 30*time.Millisecond, func(ctx context.Context) error {
+		if _, err := Exclusive(ctx, l, name, "node-b", time.Minute, func(context.Context) error { return nil }); err == nil {
+			t.Error("second holder acquired the lock")
+		}
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	if !ran || err != nil {
+		t.Fatalf("Exclusive() = %v, %v", ran, err)
+	}
+	if l.holder != "" {
+		t.Error("lock not released")
+	}
+	if l.renewals == 0 {
+		t.Error("lease never renewed")
+	}
+}
+
+func TestExclusive_LostLease(t *testing.T) {
+	l := &fakeLocker{failRenew: true}
+
+	ran, err := Exclusive(context.Background(), l, "task", "node-a", 30*time.Millisecond, func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	})
+	if !ran || !errors.Is(err, context.Canceled) {
+		t.Errorf("Exclusive() = %v, %v; want run and cancelled", ran, err)
+	}
+}
+
+func TestExclusive_NilLocker(t *testing.T) {
+	ran, err := Exclusive(context.Background(), nil, "task", "", time.Second, func(context.Context) error { return nil })
+	if !ran || err != nil {
+		t.Errorf("Exclusive() = %v, %v", ran, err)
+	}
+}
@@ -0,0 +1,126 @@
+// Package metrics names the Prometheus metrics bibd exports and generates
+// alerting rules for them.
+//
+// bibd serves its metrics on the HTTP endpoint configured under
+// server.grpc.metrics. Besides the Go runtime and process collectors, these
+// are the gRPC server metrics of go-grpc-prometheus, the per-service
+// request metrics and the node gauges named here, which are sampled on
+// every scrape. Together they cover RED (rate, errors, duration) for every
+// service and USE for the node's resources; goroutines are the runtime
+// collector's go_goroutines.
+package metrics
+
+// Node gauges.
+const (
+	// StorageUp is 1 if the database answers a ping, 0 otherwise.
+	StorageUp = "bibd_storage_up"
+
+	// P2PConnectedPeers is the number of connected libp2p peers. It is
+	// only exported if P2P is enabled.
+	P2PConnectedPeers = "bibd_p2p_connected_peers"
+
+	// ClusterHasQuorum is 1 if the Raft cluster has quorum, 0 otherwise.
+	// It is only exported if clustering is enabled.
+	ClusterHasQuorum = "bibd_cluster_has_quorum"
+
+	// ClusterClockSkew is the largest absolute clock skew, in seconds,
+	// between the node and the cluster members it recently heard from. It
+	// is only exported if clustering is enabled.
+	ClusterClockSkew = "bibd_cluster_clock_skew_seconds"
+
+	// CertificateExpiry is the expiry time of a TLS certificate in seconds
+	// since the epoch, labeled with the certificate ("ca" or "server").
+	CertificateExpiry = "bibd_certificate_expiry_timestamp_seconds"
+
+	// CertificateLabel is the label naming the certificate of
+	// CertificateExpiry.
+	CertificateLabel = "certificate"
+
+	// GRPCOpenConnections is the number of open client connections to the
+	// gRPC server, including local socket connections.
+	GRPCOpenConnections = "bibd_grpc_open_connections"
+
+	// P2PSyncLag is how long ago, in seconds, the connected peer synced
+	// least recently was synced. It is only exported in full replica mode.
+	P2PSyncLag = "bibd_p2p_sync_lag_seconds"
+
+	// P2PSyncBytes counts the catalog bytes received by full replica sync,
+	// labeled with the sync kind ("full" or "incremental").
+	P2PSyncBytes = "bibd_p2p_sync_bytes_total"
+
+	// SyncKindLabel is the label naming the sync kind of P2PSyncBytes.
+	SyncKindLabel = "kind"
+
+	// StorageConnections is the number of open database connections,
+	// labeled with their state ("in_use" or "idle").
+	StorageConnections = "bibd_storage_connections"
+
+	// StorageMaxConnections is the maximum number of open database
+	// connections. It is not exported if the pool is unbounded.
+	StorageMaxConnections = "bibd_storage_max_connections"
+
+	// ConnectionStateLabel is the label naming the connection state of
+	// StorageConnections.
+	ConnectionStateLabel = "state"
+
+	// BlobStoredBytes is the size of the blobs as stored, after
+	// compression. It is only exported if blob storage is configured.
+	BlobStoredBytes = "bibd_blob_stored_bytes"
+
+	// BlobBytes is the size of the blobs before compression.
+	BlobBytes = "bibd_blob_bytes"
+
+	// BlobCount is the number of blobs.
+	BlobCount = "bibd_blob_count"
+)
+
+// Per-service request metrics, recorded for every call of every service.
+const (
+	// GRPCRequests counts completed calls, labeled with service, method
+	// and code. Errors are the calls with a code other than "OK".
+	GRPCRequests = "bibd_grpc_requests_total"
+
+	// GRPCRequestDuration is a histogram of call durations in seconds,
+	// labeled with service and method. It is only exported if latency
+	// histograms are enabled.
+	GRPCRequestDuration = "bibd_grpc_request_duration_seconds"
+
+	// GRPCPanics counts the panics recovered from handlers, labeled with
+	// service and method.
+	GRPCPanics = "bibd_grpc_panics_total"
+
+	// ServiceLabel is the label naming the short service name, such as
+	// "QueryService".
+	ServiceLabel = "service"
+
+	// MethodLabel is the label naming the method, such as "Execute".
+	MethodLabel = "method"
+
+	// CodeLabel is the label naming the gRPC status code, such as
+	// "NotFound".
+	CodeLabel = "code"
+)
+
+// Watchdog metrics, exported if the watchdog is enabled.
+const (
+	// WatchdogFired counts the times the watchdog fired, labeled with the
+	// reason: "goroutine_limit", "goroutine_growth" or "stall".
+	WatchdogFired = "bibd_watchdog_fired_total"
+
+	// WatchdogHeartbeatAge is the number of seconds since the last beat of
+	// a liveness heartbeat, labeled with the heartbeat.
+	WatchdogHeartbeatAge = "bibd_watchdog_heartbeat_age_seconds"
+
+	// ReasonLabel is the label naming why the watchdog fired.
+	ReasonLabel = "reason"
+
+	// HeartbeatLabel is the label naming a heartbeat, such as "daemon".
+	HeartbeatLabel = "heartbeat"
+)
+
+// gRPC server metrics of go-grpc-prometheus.
+const (
+	// GRPCServerHandled counts completed RPCs, labeled with grpc_service,
+	// grpc_method and grpc_code.
+	GRPCServerHandled = "grpc_server_handled_total"
+)
@@ -0,0 +1,210 @@
+package metrics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RuleOptions are the thresholds of the generated alerting rules.
+type RuleOptions struct {
+	// Group is the name of the rule group.
+	Group string
+
+	// Selector is a label matcher list, such as `job="bibd"`, added to every
+	// expression so that the rules only match bibd targets. Empty matches
+	// every target exporting the metrics.
+	Selector string
+
+	// CertWarningDays and CertCriticalDays are how many days before a
+	// certificate expires the warning and critical alerts fire.
+	CertWarningDays  int
+	CertCriticalDays int
+
+	// MinPeers is the peer count below which a node is alerted on.
+	MinPeers int
+
+	// RateLimitRatio is the share of RPCs rejected as rate limited
+	// (RESOURCE_EXHAUSTED) above which an alert fires, between 0 and 1.
+	RateLimitRatio float64
+
+	// RateWindow is the range over which rates are computed.
+	RateWindow time.Duration
+}
+
+// DefaultRuleOptions returns the default thresholds.
+func DefaultRuleOptions() RuleOptions {
+	return RuleOptions{
+		Group:            "bibd",
+		Selector:         `job="bibd"`,
+		CertWarningDays:  30,
+		CertCriticalDays: 7,
+		MinPeers:         3,
+		RateLimitRatio:   0.05,
+		RateWindow:       5 * time.Minute,
+	}
+}
+
+// Validate checks that the options give consistent thresholds.
+func (o RuleOptions) Validate() error {
+	switch {
+	case o.Group == "":
+		return fmt.Errorf("rule group name is required")
+	case o.CertWarningDays <= 0 || o.CertCriticalDays <= 0:
+		return fmt.Errorf("certificate expiry thresholds must be positive")
+	case o.CertCriticalDays > o.CertWarningDays:
+		return fmt.Errorf("critical certificate threshold (%d days) must not exceed the warning threshold (%d days)", o.CertCriticalDays, o.CertWarningDays)
+	case o.MinPeers < 0:
+		return fmt.Errorf("minimum peer count must not be negative")
+	case o.RateLimitRatio <= 0 || o.RateLimitRatio >= 1:
+		return fmt.Errorf("rate limit ratio must be between 0 and 1, got %g", o.RateLimitRatio)
+	case o.RateWindow < time.Minute:
+		return fmt.Errorf("rate window must be at least 1m, got %s", o.RateWindow)
+	}
+	return nil
+}
+
+// RuleFile is a Prometheus rule file.
+type RuleFile struct {
+	Groups []RuleGroup `yaml:"groups"`
+}
+
+// RuleGroup is a group of rules evaluated together.
+type RuleGroup struct {
+	Name  string `yaml:"name"`
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule is a Prometheus alerting rule.
+type Rule struct {
+	Alert       string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// Severities of the generated alerts.
+const (
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Rules generates the alerting rules for bibd's metrics.
+func Rules(opts RuleOptions) (*RuleFile, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	sel := func(extra ...string) string {
+		matchers := extra
+		if opts.Selector != "" {
+			matchers = append([]string{opts.Selector}, extra...)
+		}
+		if len(matchers) == 0 {
+			return ""
+		}
+		return "{" + strings.Join(matchers, ",") + "}"
+	}
+	window := "[" + promDuration(opts.RateWindow) + "]"
+
+	certRule := func(alert, severity string, days int) Rule {
+		return Rule{
+			Alert: alert,
+			Expr:  fmt.Sprintf("%s%s - time() < %d * 86400", CertificateExpiry, sel(), days),
+			For:   "10m",
+			Labels: map[string]string{
+				"severity": severity,
+			},
+			Annotations: map[string]string{
+				"summary": "bibd {{ $labels." + CertificateLabel + " }} certificate expires soon",
+				"description": "The {{ $labels." + CertificateLabel + " }} certificate of {{ $labels.instance }} expires in " +
+					"{{ $value | humanizeDuration }}, less than " + strconv.Itoa(days) + " days. " +
+					"Renew it before clients stop trusting the node.",
+			},
+		}
+	}
+
+	rules := []Rule{
+		{
+			Alert:  "BibdDown",
+			Expr:   fmt.Sprintf("up%s == 0", sel()),
+			For:    "5m",
+			Labels: map[string]string{"severity": SeverityCritical},
+			Annotations: map[string]string{
+				"summary":     "bibd is down",
+				"description": "Prometheus has not scraped {{ $labels.instance }} for 5 minutes.",
+			},
+		},
+		certRule("BibdCertificateExpiringSoon", SeverityWarning, opts.CertWarningDays),
+		certRule("BibdCertificateExpiryCritical", SeverityCritical, opts.CertCriticalDays),
+		{
+			Alert:  "BibdClusterQuorumLost",
+			Expr:   fmt.Sprintf("%s%s == 0", ClusterHasQuorum, sel()),
+			For:    "1m",
+			Labels: map[string]string{"severity": SeverityCritical},
+			Annotations: map[string]string{
+				"summary":     "bibd cluster has lost quorum",
+				"description": "{{ $labels.instance }} sees no Raft quorum. The cluster cannot commit changes until a majority of voters is back.",
+			},
+		},
+		{
+			Alert:  "BibdPeerCountLow",
+			Expr:   fmt.Sprintf("%s%s < %d", P2PConnectedPeers, sel(), opts.MinPeers),
+			For:    "10m",
+			Labels: map[string]string{"severity": SeverityWarning},
+			Annotations: map[string]string{
+				"summary":     "bibd has few P2P peers",
+				"description": "{{ $labels.instance }} is connected to {{ $value }} peers, fewer than " + strconv.Itoa(opts.MinPeers) + ". Check bootstrap peers and network reachability.",
+			},
+		},
+		{
+			Alert:  "BibdStorageDown",
+			Expr:   fmt.Sprintf("%s%s == 0", StorageUp, sel()),
+			For:    "2m",
+			Labels: map[string]string{"severity": SeverityCritical},
+			Annotations: map[string]string{
+				"summary":     "bibd storage is down",
+				"description": "The database of {{ $labels.instance }} does not answer. Requests needing storage fail.",
+			},
+		},
+		{
+			Alert: "BibdRateLimitSaturated",
+			Expr: fmt.Sprintf("sum by (instance) (rate(%s%s%s)) / sum by (instance) (rate(%s%s%s)) > %s",
+				GRPCServerHandled, sel(`grpc_code="ResourceExhausted"`), window,
+				GRPCServerHandled, sel(), window,
+				strconv.FormatFloat(opts.RateLimitRatio, 'g', -1, 64)),
+			For:    "10m",
+			Labels: map[string]string{"severity": SeverityWarning},
+			Annotations: map[string]string{
+				"summary": "bibd is rate limiting many requests",
+				"description": "{{ $value | humanizePercentage }} of the RPCs to {{ $labels.instance }} are rejected as rate limited, more than " +
+					strconv.FormatFloat(opts.RateLimitRatio*100, 'g', -1, 64) + "%. Raise the limits or add capacity.",
+			},
+		},
+	}
+
+	return &RuleFile{Groups: []RuleGroup{{Name: opts.Group, Rules: rules}}}, nil
+}
+
+// promDuration formats d as a Prometheus duration, such as 5m or 1h30m.
+func promDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"h", time.Hour}, {"m", time.Minute}, {"s", time.Second}} {
+		if n := d / unit.size; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			d -= n * unit.size
+		}
+	}
+	if b.Len() == 0 {
+		return "1s"
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRules_Defaults(t *testing.T) {
+	file, err := Rules(DefaultRuleOptions())
+	if err != nil {
+		t.Fatalf("Rules failed: %v", err)
+	}
+	if len(file.Groups) != 1 || file.Groups[0].Name != "bibd" {
+		t.Fatalf("groups = %+v, want one group named bibd", file.Groups)
+	}
+
+	exprs := make(map[string]string)
+	for _, rule := range file.Groups[0].Rules {
+		if rule.Labels["severity"] == "" {
+			t.Errorf("%s has no severity", rule.Alert)
+		}
+		if rule.Annotations["summary"] == "" || rule.Annotations["description"] == "" {
+			t.Errorf("%s lacks annotations", rule.Alert)
+		}
+		exprs[rule.Alert] = rule.Expr
+	}
+
+	want := map[string]string{
+		"BibdDown":                      `up{job="bibd"} == 0`,
+		"BibdCertificateExpiringSoon":   `bibd_certificate_expiry_timestamp_seconds{job="bibd"} - time() < 30 * 86400`,
+		"BibdCertificateExpiryCritical": `bibd_certificate_expiry_timestamp_seconds{job="bibd"} - time() < 7 * 86400`,
+		"BibdClusterQuorumLost":         `bibd_cluster_has_quorum{job="bibd"} == 0`,
+		"BibdPeerCountLow":              `bibd_p2p_connected_peers{job="bibd"} < 3`,
+		"BibdStorageDown":               `bibd_storage_up{job="bibd"} == 0`,
+		"BibdRateLimitSaturated": `sum by (instance) (rate(grpc_server_handled_total{job="bibd",grpc_code="ResourceExhausted"}[5m]))` +
+			` / sum by (instance) (rate(grpc_server_handled_total{job="bibd"}[5m])) > 0.05`,
+	}
+	for alert, expr := range want {
+		if got := exprs[alert]; got != expr {
+			t.Errorf("%s expr = %q, want %q", alert, got, expr)
+		}
+	}
+	if len(exprs) != len(want) {
+		t.Errorf("got %d rules, want %d", len(exprs), len(want))
+	}
+}
+
+func TestRules_Options(t *testing.T) {
+	opts := DefaultRuleOptions()
+	opts.Group = "research"
+	opts.Selector = ""
+	opts.MinPeers = 10
+	opts.RateLimitRatio = 0.2
+	opts.RateWindow = 90 * time.Second
+
+	file, err := Rules(opts)
+	if err != nil {
+		t.Fatalf("Rules failed: %v", err)
+	}
+
+	data, err := yaml.Marshal(file)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"name: research",
+		"expr: up == 0",
+		"expr: bibd_p2p_connected_peers < 10",
+		`grpc_server_handled_total{grpc_code="ResourceExhausted"}[1m30s]`,
+		"> 0.2",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rules do not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRuleOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*RuleOptions)
+	}{
+		{"no group", func(o *RuleOptions) { o.Group = "" }},
+		{"critical after warning", func(o *RuleOptions) { o.CertCriticalDays = 60 }},
+		{"zero warning days", func(o *RuleOptions) { o.CertWarningDays = 0 }},
+		{"negative peers", func(o *RuleOptions) { o.MinPeers = -1 }},
+		{"ratio above one", func(o *RuleOptions) { o.RateLimitRatio = 1.5 }},
+		{"short window", func(o *RuleOptions) { o.RateWindow = 10 * time.Second }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultRuleOptions()
+			tt.modify(&opts)
+			if err := opts.Validate(); err == nil {
+				t.Error("Validate() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestPromDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		5 * time.Minute:                  "5m",
+		time.Hour + 30*time.Minute:       "1h30m",
+		90 * time.Second:                 "1m30s",
+		1500 * time.Millisecond:          "1s",
+		2*time.Hour + 3*time.Millisecond: "2h",
+	}
+	for d, want := range tests {
+		if got := promDuration(d); got != want {
+			t.Errorf("promDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
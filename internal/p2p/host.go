@@ -10,8 +10,10 @@ import (
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	"github.com/libp2p/go-libp2p/p2p/protocol/ping"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
 	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
@@ -172,6 +174,29 @@ func (h *Host) ActiveStreamsCount() int {
 	return count
 }
 
+// Ping sends a single libp2p ping to a peer and returns the round-trip time.
+// The peer must be connected or have known addresses in the peerstore.
+func (h *Host) Ping(ctx context.Context, id peer.ID) (time.Duration, error) {
+	if h.Host.Network().Connectedness(id) != network.Connected {
+		if err := h.Host.Connect(ctx, h.Host.Peerstore().PeerInfo(id)); err != nil {
+			return 0, fmt.Errorf("failed to connect to %s: %w", id, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	select {
+	case res := <-ping.Ping(ctx, h.Host, id):
+		if res.Error != nil {
+			return 0, fmt.Errorf("ping %s: %w", id, res.Error)
+		}
+		return res.RTT, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
 // Config returns the P2P configuration.
 func (h *Host) Config() config.P2PConfig {
 	return h.cfg
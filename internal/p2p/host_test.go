@@ -104,3 +104,40 @@ func TestHostPersistentIdentity(t *testing.T) {
 
 	t.Logf("Persistent peer ID verified: %s", peerID1)
 }
+
+func TestHostPing(t *testing.T) {
+	cfg := config.P2PConfig{
+		Enabled:         true,
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		ConnManager: config.ConnManagerConfig{
+			LowWatermark:  10,
+			HighWatermark: 40,
+			GracePeriod:   time.Second,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	host1, err := NewHost(ctx, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create first host: %v", err)
+	}
+	defer host1.Close()
+
+	host2, err := NewHost(ctx, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create second host: %v", err)
+	}
+	defer host2.Close()
+
+	host1.Peerstore().AddAddrs(host2.PeerID(), host2.ListenAddrs(), time.Minute)
+
+	rtt, err := host1.Ping(ctx, host2.PeerID())
+	if err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if rtt <= 0 {
+		t.Errorf("expected positive RTT, got %v", rtt)
+	}
+}
@@ -0,0 +1,260 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"sync"
+	"time"
+
+	"bib/internal/logger"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+)
+
+// ProtocolLabels is the protocol over which connected nodes exchange labels.
+const ProtocolLabels = "/bib/labels/1.0.0"
+
+// PeerstoreLabelsKey is the peerstore key under which a peer's labels are kept.
+const PeerstoreLabelsKey = "bib.labels"
+
+const (
+	// MaxLabels is the maximum number of labels on a node.
+	MaxLabels = 64
+
+	// MaxLabelValueLength is the maximum length of a label value.
+	MaxLabelValueLength = 63
+
+	labelExchangeTimeout = 10 * time.Second
+	maxLabelsFrameSize   = 16 * 1024
+)
+
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[a-z0-9]([a-z0-9_-]{0,61}[a-z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^[A-Za-z0-9._-]*$`)
+)
+
+// ValidateLabels checks that label keys are 1-63 lowercase letters, digits,
+// '-' and '_', starting and ending with a letter or digit, and that values
+// are at most 63 letters, digits, '.', '-' and '_'.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > MaxLabels {
+		return fmt.Errorf("too many labels: %d, limit is %d", len(labels), MaxLabels)
+	}
+	for k, v := range labels {
+		if !labelKeyPattern.MatchString(k) {
+			return fmt.Errorf("invalid label key %q", k)
+		}
+		if len(v) > MaxLabelValueLength || !labelValuePattern.MatchString(v) {
+			return fmt.Errorf("invalid value %q for label %q", v, k)
+		}
+	}
+	return nil
+}
+
+// MatchLabels reports whether labels has every key/value pair in selector.
+// An empty selector matches any labels.
+func MatchLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if got, ok := labels[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// PeerLabels returns the labels of a peer as kept in the peerstore, or nil if
+// none were received.
+func PeerLabels(ps peerstore.Peerstore, p peer.ID) map[string]string {
+	v, err := ps.Get(p, PeerstoreLabelsKey)
+	if err != nil {
+		return nil
+	}
+	labels, _ := v.(map[string]string)
+	return maps.Clone(labels)
+}
+
+// labelsFrame carries a node's labels over ProtocolLabels.
+type labelsFrame struct {
+	Labels map[string]string `json:"labels"`
+}
+
+// LabelManager holds this node's labels and exchanges them with connected
+// peers: whenever a connection opens, both sides send their labels, and a
+// change to the local labels is pushed to every connected peer. Received
+// labels are kept in the host's peerstore under PeerstoreLabelsKey.
+type LabelManager struct {
+	host host.Host
+	log  *logger.Logger
+
+	mu     sync.RWMutex
+	labels map[string]string
+
+	notifee *network.NotifyBundle
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewLabelManager creates a label manager with the given local labels,
+// registers its stream handler and exchanges labels with the peers already
+// connected.
+func NewLabelManager(h host.Host, labels map[string]string) (*LabelManager, error) {
+	if err := ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lm := &LabelManager{
+		host:   h,
+		log:    getLogger("labels"),
+		labels: cloneLabels(labels),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	_ = h.Peerstore().Put(h.ID(), PeerstoreLabelsKey, cloneLabels(lm.labels))
+
+	h.SetStreamHandler(ProtocolLabels, lm.handleStream)
+	lm.notifee = &network.NotifyBundle{
+		ConnectedF: func(_ network.Network, c network.Conn) {
+			lm.exchangeAsync(c.RemotePeer())
+		},
+	}
+	h.Network().Notify(lm.notifee)
+
+	lm.pushAll()
+	return lm, nil
+}
+
+// Labels returns this node's labels.
+func (lm *LabelManager) Labels() map[string]string {
+	lm.mu.RLock()
+	defer lm.mu.RUnlock()
+	return cloneLabels(lm.labels)
+}
+
+// SetLabels replaces this node's labels and pushes them to the connected peers.
+func (lm *LabelManager) SetLabels(labels map[string]string) error {
+	if err := ValidateLabels(labels); err != nil {
+		return err
+	}
+
+	lm.mu.Lock()
+	lm.labels = cloneLabels(labels)
+	lm.mu.Unlock()
+	_ = lm.host.Peerstore().Put(lm.host.ID(), PeerstoreLabelsKey, cloneLabels(labels))
+
+	lm.pushAll()
+	return nil
+}
+
+// PeerLabels returns the labels received from a peer.
+func (lm *LabelManager) PeerLabels(p peer.ID) map[string]string {
+	return PeerLabels(lm.host.Peerstore(), p)
+}
+
+// Peers returns the connected peers whose labels match selector.
+func (lm *LabelManager) Peers(selector map[string]string) []peer.ID {
+	var matched []peer.ID
+	for _, p := range lm.host.Network().Peers() {
+		if MatchLabels(lm.PeerLabels(p), selector) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+// Close stops exchanging labels and removes the stream handler.
+func (lm *LabelManager) Close() {
+	lm.host.Network().StopNotify(lm.notifee)
+	lm.host.RemoveStreamHandler(ProtocolLabels)
+	lm.cancel()
+	lm.wg.Wait()
+}
+
+// pushAll exchanges labels with every connected peer.
+func (lm *LabelManager) pushAll() {
+	for _, p := range lm.host.Network().Peers() {
+		lm.exchangeAsync(p)
+	}
+}
+
+func (lm *LabelManager) exchangeAsync(p peer.ID) {
+	if lm.ctx.Err() != nil {
+		return
+	}
+	lm.wg.Add(1)
+	go func() {
+		defer lm.wg.Done()
+		if err := lm.exchange(lm.ctx, p); err != nil {
+			lm.log.Debug("label exchange failed", "peer_id", p.String(), "error", err)
+		}
+	}()
+}
+
+// exchange sends this node's labels to a peer and stores the peer's reply.
+func (lm *LabelManager) exchange(ctx context.Context, p peer.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, labelExchangeTimeout)
+	defer cancel()
+
+	s, err := lm.host.NewStream(ctx, p, ProtocolLabels)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	_ = s.SetDeadline(time.Now().Add(labelExchangeTimeout))
+
+	if err := writeFrame(s, labelsFrame{Labels: lm.Labels()}); err != nil {
+		s.Reset()
+		return err
+	}
+
+	var reply labelsFrame
+	if err := readFrame(s, &reply, maxLabelsFrameSize); err != nil {
+		s.Reset()
+		return err
+	}
+	return lm.store(p, reply.Labels)
+}
+
+// handleStream stores a peer's labels and replies with this node's labels.
+func (lm *LabelManager) handleStream(s network.Stream) {
+	defer s.Close()
+
+	remote := s.Conn().RemotePeer()
+	_ = s.SetDeadline(time.Now().Add(labelExchangeTimeout))
+
+	var frame labelsFrame
+	if err := readFrame(s, &frame, maxLabelsFrameSize); err != nil {
+		lm.log.Debug("failed to read labels", "peer_id", remote.String(), "error", err)
+		s.Reset()
+		return
+	}
+	if err := lm.store(remote, frame.Labels); err != nil {
+		lm.log.Debug("rejected labels", "peer_id", remote.String(), "error", err)
+	}
+
+	if err := writeFrame(s, labelsFrame{Labels: lm.Labels()}); err != nil {
+		lm.log.Debug("failed to write labels", "peer_id", remote.String(), "error", err)
+		s.Reset()
+	}
+}
+
+// store keeps a peer's labels in the peerstore.
+func (lm *LabelManager) store(p peer.ID, labels map[string]string) error {
+	if err := ValidateLabels(labels); err != nil {
+		return err
+	}
+	return lm.host.Peerstore().Put(p, PeerstoreLabelsKey, cloneLabels(labels))
+}
+
+// cloneLabels copies labels, returning an empty map for nil.
+func cloneLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels))
+	maps.Copy(out, labels)
+	return out
+}
@@ -0,0 +1,141 @@
+package p2p
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"valid", map[string]string{"region": "eu-west", "capacity_class": "large", "v2": "1.2"}, false},
+		{"empty value", map[string]string{"role": ""}, false},
+		{"uppercase key", map[string]string{"Region": "eu"}, true},
+		{"dotted key", map[string]string{"topology.region": "eu"}, true},
+		{"key ends with dash", map[string]string{"region-": "eu"}, true},
+		{"empty key", map[string]string{"": "eu"}, true},
+		{"space in value", map[string]string{"region": "eu west"}, true},
+		{"long value", map[string]string{"region": strings.Repeat("a", 64)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLabels(tt.labels)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchLabels(t *testing.T) {
+	labels := map[string]string{"region": "eu-west", "role": "archive"}
+
+	if !MatchLabels(labels, nil) {
+		t.Error("empty selector should match")
+	}
+	if !MatchLabels(labels, map[string]string{"region": "eu-west"}) {
+		t.Error("subset selector should match")
+	}
+	if MatchLabels(labels, map[string]string{"region": "us-east"}) {
+		t.Error("different value should not match")
+	}
+	if MatchLabels(nil, map[string]string{"region": "eu-west"}) {
+		t.Error("missing label should not match")
+	}
+}
+
+func TestLabelManager_Exchange(t *testing.T) {
+	cfg := config.P2PConfig{
+		Enabled:         true,
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		ConnManager: config.ConnManagerConfig{
+			LowWatermark:  10,
+			HighWatermark: 40,
+			GracePeriod:   time.Second,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	a, err := NewHost(ctx, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create host: %v", err)
+	}
+	defer a.Close()
+
+	b, err := NewHost(ctx, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create host: %v", err)
+	}
+	defer b.Close()
+
+	la, err := NewLabelManager(a.Host, map[string]string{"region": "eu-west"})
+	if err != nil {
+		t.Fatalf("NewLabelManager() error = %v", err)
+	}
+	defer la.Close()
+
+	lb, err := NewLabelManager(b.Host, map[string]string{"region": "us-east", "role": "archive"})
+	if err != nil {
+		t.Fatalf("NewLabelManager() error = %v", err)
+	}
+	defer lb.Close()
+
+	if err := a.Connect(ctx, peer.AddrInfo{ID: b.PeerID(), Addrs: b.ListenAddrs()}); err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	waitForLabels(t, la, b.PeerID(), "region", "us-east")
+	waitForLabels(t, lb, a.PeerID(), "region", "eu-west")
+
+	if got := la.Peers(map[string]string{"role": "archive"}); len(got) != 1 || got[0] != b.PeerID() {
+		t.Errorf("Peers(role=archive) = %v, want [%s]", got, b.PeerID())
+	}
+	if got := la.Peers(map[string]string{"role": "edge"}); len(got) != 0 {
+		t.Errorf("Peers(role=edge) = %v, want none", got)
+	}
+
+	t.Run("changes are pushed", func(t *testing.T) {
+		if err := la.SetLabels(map[string]string{"region": "ap-south"}); err != nil {
+			t.Fatalf("SetLabels() error = %v", err)
+		}
+		waitForLabels(t, lb, a.PeerID(), "region", "ap-south")
+
+		if got := PeerLabels(a.Peerstore(), a.PeerID()); got["region"] != "ap-south" {
+			t.Errorf("own labels in peerstore = %v", got)
+		}
+	})
+
+	t.Run("invalid labels are refused", func(t *testing.T) {
+		if err := la.SetLabels(map[string]string{"Region": "x"}); err == nil {
+			t.Error("SetLabels() accepted an invalid key")
+		}
+		if got := la.Labels()["region"]; got != "ap-south" {
+			t.Errorf("labels changed after refused update: region = %q", got)
+		}
+	})
+}
+
+func waitForLabels(t *testing.T, lm *LabelManager, p peer.ID, key, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if lm.PeerLabels(p)[key] == want {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("labels of %s = %v, want %s=%s", p, lm.PeerLabels(p), key, want)
+}
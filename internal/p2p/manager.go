@@ -54,6 +54,9 @@ type NodeManager interface {
 	// GRPCClient returns the P2P gRPC client for calling remote peers.
 	// Returns nil if gRPC-over-P2P is not enabled.
 	GRPCClient() *GRPCClient
+
+	// Close stops the background stats refresh.
+	Close() error
 }
 
 // NodeManagerInfo contains information about a node.
@@ -103,6 +106,9 @@ type NodeManagerInfo struct {
 	// LastSeen is when we last saw this node.
 	LastSeen time.Time
 
+	// Labels are the node's labels, as exchanged over ProtocolLabels.
+	Labels map[string]string
+
 	// Metadata holds additional node information.
 	Metadata map[string]string
 }
@@ -253,6 +259,9 @@ type defaultNodeManager struct {
 	// In-memory ban list (supplemented by database)
 	banMu   sync.RWMutex
 	banList map[peer.ID]*BannedPeerInfo
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 // NewNodeManager creates a new NodeManager wrapping a Host.
@@ -262,6 +271,7 @@ func NewNodeManager(host *Host, cfg DefaultNodeManagerConfig) NodeManager {
 		cfg:         cfg,
 		subscribers: make(map[chan<- NodeEvent]struct{}),
 		banList:     make(map[peer.ID]*BannedPeerInfo),
+		done:        make(chan struct{}),
 	}
 
 	// Start background stats refresh
@@ -290,6 +300,7 @@ func (nm *defaultNodeManager) GetSelfInfo() (*NodeManagerInfo, error) {
 		Protocols:    protoStrings,
 		Connected:    true,
 		AgentVersion: "bib/1.0.0", // TODO: Get from version package
+		Labels:       PeerLabels(h.Peerstore(), h.ID()),
 		Metadata:     make(map[string]string),
 	}, nil
 }
@@ -326,6 +337,7 @@ func (nm *defaultNodeManager) GetPeerInfo(peerID peer.ID) (*NodeManagerInfo, err
 		Connected:    connected,
 		LatencyMs:    latencyMs,
 		AgentVersion: agentVersionStr,
+		Labels:       PeerLabels(ps, peerID),
 		Metadata:     make(map[string]string),
 	}, nil
 }
@@ -478,11 +490,22 @@ func (nm *defaultNodeManager) statsRefreshLoop() {
 	ticker := time.NewTicker(nm.cfg.StatsCacheInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		nm.refreshStats()
+	for {
+		select {
+		case <-nm.done:
+			return
+		case <-ticker.C:
+			nm.refreshStats()
+		}
 	}
 }
 
+// Close stops the background stats refresh.
+func (nm *defaultNodeManager) Close() error {
+	nm.closeOnce.Do(func() { close(nm.done) })
+	return nil
+}
+
 // SubscribeNodeEvents subscribes to node events.
 func (nm *defaultNodeManager) SubscribeNodeEvents(ctx context.Context, bufferSize int) (<-chan NodeEvent, error) {
 	if bufferSize <= 0 {
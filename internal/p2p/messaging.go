@@ -0,0 +1,347 @@
+package p2p
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+
+	"github.com/google/uuid"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ProtocolMessaging is the protocol for direct node-to-node messages.
+const ProtocolMessaging = "/bib/msg/1.0.0"
+
+var (
+	// ErrMessagingDisabled is returned when direct messaging is disabled.
+	ErrMessagingDisabled = errors.New("direct messaging is disabled")
+
+	// ErrPayloadTooLarge is returned when a payload exceeds the configured limit.
+	ErrPayloadTooLarge = errors.New("message payload too large")
+)
+
+// MessageRejectedError is returned when the receiving peer refuses a message.
+// Rejections are final and are not retried.
+type MessageRejectedError struct {
+	Reason string
+}
+
+func (e *MessageRejectedError) Error() string {
+	return "message rejected by peer: " + e.Reason
+}
+
+// seenTTL is how long received message IDs are remembered so that
+// retried deliveries are acknowledged without being handled twice.
+const seenTTL = 10 * time.Minute
+
+// DirectMessage is a message sent directly from one node to another.
+type DirectMessage struct {
+	ID      string    `json:"id"`
+	From    string    `json:"from"`
+	Kind    string    `json:"kind,omitempty"`
+	Payload []byte    `json:"payload"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// MessageAck is the receiving peer's acknowledgment of a direct message.
+type MessageAck struct {
+	MessageID string `json:"message_id"`
+	Accepted  bool   `json:"accepted"`
+	Reason    string `json:"reason,omitempty"`
+
+	// Attempts is how many deliveries the sender made; it is not sent on the wire.
+	Attempts int `json:"-"`
+}
+
+// DirectMessageHandler handles an accepted direct message. Returning an
+// error rejects the message with the error text as the reason.
+type DirectMessageHandler func(ctx context.Context, msg DirectMessage) error
+
+// MessageAuthorizer decides whether a peer may send direct messages.
+// PeerAuthorizer satisfies this interface.
+type MessageAuthorizer interface {
+	Authorize(ctx context.Context, peerID peer.ID) error
+}
+
+// Messenger sends and receives direct messages over ProtocolMessaging.
+type Messenger struct {
+	host host.Host
+	cfg  config.MessagingConfig
+	auth MessageAuthorizer
+	log  *logger.Logger
+
+	allowAll bool
+	allowed  map[string]struct{}
+
+	mu      sync.Mutex
+	handler DirectMessageHandler
+	seen    map[string]time.Time
+}
+
+// NewMessenger creates a messenger and registers its stream handler on the host.
+// Peers listed in cfg.AllowedPeers are always accepted; other peers are
+// accepted only if auth authorizes them. A nil auth accepts only the listed peers.
+func NewMessenger(h host.Host, cfg config.MessagingConfig, auth MessageAuthorizer) *Messenger {
+	m := &Messenger{
+		host:    h,
+		cfg:     cfg,
+		auth:    auth,
+		log:     getLogger("messaging"),
+		allowed: make(map[string]struct{}),
+		seen:    make(map[string]time.Time),
+	}
+	for _, p := range cfg.AllowedPeers {
+		if p == "*" {
+			m.allowAll = true
+			continue
+		}
+		m.allowed[p] = struct{}{}
+	}
+
+	h.SetStreamHandler(ProtocolMessaging, m.handleStream)
+	return m
+}
+
+// OnMessage sets the handler for accepted messages. Without a handler,
+// messages are acknowledged and dropped.
+func (m *Messenger) OnMessage(fn DirectMessageHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handler = fn
+}
+
+// Close removes the stream handler.
+func (m *Messenger) Close() {
+	m.host.RemoveStreamHandler(ProtocolMessaging)
+}
+
+// Send delivers a message to a peer and waits for its acknowledgment.
+// Each attempt is bounded by the configured timeout; transport failures are
+// retried with exponential backoff, rejections are returned immediately.
+// Retries reuse the message ID, so the peer handles the message at most once.
+func (m *Messenger) Send(ctx context.Context, to peer.ID, kind string, payload []byte) (MessageAck, error) {
+	if !m.cfg.Enabled {
+		return MessageAck{}, ErrMessagingDisabled
+	}
+	if len(payload) > m.cfg.MaxPayloadSize {
+		return MessageAck{}, fmt.Errorf("%w: %d bytes, limit is %d", ErrPayloadTooLarge, len(payload), m.cfg.MaxPayloadSize)
+	}
+
+	msg := DirectMessage{
+		ID:      uuid.New().String(),
+		From:    m.host.ID().String(),
+		Kind:    kind,
+		Payload: payload,
+		SentAt:  time.Now().UTC(),
+	}
+
+	backoff := m.cfg.RetryBackoff
+	var lastErr error
+	for attempt := 1; attempt <= m.cfg.Retries+1; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return MessageAck{MessageID: msg.ID, Attempts: attempt - 1}, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		ack, err := m.deliver(ctx, to, &msg)
+		ack.Attempts = attempt
+		if err == nil {
+			if !ack.Accepted {
+				return ack, &MessageRejectedError{Reason: ack.Reason}
+			}
+			return ack, nil
+		}
+
+		lastErr = err
+		m.log.Debug("message delivery failed",
+			"peer_id", to.String(),
+			"message_id", msg.ID,
+			"attempt", attempt,
+			"error", err,
+		)
+		if ctx.Err() != nil {
+			return ack, ctx.Err()
+		}
+	}
+
+	return MessageAck{MessageID: msg.ID, Attempts: m.cfg.Retries + 1}, fmt.Errorf("deliver message to %s: %w", to, lastErr)
+}
+
+// deliver makes one delivery attempt.
+func (m *Messenger) deliver(ctx context.Context, to peer.ID, msg *DirectMessage) (MessageAck, error) {
+	if m.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.Timeout)
+		defer cancel()
+	}
+
+	s, err := m.host.NewStream(ctx, to, ProtocolMessaging)
+	if err != nil {
+		return MessageAck{MessageID: msg.ID}, err
+	}
+	defer s.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetDeadline(deadline)
+	}
+
+	if err := writeFrame(s, msg); err != nil {
+		s.Reset()
+		return MessageAck{MessageID: msg.ID}, err
+	}
+
+	var ack MessageAck
+	if err := readFrame(s, &ack, 64*1024); err != nil {
+		s.Reset()
+		return MessageAck{MessageID: msg.ID}, err
+	}
+	return ack, nil
+}
+
+// handleStream receives a message and writes its acknowledgment.
+func (m *Messenger) handleStream(s network.Stream) {
+	defer s.Close()
+
+	remote := s.Conn().RemotePeer()
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout())
+	defer cancel()
+	_ = s.SetDeadline(time.Now().Add(m.timeout()))
+
+	// JSON encodes the payload as base64, so allow for the expansion.
+	var msg DirectMessage
+	if err := readFrame(s, &msg, m.cfg.MaxPayloadSize/3*4+4096); err != nil {
+		m.log.Debug("failed to read message", "peer_id", remote.String(), "error", err)
+		s.Reset()
+		return
+	}
+
+	ack := m.accept(ctx, remote, msg)
+	if err := writeFrame(s, &ack); err != nil {
+		m.log.Debug("failed to write acknowledgment", "peer_id", remote.String(), "error", err)
+		s.Reset()
+	}
+}
+
+// accept validates and handles a received message.
+func (m *Messenger) accept(ctx context.Context, remote peer.ID, msg DirectMessage) MessageAck {
+	ack := MessageAck{MessageID: msg.ID}
+
+	if !m.cfg.Enabled {
+		ack.Reason = "messaging disabled"
+		return ack
+	}
+	if err := m.authorize(ctx, remote); err != nil {
+		m.log.Debug("rejected message from unauthorized peer", "peer_id", remote.String(), "error", err)
+		ack.Reason = "peer not allowed"
+		return ack
+	}
+	if msg.From != remote.String() {
+		ack.Reason = "sender does not match stream peer"
+		return ack
+	}
+	if len(msg.Payload) > m.cfg.MaxPayloadSize {
+		ack.Reason = fmt.Sprintf("payload exceeds %d bytes", m.cfg.MaxPayloadSize)
+		return ack
+	}
+
+	m.mu.Lock()
+	now := time.Now()
+	for id, at := range m.seen {
+		if now.Sub(at) > seenTTL {
+			delete(m.seen, id)
+		}
+	}
+	key := msg.From + "/" + msg.ID
+	_, duplicate := m.seen[key]
+	handler := m.handler
+	m.mu.Unlock()
+
+	if duplicate {
+		ack.Accepted = true
+		return ack
+	}
+
+	if handler != nil {
+		if err := handler(ctx, msg); err != nil {
+			ack.Reason = err.Error()
+			return ack
+		}
+	}
+
+	m.mu.Lock()
+	m.seen[key] = now
+	m.mu.Unlock()
+
+	ack.Accepted = true
+	return ack
+}
+
+// authorize checks whether a peer may send messages to this node.
+func (m *Messenger) authorize(ctx context.Context, p peer.ID) error {
+	if m.allowAll {
+		return nil
+	}
+	if _, ok := m.allowed[p.String()]; ok {
+		return nil
+	}
+	if m.auth == nil {
+		return ErrUnauthorizedPeer
+	}
+	return m.auth.Authorize(ctx, p)
+}
+
+func (m *Messenger) timeout() time.Duration {
+	if m.cfg.Timeout > 0 {
+		return m.cfg.Timeout
+	}
+	return 10 * time.Second
+}
+
+// writeFrame writes a length-prefixed JSON frame.
+func writeFrame(w io.Writer, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(body)))
+	if _, err := w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// readFrame reads a length-prefixed JSON frame of at most limit bytes.
+func readFrame(r io.Reader, v any, limit int) error {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return err
+	}
+
+	length := int(binary.BigEndian.Uint32(lenBuf[:]))
+	if length > limit {
+		return fmt.Errorf("frame too large: %d bytes", length)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
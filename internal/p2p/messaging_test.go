@@ -0,0 +1,149 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func TestMessenger_SendAndAcknowledge(t *testing.T) {
+	cfg := config.P2PConfig{
+		Enabled:         true,
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		ConnManager: config.ConnManagerConfig{
+			LowWatermark:  10,
+			HighWatermark: 40,
+			GracePeriod:   time.Second,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sender, err := NewHost(ctx, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create sender host: %v", err)
+	}
+	defer sender.Close()
+
+	receiver, err := NewHost(ctx, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create receiver host: %v", err)
+	}
+	defer receiver.Close()
+
+	sender.Peerstore().AddAddrs(receiver.PeerID(), receiver.ListenAddrs(), time.Minute)
+
+	msgCfg := config.MessagingConfig{
+		Enabled:        true,
+		MaxPayloadSize: 16,
+		Timeout:        2 * time.Second,
+		Retries:        1,
+		RetryBackoff:   10 * time.Millisecond,
+	}
+
+	out := NewMessenger(sender.Host, msgCfg, nil)
+	defer out.Close()
+
+	t.Run("unlisted peer is rejected", func(t *testing.T) {
+		in := NewMessenger(receiver.Host, msgCfg, nil)
+		defer in.Close()
+
+		ack, err := out.Send(ctx, receiver.PeerID(), "greeting", []byte("hello"))
+		var rejected *MessageRejectedError
+		if !errors.As(err, &rejected) {
+			t.Fatalf("Send() error = %v, want a rejection", err)
+		}
+		if ack.Accepted || ack.Attempts != 1 {
+			t.Errorf("ack = %+v, want a single unaccepted attempt", ack)
+		}
+	})
+
+	t.Run("allowed peer is delivered", func(t *testing.T) {
+		allowCfg := msgCfg
+		allowCfg.AllowedPeers = []string{sender.PeerID().String()}
+		in := NewMessenger(receiver.Host, allowCfg, nil)
+		defer in.Close()
+
+		var mu sync.Mutex
+		var got []DirectMessage
+		in.OnMessage(func(_ context.Context, msg DirectMessage) error {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, msg)
+			return nil
+		})
+
+		ack, err := out.Send(ctx, receiver.PeerID(), "greeting", []byte("hello"))
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		if !ack.Accepted || ack.MessageID == "" {
+			t.Errorf("ack = %+v, want accepted with an ID", ack)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(got) != 1 || string(got[0].Payload) != "hello" || got[0].Kind != "greeting" {
+			t.Fatalf("received %+v", got)
+		}
+		if got[0].From != sender.PeerID().String() || got[0].ID != ack.MessageID {
+			t.Errorf("received message %+v does not match ack %+v", got[0], ack)
+		}
+	})
+
+	t.Run("oversized payload is refused locally", func(t *testing.T) {
+		_, err := out.Send(ctx, receiver.PeerID(), "", make([]byte, 17))
+		if !errors.Is(err, ErrPayloadTooLarge) {
+			t.Errorf("Send() error = %v, want ErrPayloadTooLarge", err)
+		}
+	})
+
+	t.Run("unreachable peer is retried", func(t *testing.T) {
+		receiver.RemoveStreamHandler(ProtocolMessaging)
+
+		ack, err := out.Send(ctx, receiver.PeerID(), "", []byte("hello"))
+		if err == nil {
+			t.Fatal("Send() succeeded without a handler on the peer")
+		}
+		if ack.Attempts != 2 {
+			t.Errorf("attempts = %d, want 2", ack.Attempts)
+		}
+	})
+}
+
+func TestMessenger_DuplicateDelivery(t *testing.T) {
+	m := &Messenger{
+		cfg:      config.MessagingConfig{Enabled: true, MaxPayloadSize: 64},
+		log:      getLogger("messaging"),
+		allowAll: true,
+		seen:     make(map[string]time.Time),
+	}
+
+	calls := 0
+	m.OnMessage(func(context.Context, DirectMessage) error {
+		calls++
+		return nil
+	})
+
+	from := peer.ID("peer-a")
+	msg := DirectMessage{ID: "m1", From: from.String(), Payload: []byte("x")}
+	for i := 0; i < 2; i++ {
+		if ack := m.accept(context.Background(), from, msg); !ack.Accepted {
+			t.Fatalf("delivery %d not accepted: %+v", i+1, ack)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+
+	if ack := m.accept(context.Background(), "peer-b", msg); ack.Accepted {
+		t.Error("message with a forged sender was accepted")
+	}
+}
@@ -2,11 +2,14 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 
 	"bib/internal/config"
+	"bib/internal/domain"
+	"bib/internal/maintenance"
 
 	"github.com/libp2p/go-libp2p/core/host"
 )
@@ -57,6 +60,22 @@ type ModeHandler interface {
 	OnConfigUpdate(cfg config.P2PConfig) error
 }
 
+// ErrNotSelectiveMode is returned when managing subscriptions of a node
+// that is not in selective mode.
+var ErrNotSelectiveMode = errors.New("node is not in selective mode")
+
+// ErrP2PDisabled is returned when switching the mode of a node without P2P
+// networking.
+var ErrP2PDisabled = errors.New("P2P networking is disabled")
+
+// ModeChangeOptions control what happens to local data when switching mode.
+type ModeChangeOptions struct {
+	// PurgeReplica removes the replicated catalogs and sync checkpoints
+	// when leaving full mode. Otherwise they are kept, and a later switch
+	// back to full resumes from the checkpoints.
+	PurgeReplica bool
+}
+
 // ModeManager manages the current node mode and handles mode switching.
 type ModeManager struct {
 	host      host.Host
@@ -67,6 +86,9 @@ type ModeManager struct {
 	mode    NodeMode
 	handler ModeHandler
 	cfg     config.P2PConfig
+	gate    *maintenance.Gate
+
+	onConflict func(domain.SyncConflict)
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -102,6 +124,31 @@ func NewModeManager(h host.Host, discovery *Discovery, cfg config.P2PConfig, con
 	return mm, nil
 }
 
+// SetPauseGate makes the handlers' background sync observe the maintenance
+// pause signal. It must be called before Start.
+func (mm *ModeManager) SetPauseGate(gate *maintenance.Gate) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.gate = gate
+	gate.Register(maintenance.WorkerSync)
+	if full, ok := mm.handler.(*FullReplicaHandler); ok {
+		full.gate = gate
+	}
+}
+
+// OnConflict registers a callback for the catalog conflicts found by full
+// replica sync. It must be called before Start.
+func (mm *ModeManager) OnConflict(fn func(domain.SyncConflict)) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	mm.onConflict = fn
+	if full, ok := mm.handler.(*FullReplicaHandler); ok {
+		full.onConflict = fn
+	}
+}
+
 // Start begins the mode handler.
 func (mm *ModeManager) Start(ctx context.Context) error {
 	modeLog := getLogger("mode")
@@ -151,26 +198,25 @@ func (mm *ModeManager) Mode() NodeMode {
 
 // SetMode switches to a new mode at runtime.
 func (mm *ModeManager) SetMode(mode NodeMode) error {
+	return mm.SwitchMode(mode, ModeChangeOptions{})
+}
+
+// SwitchMode switches to a new mode at runtime. Entering full mode starts
+// an initial sync with the connected peers. If the new handler cannot be
+// started, the previous mode is restored.
+func (mm *ModeManager) SwitchMode(mode NodeMode, opts ModeChangeOptions) error {
 	modeLog := getLogger("mode")
-	modeLog.Info("switching mode", "from", mm.mode, "to", mode)
 
 	mm.mu.Lock()
 	defer mm.mu.Unlock()
 
+	modeLog.Info("switching mode", "from", mm.mode, "to", mode)
+
 	if mm.mode == mode {
 		modeLog.Debug("already in requested mode", "mode", mode)
 		return nil // Already in this mode
 	}
 
-	// Stop current handler
-	if mm.handler != nil {
-		modeLog.Debug("stopping current handler", "mode", mm.mode)
-		if err := mm.handler.Stop(); err != nil {
-			modeLog.Error("failed to stop current handler", "error", err)
-			return fmt.Errorf("failed to stop current handler: %w", err)
-		}
-	}
-
 	// Create new handler
 	modeLog.Debug("creating new handler", "mode", mode)
 	handler, err := mm.createHandler(mode)
@@ -179,12 +225,34 @@ func (mm *ModeManager) SetMode(mode NodeMode) error {
 		return fmt.Errorf("failed to create handler for mode %s: %w", mode, err)
 	}
 
+	// Stop current handler
+	previous := mm.handler
+	if previous != nil {
+		modeLog.Debug("stopping current handler", "mode", mm.mode)
+		if err := previous.Stop(); err != nil {
+			modeLog.Error("failed to stop current handler", "error", err)
+			return fmt.Errorf("failed to stop current handler: %w", err)
+		}
+	}
+
 	// Start new handler
 	if err := handler.Start(mm.ctx); err != nil {
 		modeLog.Error("failed to start handler", "mode", mode, "error", err)
+		if previous != nil {
+			if restartErr := mm.restartHandler(previous); restartErr != nil {
+				modeLog.Error("failed to restore previous handler", "mode", mm.mode, "error", restartErr)
+			}
+		}
 		return fmt.Errorf("failed to start handler for mode %s: %w", mode, err)
 	}
 
+	// Drop the replica of the full mode handler left
+	if full, ok := previous.(*FullReplicaHandler); ok && opts.PurgeReplica {
+		if err := full.Purge(); err != nil {
+			modeLog.Warn("failed to purge replicated data", "error", err)
+		}
+	}
+
 	mm.mode = mode
 	mm.handler = handler
 
@@ -192,6 +260,20 @@ func (mm *ModeManager) SetMode(mode NodeMode) error {
 	return nil
 }
 
+// restartHandler starts a fresh handler in the mode of a stopped one
+// (caller must hold lock).
+func (mm *ModeManager) restartHandler(stopped ModeHandler) error {
+	handler, err := mm.createHandler(stopped.Mode())
+	if err != nil {
+		return err
+	}
+	if err := handler.Start(mm.ctx); err != nil {
+		return err
+	}
+	mm.handler = handler
+	return nil
+}
+
 // UpdateConfig updates the configuration and notifies the handler.
 func (mm *ModeManager) UpdateConfig(cfg config.P2PConfig) error {
 	mm.mu.Lock()
@@ -221,6 +303,59 @@ func (mm *ModeManager) UpdateConfig(cfg config.P2PConfig) error {
 	return nil
 }
 
+// SyncStats returns the sync metrics of the handler, or false if the node
+// is not a full replica.
+func (mm *ModeManager) SyncStats() (SyncStats, bool) {
+	mm.mu.RLock()
+	full, ok := mm.handler.(*FullReplicaHandler)
+	mm.mu.RUnlock()
+	if !ok {
+		return SyncStats{}, false
+	}
+	return full.SyncStats(), true
+}
+
+// AddSubscription subscribes the selective mode handler to a topic pattern
+// and syncs it in the background. It reports false if already subscribed.
+func (mm *ModeManager) AddSubscription(pattern string) (domain.Subscription, bool, error) {
+	selective, err := mm.selective()
+	if err != nil {
+		return domain.Subscription{}, false, err
+	}
+	return selective.AddSubscription(pattern)
+}
+
+// RemoveSubscription unsubscribes the selective mode handler from a topic
+// pattern. It reports false if not subscribed.
+func (mm *ModeManager) RemoveSubscription(pattern string) (bool, error) {
+	selective, err := mm.selective()
+	if err != nil {
+		return false, err
+	}
+	return selective.RemoveSubscription(pattern)
+}
+
+// Subscriptions returns the subscriptions of the selective mode handler.
+func (mm *ModeManager) Subscriptions() ([]domain.Subscription, error) {
+	selective, err := mm.selective()
+	if err != nil {
+		return nil, err
+	}
+	return selective.Subscriptions(), nil
+}
+
+// selective returns the selective mode handler.
+func (mm *ModeManager) selective() (*SelectiveHandler, error) {
+	mm.mu.RLock()
+	defer mm.mu.RUnlock()
+
+	selective, ok := mm.handler.(*SelectiveHandler)
+	if !ok {
+		return nil, ErrNotSelectiveMode
+	}
+	return selective, nil
+}
+
 // Handler returns the current mode handler.
 func (mm *ModeManager) Handler() ModeHandler {
 	mm.mu.RLock()
@@ -236,7 +371,13 @@ func (mm *ModeManager) createHandler(mode NodeMode) (ModeHandler, error) {
 	case NodeModeSelective:
 		return NewSelectiveHandler(mm.host, mm.discovery, mm.cfg, mm.configDir)
 	case NodeModeFull:
-		return NewFullReplicaHandler(mm.host, mm.discovery, mm.cfg, mm.configDir)
+		handler, err := NewFullReplicaHandler(mm.host, mm.discovery, mm.cfg, mm.configDir)
+		if err != nil {
+			return nil, err
+		}
+		handler.gate = mm.gate
+		handler.onConflict = mm.onConflict
+		return handler, nil
 	default:
 		return nil, fmt.Errorf("unknown mode: %s", mode)
 	}
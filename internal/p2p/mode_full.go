@@ -3,45 +3,129 @@ package p2p
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
 	"bib/internal/config"
 	"bib/internal/domain"
+	"bib/internal/maintenance"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// defaultFullSyncInterval is how often a peer's full catalog is fetched if
+// FullReplicaConfig.FullSyncInterval is not set.
+const defaultFullSyncInterval = time.Hour
+
+// Sync kinds, as labeled in SyncStats.
+const (
+	SyncKindFull        = "full"
+	SyncKindIncremental = "incremental"
+)
+
+// SyncStats are the sync metrics of a full replica.
+type SyncStats struct {
+	// Lag is how long ago the connected peer synced least recently was
+	// synced. Peers never synced are not counted.
+	Lag time.Duration
+
+	// BytesTransferred counts the catalog bytes received, by sync kind.
+	BytesTransferred map[string]uint64
+}
+
+// syncCheckpoint records how far a peer's catalog has been synced. It is
+// persisted along with the catalog, so a restarted node resumes with the
+// changes since the checkpoint.
+type syncCheckpoint struct {
+	// Version is the peer's catalog version at the last sync.
+	Version uint64 `json:"version"`
+
+	// LastSync and LastFullSync are when the catalog was last synced, and
+	// last fetched in full.
+	LastSync     time.Time `json:"last_sync"`
+	LastFullSync time.Time `json:"last_full_sync"`
+
+	// Catalog is the peer's catalog as of Version.
+	Catalog *domain.Catalog `json:"catalog"`
+}
+
 // FullReplicaHandler handles full replica mode operations.
 // In this mode, the node replicates all data from connected peers.
+// Peers are synced incrementally: each sync only fetches the catalog
+// entries changed since the peer's checkpoint, and the full catalog is
+// fetched every FullSyncInterval to catch removed entries.
+//
+// Entries of the same dataset that diverged on different peers are
+// resolved with the configured conflict strategy. Conflicts are reported
+// to the OnConflict callback once, and with manual conflict resolution are
+// held until ResolveConflict picks the entry to keep.
 type FullReplicaHandler struct {
 	host      host.Host
 	discovery *Discovery
 	cfg       config.P2PConfig
 	configDir string
 	client    *ProtocolClient
+	gate      *maintenance.Gate // pauses syncing (optional)
+
+	// onConflict is called with each new conflict (optional)
+	onConflict func(domain.SyncConflict)
 
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
 
 	// mu protects the fields below
-	mu         sync.RWMutex
-	catalogs   map[peer.ID]*domain.Catalog
-	syncStatus domain.SyncStatus
+	mu          sync.RWMutex
+	catalogs    map[peer.ID]*domain.Catalog
+	checkpoints map[peer.ID]*syncCheckpoint
+	bytes       map[string]uint64
+	syncStatus  domain.SyncStatus
+	strategy    domain.ConflictStrategy
+	conflicts   map[string]*domain.SyncConflict // by SyncConflict.Key
+	resolved    map[domain.DatasetID]domain.CatalogEntry
 }
 
 // NewFullReplicaHandler creates a new full replica handler.
 func NewFullReplicaHandler(h host.Host, discovery *Discovery, cfg config.P2PConfig, configDir string) (*FullReplicaHandler, error) {
-	return &FullReplicaHandler{
-		host:      h,
-		discovery: discovery,
-		cfg:       cfg,
-		configDir: configDir,
-		catalogs:  make(map[peer.ID]*domain.Catalog),
-		client:    NewProtocolClient(h),
-	}, nil
+	strategy, err := domain.ParseConflictStrategy(cfg.FullReplica.ConflictResolution)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ParsePeerSelectionPolicy(cfg.FullReplica.PeerSelection.Policy); err != nil {
+		return nil, err
+	}
+
+	handler := &FullReplicaHandler{
+		host:        h,
+		discovery:   discovery,
+		cfg:         cfg,
+		configDir:   configDir,
+		catalogs:    make(map[peer.ID]*domain.Catalog),
+		checkpoints: make(map[peer.ID]*syncCheckpoint),
+		bytes:       make(map[string]uint64),
+		strategy:    strategy,
+		conflicts:   make(map[string]*domain.SyncConflict),
+		resolved:    make(map[domain.DatasetID]domain.CatalogEntry),
+		client:      NewProtocolClient(h),
+	}
+
+	// Resume from the persisted checkpoints
+	if err := handler.loadCheckpoints(); err != nil {
+		getLogger("mode").Warn("failed to load sync checkpoints, syncing in full", "error", err)
+	}
+	if err := handler.loadConflicts(); err != nil {
+		getLogger("mode").Warn("failed to load sync conflicts", "error", err)
+	}
+	handler.mu.Lock()
+	handler.resolveLocked(time.Now())
+	handler.mu.Unlock()
+
+	return handler, nil
 }
 
 // Mode returns the handler's mode.
@@ -74,8 +158,17 @@ func (h *FullReplicaHandler) Stop() error {
 
 // OnConfigUpdate handles configuration changes.
 func (h *FullReplicaHandler) OnConfigUpdate(cfg config.P2PConfig) error {
+	strategy, err := domain.ParseConflictStrategy(cfg.FullReplica.ConflictResolution)
+	if err != nil {
+		return err
+	}
+	if _, err := ParsePeerSelectionPolicy(cfg.FullReplica.PeerSelection.Policy); err != nil {
+		return err
+	}
+
 	h.mu.Lock()
 	h.cfg = cfg
+	h.strategy = strategy
 	h.mu.Unlock()
 	return nil
 }
@@ -84,7 +177,14 @@ func (h *FullReplicaHandler) OnConfigUpdate(cfg config.P2PConfig) error {
 func (h *FullReplicaHandler) SyncStatus() domain.SyncStatus {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	return h.syncStatus
+
+	status := h.syncStatus
+	for _, c := range h.conflicts {
+		if c.Pending {
+			status.PendingConflicts++
+		}
+	}
+	return status
 }
 
 // syncLoop runs periodic synchronization.
@@ -112,7 +212,9 @@ func (h *FullReplicaHandler) syncLoop() {
 	}
 }
 
-// syncAll synchronizes with all connected peers.
+// syncAll synchronizes with the connected peers. Under the topology peer
+// selection policy, only the peers matching this node's labels are synced
+// if any is connected; other policies sync every peer, closest first.
 func (h *FullReplicaHandler) syncAll() {
 	h.mu.Lock()
 	h.syncStatus.InProgress = true
@@ -125,14 +227,28 @@ func (h *FullReplicaHandler) syncAll() {
 		h.mu.Unlock()
 	}()
 
-	// Get all connected peers
-	peers := h.host.Network().Peers()
+	// Persist the checkpoints reached and the conflicts found
+	defer func() {
+		if err := h.saveCheckpoints(); err != nil {
+			getLogger("mode").Warn("failed to save sync checkpoints", "error", err)
+		}
+		if err := h.saveConflicts(); err != nil {
+			getLogger("mode").Warn("failed to save sync conflicts", "error", err)
+		}
+	}()
+
+	// Resolve the synced catalogs
+	defer h.resolve()
+
+	h.mu.RLock()
+	selection := h.cfg.FullReplica.PeerSelection
+	h.mu.RUnlock()
+	peers := NearestPeers(RankPeers(h.host, selection, h.host.Network().Peers()))
 
 	for _, peerID := range peers {
-		select {
-		case <-h.ctx.Done():
+		// Hold between peers while maintenance is paused
+		if err := h.gate.Wait(h.ctx, maintenance.WorkerSync); err != nil {
 			return
-		default:
 		}
 
 		if err := h.syncFromPeer(peerID); err != nil {
@@ -144,22 +260,47 @@ func (h *FullReplicaHandler) syncAll() {
 	}
 }
 
-// syncFromPeer syncs the catalog from a specific peer.
+// syncFromPeer syncs the catalog from a specific peer. Only the changes
+// since the peer's checkpoint are fetched, unless a full sync is due.
 func (h *FullReplicaHandler) syncFromPeer(peerID peer.ID) error {
 	ctx, cancel := context.WithTimeout(h.ctx, 30*time.Second)
 	defer cancel()
 
+	h.mu.RLock()
+	since := h.sinceVersionLocked(peerID, time.Now())
+	h.mu.RUnlock()
+
 	// Request catalog from peer
-	// TODO: This will use the /bib/discovery/1.0.0 protocol in Phase 1.4
-	catalog, err := h.requestCatalog(ctx, peerID)
+	catalog, size, err := h.client.GetCatalogSince(ctx, peerID, since)
 	if err != nil {
 		return err
 	}
 
-	// Store the catalog
+	now := time.Now()
+	kind := SyncKindFull
+	if catalog.Incremental {
+		kind = SyncKindIncremental
+	}
+
+	// Store the catalog and move the checkpoint
 	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.bytes[kind] += uint64(size)
+	cp := h.checkpoints[peerID]
+	if cp == nil {
+		cp = &syncCheckpoint{}
+		h.checkpoints[peerID] = cp
+	}
+	if catalog.Incremental {
+		catalog = mergeCatalog(h.catalogs[peerID], catalog)
+	} else {
+		cp.LastFullSync = now
+	}
+	cp.Version = catalog.Version
+	cp.LastSync = now
+	cp.Catalog = catalog
 	h.catalogs[peerID] = catalog
-	h.mu.Unlock()
 
 	// TODO: In Phase 2, this will trigger actual data replication
 	// For now, we just track the catalog
@@ -167,9 +308,347 @@ func (h *FullReplicaHandler) syncFromPeer(peerID peer.ID) error {
 	return nil
 }
 
-// requestCatalog requests a peer's catalog using the discovery protocol.
-func (h *FullReplicaHandler) requestCatalog(ctx context.Context, peerID peer.ID) (*domain.Catalog, error) {
-	return h.client.GetCatalog(ctx, peerID)
+// sinceVersionLocked returns the catalog version to sync a peer from: its
+// checkpoint, or 0 for a full sync if there is none or one is due (caller
+// must hold lock).
+func (h *FullReplicaHandler) sinceVersionLocked(peerID peer.ID, now time.Time) uint64 {
+	cp := h.checkpoints[peerID]
+	if cp == nil || h.catalogs[peerID] == nil {
+		return 0
+	}
+
+	interval := h.cfg.FullReplica.FullSyncInterval
+	if interval <= 0 {
+		interval = defaultFullSyncInterval
+	}
+	if now.Sub(cp.LastFullSync) >= interval {
+		return 0
+	}
+	return cp.Version
+}
+
+// mergeCatalog applies the changes of an incremental catalog to the
+// catalog they were requested against. Entries are matched by hash.
+func mergeCatalog(base, changes *domain.Catalog) *domain.Catalog {
+	merged := &domain.Catalog{
+		PeerID:      changes.PeerID,
+		LastUpdated: changes.LastUpdated,
+		Version:     changes.Version,
+	}
+
+	changed := make(map[string]bool, len(changes.Entries))
+	for _, e := range changes.Entries {
+		changed[e.Hash] = true
+	}
+	if base != nil {
+		for _, e := range base.Entries {
+			if !changed[e.Hash] {
+				merged.Entries = append(merged.Entries, e)
+			}
+		}
+	}
+	merged.Entries = append(merged.Entries, changes.Entries...)
+
+	return merged
+}
+
+// resolve resolves the peer catalogs and reports the new conflicts.
+func (h *FullReplicaHandler) resolve() {
+	h.mu.Lock()
+	found := h.resolveLocked(time.Now())
+	onConflict := h.onConflict
+	h.mu.Unlock()
+
+	if onConflict != nil {
+		for _, c := range found {
+			onConflict(c)
+		}
+	}
+}
+
+// resolveLocked rebuilds the resolved view of the peer catalogs, keeping
+// one entry per dataset, and returns the conflicts not seen before (caller
+// must hold lock). Peers are visited in a stable order so every sync
+// resolves alike.
+func (h *FullReplicaHandler) resolveLocked(now time.Time) []domain.SyncConflict {
+	peers := make([]peer.ID, 0, len(h.catalogs))
+	for peerID := range h.catalogs {
+		peers = append(peers, peerID)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+
+	manual := h.cfg.FullReplica.ManualConflictResolution
+	resolved := make(map[domain.DatasetID]domain.CatalogEntry)
+	from := make(map[domain.DatasetID]peer.ID)
+	var found []domain.SyncConflict
+
+	for _, peerID := range peers {
+		for _, entry := range h.catalogs[peerID].Entries {
+			if entry.DatasetID == "" {
+				continue
+			}
+
+			current, ok := resolved[entry.DatasetID]
+			switch {
+			case !ok:
+				resolved[entry.DatasetID] = entry
+				from[entry.DatasetID] = peerID
+				continue
+			case current.Hash == entry.Hash:
+				continue
+			case from[entry.DatasetID] == peerID:
+				// Versions of a dataset on one peer are not in conflict
+				if entry.UpdatedAt.After(current.UpdatedAt) {
+					resolved[entry.DatasetID] = entry
+				}
+				continue
+			}
+
+			winner, conflict, concurrent := domain.ResolveConflict(h.strategy, current, entry)
+			if !conflict {
+				resolved[entry.DatasetID] = winner
+				if winner.Hash == entry.Hash {
+					from[entry.DatasetID] = peerID
+				}
+				continue
+			}
+
+			c := domain.SyncConflict{
+				DatasetID:    entry.DatasetID,
+				Current:      current,
+				CurrentPeer:  from[entry.DatasetID].String(),
+				Incoming:     entry,
+				IncomingPeer: peerID.String(),
+				Strategy:     h.strategy,
+				WinnerHash:   winner.Hash,
+				Concurrent:   concurrent,
+				Pending:      manual,
+				DetectedAt:   now,
+			}
+			if known, ok := h.conflicts[c.Key()]; ok {
+				// Keep the operator's pick of a resolved conflict
+				if known.Resolved() {
+					switch known.WinnerHash {
+					case current.Hash:
+						winner = current
+					case entry.Hash:
+						winner = entry
+					}
+				}
+			} else {
+				h.conflicts[c.Key()] = &c
+				found = append(found, c)
+			}
+
+			resolved[entry.DatasetID] = winner
+			if winner.Hash == entry.Hash {
+				from[entry.DatasetID] = peerID
+			}
+		}
+	}
+
+	h.resolved = resolved
+	return found
+}
+
+// Conflicts returns the conflicts found, oldest first.
+func (h *FullReplicaHandler) Conflicts() []domain.SyncConflict {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	conflicts := make([]domain.SyncConflict, 0, len(h.conflicts))
+	for _, c := range h.conflicts {
+		conflicts = append(conflicts, *c)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].DetectedAt.Before(conflicts[j].DetectedAt)
+	})
+	return conflicts
+}
+
+// ResolveConflict resolves a conflict of a dataset by keeping the entry
+// with the given hash. It resolves every conflict of the dataset that
+// involves that entry.
+func (h *FullReplicaHandler) ResolveConflict(datasetID domain.DatasetID, hash string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var matched bool
+	for _, c := range h.conflicts {
+		if c.DatasetID != datasetID || (c.Current.Hash != hash && c.Incoming.Hash != hash) {
+			continue
+		}
+		c.WinnerHash = hash
+		c.Pending = false
+		c.ResolvedAt = time.Now()
+		matched = true
+	}
+	if !matched {
+		return fmt.Errorf("no conflict of dataset %s involves entry %s", datasetID, hash)
+	}
+
+	h.resolveLocked(time.Now())
+	return nil
+}
+
+// ResolvedEntries returns the entries kept after conflict resolution, one
+// per dataset.
+func (h *FullReplicaHandler) ResolvedEntries() []domain.CatalogEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	entries := make([]domain.CatalogEntry, 0, len(h.resolved))
+	for _, e := range h.resolved {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DatasetID < entries[j].DatasetID })
+	return entries
+}
+
+// SyncStats returns the sync metrics.
+func (h *FullReplicaHandler) SyncStats() SyncStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	stats := SyncStats{BytesTransferred: make(map[string]uint64, len(h.bytes))}
+	for kind, n := range h.bytes {
+		stats.BytesTransferred[kind] = n
+	}
+
+	now := time.Now()
+	for _, peerID := range h.host.Network().Peers() {
+		if cp := h.checkpoints[peerID]; cp != nil {
+			if lag := now.Sub(cp.LastSync); lag > stats.Lag {
+				stats.Lag = lag
+			}
+		}
+	}
+	return stats
+}
+
+// checkpointPath returns the path of the sync checkpoint file.
+func (h *FullReplicaHandler) checkpointPath() string {
+	return filepath.Join(h.configDir, "sync_checkpoints.json")
+}
+
+// loadCheckpoints loads the sync checkpoints and the catalogs they were
+// taken at from disk.
+func (h *FullReplicaHandler) loadCheckpoints() error {
+	data, err := os.ReadFile(h.checkpointPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var stored map[string]*syncCheckpoint
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, cp := range stored {
+		peerID, err := peer.Decode(id)
+		if err != nil || cp == nil || cp.Catalog == nil {
+			continue
+		}
+		h.checkpoints[peerID] = cp
+		h.catalogs[peerID] = cp.Catalog
+	}
+	return nil
+}
+
+// saveCheckpoints saves the sync checkpoints to disk.
+func (h *FullReplicaHandler) saveCheckpoints() error {
+	h.mu.RLock()
+	stored := make(map[string]*syncCheckpoint, len(h.checkpoints))
+	for peerID, cp := range h.checkpoints {
+		stored[peerID.String()] = cp
+	}
+	data, err := json.MarshalIndent(stored, "", "  ")
+	h.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	path := h.checkpointPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// conflictPath returns the path of the sync conflict file.
+func (h *FullReplicaHandler) conflictPath() string {
+	return filepath.Join(h.configDir, "sync_conflicts.json")
+}
+
+// loadConflicts loads the conflicts found, so they are not reported again
+// and manual resolutions are kept.
+func (h *FullReplicaHandler) loadConflicts() error {
+	data, err := os.ReadFile(h.conflictPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var stored []*domain.SyncConflict
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, c := range stored {
+		if c != nil {
+			h.conflicts[c.Key()] = c
+		}
+	}
+	return nil
+}
+
+// saveConflicts saves the conflicts found to disk.
+func (h *FullReplicaHandler) saveConflicts() error {
+	h.mu.RLock()
+	stored := make([]*domain.SyncConflict, 0, len(h.conflicts))
+	for _, c := range h.conflicts {
+		stored = append(stored, c)
+	}
+	data, err := json.MarshalIndent(stored, "", "  ")
+	h.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	path := h.conflictPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Purge removes the replicated catalogs, sync checkpoints and conflicts,
+// in memory and on disk. The handler must be stopped.
+func (h *FullReplicaHandler) Purge() error {
+	h.mu.Lock()
+	h.catalogs = make(map[peer.ID]*domain.Catalog)
+	h.checkpoints = make(map[peer.ID]*syncCheckpoint)
+	h.conflicts = make(map[string]*domain.SyncConflict)
+	h.resolved = make(map[domain.DatasetID]domain.CatalogEntry)
+	h.mu.Unlock()
+
+	for _, path := range []string{h.checkpointPath(), h.conflictPath()} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
 }
 
 // GetCatalogs returns all known peer catalogs.
@@ -0,0 +1,189 @@
+package p2p
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+	"bib/internal/domain"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func newTestPeerID(t *testing.T) peer.ID {
+	t.Helper()
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	id, err := peer.IDFromPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("failed to derive peer ID: %v", err)
+	}
+	return id
+}
+
+func TestMergeCatalog(t *testing.T) {
+	base := &domain.Catalog{
+		Version: 3,
+		Entries: []domain.CatalogEntry{
+			{Hash: "a", Size: 1, CatalogVersion: 1},
+			{Hash: "b", Size: 2, CatalogVersion: 3},
+		},
+	}
+	changes := &domain.Catalog{
+		Version:     5,
+		Incremental: true,
+		Entries: []domain.CatalogEntry{
+			{Hash: "b", Size: 20, CatalogVersion: 4},
+			{Hash: "c", Size: 3, CatalogVersion: 5},
+		},
+	}
+
+	merged := mergeCatalog(base, changes)
+	if merged.Version != 5 || merged.Incremental {
+		t.Errorf("unexpected catalog: %+v", merged)
+	}
+
+	sizes := make(map[string]int64)
+	for _, e := range merged.Entries {
+		sizes[e.Hash] = e.Size
+	}
+	if len(merged.Entries) != 3 || sizes["a"] != 1 || sizes["b"] != 20 || sizes["c"] != 3 {
+		t.Errorf("unexpected entries: %+v", merged.Entries)
+	}
+}
+
+func TestFullReplicaHandler_Checkpoints(t *testing.T) {
+	cfg := config.P2PConfig{FullReplica: config.FullReplicaConfig{FullSyncInterval: time.Hour}}
+	dir := t.TempDir()
+	peerID := newTestPeerID(t)
+	now := time.Now()
+
+	handler, err := NewFullReplicaHandler(nil, nil, cfg, dir)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	if since := handler.sinceVersionLocked(peerID, now); since != 0 {
+		t.Errorf("unsynced peer should sync in full, got since %d", since)
+	}
+
+	catalog := &domain.Catalog{Version: 7, Entries: []domain.CatalogEntry{{Hash: "a", CatalogVersion: 7}}}
+	handler.catalogs[peerID] = catalog
+	handler.checkpoints[peerID] = &syncCheckpoint{
+		Version:      7,
+		LastSync:     now,
+		LastFullSync: now.Add(-10 * time.Minute),
+		Catalog:      catalog,
+	}
+	if err := handler.saveCheckpoints(); err != nil {
+		t.Fatalf("failed to save checkpoints: %v", err)
+	}
+
+	// A restarted handler resumes from the checkpoint
+	restarted, err := NewFullReplicaHandler(nil, nil, cfg, dir)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	if since := restarted.sinceVersionLocked(peerID, now); since != 7 {
+		t.Errorf("since = %d, want 7", since)
+	}
+	if got := restarted.GetCatalogs()[peerID]; got == nil || len(got.Entries) != 1 {
+		t.Errorf("catalog not restored: %+v", got)
+	}
+
+	// A full sync is due once FullSyncInterval has passed
+	if since := restarted.sinceVersionLocked(peerID, now.Add(time.Hour)); since != 0 {
+		t.Errorf("since = %d after the full sync interval, want 0", since)
+	}
+}
+
+func TestFullReplicaHandler_ResolveConflicts(t *testing.T) {
+	cfg := config.P2PConfig{FullReplica: config.FullReplicaConfig{
+		ConflictResolution:       "last-writer-wins",
+		ManualConflictResolution: true,
+	}}
+	now := time.Now()
+	peerA, peerB := newTestPeerID(t), newTestPeerID(t)
+
+	handler, err := NewFullReplicaHandler(nil, nil, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+
+	var reported []domain.SyncConflict
+	handler.onConflict = func(c domain.SyncConflict) { reported = append(reported, c) }
+
+	handler.catalogs[peerA] = &domain.Catalog{Entries: []domain.CatalogEntry{
+		{DatasetID: "ds", Hash: "old", UpdatedAt: now.Add(-time.Minute)},
+	}}
+	handler.catalogs[peerB] = &domain.Catalog{Entries: []domain.CatalogEntry{
+		{DatasetID: "ds", Hash: "new", UpdatedAt: now},
+	}}
+
+	handler.resolve()
+	handler.resolve()
+	if len(reported) != 1 || !reported[0].Pending || reported[0].WinnerHash != "new" {
+		t.Fatalf("expected one pending conflict won by the last writer, got %+v", reported)
+	}
+	if got := handler.SyncStatus().PendingConflicts; got != 1 {
+		t.Errorf("PendingConflicts = %d, want 1", got)
+	}
+	if entries := handler.ResolvedEntries(); len(entries) != 1 || entries[0].Hash != "new" {
+		t.Errorf("unexpected resolved entries: %+v", entries)
+	}
+
+	// The operator's pick overrides the strategy
+	if err := handler.ResolveConflict("ds", "old"); err != nil {
+		t.Fatalf("ResolveConflict: %v", err)
+	}
+	if err := handler.ResolveConflict("ds", "other"); err == nil {
+		t.Error("expected an error for an entry not in conflict")
+	}
+	handler.resolve()
+	if entries := handler.ResolvedEntries(); len(entries) != 1 || entries[0].Hash != "old" {
+		t.Errorf("unexpected resolved entries: %+v", entries)
+	}
+	if got := handler.SyncStatus().PendingConflicts; got != 0 {
+		t.Errorf("PendingConflicts = %d, want 0", got)
+	}
+
+	if _, err := NewFullReplicaHandler(nil, nil, config.P2PConfig{FullReplica: config.FullReplicaConfig{
+		ConflictResolution: "newest",
+	}}, t.TempDir()); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
+func TestFullReplicaHandler_Purge(t *testing.T) {
+	dir := t.TempDir()
+	peerID := newTestPeerID(t)
+
+	handler, err := NewFullReplicaHandler(nil, nil, config.P2PConfig{}, dir)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	catalog := &domain.Catalog{Version: 1, Entries: []domain.CatalogEntry{{Hash: "a"}}}
+	handler.catalogs[peerID] = catalog
+	handler.checkpoints[peerID] = &syncCheckpoint{Version: 1, Catalog: catalog}
+	if err := handler.saveCheckpoints(); err != nil {
+		t.Fatalf("failed to save checkpoints: %v", err)
+	}
+
+	if err := handler.Purge(); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if len(handler.GetCatalogs()) != 0 {
+		t.Error("expected the catalogs to be removed")
+	}
+
+	restarted, err := NewFullReplicaHandler(nil, nil, config.P2PConfig{}, dir)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	if len(restarted.GetCatalogs()) != 0 {
+		t.Error("expected the checkpoints to be removed from disk")
+	}
+}
@@ -42,6 +42,10 @@ type ProxyHandler struct {
 
 // NewProxyHandler creates a new proxy handler.
 func NewProxyHandler(h host.Host, discovery *Discovery, cfg config.P2PConfig, configDir string) (*ProxyHandler, error) {
+	if _, err := ParsePeerSelectionPolicy(cfg.Proxy.PeerSelection.Policy); err != nil {
+		return nil, err
+	}
+
 	ph := &ProxyHandler{
 		host:      h,
 		discovery: discovery,
@@ -86,6 +90,10 @@ func (h *ProxyHandler) Stop() error {
 
 // OnConfigUpdate handles configuration changes.
 func (h *ProxyHandler) OnConfigUpdate(cfg config.P2PConfig) error {
+	if _, err := ParsePeerSelectionPolicy(cfg.Proxy.PeerSelection.Policy); err != nil {
+		return err
+	}
+
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -209,12 +217,13 @@ func (h *ProxyHandler) evictOldest() {
 	}
 }
 
-// forwardQuery forwards a query to available peers.
+// forwardQuery forwards a query to available peers. The peers are queried
+// tier by tier, closest first, until a tier returns entries.
 func (h *ProxyHandler) forwardQuery(ctx context.Context, req domain.QueryRequest) (*domain.QueryResult, error) {
 	// Get peers to query
-	peers := h.getPeersForForwarding()
+	tiers := h.getPeersForForwarding()
 
-	if len(peers) == 0 {
+	if len(tiers) == 0 {
 		// No peers available, return empty result
 		return &domain.QueryResult{
 			QueryID:    req.ID,
@@ -228,26 +237,33 @@ func (h *ProxyHandler) forwardQuery(ctx context.Context, req domain.QueryRequest
 	seen := make(map[string]bool)
 	var sourcePeer string
 
-	for _, peerID := range peers {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
+	for _, peers := range tiers {
+		if len(allEntries) > 0 {
+			// Farther peers are only asked if the closer ones had nothing
+			break
 		}
 
-		entries, err := h.queryPeer(ctx, peerID, req)
-		if err != nil {
-			continue
-		}
+		for _, peerID := range peers {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
 
-		if sourcePeer == "" && len(entries) > 0 {
-			sourcePeer = peerID.String()
-		}
+			entries, err := h.queryPeer(ctx, peerID, req)
+			if err != nil {
+				continue
+			}
+
+			if sourcePeer == "" && len(entries) > 0 {
+				sourcePeer = peerID.String()
+			}
 
-		for _, entry := range entries {
-			if !seen[entry.Hash] {
-				seen[entry.Hash] = true
-				allEntries = append(allEntries, entry)
+			for _, entry := range entries {
+				if !seen[entry.Hash] {
+					seen[entry.Hash] = true
+					allEntries = append(allEntries, entry)
+				}
 			}
 		}
 	}
@@ -269,15 +285,17 @@ func (h *ProxyHandler) forwardQuery(ctx context.Context, req domain.QueryRequest
 	}, nil
 }
 
-// getPeersForForwarding returns peers to forward requests to.
-// Favorites are tried first, then discovered peers.
-func (h *ProxyHandler) getPeersForForwarding() []peer.ID {
+// getPeersForForwarding returns peers to forward requests to, grouped into
+// tiers by the peer selection policy. Favorites are tried first, then
+// discovered peers.
+func (h *ProxyHandler) getPeersForForwarding() [][]peer.ID {
 	h.mu.RLock()
 	favorites := h.favorites
+	selection := h.cfg.Proxy.PeerSelection
 	h.mu.RUnlock()
 
 	// Start with connected favorites
-	var peers []peer.ID
+	var peers, others []peer.ID
 	for _, fav := range favorites {
 		if h.host.Network().Connectedness(fav) == 1 { // Connected
 			peers = append(peers, fav)
@@ -295,11 +313,21 @@ func (h *ProxyHandler) getPeersForForwarding() []peer.ID {
 			}
 		}
 		if !isFavorite {
-			peers = append(peers, p)
+			others = append(others, p)
 		}
 	}
 
-	return peers
+	var tiers [][]peer.ID
+	for i, t := range RankPeers(h.host, selection, others) {
+		if i == 0 {
+			t.Peers = append(peers, t.Peers...)
+		}
+		tiers = append(tiers, t.Peers)
+	}
+	if len(tiers) == 0 && len(peers) > 0 {
+		tiers = append(tiers, peers)
+	}
+	return tiers
 }
 
 // queryPeer queries a specific peer using the discovery protocol.
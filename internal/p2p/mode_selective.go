@@ -3,8 +3,11 @@ package p2p
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,8 +18,24 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// Defaults of the on-demand fetch cache, if not configured.
+const (
+	defaultFetchCacheTTL  = 10 * time.Minute
+	defaultFetchCacheSize = 500
+)
+
+// fetchedDataset is a dataset fetched on demand from a peer.
+type fetchedDataset struct {
+	dataset    *domain.Dataset
+	sourcePeer peer.ID
+	expiresAt  time.Time
+	lastAccess time.Time
+}
+
 // SelectiveHandler handles selective mode operations.
 // In this mode, the node subscribes to specific topics on-demand.
+// Datasets outside the subscriptions are fetched from peers when accessed
+// and cached for FetchCacheTTL.
 type SelectiveHandler struct {
 	host      host.Host
 	discovery *Discovery
@@ -32,6 +51,7 @@ type SelectiveHandler struct {
 	mu            sync.RWMutex
 	subscriptions []domain.Subscription
 	catalog       map[string][]domain.CatalogEntry // keyed by topic pattern
+	fetched       map[domain.DatasetID]*fetchedDataset
 }
 
 // NewSelectiveHandler creates a new selective handler.
@@ -43,6 +63,7 @@ func NewSelectiveHandler(h host.Host, discovery *Discovery, cfg config.P2PConfig
 		configDir:     configDir,
 		subscriptions: []domain.Subscription{},
 		catalog:       make(map[string][]domain.CatalogEntry),
+		fetched:       make(map[domain.DatasetID]*fetchedDataset),
 		client:        NewProtocolClient(h),
 	}
 
@@ -62,6 +83,11 @@ func (h *SelectiveHandler) Mode() NodeMode {
 // Start begins selective mode operations.
 func (h *SelectiveHandler) Start(ctx context.Context) error {
 	h.ctx, h.cancel = context.WithCancel(ctx)
+
+	// Start fetch cache cleanup goroutine
+	h.wg.Add(1)
+	go h.cleanupLoop()
+
 	return nil
 }
 
@@ -102,6 +128,73 @@ func (h *SelectiveHandler) Subscribe(pattern string) error {
 	return h.saveSubscriptionsLocked()
 }
 
+// AddSubscription adds a topic subscription, persists it, and syncs the
+// matching topics in the background if the handler is running. It reports
+// false if already subscribed.
+func (h *SelectiveHandler) AddSubscription(pattern string) (domain.Subscription, bool, error) {
+	if err := validateTopicPattern(pattern); err != nil {
+		return domain.Subscription{}, false, err
+	}
+
+	h.mu.Lock()
+	for _, sub := range h.subscriptions {
+		if sub.TopicPattern == pattern {
+			h.mu.Unlock()
+			return sub, false, nil
+		}
+	}
+	h.addSubscriptionLocked(pattern)
+	sub := h.subscriptions[len(h.subscriptions)-1]
+	err := h.saveSubscriptionsLocked()
+	h.mu.Unlock()
+	if err != nil {
+		return sub, true, err
+	}
+
+	// Sync the new subscription right away
+	if h.ctx != nil {
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+
+			ctx, cancel := context.WithTimeout(h.ctx, time.Minute)
+			defer cancel()
+			if err := h.SyncSubscription(ctx, pattern); err != nil {
+				getLogger("mode").Warn("failed to sync new subscription", "pattern", pattern, "error", err)
+			}
+		}()
+	}
+
+	return sub, true, nil
+}
+
+// RemoveSubscription removes a topic subscription and persists the change.
+// It reports false if not subscribed.
+func (h *SelectiveHandler) RemoveSubscription(pattern string) (bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, sub := range h.subscriptions {
+		if sub.TopicPattern == pattern {
+			h.subscriptions = append(h.subscriptions[:i], h.subscriptions[i+1:]...)
+			delete(h.catalog, pattern)
+			return true, h.saveSubscriptionsLocked()
+		}
+	}
+	return false, nil
+}
+
+// validateTopicPattern checks a subscription pattern is well formed.
+func validateTopicPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("topic pattern must not be empty")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid topic pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
 // addSubscriptionLocked adds a subscription (caller must hold lock).
 func (h *SelectiveHandler) addSubscriptionLocked(pattern string) {
 	// Check if already subscribed
@@ -225,6 +318,135 @@ func (h *SelectiveHandler) SyncSubscription(ctx context.Context, pattern string)
 	return nil
 }
 
+// GetDataset returns a dataset, fetching it from a peer on a local miss.
+// Fetched datasets are cached for FetchCacheTTL; when the cache is full,
+// the least recently used one is evicted. It returns
+// domain.ErrDatasetNotFound if no connected peer has the dataset.
+func (h *SelectiveHandler) GetDataset(ctx context.Context, id domain.DatasetID) (*domain.Dataset, error) {
+	if dataset := h.getFetched(id, time.Now()); dataset != nil {
+		return dataset, nil
+	}
+
+	for _, peerID := range h.host.Network().Peers() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		dataset, err := h.client.GetDatasetInfo(ctx, peerID, id)
+		if err != nil || dataset == nil {
+			// Continue with other peers
+			continue
+		}
+
+		h.putFetched(id, dataset, peerID, time.Now())
+		return dataset, nil
+	}
+
+	return nil, domain.ErrDatasetNotFound
+}
+
+// getFetched returns a copy of a cached dataset if not expired.
+func (h *SelectiveHandler) getFetched(id domain.DatasetID, now time.Time) *domain.Dataset {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entry, ok := h.fetched[id]
+	if !ok || now.After(entry.expiresAt) {
+		return nil
+	}
+
+	entry.lastAccess = now
+	dataset := *entry.dataset
+	return &dataset
+}
+
+// putFetched caches a dataset fetched from a peer.
+func (h *SelectiveHandler) putFetched(id domain.DatasetID, dataset *domain.Dataset, sourcePeer peer.ID, now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ttl := h.cfg.Selective.FetchCacheTTL
+	if ttl <= 0 {
+		ttl = defaultFetchCacheTTL
+	}
+
+	if _, ok := h.fetched[id]; !ok && len(h.fetched) >= h.fetchCacheSizeLocked() {
+		h.evictLeastRecentLocked()
+	}
+
+	h.fetched[id] = &fetchedDataset{
+		dataset:    dataset,
+		sourcePeer: sourcePeer,
+		expiresAt:  now.Add(ttl),
+		lastAccess: now,
+	}
+}
+
+// fetchCacheSizeLocked returns the fetch cache size limit (caller must hold
+// lock).
+func (h *SelectiveHandler) fetchCacheSizeLocked() int {
+	if h.cfg.Selective.FetchCacheSize > 0 {
+		return h.cfg.Selective.FetchCacheSize
+	}
+	return defaultFetchCacheSize
+}
+
+// evictLeastRecentLocked removes the least recently used fetched dataset
+// (caller must hold lock).
+func (h *SelectiveHandler) evictLeastRecentLocked() {
+	var oldestID domain.DatasetID
+	var oldest time.Time
+
+	for id, entry := range h.fetched {
+		if oldestID == "" || entry.lastAccess.Before(oldest) {
+			oldestID = id
+			oldest = entry.lastAccess
+		}
+	}
+
+	if oldestID != "" {
+		delete(h.fetched, oldestID)
+	}
+}
+
+// cleanupLoop periodically removes expired fetched datasets.
+func (h *SelectiveHandler) cleanupLoop() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-ticker.C:
+			h.cleanupExpired(time.Now())
+		}
+	}
+}
+
+// cleanupExpired removes expired fetched datasets.
+func (h *SelectiveHandler) cleanupExpired(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, entry := range h.fetched {
+		if now.After(entry.expiresAt) {
+			delete(h.fetched, id)
+		}
+	}
+}
+
+// FetchCacheStats returns the size and limit of the on-demand fetch cache.
+func (h *SelectiveHandler) FetchCacheStats() (size int, maxSize int) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.fetched), h.fetchCacheSizeLocked()
+}
+
 // subscriptionStorePath returns the path to the subscription store file.
 func (h *SelectiveHandler) subscriptionStorePath() string {
 	if h.cfg.Selective.SubscriptionStorePath != "" {
@@ -297,5 +519,6 @@ func (h *SelectiveHandler) MarshalJSON() ([]byte, error) {
 		"mode":          h.Mode().String(),
 		"subscriptions": h.subscriptions,
 		"cached_topics": len(h.catalog),
+		"fetched":       len(h.fetched),
 	})
 }
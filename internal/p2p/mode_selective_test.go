@@ -5,8 +5,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"bib/internal/config"
+	"bib/internal/domain"
 )
 
 func TestSelectiveHandler_Subscriptions(t *testing.T) {
@@ -154,3 +156,78 @@ func TestSelectiveHandler_ConfigUpdate(t *testing.T) {
 		t.Fatalf("expected 2 subscriptions from config, got %d", len(subs))
 	}
 }
+
+func TestSelectiveHandler_FetchCache(t *testing.T) {
+	cfg := config.P2PConfig{Selective: config.SelectiveConfig{
+		FetchCacheTTL:  time.Minute,
+		FetchCacheSize: 2,
+	}}
+	handler, err := NewSelectiveHandler(nil, nil, cfg, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+
+	now := time.Now()
+	source := newTestPeerID(t)
+	handler.putFetched("a", &domain.Dataset{ID: "a"}, source, now)
+	handler.putFetched("b", &domain.Dataset{ID: "b"}, source, now.Add(time.Second))
+
+	// A cache hit is served without asking peers
+	if got := handler.getFetched("a", now.Add(1500*time.Millisecond)); got == nil || got.ID != "a" {
+		t.Fatalf("expected a cache hit, got %+v", got)
+	}
+
+	// "b" is now the least recently used and is evicted
+	handler.putFetched("c", &domain.Dataset{ID: "c"}, source, now.Add(2*time.Second))
+	if handler.getFetched("b", now.Add(3*time.Second)) != nil {
+		t.Error("expected the least recently used dataset to be evicted")
+	}
+	if size, max := handler.FetchCacheStats(); size != 2 || max != 2 {
+		t.Errorf("FetchCacheStats = %d, %d; want 2, 2", size, max)
+	}
+
+	// Fetched datasets expire after the TTL
+	if handler.getFetched("c", now.Add(2*time.Minute)) != nil {
+		t.Error("expected an expired dataset to be a miss")
+	}
+	handler.cleanupExpired(now.Add(2 * time.Minute))
+	if size, _ := handler.FetchCacheStats(); size != 0 {
+		t.Errorf("expected the expired datasets to be removed, %d left", size)
+	}
+}
+
+func TestSelectiveHandler_AddRemoveSubscription(t *testing.T) {
+	dir := t.TempDir()
+	handler, err := NewSelectiveHandler(nil, nil, config.P2PConfig{}, dir)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+
+	if _, _, err := handler.AddSubscription("weather/["); err == nil {
+		t.Error("expected an error for a malformed pattern")
+	}
+
+	sub, added, err := handler.AddSubscription("weather/*")
+	if err != nil || !added || sub.TopicPattern != "weather/*" {
+		t.Fatalf("AddSubscription = %+v, %v, %v", sub, added, err)
+	}
+	if _, added, _ := handler.AddSubscription("weather/*"); added {
+		t.Error("expected a duplicate subscription not to be added")
+	}
+
+	// The subscription is persisted right away
+	reloaded, err := NewSelectiveHandler(nil, nil, config.P2PConfig{}, dir)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	if subs := reloaded.Subscriptions(); len(subs) != 1 {
+		t.Fatalf("expected 1 persisted subscription, got %d", len(subs))
+	}
+
+	if removed, err := handler.RemoveSubscription("weather/*"); err != nil || !removed {
+		t.Fatalf("RemoveSubscription = %v, %v", removed, err)
+	}
+	if removed, _ := handler.RemoveSubscription("weather/*"); removed {
+		t.Error("expected removing an unknown pattern to report false")
+	}
+}
@@ -1,7 +1,10 @@
 package p2p
 
 import (
+	"errors"
 	"testing"
+
+	"bib/internal/config"
 )
 
 func TestParseNodeMode(t *testing.T) {
@@ -54,3 +57,28 @@ func TestNodeModeString(t *testing.T) {
 		})
 	}
 }
+
+func TestModeManager_SwitchMode(t *testing.T) {
+	mm, err := NewModeManager(nil, nil, config.P2PConfig{Mode: "proxy"}, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create mode manager: %v", err)
+	}
+	defer mm.Stop()
+
+	if _, err := mm.Subscriptions(); !errors.Is(err, ErrNotSelectiveMode) {
+		t.Errorf("expected ErrNotSelectiveMode in proxy mode, got %v", err)
+	}
+
+	if err := mm.SwitchMode(NodeModeSelective, ModeChangeOptions{}); err != nil {
+		t.Fatalf("SwitchMode: %v", err)
+	}
+	if mm.Mode() != NodeModeSelective {
+		t.Errorf("mode = %s, want selective", mm.Mode())
+	}
+	if _, ok := mm.Handler().(*SelectiveHandler); !ok {
+		t.Errorf("handler = %T, want *SelectiveHandler", mm.Handler())
+	}
+	if _, err := mm.Subscriptions(); err != nil {
+		t.Errorf("Subscriptions in selective mode: %v", err)
+	}
+}
@@ -125,6 +125,27 @@ func TopicInfoToProto(t *Topic) *bibv1.TopicInfo {
 		return nil
 	}
 
+	owners := make([]string, len(t.Owners))
+	for i, o := range t.Owners {
+		owners[i] = string(o)
+	}
+
+	acl := make([]*bibv1.TopicACLEntry, len(t.ACL))
+	for i, e := range t.ACL {
+		acl[i] = &bibv1.TopicACLEntry{
+			PrincipalType: string(e.PrincipalType),
+			Principal:     e.Principal,
+			Permission:    string(e.Permission),
+			GrantedBy:     string(e.GrantedBy),
+			GrantedAt:     timestamppb.New(e.GrantedAt),
+		}
+	}
+
+	var dataSchema *bibv1.DataSchema
+	if t.DataSchema != nil {
+		dataSchema = &bibv1.DataSchema{JsonSchema: t.DataSchema.JSONSchema, Mode: string(t.DataSchema.Mode)}
+	}
+
 	return &bibv1.TopicInfo{
 		Id:           string(t.ID),
 		Name:         t.Name,
@@ -133,6 +154,9 @@ func TopicInfoToProto(t *Topic) *bibv1.TopicInfo {
 		DatasetCount: int32(t.DatasetCount),
 		CreatedAt:    timestamppb.New(t.CreatedAt),
 		UpdatedAt:    timestamppb.New(t.UpdatedAt),
+		Acl:          acl,
+		Owners:       owners,
+		DataSchema:   dataSchema,
 	}
 }
 
@@ -255,6 +279,50 @@ func ProtoToDatasetInfo(d *bibv1.DatasetInfo) *Dataset {
 	}
 }
 
+// ProtoToTopicInfo converts a proto TopicInfo to domain Topic, including
+// its owners and ACL so access control follows the topic between nodes,
+// and its data schema so every node validates the same way.
+func ProtoToTopicInfo(t *bibv1.TopicInfo) *Topic {
+	if t == nil {
+		return nil
+	}
+
+	topic := &Topic{
+		ID:           TopicID(t.Id),
+		Name:         t.Name,
+		Description:  t.Description,
+		TableSchema:  t.Schema,
+		DatasetCount: int(t.DatasetCount),
+	}
+	if t.CreatedAt != nil {
+		topic.CreatedAt = t.CreatedAt.AsTime()
+	}
+	if t.UpdatedAt != nil {
+		topic.UpdatedAt = t.UpdatedAt.AsTime()
+	}
+
+	for _, o := range t.Owners {
+		topic.Owners = append(topic.Owners, domain.UserID(o))
+	}
+	for _, e := range t.Acl {
+		entry := domain.TopicACLEntry{
+			PrincipalType: domain.TopicPrincipalType(e.PrincipalType),
+			Principal:     e.Principal,
+			Permission:    domain.TopicPermission(e.Permission),
+			GrantedBy:     domain.UserID(e.GrantedBy),
+		}
+		if e.GrantedAt != nil {
+			entry.GrantedAt = e.GrantedAt.AsTime()
+		}
+		topic.ACL = append(topic.ACL, entry)
+	}
+	if s := t.DataSchema; s != nil {
+		topic.DataSchema = &domain.DataSchema{JSONSchema: s.JsonSchema, Mode: domain.SchemaMode(s.Mode)}
+	}
+
+	return topic
+}
+
 // ProtoToDatasetContent extracts DatasetContent from a proto DatasetInfo.
 func ProtoToDatasetContent(d *bibv1.DatasetInfo) *DatasetContent {
 	if d == nil {
@@ -33,6 +33,8 @@ func SupportedProtocols() []protocol.ID {
 		ProtocolJobs,
 		ProtocolSync,
 		ProtocolGRPC,
+		ProtocolMessaging,
+		ProtocolLabels,
 	}
 }
 
@@ -321,6 +323,15 @@ func makePayload(v interface{}) json.RawMessage {
 // Discovery handlers
 
 func (ph *ProtocolHandler) handleGetCatalog(ctx context.Context, peerID peer.ID, msg *Message) (*Message, error) {
+	var req struct {
+		SinceVersion uint64 `json:"since_version"`
+	}
+	if len(msg.Payload) > 0 {
+		if err := json.Unmarshal(msg.Payload, &req); err != nil {
+			return nil, err
+		}
+	}
+
 	ph.mu.RLock()
 	getCatalog := ph.onGetCatalog
 	ph.mu.RUnlock()
@@ -332,6 +343,13 @@ func (ph *ProtocolHandler) handleGetCatalog(ctx context.Context, peerID peer.ID,
 		catalog = ph.catalog
 	}
 
+	// Send only the changes if the peer has synced before
+	if catalog != nil {
+		if changes, ok := catalog.ChangesSince(req.SinceVersion); ok {
+			catalog = changes
+		}
+	}
+
 	return &Message{
 		Type:    MsgCatalog,
 		Payload: makePayload(catalog),
@@ -103,22 +103,33 @@ func (pc *ProtocolClient) sendRequest(ctx context.Context, peerID peer.ID, proto
 
 // GetCatalog retrieves a peer's catalog.
 func (pc *ProtocolClient) GetCatalog(ctx context.Context, peerID peer.ID) (*domain.Catalog, error) {
+	catalog, _, err := pc.GetCatalogSince(ctx, peerID, 0)
+	return catalog, err
+}
+
+// GetCatalogSince retrieves the entries of a peer's catalog changed after
+// version, along with the size of the response payload. The peer returns
+// its full catalog, with Incremental unset, if version is 0 or it cannot
+// tell the changes apart.
+func (pc *ProtocolClient) GetCatalogSince(ctx context.Context, peerID peer.ID, version uint64) (*domain.Catalog, int, error) {
 	msg := &Message{
-		Type:    MsgGetCatalog,
-		Payload: makePayload(map[string]interface{}{}),
+		Type: MsgGetCatalog,
+		Payload: makePayload(map[string]interface{}{
+			"since_version": version,
+		}),
 	}
 
 	resp, err := pc.sendRequest(ctx, peerID, ProtocolDiscovery, msg)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var catalog domain.Catalog
 	if err := json.Unmarshal(resp.Payload, &catalog); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return &catalog, nil
+	return &catalog, len(resp.Payload), nil
 }
 
 // QueryCatalog queries a peer's catalog.
@@ -20,6 +20,8 @@ import (
 const (
 	TopicGlobal = "/bib/global"
 	TopicNodes  = "/bib/nodes"
+	// TopicSelfTest prefixes the per-run topics used by Loopback.
+	TopicSelfTest = "/bib/selftest"
 	// Topic pattern: /bib/topics/<topic-id>
 )
 
@@ -34,6 +36,7 @@ const (
 	PubSubNewDataset    PubSubMessageType = "new_dataset"
 	PubSubTopicUpdate   PubSubMessageType = "topic_update"
 	PubSubDeleteDataset PubSubMessageType = "delete_dataset"
+	PubSubSelfTest      PubSubMessageType = "self_test"
 )
 
 // PubSubMessage is the wrapper for all pubsub messages.
@@ -417,6 +420,63 @@ func (p *PubSub) statusBroadcaster() {
 	}
 }
 
+// Loopback publishes a signed probe on a private topic and waits for it to be
+// delivered back to a local subscription. It exercises the publish, sign,
+// verify and delivery path without relying on other peers, and returns the
+// time from publish to receipt.
+func (p *PubSub) Loopback(ctx context.Context) (time.Duration, error) {
+	nonce := fmt.Sprintf("%d", time.Now().UnixNano())
+	topicName := fmt.Sprintf("%s/%s/%s", TopicSelfTest, p.host.ID(), nonce)
+
+	topic, err := p.ps.Join(topicName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to join topic %s: %w", topicName, err)
+	}
+	defer topic.Close()
+
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return 0, fmt.Errorf("failed to subscribe to topic %s: %w", topicName, err)
+	}
+	defer sub.Cancel()
+
+	p.mu.Lock()
+	p.topics[topicName] = topic
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.topics, topicName)
+		p.mu.Unlock()
+	}()
+
+	start := time.Now()
+	if err := p.Publish(ctx, topicName, PubSubSelfTest, nonce); err != nil {
+		return 0, fmt.Errorf("failed to publish: %w", err)
+	}
+
+	for {
+		msg, err := sub.Next(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("message not received: %w", err)
+		}
+
+		var psMsg PubSubMessage
+		if err := json.Unmarshal(msg.Data, &psMsg); err != nil || psMsg.Type != PubSubSelfTest {
+			continue
+		}
+		var got string
+		if err := json.Unmarshal(psMsg.Payload, &got); err != nil || got != nonce {
+			continue
+		}
+		if p.cfg.MessageSignature {
+			if valid, err := p.verifyMessage(&psMsg); err != nil || !valid {
+				return 0, fmt.Errorf("received message failed signature verification")
+			}
+		}
+		return time.Since(start), nil
+	}
+}
+
 // AnnounceNodeJoin announces this node joining the network.
 func (p *PubSub) AnnounceNodeJoin() error {
 	payload := map[string]interface{}{
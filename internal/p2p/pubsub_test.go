@@ -0,0 +1,57 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+)
+
+func TestPubSubLoopback(t *testing.T) {
+	tmpDir := t.TempDir()
+	cfg := config.P2PConfig{
+		Enabled:         true,
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		ConnManager: config.ConnManagerConfig{
+			LowWatermark:  10,
+			HighWatermark: 40,
+			GracePeriod:   time.Second,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	host, err := NewHost(ctx, cfg, tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create host: %v", err)
+	}
+	defer host.Close()
+
+	identity, err := LoadIdentity(cfg.Identity.KeyPath, tmpDir)
+	if err != nil {
+		t.Fatalf("failed to load identity: %v", err)
+	}
+
+	ps, err := NewPubSub(ctx, host, identity, cfg)
+	if err != nil {
+		t.Fatalf("failed to create pubsub: %v", err)
+	}
+	defer ps.Stop()
+
+	latency, err := ps.Loopback(ctx)
+	if err != nil {
+		t.Fatalf("loopback failed: %v", err)
+	}
+	if latency <= 0 {
+		t.Errorf("expected positive latency, got %v", latency)
+	}
+
+	// The per-run topic must not leak into the tracked topics
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	if len(ps.topics) != 0 {
+		t.Errorf("expected no tracked topics after loopback, got %d", len(ps.topics))
+	}
+}
@@ -0,0 +1,130 @@
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"bib/internal/config"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// PeerSelectionPolicy is the policy by which a node prefers some peers
+// over others when forwarding requests or syncing.
+type PeerSelectionPolicy string
+
+const (
+	// PeerSelectionAny uses the connected peers in no particular order.
+	PeerSelectionAny PeerSelectionPolicy = "any"
+
+	// PeerSelectionLatency prefers the peers with the lowest measured latency.
+	PeerSelectionLatency PeerSelectionPolicy = "latency"
+
+	// PeerSelectionTopology prefers the peers whose labels match this
+	// node's on the configured keys, then the lowest latency.
+	PeerSelectionTopology PeerSelectionPolicy = "topology"
+)
+
+// ParsePeerSelectionPolicy parses a string into a PeerSelectionPolicy.
+func ParsePeerSelectionPolicy(s string) (PeerSelectionPolicy, error) {
+	switch strings.ToLower(s) {
+	case "any", "":
+		return PeerSelectionAny, nil
+	case "latency":
+		return PeerSelectionLatency, nil
+	case "topology":
+		return PeerSelectionTopology, nil
+	default:
+		return "", fmt.Errorf("invalid peer selection policy: %s (must be any, latency, or topology)", s)
+	}
+}
+
+// PeerTier is a group of peers equally close to this node. Score is the
+// number of leading match labels on which the peers match this node's labels.
+type PeerTier struct {
+	Score int
+	Peers []peer.ID
+}
+
+// RankPeers groups peers into tiers by the selection policy, closest first.
+// With the topology policy, cfg.MatchLabels lists label keys from the
+// broadest to the narrowest, and peers matching this node's labels on more
+// of the leading keys come first; peers matching none form the last tier. Under
+// the latency and topology policies each tier is ordered by the latency
+// measured by the host, peers without a measurement last. The any policy
+// returns a single tier in the given order.
+func RankPeers(h host.Host, cfg config.PeerSelectionConfig, peers []peer.ID) []PeerTier {
+	if len(peers) == 0 {
+		return nil
+	}
+
+	policy, err := ParsePeerSelectionPolicy(cfg.Policy)
+	if err != nil || policy == PeerSelectionAny {
+		return []PeerTier{{Peers: peers}}
+	}
+
+	ps := h.Peerstore()
+	scores := make(map[peer.ID]int, len(peers))
+	if policy == PeerSelectionTopology {
+		local := PeerLabels(ps, h.ID())
+		for _, p := range peers {
+			scores[p] = labelScore(local, PeerLabels(ps, p), cfg.MatchLabels)
+		}
+	}
+
+	ranked := make([]peer.ID, len(peers))
+	copy(ranked, peers)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		la, lb := ps.LatencyEWMA(a), ps.LatencyEWMA(b)
+		if (la > 0) != (lb > 0) {
+			return la > 0
+		}
+		return la < lb
+	})
+
+	var tiers []PeerTier
+	for _, p := range ranked {
+		if n := len(tiers); n == 0 || tiers[n-1].Score != scores[p] {
+			tiers = append(tiers, PeerTier{Score: scores[p]})
+		}
+		tiers[len(tiers)-1].Peers = append(tiers[len(tiers)-1].Peers, p)
+	}
+	return tiers
+}
+
+// NearestPeers returns the peers of the tiers that match this node on at
+// least one label, or every peer if none does.
+func NearestPeers(tiers []PeerTier) []peer.ID {
+	var nearest, all []peer.ID
+	for _, t := range tiers {
+		if t.Score > 0 {
+			nearest = append(nearest, t.Peers...)
+		}
+		all = append(all, t.Peers...)
+	}
+	if len(nearest) > 0 {
+		return nearest
+	}
+	return all
+}
+
+// labelScore counts the leading keys on which a peer's labels equal this
+// node's, so with keys ["region", "zone"] a peer in the same zone of
+// another region scores 0. Keys this node has no label for never match.
+func labelScore(local, remote map[string]string, keys []string) int {
+	score := 0
+	for _, k := range keys {
+		v, ok := local[k]
+		if rv, rok := remote[k]; !ok || !rok || rv != v {
+			break
+		}
+		score++
+	}
+	return score
+}
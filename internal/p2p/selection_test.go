@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"bib/internal/config"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func TestParsePeerSelectionPolicy(t *testing.T) {
+	for in, want := range map[string]PeerSelectionPolicy{
+		"":         PeerSelectionAny,
+		"any":      PeerSelectionAny,
+		"Latency":  PeerSelectionLatency,
+		"topology": PeerSelectionTopology,
+	} {
+		got, err := ParsePeerSelectionPolicy(in)
+		if err != nil || got != want {
+			t.Errorf("ParsePeerSelectionPolicy(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParsePeerSelectionPolicy("nearest"); err == nil {
+		t.Error("ParsePeerSelectionPolicy(nearest) succeeded")
+	}
+}
+
+func TestRankPeers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	h, err := NewHost(ctx, config.P2PConfig{
+		Enabled:         true,
+		ListenAddresses: []string{"/ip4/127.0.0.1/tcp/0"},
+		ConnManager: config.ConnManagerConfig{
+			LowWatermark:  10,
+			HighWatermark: 40,
+			GracePeriod:   time.Second,
+		},
+	}, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create host: %v", err)
+	}
+	defer h.Close()
+
+	ps := h.Peerstore()
+	_ = ps.Put(h.ID(), PeerstoreLabelsKey, map[string]string{"region": "eu-west", "zone": "a"})
+
+	// near: same region and zone; mid: same region; far: same zone name in
+	// another region; unlabeled: no labels received
+	near, mid, far, unlabeled := peer.ID("near"), peer.ID("mid"), peer.ID("far"), peer.ID("unlabeled")
+	_ = ps.Put(near, PeerstoreLabelsKey, map[string]string{"region": "eu-west", "zone": "a"})
+	_ = ps.Put(mid, PeerstoreLabelsKey, map[string]string{"region": "eu-west", "zone": "b"})
+	_ = ps.Put(far, PeerstoreLabelsKey, map[string]string{"region": "us-east", "zone": "a"})
+	ps.RecordLatency(far, 5*time.Millisecond)
+	ps.RecordLatency(unlabeled, 50*time.Millisecond)
+	ps.RecordLatency(mid, 80*time.Millisecond)
+
+	peers := []peer.ID{unlabeled, far, mid, near}
+
+	t.Run("any keeps the order", func(t *testing.T) {
+		tiers := RankPeers(h, config.PeerSelectionConfig{Policy: "any"}, peers)
+		if len(tiers) != 1 || !reflect.DeepEqual(tiers[0].Peers, peers) {
+			t.Errorf("tiers = %v", tiers)
+		}
+	})
+
+	t.Run("latency orders measured peers first", func(t *testing.T) {
+		tiers := RankPeers(h, config.PeerSelectionConfig{Policy: "latency"}, peers)
+		want := []peer.ID{far, unlabeled, mid, near}
+		if len(tiers) != 1 || !reflect.DeepEqual(tiers[0].Peers, want) {
+			t.Errorf("tiers = %v, want one tier %v", tiers, want)
+		}
+	})
+
+	t.Run("topology groups by matching labels", func(t *testing.T) {
+		tiers := RankPeers(h, config.PeerSelectionConfig{
+			Policy:      "topology",
+			MatchLabels: []string{"region", "zone"},
+		}, peers)
+
+		want := []PeerTier{
+			{Score: 2, Peers: []peer.ID{near}},
+			{Score: 1, Peers: []peer.ID{mid}},
+			{Score: 0, Peers: []peer.ID{far, unlabeled}},
+		}
+		if !reflect.DeepEqual(tiers, want) {
+			t.Errorf("tiers = %v, want %v", tiers, want)
+		}
+
+		if got := NearestPeers(tiers); !reflect.DeepEqual(got, []peer.ID{near, mid}) {
+			t.Errorf("NearestPeers() = %v", got)
+		}
+	})
+
+	t.Run("topology falls back to any peer", func(t *testing.T) {
+		tiers := RankPeers(h, config.PeerSelectionConfig{
+			Policy:      "topology",
+			MatchLabels: []string{"rack"},
+		}, peers)
+
+		if len(tiers) != 1 || tiers[0].Score != 0 {
+			t.Fatalf("tiers = %v, want a single unmatched tier", tiers)
+		}
+		if got := NearestPeers(tiers); len(got) != len(peers) {
+			t.Errorf("NearestPeers() = %v, want every peer", got)
+		}
+	})
+}
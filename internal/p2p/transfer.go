@@ -2,8 +2,6 @@ package p2p
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
@@ -389,15 +387,7 @@ func (tm *TransferManager) fetchChunk(ctx context.Context, peerID peer.ID, datas
 
 // verifyChunk verifies chunk integrity.
 func (tm *TransferManager) verifyChunk(chunk *domain.Chunk) bool {
-	if chunk == nil || len(chunk.Data) == 0 {
-		return false
-	}
-
-	// Compute hash
-	hash := sha256.Sum256(chunk.Data)
-	computed := hex.EncodeToString(hash[:])
-
-	return computed == chunk.Hash
+	return chunk != nil && chunk.VerifyData()
 }
 
 // completeDownload marks a download as complete.
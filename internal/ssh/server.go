@@ -91,14 +91,20 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to create SSH server: %w", err)
 	}
 
+	// Listen before returning, so a port in use fails Start
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
 	s.server = srv
 
-	// Start server in goroutine
+	// Serve in goroutine
 	go func() {
 		if s.log != nil {
 			s.log.Info("starting SSH server", "addr", addr)
 		}
-		if err := srv.ListenAndServe(); err != nil && err != ssh.ErrServerClosed {
+		if err := srv.Serve(lis); err != nil && err != ssh.ErrServerClosed {
 			if s.log != nil {
 				s.log.Error("SSH server error", "error", err)
 			}
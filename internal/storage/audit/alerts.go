@@ -18,8 +18,14 @@ type AlertDetector struct {
 	counters       map[string]*alertCounter
 	mu             sync.RWMutex
 	callbacks      []AlertCallback
+
+	// now returns the current time; tests replace it
+	now func() time.Time
 }
 
+// maxAlertEvents is how many triggering entries an alert carries at most.
+const maxAlertEvents = 100
+
 // AlertConfig holds alert detection configuration.
 type AlertConfig struct {
 	// Enabled controls whether alert detection is active.
@@ -36,6 +42,9 @@ type AlertConfig struct {
 
 	// CleanupInterval is how often to clean up old counters.
 	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+
+	// Notify configures delivery of triggered alerts.
+	Notify AlertNotifyConfig `mapstructure:"notify"`
 }
 
 // ThresholdRule defines a simple threshold-based alert rule.
@@ -131,6 +140,11 @@ type Alert struct {
 	// Entry is the audit entry that triggered the alert.
 	Entry *Entry `json:"entry"`
 
+	// Events are the entries that led to the alert, oldest first: the
+	// entries counted in the window for threshold rules (at most the last
+	// 100), the matching entry for CEL rules.
+	Events []*Entry `json:"events"`
+
 	// Count is the count that triggered the threshold (for threshold rules).
 	Count int `json:"count,omitempty"`
 
@@ -151,16 +165,24 @@ type AlertCallback func(ctx context.Context, alert *Alert)
 type alertCounter struct {
 	key       string
 	counts    []time.Time
+	events    []counterEvent
 	threshold int
 	window    time.Duration
 }
 
+// counterEvent is a recent entry counted by an alertCounter.
+type counterEvent struct {
+	at    time.Time
+	entry *Entry
+}
+
 // DefaultAlertConfig returns the default alert configuration.
 func DefaultAlertConfig() AlertConfig {
 	return AlertConfig{
 		Enabled:         true,
 		WindowDuration:  5 * time.Minute,
 		CleanupInterval: 1 * time.Minute,
+		Notify:          DefaultAlertNotifyConfig(),
 		ThresholdRules: []ThresholdRule{
 			{
 				Name:             "bulk_select",
@@ -243,6 +265,7 @@ func NewAlertDetector(cfg AlertConfig) (*AlertDetector, error) {
 		thresholdRules: cfg.ThresholdRules,
 		counters:       make(map[string]*alertCounter),
 		callbacks:      make([]AlertCallback, 0),
+		now:            time.Now,
 	}
 
 	// Compile CEL rules
@@ -268,6 +291,19 @@ func NewAlertDetector(cfg AlertConfig) (*AlertDetector, error) {
 
 // compileCELRule compiles a CEL rule configuration.
 func compileCELRule(cfg CELRuleConfig) (*CELRule, error) {
+	program, err := compileCELExpression(cfg.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CELRule{
+		Config:  cfg,
+		Program: program,
+	}, nil
+}
+
+// compileCELExpression compiles an expression over the `entry` variable.
+func compileCELExpression(expression string) (cel.Program, error) {
 	env, err := cel.NewEnv(
 		cel.Declarations(
 			decls.NewVar("entry", decls.NewMapType(decls.String, decls.Dyn)),
@@ -277,7 +313,7 @@ func compileCELRule(cfg CELRuleConfig) (*CELRule, error) {
 		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
 	}
 
-	ast, issues := env.Compile(cfg.Expression)
+	ast, issues := env.Compile(expression)
 	if issues != nil && issues.Err() != nil {
 		return nil, fmt.Errorf("failed to compile expression: %w", issues.Err())
 	}
@@ -287,10 +323,64 @@ func compileCELRule(cfg CELRuleConfig) (*CELRule, error) {
 		return nil, fmt.Errorf("failed to create program: %w", err)
 	}
 
-	return &CELRule{
-		Config:  cfg,
-		Program: program,
-	}, nil
+	return program, nil
+}
+
+// celEntry converts an entry to the `entry` variable of CEL expressions.
+func celEntry(entry *Entry) map[string]any {
+	metadata := entry.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	return map[string]any{
+		"node_id":          entry.NodeID,
+		"job_id":           entry.JobID,
+		"operation_id":     entry.OperationID,
+		"role_used":        entry.RoleUsed,
+		"action":           string(entry.Action),
+		"table_name":       entry.TableName,
+		"rows_affected":    entry.RowsAffected,
+		"duration_ms":      entry.DurationMS,
+		"source_component": entry.SourceComponent,
+		"actor":            entry.Actor,
+		"suspicious":       entry.Flags.Suspicious,
+		"break_glass":      entry.Flags.BreakGlass,
+		"metadata":         metadata,
+	}
+}
+
+// CELFilter selects audit entries with a CEL expression. Expressions use
+// the same `entry` variable as CEL alert rules.
+type CELFilter struct {
+	expression string
+	program    cel.Program
+}
+
+// NewCELFilter compiles expression into a filter.
+func NewCELFilter(expression string) (*CELFilter, error) {
+	program, err := compileCELExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &CELFilter{expression: expression, program: program}, nil
+}
+
+// Match reports whether entry satisfies the filter. It is an error for the
+// expression to evaluate to anything but a bool.
+func (f *CELFilter) Match(entry *Entry) (bool, error) {
+	result, _, err := f.program.Eval(map[string]any{
+		"entry": celEntry(entry),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate %q: %w", f.expression, err)
+	}
+
+	matched, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q is not a condition: it returned %s", f.expression, result.Type().TypeName())
+	}
+	return matched, nil
 }
 
 // OnAlert registers a callback to be called when an alert is triggered.
@@ -352,7 +442,7 @@ func (d *AlertDetector) checkThresholdRule(ctx context.Context, entry *Entry, ru
 	counterKey := fmt.Sprintf("%s:%s:%s", rule.Name, rule.GroupBy, groupValue)
 
 	// Update counter
-	count := d.incrementCounter(counterKey, rule.Threshold, rule.Window)
+	count, events := d.incrementCounter(counterKey, rule.Threshold, rule.Window, entry)
 
 	// Check threshold
 	if count >= rule.Threshold {
@@ -361,8 +451,9 @@ func (d *AlertDetector) checkThresholdRule(ctx context.Context, entry *Entry, ru
 			RuleName:         rule.Name,
 			Description:      rule.Description,
 			Severity:         rule.Severity,
-			Timestamp:        time.Now().UTC(),
+			Timestamp:        d.now().UTC(),
 			Entry:            entry,
+			Events:           events,
 			Count:            count,
 			Threshold:        rule.Threshold,
 			TriggerRateLimit: rule.TriggerRateLimit,
@@ -379,25 +470,9 @@ func (d *AlertDetector) checkThresholdRule(ctx context.Context, entry *Entry, ru
 
 // checkCELRule checks an entry against a CEL rule.
 func (d *AlertDetector) checkCELRule(ctx context.Context, entry *Entry, rule CELRule) *Alert {
-	// Convert entry to map for CEL evaluation
-	entryMap := map[string]any{
-		"node_id":          entry.NodeID,
-		"job_id":           entry.JobID,
-		"operation_id":     entry.OperationID,
-		"role_used":        entry.RoleUsed,
-		"action":           string(entry.Action),
-		"table_name":       entry.TableName,
-		"rows_affected":    entry.RowsAffected,
-		"duration_ms":      entry.DurationMS,
-		"source_component": entry.SourceComponent,
-		"actor":            entry.Actor,
-		"suspicious":       entry.Flags.Suspicious,
-		"break_glass":      entry.Flags.BreakGlass,
-	}
-
 	// Evaluate expression
 	result, _, err := rule.Program.Eval(map[string]any{
-		"entry": entryMap,
+		"entry": celEntry(entry),
 	})
 	if err != nil {
 		// Log error but don't fail
@@ -411,8 +486,9 @@ func (d *AlertDetector) checkCELRule(ctx context.Context, entry *Entry, rule CEL
 			RuleName:         rule.Config.Name,
 			Description:      rule.Config.Description,
 			Severity:         rule.Config.Severity,
-			Timestamp:        time.Now().UTC(),
+			Timestamp:        d.now().UTC(),
 			Entry:            entry,
+			Events:           []*Entry{entry},
 			TriggerRateLimit: rule.Config.TriggerRateLimit,
 			Metadata: map[string]any{
 				"expression": rule.Config.Expression,
@@ -443,12 +519,13 @@ func (d *AlertDetector) getGroupValue(entry *Entry, groupBy string) string {
 	}
 }
 
-// incrementCounter increments a counter and returns the current count.
-func (d *AlertDetector) incrementCounter(key string, threshold int, window time.Duration) int {
+// incrementCounter counts entry and returns the count in the window along
+// with the most recent counted entries.
+func (d *AlertDetector) incrementCounter(key string, threshold int, window time.Duration, entry *Entry) (int, []*Entry) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	now := time.Now()
+	now := d.now()
 	counter, ok := d.counters[key]
 	if !ok {
 		counter = &alertCounter{
@@ -473,7 +550,24 @@ func (d *AlertDetector) incrementCounter(key string, threshold int, window time.
 	validCounts = append(validCounts, now)
 	counter.counts = validCounts
 
-	return len(counter.counts)
+	// Keep the latest entries of the window for the alert payload
+	events := make([]counterEvent, 0, min(len(counter.events)+1, maxAlertEvents))
+	for _, e := range counter.events {
+		if e.at.After(cutoff) {
+			events = append(events, e)
+		}
+	}
+	events = append(events, counterEvent{at: now, entry: entry})
+	if len(events) > maxAlertEvents {
+		events = events[len(events)-maxAlertEvents:]
+	}
+	counter.events = events
+
+	entries := make([]*Entry, len(events))
+	for i, e := range events {
+		entries[i] = e.entry
+	}
+	return len(counter.counts), entries
 }
 
 // triggerCallbacks calls all registered callbacks.
@@ -503,7 +597,7 @@ func (d *AlertDetector) cleanup() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	now := time.Now()
+	now := d.now()
 	for key, counter := range d.counters {
 		cutoff := now.Add(-counter.window)
 		hasValidEntries := false
@@ -381,3 +381,52 @@ func TestAlertDetector_Disabled(t *testing.T) {
 		t.Error("Disabled detector should not trigger alerts")
 	}
 }
+
+func TestCELFilter(t *testing.T) {
+	entry := &Entry{
+		Action:    ActionDelete,
+		TableName: "datasets",
+		Actor:     "alice",
+		Flags:     EntryFlags{BreakGlass: true},
+		Metadata:  map[string]any{"resource_id": "ds-1"},
+	}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`entry.action == "DELETE"`, true},
+		{`entry.break_glass && entry.table_name == "datasets"`, true},
+		{`entry.metadata.resource_id == "ds-1"`, true},
+		{`entry.actor.startsWith("bob")`, false},
+		{`"error" in entry.metadata`, false},
+	}
+
+	for _, tt := range tests {
+		f, err := NewCELFilter(tt.expression)
+		if err != nil {
+			t.Fatalf("NewCELFilter(%q) error = %v", tt.expression, err)
+		}
+		got, err := f.Match(entry)
+		if err != nil {
+			t.Fatalf("Match(%q) error = %v", tt.expression, err)
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.expression, got, tt.want)
+		}
+	}
+}
+
+func TestCELFilter_Errors(t *testing.T) {
+	if _, err := NewCELFilter(`entry.action ==`); err == nil {
+		t.Error("NewCELFilter() should reject invalid syntax")
+	}
+
+	f, err := NewCELFilter(`entry.rows_affected + 1`)
+	if err != nil {
+		t.Fatalf("NewCELFilter() error = %v", err)
+	}
+	if _, err := f.Match(&Entry{}); err == nil {
+		t.Error("Match() should fail for a non-bool expression")
+	}
+}
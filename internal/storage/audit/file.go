@@ -13,7 +13,7 @@ import (
 	"time"
 )
 
-// FileExporter exports audit entries to JSON-lines files.
+// FileExporter exports audit entries to JSON-lines, CEF or LEEF files.
 type FileExporter struct {
 	config   FileExportConfig
 	file     *os.File
@@ -50,6 +50,11 @@ type FileExportConfig struct {
 
 	// BufferSize is the write buffer size.
 	BufferSize int `mapstructure:"buffer_size"`
+
+	// Format is the line format: "json" (default), or "cef" or "leef" for
+	// SIEMs collecting the files. Only JSON files can be read back with
+	// FileReader.
+	Format ExportFormat `mapstructure:"format"`
 }
 
 // DefaultFileExportConfig returns the default file export configuration.
@@ -63,6 +68,7 @@ func DefaultFileExportConfig() FileExportConfig {
 		Compress:         true,
 		RotationInterval: 24 * time.Hour,
 		BufferSize:       64 * 1024, // 64KB
+		Format:           FormatJSON,
 	}
 }
 
@@ -71,6 +77,9 @@ func NewFileExporter(cfg FileExportConfig) (*FileExporter, error) {
 	if !cfg.Enabled {
 		return nil, nil
 	}
+	if cfg.Format != FormatNative && cfg.Format != FormatJSON && !cfg.Format.IsSIEM() {
+		return nil, fmt.Errorf("unsupported audit file format: %s", cfg.Format)
+	}
 
 	// Create directory if it doesn't exist
 	if err := os.MkdirAll(cfg.Directory, 0750); err != nil {
@@ -92,8 +101,11 @@ func NewFileExporter(cfg FileExportConfig) (*FileExporter, error) {
 func (e *FileExporter) openNewFile() error {
 	timestamp := time.Now().UTC().Format("2006-01-02T15-04-05")
 	extension := ".jsonl"
+	if e.config.Format.IsSIEM() {
+		extension = "." + string(e.config.Format)
+	}
 	if e.config.Compress {
-		extension = ".jsonl.gz"
+		extension += ".gz"
 	}
 
 	filename := fmt.Sprintf("%s-%s%s", e.config.FilePrefix, timestamp, extension)
@@ -129,9 +141,9 @@ func (e *FileExporter) Export(ctx context.Context, entry *Entry) error {
 		return nil
 	}
 
-	data, err := json.Marshal(entry)
+	data, err := e.formatEntry(entry)
 	if err != nil {
-		return fmt.Errorf("failed to marshal audit entry: %w", err)
+		return err
 	}
 
 	e.mu.Lock()
@@ -165,6 +177,19 @@ func (e *FileExporter) Export(ctx context.Context, entry *Entry) error {
 	return nil
 }
 
+// formatEntry formats an entry as a line of the configured format, without
+// the newline.
+func (e *FileExporter) formatEntry(entry *Entry) ([]byte, error) {
+	if e.config.Format.IsSIEM() {
+		return []byte(FormatSIEM(e.config.Format, entry)), nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	return data, nil
+}
+
 // ExportBatch writes multiple entries to the file.
 func (e *FileExporter) ExportBatch(ctx context.Context, entries []*Entry) error {
 	if e == nil || e.closed {
@@ -14,6 +14,7 @@ type Logger struct {
 	redactor     *Redactor
 	streamer     *Streamer
 	detector     *AlertDetector
+	dispatcher   *AlertDispatcher
 	rateLimiter  *RateLimiter
 	syslog       *SyslogExporter
 	fileExporter *FileExporter
@@ -124,15 +125,16 @@ func NewLogger(cfg Config, nodeID string, repo Repository, s3Client S3Client) (*
 		}
 		logger.detector = detector
 
+		// Deliver alerts if a destination is configured
+		if cfg.Alerts.Notify.Webhook != "" || cfg.Alerts.Notify.Email != "" {
+			logger.dispatcher = NewAlertDispatcher(cfg.Alerts.Notify, nodeID)
+			detector.OnAlert(logger.dispatcher.Dispatch)
+		}
+
 		// Wire up rate limiting from alerts
 		if cfg.RateLimit.Enabled {
 			logger.rateLimiter = NewRateLimiter(cfg.RateLimit)
-			detector.OnAlert(func(ctx context.Context, alert *Alert) {
-				if alert.TriggerRateLimit {
-					key := "actor:" + alert.Entry.Actor
-					logger.rateLimiter.TriggerBlock(key, cfg.RateLimit.BlockDuration)
-				}
-			})
+			detector.OnAlert(logger.rateLimiter.BlockOnAlert)
 		}
 	}
 
@@ -477,6 +479,10 @@ func (l *Logger) Close(ctx context.Context) error {
 		l.s3Exporter.Close(ctx)
 	}
 
+	if l.dispatcher != nil {
+		l.dispatcher.Close()
+	}
+
 	return nil
 }
 
@@ -504,6 +510,10 @@ func (l *Logger) Stats() LoggerStats {
 		stats.Alerts = l.detector.GetStats()
 	}
 
+	if l.dispatcher != nil {
+		stats.AlertDelivery = l.dispatcher.Stats()
+	}
+
 	if l.rateLimiter != nil {
 		stats.RateLimit = l.rateLimiter.GetStats()
 	}
@@ -521,12 +531,13 @@ func (l *Logger) Stats() LoggerStats {
 
 // LoggerStats contains audit logger statistics.
 type LoggerStats struct {
-	Enabled     bool            `json:"enabled"`
-	EntryCount  int64           `json:"entry_count"`
-	Subscribers int             `json:"subscribers"`
-	Closed      bool            `json:"closed"`
-	Alerts      AlertStats      `json:"alerts"`
-	RateLimit   RateLimitStats  `json:"rate_limit"`
-	FileExport  FileExportStats `json:"file_export"`
-	S3Export    S3ExportStats   `json:"s3_export"`
+	Enabled       bool            `json:"enabled"`
+	EntryCount    int64           `json:"entry_count"`
+	Subscribers   int             `json:"subscribers"`
+	Closed        bool            `json:"closed"`
+	Alerts        AlertStats      `json:"alerts"`
+	AlertDelivery DispatcherStats `json:"alert_delivery"`
+	RateLimit     RateLimitStats  `json:"rate_limit"`
+	FileExport    FileExportStats `json:"file_export"`
+	S3Export      S3ExportStats   `json:"s3_export"`
 }
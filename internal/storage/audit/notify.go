@@ -0,0 +1,370 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AlertNotifyConfig configures delivery of triggered alerts.
+type AlertNotifyConfig struct {
+	// Webhook is a URL that receives each alert as a JSON POST.
+	Webhook string `mapstructure:"webhook"`
+
+	// Email is the address alerts are mailed to.
+	Email string `mapstructure:"email"`
+
+	// SMTP is the mail server used to send to Email.
+	SMTP SMTPConfig `mapstructure:"smtp"`
+
+	// Cooldown is the minimum time between two notifications for the same
+	// rule and group. Alerts in between are counted and reported with the
+	// next notification.
+	Cooldown time.Duration `mapstructure:"cooldown"`
+
+	// Timeout bounds each delivery attempt.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// QueueSize is how many notifications may wait for delivery. Alerts
+	// beyond it are dropped so a slow endpoint never blocks audit logging.
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// SMTPConfig holds the mail server settings for email notifications.
+type SMTPConfig struct {
+	// Address is the server address (e.g., "smtp.example.com:587").
+	Address string `mapstructure:"address"`
+
+	// Username and Password authenticate with PLAIN auth (optional).
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+
+	// From is the sender address.
+	From string `mapstructure:"from"`
+}
+
+// DefaultAlertNotifyConfig returns the default notification configuration.
+// No destination is set, so nothing is delivered until one is configured.
+func DefaultAlertNotifyConfig() AlertNotifyConfig {
+	return AlertNotifyConfig{
+		Cooldown:  15 * time.Minute,
+		Timeout:   10 * time.Second,
+		QueueSize: 100,
+	}
+}
+
+// AlertNotification is the payload delivered for an alert.
+type AlertNotification struct {
+	// Alert is the triggered alert, including its triggering events.
+	Alert *Alert `json:"alert"`
+
+	// NodeID is the node that raised the alert.
+	NodeID string `json:"node_id"`
+
+	// Suppressed is how many alerts of the same rule and group were held
+	// back by the cooldown since the previous notification.
+	Suppressed int `json:"suppressed"`
+}
+
+// AlertNotifier delivers alert notifications.
+type AlertNotifier interface {
+	NotifyAlert(ctx context.Context, n *AlertNotification) error
+}
+
+// AlertDispatcher delivers triggered alerts to notifiers in the background.
+// Register Dispatch as an AlertDetector callback.
+//
+// Alerts of the same rule and group are deduplicated: after a notification
+// the next one is only sent once the cooldown has passed.
+type AlertDispatcher struct {
+	config    AlertNotifyConfig
+	nodeID    string
+	notifiers []AlertNotifier
+
+	mu         sync.Mutex
+	lastSent   map[string]time.Time
+	suppressed map[string]int
+	closed     bool
+	stats      DispatcherStats
+
+	queue chan *AlertNotification
+	done  chan struct{}
+}
+
+// DispatcherStats contains alert delivery statistics.
+type DispatcherStats struct {
+	Sent       int64 `json:"sent"`
+	Suppressed int64 `json:"suppressed"`
+	Dropped    int64 `json:"dropped"`
+	Failed     int64 `json:"failed"`
+}
+
+// NewAlertDispatcher creates a dispatcher delivering to the webhook and
+// email of cfg, and to any extra notifiers.
+func NewAlertDispatcher(cfg AlertNotifyConfig, nodeID string, extra ...AlertNotifier) *AlertDispatcher {
+	defaults := DefaultAlertNotifyConfig()
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaults.QueueSize
+	}
+
+	var notifiers []AlertNotifier
+	if cfg.Webhook != "" {
+		notifiers = append(notifiers, NewWebhookAlertNotifier(cfg.Webhook, cfg.Timeout))
+	}
+	if cfg.Email != "" {
+		notifiers = append(notifiers, NewEmailAlertNotifier(cfg.SMTP, cfg.Email))
+	}
+	notifiers = append(notifiers, extra...)
+
+	d := &AlertDispatcher{
+		config:     cfg,
+		nodeID:     nodeID,
+		notifiers:  notifiers,
+		lastSent:   make(map[string]time.Time),
+		suppressed: make(map[string]int),
+		queue:      make(chan *AlertNotification, cfg.QueueSize),
+		done:       make(chan struct{}),
+	}
+	go d.run()
+
+	return d
+}
+
+// Dispatch queues alert for delivery unless its rule and group are cooling
+// down. It never blocks.
+func (d *AlertDispatcher) Dispatch(_ context.Context, alert *Alert) {
+	key := alert.RuleName
+	if group, ok := alert.Metadata["group_value"]; ok {
+		key += ":" + fmt.Sprint(group)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed || len(d.notifiers) == 0 {
+		return
+	}
+	if last, ok := d.lastSent[key]; ok && alert.Timestamp.Sub(last) < d.config.Cooldown {
+		d.suppressed[key]++
+		d.stats.Suppressed++
+		return
+	}
+
+	n := &AlertNotification{
+		Alert:      snapshotAlert(alert),
+		NodeID:     d.nodeID,
+		Suppressed: d.suppressed[key],
+	}
+	select {
+	case d.queue <- n:
+		d.lastSent[key] = alert.Timestamp
+		delete(d.suppressed, key)
+	default:
+		d.stats.Dropped++
+	}
+}
+
+// snapshotAlert copies alert for delivery in the background. The entries'
+// metadata is copied too, as the logger annotates entries with their alerts
+// after the callbacks ran.
+func snapshotAlert(alert *Alert) *Alert {
+	snapshot := *alert
+	snapshot.Entry = snapshotEntry(alert.Entry)
+	snapshot.Events = make([]*Entry, len(alert.Events))
+	for i, e := range alert.Events {
+		snapshot.Events[i] = snapshotEntry(e)
+	}
+	return &snapshot
+}
+
+func snapshotEntry(e *Entry) *Entry {
+	if e == nil {
+		return nil
+	}
+	snapshot := *e
+	snapshot.Metadata = maps.Clone(e.Metadata)
+	// Alerts refer back to their entries
+	delete(snapshot.Metadata, "alerts")
+	return &snapshot
+}
+
+// run delivers queued notifications until the dispatcher is closed.
+func (d *AlertDispatcher) run() {
+	defer close(d.done)
+
+	for n := range d.queue {
+		for _, notifier := range d.notifiers {
+			ctx, cancel := context.WithTimeout(context.Background(), d.config.Timeout)
+			err := notifier.NotifyAlert(ctx, n)
+			cancel()
+
+			d.mu.Lock()
+			if err != nil {
+				d.stats.Failed++
+			} else {
+				d.stats.Sent++
+			}
+			d.mu.Unlock()
+		}
+	}
+}
+
+// Close delivers the queued notifications and stops the dispatcher.
+func (d *AlertDispatcher) Close() {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	close(d.queue)
+	d.mu.Unlock()
+
+	<-d.done
+}
+
+// Stats returns delivery statistics.
+func (d *AlertDispatcher) Stats() DispatcherStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.stats
+}
+
+// WebhookAlertNotifier posts alert notifications as JSON to a URL.
+type WebhookAlertNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookAlertNotifier creates a webhook notifier.
+func NewWebhookAlertNotifier(url string, timeout time.Duration) *WebhookAlertNotifier {
+	return &WebhookAlertNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// NotifyAlert posts n to the webhook. Any non-2xx response is an error.
+func (w *WebhookAlertNotifier) NotifyAlert(ctx context.Context, n *AlertNotification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bibd-audit-alerts")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// EmailAlertNotifier mails alert notifications over SMTP.
+type EmailAlertNotifier struct {
+	smtp SMTPConfig
+	to   string
+
+	// send is smtp.SendMail; tests replace it
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailAlertNotifier creates an email notifier sending to the address to.
+func NewEmailAlertNotifier(cfg SMTPConfig, to string) *EmailAlertNotifier {
+	return &EmailAlertNotifier{
+		smtp: cfg,
+		to:   to,
+		send: smtp.SendMail,
+	}
+}
+
+// NotifyAlert mails n. The body summarizes the alert and attaches the full
+// notification as JSON.
+func (e *EmailAlertNotifier) NotifyAlert(ctx context.Context, n *AlertNotification) error {
+	if e.smtp.Address == "" {
+		return fmt.Errorf("no SMTP server configured for alert email")
+	}
+
+	var auth smtp.Auth
+	if e.smtp.Username != "" {
+		host, _, err := net.SplitHostPort(e.smtp.Address)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %w", e.smtp.Address, err)
+		}
+		auth = smtp.PlainAuth("", e.smtp.Username, e.smtp.Password, host)
+	}
+
+	msg, err := formatAlertEmail(e.smtp.From, e.to, n)
+	if err != nil {
+		return err
+	}
+
+	// smtp.SendMail has no context; give up waiting once ctx is done
+	errc := make(chan error, 1)
+	go func() {
+		errc <- e.send(e.smtp.Address, auth, e.smtp.From, []string{e.to}, msg)
+	}()
+	select {
+	case err := <-errc:
+		if err != nil {
+			return fmt.Errorf("failed to send alert email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// formatAlertEmail builds the message for n.
+func formatAlertEmail(from, to string, n *AlertNotification) ([]byte, error) {
+	payload, err := json.MarshalIndent(n, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	a := n.Alert
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: [bib] %s audit alert: %s\r\n", strings.ToUpper(string(a.Severity)), a.RuleName)
+	fmt.Fprintf(&b, "Date: %s\r\n", a.Timestamp.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+
+	fmt.Fprintf(&b, "%s\r\n\r\n", a.Description)
+	fmt.Fprintf(&b, "Rule:      %s\r\n", a.RuleName)
+	fmt.Fprintf(&b, "Severity:  %s\r\n", a.Severity)
+	fmt.Fprintf(&b, "Node:      %s\r\n", n.NodeID)
+	fmt.Fprintf(&b, "Time:      %s\r\n", a.Timestamp.Format(time.RFC3339))
+	if a.Threshold > 0 {
+		fmt.Fprintf(&b, "Count:     %d (threshold %d)\r\n", a.Count, a.Threshold)
+	}
+	if n.Suppressed > 0 {
+		fmt.Fprintf(&b, "Suppressed: %d similar alerts since the last notification\r\n", n.Suppressed)
+	}
+	b.WriteString("\r\nNotification:\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(string(payload), "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,285 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable clock for the alert detector
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// recordingNotifier collects delivered notifications
+type recordingNotifier struct {
+	mu   sync.Mutex
+	sent []*AlertNotification
+	err  error
+}
+
+func (r *recordingNotifier) NotifyAlert(_ context.Context, n *AlertNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, n)
+	return r.err
+}
+
+func newTestDetector(t *testing.T, clock *fakeClock, rule ThresholdRule) *AlertDetector {
+	t.Helper()
+	detector, err := NewAlertDetector(AlertConfig{
+		Enabled:        true,
+		ThresholdRules: []ThresholdRule{rule},
+	})
+	if err != nil {
+		t.Fatalf("NewAlertDetector() error = %v", err)
+	}
+	detector.now = clock.now
+	return detector
+}
+
+func deleteBy(actor, id string) *Entry {
+	return &Entry{
+		OperationID: id,
+		Action:      ActionDelete,
+		TableName:   "datasets",
+		Actor:       actor,
+		Metadata:    map[string]any{},
+	}
+}
+
+func TestAlertDetector_ThresholdWindow(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	detector := newTestDetector(t, clock, ThresholdRule{
+		Name:      "bulk_delete",
+		Enabled:   true,
+		Action:    ActionDelete,
+		Threshold: 3,
+		Window:    time.Minute,
+		GroupBy:   "actor",
+		Severity:  AlertSeverityHigh,
+	})
+	ctx := context.Background()
+
+	// Events 70s apart: never three within a minute
+	for i, id := range []string{"a1", "a2", "a3", "a4"} {
+		if i > 0 {
+			clock.advance(70 * time.Second)
+		}
+		if alerts := detector.Check(ctx, deleteBy("alice", id)); len(alerts) > 0 {
+			t.Fatalf("event %s triggered an alert, want none", id)
+		}
+	}
+
+	// A burst: the third event within the window triggers
+	clock.advance(time.Second)
+	if alerts := detector.Check(ctx, deleteBy("alice", "a5")); len(alerts) > 0 {
+		t.Fatal("two events in the window triggered an alert")
+	}
+	clock.advance(time.Second)
+	alerts := detector.Check(ctx, deleteBy("alice", "a6"))
+	if len(alerts) != 1 {
+		t.Fatalf("got %d alerts, want 1", len(alerts))
+	}
+
+	alert := alerts[0]
+	if alert.Count != 3 {
+		t.Errorf("Count = %d, want 3", alert.Count)
+	}
+	if !alert.Timestamp.Equal(clock.t) {
+		t.Errorf("Timestamp = %v, want %v", alert.Timestamp, clock.t)
+	}
+	var ids []string
+	for _, e := range alert.Events {
+		ids = append(ids, e.OperationID)
+	}
+	if got := strings.Join(ids, ","); got != "a4,a5,a6" {
+		t.Errorf("Events = %s, want a4,a5,a6", got)
+	}
+
+	// Other actors are counted separately
+	if alerts := detector.Check(ctx, deleteBy("bob", "b1")); len(alerts) > 0 {
+		t.Error("bob's first event triggered an alert")
+	}
+}
+
+func TestAlertDispatcher_Cooldown(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	detector := newTestDetector(t, clock, ThresholdRule{
+		Name:      "bulk_delete",
+		Enabled:   true,
+		Action:    ActionDelete,
+		Threshold: 2,
+		Window:    time.Minute,
+		GroupBy:   "actor",
+	})
+
+	notifier := &recordingNotifier{}
+	dispatcher := NewAlertDispatcher(AlertNotifyConfig{Cooldown: 10 * time.Minute}, "node-1", notifier)
+	detector.OnAlert(dispatcher.Dispatch)
+	ctx := context.Background()
+
+	// A noisy stream: one delete every 10s for 15 minutes
+	for i := 0; i < 90; i++ {
+		detector.Check(ctx, deleteBy("alice", "op"))
+		clock.advance(10 * time.Second)
+	}
+	// Another actor alerts independently
+	detector.Check(ctx, deleteBy("bob", "op"))
+	detector.Check(ctx, deleteBy("bob", "op"))
+	dispatcher.Close()
+
+	if len(notifier.sent) != 3 {
+		t.Fatalf("sent %d notifications, want 3", len(notifier.sent))
+	}
+
+	first, second := notifier.sent[0], notifier.sent[1]
+	if first.Suppressed != 0 || first.NodeID != "node-1" {
+		t.Errorf("first notification = %+v", first)
+	}
+	if got := second.Alert.Timestamp.Sub(first.Alert.Timestamp); got != 10*time.Minute {
+		t.Errorf("second notification after %v, want the 10m cooldown", got)
+	}
+	// Alerts every 10s during the cooldown
+	if second.Suppressed != 59 {
+		t.Errorf("Suppressed = %d, want 59", second.Suppressed)
+	}
+	if got := notifier.sent[2].Alert.Entry.Actor; got != "bob" {
+		t.Errorf("third notification for %q, want bob", got)
+	}
+
+	stats := dispatcher.Stats()
+	if stats.Sent != 3 || stats.Suppressed != 87 {
+		t.Errorf("Stats() = %+v, want 3 sent and 87 suppressed", stats)
+	}
+}
+
+func TestAlertDispatcher_SnapshotsEntries(t *testing.T) {
+	notifier := &recordingNotifier{}
+	dispatcher := NewAlertDispatcher(AlertNotifyConfig{}, "node-1", notifier)
+
+	entry := deleteBy("alice", "op")
+	alert := &Alert{RuleName: "rule", Timestamp: time.Now(), Entry: entry, Events: []*Entry{entry}}
+	dispatcher.Dispatch(context.Background(), alert)
+	// The logger annotates the entry after the callbacks ran
+	entry.Metadata["alerts"] = []*Alert{alert}
+	dispatcher.Close()
+
+	if len(notifier.sent) != 1 {
+		t.Fatalf("sent %d notifications, want 1", len(notifier.sent))
+	}
+	if _, err := json.Marshal(notifier.sent[0]); err != nil {
+		t.Errorf("notification does not marshal: %v", err)
+	}
+}
+
+func TestAlertDispatcher_CountsFailures(t *testing.T) {
+	notifier := &recordingNotifier{err: errors.New("unreachable")}
+	dispatcher := NewAlertDispatcher(AlertNotifyConfig{}, "node-1", notifier)
+	dispatcher.Dispatch(context.Background(), &Alert{RuleName: "rule", Timestamp: time.Now()})
+	dispatcher.Close()
+
+	if stats := dispatcher.Stats(); stats.Failed != 1 || stats.Sent != 0 {
+		t.Errorf("Stats() = %+v, want 1 failed", stats)
+	}
+}
+
+func TestWebhookAlertNotifier(t *testing.T) {
+	var got AlertNotification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode payload: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	n := &AlertNotification{
+		Alert: &Alert{
+			RuleName: "bulk_delete",
+			Severity: AlertSeverityHigh,
+			Events:   []*Entry{deleteBy("alice", "a1"), deleteBy("alice", "a2")},
+		},
+		NodeID:     "node-1",
+		Suppressed: 4,
+	}
+	if err := NewWebhookAlertNotifier(srv.URL, time.Second).NotifyAlert(context.Background(), n); err != nil {
+		t.Fatalf("NotifyAlert() error = %v", err)
+	}
+
+	if got.Alert == nil || got.Alert.RuleName != "bulk_delete" || len(got.Alert.Events) != 2 || got.Suppressed != 4 {
+		t.Errorf("payload = %+v", got)
+	}
+}
+
+func TestWebhookAlertNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := NewWebhookAlertNotifier(srv.URL, time.Second).NotifyAlert(context.Background(), &AlertNotification{Alert: &Alert{}})
+	if err == nil {
+		t.Error("NotifyAlert() should fail on a 502")
+	}
+}
+
+func TestEmailAlertNotifier(t *testing.T) {
+	var (
+		gotAddr string
+		gotTo   []string
+		gotMsg  string
+	)
+	notifier := NewEmailAlertNotifier(SMTPConfig{Address: "smtp.example.com:587", From: "bibd@example.com"}, "ops@example.com")
+	notifier.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, string(msg)
+		return nil
+	}
+
+	n := &AlertNotification{
+		Alert: &Alert{
+			RuleName:  "bulk_delete",
+			Severity:  AlertSeverityHigh,
+			Count:     50,
+			Threshold: 50,
+			Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			Events:    []*Entry{deleteBy("alice", "a1")},
+		},
+		NodeID: "node-1",
+	}
+	if err := notifier.NotifyAlert(context.Background(), n); err != nil {
+		t.Fatalf("NotifyAlert() error = %v", err)
+	}
+
+	if gotAddr != "smtp.example.com:587" || len(gotTo) != 1 || gotTo[0] != "ops@example.com" {
+		t.Errorf("sent to %s %v", gotAddr, gotTo)
+	}
+	for _, want := range []string{
+		"Subject: [bib] HIGH audit alert: bulk_delete",
+		"Count:     50 (threshold 50)",
+		`"operation_id": "a1"`,
+	} {
+		if !strings.Contains(gotMsg, want) {
+			t.Errorf("message does not contain %q:\n%s", want, gotMsg)
+		}
+	}
+}
+
+func TestEmailAlertNotifier_NoServer(t *testing.T) {
+	notifier := NewEmailAlertNotifier(SMTPConfig{}, "ops@example.com")
+	if err := notifier.NotifyAlert(context.Background(), &AlertNotification{Alert: &Alert{}}); err == nil {
+		t.Error("NotifyAlert() should fail without an SMTP server")
+	}
+}
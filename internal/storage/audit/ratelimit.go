@@ -2,6 +2,7 @@ package audit
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 )
@@ -46,6 +47,10 @@ type limiterState struct {
 	limit      int
 	window     time.Duration
 	blocked    bool
+
+	// rule and reason describe the alert that triggered the block, if any
+	rule   string
+	reason string
 }
 
 // DefaultRateLimitConfig returns the default rate limit configuration.
@@ -84,11 +89,8 @@ func (r *RateLimiter) Check(ctx context.Context, entry *Entry) (allowed bool, re
 		return true, ""
 	}
 
-	// Check bypass roles
-	for _, bypassRole := range r.config.BypassRoles {
-		if entry.RoleUsed == bypassRole {
-			return true, ""
-		}
+	if r.IsBypassRole(entry.RoleUsed) {
+		return true, ""
 	}
 
 	// Check various rate limit keys
@@ -202,6 +204,30 @@ func (r *RateLimiter) determineLimit(key string, entry *Entry) int {
 
 // TriggerBlock manually blocks a key (e.g., from alert callback).
 func (r *RateLimiter) TriggerBlock(key string, duration time.Duration) {
+	r.block(key, duration, "", "")
+}
+
+// BlockOnAlert blocks the actor of an alert, or its role for rules grouping
+// by role, for the configured block duration. Alerts that don't trigger
+// rate limiting and entries of bypass roles are ignored. Register it as an
+// AlertDetector callback.
+func (r *RateLimiter) BlockOnAlert(_ context.Context, alert *Alert) {
+	if r == nil || !r.config.Enabled || !alert.TriggerRateLimit || alert.Entry == nil {
+		return
+	}
+	if r.IsBypassRole(alert.Entry.RoleUsed) {
+		return
+	}
+
+	key := "actor:" + alert.Entry.Actor
+	if alert.Metadata["group_by"] == "role" {
+		key = "role:" + alert.Entry.RoleUsed
+	}
+	r.block(key, r.config.BlockDuration, alert.RuleName, alert.Description)
+}
+
+// block blocks key for duration, recording the rule that triggered it.
+func (r *RateLimiter) block(key string, duration time.Duration, rule, reason string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -222,39 +248,119 @@ func (r *RateLimiter) TriggerBlock(key string, duration time.Duration) {
 	state.blocked = true
 	state.blockedAt = &now
 	state.blockUntil = &blockUntil
+	state.rule = rule
+	state.reason = reason
+}
+
+// IsBypassRole reports whether role is exempt from rate limiting.
+func (r *RateLimiter) IsBypassRole(role string) bool {
+	if r == nil {
+		return false
+	}
+	for _, bypassRole := range r.config.BypassRoles {
+		if role == bypassRole {
+			return true
+		}
+	}
+	return false
+}
+
+// Block is an active block of a rate limit key.
+type Block struct {
+	// Key is the blocked key, e.g. "actor:<id>" or "role:<role>".
+	Key string `json:"key"`
+
+	// Rule is the alert rule that triggered the block (empty for manual
+	// blocks).
+	Rule string `json:"rule,omitempty"`
+
+	// Reason describes the alert.
+	Reason string `json:"reason,omitempty"`
+
+	BlockedAt time.Time `json:"blocked_at"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // IsBlocked checks if a key is currently blocked.
 func (r *RateLimiter) IsBlocked(key string) bool {
+	_, blocked := r.ActiveBlock(key)
+	return blocked
+}
+
+// ActiveBlock returns the block of key, if it is currently blocked.
+func (r *RateLimiter) ActiveBlock(key string) (Block, bool) {
+	if r == nil {
+		return Block{}, false
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	state, ok := r.limiters[key]
 	if !ok {
-		return false
+		return Block{}, false
 	}
+	return state.activeBlock(time.Now())
+}
 
-	if !state.blocked {
-		return false
+// Blocks returns the active blocks, soonest to expire first.
+func (r *RateLimiter) Blocks() []Block {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	blocks := make([]Block, 0)
+	for _, state := range r.limiters {
+		if block, ok := state.activeBlock(now); ok {
+			blocks = append(blocks, block)
+		}
 	}
+	sort.Slice(blocks, func(i, j int) bool {
+		if !blocks[i].ExpiresAt.Equal(blocks[j].ExpiresAt) {
+			return blocks[i].ExpiresAt.Before(blocks[j].ExpiresAt)
+		}
+		return blocks[i].Key < blocks[j].Key
+	})
 
-	if state.blockUntil != nil && time.Now().After(*state.blockUntil) {
-		return false
+	return blocks
+}
+
+// activeBlock returns the block of the state if it hasn't expired at now.
+func (s *limiterState) activeBlock(now time.Time) (Block, bool) {
+	if !s.blocked || s.blockUntil == nil || now.After(*s.blockUntil) {
+		return Block{}, false
 	}
 
-	return true
+	block := Block{
+		Key:       s.key,
+		Rule:      s.rule,
+		Reason:    s.reason,
+		ExpiresAt: *s.blockUntil,
+	}
+	if s.blockedAt != nil {
+		block.BlockedAt = *s.blockedAt
+	}
+	return block, true
 }
 
-// Unblock manually unblocks a key.
-func (r *RateLimiter) Unblock(key string) {
+// Unblock manually unblocks a key. It reports whether the key was blocked.
+func (r *RateLimiter) Unblock(key string) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if state, ok := r.limiters[key]; ok {
-		state.blocked = false
-		state.blockedAt = nil
-		state.blockUntil = nil
+	state, ok := r.limiters[key]
+	if !ok {
+		return false
 	}
+	_, blocked := state.activeBlock(time.Now())
+
+	state.blocked = false
+	state.blockedAt = nil
+	state.blockUntil = nil
+	state.rule = ""
+	state.reason = ""
+
+	return blocked
 }
 
 // GetStats returns rate limiter statistics.
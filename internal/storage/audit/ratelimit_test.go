@@ -162,6 +162,107 @@ func TestRateLimiter_Unblock(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_UnblockUnknownKey(t *testing.T) {
+	limiter := NewRateLimiter(DefaultRateLimitConfig())
+
+	if limiter.Unblock("actor:nobody") {
+		t.Error("Unblock() of an unknown key should report false")
+	}
+
+	limiter.TriggerBlock("actor:user-1", time.Hour)
+	if !limiter.Unblock("actor:user-1") {
+		t.Error("Unblock() of a blocked key should report true")
+	}
+	if limiter.Unblock("actor:user-1") {
+		t.Error("Unblock() of an unblocked key should report false")
+	}
+}
+
+func TestRateLimiter_BlockOnAlert(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{
+		Enabled:       true,
+		DefaultLimit:  100,
+		DefaultWindow: time.Minute,
+		BlockDuration: 5 * time.Minute,
+		BypassRoles:   []string{"admin"},
+	})
+	ctx := context.Background()
+
+	alert := func(rule, groupBy, actor, role string, trigger bool) *Alert {
+		return &Alert{
+			RuleName:         rule,
+			Description:      rule + " fired",
+			TriggerRateLimit: trigger,
+			Entry:            &Entry{Actor: actor, RoleUsed: role},
+			Metadata:         map[string]any{"group_by": groupBy},
+		}
+	}
+
+	limiter.BlockOnAlert(ctx, alert("bulk_delete", "actor", "user-1", "user", true))
+	limiter.BlockOnAlert(ctx, alert("ddl_operations", "role", "user-2", "readonly", true))
+	limiter.BlockOnAlert(ctx, alert("slow_query", "", "user-3", "user", false))
+	limiter.BlockOnAlert(ctx, alert("bulk_delete", "actor", "user-4", "admin", true))
+
+	blocks := limiter.Blocks()
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2: %+v", len(blocks), blocks)
+	}
+
+	byKey := make(map[string]Block)
+	for _, b := range blocks {
+		byKey[b.Key] = b
+	}
+	block, ok := byKey["actor:user-1"]
+	if !ok {
+		t.Fatal("actor:user-1 should be blocked")
+	}
+	if block.Rule != "bulk_delete" || block.Reason != "bulk_delete fired" {
+		t.Errorf("block = %+v, want the bulk_delete rule", block)
+	}
+	if got := block.ExpiresAt.Sub(block.BlockedAt); got != 5*time.Minute {
+		t.Errorf("block lasts %v, want the 5m block duration", got)
+	}
+	if _, ok := byKey["role:readonly"]; !ok {
+		t.Error("role:readonly should be blocked for a rule grouping by role")
+	}
+	if limiter.IsBlocked("actor:user-3") {
+		t.Error("an alert without TriggerRateLimit should not block")
+	}
+	if limiter.IsBlocked("actor:user-4") {
+		t.Error("a bypass role should not be blocked")
+	}
+}
+
+func TestRateLimiter_BlockOnAlertDisabled(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitConfig{BlockDuration: time.Minute})
+	limiter.BlockOnAlert(context.Background(), &Alert{
+		TriggerRateLimit: true,
+		Entry:            &Entry{Actor: "user-1"},
+	})
+
+	if limiter.IsBlocked("actor:user-1") {
+		t.Error("a disabled rate limiter should not block on alerts")
+	}
+}
+
+func TestRateLimiter_BlocksExpire(t *testing.T) {
+	limiter := NewRateLimiter(DefaultRateLimitConfig())
+	limiter.TriggerBlock("actor:user-1", time.Hour)
+	limiter.TriggerBlock("actor:user-2", -time.Second)
+	limiter.TriggerBlock("actor:user-3", time.Minute)
+
+	blocks := limiter.Blocks()
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(blocks))
+	}
+	if blocks[0].Key != "actor:user-3" || blocks[1].Key != "actor:user-1" {
+		t.Errorf("blocks = %s, %s; want soonest to expire first", blocks[0].Key, blocks[1].Key)
+	}
+	if _, ok := limiter.ActiveBlock("actor:user-2"); ok {
+		t.Error("an expired block should not be active")
+	}
+}
+
 func TestRateLimiter_ActionLimits(t *testing.T) {
 	cfg := RateLimitConfig{
 		Enabled:       true,
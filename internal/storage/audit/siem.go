@@ -0,0 +1,229 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bib/internal/version"
+)
+
+// ExportFormat is the format audit entries are exported in.
+type ExportFormat string
+
+const (
+	// FormatNative is the exporter's own format: JSON lines for files,
+	// RFC 5424 structured data for syslog.
+	FormatNative ExportFormat = ""
+
+	// FormatJSON writes entries as JSON (files only).
+	FormatJSON ExportFormat = "json"
+
+	// FormatRFC5424 writes RFC 5424 structured data (syslog only).
+	FormatRFC5424 ExportFormat = "rfc5424"
+
+	// FormatCEF writes ArcSight Common Event Format.
+	FormatCEF ExportFormat = "cef"
+
+	// FormatLEEF writes IBM QRadar Log Event Extended Format 1.0.
+	FormatLEEF ExportFormat = "leef"
+)
+
+// SIEM header fields identifying bibd as the event source.
+const (
+	siemVendor  = "bib"
+	siemProduct = "bibd"
+)
+
+// IsSIEM reports whether the format is CEF or LEEF.
+func (f ExportFormat) IsSIEM() bool {
+	return f == FormatCEF || f == FormatLEEF
+}
+
+// FormatSIEM formats an entry as CEF or LEEF. It returns "" for other
+// formats.
+func FormatSIEM(format ExportFormat, entry *Entry) string {
+	switch format {
+	case FormatCEF:
+		return FormatCEFEntry(entry)
+	case FormatLEEF:
+		return FormatLEEFEntry(entry)
+	default:
+		return ""
+	}
+}
+
+// FormatCEFEntry formats an entry as a CEF:0 event. The action is the
+// signature ID, standard extensions carry the time, actor, node, operation
+// and row count, and the hash chain fields are kept in the custom
+// extensions bibPrevHash and bibEntryHash so SIEMs can verify it.
+func FormatCEFEntry(entry *Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeader(siemVendor),
+		cefHeader(siemProduct),
+		cefHeader(version.Version),
+		cefHeader(string(entry.Action)),
+		cefHeader(eventName(entry)),
+		cefSeverity(entry),
+	)
+
+	ext := []siemField{
+		{"rt", strconv.FormatInt(entry.Timestamp.UnixMilli(), 10)},
+		{"act", string(entry.Action)},
+		{"deviceExternalId", entry.NodeID},
+		{"externalId", entry.OperationID},
+		{"suser", entry.Actor},
+		{"cnt", strconv.Itoa(entry.RowsAffected)},
+		{"cs1Label", "role"},
+		{"cs1", entry.RoleUsed},
+		{"cs2Label", "table"},
+		{"cs2", entry.TableName},
+		{"cs3Label", "jobId"},
+		{"cs3", entry.JobID},
+		{"cs4Label", "sourceComponent"},
+		{"cs4", entry.SourceComponent},
+		{"cs5Label", "queryHash"},
+		{"cs5", entry.QueryHash},
+		{"cn1Label", "durationMs"},
+		{"cn1", strconv.Itoa(entry.DurationMS)},
+	}
+	ext = append(ext, siemCustomFields(entry)...)
+
+	first := true
+	for _, f := range ext {
+		if f.value == "" {
+			continue
+		}
+		if !first {
+			b.WriteByte(' ')
+		}
+		first = false
+		b.WriteString(f.key)
+		b.WriteByte('=')
+		b.WriteString(cefExtension(f.value))
+	}
+	return b.String()
+}
+
+// FormatLEEFEntry formats an entry as a tab-delimited LEEF:1.0 event. The
+// action is the event ID, the predefined attributes carry the time,
+// category, severity, actor and table, and the hash chain fields are kept
+// in the custom attributes bibPrevHash and bibEntryHash.
+func FormatLEEFEntry(entry *Entry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "LEEF:1.0|%s|%s|%s|%s|",
+		leefHeader(siemVendor),
+		leefHeader(siemProduct),
+		leefHeader(version.Version),
+		leefHeader(string(entry.Action)),
+	)
+
+	attrs := []siemField{
+		{"devTime", strconv.FormatInt(entry.Timestamp.UnixMilli(), 10)},
+		{"cat", string(entry.Action)},
+		{"sev", strconv.Itoa(cefSeverity(entry))},
+		{"usrName", entry.Actor},
+		{"resource", entry.TableName},
+		{"role", entry.RoleUsed},
+		{"nodeId", entry.NodeID},
+		{"operationId", entry.OperationID},
+		{"jobId", entry.JobID},
+		{"sourceComponent", entry.SourceComponent},
+		{"queryHash", entry.QueryHash},
+		{"rowsAffected", strconv.Itoa(entry.RowsAffected)},
+		{"durationMs", strconv.Itoa(entry.DurationMS)},
+	}
+	attrs = append(attrs, siemCustomFields(entry)...)
+
+	first := true
+	for _, f := range attrs {
+		if f.value == "" {
+			continue
+		}
+		if !first {
+			b.WriteByte('\t')
+		}
+		first = false
+		b.WriteString(f.key)
+		b.WriteByte('=')
+		b.WriteString(leefValue(f.value))
+	}
+	return b.String()
+}
+
+// siemField is a key=value pair of a CEF extension or LEEF attribute.
+type siemField struct {
+	key, value string
+}
+
+// siemCustomFields returns the bib-specific fields shared by CEF and LEEF:
+// the hash chain and the entry flags.
+func siemCustomFields(entry *Entry) []siemField {
+	fields := []siemField{
+		{"bibPrevHash", entry.PrevHash},
+		{"bibEntryHash", entry.EntryHash},
+	}
+	if entry.ID != 0 {
+		fields = append(fields, siemField{"bibEntryId", strconv.FormatInt(entry.ID, 10)})
+	}
+	for _, flag := range []struct {
+		key string
+		set bool
+	}{
+		{"bibSuspicious", entry.Flags.Suspicious},
+		{"bibRateLimited", entry.Flags.RateLimited},
+		{"bibBreakGlass", entry.Flags.BreakGlass},
+		{"bibAlertTriggered", entry.Flags.AlertTriggered},
+	} {
+		if flag.set {
+			fields = append(fields, siemField{flag.key, "true"})
+		}
+	}
+	return fields
+}
+
+// eventName is the human-readable event name of an entry.
+func eventName(entry *Entry) string {
+	if entry.TableName != "" {
+		return fmt.Sprintf("Database %s on %s", entry.Action, entry.TableName)
+	}
+	return fmt.Sprintf("Database %s", entry.Action)
+}
+
+// cefSeverity maps an entry to the 0-10 severity of CEF and LEEF, in line
+// with the syslog severity of the entry.
+func cefSeverity(entry *Entry) int {
+	switch {
+	case entry.Flags.Suspicious || entry.Flags.AlertTriggered:
+		return 8
+	case entry.Flags.BreakGlass:
+		return 6
+	case entry.Action == ActionDDL:
+		return 5
+	default:
+		return 3
+	}
+}
+
+// cefHeader escapes a CEF header field: backslashes and pipes.
+func cefHeader(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ").Replace(s)
+}
+
+// cefExtension escapes a CEF extension value: backslashes, equal signs and
+// line breaks.
+func cefExtension(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`).Replace(s)
+}
+
+// leefHeader escapes a LEEF header field.
+func leefHeader(s string) string {
+	return strings.NewReplacer(`|`, `\|`, "\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
+
+// leefValue keeps a LEEF attribute value on one line and free of the tab
+// delimiter.
+func leefValue(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,149 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func siemTestEntry() *Entry {
+	entry := NewEntry("node-1", "op-1", "bibd_query", "query", ActionDelete)
+	entry.Timestamp = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	entry.TableName = "datasets"
+	entry.Actor = "alice=admin"
+	entry.RowsAffected = 3
+	entry.DurationMS = 12
+	entry.PrevHash = "prev123"
+	entry.EntryHash = "entry456"
+	entry.Flags.Suspicious = true
+	return entry
+}
+
+func TestFormatCEFEntry(t *testing.T) {
+	got := FormatCEFEntry(siemTestEntry())
+
+	if !strings.HasPrefix(got, "CEF:0|bib|bibd|") {
+		t.Errorf("unexpected header in %q", got)
+	}
+	if !strings.Contains(got, "|DELETE|Database DELETE on datasets|8|") {
+		t.Errorf("expected signature, name and severity in %q", got)
+	}
+	for _, want := range []string{
+		"rt=1714564800000",
+		"act=DELETE",
+		"deviceExternalId=node-1",
+		"externalId=op-1",
+		`suser=alice\=admin`,
+		"cnt=3",
+		"cs2Label=table cs2=datasets",
+		"cn1=12",
+		"bibPrevHash=prev123",
+		"bibEntryHash=entry456",
+		"bibSuspicious=true",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected %q in %q", want, got)
+		}
+	}
+	if strings.Contains(got, "cs3=") {
+		t.Errorf("expected empty fields to be left out of %q", got)
+	}
+}
+
+func TestFormatLEEFEntry(t *testing.T) {
+	entry := siemTestEntry()
+	entry.TableName = "data\tsets"
+	got := FormatLEEFEntry(entry)
+
+	if !strings.HasPrefix(got, "LEEF:1.0|bib|bibd|") {
+		t.Errorf("unexpected header in %q", got)
+	}
+
+	attrs := map[string]string{}
+	header := strings.LastIndex(got, "|")
+	for _, attr := range strings.Split(got[header+1:], "\t") {
+		key, value, _ := strings.Cut(attr, "=")
+		attrs[key] = value
+	}
+	for key, want := range map[string]string{
+		"devTime":      "1714564800000",
+		"cat":          "DELETE",
+		"sev":          "8",
+		"usrName":      "alice=admin",
+		"resource":     "data sets",
+		"nodeId":       "node-1",
+		"bibPrevHash":  "prev123",
+		"bibEntryHash": "entry456",
+	} {
+		if attrs[key] != want {
+			t.Errorf("%s = %q, want %q", key, attrs[key], want)
+		}
+	}
+}
+
+func TestCEFEscaping(t *testing.T) {
+	if got := cefHeader(`a|b\c`); got != `a\|b\\c` {
+		t.Errorf("cefHeader = %q", got)
+	}
+	if got := cefExtension("a=b\nc"); got != `a\=b\nc` {
+		t.Errorf("cefExtension = %q", got)
+	}
+}
+
+func TestSyslogExporter_CEFMessage(t *testing.T) {
+	e := &SyslogExporter{
+		config:   SyslogConfig{Tag: "bibd", Format: FormatCEF},
+		facility: FacilityLocal0,
+	}
+
+	msg := e.formatMessage(siemTestEntry())
+	if !strings.HasPrefix(msg, "<132>1 2024-05-01T12:00:00Z - bibd - op-1 - CEF:0|bib|bibd|") {
+		t.Errorf("unexpected syslog message %q", msg)
+	}
+}
+
+func TestNewSyslogExporter_RejectsJSON(t *testing.T) {
+	if _, err := NewSyslogExporter(SyslogConfig{Enabled: true, Format: FormatJSON}); err == nil {
+		t.Error("expected an error for the json format")
+	}
+}
+
+func TestFileExporter_LEEF(t *testing.T) {
+	tmpDir := t.TempDir()
+	exporter, err := NewFileExporter(FileExportConfig{
+		Enabled:     true,
+		Directory:   tmpDir,
+		FilePrefix:  "audit",
+		MaxFileSize: 1024 * 1024,
+		Format:      FormatLEEF,
+	})
+	if err != nil {
+		t.Fatalf("NewFileExporter() error = %v", err)
+	}
+
+	if err := exporter.Export(context.Background(), siemTestEntry()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if err := exporter.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(tmpDir, "audit-*.leef"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one .leef file, got %v (%v)", files, err)
+	}
+	f, err := os.Open(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), "LEEF:1.0|") {
+		t.Errorf("expected a LEEF line, got %q", scanner.Text())
+	}
+}
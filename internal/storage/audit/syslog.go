@@ -50,6 +50,10 @@ type SyslogConfig struct {
 
 	// MaxRetries is the maximum number of send retries.
 	MaxRetries int `mapstructure:"max_retries"`
+
+	// Format is the message format: "rfc5424" (default) with the entry as
+	// structured data, or "cef" or "leef" for SIEMs expecting those.
+	Format ExportFormat `mapstructure:"format"`
 }
 
 // SyslogFacility represents syslog facility values.
@@ -103,6 +107,7 @@ func DefaultSyslogConfig() SyslogConfig {
 		Tag:               "bibd",
 		ReconnectInterval: 30 * time.Second,
 		MaxRetries:        3,
+		Format:            FormatRFC5424,
 	}
 }
 
@@ -111,6 +116,9 @@ func NewSyslogExporter(cfg SyslogConfig) (*SyslogExporter, error) {
 	if !cfg.Enabled {
 		return nil, nil
 	}
+	if cfg.Format != FormatNative && cfg.Format != FormatRFC5424 && !cfg.Format.IsSIEM() {
+		return nil, fmt.Errorf("unsupported syslog format: %s", cfg.Format)
+	}
 
 	exporter := &SyslogExporter{
 		config:   cfg,
@@ -204,6 +212,12 @@ func (e *SyslogExporter) formatMessage(entry *Entry) string {
 	procID := "-"
 	msgID := entry.OperationID
 
+	// CEF and LEEF carry the entry in the message instead
+	if e.config.Format.IsSIEM() {
+		return fmt.Sprintf("<%d>%d %s %s %s %s %s - %s\n",
+			pri, version, timestamp, hostname, appName, procID, msgID, FormatSIEM(e.config.Format, entry))
+	}
+
 	// Structured data
 	sd := e.formatStructuredData(entry)
 
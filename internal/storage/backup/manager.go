@@ -494,15 +494,12 @@ func (m *Manager) cleanupOldBackups() error {
 }
 
 func (m *Manager) extractPassword() string {
-	// Extract password from connection string
-	// Format: "host=X port=Y user=Z password=W dbname=D sslmode=S"
-	parts := splitConnectionString(m.connStr)
-	for _, part := range parts {
-		if len(part) > 9 && part[:9] == "password=" {
-			return part[9:]
-		}
+	// Extract password from connection string, in keyword or URL form
+	params, err := storage.ParseConnString(m.connStr)
+	if err != nil {
+		return ""
 	}
-	return ""
+	return params["password"]
 }
 
 func (m *Manager) getPostgresVersion(ctx context.Context) (string, error) {
@@ -529,9 +526,3 @@ func (m *Manager) hasData() bool {
 	}
 	return false
 }
-
-func splitConnectionString(connStr string) []string {
-	_ = connStr // unused parameter - simplified implementation
-	// Simple space-based split
-	return []string{} // Simplified for now
-}
@@ -1,15 +1,159 @@
 package blob
 
 import (
+	"bufio"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
+	"math"
 
 	"github.com/klauspost/compress/zstd"
 )
 
 // Compression utilities for blob storage.
 
+// Stored blobs start with a header naming the compression algorithm used
+// for that blob, so changing the configured algorithm doesn't break reads
+// of blobs written before. With encryption the header is inside the
+// ciphertext.
+var blobHeaderMagic = [4]byte{0xb1, 0xb0, 'c', 'z'}
+
+const blobHeaderSize = len(blobHeaderMagic) + 1
+
+// Header algorithm identifiers.
+const (
+	headerNone byte = 0
+	headerGzip byte = 1
+	headerZstd byte = 2
+)
+
+// entropySampleSize is how much of a blob is inspected to decide whether
+// compressing it is worthwhile.
+const entropySampleSize = 16 << 10
+
+// incompressibleEntropy is the Shannon entropy, in bits per byte, above
+// which a sample is treated as already compressed or encrypted.
+const incompressibleEntropy = 7.5
+
+// encodeBlob writes the header and the content of r to w, compressed with
+// algorithm unless the start of the content looks incompressible. An empty
+// algorithm or "none" stores the content as is. It returns the algorithm
+// actually used.
+func encodeBlob(w io.Writer, r io.Reader, algorithm string, level int) (CompressionType, error) {
+	br := bufio.NewReaderSize(r, entropySampleSize)
+
+	used := CompressionType(algorithm)
+	if algorithm == "" {
+		used = CompressionNone
+	}
+	if used != CompressionNone {
+		sample, err := br.Peek(entropySampleSize)
+		if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+			return "", fmt.Errorf("failed to read data: %w", err)
+		}
+		if shannonEntropy(sample) > incompressibleEntropy {
+			used = CompressionNone
+		}
+	}
+
+	id, err := headerID(used)
+	if err != nil {
+		return "", err
+	}
+	header := append(blobHeaderMagic[:], id)
+	if _, err := w.Write(header); err != nil {
+		return "", err
+	}
+
+	compWriter, err := newCompressionWriter(w, string(used), level)
+	if err != nil {
+		return "", fmt.Errorf("failed to create compression writer: %w", err)
+	}
+	if _, err := io.Copy(compWriter, br); err != nil {
+		return "", fmt.Errorf("failed to compress data: %w", err)
+	}
+	if err := compWriter.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize compression: %w", err)
+	}
+	return used, nil
+}
+
+// decodeBlob returns the original content of a stored blob. Blobs written
+// before headers were introduced have none; legacy is called to find the
+// algorithm they were stored with.
+func decodeBlob(r io.Reader, legacy func() CompressionType) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+
+	var algorithm CompressionType
+	header, err := br.Peek(blobHeaderSize)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read blob header: %w", err)
+	}
+	if len(header) == blobHeaderSize && bytes.Equal(header[:len(blobHeaderMagic)], blobHeaderMagic[:]) {
+		if algorithm, err = headerAlgorithm(header[len(blobHeaderMagic)]); err != nil {
+			return nil, err
+		}
+		_, _ = br.Discard(blobHeaderSize)
+	} else {
+		algorithm = legacy()
+	}
+
+	if algorithm == "" {
+		algorithm = CompressionNone
+	}
+	return newDecompressionReader(br, string(algorithm))
+}
+
+func headerID(c CompressionType) (byte, error) {
+	switch c {
+	case CompressionNone:
+		return headerNone, nil
+	case CompressionGzip:
+		return headerGzip, nil
+	case CompressionZstd:
+		return headerZstd, nil
+	default:
+		return 0, fmt.Errorf("unsupported compression algorithm: %s", c)
+	}
+}
+
+func headerAlgorithm(id byte) (CompressionType, error) {
+	switch id {
+	case headerNone:
+		return CompressionNone, nil
+	case headerGzip:
+		return CompressionGzip, nil
+	case headerZstd:
+		return CompressionZstd, nil
+	default:
+		return "", fmt.Errorf("unknown blob compression id %d", id)
+	}
+}
+
+// shannonEntropy returns the entropy of data in bits per byte (0-8).
+func shannonEntropy(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+
+	var counts [256]int
+	for _, b := range data {
+		counts[b]++
+	}
+
+	var entropy float64
+	n := float64(len(data))
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
 // newCompressionWriter creates a compression writer based on the algorithm.
 func newCompressionWriter(w io.Writer, algorithm string, level int) (io.WriteCloser, error) {
 	switch algorithm {
@@ -52,7 +196,7 @@ func newDecompressionReader(r io.Reader, algorithm string) (io.ReadCloser, error
 		if err != nil {
 			return nil, err
 		}
-		return io.NopCloser(decoder.IOReadCloser()), nil
+		return decoder.IOReadCloser(), nil
 
 	case "none":
 		return io.NopCloser(r), nil
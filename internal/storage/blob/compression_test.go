@@ -0,0 +1,187 @@
+package blob
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func compressibleData() []byte {
+	return bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog\n"), 1000)
+}
+
+func randomData(n int) []byte {
+	data := make([]byte, n)
+	_, _ = rand.Read(data)
+	return data
+}
+
+func noLegacy() CompressionType { return CompressionNone }
+
+func TestEncodeBlob_RoundTrip(t *testing.T) {
+	data := compressibleData()
+
+	for _, algorithm := range []string{"", "none", "gzip", "zstd"} {
+		var buf bytes.Buffer
+		used, err := encodeBlob(&buf, bytes.NewReader(data), algorithm, 3)
+		if err != nil {
+			t.Fatalf("%q: encodeBlob failed: %v", algorithm, err)
+		}
+
+		expected := CompressionType(algorithm)
+		if algorithm == "" {
+			expected = CompressionNone
+		}
+		if used != expected {
+			t.Errorf("%q: used %s", algorithm, used)
+		}
+		if used != CompressionNone && buf.Len() >= len(data)/4 {
+			t.Errorf("%q: stored %d bytes for %d bytes of text", algorithm, buf.Len(), len(data))
+		}
+
+		reader, err := decodeBlob(&buf, noLegacy)
+		if err != nil {
+			t.Fatalf("%q: decodeBlob failed: %v", algorithm, err)
+		}
+		got, _ := io.ReadAll(reader)
+		reader.Close()
+		if !bytes.Equal(got, data) {
+			t.Errorf("%q: round trip changed the data", algorithm)
+		}
+	}
+}
+
+func TestEncodeBlob_SkipsIncompressible(t *testing.T) {
+	data := randomData(64 << 10)
+
+	var buf bytes.Buffer
+	used, err := encodeBlob(&buf, bytes.NewReader(data), "zstd", 3)
+	if err != nil {
+		t.Fatalf("encodeBlob failed: %v", err)
+	}
+	if used != CompressionNone {
+		t.Errorf("expected random data to be stored uncompressed, used %s", used)
+	}
+	if buf.Len() != blobHeaderSize+len(data) {
+		t.Errorf("expected only the header to be added, got %d bytes", buf.Len())
+	}
+}
+
+func TestDecodeBlob_Legacy(t *testing.T) {
+	data := compressibleData()
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write(data)
+	_ = w.Close()
+
+	reader, err := decodeBlob(&gz, func() CompressionType { return CompressionGzip })
+	if err != nil {
+		t.Fatalf("decodeBlob failed: %v", err)
+	}
+	got, _ := io.ReadAll(reader)
+	if !bytes.Equal(got, data) {
+		t.Error("headerless gzip blob not decoded with the legacy algorithm")
+	}
+
+	// Shorter than a header
+	reader, err = decodeBlob(bytes.NewReader([]byte("hi")), noLegacy)
+	if err != nil {
+		t.Fatalf("decodeBlob failed: %v", err)
+	}
+	got, _ = io.ReadAll(reader)
+	if string(got) != "hi" {
+		t.Errorf("expected %q, got %q", "hi", got)
+	}
+}
+
+func TestShannonEntropy(t *testing.T) {
+	if e := shannonEntropy(bytes.Repeat([]byte{'a'}, 100)); e != 0 {
+		t.Errorf("expected 0 for a constant sample, got %f", e)
+	}
+	if e := shannonEntropy(compressibleData()); e > 5 {
+		t.Errorf("expected low entropy for text, got %f", e)
+	}
+	if e := shannonEntropy(randomData(entropySampleSize)); e < incompressibleEntropy {
+		t.Errorf("expected high entropy for random data, got %f", e)
+	}
+}
+
+func TestLocalStore_MixedCompression(t *testing.T) {
+	tempDir := t.TempDir()
+	log := testLogger(t)
+	defer log.Close()
+
+	open := func(enabled bool, algorithm string) *LocalStore {
+		cfg := LocalConfig{
+			Enabled:     true,
+			Path:        filepath.Join(tempDir, "blobs"),
+			Compression: CompressionConfig{Enabled: enabled, Algorithm: algorithm, Level: 3},
+		}
+		store, err := NewLocalStore(cfg, tempDir, nil, log)
+		if err != nil {
+			t.Fatalf("failed to create store: %v", err)
+		}
+		return store
+	}
+
+	ctx := context.Background()
+	put := func(store *LocalStore, data []byte) string {
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+		if err := store.Put(ctx, hash, bytes.NewReader(data), nil); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+		return hash
+	}
+
+	text := compressibleData()
+	random := randomData(32 << 10)
+	plain := append([]byte("uncompressed "), text...)
+
+	gzipStore := open(true, "gzip")
+	textHash := put(gzipStore, text)
+	randomHash := put(gzipStore, random)
+	gzipStore.Close()
+
+	plainStore := open(false, "none")
+	plainHash := put(plainStore, plain)
+	plainStore.Close()
+
+	// Reopened with a different algorithm, every blob still reads back
+	store := open(true, "zstd")
+	defer store.Close()
+
+	for hash, expected := range map[string][]byte{textHash: text, randomHash: random, plainHash: plain} {
+		reader, err := store.Get(ctx, hash)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		got, _ := io.ReadAll(reader)
+		reader.Close()
+		if !bytes.Equal(got, expected) {
+			t.Errorf("blob %s did not read back", hash[:8])
+		}
+	}
+
+	for hash, expected := range map[string]CompressionType{textHash: CompressionGzip, randomHash: CompressionNone, plainHash: CompressionNone} {
+		meta, _ := store.GetMetadata(ctx, hash)
+		if meta.Compression != expected {
+			t.Errorf("blob %s: expected %s in metadata, got %s", hash[:8], expected, meta.Compression)
+		}
+	}
+
+	stats, _ := store.Stats(ctx)
+	if stats.TotalSize != int64(len(text)+len(random)+len(plain)) {
+		t.Errorf("unexpected total size %d", stats.TotalSize)
+	}
+	if ratio := stats.CompressionRatio(); ratio <= 1 {
+		t.Errorf("expected a compression ratio above 1, got %.2f", ratio)
+	}
+}
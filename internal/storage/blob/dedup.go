@@ -0,0 +1,148 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// refLocks serializes read-modify-write updates of a blob's reference list.
+// Blobs are shared between datasets, so two ingestions of identical content
+// may update the same metadata concurrently.
+var refLocks = newKeyedMutex()
+
+// PutContent stores data under the SHA-256 hash of its content and records
+// ref against it. If a blob with the same content already exists, nothing is
+// written; the reference is added to the existing blob instead.
+// It returns the content hash and whether the data was newly stored.
+func PutContent(ctx context.Context, store Store, data io.Reader, ref Reference) (string, bool, error) {
+	buf := new(bytes.Buffer)
+	size, err := io.Copy(buf, data)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read blob data: %w", err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	hash := hex.EncodeToString(sum[:])
+
+	stored, err := putOrReference(ctx, store, hash, buf.Bytes(), size, ref)
+	return hash, stored, err
+}
+
+// putOrReference stores data under hash, or adds ref if it is already stored.
+// The caller is responsible for hash matching data.
+func putOrReference(ctx context.Context, store Store, hash string, data []byte, size int64, ref Reference) (bool, error) {
+	exists, err := store.Exists(ctx, hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to check blob existence: %w", err)
+	}
+	if exists {
+		return false, AddReference(ctx, store, hash, ref)
+	}
+
+	meta := &Metadata{
+		Hash:       hash,
+		Size:       size,
+		References: []Reference{ref},
+	}
+	if err := store.Put(ctx, hash, bytes.NewReader(data), meta); err != nil {
+		// Lost a race with another writer of the same content
+		if exists, _ := store.Exists(ctx, hash); exists {
+			return false, AddReference(ctx, store, hash, ref)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// AddReference records that a chunk uses an existing blob, so deduplicated
+// blobs are not garbage collected while any dataset still refers to them.
+func AddReference(ctx context.Context, store Store, hash string, ref Reference) error {
+	unlock := refLocks.lock(hash)
+	defer unlock()
+
+	meta, err := store.GetMetadata(ctx, hash)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range meta.References {
+		if r == ref {
+			return nil
+		}
+	}
+
+	meta.References = append(meta.References, ref)
+	return store.UpdateMetadata(ctx, hash, meta)
+}
+
+// RemoveReference drops ref from a blob and returns how many references
+// remain. The blob itself is left in place; deleting it is up to the caller
+// or the garbage collector.
+func RemoveReference(ctx context.Context, store Store, hash string, ref Reference) (int, error) {
+	unlock := refLocks.lock(hash)
+	defer unlock()
+
+	meta, err := store.GetMetadata(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+
+	refs := meta.References[:0]
+	for _, r := range meta.References {
+		if r != ref {
+			refs = append(refs, r)
+		}
+	}
+	if len(refs) == len(meta.References) {
+		return len(refs), nil
+	}
+
+	meta.References = refs
+	if err := store.UpdateMetadata(ctx, hash, meta); err != nil {
+		return 0, err
+	}
+	return len(refs), nil
+}
+
+// keyedMutex is a set of mutexes keyed by string, created on demand and
+// dropped once nobody holds or waits for them.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	sync.Mutex
+	waiters int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedLock)}
+}
+
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &keyedLock{}
+		k.locks[key] = l
+	}
+	l.waiters++
+	k.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		k.mu.Lock()
+		l.waiters--
+		if l.waiters == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
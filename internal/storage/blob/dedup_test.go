@@ -0,0 +1,186 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	log := testLogger(t)
+	t.Cleanup(func() { log.Close() })
+
+	store, err := NewLocalStore(LocalConfig{Enabled: true, Path: filepath.Join(tempDir, "blobs")}, tempDir, nil, log)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestPutContent_Deduplicates(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+	data := []byte("identical content")
+
+	refA := Reference{DatasetID: "a", VersionID: "a1", ChunkIndex: 0}
+	refB := Reference{DatasetID: "b", VersionID: "b1", ChunkIndex: 3}
+
+	hash, stored, err := PutContent(ctx, store, bytes.NewReader(data), refA)
+	if err != nil {
+		t.Fatalf("PutContent failed: %v", err)
+	}
+	if !stored {
+		t.Error("expected first write to store the blob")
+	}
+	sum := sha256.Sum256(data)
+	if hash != hex.EncodeToString(sum[:]) {
+		t.Errorf("expected content hash, got %s", hash)
+	}
+
+	again, stored, err := PutContent(ctx, store, bytes.NewReader(data), refB)
+	if err != nil {
+		t.Fatalf("second PutContent failed: %v", err)
+	}
+	if stored || again != hash {
+		t.Errorf("expected second write to return existing hash without storing, got %s stored=%v", again, stored)
+	}
+
+	stats, _ := store.Stats(ctx)
+	if stats.TotalBlobs != 1 {
+		t.Errorf("expected 1 blob, got %d", stats.TotalBlobs)
+	}
+
+	meta, err := store.GetMetadata(ctx, hash)
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if meta.RefCount() != 2 {
+		t.Errorf("expected 2 references, got %v", meta.References)
+	}
+
+	// Re-adding an existing reference is a no-op
+	if err := AddReference(ctx, store, hash, refA); err != nil {
+		t.Fatalf("AddReference failed: %v", err)
+	}
+	meta, _ = store.GetMetadata(ctx, hash)
+	if meta.RefCount() != 2 {
+		t.Errorf("expected duplicate reference to be ignored, got %v", meta.References)
+	}
+
+	reader, err := store.Get(ctx, hash)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	got, _ := io.ReadAll(reader)
+	reader.Close()
+	if !bytes.Equal(got, data) {
+		t.Errorf("read %q, expected %q", got, data)
+	}
+}
+
+func TestRemoveReference(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	refA := Reference{DatasetID: "a", VersionID: "a1"}
+	refB := Reference{DatasetID: "b", VersionID: "b1"}
+
+	hash, _, _ := PutContent(ctx, store, bytes.NewReader([]byte("shared")), refA)
+	_, _, _ = PutContent(ctx, store, bytes.NewReader([]byte("shared")), refB)
+
+	remaining, err := RemoveReference(ctx, store, hash, refA)
+	if err != nil {
+		t.Fatalf("RemoveReference failed: %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("expected 1 remaining reference, got %d", remaining)
+	}
+
+	remaining, _ = RemoveReference(ctx, store, hash, refA)
+	if remaining != 1 {
+		t.Errorf("removing an absent reference changed the count to %d", remaining)
+	}
+
+	remaining, _ = RemoveReference(ctx, store, hash, refB)
+	if remaining != 0 {
+		t.Errorf("expected no remaining references, got %d", remaining)
+	}
+}
+
+func TestAddReference_Concurrent(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	hash, _, err := PutContent(ctx, store, bytes.NewReader([]byte("popular")), Reference{DatasetID: "first"})
+	if err != nil {
+		t.Fatalf("PutContent failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_ = AddReference(ctx, store, hash, Reference{DatasetID: "ds", ChunkIndex: i})
+		}(i)
+	}
+	wg.Wait()
+
+	meta, _ := store.GetMetadata(ctx, hash)
+	if meta.RefCount() != 21 {
+		t.Errorf("expected 21 references, got %d", meta.RefCount())
+	}
+}
+
+func TestGarbageCollector_ReferenceCounting(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	kept, _, _ := PutContent(ctx, store, bytes.NewReader([]byte("kept")), Reference{DatasetID: "a"})
+	dropped, _, _ := PutContent(ctx, store, bytes.NewReader([]byte("dropped")), Reference{DatasetID: "b"})
+	if _, err := RemoveReference(ctx, store, dropped, Reference{DatasetID: "b"}); err != nil {
+		t.Fatalf("RemoveReference failed: %v", err)
+	}
+
+	gc := NewGarbageCollector(GCConfig{Method: "reference-counting"}, store, nil, store.logger)
+	if err := gc.Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if ok, _ := store.Exists(ctx, kept); !ok {
+		t.Error("referenced blob was collected")
+	}
+	if ok, _ := store.Exists(ctx, dropped); ok {
+		t.Error("unreferenced blob was not collected")
+	}
+}
+
+func TestGarbageCollector_MarkAndSweepTrustsReferences(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	for _, data := range []string{"one", "two", "three"} {
+		if _, _, err := PutContent(ctx, store, bytes.NewReader([]byte(data)), Reference{DatasetID: data}); err != nil {
+			t.Fatalf("PutContent failed: %v", err)
+		}
+	}
+
+	// No database: every blob has references, so none is needed
+	gc := NewGarbageCollector(GCConfig{Method: "mark-and-sweep"}, store, nil, store.logger)
+	stats, err := gc.runMarkAndSweep(ctx)
+	if err != nil {
+		t.Fatalf("runMarkAndSweep failed: %v", err)
+	}
+	if stats.BlobsMarked != 3 || stats.BlobsCollected != 0 {
+		t.Errorf("expected 3 marked and none collected, got %+v", stats)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"bib/internal/logger"
+	"bib/internal/maintenance"
 	"bib/internal/storage"
 )
 
@@ -18,8 +19,15 @@ type GarbageCollector struct {
 	dbStore   storage.Store
 	logger    *logger.Logger
 	scheduler *Scheduler
+	gate      *maintenance.Gate
+	locker    maintenance.Locker
+	holder    string
 }
 
+// gcLockTTL is the lease of the cluster-wide GC lock; it is renewed while a
+// cycle runs.
+const gcLockTTL = 5 * time.Minute
+
 // NewGarbageCollector creates a new garbage collector.
 func NewGarbageCollector(cfg GCConfig, blobStore Store, dbStore storage.Store, log *logger.Logger) *GarbageCollector {
 	gc := &GarbageCollector{
@@ -37,6 +45,25 @@ func NewGarbageCollector(cfg GCConfig, blobStore Store, dbStore storage.Store, l
 	return gc
 }
 
+// SetPauseGate makes garbage collection observe the maintenance pause
+// signal. A paused cycle holds between blobs and continues on resume. It
+// must be called before Start.
+func (gc *GarbageCollector) SetPauseGate(gate *maintenance.Gate) {
+	gc.gate = gate
+	gate.Register(maintenance.WorkerGC)
+	if gc.scheduler != nil {
+		gc.scheduler.gate = gate
+	}
+}
+
+// SetLocker makes garbage collection take the cluster-wide GC lock, so only
+// one node collects at a time. A node that cannot take the lock skips the
+// cycle. holder identifies this node in the lock.
+func (gc *GarbageCollector) SetLocker(l maintenance.Locker, holder string) {
+	gc.locker = l
+	gc.holder = holder
+}
+
 // Start starts the garbage collector scheduler.
 func (gc *GarbageCollector) Start(ctx context.Context) error {
 	if gc.scheduler == nil {
@@ -57,8 +84,19 @@ func (gc *GarbageCollector) Stop() error {
 	return gc.scheduler.Stop()
 }
 
-// Run executes a garbage collection cycle.
+// Run executes a garbage collection cycle, holding the cluster-wide GC lock
+// if a locker is set.
 func (gc *GarbageCollector) Run(ctx context.Context) error {
+	ran, err := maintenance.Exclusive(ctx, gc.locker, maintenance.LockName(maintenance.WorkerGC), gc.holder, gcLockTTL, gc.run)
+	if !ran {
+		gc.logger.Info("Skipping garbage collection cycle, GC lock not acquired", "error", err)
+		return nil
+	}
+	return err
+}
+
+// run executes a garbage collection cycle.
+func (gc *GarbageCollector) run(ctx context.Context) error {
 	gc.logger.Info("Starting garbage collection cycle")
 	startTime := time.Now()
 
@@ -123,21 +161,14 @@ type GCStats struct {
 }
 
 // runMarkAndSweep implements mark-and-sweep garbage collection.
+// Blobs whose metadata records references are live without consulting the
+// database; only unreferenced blobs old enough to collect are checked
+// against the chunk table, since they may predate reference tracking.
 func (gc *GarbageCollector) runMarkAndSweep(ctx context.Context) (GCStats, error) {
 	var stats GCStats
 
-	// Phase 1: Mark - scan database for all referenced blobs
+	// Phase 1: Mark - blobs with recorded references
 	gc.logger.Info("GC Phase 1: Marking referenced blobs")
-	referenced, err := gc.markReferencedBlobs(ctx)
-	if err != nil {
-		return stats, fmt.Errorf("mark phase failed: %w", err)
-	}
-	stats.BlobsMarked = int64(len(referenced))
-
-	// Phase 2: Sweep - find and collect unreferenced blobs
-	gc.logger.Info("GC Phase 2: Sweeping unreferenced blobs", "marked", stats.BlobsMarked)
-
-	// List all blobs
 	allBlobs, err := gc.store.List(ctx, "")
 	if err != nil {
 		return stats, fmt.Errorf("failed to list blobs: %w", err)
@@ -146,19 +177,60 @@ func (gc *GarbageCollector) runMarkAndSweep(ctx context.Context) (GCStats, error
 
 	minAge := time.Now().UTC().AddDate(0, 0, -gc.cfg.MinAgeDays)
 
+	var candidates []BlobInfo
 	for _, blob := range allBlobs {
-		// Check if referenced
-		if referenced[blob.Hash] {
-			continue
-		}
-
 		// Check minimum age
 		if blob.CreatedAt.After(minAge) {
 			gc.logger.Debug("Skipping blob - too new", "hash", blob.Hash, "age_days", time.Since(blob.CreatedAt).Hours()/24)
 			continue
 		}
 
-		// Collect this blob
+		meta, err := gc.store.GetMetadata(ctx, blob.Hash)
+		if err != nil {
+			gc.logger.Warn("Failed to get metadata", "hash", blob.Hash, "error", err)
+			continue
+		}
+		if meta.RefCount() > 0 {
+			stats.BlobsMarked++
+			continue
+		}
+		candidates = append(candidates, blob)
+	}
+
+	// Phase 2: Mark - unreferenced blobs still used by a chunk
+	if len(candidates) > 0 {
+		gc.logger.Info("GC Phase 2: Checking unreferenced blobs against database", "candidates", len(candidates))
+		referenced, err := gc.markReferencedBlobs(ctx)
+		if err != nil {
+			return stats, fmt.Errorf("mark phase failed: %w", err)
+		}
+
+		unreferenced := candidates[:0]
+		for _, blob := range candidates {
+			refs, ok := referenced[blob.Hash]
+			if !ok {
+				unreferenced = append(unreferenced, blob)
+				continue
+			}
+
+			// Backfill so later cycles don't need the database for this blob
+			for _, ref := range refs {
+				if err := AddReference(ctx, gc.store, blob.Hash, ref); err != nil {
+					gc.logger.Warn("Failed to record blob reference", "hash", blob.Hash, "error", err)
+					break
+				}
+			}
+			stats.BlobsMarked++
+		}
+		candidates = unreferenced
+	}
+
+	// Phase 3: Sweep - collect what's left
+	gc.logger.Info("GC Phase 3: Sweeping unreferenced blobs", "marked", stats.BlobsMarked)
+	for _, blob := range candidates {
+		if err := gc.gate.Wait(ctx, maintenance.WorkerGC); err != nil {
+			return stats, err
+		}
 		if err := gc.collectBlob(ctx, blob.Hash); err != nil {
 			gc.logger.Warn("Failed to collect blob", "hash", blob.Hash, "error", err)
 			continue
@@ -168,7 +240,10 @@ func (gc *GarbageCollector) runMarkAndSweep(ctx context.Context) (GCStats, error
 		stats.BytesFreed += blob.Size
 	}
 
-	// Phase 3: Clean up old trash
+	// Phase 4: Clean up old trash
+	if err := gc.gate.Wait(ctx, maintenance.WorkerGC); err != nil {
+		return stats, err
+	}
 	if err := gc.cleanupTrash(ctx); err != nil {
 		gc.logger.Warn("Failed to cleanup trash", "error", err)
 	}
@@ -176,9 +251,10 @@ func (gc *GarbageCollector) runMarkAndSweep(ctx context.Context) (GCStats, error
 	return stats, nil
 }
 
-// markReferencedBlobs scans the database and marks all referenced blobs.
-func (gc *GarbageCollector) markReferencedBlobs(ctx context.Context) (map[string]bool, error) {
-	referenced := make(map[string]bool)
+// markReferencedBlobs scans the database and returns the chunk references
+// of every blob in use, keyed by hash.
+func (gc *GarbageCollector) markReferencedBlobs(ctx context.Context) (map[string][]Reference, error) {
+	referenced := make(map[string][]Reference)
 
 	// Query all chunks from database
 	// Note: This is a simplified approach. In production, you'd want to stream this.
@@ -204,8 +280,11 @@ func (gc *GarbageCollector) markReferencedBlobs(ctx context.Context) (map[string
 			}
 
 			for _, chunk := range chunks {
-				// Mark this chunk's hash as referenced
-				referenced[chunk.Hash] = true
+				referenced[chunk.Hash] = append(referenced[chunk.Hash], Reference{
+					DatasetID:  string(dataset.ID),
+					VersionID:  string(version.ID),
+					ChunkIndex: chunk.Index,
+				})
 			}
 		}
 	}
@@ -235,8 +314,7 @@ func (gc *GarbageCollector) runReferenceCounting(ctx context.Context) (GCStats,
 		}
 
 		// Check reference count
-		refCount := len(meta.References)
-		if refCount > 0 {
+		if meta.RefCount() > 0 {
 			stats.BlobsMarked++
 			continue
 		}
@@ -247,6 +325,9 @@ func (gc *GarbageCollector) runReferenceCounting(ctx context.Context) (GCStats,
 		}
 
 		// Collect this blob
+		if err := gc.gate.Wait(ctx, maintenance.WorkerGC); err != nil {
+			return stats, err
+		}
 		if err := gc.collectBlob(ctx, blob.Hash); err != nil {
 			gc.logger.Warn("Failed to collect blob", "hash", blob.Hash, "error", err)
 			continue
@@ -257,6 +338,9 @@ func (gc *GarbageCollector) runReferenceCounting(ctx context.Context) (GCStats,
 	}
 
 	// Clean up old trash
+	if err := gc.gate.Wait(ctx, maintenance.WorkerGC); err != nil {
+		return stats, err
+	}
 	if err := gc.cleanupTrash(ctx); err != nil {
 		gc.logger.Warn("Failed to cleanup trash", "error", err)
 	}
@@ -310,7 +394,7 @@ func (gc *GarbageCollector) cleanupLocalTrash(store *LocalStore) error {
 
 		// Check if old enough to delete
 		if info.ModTime().Before(cutoffTime) {
-			if err := os.Remove(path); err != nil {
+			if err := gc.removeFile(path); err != nil {
 				gc.logger.Warn("Failed to delete trash file", "path", path, "error", err)
 				return nil
 			}
@@ -386,6 +470,7 @@ type Scheduler struct {
 	schedule string
 	task     func(context.Context) error
 	logger   *logger.Logger
+	gate     *maintenance.Gate
 	stop     chan struct{}
 	done     chan struct{}
 }
@@ -423,6 +508,17 @@ func (s *Scheduler) Stop() error {
 func (s *Scheduler) run(ctx context.Context, nextRun time.Time) {
 	defer close(s.done)
 
+	// Stop also releases a run held by a maintenance pause
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	timer := time.NewTimer(time.Until(nextRun))
 	defer timer.Stop()
 
@@ -431,6 +527,11 @@ func (s *Scheduler) run(ctx context.Context, nextRun time.Time) {
 		case <-s.stop:
 			return
 		case <-timer.C:
+			// Hold the run while maintenance is paused
+			if err := s.gate.Wait(ctx, maintenance.WorkerGC); err != nil {
+				return
+			}
+
 			// Run the task
 			if err := s.task(ctx); err != nil {
 				s.logger.Error("Scheduled GC task failed", "error", err)
@@ -473,14 +574,14 @@ func (gc *GarbageCollector) EmptyTrash(ctx context.Context, permanent bool) erro
 	// For local store
 	if localStore, ok := gc.store.(*LocalStore); ok {
 		trashPath := filepath.Join(localStore.basePath, ".trash")
-		return os.RemoveAll(trashPath)
+		return gc.removeAll(trashPath)
 	}
 
 	// For hybrid store
 	if hybridStore, ok := gc.store.(*HybridStore); ok {
 		if hot, ok := hybridStore.hot.(*LocalStore); ok {
 			trashPath := filepath.Join(hot.basePath, ".trash")
-			if err := os.RemoveAll(trashPath); err != nil {
+			if err := gc.removeAll(trashPath); err != nil {
 				return err
 			}
 		}
@@ -75,86 +75,43 @@ func (ing *Ingestion) IngestChunk(ctx context.Context, chunk *domain.Chunk, data
 
 	chunk.Size = size
 
-	// Check if blob already exists (deduplication)
-	exists, err := ing.blobStore.Exists(ctx, chunk.Hash)
-	if err != nil {
-		return fmt.Errorf("failed to check blob existence: %w", err)
+	// Identical content is stored once and shared between chunks
+	ref := Reference{
+		DatasetID:  string(chunk.DatasetID),
+		VersionID:  string(chunk.VersionID),
+		ChunkIndex: chunk.Index,
 	}
-
-	if !exists {
-		// Store blob
-		metadata := &Metadata{
-			Hash: chunk.Hash,
-			Size: size,
-			References: []Reference{
-				{
-					DatasetID:  string(chunk.DatasetID),
-					VersionID:  string(chunk.VersionID),
-					ChunkIndex: chunk.Index,
-				},
-			},
-		}
-
-		if err := ing.blobStore.Put(ctx, chunk.Hash, bytes.NewReader(buf.Bytes()), metadata); err != nil {
-			// Audit failure
-			if ing.audit != nil {
-				ing.audit.LogBlobOperation(ctx, AuditOperation{
-					Operation:  "put",
-					Hash:       chunk.Hash,
-					Size:       size,
-					Success:    false,
-					Error:      err.Error(),
-					DatasetID:  string(chunk.DatasetID),
-					VersionID:  string(chunk.VersionID),
-					ChunkIndex: chunk.Index,
-				})
-			}
-			return fmt.Errorf("failed to store blob: %w", err)
-		}
-
-		// Audit success
+	stored, err := putOrReference(ctx, ing.blobStore, chunk.Hash, buf.Bytes(), size, ref)
+	if err != nil {
 		if ing.audit != nil {
 			ing.audit.LogBlobOperation(ctx, AuditOperation{
 				Operation:  "put",
 				Hash:       chunk.Hash,
 				Size:       size,
-				Success:    true,
+				Success:    false,
+				Error:      err.Error(),
 				DatasetID:  string(chunk.DatasetID),
 				VersionID:  string(chunk.VersionID),
 				ChunkIndex: chunk.Index,
 			})
 		}
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
 
-		ing.logger.Debug("Blob stored", "hash", chunk.Hash, "size", size)
-	} else {
-		// Blob exists - update metadata to add reference
-		meta, err := ing.blobStore.GetMetadata(ctx, chunk.Hash)
-		if err != nil {
-			ing.logger.Warn("Failed to get existing blob metadata", "hash", chunk.Hash, "error", err)
-		} else {
-			// Add reference if not already present
-			ref := Reference{
+	if stored {
+		if ing.audit != nil {
+			ing.audit.LogBlobOperation(ctx, AuditOperation{
+				Operation:  "put",
+				Hash:       chunk.Hash,
+				Size:       size,
+				Success:    true,
 				DatasetID:  string(chunk.DatasetID),
 				VersionID:  string(chunk.VersionID),
 				ChunkIndex: chunk.Index,
-			}
-
-			found := false
-			for _, r := range meta.References {
-				if r.DatasetID == ref.DatasetID && r.VersionID == ref.VersionID && r.ChunkIndex == ref.ChunkIndex {
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				meta.References = append(meta.References, ref)
-				if err := ing.blobStore.UpdateMetadata(ctx, chunk.Hash, meta); err != nil {
-					ing.logger.Warn("Failed to update blob metadata", "hash", chunk.Hash, "error", err)
-				}
-			}
+			})
 		}
-
+		ing.logger.Debug("Blob stored", "hash", chunk.Hash, "size", size)
+	} else {
 		ing.logger.Debug("Blob already exists (deduplicated)", "hash", chunk.Hash)
 	}
 
@@ -210,30 +167,18 @@ func (ing *Ingestion) RetrieveChunk(ctx context.Context, chunk *domain.Chunk) (i
 func (ing *Ingestion) DeleteChunk(ctx context.Context, chunk *domain.Chunk) error {
 	ing.logger.Debug("Deleting chunk", "hash", chunk.Hash, "index", chunk.Index)
 
-	// Get blob metadata
-	meta, err := ing.blobStore.GetMetadata(ctx, chunk.Hash)
-	if err != nil {
-		return fmt.Errorf("failed to get blob metadata: %w", err)
-	}
-
 	// Remove reference
-	ref := Reference{
+	remaining, err := RemoveReference(ctx, ing.blobStore, chunk.Hash, Reference{
 		DatasetID:  string(chunk.DatasetID),
 		VersionID:  string(chunk.VersionID),
 		ChunkIndex: chunk.Index,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update blob references: %w", err)
 	}
 
-	var updatedRefs []Reference
-	for _, r := range meta.References {
-		if r.DatasetID != ref.DatasetID || r.VersionID != ref.VersionID || r.ChunkIndex != ref.ChunkIndex {
-			updatedRefs = append(updatedRefs, r)
-		}
-	}
-
-	meta.References = updatedRefs
-
 	// If no more references, delete the blob
-	if len(updatedRefs) == 0 {
+	if remaining == 0 {
 		if err := ing.blobStore.Delete(ctx, chunk.Hash); err != nil {
 			// Audit failure
 			if ing.audit != nil {
@@ -265,12 +210,7 @@ func (ing *Ingestion) DeleteChunk(ctx context.Context, chunk *domain.Chunk) erro
 
 		ing.logger.Debug("Blob deleted (no more references)", "hash", chunk.Hash)
 	} else {
-		// Update metadata with remaining references
-		if err := ing.blobStore.UpdateMetadata(ctx, chunk.Hash, meta); err != nil {
-			return fmt.Errorf("failed to update blob metadata: %w", err)
-		}
-
-		ing.logger.Debug("Blob reference removed", "hash", chunk.Hash, "remaining_refs", len(updatedRefs))
+		ing.logger.Debug("Blob reference removed", "hash", chunk.Hash, "remaining_refs", remaining)
 	}
 
 	// Delete chunk from database
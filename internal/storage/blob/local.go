@@ -109,30 +109,20 @@ func (s *LocalStore) Put(ctx context.Context, hash string, data io.Reader, metad
 		}
 	}()
 
-	// Read all data into buffer (needed for proper encryption/compression)
-	buf := new(bytes.Buffer)
-	size, err := io.Copy(buf, data)
-	if err != nil {
-		return fmt.Errorf("failed to read data: %w", err)
-	}
-
-	processedData := buf.Bytes()
-
-	// Apply compression if enabled
+	// Compress (unless disabled or the data looks incompressible) behind
+	// a header recording the algorithm
+	algorithm := CompressionNone
 	if s.cfg.Compression.Enabled {
-		compressed := new(bytes.Buffer)
-		compWriter, err := newCompressionWriter(compressed, s.cfg.Compression.Algorithm, s.cfg.Compression.Level)
-		if err != nil {
-			return fmt.Errorf("failed to create compression writer: %w", err)
-		}
-		if _, err := compWriter.Write(processedData); err != nil {
-			return fmt.Errorf("failed to compress data: %w", err)
-		}
-		if err := compWriter.Close(); err != nil {
-			return fmt.Errorf("failed to close compression writer: %w", err)
-		}
-		processedData = compressed.Bytes()
+		algorithm = CompressionType(s.cfg.Compression.Algorithm)
+	}
+	counter := &countingReader{r: data}
+	encoded := new(bytes.Buffer)
+	compression, err := encodeBlob(encoded, counter, string(algorithm), s.cfg.Compression.Level)
+	if err != nil {
+		return err
 	}
+	size := counter.n
+	processedData := encoded.Bytes()
 
 	// Apply encryption if enabled
 	if s.cfg.Encryption.Enabled {
@@ -171,11 +161,8 @@ func (s *LocalStore) Put(ctx context.Context, hash string, data io.Reader, metad
 	metadata.LastAccessed = metadata.CreatedAt
 	metadata.AccessCount = 0
 
-	if s.cfg.Compression.Enabled {
-		metadata.Compression = CompressionType(s.cfg.Compression.Algorithm)
-	} else {
-		metadata.Compression = CompressionNone
-	}
+	metadata.Compression = compression
+	metadata.StoredSize = int64(len(processedData))
 
 	if s.cfg.Encryption.Enabled {
 		metadata.Encryption = EncryptionAES256GCM
@@ -193,6 +180,7 @@ func (s *LocalStore) Put(ctx context.Context, hash string, data io.Reader, metad
 	s.mu.Lock()
 	s.stats.TotalBlobs++
 	s.stats.TotalSize += size
+	s.stats.TotalSizeCompressed += int64(len(processedData))
 	s.mu.Unlock()
 
 	return nil
@@ -233,16 +221,7 @@ func (s *LocalStore) Get(ctx context.Context, hash string) (io.ReadCloser, error
 		processedData = decrypted
 	}
 
-	// Apply decompression if enabled
-	if s.cfg.Compression.Enabled {
-		decompReader, err := newDecompressionReader(bytes.NewReader(processedData), s.cfg.Compression.Algorithm)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create decompression reader: %w", err)
-		}
-		return decompReader, nil
-	}
-
-	return io.NopCloser(bytes.NewReader(processedData)), nil
+	return decodeBlob(bytes.NewReader(processedData), func() CompressionType { return s.legacyCompression(hash) })
 }
 
 // Delete removes a blob by hash (moves to trash).
@@ -256,6 +235,8 @@ func (s *LocalStore) Delete(ctx context.Context, hash string) error {
 		return fmt.Errorf("blob not found: %s", hash)
 	}
 
+	meta, _ := s.GetMetadata(ctx, hash)
+
 	// Move to trash instead of immediate deletion
 	trashPath := filepath.Join(s.basePath, ".trash", hash)
 	if err := os.MkdirAll(filepath.Dir(trashPath), 0700); err != nil {
@@ -272,11 +253,11 @@ func (s *LocalStore) Delete(ctx context.Context, hash string) error {
 	os.Rename(metaPath, trashMetaPath) // Non-fatal if fails
 
 	// Update stats
-	meta, _ := s.GetMetadata(ctx, hash)
 	if meta != nil {
 		s.mu.Lock()
 		s.stats.TotalBlobs--
 		s.stats.TotalSize -= meta.Size
+		s.stats.TotalSizeCompressed -= meta.StoredSize
 		s.mu.Unlock()
 	}
 
@@ -452,6 +433,18 @@ func (s *LocalStore) Close() error {
 
 // Helper methods
 
+// legacyCompression returns the algorithm a blob without a header was
+// stored with.
+func (s *LocalStore) legacyCompression(hash string) CompressionType {
+	if meta, err := s.GetMetadata(context.Background(), hash); err == nil && meta.Compression != "" {
+		return meta.Compression
+	}
+	if s.cfg.Compression.Enabled {
+		return CompressionType(s.cfg.Compression.Algorithm)
+	}
+	return CompressionNone
+}
+
 func (s *LocalStore) blobPath(hash string) string {
 	// Structure: <basePath>/<hash[0:2]>/<hash[2:4]>/<hash>
 	return filepath.Join(s.basePath, hash[0:2], hash[2:4], hash)
@@ -482,7 +475,7 @@ func (s *LocalStore) writeMetadata(hash string, meta *Metadata) error {
 
 func (s *LocalStore) computeStats(ctx context.Context) error {
 	var totalBlobs int64
-	var totalSize int64
+	var totalSize, storedSize int64
 	var oldest, newest time.Time
 
 	err := filepath.Walk(s.basePath, func(path string, info os.FileInfo, err error) error {
@@ -495,7 +488,12 @@ func (s *LocalStore) computeStats(ctx context.Context) error {
 		}
 
 		totalBlobs++
-		totalSize += info.Size()
+		storedSize += info.Size()
+		if meta, err := s.GetMetadata(ctx, filepath.Base(path)); err == nil {
+			totalSize += meta.Size
+		} else {
+			totalSize += info.Size()
+		}
 
 		modTime := info.ModTime()
 		if oldest.IsZero() || modTime.Before(oldest) {
@@ -515,6 +513,7 @@ func (s *LocalStore) computeStats(ctx context.Context) error {
 	s.mu.Lock()
 	s.stats.TotalBlobs = totalBlobs
 	s.stats.TotalSize = totalSize
+	s.stats.TotalSizeCompressed = storedSize
 	s.stats.OldestBlob = oldest
 	s.stats.NewestBlob = newest
 	s.mu.Unlock()
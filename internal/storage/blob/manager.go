@@ -50,7 +50,8 @@ type Manager struct {
 	logger    *logger.Logger
 }
 
-// Open initializes blob storage based on configuration.
+// Open initializes blob storage based on configuration. In s3 and hybrid
+// mode a client is built from cfg.S3 unless s3Client is given.
 func Open(cfg Config, dataDir string, encKey []byte, dbStore storage.Store, s3Client audit.S3Client, auditLog AuditLogger, log *logger.Logger) (*Manager, error) {
 	log.Info("Initializing blob storage", "mode", cfg.Mode)
 
@@ -72,19 +73,25 @@ func Open(cfg Config, dataDir string, encKey []byte, dbStore storage.Store, s3Cl
 			return nil, fmt.Errorf("S3 storage is disabled but mode is 's3'")
 		}
 		if s3Client == nil {
-			return nil, fmt.Errorf("S3 client is required for S3 mode")
+			if s3Client, err = NewS3Client(cfg.S3); err != nil {
+				return nil, err
+			}
 		}
-		blobStore, err = NewS3Store(cfg.S3, s3Client, encKey, log)
+		s3Store, err := NewS3Store(cfg.S3, s3Client, encKey, log)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create S3 blob store: %w", err)
 		}
+		s3Store.SetSecureDelete(cfg.GC.SecureDelete)
+		blobStore = s3Store
 
 	case "hybrid":
 		if !cfg.Local.Enabled || !cfg.S3.Enabled {
 			return nil, fmt.Errorf("both local and S3 storage must be enabled for hybrid mode")
 		}
 		if s3Client == nil {
-			return nil, fmt.Errorf("S3 client is required for hybrid mode")
+			if s3Client, err = NewS3Client(cfg.S3); err != nil {
+				return nil, err
+			}
 		}
 
 		// Create hot (local) store
@@ -98,6 +105,7 @@ func Open(cfg Config, dataDir string, encKey []byte, dbStore storage.Store, s3Cl
 		if err != nil {
 			return nil, fmt.Errorf("failed to create S3 blob store: %w", err)
 		}
+		coldStore.SetSecureDelete(cfg.GC.SecureDelete)
 
 		// Create hybrid store
 		blobStore, err = NewHybridStore(cfg, hotStore, coldStore, log)
@@ -25,10 +25,65 @@ type S3Store struct {
 	encKey []byte         // AES-256 key for client-side encryption
 	logger *logger.Logger
 
+	// secureDelete also removes the prior versions of deleted objects
+	secureDelete bool
+
 	mu    sync.RWMutex
 	stats Stats
 }
 
+// s3ObjectCopier is implemented by clients that can copy objects
+// server-side, which Delete uses to move blobs to trash.
+type s3ObjectCopier interface {
+	CopyObject(ctx context.Context, bucket, srcKey, dstKey string) error
+}
+
+// s3ObjectStater is implemented by clients that can check for a single
+// object without listing.
+type s3ObjectStater interface {
+	ObjectExists(ctx context.Context, bucket, key string) (bool, error)
+}
+
+// s3VersionPurger is implemented by clients that can remove the stored
+// versions of an object in a versioned bucket, which Delete uses for
+// secure delete.
+type s3VersionPurger interface {
+	PurgeObjectVersions(ctx context.Context, bucket, key string) error
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// sizedReader is a reader of known length, which lets the S3 client upload
+// it in a single request.
+type sizedReader struct {
+	io.Reader
+	n int
+}
+
+func (r *sizedReader) Len() int { return r.n }
+
 // NewS3Store creates a new S3 blob store.
 func NewS3Store(cfg S3Config, client audit.S3Client, encKey []byte, log *logger.Logger) (*S3Store, error) {
 	// Validate encryption key if client-side encryption is enabled
@@ -51,6 +106,12 @@ func NewS3Store(cfg S3Config, client audit.S3Client, encKey []byte, log *logger.
 	return store, nil
 }
 
+// SetSecureDelete makes Delete remove the prior versions of deleted objects,
+// so a versioned bucket keeps only the delete marker.
+func (s *S3Store) SetSecureDelete(enabled bool) {
+	s.secureDelete = enabled
+}
+
 // Put stores a blob in S3 with the given hash and data.
 func (s *S3Store) Put(ctx context.Context, hash string, data io.Reader, metadata *Metadata) error {
 	// Validate hash
@@ -67,42 +128,51 @@ func (s *S3Store) Put(ctx context.Context, hash string, data io.Reader, metadata
 		return fmt.Errorf("blob already exists: %s", hash)
 	}
 
-	// Read data into buffer for processing
-	buf := new(bytes.Buffer)
-	originalSize, err := io.Copy(buf, data)
-	if err != nil {
-		return fmt.Errorf("failed to read data: %w", err)
-	}
-
-	dataReader := bytes.NewReader(buf.Bytes())
-	var processedData io.Reader = dataReader
-
-	// Apply compression if enabled
+	// Client-side encryption seals the blob in one piece, so it has to be
+	// buffered. Otherwise the data is streamed, compressing on the fly, and
+	// the client uploads it in parts.
+	algorithm := CompressionNone
 	if s.cfg.Compression.Enabled {
-		compressed := new(bytes.Buffer)
-		compWriter, err := newCompressionWriter(compressed, s.cfg.Compression.Algorithm, s.cfg.Compression.Level)
-		if err != nil {
-			return fmt.Errorf("failed to create compression writer: %w", err)
-		}
+		algorithm = CompressionType(s.cfg.Compression.Algorithm)
+	}
 
-		if _, err := io.Copy(compWriter, dataReader); err != nil {
-			return fmt.Errorf("failed to compress data: %w", err)
-		}
+	counter := &countingReader{r: data}
+	var processedData io.Reader
+	var storedSize int64
+	var encoded func() CompressionType
 
-		if err := compWriter.Close(); err != nil {
-			return fmt.Errorf("failed to finalize compression: %w", err)
+	if l, ok := data.(interface{ Len() int }); ok && algorithm == CompressionNone && !s.cfg.ClientSideEncryption.Enabled {
+		// Keep the length visible to the client
+		storedSize = int64(blobHeaderSize + l.Len())
+		processedData = &sizedReader{
+			Reader: io.MultiReader(bytes.NewReader(append(blobHeaderMagic[:], headerNone)), counter),
+			n:      int(storedSize),
 		}
-
-		processedData = bytes.NewReader(compressed.Bytes())
-	}
-
-	// Apply client-side encryption if enabled
-	if s.cfg.ClientSideEncryption.Enabled {
-		encrypted, err := s.encryptData(processedData)
+		encoded = func() CompressionType { return CompressionNone }
+	} else if s.cfg.ClientSideEncryption.Enabled {
+		buffered, used, err := s.processBuffered(counter, algorithm)
 		if err != nil {
-			return fmt.Errorf("failed to encrypt data: %w", err)
+			return err
+		}
+		storedSize = int64(len(buffered))
+		processedData = bytes.NewReader(buffered)
+		encoded = func() CompressionType { return used }
+	} else {
+		pr, pw := io.Pipe()
+		defer pr.Close()
+		stored := &countingWriter{w: pw}
+		done := make(chan CompressionType, 1)
+		go func() {
+			used, err := encodeBlob(stored, counter, string(algorithm), s.cfg.Compression.Level)
+			done <- used
+			pw.CloseWithError(err)
+		}()
+		processedData = pr
+		encoded = func() CompressionType {
+			used := <-done
+			storedSize = stored.n
+			return used
 		}
-		processedData = bytes.NewReader(encrypted)
 	}
 
 	// Generate S3 key
@@ -125,6 +195,8 @@ func (s *S3Store) Put(ctx context.Context, hash string, data io.Reader, metadata
 	if err := s.client.PutObject(ctx, s.cfg.Bucket, key, processedData, contentType, s3Metadata); err != nil {
 		return fmt.Errorf("failed to upload blob to S3: %w", err)
 	}
+	compression := encoded()
+	originalSize := counter.n
 
 	// Initialize metadata if not provided
 	if metadata == nil {
@@ -136,11 +208,8 @@ func (s *S3Store) Put(ctx context.Context, hash string, data io.Reader, metadata
 	metadata.LastAccessed = metadata.CreatedAt
 	metadata.AccessCount = 0
 
-	if s.cfg.Compression.Enabled {
-		metadata.Compression = CompressionType(s.cfg.Compression.Algorithm)
-	} else {
-		metadata.Compression = CompressionNone
-	}
+	metadata.Compression = compression
+	metadata.StoredSize = storedSize
 
 	if s.cfg.ClientSideEncryption.Enabled {
 		metadata.Encryption = EncryptionAES256GCM
@@ -157,6 +226,7 @@ func (s *S3Store) Put(ctx context.Context, hash string, data io.Reader, metadata
 	s.mu.Lock()
 	s.stats.TotalBlobs++
 	s.stats.TotalSize += originalSize
+	s.stats.TotalSizeCompressed += storedSize
 	s.mu.Unlock()
 
 	return nil
@@ -179,35 +249,28 @@ func (s *S3Store) Get(ctx context.Context, hash string) (io.ReadCloser, error) {
 	// Update access time asynchronously
 	go s.Touch(context.Background(), hash)
 
-	// Read entire object for decryption/decompression
-	// Note: For large objects, this could be optimized with streaming
+	// Client-side encryption has to authenticate the whole object before
+	// releasing any of it; without it the object is streamed.
+	if !s.cfg.ClientSideEncryption.Enabled {
+		decoded, err := decodeBlob(reader, func() CompressionType { return s.legacyCompression(ctx, hash) })
+		if err != nil {
+			reader.Close()
+			return nil, err
+		}
+		return &multiCloser{Reader: decoded, closers: []io.Closer{decoded, reader}}, nil
+	}
+
 	data, err := io.ReadAll(reader)
 	reader.Close()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read S3 object: %w", err)
 	}
 
-	processedData := bytes.NewReader(data)
-
-	// Apply decryption if enabled
-	if s.cfg.ClientSideEncryption.Enabled {
-		decrypted, err := s.decryptData(data)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt data: %w", err)
-		}
-		processedData = bytes.NewReader(decrypted)
-	}
-
-	// Apply decompression if enabled
-	if s.cfg.Compression.Enabled {
-		decompReader, err := newDecompressionReader(processedData, s.cfg.Compression.Algorithm)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create decompression reader: %w", err)
-		}
-		return decompReader, nil
+	decrypted, err := s.decryptData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data: %w", err)
 	}
-
-	return io.NopCloser(processedData), nil
+	return decodeBlob(bytes.NewReader(decrypted), func() CompressionType { return s.legacyCompression(ctx, hash) })
 }
 
 // Delete removes a blob from S3 (moves to trash prefix).
@@ -218,19 +281,12 @@ func (s *S3Store) Delete(ctx context.Context, hash string) error {
 
 	key := s.blobKey(hash)
 	trashKey := s.trashKey(hash)
-
-	// Get object to copy to trash
-	reader, err := s.client.GetObject(ctx, s.cfg.Bucket, key)
-	if err != nil {
-		return fmt.Errorf("failed to get blob for deletion: %w", err)
-	}
+	meta, _ := s.GetMetadata(ctx, hash)
 
 	// Copy to trash
-	if err := s.client.PutObject(ctx, s.cfg.Bucket, trashKey, reader, "application/octet-stream", nil); err != nil {
-		reader.Close()
+	if err := s.copyObject(ctx, key, trashKey); err != nil {
 		return fmt.Errorf("failed to move blob to trash: %w", err)
 	}
-	reader.Close()
 
 	// Delete original
 	if err := s.client.DeleteObject(ctx, s.cfg.Bucket, key); err != nil {
@@ -240,24 +296,44 @@ func (s *S3Store) Delete(ctx context.Context, hash string) error {
 	// Move metadata to trash
 	metaKey := s.metadataKey(hash)
 	trashMetaKey := s.trashKey(hash) + ".meta"
-	if metaReader, err := s.client.GetObject(ctx, s.cfg.Bucket, metaKey); err == nil {
-		s.client.PutObject(ctx, s.cfg.Bucket, trashMetaKey, metaReader, "application/json", nil)
-		metaReader.Close()
+	if err := s.copyObject(ctx, metaKey, trashMetaKey); err == nil {
 		s.client.DeleteObject(ctx, s.cfg.Bucket, metaKey)
 	}
 
+	if s.secureDelete {
+		if err := s.purgeVersions(ctx, key, metaKey); err != nil {
+			return fmt.Errorf("failed to purge blob versions: %w", err)
+		}
+	}
+
 	// Update stats
-	meta, _ := s.GetMetadata(ctx, hash)
 	if meta != nil {
 		s.mu.Lock()
 		s.stats.TotalBlobs--
 		s.stats.TotalSize -= meta.Size
+		s.stats.TotalSizeCompressed -= meta.StoredSize
 		s.mu.Unlock()
 	}
 
 	return nil
 }
 
+// purgeVersions removes the stored versions of keys, keeping the delete
+// markers. Unversioned buckets have nothing to purge.
+func (s *S3Store) purgeVersions(ctx context.Context, keys ...string) error {
+	purger, ok := s.client.(s3VersionPurger)
+	if !ok {
+		s.logger.Warn("S3 client cannot purge object versions, secure delete only removes the current version")
+		return nil
+	}
+	for _, key := range keys {
+		if err := purger.PurgeObjectVersions(ctx, s.cfg.Bucket, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Exists checks if a blob exists in S3.
 func (s *S3Store) Exists(ctx context.Context, hash string) (bool, error) {
 	if !isValidHash(hash) {
@@ -265,6 +341,9 @@ func (s *S3Store) Exists(ctx context.Context, hash string) (bool, error) {
 	}
 
 	key := s.blobKey(hash)
+	if stater, ok := s.client.(s3ObjectStater); ok {
+		return stater.ObjectExists(ctx, s.cfg.Bucket, key)
+	}
 
 	// Try to get object metadata
 	objects, err := s.client.ListObjects(ctx, s.cfg.Bucket, key, 1)
@@ -453,6 +532,49 @@ func (s *S3Store) trashKey(hash string) string {
 	return path.Join(s.cfg.Prefix, ".trash", hash)
 }
 
+// copyObject copies an object within the bucket, server-side if the client
+// supports it.
+func (s *S3Store) copyObject(ctx context.Context, srcKey, dstKey string) error {
+	if copier, ok := s.client.(s3ObjectCopier); ok {
+		return copier.CopyObject(ctx, s.cfg.Bucket, srcKey, dstKey)
+	}
+
+	reader, err := s.client.GetObject(ctx, s.cfg.Bucket, srcKey)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return s.client.PutObject(ctx, s.cfg.Bucket, dstKey, reader, "application/octet-stream", nil)
+}
+
+// processBuffered reads all of r, compresses it behind the blob header and
+// applies client-side encryption.
+func (s *S3Store) processBuffered(r io.Reader, algorithm CompressionType) ([]byte, CompressionType, error) {
+	var buf bytes.Buffer
+	used, err := encodeBlob(&buf, r, string(algorithm), s.cfg.Compression.Level)
+	if err != nil {
+		return nil, "", err
+	}
+
+	encrypted, err := s.encryptData(&buf)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt data: %w", err)
+	}
+	return encrypted, used, nil
+}
+
+// legacyCompression returns the algorithm a blob without a header was
+// stored with.
+func (s *S3Store) legacyCompression(ctx context.Context, hash string) CompressionType {
+	if meta, err := s.GetMetadata(ctx, hash); err == nil && meta.Compression != "" {
+		return meta.Compression
+	}
+	if s.cfg.Compression.Enabled {
+		return CompressionType(s.cfg.Compression.Algorithm)
+	}
+	return CompressionNone
+}
+
 func (s *S3Store) putMetadata(ctx context.Context, hash string, meta *Metadata) error {
 	data, err := json.MarshalIndent(meta, "", "  ")
 	if err != nil {
@@ -0,0 +1,231 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"bib/internal/storage/audit"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// s3PartSize is the part size for multipart uploads. Objects whose size is
+// unknown, or larger than this, are uploaded in parts.
+const s3PartSize = 16 << 20
+
+// MinioS3Client implements audit.S3Client for any S3-compatible service
+// (AWS S3, MinIO, Ceph RGW, ...) using minio-go.
+type MinioS3Client struct {
+	client *minio.Client
+	sse    encrypt.ServerSide
+}
+
+var _ audit.S3Client = (*MinioS3Client)(nil)
+
+// NewS3Client creates an S3 client from blob S3 configuration.
+//
+// Credentials come from the static access key if set, else from the
+// instance/task role when UseIAM is true, else from the standard AWS_* or
+// MINIO_* environment variables.
+func NewS3Client(cfg S3Config) (*MinioS3Client, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+
+	host, secure, err := parseS3Endpoint(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	sse, err := parseServerSideEncryption(cfg.ServerSideEncryption)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds *credentials.Credentials
+	switch {
+	case cfg.AccessKeyID != "" || cfg.SecretAccessKey != "":
+		creds = credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, "")
+	case cfg.UseIAM:
+		creds = credentials.NewIAM("")
+	default:
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+		})
+	}
+
+	client, err := minio.New(host, &minio.Options{
+		Creds:  creds,
+		Secure: secure,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	return &MinioS3Client{client: client, sse: sse}, nil
+}
+
+// EnsureBucket creates the bucket if it does not exist.
+func (c *MinioS3Client) EnsureBucket(ctx context.Context, bucket, region string) error {
+	exists, err := c.client.BucketExists(ctx, bucket)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := c.client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+		return fmt.Errorf("failed to create bucket: %w", err)
+	}
+	return nil
+}
+
+// PutObject uploads an object. Bodies of unknown or large size are sent as
+// a multipart upload, so they are never buffered whole; readers with a Len
+// method (bytes.Reader, bytes.Buffer, ...) are sized up front.
+func (c *MinioS3Client) PutObject(ctx context.Context, bucket, key string, body io.Reader, contentType string, metadata map[string]string) error {
+	size := int64(-1)
+	if l, ok := body.(interface{ Len() int }); ok {
+		size = int64(l.Len())
+	}
+
+	_, err := c.client.PutObject(ctx, bucket, key, body, size, minio.PutObjectOptions{
+		ContentType:          contentType,
+		UserMetadata:         metadata,
+		ServerSideEncryption: c.sse,
+		PartSize:             s3PartSize,
+	})
+	return err
+}
+
+// GetObject returns a stream of the object's content. A missing object is
+// reported here rather than on the first read.
+func (c *MinioS3Client) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	obj, err := c.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, err
+	}
+	return obj, nil
+}
+
+// ListObjects lists objects under prefix, returning at most maxKeys
+// (0 = all).
+func (c *MinioS3Client) ListObjects(ctx context.Context, bucket, prefix string, maxKeys int) ([]audit.S3Object, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var objects []audit.S3Object
+	for info := range c.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return nil, info.Err
+		}
+		objects = append(objects, audit.S3Object{
+			Key:          info.Key,
+			Size:         info.Size,
+			LastModified: info.LastModified,
+		})
+		if maxKeys > 0 && len(objects) >= maxKeys {
+			break
+		}
+	}
+	return objects, nil
+}
+
+// DeleteObject deletes an object. Deleting a missing object is not an error.
+func (c *MinioS3Client) DeleteObject(ctx context.Context, bucket, key string) error {
+	return c.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+}
+
+// PurgeObjectVersions removes every stored version of an object, leaving
+// only delete markers, so deleted content can't be restored from a
+// versioned bucket. Unversioned buckets have no versions to remove.
+func (c *MinioS3Client) PurgeObjectVersions(ctx context.Context, bucket, key string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for info := range c.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: key, WithVersions: true}) {
+		if info.Err != nil {
+			return info.Err
+		}
+		if info.Key != key || info.IsDeleteMarker || info.VersionID == "" || info.VersionID == "null" {
+			continue
+		}
+		if err := c.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{VersionID: info.VersionID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CopyObject copies an object within a bucket without downloading it.
+func (c *MinioS3Client) CopyObject(ctx context.Context, bucket, srcKey, dstKey string) error {
+	_, err := c.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucket, Object: dstKey, Encryption: c.sse},
+		minio.CopySrcOptions{Bucket: bucket, Object: srcKey},
+	)
+	return err
+}
+
+// ObjectExists reports whether an object exists without listing.
+func (c *MinioS3Client) ObjectExists(ctx context.Context, bucket, key string) (bool, error) {
+	_, err := c.client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return false, nil
+	}
+	return false, err
+}
+
+// parseS3Endpoint splits an endpoint into host[:port] and whether to use
+// TLS. An endpoint without a scheme uses TLS; an empty one means AWS.
+func parseS3Endpoint(endpoint string) (string, bool, error) {
+	if endpoint == "" {
+		return "s3.amazonaws.com", true, nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		return strings.TrimSuffix(endpoint, "/"), true, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid S3 endpoint %q: %w", endpoint, err)
+	}
+	switch u.Scheme {
+	case "https":
+		return u.Host, true, nil
+	case "http":
+		return u.Host, false, nil
+	default:
+		return "", false, fmt.Errorf("invalid S3 endpoint %q: scheme must be http or https", endpoint)
+	}
+}
+
+// parseServerSideEncryption maps the server_side_encryption setting to the
+// SSE header sent with every upload.
+func parseServerSideEncryption(mode string) (encrypt.ServerSide, error) {
+	switch {
+	case mode == "" || mode == "none":
+		return nil, nil
+	case mode == "AES256":
+		return encrypt.NewSSE(), nil
+	case mode == "aws:kms":
+		return encrypt.NewSSEKMS("", nil)
+	case strings.HasPrefix(mode, "aws:kms:"):
+		return encrypt.NewSSEKMS(strings.TrimPrefix(mode, "aws:kms:"), nil)
+	default:
+		return nil, fmt.Errorf("unsupported server-side encryption %q (use AES256, aws:kms, aws:kms:<key-id> or none)", mode)
+	}
+}
@@ -0,0 +1,55 @@
+package blob
+
+import "testing"
+
+func TestParseS3Endpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		host     string
+		secure   bool
+		wantErr  bool
+	}{
+		{"", "s3.amazonaws.com", true, false},
+		{"s3.eu-west-1.amazonaws.com", "s3.eu-west-1.amazonaws.com", true, false},
+		{"http://localhost:9000", "localhost:9000", false, false},
+		{"https://minio.example.com/", "minio.example.com", true, false},
+		{"ftp://minio.example.com", "", false, true},
+	}
+
+	for _, tt := range tests {
+		host, secure, err := parseS3Endpoint(tt.endpoint)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseS3Endpoint(%q) error = %v, wantErr %v", tt.endpoint, err, tt.wantErr)
+			continue
+		}
+		if host != tt.host || secure != tt.secure {
+			t.Errorf("parseS3Endpoint(%q) = %q, %v; expected %q, %v", tt.endpoint, host, secure, tt.host, tt.secure)
+		}
+	}
+}
+
+func TestParseServerSideEncryption(t *testing.T) {
+	for _, mode := range []string{"", "none"} {
+		sse, err := parseServerSideEncryption(mode)
+		if err != nil || sse != nil {
+			t.Errorf("%q: expected no encryption, got %v, %v", mode, sse, err)
+		}
+	}
+
+	for _, mode := range []string{"AES256", "aws:kms", "aws:kms:my-key"} {
+		sse, err := parseServerSideEncryption(mode)
+		if err != nil || sse == nil {
+			t.Errorf("%q: expected encryption, got %v, %v", mode, sse, err)
+		}
+	}
+
+	if _, err := parseServerSideEncryption("rot13"); err == nil {
+		t.Error("expected unknown mode to be rejected")
+	}
+}
+
+func TestNewS3Client_RequiresBucket(t *testing.T) {
+	if _, err := NewS3Client(S3Config{Endpoint: "http://localhost:9000"}); err == nil {
+		t.Error("expected missing bucket to be rejected")
+	}
+}
@@ -0,0 +1,80 @@
+package blob
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// secureRemove overwrites a file with random data and syncs it to disk
+// before unlinking it, so the freed blocks don't hold the blob anymore.
+//
+// On SSDs, copy-on-write and journaling filesystems the overwrite may be
+// written to new blocks, leaving the old ones intact until the device
+// reclaims them. Encrypting blobs at rest is the reliable protection there.
+func secureRemove(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode().IsRegular() && info.Size() > 0 {
+		if err := overwriteFile(path, info.Size()); err != nil {
+			return fmt.Errorf("failed to overwrite %s: %w", path, err)
+		}
+	}
+	return os.Remove(path)
+}
+
+// overwriteFile replaces the first size bytes of the file at path with
+// random data and syncs it.
+func overwriteFile(path string, size int64) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := io.CopyN(f, rand.Reader, size); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// secureRemoveAll removes dir and everything below it, overwriting each
+// file with secureRemove first.
+func secureRemoveAll(dir string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		return secureRemove(path)
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// removeFile deletes a file from trash, overwriting it first when secure
+// delete is enabled.
+func (gc *GarbageCollector) removeFile(path string) error {
+	if gc.cfg.SecureDelete {
+		return secureRemove(path)
+	}
+	return os.Remove(path)
+}
+
+// removeAll deletes a trash directory, overwriting its files first when
+// secure delete is enabled.
+func (gc *GarbageCollector) removeAll(dir string) error {
+	if gc.cfg.SecureDelete {
+		return secureRemoveAll(dir)
+	}
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,89 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecureRemove_OverwritesContent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blob")
+	content := bytes.Repeat([]byte("secret"), 1000)
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A hard link keeps the inode reachable after the unlink
+	link := filepath.Join(dir, "link")
+	if err := os.Link(path, link); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+
+	if err := secureRemove(path); err != nil {
+		t.Fatalf("secureRemove failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed, got %v", path, err)
+	}
+
+	left, err := os.ReadFile(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != len(content) {
+		t.Errorf("expected the size to be kept, got %d bytes", len(left))
+	}
+	if bytes.Contains(left, []byte("secret")) {
+		t.Error("expected the content to be overwritten")
+	}
+}
+
+func TestGarbageCollector_EmptyTrashSecureDelete(t *testing.T) {
+	tempDir := t.TempDir()
+	log := testLogger(t)
+	defer log.Close()
+
+	store, err := NewLocalStore(LocalConfig{Enabled: true, Path: filepath.Join(tempDir, "blobs")}, tempDir, nil, log)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	data := []byte("confidential blob content")
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if err := store.Put(ctx, hash, bytes.NewReader(data), &Metadata{Hash: hash}); err != nil {
+		t.Fatalf("failed to put blob: %v", err)
+	}
+	if err := store.Delete(ctx, hash); err != nil {
+		t.Fatalf("failed to delete blob: %v", err)
+	}
+
+	trashPath := filepath.Join(store.basePath, ".trash")
+	link := filepath.Join(tempDir, "link")
+	if err := os.Link(filepath.Join(trashPath, hash), link); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+
+	gc := NewGarbageCollector(GCConfig{SecureDelete: true}, store, nil, log)
+	if err := gc.EmptyTrash(ctx, true); err != nil {
+		t.Fatalf("EmptyTrash failed: %v", err)
+	}
+
+	if _, err := os.Stat(trashPath); !os.IsNotExist(err) {
+		t.Errorf("expected the trash to be removed, got %v", err)
+	}
+	left, err := os.ReadFile(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(left, data) {
+		t.Error("expected the trashed blob to be overwritten")
+	}
+}
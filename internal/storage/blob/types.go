@@ -90,6 +90,10 @@ type Metadata struct {
 	// Compression indicates if and how the blob is compressed.
 	Compression CompressionType `json:"compression"`
 
+	// StoredSize is the size in the backend after compression and
+	// encryption.
+	StoredSize int64 `json:"stored_size,omitempty"`
+
 	// Encryption indicates if and how the blob is encrypted.
 	Encryption EncryptionType `json:"encryption"`
 
@@ -100,6 +104,12 @@ type Metadata struct {
 	OriginalHash string `json:"original_hash,omitempty"`
 }
 
+// RefCount returns the number of chunks referring to the blob. A blob with
+// no references is eligible for garbage collection.
+func (m *Metadata) RefCount() int {
+	return len(m.References)
+}
+
 // Reference tracks a database reference to a blob.
 type Reference struct {
 	DatasetID  string `json:"dataset_id"`
@@ -141,7 +151,7 @@ type Stats struct {
 	// TotalSize is the total size in bytes.
 	TotalSize int64
 
-	// TotalSizeCompressed is the size after compression.
+	// TotalSizeCompressed is the size after compression, as stored.
 	TotalSizeCompressed int64
 
 	// OldestBlob is the creation time of the oldest blob.
@@ -153,3 +163,12 @@ type Stats struct {
 	// Backend is the storage backend type.
 	Backend BackendType
 }
+
+// CompressionRatio returns original size divided by stored size, or 0 if
+// nothing is stored.
+func (s *Stats) CompressionRatio() float64 {
+	if s.TotalSizeCompressed <= 0 {
+		return 0
+	}
+	return float64(s.TotalSize) / float64(s.TotalSizeCompressed)
+}
@@ -1,12 +1,14 @@
 package storage
 
 import (
+	"fmt"
 	"time"
 )
 
 // Config holds the storage configuration.
 type Config struct {
-	// Backend is the storage backend type: "sqlite" or "postgres"
+	// Backend is the storage backend type: "sqlite", "postgres", or
+	// "memory" (tests and ephemeral runs only)
 	Backend BackendType `mapstructure:"backend"`
 
 	// SQLite configuration (used when Backend is "sqlite")
@@ -54,6 +56,23 @@ type SQLiteConfig struct {
 
 	// VacuumInterval is how often to run VACUUM.
 	VacuumInterval time.Duration `mapstructure:"vacuum_interval"`
+
+	// Key encrypts the pages of the database with SQLCipher if set.
+	// An unencrypted database is encrypted in place when it is opened.
+	// bibd sets it from database.sqlite.encryption; it is never read from
+	// the configuration file.
+	Key *SQLiteKey `mapstructure:"-"`
+}
+
+// SQLiteKey is the SQLCipher key of an encrypted SQLite database. Exactly
+// one of Raw and Passphrase is set.
+type SQLiteKey struct {
+	// Raw is a 32-byte key the pages are encrypted with as is.
+	Raw []byte
+
+	// Passphrase is a passphrase SQLCipher derives the key from, with
+	// PBKDF2 and a salt kept in the database file.
+	Passphrase string
 }
 
 // PostgresConfig holds PostgreSQL-specific configuration.
@@ -67,6 +86,10 @@ type PostgresConfig struct {
 	// If empty, auto-detected (docker > podman > kubernetes)
 	ContainerRuntime string `mapstructure:"container_runtime"`
 
+	// RuntimeFallback is the order in which the other runtimes are tried if
+	// ContainerRuntime fails to start, or detected if it is empty
+	RuntimeFallback []string `mapstructure:"runtime_fallback"`
+
 	// SocketPath is the path to the container runtime socket
 	// Auto-detected if empty
 	SocketPath string `mapstructure:"socket_path"`
@@ -124,7 +147,9 @@ type PostgresConfig struct {
 
 // NetworkConfig holds network configuration for managed PostgreSQL.
 type NetworkConfig struct {
-	// UseBridgeNetwork creates a private bridge network for isolation
+	// UseBridgeNetwork creates a private bridge network for isolation.
+	// If the network cannot be created (e.g. rootless Podman without bridge
+	// support), the container falls back to the runtime's default network.
 	UseBridgeNetwork bool `mapstructure:"use_bridge_network"`
 
 	// BridgeNetworkName is the name of the bridge network
@@ -148,14 +173,19 @@ type HealthConfig struct {
 	// StartupTimeout is how long to wait for initial startup
 	StartupTimeout time.Duration `mapstructure:"startup_timeout"`
 
-	// Action defines what happens on repeated failures: "shutdown", "retry_always", "retry_limit"
+	// Action defines what happens on repeated failures: "shutdown", "retry_always", "retry_limit", "failover"
 	Action string `mapstructure:"action"`
 
-	// MaxRetries is the maximum retries (for "retry_limit" action)
+	// MaxRetries is the maximum retries (for "retry_limit" and "failover" actions)
 	MaxRetries int `mapstructure:"max_retries"`
 
 	// RetryBackoff is the backoff duration between retries
 	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	// Standby is the host:port of the streaming replica promoted by the
+	// "failover" action on Docker/Podman; on Kubernetes a CNPG replica is
+	// promoted instead
+	Standby string `mapstructure:"standby"`
 }
 
 // TLSConfig holds TLS configuration for PostgreSQL connections.
@@ -190,9 +220,18 @@ type KubernetesConfig struct {
 	// Requires CNPG operator to be installed in the cluster.
 	UseCNPG bool `mapstructure:"use_cnpg"`
 
-	// CNPGClusterVersion is the CNPG cluster version to use.
+	// CNPGClusterVersion is the PostgreSQL version run by the CNPG cluster.
+	// It is used as the tag of ghcr.io/cloudnative-pg/postgresql (e.g. "16").
 	CNPGClusterVersion string `mapstructure:"cnpg_cluster_version"`
 
+	// CNPGInstances is the number of PostgreSQL instances in the CNPG cluster.
+	// One is the primary; the others are streaming replicas.
+	CNPGInstances int `mapstructure:"cnpg_instances"`
+
+	// CNPGMonitoring creates a PodMonitor for the CNPG cluster.
+	// Requires the Prometheus operator CRDs.
+	CNPGMonitoring bool `mapstructure:"cnpg_monitoring"`
+
 	// StorageClassName is the StorageClass for PersistentVolumeClaims.
 	// Empty string uses the cluster's default StorageClass.
 	StorageClassName string `mapstructure:"storage_class_name"`
@@ -281,7 +320,8 @@ type KubernetesConfig struct {
 	// Options: "RollingUpdate", "OnDelete"
 	UpdateStrategy string `mapstructure:"update_strategy"`
 
-	// DeleteOnCleanup determines if resources are deleted on `bibd cleanup`.
+	// DeleteOnCleanup determines if resources are deleted on cleanup (bibd
+	// shutdown and `bib admin cleanup`).
 	// If false, StatefulSet is scaled to 0 but not deleted.
 	DeleteOnCleanup bool `mapstructure:"delete_on_cleanup"`
 
@@ -497,6 +537,9 @@ type SyslogExportConfig struct {
 
 	// Tag is the syslog tag/program name.
 	Tag string `mapstructure:"tag"`
+
+	// Format is the message format: "rfc5424", "cef" or "leef".
+	Format string `mapstructure:"format"`
 }
 
 // FileExportConfig holds file export configuration.
@@ -512,6 +555,9 @@ type FileExportConfig struct {
 
 	// Compress enables gzip compression.
 	Compress bool `mapstructure:"compress"`
+
+	// Format is the line format: "json", "cef" or "leef".
+	Format string `mapstructure:"format"`
 }
 
 // S3ExportConfig holds S3 export configuration.
@@ -554,6 +600,38 @@ type AlertDetectionConfig struct {
 
 	// WindowDuration is the default time window for detection.
 	WindowDuration time.Duration `mapstructure:"window_duration"`
+
+	// Notify configures delivery of triggered alerts.
+	Notify AlertNotifyConfig `mapstructure:"notify"`
+}
+
+// AlertNotifyConfig configures delivery of triggered alerts.
+type AlertNotifyConfig struct {
+	// Webhook is a URL that receives each alert as a JSON POST.
+	Webhook string `mapstructure:"webhook,omitempty"`
+
+	// Email is the address alerts are mailed to.
+	Email string `mapstructure:"email,omitempty"`
+
+	// SMTP is the mail server used to send to Email.
+	SMTP SMTPConfig `mapstructure:"smtp"`
+
+	// Cooldown is the minimum time between two notifications for the same
+	// rule and group.
+	Cooldown time.Duration `mapstructure:"cooldown"`
+}
+
+// SMTPConfig holds the mail server settings for email notifications.
+type SMTPConfig struct {
+	// Address is the server address (e.g., "smtp.example.com:587").
+	Address string `mapstructure:"address,omitempty"`
+
+	// Username and Password authenticate with PLAIN auth (optional).
+	Username string `mapstructure:"username,omitempty"`
+	Password string `mapstructure:"password,omitempty"`
+
+	// From is the sender address.
+	From string `mapstructure:"from,omitempty"`
 }
 
 // ThresholdRuleConfig defines a threshold-based alert rule.
@@ -788,6 +866,7 @@ func DefaultConfig() Config {
 		Postgres: PostgresConfig{
 			Managed:                    true,
 			ContainerRuntime:           "", // Auto-detect
+			RuntimeFallback:            []string{"docker", "podman", "kubernetes"},
 			SocketPath:                 "", // Auto-detect
 			Image:                      "postgres:16-alpine",
 			DataDir:                    "", // Defaults to <data_dir>/postgres
@@ -823,6 +902,8 @@ func DefaultConfig() Config {
 				Namespace:            "",    // Auto-detect
 				UseCNPG:              false, // Use vanilla StatefulSet by default
 				CNPGClusterVersion:   "16",
+				CNPGInstances:        1,
+				CNPGMonitoring:       false,
 				StorageClassName:     "",     // Use cluster default
 				StorageSize:          "10Gi", // 10GB default
 				BackupEnabled:        true,
@@ -902,12 +983,14 @@ func DefaultConfig() Config {
 				Address:  "localhost:514",
 				Facility: 16, // LOG_LOCAL0
 				Tag:      "bibd",
+				Format:   "rfc5424",
 			},
 			FileExport: FileExportConfig{
 				Enabled:       false,
 				Directory:     "./audit-logs",
 				MaxFileSizeMB: 100,
 				Compress:      true,
+				Format:        "json",
 			},
 			S3Export: S3ExportConfig{
 				Enabled:   false,
@@ -919,6 +1002,9 @@ func DefaultConfig() Config {
 			Alerts: AlertDetectionConfig{
 				Enabled:        true,
 				WindowDuration: 5 * time.Minute,
+				Notify: AlertNotifyConfig{
+					Cooldown: 15 * time.Minute,
+				},
 				ThresholdRules: []ThresholdRuleConfig{
 					{
 						Name:             "bulk_select",
@@ -1131,6 +1217,12 @@ type BlobGCConfig struct {
 	MinAgeDays               int    `mapstructure:"min_age_days"`
 	TrashRetentionDays       int    `mapstructure:"trash_retention_days"`
 	TrashPath                string `mapstructure:"trash_path"`
+
+	// SecureDelete overwrites blob files before unlinking them when trash
+	// is purged, and removes the prior versions of deleted objects in
+	// versioned S3 buckets. Overwriting is not guaranteed on SSDs and
+	// copy-on-write filesystems; encryption at rest is the stronger option.
+	SecureDelete bool `mapstructure:"secure_delete"`
 }
 
 // BlobAuditConfig holds audit logging configuration for blob operations.
@@ -1190,6 +1282,7 @@ func DefaultBlobConfig() BlobConfig {
 			MinAgeDays:               7,
 			TrashRetentionDays:       30,
 			TrashPath:                "", // defaults to <data_dir>/blobs/.trash
+			SecureDelete:             false,
 		},
 		BlobAudit: BlobAuditConfig{
 			LogReads:   false,
@@ -1203,7 +1296,11 @@ func DefaultBlobConfig() BlobConfig {
 func (c *Config) Validate() error {
 	switch c.Backend {
 	case BackendSQLite:
-		// SQLite config validation
+		if k := c.SQLite.Key; k != nil && (len(k.Raw) == 0) == (k.Passphrase == "") {
+			return fmt.Errorf("%w: the SQLite key needs exactly one of a raw key and a passphrase", ErrInvalidInput)
+		}
+	case BackendMemory:
+		// Memory has no configuration
 	case BackendPostgres:
 		if c.Postgres.Advanced != nil && c.Postgres.Managed {
 			return ErrInvalidInput
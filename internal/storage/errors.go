@@ -40,6 +40,10 @@ var (
 
 	// ErrCacheExpired is returned when cached data has expired.
 	ErrCacheExpired = errors.New("cache entry expired")
+
+	// ErrConflict is returned when an update is based on a stale revision
+	// because the entity changed since it was read.
+	ErrConflict = errors.New("entity was modified concurrently")
 )
 
 // IsNotFound checks if the error is a not found error.
@@ -52,6 +56,11 @@ func IsAlreadyExists(err error) bool {
 	return errors.Is(err, ErrAlreadyExists)
 }
 
+// IsConflict checks if the error is due to a concurrent modification.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
 // IsNotAuthoritative checks if the error is due to non-authoritative storage.
 func IsNotAuthoritative(err error) bool {
 	return errors.Is(err, ErrNotAuthoritative)
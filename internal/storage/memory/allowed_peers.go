@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/storage"
+)
+
+// AllowedPeerRepository implements storage.AllowedPeerRepository in memory.
+type AllowedPeerRepository struct {
+	store *Store
+}
+
+// Add adds a peer to the allowed list, replacing any existing entry.
+func (r *AllowedPeerRepository) Add(ctx context.Context, peer *storage.AllowedPeer) error {
+	stored := clone(peer)
+	if stored.AddedAt.IsZero() {
+		stored.AddedAt = time.Now().UTC()
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		d.allowedPeers[peer.PeerID] = stored
+		return nil
+	})
+}
+
+// Remove removes a peer from the allowed list.
+func (r *AllowedPeerRepository) Remove(ctx context.Context, peerID string) error {
+	return r.store.update(ctx, func(d *data) error {
+		delete(d.allowedPeers, peerID)
+		return nil
+	})
+}
+
+// Get retrieves an allowed peer by ID.
+func (r *AllowedPeerRepository) Get(ctx context.Context, peerID string) (*storage.AllowedPeer, error) {
+	var peer *storage.AllowedPeer
+	r.store.view(ctx, func(d *data) {
+		peer = clone(d.allowedPeers[peerID])
+	})
+	if peer == nil {
+		return nil, storage.ErrNotFound
+	}
+	return peer, nil
+}
+
+// List lists all allowed peers, most recently added first.
+func (r *AllowedPeerRepository) List(ctx context.Context) ([]*storage.AllowedPeer, error) {
+	var peers []*storage.AllowedPeer
+	r.store.view(ctx, func(d *data) {
+		for _, p := range d.allowedPeers {
+			peers = append(peers, p)
+		}
+	})
+
+	sortBy(peers, true, func(a, b *storage.AllowedPeer) bool { return a.AddedAt.Before(b.AddedAt) })
+	return cloneAll(peers), nil
+}
+
+// IsAllowed checks if a peer is in the allowed list and not expired.
+func (r *AllowedPeerRepository) IsAllowed(ctx context.Context, peerID string) (bool, error) {
+	now := time.Now().UTC()
+
+	var allowed bool
+	r.store.view(ctx, func(d *data) {
+		p, ok := d.allowedPeers[peerID]
+		allowed = ok && !expired(p.ExpiresAt, now)
+	})
+	return allowed, nil
+}
+
+// Cleanup removes expired entries.
+func (r *AllowedPeerRepository) Cleanup(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	return r.store.update(ctx, func(d *data) error {
+		for id, p := range d.allowedPeers {
+			if p.ExpiresAt != nil && p.ExpiresAt.Before(now) {
+				delete(d.allowedPeers, id)
+			}
+		}
+		return nil
+	})
+}
+
+// Count returns the number of allowed peers that are not expired.
+func (r *AllowedPeerRepository) Count(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, p := range d.allowedPeers {
+			if !expired(p.ExpiresAt, now) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// Ensure interface compliance
+var _ storage.AllowedPeerRepository = (*AllowedPeerRepository)(nil)
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"bib/internal/storage"
+)
+
+// AuditRepository implements storage.AuditRepository in memory. Entries
+// are hash chained like those of the SQLite store.
+type AuditRepository struct {
+	store  *Store
+	nodeID string
+}
+
+// Log persists an audit entry, assigning it the next ID.
+func (r *AuditRepository) Log(ctx context.Context, entry *storage.AuditEntry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+
+	if entry.NodeID == "" {
+		entry.NodeID = r.nodeID
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if entry.EntryHash == "" {
+			entry.PrevHash = d.lastHash
+			entry.EntryHash = calculateEntryHash(entry)
+		}
+
+		d.auditSeq++
+		entry.ID = d.auditSeq
+		d.audit = append(d.audit, clone(entry))
+		d.lastHash = entry.EntryHash
+		return nil
+	})
+}
+
+// Query retrieves entries matching a filter, newest first.
+func (r *AuditRepository) Query(ctx context.Context, filter storage.AuditFilter) ([]*storage.AuditEntry, error) {
+	var entries []*storage.AuditEntry
+	r.store.view(ctx, func(d *data) {
+		for _, e := range slices.Backward(d.audit) {
+			if matchAuditEntry(e, filter) {
+				entries = append(entries, e)
+			}
+		}
+	})
+
+	return cloneAll(page(entries, filter.Offset, filter.Limit)), nil
+}
+
+// Count returns the number of matching entries.
+func (r *AuditRepository) Count(ctx context.Context, filter storage.AuditFilter) (int64, error) {
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, e := range d.audit {
+			if matchAuditEntry(e, filter) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// GetByOperationID retrieves all entries for an operation.
+func (r *AuditRepository) GetByOperationID(ctx context.Context, operationID string) ([]*storage.AuditEntry, error) {
+	return r.Query(ctx, storage.AuditFilter{OperationID: operationID})
+}
+
+// GetByJobID retrieves all entries for a job.
+func (r *AuditRepository) GetByJobID(ctx context.Context, jobID string) ([]*storage.AuditEntry, error) {
+	return r.Query(ctx, storage.AuditFilter{JobID: jobID})
+}
+
+// Purge removes entries older than the given time.
+func (r *AuditRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := r.store.update(ctx, func(d *data) error {
+		kept := make([]*storage.AuditEntry, 0, len(d.audit))
+		for _, e := range d.audit {
+			if e.Timestamp.Before(before) {
+				count++
+				continue
+			}
+			kept = append(kept, e)
+		}
+		d.audit = kept
+		return nil
+	})
+	return count, err
+}
+
+// VerifyChain verifies hash chain integrity of the entries from and to the
+// given IDs.
+func (r *AuditRepository) VerifyChain(ctx context.Context, from, to int64) (bool, error) {
+	valid := true
+	r.store.view(ctx, func(d *data) {
+		var prevHash string
+		for _, e := range d.audit {
+			if e.ID < from || e.ID > to {
+				continue
+			}
+			if e.PrevHash != prevHash || e.EntryHash != calculateEntryHash(e) {
+				valid = false
+				return
+			}
+			prevHash = e.EntryHash
+		}
+	})
+	return valid, nil
+}
+
+// GetLastHash returns the hash of the last entry.
+func (r *AuditRepository) GetLastHash(ctx context.Context) (string, error) {
+	var hash string
+	r.store.view(ctx, func(d *data) {
+		if n := len(d.audit); n > 0 {
+			hash = d.audit[n-1].EntryHash
+		}
+	})
+	return hash, nil
+}
+
+// matchAuditEntry reports whether e matches the filter.
+func matchAuditEntry(e *storage.AuditEntry, filter storage.AuditFilter) bool {
+	switch {
+	case filter.NodeID != "" && e.NodeID != filter.NodeID,
+		filter.JobID != "" && e.JobID != filter.JobID,
+		filter.OperationID != "" && e.OperationID != filter.OperationID,
+		filter.Action != "" && e.Action != filter.Action,
+		filter.TableName != "" && e.TableName != filter.TableName,
+		filter.RoleUsed != "" && e.RoleUsed != filter.RoleUsed,
+		filter.Actor != "" && e.Actor != filter.Actor,
+		filter.After != nil && e.Timestamp.Before(*filter.After),
+		filter.Before != nil && e.Timestamp.After(*filter.Before),
+		filter.Suspicious != nil && *filter.Suspicious && !e.Flags.Suspicious:
+		return false
+	}
+	return true
+}
+
+// calculateEntryHash hashes an entry the way the SQLite and PostgreSQL
+// stores do, so that chains are comparable across backends.
+func calculateEntryHash(entry *storage.AuditEntry) string {
+	data := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%d|%d|%s|%s|%s",
+		entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		entry.NodeID,
+		entry.OperationID,
+		entry.RoleUsed,
+		entry.Action,
+		entry.TableName,
+		entry.SourceComponent,
+		entry.RowsAffected,
+		entry.DurationMS,
+		entry.PrevHash,
+		entry.JobID,
+		entry.QueryHash,
+	)
+	hash := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(hash[:])
+}
+
+// Ensure interface compliance
+var _ storage.AuditRepository = (*AuditRepository)(nil)
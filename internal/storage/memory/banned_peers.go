@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// BannedPeerRepository implements storage.BannedPeerRepository in memory.
+// Like the SQLite store it reports a missing ban as domain.ErrUserNotFound.
+type BannedPeerRepository struct {
+	store *Store
+}
+
+// Create creates a new ban, replacing any existing ban of the peer.
+func (r *BannedPeerRepository) Create(ctx context.Context, ban *storage.BannedPeer) error {
+	return r.store.update(ctx, func(d *data) error {
+		d.bannedPeers[ban.PeerID] = clone(ban)
+		return nil
+	})
+}
+
+// Get retrieves a ban by peer ID.
+func (r *BannedPeerRepository) Get(ctx context.Context, peerID string) (*storage.BannedPeer, error) {
+	var ban *storage.BannedPeer
+	r.store.view(ctx, func(d *data) {
+		ban = clone(d.bannedPeers[peerID])
+	})
+	if ban == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return ban, nil
+}
+
+// List lists bans, most recent first. Expired bans are left out unless
+// the filter includes them.
+func (r *BannedPeerRepository) List(ctx context.Context, filter storage.BannedPeerFilter) ([]*storage.BannedPeer, error) {
+	now := time.Now().UTC()
+
+	var bans []*storage.BannedPeer
+	r.store.view(ctx, func(d *data) {
+		for _, b := range d.bannedPeers {
+			if filter.IncludeExpired || !expired(b.ExpiresAt, now) {
+				bans = append(bans, b)
+			}
+		}
+	})
+
+	sortBy(bans, true, func(a, b *storage.BannedPeer) bool { return a.BannedAt.Before(b.BannedAt) })
+	return cloneAll(page(bans, filter.Offset, filter.Limit)), nil
+}
+
+// Delete removes a ban.
+func (r *BannedPeerRepository) Delete(ctx context.Context, peerID string) error {
+	return r.store.update(ctx, func(d *data) error {
+		delete(d.bannedPeers, peerID)
+		return nil
+	})
+}
+
+// IsBanned checks if a peer is currently banned.
+func (r *BannedPeerRepository) IsBanned(ctx context.Context, peerID string) (bool, error) {
+	now := time.Now().UTC()
+
+	var banned bool
+	r.store.view(ctx, func(d *data) {
+		b, ok := d.bannedPeers[peerID]
+		banned = ok && !expired(b.ExpiresAt, now)
+	})
+	return banned, nil
+}
+
+// CleanupExpired removes expired bans.
+func (r *BannedPeerRepository) CleanupExpired(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+
+	var count int64
+	err := r.store.update(ctx, func(d *data) error {
+		for id, b := range d.bannedPeers {
+			if b.ExpiresAt != nil && b.ExpiresAt.Before(now) {
+				delete(d.bannedPeers, id)
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// expired reports whether an optional expiry lies at or before now.
+func expired(expiresAt *time.Time, now time.Time) bool {
+	return expiresAt != nil && !expiresAt.After(now)
+}
+
+// Ensure interface compliance
+var _ storage.BannedPeerRepository = (*BannedPeerRepository)(nil)
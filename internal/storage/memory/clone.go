@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// clone returns a deep copy of v, so that neither the caller nor the store
+// can change the other's copy. Unexported fields, such as the location of a
+// time.Time, are shared.
+func clone[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	return cloneValue(reflect.ValueOf(v)).Interface().(*T)
+}
+
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(cloneValue(v.Elem()))
+		return c
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		if needsClone(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(cloneValue(v.Index(i)))
+			}
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+		return c
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(cloneValue(v.Elem()))
+		return c
+
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := c.Field(i); f.CanSet() && needsClone(f.Type()) {
+				f.Set(cloneValue(v.Field(i)))
+			}
+		}
+		return c
+
+	default:
+		return v
+	}
+}
+
+// needsClone reports whether values of type t may share memory.
+func needsClone(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Struct:
+		return true
+	default:
+		return false
+	}
+}
+
+// cloneAll returns deep copies of vs.
+func cloneAll[T any](vs []*T) []*T {
+	out := make([]*T, 0, len(vs))
+	for _, v := range vs {
+		out = append(out, clone(v))
+	}
+	return out
+}
+
+// page applies an offset and a limit (0: none) to vs.
+func page[T any](vs []T, offset, limit int) []T {
+	if offset > 0 {
+		if offset >= len(vs) {
+			return nil
+		}
+		vs = vs[offset:]
+	}
+	if limit > 0 && limit < len(vs) {
+		vs = vs[:limit]
+	}
+	return vs
+}
+
+// sortBy sorts vs by the key less compares, stably, reversing the order if
+// desc is set.
+func sortBy[T any](vs []T, desc bool, less func(a, b T) bool) {
+	sort.SliceStable(vs, func(i, j int) bool {
+		if desc {
+			return less(vs[j], vs[i])
+		}
+		return less(vs[i], vs[j])
+	})
+}
+
+// like reports whether s contains substr, ignoring case, as a LIKE
+// '%substr%' does in SQLite.
+func like(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
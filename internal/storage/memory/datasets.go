@@ -0,0 +1,351 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// DatasetRepository implements storage.DatasetRepository in memory.
+type DatasetRepository struct {
+	store *Store
+}
+
+// Create creates a new dataset. Dataset names are unique within a topic.
+func (r *DatasetRepository) Create(ctx context.Context, dataset *domain.Dataset) error {
+	if err := dataset.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.datasets[dataset.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+		if findDatasetByName(d, dataset.TopicID, dataset.Name) != nil {
+			return storage.ErrAlreadyExists
+		}
+
+		dataset.Revision = 1
+		d.datasets[dataset.ID] = clone(dataset)
+		return nil
+	})
+}
+
+func findDatasetByName(d *data, topicID domain.TopicID, name string) *domain.Dataset {
+	for _, ds := range d.datasets {
+		if ds.TopicID == topicID && ds.Name == name {
+			return ds
+		}
+	}
+	return nil
+}
+
+// Get retrieves a dataset by ID.
+func (r *DatasetRepository) Get(ctx context.Context, id domain.DatasetID) (*domain.Dataset, error) {
+	var dataset *domain.Dataset
+	r.store.view(ctx, func(d *data) {
+		dataset = clone(d.datasets[id])
+	})
+	if dataset == nil {
+		return nil, storage.ErrNotFound
+	}
+	return dataset, nil
+}
+
+// List retrieves datasets matching the filter.
+func (r *DatasetRepository) List(ctx context.Context, filter storage.DatasetFilter) ([]*domain.Dataset, error) {
+	var datasets []*domain.Dataset
+	r.store.view(ctx, func(d *data) {
+		for _, ds := range d.datasets {
+			if matchDataset(ds, filter) {
+				datasets = append(datasets, ds)
+			}
+		}
+	})
+
+	sortBy(datasets, filter.OrderDesc, datasetLess(filter.OrderBy))
+	return cloneAll(page(datasets, filter.Offset, filter.Limit)), nil
+}
+
+// Update updates an existing dataset. Unless dataset.Revision is 0, the
+// update only applies if the stored dataset still has that revision and
+// fails with storage.ErrConflict otherwise. dataset.Revision is set to the
+// new revision.
+func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset) error {
+	if err := dataset.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.datasets[dataset.ID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+		if dataset.Revision != 0 && dataset.Revision != stored.Revision {
+			return storage.ErrConflict
+		}
+		if other := findDatasetByName(d, dataset.TopicID, dataset.Name); other != nil && other.ID != dataset.ID {
+			return storage.ErrAlreadyExists
+		}
+
+		updated := clone(dataset)
+		updated.CreatedBy, updated.CreatedAt = stored.CreatedBy, stored.CreatedAt
+		updated.UpdatedAt = time.Now().UTC()
+		updated.Revision = stored.Revision + 1
+		d.datasets[dataset.ID] = updated
+		dataset.Revision = updated.Revision
+		return nil
+	})
+}
+
+// Delete soft-deletes a dataset. Unless revision is 0, the delete only
+// applies if the stored dataset is still at that revision, and fails with
+// storage.ErrConflict otherwise.
+func (r *DatasetRepository) Delete(ctx context.Context, id domain.DatasetID, revision int64) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.datasets[id]
+		if !ok {
+			return storage.ErrNotFound
+		}
+		if revision != 0 && revision != stored.Revision {
+			return storage.ErrConflict
+		}
+
+		deleted := clone(stored)
+		deleted.Status = domain.DatasetStatusDeleted
+		deleted.UpdatedAt = time.Now().UTC()
+		deleted.Revision++
+		d.datasets[id] = deleted
+		return nil
+	})
+}
+
+// Count returns the number of datasets matching the filter.
+func (r *DatasetRepository) Count(ctx context.Context, filter storage.DatasetFilter) (int64, error) {
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, ds := range d.datasets {
+			if matchDataset(ds, filter) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// matchDataset reports whether ds matches the filter.
+func matchDataset(ds *domain.Dataset, filter storage.DatasetFilter) bool {
+	if filter.TopicID != nil && ds.TopicID != *filter.TopicID {
+		return false
+	}
+	if filter.Status != "" && ds.Status != filter.Status {
+		return false
+	}
+	if filter.OwnerID != nil && !slices.Contains(ds.Owners, *filter.OwnerID) {
+		return false
+	}
+	if filter.HasContent != nil && ds.HasContent != *filter.HasContent {
+		return false
+	}
+	if filter.HasInstructions != nil && ds.HasInstructions != *filter.HasInstructions {
+		return false
+	}
+	if filter.Search != "" && !like(ds.Name, filter.Search) && !like(ds.Description, filter.Search) {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(ds.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// datasetLess orders datasets by the given column, by name by default.
+func datasetLess(orderBy string) func(a, b *domain.Dataset) bool {
+	switch orderBy {
+	case "created_at":
+		return func(a, b *domain.Dataset) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case "updated_at":
+		return func(a, b *domain.Dataset) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case "version_count":
+		return func(a, b *domain.Dataset) bool { return a.VersionCount < b.VersionCount }
+	default:
+		return func(a, b *domain.Dataset) bool { return a.Name < b.Name }
+	}
+}
+
+// =============================================================================
+// Versions
+// =============================================================================
+
+// CreateVersion creates a new dataset version. Version strings are unique
+// within a dataset.
+func (r *DatasetRepository) CreateVersion(ctx context.Context, version *domain.DatasetVersion) error {
+	if err := version.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.versions[version.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+		for _, v := range d.versions {
+			if v.DatasetID == version.DatasetID && v.Version == version.Version {
+				return storage.ErrAlreadyExists
+			}
+		}
+
+		d.versions[version.ID] = clone(version)
+		return nil
+	})
+}
+
+// GetVersion retrieves a specific version.
+func (r *DatasetRepository) GetVersion(ctx context.Context, datasetID domain.DatasetID, versionID domain.DatasetVersionID) (*domain.DatasetVersion, error) {
+	var version *domain.DatasetVersion
+	r.store.view(ctx, func(d *data) {
+		if v, ok := d.versions[versionID]; ok && v.DatasetID == datasetID {
+			version = clone(v)
+		}
+	})
+	if version == nil {
+		return nil, storage.ErrNotFound
+	}
+	return version, nil
+}
+
+// GetLatestVersion retrieves the most recently created version of a dataset.
+func (r *DatasetRepository) GetLatestVersion(ctx context.Context, datasetID domain.DatasetID) (*domain.DatasetVersion, error) {
+	versions, _ := r.ListVersions(ctx, datasetID)
+	if len(versions) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return versions[0], nil
+}
+
+// ListVersions lists all versions of a dataset, newest first.
+func (r *DatasetRepository) ListVersions(ctx context.Context, datasetID domain.DatasetID) ([]*domain.DatasetVersion, error) {
+	var versions []*domain.DatasetVersion
+	r.store.view(ctx, func(d *data) {
+		for _, v := range d.versions {
+			if v.DatasetID == datasetID {
+				versions = append(versions, v)
+			}
+		}
+	})
+
+	sortBy(versions, true, func(a, b *domain.DatasetVersion) bool {
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return cloneAll(versions), nil
+}
+
+// DeleteVersion deletes a version and its chunks. A version that followed
+// it is linked to the version before it instead.
+func (r *DatasetRepository) DeleteVersion(ctx context.Context, datasetID domain.DatasetID, versionID domain.DatasetVersionID) error {
+	return r.store.update(ctx, func(d *data) error {
+		version, ok := d.versions[versionID]
+		if !ok || version.DatasetID != datasetID {
+			return storage.ErrNotFound
+		}
+
+		for id, v := range d.versions {
+			if v.DatasetID == datasetID && v.PreviousVersionID == versionID {
+				relinked := clone(v)
+				relinked.PreviousVersionID = version.PreviousVersionID
+				d.versions[id] = relinked
+			}
+		}
+		for id, c := range d.chunks {
+			if c.VersionID == versionID {
+				delete(d.chunks, id)
+			}
+		}
+		delete(d.versions, versionID)
+		return nil
+	})
+}
+
+// =============================================================================
+// Chunks
+// =============================================================================
+
+// CreateChunk creates a new chunk record. Chunk indexes are unique within
+// a version. Like the other stores it records the chunk, not its data.
+func (r *DatasetRepository) CreateChunk(ctx context.Context, chunk *domain.Chunk) error {
+	if err := chunk.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.chunks[chunk.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+		if findChunk(d, chunk.VersionID, chunk.Index) != nil {
+			return storage.ErrAlreadyExists
+		}
+
+		stored := clone(chunk)
+		stored.Data = nil
+		d.chunks[chunk.ID] = stored
+		return nil
+	})
+}
+
+func findChunk(d *data, versionID domain.DatasetVersionID, index int) *domain.Chunk {
+	for _, c := range d.chunks {
+		if c.VersionID == versionID && c.Index == index {
+			return c
+		}
+	}
+	return nil
+}
+
+// GetChunk retrieves a chunk by dataset version and index.
+func (r *DatasetRepository) GetChunk(ctx context.Context, versionID domain.DatasetVersionID, index int) (*domain.Chunk, error) {
+	var chunk *domain.Chunk
+	r.store.view(ctx, func(d *data) {
+		chunk = clone(findChunk(d, versionID, index))
+	})
+	if chunk == nil {
+		return nil, storage.ErrNotFound
+	}
+	return chunk, nil
+}
+
+// ListChunks lists all chunks for a version, by index.
+func (r *DatasetRepository) ListChunks(ctx context.Context, versionID domain.DatasetVersionID) ([]*domain.Chunk, error) {
+	var chunks []*domain.Chunk
+	r.store.view(ctx, func(d *data) {
+		for _, c := range d.chunks {
+			if c.VersionID == versionID {
+				chunks = append(chunks, c)
+			}
+		}
+	})
+
+	sortBy(chunks, false, func(a, b *domain.Chunk) bool { return a.Index < b.Index })
+	return cloneAll(chunks), nil
+}
+
+// UpdateChunkStatus updates the status of a chunk.
+func (r *DatasetRepository) UpdateChunkStatus(ctx context.Context, chunkID domain.ChunkID, status domain.ChunkStatus) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.chunks[chunkID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+
+		updated := clone(stored)
+		updated.Status = status
+		d.chunks[chunkID] = updated
+		return nil
+	})
+}
+
+// Ensure interface compliance
+var _ storage.DatasetRepository = (*DatasetRepository)(nil)
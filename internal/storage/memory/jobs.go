@@ -0,0 +1,242 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// JobRepository implements storage.JobRepository in memory.
+type JobRepository struct {
+	store *Store
+}
+
+// Create creates a new job.
+func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	if job.ExecutionMode == "" {
+		job.ExecutionMode = domain.ExecutionModeGoroutine
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.jobs[job.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+
+		d.jobs[job.ID] = clone(job)
+		return nil
+	})
+}
+
+// Get retrieves a job by ID.
+func (r *JobRepository) Get(ctx context.Context, id domain.JobID) (*domain.Job, error) {
+	var job *domain.Job
+	r.store.view(ctx, func(d *data) {
+		job = clone(d.jobs[id])
+	})
+	if job == nil {
+		return nil, storage.ErrNotFound
+	}
+	return job, nil
+}
+
+// List retrieves jobs matching the filter, oldest first by default.
+func (r *JobRepository) List(ctx context.Context, filter storage.JobFilter) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	r.store.view(ctx, func(d *data) {
+		for _, j := range d.jobs {
+			if matchJob(j, filter) {
+				jobs = append(jobs, j)
+			}
+		}
+	})
+
+	sortBy(jobs, filter.OrderDesc, jobLess(filter.OrderBy))
+	return cloneAll(page(jobs, filter.Offset, filter.Limit)), nil
+}
+
+// Update updates an existing job.
+func (r *JobRepository) Update(ctx context.Context, job *domain.Job) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	if job.ExecutionMode == "" {
+		job.ExecutionMode = domain.ExecutionModeGoroutine
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.jobs[job.ID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+
+		updated := clone(job)
+		updated.CreatedBy, updated.CreatedAt = stored.CreatedBy, stored.CreatedAt
+		d.jobs[job.ID] = updated
+		return nil
+	})
+}
+
+// UpdateStatus updates just the job status. Starting to run sets the start
+// time; finishing sets the completion time.
+func (r *JobRepository) UpdateStatus(ctx context.Context, id domain.JobID, status domain.JobStatus) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.jobs[id]
+		if !ok {
+			return storage.ErrNotFound
+		}
+
+		now := time.Now().UTC()
+		updated := clone(stored)
+		updated.Status = status
+		switch status {
+		case domain.JobStatusRunning:
+			updated.StartedAt = &now
+		case domain.JobStatusCompleted, domain.JobStatusFailed, domain.JobStatusCancelled:
+			updated.CompletedAt = &now
+		}
+		d.jobs[id] = updated
+		return nil
+	})
+}
+
+// Delete deletes a job.
+func (r *JobRepository) Delete(ctx context.Context, id domain.JobID) error {
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.jobs[id]; !ok {
+			return storage.ErrNotFound
+		}
+
+		delete(d.jobs, id)
+		return nil
+	})
+}
+
+// Count returns the number of jobs matching the filter.
+func (r *JobRepository) Count(ctx context.Context, filter storage.JobFilter) (int64, error) {
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, j := range d.jobs {
+			if matchJob(j, filter) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// GetPending retrieves pending jobs, highest priority first and oldest
+// first within a priority.
+func (r *JobRepository) GetPending(ctx context.Context, limit int) ([]*domain.Job, error) {
+	var jobs []*domain.Job
+	r.store.view(ctx, func(d *data) {
+		for _, j := range d.jobs {
+			if j.Status == domain.JobStatusPending {
+				jobs = append(jobs, j)
+			}
+		}
+	})
+
+	sortBy(jobs, false, func(a, b *domain.Job) bool {
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return cloneAll(page(jobs, 0, limit)), nil
+}
+
+// matchJob reports whether j matches the filter.
+func matchJob(j *domain.Job, filter storage.JobFilter) bool {
+	if filter.Type != "" && j.Type != filter.Type {
+		return false
+	}
+	if filter.Status != "" && j.Status != filter.Status {
+		return false
+	}
+	if filter.CreatedBy != "" && string(j.CreatedBy) != filter.CreatedBy {
+		return false
+	}
+	if filter.TopicID != nil && j.TopicID != *filter.TopicID {
+		return false
+	}
+	if filter.DatasetID != nil && j.DatasetID != *filter.DatasetID {
+		return false
+	}
+	if filter.MinPriority != nil && j.Priority < *filter.MinPriority {
+		return false
+	}
+	if filter.CreatedAfter != nil && j.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && j.CreatedAt.After(*filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// jobLess orders jobs by the given column, by creation time by default.
+func jobLess(orderBy string) func(a, b *domain.Job) bool {
+	switch orderBy {
+	case "priority":
+		return func(a, b *domain.Job) bool { return a.Priority < b.Priority }
+	case "status":
+		return func(a, b *domain.Job) bool { return a.Status < b.Status }
+	case "type":
+		return func(a, b *domain.Job) bool { return a.Type < b.Type }
+	default:
+		return func(a, b *domain.Job) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	}
+}
+
+// =============================================================================
+// Results
+// =============================================================================
+
+// CreateResult creates a job result.
+func (r *JobRepository) CreateResult(ctx context.Context, result *domain.JobResult) error {
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.jobResults[result.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+
+		d.jobResults[result.ID] = clone(result)
+		return nil
+	})
+}
+
+// GetResult retrieves a job result by ID.
+func (r *JobRepository) GetResult(ctx context.Context, id string) (*domain.JobResult, error) {
+	var result *domain.JobResult
+	r.store.view(ctx, func(d *data) {
+		result = clone(d.jobResults[id])
+	})
+	if result == nil {
+		return nil, storage.ErrNotFound
+	}
+	return result, nil
+}
+
+// ListResults lists results for a job, most recently completed first.
+func (r *JobRepository) ListResults(ctx context.Context, jobID domain.JobID) ([]*domain.JobResult, error) {
+	var results []*domain.JobResult
+	r.store.view(ctx, func(d *data) {
+		for _, res := range d.jobResults {
+			if res.JobID == jobID {
+				results = append(results, res)
+			}
+		}
+	})
+
+	sortBy(results, true, func(a, b *domain.JobResult) bool {
+		return a.CompletedAt.Before(b.CompletedAt)
+	})
+	return cloneAll(results), nil
+}
+
+// Ensure interface compliance
+var _ storage.JobRepository = (*JobRepository)(nil)
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/storage"
+)
+
+// NodeRepository implements storage.NodeRepository in memory.
+type NodeRepository struct {
+	store *Store
+}
+
+// Upsert creates or updates a node. An update keeps the creation time.
+func (r *NodeRepository) Upsert(ctx context.Context, node *storage.NodeInfo) error {
+	if node.CreatedAt.IsZero() {
+		node.CreatedAt = time.Now().UTC()
+	}
+	node.UpdatedAt = time.Now().UTC()
+
+	return r.store.update(ctx, func(d *data) error {
+		stored := clone(node)
+		if existing, ok := d.nodes[node.PeerID]; ok {
+			stored.CreatedAt = existing.CreatedAt
+		}
+		d.nodes[node.PeerID] = stored
+		return nil
+	})
+}
+
+// Get retrieves a node by peer ID.
+func (r *NodeRepository) Get(ctx context.Context, peerID string) (*storage.NodeInfo, error) {
+	var node *storage.NodeInfo
+	r.store.view(ctx, func(d *data) {
+		node = clone(d.nodes[peerID])
+	})
+	if node == nil {
+		return nil, storage.ErrNotFound
+	}
+	return node, nil
+}
+
+// List retrieves nodes matching the filter, most recently seen first.
+func (r *NodeRepository) List(ctx context.Context, filter storage.NodeFilter) ([]*storage.NodeInfo, error) {
+	var nodes []*storage.NodeInfo
+	r.store.view(ctx, func(d *data) {
+		for _, n := range d.nodes {
+			if matchNode(n, filter) {
+				nodes = append(nodes, n)
+			}
+		}
+	})
+
+	sortBy(nodes, true, func(a, b *storage.NodeInfo) bool { return a.LastSeen.Before(b.LastSeen) })
+	return cloneAll(page(nodes, filter.Offset, filter.Limit)), nil
+}
+
+// Delete removes a node.
+func (r *NodeRepository) Delete(ctx context.Context, peerID string) error {
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.nodes[peerID]; !ok {
+			return storage.ErrNotFound
+		}
+
+		delete(d.nodes, peerID)
+		return nil
+	})
+}
+
+// UpdateLastSeen updates the last seen timestamp.
+func (r *NodeRepository) UpdateLastSeen(ctx context.Context, peerID string) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.nodes[peerID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+
+		now := time.Now().UTC()
+		updated := clone(stored)
+		updated.LastSeen, updated.UpdatedAt = now, now
+		d.nodes[peerID] = updated
+		return nil
+	})
+}
+
+// Count returns the number of nodes matching the filter.
+func (r *NodeRepository) Count(ctx context.Context, filter storage.NodeFilter) (int64, error) {
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, n := range d.nodes {
+			if matchNode(n, filter) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// matchNode reports whether n matches the filter.
+func matchNode(n *storage.NodeInfo, filter storage.NodeFilter) bool {
+	if filter.Mode != "" && n.Mode != filter.Mode {
+		return false
+	}
+	if filter.TrustedOnly && !n.TrustedStorage {
+		return false
+	}
+	if filter.SeenAfter != nil && n.LastSeen.Before(*filter.SeenAfter) {
+		return false
+	}
+	return true
+}
+
+// Ensure interface compliance
+var _ storage.NodeRepository = (*NodeRepository)(nil)
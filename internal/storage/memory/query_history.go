@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// QueryHistoryRepository implements storage.QueryHistoryRepository in memory.
+type QueryHistoryRepository struct {
+	store *Store
+}
+
+// Add adds a query to history.
+func (r *QueryHistoryRepository) Add(ctx context.Context, query *storage.SavedQuery) error {
+	entry := clone(query)
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		d.history = append(d.history, entry)
+		return nil
+	})
+}
+
+// List lists the most recent queries of a user, newest first.
+func (r *QueryHistoryRepository) List(ctx context.Context, userID domain.UserID, limit int) ([]*storage.SavedQuery, error) {
+	var queries []*storage.SavedQuery
+	r.store.view(ctx, func(d *data) {
+		for _, q := range slices.Backward(d.history) {
+			if q.UserID == userID {
+				queries = append(queries, q)
+			}
+		}
+	})
+
+	return cloneAll(page(queries, 0, limit)), nil
+}
+
+// Clear clears query history for a user.
+func (r *QueryHistoryRepository) Clear(ctx context.Context, userID domain.UserID) error {
+	return r.store.update(ctx, func(d *data) error {
+		d.history = slices.DeleteFunc(slices.Clone(d.history), func(q *storage.SavedQuery) bool {
+			return q.UserID == userID
+		})
+		return nil
+	})
+}
+
+// Cleanup removes history entries older than the given time.
+func (r *QueryHistoryRepository) Cleanup(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := r.store.update(ctx, func(d *data) error {
+		d.history = slices.DeleteFunc(slices.Clone(d.history), func(q *storage.SavedQuery) bool {
+			if q.CreatedAt.Before(before) {
+				count++
+				return true
+			}
+			return false
+		})
+		return nil
+	})
+	return count, err
+}
+
+// Ensure interface compliance
+var _ storage.QueryHistoryRepository = (*QueryHistoryRepository)(nil)
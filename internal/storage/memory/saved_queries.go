@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// SavedQueryRepository implements storage.SavedQueryRepository in memory.
+type SavedQueryRepository struct {
+	store *Store
+}
+
+// Create creates a new saved query. Names are unique per user.
+func (r *SavedQueryRepository) Create(ctx context.Context, query *storage.SavedQuery) error {
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.savedQueries[query.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+		if nameTaken(d, query) {
+			return storage.ErrAlreadyExists
+		}
+
+		d.savedQueries[query.ID] = clone(query)
+		return nil
+	})
+}
+
+// nameTaken reports whether another query of the same user has the name
+// of query.
+func nameTaken(d *data, query *storage.SavedQuery) bool {
+	for _, q := range d.savedQueries {
+		if q.ID != query.ID && q.UserID == query.UserID && q.Name == query.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Get retrieves a saved query by ID.
+func (r *SavedQueryRepository) Get(ctx context.Context, id string) (*storage.SavedQuery, error) {
+	return r.find(ctx, func(q *storage.SavedQuery) bool { return q.ID == id })
+}
+
+// GetByName retrieves a user's saved query by name.
+func (r *SavedQueryRepository) GetByName(ctx context.Context, userID domain.UserID, name string) (*storage.SavedQuery, error) {
+	return r.find(ctx, func(q *storage.SavedQuery) bool { return q.UserID == userID && q.Name == name })
+}
+
+func (r *SavedQueryRepository) find(ctx context.Context, match func(q *storage.SavedQuery) bool) (*storage.SavedQuery, error) {
+	var query *storage.SavedQuery
+	r.store.view(ctx, func(d *data) {
+		for _, q := range d.savedQueries {
+			if match(q) {
+				query = clone(q)
+				return
+			}
+		}
+	})
+	if query == nil {
+		return nil, storage.ErrNotFound
+	}
+	return query, nil
+}
+
+// Update updates a saved query. Its owner, use count and creation time
+// cannot change.
+func (r *SavedQueryRepository) Update(ctx context.Context, query *storage.SavedQuery) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.savedQueries[query.ID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+
+		updated := clone(stored)
+		updated.Name = query.Name
+		updated.Description = query.Description
+		updated.Query = query.Query
+		updated.Parameters = *clone(&query.Parameters)
+		updated.Tags = slices.Clone(query.Tags)
+		updated.IsPublic = query.IsPublic
+		updated.UpdatedAt = query.UpdatedAt
+		if nameTaken(d, updated) {
+			return storage.ErrAlreadyExists
+		}
+
+		d.savedQueries[query.ID] = updated
+		return nil
+	})
+}
+
+// Delete deletes a saved query.
+func (r *SavedQueryRepository) Delete(ctx context.Context, id string) error {
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.savedQueries[id]; !ok {
+			return storage.ErrNotFound
+		}
+
+		delete(d.savedQueries, id)
+		return nil
+	})
+}
+
+// IncrementUseCount records that a saved query was run.
+func (r *SavedQueryRepository) IncrementUseCount(ctx context.Context, id string) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.savedQueries[id]
+		if !ok {
+			return storage.ErrNotFound
+		}
+
+		updated := clone(stored)
+		updated.UseCount++
+		d.savedQueries[id] = updated
+		return nil
+	})
+}
+
+// List lists saved queries with optional filtering, ordered by name.
+func (r *SavedQueryRepository) List(ctx context.Context, filter storage.SavedQueryFilter) ([]*storage.SavedQuery, error) {
+	var queries []*storage.SavedQuery
+	r.store.view(ctx, func(d *data) {
+		for _, q := range d.savedQueries {
+			if matchSavedQuery(q, filter) {
+				queries = append(queries, q)
+			}
+		}
+	})
+
+	sortBy(queries, false, func(a, b *storage.SavedQuery) bool {
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+	return cloneAll(page(queries, filter.Offset, filter.Limit)), nil
+}
+
+// Count returns the number of saved queries matching the filter.
+func (r *SavedQueryRepository) Count(ctx context.Context, filter storage.SavedQueryFilter) (int64, error) {
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, q := range d.savedQueries {
+			if matchSavedQuery(q, filter) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// matchSavedQuery reports whether q matches the filter.
+func matchSavedQuery(q *storage.SavedQuery, filter storage.SavedQueryFilter) bool {
+	if filter.UserID != nil && q.UserID != *filter.UserID && !(filter.IncludePublic && q.IsPublic) {
+		return false
+	}
+	if filter.PublicOnly && !q.IsPublic {
+		return false
+	}
+	if filter.Name != "" && q.Name != filter.Name {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(q.Tags, tag) {
+			return false
+		}
+	}
+	if filter.Search != "" && !like(q.Name, filter.Search) &&
+		!like(q.Description, filter.Search) && !like(q.Query, filter.Search) {
+		return false
+	}
+	return true
+}
+
+// Ensure interface compliance
+var _ storage.SavedQueryRepository = (*SavedQueryRepository)(nil)
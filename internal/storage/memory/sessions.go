@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// SessionRepository implements storage.SessionRepository in memory.
+type SessionRepository struct {
+	store *Store
+}
+
+// Create creates a new session.
+func (r *SessionRepository) Create(ctx context.Context, session *storage.Session) error {
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.sessions[session.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+
+		d.sessions[session.ID] = clone(session)
+		return nil
+	})
+}
+
+// Get retrieves a session by ID.
+func (r *SessionRepository) Get(ctx context.Context, id string) (*storage.Session, error) {
+	var session *storage.Session
+	r.store.view(ctx, func(d *data) {
+		session = clone(d.sessions[id])
+	})
+	if session == nil {
+		return nil, domain.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+// GetByUser retrieves all active sessions for a user, newest first.
+func (r *SessionRepository) GetByUser(ctx context.Context, userID domain.UserID) ([]*storage.Session, error) {
+	active := true
+	return r.List(ctx, storage.SessionFilter{UserID: &userID, Active: &active})
+}
+
+// Update updates the end, last activity and metadata of a session.
+func (r *SessionRepository) Update(ctx context.Context, session *storage.Session) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.sessions[session.ID]
+		if !ok {
+			return domain.ErrSessionNotFound
+		}
+
+		updated := clone(stored)
+		updated.EndedAt = clone(session.EndedAt)
+		updated.LastActivityAt = session.LastActivityAt
+		updated.Metadata = maps.Clone(session.Metadata)
+		d.sessions[session.ID] = updated
+		return nil
+	})
+}
+
+// End marks a session as ended.
+func (r *SessionRepository) End(ctx context.Context, id string) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.sessions[id]
+		if !ok || stored.EndedAt != nil {
+			return domain.ErrSessionNotFound
+		}
+
+		d.sessions[id] = endSession(stored)
+		return nil
+	})
+}
+
+// EndAllForUser ends all active sessions for a user.
+func (r *SessionRepository) EndAllForUser(ctx context.Context, userID domain.UserID) error {
+	return r.store.update(ctx, func(d *data) error {
+		for id, s := range d.sessions {
+			if s.UserID == userID && s.EndedAt == nil {
+				d.sessions[id] = endSession(s)
+			}
+		}
+		return nil
+	})
+}
+
+// endSession returns a copy of s, ended now.
+func endSession(s *storage.Session) *storage.Session {
+	now := time.Now().UTC()
+	ended := clone(s)
+	ended.EndedAt = &now
+	ended.LastActivityAt = now
+	return ended
+}
+
+// List retrieves sessions matching the filter, newest first.
+func (r *SessionRepository) List(ctx context.Context, filter storage.SessionFilter) ([]*storage.Session, error) {
+	var sessions []*storage.Session
+	r.store.view(ctx, func(d *data) {
+		for _, s := range d.sessions {
+			if matchSession(s, filter) {
+				sessions = append(sessions, s)
+			}
+		}
+	})
+
+	sortBy(sessions, true, func(a, b *storage.Session) bool { return a.StartedAt.Before(b.StartedAt) })
+	return cloneAll(page(sessions, filter.Offset, filter.Limit)), nil
+}
+
+// Cleanup removes sessions that ended before the given time.
+func (r *SessionRepository) Cleanup(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := r.store.update(ctx, func(d *data) error {
+		for id, s := range d.sessions {
+			if s.EndedAt != nil && s.EndedAt.Before(before) {
+				delete(d.sessions, id)
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// matchSession reports whether s matches the filter.
+func matchSession(s *storage.Session, filter storage.SessionFilter) bool {
+	if filter.UserID != nil && s.UserID != *filter.UserID {
+		return false
+	}
+	if filter.Type != "" && s.Type != filter.Type {
+		return false
+	}
+	if filter.Active != nil && (s.EndedAt == nil) != *filter.Active {
+		return false
+	}
+	if filter.ClientIP != "" && s.ClientIP != filter.ClientIP {
+		return false
+	}
+	if filter.NodeID != "" && s.NodeID != filter.NodeID {
+		return false
+	}
+	if filter.After != nil && s.StartedAt.Before(*filter.After) {
+		return false
+	}
+	if filter.Before != nil && s.StartedAt.After(*filter.Before) {
+		return false
+	}
+	return true
+}
+
+// Ensure interface compliance
+var _ storage.SessionRepository = (*SessionRepository)(nil)
@@ -0,0 +1,230 @@
+// Package memory provides an in-memory implementation of the storage
+// interfaces, for unit tests and ephemeral runs.
+//
+// Everything is kept in process memory and lost when the store is closed
+// or the process exits, so the store must never hold data that matters.
+// Like SQLite it is non-authoritative. It follows the semantics of the
+// SQLite store: the same uniqueness constraints, soft deletes, revision
+// checks, orderings and errors, so tests written against it hold for the
+// real backends. Values are copied in and out: callers never share memory
+// with the store.
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// errClosed is returned by Ping once the store is closed.
+var errClosed = errors.New("memory store is closed")
+
+// Store implements the storage.Store interface in memory.
+// Memory stores are non-authoritative and cannot serve as trusted data sources.
+type Store struct {
+	nodeID string
+
+	topics           *TopicRepository
+	datasets         *DatasetRepository
+	jobs             *JobRepository
+	nodes            *NodeRepository
+	users            *UserRepository
+	sessions         *SessionRepository
+	audit            *AuditRepository
+	userPreferences  *UserPreferencesRepository
+	topicMembers     *TopicMemberRepository
+	topicInvitations *TopicInvitationRepository
+	bannedPeers      *BannedPeerRepository
+	queryHistory     *QueryHistoryRepository
+	savedQueries     *SavedQueryRepository
+	allowedPeers     *AllowedPeerRepository
+
+	writeMu sync.Mutex   // serializes writers, held by transactions throughout
+	mu      sync.RWMutex // guards data and closed
+	data    *data
+	closed  bool
+}
+
+// New creates a new, empty memory store.
+func New(nodeID string) *Store {
+	s := &Store{
+		nodeID: nodeID,
+		data:   newData(),
+	}
+
+	// Initialize repositories
+	s.topics = &TopicRepository{store: s}
+	s.datasets = &DatasetRepository{store: s}
+	s.jobs = &JobRepository{store: s}
+	s.nodes = &NodeRepository{store: s}
+	s.users = &UserRepository{store: s}
+	s.sessions = &SessionRepository{store: s}
+	s.audit = &AuditRepository{store: s, nodeID: nodeID}
+	s.userPreferences = &UserPreferencesRepository{store: s}
+	s.topicMembers = &TopicMemberRepository{store: s}
+	s.topicInvitations = &TopicInvitationRepository{store: s}
+	s.bannedPeers = &BannedPeerRepository{store: s}
+	s.queryHistory = &QueryHistoryRepository{store: s}
+	s.savedQueries = &SavedQueryRepository{store: s}
+	s.allowedPeers = &AllowedPeerRepository{store: s}
+
+	return s
+}
+
+// Close discards the data of the store.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.data = newData()
+
+	return nil
+}
+
+// Topics returns the topic repository.
+func (s *Store) Topics() storage.TopicRepository {
+	return s.topics
+}
+
+// Datasets returns the dataset repository.
+func (s *Store) Datasets() storage.DatasetRepository {
+	return s.datasets
+}
+
+// Jobs returns the job repository.
+func (s *Store) Jobs() storage.JobRepository {
+	return s.jobs
+}
+
+// Nodes returns the node repository.
+func (s *Store) Nodes() storage.NodeRepository {
+	return s.nodes
+}
+
+// Users returns the user repository.
+func (s *Store) Users() storage.UserRepository {
+	return s.users
+}
+
+// Sessions returns the session repository.
+func (s *Store) Sessions() storage.SessionRepository {
+	return s.sessions
+}
+
+// Audit returns the audit repository.
+func (s *Store) Audit() storage.AuditRepository {
+	return s.audit
+}
+
+// UserPreferences returns the user preferences repository.
+func (s *Store) UserPreferences() storage.UserPreferencesRepository {
+	return s.userPreferences
+}
+
+// TopicMembers returns the topic membership repository.
+func (s *Store) TopicMembers() storage.TopicMemberRepository {
+	return s.topicMembers
+}
+
+// TopicInvitations returns the topic invitations repository.
+func (s *Store) TopicInvitations() storage.TopicInvitationRepository {
+	return s.topicInvitations
+}
+
+// BannedPeers returns the banned peers repository.
+func (s *Store) BannedPeers() storage.BannedPeerRepository {
+	return s.bannedPeers
+}
+
+// QueryHistory returns the query history repository.
+func (s *Store) QueryHistory() storage.QueryHistoryRepository {
+	return s.queryHistory
+}
+
+// SavedQueries returns the saved queries repository.
+func (s *Store) SavedQueries() storage.SavedQueryRepository {
+	return s.savedQueries
+}
+
+// AllowedPeers returns the allowed peers repository.
+func (s *Store) AllowedPeers() storage.AllowedPeerRepository {
+	return s.allowedPeers
+}
+
+// Vacuum does nothing; there is nothing to reclaim.
+func (s *Store) Vacuum(ctx context.Context) error {
+	return nil
+}
+
+// Ping fails once the store is closed.
+func (s *Store) Ping(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return errClosed
+	}
+	return nil
+}
+
+// IsAuthoritative returns false for memory stores.
+// Data that is lost on restart cannot be an authoritative data source.
+func (s *Store) IsAuthoritative() bool {
+	return false
+}
+
+// Backend returns the storage backend type.
+func (s *Store) Backend() storage.BackendType {
+	return storage.BackendMemory
+}
+
+// Migrate does nothing; a memory store has no schema.
+func (s *Store) Migrate(ctx context.Context) error {
+	return nil
+}
+
+// Stats returns storage statistics for the memory store.
+func (s *Store) Stats(ctx context.Context) (storage.StorageStats, error) {
+	if err := s.Ping(ctx); err != nil {
+		return storage.StorageStats{
+			Healthy: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	stats := storage.StorageStats{
+		Healthy: true,
+		Message: "in-memory storage; data is lost on restart",
+	}
+	s.view(ctx, func(d *data) {
+		for _, t := range d.topics {
+			if t.Status != domain.TopicStatusDeleted {
+				stats.TopicCount++
+			}
+		}
+		for _, ds := range d.datasets {
+			if ds.Status != domain.DatasetStatusDeleted {
+				stats.DatasetCount++
+			}
+		}
+	})
+
+	return stats, nil
+}
+
+// init registers the memory store factory with the storage package.
+func init() {
+	storage.OpenMemory = func(ctx context.Context, dataDir, nodeID string) (storage.Store, error) {
+		return New(nodeID), nil
+	}
+}
+
+// Ensure interface compliance
+var _ storage.Store = (*Store)(nil)
@@ -0,0 +1,406 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+	"bib/internal/storage/storagetest"
+)
+
+func testTopic(id string) *domain.Topic {
+	return &domain.Topic{
+		ID:        domain.TopicID(id),
+		Name:      "Topic " + id,
+		Status:    domain.TopicStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		Tags:      []string{"weather"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+}
+
+func testDataset(id string, topic domain.TopicID) *domain.Dataset {
+	return &domain.Dataset{
+		ID:        domain.DatasetID(id),
+		TopicID:   topic,
+		Name:      "Dataset " + id,
+		Status:    domain.DatasetStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+}
+
+func testContext() context.Context {
+	return storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+}
+
+func TestOpen(t *testing.T) {
+	cfg := storage.DefaultConfig()
+	cfg.Backend = storage.BackendMemory
+
+	store, err := storage.Open(context.Background(), cfg, t.TempDir(), "test-node-id", "selective")
+	if err != nil {
+		t.Fatalf("failed to open memory store: %v", err)
+	}
+	defer store.Close()
+
+	if store.IsAuthoritative() {
+		t.Error("memory store should not be authoritative")
+	}
+	if store.Backend() != storage.BackendMemory {
+		t.Errorf("expected backend memory, got %s", store.Backend())
+	}
+	if err := store.Ping(context.Background()); err != nil {
+		t.Errorf("ping failed: %v", err)
+	}
+
+	// A memory store cannot be a full replica
+	var modeErr *storage.ModeBackendError
+	if _, err := storage.Open(context.Background(), cfg, t.TempDir(), "test-node-id", "full"); !errors.As(err, &modeErr) {
+		t.Errorf("expected a mode/backend error for full mode, got %v", err)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Store {
+		store := New("test-node-id")
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
+func TestTopicRepository_CRUD(t *testing.T) {
+	store := New("test-node-id")
+	defer store.Close()
+
+	ctx := testContext()
+	repo := store.Topics()
+
+	topic := testTopic("topic-1")
+	if err := repo.Create(ctx, topic); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	if err := repo.Create(ctx, testTopic("topic-1")); !errors.Is(err, storage.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate ID, got %v", err)
+	}
+
+	// Values are copied in and out of the store
+	topic.Tags[0] = "changed"
+	got, err := repo.Get(ctx, topic.ID)
+	if err != nil {
+		t.Fatalf("failed to get topic: %v", err)
+	}
+	if got.Tags[0] != "weather" {
+		t.Errorf("expected the stored topic to be unaffected by the caller, got %v", got.Tags)
+	}
+	got.Owners[0] = "user-2"
+	if again, _ := repo.Get(ctx, topic.ID); again.Owners[0] != "user-1" {
+		t.Errorf("expected the stored topic to be unaffected by readers, got %v", again.Owners)
+	}
+
+	if _, err := repo.GetByName(ctx, topic.Name); err != nil {
+		t.Fatalf("failed to get topic by name: %v", err)
+	}
+	if _, err := repo.Get(ctx, "missing"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found, got %v", err)
+	}
+
+	if err := repo.Create(ctx, testTopic("topic-2")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	topics, err := repo.List(ctx, storage.TopicFilter{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("failed to list topics: %v", err)
+	}
+	if len(topics) != 1 || topics[0].ID != "topic-2" {
+		t.Errorf("expected the second page to hold topic-2, got %+v", topics)
+	}
+
+	if err := repo.Delete(ctx, topic.ID, 0); err != nil {
+		t.Fatalf("failed to delete topic: %v", err)
+	}
+	got, _ = repo.Get(ctx, topic.ID)
+	if got.Status != domain.TopicStatusDeleted {
+		t.Errorf("expected status deleted, got %s", got.Status)
+	}
+	if count, _ := repo.Count(ctx, storage.TopicFilter{Status: domain.TopicStatusActive}); count != 1 {
+		t.Errorf("expected 1 active topic, got %d", count)
+	}
+}
+
+func TestTopicRepository_UpdateRevision(t *testing.T) {
+	store := New("test-node-id")
+	defer store.Close()
+
+	ctx := testContext()
+	repo := store.Topics()
+	if err := repo.Create(ctx, testTopic("topic-1")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	first, _ := repo.Get(ctx, "topic-1")
+	second, _ := repo.Get(ctx, "topic-1")
+
+	first.Description = "first"
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("failed to update topic: %v", err)
+	}
+	if first.Revision != 2 {
+		t.Errorf("expected the update to bump the revision to 2, got %d", first.Revision)
+	}
+
+	second.Description = "second"
+	if err := repo.Update(ctx, second); !storage.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if got, _ := repo.Get(ctx, "topic-1"); got.Description != "first" || got.Revision != 2 {
+		t.Errorf("expected the first update to stand, got %q at revision %d", got.Description, got.Revision)
+	}
+}
+
+func TestStore_WithTx(t *testing.T) {
+	store := New("test-node-id")
+	defer store.Close()
+
+	ctx := testContext()
+	failure := errors.New("attach failed")
+
+	err := store.WithTx(ctx, func(ctx context.Context) error {
+		if err := store.Topics().Create(ctx, testTopic("topic-1")); err != nil {
+			return err
+		}
+		// A nested call joins the outer transaction
+		return store.WithTx(ctx, func(ctx context.Context) error {
+			if _, err := store.Topics().Get(ctx, "topic-1"); err != nil {
+				return err
+			}
+			return store.Datasets().Create(ctx, testDataset("dataset-1", "topic-1"))
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+	if _, err := store.Datasets().Get(ctx, "dataset-1"); err != nil {
+		t.Errorf("expected committed dataset: %v", err)
+	}
+
+	err = store.WithTx(ctx, func(ctx context.Context) error {
+		if err := store.Topics().Create(ctx, testTopic("topic-2")); err != nil {
+			return err
+		}
+		// Readers outside the transaction don't see its writes
+		if _, err := store.Topics().Get(context.Background(), "topic-2"); !storage.IsNotFound(err) {
+			t.Errorf("expected the uncommitted topic to be invisible, got %v", err)
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+	if _, err := store.Topics().Get(ctx, "topic-2"); !storage.IsNotFound(err) {
+		t.Errorf("expected the topic to be rolled back, got %v", err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		_ = store.WithTx(ctx, func(ctx context.Context) error {
+			if err := store.Topics().Create(ctx, testTopic("topic-3")); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+	if _, err := store.Topics().Get(ctx, "topic-3"); !storage.IsNotFound(err) {
+		t.Errorf("expected the topic to be rolled back, got %v", err)
+	}
+
+	// The store keeps working after a rollback
+	if err := store.Topics().Create(ctx, testTopic("topic-4")); err != nil {
+		t.Errorf("failed to create topic after rollback: %v", err)
+	}
+}
+
+func TestDatasetRepository_DeleteVersion(t *testing.T) {
+	store := New("test-node-id")
+	defer store.Close()
+
+	ctx := testContext()
+	if err := store.Topics().Create(ctx, testTopic("topic-1")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	repo := store.Datasets()
+	if err := repo.Create(ctx, testDataset("dataset-1", "topic-1")); err != nil {
+		t.Fatalf("failed to create dataset: %v", err)
+	}
+
+	// Three versions chained v1 <- v2 <- v3
+	var previous domain.DatasetVersionID
+	for n := 1; n <= 3; n++ {
+		id := domain.DatasetVersionID(fmt.Sprintf("v%d", n))
+		if err := repo.CreateVersion(ctx, &domain.DatasetVersion{
+			ID:                id,
+			DatasetID:         "dataset-1",
+			Version:           domain.VersionString(n),
+			PreviousVersionID: previous,
+			Content:           &domain.DatasetContent{Hash: "hash", Size: 1, ChunkCount: 1},
+			CreatedBy:         "user-1",
+			CreatedAt:         time.Now().UTC().Add(time.Duration(n) * time.Second),
+		}); err != nil {
+			t.Fatalf("failed to create version %d: %v", n, err)
+		}
+		previous = id
+	}
+	if err := repo.CreateChunk(ctx, &domain.Chunk{ID: "c1", DatasetID: "dataset-1", VersionID: "v2", Hash: "hash", Size: 1, Status: domain.ChunkStatusVerified}); err != nil {
+		t.Fatalf("failed to create chunk: %v", err)
+	}
+
+	if err := repo.DeleteVersion(ctx, "dataset-1", "v2"); err != nil {
+		t.Fatalf("failed to delete version: %v", err)
+	}
+
+	got, err := repo.GetVersion(ctx, "dataset-1", "v3")
+	if err != nil {
+		t.Fatalf("failed to get version: %v", err)
+	}
+	if got.PreviousVersionID != "v1" {
+		t.Errorf("expected v3 to be relinked to v1, got %q", got.PreviousVersionID)
+	}
+	if chunks, _ := repo.ListChunks(ctx, "v2"); len(chunks) != 0 {
+		t.Errorf("expected the chunks of v2 to be deleted, got %d", len(chunks))
+	}
+	if latest, _ := repo.GetLatestVersion(ctx, "dataset-1"); latest == nil || latest.ID != "v3" {
+		t.Errorf("expected v3 to be the latest version, got %+v", latest)
+	}
+}
+
+func TestUserRepository_PublicKeys(t *testing.T) {
+	store := New("test-node-id")
+	defer store.Close()
+
+	ctx := testContext()
+	alice := domain.NewUser(bytes.Repeat([]byte{1}, 32), domain.KeyTypeEd25519, "alice", "", false)
+	bob := domain.NewUser(bytes.Repeat([]byte{2}, 32), domain.KeyTypeEd25519, "bob", "", false)
+	for _, u := range []*domain.User{alice, bob} {
+		if err := store.Users().Create(ctx, u); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+	if err := store.Users().Create(ctx, alice); !errors.Is(err, domain.ErrUserExists) {
+		t.Errorf("expected ErrUserExists for a duplicate user, got %v", err)
+	}
+
+	repo := store.Users()
+	laptop := domain.NewUserPublicKey(alice.ID, bytes.Repeat([]byte{3}, 32), domain.KeyTypeEd25519, "laptop")
+	if err := repo.AddPublicKey(ctx, laptop); err != nil {
+		t.Fatalf("failed to add public key: %v", err)
+	}
+	if err := repo.AddPublicKey(ctx, domain.NewUserPublicKey(alice.ID, bytes.Repeat([]byte{4}, 32), domain.KeyTypeEd25519, "laptop")); !errors.Is(err, domain.ErrKeyExists) {
+		t.Errorf("expected ErrKeyExists for a duplicate label, got %v", err)
+	}
+
+	got, err := repo.GetByPublicKey(ctx, laptop.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to get user by additional key: %v", err)
+	}
+	if got.ID != alice.ID {
+		t.Errorf("expected alice, got %s", got.ID)
+	}
+
+	if err := repo.RemovePublicKey(ctx, bob.ID, laptop.Fingerprint); !errors.Is(err, domain.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound removing another user's key, got %v", err)
+	}
+	if err := repo.RemovePublicKey(ctx, alice.ID, laptop.Fingerprint); err != nil {
+		t.Fatalf("failed to remove public key: %v", err)
+	}
+	if _, err := repo.GetByPublicKey(ctx, laptop.PublicKey); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("expected removed key to no longer authenticate, got %v", err)
+	}
+}
+
+func TestSavedQueryRepository(t *testing.T) {
+	store := New("test-node-id")
+	defer store.Close()
+
+	ctx := testContext()
+	repo := store.SavedQueries()
+	now := time.Now().UTC()
+
+	private := &storage.SavedQuery{ID: "q-1", UserID: "alice", Name: "hot-days", Query: "temp > ${min}", Tags: []string{"weather"}, CreatedAt: now, UpdatedAt: now}
+	shared := &storage.SavedQuery{ID: "q-2", UserID: "bob", Name: "cold-days", Query: "temp < 0", IsPublic: true, CreatedAt: now, UpdatedAt: now}
+	for _, q := range []*storage.SavedQuery{private, shared} {
+		if err := repo.Create(ctx, q); err != nil {
+			t.Fatalf("failed to create saved query: %v", err)
+		}
+	}
+
+	dup := *private
+	dup.ID = "q-3"
+	if err := repo.Create(ctx, &dup); !errors.Is(err, storage.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate name, got %v", err)
+	}
+
+	alice := domain.UserID("alice")
+	if own, _ := repo.List(ctx, storage.SavedQueryFilter{UserID: &alice}); len(own) != 1 {
+		t.Errorf("expected 1 own query, got %d", len(own))
+	}
+	if visible, _ := repo.List(ctx, storage.SavedQueryFilter{UserID: &alice, IncludePublic: true}); len(visible) != 2 {
+		t.Errorf("expected own and public queries, got %d", len(visible))
+	}
+
+	if err := repo.IncrementUseCount(ctx, private.ID); err != nil {
+		t.Fatalf("failed to increment use count: %v", err)
+	}
+	private.Query = "temp >= ${min}"
+	if err := repo.Update(ctx, private); err != nil {
+		t.Fatalf("failed to update saved query: %v", err)
+	}
+	got, _ := repo.Get(ctx, private.ID)
+	if got.Query != private.Query || got.UseCount != 1 {
+		t.Errorf("expected updated query keeping its use count, got %+v", got)
+	}
+}
+
+func TestAuditRepository_HashChain(t *testing.T) {
+	store := New("test-node-id")
+	defer store.Close()
+
+	ctx := testContext()
+	repo := store.Audit()
+	for i := 0; i < 3; i++ {
+		if err := repo.Log(ctx, &storage.AuditEntry{OperationID: "op-1", Action: "INSERT", TableName: "topics"}); err != nil {
+			t.Fatalf("failed to log entry: %v", err)
+		}
+	}
+
+	entries, err := repo.GetByOperationID(ctx, "op-1")
+	if err != nil {
+		t.Fatalf("failed to query entries: %v", err)
+	}
+	if len(entries) != 3 || entries[0].ID != 3 {
+		t.Fatalf("expected 3 entries newest first, got %+v", entries)
+	}
+	if entries[0].PrevHash != entries[1].EntryHash || entries[0].NodeID != "test-node-id" {
+		t.Errorf("expected entries to be chained, got %+v", entries[0])
+	}
+	if ok, _ := repo.VerifyChain(ctx, 1, 3); !ok {
+		t.Error("expected a valid chain")
+	}
+	if hash, _ := repo.GetLastHash(ctx); hash != entries[0].EntryHash {
+		t.Errorf("expected last hash %s, got %s", entries[0].EntryHash, hash)
+	}
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// TopicInvitationRepository implements storage.TopicInvitationRepository
+// in memory. Like the SQLite store it reports a missing invitation as
+// domain.ErrOwnerNotFound.
+type TopicInvitationRepository struct {
+	store *Store
+}
+
+// Create creates a new invitation. Tokens are unique.
+func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.TopicInvitation) error {
+	if inv.Kind == "" {
+		inv.Kind = storage.InvitationKindInvite
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.invitations[inv.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+		for _, i := range d.invitations {
+			if i.Token == inv.Token {
+				return storage.ErrAlreadyExists
+			}
+		}
+
+		d.invitations[inv.ID] = clone(inv)
+		return nil
+	})
+}
+
+// Get retrieves an invitation by ID.
+func (r *TopicInvitationRepository) Get(ctx context.Context, id string) (*storage.TopicInvitation, error) {
+	return r.find(ctx, func(i *storage.TopicInvitation) bool { return i.ID == id })
+}
+
+// GetByToken retrieves an invitation by token.
+func (r *TopicInvitationRepository) GetByToken(ctx context.Context, token string) (*storage.TopicInvitation, error) {
+	return r.find(ctx, func(i *storage.TopicInvitation) bool { return i.Token == token })
+}
+
+func (r *TopicInvitationRepository) find(ctx context.Context, match func(i *storage.TopicInvitation) bool) (*storage.TopicInvitation, error) {
+	var inv *storage.TopicInvitation
+	r.store.view(ctx, func(d *data) {
+		for _, i := range d.invitations {
+			if match(i) {
+				inv = clone(i)
+				return
+			}
+		}
+	})
+	if inv == nil {
+		return nil, domain.ErrOwnerNotFound
+	}
+	return inv, nil
+}
+
+// ListByTopic lists invitations for a topic, newest first.
+func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID domain.TopicID, filter storage.InvitationFilter) ([]*storage.TopicInvitation, error) {
+	return r.list(ctx, filter.Offset, filter.Limit, func(i *storage.TopicInvitation) bool {
+		return i.TopicID == topicID &&
+			(filter.Status == "" || i.Status == filter.Status) &&
+			(filter.Kind == "" || i.Kind == filter.Kind)
+	}), nil
+}
+
+// ListByUser lists invitations for a user (as invitee), newest first.
+func (r *TopicInvitationRepository) ListByUser(ctx context.Context, userID domain.UserID) ([]*storage.TopicInvitation, error) {
+	return r.list(ctx, 0, 0, func(i *storage.TopicInvitation) bool {
+		return i.InviteeUserID != "" && i.InviteeUserID == userID
+	}), nil
+}
+
+// ListByEmail lists invitations for an email address, newest first.
+func (r *TopicInvitationRepository) ListByEmail(ctx context.Context, email string) ([]*storage.TopicInvitation, error) {
+	return r.list(ctx, 0, 0, func(i *storage.TopicInvitation) bool {
+		return i.InviteeEmail != "" && i.InviteeEmail == email
+	}), nil
+}
+
+func (r *TopicInvitationRepository) list(ctx context.Context, offset, limit int, match func(i *storage.TopicInvitation) bool) []*storage.TopicInvitation {
+	var invitations []*storage.TopicInvitation
+	r.store.view(ctx, func(d *data) {
+		for _, i := range d.invitations {
+			if match(i) {
+				invitations = append(invitations, i)
+			}
+		}
+	})
+
+	sortBy(invitations, true, func(a, b *storage.TopicInvitation) bool { return a.CreatedAt.Before(b.CreatedAt) })
+	return cloneAll(page(invitations, offset, limit))
+}
+
+// Update updates the status and response of an invitation. Like the
+// SQLite store it ignores a missing invitation.
+func (r *TopicInvitationRepository) Update(ctx context.Context, inv *storage.TopicInvitation) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.invitations[inv.ID]
+		if !ok {
+			return nil
+		}
+
+		updated := clone(stored)
+		updated.Status = inv.Status
+		updated.RespondedAt = clone(inv.RespondedAt)
+		updated.RespondedBy = inv.RespondedBy
+		d.invitations[inv.ID] = updated
+		return nil
+	})
+}
+
+// Delete removes an invitation.
+func (r *TopicInvitationRepository) Delete(ctx context.Context, id string) error {
+	return r.store.update(ctx, func(d *data) error {
+		delete(d.invitations, id)
+		return nil
+	})
+}
+
+// ExpirePending expires all pending invitations that have passed their expiration.
+func (r *TopicInvitationRepository) ExpirePending(ctx context.Context) (int64, error) {
+	now := time.Now().UTC()
+
+	var count int64
+	err := r.store.update(ctx, func(d *data) error {
+		for id, i := range d.invitations {
+			if i.Status == storage.InvitationStatusPending && i.ExpiresAt.Before(now) {
+				expired := clone(i)
+				expired.Status = storage.InvitationStatusExpired
+				d.invitations[id] = expired
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Ensure interface compliance
+var _ storage.TopicInvitationRepository = (*TopicInvitationRepository)(nil)
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// TopicMemberRepository implements storage.TopicMemberRepository in memory.
+// Like the SQLite store it reports a missing membership as
+// domain.ErrNotOwner.
+type TopicMemberRepository struct {
+	store *Store
+}
+
+// Create creates a new membership. A user is a member of a topic once.
+func (r *TopicMemberRepository) Create(ctx context.Context, member *storage.TopicMember) error {
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.members[member.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+		if findMember(d, member.TopicID, member.UserID) != nil {
+			return storage.ErrAlreadyExists
+		}
+
+		d.members[member.ID] = clone(member)
+		return nil
+	})
+}
+
+func findMember(d *data, topicID domain.TopicID, userID domain.UserID) *storage.TopicMember {
+	for _, m := range d.members {
+		if m.TopicID == topicID && m.UserID == userID {
+			return m
+		}
+	}
+	return nil
+}
+
+// Get retrieves a membership by topic and user.
+func (r *TopicMemberRepository) Get(ctx context.Context, topicID domain.TopicID, userID domain.UserID) (*storage.TopicMember, error) {
+	var member *storage.TopicMember
+	r.store.view(ctx, func(d *data) {
+		member = clone(findMember(d, topicID, userID))
+	})
+	if member == nil {
+		return nil, domain.ErrNotOwner
+	}
+	return member, nil
+}
+
+// GetByID retrieves a membership by ID.
+func (r *TopicMemberRepository) GetByID(ctx context.Context, id string) (*storage.TopicMember, error) {
+	var member *storage.TopicMember
+	r.store.view(ctx, func(d *data) {
+		member = clone(d.members[id])
+	})
+	if member == nil {
+		return nil, domain.ErrNotOwner
+	}
+	return member, nil
+}
+
+// ListByTopic lists all members of a topic, in the order they joined.
+func (r *TopicMemberRepository) ListByTopic(ctx context.Context, topicID domain.TopicID, filter storage.TopicMemberFilter) ([]*storage.TopicMember, error) {
+	return r.list(ctx, filter.Offset, filter.Limit, func(m *storage.TopicMember) bool {
+		return m.TopicID == topicID && (filter.Role == "" || m.Role == filter.Role)
+	}), nil
+}
+
+// ListByUser lists all topic memberships for a user, in the order they
+// were created.
+func (r *TopicMemberRepository) ListByUser(ctx context.Context, userID domain.UserID) ([]*storage.TopicMember, error) {
+	return r.list(ctx, 0, 0, func(m *storage.TopicMember) bool {
+		return m.UserID == userID
+	}), nil
+}
+
+func (r *TopicMemberRepository) list(ctx context.Context, offset, limit int, match func(m *storage.TopicMember) bool) []*storage.TopicMember {
+	var members []*storage.TopicMember
+	r.store.view(ctx, func(d *data) {
+		for _, m := range d.members {
+			if match(m) {
+				members = append(members, m)
+			}
+		}
+	})
+
+	sortBy(members, false, func(a, b *storage.TopicMember) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return cloneAll(page(members, offset, limit))
+}
+
+// Update updates the role and acceptance of a membership. Like the SQLite
+// store it ignores a missing membership.
+func (r *TopicMemberRepository) Update(ctx context.Context, member *storage.TopicMember) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.members[member.ID]
+		if !ok {
+			return nil
+		}
+
+		updated := clone(stored)
+		updated.Role = member.Role
+		updated.AcceptedAt = clone(member.AcceptedAt)
+		updated.UpdatedAt = time.Now().UTC()
+		d.members[member.ID] = updated
+		return nil
+	})
+}
+
+// Delete removes a membership.
+func (r *TopicMemberRepository) Delete(ctx context.Context, topicID domain.TopicID, userID domain.UserID) error {
+	return r.store.update(ctx, func(d *data) error {
+		if m := findMember(d, topicID, userID); m != nil {
+			delete(d.members, m.ID)
+		}
+		return nil
+	})
+}
+
+// CountOwners counts the number of owners for a topic.
+func (r *TopicMemberRepository) CountOwners(ctx context.Context, topicID domain.TopicID) (int, error) {
+	var count int
+	r.store.view(ctx, func(d *data) {
+		for _, m := range d.members {
+			if m.TopicID == topicID && m.Role == storage.TopicMemberRoleOwner {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// HasAccess checks if a user is a member of a topic.
+func (r *TopicMemberRepository) HasAccess(ctx context.Context, topicID domain.TopicID, userID domain.UserID) (bool, error) {
+	var ok bool
+	r.store.view(ctx, func(d *data) {
+		ok = findMember(d, topicID, userID) != nil
+	})
+	return ok, nil
+}
+
+// GetRole gets the role of a user in a topic.
+func (r *TopicMemberRepository) GetRole(ctx context.Context, topicID domain.TopicID, userID domain.UserID) (storage.TopicMemberRole, error) {
+	member, err := r.Get(ctx, topicID, userID)
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// Ensure interface compliance
+var _ storage.TopicMemberRepository = (*TopicMemberRepository)(nil)
@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// TopicRepository implements storage.TopicRepository in memory.
+type TopicRepository struct {
+	store *Store
+}
+
+// Create creates a new topic. Topic names are unique.
+func (r *TopicRepository) Create(ctx context.Context, topic *domain.Topic) error {
+	if err := topic.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.topics[topic.ID]; ok {
+			return storage.ErrAlreadyExists
+		}
+		if findTopicByName(d, topic.Name) != nil {
+			return storage.ErrAlreadyExists
+		}
+
+		topic.Revision = 1
+		d.topics[topic.ID] = clone(topic)
+		return nil
+	})
+}
+
+// Get retrieves a topic by ID.
+func (r *TopicRepository) Get(ctx context.Context, id domain.TopicID) (*domain.Topic, error) {
+	var topic *domain.Topic
+	r.store.view(ctx, func(d *data) {
+		topic = clone(d.topics[id])
+	})
+	if topic == nil {
+		return nil, storage.ErrNotFound
+	}
+	return topic, nil
+}
+
+// GetByName retrieves a topic by name.
+func (r *TopicRepository) GetByName(ctx context.Context, name string) (*domain.Topic, error) {
+	var topic *domain.Topic
+	r.store.view(ctx, func(d *data) {
+		topic = clone(findTopicByName(d, name))
+	})
+	if topic == nil {
+		return nil, storage.ErrNotFound
+	}
+	return topic, nil
+}
+
+func findTopicByName(d *data, name string) *domain.Topic {
+	for _, t := range d.topics {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// List retrieves topics matching the filter.
+func (r *TopicRepository) List(ctx context.Context, filter storage.TopicFilter) ([]*domain.Topic, error) {
+	var topics []*domain.Topic
+	r.store.view(ctx, func(d *data) {
+		for _, t := range d.topics {
+			if matchTopic(t, filter) {
+				topics = append(topics, t)
+			}
+		}
+	})
+
+	sortBy(topics, filter.OrderDesc, topicLess(filter.OrderBy))
+	return cloneAll(page(topics, filter.Offset, filter.Limit)), nil
+}
+
+// Update updates an existing topic. Unless topic.Revision is 0, the update
+// only applies if the stored topic is still at that revision, and fails with
+// storage.ErrConflict otherwise. topic.Revision is set to the new revision.
+func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error {
+	if err := topic.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.topics[topic.ID]
+		if !ok {
+			return storage.ErrNotFound
+		}
+		if topic.Revision != 0 && topic.Revision != stored.Revision {
+			return storage.ErrConflict
+		}
+		if other := findTopicByName(d, topic.Name); other != nil && other.ID != topic.ID {
+			return storage.ErrAlreadyExists
+		}
+
+		updated := clone(topic)
+		updated.CreatedBy, updated.CreatedAt = stored.CreatedBy, stored.CreatedAt
+		updated.UpdatedAt = time.Now().UTC()
+		updated.Revision = stored.Revision + 1
+		d.topics[topic.ID] = updated
+		topic.Revision = updated.Revision
+		return nil
+	})
+}
+
+// Delete soft-deletes a topic. Unless revision is 0, the delete only
+// applies if the stored topic is still at that revision, and fails with
+// storage.ErrConflict otherwise.
+func (r *TopicRepository) Delete(ctx context.Context, id domain.TopicID, revision int64) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.topics[id]
+		if !ok {
+			return storage.ErrNotFound
+		}
+		if revision != 0 && revision != stored.Revision {
+			return storage.ErrConflict
+		}
+
+		deleted := clone(stored)
+		deleted.Status = domain.TopicStatusDeleted
+		deleted.UpdatedAt = time.Now().UTC()
+		deleted.Revision++
+		d.topics[id] = deleted
+		return nil
+	})
+}
+
+// Count returns the number of topics matching the filter.
+func (r *TopicRepository) Count(ctx context.Context, filter storage.TopicFilter) (int64, error) {
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, t := range d.topics {
+			if matchTopic(t, filter) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// matchTopic reports whether t matches the filter.
+func matchTopic(t *domain.Topic, filter storage.TopicFilter) bool {
+	if filter.Status != "" && t.Status != filter.Status {
+		return false
+	}
+	if filter.ParentID != nil && t.ParentID != *filter.ParentID {
+		return false
+	}
+	if filter.OwnerID != nil && !slices.Contains(t.Owners, *filter.OwnerID) {
+		return false
+	}
+	if filter.Search != "" && !like(t.Name, filter.Search) && !like(t.Description, filter.Search) {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(t.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// topicLess orders topics by the given column, by name by default.
+func topicLess(orderBy string) func(a, b *domain.Topic) bool {
+	switch orderBy {
+	case "created_at":
+		return func(a, b *domain.Topic) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case "updated_at":
+		return func(a, b *domain.Topic) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case "dataset_count":
+		return func(a, b *domain.Topic) bool { return a.DatasetCount < b.DatasetCount }
+	default:
+		return func(a, b *domain.Topic) bool { return a.Name < b.Name }
+	}
+}
+
+// Ensure interface compliance
+var _ storage.TopicRepository = (*TopicRepository)(nil)
@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// data holds the content of a store. The values in its maps are private
+// copies that are replaced, never modified, so that a shallow copy of the
+// maps is a snapshot of the store.
+type data struct {
+	topics       map[domain.TopicID]*domain.Topic
+	datasets     map[domain.DatasetID]*domain.Dataset
+	versions     map[domain.DatasetVersionID]*domain.DatasetVersion
+	chunks       map[domain.ChunkID]*domain.Chunk
+	jobs         map[domain.JobID]*domain.Job
+	jobResults   map[string]*domain.JobResult
+	nodes        map[string]*storage.NodeInfo
+	users        map[domain.UserID]*domain.User
+	userKeys     map[string]*domain.UserPublicKey // by fingerprint
+	sessions     map[string]*storage.Session
+	preferences  map[domain.UserID]*storage.UserPreferences
+	members      map[string]*storage.TopicMember
+	invitations  map[string]*storage.TopicInvitation
+	bannedPeers  map[string]*storage.BannedPeer
+	savedQueries map[string]*storage.SavedQuery
+	allowedPeers map[string]*storage.AllowedPeer
+
+	history  []*storage.SavedQuery // oldest first
+	audit    []*storage.AuditEntry // by ID
+	auditSeq int64                 // ID of the last audit entry
+	lastHash string                // head of the audit hash chain
+}
+
+func newData() *data {
+	return &data{
+		topics:       make(map[domain.TopicID]*domain.Topic),
+		datasets:     make(map[domain.DatasetID]*domain.Dataset),
+		versions:     make(map[domain.DatasetVersionID]*domain.DatasetVersion),
+		chunks:       make(map[domain.ChunkID]*domain.Chunk),
+		jobs:         make(map[domain.JobID]*domain.Job),
+		jobResults:   make(map[string]*domain.JobResult),
+		nodes:        make(map[string]*storage.NodeInfo),
+		users:        make(map[domain.UserID]*domain.User),
+		userKeys:     make(map[string]*domain.UserPublicKey),
+		sessions:     make(map[string]*storage.Session),
+		preferences:  make(map[domain.UserID]*storage.UserPreferences),
+		members:      make(map[string]*storage.TopicMember),
+		invitations:  make(map[string]*storage.TopicInvitation),
+		bannedPeers:  make(map[string]*storage.BannedPeer),
+		savedQueries: make(map[string]*storage.SavedQuery),
+		allowedPeers: make(map[string]*storage.AllowedPeer),
+	}
+}
+
+// snapshot returns a copy of d that can be changed without changing d.
+func (d *data) snapshot() *data {
+	return &data{
+		topics:       maps.Clone(d.topics),
+		datasets:     maps.Clone(d.datasets),
+		versions:     maps.Clone(d.versions),
+		chunks:       maps.Clone(d.chunks),
+		jobs:         maps.Clone(d.jobs),
+		jobResults:   maps.Clone(d.jobResults),
+		nodes:        maps.Clone(d.nodes),
+		users:        maps.Clone(d.users),
+		userKeys:     maps.Clone(d.userKeys),
+		sessions:     maps.Clone(d.sessions),
+		preferences:  maps.Clone(d.preferences),
+		members:      maps.Clone(d.members),
+		invitations:  maps.Clone(d.invitations),
+		bannedPeers:  maps.Clone(d.bannedPeers),
+		savedQueries: maps.Clone(d.savedQueries),
+		allowedPeers: maps.Clone(d.allowedPeers),
+		// Clipped, so that appending to the copy reallocates
+		history:  slices.Clip(d.history),
+		audit:    slices.Clip(d.audit),
+		auditSeq: d.auditSeq,
+		lastHash: d.lastHash,
+	}
+}
+
+// txKey is the context key of the transaction WithTx runs fn in.
+type txKey struct{}
+
+// tx is a transaction of a store, working on a snapshot of its data.
+type tx struct {
+	store *Store
+
+	mu   sync.RWMutex // fn may use the transaction from several goroutines
+	data *data
+}
+
+// txFrom returns the transaction of this store in ctx, or nil.
+func (s *Store) txFrom(ctx context.Context) *tx {
+	if t, ok := ctx.Value(txKey{}).(*tx); ok && t.store == s {
+		return t
+	}
+	return nil
+}
+
+// view calls fn with the data visible to ctx: that of its transaction, or
+// the committed data. fn must not change d.
+func (s *Store) view(ctx context.Context, fn func(d *data)) {
+	if t := s.txFrom(ctx); t != nil {
+		t.mu.RLock()
+		defer t.mu.RUnlock()
+		fn(t.data)
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.data)
+}
+
+// update calls fn to change the data visible to ctx. Outside of a
+// transaction it waits for the running transaction, if any, to end. fn must
+// check everything that can fail before it changes d, so that a failed
+// update changes nothing.
+func (s *Store) update(ctx context.Context, fn func(d *data) error) error {
+	if t := s.txFrom(ctx); t != nil {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return fn(t.data)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+// WithTx runs fn in a transaction. Transactions are serialized: like the
+// write lock of SQLite, a transaction holds off other transactions and
+// writes made outside of it until it ends, while reads outside of it see
+// the data as it was before. fn works on a snapshot of the data that
+// replaces the data when fn returns nil and is discarded otherwise.
+func (s *Store) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.txFrom(ctx) != nil {
+		return fn(ctx)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.RLock()
+	t := &tx{store: s, data: s.data.snapshot()}
+	s.mu.RUnlock()
+
+	// A panic in fn skips the commit and discards the snapshot
+	if err := fn(context.WithValue(ctx, txKey{}, t)); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = t.data
+	return nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// UserPreferencesRepository implements storage.UserPreferencesRepository in memory.
+type UserPreferencesRepository struct {
+	store *Store
+}
+
+// Get retrieves preferences for a user, or the defaults if the user has
+// none.
+func (r *UserPreferencesRepository) Get(ctx context.Context, userID domain.UserID) (*storage.UserPreferences, error) {
+	var prefs *storage.UserPreferences
+	r.store.view(ctx, func(d *data) {
+		prefs = clone(d.preferences[userID])
+	})
+	if prefs != nil {
+		return prefs, nil
+	}
+
+	now := time.Now().UTC()
+	return &storage.UserPreferences{
+		UserID:               userID,
+		Theme:                "system",
+		Locale:               "en",
+		Timezone:             "UTC",
+		DateFormat:           "YYYY-MM-DD",
+		NotificationsEnabled: true,
+		EmailNotifications:   false,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}, nil
+}
+
+// Upsert creates or updates preferences for a user.
+func (r *UserPreferencesRepository) Upsert(ctx context.Context, prefs *storage.UserPreferences) error {
+	now := time.Now().UTC()
+
+	return r.store.update(ctx, func(d *data) error {
+		stored := clone(prefs)
+		stored.CreatedAt = now
+		if existing, ok := d.preferences[prefs.UserID]; ok {
+			stored.CreatedAt = existing.CreatedAt
+		}
+		stored.UpdatedAt = now
+
+		d.preferences[prefs.UserID] = stored
+		return nil
+	})
+}
+
+// Delete removes preferences for a user.
+func (r *UserPreferencesRepository) Delete(ctx context.Context, userID domain.UserID) error {
+	return r.store.update(ctx, func(d *data) error {
+		delete(d.preferences, userID)
+		return nil
+	})
+}
+
+// Ensure interface compliance
+var _ storage.UserPreferencesRepository = (*UserPreferencesRepository)(nil)
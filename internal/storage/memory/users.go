@@ -0,0 +1,273 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// UserRepository implements storage.UserRepository in memory.
+type UserRepository struct {
+	store *Store
+}
+
+// Create creates a new user. Public keys, their fingerprints and email
+// addresses are unique.
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		if _, ok := d.users[user.ID]; ok {
+			return domain.ErrUserExists
+		}
+		for _, u := range d.users {
+			if bytes.Equal(u.PublicKey, user.PublicKey) ||
+				u.PublicKeyFingerprint == user.PublicKeyFingerprint ||
+				(user.Email != "" && u.Email == user.Email) {
+				return domain.ErrUserExists
+			}
+		}
+
+		d.users[user.ID] = clone(user)
+		return nil
+	})
+}
+
+// Get retrieves a user by ID.
+func (r *UserRepository) Get(ctx context.Context, id domain.UserID) (*domain.User, error) {
+	return r.find(ctx, func(u *domain.User, _ *data) bool { return u.ID == id })
+}
+
+// GetByPublicKey retrieves a user by their primary key or any of their
+// additional keys.
+func (r *UserRepository) GetByPublicKey(ctx context.Context, publicKey []byte) (*domain.User, error) {
+	return r.find(ctx, func(u *domain.User, d *data) bool { return hasPublicKey(d, u, publicKey) })
+}
+
+// GetByEmail retrieves a user by email.
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.find(ctx, func(u *domain.User, _ *data) bool { return u.Email == email })
+}
+
+// find returns the user that isn't deleted and matches.
+func (r *UserRepository) find(ctx context.Context, match func(u *domain.User, d *data) bool) (*domain.User, error) {
+	var user *domain.User
+	r.store.view(ctx, func(d *data) {
+		for _, u := range d.users {
+			if u.Status != domain.UserStatusDeleted && match(u, d) {
+				user = clone(u)
+				return
+			}
+		}
+	})
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// hasPublicKey reports whether publicKey is the primary key or an
+// additional key of u.
+func hasPublicKey(d *data, u *domain.User, publicKey []byte) bool {
+	if bytes.Equal(u.PublicKey, publicKey) {
+		return true
+	}
+	for _, k := range d.userKeys {
+		if k.UserID == u.ID && bytes.Equal(k.PublicKey, publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// List retrieves users that aren't deleted and match the filter.
+func (r *UserRepository) List(ctx context.Context, filter storage.UserFilter) ([]*domain.User, error) {
+	var users []*domain.User
+	r.store.view(ctx, func(d *data) {
+		for _, u := range d.users {
+			if matchUser(u, filter) {
+				users = append(users, u)
+			}
+		}
+	})
+
+	sortBy(users, filter.OrderDesc, userLess(filter.OrderBy))
+	return cloneAll(page(users, filter.Offset, filter.Limit)), nil
+}
+
+// Update updates an existing user. The keys of a user and its creation
+// time cannot change.
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.users[user.ID]
+		if !ok {
+			return domain.ErrUserNotFound
+		}
+		if user.Email != "" {
+			for _, u := range d.users {
+				if u.ID != user.ID && u.Email == user.Email {
+					return domain.ErrUserExists
+				}
+			}
+		}
+
+		user.UpdatedAt = time.Now().UTC()
+		updated := clone(stored)
+		updated.Name = user.Name
+		updated.Email = user.Email
+		updated.Status = user.Status
+		updated.Role = user.Role
+		updated.Locale = user.Locale
+		updated.UpdatedAt = user.UpdatedAt
+		updated.LastLoginAt = clone(user.LastLoginAt)
+		updated.Metadata = maps.Clone(user.Metadata)
+		d.users[user.ID] = updated
+		return nil
+	})
+}
+
+// Delete soft-deletes a user.
+func (r *UserRepository) Delete(ctx context.Context, id domain.UserID) error {
+	return r.store.update(ctx, func(d *data) error {
+		stored, ok := d.users[id]
+		if !ok {
+			return domain.ErrUserNotFound
+		}
+
+		deleted := clone(stored)
+		deleted.Status = domain.UserStatusDeleted
+		deleted.UpdatedAt = time.Now().UTC()
+		d.users[id] = deleted
+		return nil
+	})
+}
+
+// Count returns the number of users that aren't deleted and match the
+// filter.
+func (r *UserRepository) Count(ctx context.Context, filter storage.UserFilter) (int64, error) {
+	var count int64
+	r.store.view(ctx, func(d *data) {
+		for _, u := range d.users {
+			if matchUser(u, filter) {
+				count++
+			}
+		}
+	})
+	return count, nil
+}
+
+// Exists checks if a user with the given public key exists, as either the
+// primary key or an additional key.
+func (r *UserRepository) Exists(ctx context.Context, publicKey []byte) (bool, error) {
+	_, err := r.GetByPublicKey(ctx, publicKey)
+	return err == nil, nil
+}
+
+// IsFirstUser returns true if no users exist yet.
+func (r *UserRepository) IsFirstUser(ctx context.Context) (bool, error) {
+	count, err := r.Count(ctx, storage.UserFilter{})
+	return count == 0, err
+}
+
+// matchUser reports whether u isn't deleted and matches the filter.
+func matchUser(u *domain.User, filter storage.UserFilter) bool {
+	if u.Status == domain.UserStatusDeleted {
+		return false
+	}
+	if filter.Status != "" && u.Status != filter.Status {
+		return false
+	}
+	if filter.Role != "" && u.Role != filter.Role {
+		return false
+	}
+	if filter.Search != "" && !like(u.Name, filter.Search) && !like(u.Email, filter.Search) {
+		return false
+	}
+	return true
+}
+
+// userLess orders users by the given column, by name by default.
+func userLess(orderBy string) func(a, b *domain.User) bool {
+	switch orderBy {
+	case "created_at":
+		return func(a, b *domain.User) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	case "updated_at":
+		return func(a, b *domain.User) bool { return a.UpdatedAt.Before(b.UpdatedAt) }
+	case "email":
+		return func(a, b *domain.User) bool { return a.Email < b.Email }
+	case "role":
+		return func(a, b *domain.User) bool { return a.Role < b.Role }
+	default:
+		return func(a, b *domain.User) bool { return a.Name < b.Name }
+	}
+}
+
+// =============================================================================
+// Public Keys
+// =============================================================================
+
+// AddPublicKey registers an additional key for a user. Returns
+// domain.ErrKeyExists if the key or the label is already in use.
+func (r *UserRepository) AddPublicKey(ctx context.Context, key *domain.UserPublicKey) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	return r.store.update(ctx, func(d *data) error {
+		for _, k := range d.userKeys {
+			if k.Fingerprint == key.Fingerprint || bytes.Equal(k.PublicKey, key.PublicKey) ||
+				(k.UserID == key.UserID && k.Label == key.Label) {
+				return domain.ErrKeyExists
+			}
+		}
+
+		d.userKeys[key.Fingerprint] = clone(key)
+		return nil
+	})
+}
+
+// ListPublicKeys lists a user's additional keys, oldest first.
+func (r *UserRepository) ListPublicKeys(ctx context.Context, userID domain.UserID) ([]*domain.UserPublicKey, error) {
+	var keys []*domain.UserPublicKey
+	r.store.view(ctx, func(d *data) {
+		for _, k := range d.userKeys {
+			if k.UserID == userID {
+				keys = append(keys, k)
+			}
+		}
+	})
+
+	sortBy(keys, false, func(a, b *domain.UserPublicKey) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return cloneAll(keys), nil
+}
+
+// RemovePublicKey removes an additional key by fingerprint.
+func (r *UserRepository) RemovePublicKey(ctx context.Context, userID domain.UserID, fingerprint string) error {
+	return r.store.update(ctx, func(d *data) error {
+		k, ok := d.userKeys[fingerprint]
+		if !ok || k.UserID != userID {
+			return domain.ErrKeyNotFound
+		}
+
+		delete(d.userKeys, fingerprint)
+		return nil
+	})
+}
+
+// Ensure interface compliance
+var _ storage.UserRepository = (*UserRepository)(nil)
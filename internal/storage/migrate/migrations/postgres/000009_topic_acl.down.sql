@@ -0,0 +1,2 @@
+-- Drop the topic access control list
+ALTER TABLE topics DROP COLUMN IF EXISTS acl;
@@ -0,0 +1,2 @@
+-- Per-topic access control list, stored with the topic
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS acl JSONB;
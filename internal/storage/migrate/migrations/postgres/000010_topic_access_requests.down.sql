@@ -0,0 +1,5 @@
+-- Drop topic access requests
+DELETE FROM topic_invitations WHERE kind = 'request';
+DROP INDEX IF EXISTS idx_topic_invitations_kind;
+ALTER TABLE topic_invitations DROP COLUMN IF EXISTS responded_by;
+ALTER TABLE topic_invitations DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,6 @@
+-- Access requests share the invitations table: a request is an invitation
+-- whose inviter is the requester
+ALTER TABLE topic_invitations ADD COLUMN kind TEXT NOT NULL DEFAULT 'invite' CHECK (kind IN ('invite', 'request'));
+ALTER TABLE topic_invitations ADD COLUMN responded_by TEXT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_topic_invitations_kind ON topic_invitations(kind);
@@ -0,0 +1,3 @@
+-- Drop the data schemas
+ALTER TABLE datasets DROP COLUMN IF EXISTS data_schema;
+ALTER TABLE topics DROP COLUMN IF EXISTS data_schema;
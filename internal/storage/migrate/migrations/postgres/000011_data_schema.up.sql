@@ -0,0 +1,3 @@
+-- Optional JSON Schema that dataset content must conform to
+ALTER TABLE topics ADD COLUMN IF NOT EXISTS data_schema JSONB;
+ALTER TABLE datasets ADD COLUMN IF NOT EXISTS data_schema JSONB;
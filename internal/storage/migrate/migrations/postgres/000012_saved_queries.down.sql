@@ -0,0 +1,4 @@
+-- Drop saved queries
+DROP INDEX IF EXISTS idx_saved_queries_is_public;
+DROP INDEX IF EXISTS idx_saved_queries_name;
+DROP TABLE IF EXISTS saved_queries;
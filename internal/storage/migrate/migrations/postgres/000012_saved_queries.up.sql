@@ -0,0 +1,18 @@
+-- Saved queries, named per user and optionally shared with all users
+CREATE TABLE saved_queries (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    query TEXT NOT NULL,
+    parameters JSONB, -- placeholder defaults
+    tags TEXT[],
+    is_public BOOLEAN NOT NULL DEFAULT false,
+    use_count BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX idx_saved_queries_name ON saved_queries(name);
+CREATE INDEX idx_saved_queries_is_public ON saved_queries(is_public) WHERE is_public;
@@ -0,0 +1,12 @@
+-- Additional identity keys, one per device. The primary key stays on users.
+CREATE TABLE user_keys (
+    fingerprint TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    public_key BYTEA NOT NULL UNIQUE,
+    key_type TEXT NOT NULL CHECK (key_type IN ('ed25519', 'rsa')),
+    label TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, label)
+);
+
+CREATE INDEX idx_user_keys_user_id ON user_keys(user_id);
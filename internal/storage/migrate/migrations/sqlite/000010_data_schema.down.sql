@@ -0,0 +1,3 @@
+-- Drop the data schemas
+ALTER TABLE datasets DROP COLUMN data_schema;
+ALTER TABLE topics DROP COLUMN data_schema;
@@ -0,0 +1,3 @@
+-- Optional JSON Schema that dataset content must conform to
+ALTER TABLE topics ADD COLUMN data_schema TEXT; -- JSON
+ALTER TABLE datasets ADD COLUMN data_schema TEXT; -- JSON
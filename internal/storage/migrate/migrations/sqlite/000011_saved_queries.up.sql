@@ -0,0 +1,19 @@
+-- Saved queries, named per user and optionally shared with all users
+CREATE TABLE saved_queries (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    query TEXT NOT NULL,
+    parameters TEXT, -- JSON object of placeholder defaults
+    tags TEXT, -- JSON array
+    is_public INTEGER NOT NULL DEFAULT 0,
+    use_count INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    UNIQUE (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_saved_queries_name ON saved_queries(name);
+CREATE INDEX idx_saved_queries_is_public ON saved_queries(is_public);
@@ -0,0 +1,3 @@
+-- Drop user keys
+DROP INDEX IF EXISTS idx_user_keys_user_id;
+DROP TABLE IF EXISTS user_keys;
@@ -0,0 +1,13 @@
+-- Additional identity keys, one per device. The primary key stays on users.
+CREATE TABLE user_keys (
+    fingerprint TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    public_key BLOB NOT NULL UNIQUE,
+    key_type TEXT NOT NULL CHECK (key_type IN ('ed25519', 'rsa')),
+    label TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')),
+    UNIQUE (user_id, label),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_keys_user_id ON user_keys(user_id);
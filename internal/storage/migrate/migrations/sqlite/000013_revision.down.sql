@@ -0,0 +1,3 @@
+-- Drop the revisions
+ALTER TABLE datasets DROP COLUMN revision;
+ALTER TABLE topics DROP COLUMN revision;
@@ -0,0 +1,3 @@
+-- Revisions for optimistic concurrency control of topic and dataset updates
+ALTER TABLE topics ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE datasets ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
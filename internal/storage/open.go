@@ -44,6 +44,15 @@ func ValidateModeBackend(nodeMode string, backend BackendType) error {
 				SuggestedMode: "selective",
 			}
 		}
+		if backend == BackendMemory {
+			return &ModeBackendError{
+				Mode:          nodeMode,
+				Backend:       backend,
+				Message:       "full replica mode requires PostgreSQL backend; memory storage cannot be an authoritative data source",
+				CanDowngrade:  true,
+				SuggestedMode: "selective",
+			}
+		}
 	case "selective":
 		// Both backends are allowed, but SQLite is cache-only
 		// No error, but caller should be aware of limitations
@@ -75,9 +84,12 @@ var OpenSQLite func(ctx context.Context, cfg SQLiteConfig, dataDir, nodeID strin
 // OpenPostgres is set by the postgres package init to avoid import cycles.
 var OpenPostgres func(ctx context.Context, cfg PostgresConfig, dataDir, nodeID string) (Store, error)
 
+// OpenMemory is set by the memory package init to avoid import cycles.
+var OpenMemory func(ctx context.Context, dataDir, nodeID string) (Store, error)
+
 // Open creates a new Store based on the configuration.
 // It validates the configuration and returns the appropriate store implementation.
-// Note: The caller must import the sqlite and/or postgres packages to register the factories.
+// Note: The caller must import the sqlite, postgres and/or memory packages to register the factories.
 func Open(ctx context.Context, cfg Config, dataDir, nodeID, nodeMode string) (Store, error) {
 	storageLog := getLogger("open")
 
@@ -148,6 +160,20 @@ func Open(ctx context.Context, cfg Config, dataDir, nodeID, nodeMode string) (St
 		storageLog.Info("PostgreSQL storage opened successfully", "authoritative", store.IsAuthoritative())
 		return store, nil
 
+	case BackendMemory:
+		if OpenMemory == nil {
+			storageLog.Error("memory backend not available")
+			return nil, fmt.Errorf("memory backend not available; import bib/internal/storage/memory")
+		}
+		storageLog.Warn("using in-memory storage: all data is lost on restart; do not use in production")
+		store, err := OpenMemory(ctx, dataDir, nodeID)
+		if err != nil {
+			storageLog.Error("failed to create memory store", "error", err)
+			return nil, fmt.Errorf("failed to create memory store: %w", err)
+		}
+		storageLog.Info("memory storage opened successfully", "authoritative", store.IsAuthoritative())
+		return store, nil
+
 	default:
 		storageLog.Error("unknown storage backend", "backend", cfg.Backend)
 		return nil, fmt.Errorf("unknown storage backend: %s", cfg.Backend)
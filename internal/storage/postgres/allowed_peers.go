@@ -40,7 +40,7 @@ func (r *AllowedPeerRepository) Add(ctx context.Context, peer *storage.AllowedPe
 		addedAt = time.Now().UTC()
 	}
 
-	_, err := r.store.pool.Exec(ctx, query,
+	_, err := r.store.conn(ctx).Exec(ctx, query,
 		peer.PeerID,
 		nullString(peer.Name),
 		addedAt,
@@ -54,7 +54,7 @@ func (r *AllowedPeerRepository) Add(ctx context.Context, peer *storage.AllowedPe
 
 // Remove removes a peer from the allowed list.
 func (r *AllowedPeerRepository) Remove(ctx context.Context, peerID string) error {
-	_, err := r.store.pool.Exec(ctx,
+	_, err := r.store.conn(ctx).Exec(ctx,
 		"DELETE FROM allowed_peers WHERE peer_id = $1",
 		peerID,
 	)
@@ -69,7 +69,7 @@ func (r *AllowedPeerRepository) Get(ctx context.Context, peerID string) (*storag
 		WHERE peer_id = $1
 	`
 
-	return r.scanPeer(r.store.pool.QueryRow(ctx, query, peerID))
+	return r.scanPeer(r.store.conn(ctx).QueryRow(ctx, query, peerID))
 }
 
 // List lists all allowed peers.
@@ -80,7 +80,7 @@ func (r *AllowedPeerRepository) List(ctx context.Context) ([]*storage.AllowedPee
 		ORDER BY added_at DESC
 	`
 
-	rows, err := r.store.pool.Query(ctx, query)
+	rows, err := r.store.conn(ctx).Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -97,13 +97,13 @@ func (r *AllowedPeerRepository) IsAllowed(ctx context.Context, peerID string) (b
 	`
 
 	var count int
-	err := r.store.pool.QueryRow(ctx, query, peerID, time.Now().UTC()).Scan(&count)
+	err := r.store.conn(ctx).QueryRow(ctx, query, peerID, time.Now().UTC()).Scan(&count)
 	return count > 0, err
 }
 
 // Cleanup removes expired entries.
 func (r *AllowedPeerRepository) Cleanup(ctx context.Context) error {
-	_, err := r.store.pool.Exec(ctx,
+	_, err := r.store.conn(ctx).Exec(ctx,
 		"DELETE FROM allowed_peers WHERE expires_at IS NOT NULL AND expires_at < $1",
 		time.Now().UTC(),
 	)
@@ -115,7 +115,7 @@ func (r *AllowedPeerRepository) Count(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM allowed_peers WHERE expires_at IS NULL OR expires_at > $1`
 
 	var count int64
-	err := r.store.pool.QueryRow(ctx, query, time.Now().UTC()).Scan(&count)
+	err := r.store.conn(ctx).QueryRow(ctx, query, time.Now().UTC()).Scan(&count)
 	return count, err
 }
 
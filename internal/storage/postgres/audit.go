@@ -38,12 +38,9 @@ func (r *AuditRepository) Log(ctx context.Context, entry *storage.AuditEntry) er
 		entry.EntryHash = calculateEntryHash(entry)
 	}
 
-	pool := r.pool
-	if pool == nil && r.store != nil {
-		pool = r.store.pool
-	}
+	conn := r.conn(ctx)
 
-	row := pool.QueryRow(ctx, `
+	row := conn.QueryRow(ctx, `
 		INSERT INTO audit_log (
 			timestamp, node_id, job_id, operation_id, role_used, action,
 			table_name, query, query_hash, rows_affected, duration_ms,
@@ -171,12 +168,9 @@ func (r *AuditRepository) Query(ctx context.Context, filter storage.AuditFilter)
 		args = append(args, filter.Offset)
 	}
 
-	pool := r.pool
-	if pool == nil && r.store != nil {
-		pool = r.store.pool
-	}
+	conn := r.conn(ctx)
 
-	rows, err := pool.Query(ctx, query, args...)
+	rows, err := conn.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query audit log: %w", err)
 	}
@@ -229,13 +223,10 @@ func (r *AuditRepository) Count(ctx context.Context, filter storage.AuditFilter)
 		args = append(args, *filter.Before)
 	}
 
-	pool := r.pool
-	if pool == nil && r.store != nil {
-		pool = r.store.pool
-	}
+	conn := r.conn(ctx)
 
 	var count int64
-	err := pool.QueryRow(ctx, query, args...).Scan(&count)
+	err := conn.QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count audit entries: %w", err)
 	}
@@ -255,12 +246,9 @@ func (r *AuditRepository) GetByJobID(ctx context.Context, jobID string) ([]*stor
 
 // Purge removes entries older than the given time.
 func (r *AuditRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
-	pool := r.pool
-	if pool == nil && r.store != nil {
-		pool = r.store.pool
-	}
+	conn := r.conn(ctx)
 
-	result, err := pool.Exec(ctx, `
+	result, err := conn.Exec(ctx, `
 		DELETE FROM audit_log WHERE timestamp < $1
 	`, before)
 
@@ -273,12 +261,9 @@ func (r *AuditRepository) Purge(ctx context.Context, before time.Time) (int64, e
 
 // VerifyChain verifies hash chain integrity.
 func (r *AuditRepository) VerifyChain(ctx context.Context, from, to int64) (bool, error) {
-	pool := r.pool
-	if pool == nil && r.store != nil {
-		pool = r.store.pool
-	}
+	conn := r.conn(ctx)
 
-	rows, err := pool.Query(ctx, `
+	rows, err := conn.Query(ctx, `
 		SELECT id, timestamp, node_id, job_id, operation_id, role_used, action,
 			table_name, query, query_hash, rows_affected, duration_ms,
 			source_component, actor, metadata, prev_hash, entry_hash,
@@ -314,13 +299,10 @@ func (r *AuditRepository) VerifyChain(ctx context.Context, from, to int64) (bool
 
 // GetLastHash returns the hash of the last entry.
 func (r *AuditRepository) GetLastHash(ctx context.Context) (string, error) {
-	pool := r.pool
-	if pool == nil && r.store != nil {
-		pool = r.store.pool
-	}
+	conn := r.conn(ctx)
 
 	var entryHash *string
-	err := pool.QueryRow(ctx, `
+	err := conn.QueryRow(ctx, `
 		SELECT entry_hash FROM audit_log ORDER BY id DESC LIMIT 1
 	`).Scan(&entryHash)
 
@@ -442,3 +424,23 @@ func scanAuditEntry(rows pgx.Rows) (*storage.AuditEntry, error) {
 
 // Ensure interface compliance
 var _ storage.AuditRepository = (*AuditRepository)(nil)
+
+// conn returns the pool of a standalone repository, or the store's
+// connection for ctx.
+func (r *AuditRepository) conn(ctx context.Context) querier {
+	if r.pool != nil {
+		return r.pool
+	}
+	return r.store.conn(ctx)
+}
+
+// resetLastHash re-reads the head of the hash chain after a rolled back
+// transaction discarded the entries logged in it.
+func (r *AuditRepository) resetLastHash(ctx context.Context) {
+	if r == nil || !r.hashChain {
+		return
+	}
+	if hash, err := r.GetLastHash(ctx); err == nil {
+		r.lastHash = hash
+	}
+}
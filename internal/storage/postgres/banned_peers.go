@@ -43,7 +43,7 @@ func (r *BannedPeerRepository) Create(ctx context.Context, ban *storage.BannedPe
 		bannedBy = &s
 	}
 
-	_, err := r.store.pool.Exec(ctx, query,
+	_, err := r.store.conn(ctx).Exec(ctx, query,
 		ban.PeerID,
 		ban.Reason,
 		bannedBy,
@@ -63,7 +63,7 @@ func (r *BannedPeerRepository) Get(ctx context.Context, peerID string) (*storage
 		WHERE peer_id = $1
 	`
 
-	return r.scanBan(r.store.pool.QueryRow(ctx, query, peerID))
+	return r.scanBan(r.store.conn(ctx).QueryRow(ctx, query, peerID))
 }
 
 // List lists all bans.
@@ -94,7 +94,7 @@ func (r *BannedPeerRepository) List(ctx context.Context, filter storage.BannedPe
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.pool.Query(ctx, query, args...)
+	rows, err := r.store.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +105,7 @@ func (r *BannedPeerRepository) List(ctx context.Context, filter storage.BannedPe
 
 // Delete removes a ban.
 func (r *BannedPeerRepository) Delete(ctx context.Context, peerID string) error {
-	_, err := r.store.pool.Exec(ctx,
+	_, err := r.store.conn(ctx).Exec(ctx,
 		"DELETE FROM banned_peers WHERE peer_id = $1",
 		peerID,
 	)
@@ -120,13 +120,13 @@ func (r *BannedPeerRepository) IsBanned(ctx context.Context, peerID string) (boo
 	`
 
 	var count int
-	err := r.store.pool.QueryRow(ctx, query, peerID, time.Now().UTC()).Scan(&count)
+	err := r.store.conn(ctx).QueryRow(ctx, query, peerID, time.Now().UTC()).Scan(&count)
 	return count > 0, err
 }
 
 // CleanupExpired removes expired bans.
 func (r *BannedPeerRepository) CleanupExpired(ctx context.Context) (int64, error) {
-	result, err := r.store.pool.Exec(ctx,
+	result, err := r.store.conn(ctx).Exec(ctx,
 		"DELETE FROM banned_peers WHERE expires_at IS NOT NULL AND expires_at < $1",
 		time.Now().UTC(),
 	)
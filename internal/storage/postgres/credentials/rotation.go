@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"bib/internal/maintenance"
 	"bib/internal/storage"
 )
 
@@ -291,6 +292,7 @@ func (r *Rotator) failRotation(oldVersion, newVersion int, message string, err e
 type RotationScheduler struct {
 	rotator  *Rotator
 	interval time.Duration
+	gate     *maintenance.Gate
 	stopCh   chan struct{}
 	doneCh   chan struct{}
 }
@@ -305,6 +307,13 @@ func NewRotationScheduler(rotator *Rotator, interval time.Duration) *RotationSch
 	}
 }
 
+// SetPauseGate makes rotations observe the maintenance pause signal.
+// Rotations due while paused run on resume. It must be called before Start.
+func (s *RotationScheduler) SetPauseGate(gate *maintenance.Gate) {
+	s.gate = gate
+	gate.Register(maintenance.WorkerRotation)
+}
+
 // Start begins the automatic rotation schedule.
 func (s *RotationScheduler) Start(ctx context.Context) {
 	go s.run(ctx)
@@ -319,6 +328,17 @@ func (s *RotationScheduler) Stop() {
 func (s *RotationScheduler) run(ctx context.Context) {
 	defer close(s.doneCh)
 
+	// Stop also releases a rotation held by a maintenance pause
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	ticker := time.NewTicker(s.interval / 10) // Check 10 times per interval
 	defer ticker.Stop()
 
@@ -330,6 +350,9 @@ func (s *RotationScheduler) run(ctx context.Context) {
 			return
 		case <-ticker.C:
 			if s.rotator.manager.NeedsRotation() {
+				if err := s.gate.Wait(ctx, maintenance.WorkerRotation); err != nil {
+					return
+				}
 				if err := s.rotator.Rotate(ctx); err != nil {
 					// Log error but continue - will retry on next tick
 					continue
@@ -28,8 +28,8 @@ func (r *DatasetRepository) Create(ctx context.Context, dataset *domain.Dataset)
 	}
 
 	_, err := r.store.execWithAudit(ctx, "INSERT", "datasets", `
-		INSERT INTO datasets (id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		INSERT INTO datasets (id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata, data_schema)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`,
 		string(dataset.ID),
 		string(dataset.TopicID),
@@ -46,6 +46,7 @@ func (r *DatasetRepository) Create(ctx context.Context, dataset *domain.Dataset)
 		dataset.UpdatedAt,
 		dataset.Tags,
 		dataset.Metadata,
+		dataset.DataSchema,
 	)
 
 	if err != nil {
@@ -55,13 +56,14 @@ func (r *DatasetRepository) Create(ctx context.Context, dataset *domain.Dataset)
 		return fmt.Errorf("failed to create dataset: %w", err)
 	}
 
+	dataset.Revision = 1
 	return nil
 }
 
 // Get retrieves a dataset by ID.
 func (r *DatasetRepository) Get(ctx context.Context, id domain.DatasetID) (*domain.Dataset, error) {
 	rows, err := r.store.queryWithAudit(ctx, "datasets", `
-		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata
+		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata, data_schema, revision
 		FROM datasets WHERE id = $1
 	`, string(id))
 	if err != nil {
@@ -79,7 +81,7 @@ func (r *DatasetRepository) Get(ctx context.Context, id domain.DatasetID) (*doma
 // List retrieves datasets matching the filter.
 func (r *DatasetRepository) List(ctx context.Context, filter storage.DatasetFilter) ([]*domain.Dataset, error) {
 	query := `
-		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata
+		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata, data_schema, revision
 		FROM datasets WHERE 1=1
 	`
 	args := []any{}
@@ -167,7 +169,10 @@ func (r *DatasetRepository) List(ctx context.Context, filter storage.DatasetFilt
 	return datasets, rows.Err()
 }
 
-// Update updates an existing dataset.
+// Update updates an existing dataset. Unless dataset.Revision is 0, the
+// update only applies if the stored dataset still has that revision and
+// fails with storage.ErrConflict otherwise. dataset.Revision is set to the
+// new revision.
 func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset) error {
 	if err := dataset.Validate(); err != nil {
 		return err
@@ -190,8 +195,10 @@ func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset)
 			has_instructions = $8,
 			owners = $9,
 			tags = $10,
-			metadata = $11
-		WHERE id = $12
+			metadata = $11,
+			data_schema = $12,
+			revision = revision + 1
+		WHERE id = $13 AND ($14::bigint = 0 OR revision = $14)
 	`,
 		string(dataset.TopicID),
 		dataset.Name,
@@ -204,33 +211,30 @@ func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset)
 		owners,
 		dataset.Tags,
 		dataset.Metadata,
+		dataset.DataSchema,
 		string(dataset.ID),
+		dataset.Revision,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update dataset: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "datasets", string(dataset.ID), rowsAffected, &dataset.Revision)
 }
 
-// Delete soft-deletes a dataset.
-func (r *DatasetRepository) Delete(ctx context.Context, id domain.DatasetID) error {
+// Delete soft-deletes a dataset. Unless revision is 0, the delete only
+// applies if the stored dataset still has that revision and fails with
+// storage.ErrConflict otherwise.
+func (r *DatasetRepository) Delete(ctx context.Context, id domain.DatasetID, revision int64) error {
 	rowsAffected, err := r.store.execWithAudit(ctx, "UPDATE", "datasets", `
-		UPDATE datasets SET status = 'deleted' WHERE id = $1
-	`, string(id))
+		UPDATE datasets SET status = 'deleted', revision = revision + 1
+		WHERE id = $1 AND ($2::bigint = 0 OR revision = $2)
+	`, string(id), revision)
 	if err != nil {
 		return fmt.Errorf("failed to delete dataset: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "datasets", string(id), rowsAffected, &revision)
 }
 
 // Count returns the number of datasets matching the filter.
@@ -359,6 +363,35 @@ func (r *DatasetRepository) ListVersions(ctx context.Context, datasetID domain.D
 	return versions, rows.Err()
 }
 
+// DeleteVersion deletes a version and its chunks. A version that followed
+// it is linked to the version before it instead.
+func (r *DatasetRepository) DeleteVersion(ctx context.Context, datasetID domain.DatasetID, versionID domain.DatasetVersionID) error {
+	version, err := r.GetVersion(ctx, datasetID, versionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.store.execWithAudit(ctx, "UPDATE", "dataset_versions", `
+		UPDATE dataset_versions SET previous_version_id = $1 WHERE dataset_id = $2 AND previous_version_id = $3
+	`, nullString(string(version.PreviousVersionID)), string(datasetID), string(versionID)); err != nil {
+		return fmt.Errorf("failed to unlink version: %w", err)
+	}
+
+	// Chunks are removed by ON DELETE CASCADE
+	rowsAffected, err := r.store.execWithAudit(ctx, "DELETE", "dataset_versions", `
+		DELETE FROM dataset_versions WHERE dataset_id = $1 AND id = $2
+	`, string(datasetID), string(versionID))
+	if err != nil {
+		return fmt.Errorf("failed to delete version: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
 // CreateChunk creates a new chunk record.
 func (r *DatasetRepository) CreateChunk(ctx context.Context, chunk *domain.Chunk) error {
 	if err := chunk.Validate(); err != nil {
@@ -465,12 +498,15 @@ func scanDataset(rows pgx.Rows) (*domain.Dataset, error) {
 		updatedAt       interface{}
 		tags            []string
 		metadata        map[string]string
+		dataSchema      *domain.DataSchema
+		revision        int64
 	)
 
 	err := rows.Scan(
 		&id, &topicID, &name, &description, &status, &latestVersionID,
 		&versionCount, &hasContent, &hasInstructions, &owners,
 		&createdBy, &createdAt, &updatedAt, &tags, &metadata,
+		&dataSchema, &revision,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan dataset: %w", err)
@@ -488,6 +524,8 @@ func scanDataset(rows pgx.Rows) (*domain.Dataset, error) {
 		CreatedBy:       domain.UserID(createdBy),
 		Tags:            tags,
 		Metadata:        metadata,
+		DataSchema:      dataSchema,
+		Revision:        revision,
 	}
 
 	if latestVersionID != nil {
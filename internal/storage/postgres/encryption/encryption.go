@@ -6,6 +6,7 @@ package encryption
 import (
 	"context"
 	"errors"
+	"fmt"
 	"runtime"
 	"time"
 )
@@ -299,6 +300,12 @@ func (m *Manager) Initialize(ctx context.Context, dataDir string) error {
 		return ErrAlreadyInitialized
 	}
 
+	// Hybrid falls back to column encryption alone; LUKS must not run
+	// without the volume
+	if m.config.Method == MethodLUKS && !m.volumeEncryption.IsSupported() {
+		return ErrNotSupported
+	}
+
 	if m.volumeEncryption != nil && m.volumeEncryption.IsSupported() {
 		key := m.keyManager.DeriveKey("volume-encryption")
 		if err := m.volumeEncryption.Initialize(ctx, dataDir, key); err != nil {
@@ -324,6 +331,90 @@ func (m *Manager) Mount(ctx context.Context) error {
 	return m.volumeEncryption.Mount(ctx, key)
 }
 
+// MountWithShares recovers the master key from recovery shares and mounts
+// the volume with it. It is used when the key derived from the identity
+// no longer unlocks the volume; that key is then added to the volume, so
+// later mounts need no shares.
+func (m *Manager) MountWithShares(ctx context.Context, shares []Share) error {
+	return m.mountRecovered(ctx, func() error {
+		return m.keyManager.RecoverFromShares(shares)
+	})
+}
+
+// MountWithEscrow is MountWithShares for a master key recovered from an
+// escrow, opened with the private key of its recipient.
+func (m *Manager) MountWithEscrow(ctx context.Context, escrow *Escrow, privateKey []byte) error {
+	return m.mountRecovered(ctx, func() error {
+		return m.keyManager.RecoverFromEscrow(escrow, privateKey)
+	})
+}
+
+// mountRecovered mounts the volume with the master key set by recover, and
+// adds the key derived from the identity to it.
+func (m *Manager) mountRecovered(ctx context.Context, recover func() error) error {
+	luks, ok := m.volumeEncryption.(*LUKSEncryption)
+	if !m.config.Enabled || !ok {
+		return ErrNotSupported
+	}
+
+	identityKey := m.keyManager.DeriveKey("volume-encryption")
+	if err := recover(); err != nil {
+		return err
+	}
+
+	key := m.keyManager.DeriveKey("volume-encryption")
+	if err := luks.Mount(ctx, key); err != nil {
+		return err
+	}
+
+	if err := luks.AddKey(ctx, key, identityKey); err != nil {
+		return fmt.Errorf("volume mounted, but the identity key could not be added: %w", err)
+	}
+	return nil
+}
+
+// Escrow seals the master key to an administrator's X25519 public key.
+func (m *Manager) Escrow(recipientPublicKey []byte) (*Escrow, error) {
+	if m.keyManager == nil {
+		return nil, ErrNotInitialized
+	}
+
+	return m.keyManager.Escrow(recipientPublicKey)
+}
+
+// MountPoint returns the directory the encrypted volume is mounted on, or
+// "" if the method uses no volume.
+func (m *Manager) MountPoint() string {
+	if luks, ok := m.volumeEncryption.(*LUKSEncryption); ok {
+		return luks.MountPoint()
+	}
+	return ""
+}
+
+// VolumeCreated reports whether Initialize created a new encrypted volume.
+// Recovery shares for it should be handed out then.
+func (m *Manager) VolumeCreated() bool {
+	luks, ok := m.volumeEncryption.(*LUKSEncryption)
+	return ok && luks.Created()
+}
+
+// RemoveCreatedVolume removes the encrypted volume Initialize created,
+// before it is mounted. It is used when the recovery material of a new
+// volume could not be written, so no volume is left that only the
+// identity key unlocks.
+func (m *Manager) RemoveCreatedVolume() error {
+	luks, ok := m.volumeEncryption.(*LUKSEncryption)
+	if !ok {
+		return ErrNotSupported
+	}
+	if err := luks.Remove(); err != nil {
+		return err
+	}
+
+	m.initialized = false
+	return nil
+}
+
 // Unmount securely unmounts the encrypted storage.
 func (m *Manager) Unmount(ctx context.Context) error {
 	if !m.config.Enabled || m.volumeEncryption == nil {
@@ -1,6 +1,12 @@
 package encryption
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -190,10 +196,17 @@ func TestShamirSecretSharing(t *testing.T) {
 		t.Fatalf("failed to recover key: %v", err)
 	}
 
-	// Note: Due to GF(256) arithmetic simplification, exact recovery may vary
-	// In production, use a proper library like hashicorp/vault/shamir
-	if len(recovered) != len(key) {
-		t.Errorf("recovered key has wrong length: got %d, want %d", len(recovered), len(key))
+	if !bytes.Equal(recovered, key) {
+		t.Errorf("recovered key mismatch: got %x, want %x", recovered, key)
+	}
+
+	// Any threshold shares recover the key
+	recovered, err = manager.RecoverKey([]Share{shares[4], shares[1], shares[2]})
+	if err != nil {
+		t.Fatalf("failed to recover key: %v", err)
+	}
+	if !bytes.Equal(recovered, key) {
+		t.Errorf("recovered key mismatch with other shares: got %x, want %x", recovered, key)
 	}
 }
 
@@ -216,6 +229,12 @@ func TestShamirInsufficientShares(t *testing.T) {
 	if err == nil {
 		t.Error("should fail with insufficient shares")
 	}
+
+	// The same share twice does not count twice
+	_, err = manager.RecoverKey([]Share{shares[0], shares[0], shares[1]})
+	if !errors.Is(err, ErrInsufficientShares) {
+		t.Errorf("expected ErrInsufficientShares for duplicate shares, got %v", err)
+	}
 }
 
 func TestKeyManager(t *testing.T) {
@@ -317,3 +336,161 @@ func TestFieldEncryptor(t *testing.T) {
 		t.Errorf("decrypted field mismatch: got %q, want %q", decrypted, data)
 	}
 }
+
+func TestLUKSDeviceName(t *testing.T) {
+	// The name must survive a restart, so it only depends on the path
+	name := deviceNameFor("/var/lib/bibd/encrypted.img")
+	if name != deviceNameFor("/var/lib/bibd/encrypted.img") {
+		t.Error("device name should be stable for the same volume")
+	}
+	if name == deviceNameFor("/srv/bibd/encrypted.img") {
+		t.Error("different volumes should get different device names")
+	}
+	if !strings.HasPrefix(name, "bibd-") || len(name) > 127 {
+		t.Errorf("unexpected device name %q", name)
+	}
+}
+
+func TestLUKSKeyPipe(t *testing.T) {
+	key := bytes.Repeat([]byte{0, 1, '\n'}, 32)
+	r, err := keyPipe(key)
+	if err != nil {
+		t.Fatalf("keyPipe() error = %v", err)
+	}
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil || !bytes.Equal(got, key) {
+		t.Errorf("keyPipe() read %x, %v; want %x", got, err, key)
+	}
+}
+
+func TestEscrow(t *testing.T) {
+	publicKey, privateKey, err := GenerateEscrowKey()
+	if err != nil {
+		t.Fatalf("failed to generate escrow key: %v", err)
+	}
+
+	masterKey := bytes.Repeat([]byte{7}, 32)
+	escrow, err := SealEscrow(masterKey, publicKey)
+	if err != nil {
+		t.Fatalf("failed to seal escrow: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "escrow.json")
+	if err := WriteEscrow(path, escrow); err != nil {
+		t.Fatalf("failed to write escrow: %v", err)
+	}
+	escrow, err = ReadEscrow(path)
+	if err != nil {
+		t.Fatalf("failed to read escrow: %v", err)
+	}
+
+	opened, err := escrow.Open(privateKey)
+	if err != nil {
+		t.Fatalf("failed to open escrow: %v", err)
+	}
+	if !bytes.Equal(opened, masterKey) {
+		t.Errorf("opened key mismatch: got %x, want %x", opened, masterKey)
+	}
+
+	// Another private key is refused
+	_, otherKey, _ := GenerateEscrowKey()
+	if _, err := escrow.Open(otherKey); !errors.Is(err, ErrEscrowRecipient) {
+		t.Errorf("expected ErrEscrowRecipient, got %v", err)
+	}
+}
+
+func TestKeyManagerRecoverFromShares(t *testing.T) {
+	identityKey := bytes.Repeat([]byte{1}, 32)
+	recovery := RecoveryConfig{Method: "shamir", Shamir: ShamirConfig{TotalShares: 3, Threshold: 2}}
+
+	km, err := NewKeyManager(identityKey, recovery)
+	if err != nil {
+		t.Fatalf("failed to create key manager: %v", err)
+	}
+	shares, err := km.GenerateRecoveryShares()
+	if err != nil {
+		t.Fatalf("failed to generate shares: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := WriteShares(dir, shares[1:]); err != nil {
+		t.Fatalf("failed to write shares: %v", err)
+	}
+	read, err := ReadShares(dir)
+	if err != nil {
+		t.Fatalf("failed to read shares: %v", err)
+	}
+
+	// A node with another identity gets the original keys back
+	other, err := NewKeyManager(bytes.Repeat([]byte{2}, 32), recovery)
+	if err != nil {
+		t.Fatalf("failed to create key manager: %v", err)
+	}
+	if err := other.RecoverFromShares(read); err != nil {
+		t.Fatalf("failed to recover: %v", err)
+	}
+	if !bytes.Equal(other.DeriveKey("volume-encryption"), km.DeriveKey("volume-encryption")) {
+		t.Error("recovered key manager derives different keys")
+	}
+}
+
+func TestRemoveShares(t *testing.T) {
+	km, err := NewKeyManager(bytes.Repeat([]byte{1}, 32), RecoveryConfig{Method: "shamir", Shamir: ShamirConfig{TotalShares: 3, Threshold: 2}})
+	if err != nil {
+		t.Fatalf("failed to create key manager: %v", err)
+	}
+	shares, err := km.GenerateRecoveryShares()
+	if err != nil {
+		t.Fatalf("failed to generate shares: %v", err)
+	}
+
+	dir := t.TempDir()
+	other := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(other, []byte("keep"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteShares(dir, shares); err != nil {
+		t.Fatalf("failed to write shares: %v", err)
+	}
+
+	// Only the files of the shares are removed, and a share already gone
+	// is no error
+	os.Remove(sharePath(dir, shares[0]))
+	if err := RemoveShares(dir, shares); err != nil {
+		t.Fatalf("failed to remove shares: %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "notes.txt" {
+		t.Errorf("shares directory holds %v after removing the shares, want notes.txt", entries)
+	}
+}
+
+func TestLUKSRemove(t *testing.T) {
+	dir := t.TempDir()
+	volumePath := LUKSVolumePath(dir)
+	if err := os.WriteFile(volumePath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	l := &LUKSEncryption{volumePath: volumePath, deviceName: deviceNameFor(volumePath), initialized: true}
+
+	// A volume found on start is never removed
+	if err := l.Remove(); err == nil {
+		t.Error("Remove() of an existing volume succeeded")
+	}
+	if _, err := os.Stat(volumePath); err != nil {
+		t.Fatalf("existing volume removed: %v", err)
+	}
+
+	l.created = true
+	if err := l.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(volumePath); !os.IsNotExist(err) {
+		t.Errorf("volume still exists: %v", err)
+	}
+	if l.initialized || l.Created() {
+		t.Error("removed volume still marked initialized")
+	}
+}
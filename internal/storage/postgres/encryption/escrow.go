@@ -0,0 +1,218 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// escrowVersion is the format version of Escrow.
+const escrowVersion = 1
+
+var (
+	// ErrEscrowRecipient indicates an escrow was sealed to another key.
+	ErrEscrowRecipient = errors.New("escrow was sealed to a different key")
+
+	// ErrNoShares indicates a shares directory holds no shares.
+	ErrNoShares = errors.New("no recovery shares found")
+)
+
+// Escrow holds the master key sealed to an administrator's X25519 public
+// key. Only the matching private key opens it, so it can be kept apart
+// from the node and from the recovery shares.
+type Escrow struct {
+	// Version is the format version.
+	Version int `json:"version"`
+
+	// Recipient is the base64 public key the master key is sealed to.
+	Recipient string `json:"recipient"`
+
+	// KeyHash is the hex SHA-256 of the master key, to check the
+	// recovered key.
+	KeyHash string `json:"key_hash"`
+
+	// Sealed is the master key in a NaCl anonymous box.
+	Sealed []byte `json:"sealed"`
+
+	// Created is when the escrow was made.
+	Created time.Time `json:"created"`
+}
+
+// GenerateEscrowKey generates an X25519 key pair for sealing escrows.
+func GenerateEscrowKey() (publicKey, privateKey []byte, err error) {
+	pub, priv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate escrow key: %w", err)
+	}
+	return pub[:], priv[:], nil
+}
+
+// SealEscrow seals masterKey to recipientPublicKey.
+func SealEscrow(masterKey, recipientPublicKey []byte) (*Escrow, error) {
+	if len(recipientPublicKey) != 32 {
+		return nil, fmt.Errorf("%w: escrow public key must be 32 bytes", ErrInvalidKey)
+	}
+
+	var recipient [32]byte
+	copy(recipient[:], recipientPublicKey)
+
+	sealed, err := box.SealAnonymous(nil, masterKey, &recipient, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal escrow: %w", err)
+	}
+
+	hash := sha256.Sum256(masterKey)
+	return &Escrow{
+		Version:   escrowVersion,
+		Recipient: base64.StdEncoding.EncodeToString(recipientPublicKey),
+		KeyHash:   hex.EncodeToString(hash[:]),
+		Sealed:    sealed,
+		Created:   time.Now().UTC(),
+	}, nil
+}
+
+// Open returns the master key in the escrow, using the private key of its
+// recipient.
+func (e *Escrow) Open(privateKey []byte) ([]byte, error) {
+	if len(privateKey) != 32 {
+		return nil, fmt.Errorf("%w: escrow private key must be 32 bytes", ErrInvalidKey)
+	}
+
+	publicKey, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	if base64.StdEncoding.EncodeToString(publicKey) != e.Recipient {
+		return nil, ErrEscrowRecipient
+	}
+
+	var pub, priv [32]byte
+	copy(pub[:], publicKey)
+	copy(priv[:], privateKey)
+
+	masterKey, ok := box.OpenAnonymous(nil, e.Sealed, &pub, &priv)
+	if !ok {
+		return nil, ErrDecryptFailed
+	}
+
+	hash := sha256.Sum256(masterKey)
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(hash[:])), []byte(e.KeyHash)) != 1 {
+		return nil, ErrDecryptFailed
+	}
+
+	return masterKey, nil
+}
+
+// WriteEscrow writes e to path, readable only by the current user.
+func WriteEscrow(path string, e *Escrow) error {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal escrow: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create escrow directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write escrow: %w", err)
+	}
+
+	return nil
+}
+
+// ReadEscrow reads an escrow written by WriteEscrow.
+func ReadEscrow(path string) (*Escrow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var e Escrow
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("failed to parse escrow: %w", err)
+	}
+	if e.Version != escrowVersion {
+		return nil, fmt.Errorf("unsupported escrow version %d", e.Version)
+	}
+
+	return &e, nil
+}
+
+// WriteShares writes one file per share to dir, readable only by the
+// current user. If a share can't be written, the ones written are removed
+// again.
+func WriteShares(dir string, shares []Share) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create shares directory: %w", err)
+	}
+
+	for i, share := range shares {
+		encoded, err := share.Export(nil)
+		if err == nil {
+			err = os.WriteFile(sharePath(dir, share), []byte(encoded+"\n"), 0600)
+		}
+		if err != nil {
+			RemoveShares(dir, shares[:i+1])
+			return fmt.Errorf("failed to write share: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveShares removes the files WriteShares wrote for shares from dir.
+func RemoveShares(dir string, shares []Share) error {
+	var errs []error
+	for _, share := range shares {
+		if err := os.Remove(sharePath(dir, share)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sharePath returns the path of the file of share in dir.
+func sharePath(dir string, share Share) string {
+	return filepath.Join(dir, fmt.Sprintf("share-%d.txt", share.Index))
+}
+
+// ReadShares reads the shares in dir, one per file.
+func ReadShares(dir string) ([]Share, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var shares []Share
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		share, err := ImportShare(strings.TrimSpace(string(data)), nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		shares = append(shares, *share)
+	}
+
+	if len(shares) == 0 {
+		return nil, ErrNoShares
+	}
+	return shares, nil
+}
@@ -38,6 +38,16 @@ func NewKeyManager(identityKey []byte, config RecoveryConfig) (*KeyManager, erro
 	return km, nil
 }
 
+// DeriveSQLiteKey returns the SQLCipher key of the SQLite database of the
+// node with identityKey.
+func DeriveSQLiteKey(identityKey []byte) ([]byte, error) {
+	km, err := NewKeyManager(identityKey, RecoveryConfig{})
+	if err != nil {
+		return nil, err
+	}
+	return km.DeriveKey("sqlite-encryption"), nil
+}
+
 // DeriveKey derives a purpose-specific key from the master key.
 func (km *KeyManager) DeriveKey(purpose string) []byte {
 	return deriveKey(km.masterKey, purpose, 32)
@@ -72,6 +82,23 @@ func (km *KeyManager) RecoverFromShares(shares []Share) error {
 	return nil
 }
 
+// Escrow seals the master key to an administrator's X25519 public key.
+func (km *KeyManager) Escrow(recipientPublicKey []byte) (*Escrow, error) {
+	return SealEscrow(km.masterKey, recipientPublicKey)
+}
+
+// RecoverFromEscrow recovers the master key from an escrow, using the
+// private key of its recipient.
+func (km *KeyManager) RecoverFromEscrow(escrow *Escrow, privateKey []byte) error {
+	masterKey, err := escrow.Open(privateKey)
+	if err != nil {
+		return err
+	}
+
+	km.masterKey = masterKey
+	return nil
+}
+
 // ExportShare exports a share in a portable format.
 func (km *KeyManager) ExportShare(share Share, recipientPublicKey []byte) (string, error) {
 	return share.Export(recipientPublicKey)
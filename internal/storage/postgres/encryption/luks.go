@@ -1,7 +1,11 @@
 package encryption
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -19,6 +23,7 @@ type LUKSEncryption struct {
 	volumePath  string
 	mountPoint  string
 	initialized bool
+	created     bool
 }
 
 // NewLUKSEncryption creates a new LUKS encryption handler.
@@ -50,10 +55,12 @@ func (l *LUKSEncryption) IsSupported() bool {
 		return false
 	}
 
-	// Check if cryptsetup is available
-	_, err := exec.LookPath("cryptsetup")
-	if err != nil {
-		return false
+	// Check if cryptsetup and the tools to create the filesystem are
+	// available
+	for _, tool := range []string{"cryptsetup", "blkid", "mkfs.ext4"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			return false
+		}
 	}
 
 	// Check if we can access device mapper
@@ -75,8 +82,8 @@ func (l *LUKSEncryption) Initialize(ctx context.Context, dataDir string, key []b
 	}
 
 	l.dataDir = dataDir
-	l.volumePath = filepath.Join(dataDir, "encrypted.img")
-	l.deviceName = fmt.Sprintf("bibd-encrypted-%d", os.Getpid())
+	l.volumePath = LUKSVolumePath(dataDir)
+	l.deviceName = deviceNameFor(l.volumePath)
 	l.mountPoint = filepath.Join(dataDir, "data")
 
 	// Check if already initialized
@@ -97,6 +104,52 @@ func (l *LUKSEncryption) Initialize(ctx context.Context, dataDir string, key []b
 	}
 
 	l.initialized = true
+	l.created = true
+	return nil
+}
+
+// LUKSVolumePath returns the path of the volume file in dataDir.
+func LUKSVolumePath(dataDir string) string {
+	return filepath.Join(dataDir, "encrypted.img")
+}
+
+// deviceNameFor returns the device mapper name of the volume at
+// volumePath. It only depends on the path, so a restarted bibd finds the
+// device it opened before.
+func deviceNameFor(volumePath string) string {
+	if abs, err := filepath.Abs(volumePath); err == nil {
+		volumePath = abs
+	}
+	sum := sha256.Sum256([]byte(volumePath))
+	return "bibd-" + hex.EncodeToString(sum[:6])
+}
+
+// MountPoint returns the directory the decrypted volume is mounted on.
+func (l *LUKSEncryption) MountPoint() string {
+	return l.mountPoint
+}
+
+// Created reports whether Initialize created a new volume, rather than
+// finding an existing one.
+func (l *LUKSEncryption) Created() bool {
+	return l.created
+}
+
+// Remove deletes a volume Initialize created in this run, before it was
+// opened. A volume that existed before is never removed.
+func (l *LUKSEncryption) Remove() error {
+	if !l.created {
+		return fmt.Errorf("%s was not created in this run", l.volumePath)
+	}
+	if l.isOpen() {
+		return fmt.Errorf("%s is open as /dev/mapper/%s", l.volumePath, l.deviceName)
+	}
+	if err := os.Remove(l.volumePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove volume file: %w", err)
+	}
+
+	l.initialized = false
+	l.created = false
 	return nil
 }
 
@@ -148,31 +201,17 @@ func (l *LUKSEncryption) parseVolumeSize() int64 {
 
 // formatLUKS formats the volume with LUKS encryption.
 func (l *LUKSEncryption) formatLUKS(ctx context.Context, key []byte) error {
-	// Create a temporary key file (more secure than passing via stdin in some cases)
-	keyFile, err := os.CreateTemp("", "luks-key-*")
-	if err != nil {
-		return fmt.Errorf("failed to create key file: %w", err)
-	}
-	defer func() {
-		keyFile.Close()
-		os.Remove(keyFile.Name())
-	}()
-
-	if _, err := keyFile.Write(key); err != nil {
-		return fmt.Errorf("failed to write key file: %w", err)
-	}
-	keyFile.Close()
-
 	// Format with cryptsetup
 	cmd := exec.CommandContext(ctx, "cryptsetup", "luksFormat",
 		"--type", "luks2",
 		"--cipher", l.config.Cipher,
 		"--key-size", fmt.Sprintf("%d", l.config.KeySize),
 		"--hash", l.config.HashAlgorithm,
-		"--key-file", keyFile.Name(),
+		"--key-file", "-",
 		"--batch-mode",
 		l.volumePath,
 	)
+	cmd.Stdin = bytes.NewReader(key)
 
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("cryptsetup luksFormat failed: %w\nOutput: %s", err, output)
@@ -196,30 +235,12 @@ func (l *LUKSEncryption) Mount(ctx context.Context, key []byte) error {
 		return nil
 	}
 
-	// Create key file
-	keyFile, err := os.CreateTemp("", "luks-key-*")
-	if err != nil {
-		return fmt.Errorf("failed to create key file: %w", err)
-	}
-	defer func() {
-		keyFile.Close()
-		os.Remove(keyFile.Name())
-	}()
-
-	if _, err := keyFile.Write(key); err != nil {
-		return fmt.Errorf("failed to write key file: %w", err)
-	}
-	keyFile.Close()
-
-	// Open LUKS volume
-	cmd := exec.CommandContext(ctx, "cryptsetup", "luksOpen",
-		"--key-file", keyFile.Name(),
-		l.volumePath,
-		l.deviceName,
-	)
-
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("cryptsetup luksOpen failed: %w\nOutput: %s", err, output)
+	// After a crash the device can still be open; it is only opened again
+	// when it is not, e.g. after a reboot
+	if !l.isOpen() {
+		if err := l.openLUKS(ctx, key); err != nil {
+			return err
+		}
 	}
 
 	// Create mount point if needed
@@ -235,7 +256,7 @@ func (l *LUKSEncryption) Mount(ctx context.Context, key []byte) error {
 	}
 
 	// Mount
-	cmd = exec.CommandContext(ctx, "mount",
+	cmd := exec.CommandContext(ctx, "mount",
 		fmt.Sprintf("/dev/mapper/%s", l.deviceName),
 		l.mountPoint,
 	)
@@ -248,15 +269,101 @@ func (l *LUKSEncryption) Mount(ctx context.Context, key []byte) error {
 	return nil
 }
 
-// ensureFilesystem creates an ext4 filesystem if one doesn't exist.
+// openLUKS opens the volume as /dev/mapper/<deviceName>. A key that does
+// not unlock the volume is reported as ErrInvalidKey.
+func (l *LUKSEncryption) openLUKS(ctx context.Context, key []byte) error {
+	cmd := exec.CommandContext(ctx, "cryptsetup", "luksOpen",
+		"--key-file", "-",
+		l.volumePath,
+		l.deviceName,
+	)
+	cmd.Stdin = bytes.NewReader(key)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		// cryptsetup exits with 2 when no key slot matches the key
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 2 {
+			return fmt.Errorf("%w: the key does not unlock %s", ErrInvalidKey, l.volumePath)
+		}
+		return fmt.Errorf("cryptsetup luksOpen failed: %w\nOutput: %s", err, output)
+	}
+
+	return nil
+}
+
+// AddKey adds newKey to a free key slot of the volume, unlocking it with
+// key. Both keys unlock the volume afterwards.
+func (l *LUKSEncryption) AddKey(ctx context.Context, key, newKey []byte) error {
+	if !l.initialized {
+		return ErrNotInitialized
+	}
+
+	// The key is read from stdin, the new key from a pipe passed as
+	// file descriptor 3
+	newKeyPipe, err := keyPipe(newKey)
+	if err != nil {
+		return err
+	}
+	defer newKeyPipe.Close()
+
+	cmd := exec.CommandContext(ctx, "cryptsetup", "luksAddKey",
+		"--key-file", "-",
+		"--batch-mode",
+		l.volumePath,
+		"/dev/fd/3",
+	)
+	cmd.Stdin = bytes.NewReader(key)
+	cmd.ExtraFiles = []*os.File{newKeyPipe}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cryptsetup luksAddKey failed: %w\nOutput: %s", err, output)
+	}
+
+	return nil
+}
+
+// keyPipe returns the read end of a pipe key is written to, so a key can
+// be passed to cryptsetup without it ever touching the disk. The caller
+// closes it.
+func keyPipe(key []byte) (*os.File, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key pipe: %w", err)
+	}
+	go func() {
+		// Fails once the reader is closed if cryptsetup never read the key
+		_, _ = w.Write(key)
+		w.Close()
+	}()
+	return r, nil
+}
+
+// isOpen checks if the volume is open in the device mapper.
+func (l *LUKSEncryption) isOpen() bool {
+	_, err := os.Stat(fmt.Sprintf("/dev/mapper/%s", l.deviceName))
+	return err == nil
+}
+
+// ensureFilesystem creates an ext4 filesystem on a volume Initialize just
+// created. A volume that existed before must already have one: it is never
+// formatted, so a failed probe can't wipe the data on it.
 func (l *LUKSEncryption) ensureFilesystem(ctx context.Context) error {
 	devicePath := fmt.Sprintf("/dev/mapper/%s", l.deviceName)
 
-	// Check if filesystem exists
-	cmd := exec.CommandContext(ctx, "blkid", devicePath)
-	if cmd.Run() == nil {
+	// blkid -p probes the device itself rather than the blkid cache, and
+	// exits with 2 only when it finds no signature at all
+	cmd := exec.CommandContext(ctx, "blkid", "-p", devicePath)
+	output, err := cmd.CombinedOutput()
+	if err == nil {
 		return nil // Filesystem already exists
 	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 2 {
+		return fmt.Errorf("blkid failed: %w\nOutput: %s", err, output)
+	}
+	if !l.created {
+		return fmt.Errorf("no filesystem found on %s, which this run did not create; refusing to format it", l.volumePath)
+	}
 
 	// Create ext4 filesystem
 	cmd = exec.CommandContext(ctx, "mkfs.ext4", "-q", devicePath)
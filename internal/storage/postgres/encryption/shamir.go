@@ -110,6 +110,10 @@ func (sm *ShamirManager) SplitKey(key []byte) ([]Share, error) {
 		return nil, fmt.Errorf("threshold must be at least 2")
 	}
 
+	if sm.config.TotalShares > 255 {
+		return nil, fmt.Errorf("total shares must be at most 255")
+	}
+
 	// Generate random coefficients for the polynomial
 	// The secret is the constant term (coefficient 0)
 	coefficients := make([][]byte, sm.config.Threshold)
@@ -152,14 +156,31 @@ func (sm *ShamirManager) SplitKey(key []byte) ([]Share, error) {
 }
 
 // RecoverKey recovers the secret from shares using Lagrange interpolation.
+// It needs as many distinct shares as the configured threshold, or as the
+// threshold the shares were made with if that is higher.
 func (sm *ShamirManager) RecoverKey(shares []Share) ([]byte, error) {
-	if len(shares) < sm.config.Threshold {
+	threshold := sm.config.Threshold
+	seen := make(map[int]bool)
+	var distinct []Share
+	for _, share := range shares {
+		if share.Index < 1 || share.Index > 255 {
+			return nil, ErrInvalidShare
+		}
+		if share.Threshold > threshold {
+			threshold = share.Threshold
+		}
+		if !seen[share.Index] {
+			seen[share.Index] = true
+			distinct = append(distinct, share)
+		}
+	}
+	if len(distinct) < threshold {
 		return nil, fmt.Errorf("%w: need %d, got %d",
-			ErrInsufficientShares, sm.config.Threshold, len(shares))
+			ErrInsufficientShares, threshold, len(distinct))
 	}
 
 	// Use only the first 'threshold' shares
-	shares = shares[:sm.config.Threshold]
+	shares = distinct[:threshold]
 
 	// Verify all shares have the same length
 	keyLen := len(shares[0].Data)
@@ -173,22 +194,17 @@ func (sm *ShamirManager) RecoverKey(shares []Share) ([]byte, error) {
 	secret := make([]byte, keyLen)
 
 	for i, share := range shares {
-		// Calculate Lagrange basis polynomial L_i(0)
-		// L_i(0) = Π (0 - x_j) / (x_i - x_j) for j ≠ i
-		numerator := 1
-		denominator := 1
-
+		// Calculate Lagrange basis polynomial L_i(0) in GF(256)
+		// L_i(0) = Π x_j / (x_i - x_j) for j ≠ i; subtraction is XOR
+		coefficient := byte(1)
 		for j, otherShare := range shares {
 			if i == j {
 				continue
 			}
-			numerator *= -otherShare.Index                // 0 - x_j
-			denominator *= share.Index - otherShare.Index // x_i - x_j
+			xi, xj := byte(share.Index), byte(otherShare.Index)
+			coefficient = gfMul(coefficient, gfDiv(xj, xi^xj))
 		}
 
-		// Calculate coefficient in GF(256)
-		coefficient := gfDiv(numerator, denominator)
-
 		// Add contribution to secret
 		for k := 0; k < keyLen; k++ {
 			secret[k] ^= gfMul(share.Data[k], coefficient)
@@ -229,7 +245,7 @@ func init() {
 	for i := 0; i < 255; i++ {
 		gfExp[i] = x
 		gfLog[x] = byte(i)
-		x = gfMulNoTable(x, 2)
+		x = gfMulNoTable(x, 3) // 3 generates the multiplicative group
 	}
 	for i := 255; i < 512; i++ {
 		gfExp[i] = gfExp[i-255]
@@ -261,21 +277,12 @@ func gfMul(a, b byte) byte {
 	return gfExp[int(gfLog[a])+int(gfLog[b])]
 }
 
-// gfDiv divides two integers and returns result in GF(256).
-func gfDiv(num, denom int) byte {
-	// Handle modular arithmetic for Lagrange coefficients
-	// This is simplified - production code needs proper GF(256) division
-	result := num
-	for result < 0 {
-		result += 256
-	}
-	for denom < 0 {
-		denom += 256
-	}
-	if denom == 0 {
+// gfDiv divides a by b in GF(256). b must not be 0.
+func gfDiv(a, b byte) byte {
+	if a == 0 {
 		return 0
 	}
-	return byte(result % 256)
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
 }
 
 // encryptForRecipient encrypts data for a specific recipient using their public key.
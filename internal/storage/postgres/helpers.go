@@ -1,7 +1,13 @@
 package postgres
 
 import (
+	"context"
+	"errors"
 	"strings"
+
+	"bib/internal/storage"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // nullString returns a pointer to s if non-empty, otherwise nil.
@@ -28,3 +34,33 @@ func isUniqueViolation(err error) bool {
 		strings.Contains(errStr, "unique constraint") ||
 		strings.Contains(errStr, "duplicate key")
 }
+
+// revisionOf returns the revision of the row with id in table
+func (s *Store) revisionOf(ctx context.Context, table, id string) (int64, error) {
+	var revision int64
+	err := s.conn(ctx).QueryRow(ctx, "SELECT revision FROM "+table+" WHERE id = $1", id).Scan(&revision)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, storage.ErrNotFound
+	}
+	return revision, err
+}
+
+// finishUpdate completes a conditional update of the row with id in table
+// that was based on *revision. If the update affected no rows it returns
+// storage.ErrNotFound or, if the row exists, storage.ErrConflict;
+// otherwise it sets *revision to the new revision.
+func (s *Store) finishUpdate(ctx context.Context, table, id string, rowsAffected int64, revision *int64) error {
+	if rowsAffected > 0 && *revision > 0 {
+		*revision++
+		return nil
+	}
+	current, err := s.revisionOf(ctx, table, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return storage.ErrConflict
+	}
+	*revision = current
+	return nil
+}
@@ -21,6 +21,9 @@ func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
 	if err := job.Validate(); err != nil {
 		return err
 	}
+	if job.ExecutionMode == "" {
+		job.ExecutionMode = domain.ExecutionModeGoroutine
+	}
 
 	// Convert dependencies to string array for PostgreSQL UUID[]
 	var dependencies []string
@@ -188,6 +191,9 @@ func (r *JobRepository) Update(ctx context.Context, job *domain.Job) error {
 	if err := job.Validate(); err != nil {
 		return err
 	}
+	if job.ExecutionMode == "" {
+		job.ExecutionMode = domain.ExecutionModeGoroutine
+	}
 
 	var dependencies []string
 	for _, d := range job.Dependencies {
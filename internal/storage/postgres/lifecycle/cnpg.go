@@ -0,0 +1,483 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// cnpgGroupVersion is the API group/version served by the CloudNativePG operator.
+const cnpgGroupVersion = "postgresql.cnpg.io/v1"
+
+// cnpgImageRepository is the operand image repository; CNPGClusterVersion
+// selects the tag.
+const cnpgImageRepository = "ghcr.io/cloudnative-pg/postgresql"
+
+var (
+	cnpgClusterGVR         = schema.GroupVersionResource{Group: "postgresql.cnpg.io", Version: "v1", Resource: "clusters"}
+	cnpgScheduledBackupGVR = schema.GroupVersionResource{Group: "postgresql.cnpg.io", Version: "v1", Resource: "scheduledbackups"}
+)
+
+// cnpgOperatorInstalled reports whether the CNPG Cluster CRD is served by the
+// API server. The operator may live in any namespace, so the CRD is the only
+// reliable signal.
+func (km *KubernetesManager) cnpgOperatorInstalled() bool {
+	resources, err := km.clientset.Discovery().ServerResourcesForGroupVersion(cnpgGroupVersion)
+	if err != nil {
+		return false
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == cnpgClusterGVR.Resource {
+			return true
+		}
+	}
+	return false
+}
+
+// cnpgSuperuserSecretName returns the basic-auth secret for the postgres user.
+func (km *KubernetesManager) cnpgSuperuserSecretName() string {
+	return km.secretName + "-superuser"
+}
+
+// cnpgAppSecretName returns the basic-auth secret for the database owner.
+func (km *KubernetesManager) cnpgAppSecretName() string {
+	return km.secretName + "-app"
+}
+
+// cnpgS3SecretName returns the secret holding the S3 backup access keys.
+func (km *KubernetesManager) cnpgS3SecretName() string {
+	return km.secretName + "-s3"
+}
+
+// cnpgScheduledBackupName returns the name of the ScheduledBackup resource.
+func (km *KubernetesManager) cnpgScheduledBackupName() string {
+	return km.statefulSetName + "-backup"
+}
+
+// cnpgBackupToS3 reports whether backups are taken by the operator's
+// barman-cloud integration rather than the pg_dump CronJob.
+func (km *KubernetesManager) cnpgBackupToS3() bool {
+	return km.k8sConfig.UseCNPG && km.k8sConfig.BackupEnabled && km.k8sConfig.BackupToS3
+}
+
+// deployCNPGCluster deploys PostgreSQL using CloudNativePG operator.
+func (km *KubernetesManager) deployCNPGCluster(ctx context.Context) error {
+	if err := km.createCNPGSecrets(ctx); err != nil {
+		return fmt.Errorf("failed to create CNPG secrets: %w", err)
+	}
+
+	cluster, err := km.buildCNPGCluster()
+	if err != nil {
+		return err
+	}
+	_, err = km.dynamic.Resource(cnpgClusterGVR).Namespace(km.namespace).Create(ctx, cluster, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	if km.cnpgBackupToS3() {
+		_, err = km.dynamic.Resource(cnpgScheduledBackupGVR).Namespace(km.namespace).Create(ctx, km.buildCNPGScheduledBackup(), metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create ScheduledBackup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// createCNPGSecrets creates the secrets referenced by the Cluster. CNPG
+// requires basic-auth secrets for the superuser and the database owner.
+func (km *KubernetesManager) createCNPGSecrets(ctx context.Context) error {
+	secrets := []*corev1.Secret{
+		km.basicAuthSecret(km.cnpgSuperuserSecretName(), "postgres", km.credentials.SuperuserPassword),
+		km.basicAuthSecret(km.cnpgAppSecretName(), "bibd", km.credentials.AdminPassword),
+	}
+
+	s3 := km.k8sConfig.BackupS3
+	if km.cnpgBackupToS3() && !s3.UseIRSA {
+		secrets = append(secrets, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      km.cnpgS3SecretName(),
+				Namespace: km.namespace,
+				Labels:    km.getLabels(),
+			},
+			Type: corev1.SecretTypeOpaque,
+			StringData: map[string]string{
+				"ACCESS_KEY_ID":     s3.AccessKeyID,
+				"ACCESS_SECRET_KEY": s3.SecretAccessKey,
+			},
+		})
+	}
+
+	for _, secret := range secrets {
+		_, err := km.clientset.CoreV1().Secrets(km.namespace).Create(ctx, secret, metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// basicAuthSecret builds a kubernetes.io/basic-auth Secret.
+func (km *KubernetesManager) basicAuthSecret(name, username, password string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: km.namespace,
+			Labels:    km.getLabels(),
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		StringData: map[string]string{
+			corev1.BasicAuthUsernameKey: username,
+			corev1.BasicAuthPasswordKey: password,
+		},
+	}
+}
+
+// buildCNPGCluster builds the CNPG Cluster resource. Values are plain JSON
+// types so the object can be sent through the dynamic client.
+func (km *KubernetesManager) buildCNPGCluster() (*unstructured.Unstructured, error) {
+	cfg := km.k8sConfig
+
+	if cfg.StorageSize == "" {
+		return nil, fmt.Errorf("storage size is required for a CNPG Cluster")
+	}
+	version := cfg.CNPGClusterVersion
+	if version == "" {
+		version = "16"
+	}
+	instances := cfg.CNPGInstances
+	if instances < 1 {
+		instances = 1
+	}
+
+	storage := map[string]interface{}{
+		"size": cfg.StorageSize,
+	}
+	if cfg.StorageClassName != "" {
+		storage["storageClass"] = cfg.StorageClassName
+	}
+
+	spec := map[string]interface{}{
+		"instances":             int64(instances),
+		"imageName":             fmt.Sprintf("%s:%s", cnpgImageRepository, version),
+		"primaryUpdateStrategy": "unsupervised",
+		"enableSuperuserAccess": true,
+		"superuserSecret": map[string]interface{}{
+			"name": km.cnpgSuperuserSecretName(),
+		},
+		"bootstrap": map[string]interface{}{
+			"initdb": map[string]interface{}{
+				"database": "bibd",
+				"owner":    "bibd",
+				"secret": map[string]interface{}{
+					"name": km.cnpgAppSecretName(),
+				},
+			},
+		},
+		"storage": storage,
+		// Operator-managed pods carry our labels so the NetworkPolicy and
+		// anti-affinity rules written for the StatefulSet still apply
+		"inheritedMetadata": map[string]interface{}{
+			"labels": stringMap(km.getPodLabels()),
+		},
+		"monitoring": map[string]interface{}{
+			"enablePodMonitor": cfg.CNPGMonitoring,
+		},
+	}
+
+	if resources := km.buildCNPGResources(); resources != nil {
+		spec["resources"] = resources
+	}
+
+	affinity := map[string]interface{}{
+		"enablePodAntiAffinity": cfg.PodAntiAffinity,
+		"topologyKey":           "kubernetes.io/hostname",
+	}
+	if len(cfg.NodeSelector) > 0 {
+		affinity["nodeSelector"] = stringMap(cfg.NodeSelector)
+	}
+	if len(cfg.Tolerations) > 0 {
+		tolerations := make([]interface{}, 0, len(cfg.Tolerations))
+		for _, t := range cfg.Tolerations {
+			toleration := map[string]interface{}{}
+			setIfNotEmpty(toleration, "key", t.Key)
+			setIfNotEmpty(toleration, "operator", t.Operator)
+			setIfNotEmpty(toleration, "value", t.Value)
+			setIfNotEmpty(toleration, "effect", t.Effect)
+			if t.TolerationSeconds != nil {
+				toleration["tolerationSeconds"] = *t.TolerationSeconds
+			}
+			tolerations = append(tolerations, toleration)
+		}
+		affinity["tolerations"] = tolerations
+	}
+	spec["affinity"] = affinity
+
+	if cfg.PriorityClassName != "" {
+		spec["priorityClassName"] = cfg.PriorityClassName
+	}
+	if len(cfg.ImagePullSecrets) > 0 {
+		secrets := make([]interface{}, 0, len(cfg.ImagePullSecrets))
+		for _, name := range cfg.ImagePullSecrets {
+			secrets = append(secrets, map[string]interface{}{"name": name})
+		}
+		spec["imagePullSecrets"] = secrets
+	}
+
+	if km.cnpgBackupToS3() {
+		backup, err := km.buildCNPGBackup()
+		if err != nil {
+			return nil, err
+		}
+		spec["backup"] = backup
+
+		if cfg.BackupS3.UseIRSA && cfg.BackupS3.IAMRole != "" {
+			spec["serviceAccountTemplate"] = map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"eks.amazonaws.com/role-arn": cfg.BackupS3.IAMRole,
+					},
+				},
+			}
+		}
+	}
+
+	metadata := map[string]interface{}{
+		"name":      km.statefulSetName,
+		"namespace": km.namespace,
+		"labels":    stringMap(km.getLabels()),
+	}
+	if len(cfg.Annotations) > 0 {
+		metadata["annotations"] = stringMap(cfg.Annotations)
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": cnpgGroupVersion,
+		"kind":       "Cluster",
+		"metadata":   metadata,
+		"spec":       spec,
+	}}, nil
+}
+
+// buildCNPGResources builds the resources stanza, or nil if none are set.
+func (km *KubernetesManager) buildCNPGResources() map[string]interface{} {
+	res := km.k8sConfig.Resources
+	resources := map[string]interface{}{}
+
+	requests := map[string]interface{}{}
+	setIfNotEmpty(requests, "cpu", res.Requests.CPU)
+	setIfNotEmpty(requests, "memory", res.Requests.Memory)
+	if len(requests) > 0 {
+		resources["requests"] = requests
+	}
+
+	limits := map[string]interface{}{}
+	setIfNotEmpty(limits, "cpu", res.Limits.CPU)
+	setIfNotEmpty(limits, "memory", res.Limits.Memory)
+	if len(limits) > 0 {
+		resources["limits"] = limits
+	}
+
+	if len(resources) == 0 {
+		return nil
+	}
+	return resources
+}
+
+// buildCNPGBackup builds the barman object store backup stanza from the
+// S3 backup configuration.
+func (km *KubernetesManager) buildCNPGBackup() (map[string]interface{}, error) {
+	s3 := km.k8sConfig.BackupS3
+	if s3.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required for CNPG backups")
+	}
+
+	destination := "s3://" + s3.Bucket
+	if prefix := strings.Trim(s3.Prefix, "/"); prefix != "" {
+		destination += "/" + prefix
+	}
+
+	credentials := map[string]interface{}{}
+	if s3.UseIRSA {
+		credentials["inheritFromIAMRole"] = true
+	} else {
+		credentials["accessKeyId"] = map[string]interface{}{
+			"name": km.cnpgS3SecretName(),
+			"key":  "ACCESS_KEY_ID",
+		}
+		credentials["secretAccessKey"] = map[string]interface{}{
+			"name": km.cnpgS3SecretName(),
+			"key":  "ACCESS_SECRET_KEY",
+		}
+	}
+
+	store := map[string]interface{}{
+		"destinationPath": destination,
+		"s3Credentials":   credentials,
+	}
+	if s3.Endpoint != "" {
+		endpoint := s3.Endpoint
+		if !strings.Contains(endpoint, "://") {
+			endpoint = "https://" + endpoint
+		}
+		store["endpointURL"] = endpoint
+	}
+
+	backup := map[string]interface{}{
+		"barmanObjectStore": store,
+	}
+	if km.k8sConfig.BackupRetention > 0 {
+		backup["retentionPolicy"] = fmt.Sprintf("%dd", km.k8sConfig.BackupRetention)
+	}
+
+	return backup, nil
+}
+
+// buildCNPGScheduledBackup builds the ScheduledBackup for the cluster. CNPG
+// schedules have a leading seconds field, so the 5-field BackupSchedule is
+// run at second 0.
+func (km *KubernetesManager) buildCNPGScheduledBackup() *unstructured.Unstructured {
+	schedule := km.k8sConfig.BackupSchedule
+	if len(strings.Fields(schedule)) == 5 {
+		schedule = "0 " + schedule
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": cnpgGroupVersion,
+		"kind":       "ScheduledBackup",
+		"metadata": map[string]interface{}{
+			"name":      km.cnpgScheduledBackupName(),
+			"namespace": km.namespace,
+			"labels":    stringMap(km.getLabels()),
+		},
+		"spec": map[string]interface{}{
+			"schedule":             schedule,
+			"backupOwnerReference": "cluster",
+			"immediate":            true,
+			"cluster": map[string]interface{}{
+				"name": km.statefulSetName,
+			},
+		},
+	}}
+}
+
+// cnpgReadyInstances returns the number of ready instances of the Cluster.
+func (km *KubernetesManager) cnpgReadyInstances(ctx context.Context) (int64, error) {
+	cluster, err := km.dynamic.Resource(cnpgClusterGVR).Namespace(km.namespace).Get(ctx, km.statefulSetName, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	ready, _, err := unstructured.NestedInt64(cluster.Object, "status", "readyInstances")
+	return ready, err
+}
+
+// cnpgPrimaryHealth returns an error unless the current primary of the
+// Cluster is healthy.
+func (km *KubernetesManager) cnpgPrimaryHealth(ctx context.Context) error {
+	cluster, err := km.dynamic.Resource(cnpgClusterGVR).Namespace(km.namespace).Get(ctx, km.statefulSetName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	primary, healthy := cnpgInstances(cluster)
+	for _, name := range healthy {
+		if name == primary {
+			return nil
+		}
+	}
+
+	phase, _, _ := unstructured.NestedString(cluster.Object, "status", "phase")
+	return fmt.Errorf("CNPG primary %q is not healthy (phase: %s)", primary, phase)
+}
+
+// cnpgFailover switches the Cluster over to a healthy replica, as
+// "kubectl cnpg promote" does, and returns the old and new primary. The
+// operator moves the Service to the new primary.
+func (km *KubernetesManager) cnpgFailover(ctx context.Context) (string, string, error) {
+	clusters := km.dynamic.Resource(cnpgClusterGVR).Namespace(km.namespace)
+
+	cluster, err := clusters.Get(ctx, km.statefulSetName, metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+
+	primary, target, err := cnpgFailoverTarget(cluster)
+	if err != nil {
+		return primary, "", err
+	}
+
+	patch := fmt.Sprintf(`{"status":{"targetPrimary":%q,"phase":"Switchover in progress","phaseReason":"bibd failover to %s"}}`, target, target)
+	if _, err := clusters.Patch(ctx, km.statefulSetName, types.MergePatchType, []byte(patch), metav1.PatchOptions{}, "status"); err != nil {
+		return primary, target, fmt.Errorf("failed to switch over to %s: %w", target, err)
+	}
+	return primary, target, nil
+}
+
+// cnpgFailoverTarget picks the healthy replica to promote in place of the
+// current primary.
+func cnpgFailoverTarget(cluster *unstructured.Unstructured) (string, string, error) {
+	primary, healthy := cnpgInstances(cluster)
+
+	if target, _, _ := unstructured.NestedString(cluster.Object, "status", "targetPrimary"); target != "" && target != primary {
+		return primary, "", fmt.Errorf("switchover to %s already in progress", target)
+	}
+
+	for _, name := range healthy {
+		if name != primary {
+			return primary, name, nil
+		}
+	}
+	return primary, "", fmt.Errorf("no healthy replica to fail over to; raise cnpg_instances")
+}
+
+// cnpgInstances returns the current primary of the Cluster and its healthy
+// instances.
+func cnpgInstances(cluster *unstructured.Unstructured) (string, []string) {
+	primary, _, _ := unstructured.NestedString(cluster.Object, "status", "currentPrimary")
+	healthy, _, _ := unstructured.NestedStringSlice(cluster.Object, "status", "instancesStatus", "healthy")
+	return primary, healthy
+}
+
+// cleanupCNPG deletes the Cluster and its secrets, or hibernates it when
+// resources are kept. Hibernation stops the pods but keeps the PVCs.
+func (km *KubernetesManager) cleanupCNPG(ctx context.Context) {
+	clusters := km.dynamic.Resource(cnpgClusterGVR).Namespace(km.namespace)
+
+	if !km.k8sConfig.DeleteOnCleanup {
+		patch := []byte(`{"metadata":{"annotations":{"cnpg.io/hibernation":"on"}}}`)
+		clusters.Patch(ctx, km.statefulSetName, types.MergePatchType, patch, metav1.PatchOptions{})
+		return
+	}
+
+	km.dynamic.Resource(cnpgScheduledBackupGVR).Namespace(km.namespace).Delete(ctx, km.cnpgScheduledBackupName(), metav1.DeleteOptions{})
+	clusters.Delete(ctx, km.statefulSetName, metav1.DeleteOptions{})
+
+	for _, name := range []string{km.cnpgSuperuserSecretName(), km.cnpgAppSecretName(), km.cnpgS3SecretName()} {
+		km.clientset.CoreV1().Secrets(km.namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+}
+
+// stringMap converts a map[string]string for use in an unstructured object.
+func stringMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// setIfNotEmpty sets key to value unless value is empty.
+func setIfNotEmpty(m map[string]interface{}, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
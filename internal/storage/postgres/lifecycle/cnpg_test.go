@@ -0,0 +1,376 @@
+package postgres
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"bib/internal/storage"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newTestCNPGManager(cfg storage.KubernetesConfig) *KubernetesManager {
+	return &KubernetesManager{
+		k8sConfig:       cfg,
+		nodeID:          "node-1234abcd",
+		namespace:       "bib",
+		statefulSetName: "bibd-postgres-node-123",
+		secretName:      "bibd-postgres-creds-node-123",
+		credentials:     &Credentials{SuperuserPassword: "super", AdminPassword: "admin"},
+	}
+}
+
+func testCNPGConfig() storage.KubernetesConfig {
+	cfg := storage.DefaultConfig().Postgres.Kubernetes
+	cfg.UseCNPG = true
+	cfg.CNPGInstances = 3
+	cfg.CNPGMonitoring = true
+	cfg.StorageClassName = "fast-ssd"
+	cfg.StorageSize = "50Gi"
+	cfg.NodeSelector = map[string]string{"disktype": "ssd"}
+	cfg.Tolerations = []storage.Toleration{{Key: "dedicated", Operator: "Equal", Value: "db", Effect: "NoSchedule"}}
+	cfg.ImagePullSecrets = []string{"registry"}
+	cfg.BackupToS3 = true
+	cfg.BackupS3 = storage.S3BackupConfig{
+		Endpoint:        "minio.example.com:9000",
+		Bucket:          "backups",
+		Prefix:          "/bibd/",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+	}
+	return cfg
+}
+
+// loadCNPGSchema loads a CRD schema from testdata.
+func loadCNPGSchema(t *testing.T, kind string) map[string]interface{} {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/cnpg-v1.24.1-schema.yaml")
+	if err != nil {
+		t.Fatalf("failed to read schema: %v", err)
+	}
+	var schemas map[string]map[string]interface{}
+	if err := yaml.Unmarshal(data, &schemas); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	schema, ok := schemas[kind]
+	if !ok {
+		t.Fatalf("no schema for %s", kind)
+	}
+	return schema
+}
+
+// roundTripYAML marshals obj to YAML and parses it back, as kubectl would
+// see the manifest.
+func roundTripYAML(t *testing.T, obj map[string]interface{}) (string, map[string]interface{}) {
+	t.Helper()
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		t.Fatalf("failed to marshal manifest: %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+	return string(data), parsed
+}
+
+// validateSchema checks value against the subset of OpenAPI v3 used by the
+// CNPG CRDs and returns every violation found.
+func validateSchema(path string, value interface{}, schema map[string]interface{}) []string {
+	var errs []string
+
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, s := range anyOf {
+			if len(validateSchema(path, value, s.(map[string]interface{}))) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			errs = append(errs, fmt.Sprintf("%s: %v matches no anyOf schema", path, value))
+		}
+	}
+
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return append(errs, fmt.Sprintf("%s: expected object, got %T", path, value))
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		additional, _ := schema["additionalProperties"].(map[string]interface{})
+		for key, v := range obj {
+			switch {
+			case props[key] != nil:
+				errs = append(errs, validateSchema(path+"."+key, v, props[key].(map[string]interface{}))...)
+			case additional != nil:
+				errs = append(errs, validateSchema(path+"."+key, v, additional)...)
+			case props != nil:
+				errs = append(errs, fmt.Sprintf("%s: unknown field %q", path, key))
+			}
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, r := range required {
+			if _, ok := obj[r.(string)]; !ok {
+				errs = append(errs, fmt.Sprintf("%s: missing required field %q", path, r))
+			}
+		}
+	case "array":
+		arr, ok := value.([]interface{})
+		if !ok {
+			return append(errs, fmt.Sprintf("%s: expected array, got %T", path, value))
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		for i, v := range arr {
+			errs = append(errs, validateSchema(fmt.Sprintf("%s[%d]", path, i), v, items)...)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return append(errs, fmt.Sprintf("%s: expected string, got %T", path, value))
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(s) {
+			errs = append(errs, fmt.Sprintf("%s: %q does not match %s", path, s, pattern))
+		}
+		if min, ok := schema["minLength"].(int); ok && len(s) < min {
+			errs = append(errs, fmt.Sprintf("%s: %q shorter than %d", path, s, min))
+		}
+	case "integer":
+		n, ok := value.(int)
+		if !ok {
+			return append(errs, fmt.Sprintf("%s: expected integer, got %T", path, value))
+		}
+		if min, ok := schema["minimum"].(int); ok && n < min {
+			errs = append(errs, fmt.Sprintf("%s: %d below minimum %d", path, n, min))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			errs = append(errs, fmt.Sprintf("%s: expected boolean, got %T", path, value))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if e == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Sprintf("%s: %v not in %v", path, value, enum))
+		}
+	}
+
+	sort.Strings(errs)
+	return errs
+}
+
+func TestBuildCNPGCluster_MatchesSchema(t *testing.T) {
+	schema := loadCNPGSchema(t, "Cluster")
+
+	tests := []struct {
+		name   string
+		modify func(*storage.KubernetesConfig)
+	}{
+		{"s3 access keys", func(*storage.KubernetesConfig) {}},
+		{"irsa", func(cfg *storage.KubernetesConfig) {
+			cfg.BackupS3 = storage.S3BackupConfig{Bucket: "backups", UseIRSA: true, IAMRole: "arn:aws:iam::123456789012:role/bibd"}
+		}},
+		{"no backup", func(cfg *storage.KubernetesConfig) {
+			cfg.BackupEnabled = false
+			cfg.Resources = storage.KubernetesResources{}
+			cfg.NodeSelector = nil
+			cfg.Tolerations = nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testCNPGConfig()
+			tt.modify(&cfg)
+
+			cluster, err := newTestCNPGManager(cfg).buildCNPGCluster()
+			if err != nil {
+				t.Fatalf("buildCNPGCluster failed: %v", err)
+			}
+			manifest, parsed := roundTripYAML(t, cluster.Object)
+			if errs := validateSchema("Cluster", parsed, schema); len(errs) > 0 {
+				t.Errorf("manifest does not match the CNPG schema:\n%s\n\n%s", strings.Join(errs, "\n"), manifest)
+			}
+		})
+	}
+}
+
+func TestBuildCNPGCluster_Spec(t *testing.T) {
+	cluster, err := newTestCNPGManager(testCNPGConfig()).buildCNPGCluster()
+	if err != nil {
+		t.Fatalf("buildCNPGCluster failed: %v", err)
+	}
+	_, parsed := roundTripYAML(t, cluster.Object)
+	spec := parsed["spec"].(map[string]interface{})
+
+	if spec["instances"] != 3 {
+		t.Errorf("expected 3 instances, got %v", spec["instances"])
+	}
+	if spec["imageName"] != "ghcr.io/cloudnative-pg/postgresql:16" {
+		t.Errorf("unexpected image %v", spec["imageName"])
+	}
+
+	storageSpec := spec["storage"].(map[string]interface{})
+	if storageSpec["size"] != "50Gi" || storageSpec["storageClass"] != "fast-ssd" {
+		t.Errorf("unexpected storage %v", storageSpec)
+	}
+
+	labels := spec["inheritedMetadata"].(map[string]interface{})["labels"].(map[string]interface{})
+	if labels["app"] != "bibd-postgres" || labels["node-id"] != "node-1234abcd" {
+		t.Errorf("expected pod labels to be inherited, got %v", labels)
+	}
+
+	store := spec["backup"].(map[string]interface{})["barmanObjectStore"].(map[string]interface{})
+	if store["destinationPath"] != "s3://backups/bibd" {
+		t.Errorf("unexpected destination %v", store["destinationPath"])
+	}
+	if store["endpointURL"] != "https://minio.example.com:9000" {
+		t.Errorf("unexpected endpoint %v", store["endpointURL"])
+	}
+	if spec["backup"].(map[string]interface{})["retentionPolicy"] != "7d" {
+		t.Errorf("unexpected retention %v", spec["backup"])
+	}
+	if _, ok := spec["serviceAccountTemplate"]; ok {
+		t.Error("expected no service account template without IRSA")
+	}
+}
+
+func TestBuildCNPGCluster_IRSA(t *testing.T) {
+	cfg := testCNPGConfig()
+	cfg.BackupS3 = storage.S3BackupConfig{Bucket: "backups", UseIRSA: true, IAMRole: "arn:aws:iam::123456789012:role/bibd"}
+
+	cluster, err := newTestCNPGManager(cfg).buildCNPGCluster()
+	if err != nil {
+		t.Fatalf("buildCNPGCluster failed: %v", err)
+	}
+	_, parsed := roundTripYAML(t, cluster.Object)
+	spec := parsed["spec"].(map[string]interface{})
+
+	creds := spec["backup"].(map[string]interface{})["barmanObjectStore"].(map[string]interface{})["s3Credentials"].(map[string]interface{})
+	if creds["inheritFromIAMRole"] != true || creds["accessKeyId"] != nil {
+		t.Errorf("expected IAM role credentials only, got %v", creds)
+	}
+
+	annotations := spec["serviceAccountTemplate"].(map[string]interface{})["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+	if annotations["eks.amazonaws.com/role-arn"] != cfg.BackupS3.IAMRole {
+		t.Errorf("expected role annotation, got %v", annotations)
+	}
+}
+
+func TestBuildCNPGCluster_RequiresBucket(t *testing.T) {
+	cfg := testCNPGConfig()
+	cfg.BackupS3.Bucket = ""
+	if _, err := newTestCNPGManager(cfg).buildCNPGCluster(); err == nil {
+		t.Error("expected an error without a backup bucket")
+	}
+}
+
+func TestBuildCNPGScheduledBackup_MatchesSchema(t *testing.T) {
+	schema := loadCNPGSchema(t, "ScheduledBackup")
+
+	backup := newTestCNPGManager(testCNPGConfig()).buildCNPGScheduledBackup()
+	manifest, parsed := roundTripYAML(t, backup.Object)
+	if errs := validateSchema("ScheduledBackup", parsed, schema); len(errs) > 0 {
+		t.Errorf("manifest does not match the CNPG schema:\n%s\n\n%s", strings.Join(errs, "\n"), manifest)
+	}
+
+	spec := parsed["spec"].(map[string]interface{})
+	if spec["schedule"] != "0 0 2 * * *" {
+		t.Errorf("expected 6-field schedule, got %v", spec["schedule"])
+	}
+}
+
+func TestValidateSchema_ReportsViolations(t *testing.T) {
+	schema := loadCNPGSchema(t, "Cluster")
+
+	bad := map[string]interface{}{
+		"metadata": map[string]interface{}{},
+		"spec": map[string]interface{}{
+			"instances":             0,
+			"primaryUpdateStrategy": "sometimes",
+			"storage":               map[string]interface{}{"sizee": "1Gi"},
+		},
+	}
+	errs := validateSchema("Cluster", bad, schema)
+	if len(errs) != 3 {
+		t.Errorf("expected 3 violations, got %v", errs)
+	}
+}
+
+func TestServiceSelector(t *testing.T) {
+	km := newTestCNPGManager(testCNPGConfig())
+	if sel := km.serviceSelector(); sel["cnpg.io/instanceRole"] != "primary" || sel["cnpg.io/cluster"] != km.statefulSetName {
+		t.Errorf("expected CNPG primary selector, got %v", sel)
+	}
+
+	km.k8sConfig.UseCNPG = false
+	if sel := km.serviceSelector(); sel["app"] != "bibd-postgres" {
+		t.Errorf("expected StatefulSet selector, got %v", sel)
+	}
+}
+
+func TestCNPGFailoverTarget(t *testing.T) {
+	cluster := func(status map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	}
+
+	tests := []struct {
+		name    string
+		status  map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "healthy replica",
+			status: map[string]interface{}{
+				"currentPrimary":  "pg-1",
+				"instancesStatus": map[string]interface{}{"healthy": []interface{}{"pg-2", "pg-3"}, "failed": []interface{}{"pg-1"}},
+			},
+			want: "pg-2",
+		},
+		{
+			name: "no healthy replica",
+			status: map[string]interface{}{
+				"currentPrimary":  "pg-1",
+				"instancesStatus": map[string]interface{}{"healthy": []interface{}{"pg-1"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "switchover in progress",
+			status: map[string]interface{}{
+				"currentPrimary":  "pg-1",
+				"targetPrimary":   "pg-3",
+				"instancesStatus": map[string]interface{}{"healthy": []interface{}{"pg-2", "pg-3"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary, target, err := cnpgFailoverTarget(cluster(tt.status))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("cnpgFailoverTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if primary != "pg-1" || target != tt.want {
+				t.Errorf("cnpgFailoverTarget() = %q, %q, want pg-1, %q", primary, target, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// diagnosticsLogLines is how many trailing log lines Diagnostics collects.
+const diagnosticsLogLines = 50
+
+// diagnosticsTimeout bounds each runtime call made by Diagnostics.
+const diagnosticsTimeout = 10 * time.Second
+
+// Diagnostics describes why managed PostgreSQL failed to start.
+type Diagnostics struct {
+	// Runtime is the container runtime or orchestrator.
+	Runtime RuntimeType `json:"runtime"`
+
+	// Name is the container, or the StatefulSet / CNPG Cluster on Kubernetes.
+	Name string `json:"name"`
+
+	// State is the container or pod state, e.g. "exited (exit code 1)".
+	State string `json:"state,omitempty"`
+
+	// StartError is the error returned by Start, if any.
+	StartError string `json:"start_error,omitempty"`
+
+	// LastHealthError is the output of the last failed health check.
+	LastHealthError string `json:"last_health_error,omitempty"`
+
+	// Logs are the last lines of the container or pod log.
+	Logs string `json:"logs,omitempty"`
+
+	// Cause is the likely cause of the failure.
+	Cause string `json:"cause"`
+
+	// Hint suggests how to fix it.
+	Hint string `json:"hint"`
+}
+
+// String returns a one-line summary suitable for an error message.
+func (d *Diagnostics) String() string {
+	return fmt.Sprintf("likely cause: %s (%s)", d.Cause, d.Hint)
+}
+
+// Diagnostics gathers the container or pod state and logs, the last health
+// check error and a likely cause. Call it after Start fails or readiness
+// times out; it only reads state and is safe to call at any time.
+func (m *Manager) Diagnostics(ctx context.Context) *Diagnostics {
+	m.mu.RLock()
+	d := m.diagnosticsState()
+	rt, km := m.runtime, m.k8sManager
+	m.mu.RUnlock()
+
+	m.gatherDiagnostics(ctx, d, rt, km)
+	return d
+}
+
+// diagnosticsState returns the diagnostics recorded by the manager itself.
+// The caller must hold m.mu.
+func (m *Manager) diagnosticsState() *Diagnostics {
+	d := &Diagnostics{
+		Runtime: m.runtime,
+		Name:    m.cfg.ContainerName,
+	}
+	if m.startErr != nil {
+		d.StartError = m.startErr.Error()
+	}
+	if m.lastHealthErr != nil {
+		d.LastHealthError = m.lastHealthErr.Error()
+	}
+	return d
+}
+
+// gatherDiagnostics adds the state and logs of rt to d, and its likely
+// cause.
+func (m *Manager) gatherDiagnostics(ctx context.Context, d *Diagnostics, rt RuntimeType, km *KubernetesManager) {
+	switch rt {
+	case RuntimeDocker, RuntimePodman:
+		d.State, d.Logs = m.containerDiagnostics(ctx, string(rt))
+	case RuntimeKubernetes:
+		if km != nil {
+			d.Name = km.statefulSetName
+			d.State, d.Logs = km.podDiagnostics(ctx)
+		}
+	}
+
+	d.Cause, d.Hint = m.likelyCause(d)
+}
+
+// containerDiagnostics returns the container state and its log tail.
+func (m *Manager) containerDiagnostics(ctx context.Context, runtime string) (string, string) {
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, runtime, "container", "inspect", m.cfg.ContainerName,
+		"--format", "{{.State.Status}}|{{.State.ExitCode}}|{{.State.OOMKilled}}|{{.State.Error}}").Output()
+	if err != nil {
+		state := "not created"
+		if exec.CommandContext(ctx, runtime, "image", "inspect", m.cfg.Image).Run() != nil {
+			state += fmt.Sprintf("; image %s not present locally", m.cfg.Image)
+		}
+		return state, ""
+	}
+
+	state := formatContainerState(strings.TrimSpace(string(out)))
+
+	// Logs go to stdout and stderr depending on the runtime
+	logs, _ := exec.CommandContext(ctx, runtime, "logs", "--tail",
+		fmt.Sprint(diagnosticsLogLines), m.cfg.ContainerName).CombinedOutput()
+
+	return state, strings.TrimSpace(string(logs))
+}
+
+// formatContainerState renders "status|exit code|oom killed|error" from
+// container inspect as e.g. "exited (exit code 137, OOM killed)".
+func formatContainerState(raw string) string {
+	parts := strings.SplitN(raw, "|", 4)
+	if len(parts) != 4 {
+		return raw
+	}
+	status, exitCode, oomKilled, errMsg := parts[0], parts[1], parts[2], parts[3]
+
+	var details []string
+	if status != "running" && exitCode != "" && exitCode != "0" {
+		details = append(details, "exit code "+exitCode)
+	}
+	if oomKilled == "true" {
+		details = append(details, "OOM killed")
+	}
+	if errMsg != "" {
+		details = append(details, errMsg)
+	}
+	if len(details) == 0 {
+		return status
+	}
+	return fmt.Sprintf("%s (%s)", status, strings.Join(details, ", "))
+}
+
+// podDiagnostics returns the state of the first PostgreSQL pod and its log
+// tail.
+func (km *KubernetesManager) podDiagnostics(ctx context.Context) (string, string) {
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsTimeout)
+	defer cancel()
+
+	selector := map[string]string{"app": "bibd-postgres", "node-id": km.nodeID}
+	if km.k8sConfig.UseCNPG {
+		selector = map[string]string{"cnpg.io/cluster": km.statefulSetName}
+	}
+
+	pods, err := km.clientset.CoreV1().Pods(km.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(selector).String(),
+	})
+	if err != nil {
+		return fmt.Sprintf("unknown (failed to list pods: %v)", err), ""
+	}
+	if len(pods.Items) == 0 {
+		return "no pods created", ""
+	}
+
+	pod := pods.Items[0]
+	state := formatPodState(&pod)
+
+	tail := int64(diagnosticsLogLines)
+	raw, err := km.clientset.CoreV1().Pods(km.namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: "postgres",
+		TailLines: &tail,
+	}).Do(ctx).Raw()
+	if err != nil {
+		return state, ""
+	}
+	return state, strings.TrimSpace(string(raw))
+}
+
+// formatPodState describes why a pod is not ready, e.g.
+// "Pending: ImagePullBackOff: Back-off pulling image ...".
+func formatPodState(pod *corev1.Pod) string {
+	details := []string{string(pod.Status.Phase)}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Message != "" {
+			details = append(details, cond.Message)
+		}
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		switch {
+		case cs.State.Waiting != nil:
+			details = append(details, joinNonEmpty(cs.State.Waiting.Reason, cs.State.Waiting.Message))
+		case cs.State.Terminated != nil:
+			details = append(details, joinNonEmpty(cs.State.Terminated.Reason, cs.State.Terminated.Message))
+		}
+		if t := cs.LastTerminationState.Terminated; t != nil {
+			details = append(details, "last exit: "+joinNonEmpty(t.Reason, t.Message))
+		}
+	}
+
+	return strings.Join(details, ": ")
+}
+
+// joinNonEmpty joins the non-empty strings with ": ".
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ": ")
+}
+
+// failureCause maps symptoms found in errors, state and logs to a cause.
+type failureCause struct {
+	patterns []string
+	cause    func(m *Manager) string
+	hint     func(m *Manager) string
+}
+
+// failureCauses are checked in order; the first match wins. Runtime
+// failures come first because their messages can also mention permissions.
+var failureCauses = []failureCause{
+	{
+		patterns: []string{"cannot connect to the docker daemon", "permission denied while trying to connect to the docker daemon", "cannot connect to podman"},
+		cause:    func(m *Manager) string { return fmt.Sprintf("the %s daemon is not reachable", m.runtime) },
+		hint: func(m *Manager) string {
+			return "start it or give this user access to it, or set database.postgres.container_runtime"
+		},
+	},
+	{
+		patterns: []string{"registry unreachable"},
+		cause:    func(m *Manager) string { return fmt.Sprintf("the registry for %s is unreachable", m.cfg.Image) },
+		hint: func(m *Manager) string {
+			return fmt.Sprintf("check the network and proxy settings, or load the image with '%s load'", m.runtime)
+		},
+	},
+	{
+		patterns: []string{"pull access denied", "manifest unknown", "errimagepull", "imagepullbackoff", "failed to resolve reference", "not present locally", "toomanyrequests", "unable to pull", "error pulling image"},
+		cause:    func(m *Manager) string { return fmt.Sprintf("the image %s could not be pulled", m.cfg.Image) },
+		hint: func(m *Manager) string {
+			if m.runtime == RuntimeKubernetes {
+				return "check database.postgres.image and that the cluster can reach the registry (imagePullSecrets)"
+			}
+			return fmt.Sprintf("check database.postgres.image and registry access, or pull it manually with '%s pull %s'", m.runtime, m.cfg.Image)
+		},
+	},
+	{
+		patterns: []string{"port is already allocated", "address already in use", "bind: address"},
+		cause:    func(m *Manager) string { return fmt.Sprintf("port %d is already in use on the host", m.cfg.Port) },
+		hint: func(m *Manager) string {
+			return "stop the process using it, often a local PostgreSQL, or set database.postgres.port to a free port"
+		},
+	},
+	{
+		patterns: []string{"no space left on device"},
+		cause:    func(m *Manager) string { return fmt.Sprintf("the disk holding %s is full", m.cfg.DataDir) },
+		hint:     func(m *Manager) string { return "free up space or set database.postgres.data_dir to a larger volume" },
+	},
+	{
+		patterns: []string{"permission denied", "operation not permitted", "wrong ownership", "could not change permissions"},
+		cause: func(m *Manager) string {
+			return fmt.Sprintf("the container cannot write the data directory %s", m.cfg.DataDir)
+		},
+		hint: func(m *Manager) string {
+			return "make it writable by the container's postgres user; with SELinux or rootless Podman, check volume labels and user namespace mapping"
+		},
+	},
+	{
+		patterns: []string{"oom killed", "oomkilled"},
+		cause:    func(m *Manager) string { return "PostgreSQL was killed for exceeding its memory limit" },
+		hint:     func(m *Manager) string { return "raise database.postgres.memory_mb" },
+	},
+	{
+		patterns: []string{"unbound immediate persistentvolumeclaims", "storageclass", "failedbinding"},
+		cause:    func(m *Manager) string { return "the data volume could not be provisioned" },
+		hint: func(m *Manager) string {
+			return "check that the configured storage class exists and can provision volumes"
+		},
+	},
+	{
+		patterns: []string{"insufficient cpu", "insufficient memory", "didn't match pod's node affinity"},
+		cause:    func(m *Manager) string { return "no Kubernetes node can run the PostgreSQL pod" },
+		hint:     func(m *Manager) string { return "lower database.postgres.memory_mb / cpu_cores or add capacity" },
+	},
+	{
+		patterns: []string{"crashloopbackoff", "exited", "exit code"},
+		cause:    func(m *Manager) string { return "PostgreSQL exited during startup" },
+		hint:     func(m *Manager) string { return "see the container logs for the error" },
+	},
+}
+
+// likelyCause returns the first failure cause whose symptoms appear in d.
+func (m *Manager) likelyCause(d *Diagnostics) (string, string) {
+	text := strings.ToLower(strings.Join([]string{d.StartError, d.State, d.LastHealthError, d.Logs}, "\n"))
+
+	for _, fc := range failureCauses {
+		for _, p := range fc.patterns {
+			if strings.Contains(text, p) {
+				return fc.cause(m), fc.hint(m)
+			}
+		}
+	}
+
+	return "PostgreSQL did not become ready in time",
+		fmt.Sprintf("it may still be initializing; raise database.postgres.health.startup_timeout (currently %s) on slow hosts", m.cfg.Health.StartupTimeout)
+}
@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFormatContainerState(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"running|0|false|", "running"},
+		{"exited|1|false|", "exited (exit code 1)"},
+		{"exited|137|true|", "exited (exit code 137, OOM killed)"},
+		{"created|0|false|driver failed programming external connectivity", "created (driver failed programming external connectivity)"},
+		{"garbage", "garbage"},
+	}
+
+	for _, tt := range tests {
+		if got := formatContainerState(tt.raw); got != tt.want {
+			t.Errorf("formatContainerState(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestFormatPodState(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "postgres",
+				State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: `Back-off pulling image "postgres:99"`,
+					},
+				},
+			}},
+		},
+	}
+
+	got := formatPodState(pod)
+	want := `Pending: ImagePullBackOff: Back-off pulling image "postgres:99"`
+	if got != want {
+		t.Errorf("formatPodState() = %q, want %q", got, want)
+	}
+}
+
+func TestLikelyCause(t *testing.T) {
+	m := &Manager{
+		runtime: RuntimeDocker,
+		cfg: LifecycleConfig{
+			Image:   "postgres:16-alpine",
+			Port:    5432,
+			DataDir: "/var/lib/bibd/postgres",
+			Health:  HealthConfig{StartupTimeout: time.Minute},
+		},
+	}
+
+	tests := []struct {
+		name string
+		diag Diagnostics
+		want string
+	}{
+		{
+			name: "image pull",
+			diag: Diagnostics{StartError: "failed to start container: exit status 125\nOutput: Unable to find image 'postgres:99' locally\nError response from daemon: manifest unknown"},
+			want: "the image postgres:16-alpine could not be pulled",
+		},
+		{
+			name: "image missing locally",
+			diag: Diagnostics{State: "not created; image postgres:16-alpine not present locally"},
+			want: "could not be pulled",
+		},
+		{
+			name: "port conflict",
+			diag: Diagnostics{StartError: "Bind for 127.0.0.1:5432 failed: port is already allocated"},
+			want: "port 5432 is already in use",
+		},
+		{
+			name: "volume permission",
+			diag: Diagnostics{
+				State: "exited (exit code 1)",
+				Logs:  `initdb: error: could not change permissions of directory "/var/lib/postgresql/data": Operation not permitted`,
+			},
+			want: "cannot write the data directory /var/lib/bibd/postgres",
+		},
+		{
+			name: "docker daemon down",
+			diag: Diagnostics{StartError: "Cannot connect to the Docker daemon at unix:///var/run/docker.sock"},
+			want: "the docker daemon is not reachable",
+		},
+		{
+			name: "oom",
+			diag: Diagnostics{State: "exited (exit code 137, OOM killed)"},
+			want: "exceeding its memory limit",
+		},
+		{
+			name: "crash",
+			diag: Diagnostics{State: "exited (exit code 1)", Logs: "FATAL: invalid value for parameter"},
+			want: "exited during startup",
+		},
+		{
+			name: "slow start",
+			diag: Diagnostics{State: "running", LastHealthError: "exit status 2: /var/run/postgresql:5432 - no response"},
+			want: "did not become ready in time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cause, hint := m.likelyCause(&tt.diag)
+			if !strings.Contains(cause, tt.want) {
+				t.Errorf("cause = %q, want it to contain %q", cause, tt.want)
+			}
+			if hint == "" {
+				t.Error("expected a hint")
+			}
+		})
+	}
+}
+
+func TestManagerDiagnostics(t *testing.T) {
+	m := &Manager{
+		runtime:       RuntimeManual,
+		cfg:           LifecycleConfig{ContainerName: "bibd-postgres-test", Port: 5432},
+		startErr:      errors.New("Bind for 127.0.0.1:5432 failed: port is already allocated"),
+		lastHealthErr: errors.New("no response"),
+	}
+
+	diag := m.Diagnostics(context.Background())
+
+	if diag.Name != "bibd-postgres-test" {
+		t.Errorf("Name = %q", diag.Name)
+	}
+	if diag.StartError == "" || diag.LastHealthError != "no response" {
+		t.Errorf("errors not reported: %+v", diag)
+	}
+	if !strings.Contains(diag.String(), "port 5432 is already in use") {
+		t.Errorf("String() = %q", diag.String())
+	}
+}
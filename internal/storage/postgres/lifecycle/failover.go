@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"bib/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// failoverTimeout bounds a failover, including the promotion of the
+// standby.
+const failoverTimeout = 2 * time.Minute
+
+// FailoverEvent describes a failover attempted by the failover health
+// action.
+type FailoverEvent struct {
+	// At is when the failover was attempted.
+	At time.Time `json:"at"`
+
+	// Runtime is the runtime of the failed primary.
+	Runtime RuntimeType `json:"runtime"`
+
+	// From is the failed primary: the container, or the CNPG instance.
+	From string `json:"from"`
+
+	// To is the new primary: the standby address, or the CNPG instance.
+	To string `json:"to,omitempty"`
+
+	// Host and Port are where the store must connect now. Host is empty if
+	// the address did not change, as with CNPG whose Service follows the
+	// primary; the store only has to reconnect.
+	Host string `json:"host,omitempty"`
+	Port int    `json:"port,omitempty"`
+
+	// Error is why the failover failed, empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// checkPrimaryHealth checks the instance the store connects to: the
+// promoted standby after a failover, the CNPG primary on Kubernetes, and
+// the container otherwise.
+func (m *Manager) checkPrimaryHealth(ctx context.Context) error {
+	if m.standbyPromoted() {
+		return m.pingStandby(ctx)
+	}
+
+	m.mu.RLock()
+	km := m.k8sManager
+	m.mu.RUnlock()
+	if m.runtime == RuntimeKubernetes && km != nil && km.k8sConfig.UseCNPG {
+		return km.cnpgPrimaryHealth(ctx)
+	}
+
+	return m.checkHealth(ctx)
+}
+
+// failover promotes the standby, or switches a CNPG cluster over to a
+// healthy replica, records the event and reports it to OnFailover.
+func (m *Manager) failover() {
+	ctx, cancel := context.WithTimeout(context.Background(), failoverTimeout)
+	defer cancel()
+
+	m.mu.RLock()
+	km := m.k8sManager
+	m.mu.RUnlock()
+
+	event := FailoverEvent{
+		At:      time.Now().UTC(),
+		Runtime: m.runtime,
+		From:    m.cfg.ContainerName,
+	}
+
+	var err error
+	switch {
+	case m.runtime == RuntimeKubernetes && km != nil && km.k8sConfig.UseCNPG:
+		event.From, event.To, err = km.cnpgFailover(ctx)
+	case m.runtime == RuntimeDocker || m.runtime == RuntimePodman:
+		event.To = m.cfg.Health.Standby
+		err = m.promoteStandby(ctx)
+		if err == nil {
+			event.Host, event.Port = m.standbyAddr()
+			m.fenceContainer()
+		}
+	default:
+		err = fmt.Errorf("failover is not supported on %s", m.runtime)
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	m.mu.Lock()
+	m.failovers = append(m.failovers, event)
+	m.healthErrors = 0
+	if err == nil && event.Host != "" {
+		m.promoted = true
+	}
+	m.mu.Unlock()
+
+	if m.cfg.OnFailover != nil {
+		m.cfg.OnFailover(event)
+	}
+}
+
+// promoteStandby promotes the configured standby to primary. A standby that
+// is no longer in recovery has already been promoted.
+func (m *Manager) promoteStandby(ctx context.Context) error {
+	if m.cfg.Health.Standby == "" {
+		return errors.New("no standby configured (database.postgres.health.standby)")
+	}
+
+	conn, err := m.connectStandby(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	var inRecovery bool
+	if err := conn.QueryRow(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+		return fmt.Errorf("failed to query standby: %w", err)
+	}
+	if !inRecovery {
+		return nil
+	}
+
+	var promoted bool
+	if err := conn.QueryRow(ctx, "SELECT pg_promote(true, 60)").Scan(&promoted); err != nil {
+		return fmt.Errorf("failed to promote standby: %w", err)
+	}
+	if !promoted {
+		return errors.New("standby was not promoted within 60s")
+	}
+	return nil
+}
+
+// fenceContainer stops the failed primary so it cannot take writes again
+// if it recovers.
+func (m *Manager) fenceContainer() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_ = exec.CommandContext(ctx, string(m.runtime), "stop", "-t", "10", m.cfg.ContainerName).Run()
+}
+
+// pingStandby checks that the promoted standby accepts connections.
+func (m *Manager) pingStandby(ctx context.Context) error {
+	conn, err := m.connectStandby(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	return conn.Ping(ctx)
+}
+
+// connectStandby connects to the standby as the superuser. The standby
+// replicates the primary, so it has the same credentials.
+func (m *Manager) connectStandby(ctx context.Context) (*pgx.Conn, error) {
+	host, port := m.standbyAddr()
+	cfg, err := pgx.ParseConfig(fmt.Sprintf("host=%s port=%d user=postgres dbname=bibd sslmode=prefer", storage.QuoteConnValue(host), port))
+	if err != nil {
+		return nil, fmt.Errorf("invalid standby %q: %w", m.cfg.Health.Standby, err)
+	}
+	cfg.Password = m.credentials.SuperuserPassword
+
+	conn, err := pgx.ConnectConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to standby %s: %w", m.cfg.Health.Standby, err)
+	}
+	return conn, nil
+}
+
+// standbyAddr splits the standby address, defaulting to port 5432.
+func (m *Manager) standbyAddr() (string, int) {
+	host, portStr, err := net.SplitHostPort(m.cfg.Health.Standby)
+	if err != nil {
+		return m.cfg.Health.Standby, 5432
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return host, 5432
+	}
+	return host, port
+}
+
+// standbyPromoted reports whether the standby has taken over.
+func (m *Manager) standbyPromoted() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.promoted
+}
+
+// Failovers returns the failovers attempted by the failover health action,
+// oldest first.
+func (m *Manager) Failovers() []FailoverEvent {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]FailoverEvent(nil), m.failovers...)
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import "testing"
+
+func TestHandleHealthFailure_Failover(t *testing.T) {
+	m := &Manager{
+		runtime: RuntimeManual,
+		cfg:     LifecycleConfig{Health: HealthConfig{Action: HealthActionFailover, MaxRetries: 3}},
+	}
+
+	for m.healthErrors = 1; m.healthErrors < 3; m.healthErrors++ {
+		if m.handleHealthFailure() {
+			t.Fatalf("failed over after %d failures", m.healthErrors)
+		}
+	}
+	if !m.handleHealthFailure() {
+		t.Error("did not fail over after MaxRetries failures")
+	}
+
+	m.promoted = true
+	if m.handleHealthFailure() {
+		t.Error("failed over again with the standby already promoted")
+	}
+
+	m.promoted = false
+	m.cfg.Health.Action = HealthActionRetryLimit
+	if m.handleHealthFailure() {
+		t.Error("retry_limit failed over")
+	}
+}
+
+func TestStandbyAddr(t *testing.T) {
+	tests := []struct {
+		standby string
+		host    string
+		port    int
+	}{
+		{"10.0.0.2:5433", "10.0.0.2", 5433},
+		{"standby.example.com", "standby.example.com", 5432},
+		{"[fd00::2]:5432", "fd00::2", 5432},
+	}
+
+	for _, tt := range tests {
+		m := &Manager{cfg: LifecycleConfig{Health: HealthConfig{Standby: tt.standby}}}
+		if host, port := m.standbyAddr(); host != tt.host || port != tt.port {
+			t.Errorf("standbyAddr(%q) = %s, %d, want %s, %d", tt.standby, host, port, tt.host, tt.port)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -27,6 +28,7 @@ import (
 // KubernetesManager manages PostgreSQL deployment in Kubernetes.
 type KubernetesManager struct {
 	clientset *kubernetes.Clientset
+	dynamic   dynamic.Interface
 	config    *rest.Config
 	k8sConfig storage.KubernetesConfig
 	nodeID    string
@@ -44,6 +46,9 @@ type KubernetesManager struct {
 
 	// Credentials
 	credentials *Credentials
+
+	// warnings are non-fatal problems found while validating or deploying
+	warnings []string
 }
 
 // NewKubernetesManager creates a new Kubernetes PostgreSQL manager.
@@ -83,6 +88,13 @@ func NewKubernetesManager(cfg LifecycleConfig, nodeID string, creds *Credentials
 	}
 	km.clientset = clientset
 
+	// Create dynamic client for CNPG custom resources
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes dynamic client: %w", err)
+	}
+	km.dynamic = dynamicClient
+
 	// Determine namespace
 	if err := km.determineNamespace(); err != nil {
 		return nil, err
@@ -147,11 +159,12 @@ func (km *KubernetesManager) ValidatePrerequisites(ctx context.Context) error {
 		}
 	}
 
-	// If using CNPG, check if operator is installed
-	if km.k8sConfig.UseCNPG {
-		if err := km.validateCNPGOperator(ctx); err != nil {
-			return fmt.Errorf("CNPG operator validation failed: %w", err)
-		}
+	// If using CNPG, check if operator is installed; without it a Cluster
+	// resource would never be reconciled, so fall back to a StatefulSet
+	if km.k8sConfig.UseCNPG && !km.cnpgOperatorInstalled() {
+		km.warnings = append(km.warnings, fmt.Sprintf(
+			"CloudNativePG operator not installed (%s clusters not served); deploying a StatefulSet instead", cnpgGroupVersion))
+		km.k8sConfig.UseCNPG = false
 	}
 
 	return nil
@@ -186,18 +199,6 @@ func (km *KubernetesManager) validateRBACPermissions(ctx context.Context) error
 	return nil
 }
 
-// validateCNPGOperator checks if CNPG operator is installed.
-func (km *KubernetesManager) validateCNPGOperator(ctx context.Context) error {
-	// Check if CNPG CRDs are installed by looking for deployments in cnpg-system namespace
-	_, err := km.clientset.AppsV1().Deployments("cnpg-system").List(ctx, metav1.ListOptions{
-		LabelSelector: "app.kubernetes.io/name=cloudnative-pg",
-	})
-	if err != nil {
-		return fmt.Errorf("CNPG operator not found: %w", err)
-	}
-	return nil
-}
-
 // Deploy deploys PostgreSQL to Kubernetes.
 func (km *KubernetesManager) Deploy(ctx context.Context) error {
 	// Create ServiceAccount and RBAC if needed
@@ -212,9 +213,11 @@ func (km *KubernetesManager) Deploy(ctx context.Context) error {
 		return fmt.Errorf("failed to create credentials Secret: %w", err)
 	}
 
-	// Create PVC for data
-	if err := km.createDataPVC(ctx); err != nil {
-		return fmt.Errorf("failed to create data PVC: %w", err)
+	// Create PVC for data (CNPG manages its own PVCs)
+	if !km.k8sConfig.UseCNPG {
+		if err := km.createDataPVC(ctx); err != nil {
+			return fmt.Errorf("failed to create data PVC: %w", err)
+		}
 	}
 
 	// Create Service
@@ -240,8 +243,8 @@ func (km *KubernetesManager) Deploy(ctx context.Context) error {
 		}
 	}
 
-	// Create backup CronJob if enabled
-	if km.k8sConfig.BackupEnabled && !km.k8sConfig.UseCNPG {
+	// Create backup CronJob if enabled (CNPG backs up to S3 itself)
+	if km.k8sConfig.BackupEnabled && !km.cnpgBackupToS3() {
 		if err := km.createBackupCronJob(ctx); err != nil {
 			return fmt.Errorf("failed to create backup CronJob: %w", err)
 		}
@@ -406,11 +409,8 @@ func (km *KubernetesManager) createService(ctx context.Context) error {
 			Labels:    km.getLabels(),
 		},
 		Spec: corev1.ServiceSpec{
-			Type: serviceType,
-			Selector: map[string]string{
-				"app":     "bibd-postgres",
-				"node-id": km.nodeID,
-			},
+			Type:     serviceType,
+			Selector: km.serviceSelector(),
 			Ports: []corev1.ServicePort{
 				{
 					Name:       "postgres",
@@ -435,6 +435,21 @@ func (km *KubernetesManager) createService(ctx context.Context) error {
 	return nil
 }
 
+// serviceSelector returns the pod selector of the Service. With CNPG only
+// the current primary is selected, so writes never reach a replica.
+func (km *KubernetesManager) serviceSelector() map[string]string {
+	if km.k8sConfig.UseCNPG {
+		return map[string]string{
+			"cnpg.io/cluster":      km.statefulSetName,
+			"cnpg.io/instanceRole": "primary",
+		}
+	}
+	return map[string]string{
+		"app":     "bibd-postgres",
+		"node-id": km.nodeID,
+	}
+}
+
 // createNetworkPolicy creates a NetworkPolicy to restrict access.
 func (km *KubernetesManager) createNetworkPolicy(ctx context.Context) error {
 	allowedLabels := km.k8sConfig.NetworkPolicyAllowedLabels
@@ -916,13 +931,6 @@ func (km *KubernetesManager) buildBackupCommand() string {
 	return fmt.Sprintf("%s && %s", rotateCmd, backupCmd)
 }
 
-// deployCNPGCluster deploys PostgreSQL using CloudNativePG operator.
-func (km *KubernetesManager) deployCNPGCluster(ctx context.Context) error {
-	// TODO: Implement CNPG Cluster deployment
-	// This requires defining the CNPG CRD types or using dynamic client
-	return fmt.Errorf("CNPG deployment not yet implemented")
-}
-
 // waitForReady waits for PostgreSQL to be ready.
 func (km *KubernetesManager) waitForReady(ctx context.Context) error {
 	timeout := time.After(5 * time.Minute)
@@ -936,6 +944,14 @@ func (km *KubernetesManager) waitForReady(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
+			if km.k8sConfig.UseCNPG {
+				ready, err := km.cnpgReadyInstances(ctx)
+				if err == nil && ready > 0 {
+					return nil
+				}
+				continue
+			}
+
 			// Check if StatefulSet is ready
 			sts, err := km.clientset.AppsV1().StatefulSets(km.namespace).Get(ctx, km.statefulSetName, metav1.GetOptions{})
 			if err != nil {
@@ -954,6 +970,10 @@ func (km *KubernetesManager) Cleanup(ctx context.Context) error {
 	// Delete CronJob
 	km.clientset.BatchV1().CronJobs(km.namespace).Delete(ctx, fmt.Sprintf("%s-backup", km.statefulSetName), metav1.DeleteOptions{})
 
+	if km.k8sConfig.UseCNPG {
+		km.cleanupCNPG(ctx)
+	}
+
 	if km.k8sConfig.DeleteOnCleanup {
 		// Delete StatefulSet
 		km.clientset.AppsV1().StatefulSets(km.namespace).Delete(ctx, km.statefulSetName, metav1.DeleteOptions{})
@@ -1024,6 +1044,11 @@ func (km *KubernetesManager) GetConnectionInfo(ctx context.Context) (string, int
 	return host, port, nil
 }
 
+// Warnings returns non-fatal problems found while validating or deploying.
+func (km *KubernetesManager) Warnings() []string {
+	return append([]string(nil), km.warnings...)
+}
+
 // getLabels returns standard labels for resources.
 func (km *KubernetesManager) getLabels() map[string]string {
 	labels := map[string]string{
@@ -16,6 +16,7 @@ import (
 	"sync"
 	"time"
 
+	"bib/internal/deploy"
 	"bib/internal/storage"
 	"bib/internal/storage/postgres/lifecycle/certs"
 )
@@ -37,6 +38,7 @@ const (
 	HealthActionShutdown    HealthAction = "shutdown"     // Shutdown bibd on failure
 	HealthActionRetryAlways HealthAction = "retry_always" // Keep retrying forever
 	HealthActionRetryLimit  HealthAction = "retry_limit"  // Retry up to MaxRetries
+	HealthActionFailover    HealthAction = "failover"     // Retry, then promote the standby after MaxRetries
 )
 
 // LifecycleConfig holds configuration for PostgreSQL lifecycle management.
@@ -44,6 +46,10 @@ type LifecycleConfig struct {
 	// Runtime is the container runtime type (auto-detected if empty)
 	Runtime RuntimeType `mapstructure:"runtime"`
 
+	// RuntimeFallback is the order in which the other runtimes are tried
+	// when Runtime fails to start, or are detected when it is empty
+	RuntimeFallback []RuntimeType `mapstructure:"runtime_fallback"`
+
 	// SocketPath is the path to the container runtime socket
 	// Auto-detected if empty
 	SocketPath string `mapstructure:"socket_path"`
@@ -55,9 +61,18 @@ type LifecycleConfig struct {
 	// Defaults to bibd-postgres-<node-id>
 	ContainerName string `mapstructure:"container_name"`
 
-	// Image is the PostgreSQL container image
+	// Image is the PostgreSQL container image. It may be pinned by digest,
+	// e.g. postgres:16-alpine@sha256:...
 	Image string `mapstructure:"image"`
 
+	// PullProgress receives the pull output while Start pulls a missing
+	// image (optional)
+	PullProgress func(line string) `mapstructure:"-"`
+
+	// OnFailover is called after every failover the failover health action
+	// attempts, successful or not (optional)
+	OnFailover func(event FailoverEvent) `mapstructure:"-"`
+
 	// DataDir is where PostgreSQL data is stored
 	DataDir string `mapstructure:"data_dir"`
 
@@ -126,6 +141,11 @@ type HealthConfig struct {
 
 	// RetryBackoff is the backoff duration between retries
 	RetryBackoff time.Duration `mapstructure:"retry_backoff"`
+
+	// Standby is the host:port of the streaming replica the failover action
+	// promotes when a container primary fails. On Kubernetes the failover
+	// action switches over to a healthy CNPG replica instead.
+	Standby string `mapstructure:"standby"`
 }
 
 // TLSConfig holds TLS configuration for PostgreSQL connections.
@@ -157,10 +177,11 @@ func DefaultLifecycleConfig() LifecycleConfig {
 	useUnixSocket := runtime.GOOS == "linux"
 
 	return LifecycleConfig{
-		Runtime:    "", // Auto-detect
-		SocketPath: "", // Auto-detect
-		Image:      "postgres:16-alpine",
-		Port:       5432,
+		Runtime:         "", // Auto-detect
+		RuntimeFallback: DefaultRuntimeFallback(),
+		SocketPath:      "", // Auto-detect
+		Image:           "postgres:16-alpine",
+		Port:            5432,
 		Network: NetworkConfig{
 			UseBridgeNetwork:  true,
 			BridgeNetworkName: "bibd-network",
@@ -214,6 +235,22 @@ type Manager struct {
 	healthErrors int
 	shutdownCh   chan struct{}
 	credentials  *Credentials
+	warnings     []string
+
+	// candidates are the runtimes Start tries, in order, and attempts
+	// records how each one it tried went
+	candidates []RuntimeType
+	attempts   []RuntimeAttempt
+
+	// promoted is set once the standby has been promoted; the store then
+	// connects to it instead of the container. failovers records every
+	// failover attempted.
+	promoted  bool
+	failovers []FailoverEvent
+
+	// startErr and lastHealthErr are reported by Diagnostics
+	startErr      error
+	lastHealthErr error
 
 	// New security components
 	credentialManager *CredentialManager
@@ -271,13 +308,6 @@ func NewManager(cfg LifecycleConfig, nodeID, dataDir string) (*Manager, error) {
 		shutdownCh: make(chan struct{}),
 	}
 
-	// Detect or validate runtime
-	rt, err := m.detectRuntime()
-	if err != nil {
-		return nil, fmt.Errorf("failed to detect runtime: %w", err)
-	}
-	m.runtime = rt
-
 	// Set defaults based on node ID
 	if m.cfg.ContainerName == "" {
 		m.cfg.ContainerName = fmt.Sprintf("bibd-postgres-%s", nodeID)
@@ -291,6 +321,14 @@ func NewManager(cfg LifecycleConfig, nodeID, dataDir string) (*Manager, error) {
 		m.cfg.TLS.CertDir = filepath.Join(m.cfg.DataDir, "certs")
 	}
 
+	// Order the runtimes to try; the recorded runtime needs DataDir
+	candidates, err := m.runtimeCandidates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect runtime: %w", err)
+	}
+	m.candidates = candidates
+	m.runtime = candidates[0]
+
 	if m.cfg.Credentials.EncryptedPath == "" {
 		m.cfg.Credentials.EncryptedPath = filepath.Join(dataDir, "secrets", "db.enc")
 	}
@@ -298,43 +336,6 @@ func NewManager(cfg LifecycleConfig, nodeID, dataDir string) (*Manager, error) {
 	return m, nil
 }
 
-// detectRuntime detects the available container runtime.
-func (m *Manager) detectRuntime() (RuntimeType, error) {
-	// If explicitly configured, validate and use it
-	if m.cfg.Runtime != "" {
-		switch m.cfg.Runtime {
-		case RuntimeDocker, RuntimePodman, RuntimeKubernetes, RuntimeManual:
-			return m.cfg.Runtime, nil
-		default:
-			return "", fmt.Errorf("unknown runtime: %s", m.cfg.Runtime)
-		}
-	}
-
-	// Auto-detect: check Kubernetes first
-	if m.isKubernetes() {
-		// But prefer container if both are available (per user requirement)
-		if m.isDockerAvailable() || m.isPodmanAvailable() {
-			// Container takes precedence over Kubernetes
-			if m.isDockerAvailable() {
-				return RuntimeDocker, nil
-			}
-			return RuntimePodman, nil
-		}
-		return RuntimeKubernetes, nil
-	}
-
-	// Check Docker first (preferred), then Podman
-	if m.isDockerAvailable() {
-		return RuntimeDocker, nil
-	}
-
-	if m.isPodmanAvailable() {
-		return RuntimePodman, nil
-	}
-
-	return "", fmt.Errorf("no container runtime found; install Docker or Podman, or configure manual PostgreSQL (tried: Docker='docker info', Podman='podman info')")
-}
-
 // isKubernetes checks if running in Kubernetes.
 func (m *Manager) isKubernetes() bool {
 	// Check environment variable
@@ -427,10 +428,14 @@ func (m *Manager) isPodmanAvailable() bool {
 	return false
 }
 
-// Start starts the PostgreSQL instance and waits for it to be ready.
-func (m *Manager) Start(ctx context.Context) error {
+// Start starts the PostgreSQL instance and waits for it to be ready. It
+// tries the runtimes in order (see runtimeCandidates) until one starts;
+// Attempts reports how each went. If all fail, Diagnostics explains why
+// the last one did.
+func (m *Manager) Start(ctx context.Context) (err error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	defer func() { m.startErr = err }()
 
 	// Create necessary directories
 	if err := m.createDirectories(); err != nil {
@@ -455,7 +460,21 @@ func (m *Manager) Start(ctx context.Context) error {
 	// 	return fmt.Errorf("failed to generate PostgreSQL config: %w", err)
 	// }
 
-	// Start based on runtime
+	// Try the runtimes in order until one starts
+	if err := m.startFirstRuntime(ctx); err != nil {
+		return err
+	}
+
+	m.ready = true
+
+	// Start health check goroutine
+	go m.healthCheckLoop()
+
+	return nil
+}
+
+// startRuntime starts PostgreSQL on m.runtime and waits for it to be ready.
+func (m *Manager) startRuntime(ctx context.Context) error {
 	switch m.runtime {
 	case RuntimeDocker:
 		if err := m.startDocker(ctx); err != nil {
@@ -485,11 +504,6 @@ func (m *Manager) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize database roles: %w", err)
 	}
 
-	m.ready = true
-
-	// Start health check goroutine
-	go m.healthCheckLoop()
-
 	return nil
 }
 
@@ -728,6 +742,13 @@ func (m *Manager) startPodman(ctx context.Context) error {
 
 // startContainer starts PostgreSQL using the specified container runtime.
 func (m *Manager) startContainer(ctx context.Context, runtime string) error {
+	// Pull the image up front, so a first start shows progress instead of
+	// blocking in run, and an unreachable registry fails before the existing
+	// container is removed
+	if _, err := deploy.PullImage(ctx, runtime, m.cfg.Image, m.cfg.PullProgress); err != nil {
+		return fmt.Errorf("failed to pull PostgreSQL image: %w", err)
+	}
+
 	// Check if container already exists
 	checkCmd := exec.CommandContext(ctx, runtime, "container", "inspect", m.cfg.ContainerName)
 	containerExists := checkCmd.Run() == nil
@@ -777,17 +798,14 @@ func (m *Manager) startContainer(ctx context.Context, runtime string) error {
 	}
 
 	// Create network if needed
-	if m.cfg.Network.UseBridgeNetwork {
-		networkCmd := exec.CommandContext(ctx, runtime, "network", "create", m.cfg.Network.BridgeNetworkName)
-		networkCmd.Run() // Ignore error if network already exists
-	}
+	network := m.containerNetwork(ctx, runtime)
 
 	// Build run command
 	args := []string{"run", "-d", "--name", m.cfg.ContainerName}
 
 	// Network
-	if m.cfg.Network.UseBridgeNetwork {
-		args = append(args, "--network", m.cfg.Network.BridgeNetworkName)
+	if network != "" {
+		args = append(args, "--network", network)
 	}
 
 	// Don't expose ports externally - use Unix socket or localhost only
@@ -858,6 +876,59 @@ func (m *Manager) startContainer(ctx context.Context, runtime string) error {
 	return nil
 }
 
+// containerNetwork returns the network to attach the container to, creating
+// the bridge network if configured. Rootless Podman may be unable to create
+// bridge networks (e.g. CNI without rootless support), so instead of failing
+// the start this falls back to the runtime's default network, which for
+// rootless Podman is slirp4netns. Ports are still published on localhost only.
+func (m *Manager) containerNetwork(ctx context.Context, runtime string) string {
+	if !m.cfg.Network.UseBridgeNetwork {
+		return ""
+	}
+	name := m.cfg.Network.BridgeNetworkName
+
+	if exec.CommandContext(ctx, runtime, "network", "inspect", name).Run() == nil {
+		return name
+	}
+
+	output, err := exec.CommandContext(ctx, runtime, "network", "create", name).CombinedOutput()
+	if err == nil || strings.Contains(string(output), "already exists") {
+		return name
+	}
+
+	fallback := ""
+	mode := "the default"
+	if runtime == "podman" && isRootlessPodman(ctx) {
+		fallback = "slirp4netns"
+		mode = "slirp4netns"
+	}
+	m.addWarning(fmt.Sprintf("bridge network %q unavailable (%s); using %s network",
+		name, strings.TrimSpace(string(output)), mode))
+	return fallback
+}
+
+// isRootlessPodman reports whether podman runs rootless for this user.
+func isRootlessPodman(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "podman", "info", "--format", "{{.Host.Security.Rootless}}").Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// addWarning records a non-fatal problem encountered while starting. It is
+// only called from Start, which holds m.mu.
+func (m *Manager) addWarning(msg string) {
+	m.warnings = append(m.warnings, msg)
+}
+
+// Warnings returns non-fatal problems encountered while starting, such as
+// a configured network that could not be used.
+func (m *Manager) Warnings() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string(nil), m.warnings...)
+}
+
 // startKubernetes starts PostgreSQL in Kubernetes.
 func (m *Manager) startKubernetes(ctx context.Context) error {
 	// Create Kubernetes manager
@@ -870,15 +941,18 @@ func (m *Manager) startKubernetes(ctx context.Context) error {
 	if err := k8sMgr.ValidatePrerequisites(ctx); err != nil {
 		return fmt.Errorf("Kubernetes prerequisites validation failed: %w", err)
 	}
+	for _, w := range k8sMgr.Warnings() {
+		m.addWarning(w)
+	}
+
+	// Store manager for later use, and for Diagnostics if deploying fails
+	m.k8sManager = k8sMgr
 
 	// Deploy PostgreSQL
 	if err := k8sMgr.Deploy(ctx); err != nil {
 		return fmt.Errorf("failed to deploy PostgreSQL to Kubernetes: %w", err)
 	}
 
-	// Store manager for later use
-	m.k8sManager = k8sMgr
-
 	return nil
 }
 
@@ -893,7 +967,8 @@ func (m *Manager) waitForReady(ctx context.Context) error {
 		default:
 		}
 
-		if m.checkHealth(ctx) {
+		err := m.checkHealth(ctx)
+		if err == nil {
 			// Add a small delay to ensure PostgreSQL is fully ready to accept connections
 			// pg_isready can return true slightly before PostgreSQL is fully initialized
 			time.Sleep(2 * time.Second)
@@ -907,6 +982,7 @@ func (m *Manager) waitForReady(ctx context.Context) error {
 
 			return nil
 		}
+		m.lastHealthErr = err
 
 		time.Sleep(time.Second)
 	}
@@ -914,8 +990,9 @@ func (m *Manager) waitForReady(ctx context.Context) error {
 	return fmt.Errorf("PostgreSQL did not become ready within %s", m.cfg.Health.StartupTimeout)
 }
 
-// checkHealth checks if PostgreSQL is healthy.
-func (m *Manager) checkHealth(ctx context.Context) bool {
+// checkHealth checks if PostgreSQL is healthy. The error carries the output
+// of the failed check.
+func (m *Manager) checkHealth(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.Health.Timeout)
 	defer cancel()
 
@@ -928,10 +1005,16 @@ func (m *Manager) checkHealth(ctx context.Context) bool {
 	default:
 		// For manual mode, try to connect directly
 		// TODO: Implement direct connection check
-		return true
+		return nil
 	}
 
-	return cmd.Run() == nil
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
 }
 
 // initializeRoles creates database roles.
@@ -953,24 +1036,33 @@ func (m *Manager) healthCheckLoop() {
 			return
 		case <-ticker.C:
 			ctx, cancel := context.WithTimeout(context.Background(), m.cfg.Health.Timeout)
-			healthy := m.checkHealth(ctx)
+			err := m.checkPrimaryHealth(ctx)
 			cancel()
 
+			failover := false
 			m.mu.Lock()
-			if healthy {
+			if err == nil {
 				m.lastHealth = time.Now()
 				m.healthErrors = 0
 			} else {
+				m.lastHealthErr = err
 				m.healthErrors++
-				m.handleHealthFailure()
+				failover = m.handleHealthFailure()
 			}
 			m.mu.Unlock()
+
+			// Fail over without the lock; it can take a while and
+			// OnFailover may call back into the manager
+			if failover {
+				m.failover()
+			}
 		}
 	}
 }
 
-// handleHealthFailure handles health check failures.
-func (m *Manager) handleHealthFailure() {
+// handleHealthFailure handles health check failures. It returns true if the
+// primary should be failed over.
+func (m *Manager) handleHealthFailure() bool {
 	switch m.cfg.Health.Action {
 	case HealthActionShutdown:
 		// Signal shutdown
@@ -991,7 +1083,23 @@ func (m *Manager) handleHealthFailure() {
 	case HealthActionRetryAlways:
 		fmt.Printf("PostgreSQL health check failed, retrying (attempt %d)\n", m.healthErrors)
 		m.restartContainer()
+
+	case HealthActionFailover:
+		switch {
+		case m.promoted:
+			fmt.Printf("PostgreSQL standby health check failed %d times; no standby left to fail over to\n", m.healthErrors)
+		case m.healthErrors >= m.cfg.Health.MaxRetries:
+			fmt.Printf("PostgreSQL health check failed %d times (max: %d), failing over\n",
+				m.healthErrors, m.cfg.Health.MaxRetries)
+			return true
+		default:
+			fmt.Printf("PostgreSQL health check failed, retry %d/%d before failing over\n",
+				m.healthErrors, m.cfg.Health.MaxRetries)
+			m.restartContainer()
+		}
 	}
+
+	return false
 }
 
 // restartContainer attempts to restart the container.
@@ -1087,13 +1195,26 @@ func (m *Manager) IsReady() bool {
 	return m.ready
 }
 
-// Runtime returns the detected runtime type.
+// Runtime returns the runtime PostgreSQL was started with, or the first
+// one Start will try if it has not been started.
 func (m *Manager) Runtime() RuntimeType {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.runtime
 }
 
 // ConnectionString returns the connection string for the store.
 func (m *Manager) ConnectionString() string {
+	// After a failover, connect to the promoted standby
+	if m.standbyPromoted() {
+		host, port := m.standbyAddr()
+		return fmt.Sprintf("host=%s port=%d user=postgres password=%s dbname=bibd sslmode=prefer",
+			storage.QuoteConnValue(host),
+			port,
+			storage.QuoteConnValue(m.credentials.SuperuserPassword),
+		)
+	}
+
 	// For Kubernetes, get connection info from the manager
 	if m.runtime == RuntimeKubernetes && m.k8sManager != nil {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -1112,9 +1233,9 @@ func (m *Manager) ConnectionString() string {
 		}
 
 		return fmt.Sprintf("host=%s port=%d user=postgres password=%s dbname=bibd sslmode=%s",
-			host,
+			storage.QuoteConnValue(host),
 			port,
-			m.credentials.SuperuserPassword,
+			storage.QuoteConnValue(m.credentials.SuperuserPassword),
 			sslmode,
 		)
 	}
@@ -1122,9 +1243,9 @@ func (m *Manager) ConnectionString() string {
 	// For Docker/Podman
 	if m.cfg.Network.UseUnixSocket {
 		return fmt.Sprintf("host=%s port=%d user=postgres password=%s dbname=bibd sslmode=disable",
-			filepath.Join(m.cfg.DataDir, "run"),
+			storage.QuoteConnValue(filepath.Join(m.cfg.DataDir, "run")),
 			m.cfg.Port,
-			m.credentials.SuperuserPassword,
+			storage.QuoteConnValue(m.credentials.SuperuserPassword),
 		)
 	}
 
@@ -1132,9 +1253,9 @@ func (m *Manager) ConnectionString() string {
 	// The connection is local and the container isn't configured with SSL certificates
 	// TLS is only useful for remote connections
 	return fmt.Sprintf("host=%s port=%d user=postgres password=%s dbname=bibd sslmode=disable",
-		m.cfg.Network.BindAddress,
+		storage.QuoteConnValue(m.cfg.Network.BindAddress),
 		m.cfg.Port,
-		m.credentials.SuperuserPassword,
+		storage.QuoteConnValue(m.credentials.SuperuserPassword),
 	)
 }
 
@@ -0,0 +1,229 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// runtimeStateFile records, in the PostgreSQL data directory, the runtime
+// PostgreSQL last started with.
+const runtimeStateFile = "runtime.json"
+
+// DefaultRuntimeFallback returns the order in which runtimes are tried when
+// none is configured or the configured one fails: container runtimes first,
+// Kubernetes only when running in a cluster.
+func DefaultRuntimeFallback() []RuntimeType {
+	return []RuntimeType{RuntimeDocker, RuntimePodman, RuntimeKubernetes}
+}
+
+// RuntimeAttempt describes how one runtime fared in Start.
+type RuntimeAttempt struct {
+	// Runtime is the runtime that was tried.
+	Runtime RuntimeType `json:"runtime"`
+
+	// Skipped is set if the runtime was not available, so it was not tried.
+	Skipped bool `json:"skipped,omitempty"`
+
+	// Error is why the runtime failed, empty if it started.
+	Error string `json:"error,omitempty"`
+
+	// Diagnostics explains the failure of a runtime that was tried.
+	Diagnostics *Diagnostics `json:"diagnostics,omitempty"`
+}
+
+// runtimeState is the content of runtimeStateFile.
+type runtimeState struct {
+	// Runtime is the runtime PostgreSQL started with.
+	Runtime RuntimeType `json:"runtime"`
+
+	// Configured is the runtime that was configured at the time, so a
+	// changed configuration takes precedence over the record.
+	Configured RuntimeType `json:"configured,omitempty"`
+
+	StartedAt time.Time `json:"started_at"`
+}
+
+// validRuntime reports whether rt is a known runtime.
+func validRuntime(rt RuntimeType) bool {
+	switch rt {
+	case RuntimeDocker, RuntimePodman, RuntimeKubernetes, RuntimeManual:
+		return true
+	}
+	return false
+}
+
+// runtimeCandidates returns the runtimes Start tries, in order: the runtime
+// recorded by the last successful start, the configured runtime, then the
+// fallback list. The record keeps restarts on the runtime holding the data
+// even if another one was tried first; it is ignored once the configured
+// runtime changes. The manual runtime never falls back.
+func (m *Manager) runtimeCandidates() ([]RuntimeType, error) {
+	if m.cfg.Runtime != "" && !validRuntime(m.cfg.Runtime) {
+		return nil, fmt.Errorf("unknown runtime: %s", m.cfg.Runtime)
+	}
+	for _, rt := range m.cfg.RuntimeFallback {
+		if !validRuntime(rt) || rt == RuntimeManual {
+			return nil, fmt.Errorf("invalid fallback runtime: %s", rt)
+		}
+	}
+	if m.cfg.Runtime == RuntimeManual {
+		return []RuntimeType{RuntimeManual}, nil
+	}
+
+	fallback := m.cfg.RuntimeFallback
+	if m.cfg.Runtime == "" && len(fallback) == 0 {
+		fallback = DefaultRuntimeFallback()
+	}
+
+	var order []RuntimeType
+	if state, err := m.readRuntimeState(); err == nil && state.Configured == m.cfg.Runtime && validRuntime(state.Runtime) {
+		order = append(order, state.Runtime)
+	}
+	if m.cfg.Runtime != "" {
+		order = append(order, m.cfg.Runtime)
+	}
+	order = append(order, fallback...)
+
+	seen := make(map[RuntimeType]bool)
+	candidates := order[:0]
+	for _, rt := range order {
+		if !seen[rt] {
+			seen[rt] = true
+			candidates = append(candidates, rt)
+		}
+	}
+
+	return candidates, nil
+}
+
+// runtimeAvailable reports whether a fallback runtime can be tried. The
+// configured and recorded runtimes are always tried, so their own errors
+// explain why they are unusable.
+func (m *Manager) runtimeAvailable(rt RuntimeType) bool {
+	switch rt {
+	case RuntimeDocker:
+		return m.isDockerAvailable()
+	case RuntimePodman:
+		return m.isPodmanAvailable()
+	case RuntimeKubernetes:
+		return m.isKubernetes()
+	}
+	return false
+}
+
+// startFirstRuntime tries the candidate runtimes in order until one starts,
+// recording every attempt, and records the runtime that started. The caller
+// must hold m.mu.
+func (m *Manager) startFirstRuntime(ctx context.Context) error {
+	state, _ := m.readRuntimeState()
+
+	m.attempts = nil
+	var lastErr error
+	for i, rt := range m.candidates {
+		required := rt == m.cfg.Runtime || (state != nil && rt == state.Runtime)
+		if !required && !m.runtimeAvailable(rt) {
+			m.attempts = append(m.attempts, RuntimeAttempt{Runtime: rt, Skipped: true})
+			continue
+		}
+
+		m.runtime = rt
+		m.k8sManager = nil
+		m.lastHealthErr = nil
+
+		err := m.startRuntime(ctx)
+		if err == nil {
+			m.attempts = append(m.attempts, RuntimeAttempt{Runtime: rt})
+			m.recordRuntime(state)
+			return nil
+		}
+
+		// Diagnose before stopping the failed instance, which removes its state
+		m.startErr = err
+		diag := m.diagnosticsState()
+		m.gatherDiagnostics(ctx, diag, rt, m.k8sManager)
+		m.attempts = append(m.attempts, RuntimeAttempt{Runtime: rt, Error: err.Error(), Diagnostics: diag})
+		lastErr = fmt.Errorf("%s: %w", rt, err)
+
+		// Free the port for the next runtime, keeping the last one for inspection
+		if i < len(m.candidates)-1 {
+			m.stopFailedContainer(rt)
+		}
+	}
+
+	if lastErr == nil {
+		var tried []string
+		for _, a := range m.attempts {
+			tried = append(tried, string(a.Runtime))
+		}
+		return fmt.Errorf("no container runtime found; install Docker or Podman, or configure manual PostgreSQL (tried: %s)", strings.Join(tried, ", "))
+	}
+	return lastErr
+}
+
+// stopFailedContainer stops the container of a runtime that failed to
+// start. The data directory is bind mounted, so nothing is lost.
+func (m *Manager) stopFailedContainer(rt RuntimeType) {
+	if rt != RuntimeDocker && rt != RuntimePodman {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_ = exec.CommandContext(ctx, string(rt), "stop", "-t", "10", m.cfg.ContainerName).Run()
+}
+
+// recordRuntime records the runtime that started in runtimeStateFile, and
+// warns if it differs from the recorded one. The caller must hold m.mu.
+func (m *Manager) recordRuntime(previous *runtimeState) {
+	if previous != nil && previous.Runtime != m.runtime {
+		msg := fmt.Sprintf("PostgreSQL started with %s instead of %s, which it last ran on", m.runtime, previous.Runtime)
+		if previous.Runtime == RuntimeKubernetes || m.runtime == RuntimeKubernetes {
+			msg += "; the data of the previous runtime is not available on this one"
+		}
+		m.addWarning(msg)
+	}
+
+	data, err := json.Marshal(runtimeState{
+		Runtime:    m.runtime,
+		Configured: m.cfg.Runtime,
+		StartedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(filepath.Join(m.cfg.DataDir, runtimeStateFile), data, 0600); err != nil {
+		m.addWarning(fmt.Sprintf("failed to record the runtime: %v", err))
+	}
+}
+
+// readRuntimeState reads runtimeStateFile.
+func (m *Manager) readRuntimeState() (*runtimeState, error) {
+	data, err := os.ReadFile(filepath.Join(m.cfg.DataDir, runtimeStateFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var state runtimeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	if state.Runtime == "" {
+		return nil, errors.New("no runtime recorded")
+	}
+	return &state, nil
+}
+
+// Attempts returns how each runtime fared in the last Start, in the order
+// they were tried.
+func (m *Manager) Attempts() []RuntimeAttempt {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]RuntimeAttempt(nil), m.attempts...)
+}
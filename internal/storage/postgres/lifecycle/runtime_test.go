@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRuntimeCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		runtime  RuntimeType
+		fallback []RuntimeType
+		recorded *runtimeState
+		want     []RuntimeType
+	}{
+		{
+			name: "auto-detect uses the default order",
+			want: []RuntimeType{RuntimeDocker, RuntimePodman, RuntimeKubernetes},
+		},
+		{
+			name:     "configured runtime comes first",
+			runtime:  RuntimeKubernetes,
+			fallback: DefaultRuntimeFallback(),
+			want:     []RuntimeType{RuntimeKubernetes, RuntimeDocker, RuntimePodman},
+		},
+		{
+			name:    "configured runtime without fallback",
+			runtime: RuntimePodman,
+			want:    []RuntimeType{RuntimePodman},
+		},
+		{
+			name:     "recorded runtime keeps restarts stable",
+			runtime:  RuntimeKubernetes,
+			fallback: DefaultRuntimeFallback(),
+			recorded: &runtimeState{Runtime: RuntimePodman, Configured: RuntimeKubernetes},
+			want:     []RuntimeType{RuntimePodman, RuntimeKubernetes, RuntimeDocker},
+		},
+		{
+			name:     "changed configuration ignores the record",
+			runtime:  RuntimeDocker,
+			fallback: DefaultRuntimeFallback(),
+			recorded: &runtimeState{Runtime: RuntimePodman, Configured: RuntimeKubernetes},
+			want:     []RuntimeType{RuntimeDocker, RuntimePodman, RuntimeKubernetes},
+		},
+		{
+			name:     "manual never falls back",
+			runtime:  RuntimeManual,
+			fallback: DefaultRuntimeFallback(),
+			recorded: &runtimeState{Runtime: RuntimeDocker, Configured: RuntimeManual},
+			want:     []RuntimeType{RuntimeManual},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.recorded != nil {
+				data, _ := json.Marshal(tt.recorded)
+				if err := os.WriteFile(filepath.Join(dir, runtimeStateFile), data, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			m := &Manager{cfg: LifecycleConfig{Runtime: tt.runtime, RuntimeFallback: tt.fallback, DataDir: dir}}
+			got, err := m.runtimeCandidates()
+			if err != nil {
+				t.Fatalf("runtimeCandidates() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("runtimeCandidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeCandidates_Invalid(t *testing.T) {
+	for _, cfg := range []LifecycleConfig{
+		{Runtime: "containerd"},
+		{RuntimeFallback: []RuntimeType{RuntimeDocker, "lxc"}},
+		{RuntimeFallback: []RuntimeType{RuntimeManual}},
+	} {
+		cfg.DataDir = t.TempDir()
+		m := &Manager{cfg: cfg}
+		if _, err := m.runtimeCandidates(); err == nil {
+			t.Errorf("runtimeCandidates() with %+v succeeded", cfg)
+		}
+	}
+}
+
+func TestRecordRuntime(t *testing.T) {
+	m := &Manager{
+		runtime: RuntimeDocker,
+		cfg:     LifecycleConfig{DataDir: t.TempDir()},
+	}
+
+	m.recordRuntime(&runtimeState{Runtime: RuntimeKubernetes})
+
+	state, err := m.readRuntimeState()
+	if err != nil {
+		t.Fatalf("readRuntimeState() error = %v", err)
+	}
+	if state.Runtime != RuntimeDocker || state.Configured != "" {
+		t.Errorf("recorded %+v", state)
+	}
+	if len(m.warnings) != 1 {
+		t.Errorf("switching from Kubernetes was not warned about: %v", m.warnings)
+	}
+}
@@ -266,7 +266,7 @@ func (rm *RoleManager) VerifyRoleExists(ctx context.Context, conn *pgx.Conn, rol
 // VerifyRolePassword verifies that a role can authenticate with the given password.
 func (rm *RoleManager) VerifyRolePassword(ctx context.Context, connString string, role storage.DBRole, password string) error {
 	// Build connection string with role credentials
-	testConnString := fmt.Sprintf("%s user=%s password=%s", connString, role, password)
+	testConnString := fmt.Sprintf("%s user=%s password=%s", connString, role, storage.QuoteConnValue(password))
 
 	conn, err := pgx.Connect(ctx, testConnString)
 	if err != nil {
@@ -2,8 +2,12 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 
+	"bib/internal/domain"
 	"bib/internal/storage"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // SavedQueryRepository implements storage.SavedQueryRepository for PostgreSQL.
@@ -11,38 +15,234 @@ type SavedQueryRepository struct {
 	store *Store
 }
 
+const savedQueryColumns = `id, user_id, name, description, query, parameters, tags, is_public, use_count, created_at, updated_at`
+
 // Create creates a new saved query.
 func (r *SavedQueryRepository) Create(ctx context.Context, query *storage.SavedQuery) error {
-	// TODO: Implement when Phase 3 CEL query system is ready
+	_, err := r.store.execWithAudit(ctx, "INSERT", "saved_queries", `
+		INSERT INTO saved_queries (`+savedQueryColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`,
+		query.ID,
+		string(query.UserID),
+		query.Name,
+		nullableString(query.Description),
+		query.Query,
+		query.Parameters,
+		query.Tags,
+		query.IsPublic,
+		query.UseCount,
+		query.CreatedAt,
+		query.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create saved query: %w", err)
+	}
+
 	return nil
 }
 
 // Get retrieves a saved query by ID.
 func (r *SavedQueryRepository) Get(ctx context.Context, id string) (*storage.SavedQuery, error) {
-	// TODO: Implement when Phase 3 CEL query system is ready
-	return nil, storage.ErrNotFound
+	return r.getOne(ctx, "id = $1", id)
+}
+
+// GetByName retrieves a user's saved query by name.
+func (r *SavedQueryRepository) GetByName(ctx context.Context, userID domain.UserID, name string) (*storage.SavedQuery, error) {
+	return r.getOne(ctx, "user_id = $1 AND name = $2", string(userID), name)
+}
+
+func (r *SavedQueryRepository) getOne(ctx context.Context, where string, args ...any) (*storage.SavedQuery, error) {
+	rows, err := r.store.queryWithAudit(ctx, "saved_queries",
+		"SELECT "+savedQueryColumns+" FROM saved_queries WHERE "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved query: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, storage.ErrNotFound
+	}
+
+	return scanSavedQuery(rows)
 }
 
 // Update updates a saved query.
 func (r *SavedQueryRepository) Update(ctx context.Context, query *storage.SavedQuery) error {
-	// TODO: Implement when Phase 3 CEL query system is ready
+	rowsAffected, err := r.store.execWithAudit(ctx, "UPDATE", "saved_queries", `
+		UPDATE saved_queries SET
+			name = $1,
+			description = $2,
+			query = $3,
+			parameters = $4,
+			tags = $5,
+			is_public = $6,
+			updated_at = $7
+		WHERE id = $8
+	`,
+		query.Name,
+		nullableString(query.Description),
+		query.Query,
+		query.Parameters,
+		query.Tags,
+		query.IsPublic,
+		query.UpdatedAt,
+		query.ID,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to update saved query: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
 	return nil
 }
 
 // Delete deletes a saved query.
 func (r *SavedQueryRepository) Delete(ctx context.Context, id string) error {
-	// TODO: Implement when Phase 3 CEL query system is ready
+	rowsAffected, err := r.store.execWithAudit(ctx, "DELETE", "saved_queries", `
+		DELETE FROM saved_queries WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved query: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// IncrementUseCount records that a saved query was run.
+func (r *SavedQueryRepository) IncrementUseCount(ctx context.Context, id string) error {
+	rowsAffected, err := r.store.execWithAudit(ctx, "UPDATE", "saved_queries", `
+		UPDATE saved_queries SET use_count = use_count + 1 WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to update saved query: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
 	return nil
 }
 
 // List lists saved queries with optional filtering.
 func (r *SavedQueryRepository) List(ctx context.Context, filter storage.SavedQueryFilter) ([]*storage.SavedQuery, error) {
-	// TODO: Implement when Phase 3 CEL query system is ready
-	return []*storage.SavedQuery{}, nil
+	where, args := savedQueryWhere(filter)
+	query := "SELECT " + savedQueryColumns + " FROM saved_queries WHERE " + where + " ORDER BY name, created_at"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.store.queryWithAudit(ctx, "saved_queries", query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved queries: %w", err)
+	}
+	defer rows.Close()
+
+	var queries []*storage.SavedQuery
+	for rows.Next() {
+		q, err := scanSavedQuery(rows)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+
+	return queries, rows.Err()
 }
 
 // Count returns the count of saved queries matching a filter.
 func (r *SavedQueryRepository) Count(ctx context.Context, filter storage.SavedQueryFilter) (int64, error) {
-	// TODO: Implement when Phase 3 CEL query system is ready
-	return 0, nil
+	where, args := savedQueryWhere(filter)
+
+	var count int64
+	err := r.store.queryRowWithAudit(ctx, "saved_queries", "SELECT COUNT(*) FROM saved_queries WHERE "+where, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count saved queries: %w", err)
+	}
+
+	return count, nil
+}
+
+// savedQueryWhere builds the WHERE clause for a filter.
+func savedQueryWhere(filter storage.SavedQueryFilter) (string, []any) {
+	where := "1=1"
+	args := []any{}
+
+	switch {
+	case filter.UserID != nil && filter.IncludePublic:
+		args = append(args, string(*filter.UserID))
+		where += fmt.Sprintf(" AND (user_id = $%d OR is_public)", len(args))
+	case filter.UserID != nil:
+		args = append(args, string(*filter.UserID))
+		where += fmt.Sprintf(" AND user_id = $%d", len(args))
+	}
+
+	if filter.PublicOnly {
+		where += " AND is_public"
+	}
+
+	if filter.Name != "" {
+		args = append(args, filter.Name)
+		where += fmt.Sprintf(" AND name = $%d", len(args))
+	}
+
+	if len(filter.Tags) > 0 {
+		args = append(args, filter.Tags)
+		where += fmt.Sprintf(" AND tags @> $%d", len(args))
+	}
+
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		n := len(args)
+		where += fmt.Sprintf(" AND (name ILIKE $%d OR description ILIKE $%d OR query ILIKE $%d)", n, n, n)
+	}
+
+	return where, args
+}
+
+func scanSavedQuery(rows pgx.Rows) (*storage.SavedQuery, error) {
+	var (
+		q           storage.SavedQuery
+		userID      string
+		description *string
+		createdAt   interface{}
+		updatedAt   interface{}
+	)
+
+	err := rows.Scan(
+		&q.ID, &userID, &q.Name, &description, &q.Query, &q.Parameters,
+		&q.Tags, &q.IsPublic, &q.UseCount, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan saved query: %w", err)
+	}
+
+	q.UserID = domain.UserID(userID)
+	if description != nil {
+		q.Description = *description
+	}
+	q.CreatedAt = parseTime(createdAt)
+	q.UpdatedAt = parseTime(updatedAt)
+
+	return &q, nil
 }
@@ -25,7 +25,7 @@ func (r *SessionRepository) Create(ctx context.Context, session *storage.Session
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	_, err = r.store.pool.Exec(ctx, `
+	_, err = r.store.conn(ctx).Exec(ctx, `
 		INSERT INTO sessions (id, user_id, type, client_ip, client_agent, public_key_fingerprint, node_id, started_at, ended_at, last_activity_at, metadata)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`,
@@ -51,7 +51,7 @@ func (r *SessionRepository) Create(ctx context.Context, session *storage.Session
 
 // Get retrieves a session by ID.
 func (r *SessionRepository) Get(ctx context.Context, id string) (*storage.Session, error) {
-	row := r.store.pool.QueryRow(ctx, `
+	row := r.store.conn(ctx).QueryRow(ctx, `
 		SELECT id, user_id, type, client_ip, client_agent, public_key_fingerprint, node_id, started_at, ended_at, last_activity_at, metadata
 		FROM sessions WHERE id = $1
 	`, id)
@@ -61,7 +61,7 @@ func (r *SessionRepository) Get(ctx context.Context, id string) (*storage.Sessio
 
 // GetByUser retrieves all active sessions for a user.
 func (r *SessionRepository) GetByUser(ctx context.Context, userID domain.UserID) ([]*storage.Session, error) {
-	rows, err := r.store.pool.Query(ctx, `
+	rows, err := r.store.conn(ctx).Query(ctx, `
 		SELECT id, user_id, type, client_ip, client_agent, public_key_fingerprint, node_id, started_at, ended_at, last_activity_at, metadata
 		FROM sessions WHERE user_id = $1 AND ended_at IS NULL
 		ORDER BY started_at DESC
@@ -90,7 +90,7 @@ func (r *SessionRepository) Update(ctx context.Context, session *storage.Session
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	result, err := r.store.pool.Exec(ctx, `
+	result, err := r.store.conn(ctx).Exec(ctx, `
 		UPDATE sessions SET
 			ended_at = $1,
 			last_activity_at = $2,
@@ -116,7 +116,7 @@ func (r *SessionRepository) Update(ctx context.Context, session *storage.Session
 // End marks a session as ended.
 func (r *SessionRepository) End(ctx context.Context, id string) error {
 	now := time.Now().UTC()
-	result, err := r.store.pool.Exec(ctx, `
+	result, err := r.store.conn(ctx).Exec(ctx, `
 		UPDATE sessions SET ended_at = $1, last_activity_at = $1 WHERE id = $2 AND ended_at IS NULL
 	`, now, id)
 	if err != nil {
@@ -133,7 +133,7 @@ func (r *SessionRepository) End(ctx context.Context, id string) error {
 // EndAllForUser ends all sessions for a user.
 func (r *SessionRepository) EndAllForUser(ctx context.Context, userID domain.UserID) error {
 	now := time.Now().UTC()
-	_, err := r.store.pool.Exec(ctx, `
+	_, err := r.store.conn(ctx).Exec(ctx, `
 		UPDATE sessions SET ended_at = $1, last_activity_at = $1 WHERE user_id = $2 AND ended_at IS NULL
 	`, now, string(userID))
 	if err != nil {
@@ -208,7 +208,7 @@ func (r *SessionRepository) List(ctx context.Context, filter storage.SessionFilt
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.pool.Query(ctx, query, args...)
+	rows, err := r.store.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sessions: %w", err)
 	}
@@ -228,7 +228,7 @@ func (r *SessionRepository) List(ctx context.Context, filter storage.SessionFilt
 
 // Cleanup removes expired sessions older than the given time.
 func (r *SessionRepository) Cleanup(ctx context.Context, before time.Time) (int64, error) {
-	result, err := r.store.pool.Exec(ctx, `
+	result, err := r.store.conn(ctx).Exec(ctx, `
 		DELETE FROM sessions WHERE ended_at IS NOT NULL AND ended_at < $1
 	`, before)
 	if err != nil {
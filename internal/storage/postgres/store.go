@@ -26,6 +26,9 @@ type Store struct {
 	// Role-specific pools for permission isolation
 	pools map[storage.DBRole]*pgxpool.Pool
 
+	// target overrides the server new connections go to, see Retarget
+	target *connTarget
+
 	topics           *TopicRepository
 	datasets         *DatasetRepository
 	jobs             *JobRepository
@@ -48,17 +51,18 @@ type Store struct {
 // New creates a new PostgreSQL store.
 func New(ctx context.Context, cfg storage.PostgresConfig, dataDir, nodeID string) (*Store, error) {
 	var pool *pgxpool.Pool
+	target := &connTarget{}
 
 	if cfg.Advanced != nil {
 		// Use advanced/manual configuration (for testing only)
 		connString := fmt.Sprintf(
 			"host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
-			cfg.Advanced.Host,
+			storage.QuoteConnValue(cfg.Advanced.Host),
 			cfg.Advanced.Port,
-			cfg.Advanced.Database,
-			cfg.Advanced.User,
-			cfg.Advanced.Password,
-			cfg.Advanced.SSLMode,
+			storage.QuoteConnValue(cfg.Advanced.Database),
+			storage.QuoteConnValue(cfg.Advanced.User),
+			storage.QuoteConnValue(cfg.Advanced.Password),
+			storage.QuoteConnValue(cfg.Advanced.SSLMode),
 		)
 
 		poolConfig, err := pgxpool.ParseConfig(connString)
@@ -70,6 +74,7 @@ func New(ctx context.Context, cfg storage.PostgresConfig, dataDir, nodeID string
 		if poolConfig.MaxConns == 0 {
 			poolConfig.MaxConns = 20
 		}
+		poolConfig.BeforeConnect = target.apply
 
 		var poolErr error
 		pool, poolErr = pgxpool.NewWithConfig(ctx, poolConfig)
@@ -95,6 +100,7 @@ func New(ctx context.Context, cfg storage.PostgresConfig, dataDir, nodeID string
 		cfg:    cfg,
 		nodeID: nodeID,
 		pools:  make(map[storage.DBRole]*pgxpool.Pool),
+		target: target,
 	}
 
 	// Initialize repositories
@@ -310,6 +316,28 @@ func (s *Store) Stats(ctx context.Context) (storage.StorageStats, error) {
 	return stats, nil
 }
 
+// ConnectionStats returns the statistics of the main and role-specific
+// connection pools combined.
+func (s *Store) ConnectionStats() storage.ConnectionStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var stats storage.ConnectionStats
+	add := func(pool *pgxpool.Pool) {
+		poolStats := pool.Stat()
+		stats.InUse += int(poolStats.AcquiredConns())
+		stats.Idle += int(poolStats.IdleConns())
+		stats.Max += int(poolStats.MaxConns())
+	}
+	if s.pool != nil {
+		add(s.pool)
+	}
+	for _, pool := range s.pools {
+		add(pool)
+	}
+	return stats
+}
+
 // Pool returns the main connection pool.
 func (s *Store) Pool() *pgxpool.Pool {
 	return s.pool
@@ -336,17 +364,83 @@ func (s *Store) ConnString() string {
 		sslMode = "require"
 	}
 
+	host, port := cfg.Host, cfg.Port
+	if s.target != nil {
+		host, port = s.target.resolve(host, port)
+	}
+
 	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		cfg.User,
 		cfg.Password,
-		cfg.Host,
-		cfg.Port,
+		host,
+		port,
 		cfg.Database,
 		sslMode,
 	)
 }
 
+// Retarget makes new connections go to host:port, e.g. a standby promoted
+// after the primary failed, and closes the idle connections to the old
+// server; connections in use are closed when they are released. An empty
+// host keeps the server and only reconnects, for failovers behind a
+// stable address.
+func (s *Store) Retarget(host string, port int) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+	if s.target == nil {
+		return fmt.Errorf("store does not support retargeting")
+	}
+	if host != "" {
+		s.target.set(host, uint16(port))
+	}
+
+	s.pool.Reset()
+	for _, pool := range s.pools {
+		pool.Reset()
+	}
+	return nil
+}
+
+// connTarget is the server connections are redirected to. It is applied
+// to every new connection of the pool.
+type connTarget struct {
+	mu   sync.RWMutex
+	host string
+	port uint16
+}
+
+// set redirects new connections to host:port.
+func (t *connTarget) set(host string, port uint16) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.host, t.port = host, port
+}
+
+// resolve returns the server to connect to instead of host:port.
+func (t *connTarget) resolve(host string, port uint16) (string, uint16) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.host == "" {
+		return host, port
+	}
+	return t.host, t.port
+}
+
+// apply is the pool's BeforeConnect hook.
+func (t *connTarget) apply(ctx context.Context, cfg *pgx.ConnConfig) error {
+	host, port := t.resolve(cfg.Host, cfg.Port)
+	if host != cfg.Host || port != cfg.Port {
+		cfg.Host, cfg.Port = host, port
+		cfg.Fallbacks = nil
+	}
+	return nil
+}
+
 // execWithAudit executes a query and logs to audit.
 func (s *Store) execWithAudit(ctx context.Context, action, table, query string, args ...any) (int64, error) {
 	oc := storage.MustGetOperationContext(ctx)
@@ -355,10 +449,8 @@ func (s *Store) execWithAudit(ctx context.Context, action, table, query string,
 	// Add query comment for tagging
 	taggedQuery := oc.QueryComment() + " " + query
 
-	// Get appropriate pool for the role
-	pool := s.PoolForRole(oc.Role)
-
-	result, err := pool.Exec(ctx, taggedQuery, args...)
+	// Get appropriate pool for the role, unless in a transaction
+	result, err := s.connForRole(ctx, oc.Role).Exec(ctx, taggedQuery, args...)
 
 	duration := time.Since(start)
 
@@ -391,10 +483,8 @@ func (s *Store) queryWithAudit(ctx context.Context, table, query string, args ..
 	// Add query comment for tagging
 	taggedQuery := oc.QueryComment() + " " + query
 
-	// Get appropriate pool for the role
-	pool := s.PoolForRole(oc.Role)
-
-	rows, err := pool.Query(ctx, taggedQuery, args...)
+	// Get appropriate pool for the role, unless in a transaction
+	rows, err := s.connForRole(ctx, oc.Role).Query(ctx, taggedQuery, args...)
 
 	duration := time.Since(start)
 
@@ -425,12 +515,9 @@ func (s *Store) queryRowWithAudit(ctx context.Context, table, query string, args
 	// Add query comment for tagging
 	taggedQuery := oc.QueryComment() + " " + query
 
-	// Get appropriate pool for the role
-	pool := s.PoolForRole(oc.Role)
-
 	// Note: We can't easily audit single row queries without executing twice
 	// The audit happens after the scan in the caller
-	return pool.QueryRow(ctx, taggedQuery, args...)
+	return s.connForRole(ctx, oc.Role).QueryRow(ctx, taggedQuery, args...)
 }
 
 // DataDir returns the configured data directory.
@@ -21,13 +21,17 @@ type TopicInvitationRepository struct {
 func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.TopicInvitation) error {
 	query := `
 		INSERT INTO topic_invitations (
-			id, topic_id, inviter_id, invitee_email, invitee_user_id,
-			role, token, message, status, expires_at, created_at, responded_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+			role, token, message, status, expires_at, created_at, responded_at,
+			responded_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
-	var inviteeEmail, inviteeUserID, message *string
+	var inviteeEmail, inviteeUserID, message, respondedBy *string
 
+	if inv.Kind == "" {
+		inv.Kind = storage.InvitationKindInvite
+	}
 	if inv.InviteeEmail != "" {
 		inviteeEmail = &inv.InviteeEmail
 	}
@@ -38,9 +42,14 @@ func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.Top
 	if inv.Message != "" {
 		message = &inv.Message
 	}
+	if inv.RespondedBy != "" {
+		s := string(inv.RespondedBy)
+		respondedBy = &s
+	}
 
-	_, err := r.store.pool.Exec(ctx, query,
+	_, err := r.store.conn(ctx).Exec(ctx, query,
 		inv.ID,
+		string(inv.Kind),
 		string(inv.TopicID),
 		string(inv.InviterID),
 		inviteeEmail,
@@ -52,6 +61,7 @@ func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.Top
 		inv.ExpiresAt,
 		inv.CreatedAt,
 		inv.RespondedAt,
+		respondedBy,
 	)
 
 	return err
@@ -60,32 +70,35 @@ func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.Top
 // Get retrieves an invitation by ID.
 func (r *TopicInvitationRepository) Get(ctx context.Context, id string) (*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE id = $1
 	`
 
-	return r.scanInvitation(r.store.pool.QueryRow(ctx, query, id))
+	return r.scanInvitation(r.store.conn(ctx).QueryRow(ctx, query, id))
 }
 
 // GetByToken retrieves an invitation by token.
 func (r *TopicInvitationRepository) GetByToken(ctx context.Context, token string) (*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE token = $1
 	`
 
-	return r.scanInvitation(r.store.pool.QueryRow(ctx, query, token))
+	return r.scanInvitation(r.store.conn(ctx).QueryRow(ctx, query, token))
 }
 
 // ListByTopic lists invitations for a topic.
 func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID domain.TopicID, filter storage.InvitationFilter) ([]*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE topic_id = $1
 	`
@@ -97,6 +110,11 @@ func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID dom
 		args = append(args, string(filter.Status))
 		argNum++
 	}
+	if filter.Kind != "" {
+		query += " AND kind = $" + strconv.Itoa(argNum)
+		args = append(args, string(filter.Kind))
+		argNum++
+	}
 
 	query += " ORDER BY created_at DESC"
 
@@ -110,7 +128,7 @@ func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID dom
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.pool.Query(ctx, query, args...)
+	rows, err := r.store.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -122,14 +140,15 @@ func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID dom
 // ListByUser lists invitations for a user (as invitee).
 func (r *TopicInvitationRepository) ListByUser(ctx context.Context, userID domain.UserID) ([]*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE invitee_user_id = $1
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.store.pool.Query(ctx, query, string(userID))
+	rows, err := r.store.conn(ctx).Query(ctx, query, string(userID))
 	if err != nil {
 		return nil, err
 	}
@@ -141,14 +160,15 @@ func (r *TopicInvitationRepository) ListByUser(ctx context.Context, userID domai
 // ListByEmail lists invitations for an email address.
 func (r *TopicInvitationRepository) ListByEmail(ctx context.Context, email string) ([]*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE invitee_email = $1
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.store.pool.Query(ctx, query, email)
+	rows, err := r.store.conn(ctx).Query(ctx, query, email)
 	if err != nil {
 		return nil, err
 	}
@@ -161,13 +181,20 @@ func (r *TopicInvitationRepository) ListByEmail(ctx context.Context, email strin
 func (r *TopicInvitationRepository) Update(ctx context.Context, inv *storage.TopicInvitation) error {
 	query := `
 		UPDATE topic_invitations
-		SET status = $1, responded_at = $2
-		WHERE id = $3
+		SET status = $1, responded_at = $2, responded_by = $3
+		WHERE id = $4
 	`
 
-	_, err := r.store.pool.Exec(ctx, query,
+	var respondedBy *string
+	if inv.RespondedBy != "" {
+		s := string(inv.RespondedBy)
+		respondedBy = &s
+	}
+
+	_, err := r.store.conn(ctx).Exec(ctx, query,
 		string(inv.Status),
 		inv.RespondedAt,
+		respondedBy,
 		inv.ID,
 	)
 
@@ -176,7 +203,7 @@ func (r *TopicInvitationRepository) Update(ctx context.Context, inv *storage.Top
 
 // Delete removes an invitation.
 func (r *TopicInvitationRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.store.pool.Exec(ctx,
+	_, err := r.store.conn(ctx).Exec(ctx,
 		"DELETE FROM topic_invitations WHERE id = $1",
 		id,
 	)
@@ -185,7 +212,7 @@ func (r *TopicInvitationRepository) Delete(ctx context.Context, id string) error
 
 // ExpirePending expires all pending invitations that have passed their expiration.
 func (r *TopicInvitationRepository) ExpirePending(ctx context.Context) (int64, error) {
-	result, err := r.store.pool.Exec(ctx,
+	result, err := r.store.conn(ctx).Exec(ctx,
 		`UPDATE topic_invitations 
 		 SET status = 'expired' 
 		 WHERE status = 'pending' AND expires_at < $1`,
@@ -200,10 +227,11 @@ func (r *TopicInvitationRepository) ExpirePending(ctx context.Context) (int64, e
 // scanInvitation scans a single invitation from a row.
 func (r *TopicInvitationRepository) scanInvitation(row pgx.Row) (*storage.TopicInvitation, error) {
 	var inv storage.TopicInvitation
-	var inviteeEmail, inviteeUserID, message *string
+	var inviteeEmail, inviteeUserID, message, respondedBy *string
 
 	err := row.Scan(
 		&inv.ID,
+		&inv.Kind,
 		&inv.TopicID,
 		&inv.InviterID,
 		&inviteeEmail,
@@ -215,6 +243,7 @@ func (r *TopicInvitationRepository) scanInvitation(row pgx.Row) (*storage.TopicI
 		&inv.ExpiresAt,
 		&inv.CreatedAt,
 		&inv.RespondedAt,
+		&respondedBy,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -233,6 +262,9 @@ func (r *TopicInvitationRepository) scanInvitation(row pgx.Row) (*storage.TopicI
 	if message != nil {
 		inv.Message = *message
 	}
+	if respondedBy != nil {
+		inv.RespondedBy = domain.UserID(*respondedBy)
+	}
 
 	return &inv, nil
 }
@@ -243,10 +275,11 @@ func (r *TopicInvitationRepository) scanInvitations(rows pgx.Rows) ([]*storage.T
 
 	for rows.Next() {
 		var inv storage.TopicInvitation
-		var inviteeEmail, inviteeUserID, message *string
+		var inviteeEmail, inviteeUserID, message, respondedBy *string
 
 		err := rows.Scan(
 			&inv.ID,
+			&inv.Kind,
 			&inv.TopicID,
 			&inv.InviterID,
 			&inviteeEmail,
@@ -258,6 +291,7 @@ func (r *TopicInvitationRepository) scanInvitations(rows pgx.Rows) ([]*storage.T
 			&inv.ExpiresAt,
 			&inv.CreatedAt,
 			&inv.RespondedAt,
+			&respondedBy,
 		)
 		if err != nil {
 			return nil, err
@@ -272,6 +306,9 @@ func (r *TopicInvitationRepository) scanInvitations(rows pgx.Rows) ([]*storage.T
 		if message != nil {
 			inv.Message = *message
 		}
+		if respondedBy != nil {
+			inv.RespondedBy = domain.UserID(*respondedBy)
+		}
 
 		invitations = append(invitations, &inv)
 	}
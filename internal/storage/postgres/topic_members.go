@@ -25,12 +25,12 @@ func (r *TopicMemberRepository) Create(ctx context.Context, member *storage.Topi
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
-	_, err := r.store.pool.Exec(ctx, query,
+	_, err := r.store.conn(ctx).Exec(ctx, query,
 		member.ID,
 		string(member.TopicID),
 		string(member.UserID),
 		string(member.Role),
-		string(member.InvitedBy),
+		nullableString(string(member.InvitedBy)),
 		member.InvitedAt,
 		member.AcceptedAt,
 		member.CreatedAt,
@@ -49,7 +49,7 @@ func (r *TopicMemberRepository) Get(ctx context.Context, topicID domain.TopicID,
 		WHERE topic_id = $1 AND user_id = $2
 	`
 
-	return r.scanMember(r.store.pool.QueryRow(ctx, query, string(topicID), string(userID)))
+	return r.scanMember(r.store.conn(ctx).QueryRow(ctx, query, string(topicID), string(userID)))
 }
 
 // GetByID retrieves a membership by ID.
@@ -61,7 +61,7 @@ func (r *TopicMemberRepository) GetByID(ctx context.Context, id string) (*storag
 		WHERE id = $1
 	`
 
-	return r.scanMember(r.store.pool.QueryRow(ctx, query, id))
+	return r.scanMember(r.store.conn(ctx).QueryRow(ctx, query, id))
 }
 
 // ListByTopic lists all members of a topic.
@@ -91,7 +91,7 @@ func (r *TopicMemberRepository) ListByTopic(ctx context.Context, topicID domain.
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.pool.Query(ctx, query, args...)
+	rows, err := r.store.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +109,7 @@ func (r *TopicMemberRepository) ListByUser(ctx context.Context, userID domain.Us
 		WHERE user_id = $1
 	`
 
-	rows, err := r.store.pool.Query(ctx, query, string(userID))
+	rows, err := r.store.conn(ctx).Query(ctx, query, string(userID))
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +126,7 @@ func (r *TopicMemberRepository) Update(ctx context.Context, member *storage.Topi
 		WHERE id = $3
 	`
 
-	_, err := r.store.pool.Exec(ctx, query,
+	_, err := r.store.conn(ctx).Exec(ctx, query,
 		string(member.Role),
 		member.AcceptedAt,
 		member.ID,
@@ -137,7 +137,7 @@ func (r *TopicMemberRepository) Update(ctx context.Context, member *storage.Topi
 
 // Delete removes a membership.
 func (r *TopicMemberRepository) Delete(ctx context.Context, topicID domain.TopicID, userID domain.UserID) error {
-	_, err := r.store.pool.Exec(ctx,
+	_, err := r.store.conn(ctx).Exec(ctx,
 		"DELETE FROM topic_members WHERE topic_id = $1 AND user_id = $2",
 		string(topicID), string(userID),
 	)
@@ -147,7 +147,7 @@ func (r *TopicMemberRepository) Delete(ctx context.Context, topicID domain.Topic
 // CountOwners counts the number of owners for a topic.
 func (r *TopicMemberRepository) CountOwners(ctx context.Context, topicID domain.TopicID) (int, error) {
 	var count int
-	err := r.store.pool.QueryRow(ctx,
+	err := r.store.conn(ctx).QueryRow(ctx,
 		"SELECT COUNT(*) FROM topic_members WHERE topic_id = $1 AND role = 'owner'",
 		string(topicID),
 	).Scan(&count)
@@ -157,7 +157,7 @@ func (r *TopicMemberRepository) CountOwners(ctx context.Context, topicID domain.
 // HasAccess checks if a user has access to a topic.
 func (r *TopicMemberRepository) HasAccess(ctx context.Context, topicID domain.TopicID, userID domain.UserID) (bool, error) {
 	var count int
-	err := r.store.pool.QueryRow(ctx,
+	err := r.store.conn(ctx).QueryRow(ctx,
 		"SELECT COUNT(*) FROM topic_members WHERE topic_id = $1 AND user_id = $2",
 		string(topicID), string(userID),
 	).Scan(&count)
@@ -167,7 +167,7 @@ func (r *TopicMemberRepository) HasAccess(ctx context.Context, topicID domain.To
 // GetRole gets the role of a user in a topic.
 func (r *TopicMemberRepository) GetRole(ctx context.Context, topicID domain.TopicID, userID domain.UserID) (storage.TopicMemberRole, error) {
 	var role string
-	err := r.store.pool.QueryRow(ctx,
+	err := r.store.conn(ctx).QueryRow(ctx,
 		"SELECT role FROM topic_members WHERE topic_id = $1 AND user_id = $2",
 		string(topicID), string(userID),
 	).Scan(&role)
@@ -27,8 +27,8 @@ func (r *TopicRepository) Create(ctx context.Context, topic *domain.Topic) error
 	}
 
 	_, err := r.store.execWithAudit(ctx, "INSERT", "topics", `
-		INSERT INTO topics (id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		INSERT INTO topics (id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`,
 		string(topic.ID),
 		nullableString(string(topic.ParentID)),
@@ -43,6 +43,8 @@ func (r *TopicRepository) Create(ctx context.Context, topic *domain.Topic) error
 		topic.DatasetCount,
 		topic.Tags,
 		topic.Metadata,
+		topic.ACL,
+		topic.DataSchema,
 	)
 
 	if err != nil {
@@ -52,13 +54,14 @@ func (r *TopicRepository) Create(ctx context.Context, topic *domain.Topic) error
 		return fmt.Errorf("failed to create topic: %w", err)
 	}
 
+	topic.Revision = 1
 	return nil
 }
 
 // Get retrieves a topic by ID.
 func (r *TopicRepository) Get(ctx context.Context, id domain.TopicID) (*domain.Topic, error) {
 	rows, err := r.store.queryWithAudit(ctx, "topics", `
-		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata
+		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema, revision
 		FROM topics WHERE id = $1
 	`, string(id))
 	if err != nil {
@@ -76,7 +79,7 @@ func (r *TopicRepository) Get(ctx context.Context, id domain.TopicID) (*domain.T
 // GetByName retrieves a topic by name.
 func (r *TopicRepository) GetByName(ctx context.Context, name string) (*domain.Topic, error) {
 	rows, err := r.store.queryWithAudit(ctx, "topics", `
-		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata
+		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema, revision
 		FROM topics WHERE name = $1
 	`, name)
 	if err != nil {
@@ -94,7 +97,7 @@ func (r *TopicRepository) GetByName(ctx context.Context, name string) (*domain.T
 // List retrieves topics matching the filter.
 func (r *TopicRepository) List(ctx context.Context, filter storage.TopicFilter) ([]*domain.Topic, error) {
 	query := `
-		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata
+		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema, revision
 		FROM topics WHERE 1=1
 	`
 	args := []any{}
@@ -176,7 +179,9 @@ func (r *TopicRepository) List(ctx context.Context, filter storage.TopicFilter)
 	return topics, rows.Err()
 }
 
-// Update updates an existing topic.
+// Update updates an existing topic. Unless topic.Revision is 0, the update
+// only applies if the stored topic still has that revision and fails with
+// storage.ErrConflict otherwise. topic.Revision is set to the new revision.
 func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error {
 	if err := topic.Validate(); err != nil {
 		return err
@@ -197,8 +202,11 @@ func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error
 			owners = $6,
 			dataset_count = $7,
 			tags = $8,
-			metadata = $9
-		WHERE id = $10
+			metadata = $9,
+			acl = $10,
+			data_schema = $11,
+			revision = revision + 1
+		WHERE id = $12 AND ($13::bigint = 0 OR revision = $13)
 	`,
 		nullableString(string(topic.ParentID)),
 		topic.Name,
@@ -209,33 +217,31 @@ func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error
 		topic.DatasetCount,
 		topic.Tags,
 		topic.Metadata,
+		topic.ACL,
+		topic.DataSchema,
 		string(topic.ID),
+		topic.Revision,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update topic: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "topics", string(topic.ID), rowsAffected, &topic.Revision)
 }
 
-// Delete soft-deletes a topic.
-func (r *TopicRepository) Delete(ctx context.Context, id domain.TopicID) error {
+// Delete soft-deletes a topic. Unless revision is 0, the delete only
+// applies if the stored topic still has that revision and fails with
+// storage.ErrConflict otherwise.
+func (r *TopicRepository) Delete(ctx context.Context, id domain.TopicID, revision int64) error {
 	rowsAffected, err := r.store.execWithAudit(ctx, "UPDATE", "topics", `
-		UPDATE topics SET status = 'deleted' WHERE id = $1
-	`, string(id))
+		UPDATE topics SET status = 'deleted', revision = revision + 1
+		WHERE id = $1 AND ($2::bigint = 0 OR revision = $2)
+	`, string(id), revision)
 	if err != nil {
 		return fmt.Errorf("failed to delete topic: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "topics", string(id), rowsAffected, &revision)
 }
 
 // Count returns the number of topics matching the filter.
@@ -293,12 +299,15 @@ func scanTopic(rows pgx.Rows) (*domain.Topic, error) {
 		datasetCount int
 		tags         []string
 		metadata     map[string]string
+		acl          []domain.TopicACLEntry
+		dataSchema   *domain.DataSchema
+		revision     int64
 	)
 
 	err := rows.Scan(
 		&id, &parentID, &name, &description, &tableSchema, &status,
 		&owners, &createdBy, &createdAt, &updatedAt, &datasetCount,
-		&tags, &metadata,
+		&tags, &metadata, &acl, &dataSchema, &revision,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan topic: %w", err)
@@ -314,6 +323,9 @@ func scanTopic(rows pgx.Rows) (*domain.Topic, error) {
 		DatasetCount: datasetCount,
 		Tags:         tags,
 		Metadata:     metadata,
+		ACL:          acl,
+		DataSchema:   dataSchema,
+		Revision:     revision,
 	}
 
 	if parentID != nil {
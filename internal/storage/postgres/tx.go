@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"bib/internal/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// querier runs statements on a pool or in a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// txKey is the context key of the transaction WithTx runs fn in.
+type txKey struct{}
+
+// storeTx is a transaction of a store.
+type storeTx struct {
+	store *Store
+	tx    pgx.Tx
+}
+
+// txFrom returns the transaction of this store in ctx, if any.
+func (s *Store) txFrom(ctx context.Context) (pgx.Tx, bool) {
+	if t, ok := ctx.Value(txKey{}).(*storeTx); ok && t.store == s {
+		return t.tx, true
+	}
+	return nil, false
+}
+
+// conn returns the transaction in ctx, or the main pool.
+func (s *Store) conn(ctx context.Context) querier {
+	if tx, ok := s.txFrom(ctx); ok {
+		return tx
+	}
+	return s.pool
+}
+
+// connForRole returns the transaction in ctx, or the pool of role.
+func (s *Store) connForRole(ctx context.Context, role storage.DBRole) querier {
+	if tx, ok := s.txFrom(ctx); ok {
+		return tx
+	}
+	return s.PoolForRole(role)
+}
+
+// WithTx runs fn in a transaction on the pool of the operation's role.
+func (s *Store) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := s.txFrom(ctx); ok {
+		return fn(ctx)
+	}
+
+	oc := storage.MustGetOperationContext(ctx)
+	tx, err := s.PoolForRole(oc.Role).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		// The context may be canceled already; roll back regardless
+		rbCtx := context.WithoutCancel(ctx)
+		if rbErr := tx.Rollback(rbCtx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) && err != nil {
+			err = errors.Join(err, fmt.Errorf("failed to roll back transaction: %w", rbErr))
+		}
+		s.audit.resetLastHash(rbCtx)
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &storeTx{store: s, tx: tx})); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
@@ -29,7 +29,7 @@ func (r *UserPreferencesRepository) Get(ctx context.Context, userID domain.UserI
 	var prefs storage.UserPreferences
 	var customJSON []byte
 
-	err := r.store.pool.QueryRow(ctx, query, string(userID)).Scan(
+	err := r.store.conn(ctx).QueryRow(ctx, query, string(userID)).Scan(
 		&prefs.UserID,
 		&prefs.Theme,
 		&prefs.Locale,
@@ -89,7 +89,7 @@ func (r *UserPreferencesRepository) Upsert(ctx context.Context, prefs *storage.U
 			updated_at = NOW()
 	`
 
-	_, err := r.store.pool.Exec(ctx, query,
+	_, err := r.store.conn(ctx).Exec(ctx, query,
 		string(prefs.UserID),
 		prefs.Theme,
 		prefs.Locale,
@@ -105,7 +105,7 @@ func (r *UserPreferencesRepository) Upsert(ctx context.Context, prefs *storage.U
 
 // Delete removes preferences for a user.
 func (r *UserPreferencesRepository) Delete(ctx context.Context, userID domain.UserID) error {
-	_, err := r.store.pool.Exec(ctx,
+	_, err := r.store.conn(ctx).Exec(ctx,
 		"DELETE FROM user_preferences WHERE user_id = $1",
 		string(userID),
 	)
@@ -29,7 +29,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	_, err = r.store.pool.Exec(ctx, `
+	_, err = r.store.conn(ctx).Exec(ctx, `
 		INSERT INTO users (id, public_key, key_type, public_key_fingerprint, name, email, status, role, locale, created_at, updated_at, last_login_at, metadata)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`,
@@ -60,7 +60,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 
 // Get retrieves a user by ID.
 func (r *UserRepository) Get(ctx context.Context, id domain.UserID) (*domain.User, error) {
-	row := r.store.pool.QueryRow(ctx, `
+	row := r.store.conn(ctx).QueryRow(ctx, `
 		SELECT id, public_key, key_type, public_key_fingerprint, name, email, status, role, locale, created_at, updated_at, last_login_at, metadata
 		FROM users WHERE id = $1 AND status != 'deleted'
 	`, string(id))
@@ -68,11 +68,11 @@ func (r *UserRepository) Get(ctx context.Context, id domain.UserID) (*domain.Use
 	return scanUserRow(row)
 }
 
-// GetByPublicKey retrieves a user by their public key.
+// GetByPublicKey retrieves a user by their primary key or an additional key.
 func (r *UserRepository) GetByPublicKey(ctx context.Context, publicKey []byte) (*domain.User, error) {
-	row := r.store.pool.QueryRow(ctx, `
+	row := r.store.conn(ctx).QueryRow(ctx, `
 		SELECT id, public_key, key_type, public_key_fingerprint, name, email, status, role, locale, created_at, updated_at, last_login_at, metadata
-		FROM users WHERE public_key = $1 AND status != 'deleted'
+		FROM users WHERE (public_key = $1 OR id IN (SELECT user_id FROM user_keys WHERE public_key = $1)) AND status != 'deleted'
 	`, publicKey)
 
 	return scanUserRow(row)
@@ -80,7 +80,7 @@ func (r *UserRepository) GetByPublicKey(ctx context.Context, publicKey []byte) (
 
 // GetByEmail retrieves a user by email.
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	row := r.store.pool.QueryRow(ctx, `
+	row := r.store.conn(ctx).QueryRow(ctx, `
 		SELECT id, public_key, key_type, public_key_fingerprint, name, email, status, role, locale, created_at, updated_at, last_login_at, metadata
 		FROM users WHERE email = $1 AND status != 'deleted'
 	`, email)
@@ -137,7 +137,7 @@ func (r *UserRepository) List(ctx context.Context, filter storage.UserFilter) ([
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.pool.Query(ctx, query, args...)
+	rows, err := r.store.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
@@ -168,7 +168,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 
 	user.UpdatedAt = time.Now().UTC()
 
-	result, err := r.store.pool.Exec(ctx, `
+	result, err := r.store.conn(ctx).Exec(ctx, `
 		UPDATE users SET
 			name = $1,
 			email = $2,
@@ -203,7 +203,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 
 // Delete deletes a user (soft delete).
 func (r *UserRepository) Delete(ctx context.Context, id domain.UserID) error {
-	result, err := r.store.pool.Exec(ctx, `
+	result, err := r.store.conn(ctx).Exec(ctx, `
 		UPDATE users SET status = 'deleted', updated_at = $1 WHERE id = $2
 	`, time.Now().UTC(), string(id))
 	if err != nil {
@@ -242,7 +242,7 @@ func (r *UserRepository) Count(ctx context.Context, filter storage.UserFilter) (
 	}
 
 	var count int64
-	err := r.store.pool.QueryRow(ctx, query, args...).Scan(&count)
+	err := r.store.conn(ctx).QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count users: %w", err)
 	}
@@ -253,8 +253,9 @@ func (r *UserRepository) Count(ctx context.Context, filter storage.UserFilter) (
 // Exists checks if a user with the given public key exists.
 func (r *UserRepository) Exists(ctx context.Context, publicKey []byte) (bool, error) {
 	var count int
-	err := r.store.pool.QueryRow(ctx, `
-		SELECT COUNT(*) FROM users WHERE public_key = $1 AND status != 'deleted'
+	err := r.store.conn(ctx).QueryRow(ctx, `
+		SELECT COUNT(*) FROM users
+		WHERE (public_key = $1 OR id IN (SELECT user_id FROM user_keys WHERE public_key = $1)) AND status != 'deleted'
 	`, publicKey).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check user existence: %w", err)
@@ -265,7 +266,7 @@ func (r *UserRepository) Exists(ctx context.Context, publicKey []byte) (bool, er
 // IsFirstUser returns true if no users exist yet.
 func (r *UserRepository) IsFirstUser(ctx context.Context) (bool, error) {
 	var count int
-	err := r.store.pool.QueryRow(ctx, `
+	err := r.store.conn(ctx).QueryRow(ctx, `
 		SELECT COUNT(*) FROM users WHERE status != 'deleted'
 	`).Scan(&count)
 	if err != nil {
@@ -274,6 +275,78 @@ func (r *UserRepository) IsFirstUser(ctx context.Context) (bool, error) {
 	return count == 0, nil
 }
 
+// AddPublicKey registers an additional key for a user.
+func (r *UserRepository) AddPublicKey(ctx context.Context, key *domain.UserPublicKey) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	_, err := r.store.execWithAudit(ctx, "INSERT", "user_keys", `
+		INSERT INTO user_keys (fingerprint, user_id, public_key, key_type, label, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`,
+		key.Fingerprint,
+		string(key.UserID),
+		key.PublicKey,
+		string(key.KeyType),
+		key.Label,
+		key.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrKeyExists
+		}
+		return fmt.Errorf("failed to add public key: %w", err)
+	}
+
+	return nil
+}
+
+// ListPublicKeys lists a user's additional keys, oldest first.
+func (r *UserRepository) ListPublicKeys(ctx context.Context, userID domain.UserID) ([]*domain.UserPublicKey, error) {
+	rows, err := r.store.conn(ctx).Query(ctx, `
+		SELECT fingerprint, user_id, public_key, key_type, label, created_at
+		FROM user_keys WHERE user_id = $1 ORDER BY created_at, fingerprint
+	`, string(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.UserPublicKey
+	for rows.Next() {
+		var (
+			key     domain.UserPublicKey
+			id      string
+			keyType string
+		)
+		if err := rows.Scan(&key.Fingerprint, &id, &key.PublicKey, &keyType, &key.Label, &key.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan public key: %w", err)
+		}
+		key.UserID = domain.UserID(id)
+		key.KeyType = domain.KeyType(keyType)
+		keys = append(keys, &key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RemovePublicKey removes an additional key by fingerprint.
+func (r *UserRepository) RemovePublicKey(ctx context.Context, userID domain.UserID, fingerprint string) error {
+	rowsAffected, err := r.store.execWithAudit(ctx, "DELETE", "user_keys", `
+		DELETE FROM user_keys WHERE user_id = $1 AND fingerprint = $2
+	`, string(userID), fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to remove public key: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrKeyNotFound
+	}
+
+	return nil
+}
+
 // scanUserRow scans a single user row.
 func scanUserRow(row pgx.Row) (*domain.User, error) {
 	var (
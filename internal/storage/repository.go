@@ -55,6 +55,19 @@ type Store interface {
 	// AllowedPeers returns the allowed peers repository for P2P gRPC authorization.
 	AllowedPeers() AllowedPeerRepository
 
+	// WithTx runs fn in a transaction, committing it if fn returns nil and
+	// rolling it back if fn returns an error or panics. Repository calls
+	// made with the context passed to fn are part of the transaction, as
+	// are the audit entries they write; calls made with any other context
+	// are not. Calling WithTx again with that context joins the outer
+	// transaction.
+	//
+	// The transaction only covers this store. On non-authoritative stores
+	// (SQLite in proxy and selective mode) it keeps the local cache
+	// consistent, but the authoritative copy lives on other nodes and is
+	// not part of it.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// Ping checks database connectivity.
 	Ping(ctx context.Context) error
 
@@ -96,12 +109,43 @@ type StorageStats struct {
 	Message string
 }
 
+// ConnectionStats contains database connection pool statistics.
+type ConnectionStats struct {
+	// InUse is the number of connections in use.
+	InUse int
+
+	// Idle is the number of open connections not in use.
+	Idle int
+
+	// Max is the maximum number of open connections (0: unlimited).
+	Max int
+}
+
+// ConnectionStatsProvider is implemented by stores that pool database
+// connections, to report how many are in use.
+type ConnectionStatsProvider interface {
+	// ConnectionStats returns the connection pool statistics.
+	ConnectionStats() ConnectionStats
+}
+
+// Retargetable is implemented by stores that can move their connections to
+// another server, such as a standby promoted by a failover.
+type Retargetable interface {
+	// Retarget makes new connections go to host:port and drops the existing
+	// ones. An empty host keeps the server and only reconnects.
+	Retarget(host string, port int) error
+}
+
 // BackendType represents the storage backend.
 type BackendType string
 
 const (
 	BackendSQLite   BackendType = "sqlite"
 	BackendPostgres BackendType = "postgres"
+
+	// BackendMemory keeps all data in process memory. It is meant for
+	// tests and ephemeral runs only; nothing survives a restart.
+	BackendMemory BackendType = "memory"
 )
 
 // String returns the backend name.
@@ -126,8 +170,10 @@ type TopicRepository interface {
 	// Update updates an existing topic.
 	Update(ctx context.Context, topic *domain.Topic) error
 
-	// Delete deletes a topic (soft delete - sets status to deleted).
-	Delete(ctx context.Context, id domain.TopicID) error
+	// Delete deletes a topic (soft delete - sets status to deleted). Unless
+	// revision is 0, it only applies if the topic is still at revision, and
+	// fails with ErrConflict otherwise.
+	Delete(ctx context.Context, id domain.TopicID, revision int64) error
 
 	// Count returns the number of topics matching the filter.
 	Count(ctx context.Context, filter TopicFilter) (int64, error)
@@ -177,8 +223,10 @@ type DatasetRepository interface {
 	// Update updates an existing dataset.
 	Update(ctx context.Context, dataset *domain.Dataset) error
 
-	// Delete deletes a dataset (soft delete).
-	Delete(ctx context.Context, id domain.DatasetID) error
+	// Delete deletes a dataset (soft delete). Unless revision is 0, it only
+	// applies if the dataset is still at revision, and fails with
+	// ErrConflict otherwise.
+	Delete(ctx context.Context, id domain.DatasetID, revision int64) error
 
 	// Count returns the number of datasets matching the filter.
 	Count(ctx context.Context, filter DatasetFilter) (int64, error)
@@ -197,6 +245,9 @@ type DatasetRepository interface {
 	// ListVersions lists all versions of a dataset.
 	ListVersions(ctx context.Context, datasetID domain.DatasetID) ([]*domain.DatasetVersion, error)
 
+	// DeleteVersion deletes a version and its chunks.
+	DeleteVersion(ctx context.Context, datasetID domain.DatasetID, versionID domain.DatasetVersionID) error
+
 	// Chunks
 
 	// CreateChunk creates a new chunk record.
@@ -540,7 +591,8 @@ type UserRepository interface {
 	// Get retrieves a user by ID.
 	Get(ctx context.Context, id domain.UserID) (*domain.User, error)
 
-	// GetByPublicKey retrieves a user by their public key.
+	// GetByPublicKey retrieves a user by their primary key or any of their
+	// additional keys.
 	GetByPublicKey(ctx context.Context, publicKey []byte) (*domain.User, error)
 
 	// GetByEmail retrieves a user by email.
@@ -558,11 +610,22 @@ type UserRepository interface {
 	// Count returns the number of users matching the filter.
 	Count(ctx context.Context, filter UserFilter) (int64, error)
 
-	// Exists checks if a user with the given public key exists.
+	// Exists checks if a user with the given public key exists, as either
+	// the primary key or an additional key.
 	Exists(ctx context.Context, publicKey []byte) (bool, error)
 
 	// IsFirstUser returns true if no users exist yet (for auto-admin).
 	IsFirstUser(ctx context.Context) (bool, error)
+
+	// AddPublicKey registers an additional key for a user. Returns
+	// domain.ErrKeyExists if the key or the label is already in use.
+	AddPublicKey(ctx context.Context, key *domain.UserPublicKey) error
+
+	// ListPublicKeys lists a user's additional keys, oldest first.
+	ListPublicKeys(ctx context.Context, userID domain.UserID) ([]*domain.UserPublicKey, error)
+
+	// RemovePublicKey removes an additional key by fingerprint.
+	RemovePublicKey(ctx context.Context, userID domain.UserID, fingerprint string) error
 }
 
 // UserFilter defines filtering options for user queries.
@@ -768,6 +831,20 @@ func (r TopicMemberRole) CanEdit() bool {
 	return r == TopicMemberRoleOwner || r == TopicMemberRoleEditor
 }
 
+// Permission returns the topic permission the role carries.
+func (r TopicMemberRole) Permission() domain.TopicPermission {
+	switch r {
+	case TopicMemberRoleOwner:
+		return domain.TopicPermissionAdmin
+	case TopicMemberRoleEditor:
+		return domain.TopicPermissionWrite
+	case TopicMemberRoleViewer:
+		return domain.TopicPermissionRead
+	default:
+		return ""
+	}
+}
+
 // TopicMember represents a user's membership in a topic.
 type TopicMember struct {
 	// ID is the unique membership ID.
@@ -858,11 +935,44 @@ const (
 	InvitationStatusCancelled InvitationStatus = "cancelled"
 )
 
-// TopicInvitation represents an invitation to join a topic.
+// InvitationKind distinguishes invitations from access requests.
+type InvitationKind string
+
+const (
+	// InvitationKindInvite is an owner inviting a user to a topic.
+	InvitationKindInvite InvitationKind = "invite"
+
+	// InvitationKindRequest is a user asking to join a topic. The requester
+	// is both inviter and invitee.
+	InvitationKindRequest InvitationKind = "request"
+)
+
+// TopicMembershipState is where a user stands in joining a topic.
+type TopicMembershipState string
+
+const (
+	// TopicMembershipNone means the user has no relation to the topic.
+	TopicMembershipNone TopicMembershipState = "none"
+
+	// TopicMembershipInvited means the user has a pending invitation.
+	TopicMembershipInvited TopicMembershipState = "invited"
+
+	// TopicMembershipPending means the user's access request awaits review.
+	TopicMembershipPending TopicMembershipState = "pending"
+
+	// TopicMembershipMember means the user is a member.
+	TopicMembershipMember TopicMembershipState = "member"
+)
+
+// TopicInvitation represents an invitation to join a topic, or a request
+// to join one.
 type TopicInvitation struct {
 	// ID is the unique invitation ID.
 	ID string `json:"id"`
 
+	// Kind is whether this is an invitation or an access request.
+	Kind InvitationKind `json:"kind"`
+
 	// TopicID is the topic being invited to.
 	TopicID domain.TopicID `json:"topic_id"`
 
@@ -895,6 +1005,10 @@ type TopicInvitation struct {
 
 	// RespondedAt is when the invitation was responded to.
 	RespondedAt *time.Time `json:"responded_at,omitempty"`
+
+	// RespondedBy is the user who accepted, declined or cancelled the
+	// invitation, or approved or denied the request.
+	RespondedBy domain.UserID `json:"responded_by,omitempty"`
 }
 
 // TopicInvitationRepository handles topic invitation persistence.
@@ -932,6 +1046,9 @@ type InvitationFilter struct {
 	// Status filters by status
 	Status InvitationStatus
 
+	// Kind filters by kind
+	Kind InvitationKind
+
 	// Limit is the maximum number of results
 	Limit int
 
@@ -1034,6 +1151,16 @@ type SavedQuery struct {
 	// Tags are optional labels for categorization.
 	Tags []string `json:"tags,omitempty"`
 
+	// Parameters holds default values for the query's placeholders,
+	// as decoded JSON values (for saved queries).
+	Parameters map[string]any `json:"parameters,omitempty"`
+
+	// IsPublic shares a saved query with all users.
+	IsPublic bool `json:"is_public,omitempty"`
+
+	// UseCount is how often a saved query has been run.
+	UseCount int64 `json:"use_count,omitempty"`
+
 	// Duration is the execution duration (for history).
 	Duration int64 `json:"duration,omitempty"`
 
@@ -1070,6 +1197,9 @@ type SavedQueryRepository interface {
 	// Get retrieves a saved query by ID.
 	Get(ctx context.Context, id string) (*SavedQuery, error)
 
+	// GetByName retrieves a user's saved query by name.
+	GetByName(ctx context.Context, userID domain.UserID, name string) (*SavedQuery, error)
+
 	// List lists saved queries matching the filter.
 	List(ctx context.Context, filter SavedQueryFilter) ([]*SavedQuery, error)
 
@@ -1079,6 +1209,9 @@ type SavedQueryRepository interface {
 	// Delete deletes a saved query.
 	Delete(ctx context.Context, id string) error
 
+	// IncrementUseCount records that a saved query was run.
+	IncrementUseCount(ctx context.Context, id string) error
+
 	// Count returns the number of saved queries matching the filter.
 	Count(ctx context.Context, filter SavedQueryFilter) (int64, error)
 }
@@ -1088,6 +1221,16 @@ type SavedQueryFilter struct {
 	// UserID filters by owner
 	UserID *domain.UserID
 
+	// IncludePublic also matches other users' public queries when
+	// UserID is set
+	IncludePublic bool
+
+	// PublicOnly matches only public queries
+	PublicOnly bool
+
+	// Name filters by exact name
+	Name string
+
 	// Tags filters by tags
 	Tags []string
 
@@ -45,7 +45,7 @@ func (r *AllowedPeerRepository) Add(ctx context.Context, peer *storage.AllowedPe
 		expiresAt = &t
 	}
 
-	_, err := r.store.db.ExecContext(ctx, query,
+	_, err := r.store.conn(ctx).ExecContext(ctx, query,
 		peer.PeerID,
 		nullString(peer.Name),
 		addedAt.UTC().Format(time.RFC3339),
@@ -59,7 +59,7 @@ func (r *AllowedPeerRepository) Add(ctx context.Context, peer *storage.AllowedPe
 
 // Remove removes a peer from the allowed list.
 func (r *AllowedPeerRepository) Remove(ctx context.Context, peerID string) error {
-	_, err := r.store.db.ExecContext(ctx,
+	_, err := r.store.conn(ctx).ExecContext(ctx,
 		"DELETE FROM allowed_peers WHERE peer_id = ?",
 		peerID,
 	)
@@ -74,7 +74,7 @@ func (r *AllowedPeerRepository) Get(ctx context.Context, peerID string) (*storag
 		WHERE peer_id = ?
 	`
 
-	return r.scanPeer(r.store.db.QueryRowContext(ctx, query, peerID))
+	return r.scanPeer(r.store.conn(ctx).QueryRowContext(ctx, query, peerID))
 }
 
 // List lists all allowed peers.
@@ -85,7 +85,7 @@ func (r *AllowedPeerRepository) List(ctx context.Context) ([]*storage.AllowedPee
 		ORDER BY added_at DESC
 	`
 
-	rows, err := r.store.db.QueryContext(ctx, query)
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -102,13 +102,13 @@ func (r *AllowedPeerRepository) IsAllowed(ctx context.Context, peerID string) (b
 	`
 
 	var count int
-	err := r.store.db.QueryRowContext(ctx, query, peerID, time.Now().UTC().Format(time.RFC3339)).Scan(&count)
+	err := r.store.conn(ctx).QueryRowContext(ctx, query, peerID, time.Now().UTC().Format(time.RFC3339)).Scan(&count)
 	return count > 0, err
 }
 
 // Cleanup removes expired entries.
 func (r *AllowedPeerRepository) Cleanup(ctx context.Context) error {
-	_, err := r.store.db.ExecContext(ctx,
+	_, err := r.store.conn(ctx).ExecContext(ctx,
 		"DELETE FROM allowed_peers WHERE expires_at IS NOT NULL AND expires_at < ?",
 		time.Now().UTC().Format(time.RFC3339),
 	)
@@ -120,7 +120,7 @@ func (r *AllowedPeerRepository) Count(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM allowed_peers WHERE expires_at IS NULL OR expires_at > ?`
 
 	var count int64
-	err := r.store.db.QueryRowContext(ctx, query, time.Now().UTC().Format(time.RFC3339)).Scan(&count)
+	err := r.store.conn(ctx).QueryRowContext(ctx, query, time.Now().UTC().Format(time.RFC3339)).Scan(&count)
 	return count, err
 }
 
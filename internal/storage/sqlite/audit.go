@@ -38,7 +38,7 @@ func (r *AuditRepository) Log(ctx context.Context, entry *storage.AuditEntry) er
 
 	metadataJSON, _ := json.Marshal(entry.Metadata)
 
-	result, err := r.store.db.ExecContext(ctx, `
+	result, err := r.store.conn(ctx).ExecContext(ctx, `
 		INSERT INTO audit_log (
 			timestamp, node_id, job_id, operation_id, role_used, action,
 			table_name, query, query_hash, rows_affected, duration_ms,
@@ -154,7 +154,7 @@ func (r *AuditRepository) Query(ctx context.Context, filter storage.AuditFilter)
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.db.QueryContext(ctx, query, args...)
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query audit log: %w", err)
 	}
@@ -203,7 +203,7 @@ func (r *AuditRepository) Count(ctx context.Context, filter storage.AuditFilter)
 	}
 
 	var count int64
-	err := r.store.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := r.store.conn(ctx).QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count audit entries: %w", err)
 	}
@@ -223,7 +223,7 @@ func (r *AuditRepository) GetByJobID(ctx context.Context, jobID string) ([]*stor
 
 // Purge removes entries older than the given time.
 func (r *AuditRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
-	result, err := r.store.db.ExecContext(ctx, `
+	result, err := r.store.conn(ctx).ExecContext(ctx, `
 		DELETE FROM audit_log WHERE timestamp < ?
 	`, before.UTC().Format(time.RFC3339Nano))
 
@@ -237,7 +237,7 @@ func (r *AuditRepository) Purge(ctx context.Context, before time.Time) (int64, e
 
 // VerifyChain verifies hash chain integrity.
 func (r *AuditRepository) VerifyChain(ctx context.Context, from, to int64) (bool, error) {
-	rows, err := r.store.db.QueryContext(ctx, `
+	rows, err := r.store.conn(ctx).QueryContext(ctx, `
 		SELECT id, timestamp, node_id, job_id, operation_id, role_used, action,
 			table_name, query, query_hash, rows_affected, duration_ms,
 			source_component, actor, metadata, prev_hash, entry_hash,
@@ -274,7 +274,7 @@ func (r *AuditRepository) VerifyChain(ctx context.Context, from, to int64) (bool
 // GetLastHash returns the hash of the last entry.
 func (r *AuditRepository) GetLastHash(ctx context.Context) (string, error) {
 	var entryHash sql.NullString
-	err := r.store.db.QueryRowContext(ctx, `
+	err := r.store.conn(ctx).QueryRowContext(ctx, `
 		SELECT entry_hash FROM audit_log ORDER BY id DESC LIMIT 1
 	`).Scan(&entryHash)
 
@@ -407,3 +407,14 @@ func nullStr(s string) interface{} {
 
 // Ensure interface compliance
 var _ storage.AuditRepository = (*AuditRepository)(nil)
+
+// resetLastHash re-reads the head of the hash chain after a rolled back
+// transaction discarded the entries logged in it.
+func (r *AuditRepository) resetLastHash(ctx context.Context) {
+	if r == nil || !r.hashChain {
+		return
+	}
+	if hash, err := r.GetLastHash(ctx); err == nil {
+		r.lastHash = hash
+	}
+}
@@ -41,7 +41,7 @@ func (r *BannedPeerRepository) Create(ctx context.Context, ban *storage.BannedPe
 		metadataJSON, _ = json.Marshal(ban.Metadata)
 	}
 
-	_, err := r.store.db.ExecContext(ctx, query,
+	_, err := r.store.conn(ctx).ExecContext(ctx, query,
 		ban.PeerID,
 		ban.Reason,
 		string(ban.BannedBy),
@@ -61,7 +61,7 @@ func (r *BannedPeerRepository) Get(ctx context.Context, peerID string) (*storage
 		WHERE peer_id = ?
 	`
 
-	return r.scanBan(r.store.db.QueryRowContext(ctx, query, peerID))
+	return r.scanBan(r.store.conn(ctx).QueryRowContext(ctx, query, peerID))
 }
 
 // List lists all bans.
@@ -89,7 +89,7 @@ func (r *BannedPeerRepository) List(ctx context.Context, filter storage.BannedPe
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.db.QueryContext(ctx, query, args...)
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +100,7 @@ func (r *BannedPeerRepository) List(ctx context.Context, filter storage.BannedPe
 
 // Delete removes a ban.
 func (r *BannedPeerRepository) Delete(ctx context.Context, peerID string) error {
-	_, err := r.store.db.ExecContext(ctx,
+	_, err := r.store.conn(ctx).ExecContext(ctx,
 		"DELETE FROM banned_peers WHERE peer_id = ?",
 		peerID,
 	)
@@ -115,13 +115,13 @@ func (r *BannedPeerRepository) IsBanned(ctx context.Context, peerID string) (boo
 	`
 
 	var count int
-	err := r.store.db.QueryRowContext(ctx, query, peerID, time.Now().UTC().Format(time.RFC3339)).Scan(&count)
+	err := r.store.conn(ctx).QueryRowContext(ctx, query, peerID, time.Now().UTC().Format(time.RFC3339)).Scan(&count)
 	return count > 0, err
 }
 
 // CleanupExpired removes expired bans.
 func (r *BannedPeerRepository) CleanupExpired(ctx context.Context) (int64, error) {
-	result, err := r.store.db.ExecContext(ctx,
+	result, err := r.store.conn(ctx).ExecContext(ctx,
 		"DELETE FROM banned_peers WHERE expires_at IS NOT NULL AND expires_at < ?",
 		time.Now().UTC().Format(time.RFC3339),
 	)
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"bib/internal/storage"
+)
+
+// plaintextHeader starts every unencrypted SQLite database file. SQLCipher
+// files start with a random salt instead.
+var plaintextHeader = []byte("SQLite format 3\x00")
+
+// dbFormat is the format of a database file.
+type dbFormat int
+
+const (
+	formatEmpty     dbFormat = iota // missing or empty, so either format
+	formatPlain                     // unencrypted SQLite
+	formatEncrypted                 // anything else, encrypted by SQLCipher
+)
+
+// detectFormat returns the format of the database file at path.
+func detectFormat(path string) (dbFormat, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return formatEmpty, nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	header := make([]byte, len(plaintextHeader))
+	n, err := io.ReadFull(f, header)
+	switch {
+	case n == 0 && errors.Is(err, io.EOF):
+		return formatEmpty, nil
+	case err != nil && !errors.Is(err, io.ErrUnexpectedEOF):
+		return 0, err
+	case bytes.Equal(header[:n], plaintextHeader):
+		return formatPlain, nil
+	default:
+		return formatEncrypted, nil
+	}
+}
+
+// keyText returns key as SQLCipher reads it: a raw key in the x'<hex>'
+// form, or the passphrase.
+func keyText(key *storage.SQLiteKey) string {
+	if len(key.Raw) > 0 {
+		return "x'" + hex.EncodeToString(key.Raw) + "'"
+	}
+	return key.Passphrase
+}
+
+// quoteString quotes s as an SQL string literal, for statements such as
+// PRAGMA key that take no parameters.
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// checkUnencrypted returns an error if the database at path was encrypted
+// by SQLCipher, which the unencrypted store can't read.
+func checkUnencrypted(path string) error {
+	format, err := detectFormat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read database header: %w", err)
+	}
+	if format == formatEncrypted {
+		return fmt.Errorf("%s is not an unencrypted SQLite database; if it was encrypted, enable database.sqlite.encryption with its key", path)
+	}
+	return nil
+}
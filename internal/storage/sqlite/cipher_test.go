@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bib/internal/storage"
+)
+
+func TestDetectFormat(t *testing.T) {
+	dir := t.TempDir()
+	store, err := New(storage.SQLiteConfig{}, dir, "test-node")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	store.Close()
+
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want dbFormat
+	}{
+		{"missing", filepath.Join(dir, "missing.db"), formatEmpty},
+		{"empty", write("empty.db", nil), formatEmpty},
+		{"unencrypted", filepath.Join(dir, "cache.db"), formatPlain},
+		{"encrypted", write("encrypted.db", bytes.Repeat([]byte{0xa5}, 4096)), formatEncrypted},
+		{"truncated", write("short.db", []byte("SQLite")), formatEncrypted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectFormat(tt.path)
+			if err != nil || got != tt.want {
+				t.Errorf("detectFormat() = %v, %v; want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_EncryptedWithoutKey(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cache.db")
+	if err := os.WriteFile(path, bytes.Repeat([]byte{0xa5}, 4096), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := New(storage.SQLiteConfig{Path: path}, dir, "test-node")
+	if err == nil || !strings.Contains(err.Error(), "database.sqlite.encryption") {
+		t.Errorf("New() of an encrypted database without a key error = %v, want a hint at database.sqlite.encryption", err)
+	}
+}
+
+func TestKeyText(t *testing.T) {
+	raw := &storage.SQLiteKey{Raw: []byte{0x01, 0xab}}
+	if got := keyText(raw); got != "x'01ab'" {
+		t.Errorf("keyText(raw) = %q, want x'01ab'", got)
+	}
+	passphrase := &storage.SQLiteKey{Passphrase: "it's secret"}
+	if got := quoteString(keyText(passphrase)); got != "'it''s secret'" {
+		t.Errorf("quoteString(keyText(passphrase)) = %q", got)
+	}
+}
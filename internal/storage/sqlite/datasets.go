@@ -25,11 +25,12 @@ func (r *DatasetRepository) Create(ctx context.Context, dataset *domain.Dataset)
 	ownersJSON, _ := json.Marshal(dataset.Owners)
 	tagsJSON, _ := json.Marshal(dataset.Tags)
 	metadataJSON, _ := json.Marshal(dataset.Metadata)
+	dataSchemaJSON, _ := json.Marshal(dataset.DataSchema)
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 
 	_, err := r.store.execWithAudit(ctx, "INSERT", "datasets", `
-		INSERT INTO datasets (id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata, cached_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO datasets (id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata, data_schema, cached_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		string(dataset.ID),
 		string(dataset.TopicID),
@@ -46,6 +47,7 @@ func (r *DatasetRepository) Create(ctx context.Context, dataset *domain.Dataset)
 		dataset.UpdatedAt.UTC().Format(time.RFC3339Nano),
 		string(tagsJSON),
 		string(metadataJSON),
+		string(dataSchemaJSON),
 		now,
 	)
 
@@ -56,13 +58,14 @@ func (r *DatasetRepository) Create(ctx context.Context, dataset *domain.Dataset)
 		return fmt.Errorf("failed to create dataset: %w", err)
 	}
 
+	dataset.Revision = 1
 	return nil
 }
 
 // Get retrieves a dataset by ID.
 func (r *DatasetRepository) Get(ctx context.Context, id domain.DatasetID) (*domain.Dataset, error) {
 	rows, err := r.store.queryWithAudit(ctx, "datasets", `
-		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata
+		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata, data_schema, revision
 		FROM datasets WHERE id = ?
 	`, string(id))
 	if err != nil {
@@ -80,7 +83,7 @@ func (r *DatasetRepository) Get(ctx context.Context, id domain.DatasetID) (*doma
 // List retrieves datasets matching the filter.
 func (r *DatasetRepository) List(ctx context.Context, filter storage.DatasetFilter) ([]*domain.Dataset, error) {
 	query := `
-		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata
+		SELECT id, topic_id, name, description, status, latest_version_id, version_count, has_content, has_instructions, owners, created_by, created_at, updated_at, tags, metadata, data_schema, revision
 		FROM datasets WHERE 1=1
 	`
 	args := []any{}
@@ -154,7 +157,10 @@ func (r *DatasetRepository) List(ctx context.Context, filter storage.DatasetFilt
 	return datasets, rows.Err()
 }
 
-// Update updates an existing dataset.
+// Update updates an existing dataset. Unless dataset.Revision is 0, the
+// update only applies if the stored dataset still has that revision and
+// fails with storage.ErrConflict otherwise. dataset.Revision is set to the
+// new revision.
 func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset) error {
 	if err := dataset.Validate(); err != nil {
 		return err
@@ -163,6 +169,7 @@ func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset)
 	ownersJSON, _ := json.Marshal(dataset.Owners)
 	tagsJSON, _ := json.Marshal(dataset.Tags)
 	metadataJSON, _ := json.Marshal(dataset.Metadata)
+	dataSchemaJSON, _ := json.Marshal(dataset.DataSchema)
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 
 	result, err := r.store.execWithAudit(ctx, "UPDATE", "datasets", `
@@ -179,8 +186,10 @@ func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset)
 			updated_at = ?,
 			tags = ?,
 			metadata = ?,
-			cached_at = ?
-		WHERE id = ?
+			data_schema = ?,
+			cached_at = ?,
+			revision = revision + 1
+		WHERE id = ? AND (? = 0 OR revision = ?)
 	`,
 		string(dataset.TopicID),
 		dataset.Name,
@@ -194,38 +203,36 @@ func (r *DatasetRepository) Update(ctx context.Context, dataset *domain.Dataset)
 		now,
 		string(tagsJSON),
 		string(metadataJSON),
+		string(dataSchemaJSON),
 		now,
 		string(dataset.ID),
+		dataset.Revision,
+		dataset.Revision,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update dataset: %w", err)
 	}
 
 	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "datasets", string(dataset.ID), rowsAffected, &dataset.Revision)
 }
 
-// Delete soft-deletes a dataset.
-func (r *DatasetRepository) Delete(ctx context.Context, id domain.DatasetID) error {
+// Delete soft-deletes a dataset. Unless revision is 0, the delete only
+// applies if the stored dataset still has that revision and fails with
+// storage.ErrConflict otherwise.
+func (r *DatasetRepository) Delete(ctx context.Context, id domain.DatasetID, revision int64) error {
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 
 	result, err := r.store.execWithAudit(ctx, "UPDATE", "datasets", `
-		UPDATE datasets SET status = 'deleted', updated_at = ? WHERE id = ?
-	`, now, string(id))
+		UPDATE datasets SET status = 'deleted', updated_at = ?, revision = revision + 1
+		WHERE id = ? AND (? = 0 OR revision = ?)
+	`, now, string(id), revision, revision)
 	if err != nil {
 		return fmt.Errorf("failed to delete dataset: %w", err)
 	}
 
 	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "datasets", string(id), rowsAffected, &revision)
 }
 
 // Count returns the number of datasets matching the filter.
@@ -357,6 +364,36 @@ func (r *DatasetRepository) ListVersions(ctx context.Context, datasetID domain.D
 	return versions, rows.Err()
 }
 
+// DeleteVersion deletes a version and its chunks. A version that followed
+// it is linked to the version before it instead.
+func (r *DatasetRepository) DeleteVersion(ctx context.Context, datasetID domain.DatasetID, versionID domain.DatasetVersionID) error {
+	version, err := r.GetVersion(ctx, datasetID, versionID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.store.execWithAudit(ctx, "UPDATE", "dataset_versions", `
+		UPDATE dataset_versions SET previous_version_id = ? WHERE dataset_id = ? AND previous_version_id = ?
+	`, nullString(string(version.PreviousVersionID)), string(datasetID), string(versionID)); err != nil {
+		return fmt.Errorf("failed to unlink version: %w", err)
+	}
+
+	// Chunks are removed by ON DELETE CASCADE
+	result, err := r.store.execWithAudit(ctx, "DELETE", "dataset_versions", `
+		DELETE FROM dataset_versions WHERE dataset_id = ? AND id = ?
+	`, string(datasetID), string(versionID))
+	if err != nil {
+		return fmt.Errorf("failed to delete version: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
 // CreateChunk creates a new chunk record.
 func (r *DatasetRepository) CreateChunk(ctx context.Context, chunk *domain.Chunk) error {
 	if err := chunk.Validate(); err != nil {
@@ -467,12 +504,15 @@ func scanDataset(rows *sql.Rows) (*domain.Dataset, error) {
 		updatedAt       string
 		tagsJSON        sql.NullString
 		metadataJSON    sql.NullString
+		schemaJSON      sql.NullString
+		revision        int64
 	)
 
 	err := rows.Scan(
 		&id, &topicID, &name, &description, &status, &latestVersionID,
 		&versionCount, &hasContent, &hasInstructions, &ownersJSON,
 		&createdBy, &createdAt, &updatedAt, &tagsJSON, &metadataJSON,
+		&schemaJSON, &revision,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan dataset: %w", err)
@@ -488,6 +528,7 @@ func scanDataset(rows *sql.Rows) (*domain.Dataset, error) {
 		HasContent:      hasContent == 1,
 		HasInstructions: hasInstructions == 1,
 		CreatedBy:       domain.UserID(createdBy),
+		Revision:        revision,
 	}
 
 	if latestVersionID.Valid {
@@ -522,6 +563,13 @@ func scanDataset(rows *sql.Rows) (*domain.Dataset, error) {
 		}
 	}
 
+	if schemaJSON.Valid && schemaJSON.String != "" {
+		var dataSchema *domain.DataSchema
+		if err := json.Unmarshal([]byte(schemaJSON.String), &dataSchema); err == nil {
+			dataset.DataSchema = dataSchema
+		}
+	}
+
 	return dataset, nil
 }
 
@@ -21,6 +21,9 @@ func (r *JobRepository) Create(ctx context.Context, job *domain.Job) error {
 	if err := job.Validate(); err != nil {
 		return err
 	}
+	if job.ExecutionMode == "" {
+		job.ExecutionMode = domain.ExecutionModeGoroutine
+	}
 
 	inlineInstructionsJSON, _ := json.Marshal(job.InlineInstructions)
 	scheduleJSON, _ := json.Marshal(job.Schedule)
@@ -182,6 +185,9 @@ func (r *JobRepository) Update(ctx context.Context, job *domain.Job) error {
 	if err := job.Validate(); err != nil {
 		return err
 	}
+	if job.ExecutionMode == "" {
+		job.ExecutionMode = domain.ExecutionModeGoroutine
+	}
 
 	inlineInstructionsJSON, _ := json.Marshal(job.InlineInstructions)
 	scheduleJSON, _ := json.Marshal(job.Schedule)
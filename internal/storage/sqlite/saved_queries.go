@@ -2,7 +2,12 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"bib/internal/domain"
 	"bib/internal/storage"
 )
 
@@ -11,38 +16,280 @@ type SavedQueryRepository struct {
 	store *Store
 }
 
+const savedQueryColumns = `id, user_id, name, description, query, parameters, tags, is_public, use_count, created_at, updated_at`
+
 // Create creates a new saved query.
 func (r *SavedQueryRepository) Create(ctx context.Context, query *storage.SavedQuery) error {
-	// TODO: Implement when Phase 3 CEL query system is ready
+	tagsJSON, _ := json.Marshal(query.Tags)
+	paramsJSON, err := json.Marshal(query.Parameters)
+	if err != nil {
+		return fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+
+	_, err = r.store.execWithAudit(ctx, "INSERT", "saved_queries", `
+		INSERT INTO saved_queries (`+savedQueryColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		query.ID,
+		string(query.UserID),
+		query.Name,
+		query.Description,
+		query.Query,
+		string(paramsJSON),
+		string(tagsJSON),
+		boolToInt(query.IsPublic),
+		query.UseCount,
+		query.CreatedAt.UTC().Format(time.RFC3339Nano),
+		query.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		if isConstraintError(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create saved query: %w", err)
+	}
+
 	return nil
 }
 
 // Get retrieves a saved query by ID.
 func (r *SavedQueryRepository) Get(ctx context.Context, id string) (*storage.SavedQuery, error) {
-	// TODO: Implement when Phase 3 CEL query system is ready
-	return nil, storage.ErrNotFound
+	return r.getOne(ctx, "id = ?", id)
+}
+
+// GetByName retrieves a user's saved query by name.
+func (r *SavedQueryRepository) GetByName(ctx context.Context, userID domain.UserID, name string) (*storage.SavedQuery, error) {
+	return r.getOne(ctx, "user_id = ? AND name = ?", string(userID), name)
+}
+
+func (r *SavedQueryRepository) getOne(ctx context.Context, where string, args ...any) (*storage.SavedQuery, error) {
+	rows, err := r.store.queryWithAudit(ctx, "saved_queries",
+		"SELECT "+savedQueryColumns+" FROM saved_queries WHERE "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved query: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, storage.ErrNotFound
+	}
+
+	return scanSavedQuery(rows)
 }
 
 // Update updates a saved query.
 func (r *SavedQueryRepository) Update(ctx context.Context, query *storage.SavedQuery) error {
-	// TODO: Implement when Phase 3 CEL query system is ready
+	tagsJSON, _ := json.Marshal(query.Tags)
+	paramsJSON, err := json.Marshal(query.Parameters)
+	if err != nil {
+		return fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+
+	result, err := r.store.execWithAudit(ctx, "UPDATE", "saved_queries", `
+		UPDATE saved_queries SET
+			name = ?,
+			description = ?,
+			query = ?,
+			parameters = ?,
+			tags = ?,
+			is_public = ?,
+			updated_at = ?
+		WHERE id = ?
+	`,
+		query.Name,
+		query.Description,
+		query.Query,
+		string(paramsJSON),
+		string(tagsJSON),
+		boolToInt(query.IsPublic),
+		query.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		query.ID,
+	)
+	if err != nil {
+		if isConstraintError(err) {
+			return storage.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to update saved query: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
 	return nil
 }
 
 // Delete deletes a saved query.
 func (r *SavedQueryRepository) Delete(ctx context.Context, id string) error {
-	// TODO: Implement when Phase 3 CEL query system is ready
+	result, err := r.store.execWithAudit(ctx, "DELETE", "saved_queries", `
+		DELETE FROM saved_queries WHERE id = ?
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete saved query: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// IncrementUseCount records that a saved query was run.
+func (r *SavedQueryRepository) IncrementUseCount(ctx context.Context, id string) error {
+	result, err := r.store.execWithAudit(ctx, "UPDATE", "saved_queries", `
+		UPDATE saved_queries SET use_count = use_count + 1 WHERE id = ?
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to update saved query: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrNotFound
+	}
+
 	return nil
 }
 
 // List lists saved queries with optional filtering.
 func (r *SavedQueryRepository) List(ctx context.Context, filter storage.SavedQueryFilter) ([]*storage.SavedQuery, error) {
-	// TODO: Implement when Phase 3 CEL query system is ready
-	return []*storage.SavedQuery{}, nil
+	where, args := savedQueryWhere(filter)
+	query := "SELECT " + savedQueryColumns + " FROM saved_queries WHERE " + where + " ORDER BY name, created_at"
+
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+	if filter.Offset > 0 {
+		if filter.Limit <= 0 {
+			query += " LIMIT -1"
+		}
+		query += " OFFSET ?"
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := r.store.queryWithAudit(ctx, "saved_queries", query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved queries: %w", err)
+	}
+	defer rows.Close()
+
+	var queries []*storage.SavedQuery
+	for rows.Next() {
+		q, err := scanSavedQuery(rows)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+
+	return queries, rows.Err()
 }
 
 // Count returns the count of saved queries matching a filter.
 func (r *SavedQueryRepository) Count(ctx context.Context, filter storage.SavedQueryFilter) (int64, error) {
-	// TODO: Implement when Phase 3 CEL query system is ready
-	return 0, nil
+	where, args := savedQueryWhere(filter)
+
+	rows, err := r.store.queryWithAudit(ctx, "saved_queries", "SELECT COUNT(*) FROM saved_queries WHERE "+where, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count saved queries: %w", err)
+	}
+	defer rows.Close()
+
+	var count int64
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, err
+		}
+	}
+
+	return count, rows.Err()
+}
+
+// savedQueryWhere builds the WHERE clause for a filter.
+func savedQueryWhere(filter storage.SavedQueryFilter) (string, []any) {
+	where := "1=1"
+	args := []any{}
+
+	switch {
+	case filter.UserID != nil && filter.IncludePublic:
+		where += " AND (user_id = ? OR is_public = 1)"
+		args = append(args, string(*filter.UserID))
+	case filter.UserID != nil:
+		where += " AND user_id = ?"
+		args = append(args, string(*filter.UserID))
+	}
+
+	if filter.PublicOnly {
+		where += " AND is_public = 1"
+	}
+
+	if filter.Name != "" {
+		where += " AND name = ?"
+		args = append(args, filter.Name)
+	}
+
+	for _, tag := range filter.Tags {
+		where += " AND EXISTS (SELECT 1 FROM json_each(saved_queries.tags) WHERE json_each.value = ?)"
+		args = append(args, tag)
+	}
+
+	if filter.Search != "" {
+		where += " AND (name LIKE ? OR description LIKE ? OR query LIKE ?)"
+		search := "%" + filter.Search + "%"
+		args = append(args, search, search, search)
+	}
+
+	return where, args
+}
+
+func scanSavedQuery(rows *sql.Rows) (*storage.SavedQuery, error) {
+	var (
+		q           storage.SavedQuery
+		userID      string
+		description sql.NullString
+		paramsJSON  sql.NullString
+		tagsJSON    sql.NullString
+		isPublic    int
+		createdAt   string
+		updatedAt   string
+	)
+
+	err := rows.Scan(
+		&q.ID, &userID, &q.Name, &description, &q.Query, &paramsJSON,
+		&tagsJSON, &isPublic, &q.UseCount, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan saved query: %w", err)
+	}
+
+	q.UserID = domain.UserID(userID)
+	q.Description = description.String
+	q.IsPublic = isPublic == 1
+
+	if t, err := time.Parse(time.RFC3339Nano, createdAt); err == nil {
+		q.CreatedAt = t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, updatedAt); err == nil {
+		q.UpdatedAt = t
+	}
+
+	if paramsJSON.Valid && paramsJSON.String != "" {
+		var params map[string]any
+		if err := json.Unmarshal([]byte(paramsJSON.String), &params); err == nil {
+			q.Parameters = params
+		}
+	}
+
+	if tagsJSON.Valid && tagsJSON.String != "" {
+		var tags []string
+		if err := json.Unmarshal([]byte(tagsJSON.String), &tags); err == nil {
+			q.Tags = tags
+		}
+	}
+
+	return &q, nil
 }
@@ -0,0 +1,188 @@
+//go:build sqlcipher && cgo
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"bib/internal/storage"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// errNotSQLCipher is returned when the SQLite library bibd is linked
+// against is not SQLCipher.
+var errNotSQLCipher = errors.New("the SQLite library bibd is linked against is not SQLCipher; the database would not be encrypted")
+
+// openEncrypted opens the SQLCipher database at dbPath with key, first
+// encrypting an unencrypted database there in place.
+//
+// The SQLite library must be SQLCipher: build with the libsqlite3 tag of
+// go-sqlite3 and link against libsqlcipher. A plain SQLite library ignores
+// the key, so every connection checks it is talking to SQLCipher.
+func openEncrypted(dbPath string, key *storage.SQLiteKey) (*sql.DB, error) {
+	format, err := detectFormat(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database header: %w", err)
+	}
+	if format == formatPlain {
+		if err := encryptDatabase(dbPath, key); err != nil {
+			return nil, fmt.Errorf("failed to encrypt the unencrypted database: %w", err)
+		}
+	}
+
+	// The busy timeout and the write lock of transactions match the
+	// unencrypted store; foreign keys are enabled after the key is set,
+	// as nothing may touch the database before
+	db := sql.OpenDB(&cipherConnector{
+		dsn: dbPath + "?_busy_timeout=5000&_txlock=immediate",
+		driver: &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				return unlock(conn, key)
+			},
+		},
+	})
+
+	// Connect once, so a wrong key fails here rather than on first use
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// cipherConnector opens connections with a driver keyed for one database.
+type cipherConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+}
+
+// Connect implements driver.Connector.
+func (c *cipherConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+// Driver implements driver.Connector.
+func (c *cipherConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// unlock sets the key of a new connection and checks that it decrypts the
+// database.
+func unlock(conn *sqlite3.SQLiteConn, key *storage.SQLiteKey) error {
+	if _, err := conn.Exec("PRAGMA key = "+quoteString(keyText(key)), nil); err != nil {
+		return fmt.Errorf("failed to set the database key: %w", err)
+	}
+	if err := checkSQLCipher(conn); err != nil {
+		return err
+	}
+
+	// SQLCipher only checks the key when the first page is read
+	if _, err := conn.Exec("SELECT count(*) FROM sqlite_master", nil); err != nil {
+		return fmt.Errorf("the key does not decrypt the database: %w", err)
+	}
+	if _, err := conn.Exec("PRAGMA foreign_keys = ON", nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkSQLCipher returns an error unless the SQLite library of conn is
+// SQLCipher, which is the only one that answers PRAGMA cipher_version.
+func checkSQLCipher(conn *sqlite3.SQLiteConn) error {
+	rows, err := conn.Query("PRAGMA cipher_version", nil)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	version := make([]driver.Value, len(rows.Columns()))
+	if err := rows.Next(version); errors.Is(err, io.EOF) {
+		return errNotSQLCipher
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// encryptDatabase encrypts the unencrypted database at path in place. The
+// database is exported into an encrypted copy next to it, which is synced
+// and renamed over the original, so an interrupted run leaves the
+// unencrypted database in use and the next start encrypts it again. The
+// blocks of the original may remain on the disk until they are
+// overwritten.
+func encryptDatabase(path string, key *storage.SQLiteKey) error {
+	tmp := path + ".encrypting"
+	if err := os.Remove(tmp); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// Without a key, SQLCipher reads the database like plain SQLite
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	// ATTACH only applies to the connection it runs on
+	db.SetMaxOpenConns(1)
+
+	var cipherVersion string
+	if err := db.QueryRow("PRAGMA cipher_version").Scan(&cipherVersion); errors.Is(err, sql.ErrNoRows) {
+		return errNotSQLCipher
+	} else if err != nil {
+		return err
+	}
+	var userVersion int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&userVersion); err != nil {
+		return err
+	}
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{"PRAGMA wal_checkpoint(TRUNCATE)", nil},
+		{"ATTACH DATABASE ? AS encrypted KEY ?", []any{tmp, keyText(key)}},
+		{"SELECT sqlcipher_export('encrypted')", nil},
+		{fmt.Sprintf("PRAGMA encrypted.user_version = %d", userVersion), nil},
+		{"DETACH DATABASE encrypted", nil},
+	} {
+		if _, err := db.Exec(stmt.query, stmt.args...); err != nil {
+			os.Remove(tmp)
+			return fmt.Errorf("%s: %w", stmt.query, err)
+		}
+	}
+	if err := db.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := syncFile(tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	// The write-ahead log was checkpointed into the database, and must not
+	// be applied to the encrypted one
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			os.Remove(tmp)
+			return err
+		}
+	}
+	return os.Rename(tmp, path)
+}
+
+// syncFile flushes the file at path to disk.
+func syncFile(path string) error {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Sync()
+}
@@ -0,0 +1,16 @@
+//go:build !sqlcipher || !cgo
+
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"bib/internal/storage"
+)
+
+// openEncrypted fails: SQLCipher support requires cgo and the sqlcipher
+// build tag.
+func openEncrypted(dbPath string, key *storage.SQLiteKey) (*sql.DB, error) {
+	return nil, fmt.Errorf("this binary was built without SQLCipher support (rebuild with CGO_ENABLED=1 and -tags \"sqlcipher libsqlite3\", linked against libsqlcipher)")
+}
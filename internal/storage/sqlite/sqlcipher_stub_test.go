@@ -0,0 +1,18 @@
+//go:build !sqlcipher || !cgo
+
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"bib/internal/storage"
+)
+
+func TestNew_EncryptedWithoutSQLCipher(t *testing.T) {
+	cfg := storage.SQLiteConfig{Key: &storage.SQLiteKey{Passphrase: "secret"}}
+	_, err := New(cfg, t.TempDir(), "test-node")
+	if err == nil || !strings.Contains(err.Error(), "-tags") {
+		t.Errorf("New() with a key error = %v, want a hint at the sqlcipher build tag", err)
+	}
+}
@@ -0,0 +1,73 @@
+//go:build sqlcipher && cgo
+
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+func TestNew_EncryptsUnencryptedDatabase(t *testing.T) {
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cache.db")
+
+	// An unencrypted database with data in it
+	store, err := New(storage.SQLiteConfig{Path: path}, dir, "test-node")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := storage.RunMigrations(ctx, store, storage.DefaultMigrationsConfig()); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	topic := &domain.Topic{
+		ID:        domain.TopicID("t1"),
+		Name:      "weather",
+		Status:    domain.TopicStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := store.Topics().Create(ctx, topic); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	store.Close()
+
+	key := &storage.SQLiteKey{Raw: bytes.Repeat([]byte{7}, 32)}
+	store, err = New(storage.SQLiteConfig{Path: path, Key: key}, dir, "test-node")
+	if err != nil {
+		t.Fatalf("New() with a key error = %v", err)
+	}
+	got, err := store.Topics().Get(ctx, "t1")
+	store.Close()
+	if err != nil || got.Name != "weather" {
+		t.Fatalf("Get() after encrypting = %v, %v; want the topic", got, err)
+	}
+
+	header, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.HasPrefix(header, plaintextHeader) {
+		t.Error("database is still unencrypted")
+	}
+
+	// Neither another key nor no key opens it
+	other := &storage.SQLiteKey{Passphrase: "guess"}
+	if _, err := New(storage.SQLiteConfig{Path: path, Key: other}, dir, "test-node"); err == nil {
+		t.Error("New() with another key succeeded")
+	}
+	if _, err := New(storage.SQLiteConfig{Path: path}, dir, "test-node"); err == nil {
+		t.Error("New() without a key succeeded")
+	}
+}
@@ -50,11 +50,27 @@ func New(cfg storage.SQLiteConfig, dataDir, nodeID string) (*Store, error) {
 	}
 
 	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0700); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	// An unencrypted database is opened with the pure Go driver, an
+	// encrypted one with SQLCipher
+	var (
+		db  *sql.DB
+		err error
+	)
+	if cfg.Key != nil {
+		db, err = openEncrypted(dbPath, cfg.Key)
+	} else if err = checkUnencrypted(dbPath); err == nil {
+		// Foreign keys and the busy timeout are per-connection settings, so
+		// they go into the DSN for every connection of the pool to apply
+		// them. Transactions take the write lock up front: a deferred
+		// transaction that later writes fails with SQLITE_BUSY instead of
+		// waiting.
+		dsn := dbPath + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate"
+		db, err = sql.Open("sqlite", dsn)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -71,18 +87,6 @@ func New(cfg storage.SQLiteConfig, dataDir, nodeID string) (*Store, error) {
 		return nil, fmt.Errorf("failed to enable WAL mode: %w", err)
 	}
 
-	// Enable foreign keys
-	if _, err := db.Exec("PRAGMA foreign_keys=ON"); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
-	}
-
-	// Set busy timeout
-	if _, err := db.Exec("PRAGMA busy_timeout=5000"); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to set busy timeout: %w", err)
-	}
-
 	s := &Store{
 		db:     db,
 		cfg:    cfg,
@@ -282,6 +286,16 @@ func (s *Store) Stats(ctx context.Context) (storage.StorageStats, error) {
 	return stats, nil
 }
 
+// ConnectionStats returns the statistics of the connection pool.
+func (s *Store) ConnectionStats() storage.ConnectionStats {
+	dbStats := s.db.Stats()
+	return storage.ConnectionStats{
+		InUse: dbStats.InUse,
+		Idle:  dbStats.Idle,
+		Max:   dbStats.MaxOpenConnections,
+	}
+}
+
 // DB returns the underlying database connection.
 // Use with caution - prefer repository methods.
 func (s *Store) DB() *sql.DB {
@@ -296,7 +310,7 @@ func (s *Store) execWithAudit(ctx context.Context, action, table, query string,
 	// Add query comment for tagging
 	taggedQuery := oc.QueryComment() + " " + query
 
-	result, err := s.db.ExecContext(ctx, taggedQuery, args...)
+	result, err := s.conn(ctx).ExecContext(ctx, taggedQuery, args...)
 
 	duration := time.Since(start)
 
@@ -335,7 +349,7 @@ func (s *Store) queryWithAudit(ctx context.Context, table, query string, args ..
 	// Add query comment for tagging
 	taggedQuery := oc.QueryComment() + " " + query
 
-	rows, err := s.db.QueryContext(ctx, taggedQuery, args...)
+	rows, err := s.conn(ctx).QueryContext(ctx, taggedQuery, args...)
 
 	duration := time.Since(start)
 
@@ -1,7 +1,9 @@
 package sqlite
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -9,6 +11,7 @@ import (
 
 	"bib/internal/domain"
 	"bib/internal/storage"
+	"bib/internal/storage/storagetest"
 )
 
 func TestStore_CreateAndMigrate(t *testing.T) {
@@ -48,6 +51,39 @@ func TestStore_CreateAndMigrate(t *testing.T) {
 	}
 }
 
+func TestStore_ConnectionStats(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := New(storage.SQLiteConfig{
+		Path:         filepath.Join(tmpDir, "test.db"),
+		MaxOpenConns: 5,
+	}, tmpDir, "test-node-id")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.Ping(context.Background()); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+
+	var provider storage.ConnectionStatsProvider = store
+	stats := provider.ConnectionStats()
+	if stats.Max != 5 {
+		t.Errorf("expected max 5 connections, got %d", stats.Max)
+	}
+	if stats.InUse != 0 || stats.Idle < 1 {
+		t.Errorf("expected an idle connection after ping, got %+v", stats)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Store {
+		store := setupTestStore(t)
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
 func TestTopicRepository_CRUD(t *testing.T) {
 	store := setupTestStore(t)
 	defer store.Close()
@@ -104,6 +140,40 @@ func TestTopicRepository_CRUD(t *testing.T) {
 	if got.Description != "Updated description" {
 		t.Errorf("expected updated description, got %s", got.Description)
 	}
+	if got.IsRestricted() {
+		t.Errorf("expected no ACL, got %+v", got.ACL)
+	}
+
+	// ACL is stored with the topic
+	topic.Grant(domain.TopicACLEntry{
+		PrincipalType: domain.TopicPrincipalUser,
+		Principal:     "user-2",
+		Permission:    domain.TopicPermissionWrite,
+		GrantedBy:     "user-1",
+		GrantedAt:     time.Now().UTC(),
+	})
+	if err := repo.Update(ctx, topic); err != nil {
+		t.Fatalf("failed to update topic ACL: %v", err)
+	}
+
+	got, _ = repo.Get(ctx, topic.ID)
+	if len(got.ACL) != 1 || got.ACL[0].Principal != "user-2" || got.ACL[0].Permission != domain.TopicPermissionWrite {
+		t.Errorf("expected ACL to round-trip, got %+v", got.ACL)
+	}
+	if got.DataSchema != nil {
+		t.Errorf("expected no data schema, got %+v", got.DataSchema)
+	}
+
+	// Data schema is stored with the topic
+	topic.DataSchema = &domain.DataSchema{JSONSchema: `{"type": "object"}`, Mode: domain.SchemaModeWarn}
+	if err := repo.Update(ctx, topic); err != nil {
+		t.Fatalf("failed to update topic data schema: %v", err)
+	}
+
+	got, _ = repo.Get(ctx, topic.ID)
+	if got.DataSchema == nil || *got.DataSchema != *topic.DataSchema {
+		t.Errorf("expected data schema to round-trip, got %+v", got.DataSchema)
+	}
 
 	// List
 	topics, err := repo.List(ctx, storage.TopicFilter{})
@@ -126,7 +196,7 @@ func TestTopicRepository_CRUD(t *testing.T) {
 	}
 
 	// Delete (soft delete)
-	if err := repo.Delete(ctx, topic.ID); err != nil {
+	if err := repo.Delete(ctx, topic.ID, 0); err != nil {
 		t.Fatalf("failed to delete topic: %v", err)
 	}
 
@@ -136,6 +206,97 @@ func TestTopicRepository_CRUD(t *testing.T) {
 	}
 }
 
+func TestTopicRepository_CorruptACL(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	topic := &domain.Topic{
+		ID:        domain.TopicID("topic-1"),
+		Name:      "Restricted Topic",
+		Status:    domain.TopicStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	topic.Grant(domain.TopicACLEntry{
+		PrincipalType: domain.TopicPrincipalUser,
+		Principal:     "user-2",
+		Permission:    domain.TopicPermissionRead,
+	})
+	if err := store.Topics().Create(ctx, topic); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	if _, err := store.DB().ExecContext(ctx, `UPDATE topics SET acl = '{not json' WHERE id = ?`, topic.ID); err != nil {
+		t.Fatalf("failed to corrupt ACL: %v", err)
+	}
+
+	// A topic whose ACL can't be read must not come back unrestricted
+	if got, err := store.Topics().Get(ctx, topic.ID); err == nil {
+		t.Errorf("expected an error reading a corrupt ACL, got topic with ACL %+v", got.ACL)
+	}
+	if _, err := store.Topics().List(ctx, storage.TopicFilter{}); err == nil {
+		t.Error("expected an error listing a topic with a corrupt ACL")
+	}
+}
+
+func TestTopicRepository_UpdateRevision(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	repo := store.Topics()
+	if err := repo.Create(ctx, txTestTopic("topic-1")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	first, _ := repo.Get(ctx, "topic-1")
+	second, _ := repo.Get(ctx, "topic-1")
+	if first.Revision != 1 {
+		t.Fatalf("expected revision 1, got %d", first.Revision)
+	}
+
+	first.Description = "first"
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("failed to update topic: %v", err)
+	}
+	if first.Revision != 2 {
+		t.Errorf("expected the update to bump the revision to 2, got %d", first.Revision)
+	}
+
+	// The second writer read revision 1 and must not clobber the first
+	second.Description = "second"
+	if err := repo.Update(ctx, second); !storage.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if got, _ := repo.Get(ctx, "topic-1"); got.Description != "first" || got.Revision != 2 {
+		t.Errorf("expected the first update to stand, got %q at revision %d", got.Description, got.Revision)
+	}
+
+	// Revision 0 skips the check
+	second.Revision = 0
+	if err := repo.Update(ctx, second); err != nil {
+		t.Fatalf("failed to update topic without revision: %v", err)
+	}
+	if second.Revision != 3 {
+		t.Errorf("expected revision 3, got %d", second.Revision)
+	}
+
+	missing := txTestTopic("topic-2")
+	missing.Revision = 1
+	if err := repo.Update(ctx, missing); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for a missing topic, got %v", err)
+	}
+}
+
 func TestDatasetRepository_CRUD(t *testing.T) {
 	store := setupTestStore(t)
 	defer store.Close()
@@ -171,6 +332,7 @@ func TestDatasetRepository_CRUD(t *testing.T) {
 		CreatedBy:   "user-1",
 		CreatedAt:   time.Now().UTC(),
 		UpdatedAt:   time.Now().UTC(),
+		DataSchema:  &domain.DataSchema{JSONSchema: `{"type": "array"}`, Mode: domain.SchemaModeEnforce},
 	}
 
 	if err := repo.Create(ctx, dataset); err != nil {
@@ -186,6 +348,9 @@ func TestDatasetRepository_CRUD(t *testing.T) {
 	if got.Name != dataset.Name {
 		t.Errorf("expected name %s, got %s", dataset.Name, got.Name)
 	}
+	if got.DataSchema == nil || *got.DataSchema != *dataset.DataSchema {
+		t.Errorf("expected data schema to round-trip, got %+v", got.DataSchema)
+	}
 
 	// List
 	datasets, err := repo.List(ctx, storage.DatasetFilter{TopicID: &topic.ID})
@@ -208,6 +373,99 @@ func TestDatasetRepository_CRUD(t *testing.T) {
 	}
 }
 
+func TestDatasetRepository_DeleteVersion(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	topic := &domain.Topic{
+		ID:        domain.TopicID("topic-1"),
+		Name:      "Test Topic",
+		Status:    domain.TopicStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := store.Topics().Create(ctx, topic); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	repo := store.Datasets()
+	dataset := &domain.Dataset{
+		ID:        domain.DatasetID("dataset-1"),
+		TopicID:   topic.ID,
+		Name:      "Test Dataset",
+		Status:    domain.DatasetStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := repo.Create(ctx, dataset); err != nil {
+		t.Fatalf("failed to create dataset: %v", err)
+	}
+
+	// Three versions chained v1 <- v2 <- v3, each with one chunk
+	var previous domain.DatasetVersionID
+	for n := 1; n <= 3; n++ {
+		id := domain.DatasetVersionID(domain.VersionString(n))
+		if err := repo.CreateVersion(ctx, &domain.DatasetVersion{
+			ID:                id,
+			DatasetID:         dataset.ID,
+			Version:           domain.VersionString(n),
+			PreviousVersionID: previous,
+			Content:           &domain.DatasetContent{Hash: "hash", Size: 1, ChunkCount: 1},
+			CreatedBy:         "user-1",
+			CreatedAt:         time.Now().UTC().Add(time.Duration(n) * time.Second),
+		}); err != nil {
+			t.Fatalf("failed to create version %d: %v", n, err)
+		}
+		if err := repo.CreateChunk(ctx, &domain.Chunk{
+			ID:        domain.ChunkID("chunk-" + string(id)),
+			DatasetID: dataset.ID,
+			VersionID: id,
+			Hash:      "hash",
+			Size:      1,
+			Status:    domain.ChunkStatusVerified,
+		}); err != nil {
+			t.Fatalf("failed to create chunk %d: %v", n, err)
+		}
+		previous = id
+	}
+
+	// Deleting the middle version relinks the chain
+	if err := repo.DeleteVersion(ctx, dataset.ID, "2.0.0"); err != nil {
+		t.Fatalf("failed to delete version: %v", err)
+	}
+
+	versions, err := repo.ListVersions(ctx, dataset.ID)
+	if err != nil {
+		t.Fatalf("failed to list versions: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if versions[0].Number() != 3 || versions[0].PreviousVersionID != "1.0.0" {
+		t.Errorf("expected version 3 to follow version 1, got %d after %q", versions[0].Number(), versions[0].PreviousVersionID)
+	}
+
+	chunks, err := repo.ListChunks(ctx, "2.0.0")
+	if err != nil {
+		t.Fatalf("failed to list chunks: %v", err)
+	}
+	if len(chunks) != 0 {
+		t.Errorf("expected the deleted version's chunks to be gone, got %d", len(chunks))
+	}
+
+	if err := repo.DeleteVersion(ctx, dataset.ID, "2.0.0"); err != storage.ErrNotFound {
+		t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+	}
+}
+
 func TestJobRepository_CRUD(t *testing.T) {
 	store := setupTestStore(t)
 	defer store.Close()
@@ -349,6 +607,270 @@ func TestNodeRepository_CRUD(t *testing.T) {
 	}
 }
 
+func TestTopicInvitationRepository_Requests(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	owner := domain.NewUser(bytes.Repeat([]byte{1}, 32), domain.KeyTypeEd25519, "owner", "", true)
+	requester := domain.NewUser(bytes.Repeat([]byte{2}, 32), domain.KeyTypeEd25519, "requester", "", false)
+	for _, u := range []*domain.User{owner, requester} {
+		if err := store.Users().Create(ctx, u); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+
+	topic := &domain.Topic{
+		ID:        domain.TopicID("topic-1"),
+		Name:      "Test Topic",
+		Status:    domain.TopicStatusActive,
+		Owners:    []domain.UserID{owner.ID},
+		CreatedBy: owner.ID,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := store.Topics().Create(ctx, topic); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	repo := store.TopicInvitations()
+
+	invite := &storage.TopicInvitation{
+		ID:            "inv-1",
+		TopicID:       topic.ID,
+		InviterID:     owner.ID,
+		InviteeUserID: requester.ID,
+		Role:          storage.TopicMemberRoleViewer,
+		Token:         "token-1",
+		Status:        storage.InvitationStatusPending,
+		ExpiresAt:     time.Now().UTC().Add(time.Hour),
+		CreatedAt:     time.Now().UTC(),
+	}
+	request := &storage.TopicInvitation{
+		ID:            "req-1",
+		Kind:          storage.InvitationKindRequest,
+		TopicID:       topic.ID,
+		InviterID:     requester.ID,
+		InviteeUserID: requester.ID,
+		Role:          storage.TopicMemberRoleEditor,
+		Token:         "token-2",
+		Status:        storage.InvitationStatusPending,
+		ExpiresAt:     time.Now().UTC().Add(time.Hour),
+		CreatedAt:     time.Now().UTC(),
+	}
+	for _, inv := range []*storage.TopicInvitation{invite, request} {
+		if err := repo.Create(ctx, inv); err != nil {
+			t.Fatalf("failed to create invitation: %v", err)
+		}
+	}
+
+	got, err := repo.Get(ctx, invite.ID)
+	if err != nil {
+		t.Fatalf("failed to get invitation: %v", err)
+	}
+	if got.Kind != storage.InvitationKindInvite {
+		t.Errorf("expected kind to default to invite, got %s", got.Kind)
+	}
+
+	// Filter by kind
+	requests, err := repo.ListByTopic(ctx, topic.ID, storage.InvitationFilter{Kind: storage.InvitationKindRequest})
+	if err != nil {
+		t.Fatalf("failed to list requests: %v", err)
+	}
+	if len(requests) != 1 || requests[0].ID != request.ID {
+		t.Fatalf("expected only the request, got %+v", requests)
+	}
+
+	// Approve
+	now := time.Now().UTC()
+	request.Status = storage.InvitationStatusAccepted
+	request.RespondedAt = &now
+	request.RespondedBy = owner.ID
+	if err := repo.Update(ctx, request); err != nil {
+		t.Fatalf("failed to update request: %v", err)
+	}
+
+	got, _ = repo.Get(ctx, request.ID)
+	if got.Status != storage.InvitationStatusAccepted || got.RespondedBy != owner.ID || got.RespondedAt == nil {
+		t.Errorf("expected approved request, got %+v", got)
+	}
+}
+
+func TestSavedQueryRepository(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	alice := domain.NewUser(bytes.Repeat([]byte{1}, 32), domain.KeyTypeEd25519, "alice", "", false)
+	bob := domain.NewUser(bytes.Repeat([]byte{2}, 32), domain.KeyTypeEd25519, "bob", "", false)
+	for _, u := range []*domain.User{alice, bob} {
+		if err := store.Users().Create(ctx, u); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+
+	repo := store.SavedQueries()
+	now := time.Now().UTC()
+
+	private := &storage.SavedQuery{
+		ID:         "q-1",
+		UserID:     alice.ID,
+		Name:       "hot-days",
+		Query:      "temp > ${min}",
+		Tags:       []string{"weather"},
+		Parameters: map[string]any{"min": float64(30)},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	shared := &storage.SavedQuery{
+		ID:        "q-2",
+		UserID:    bob.ID,
+		Name:      "cold-days",
+		Query:     "temp < 0",
+		IsPublic:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, q := range []*storage.SavedQuery{private, shared} {
+		if err := repo.Create(ctx, q); err != nil {
+			t.Fatalf("failed to create saved query: %v", err)
+		}
+	}
+
+	// Names are unique per user
+	dup := *private
+	dup.ID = "q-3"
+	if err := repo.Create(ctx, &dup); !errors.Is(err, storage.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate name, got %v", err)
+	}
+
+	got, err := repo.GetByName(ctx, alice.ID, "hot-days")
+	if err != nil {
+		t.Fatalf("failed to get saved query by name: %v", err)
+	}
+	if got.Query != private.Query || got.Parameters["min"] != float64(30) || len(got.Tags) != 1 {
+		t.Errorf("expected saved query to round-trip, got %+v", got)
+	}
+	if _, err := repo.GetByName(ctx, bob.ID, "hot-days"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for another user's name, got %v", err)
+	}
+
+	// Own queries, with and without public ones
+	own, err := repo.List(ctx, storage.SavedQueryFilter{UserID: &alice.ID})
+	if err != nil {
+		t.Fatalf("failed to list saved queries: %v", err)
+	}
+	if len(own) != 1 {
+		t.Errorf("expected 1 own query, got %d", len(own))
+	}
+	visible, _ := repo.List(ctx, storage.SavedQueryFilter{UserID: &alice.ID, IncludePublic: true})
+	if len(visible) != 2 {
+		t.Errorf("expected own and public queries, got %d", len(visible))
+	}
+	tagged, _ := repo.List(ctx, storage.SavedQueryFilter{Tags: []string{"weather"}})
+	if len(tagged) != 1 || tagged[0].ID != private.ID {
+		t.Errorf("expected the tagged query, got %+v", tagged)
+	}
+	if count, _ := repo.Count(ctx, storage.SavedQueryFilter{PublicOnly: true}); count != 1 {
+		t.Errorf("expected 1 public query, got %d", count)
+	}
+
+	if err := repo.IncrementUseCount(ctx, private.ID); err != nil {
+		t.Fatalf("failed to increment use count: %v", err)
+	}
+	got, _ = repo.Get(ctx, private.ID)
+	if got.UseCount != 1 {
+		t.Errorf("expected use count 1, got %d", got.UseCount)
+	}
+
+	private.Query = "temp >= ${min}"
+	private.UpdatedAt = time.Now().UTC()
+	if err := repo.Update(ctx, private); err != nil {
+		t.Fatalf("failed to update saved query: %v", err)
+	}
+	got, _ = repo.Get(ctx, private.ID)
+	if got.Query != private.Query || got.UseCount != 1 {
+		t.Errorf("expected updated query keeping its use count, got %+v", got)
+	}
+
+	if err := repo.Delete(ctx, private.ID); err != nil {
+		t.Fatalf("failed to delete saved query: %v", err)
+	}
+	if _, err := repo.Get(ctx, private.ID); !storage.IsNotFound(err) {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+}
+
+func TestUserRepository_PublicKeys(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	alice := domain.NewUser(bytes.Repeat([]byte{1}, 32), domain.KeyTypeEd25519, "alice", "", false)
+	bob := domain.NewUser(bytes.Repeat([]byte{2}, 32), domain.KeyTypeEd25519, "bob", "", false)
+	for _, u := range []*domain.User{alice, bob} {
+		if err := store.Users().Create(ctx, u); err != nil {
+			t.Fatalf("failed to create user: %v", err)
+		}
+	}
+
+	repo := store.Users()
+	laptop := domain.NewUserPublicKey(alice.ID, bytes.Repeat([]byte{3}, 32), domain.KeyTypeEd25519, "laptop")
+	if err := repo.AddPublicKey(ctx, laptop); err != nil {
+		t.Fatalf("failed to add public key: %v", err)
+	}
+
+	// Keys and labels are unique
+	if err := repo.AddPublicKey(ctx, domain.NewUserPublicKey(bob.ID, laptop.PublicKey, domain.KeyTypeEd25519, "desktop")); !errors.Is(err, domain.ErrKeyExists) {
+		t.Errorf("expected ErrKeyExists for a registered key, got %v", err)
+	}
+	if err := repo.AddPublicKey(ctx, domain.NewUserPublicKey(alice.ID, bytes.Repeat([]byte{4}, 32), domain.KeyTypeEd25519, "laptop")); !errors.Is(err, domain.ErrKeyExists) {
+		t.Errorf("expected ErrKeyExists for a duplicate label, got %v", err)
+	}
+
+	// The additional key authenticates as its user
+	got, err := repo.GetByPublicKey(ctx, laptop.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to get user by additional key: %v", err)
+	}
+	if got.ID != alice.ID {
+		t.Errorf("expected alice, got %s", got.ID)
+	}
+	if exists, _ := repo.Exists(ctx, laptop.PublicKey); !exists {
+		t.Error("expected additional key to exist")
+	}
+
+	keys, err := repo.ListPublicKeys(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("failed to list public keys: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Label != "laptop" || keys[0].Fingerprint != laptop.Fingerprint {
+		t.Errorf("expected the laptop key, got %+v", keys)
+	}
+	if keys, _ := repo.ListPublicKeys(ctx, bob.ID); len(keys) != 0 {
+		t.Errorf("expected no keys for bob, got %d", len(keys))
+	}
+
+	if err := repo.RemovePublicKey(ctx, bob.ID, laptop.Fingerprint); !errors.Is(err, domain.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound removing another user's key, got %v", err)
+	}
+	if err := repo.RemovePublicKey(ctx, alice.ID, laptop.Fingerprint); err != nil {
+		t.Fatalf("failed to remove public key: %v", err)
+	}
+	if _, err := repo.GetByPublicKey(ctx, laptop.PublicKey); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("expected removed key to no longer authenticate, got %v", err)
+	}
+}
+
 func setupTestStore(t *testing.T) *Store {
 	t.Helper()
 
@@ -19,14 +19,18 @@ type TopicInvitationRepository struct {
 func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.TopicInvitation) error {
 	query := `
 		INSERT INTO topic_invitations (
-			id, topic_id, inviter_id, invitee_email, invitee_user_id,
-			role, token, message, status, expires_at, created_at, responded_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+			role, token, message, status, expires_at, created_at, responded_at,
+			responded_by
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	var inviteeEmail, inviteeUserID, message *string
+	var inviteeEmail, inviteeUserID, message, respondedBy *string
 	var respondedAt *string
 
+	if inv.Kind == "" {
+		inv.Kind = storage.InvitationKindInvite
+	}
 	if inv.InviteeEmail != "" {
 		inviteeEmail = &inv.InviteeEmail
 	}
@@ -37,13 +41,18 @@ func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.Top
 	if inv.Message != "" {
 		message = &inv.Message
 	}
+	if inv.RespondedBy != "" {
+		s := string(inv.RespondedBy)
+		respondedBy = &s
+	}
 	if inv.RespondedAt != nil {
 		s := inv.RespondedAt.Format(time.RFC3339)
 		respondedAt = &s
 	}
 
-	_, err := r.store.db.ExecContext(ctx, query,
+	_, err := r.store.conn(ctx).ExecContext(ctx, query,
 		inv.ID,
+		string(inv.Kind),
 		string(inv.TopicID),
 		string(inv.InviterID),
 		inviteeEmail,
@@ -55,6 +64,7 @@ func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.Top
 		inv.ExpiresAt.Format(time.RFC3339),
 		inv.CreatedAt.Format(time.RFC3339),
 		respondedAt,
+		respondedBy,
 	)
 
 	return err
@@ -63,32 +73,35 @@ func (r *TopicInvitationRepository) Create(ctx context.Context, inv *storage.Top
 // Get retrieves an invitation by ID.
 func (r *TopicInvitationRepository) Get(ctx context.Context, id string) (*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE id = ?
 	`
 
-	return r.scanInvitation(r.store.db.QueryRowContext(ctx, query, id))
+	return r.scanInvitation(r.store.conn(ctx).QueryRowContext(ctx, query, id))
 }
 
 // GetByToken retrieves an invitation by token.
 func (r *TopicInvitationRepository) GetByToken(ctx context.Context, token string) (*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE token = ?
 	`
 
-	return r.scanInvitation(r.store.db.QueryRowContext(ctx, query, token))
+	return r.scanInvitation(r.store.conn(ctx).QueryRowContext(ctx, query, token))
 }
 
 // ListByTopic lists invitations for a topic.
 func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID domain.TopicID, filter storage.InvitationFilter) ([]*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE topic_id = ?
 	`
@@ -98,6 +111,10 @@ func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID dom
 		query += " AND status = ?"
 		args = append(args, string(filter.Status))
 	}
+	if filter.Kind != "" {
+		query += " AND kind = ?"
+		args = append(args, string(filter.Kind))
+	}
 
 	query += " ORDER BY created_at DESC"
 
@@ -110,7 +127,7 @@ func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID dom
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.db.QueryContext(ctx, query, args...)
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -122,14 +139,15 @@ func (r *TopicInvitationRepository) ListByTopic(ctx context.Context, topicID dom
 // ListByUser lists invitations for a user (as invitee).
 func (r *TopicInvitationRepository) ListByUser(ctx context.Context, userID domain.UserID) ([]*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE invitee_user_id = ?
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.store.db.QueryContext(ctx, query, string(userID))
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query, string(userID))
 	if err != nil {
 		return nil, err
 	}
@@ -141,14 +159,15 @@ func (r *TopicInvitationRepository) ListByUser(ctx context.Context, userID domai
 // ListByEmail lists invitations for an email address.
 func (r *TopicInvitationRepository) ListByEmail(ctx context.Context, email string) ([]*storage.TopicInvitation, error) {
 	query := `
-		SELECT id, topic_id, inviter_id, invitee_email, invitee_user_id,
-		       role, token, message, status, expires_at, created_at, responded_at
+		SELECT id, kind, topic_id, inviter_id, invitee_email, invitee_user_id,
+		       role, token, message, status, expires_at, created_at, responded_at,
+		       responded_by
 		FROM topic_invitations
 		WHERE invitee_email = ?
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.store.db.QueryContext(ctx, query, email)
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query, email)
 	if err != nil {
 		return nil, err
 	}
@@ -161,19 +180,24 @@ func (r *TopicInvitationRepository) ListByEmail(ctx context.Context, email strin
 func (r *TopicInvitationRepository) Update(ctx context.Context, inv *storage.TopicInvitation) error {
 	query := `
 		UPDATE topic_invitations
-		SET status = ?, responded_at = ?
+		SET status = ?, responded_at = ?, responded_by = ?
 		WHERE id = ?
 	`
 
-	var respondedAt *string
+	var respondedAt, respondedBy *string
 	if inv.RespondedAt != nil {
 		s := inv.RespondedAt.Format(time.RFC3339)
 		respondedAt = &s
 	}
+	if inv.RespondedBy != "" {
+		s := string(inv.RespondedBy)
+		respondedBy = &s
+	}
 
-	_, err := r.store.db.ExecContext(ctx, query,
+	_, err := r.store.conn(ctx).ExecContext(ctx, query,
 		string(inv.Status),
 		respondedAt,
+		respondedBy,
 		inv.ID,
 	)
 
@@ -182,7 +206,7 @@ func (r *TopicInvitationRepository) Update(ctx context.Context, inv *storage.Top
 
 // Delete removes an invitation.
 func (r *TopicInvitationRepository) Delete(ctx context.Context, id string) error {
-	_, err := r.store.db.ExecContext(ctx,
+	_, err := r.store.conn(ctx).ExecContext(ctx,
 		"DELETE FROM topic_invitations WHERE id = ?",
 		id,
 	)
@@ -191,7 +215,7 @@ func (r *TopicInvitationRepository) Delete(ctx context.Context, id string) error
 
 // ExpirePending expires all pending invitations that have passed their expiration.
 func (r *TopicInvitationRepository) ExpirePending(ctx context.Context) (int64, error) {
-	result, err := r.store.db.ExecContext(ctx,
+	result, err := r.store.conn(ctx).ExecContext(ctx,
 		`UPDATE topic_invitations 
 		 SET status = 'expired' 
 		 WHERE status = 'pending' AND expires_at < ?`,
@@ -208,10 +232,11 @@ func (r *TopicInvitationRepository) scanInvitation(row *sql.Row) (*storage.Topic
 	var inv storage.TopicInvitation
 	var inviteeEmail, inviteeUserID, message sql.NullString
 	var expiresAt, createdAt string
-	var respondedAt sql.NullString
+	var respondedAt, respondedBy sql.NullString
 
 	err := row.Scan(
 		&inv.ID,
+		&inv.Kind,
 		&inv.TopicID,
 		&inv.InviterID,
 		&inviteeEmail,
@@ -223,6 +248,7 @@ func (r *TopicInvitationRepository) scanInvitation(row *sql.Row) (*storage.Topic
 		&expiresAt,
 		&createdAt,
 		&respondedAt,
+		&respondedBy,
 	)
 
 	if err == sql.ErrNoRows {
@@ -249,6 +275,9 @@ func (r *TopicInvitationRepository) scanInvitation(row *sql.Row) (*storage.Topic
 		t, _ := time.Parse(time.RFC3339, respondedAt.String)
 		inv.RespondedAt = &t
 	}
+	if respondedBy.Valid {
+		inv.RespondedBy = domain.UserID(respondedBy.String)
+	}
 
 	return &inv, nil
 }
@@ -261,10 +290,11 @@ func (r *TopicInvitationRepository) scanInvitations(rows *sql.Rows) ([]*storage.
 		var inv storage.TopicInvitation
 		var inviteeEmail, inviteeUserID, message sql.NullString
 		var expiresAt, createdAt string
-		var respondedAt sql.NullString
+		var respondedAt, respondedBy sql.NullString
 
 		err := rows.Scan(
 			&inv.ID,
+			&inv.Kind,
 			&inv.TopicID,
 			&inv.InviterID,
 			&inviteeEmail,
@@ -276,6 +306,7 @@ func (r *TopicInvitationRepository) scanInvitations(rows *sql.Rows) ([]*storage.
 			&expiresAt,
 			&createdAt,
 			&respondedAt,
+			&respondedBy,
 		)
 		if err != nil {
 			return nil, err
@@ -298,6 +329,9 @@ func (r *TopicInvitationRepository) scanInvitations(rows *sql.Rows) ([]*storage.
 			t, _ := time.Parse(time.RFC3339, respondedAt.String)
 			inv.RespondedAt = &t
 		}
+		if respondedBy.Valid {
+			inv.RespondedBy = domain.UserID(respondedBy.String)
+		}
 
 		invitations = append(invitations, &inv)
 	}
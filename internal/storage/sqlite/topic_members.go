@@ -30,12 +30,12 @@ func (r *TopicMemberRepository) Create(ctx context.Context, member *storage.Topi
 		acceptedAt = &s
 	}
 
-	_, err := r.store.db.ExecContext(ctx, query,
+	_, err := r.store.conn(ctx).ExecContext(ctx, query,
 		member.ID,
 		string(member.TopicID),
 		string(member.UserID),
 		string(member.Role),
-		string(member.InvitedBy),
+		nullString(string(member.InvitedBy)),
 		member.InvitedAt.Format(time.RFC3339),
 		acceptedAt,
 		member.CreatedAt.Format(time.RFC3339),
@@ -54,7 +54,7 @@ func (r *TopicMemberRepository) Get(ctx context.Context, topicID domain.TopicID,
 		WHERE topic_id = ? AND user_id = ?
 	`
 
-	return r.scanMember(r.store.db.QueryRowContext(ctx, query, string(topicID), string(userID)))
+	return r.scanMember(r.store.conn(ctx).QueryRowContext(ctx, query, string(topicID), string(userID)))
 }
 
 // GetByID retrieves a membership by ID.
@@ -66,7 +66,7 @@ func (r *TopicMemberRepository) GetByID(ctx context.Context, id string) (*storag
 		WHERE id = ?
 	`
 
-	return r.scanMember(r.store.db.QueryRowContext(ctx, query, id))
+	return r.scanMember(r.store.conn(ctx).QueryRowContext(ctx, query, id))
 }
 
 // ListByTopic lists all members of a topic.
@@ -93,7 +93,7 @@ func (r *TopicMemberRepository) ListByTopic(ctx context.Context, topicID domain.
 		args = append(args, filter.Offset)
 	}
 
-	rows, err := r.store.db.QueryContext(ctx, query, args...)
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +111,7 @@ func (r *TopicMemberRepository) ListByUser(ctx context.Context, userID domain.Us
 		WHERE user_id = ?
 	`
 
-	rows, err := r.store.db.QueryContext(ctx, query, string(userID))
+	rows, err := r.store.conn(ctx).QueryContext(ctx, query, string(userID))
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +134,7 @@ func (r *TopicMemberRepository) Update(ctx context.Context, member *storage.Topi
 		acceptedAt = &s
 	}
 
-	_, err := r.store.db.ExecContext(ctx, query,
+	_, err := r.store.conn(ctx).ExecContext(ctx, query,
 		string(member.Role),
 		acceptedAt,
 		time.Now().UTC().Format(time.RFC3339),
@@ -146,7 +146,7 @@ func (r *TopicMemberRepository) Update(ctx context.Context, member *storage.Topi
 
 // Delete removes a membership.
 func (r *TopicMemberRepository) Delete(ctx context.Context, topicID domain.TopicID, userID domain.UserID) error {
-	_, err := r.store.db.ExecContext(ctx,
+	_, err := r.store.conn(ctx).ExecContext(ctx,
 		"DELETE FROM topic_members WHERE topic_id = ? AND user_id = ?",
 		string(topicID), string(userID),
 	)
@@ -156,7 +156,7 @@ func (r *TopicMemberRepository) Delete(ctx context.Context, topicID domain.Topic
 // CountOwners counts the number of owners for a topic.
 func (r *TopicMemberRepository) CountOwners(ctx context.Context, topicID domain.TopicID) (int, error) {
 	var count int
-	err := r.store.db.QueryRowContext(ctx,
+	err := r.store.conn(ctx).QueryRowContext(ctx,
 		"SELECT COUNT(*) FROM topic_members WHERE topic_id = ? AND role = 'owner'",
 		string(topicID),
 	).Scan(&count)
@@ -166,7 +166,7 @@ func (r *TopicMemberRepository) CountOwners(ctx context.Context, topicID domain.
 // HasAccess checks if a user has access to a topic.
 func (r *TopicMemberRepository) HasAccess(ctx context.Context, topicID domain.TopicID, userID domain.UserID) (bool, error) {
 	var count int
-	err := r.store.db.QueryRowContext(ctx,
+	err := r.store.conn(ctx).QueryRowContext(ctx,
 		"SELECT COUNT(*) FROM topic_members WHERE topic_id = ? AND user_id = ?",
 		string(topicID), string(userID),
 	).Scan(&count)
@@ -176,7 +176,7 @@ func (r *TopicMemberRepository) HasAccess(ctx context.Context, topicID domain.To
 // GetRole gets the role of a user in a topic.
 func (r *TopicMemberRepository) GetRole(ctx context.Context, topicID domain.TopicID, userID domain.UserID) (storage.TopicMemberRole, error) {
 	var role string
-	err := r.store.db.QueryRowContext(ctx,
+	err := r.store.conn(ctx).QueryRowContext(ctx,
 		"SELECT role FROM topic_members WHERE topic_id = ? AND user_id = ?",
 		string(topicID), string(userID),
 	).Scan(&role)
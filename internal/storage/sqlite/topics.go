@@ -39,11 +39,21 @@ func (r *TopicRepository) Create(ctx context.Context, topic *domain.Topic) error
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
+	aclJSON, err := json.Marshal(topic.ACL)
+	if err != nil {
+		return fmt.Errorf("failed to marshal acl: %w", err)
+	}
+
+	dataSchemaJSON, err := json.Marshal(topic.DataSchema)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data schema: %w", err)
+	}
+
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 
 	_, err = r.store.execWithAudit(ctx, "INSERT", "topics", `
-		INSERT INTO topics (id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, cached_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO topics (id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema, cached_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		string(topic.ID),
 		nullString(string(topic.ParentID)),
@@ -58,6 +68,8 @@ func (r *TopicRepository) Create(ctx context.Context, topic *domain.Topic) error
 		topic.DatasetCount,
 		string(tagsJSON),
 		string(metadataJSON),
+		string(aclJSON),
+		string(dataSchemaJSON),
 		now,
 	)
 
@@ -68,13 +80,14 @@ func (r *TopicRepository) Create(ctx context.Context, topic *domain.Topic) error
 		return fmt.Errorf("failed to create topic: %w", err)
 	}
 
+	topic.Revision = 1
 	return nil
 }
 
 // Get retrieves a topic by ID.
 func (r *TopicRepository) Get(ctx context.Context, id domain.TopicID) (*domain.Topic, error) {
 	rows, err := r.store.queryWithAudit(ctx, "topics", `
-		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata
+		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema, revision
 		FROM topics WHERE id = ?
 	`, string(id))
 	if err != nil {
@@ -92,7 +105,7 @@ func (r *TopicRepository) Get(ctx context.Context, id domain.TopicID) (*domain.T
 // GetByName retrieves a topic by name.
 func (r *TopicRepository) GetByName(ctx context.Context, name string) (*domain.Topic, error) {
 	rows, err := r.store.queryWithAudit(ctx, "topics", `
-		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata
+		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema, revision
 		FROM topics WHERE name = ?
 	`, name)
 	if err != nil {
@@ -110,7 +123,7 @@ func (r *TopicRepository) GetByName(ctx context.Context, name string) (*domain.T
 // List retrieves topics matching the filter.
 func (r *TopicRepository) List(ctx context.Context, filter storage.TopicFilter) ([]*domain.Topic, error) {
 	query := `
-		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata
+		SELECT id, parent_id, name, description, table_schema, status, owners, created_by, created_at, updated_at, dataset_count, tags, metadata, acl, data_schema, revision
 		FROM topics WHERE 1=1
 	`
 	args := []any{}
@@ -185,7 +198,9 @@ func (r *TopicRepository) List(ctx context.Context, filter storage.TopicFilter)
 	return topics, rows.Err()
 }
 
-// Update updates an existing topic.
+// Update updates an existing topic. Unless topic.Revision is 0, the update
+// only applies if the stored topic still has that revision and fails with
+// storage.ErrConflict otherwise. topic.Revision is set to the new revision.
 func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error {
 	if err := topic.Validate(); err != nil {
 		return err
@@ -194,6 +209,8 @@ func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error
 	ownersJSON, _ := json.Marshal(topic.Owners)
 	tagsJSON, _ := json.Marshal(topic.Tags)
 	metadataJSON, _ := json.Marshal(topic.Metadata)
+	aclJSON, _ := json.Marshal(topic.ACL)
+	dataSchemaJSON, _ := json.Marshal(topic.DataSchema)
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 
 	result, err := r.store.execWithAudit(ctx, "UPDATE", "topics", `
@@ -208,8 +225,11 @@ func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error
 			dataset_count = ?,
 			tags = ?,
 			metadata = ?,
-			cached_at = ?
-		WHERE id = ?
+			acl = ?,
+			data_schema = ?,
+			cached_at = ?,
+			revision = revision + 1
+		WHERE id = ? AND (? = 0 OR revision = ?)
 	`,
 		nullString(string(topic.ParentID)),
 		topic.Name,
@@ -221,38 +241,37 @@ func (r *TopicRepository) Update(ctx context.Context, topic *domain.Topic) error
 		topic.DatasetCount,
 		string(tagsJSON),
 		string(metadataJSON),
+		string(aclJSON),
+		string(dataSchemaJSON),
 		now,
 		string(topic.ID),
+		topic.Revision,
+		topic.Revision,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update topic: %w", err)
 	}
 
 	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "topics", string(topic.ID), rowsAffected, &topic.Revision)
 }
 
-// Delete soft-deletes a topic.
-func (r *TopicRepository) Delete(ctx context.Context, id domain.TopicID) error {
+// Delete soft-deletes a topic. Unless revision is 0, the delete only
+// applies if the stored topic still has that revision and fails with
+// storage.ErrConflict otherwise.
+func (r *TopicRepository) Delete(ctx context.Context, id domain.TopicID, revision int64) error {
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 
 	result, err := r.store.execWithAudit(ctx, "UPDATE", "topics", `
-		UPDATE topics SET status = 'deleted', updated_at = ? WHERE id = ?
-	`, now, string(id))
+		UPDATE topics SET status = 'deleted', updated_at = ?, revision = revision + 1
+		WHERE id = ? AND (? = 0 OR revision = ?)
+	`, now, string(id), revision, revision)
 	if err != nil {
 		return fmt.Errorf("failed to delete topic: %w", err)
 	}
 
 	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return storage.ErrNotFound
-	}
-
-	return nil
+	return r.store.finishUpdate(ctx, "topics", string(id), rowsAffected, &revision)
 }
 
 // Count returns the number of topics matching the filter.
@@ -307,12 +326,15 @@ func scanTopic(rows *sql.Rows) (*domain.Topic, error) {
 		datasetCount int
 		tagsJSON     sql.NullString
 		metadataJSON sql.NullString
+		aclJSON      sql.NullString
+		schemaJSON   sql.NullString
+		revision     int64
 	)
 
 	err := rows.Scan(
 		&id, &parentID, &name, &description, &tableSchema, &status,
 		&ownersJSON, &createdBy, &createdAt, &updatedAt, &datasetCount,
-		&tagsJSON, &metadataJSON,
+		&tagsJSON, &metadataJSON, &aclJSON, &schemaJSON, &revision,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan topic: %w", err)
@@ -326,6 +348,7 @@ func scanTopic(rows *sql.Rows) (*domain.Topic, error) {
 		Status:       domain.TopicStatus(status),
 		CreatedBy:    domain.UserID(createdBy),
 		DatasetCount: datasetCount,
+		Revision:     revision,
 	}
 
 	if parentID.Valid {
@@ -362,6 +385,21 @@ func scanTopic(rows *sql.Rows) (*domain.Topic, error) {
 		}
 	}
 
+	// Unlike the descriptive fields, an unreadable ACL is an error: dropping
+	// it would leave the topic unrestricted
+	if aclJSON.Valid && aclJSON.String != "" {
+		if err := json.Unmarshal([]byte(aclJSON.String), &topic.ACL); err != nil {
+			return nil, fmt.Errorf("failed to parse ACL of topic %s: %w", id, err)
+		}
+	}
+
+	if schemaJSON.Valid && schemaJSON.String != "" {
+		var dataSchema *domain.DataSchema
+		if err := json.Unmarshal([]byte(schemaJSON.String), &dataSchema); err == nil {
+			topic.DataSchema = dataSchema
+		}
+	}
+
 	return topic, nil
 }
 
@@ -389,6 +427,33 @@ func isConstraintError(err error) bool {
 		strings.Contains(err.Error(), "FOREIGN KEY constraint")
 }
 
+// revisionOf returns the revision of the row with id in table
+func (s *Store) revisionOf(ctx context.Context, table, id string) (int64, error) {
+	var revision int64
+	err := s.conn(ctx).QueryRowContext(ctx, "SELECT revision FROM "+table+" WHERE id = ?", id).Scan(&revision)
+	return revision, wrapNotFound(err)
+}
+
+// finishUpdate completes a conditional update of the row with id in table
+// that was based on *revision. If the update affected no rows it returns
+// storage.ErrNotFound or, if the row exists, storage.ErrConflict;
+// otherwise it sets *revision to the new revision.
+func (s *Store) finishUpdate(ctx context.Context, table, id string, rowsAffected int64, revision *int64) error {
+	if rowsAffected > 0 && *revision > 0 {
+		*revision++
+		return nil
+	}
+	current, err := s.revisionOf(ctx, table, id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return storage.ErrConflict
+	}
+	*revision = current
+	return nil
+}
+
 // wrapNotFound wraps sql.ErrNoRows as storage.ErrNotFound
 func wrapNotFound(err error) error {
 	if errors.Is(err, sql.ErrNoRows) {
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// querier runs statements on the database or on the connection of a
+// transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// txKey is the context key of the transaction WithTx runs fn in.
+type txKey struct{}
+
+// txConn is a transaction of a store, held on a single connection.
+type txConn struct {
+	store *Store
+	conn  *sql.Conn
+}
+
+// conn returns the connection of the transaction in ctx, or the database
+// if ctx isn't in a transaction of this store.
+func (s *Store) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*txConn); ok && tx.store == s {
+		return tx.conn
+	}
+	return s.db
+}
+
+// WithTx runs fn in a transaction. The transaction is started with
+// BEGIN IMMEDIATE, taking the write lock up front, so it waits for other
+// writers instead of failing when it first writes.
+func (s *Store) WithTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if tx, ok := ctx.Value(txKey{}).(*txConn); ok && tx.store == s {
+		return fn(ctx)
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	// Pragmas are per connection
+	if _, err := conn.ExecContext(ctx, "PRAGMA busy_timeout=5000"); err != nil {
+		return fmt.Errorf("failed to set busy timeout: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		// The context may be canceled already; roll back regardless
+		if _, rbErr := conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK"); rbErr != nil && err != nil {
+			err = errors.Join(err, fmt.Errorf("failed to roll back transaction: %w", rbErr))
+		}
+		s.audit.resetLastHash(context.WithoutCancel(ctx))
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, &txConn{store: s, conn: conn})); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+func txTestTopic(id string) *domain.Topic {
+	return &domain.Topic{
+		ID:        domain.TopicID(id),
+		Name:      "Topic " + id,
+		Status:    domain.TopicStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+}
+
+func txTestDataset(id string, topic domain.TopicID) *domain.Dataset {
+	return &domain.Dataset{
+		ID:        domain.DatasetID(id),
+		TopicID:   topic,
+		Name:      "Dataset " + id,
+		Status:    domain.DatasetStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+}
+
+func TestStore_WithTx_Commit(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	err := store.WithTx(ctx, func(ctx context.Context) error {
+		if err := store.Topics().Create(ctx, txTestTopic("topic-1")); err != nil {
+			return err
+		}
+		// Reads in the transaction see its writes
+		if _, err := store.Topics().Get(ctx, "topic-1"); err != nil {
+			return err
+		}
+		return store.Datasets().Create(ctx, txTestDataset("dataset-1", "topic-1"))
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+
+	if _, err := store.Topics().Get(ctx, "topic-1"); err != nil {
+		t.Errorf("expected committed topic: %v", err)
+	}
+	if _, err := store.Datasets().Get(ctx, "dataset-1"); err != nil {
+		t.Errorf("expected committed dataset: %v", err)
+	}
+}
+
+func TestStore_WithTx_Rollback(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+	failure := errors.New("attach failed")
+
+	err := store.WithTx(ctx, func(ctx context.Context) error {
+		if err := store.Topics().Create(ctx, txTestTopic("topic-1")); err != nil {
+			return err
+		}
+		// A nested call joins the outer transaction
+		if err := store.WithTx(ctx, func(ctx context.Context) error {
+			return store.Datasets().Create(ctx, txTestDataset("dataset-1", "topic-1"))
+		}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+
+	if _, err := store.Topics().Get(ctx, "topic-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected the topic to be rolled back, got %v", err)
+	}
+	if _, err := store.Datasets().Get(ctx, "dataset-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected the dataset to be rolled back, got %v", err)
+	}
+
+	// The store keeps working after a rollback
+	if err := store.Topics().Create(ctx, txTestTopic("topic-2")); err != nil {
+		t.Errorf("failed to create topic after rollback: %v", err)
+	}
+}
+
+func TestStore_WithTx_Panic(t *testing.T) {
+	store := setupTestStore(t)
+	defer store.Close()
+
+	ctx := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		_ = store.WithTx(ctx, func(ctx context.Context) error {
+			if err := store.Topics().Create(ctx, txTestTopic("topic-1")); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	if _, err := store.Topics().Get(ctx, "topic-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected the topic to be rolled back, got %v", err)
+	}
+}
@@ -30,7 +30,7 @@ func (r *UserPreferencesRepository) Get(ctx context.Context, userID domain.UserI
 	var customJSON sql.NullString
 	var createdAt, updatedAt string
 
-	err := r.store.db.QueryRowContext(ctx, query, string(userID)).Scan(
+	err := r.store.conn(ctx).QueryRowContext(ctx, query, string(userID)).Scan(
 		&prefs.UserID,
 		&prefs.Theme,
 		&prefs.Locale,
@@ -98,7 +98,7 @@ func (r *UserPreferencesRepository) Upsert(ctx context.Context, prefs *storage.U
 	`
 
 	now := time.Now().UTC().Format(time.RFC3339)
-	_, err := r.store.db.ExecContext(ctx, query,
+	_, err := r.store.conn(ctx).ExecContext(ctx, query,
 		string(prefs.UserID),
 		prefs.Theme,
 		prefs.Locale,
@@ -116,7 +116,7 @@ func (r *UserPreferencesRepository) Upsert(ctx context.Context, prefs *storage.U
 
 // Delete removes preferences for a user.
 func (r *UserPreferencesRepository) Delete(ctx context.Context, userID domain.UserID) error {
-	_, err := r.store.db.ExecContext(ctx,
+	_, err := r.store.conn(ctx).ExecContext(ctx,
 		"DELETE FROM user_preferences WHERE user_id = ?",
 		string(userID),
 	)
@@ -81,12 +81,12 @@ func (r *UserRepository) Get(ctx context.Context, id domain.UserID) (*domain.Use
 	return scanUser(rows)
 }
 
-// GetByPublicKey retrieves a user by their public key.
+// GetByPublicKey retrieves a user by their primary key or an additional key.
 func (r *UserRepository) GetByPublicKey(ctx context.Context, publicKey []byte) (*domain.User, error) {
 	rows, err := r.store.queryWithAudit(ctx, "users", `
 		SELECT id, public_key, key_type, public_key_fingerprint, name, email, status, role, locale, created_at, updated_at, last_login_at, metadata
-		FROM users WHERE public_key = ? AND status != 'deleted'
-	`, publicKey)
+		FROM users WHERE (public_key = ? OR id IN (SELECT user_id FROM user_keys WHERE public_key = ?)) AND status != 'deleted'
+	`, publicKey, publicKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query user: %w", err)
 	}
@@ -271,7 +271,7 @@ func (r *UserRepository) Count(ctx context.Context, filter storage.UserFilter) (
 	}
 
 	var count int64
-	err := r.store.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := r.store.conn(ctx).QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count users: %w", err)
 	}
@@ -282,9 +282,10 @@ func (r *UserRepository) Count(ctx context.Context, filter storage.UserFilter) (
 // Exists checks if a user with the given public key exists.
 func (r *UserRepository) Exists(ctx context.Context, publicKey []byte) (bool, error) {
 	var count int
-	err := r.store.db.QueryRowContext(ctx, `
-		SELECT COUNT(*) FROM users WHERE public_key = ? AND status != 'deleted'
-	`, publicKey).Scan(&count)
+	err := r.store.conn(ctx).QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM users
+		WHERE (public_key = ? OR id IN (SELECT user_id FROM user_keys WHERE public_key = ?)) AND status != 'deleted'
+	`, publicKey, publicKey).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check user existence: %w", err)
 	}
@@ -294,7 +295,7 @@ func (r *UserRepository) Exists(ctx context.Context, publicKey []byte) (bool, er
 // IsFirstUser returns true if no users exist yet.
 func (r *UserRepository) IsFirstUser(ctx context.Context) (bool, error) {
 	var count int
-	err := r.store.db.QueryRowContext(ctx, `
+	err := r.store.conn(ctx).QueryRowContext(ctx, `
 		SELECT COUNT(*) FROM users WHERE status != 'deleted'
 	`).Scan(&count)
 	if err != nil {
@@ -303,6 +304,83 @@ func (r *UserRepository) IsFirstUser(ctx context.Context) (bool, error) {
 	return count == 0, nil
 }
 
+// AddPublicKey registers an additional key for a user.
+func (r *UserRepository) AddPublicKey(ctx context.Context, key *domain.UserPublicKey) error {
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	_, err := r.store.execWithAudit(ctx, "INSERT", "user_keys", `
+		INSERT INTO user_keys (fingerprint, user_id, public_key, key_type, label, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`,
+		key.Fingerprint,
+		string(key.UserID),
+		key.PublicKey,
+		string(key.KeyType),
+		key.Label,
+		key.CreatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint") {
+			return domain.ErrKeyExists
+		}
+		return fmt.Errorf("failed to add public key: %w", err)
+	}
+
+	return nil
+}
+
+// ListPublicKeys lists a user's additional keys, oldest first.
+func (r *UserRepository) ListPublicKeys(ctx context.Context, userID domain.UserID) ([]*domain.UserPublicKey, error) {
+	rows, err := r.store.queryWithAudit(ctx, "user_keys", `
+		SELECT fingerprint, user_id, public_key, key_type, label, created_at
+		FROM user_keys WHERE user_id = ? ORDER BY created_at, fingerprint
+	`, string(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list public keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*domain.UserPublicKey
+	for rows.Next() {
+		var (
+			key       domain.UserPublicKey
+			id        string
+			keyType   string
+			createdAt string
+		)
+		if err := rows.Scan(&key.Fingerprint, &id, &key.PublicKey, &keyType, &key.Label, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan public key: %w", err)
+		}
+		key.UserID = domain.UserID(id)
+		key.KeyType = domain.KeyType(keyType)
+		if t, err := time.Parse(time.RFC3339Nano, createdAt); err == nil {
+			key.CreatedAt = t
+		}
+		keys = append(keys, &key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RemovePublicKey removes an additional key by fingerprint.
+func (r *UserRepository) RemovePublicKey(ctx context.Context, userID domain.UserID, fingerprint string) error {
+	result, err := r.store.execWithAudit(ctx, "DELETE", "user_keys", `
+		DELETE FROM user_keys WHERE user_id = ? AND fingerprint = ?
+	`, string(userID), fingerprint)
+	if err != nil {
+		return fmt.Errorf("failed to remove public key: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return domain.ErrKeyNotFound
+	}
+
+	return nil
+}
+
 // scanUser scans a user row into a User struct.
 func scanUser(rows *sql.Rows) (*domain.User, error) {
 	var (
@@ -0,0 +1,574 @@
+package storagetest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+func newTopic(id string) *domain.Topic {
+	now := time.Now().UTC()
+	return &domain.Topic{
+		ID:          domain.TopicID(id),
+		Name:        "Topic " + id,
+		Description: "A conformance test topic",
+		Status:      domain.TopicStatusActive,
+		Owners:      []domain.UserID{"user-1"},
+		Tags:        []string{"conformance"},
+		CreatedBy:   "user-1",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func newDataset(id string, topic domain.TopicID) *domain.Dataset {
+	now := time.Now().UTC()
+	return &domain.Dataset{
+		ID:        domain.DatasetID(id),
+		TopicID:   topic,
+		Name:      "Dataset " + id,
+		Status:    domain.DatasetStatusActive,
+		Owners:    []domain.UserID{"user-1"},
+		CreatedBy: "user-1",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func newJob(id string, priority int, createdAt time.Time) *domain.Job {
+	return &domain.Job{
+		ID:        domain.JobID(id),
+		Type:      domain.JobTypeScrape,
+		Status:    domain.JobStatusPending,
+		TaskID:    "task-1",
+		Priority:  priority,
+		CreatedBy: "user-1",
+		CreatedAt: createdAt,
+	}
+}
+
+// createUser creates a user whose key is n repeated.
+func createUser(t *testing.T, store storage.Store, n byte, name string) *domain.User {
+	t.Helper()
+
+	user := domain.NewUser(bytes.Repeat([]byte{n}, 32), domain.KeyTypeEd25519, name, name+"@example.com", false)
+	if err := store.Users().Create(testContext(t), user); err != nil {
+		t.Fatalf("failed to create user %s: %v", name, err)
+	}
+	return user
+}
+
+func testTopics(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	repo := store.Topics()
+
+	topic := newTopic("topic-1")
+	if err := repo.Create(ctx, topic); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	if topic.Revision != 1 {
+		t.Errorf("expected revision 1 after create, got %d", topic.Revision)
+	}
+	if err := repo.Create(ctx, newTopic("topic-1")); !errors.Is(err, storage.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate ID, got %v", err)
+	}
+
+	got, err := repo.Get(ctx, topic.ID)
+	if err != nil {
+		t.Fatalf("failed to get topic: %v", err)
+	}
+	if got.Name != topic.Name || len(got.Tags) != 1 || len(got.Owners) != 1 {
+		t.Errorf("expected topic to round-trip, got %+v", got)
+	}
+	if _, err := repo.GetByName(ctx, topic.Name); err != nil {
+		t.Errorf("failed to get topic by name: %v", err)
+	}
+	if _, err := repo.Get(ctx, "missing"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for a missing topic, got %v", err)
+	}
+	if _, err := repo.GetByName(ctx, "missing"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for a missing name, got %v", err)
+	}
+
+	got.Description = "updated"
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update topic: %v", err)
+	}
+	if got, _ := repo.Get(ctx, topic.ID); got.Description != "updated" {
+		t.Errorf("expected updated description, got %q", got.Description)
+	}
+
+	for _, id := range []string{"topic-2", "topic-3"} {
+		if err := repo.Create(ctx, newTopic(id)); err != nil {
+			t.Fatalf("failed to create topic: %v", err)
+		}
+	}
+
+	// Topics are ordered by name by default
+	topics, err := repo.List(ctx, storage.TopicFilter{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("failed to list topics: %v", err)
+	}
+	if len(topics) != 2 || topics[0].ID != "topic-2" || topics[1].ID != "topic-3" {
+		t.Errorf("expected the second page to hold topic-2 and topic-3, got %v", topicIDs(topics))
+	}
+
+	// Deletes are conditional on the revision, and soft
+	if err := repo.Delete(ctx, topic.ID, topic.Revision); !storage.IsConflict(err) {
+		t.Errorf("expected a conflict for a stale delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, topic.ID, got.Revision); err != nil {
+		t.Fatalf("failed to delete topic: %v", err)
+	}
+	if got, err := repo.Get(ctx, topic.ID); err != nil || got.Status != domain.TopicStatusDeleted {
+		t.Errorf("expected a soft-deleted topic, got %+v (%v)", got, err)
+	}
+	if err := repo.Delete(ctx, "missing", 0); !storage.IsNotFound(err) {
+		t.Errorf("expected not found deleting a missing topic, got %v", err)
+	}
+
+	count, err := repo.Count(ctx, storage.TopicFilter{Status: domain.TopicStatusActive})
+	if err != nil {
+		t.Fatalf("failed to count topics: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 active topics, got %d", count)
+	}
+}
+
+func testTopicRevision(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	repo := store.Topics()
+	if err := repo.Create(ctx, newTopic("topic-1")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+
+	first, _ := repo.Get(ctx, "topic-1")
+	second, _ := repo.Get(ctx, "topic-1")
+
+	first.Description = "first"
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("failed to update topic: %v", err)
+	}
+	if first.Revision != 2 {
+		t.Errorf("expected the update to bump the revision to 2, got %d", first.Revision)
+	}
+
+	second.Description = "second"
+	if err := repo.Update(ctx, second); !storage.IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if got, _ := repo.Get(ctx, "topic-1"); got.Description != "first" || got.Revision != 2 {
+		t.Errorf("expected the first update to stand, got %q at revision %d", got.Description, got.Revision)
+	}
+
+	// Revision 0 skips the check
+	second.Revision = 0
+	if err := repo.Update(ctx, second); err != nil {
+		t.Fatalf("failed to update topic without revision: %v", err)
+	}
+	if second.Revision != 3 {
+		t.Errorf("expected revision 3, got %d", second.Revision)
+	}
+
+	missing := newTopic("topic-2")
+	missing.Revision = 1
+	if err := repo.Update(ctx, missing); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for a missing topic, got %v", err)
+	}
+}
+
+func testDatasets(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	repo := store.Datasets()
+
+	dataset := newDataset("dataset-1", "topic-1")
+	if err := repo.Create(ctx, dataset); err != nil {
+		t.Fatalf("failed to create dataset: %v", err)
+	}
+	if err := repo.Create(ctx, newDataset("dataset-1", "topic-1")); !errors.Is(err, storage.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate ID, got %v", err)
+	}
+	if err := repo.Create(ctx, newDataset("dataset-2", "topic-1")); err != nil {
+		t.Fatalf("failed to create dataset: %v", err)
+	}
+
+	got, err := repo.Get(ctx, dataset.ID)
+	if err != nil {
+		t.Fatalf("failed to get dataset: %v", err)
+	}
+	if got.Name != dataset.Name || got.TopicID != "topic-1" {
+		t.Errorf("expected dataset to round-trip, got %+v", got)
+	}
+	if _, err := repo.Get(ctx, "missing"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for a missing dataset, got %v", err)
+	}
+
+	got.Description = "updated"
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update dataset: %v", err)
+	}
+	stale := newDataset("dataset-1", "topic-1")
+	stale.Revision = 1
+	if err := repo.Update(ctx, stale); !storage.IsConflict(err) {
+		t.Errorf("expected a conflict for a stale update, got %v", err)
+	}
+
+	topicID := domain.TopicID("topic-1")
+	datasets, err := repo.List(ctx, storage.DatasetFilter{TopicID: &topicID})
+	if err != nil {
+		t.Fatalf("failed to list datasets: %v", err)
+	}
+	if len(datasets) != 2 {
+		t.Errorf("expected 2 datasets in the topic, got %d", len(datasets))
+	}
+
+	if err := repo.Delete(ctx, dataset.ID, dataset.Revision); !storage.IsConflict(err) {
+		t.Errorf("expected a conflict for a stale delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, dataset.ID, got.Revision); err != nil {
+		t.Fatalf("failed to delete dataset: %v", err)
+	}
+	if got, err := repo.Get(ctx, dataset.ID); err != nil || got.Status != domain.DatasetStatusDeleted {
+		t.Errorf("expected a soft-deleted dataset, got %+v (%v)", got, err)
+	}
+	if err := repo.Delete(ctx, "dataset-2", 0); err != nil {
+		t.Errorf("failed to delete dataset without a revision: %v", err)
+	}
+	if err := repo.Delete(ctx, "missing", 1); !storage.IsNotFound(err) {
+		t.Errorf("expected not found deleting a missing dataset, got %v", err)
+	}
+}
+
+func testDatasetVersions(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	repo := store.Datasets()
+	if err := repo.Create(ctx, newDataset("dataset-1", "topic-1")); err != nil {
+		t.Fatalf("failed to create dataset: %v", err)
+	}
+
+	// Three versions chained v1 <- v2 <- v3, each with two chunks
+	var previous domain.DatasetVersionID
+	for n := 1; n <= 3; n++ {
+		id := domain.DatasetVersionID(fmt.Sprintf("v%d", n))
+		if err := repo.CreateVersion(ctx, &domain.DatasetVersion{
+			ID:                id,
+			DatasetID:         "dataset-1",
+			Version:           domain.VersionString(n),
+			PreviousVersionID: previous,
+			Content:           &domain.DatasetContent{Hash: "hash", Size: 2, ChunkCount: 2},
+			CreatedBy:         "user-1",
+			CreatedAt:         time.Now().UTC().Add(time.Duration(n) * time.Second),
+		}); err != nil {
+			t.Fatalf("failed to create version %d: %v", n, err)
+		}
+		// Chunks are created out of order
+		for _, index := range []int{1, 0} {
+			if err := repo.CreateChunk(ctx, &domain.Chunk{
+				ID:        domain.ChunkID(fmt.Sprintf("chunk-%s-%d", id, index)),
+				DatasetID: "dataset-1",
+				VersionID: id,
+				Index:     index,
+				Hash:      "hash",
+				Size:      1,
+				Status:    domain.ChunkStatusPending,
+			}); err != nil {
+				t.Fatalf("failed to create chunk %d of version %d: %v", index, n, err)
+			}
+		}
+		previous = id
+	}
+
+	latest, err := repo.GetLatestVersion(ctx, "dataset-1")
+	if err != nil {
+		t.Fatalf("failed to get latest version: %v", err)
+	}
+	if latest.ID != "v3" {
+		t.Errorf("expected v3 to be the latest version, got %s", latest.ID)
+	}
+	versions, err := repo.ListVersions(ctx, "dataset-1")
+	if err != nil {
+		t.Fatalf("failed to list versions: %v", err)
+	}
+	if len(versions) != 3 || versions[0].ID != "v3" || versions[2].ID != "v1" {
+		t.Errorf("expected versions newest first, got %d", len(versions))
+	}
+
+	chunks, err := repo.ListChunks(ctx, "v1")
+	if err != nil {
+		t.Fatalf("failed to list chunks: %v", err)
+	}
+	if len(chunks) != 2 || chunks[0].Index != 0 || chunks[1].Index != 1 {
+		t.Errorf("expected chunks ordered by index, got %d", len(chunks))
+	}
+	if err := repo.UpdateChunkStatus(ctx, "chunk-v1-0", domain.ChunkStatusVerified); err != nil {
+		t.Fatalf("failed to update chunk status: %v", err)
+	}
+	if chunk, _ := repo.GetChunk(ctx, "v1", 0); chunk == nil || chunk.Status != domain.ChunkStatusVerified {
+		t.Errorf("expected a verified chunk, got %+v", chunk)
+	}
+
+	// Deleting the middle version relinks the chain and drops its chunks
+	if err := repo.DeleteVersion(ctx, "dataset-1", "v2"); err != nil {
+		t.Fatalf("failed to delete version: %v", err)
+	}
+	if _, err := repo.GetVersion(ctx, "dataset-1", "v2"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for the deleted version, got %v", err)
+	}
+	if v3, _ := repo.GetVersion(ctx, "dataset-1", "v3"); v3 == nil || v3.PreviousVersionID != "v1" {
+		t.Errorf("expected v3 to be relinked to v1, got %+v", v3)
+	}
+	if chunks, _ := repo.ListChunks(ctx, "v2"); len(chunks) != 0 {
+		t.Errorf("expected the chunks of v2 to be deleted, got %d", len(chunks))
+	}
+	if err := repo.DeleteVersion(ctx, "dataset-1", "v2"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found deleting a missing version, got %v", err)
+	}
+}
+
+func testJobs(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	repo := store.Jobs()
+	now := time.Now().UTC()
+
+	for _, job := range []*domain.Job{
+		newJob("job-1", 1, now),
+		newJob("job-2", 5, now.Add(time.Second)),
+		newJob("job-3", 5, now.Add(2*time.Second)),
+	} {
+		if err := repo.Create(ctx, job); err != nil {
+			t.Fatalf("failed to create job: %v", err)
+		}
+	}
+	if err := repo.Create(ctx, newJob("job-1", 1, now)); !errors.Is(err, storage.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate ID, got %v", err)
+	}
+	if _, err := repo.Get(ctx, "missing"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found for a missing job, got %v", err)
+	}
+
+	// Pending jobs come by priority, then oldest first
+	pending, err := repo.GetPending(ctx, 2)
+	if err != nil {
+		t.Fatalf("failed to get pending jobs: %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != "job-2" || pending[1].ID != "job-3" {
+		t.Errorf("expected job-2 and job-3, got %d jobs", len(pending))
+	}
+
+	if err := repo.UpdateStatus(ctx, "job-2", domain.JobStatusRunning); err != nil {
+		t.Fatalf("failed to update job status: %v", err)
+	}
+	if err := repo.UpdateStatus(ctx, "job-3", domain.JobStatusCompleted); err != nil {
+		t.Fatalf("failed to update job status: %v", err)
+	}
+	if job, _ := repo.Get(ctx, "job-2"); job == nil || job.Status != domain.JobStatusRunning || job.StartedAt == nil {
+		t.Errorf("expected a started job, got %+v", job)
+	}
+	if job, _ := repo.Get(ctx, "job-3"); job == nil || job.CompletedAt == nil {
+		t.Errorf("expected a completed job, got %+v", job)
+	}
+	if err := repo.UpdateStatus(ctx, "missing", domain.JobStatusRunning); !storage.IsNotFound(err) {
+		t.Errorf("expected not found updating a missing job, got %v", err)
+	}
+
+	if count, _ := repo.Count(ctx, storage.JobFilter{Status: domain.JobStatusPending}); count != 1 {
+		t.Errorf("expected 1 pending job, got %d", count)
+	}
+
+	// Deletes are hard
+	if err := repo.Delete(ctx, "job-1"); err != nil {
+		t.Fatalf("failed to delete job: %v", err)
+	}
+	if _, err := repo.Get(ctx, "job-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+}
+
+func testNodes(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	repo := store.Nodes()
+	now := time.Now().UTC()
+
+	for i, peer := range []string{"peer-1", "peer-2"} {
+		if err := repo.Upsert(ctx, &storage.NodeInfo{
+			PeerID:         peer,
+			Addresses:      []string{"/ip4/127.0.0.1/tcp/4001"},
+			Mode:           "proxy",
+			StorageType:    "sqlite",
+			TrustedStorage: i == 1,
+			LastSeen:       now.Add(time.Duration(i) * time.Second),
+		}); err != nil {
+			t.Fatalf("failed to upsert node: %v", err)
+		}
+	}
+
+	// Upserting an existing node updates it
+	if err := repo.Upsert(ctx, &storage.NodeInfo{PeerID: "peer-1", Mode: "full", StorageType: "postgres", LastSeen: now}); err != nil {
+		t.Fatalf("failed to upsert node: %v", err)
+	}
+	got, err := repo.Get(ctx, "peer-1")
+	if err != nil {
+		t.Fatalf("failed to get node: %v", err)
+	}
+	if got.Mode != "full" {
+		t.Errorf("expected the upsert to update the mode, got %s", got.Mode)
+	}
+
+	// Nodes are ordered by last seen, most recent first
+	nodes, err := repo.List(ctx, storage.NodeFilter{})
+	if err != nil {
+		t.Fatalf("failed to list nodes: %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].PeerID != "peer-2" {
+		t.Errorf("expected peer-2 first, got %d nodes", len(nodes))
+	}
+	if count, _ := repo.Count(ctx, storage.NodeFilter{TrustedOnly: true}); count != 1 {
+		t.Errorf("expected 1 trusted node, got %d", count)
+	}
+
+	if err := repo.Delete(ctx, "peer-1"); err != nil {
+		t.Fatalf("failed to delete node: %v", err)
+	}
+	if _, err := repo.Get(ctx, "peer-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, "peer-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected not found deleting a missing node, got %v", err)
+	}
+}
+
+func testUsers(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	repo := store.Users()
+
+	if first, err := repo.IsFirstUser(ctx); err != nil || !first {
+		t.Errorf("expected an empty store to have no users, got %v (%v)", first, err)
+	}
+	alice := createUser(t, store, 1, "alice")
+	bob := createUser(t, store, 2, "bob")
+	if err := repo.Create(ctx, alice); !errors.Is(err, domain.ErrUserExists) {
+		t.Errorf("expected ErrUserExists for a duplicate user, got %v", err)
+	}
+
+	got, err := repo.GetByPublicKey(ctx, alice.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to get user by public key: %v", err)
+	}
+	if got.ID != alice.ID || got.Name != "alice" {
+		t.Errorf("expected alice, got %+v", got)
+	}
+	if _, err := repo.GetByEmail(ctx, "bob@example.com"); err != nil {
+		t.Errorf("failed to get user by email: %v", err)
+	}
+	if _, err := repo.Get(ctx, "missing"); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound for a missing user, got %v", err)
+	}
+
+	got.Name = "alice liddell"
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("failed to update user: %v", err)
+	}
+	if got, _ := repo.Get(ctx, alice.ID); got == nil || got.Name != "alice liddell" {
+		t.Errorf("expected the updated name, got %+v", got)
+	}
+
+	// Additional keys authenticate as their user
+	laptop := domain.NewUserPublicKey(alice.ID, bytes.Repeat([]byte{3}, 32), domain.KeyTypeEd25519, "laptop")
+	if err := repo.AddPublicKey(ctx, laptop); err != nil {
+		t.Fatalf("failed to add public key: %v", err)
+	}
+	if err := repo.AddPublicKey(ctx, domain.NewUserPublicKey(bob.ID, laptop.PublicKey, domain.KeyTypeEd25519, "desktop")); !errors.Is(err, domain.ErrKeyExists) {
+		t.Errorf("expected ErrKeyExists for a registered key, got %v", err)
+	}
+	if got, err := repo.GetByPublicKey(ctx, laptop.PublicKey); err != nil || got.ID != alice.ID {
+		t.Errorf("expected the additional key to authenticate alice, got %+v (%v)", got, err)
+	}
+	if err := repo.RemovePublicKey(ctx, bob.ID, laptop.Fingerprint); !errors.Is(err, domain.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound removing another user's key, got %v", err)
+	}
+
+	// Deletes are soft, and deleted users are left out
+	if err := repo.Delete(ctx, bob.ID); err != nil {
+		t.Fatalf("failed to delete user: %v", err)
+	}
+	users, err := repo.List(ctx, storage.UserFilter{})
+	if err != nil {
+		t.Fatalf("failed to list users: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != alice.ID {
+		t.Errorf("expected only alice, got %d users", len(users))
+	}
+	if count, _ := repo.Count(ctx, storage.UserFilter{}); count != 1 {
+		t.Errorf("expected 1 user, got %d", count)
+	}
+}
+
+func testSessions(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	alice := createUser(t, store, 1, "alice")
+	repo := store.Sessions()
+	now := time.Now().UTC()
+
+	for i, id := range []string{"session-1", "session-2"} {
+		if err := repo.Create(ctx, &storage.Session{
+			ID:                   id,
+			UserID:               alice.ID,
+			Type:                 storage.SessionTypeGRPC,
+			ClientIP:             "127.0.0.1",
+			PublicKeyFingerprint: alice.PublicKeyFingerprint,
+			NodeID:               "node-1",
+			StartedAt:            now.Add(time.Duration(i) * time.Second),
+			LastActivityAt:       now,
+		}); err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+	}
+	if _, err := repo.Get(ctx, "missing"); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound for a missing session, got %v", err)
+	}
+
+	if err := repo.End(ctx, "session-1"); err != nil {
+		t.Fatalf("failed to end session: %v", err)
+	}
+	if got, _ := repo.Get(ctx, "session-1"); got == nil || got.EndedAt == nil {
+		t.Errorf("expected an ended session, got %+v", got)
+	}
+	if err := repo.End(ctx, "session-1"); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound ending an ended session, got %v", err)
+	}
+
+	active, err := repo.GetByUser(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("failed to get sessions: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != "session-2" {
+		t.Errorf("expected only session-2 to be active, got %d", len(active))
+	}
+
+	if err := repo.EndAllForUser(ctx, alice.ID); err != nil {
+		t.Fatalf("failed to end sessions: %v", err)
+	}
+	if active, _ := repo.GetByUser(ctx, alice.ID); len(active) != 0 {
+		t.Errorf("expected no active sessions, got %d", len(active))
+	}
+}
+
+func topicIDs(topics []*domain.Topic) []domain.TopicID {
+	ids := make([]domain.TopicID, len(topics))
+	for i, t := range topics {
+		ids[i] = t.ID
+	}
+	return ids
+}
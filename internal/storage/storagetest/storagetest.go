@@ -0,0 +1,276 @@
+// Package storagetest provides a conformance test suite for implementations
+// of storage.Store.
+//
+// Every backend runs the same suite, so that the services built on the
+// storage layer can rely on the same behavior whichever backend a node
+// uses: the same errors for missing and duplicate records, the same
+// revision checks, soft deletes and orderings, and transactions that
+// commit and roll back alike. A new backend is done when it passes:
+//
+//	func TestConformance(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.Store {
+//			store := mybackend.New(...)
+//			t.Cleanup(func() { store.Close() })
+//			return store
+//		})
+//	}
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"bib/internal/storage"
+)
+
+// OpenFunc returns a new, empty and migrated store for a test. It is called
+// once per subtest and should close the store in t.Cleanup.
+type OpenFunc func(t *testing.T) storage.Store
+
+// Run runs the conformance suite against the stores returned by open.
+func Run(t *testing.T, open OpenFunc) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, store storage.Store)
+	}{
+		{"Store", testStore},
+		{"Topics", testTopics},
+		{"TopicRevision", testTopicRevision},
+		{"Datasets", testDatasets},
+		{"DatasetVersions", testDatasetVersions},
+		{"Jobs", testJobs},
+		{"Nodes", testNodes},
+		{"Users", testUsers},
+		{"Sessions", testSessions},
+		{"TxCommit", testTxCommit},
+		{"TxRollback", testTxRollback},
+		{"TxPanic", testTxPanic},
+		{"TxIsolation", testTxIsolation},
+		{"ConcurrentCreates", testConcurrentCreates},
+		{"ConcurrentUpdates", testConcurrentUpdates},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, open(t))
+		})
+	}
+}
+
+// testContext returns the context the suite calls the store with.
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+	return storage.WithOperationContext(ctx,
+		storage.NewOperationContext(storage.RoleAdmin, "storagetest"),
+	)
+}
+
+func testStore(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+
+	if err := store.Ping(ctx); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if store.Backend() == "" {
+		t.Error("expected a backend type")
+	}
+
+	// A store may serve a full replica exactly when it is authoritative
+	err := storage.ValidateModeBackend("full", store.Backend())
+	if store.IsAuthoritative() && err != nil {
+		t.Errorf("authoritative %s store rejected for full mode: %v", store.Backend(), err)
+	}
+	if !store.IsAuthoritative() && err == nil {
+		t.Errorf("non-authoritative %s store accepted for full mode", store.Backend())
+	}
+
+	if _, err := store.Stats(ctx); err != nil {
+		t.Errorf("stats failed: %v", err)
+	}
+}
+
+func testTxCommit(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+
+	err := store.WithTx(ctx, func(ctx context.Context) error {
+		if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+			return err
+		}
+		// Reads in the transaction see its writes
+		if _, err := store.Topics().Get(ctx, "topic-1"); err != nil {
+			return err
+		}
+		// A nested call joins the outer transaction
+		return store.WithTx(ctx, func(ctx context.Context) error {
+			return store.Datasets().Create(ctx, newDataset("dataset-1", "topic-1"))
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+
+	if _, err := store.Topics().Get(ctx, "topic-1"); err != nil {
+		t.Errorf("expected committed topic: %v", err)
+	}
+	if _, err := store.Datasets().Get(ctx, "dataset-1"); err != nil {
+		t.Errorf("expected committed dataset: %v", err)
+	}
+}
+
+func testTxRollback(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	failure := errors.New("attach failed")
+
+	err := store.WithTx(ctx, func(ctx context.Context) error {
+		if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+			return err
+		}
+		if err := store.WithTx(ctx, func(ctx context.Context) error {
+			return store.Datasets().Create(ctx, newDataset("dataset-1", "topic-1"))
+		}); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+
+	if _, err := store.Topics().Get(ctx, "topic-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected the topic to be rolled back, got %v", err)
+	}
+	if _, err := store.Datasets().Get(ctx, "dataset-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected the dataset to be rolled back, got %v", err)
+	}
+
+	// The store keeps working after a rollback
+	if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+		t.Errorf("failed to create topic after rollback: %v", err)
+	}
+}
+
+func testTxPanic(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		_ = store.WithTx(ctx, func(ctx context.Context) error {
+			if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	if _, err := store.Topics().Get(ctx, "topic-1"); !storage.IsNotFound(err) {
+		t.Errorf("expected the topic to be rolled back, got %v", err)
+	}
+	if err := store.Topics().Create(ctx, newTopic("topic-2")); err != nil {
+		t.Errorf("failed to create topic after a panic: %v", err)
+	}
+}
+
+func testTxIsolation(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	outside := storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "storagetest"),
+	)
+
+	err := store.WithTx(ctx, func(ctx context.Context) error {
+		if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+			return err
+		}
+		// Calls with another context are not part of the transaction
+		if _, err := store.Topics().Get(outside, "topic-1"); !storage.IsNotFound(err) {
+			t.Errorf("expected the uncommitted topic to be invisible outside the transaction, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx failed: %v", err)
+	}
+
+	if _, err := store.Topics().Get(outside, "topic-1"); err != nil {
+		t.Errorf("expected the committed topic to be visible: %v", err)
+	}
+}
+
+func testConcurrentCreates(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	const n = 16
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- store.Topics().Create(ctx, newTopic(fmt.Sprintf("topic-%d", i)))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("concurrent create failed: %v", err)
+		}
+	}
+	if count, err := store.Topics().Count(ctx, storage.TopicFilter{}); err != nil || count != n {
+		t.Errorf("expected %d topics, got %d (%v)", n, count, err)
+	}
+}
+
+func testConcurrentUpdates(t *testing.T, store storage.Store) {
+	ctx := testContext(t)
+	if err := store.Topics().Create(ctx, newTopic("topic-1")); err != nil {
+		t.Fatalf("failed to create topic: %v", err)
+	}
+	const n = 8
+
+	// Every writer read revision 1: exactly one of them may win
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := range n {
+		topic, err := store.Topics().Get(ctx, "topic-1")
+		if err != nil {
+			t.Fatalf("failed to get topic: %v", err)
+		}
+		topic.Description = fmt.Sprintf("writer %d", i)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- store.Topics().Update(ctx, topic)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var won int
+	for err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case !storage.IsConflict(err):
+			t.Errorf("expected a conflict for a stale update, got %v", err)
+		}
+	}
+	if won != 1 {
+		t.Errorf("expected exactly one update to win, got %d", won)
+	}
+	if got, _ := store.Topics().Get(ctx, "topic-1"); got == nil || got.Revision != 2 {
+		t.Errorf("expected revision 2 after one update, got %+v", got)
+	}
+}
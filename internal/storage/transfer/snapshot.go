@@ -0,0 +1,273 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+)
+
+// auditPageSize is how many audit entries are read per query.
+const auditPageSize = 1000
+
+// snapshot holds everything a transfer copies, in the order it is written.
+type snapshot struct {
+	users       []*domain.User
+	keys        []*domain.UserPublicKey
+	preferences []*storage.UserPreferences
+	topics      []*domain.Topic // parents before children
+	members     []*storage.TopicMember
+	datasets    []*domain.Dataset
+	versions    []*domain.DatasetVersion // oldest first per dataset
+	chunks      []*domain.Chunk
+	audit       []*storage.AuditEntry // oldest first
+}
+
+// counts returns the number of records of each entity.
+func (s *snapshot) counts() []Count {
+	return []Count{
+		{Entity: EntityUsers, Records: len(s.users)},
+		{Entity: EntityUserKeys, Records: len(s.keys)},
+		{Entity: EntityPreferences, Records: len(s.preferences)},
+		{Entity: EntityTopics, Records: len(s.topics)},
+		{Entity: EntityTopicMembers, Records: len(s.members)},
+		{Entity: EntityDatasets, Records: len(s.datasets)},
+		{Entity: EntityVersions, Records: len(s.versions)},
+		{Entity: EntityChunks, Records: len(s.chunks)},
+		{Entity: EntityAudit, Records: len(s.audit)},
+	}
+}
+
+// blobHashes returns the distinct blob hashes the chunks reference.
+func (s *snapshot) blobHashes() []string {
+	seen := make(map[string]bool, len(s.chunks))
+	var hashes []string
+	for _, chunk := range s.chunks {
+		if chunk.Hash != "" && !seen[chunk.Hash] {
+			seen[chunk.Hash] = true
+			hashes = append(hashes, chunk.Hash)
+		}
+	}
+	return hashes
+}
+
+// read reads a snapshot of store. Only audit entries logged before cutoff
+// are read, so that the entries a store writes while it is copied to or
+// read from are left out.
+func read(ctx context.Context, store storage.Store, cutoff time.Time) (*snapshot, error) {
+	s := &snapshot{}
+
+	// Read the audit log first: reads on the other repositories are
+	// audited too, but only after the cutoff
+	var err error
+	if s.audit, err = readAudit(ctx, store, cutoff); err != nil {
+		return nil, err
+	}
+
+	// Deleted users are not listed, so their keys and memberships are
+	// left out as well
+	if s.users, err = store.Users().List(ctx, storage.UserFilter{OrderBy: "created_at"}); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	users := make(map[domain.UserID]bool, len(s.users))
+	for _, user := range s.users {
+		users[user.ID] = true
+
+		keys, err := store.Users().ListPublicKeys(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list keys of user %s: %w", user.ID, err)
+		}
+		s.keys = append(s.keys, keys...)
+
+		prefs, err := store.UserPreferences().Get(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get preferences of user %s: %w", user.ID, err)
+		}
+		s.preferences = append(s.preferences, prefs)
+	}
+
+	topics, err := store.Topics().List(ctx, storage.TopicFilter{OrderBy: "created_at"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list topics: %w", err)
+	}
+	s.topics = parentsFirst(topics)
+	for _, topic := range s.topics {
+		members, err := store.TopicMembers().ListByTopic(ctx, topic.ID, storage.TopicMemberFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members of topic %s: %w", topic.ID, err)
+		}
+		for _, member := range members {
+			if !users[member.UserID] {
+				continue
+			}
+			if !users[member.InvitedBy] {
+				member.InvitedBy = ""
+			}
+			s.members = append(s.members, member)
+		}
+	}
+
+	if s.datasets, err = store.Datasets().List(ctx, storage.DatasetFilter{OrderBy: "created_at"}); err != nil {
+		return nil, fmt.Errorf("failed to list datasets: %w", err)
+	}
+	for _, dataset := range s.datasets {
+		versions, err := store.Datasets().ListVersions(ctx, dataset.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of dataset %s: %w", dataset.ID, err)
+		}
+		// Versions are listed newest first, but link to their predecessor
+		slices.Reverse(versions)
+		s.versions = append(s.versions, versions...)
+
+		for _, version := range versions {
+			chunks, err := store.Datasets().ListChunks(ctx, version.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list chunks of version %s: %w", version.ID, err)
+			}
+			s.chunks = append(s.chunks, chunks...)
+		}
+	}
+
+	return s, nil
+}
+
+// readAudit reads the audit entries logged before cutoff, oldest first.
+func readAudit(ctx context.Context, store storage.Store, cutoff time.Time) ([]*storage.AuditEntry, error) {
+	var entries []*storage.AuditEntry
+	for {
+		page, err := store.Audit().Query(ctx, storage.AuditFilter{
+			Before: &cutoff,
+			Limit:  auditPageSize,
+			Offset: len(entries),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query audit log: %w", err)
+		}
+		entries = append(entries, page...)
+		if len(page) < auditPageSize {
+			break
+		}
+	}
+
+	// Entries are returned newest first
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// parentsFirst orders topics so that every topic follows its parent.
+// Topics whose parent is missing are kept, after all the others.
+func parentsFirst(topics []*domain.Topic) []*domain.Topic {
+	known := make(map[domain.TopicID]bool, len(topics))
+	for _, topic := range topics {
+		known[topic.ID] = true
+	}
+
+	ordered := make([]*domain.Topic, 0, len(topics))
+	placed := make(map[domain.TopicID]bool, len(topics))
+	for len(ordered) < len(topics) {
+		progress := false
+		for _, topic := range topics {
+			if placed[topic.ID] {
+				continue
+			}
+			if topic.ParentID == "" || placed[topic.ParentID] || !known[topic.ParentID] {
+				ordered = append(ordered, topic)
+				placed[topic.ID] = true
+				progress = true
+			}
+		}
+		if !progress {
+			// A cycle: append the rest as they are
+			for _, topic := range topics {
+				if !placed[topic.ID] {
+					ordered = append(ordered, topic)
+					placed[topic.ID] = true
+				}
+			}
+		}
+	}
+	return ordered
+}
+
+// write writes s to store, reporting progress as it goes.
+func write(ctx context.Context, store storage.Store, s *snapshot, progress func(entity string, done, total int)) error {
+	for i, user := range s.users {
+		if err := store.Users().Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to copy user %s: %w", user.ID, err)
+		}
+		progress(EntityUsers, i+1, len(s.users))
+	}
+	for i, key := range s.keys {
+		if err := store.Users().AddPublicKey(ctx, key); err != nil {
+			return fmt.Errorf("failed to copy key %s of user %s: %w", key.Fingerprint, key.UserID, err)
+		}
+		progress(EntityUserKeys, i+1, len(s.keys))
+	}
+	for i, prefs := range s.preferences {
+		if err := store.UserPreferences().Upsert(ctx, prefs); err != nil {
+			return fmt.Errorf("failed to copy preferences of user %s: %w", prefs.UserID, err)
+		}
+		progress(EntityPreferences, i+1, len(s.preferences))
+	}
+
+	for i, topic := range s.topics {
+		if err := store.Topics().Create(ctx, topic); err != nil {
+			return fmt.Errorf("failed to copy topic %s: %w", topic.ID, err)
+		}
+		progress(EntityTopics, i+1, len(s.topics))
+	}
+	for i, member := range s.members {
+		if err := store.TopicMembers().Create(ctx, member); err != nil {
+			return fmt.Errorf("failed to copy member %s of topic %s: %w", member.UserID, member.TopicID, err)
+		}
+		progress(EntityTopicMembers, i+1, len(s.members))
+	}
+
+	// A dataset refers to its latest version, which only exists once the
+	// dataset does: create the dataset without it and set it afterwards
+	for i, dataset := range s.datasets {
+		created := *dataset
+		created.LatestVersionID = ""
+		if err := store.Datasets().Create(ctx, &created); err != nil {
+			return fmt.Errorf("failed to copy dataset %s: %w", dataset.ID, err)
+		}
+		progress(EntityDatasets, i+1, len(s.datasets))
+	}
+	for i, version := range s.versions {
+		if err := store.Datasets().CreateVersion(ctx, version); err != nil {
+			return fmt.Errorf("failed to copy version %s of dataset %s: %w", version.ID, version.DatasetID, err)
+		}
+		progress(EntityVersions, i+1, len(s.versions))
+	}
+	for _, dataset := range s.datasets {
+		if dataset.LatestVersionID == "" {
+			continue
+		}
+		updated := *dataset
+		updated.Revision = 0
+		if err := store.Datasets().Update(ctx, &updated); err != nil {
+			return fmt.Errorf("failed to set latest version of dataset %s: %w", dataset.ID, err)
+		}
+	}
+	for i, chunk := range s.chunks {
+		if err := store.Datasets().CreateChunk(ctx, chunk); err != nil {
+			return fmt.Errorf("failed to copy chunk %d of version %s: %w", chunk.Index, chunk.VersionID, err)
+		}
+		progress(EntityChunks, i+1, len(s.chunks))
+	}
+
+	// Entries keep their hashes, so the copied chain verifies as before
+	for i, entry := range s.audit {
+		copied := *entry
+		copied.ID = 0
+		if err := store.Audit().Log(ctx, &copied); err != nil {
+			return fmt.Errorf("failed to copy audit entry %d: %w", entry.ID, err)
+		}
+		progress(EntityAudit, i+1, len(s.audit))
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+// Package transfer copies the contents of one store into another, to move a
+// node from one storage backend to another (typically from SQLite to
+// PostgreSQL).
+//
+// A transfer reads users with their keys and preferences, topics with
+// their members, datasets with their versions and chunks, and the audit
+// log from the source, and writes them to an empty target in a single
+// transaction, keeping IDs, timestamps and audit hashes. It then reads
+// the target back and compares it with the source.
+//
+// Blobs are stored by content hash outside the database and stay where
+// they are; a transfer checks that every blob a chunk refers to is present.
+//
+// Jobs, sessions, node records, invitations, peer lists and query history
+// are not copied: they are either rebuilt by the daemon at runtime or
+// short-lived.
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bib/internal/storage"
+)
+
+// Entities a transfer copies, in the order they are written.
+const (
+	EntityUsers        = "users"
+	EntityUserKeys     = "user keys"
+	EntityPreferences  = "user preferences"
+	EntityTopics       = "topics"
+	EntityTopicMembers = "topic members"
+	EntityDatasets     = "datasets"
+	EntityVersions     = "dataset versions"
+	EntityChunks       = "chunks"
+	EntityAudit        = "audit entries"
+)
+
+var (
+	// ErrTargetNotEmpty is returned when the target store already holds data.
+	ErrTargetNotEmpty = errors.New("target store is not empty")
+
+	// ErrVerificationFailed is returned when the target does not match the
+	// source after the copy.
+	ErrVerificationFailed = errors.New("verification failed")
+)
+
+// BlobStore is the part of a blob store a transfer checks blobs against.
+type BlobStore interface {
+	Exists(ctx context.Context, hash string) (bool, error)
+}
+
+// Options configures a transfer.
+type Options struct {
+	// DryRun reads the source and checks the target and the blobs, but
+	// writes nothing.
+	DryRun bool
+
+	// Blobs is the blob store the chunks refer to. If nil, blobs are not
+	// checked.
+	Blobs BlobStore
+
+	// Progress is called after each record is written.
+	Progress func(entity string, done, total int)
+}
+
+// Count is the number of records of an entity.
+type Count struct {
+	Entity  string
+	Records int
+}
+
+// Report describes a transfer.
+type Report struct {
+	// Source holds the number of records read from the source.
+	Source []Count
+
+	// Target holds the number of records read back from the target. It is
+	// empty for a dry run.
+	Target []Count
+
+	// Blobs is the number of distinct blobs the chunks refer to.
+	Blobs int
+
+	// MissingBlobs lists the referenced blobs the blob store does not have.
+	MissingBlobs []string
+
+	// DryRun reports whether nothing was written.
+	DryRun bool
+
+	// Duration is how long the transfer took.
+	Duration time.Duration
+}
+
+// Run copies the contents of src into dst, which must be empty. Both
+// stores must be migrated, and nothing else may write to src while it runs.
+func Run(ctx context.Context, src, dst storage.Store, opts Options) (*Report, error) {
+	start := time.Now()
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(string, int, int) {}
+	}
+
+	// Audit entries logged from here on are the transfer's own
+	cutoff := time.Now().UTC()
+
+	if err := checkEmpty(ctx, dst); err != nil {
+		return nil, err
+	}
+	existing, err := readAudit(ctx, dst, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read target: %w", err)
+	}
+
+	source, err := read(ctx, src, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source: %w", err)
+	}
+
+	report := &Report{
+		Source: source.counts(),
+		DryRun: opts.DryRun,
+	}
+
+	hashes := source.blobHashes()
+	report.Blobs = len(hashes)
+	if opts.Blobs != nil {
+		for _, hash := range hashes {
+			ok, err := opts.Blobs.Exists(ctx, hash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check blob %s: %w", hash, err)
+			}
+			if !ok {
+				report.MissingBlobs = append(report.MissingBlobs, hash)
+			}
+		}
+	}
+
+	if opts.DryRun {
+		report.Duration = time.Since(start)
+		return report, nil
+	}
+
+	if err := dst.WithTx(ctx, func(ctx context.Context) error {
+		return write(ctx, dst, source, progress)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to write target: %w", err)
+	}
+
+	target, err := read(ctx, dst, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read target: %w", err)
+	}
+	// Leave out the entries the target logged before the copy, such as
+	// those of its migrations
+	target.audit = target.audit[min(len(existing), len(target.audit)):]
+	report.Target = target.counts()
+	report.Duration = time.Since(start)
+
+	if err := verify(source, target); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// checkEmpty returns ErrTargetNotEmpty if store holds users, topics or
+// datasets.
+func checkEmpty(ctx context.Context, store storage.Store) error {
+	users, err := store.Users().Count(ctx, storage.UserFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to count target users: %w", err)
+	}
+	topics, err := store.Topics().Count(ctx, storage.TopicFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to count target topics: %w", err)
+	}
+	datasets, err := store.Datasets().Count(ctx, storage.DatasetFilter{})
+	if err != nil {
+		return fmt.Errorf("failed to count target datasets: %w", err)
+	}
+
+	if users+topics+datasets > 0 {
+		return fmt.Errorf("%w: %d users, %d topics and %d datasets", ErrTargetNotEmpty, users, topics, datasets)
+	}
+	return nil
+}
+
+// verify compares the record counts of source and target, and checks that
+// the audit entries kept their hashes.
+func verify(source, target *snapshot) error {
+	want, got := source.counts(), target.counts()
+	for i := range want {
+		if want[i].Records != got[i].Records {
+			return fmt.Errorf("%w: copied %d %s, found %d", ErrVerificationFailed, want[i].Records, want[i].Entity, got[i].Records)
+		}
+	}
+
+	for i, entry := range source.audit {
+		// Entries without a hash get one from the target's chain
+		if entry.EntryHash != "" && target.audit[i].EntryHash != entry.EntryHash {
+			return fmt.Errorf("%w: audit entry %d has hash %q, want %q", ErrVerificationFailed, entry.ID, target.audit[i].EntryHash, entry.EntryHash)
+		}
+	}
+	return nil
+}
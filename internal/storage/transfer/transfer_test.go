@@ -0,0 +1,275 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bib/internal/domain"
+	"bib/internal/storage"
+	"bib/internal/storage/memory"
+	"bib/internal/storage/sqlite"
+)
+
+func testContext() context.Context {
+	return storage.WithOperationContext(context.Background(),
+		storage.NewOperationContext(storage.RoleAdmin, "test"),
+	)
+}
+
+func setupSQLiteStore(t *testing.T) storage.Store {
+	t.Helper()
+
+	dir := t.TempDir()
+	store, err := sqlite.New(storage.SQLiteConfig{Path: filepath.Join(dir, "test.db")}, dir, "test-node-id")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	if err := storage.RunMigrations(context.Background(), store, storage.DefaultMigrationsConfig()); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	return store
+}
+
+// populate fills store with a user with a second key, a parent and a child
+// topic, a member, a dataset with two versions of two chunks each, and
+// three audit entries.
+func populate(t *testing.T, store storage.Store) {
+	t.Helper()
+	ctx := testContext()
+	now := time.Now().UTC().Add(-time.Hour)
+
+	user := domain.NewUser(bytes.Repeat([]byte{1}, 32), domain.KeyTypeEd25519, "alice", "alice@example.com", true)
+	if err := store.Users().Create(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	key := domain.NewUserPublicKey(user.ID, bytes.Repeat([]byte{2}, 32), domain.KeyTypeEd25519, "laptop")
+	if err := store.Users().AddPublicKey(ctx, key); err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+
+	// The child is created first, so it is listed before its parent
+	for _, topic := range []*domain.Topic{
+		{ID: "topic-2", ParentID: "topic-1", Name: "child", CreatedAt: now.Add(time.Second)},
+		{ID: "topic-1", Name: "parent", CreatedAt: now},
+	} {
+		topic.Status = domain.TopicStatusActive
+		topic.Owners = []domain.UserID{user.ID}
+		topic.CreatedBy = user.ID
+		topic.UpdatedAt = topic.CreatedAt
+		if err := store.Topics().Create(ctx, topic); err != nil {
+			t.Fatalf("failed to create topic %s: %v", topic.ID, err)
+		}
+	}
+	if err := store.TopicMembers().Create(ctx, &storage.TopicMember{
+		ID:        "member-1",
+		TopicID:   "topic-1",
+		UserID:    user.ID,
+		Role:      storage.TopicMemberRoleOwner,
+		InvitedAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}); err != nil {
+		t.Fatalf("failed to create member: %v", err)
+	}
+
+	dataset := &domain.Dataset{
+		ID:        "dataset-1",
+		TopicID:   "topic-1",
+		Name:      "dataset",
+		Status:    domain.DatasetStatusActive,
+		Owners:    []domain.UserID{user.ID},
+		CreatedBy: user.ID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := store.Datasets().Create(ctx, dataset); err != nil {
+		t.Fatalf("failed to create dataset: %v", err)
+	}
+	var previous domain.DatasetVersionID
+	for n := 1; n <= 2; n++ {
+		id := domain.DatasetVersionID(fmt.Sprintf("v%d", n))
+		if err := store.Datasets().CreateVersion(ctx, &domain.DatasetVersion{
+			ID:                id,
+			DatasetID:         dataset.ID,
+			Version:           domain.VersionString(n),
+			PreviousVersionID: previous,
+			Content:           &domain.DatasetContent{Hash: "hash", Size: 2, ChunkCount: 2},
+			CreatedBy:         user.ID,
+			CreatedAt:         now.Add(time.Duration(n) * time.Second),
+		}); err != nil {
+			t.Fatalf("failed to create version %d: %v", n, err)
+		}
+		for index := range 2 {
+			if err := store.Datasets().CreateChunk(ctx, &domain.Chunk{
+				ID:        domain.ChunkID(fmt.Sprintf("chunk-%s-%d", id, index)),
+				DatasetID: dataset.ID,
+				VersionID: id,
+				Index:     index,
+				Hash:      fmt.Sprintf("blob-%d", index),
+				Size:      1,
+				Status:    domain.ChunkStatusVerified,
+			}); err != nil {
+				t.Fatalf("failed to create chunk: %v", err)
+			}
+		}
+		previous = id
+	}
+	dataset.LatestVersionID = previous
+	dataset.VersionCount = 2
+	if err := store.Datasets().Update(ctx, dataset); err != nil {
+		t.Fatalf("failed to update dataset: %v", err)
+	}
+
+	for i := range 3 {
+		if err := store.Audit().Log(ctx, &storage.AuditEntry{
+			Timestamp:       now.Add(time.Duration(i) * time.Second),
+			OperationID:     fmt.Sprintf("op-%d", i),
+			RoleUsed:        string(storage.RoleAdmin),
+			Action:          "INSERT",
+			TableName:       "topics",
+			SourceComponent: "test",
+		}); err != nil {
+			t.Fatalf("failed to log audit entry: %v", err)
+		}
+	}
+}
+
+// blobs is a blob store holding a fixed set of hashes.
+type blobs map[string]bool
+
+func (b blobs) Exists(_ context.Context, hash string) (bool, error) {
+	return b[hash], nil
+}
+
+func TestRun(t *testing.T) {
+	ctx := testContext()
+	src := memory.New("source-node")
+	defer src.Close()
+	populate(t, src)
+	dst := setupSQLiteStore(t)
+
+	var calls int
+	report, err := Run(ctx, src, dst, Options{
+		Blobs:    blobs{"blob-0": true, "blob-1": true},
+		Progress: func(string, int, int) { calls++ },
+	})
+	if err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+
+	want := map[string]int{
+		EntityUsers:        1,
+		EntityUserKeys:     1,
+		EntityPreferences:  1,
+		EntityTopics:       2,
+		EntityTopicMembers: 1,
+		EntityDatasets:     1,
+		EntityVersions:     2,
+		EntityChunks:       4,
+		EntityAudit:        3,
+	}
+	var total int
+	for i, count := range report.Source {
+		if count.Records != want[count.Entity] {
+			t.Errorf("expected %d %s, got %d", want[count.Entity], count.Entity, count.Records)
+		}
+		if report.Target[i] != count {
+			t.Errorf("expected target to match source, got %+v for %+v", report.Target[i], count)
+		}
+		total += count.Records
+	}
+	if calls != total {
+		t.Errorf("expected progress for each of %d records, got %d calls", total, calls)
+	}
+	if report.Blobs != 2 || len(report.MissingBlobs) != 0 {
+		t.Errorf("expected 2 blobs, none missing, got %d and %v", report.Blobs, report.MissingBlobs)
+	}
+
+	child, err := dst.Topics().Get(ctx, "topic-2")
+	if err != nil || child.ParentID != "topic-1" {
+		t.Errorf("expected the child topic under its parent, got %+v (%v)", child, err)
+	}
+	dataset, err := dst.Datasets().Get(ctx, "dataset-1")
+	if err != nil || dataset.LatestVersionID != "v2" {
+		t.Errorf("expected latest version v2, got %+v (%v)", dataset, err)
+	}
+	version, err := dst.Datasets().GetVersion(ctx, "dataset-1", "v2")
+	if err != nil || version.PreviousVersionID != "v1" {
+		t.Errorf("expected v2 to follow v1, got %+v (%v)", version, err)
+	}
+
+	srcHash, _ := src.Audit().GetLastHash(ctx)
+	dstHash, _ := dst.Audit().GetLastHash(ctx)
+	if srcHash == "" || srcHash != dstHash {
+		t.Errorf("expected the audit chain to end in %q, got %q", srcHash, dstHash)
+	}
+}
+
+func TestRun_DryRun(t *testing.T) {
+	ctx := testContext()
+	src := memory.New("source-node")
+	defer src.Close()
+	populate(t, src)
+	dst := memory.New("target-node")
+	defer dst.Close()
+
+	report, err := Run(ctx, src, dst, Options{DryRun: true, Blobs: blobs{"blob-0": true}})
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if !report.DryRun || report.Target != nil {
+		t.Errorf("expected a dry run report, got %+v", report)
+	}
+	if len(report.MissingBlobs) != 1 || report.MissingBlobs[0] != "blob-1" {
+		t.Errorf("expected blob-1 to be missing, got %v", report.MissingBlobs)
+	}
+
+	if count, _ := dst.Topics().Count(ctx, storage.TopicFilter{}); count != 0 {
+		t.Errorf("expected a dry run to write nothing, got %d topics", count)
+	}
+}
+
+func TestRun_TargetNotEmpty(t *testing.T) {
+	ctx := testContext()
+	src := memory.New("source-node")
+	defer src.Close()
+	populate(t, src)
+
+	// A second run into the same target is refused
+	dst := memory.New("target-node")
+	defer dst.Close()
+	if _, err := Run(ctx, src, dst, Options{}); err != nil {
+		t.Fatalf("transfer failed: %v", err)
+	}
+	if _, err := Run(ctx, src, dst, Options{}); !errors.Is(err, ErrTargetNotEmpty) {
+		t.Errorf("expected ErrTargetNotEmpty, got %v", err)
+	}
+}
+
+func TestParentsFirst(t *testing.T) {
+	topics := []*domain.Topic{
+		{ID: "c", ParentID: "b"},
+		{ID: "b", ParentID: "a"},
+		{ID: "orphan", ParentID: "missing"},
+		{ID: "a"},
+	}
+
+	ordered := parentsFirst(topics)
+	if len(ordered) != len(topics) {
+		t.Fatalf("expected %d topics, got %d", len(topics), len(ordered))
+	}
+	position := make(map[domain.TopicID]int)
+	for i, topic := range ordered {
+		position[topic.ID] = i
+	}
+	if position["a"] > position["b"] || position["b"] > position["c"] {
+		t.Errorf("expected parents before children, got %v", position)
+	}
+}
@@ -58,6 +58,10 @@ type NodeSelector struct {
 	customAddress string
 	inCustomMode  bool
 
+	// userDefault is set once the user picks the default node, which
+	// ranking then leaves alone
+	userDefault bool
+
 	// Callbacks
 	onSelectionChange func([]NodeSelectorItem)
 }
@@ -97,6 +101,15 @@ func (n *NodeSelector) WithNodes(nodes []discovery.DiscoveredNode) *NodeSelector
 	return n
 }
 
+// WithRankedNodes sets the discovered nodes in ranking order, best first
+func (n *NodeSelector) WithRankedNodes(ranked []discovery.RankedNode) *NodeSelector {
+	nodes := make([]discovery.DiscoveredNode, len(ranked))
+	for i, node := range ranked {
+		nodes[i] = node.DiscoveredNode
+	}
+	return n.WithNodes(nodes)
+}
+
 // WithItems sets the node selector items directly
 func (n *NodeSelector) WithItems(items []NodeSelectorItem) *NodeSelector {
 	n.items = items
@@ -161,6 +174,8 @@ func (n *NodeSelector) generateAlias(node discovery.DiscoveredNode) string {
 			return node.NodeInfo.Name
 		}
 		return fmt.Sprintf("Peer (%s)", node.Address)
+	case discovery.MethodDNS:
+		return fmt.Sprintf("DNS (%s)", node.Address)
 	case discovery.MethodPublic:
 		return "bib.dev (Public Network)"
 	default:
@@ -346,10 +361,42 @@ func (n *NodeSelector) setCurrentAsDefault() {
 		item.IsDefault = true
 		item.Selected = true
 	}
+	n.userDefault = true
 
 	n.notifySelectionChange()
 }
 
+// DefaultToBest makes the best ranked reachable node among the selected
+// ones the default, unless the user picked the default. It returns whether
+// the default changed.
+func (n *NodeSelector) DefaultToBest(ranked []discovery.RankedNode) bool {
+	if n.userDefault {
+		return false
+	}
+
+	for _, node := range ranked {
+		if !node.Reachable {
+			continue
+		}
+		for i := range n.items {
+			if n.items[i].Node.Address != node.Address || !n.items[i].Selected {
+				continue
+			}
+			if n.items[i].IsDefault {
+				return false
+			}
+			for j := range n.items {
+				n.items[j].IsDefault = false
+			}
+			n.bibDevItem.IsDefault = false
+			n.items[i].IsDefault = true
+			n.notifySelectionChange()
+			return true
+		}
+	}
+	return false
+}
+
 // addCustomNode adds a custom node address
 func (n *NodeSelector) addCustomNode(address string) {
 	// Normalize address
@@ -591,6 +638,8 @@ func (n *NodeSelector) getMethodIcon(method discovery.DiscoveryMethod) string {
 		return "📡"
 	case discovery.MethodP2P:
 		return "🌐"
+	case discovery.MethodDNS:
+		return "🔎"
 	case discovery.MethodPublic:
 		return "☁️"
 	case discovery.MethodManual:
@@ -229,6 +229,38 @@ func TestNodeSelectorSetDefault(t *testing.T) {
 	}
 }
 
+func TestNodeSelectorDefaultToBest(t *testing.T) {
+	nodes := []discovery.DiscoveredNode{
+		{Address: "localhost:4000", Method: discovery.MethodLocal},
+		{Address: "localhost:8080", Method: discovery.MethodLocal},
+	}
+	ranked := []discovery.RankedNode{
+		{DiscoveredNode: nodes[0], Score: 90, Reachable: false},
+		{DiscoveredNode: nodes[1], Score: 80, Reachable: true},
+	}
+
+	ns := NewNodeSelector().WithNodes(nodes)
+	ns.SelectFirst()
+	ns.items[1].Selected = true
+
+	if !ns.DefaultToBest(ranked) {
+		t.Fatal("expected the default to change")
+	}
+	if ns.items[0].IsDefault || !ns.items[1].IsDefault {
+		t.Error("expected the best reachable node to be default")
+	}
+	if ns.DefaultToBest(ranked) {
+		t.Error("expected no change when the best node is already default")
+	}
+
+	// A default picked by the user is kept
+	ns.cursorIndex = 0
+	ns.setCurrentAsDefault()
+	if ns.DefaultToBest(ranked) || !ns.items[0].IsDefault {
+		t.Error("expected the user's default to be kept")
+	}
+}
+
 func TestNodeSelectorGetDefaultNode(t *testing.T) {
 	nodes := []discovery.DiscoveredNode{
 		{Address: "localhost:4000", Method: discovery.MethodLocal},
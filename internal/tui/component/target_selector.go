@@ -218,6 +218,12 @@ func (s *TargetSelector) View() string {
 					sb.WriteString(fmt.Sprintf("     • %s: %s\n", k, v))
 				}
 			}
+
+			// Show runtime health, including why an unavailable target failed
+			if health := deploy.FormatHealth(target); health != "" {
+				sb.WriteString(s.descStyle.Render(strings.TrimRight(health, "\n")))
+				sb.WriteString("\n")
+			}
 		}
 	}
 
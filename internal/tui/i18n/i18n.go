@@ -449,7 +449,7 @@ func DetectSystemLocale() string {
 
 	for _, envVar := range envVars {
 		if locale := os.Getenv(envVar); locale != "" {
-			if detected := matchLocale(locale); detected != "" {
+			if detected := MatchLocale(locale); detected != "" {
 				return detected
 			}
 		}
@@ -465,9 +465,9 @@ func DetectSystemLocale() string {
 	return DefaultLocale
 }
 
-// matchLocale tries to match a system locale string to a supported locale.
+// MatchLocale tries to match a locale string to a supported locale.
 // It handles formats like "de_DE.UTF-8", "de_DE", "de", "zh_TW.UTF-8", etc.
-func matchLocale(sysLocale string) string {
+func MatchLocale(sysLocale string) string {
 	// Normalize: lowercase and remove encoding suffix
 	locale := strings.ToLower(sysLocale)
 	if idx := strings.Index(locale, "."); idx != -1 {
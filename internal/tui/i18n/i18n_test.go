@@ -254,9 +254,9 @@ func TestMatchLocale(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.sysLocale, func(t *testing.T) {
-			result := matchLocale(tt.sysLocale)
+			result := MatchLocale(tt.sysLocale)
 			if result != tt.expected {
-				t.Errorf("matchLocale(%q): expected %q, got %q", tt.sysLocale, tt.expected, result)
+				t.Errorf("MatchLocale(%q): expected %q, got %q", tt.sysLocale, tt.expected, result)
 			}
 		})
 	}
@@ -4,6 +4,8 @@ package version
 import (
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -84,3 +86,68 @@ func (i Info) Full() string {
 	return fmt.Sprintf("Version: %s\nCommit: %s\nBuild Time: %s\nGo Version: %s\nOS/Arch: %s/%s",
 		i.Version, i.Commit, buildTimeStr, i.GoVersion, i.OS, i.Arch)
 }
+
+// APIVersion is the version of the gRPC API (major.minor) served by the
+// daemon and spoken by the client. The major version changes when RPCs or
+// fields are removed or change meaning; the minor version when they are added.
+const APIVersion = "1.0"
+
+// MinAPIVersion is the oldest client API version the daemon still serves.
+const MinAPIVersion = "1.0"
+
+// Features lists the optional API features of this build. Clients should
+// check for a feature rather than compare versions.
+var Features = []string{
+	"admin.upgrade",
+	"compression.zstd",
+	"dataset.resumable_upload",
+}
+
+// CheckAPICompatibility compares the API version a client was built against
+// with the API version of a server and the oldest client version it serves.
+// An error means the client cannot work with the server; a warning means it
+// can, but some calls may fail.
+func CheckAPICompatibility(clientAPI, serverAPI, minAPI string) (warning string, err error) {
+	client, err := parseAPIVersion(clientAPI)
+	if err != nil {
+		return "", fmt.Errorf("client: %w", err)
+	}
+	server, err := parseAPIVersion(serverAPI)
+	if err != nil {
+		return "", fmt.Errorf("server: %w", err)
+	}
+
+	if client[0] != server[0] {
+		return "", fmt.Errorf("client API %s is incompatible with server API %s", clientAPI, serverAPI)
+	}
+	if minAPI != "" {
+		min, err := parseAPIVersion(minAPI)
+		if err != nil {
+			return "", fmt.Errorf("server minimum: %w", err)
+		}
+		if client[0] == min[0] && client[1] < min[1] {
+			return "", fmt.Errorf("client API %s is older than the minimum %s supported by the server", clientAPI, minAPI)
+		}
+	}
+	if client[1] > server[1] {
+		return fmt.Sprintf("client API %s is newer than server API %s; upgrade the daemon to use all features", clientAPI, serverAPI), nil
+	}
+	return "", nil
+}
+
+// parseAPIVersion parses a major.minor API version
+func parseAPIVersion(v string) ([2]int, error) {
+	var parsed [2]int
+	major, minor, ok := strings.Cut(strings.TrimPrefix(v, "v"), ".")
+	if !ok {
+		return parsed, fmt.Errorf("invalid API version %q", v)
+	}
+	var err error
+	if parsed[0], err = strconv.Atoi(major); err != nil {
+		return parsed, fmt.Errorf("invalid API version %q", v)
+	}
+	if parsed[1], err = strconv.Atoi(minor); err != nil {
+		return parsed, fmt.Errorf("invalid API version %q", v)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,40 @@
+package version
+
+import "testing"
+
+func TestCheckAPICompatibility(t *testing.T) {
+	tests := []struct {
+		name              string
+		client, server    string
+		min               string
+		wantErr, wantWarn bool
+	}{
+		{name: "equal", client: "1.0", server: "1.0", min: "1.0"},
+		{name: "server newer minor", client: "1.0", server: "1.3", min: "1.0"},
+		{name: "client newer minor", client: "1.2", server: "1.0", min: "1.0", wantWarn: true},
+		{name: "major mismatch", client: "2.0", server: "1.4", min: "1.0", wantErr: true},
+		{name: "client below minimum", client: "1.1", server: "1.5", min: "1.2", wantErr: true},
+		{name: "no minimum", client: "1.0", server: "1.5"},
+		{name: "v prefix", client: "v1.0", server: "1.0"},
+		{name: "invalid server", client: "1.0", server: "one", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning, err := CheckAPICompatibility(tt.client, tt.server, tt.min)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (warning != "") != tt.wantWarn {
+				t.Errorf("warning = %q, wantWarn %v", warning, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestAPIVersionSupportsItself(t *testing.T) {
+	warning, err := CheckAPICompatibility(APIVersion, APIVersion, MinAPIVersion)
+	if err != nil || warning != "" {
+		t.Errorf("build is not compatible with itself: %q %v", warning, err)
+	}
+}
@@ -0,0 +1,270 @@
+// Package watchdog flags goroutine leaks and a hung daemon.
+//
+// The watchdog samples the goroutine count on an interval and fires if it
+// exceeds a limit or keeps growing, sample after sample. It also watches
+// heartbeats: a component that beats regularly while it is healthy stops
+// beating if it deadlocks, and the watchdog fires once a heartbeat has been
+// missing for longer than the stall timeout. Every time it fires the
+// watchdog logs a dump of all goroutines, so that "the daemon got slow" or
+// "the daemon hung" comes with the stacks to investigate.
+//
+// The watchdog fires once per problem: it logs again only after the
+// condition has cleared and come back.
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"bib/internal/logger"
+	"bib/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons the watchdog fires for.
+const (
+	ReasonGoroutineLimit  = "goroutine_limit"
+	ReasonGoroutineGrowth = "goroutine_growth"
+	ReasonStall           = "stall"
+)
+
+// Config holds the thresholds of the watchdog. A zero threshold is not
+// checked.
+type Config struct {
+	// Interval is how often the watchdog samples.
+	Interval time.Duration
+
+	// MaxGoroutines is the goroutine count above which the watchdog fires.
+	MaxGoroutines int
+
+	// GrowthSamples is the number of consecutive samples the goroutine
+	// count must grow in before the watchdog fires.
+	GrowthSamples int
+
+	// StallTimeout is how long a heartbeat may be missing before the
+	// watchdog fires.
+	StallTimeout time.Duration
+}
+
+// Watchdog samples the goroutine count and heartbeats. It is a
+// prometheus.Collector exporting the age of the heartbeats and the number
+// of times it fired.
+type Watchdog struct {
+	cfg Config
+	log *logger.Logger
+
+	mu         sync.Mutex
+	heartbeats map[string]*Heartbeat
+	sampled    bool            // whether the goroutine count was sampled yet
+	last       int             // goroutine count of the previous sample
+	growth     int             // consecutive samples the count grew in
+	firing     map[string]bool // conditions currently firing, by reason or heartbeat
+
+	fired *prometheus.CounterVec
+	age   *prometheus.Desc
+
+	// Sources of the samples; tests replace them
+	goroutines func() int
+	now        func() time.Time
+	dump       func() string
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// New creates a watchdog logging to log.
+func New(cfg Config, log *logger.Logger) *Watchdog {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 30 * time.Second
+	}
+	return &Watchdog{
+		cfg:        cfg,
+		log:        log,
+		heartbeats: make(map[string]*Heartbeat),
+		firing:     make(map[string]bool),
+		fired: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metrics.WatchdogFired,
+			Help: "Times the watchdog fired, by reason.",
+		}, []string{metrics.ReasonLabel}),
+		age: prometheus.NewDesc(metrics.WatchdogHeartbeatAge,
+			"Seconds since the last beat of a watchdog heartbeat.",
+			[]string{metrics.HeartbeatLabel}, nil),
+		goroutines: runtime.NumGoroutine,
+		now:        time.Now,
+		dump:       goroutineDump,
+		stopCh:     make(chan struct{}),
+		doneCh:     make(chan struct{}),
+	}
+}
+
+// Heartbeat returns the heartbeat called name, registering it with the
+// watchdog. The owner must call Beat more often than the stall timeout.
+func (w *Watchdog) Heartbeat(name string) *Heartbeat {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if hb, ok := w.heartbeats[name]; ok {
+		return hb
+	}
+	hb := &Heartbeat{now: w.now}
+	hb.Beat()
+	w.heartbeats[name] = hb
+	return hb
+}
+
+// Interval returns how often the watchdog samples.
+func (w *Watchdog) Interval() time.Duration {
+	return w.cfg.Interval
+}
+
+// Start begins sampling in the background.
+func (w *Watchdog) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Stop stops sampling.
+func (w *Watchdog) Stop() {
+	close(w.stopCh)
+	<-w.doneCh
+}
+
+func (w *Watchdog) run(ctx context.Context) {
+	defer close(w.doneCh)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+// Check samples the goroutine count and heartbeats now, and logs a
+// goroutine dump if a threshold was newly crossed. It returns the reasons
+// the watchdog fired for.
+func (w *Watchdog) Check() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var fired []string
+	count := w.goroutines()
+
+	if w.sampled && count > w.last {
+		w.growth++
+	} else {
+		w.growth = 0
+	}
+	w.sampled, w.last = true, count
+
+	if w.trip(ReasonGoroutineLimit, ReasonGoroutineLimit, w.cfg.MaxGoroutines > 0 && count > w.cfg.MaxGoroutines) {
+		w.log.Warn("goroutine count exceeds limit",
+			"goroutines", count, "limit", w.cfg.MaxGoroutines)
+		fired = append(fired, ReasonGoroutineLimit)
+	}
+	if w.trip(ReasonGoroutineGrowth, ReasonGoroutineGrowth, w.cfg.GrowthSamples > 0 && w.growth >= w.cfg.GrowthSamples) {
+		w.log.Warn("goroutine count keeps growing; possible goroutine leak",
+			"goroutines", count, "samples", w.growth, "interval", w.cfg.Interval)
+		fired = append(fired, ReasonGoroutineGrowth)
+	}
+
+	now := w.now()
+	for _, name := range w.heartbeatNames() {
+		age := now.Sub(w.heartbeats[name].Last())
+		if w.trip(ReasonStall+":"+name, ReasonStall, w.cfg.StallTimeout > 0 && age > w.cfg.StallTimeout) {
+			w.log.Error("heartbeat missing; component may be deadlocked",
+				"heartbeat", name, "last_beat", age.Round(time.Second), "timeout", w.cfg.StallTimeout)
+			fired = append(fired, ReasonStall)
+		}
+	}
+
+	if len(fired) > 0 {
+		w.log.Warn("watchdog goroutine dump", "reasons", fired, "goroutines", count, "dump", w.dump())
+	}
+	return fired
+}
+
+// trip records whether the condition key holds and reports whether it
+// newly does, counting reason as fired.
+func (w *Watchdog) trip(key, reason string, holds bool) bool {
+	was := w.firing[key]
+	w.firing[key] = holds
+	if !holds || was {
+		return false
+	}
+	w.fired.WithLabelValues(reason).Inc()
+	return true
+}
+
+// heartbeatNames returns the names of the heartbeats, sorted.
+func (w *Watchdog) heartbeatNames() []string {
+	names := make([]string, 0, len(w.heartbeats))
+	for name := range w.heartbeats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Describe implements prometheus.Collector.
+func (w *Watchdog) Describe(ch chan<- *prometheus.Desc) {
+	w.fired.Describe(ch)
+	ch <- w.age
+}
+
+// Collect implements prometheus.Collector.
+func (w *Watchdog) Collect(ch chan<- prometheus.Metric) {
+	w.fired.Collect(ch)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	for _, name := range w.heartbeatNames() {
+		age := now.Sub(w.heartbeats[name].Last()).Seconds()
+		ch <- prometheus.MustNewConstMetric(w.age, prometheus.GaugeValue, age, name)
+	}
+}
+
+// goroutineDump returns the stacks of all goroutines, in the format of an
+// unrecovered panic.
+func goroutineDump() string {
+	var buf bytes.Buffer
+	_ = pprof.Lookup("goroutine").WriteTo(&buf, 2)
+	return buf.String()
+}
+
+// Heartbeat is the liveness signal of a component. Beat it from the loop
+// that would stop if the component hung.
+type Heartbeat struct {
+	now func() time.Time
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// Beat records that the component is alive.
+func (h *Heartbeat) Beat() {
+	h.mu.Lock()
+	h.last = h.now()
+	h.mu.Unlock()
+}
+
+// Last returns the time of the last beat.
+func (h *Heartbeat) Last() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.last
+}
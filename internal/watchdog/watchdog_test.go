@@ -0,0 +1,160 @@
+package watchdog
+
+import (
+	"testing"
+	"time"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+	"bib/internal/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// testWatchdog returns a watchdog sampling a fake goroutine count and
+// clock, and counting the dumps it takes.
+func testWatchdog(t *testing.T, cfg Config) (w *Watchdog, count *int, now *time.Time, dumps *int) {
+	t.Helper()
+	log, err := logger.New(config.LogConfig{Level: "error", Format: "text", Output: "stderr"})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	count, now, dumps = new(int), new(time.Time), new(int)
+	*now = time.Unix(1700000000, 0)
+
+	w = New(cfg, log)
+	w.goroutines = func() int { return *count }
+	w.now = func() time.Time { return *now }
+	w.dump = func() string { *dumps++; return "goroutine 1 [running]:" }
+	return w, count, now, dumps
+}
+
+func TestWatchdog_GoroutineLimit(t *testing.T) {
+	w, count, _, dumps := testWatchdog(t, Config{MaxGoroutines: 100})
+
+	*count = 50
+	if fired := w.Check(); len(fired) != 0 {
+		t.Fatalf("fired below the limit: %v", fired)
+	}
+
+	*count = 150
+	if fired := w.Check(); len(fired) != 1 || fired[0] != ReasonGoroutineLimit {
+		t.Fatalf("fired = %v, want [%s]", fired, ReasonGoroutineLimit)
+	}
+	if *dumps != 1 {
+		t.Errorf("dumps = %d, want 1", *dumps)
+	}
+
+	// Still above the limit: not fired again
+	*count = 140
+	if fired := w.Check(); len(fired) != 0 {
+		t.Errorf("fired again while above the limit: %v", fired)
+	}
+
+	// Cleared, then crossed again
+	*count = 60
+	w.Check()
+	*count = 120
+	if fired := w.Check(); len(fired) != 1 {
+		t.Errorf("fired = %v after the limit was crossed again", fired)
+	}
+	if *dumps != 2 {
+		t.Errorf("dumps = %d, want 2", *dumps)
+	}
+}
+
+func TestWatchdog_GoroutineGrowth(t *testing.T) {
+	w, count, _, _ := testWatchdog(t, Config{GrowthSamples: 3})
+
+	*count = 10
+	w.Check()
+	for i := 0; i < 2; i++ {
+		*count += 5
+		if fired := w.Check(); len(fired) != 0 {
+			t.Fatalf("fired after %d growing samples: %v", i+1, fired)
+		}
+	}
+
+	*count += 5
+	if fired := w.Check(); len(fired) != 1 || fired[0] != ReasonGoroutineGrowth {
+		t.Fatalf("fired = %v, want [%s]", fired, ReasonGoroutineGrowth)
+	}
+
+	// A sample without growth resets the streak
+	w.Check()
+	for i := 0; i < 2; i++ {
+		*count++
+		if fired := w.Check(); len(fired) != 0 {
+			t.Errorf("fired after the streak was reset: %v", fired)
+		}
+	}
+}
+
+func TestWatchdog_Stall(t *testing.T) {
+	w, _, now, dumps := testWatchdog(t, Config{StallTimeout: time.Minute})
+	hb := w.Heartbeat("daemon")
+
+	*now = now.Add(30 * time.Second)
+	if fired := w.Check(); len(fired) != 0 {
+		t.Fatalf("fired within the stall timeout: %v", fired)
+	}
+
+	*now = now.Add(45 * time.Second)
+	if fired := w.Check(); len(fired) != 1 || fired[0] != ReasonStall {
+		t.Fatalf("fired = %v, want [%s]", fired, ReasonStall)
+	}
+	if *dumps != 1 {
+		t.Errorf("dumps = %d, want 1", *dumps)
+	}
+
+	hb.Beat()
+	if fired := w.Check(); len(fired) != 0 {
+		t.Errorf("fired after a beat: %v", fired)
+	}
+	if w.Heartbeat("daemon") != hb {
+		t.Error("Heartbeat should return the registered heartbeat")
+	}
+}
+
+func TestWatchdog_Metrics(t *testing.T) {
+	w, count, now, _ := testWatchdog(t, Config{MaxGoroutines: 10, StallTimeout: time.Minute})
+	w.Heartbeat("daemon")
+
+	*count = 20
+	*now = now.Add(2 * time.Minute)
+	w.Check()
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(w)
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+
+	values := make(map[string]float64)
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			key := mf.GetName()
+			for _, l := range m.GetLabel() {
+				key += "/" + l.GetValue()
+			}
+			if m.GetCounter() != nil {
+				values[key] = m.GetCounter().GetValue()
+			} else {
+				values[key] = m.GetGauge().GetValue()
+			}
+		}
+	}
+
+	want := map[string]float64{
+		metrics.WatchdogFired + "/" + ReasonGoroutineLimit: 1,
+		metrics.WatchdogFired + "/" + ReasonStall:          1,
+		metrics.WatchdogHeartbeatAge + "/daemon":           120,
+	}
+	for key, v := range want {
+		if got, ok := values[key]; !ok || got != v {
+			t.Errorf("%s = %v (present: %v), want %v", key, got, ok, v)
+		}
+	}
+}
@@ -153,7 +153,33 @@ func TestAuthService_CompleteAuthFlow(t *testing.T) {
 		}
 	})
 
-	// Step 6: Logout
+	// Step 6: Inspect identity
+	t.Run("WhoAmI", func(t *testing.T) {
+		authCtx := metadata.AppendToOutgoingContext(ctx, "x-session-token", sessionToken)
+		resp, err := authClient.WhoAmI(authCtx, &services.WhoAmIRequest{})
+		assertNoError(t, err)
+
+		if resp.User == nil || resp.User.Id == "" {
+			t.Fatal("expected user")
+		}
+		if resp.PublicKeyFingerprint != resp.User.PublicKeyFingerprint {
+			t.Errorf("expected session key %s to be the primary key %s", resp.PublicKeyFingerprint, resp.User.PublicKeyFingerprint)
+		}
+		if len(resp.Roles) == 0 || resp.Roles[0] != resp.User.Role {
+			t.Errorf("expected roles to start with %s, got %v", resp.User.Role, resp.Roles)
+		}
+		if len(resp.Permissions) == 0 {
+			t.Error("expected permissions")
+		}
+		if resp.Session == nil || !resp.Session.IsCurrent || resp.ExpiresAt == nil {
+			t.Error("expected current session with expiry")
+		}
+		if resp.NodeId == "" {
+			t.Error("expected answering node ID")
+		}
+	})
+
+	// Step 7: Logout
 	t.Run("Logout", func(t *testing.T) {
 		authCtx := metadata.AppendToOutgoingContext(ctx, "x-session-token", sessionToken)
 		resp, err := authClient.Logout(authCtx, &services.LogoutRequest{})
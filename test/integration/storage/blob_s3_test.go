@@ -0,0 +1,142 @@
+//go:build integration
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"bib/internal/config"
+	"bib/internal/logger"
+	"bib/internal/storage"
+	"bib/internal/storage/blob"
+	"bib/test/testutil"
+	"bib/test/testutil/containers"
+)
+
+// TestS3BlobStore_Integration exercises the S3 blob backend against MinIO.
+func TestS3BlobStore_Integration(t *testing.T) {
+	t.Parallel()
+	testutil.SkipIfShort(t)
+	ctx := testutil.TestContext(t)
+
+	cm := containers.NewManager(t)
+	minioCfg := containers.DefaultMinIOConfig()
+	minioContainer, err := cm.StartMinIO(ctx, minioCfg)
+	if err != nil {
+		t.Fatalf("failed to start minio: %v", err)
+	}
+
+	log, err := logger.New(config.LogConfig{Level: "warn", Format: "text", Output: "stderr"})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	defer log.Close()
+
+	encKey := make([]byte, 32)
+	_, _ = rand.Read(encKey)
+
+	variants := []struct {
+		name        string
+		compression bool
+		encryption  bool
+	}{
+		{"plain", false, false},
+		{"compressed", true, false},
+		{"encrypted", true, true},
+	}
+
+	for _, v := range variants {
+		t.Run(v.name, func(t *testing.T) {
+			cfg := storage.DefaultBlobConfig().S3
+			cfg.Enabled = true
+			cfg.Endpoint = "http://" + minioContainer.Address(9000)
+			cfg.Bucket = "bib-test"
+			cfg.Prefix = "blobs/" + v.name
+			cfg.AccessKeyID = minioCfg.AccessKey
+			cfg.SecretAccessKey = minioCfg.SecretKey
+			cfg.ServerSideEncryption = "none" // MinIO needs a KMS for SSE
+			cfg.Compression.Enabled = v.compression
+			cfg.ClientSideEncryption.Enabled = v.encryption
+
+			client, err := blob.NewS3Client(cfg)
+			if err != nil {
+				t.Fatalf("failed to create S3 client: %v", err)
+			}
+			if err := client.EnsureBucket(ctx, cfg.Bucket, cfg.Region); err != nil {
+				t.Fatalf("failed to create bucket: %v", err)
+			}
+
+			store, err := blob.NewS3Store(cfg, client, encKey, log)
+			if err != nil {
+				t.Fatalf("failed to create store: %v", err)
+			}
+			defer store.Close()
+
+			testS3BlobRoundTrip(t, ctx, store, []byte("small blob content"))
+
+			// Larger than the multipart part size
+			large := make([]byte, 40<<20)
+			_, _ = rand.Read(large[:1<<20])
+			testS3BlobRoundTrip(t, ctx, store, large)
+		})
+	}
+}
+
+func testS3BlobRoundTrip(t *testing.T, ctx context.Context, store blob.Store, data []byte) {
+	t.Helper()
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	if err := store.Put(ctx, hash, bytes.NewReader(data), &blob.Metadata{Tags: []string{"test"}}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if err := store.Put(ctx, hash, bytes.NewReader(data), nil); err == nil {
+		t.Error("expected second Put of the same hash to fail")
+	}
+
+	exists, err := store.Exists(ctx, hash)
+	if err != nil || !exists {
+		t.Fatalf("Exists = %v, %v; expected true", exists, err)
+	}
+	if size, err := store.Size(ctx, hash); err != nil || size != int64(len(data)) {
+		t.Errorf("Size = %d, %v; expected %d", size, err, len(data))
+	}
+
+	reader, err := store.Get(ctx, hash)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("read %d bytes that differ from the %d written", len(got), len(data))
+	}
+
+	blobs, err := store.List(ctx, hash[:4])
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(blobs) != 1 || blobs[0].Hash != hash {
+		t.Errorf("List returned %v, expected %s", blobs, hash)
+	}
+
+	if err := store.Delete(ctx, hash); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if exists, _ := store.Exists(ctx, hash); exists {
+		t.Error("blob still exists after Delete")
+	}
+	if _, err := store.Get(ctx, hash); err == nil {
+		t.Errorf("Get of deleted blob %s succeeded", hash)
+	}
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package storage_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"bib/internal/storage"
+	_ "bib/internal/storage/memory"
+	"bib/internal/storage/postgres"
+	"bib/internal/storage/storagetest"
+	"bib/test/testutil"
+	"bib/test/testutil/containers"
+)
+
+// TestPostgresStore_Conformance runs the storage conformance suite against
+// PostgreSQL. Every subtest gets a database of its own in one container.
+func TestPostgresStore_Conformance(t *testing.T) {
+	t.Parallel()
+	testutil.SkipIfShort(t)
+	ctx := testutil.TestContext(t)
+
+	cm := containers.NewManager(t)
+	pgCfg := containers.DefaultPostgresConfig()
+	pgContainer, err := cm.StartPostgres(ctx, pgCfg)
+	if err != nil {
+		t.Fatalf("failed to start postgres: %v", err)
+	}
+
+	admin := createPostgresStore(t, ctx, pgContainer, pgCfg, testutil.TempDir(t, "postgres"))
+	defer admin.Close()
+
+	var databases atomic.Int32
+	storagetest.Run(t, func(t *testing.T) storage.Store {
+		dbCfg := pgCfg
+		dbCfg.Database = fmt.Sprintf("conformance_%d", databases.Add(1))
+		if _, err := admin.(*postgres.Store).Pool().Exec(ctx, "CREATE DATABASE "+dbCfg.Database); err != nil {
+			t.Fatalf("failed to create database: %v", err)
+		}
+
+		store := createPostgresStore(t, ctx, pgContainer, dbCfg, testutil.TempDir(t, "postgres"))
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
+// TestSQLiteStore_Conformance runs the storage conformance suite against
+// SQLite.
+func TestSQLiteStore_Conformance(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	storagetest.Run(t, func(t *testing.T) storage.Store {
+		dataDir := testutil.TempDir(t, "sqlite")
+		store := createSQLiteStore(t, ctx, dataDir)
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
+// TestMemoryStore_Conformance runs the storage conformance suite against
+// the memory store, opened like the daemon opens it.
+func TestMemoryStore_Conformance(t *testing.T) {
+	t.Parallel()
+	ctx := testutil.TestContext(t)
+
+	cfg := storage.DefaultConfig()
+	cfg.Backend = storage.BackendMemory
+	storagetest.Run(t, func(t *testing.T) storage.Store {
+		store, err := storage.Open(ctx, cfg, t.TempDir(), "test-node", "selective")
+		if err != nil {
+			t.Fatalf("failed to open memory store: %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
@@ -396,3 +396,43 @@ func ParsePort(address string) (int, error) {
 	}
 	return strconv.Atoi(portStr)
 }
+
+// MinIOConfig holds configuration for a MinIO container.
+type MinIOConfig struct {
+	Image     string
+	AccessKey string
+	SecretKey string
+}
+
+// DefaultMinIOConfig returns the default MinIO configuration.
+func DefaultMinIOConfig() MinIOConfig {
+	return MinIOConfig{
+		Image:     GetEnvOrDefault("TEST_MINIO_IMAGE", "minio/minio:latest"),
+		AccessKey: "bib_test",
+		SecretKey: "bib_test_password",
+	}
+}
+
+// StartMinIO starts a MinIO container serving the S3 API on port 9000.
+func (m *Manager) StartMinIO(ctx context.Context, cfg MinIOConfig) (*Container, error) {
+	c, err := m.Start(ctx, ContainerConfig{
+		Image: cfg.Image,
+		Env: map[string]string{
+			"MINIO_ROOT_USER":     cfg.AccessKey,
+			"MINIO_ROOT_PASSWORD": cfg.SecretKey,
+		},
+		Ports:   []int{9000},
+		Cmd:     []string{"server", "/data"},
+		WaitFor: 60 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The image has no curl or wget, so probe the port from the host
+	if err := WaitForPort(c.Address(9000), 60*time.Second); err != nil {
+		m.Stop(c)
+		return nil, err
+	}
+	return c, nil
+}